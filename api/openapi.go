@@ -0,0 +1,13 @@
+// Package api はAPIの契約（OpenAPI仕様書）を提供します
+//
+// openapi.json は手動で管理する仕様書です。ハンドラーやDTOを変更した場合は、この仕様書も合わせて更新してください
+// 契約テスト（internal/infrastructure/web の TestAPIContract）が実装と仕様書のずれを検出します
+// 起動中のサーバーは、この仕様書を GET /api/v1/openapi.json と Swagger UI（/api/v1/docs）で公開します
+package api
+
+import _ "embed"
+
+// OpenAPISpec はOpenAPI 3.1形式のAPI仕様書（JSON）です
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
@@ -1,13 +1,49 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	grpclib "google.golang.org/grpc"
+
+	"todoapp-api-golang/api"
+	"todoapp-api-golang/internal/application/contract"
+	"todoapp-api-golang/internal/application/dto"
 	"todoapp-api-golang/internal/application/handler"
+	"todoapp-api-golang/internal/application/middleware"
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+	"todoapp-api-golang/internal/domain/repository"
 	"todoapp-api-golang/internal/domain/service"
 	"todoapp-api-golang/internal/infrastructure/database"
+	"todoapp-api-golang/internal/infrastructure/devserver"
+	grpcserver "todoapp-api-golang/internal/infrastructure/grpc"
+	"todoapp-api-golang/internal/infrastructure/httpclient"
+	"todoapp-api-golang/internal/infrastructure/markdown"
+	"todoapp-api-golang/internal/infrastructure/memory"
+	"todoapp-api-golang/internal/infrastructure/metrics"
+	"todoapp-api-golang/internal/infrastructure/notifier"
+	"todoapp-api-golang/internal/infrastructure/realtime"
+	"todoapp-api-golang/internal/infrastructure/telemetry"
+	"todoapp-api-golang/internal/infrastructure/todoformat"
 	"todoapp-api-golang/internal/infrastructure/web"
+	"todoapp-api-golang/internal/infrastructure/worker"
 	"todoapp-api-golang/pkg/config"
+	"todoapp-api-golang/pkg/hashids"
+	"todoapp-api-golang/pkg/msgpack"
 )
 
 // main はアプリケーションのエントリーポイント（開始点）です
@@ -18,6 +54,17 @@ import (
 // 4. エラーハンドリングとログ出力
 // 5. アプリケーションライフサイクルの管理
 func main() {
+	// コマンドライン引数の解析（-mock でデータベースなしのモックサーバーとして起動）
+	mock := registerMockFlags(flag.CommandLine)
+	dev := flag.Bool("dev", false, "rebuild and restart on source changes, keeping the in-memory data across restarts (implies -mock)")
+	flag.Parse()
+
+	// 開発サーバーは自身ではリクエストを処理せず、ビルドしたAPIを子プロセスとして起動・再起動する
+	if *dev {
+		runDevServer(flag.CommandLine)
+		return
+	}
+
 	// アプリケーション初期化の開始ログ
 	log.Println("Starting Todo API application with standard packages...")
 
@@ -30,34 +77,119 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// ログレベルは LevelVar で保持し、PUT /admin/loglevel から再起動なしで変更できるようにする
+	// slog.SetDefault の後は既存の log.Printf の出力も INFO レベルとしてこのロガーを経由する
+	logLevel := new(slog.LevelVar)
+	if err := logLevel.UnmarshalText([]byte(cfg.App.LogLevel)); err != nil {
+		log.Fatalf("Failed to parse log level: %v", err)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})))
+
 	// 設定内容のログ出力（本番環境では機密情報を除外すること）
 	log.Printf("Configuration loaded - Environment: %s, Port: %d, DB Driver: %s",
 		cfg.App.Environment, cfg.Server.Port, cfg.Database.Driver)
 
+	// レスポンスのJSON表現（日時の形式・IDの型）はすべてのDTOに共通で適用される
+	dto.Encoding = dto.EncodingOptions{
+		TimeFormat: dto.TimeFormat(cfg.App.ResponseTimeFormat),
+		StringIDs:  cfg.App.ResponseStringIDs,
+	}
+	// 連番のIDを公開しないよう、APIの境界でIDを不透明な文字列に変換する（内部では整数のまま）
+	if cfg.App.IDObfuscationSalt != "" {
+		codec, err := hashids.New(cfg.App.IDObfuscationSalt, cfg.App.IDObfuscationMinLength)
+		if err != nil {
+			log.Fatalf("Failed to configure ID obfuscation: %v", err)
+		}
+		dto.Encoding.IDCodec = codec
+	}
+
+	// モックサーバーはデータベースに接続せず、メモリ上のダミーデータで応答する
+	if mock.enabled {
+		runMockServer(cfg, mock)
+		return
+	}
+	// 外部サービス呼び出し用のHTTPクライアント
+	// 接続プールを共有し、連携先（DynamoDB・Webhook等）ごとに名前付きのクライアントを作成する
+	httpClientCfg := httpclient.DefaultConfig()
+	httpClientCfg.Timeout = time.Duration(cfg.HTTPClient.Timeout) * time.Second
+	httpClientCfg.MaxRetries = cfg.HTTPClient.MaxRetries
+	httpClientCfg.MaxIdleConnsPerHost = cfg.HTTPClient.MaxIdleConnsPerHost
+	httpClientCfg.ProxyURL = cfg.HTTPClient.ProxyURL
+	httpClientCfg.CAFile = cfg.HTTPClient.CAFile
+	httpClients, err := httpclient.NewFactory(httpClientCfg)
+	if err != nil {
+		log.Fatalf("Failed to configure HTTP client: %v", err)
+	}
+
 	// 2. データベース接続の確立
 	// 標準パッケージを使用したデータベースマネージャーの作成と接続
-	dbManager := database.NewDatabaseManager(cfg)
-	if err := dbManager.Connect(); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
+	// メトリクスが有効な場合は、全てのクエリの実行時間を /metrics に記録する
+	// SQLデータベース以外の保存先（DB_DRIVER=memory・embedded・dynamodb）では、Todo本体だけをその保存先に保存し、
+	// SQLデータベースが必要な機能（sqlOnlyFeatures）を無効にして起動する
+	metricsRegistry := metrics.NewRegistry()
+	var dbManager *database.DatabaseManager
+	var dbMetrics *metrics.DBMetrics
+	var shardManager *database.ShardedDatabaseManager
+	var store *todoStore
+	if cfg.IsSQLDatabase() {
+		var dbOpts []database.DatabaseManagerOption
+		if cfg.App.MetricsEnabled {
+			dbMetrics = metrics.NewDBMetrics(metricsRegistry)
+			dbOpts = append(dbOpts, database.WithQueryObserver(dbMetrics.ObserveQuery))
+		}
+		dbManager = database.NewDatabaseManager(cfg, dbOpts...)
+		if err := dbManager.Connect(); err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+
+		// アプリケーション終了時のクリーンアップ処理
+		// defer文により、main関数終了時に自動実行される
+		defer func() {
+			if err := dbManager.Close(); err != nil {
+				log.Printf("Error closing database connection: %v", err)
+			}
+		}()
 
-	// アプリケーション終了時のクリーンアップ処理
-	// defer文により、main関数終了時に自動実行される
-	defer func() {
-		if err := dbManager.Close(); err != nil {
-			log.Printf("Error closing database connection: %v", err)
+		// 3. スキーマのマイグレーション
+		// 開発環境では起動時に未適用のマイグレーションを自動で適用し、本番環境ではデプロイの手順で適用する
+		if !cfg.IsProduction() {
+			if err := dbManager.Migrate(context.Background()); err != nil {
+				log.Fatalf("Failed to migrate database: %v", err)
+			}
+		} else {
+			log.Println("Production mode: skipping automatic migrations")
+			log.Println("Please ensure database schema is properly migrated")
 		}
-	}()
 
-	// 3. データベーステーブルの作成
-	// 開発環境では自動テーブル作成、本番環境では手動マイグレーション推奨
-	if !cfg.IsProduction() {
-		if err := dbManager.CreateTables(); err != nil {
-			log.Fatalf("Failed to create database tables: %v", err)
+		// 3-1. シャードの準備（DB_SHARDS が設定されている場合のみ）
+		// 全シャードへの接続、テーブル作成、ヘルスチェックをまとめて実行
+		if cfg.IsSharded() {
+			shardManager = database.NewShardedDatabaseManager(cfg)
+			if err := shardManager.Connect(); err != nil {
+				log.Fatalf("Failed to connect to database shards: %v", err)
+			}
+			defer func() {
+				if err := shardManager.Close(); err != nil {
+					log.Printf("Error closing database shards: %v", err)
+				}
+			}()
+
+			if !cfg.IsProduction() {
+				if err := shardManager.Migrate(context.Background()); err != nil {
+					log.Fatalf("Failed to migrate database shards: %v", err)
+				}
+			}
+			if err := shardManager.HealthCheck(); err != nil {
+				log.Fatalf("Database shard health check failed: %v", err)
+			}
+			log.Printf("Database sharding enabled: %d shards", shardManager.ShardCount())
 		}
 	} else {
-		log.Println("Production mode: skipping automatic table creation")
-		log.Println("Please ensure database schema is properly migrated")
+		store, err = openTodoStore(cfg, mock.snapshot, httpClients)
+		if err != nil {
+			log.Fatalf("Failed to open todo storage: %v", err)
+		}
+		log.Printf("DB_DRIVER=%s stores only todos; not available without a SQL database: %s", cfg.Database.Driver, strings.Join(sqlOnlyFeatures, ", "))
 	}
 
 	// 4. 依存性注入による各層の構築
@@ -66,41 +198,448 @@ func main() {
 
 	// 4-1. リポジトリ層（データアクセス）の初期化
 	// 標準のdatabase/sqlパッケージを使用したリポジトリ実装
-	todoRepo := database.NewTodoRepository(dbManager.DB)
+	// SQLデータベース以外の保存先では、その保存先のTodoRepositoryを同じように各サービスへ注入する
+	var todoRepo repository.TodoRepository
+	var shardFactory *database.TodoRepositoryFactory
+	if store != nil {
+		todoRepo = store.repo
+	} else {
+		// Upsert は接続先のエンジンの方言で組み立て、SELECT ... FOR UPDATE をサポートしないエンジン（SQLite）では、Todoの行ロックをSQLite向けの方法に切り替える
+		todoRepoOpts := []database.TodoRepositoryOption{database.WithDialect(dbManager.Dialect())}
+		if !dbManager.Quirks().SelectForUpdate {
+			todoRepoOpts = append(todoRepoOpts, database.WithSQLiteLocking())
+		}
+		todoRepo = database.NewTodoRepository(dbManager.DB, todoRepoOpts...)
+		// シャーディングが有効な場合は、リクエストごとに認証されたユーザーのシャードのリポジトリへ振り分ける
+		if shardManager != nil {
+			shardFactory = database.NewTodoRepositoryFactory(shardManager, todoRepoOpts...)
+			todoRepo = database.NewShardedTodoRepository(shardFactory)
+		}
+	}
 
 	// 4-2. ドメインサービス層（ビジネスロジック）の初期化
 	// リポジトリをサービスに注入
-	todoService := service.NewTodoService(todoRepo)
+	// Todoの変更はイベントバスへ発行し、変更履歴・Webhook・指標はその購読者として記録する
+	todoEvents := event.NewBus()
+	todoServiceOpts := []service.TodoServiceOption{
+		service.WithTodoEvents(todoEvents),
+	}
+	if cfg.App.MetricsEnabled {
+		todoServiceOpts = append(todoServiceOpts, service.WithTodoMetrics(metrics.NewTodoKPIs(metricsRegistry)))
+	}
+	if cfg.App.UniqueTodoTitles {
+		todoServiceOpts = append(todoServiceOpts, service.WithUniqueTitles())
+	}
+	// 削除を UNDO_WINDOW 秒以内であれば POST /api/v1/undo で取り消せるようにする
+	var undoService *service.UndoService
+	if cfg.App.UndoWindow > 0 {
+		undoService = service.NewUndoService(time.Duration(cfg.App.UndoWindow) * time.Second)
+		todoServiceOpts = append(todoServiceOpts, service.WithTodoUndo(undoService))
+	}
+
+	// 4-2-1. SQLデータベースに保存する機能（チェックリスト・プロジェクト・リマインダー・変更履歴・期限・繰り返し・Webhook）
+	// SQLデータベース以外の保存先では作成せず、ルートも登録しない（sqlOnlyFeatures）
+	var (
+		checklistRepo      repository.ChecklistRepository
+		shareRepo          repository.TodoShareRepository
+		transactor         repository.Transactor
+		checklistService   *service.ChecklistService
+		projectService     *service.ProjectService
+		reminderService    *service.ReminderService
+		historyService     *service.TodoHistoryService
+		dueDateService     *service.DueDateService
+		recurrenceService  *service.RecurrenceService
+		deliveryService    *service.DeliveryService
+		webhookService     *service.WebhookService
+		outboxRelay        *service.OutboxRelay
+		preferencesService *service.PreferencesService
+	)
+	// シャーディングが有効な場合、Todoはシャードにあり、DB_HOST のデータベースのTodoのIDで参照する機能は使えない（shardedUnavailableFeatures）
+	todosInMainDB := dbManager != nil && shardManager == nil
+	if dbManager != nil {
+		deliveryRepo := database.NewFailedDeliveryRepository(dbManager.DB)
+		webhookRepo := database.NewWebhookRepository(dbManager.DB)
+
+		transactor = database.NewTransactor(dbManager.DB)
+		if shardFactory != nil {
+			transactor = shardFactory.Transactor()
+			log.Printf("Database sharding: not available with DB_SHARDS: %s", strings.Join(shardedUnavailableFeatures, ", "))
+		}
+		// 複製・復元・インポートなど複数の書き込みを1つのトランザクションで行う
+		todoServiceOpts = append(todoServiceOpts, service.WithTodoTransactor(transactor))
+		// 作成・更新・完了・削除を登録されたWebhookへ通知する（失敗した通知は再送キューで再送する）
+		retryPolicy := service.DefaultRetryPolicy()
+		retryPolicy.MaxAttempts = cfg.App.DeliveryMaxAttempts
+		deliveryService = service.NewDeliveryService(deliveryRepo, retryPolicy)
+		webhookService = service.NewWebhookService(webhookRepo, notifier.NewHTTPWebhookSender(httpClients.Client("webhooks")),
+			service.WithWebhookDeliveryQueue(deliveryService),
+			service.WithWebhookDebounce(time.Duration(cfg.App.WebhookDebounceWindow)*time.Second),
+		)
+		deliveryService.RegisterHandler(entity.DeliveryKindWebhook, webhookService.Redeliver)
+		// OUTBOX_RELAY_INTERVAL を設定した場合は、イベントを変更と同じトランザクションでアウトボックスに保存し、
+		// リレーのワーカーがWebhookへ通知する（プロセスが停止しても通知を取りこぼさない）
+		if cfg.App.OutboxRelayInterval > 0 {
+			outboxRepo := database.NewOutboxRepository(dbManager.DB)
+			todoServiceOpts = append(todoServiceOpts, service.WithTodoOutbox(transactor, outboxRepo))
+			outboxEvents := event.NewBus()
+			webhookService.SubscribeOutbox(outboxEvents)
+			outboxRelay = service.NewOutboxRelay(outboxRepo, outboxEvents)
+		} else {
+			webhookService.Subscribe(todoEvents)
+		}
+		// ユーザーごとの設定（タイムゾーン・並び順・リマインダーの通知）は認証が有効な場合のみ保存できる
+		var preferencesRepo repository.PreferencesRepository
+		if cfg.IsAuthEnabled() {
+			preferencesRepo = database.NewPreferencesRepository(dbManager.DB, dbManager.Dialect())
+			preferencesService = service.NewPreferencesService(preferencesRepo)
+		}
+
+		if todosInMainDB {
+			checklistRepo = database.NewChecklistRepository(dbManager.DB)
+			projectRepo := database.NewProjectRepository(dbManager.DB)
+			reminderRepo := database.NewReminderRepository(dbManager.DB)
+			recurrenceRepo := database.NewRecurrenceRepository(dbManager.DB)
+			historyRepo := database.NewTodoHistoryRepository(dbManager.DB)
+			dueDateRepo := database.NewDueDateRepository(dbManager.DB)
+			shareRepo = database.NewTodoShareRepository(dbManager.DB, dbManager.Dialect())
+
+			var reminderNotifier service.ReminderNotifier = notifier.NewLogNotifier(nil)
+			if cfg.App.ReminderWebhookURL != "" {
+				reminderNotifier = notifier.NewWebhookNotifier(httpClients.Client("reminder_webhook"), cfg.App.ReminderWebhookURL)
+			}
+
+			service.SubscribeTodoHistory(todoEvents, historyRepo)
+			todoServiceOpts = append(todoServiceOpts,
+				service.WithTodoChecklist(checklistRepo), // 複製でチェックリストもコピーできるようにする
+				service.WithTodoProjects(projectRepo),    // アーカイブ済みのプロジェクトへのTodoの追加を拒否する
+			)
+			// 認証が有効な場合は、共有されたTodoを共有の権限の範囲で他のユーザーにも操作させる
+			reminderServiceOpts := []service.ReminderServiceOption{service.WithDeliveryQueue(deliveryService)}
+			if cfg.IsAuthEnabled() {
+				todoServiceOpts = append(todoServiceOpts, service.WithTodoShares(shareRepo))
+				reminderServiceOpts = append(reminderServiceOpts, service.WithReminderPreferences(preferencesRepo))
+			}
+			checklistService = service.NewChecklistService(checklistRepo, todoRepo)
+			projectService = service.NewProjectService(projectRepo)
+			reminderService = service.NewReminderService(reminderRepo, todoRepo, reminderNotifier, reminderServiceOpts...)
+			deliveryService.RegisterHandler(entity.DeliveryKindReminder, reminderService.Redeliver)
+			historyService = service.NewTodoHistoryService(historyRepo, todoRepo)
+			dueDateService = service.NewDueDateService(dueDateRepo)
+			recurrenceService = service.NewRecurrenceService(recurrenceRepo, time.Duration(cfg.App.RecurrenceHorizonDays)*24*time.Hour)
+		}
+	}
+	todoService := service.NewTodoService(todoRepo, todoServiceOpts...)
 
 	// 4-3. ハンドラー層（HTTP処理）の初期化
 	// サービスをハンドラーに注入
-	todoHandler := handler.NewTodoHandler(todoService)
+	// 説明のMarkdownは ?render=html でサニタイズ済みのHTMLに変換して返す
+	// 業務ロジックのパニックは InternalError に変換し、通常のエラーと同じく 500 のJSONで返す
+	// REQUIRE_IF_MATCH の場合は更新・削除に If-Match を必須にし、同時編集による上書きを防ぐ
+	todoHandlerOpts := []handler.TodoHandlerOption{handler.WithMarkdownRenderer(markdown.NewRenderer())}
+	if cfg.App.RequireIfMatch {
+		todoHandlerOpts = append(todoHandlerOpts, handler.WithIfMatchRequired())
+	}
+	// 保存した設定がある場合は、Todo一覧の並び順と期限の一覧のタイムゾーンに使う
+	var dueDateHandlerOpts []handler.DueDateHandlerOption
+	if preferencesService != nil {
+		todoHandlerOpts = append(todoHandlerOpts, handler.WithTodoPreferences(preferencesService))
+		dueDateHandlerOpts = append(dueDateHandlerOpts, handler.WithDueDatePreferences(preferencesService))
+	}
+	todoHandler := handler.NewTodoHandler(service.WithPanicRecovery(todoService), todoHandlerOpts...)
+	schemaHandler := handler.NewSchemaHandler()
+	tagHandler := handler.NewTagHandler(todoService)
+
+	// 組み込みUIの静的ファイル（起動時にハッシュ計算と圧縮を済ませる）
+	staticHandler, err := web.NewStaticHandler(cfg.Server.BasePath)
+	if err != nil {
+		log.Fatalf("Failed to load static assets: %v", err)
+	}
 
 	// 4-4. ルーティング層の初期化
 	// 標準パッケージを使用したルーター作成
-	router := web.NewRouter(todoHandler)
+	// 任意のハンドラーはオプションとして渡す
+	routerOpts := []web.RouterOption{
+		web.WithSchemaHandler(schemaHandler),
+		web.WithTagHandler(tagHandler),
+		web.WithOpenAPIHandler(openAPIHandler(cfg)),
+		web.WithStaticHandler(staticHandler),
+		web.WithBasePath(cfg.Server.BasePath),
+		// 大きすぎる・遅すぎるリクエストボディを 413 / 408 で打ち切る（通信の記録より先に適用する）
+		web.WithMiddleware(middleware.RequestBodyMiddleware(middleware.RequestBodyLimits{
+			MaxBytes:    int64(cfg.Server.MaxBodyBytes),
+			ReadTimeout: time.Duration(cfg.Server.BodyReadTimeout) * time.Second,
+		})),
+		// Content-Type / Accept で application/msgpack が指定された場合に、ボディをJSONと相互に変換する
+		// （レート制限等のエラーのレスポンスも変換されるよう、ボディの上限の直後に適用する）
+		web.WithMiddleware(middleware.CodecMiddleware(msgpack.Codec{})),
+	}
+	if todosInMainDB {
+		routerOpts = append(routerOpts,
+			web.WithChecklistHandler(handler.NewChecklistHandler(checklistService)),
+			web.WithProjectHandler(handler.NewProjectHandler(projectService)),
+			web.WithReminderHandler(handler.NewReminderHandler(reminderService)),
+			web.WithHistoryHandler(handler.NewTodoHistoryHandler(historyService)),
+			web.WithDueDateHandler(handler.NewDueDateHandler(dueDateService, dueDateHandlerOpts...)),
+		)
+	}
+	if dbManager != nil {
+		routerOpts = append(routerOpts, web.WithWebhookHandler(handler.NewWebhookHandler(webhookService)))
+		// デッドレターは全てのユーザーの通知を扱うため、管理用トークンが設定されている場合のみ公開する
+		if cfg.App.AdminToken != "" {
+			routerOpts = append(routerOpts, web.WithDeadLetterHandler(handler.NewDeadLetterHandler(deliveryService), cfg.App.AdminToken))
+		}
+
+		// /health でDB接続とフェイルオーバーの状態を返す（接続できない場合は 503）
+		// プローブのたびにDBへ問い合わせないよう、チェック結果を短い間キャッシュする
+		databaseHealthCheck := web.HealthCheck(dbManager.HealthStatus)
+		if cfg.App.HealthCheckCacheTTL > 0 {
+			databaseHealthCheck = web.CachedHealthCheck(databaseHealthCheck, web.HealthCacheConfig{
+				TTL:    time.Duration(cfg.App.HealthCheckCacheTTL) * time.Second,
+				Jitter: time.Duration(cfg.App.HealthCheckCacheJitter) * time.Second,
+			})
+		}
+		routerOpts = append(routerOpts, web.WithHealthCheck("database", databaseHealthCheck))
+	}
+	// シャーディングが有効な場合は、起動時だけでなく /health でも全シャードの接続状態を返す
+	if shardManager != nil {
+		shardsHealthCheck := web.HealthCheck(shardManager.HealthStatus)
+		if cfg.App.HealthCheckCacheTTL > 0 {
+			shardsHealthCheck = web.CachedHealthCheck(shardsHealthCheck, web.HealthCacheConfig{
+				TTL:    time.Duration(cfg.App.HealthCheckCacheTTL) * time.Second,
+				Jitter: time.Duration(cfg.App.HealthCheckCacheJitter) * time.Second,
+			})
+		}
+		routerOpts = append(routerOpts, web.WithHealthCheck("shards", shardsHealthCheck))
+	}
+	if cfg.App.MetricsEnabled {
+		routerOpts = append(routerOpts, web.WithMetricsHandler(metricsRegistry))
+	}
+	// クライアントごとのレート制限（warn では拒否せず、ヘッダーとログで上限の調整に必要な情報だけを出す）
+	// 認証されたリクエストはユーザーごとに数え、ユーザーのプランの上限を適用する
+	if cfg.Server.RateLimitMode != string(middleware.RateLimitOff) {
+		log.Printf("Rate limiting in %s mode: %d requests/minute per client (burst %d), %d plans", cfg.Server.RateLimitMode, cfg.Server.RateLimitRequestsPerMinute, cfg.Server.RateLimitBurst, len(cfg.Server.RateLimitPlans))
+		plans := make(map[string]middleware.RateLimitPlan, len(cfg.Server.RateLimitPlans))
+		for name, plan := range cfg.Server.RateLimitPlans {
+			plans[name] = middleware.RateLimitPlan{RequestsPerMinute: plan.RequestsPerMinute, Burst: plan.Burst}
+		}
+		routerOpts = append(routerOpts, web.WithMiddleware(middleware.RateLimitMiddleware(middleware.RateLimitConfig{
+			Mode:              middleware.RateLimitMode(cfg.Server.RateLimitMode),
+			RequestsPerMinute: cfg.Server.RateLimitRequestsPerMinute,
+			Burst:             cfg.Server.RateLimitBurst,
+			Plans:             plans,
+			UserPlans:         cfg.Server.RateLimitUserPlans,
+			DefaultPlan:       cfg.Server.RateLimitDefaultPlan,
+			WarnPeriod:        time.Duration(cfg.Server.RateLimitWarnPeriod) * time.Second,
+		})))
+	}
+	// Idempotency-Key を付けた作成・一括更新の再送では、処理を繰り返さずに保存したレスポンスを返す
+	// （レート制限の内側に置き、制限で拒否したリクエストのキーは記録しない）
+	var idempotencyService *service.IdempotencyService
+	if cfg.App.IdempotencyKeyTTL > 0 {
+		// SQLデータベース以外の保存先では、保存したレスポンスをメモリ上に保持する
+		idempotencyRepo := memory.NewIdempotencyRepository()
+		if dbManager != nil {
+			idempotencyRepo = database.NewIdempotencyRepository(dbManager.DB)
+		}
+		idempotencyService = service.NewIdempotencyService(idempotencyRepo, time.Duration(cfg.App.IdempotencyKeyTTL)*time.Second)
+		routerOpts = append(routerOpts, web.WithMiddleware(middleware.IdempotencyMiddleware(idempotencyService)))
+	}
+	// 運用向けの資格情報が設定されている場合のみ、/metrics を Basic 認証で保護し /debug/pprof/ を公開する
+	if cfg.IsOpsAuthEnabled() {
+		log.Printf("Ops endpoints (/metrics, /debug/pprof/) protected by basic auth")
+		routerOpts = append(routerOpts, web.WithOpsAuth(middleware.BasicAuthMiddleware(cfg.App.OpsUsername, cfg.App.OpsPassword, "ops")))
+	}
+	// 管理用トークンが設定されている場合のみ、ログレベルと接続プールの設定の変更エンドポイントを公開する
+	if cfg.App.AdminToken != "" {
+		routerOpts = append(routerOpts, web.WithLogLevelHandler(handler.NewLogLevelHandler(logLevel), cfg.App.AdminToken))
+		if dbManager != nil {
+			routerOpts = append(routerOpts, web.WithDBPoolHandler(handler.NewDBPoolHandler(dbManager.DB, handler.DBPoolSettings{
+				MaxOpenConns:           cfg.Database.MaxOpenConns,
+				MaxIdleConns:           cfg.Database.MaxIdleConns,
+				ConnMaxLifetimeSeconds: cfg.Database.ConnMaxLifetime * 60,
+			}), cfg.App.AdminToken))
+		}
+	}
+	// 署名鍵が設定されている場合のみ、ユーザー認証を有効にする（/api/v1/ 配下と /graphql にアクセストークンが必要になる）
+	// ユーザーはSQLデータベースに保存するため、SQLデータベース以外の保存先では設定の読み込み時にエラーになる
+	var authService *service.AuthService
+	var sessionService *service.SessionService
+	if cfg.IsAuthEnabled() {
+		authService = service.NewAuthService(
+			database.NewUserRepository(dbManager.DB),
+			database.NewRefreshTokenRepository(dbManager.DB),
+			[]byte(cfg.Auth.TokenSecret),
+			time.Duration(cfg.Auth.AccessTokenTTL)*time.Second,
+			time.Duration(cfg.Auth.RefreshTokenTTL)*time.Second,
+		)
+		authMiddleware := middleware.AuthMiddleware(authService)
+		// アクセストークンの代わりに、Cookieのセッションでもログインできるようにする（ブラウザ向け）
+		if cfg.IsSessionEnabled() {
+			sessionStore := memory.NewSessionRepository()
+			if cfg.Auth.SessionStore == "database" {
+				sessionStore = database.NewSessionRepository(dbManager.DB)
+			}
+			sessionService = service.NewSessionService(sessionStore, database.NewUserRepository(dbManager.DB), time.Duration(cfg.Auth.SessionTTL)*time.Second)
+			authMiddleware = middleware.SessionAuthMiddleware(authService, sessionService)
+			routerOpts = append(routerOpts, web.WithSessions(handler.NewSessionHandler(sessionService, cfg.Server.BasePath+"/", cfg.Auth.SessionCookieSecure)))
+			// セッションのCookieで認証するリクエストは、状態を変更する場合に CSRF トークンを必須にする
+			routerOpts = append(routerOpts, web.WithMiddleware(middleware.CSRFMiddleware(cfg.Server.BasePath+"/", cfg.Auth.SessionCookieSecure)))
+		}
+		routerOpts = append(routerOpts, web.WithAuth(handler.NewAuthHandler(authService), authMiddleware))
+		routerOpts = append(routerOpts, web.WithPreferencesHandler(handler.NewPreferencesHandler(preferencesService)))
+		// Todoの共有とワークスペースはユーザーを区別できる場合のみ有効にする
+		// シャーディングでは共有したTodoとワークスペースのTodoがメンバーのシャードに分かれるため有効にしない
+		if todosInMainDB {
+			shareService := service.NewTodoShareService(todoRepo, shareRepo, database.NewUserRepository(dbManager.DB))
+			routerOpts = append(routerOpts, web.WithTodoShareHandler(handler.NewTodoShareHandler(shareService)))
+			workspaceService := service.NewWorkspaceService(database.NewWorkspaceRepository(dbManager.DB), database.NewUserRepository(dbManager.DB),
+				time.Duration(cfg.Auth.InvitationTTL)*time.Second)
+			routerOpts = append(routerOpts, web.WithWorkspaces(handler.NewWorkspaceHandler(workspaceService), middleware.WorkspaceMiddleware(workspaceService)))
+		}
+		log.Printf("User authentication enabled: access tokens expire in %ds", cfg.Auth.AccessTokenTTL)
+	}
+	if undoService != nil {
+		routerOpts = append(routerOpts, web.WithUndoHandler(handler.NewUndoHandler(undoService)))
+	}
+	// インポート・エクスポートの形式は ?format= で選ぶ（形式を追加する場合はここに登録する）
+	routerOpts = append(routerOpts, web.WithTodoTransferHandler(handler.NewTodoTransferHandler(service.WithPanicRecovery(todoService), todoFormats())))
+	// 必要なフィールドだけを取得できるGraphQL API（RESTと同じサービスを使う）
+	routerOpts = append(routerOpts, web.WithGraphQLHandler(handler.NewGraphQLHandler(service.WithPanicRecovery(todoService))))
+	// Todoの変更を /ws に接続したブラウザへ JSON Patch で配信する（複数の画面での同時編集向け）
+	realtimeHub := realtime.NewHub(realtime.WithAllowedOrigins(cfg.Server.WebSocketOrigins))
+	realtimeHub.Subscribe(todoEvents)
+	routerOpts = append(routerOpts, web.WithWebSocketHandler(realtimeHub))
+	// 不具合の再現用に、APIの通信をファイルに記録する（cmd/replay で再送信できる）
+	if dir := cfg.App.RecordTrafficDir; dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			log.Fatalf("Failed to create traffic record directory: %v", err)
+		}
+		log.Printf("Recording API traffic to %s", dir)
+		routerOpts = append(routerOpts, web.WithMiddleware(middleware.TrafficRecorderMiddleware(dir)))
+	}
+	// 開発中に実装と仕様書（api/openapi.json）のずれに気付けるよう、レスポンスを検証してログに出力する
+	if cfg.App.ContractValidation {
+		validator, err := contract.NewValidator(api.OpenAPISpec)
+		if err != nil {
+			log.Fatalf("Failed to load OpenAPI spec: %v", err)
+		}
+		routerOpts = append(routerOpts, web.WithMiddleware(middleware.ContractValidationMiddleware(validator, func(r *http.Request, err error) {
+			log.Printf("Contract violation: %s %s: %v", r.Method, r.URL.Path, err)
+		})))
+	}
+	router := web.NewRouter(todoHandler, routerOpts...)
 
 	// 4-5. HTTPサーバー層の初期化
 	server := web.NewServer(cfg, router)
 
 	// 5. データベース接続の健全性チェック
 	// アプリケーション起動前の最終確認
-	if err := dbManager.HealthCheck(); err != nil {
-		log.Fatalf("Database health check failed: %v", err)
+	if dbManager != nil {
+		if err := dbManager.HealthCheck(); err != nil {
+			log.Fatalf("Database health check failed: %v", err)
+		}
+	}
+	// 6-1. gRPCサーバーの起動（HTTPと同じサービスを別のポートで公開する）
+	// シグナル受信時はHTTPサーバーの停止後、ワーカーより先に処理中のRPCの完了を待って停止する
+	if cfg.Server.GRPCPort > 0 {
+		// ユーザー認証が有効な場合は REST API と同じアクセストークンで認証し、本人のTodoだけを操作できるようにします
+		var grpcOpts []grpclib.ServerOption
+		if authService != nil {
+			grpcOpts = append(grpcOpts, grpcserver.WithAuth(authService))
+		}
+		grpcServer := grpcserver.NewServer(service.WithPanicRecovery(todoService), grpcOpts...)
+		grpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.GRPCPort)
+		go func() {
+			log.Printf("gRPC server will start on: %s", grpcAddr)
+			if err := grpcServer.ListenAndServe(grpcAddr); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+		server.OnShutdown(func(ctx context.Context) {
+			if err := grpcServer.Shutdown(ctx); err != nil {
+				log.Printf("gRPC server did not stop gracefully: %v", err)
+			}
+		})
 	}
 
-	// 6. 接続プール統計情報の出力（デバッグ用）
-	if !cfg.IsProduction() {
-		if stats, err := dbManager.GetStats(); err == nil {
-			log.Printf("Database connection pool stats: %+v", stats)
+	// 6-2. バックグラウンドワーカーの起動
+	// シグナル受信時はサーバー停止後に、実行中の処理の完了を待ってからワーカーを停止する
+	workers := worker.NewGroup()
+	server.OnShutdown(func(ctx context.Context) {
+		if err := workers.Stop(ctx); err != nil {
+			log.Printf("Background workers did not stop in time: %v", err)
+		}
+	})
+	server.OnShutdown(func(ctx context.Context) {
+		if err := realtimeHub.Shutdown(ctx); err != nil {
+			log.Printf("WebSocket connections were not closed cleanly: %v", err)
 		}
+	})
+	if webhookService != nil {
+		server.OnShutdown(func(ctx context.Context) {
+			if err := webhookService.Wait(ctx); err != nil {
+				log.Printf("Pending webhooks were not sent before shutdown: %v", err)
+			}
+		})
+	}
+	server.OnShutdown(func(ctx context.Context) {
+		for name, stats := range httpClients.Stats() {
+			log.Printf("Outbound HTTP %s: requests=%d failures=%d retries=%d avg=%s",
+				name, stats.Requests, stats.Failures, stats.Retries, stats.AverageDuration())
+		}
+	})
+	// Todoの保存先は、保存先を使うgRPC・ワーカー・WebSocket・Webhookの停止を待ってから最後に閉じる（フックは登録順に実行される）
+	if store != nil && store.close != nil {
+		server.OnShutdown(func(ctx context.Context) {
+			if err := store.close(ctx); err != nil {
+				log.Printf("Failed to close todo storage: %v", err)
+			}
+		})
+	}
+
+	if reminderService != nil && cfg.App.ReminderScanInterval > 0 {
+		workers.Start(worker.NewReminderWorker(reminderService, time.Duration(cfg.App.ReminderScanInterval)*time.Second))
+	}
+	if deliveryService != nil && cfg.App.DeliveryRetryInterval > 0 {
+		workers.Start(worker.NewDeliveryWorker(deliveryService, time.Duration(cfg.App.DeliveryRetryInterval)*time.Second))
+	}
+	if outboxRelay != nil {
+		workers.Start(worker.NewOutboxWorker(outboxRelay, time.Duration(cfg.App.OutboxRelayInterval)*time.Second))
+	}
+	if idempotencyService != nil {
+		workers.Start(worker.NewIdempotencyWorker(idempotencyService, idempotencyPurgeInterval))
+	}
+	if authService != nil {
+		workers.Start(worker.NewRefreshTokenWorker(authService, refreshTokenPurgeInterval))
+	}
+	if sessionService != nil {
+		workers.Start(worker.NewSessionWorker(sessionService, sessionPurgeInterval))
+	}
+	if recurrenceService != nil && cfg.App.RecurrenceScanInterval > 0 {
+		workers.Start(worker.NewRecurrenceWorker(recurrenceService, time.Duration(cfg.App.RecurrenceScanInterval)*time.Second))
+	}
+
+	// 接続プールの統計は起動時の1回ではなく、一定間隔で /metrics に記録する（プール設定の調整用）
+	if dbMetrics != nil && cfg.Database.StatsInterval > 0 {
+		workers.Start(worker.NewDBStatsWorker(dbManager.DB, dbMetrics, time.Duration(cfg.Database.StatsInterval)*time.Second))
+	}
+
+	// 匿名の利用状況レポートは明示的に有効にした場合のみ送信する（オプトイン）
+	if cfg.Telemetry.Enabled {
+		reporter := telemetry.NewReporter(httpClients.Client("telemetry"), cfg.Telemetry.Endpoint, telemetry.Environment{
+			AppVersion: cfg.App.Version,
+			DBDriver:   cfg.Database.Driver,
+			Sharded:    cfg.IsSharded(),
+		}, todoRepo)
+		log.Printf("Anonymous usage telemetry enabled: reporting to %s (set TELEMETRY_ENABLED=false to opt out)", cfg.Telemetry.Endpoint)
+		workers.Start(worker.NewTelemetryWorker(reporter, time.Duration(cfg.Telemetry.Interval)*time.Second))
 	}
 
 	// 7. アプリケーション起動の完了ログ
 	log.Printf("Todo API is ready to serve requests")
 	log.Printf("Server will start on: http://%s:%d", cfg.Server.Host, cfg.Server.Port)
-	log.Printf("Health check endpoint: http://%s:%d/health", cfg.Server.Host, cfg.Server.Port)
-	log.Printf("API base URL: http://%s:%d/api/v1", cfg.Server.Host, cfg.Server.Port)
+	log.Printf("Health check endpoint: http://%s:%d%s/health", cfg.Server.Host, cfg.Server.Port, cfg.Server.BasePath)
+	log.Printf("API base URL: http://%s:%d%s/api/v1", cfg.Server.Host, cfg.Server.Port, cfg.Server.BasePath)
 
 	// 8. HTTPサーバーの起動
 	// Start()は内部でグレースフルシャットダウンを処理
@@ -110,6 +649,211 @@ func main() {
 	}
 }
 
+// mockOptions はモックサーバーモードのコマンドライン引数です
+type mockOptions struct {
+	enabled   bool
+	todos     int
+	seed      int64
+	latency   time.Duration
+	jitter    time.Duration
+	errorRate float64
+	snapshot  string
+}
+
+// 開発サーバー（-dev）のビルドの出力先と、再起動をまたいでデータを引き継ぐスナップショットのファイルです
+var (
+	devBinary       = filepath.Join("tmp", "dev", "todoapp")
+	devSnapshotFile = filepath.Join("tmp", "dev", "snapshot.json")
+)
+
+// idempotencyPurgeInterval は有効期限を過ぎた Idempotency-Key の記録を削除する間隔です
+const idempotencyPurgeInterval = 10 * time.Minute
+
+// refreshTokenPurgeInterval は有効期限を過ぎたリフレッシュトークンの記録を削除する間隔です
+const refreshTokenPurgeInterval = time.Hour
+
+// sessionPurgeInterval は有効期限を過ぎたCookieのセッションを削除する間隔です
+const sessionPurgeInterval = time.Hour
+
+// todoFormats はTodoのインポート・エクスポートに使える形式を登録したレジストリを作成します
+func todoFormats() *transfer.Registry {
+	return transfer.NewRegistry(
+		todoformat.JSON{},
+		todoformat.OPML{},
+		todoformat.Org{},
+		todoformat.Taskwarrior{},
+	)
+}
+
+// openAPIHandler は api/openapi.json を公開するハンドラーを作成します
+// ベースパスの配下で公開する場合は、Swagger UI から正しいURLへリクエストできるよう servers に設定する
+func openAPIHandler(cfg *config.Config) *handler.OpenAPIHandler {
+	var opts []handler.OpenAPIHandlerOption
+	if cfg.Server.BasePath != "" {
+		opts = append(opts, handler.WithOpenAPIServerURL(cfg.Server.BasePath))
+	}
+	h, err := handler.NewOpenAPIHandler(api.OpenAPISpec, opts...)
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	return h
+}
+
+// registerMockFlags はモックサーバーモードの引数を登録します
+//
+// 使用例:
+//
+//	go run cmd/api/main.go -mock -mock-latency=300ms -mock-jitter=200ms -mock-error-rate=0.05
+func registerMockFlags(fs *flag.FlagSet) *mockOptions {
+	opts := &mockOptions{}
+	fs.BoolVar(&opts.enabled, "mock", false, "serve fake data from memory without a database (for frontend development)")
+	fs.IntVar(&opts.todos, "mock-todos", 25, "number of fake todos to generate in mock mode")
+	fs.Int64Var(&opts.seed, "mock-seed", 1, "random seed for the fake data (the same seed produces the same data)")
+	fs.DurationVar(&opts.latency, "mock-latency", 0, "latency added to every API request in mock mode (e.g. 200ms)")
+	fs.DurationVar(&opts.jitter, "mock-jitter", 0, "maximum random latency added on top of -mock-latency")
+	fs.Float64Var(&opts.errorRate, "mock-error-rate", 0, "fraction of API requests answered with 503 in mock mode (0.0-1.0)")
+	fs.StringVar(&opts.snapshot, "mock-snapshot", "", "file to restore the in-memory data from at startup and save it to on shutdown")
+	return opts
+}
+
+// runDevServer はソースコードの変更のたびにAPIを再ビルド・再起動する開発サーバーを起動します
+//
+// APIはモックサーバー（メモリ上のリポジトリ）として起動し、終了時にデータをスナップショットのファイルに保存して、
+// 次の起動時に復元します。再起動の前後で作成したTodoが消えないため、画面を操作しながらコードを修正できます
+//
+// 使用例:
+//
+//	go run ./cmd/api -dev -mock-latency=200ms
+func runDevServer(fs *flag.FlagSet) {
+	// -dev 以外の引数はそのまま子プロセスに渡す（-mock=false を指定するとデータベースに接続する）
+	var args []string
+	specified := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		specified[f.Name] = true
+		if f.Name != "dev" {
+			args = append(args, "-"+f.Name+"="+f.Value.String())
+		}
+	})
+	if !specified["mock"] {
+		args = append(args, "-mock")
+	}
+	if !specified["mock-snapshot"] {
+		args = append(args, "-mock-snapshot="+devSnapshotFile)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Dev mode: watching cmd, internal, pkg and api for changes (data is kept in %s)", devSnapshotFile)
+	err := devserver.Run(ctx, devserver.Config{
+		Dirs:       []string{"cmd", "internal", "pkg", "api"},
+		Extensions: []string{".go", ".html", ".css", ".js", ".json", ".sql"},
+		Package:    "./cmd/api",
+		Binary:     devBinary,
+		Args:       args,
+	})
+	if err != nil {
+		log.Fatalf("Dev server failed: %v", err)
+	}
+}
+
+// runMockServer はデータベースの代わりにメモリ上のリポジトリで応答するサーバーを起動します
+// フロントエンドのチームが、データベースを用意する前からAPIに対して開発できるようにするためのモードです
+// Todo本体の操作・スキーマ・組み込みUIのみを提供し、データはプロセスの終了とともに失われます
+func runMockServer(cfg *config.Config, opts *mockOptions) {
+	if opts.todos < 0 || opts.latency < 0 || opts.jitter < 0 || opts.errorRate < 0 || opts.errorRate > 1 {
+		log.Fatalf("Invalid mock options: -mock-todos, -mock-latency and -mock-jitter must not be negative, -mock-error-rate must be between 0 and 1")
+	}
+
+	// スナップショットがある場合は前回のデータを復元し、ない場合はダミーデータを作成する
+	todoRepo := memory.NewTodoRepository()
+	restored := false
+	if opts.snapshot != "" {
+		n, err := memory.LoadSnapshot(context.Background(), todoRepo, opts.snapshot)
+		switch {
+		case err == nil:
+			log.Printf("Mock mode: restored %d todos from %s", n, opts.snapshot)
+			restored = true
+		case !errors.Is(err, os.ErrNotExist):
+			log.Fatalf("Failed to restore mock data: %v", err)
+		}
+	}
+	if !restored {
+		rng := rand.New(rand.NewSource(opts.seed))
+		if err := memory.SeedTodos(context.Background(), todoRepo, opts.todos, rng, time.Now()); err != nil {
+			log.Fatalf("Failed to generate mock data: %v", err)
+		}
+	}
+
+	var todoServiceOpts []service.TodoServiceOption
+	if cfg.App.UniqueTodoTitles {
+		todoServiceOpts = append(todoServiceOpts, service.WithUniqueTitles())
+	}
+	var undoService *service.UndoService
+	if cfg.App.UndoWindow > 0 {
+		undoService = service.NewUndoService(time.Duration(cfg.App.UndoWindow) * time.Second)
+		todoServiceOpts = append(todoServiceOpts, service.WithTodoUndo(undoService))
+	}
+	baseTodoService := service.NewTodoService(todoRepo, todoServiceOpts...)
+	todoService := service.WithPanicRecovery(baseTodoService)
+	todoHandlerOpts := []handler.TodoHandlerOption{handler.WithMarkdownRenderer(markdown.NewRenderer())}
+	if cfg.App.RequireIfMatch {
+		todoHandlerOpts = append(todoHandlerOpts, handler.WithIfMatchRequired())
+	}
+	todoHandler := handler.NewTodoHandler(todoService, todoHandlerOpts...)
+	staticHandler, err := web.NewStaticHandler(cfg.Server.BasePath)
+	if err != nil {
+		log.Fatalf("Failed to load static assets: %v", err)
+	}
+
+	routerOpts := []web.RouterOption{
+		web.WithSchemaHandler(handler.NewSchemaHandler()),
+		web.WithOpenAPIHandler(openAPIHandler(cfg)),
+		web.WithStaticHandler(staticHandler),
+		web.WithBasePath(cfg.Server.BasePath),
+		web.WithMiddleware(middleware.CodecMiddleware(msgpack.Codec{})),
+	}
+	// 擬似的な障害（5xx）は記録されないため、Idempotency-Key を付けた再送の動作をフロントエンドから確認できる
+	if cfg.App.IdempotencyKeyTTL > 0 {
+		idempotencyService := service.NewIdempotencyService(memory.NewIdempotencyRepository(), time.Duration(cfg.App.IdempotencyKeyTTL)*time.Second)
+		routerOpts = append(routerOpts, web.WithMiddleware(middleware.IdempotencyMiddleware(idempotencyService)))
+	}
+	routerOpts = append(routerOpts,
+		web.WithMiddleware(middleware.FaultInjectionMiddleware(middleware.FaultInjectionConfig{
+			Latency:   opts.latency,
+			Jitter:    opts.jitter,
+			ErrorRate: opts.errorRate,
+		})),
+	)
+	if undoService != nil {
+		routerOpts = append(routerOpts, web.WithUndoHandler(handler.NewUndoHandler(undoService)))
+	}
+	routerOpts = append(routerOpts, web.WithTodoTransferHandler(handler.NewTodoTransferHandler(todoService, todoFormats())))
+	routerOpts = append(routerOpts, web.WithGraphQLHandler(handler.NewGraphQLHandler(todoService)))
+	routerOpts = append(routerOpts, web.WithTagHandler(handler.NewTagHandler(baseTodoService)))
+	router := web.NewRouter(todoHandler, routerOpts...)
+	server := web.NewServer(cfg, router)
+	if opts.snapshot != "" {
+		server.OnShutdown(func(ctx context.Context) {
+			if err := memory.SaveSnapshot(ctx, todoRepo, opts.snapshot); err != nil {
+				log.Printf("Failed to save mock data: %v", err)
+				return
+			}
+			log.Printf("Mock mode: saved todos to %s", opts.snapshot)
+		})
+	}
+
+	if !restored {
+		log.Printf("Mock mode: serving %d fake todos from memory (seed %d)", opts.todos, opts.seed)
+	}
+	log.Printf("Mock mode: latency %s + up to %s, error rate %.0f%%", opts.latency, opts.jitter, opts.errorRate*100)
+	log.Printf("API base URL: http://%s:%d%s/api/v1", cfg.Server.Host, cfg.Server.Port, cfg.Server.BasePath)
+
+	if err := server.Start(); err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
+
 // 標準パッケージを使用したアプリケーション構築の学習ポイント：
 //
 // 1. 手動依存性注入：
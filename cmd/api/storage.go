@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/dynamodb"
+	"todoapp-api-golang/internal/infrastructure/httpclient"
+	"todoapp-api-golang/internal/infrastructure/memory"
+	"todoapp-api-golang/pkg/config"
+)
+
+// sqlOnlyFeatures はSQLデータベースに保存するため、SQLデータベース以外の保存先（DB_DRIVER=memory・embedded・dynamodb）では使用できない機能です
+// ユーザー認証（共有・ワークスペース・設定・セッションを含む）とアウトボックスは、設定で有効にした場合に起動時のエラーになります
+var sqlOnlyFeatures = []string{
+	"checklists",
+	"projects",
+	"reminders",
+	"history",
+	"due dates",
+	"recurrence",
+	"webhooks and dead letters",
+	"user authentication",
+	"outbox",
+	"database pool admin",
+}
+
+// shardedUnavailableFeatures はシャーディング（DB_SHARDS）では使用できない機能です
+// Todoはユーザーのシャードにあり、IDはシャードごとに採番されるため、DB_HOST のデータベースにTodoのIDで保存するデータ
+// （チェックリスト・変更履歴・共有など）とTodoを対応付けられず、ワークスペースのTodoはメンバーのシャードに分かれてしまいます
+// ユーザーのいない操作もまとめて1つのシャードに振り分けられるため、シャーディングにはユーザー認証が必要です（設定の読み込み時に確認）
+var shardedUnavailableFeatures = []string{
+	"checklists",
+	"projects",
+	"reminders",
+	"history",
+	"due dates",
+	"recurrence",
+	"todo sharing",
+	"workspaces",
+}
+
+// todoStore はSQLデータベースの代わりにTodo本体を保存する保存先です
+type todoStore struct {
+	repo repository.TodoRepository
+
+	// close はサーバーの終了時に呼ばれます（nil の場合は何もしません）
+	close func(ctx context.Context) error
+}
+
+// openTodoStore は DB_DRIVER に応じたSQLデータベース以外のTodoの保存先を開きます
+// snapshot を指定した場合（-mock-snapshot）、DB_DRIVER=memory では起動時にファイルから復元し、終了時に保存します
+// DynamoDB へのリクエストには httpClients の "dynamodb" のクライアント（タイムアウト・プロキシ・CA・統計を共有）を使います
+func openTodoStore(cfg *config.Config, snapshot string, httpClients *httpclient.Factory) (*todoStore, error) {
+	switch {
+	case cfg.IsMemory():
+		repo := memory.NewTodoRepository()
+		if snapshot == "" {
+			log.Printf("Memory storage: todos are kept in memory and lost on shutdown unless -mock-snapshot is set")
+			return &todoStore{repo: repo}, nil
+		}
+		n, err := memory.LoadSnapshot(context.Background(), repo, snapshot)
+		switch {
+		case err == nil:
+			log.Printf("Memory storage: restored %d todos from %s", n, snapshot)
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, fmt.Errorf("failed to restore todos: %w", err)
+		}
+		return &todoStore{repo: repo, close: func(ctx context.Context) error {
+			if err := memory.SaveSnapshot(ctx, repo, snapshot); err != nil {
+				return err
+			}
+			log.Printf("Memory storage: saved todos to %s", snapshot)
+			return nil
+		}}, nil
+	case cfg.IsEmbedded():
+		repo, closer, err := memory.OpenTodoJournal(cfg.EmbeddedPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to open embedded storage: %w", err)
+		}
+		log.Printf("Embedded storage: todos are stored in %s", cfg.EmbeddedPath())
+		return &todoStore{repo: repo, close: func(context.Context) error { return closer.Close() }}, nil
+	case cfg.IsDynamoDB():
+		client := dynamodb.NewClient(dynamodb.Config{
+			Region:          cfg.DynamoDB.Region,
+			Endpoint:        cfg.DynamoDB.Endpoint,
+			AccessKeyID:     cfg.DynamoDB.AccessKeyID,
+			SecretAccessKey: cfg.DynamoDB.SecretAccessKey,
+			SessionToken:    cfg.DynamoDB.SessionToken,
+			HTTPClient:      httpClients.Client("dynamodb"),
+		})
+		log.Printf("DynamoDB storage: todos are stored in table %s (%s)", cfg.DynamoDB.Table, cfg.DynamoDB.Region)
+		return &todoStore{repo: dynamodb.NewTodoRepository(client, cfg.DynamoDB.Table)}, nil
+	}
+	return nil, fmt.Errorf("unsupported todo storage: %s", cfg.Database.Driver)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"todoapp-api-golang/internal/infrastructure/database"
+	"todoapp-api-golang/internal/infrastructure/database/migration"
+	"todoapp-api-golang/pkg/config"
+)
+
+// main はスキーマのマイグレーションを実行するツールのエントリーポイントです
+//
+// 使い方：
+//
+//	go run ./cmd/migrate up       # 未適用のマイグレーションを全て適用
+//	go run ./cmd/migrate status   # 適用状況を表示
+//	go run ./cmd/migrate down     # 最後に適用したマイグレーションを1つ取り消す
+//
+// 接続先はAPIと同じ環境変数（DB_DRIVER・DB_HOST 等）で指定し、DB_SHARDS を設定した場合は全シャードにも実行します
+// 成功した場合は終了コード 0、失敗した場合は 1 で終了します（引数の誤りは 2）
+// status に -check を付けると、未適用のマイグレーションがある場合に終了コード 3 で終了します（デプロイ前の確認用）
+func main() {
+	timeout := flag.Duration("timeout", 5*time.Minute, "全体のタイムアウト（大きなテーブルの変更に合わせて調整）")
+	check := flag.Bool("check", false, "status で未適用のマイグレーションがある場合に終了コード 3 で終了する")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: migrate [flags] up|status|down\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	command := flag.Arg(0)
+	if command != "up" && command != "status" && command != "down" {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if !cfg.IsSQLDatabase() {
+		fmt.Fprintf(os.Stderr, "DB_DRIVER=%s has no schema to migrate\n", cfg.Database.Driver)
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	pending := false
+	for _, target := range targets(cfg) {
+		result, err := run(ctx, command, target, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] %v\n", target.name, err)
+			os.Exit(1)
+		}
+		pending = pending || result.pending
+	}
+	if *check && command == "status" && pending {
+		os.Exit(3)
+	}
+}
+
+// target はマイグレーションを実行する1つのデータベースです
+type target struct {
+	name string
+	cfg  *config.Config
+}
+
+// targets は設定からマイグレーションを実行するデータベースを列挙します（プライマリ、シャードの順）
+func targets(cfg *config.Config) []target {
+	result := []target{{name: "primary", cfg: cfg}}
+	for _, shard := range cfg.Database.Shards {
+		result = append(result, target{name: "shard " + shard.Name, cfg: cfg.ShardConfigFor(shard)})
+	}
+	return result
+}
+
+// runResult はコマンドを実行した結果です
+type runResult struct {
+	// pending は status で未適用のマイグレーションがあったかどうかです
+	pending bool
+}
+
+// run は1つのデータベースに接続してコマンドを実行し、結果を out に表示します
+func run(ctx context.Context, command string, t target, out io.Writer) (runResult, error) {
+	dm := database.NewDatabaseManager(t.cfg)
+	if err := dm.Connect(); err != nil {
+		return runResult{}, err
+	}
+	defer dm.Close()
+
+	migrator, err := database.NewMigrator(dm.DB, t.cfg.Database.Driver)
+	if err != nil {
+		return runResult{}, err
+	}
+
+	// 大きなテーブルの変更は1回のクエリの期限（DB_QUERY_TIMEOUT）より長くかかり得るため、期限を設けない
+	ctx = database.WithoutQueryTimeout(ctx)
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Fprintf(out, "[%s] applied %s\n", t.name, m)
+		}
+		if err != nil {
+			return runResult{}, err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintf(out, "[%s] no pending migrations\n", t.name)
+		}
+	case "down":
+		m, err := migrator.Down(ctx)
+		if errors.Is(err, migration.ErrNothingToRollBack) {
+			fmt.Fprintf(out, "[%s] no applied migrations\n", t.name)
+			return runResult{}, nil
+		}
+		if err != nil {
+			return runResult{}, err
+		}
+		fmt.Fprintf(out, "[%s] rolled back %s\n", t.name, m)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return runResult{}, err
+		}
+		return runResult{pending: printStatus(out, t.name, statuses)}, nil
+	}
+	return runResult{}, nil
+}
+
+// printStatus は適用状況を表形式で表示し、未適用のマイグレーションがあるかどうかを返します
+func printStatus(out io.Writer, name string, statuses []migration.Status) bool {
+	fmt.Fprintf(out, "[%s]\n", name)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	pending := false
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		switch {
+		case status.Unknown:
+			state = "unknown"
+		case status.Applied:
+			state = "applied"
+		default:
+			pending = true
+		}
+		if status.Applied {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+	return pending
+}
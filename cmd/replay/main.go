@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/infrastructure/replay"
+)
+
+// main は記録したAPIの通信を別のビルドに再送信するツールのエントリーポイントです
+//
+// 使い方：
+//
+//	RECORD_TRAFFIC_DIR=recordings go run cmd/api/main.go   # 通信を記録する
+//	go run cmd/replay/main.go -dir recordings -target http://localhost:8080
+//
+// 記録を受信順に1件ずつ再送信し、ステータスコードとボディが記録時と一致するかを表示します
+// 一致しないものがあった場合は終了コード 1 で終了します
+//
+// 作成・更新・削除のリクエストも再送信するため、記録時と同じ状態のデータベースに対して実行してください
+func main() {
+	dir := flag.String("dir", "recordings", "記録ファイル（*.json）のディレクトリ")
+	target := flag.String("target", "http://localhost:8080", "再送信先のURL")
+	ignore := flag.String("ignore", strings.Join(replay.DefaultIgnoreFields, ","), "比較時に無視するJSONのキー（カンマ区切り）")
+	timeout := flag.Duration("timeout", 10*time.Second, "1リクエストあたりのタイムアウト")
+	var headers headerFlag
+	flag.Var(&headers, "header", "全てのリクエストに追加するヘッダー（例: \"Authorization: Bearer xxx\"、複数指定可）")
+	flag.Parse()
+
+	recordings, err := replay.Load(*dir)
+	if err != nil {
+		log.Fatalf("Failed to load recordings: %v", err)
+	}
+	if len(recordings) == 0 {
+		log.Fatalf("No recordings found in %s", *dir)
+	}
+
+	replayer := &replay.Replayer{
+		Client:       &http.Client{Timeout: *timeout},
+		Target:       *target,
+		Header:       http.Header(headers),
+		IgnoreFields: splitList(*ignore),
+	}
+
+	failed := 0
+	for _, recording := range recordings {
+		result := replayer.Replay(context.Background(), recording)
+		request := recording.Exchange.Request
+
+		switch {
+		case result.Err != nil:
+			failed++
+			fmt.Printf("ERROR %s %s %s: %v\n", recording.Name, request.Method, request.URI, result.Err)
+		case result.Skipped != "":
+			fmt.Printf("SKIP  %s %s %s: %s\n", recording.Name, request.Method, request.URI, result.Skipped)
+		case result.Diff != "":
+			failed++
+			fmt.Printf("DIFF  %s %s %s: %s\n", recording.Name, request.Method, request.URI, result.Diff)
+		default:
+			fmt.Printf("OK    %s %s %s %d\n", recording.Name, request.Method, request.URI, result.Status)
+		}
+	}
+
+	fmt.Printf("%d replayed, %d failed\n", len(recordings), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// headerFlag は "Name: value" 形式で複数回指定できるフラグです
+type headerFlag http.Header
+
+// String は flag.Value インターフェースの実装です
+func (h *headerFlag) String() string {
+	return fmt.Sprint(http.Header(*h))
+}
+
+// Set は flag.Value インターフェースの実装で、ヘッダーを1つ追加します
+func (h *headerFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("header must be in \"Name: value\" format: %q", value)
+	}
+	if *h == nil {
+		*h = headerFlag{}
+	}
+	http.Header(*h).Add(strings.TrimSpace(name), strings.TrimSpace(val))
+	return nil
+}
+
+// splitList はカンマ区切りの文字列を空要素を除いて分割します
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/infrastructure/smoketest"
+)
+
+// main はデプロイ後の動作確認（スモークテスト）を行うツールのエントリーポイントです
+//
+// 使い方：
+//
+//	go run ./cmd/smoketest -target https://todo.example.com -wait 60s
+//
+// Todo の作成 → 取得 → 一覧 → 完了 → 削除 を順に実行し、手順ごとの結果を表示します
+// 全ての手順が成功した場合は終了コード 0、失敗した手順があった場合は 1 で終了します
+// （引数の誤りは 2）。作成したTodoは失敗した場合も削除を試みます
+func main() {
+	target := flag.String("target", "http://localhost:8080", "対象のサーバーのURL（ベースパスで公開している場合はそれも含める）")
+	title := flag.String("title", "", "作成するTodoのタイトル（省略時は \"smoketest \" と現在時刻）")
+	timeout := flag.Duration("timeout", 10*time.Second, "1リクエストあたりのタイムアウト")
+	wait := flag.Duration("wait", 0, "/health が成功するまで再試行する時間（デプロイ直後の起動待ち）")
+	var headers headerFlag
+	flag.Var(&headers, "header", "全てのリクエストに追加するヘッダー（例: \"Authorization: Bearer xxx\"、複数指定可）")
+	flag.Parse()
+
+	runner := &smoketest.Runner{
+		Client:       &http.Client{Timeout: *timeout},
+		BaseURL:      *target,
+		Header:       http.Header(headers),
+		Title:        *title,
+		ReadyTimeout: *wait,
+	}
+
+	failed := 0
+	for _, step := range runner.Run(context.Background()) {
+		if step.OK() {
+			fmt.Printf("OK    %-14s %s %s %d (%s)\n", step.Name, step.Method, step.Path, step.Status, step.Duration.Round(time.Millisecond))
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL  %-14s %s %s: %v\n", step.Name, step.Method, step.Path, step.Err)
+	}
+
+	if failed > 0 {
+		fmt.Printf("smoke test failed against %s\n", *target)
+		os.Exit(1)
+	}
+	fmt.Printf("smoke test passed against %s\n", *target)
+}
+
+// headerFlag は "Name: value" 形式で複数回指定できるフラグです
+type headerFlag http.Header
+
+// String は flag.Value インターフェースの実装です
+func (h *headerFlag) String() string {
+	return fmt.Sprint(http.Header(*h))
+}
+
+// Set は flag.Value インターフェースの実装で、ヘッダーを1つ追加します
+func (h *headerFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("header must be in \"Name: value\" format: %q", value)
+	}
+	if *h == nil {
+		*h = headerFlag{}
+	}
+	http.Header(*h).Add(strings.TrimSpace(name), strings.TrimSpace(val))
+	return nil
+}
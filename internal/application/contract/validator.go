@@ -0,0 +1,445 @@
+// Package contract はHTTPレスポンスがOpenAPI仕様書（api/openapi.json）の契約どおりかを検証します
+//
+// 学習ポイント：
+//  1. 仕様書を「ドキュメント」ではなく「検証可能な契約」として扱い、実装とのずれをテストで検出する
+//  2. 外部ライブラリを使わず、このAPIの仕様書で使用するJSON Schemaのキーワードだけを実装する
+//     （$ref, type, properties, required, additionalProperties: false, items, enum, oneOf, anyOf, pattern）
+//  3. 仕様書の読み込み時に全ての $ref を解決し、仕様書自体の誤りは起動時（テスト開始時）に検出する
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Validator はOpenAPI仕様書に基づいてレスポンスを検証します
+type Validator struct {
+	routes []*route
+}
+
+// route は仕様書の1つのパス（例: /api/v1/todos/{id}）です
+type route struct {
+	template   string
+	segments   []string
+	literals   int
+	operations map[string]*operation
+}
+
+// operation は1つのHTTPメソッドの定義です
+type operation struct {
+	Responses map[string]*response `json:"responses"`
+}
+
+// response は1つのステータスコードのレスポンス定義です
+type response struct {
+	Ref     string               `json:"$ref"`
+	Content map[string]mediaType `json:"content"`
+}
+
+// mediaType はContent-Typeごとのボディの定義です
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+// schema はJSON Schemaのうち、このAPIの仕様書で使用するキーワードです
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaTypes        `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+	OneOf                []*schema          `json:"oneOf"`
+	AnyOf                []*schema          `json:"anyOf"`
+	Pattern              string             `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes は type キーワードです（OpenAPI 3.1 では "string" と ["number", "null"] の両方の書き方があります）
+type schemaTypes []string
+
+// UnmarshalJSON は文字列と文字列の配列の両方を受け付けます
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %s", data)
+	}
+	*t = multiple
+	return nil
+}
+
+// document は仕様書全体のうち、検証に必要な部分です
+type document struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas   map[string]*schema   `json:"schemas"`
+		Responses map[string]*response `json:"responses"`
+	} `json:"components"`
+}
+
+// httpMethods はパスの定義のうち、操作（operation）として扱うキーです
+var httpMethods = map[string]string{
+	"get": http.MethodGet, "put": http.MethodPut, "post": http.MethodPost, "delete": http.MethodDelete,
+	"patch": http.MethodPatch, "head": http.MethodHead, "options": http.MethodOptions,
+}
+
+// NewValidator はOpenAPI仕様書（JSON）からValidatorを作成します
+// 参照先のない $ref や不正な pattern がある場合はエラーを返します
+func NewValidator(spec []byte) (*Validator, error) {
+	var doc document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	r := &resolver{doc: &doc, seen: make(map[*schema]bool)}
+	v := &Validator{}
+	for template, item := range doc.Paths {
+		rt := &route{template: template, segments: splitPath(template), operations: make(map[string]*operation)}
+		for _, segment := range rt.segments {
+			if !isParam(segment) {
+				rt.literals++
+			}
+		}
+
+		for key, raw := range item {
+			method, ok := httpMethods[key]
+			if !ok {
+				continue // parameters など
+			}
+			var op operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, template, err)
+			}
+			for status, resp := range op.Responses {
+				resolved, err := r.response(resp)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s %s: %w", method, template, status, err)
+				}
+				op.Responses[status] = resolved
+			}
+			rt.operations[method] = &op
+		}
+		v.routes = append(v.routes, rt)
+	}
+
+	// リテラルの多いパスを優先する（/todos/stats を /todos/{id} より先に照合する）
+	sort.Slice(v.routes, func(i, j int) bool {
+		if v.routes[i].literals != v.routes[j].literals {
+			return v.routes[i].literals > v.routes[j].literals
+		}
+		return v.routes[i].template < v.routes[j].template
+	})
+	return v, nil
+}
+
+// ValidateResponse はレスポンスが仕様書の定義に一致するかを検証します
+//
+// 次の場合はエラーを返します：
+//   - 仕様書にないパス・メソッドへのリクエストが 404 / 405 以外を返した（OPTIONS を除く）
+//   - 仕様書にないステータスコードを返した
+//   - 仕様書にないContent-Typeを返した、またはJSONのボディがスキーマに一致しない
+func (v *Validator) ValidateResponse(method, path string, status int, header http.Header, body []byte) error {
+	rt := v.match(path)
+	if rt == nil {
+		if status == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("undocumented path: %s", path)
+	}
+
+	op, ok := rt.operations[method]
+	if !ok {
+		// ルーターが返す 404・405 と、OPTIONS への応答（Allow ヘッダー）は仕様書に個別に記載しない
+		if status == http.StatusNotFound || status == http.StatusMethodNotAllowed || method == http.MethodOptions {
+			return nil
+		}
+		return fmt.Errorf("undocumented operation: %s %s", method, rt.template)
+	}
+
+	resp := op.Responses[strconv.Itoa(status)]
+	if resp == nil {
+		resp = op.Responses[fmt.Sprintf("%dXX", status/100)]
+	}
+	if resp == nil {
+		resp = op.Responses["default"]
+	}
+	if resp == nil {
+		return fmt.Errorf("%s %s: undocumented status %d", method, rt.template, status)
+	}
+
+	if len(resp.Content) == 0 {
+		if len(body) > 0 {
+			return fmt.Errorf("%s %s %d: response must not have a body", method, rt.template, status)
+		}
+		return nil
+	}
+
+	contentType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("%s %s %d: invalid Content-Type %q", method, rt.template, status, header.Get("Content-Type"))
+	}
+	media, ok := resp.Content[contentType]
+	if !ok {
+		return fmt.Errorf("%s %s %d: undocumented Content-Type %s", method, rt.template, status, contentType)
+	}
+	if contentType != "application/json" || media.Schema == nil {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("%s %s %d: invalid JSON body: %w", method, rt.template, status, err)
+	}
+	if err := validate(value, media.Schema, "$"); err != nil {
+		return fmt.Errorf("%s %s %d: %w", method, rt.template, status, err)
+	}
+	return nil
+}
+
+// match はリクエストのパスに一致するパスの定義を返します（一致しない場合は nil）
+func (v *Validator) match(path string) *route {
+	segments := splitPath(path)
+	for _, rt := range v.routes {
+		if len(rt.segments) != len(segments) {
+			continue
+		}
+		matched := true
+		for i, segment := range rt.segments {
+			if !isParam(segment) && segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rt
+		}
+	}
+	return nil
+}
+
+// validate は値がスキーマに一致するかを検証します（path はエラー表示用のJSONパス）
+func validate(value any, s *schema, path string) error {
+	if len(s.OneOf) > 0 {
+		matches := 0
+		for _, candidate := range s.OneOf {
+			if validate(value, candidate, path) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fmt.Errorf("%s: must match exactly one schema in oneOf (matched %d)", path, matches)
+		}
+	}
+	if len(s.AnyOf) > 0 {
+		// anyOf は oneOf と違い、複数のスキーマに一致してもよい（例: 全フィールドのTodoは一部のフィールドのTodoにも一致する）
+		matched := false
+		for _, candidate := range s.AnyOf {
+			if validate(value, candidate, path) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: must match at least one schema in anyOf", path)
+		}
+	}
+
+	if len(s.Type) > 0 && !slicesContainsType(s.Type, value) {
+		return fmt.Errorf("%s: must be %s, got %s", path, strings.Join(s.Type, " or "), jsonType(value))
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, candidate := range s.Enum {
+			if reflect.DeepEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, s.Enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			return fmt.Errorf("%s: %q does not match pattern %s", path, v, s.Pattern)
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				if string(s.AdditionalProperties) == "false" {
+					return fmt.Errorf("%s: undocumented property %q", path, name)
+				}
+				continue
+			}
+			if err := validate(v[name], property, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// slicesContainsType は値のJSONの型が types のいずれかに一致するかを返します
+// integer は小数部のない number として判定します
+func slicesContainsType(types []string, value any) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType は encoding/json でデコードした値のJSON Schemaの型名を返します
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// resolver は仕様書内の $ref を解決します
+type resolver struct {
+	doc  *document
+	seen map[*schema]bool
+}
+
+// response はレスポンスの $ref を解決し、含まれるスキーマも解決します
+func (r *resolver) response(resp *response) (*response, error) {
+	if resp.Ref != "" {
+		name, ok := strings.CutPrefix(resp.Ref, "#/components/responses/")
+		target := r.doc.Components.Responses[name]
+		if !ok || target == nil {
+			return nil, fmt.Errorf("unresolved $ref %q", resp.Ref)
+		}
+		resp = target
+	}
+	for _, media := range resp.Content {
+		if media.Schema == nil {
+			continue
+		}
+		if err := r.schema(media.Schema); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// schema はスキーマ内の $ref を参照先のスキーマで置き換え、pattern をコンパイルします
+// 再帰的なスキーマでも無限ループしないよう、処理済みのスキーマは記録します
+func (r *resolver) schema(s *schema) error {
+	if r.seen[s] {
+		return nil
+	}
+	r.seen[s] = true
+
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		target := r.doc.Components.Schemas[name]
+		if !ok || target == nil {
+			return fmt.Errorf("unresolved $ref %q", s.Ref)
+		}
+		if err := r.schema(target); err != nil {
+			return err
+		}
+		ref := s.Ref
+		*s = *target
+		s.Ref = ref
+		return nil
+	}
+
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+	if s.Items != nil {
+		if err := r.schema(s.Items); err != nil {
+			return err
+		}
+	}
+	for _, property := range s.Properties {
+		if err := r.schema(property); err != nil {
+			return err
+		}
+	}
+	for _, candidate := range s.OneOf {
+		if err := r.schema(candidate); err != nil {
+			return err
+		}
+	}
+	for _, candidate := range s.AnyOf {
+		if err := r.schema(candidate); err != nil {
+			return err
+		}
+	}
+	if len(s.AdditionalProperties) > 0 && string(s.AdditionalProperties) != "false" && string(s.AdditionalProperties) != "true" {
+		return errors.New("additionalProperties must be a boolean")
+	}
+	return nil
+}
+
+// splitPath はパスを "/" で分割します（前後の "/" は無視）
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// isParam はパスのセグメントがパラメーター（{id} 等）かどうかを返します
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
@@ -0,0 +1,231 @@
+package contract
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/api"
+)
+
+// testSpec はテスト用の小さなOpenAPI仕様書です
+const testSpec = `{
+  "openapi": "3.1.0",
+  "paths": {
+    "/api/v1/todos/{id}": {
+      "get": {
+        "responses": {
+          "200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Todo"}}}},
+          "404": {"$ref": "#/components/responses/NotFound"}
+        }
+      },
+      "delete": {"responses": {"204": {"description": "削除"}}}
+    },
+    "/api/v1/todos/stats": {
+      "get": {"responses": {"2XX": {"content": {"application/json": {"schema": {"type": "object"}}}}}}
+    }
+  },
+  "components": {
+    "schemas": {
+      "Todo": {
+        "type": "object",
+        "required": ["id", "title"],
+        "additionalProperties": false,
+        "properties": {
+          "id": {"type": "integer"},
+          "title": {"type": "string"},
+          "priority": {"enum": ["low", "medium", "high"]},
+          "color": {"type": "string", "pattern": "^#[0-9a-f]{6}$"},
+          "due_date": {"oneOf": [{"type": "string"}, {"type": "null"}]},
+          "note": {"anyOf": [{"type": "string"}, {"type": "string", "pattern": "^memo:"}]},
+          "tags": {"type": "array", "items": {"type": "string"}},
+          "ratio": {"type": ["number", "null"]}
+        }
+      },
+      "Error": {
+        "type": "object",
+        "required": ["error"],
+        "properties": {"error": {"type": "string"}}
+      }
+    },
+    "responses": {
+      "NotFound": {
+        "content": {
+          "application/json": {"schema": {"$ref": "#/components/schemas/Error"}},
+          "text/plain": {"schema": {"type": "string"}}
+        }
+      }
+    }
+  }
+}`
+
+// TestValidator_ValidateResponse はレスポンスの検証をテストします
+func TestValidator_ValidateResponse(t *testing.T) {
+	validator, err := NewValidator([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("仕様書の読み込みに失敗: %v", err)
+	}
+
+	jsonHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	textHeader := http.Header{"Content-Type": {"text/plain; charset=utf-8"}}
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		status      int
+		header      http.Header
+		body        string
+		expectedErr string // 空の場合は成功を期待
+	}{
+		{
+			name: "仕様どおりのレスポンス", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","priority":"high","color":"#ff0000","due_date":null,"tags":["x"],"ratio":0.5}`,
+		},
+		{
+			name: "必須のプロパティがない", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1}`, expectedErr: `missing required property "title"`,
+		},
+		{
+			name: "仕様書にないプロパティ", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","secret":true}`, expectedErr: `undocumented property "secret"`,
+		},
+		{
+			name: "型が違う", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":"1","title":"a"}`, expectedErr: "$.id: must be integer, got string",
+		},
+		{
+			name: "小数は integer ではない", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1.5,"title":"a"}`, expectedErr: "$.id: must be integer",
+		},
+		{
+			name: "enum にない値", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","priority":"urgent"}`, expectedErr: "$.priority",
+		},
+		{
+			name: "pattern に一致しない", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","color":"red"}`, expectedErr: "does not match pattern",
+		},
+		{
+			name: "oneOf のどれにも一致しない", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","due_date":1}`, expectedErr: "$.due_date: must match exactly one schema",
+		},
+		{
+			name: "anyOf は複数のスキーマに一致してもよい", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","note":"memo:x"}`,
+		},
+		{
+			name: "anyOf のどれにも一致しない", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","note":1}`, expectedErr: "$.note: must match at least one schema in anyOf",
+		},
+		{
+			name: "配列の要素の型が違う", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","tags":[1]}`, expectedErr: "$.tags[0]",
+		},
+		{
+			name: "type の配列で null を許可", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":1,"title":"a","ratio":null}`,
+		},
+		{
+			name: "components/responses の参照（text/plain）", method: http.MethodGet, path: "/api/v1/todos/1", status: 404, header: textHeader,
+			body: "404 page not found",
+		},
+		{
+			name: "仕様書にないContent-Type", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: http.Header{"Content-Type": {"text/csv"}},
+			body: "id,title", expectedErr: "undocumented Content-Type text/csv",
+		},
+		{
+			name: "JSONとして不正なボディ", method: http.MethodGet, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{"id":`, expectedErr: "invalid JSON body",
+		},
+		{
+			name: "仕様書にないステータスコード", method: http.MethodGet, path: "/api/v1/todos/1", status: 500, header: jsonHeader,
+			body: `{"error":"x"}`, expectedErr: "undocumented status 500",
+		},
+		{
+			name: "OPTIONS への応答", method: http.MethodOptions, path: "/api/v1/todos/1", status: 204,
+		},
+		{
+			name: "ボディなしのレスポンス", method: http.MethodDelete, path: "/api/v1/todos/1", status: 204,
+		},
+		{
+			name: "ボディなしのはずがボディがある", method: http.MethodDelete, path: "/api/v1/todos/1", status: 204,
+			body: "x", expectedErr: "must not have a body",
+		},
+		{
+			name: "リテラルのパスを優先し 2XX で照合", method: http.MethodGet, path: "/api/v1/todos/stats", status: 200, header: jsonHeader,
+			body: `{"total":1}`,
+		},
+		{
+			name: "仕様書にないメソッドの 405", method: http.MethodPut, path: "/api/v1/todos/1", status: 405, header: textHeader,
+		},
+		{
+			name: "仕様書にないメソッドが 200", method: http.MethodPut, path: "/api/v1/todos/1", status: 200, header: jsonHeader,
+			body: `{}`, expectedErr: "undocumented operation",
+		},
+		{
+			name: "仕様書にないパスの 404", method: http.MethodGet, path: "/api/v1/unknown", status: 404, header: textHeader,
+		},
+		{
+			name: "仕様書にないパスが 200", method: http.MethodGet, path: "/api/v1/unknown", status: 200, header: jsonHeader,
+			body: `{}`, expectedErr: "undocumented path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			err := validator.ValidateResponse(tt.method, tt.path, tt.status, header, []byte(tt.body))
+
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("予期しないエラー: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("エラー = %v, 期待値に含まれる文字列 = %q", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+// TestNewValidator_InvalidSpec は仕様書自体の誤りの検出をテストします
+func TestNewValidator_InvalidSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{name: "JSONとして不正", spec: `{`},
+		{
+			name: "参照先のないスキーマ",
+			spec: `{"paths":{"/a":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}}}}`,
+		},
+		{
+			name: "参照先のないレスポンス",
+			spec: `{"paths":{"/a":{"get":{"responses":{"404":{"$ref":"#/components/responses/Missing"}}}}}}`,
+		},
+		{
+			name: "不正な pattern",
+			spec: `{"paths":{"/a":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"type":"string","pattern":"("}}}}}}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewValidator([]byte(tt.spec)); err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+// TestNewValidator_APISpec はリポジトリの仕様書（api/openapi.json）が読み込めることをテストします
+func TestNewValidator_APISpec(t *testing.T) {
+	if _, err := NewValidator(api.OpenAPISpec); err != nil {
+		t.Fatalf("api/openapi.json の読み込みに失敗: %v", err)
+	}
+}
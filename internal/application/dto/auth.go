@@ -0,0 +1,86 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// CredentialsRequest はユーザー登録・ログイン時のリクエストボディです
+type CredentialsRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RefreshTokenRequest はトークンの再発行・ログアウト時のリクエストボディです
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// UserResponse はユーザーのレスポンスDTOです（パスワードのハッシュは含めない）
+type UserResponse struct {
+	ID        ID        `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+// TokenResponse はトークン発行のレスポンスDTOです
+// 形式は OAuth 2.0（RFC 6749 5.1節）のトークンレスポンスに合わせています
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+
+	// TokenType は常に "Bearer" です（Authorization: Bearer <access_token> で送る）
+	TokenType string `json:"token_type"`
+
+	// ExpiresIn はアクセストークンの有効期間（秒）です
+	ExpiresIn int64 `json:"expires_in"`
+
+	// RefreshToken は1回だけ使えるトークンです（使うと新しいトークンが発行される）
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt Timestamp `json:"refresh_token_expires_at"`
+}
+
+// CreateTokenRequest はスコープを限定したアクセストークンの発行時のリクエストボディです
+type CreateTokenRequest struct {
+	// Scopes は許可する操作の範囲（todos:read / todos:write、1つ以上）
+	Scopes []string `json:"scopes"`
+
+	// ExpiresIn は有効期間（秒、省略時はログインのアクセストークンと同じ）
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+}
+
+// ScopedTokenResponse はスコープを限定したアクセストークンのレスポンスDTOです
+// リフレッシュトークンは発行しないため、期限が切れたら新しく発行し直します
+type ScopedTokenResponse struct {
+	AccessToken string `json:"access_token"`
+
+	// TokenType は常に "Bearer" です（Authorization: Bearer <access_token> で送る）
+	TokenType string `json:"token_type"`
+
+	// ExpiresIn はアクセストークンの有効期間（秒）です
+	ExpiresIn int64     `json:"expires_in"`
+	ExpiresAt Timestamp `json:"expires_at"`
+
+	// Scopes は許可した操作の範囲です（重複を除いて並べ替えたもの）
+	Scopes []string `json:"scopes"`
+}
+
+// SessionResponse はCookieのセッションでログインした場合のレスポンスDTOです
+// セッションIDは Set-Cookie ヘッダーだけで返し、ボディには含めません（JavaScript から読めないようにするため）
+type SessionResponse struct {
+	Username  string    `json:"username"`
+	ExpiresAt Timestamp `json:"expires_at"`
+}
+
+// ToUserResponse はエンティティをレスポンスDTOに変換します
+func ToUserResponse(user *entity.User) UserResponse {
+	return UserResponse{
+		ID:        ID(user.ID),
+		Username:  user.Username,
+		CreatedAt: NewTimestamp(user.CreatedAt),
+	}
+}
+
+// ToSessionResponse はエンティティをレスポンスDTOに変換します
+func ToSessionResponse(session *entity.Session) SessionResponse {
+	return SessionResponse{
+		Username:  session.Username,
+		ExpiresAt: NewTimestamp(session.ExpiresAt),
+	}
+}
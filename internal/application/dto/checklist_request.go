@@ -0,0 +1,41 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// CreateChecklistItemRequest はチェックリスト項目の追加時のリクエストボディです
+type CreateChecklistItemRequest struct {
+	// Text はチェックリスト項目の内容（必須）
+	Text string `json:"text"`
+
+	// IsDone は作成時点でチェック済みにするか（任意、デフォルトは false）
+	IsDone bool `json:"is_done"`
+}
+
+// UpdateChecklistItemRequest はチェックリスト項目の更新時のリクエストボディです
+// UpdateTodoRequest と同様に、ポインタ型で送信されたフィールドのみを更新します
+type UpdateChecklistItemRequest struct {
+	// Text の更新（任意）
+	Text *string `json:"text,omitempty"`
+
+	// IsDone の更新（任意）
+	IsDone *bool `json:"is_done,omitempty"`
+}
+
+// ToEntity はリクエストDTOを指定TodoのChecklistItemエンティティに変換します
+func (req CreateChecklistItemRequest) ToEntity(todoID int) *entity.ChecklistItem {
+	return &entity.ChecklistItem{
+		TodoID: todoID,
+		Text:   req.Text,
+		IsDone: req.IsDone,
+	}
+}
+
+// ApplyToEntity は送信されたフィールドのみを既存のチェックリスト項目に適用します
+func (req UpdateChecklistItemRequest) ApplyToEntity(item *entity.ChecklistItem) {
+	if req.Text != nil {
+		item.Text = *req.Text
+	}
+	if req.IsDone != nil {
+		item.IsDone = *req.IsDone
+	}
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ChecklistItemResponse はチェックリスト項目のレスポンスDTOです
+type ChecklistItemResponse struct {
+	ID        ID        `json:"id"`
+	TodoID    ID        `json:"todo_id"`
+	Text      string    `json:"text"`
+	IsDone    bool      `json:"is_done"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// ChecklistProgressResponse はTodoに含まれるチェックリストの進捗を表すDTOです
+type ChecklistProgressResponse struct {
+	// Total はチェックリスト項目の総数
+	Total int `json:"total"`
+
+	// Done はチェック済みの項目数
+	Done int `json:"done"`
+}
+
+// ChecklistResponse はTodoのチェックリスト全体を返すレスポンスDTOです
+type ChecklistResponse struct {
+	// Items はチェックリスト項目のリスト（作成順）
+	Items []ChecklistItemResponse `json:"items"`
+
+	// Progress はチェックリストの進捗
+	Progress ChecklistProgressResponse `json:"progress"`
+}
+
+// ToChecklistItemResponse はエンティティをレスポンスDTOに変換します
+func ToChecklistItemResponse(item *entity.ChecklistItem) ChecklistItemResponse {
+	return ChecklistItemResponse{
+		ID:        ID(item.ID),
+		TodoID:    ID(item.TodoID),
+		Text:      item.Text,
+		IsDone:    item.IsDone,
+		CreatedAt: NewTimestamp(item.CreatedAt),
+		UpdatedAt: NewTimestamp(item.UpdatedAt),
+	}
+}
+
+// ToChecklistProgressResponse は進捗の値オブジェクトをレスポンスDTOに変換します
+func ToChecklistProgressResponse(progress entity.ChecklistProgress) ChecklistProgressResponse {
+	return ChecklistProgressResponse{
+		Total: progress.Total,
+		Done:  progress.Done,
+	}
+}
+
+// ToChecklistResponse はチェックリスト項目の配列を進捗付きのレスポンスに変換します
+func ToChecklistResponse(items []*entity.ChecklistItem) ChecklistResponse {
+	responses := make([]ChecklistItemResponse, len(items))
+	var progress entity.ChecklistProgress
+	for i, item := range items {
+		responses[i] = ToChecklistItemResponse(item)
+		progress.Total++
+		if item.IsDone {
+			progress.Done++
+		}
+	}
+
+	return ChecklistResponse{
+		Items:    responses,
+		Progress: ToChecklistProgressResponse(progress),
+	}
+}
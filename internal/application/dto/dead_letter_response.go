@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// DeadLetterResponse は再送の上限に達した送信（デッドレター）のレスポンスDTOです
+type DeadLetterResponse struct {
+	ID     ID     `json:"id"`
+	Kind   string `json:"kind"`
+	TodoID ID     `json:"todo_id"`
+
+	// Payload は送信しようとした内容です（種類ごとの形式のJSONをそのまま返します）
+	Payload json.RawMessage `json:"payload"`
+
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	Status        string    `json:"status"`
+	NextAttemptAt Timestamp `json:"next_attempt_at"`
+	CreatedAt     Timestamp `json:"created_at"`
+	UpdatedAt     Timestamp `json:"updated_at"`
+}
+
+// DeadLetterListResponse はデッドレター一覧のレスポンスDTOです
+type DeadLetterListResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+	Meta        ResponseMeta         `json:"meta"`
+}
+
+// ToDeadLetterResponse はエンティティをレスポンスDTOに変換します
+func ToDeadLetterResponse(delivery *entity.FailedDelivery) DeadLetterResponse {
+	payload := json.RawMessage(delivery.Payload)
+	if !json.Valid(payload) {
+		payload = json.RawMessage("null")
+	}
+
+	return DeadLetterResponse{
+		ID:            ID(delivery.ID),
+		Kind:          string(delivery.Kind),
+		TodoID:        ID(delivery.TodoID),
+		Payload:       payload,
+		Attempts:      delivery.Attempts,
+		LastError:     delivery.LastError,
+		Status:        string(delivery.Status),
+		NextAttemptAt: NewTimestamp(delivery.NextAttemptAt),
+		CreatedAt:     NewTimestamp(delivery.CreatedAt),
+		UpdatedAt:     NewTimestamp(delivery.UpdatedAt),
+	}
+}
+
+// ToDeadLetterListResponse はデッドレターの配列を一覧レスポンスDTOに変換します
+func ToDeadLetterListResponse(deliveries []*entity.FailedDelivery) DeadLetterListResponse {
+	deadLetters := make([]DeadLetterResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		deadLetters[i] = ToDeadLetterResponse(delivery)
+	}
+
+	return DeadLetterListResponse{
+		DeadLetters: deadLetters,
+		Meta:        NewResponseMeta(),
+	}
+}
@@ -0,0 +1,185 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeFormat はレスポンスJSONでの日時の表現形式です
+type TimeFormat string
+
+const (
+	// TimeFormatRFC3339 は "2024-05-01T09:30:00Z" 形式の文字列です（デフォルト）
+	TimeFormatRFC3339 TimeFormat = "rfc3339"
+
+	// TimeFormatEpochSeconds はUNIXエポックからの秒数（数値）です
+	TimeFormatEpochSeconds TimeFormat = "epoch_seconds"
+
+	// TimeFormatEpochMillis はUNIXエポックからのミリ秒数（数値）です
+	TimeFormatEpochMillis TimeFormat = "epoch_millis"
+)
+
+// IsValid は対応している形式かどうかを判定します
+func (f TimeFormat) IsValid() bool {
+	switch f {
+	case TimeFormatRFC3339, TimeFormatEpochSeconds, TimeFormatEpochMillis:
+		return true
+	}
+	return false
+}
+
+// EncodingOptions はレスポンスDTOのJSON表現の設定です
+//
+// 学習ポイント：
+// クライアントによっては日時の文字列をパースしにくかったり、
+// JavaScript の Number で大きな整数IDの精度が失われたりします
+// 各DTOのフィールドを ID / Timestamp 型にしておくことで、
+// 変換関数やハンドラーを変更せずに表現をまとめて切り替えられます
+type EncodingOptions struct {
+	// TimeFormat は日時の表現形式です
+	TimeFormat TimeFormat
+
+	// StringIDs が true の場合、IDを文字列（"42"）で返します
+	StringIDs bool
+
+	// IDCodec を設定すると、IDを連番の整数ではなく不透明な文字列（"gB0NV05e"）で公開します
+	// レスポンスのJSON・URLのパス・Location ヘッダーのIDが変換され、リクエストのIDも変換後の文字列で受け付けます
+	// 内部（エンティティ・データベース）のIDは整数のままです
+	IDCodec IDCodec
+}
+
+// IDCodec は公開するIDと内部の整数のIDを相互に変換します（pkg/hashids 等）
+type IDCodec interface {
+	Encode(id int) (string, error)
+	Decode(s string) (int, error)
+}
+
+// Encoding はレスポンスDTOのJSON表現の設定です
+// 起動時に設定ファイルの内容で一度だけ上書きします（テストでは変更後に元に戻します）
+var Encoding = EncodingOptions{TimeFormat: TimeFormatRFC3339}
+
+// ID はレスポンスDTOで使用するリソースのIDです
+// Encoding.StringIDs に応じて数値または文字列でJSONに変換されます
+type ID int
+
+// String はURLのパスやHTMLで使用する、公開する形式のIDを返します
+func (id ID) String() string {
+	return FormatID(int(id))
+}
+
+// MarshalJSON はIDをJSONに変換します
+func (id ID) MarshalJSON() ([]byte, error) {
+	if Encoding.IDCodec != nil || Encoding.StringIDs {
+		return []byte(strconv.Quote(FormatID(int(id)))), nil
+	}
+	return []byte(strconv.Itoa(int(id))), nil
+}
+
+// UnmarshalJSON は数値・文字列のどちらの形式のIDも受け付けます
+// Encoding.IDCodec を設定している場合は、変換後の文字列のみを受け付けます（0 は「指定なし」として常に受け付けます）
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if Encoding.IDCodec != nil && string(data) == "0" {
+		*id = 0
+		return nil
+	}
+	n, err := ParseID(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return fmt.Errorf("invalid id: %s", data)
+	}
+	*id = ID(n)
+	return nil
+}
+
+// FormatID は内部の整数のIDを公開する形式に変換します
+// Encoding.IDCodec を設定していない場合は10進数の文字列です
+func FormatID(id int) string {
+	if Encoding.IDCodec != nil {
+		if s, err := Encoding.IDCodec.Encode(id); err == nil {
+			return s
+		}
+	}
+	return strconv.Itoa(id)
+}
+
+// ParseID は公開する形式のID（URLのパス等）を内部の整数のIDに変換します
+// Encoding.IDCodec を設定している場合、連番の整数は受け付けません（総当たりでリソースを探せないようにするため）
+func ParseID(s string) (int, error) {
+	if Encoding.IDCodec != nil {
+		id, err := Encoding.IDCodec.Decode(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid id %q", s)
+		}
+		return id, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// idPtr はポインタのIDを変換します（nil の場合は nil）
+func idPtr(id *int) *ID {
+	if id == nil {
+		return nil
+	}
+	v := ID(*id)
+	return &v
+}
+
+// Timestamp はレスポンスDTOで使用する日時です
+// Encoding.TimeFormat に応じた形式でJSONに変換されます
+// time.Time を埋め込んでいるため、Format などのメソッドはそのまま使用できます
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp は time.Time からTimestampを作成します
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// timestampPtr はポインタの日時を変換します（nil の場合は nil）
+func timestampPtr(t *time.Time) *Timestamp {
+	if t == nil {
+		return nil
+	}
+	v := NewTimestamp(*t)
+	return &v
+}
+
+// MarshalJSON は日時をJSONに変換します
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	switch Encoding.TimeFormat {
+	case TimeFormatEpochSeconds:
+		return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+	case TimeFormatEpochMillis:
+		return []byte(strconv.FormatInt(t.UnixMilli(), 10)), nil
+	default:
+		return json.Marshal(t.Time)
+	}
+}
+
+// UnmarshalJSON はRFC3339形式の文字列と、エポックからの数値の日時を受け付けます
+// 数値の場合は現在の Encoding.TimeFormat の単位（秒またはミリ秒）として解釈します
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &t.Time)
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %s", data)
+	}
+	if Encoding.TimeFormat == TimeFormatEpochMillis {
+		t.Time = time.UnixMilli(n).UTC()
+	} else {
+		t.Time = time.Unix(n, 0).UTC()
+	}
+	return nil
+}
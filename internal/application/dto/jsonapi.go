@@ -0,0 +1,172 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON:API（https://jsonapi.org/）形式のレスポンスです
+//
+// 学習ポイント：
+// JSON:API はリソースを type / id / attributes / relationships に分けて表現する仕様です
+// 既存のレスポンスDTO（TodoResponse 等）をそのまま JSON:API のドキュメントに変換するため、
+// フィールドの追加・変更は DTO 側だけで済み、2つの形式で内容がずれることはありません
+//
+//   - id は仕様に従い常に文字列で返します（Encoding.StringIDs の設定によらない、Encoding.IDCodec の設定は反映する）
+//   - 他のリソースを指すID（project_id 等）は attributes ではなく relationships に移します
+//   - 個々のDTOの meta（NotModifiedMeta）はドキュメントの meta に移します
+
+// MediaTypeJSONAPI は JSON:API のメディアタイプです
+const MediaTypeJSONAPI = "application/vnd.api+json"
+
+// JSON:API のリソースの種類（type）です
+const (
+	JSONAPITypeTodos    = "todos"
+	JSONAPITypeProjects = "projects"
+)
+
+// JSONAPIDocument は JSON:API のトップレベルのドキュメントです
+// Data は1件の場合は JSONAPIResource、一覧の場合は []JSONAPIResource です
+type JSONAPIDocument struct {
+	Data    any               `json:"data"`
+	Meta    any               `json:"meta,omitempty"`
+	Links   map[string]string `json:"links,omitempty"`
+	JSONAPI JSONAPIVersion    `json:"jsonapi"`
+}
+
+// JSONAPIVersion は準拠している JSON:API のバージョンです
+type JSONAPIVersion struct {
+	Version string `json:"version"`
+}
+
+// JSONAPIResource は JSON:API のリソースオブジェクトです
+type JSONAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]json.RawMessage     `json:"attributes"`
+	Relationships map[string]JSONAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+}
+
+// JSONAPIRelationship は関連するリソースへの参照です（関連がない場合、Data は null）
+type JSONAPIRelationship struct {
+	Data *JSONAPIResourceIdentifier `json:"data"`
+}
+
+// JSONAPIResourceIdentifier は関連するリソースの type と id です
+type JSONAPIResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// jsonapiVersion は準拠している JSON:API のバージョンです
+var jsonapiVersion = JSONAPIVersion{Version: "1.1"}
+
+// NewTodoDocument はTodo1件のレスポンスを JSON:API のドキュメントに変換します
+// apiPath はリンクの基点となるAPIのパス（ベースパスを含む、例: /todoapp/api/v1）です
+func NewTodoDocument(todo TodoResponse, apiPath string) (JSONAPIDocument, error) {
+	resource, err := newTodoResource(todo, apiPath)
+	if err != nil {
+		return JSONAPIDocument{}, err
+	}
+	document := JSONAPIDocument{Data: resource, JSONAPI: jsonapiVersion}
+	if todo.Meta != nil {
+		document.Meta = todo.Meta
+	}
+	return document, nil
+}
+
+// NewTodoListDocument はTodo一覧のレスポンスを JSON:API のドキュメントに変換します
+// ページングのリンク（first / prev / next / last）は呼び出し側が links に設定します
+func NewTodoListDocument(list TodoListResponse, apiPath string) (JSONAPIDocument, error) {
+	resources := make([]JSONAPIResource, len(list.Todos))
+	for i, todo := range list.Todos {
+		resource, err := newTodoResource(todo, apiPath)
+		if err != nil {
+			return JSONAPIDocument{}, err
+		}
+		resources[i] = resource
+	}
+	return JSONAPIDocument{Data: resources, Meta: list.Meta, JSONAPI: jsonapiVersion}, nil
+}
+
+// NewProjectDocument はプロジェクト1件のレスポンスを JSON:API のドキュメントに変換します
+// プロジェクトの url はリソースの self リンクとして返します（ベースパスは設定済みの前提）
+func NewProjectDocument(project ProjectResponse) (JSONAPIDocument, error) {
+	resource, err := newProjectResource(project)
+	if err != nil {
+		return JSONAPIDocument{}, err
+	}
+	return JSONAPIDocument{Data: resource, JSONAPI: jsonapiVersion}, nil
+}
+
+// NewProjectListDocument はプロジェクト一覧のレスポンスを JSON:API のドキュメントに変換します
+func NewProjectListDocument(list ProjectListResponse) (JSONAPIDocument, error) {
+	resources := make([]JSONAPIResource, len(list.Projects))
+	for i, project := range list.Projects {
+		resource, err := newProjectResource(project)
+		if err != nil {
+			return JSONAPIDocument{}, err
+		}
+		resources[i] = resource
+	}
+	return JSONAPIDocument{Data: resources, Meta: list.Meta, JSONAPI: jsonapiVersion}, nil
+}
+
+// newTodoResource はTodoのレスポンスDTOをリソースオブジェクトに変換します
+func newTodoResource(todo TodoResponse, apiPath string) (JSONAPIResource, error) {
+	attributes, err := jsonapiAttributes(todo, "id", "meta", "project_id", "recurrence_parent_id")
+	if err != nil {
+		return JSONAPIResource{}, err
+	}
+	id := todo.ID.String()
+	return JSONAPIResource{
+		Type:       JSONAPITypeTodos,
+		ID:         id,
+		Attributes: attributes,
+		Relationships: map[string]JSONAPIRelationship{
+			"project":           jsonapiRelationship(JSONAPITypeProjects, todo.ProjectID),
+			"recurrence_parent": jsonapiRelationship(JSONAPITypeTodos, todo.RecurrenceParentID),
+		},
+		Links: map[string]string{"self": apiPath + "/todos/" + id},
+	}, nil
+}
+
+// newProjectResource はプロジェクトのレスポンスDTOをリソースオブジェクトに変換します
+func newProjectResource(project ProjectResponse) (JSONAPIResource, error) {
+	attributes, err := jsonapiAttributes(project, "id", "url")
+	if err != nil {
+		return JSONAPIResource{}, err
+	}
+	return JSONAPIResource{
+		Type:       JSONAPITypeProjects,
+		ID:         project.ID.String(),
+		Attributes: attributes,
+		Links:      map[string]string{"self": project.URL},
+	}, nil
+}
+
+// jsonapiAttributes はDTOをJSONのオブジェクトに変換し、exclude のキーを除いたものを attributes として返します
+// DTOのJSONタグ・omitempty・Encoding の設定をそのまま反映させるため、一度JSONに変換してから分解します
+func jsonapiAttributes(response any, exclude ...string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON:API attributes: %w", err)
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON:API attributes: %w", err)
+	}
+	for _, key := range exclude {
+		delete(attributes, key)
+	}
+	return attributes, nil
+}
+
+// jsonapiRelationship は関連するリソースへの参照を返します（id が nil の場合は data: null）
+func jsonapiRelationship(resourceType string, id *ID) JSONAPIRelationship {
+	if id == nil {
+		return JSONAPIRelationship{}
+	}
+	return JSONAPIRelationship{Data: &JSONAPIResourceIdentifier{Type: resourceType, ID: id.String()}}
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// PreferencesRequest はユーザーの設定の保存（PUT /api/v1/me/preferences）のリクエストボディです
+// PUT は設定全体を置き換えるため、省略したフィールドは既定値（UTC・作成日時の新しい順・通知する）になります
+type PreferencesRequest struct {
+	// Timezone は「今日」の区切りに使うIANAタイムゾーン名（例: Asia/Tokyo）
+	Timezone string `json:"timezone"`
+
+	// SortOrder はTodo一覧の既定の並び順（-created_at / created_at / due_date / title）
+	SortOrder string `json:"sort_order"`
+
+	// Notifications は通知の設定です
+	Notifications *NotificationPreferences `json:"notifications"`
+}
+
+// NotificationPreferences は通知の設定のDTOです
+type NotificationPreferences struct {
+	// Reminders が false の場合、リマインダーの時刻になっても通知しません
+	Reminders bool `json:"reminders"`
+}
+
+// PreferencesResponse はユーザーの設定のレスポンスDTOです
+type PreferencesResponse struct {
+	Timezone      string                  `json:"timezone"`
+	SortOrder     string                  `json:"sort_order"`
+	Notifications NotificationPreferences `json:"notifications"`
+
+	// UpdatedAt は最後に保存した日時です（保存していない場合は省略）
+	UpdatedAt *Timestamp `json:"updated_at,omitempty"`
+}
+
+// ToEntity はリクエストDTOをEntityに変換します（省略したフィールドは既定値）
+func (req PreferencesRequest) ToEntity() *entity.Preferences {
+	preferences := entity.DefaultPreferences(0)
+	if req.Timezone != "" {
+		preferences.Timezone = req.Timezone
+	}
+	if req.SortOrder != "" {
+		preferences.SortOrder = entity.TodoSortOrder(req.SortOrder)
+	}
+	if req.Notifications != nil {
+		preferences.NotifyReminders = req.Notifications.Reminders
+	}
+	return preferences
+}
+
+// ToPreferencesResponse はエンティティをレスポンスDTOに変換します
+func ToPreferencesResponse(preferences *entity.Preferences) PreferencesResponse {
+	response := PreferencesResponse{
+		Timezone:      preferences.Timezone,
+		SortOrder:     string(preferences.SortOrder),
+		Notifications: NotificationPreferences{Reminders: preferences.NotifyReminders},
+	}
+	if !preferences.UpdatedAt.IsZero() {
+		response.UpdatedAt = timestampPtr(&preferences.UpdatedAt)
+	}
+	return response
+}
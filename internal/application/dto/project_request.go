@@ -0,0 +1,21 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// CreateProjectRequest はプロジェクト作成時のリクエストボディです
+// スラッグはサーバー側で名前から自動生成するため、リクエストには含めません
+type CreateProjectRequest struct {
+	// Name はプロジェクト名（必須）
+	Name string `json:"name"`
+
+	// Description はプロジェクトの説明（任意）
+	Description string `json:"description"`
+}
+
+// ToEntity はリクエストDTOをProjectエンティティに変換します
+func (req CreateProjectRequest) ToEntity() *entity.Project {
+	return &entity.Project{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ProjectResponse はプロジェクト情報のレスポンスDTOです
+type ProjectResponse struct {
+	ID          ID        `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	CreatedAt   Timestamp `json:"created_at"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+
+	// ArchivedAt はアーカイブした日時（アーカイブされていない場合は省略）
+	ArchivedAt *Timestamp `json:"archived_at,omitempty"`
+
+	// URL はスラッグを使ったプロジェクトのパス（共有リンク用）
+	URL string `json:"url"`
+}
+
+// ProjectListResponse はプロジェクト一覧のレスポンスDTOです
+type ProjectListResponse struct {
+	Projects []ProjectResponse `json:"projects"`
+	Meta     ResponseMeta      `json:"meta"`
+}
+
+// ToProjectResponse はエンティティをレスポンスDTOに変換します
+func ToProjectResponse(project *entity.Project) ProjectResponse {
+	return ProjectResponse{
+		ID:          ID(project.ID),
+		Name:        project.Name,
+		Slug:        project.Slug,
+		Description: project.Description,
+		CreatedAt:   NewTimestamp(project.CreatedAt),
+		UpdatedAt:   NewTimestamp(project.UpdatedAt),
+		ArchivedAt:  timestampPtr(project.ArchivedAt),
+		URL:         "/api/v1/projects/" + project.Slug,
+	}
+}
+
+// ToProjectListResponse はエンティティ配列を一覧レスポンスに変換します
+func ToProjectListResponse(projects []*entity.Project) ProjectListResponse {
+	responses := make([]ProjectResponse, len(projects))
+	for i, project := range projects {
+		responses[i] = ToProjectResponse(project)
+	}
+	return ProjectListResponse{
+		Projects: responses,
+		Meta:     NewResponseMeta(),
+	}
+}
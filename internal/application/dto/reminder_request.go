@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultSnoozeMinutes はスヌーズ時間が指定されなかった場合の延期時間（分）です
+const DefaultSnoozeMinutes = 10
+
+// MaxSnoozeMinutes は minutes で指定できるスヌーズ時間の上限（分）です（7日間）
+const MaxSnoozeMinutes = 7 * 24 * 60
+
+// SnoozeReminderRequest はリマインダーのスヌーズ時のリクエストボディです
+// until（延期先の日時）と minutes（現在からの延期時間）のどちらか一方を指定します
+// どちらも省略した場合は DefaultSnoozeMinutes 分後に延期します
+type SnoozeReminderRequest struct {
+	// Until は延期先の日時（RFC3339形式、任意）
+	Until *time.Time `json:"until,omitempty"`
+
+	// Minutes は現在時刻からの延期時間（分、任意）
+	Minutes *int `json:"minutes,omitempty"`
+}
+
+// ResolveUntil はリクエストから延期先の日時を決定します
+func (req SnoozeReminderRequest) ResolveUntil(now time.Time) (time.Time, error) {
+	if req.Until != nil && req.Minutes != nil {
+		return time.Time{}, errors.New("specify either until or minutes, not both")
+	}
+
+	if req.Until != nil {
+		return req.Until.UTC(), nil
+	}
+
+	minutes := DefaultSnoozeMinutes
+	if req.Minutes != nil {
+		minutes = *req.Minutes
+	}
+	if minutes <= 0 || minutes > MaxSnoozeMinutes {
+		return time.Time{}, errors.New("minutes must be between 1 and 10080")
+	}
+
+	return now.UTC().Add(time.Duration(minutes) * time.Minute), nil
+}
@@ -0,0 +1,131 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// ResourceSchema はリソースのフィールド制約を表すレスポンスDTOです
+// フロントエンドはこの情報からフォームの入力制限やエラーメッセージを組み立てられます
+type ResourceSchema struct {
+	// Resource はリソース名（todo, checklist_item 等）
+	Resource string `json:"resource"`
+
+	// SchemaVersion はAPIレスポンスのスキーマバージョン
+	SchemaVersion int `json:"schema_version"`
+
+	// Fields はフィールドごとの制約
+	Fields []FieldSchema `json:"fields"`
+}
+
+// FieldSchema は1フィールド分の制約情報です
+type FieldSchema struct {
+	// Name はJSONでのフィールド名
+	Name string `json:"name"`
+
+	// Type はJSONの型（string, boolean, integer 等）
+	Type string `json:"type"`
+
+	// Required は作成時に必須かどうか
+	Required bool `json:"required"`
+
+	// ReadOnly はサーバーが設定するため書き込みできないフィールドかどうか
+	ReadOnly bool `json:"read_only,omitempty"`
+
+	// MinLength は文字列の最小文字数（制約がない場合は省略）
+	MinLength *int `json:"min_length,omitempty"`
+
+	// MaxLength は文字列の最大文字数（制約がない場合は省略）
+	MaxLength *int `json:"max_length,omitempty"`
+
+	// Minimum・Maximum は整数の最小値・最大値（制約がない場合は省略）
+	Minimum *int `json:"minimum,omitempty"`
+	Maximum *int `json:"maximum,omitempty"`
+
+	// Enum は取り得る値の列挙（制約がない場合は省略）
+	Enum []string `json:"enum,omitempty"`
+
+	// Format は値の書式（date-time 等）
+	Format string `json:"format,omitempty"`
+
+	// Pattern は値が一致すべき正規表現です
+	// Enum と併記されている場合は、どちらか一方に一致すれば有効です
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// resourceSchemas はリソース名からスキーマ生成関数への対応表です
+var resourceSchemas = map[string]func() ResourceSchema{
+	"todo":           TodoSchema,
+	"checklist_item": ChecklistItemSchema,
+}
+
+// LookupResourceSchema はリソース名に対応するスキーマを返します
+// 未知のリソースの場合は ok=false を返します
+func LookupResourceSchema(resource string) (ResourceSchema, bool) {
+	build, ok := resourceSchemas[resource]
+	if !ok {
+		return ResourceSchema{}, false
+	}
+	return build(), true
+}
+
+// TodoSchema はTodoリソースのフィールド制約を返します
+// 制約値はエンティティの定数から導出するため、バリデーションと常に一致します
+func TodoSchema() ResourceSchema {
+	return ResourceSchema{
+		Resource:      "todo",
+		SchemaVersion: SchemaVersion,
+		Fields: []FieldSchema{
+			{Name: "id", Type: "integer", ReadOnly: true},
+			{Name: "title", Type: "string", Required: true, MinLength: intPtr(1), MaxLength: intPtr(entity.MaxTitleLength)},
+			{Name: "description", Type: "string", MaxLength: intPtr(entity.MaxDescriptionLength)},
+			{Name: "is_completed", Type: "boolean"},
+			{Name: "created_at", Type: "string", Format: "date-time", ReadOnly: true},
+			{Name: "updated_at", Type: "string", Format: "date-time", ReadOnly: true},
+			{Name: "checklist_progress", Type: "object", ReadOnly: true},
+			{Name: "remind_at", Type: "string", Format: "date-time"},
+			{Name: "due_date", Type: "string", Format: "date-time"},
+			{Name: "recurrence", Type: "string", Enum: recurrenceNames()},
+			{Name: "recurrence_parent_id", Type: "integer", ReadOnly: true},
+			{Name: "color", Type: "string", Enum: colorNames(), Pattern: entity.ColorHexPattern},
+			{Name: "estimate_minutes", Type: "integer", Minimum: intPtr(0), Maximum: intPtr(entity.MaxEstimateMinutes)},
+			{Name: "actual_minutes", Type: "integer", Minimum: intPtr(0), Maximum: intPtr(entity.MaxEstimateMinutes)},
+		},
+	}
+}
+
+// recurrenceNames は指定可能な繰り返し規則の一覧を文字列で返します
+func recurrenceNames() []string {
+	names := make([]string, len(entity.Recurrences))
+	for i, recurrence := range entity.Recurrences {
+		names[i] = string(recurrence)
+	}
+	return names
+}
+
+// colorNames は名前で指定できる色の一覧を文字列で返します
+func colorNames() []string {
+	names := make([]string, len(entity.ColorPalette))
+	for i, color := range entity.ColorPalette {
+		names[i] = string(color)
+	}
+	return names
+}
+
+// ChecklistItemSchema はチェックリスト項目リソースのフィールド制約を返します
+func ChecklistItemSchema() ResourceSchema {
+	return ResourceSchema{
+		Resource:      "checklist_item",
+		SchemaVersion: SchemaVersion,
+		Fields: []FieldSchema{
+			{Name: "id", Type: "integer", ReadOnly: true},
+			{Name: "todo_id", Type: "integer", ReadOnly: true},
+			{Name: "text", Type: "string", Required: true, MinLength: intPtr(1), MaxLength: intPtr(entity.MaxChecklistTextLength)},
+			{Name: "is_done", Type: "boolean"},
+			{Name: "created_at", Type: "string", Format: "date-time", ReadOnly: true},
+			{Name: "updated_at", Type: "string", Format: "date-time", ReadOnly: true},
+		},
+	}
+}
+
+// intPtr は整数値のポインタを返すヘルパー関数です
+func intPtr(i int) *int {
+	return &i
+}
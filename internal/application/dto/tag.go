@@ -0,0 +1,53 @@
+package dto
+
+// BulkTagRequest は一括タグ操作（POST/DELETE /api/v1/tags/{tag}/todos）のリクエストボディです
+// 対象のTodoは ids または filter のどちらか一方で指定します
+type BulkTagRequest struct {
+	// IDs は対象のTodoのID（最大 MaxBatchSize 件）
+	IDs []ID `json:"ids,omitempty"`
+
+	// Filter は対象のTodoの条件（一致する全てのTodoが対象）
+	Filter *TodoFilterRequest `json:"filter,omitempty"`
+}
+
+// TodoFilterRequest は一括操作の対象を選ぶ条件です
+// 指定した条件を全て満たすTodoが対象になります（1つ以上の指定が必要）
+type TodoFilterRequest struct {
+	// Color は色（パレットの名前または16進カラーコード）
+	Color string `json:"color,omitempty"`
+
+	// IsCompleted は完了状態
+	IsCompleted *bool `json:"is_completed,omitempty"`
+
+	// Tag は付いているタグ
+	Tag string `json:"tag,omitempty"`
+}
+
+// MergeTagsRequest はタグの統合（POST /api/v1/tags/merge）のリクエストボディです
+type MergeTagsRequest struct {
+	// Source は統合元のタグ（統合後はどのTodoにも残りません）
+	Source string `json:"source"`
+
+	// Target は統合先のタグ
+	Target string `json:"target"`
+}
+
+// BulkTagResponse は一括タグ操作のレスポンスDTOです
+type BulkTagResponse struct {
+	// Tag は追加・削除したタグ、または統合先のタグ（正規化済み）
+	Tag string `json:"tag"`
+
+	// MergedFrom は統合元のタグ（統合の場合のみ）
+	MergedFrom string `json:"merged_from,omitempty"`
+
+	// Matched は対象として選ばれたTodoの数（変更がなかったものを含む）
+	Matched int `json:"matched"`
+
+	// Updated はタグが変わったTodoの数
+	Updated int `json:"updated"`
+
+	// Todos はタグが変わったTodo
+	Todos []TodoResponse `json:"todos"`
+
+	Meta ResponseMeta `json:"meta"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+// MaxBatchSize は一括操作で1回のリクエストに含められる項目数の上限です
+const MaxBatchSize = 100
+
+// BatchUpdateTodoItem は一括更新（PATCH /api/v1/todos）の1件分のリクエストです
+// id で対象を指定し、それ以外のフィールドは UpdateTodoRequest と同じ部分更新です
+type BatchUpdateTodoItem struct {
+	// ID は更新するTodoのID（必須）
+	ID ID `json:"id"`
+
+	UpdateTodoRequest
+}
+
+// BatchItemResult は一括操作の1件分の結果です
+// 成功した項目は Todo を、失敗した項目は Error を持ちます
+type BatchItemResult struct {
+	// ID はリクエストで指定されたTodoのID
+	ID ID `json:"id"`
+
+	// Status はこの項目の結果を表すHTTPステータスコード
+	// （200: 成功、400/404/409: この項目の失敗、424: 他の項目の失敗により未適用）
+	Status int `json:"status"`
+
+	// Todo は更新後のTodo（成功した場合のみ）
+	Todo *TodoResponse `json:"todo,omitempty"`
+
+	// NotModified は値が何も変わらず、保存しなかった項目であることを表します
+	NotModified bool `json:"not_modified,omitempty"`
+
+	// Error は失敗の理由（失敗した場合のみ）
+	Error string `json:"error,omitempty"`
+}
+
+// BatchResultResponse は一括操作のレスポンスDTOです
+// Results はリクエストと同じ順序で並びます
+type BatchResultResponse struct {
+	Results []BatchItemResult `json:"results"`
+
+	Meta ResponseMeta `json:"meta"`
+}
+
+// NewBatchResultResponse は項目ごとの結果をレスポンスDTOにまとめます
+func NewBatchResultResponse(results []BatchItemResult) BatchResultResponse {
+	return BatchResultResponse{
+		Results: results,
+		Meta:    NewResponseMeta(),
+	}
+}
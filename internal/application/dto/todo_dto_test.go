@@ -2,10 +2,12 @@ package dto
 
 import (
 	"encoding/json"
+	"net/url"
 	"testing"
 	"time"
 
 	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/pkg/hashids"
 )
 
 // TestCreateTodoRequest_ToEntity はCreateRequestのエンティティ変換をテストします
@@ -197,8 +199,8 @@ func TestToTodoResponse(t *testing.T) {
 				Title:       "テストタスク",
 				Description: "説明文",
 				IsCompleted: true,
-				CreatedAt:   fixedTime,
-				UpdatedAt:   fixedTime.Add(1 * time.Hour),
+				CreatedAt:   NewTimestamp(fixedTime),
+				UpdatedAt:   NewTimestamp(fixedTime.Add(1 * time.Hour)),
 			},
 		},
 		{
@@ -216,8 +218,8 @@ func TestToTodoResponse(t *testing.T) {
 				Title:       "未完了タスク",
 				Description: "",
 				IsCompleted: false,
-				CreatedAt:   fixedTime,
-				UpdatedAt:   fixedTime,
+				CreatedAt:   NewTimestamp(fixedTime),
+				UpdatedAt:   NewTimestamp(fixedTime),
 			},
 		},
 	}
@@ -242,11 +244,11 @@ func TestToTodoResponse(t *testing.T) {
 				t.Errorf("完了状態 = %v, 期待値 = %v", got.IsCompleted, tt.want.IsCompleted)
 			}
 
-			if !got.CreatedAt.Equal(tt.want.CreatedAt) {
+			if !got.CreatedAt.Equal(tt.want.CreatedAt.Time) {
 				t.Errorf("作成日時 = %v, 期待値 = %v", got.CreatedAt, tt.want.CreatedAt)
 			}
 
-			if !got.UpdatedAt.Equal(tt.want.UpdatedAt) {
+			if !got.UpdatedAt.Equal(tt.want.UpdatedAt.Time) {
 				t.Errorf("更新日時 = %v, 期待値 = %v", got.UpdatedAt, tt.want.UpdatedAt)
 			}
 		})
@@ -262,8 +264,8 @@ func TestTodoResponse_JSONSerialization(t *testing.T) {
 		Title:       "テストタスク",
 		Description: "説明文",
 		IsCompleted: true,
-		CreatedAt:   fixedTime,
-		UpdatedAt:   fixedTime,
+		CreatedAt:   NewTimestamp(fixedTime),
+		UpdatedAt:   NewTimestamp(fixedTime),
 	}
 
 	// JSONにシリアライズ
@@ -305,6 +307,151 @@ func TestTodoResponse_JSONSerialization(t *testing.T) {
 	}
 }
 
+// TestToTodoListResponse_Meta は一覧レスポンスのメタ情報をテストします
+func TestToTodoListResponse_Meta(t *testing.T) {
+	fixedTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("JST", 9*60*60))
+
+	// サーバー時刻を固定（テスト終了時に元に戻す）
+	originalNow := Now
+	Now = func() time.Time { return fixedTime }
+	defer func() { Now = originalNow }()
+
+	response := ToTodoListResponse([]*entity.Todo{{ID: 1, Title: "タスク"}}, 1, 10, 1)
+
+	if !response.Meta.ServerTime.Equal(fixedTime) {
+		t.Errorf("サーバー時刻 = %v, 期待値 = %v", response.Meta.ServerTime, fixedTime)
+	}
+	if response.Meta.ServerTime.Location() != time.UTC {
+		t.Errorf("サーバー時刻はUTCであるべきです: %v", response.Meta.ServerTime.Location())
+	}
+	if response.Meta.SchemaVersion != SchemaVersion {
+		t.Errorf("スキーマバージョン = %v, 期待値 = %v", response.Meta.SchemaVersion, SchemaVersion)
+	}
+
+	// 埋め込みフィールドがmetaの同じ階層に展開されることを確認
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("JSONシリアライゼーションに失敗: %v", err)
+	}
+	for _, field := range []string{`"server_time":"2024-01-01T18:04:05Z"`, `"schema_version":1`, `"total":1`} {
+		if !contains(string(jsonData), field) {
+			t.Errorf("JSONに期待されるフィールドが含まれていません: %s", field)
+		}
+	}
+}
+
+// TestTodoFields はスパースフィールドセット（?fields=）の解析と射影をテストします
+func TestTodoFields(t *testing.T) {
+	todo := TodoResponse{ID: 1, Title: "牛乳を買う", Description: "2本", IsCompleted: true, Tags: []string{"買い物"}}
+
+	tests := []struct {
+		name         string
+		raw          string
+		expectedJSON string // 空の場合は全フィールド（nil）を期待
+		expectedErr  string // 空の場合は成功を期待
+	}{
+		{name: "指定なし", raw: ""},
+		{name: "定義順に並べる", raw: "is_completed,title,id", expectedJSON: `{"id":1,"title":"牛乳を買う","is_completed":true}`},
+		{name: "空白と重複は無視する", raw: " tags , tags,", expectedJSON: `{"tags":["買い物"]}`},
+		{name: "値が空のフィールドは省略する", raw: "id,due_date", expectedJSON: `{"id":1}`},
+		{name: "未知のフィールド", raw: "id,secret", expectedErr: `unknown field "secret"`},
+		{name: "meta は指定できない", raw: "meta", expectedErr: `unknown field "meta"`},
+		{name: "フィールド名がない", raw: ",", expectedErr: "fields must name at least one field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := ParseTodoFields(tt.raw)
+			if tt.expectedErr != "" {
+				if err == nil || !contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("エラー = %v, 期待値 = %q を含むエラー", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if tt.expectedJSON == "" {
+				if fields != nil {
+					t.Errorf("fields = %v, 期待値 = nil", fields)
+				}
+				return
+			}
+
+			projected, err := fields.Project(todo)
+			if err != nil {
+				t.Fatalf("射影に失敗: %v", err)
+			}
+			if string(projected) != tt.expectedJSON {
+				t.Errorf("Project() = %s, 期待値 = %s", projected, tt.expectedJSON)
+			}
+		})
+	}
+
+	// 一覧では各Todoを射影し、meta はそのまま残す
+	fields, _ := ParseTodoFields("title")
+	list, err := fields.ProjectList(ToTodoListResponse([]*entity.Todo{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}}, 1, 10, 2))
+	if err != nil {
+		t.Fatalf("一覧の射影に失敗: %v", err)
+	}
+	if len(list.Todos) != 2 || string(list.Todos[1]) != `{"title":"b"}` || list.Meta.Total != 2 {
+		t.Errorf("ProjectList() = %s / meta = %+v", list.Todos, list.Meta)
+	}
+}
+
+// TestUpdateTodoRequestFromForm はフォームの値からの更新リクエスト組み立てをテストします
+func TestUpdateTodoRequestFromForm(t *testing.T) {
+	tests := []struct {
+		name          string
+		form          string
+		wantErr       bool
+		wantTitle     *string
+		wantCompleted *bool
+		wantRemindAt  *time.Time
+	}{
+		{name: "送信したフィールドのみ設定", form: "title=買い物", wantTitle: stringPtr("買い物")},
+		{name: "チェックボックスのon", form: "is_completed=on", wantCompleted: boolPtr(true)},
+		{name: "falseの指定", form: "is_completed=false", wantCompleted: boolPtr(false)},
+		{name: "不正な真偽値", form: "is_completed=maybe", wantErr: true},
+		{name: "datetime-local形式の日時", form: "remind_at=2024-05-01T09:30", wantRemindAt: timePtr(time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC))},
+		{name: "RFC3339形式の日時", form: "remind_at=2024-05-01T18:30:00%2B09:00", wantRemindAt: timePtr(time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC))},
+		{name: "不正な日時", form: "remind_at=tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.form)
+			if err != nil {
+				t.Fatalf("フォームのパースに失敗: %v", err)
+			}
+
+			req, err := UpdateTodoRequestFromForm(values)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("エラーが期待されましたが、発生しませんでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラーが発生しました: %v", err)
+			}
+
+			if (req.Title == nil) != (tt.wantTitle == nil) || (req.Title != nil && *req.Title != *tt.wantTitle) {
+				t.Errorf("Title = %v, 期待値 = %v", req.Title, tt.wantTitle)
+			}
+			if req.Description != nil {
+				t.Errorf("未送信のDescriptionが設定されています: %v", *req.Description)
+			}
+			if (req.IsCompleted == nil) != (tt.wantCompleted == nil) || (req.IsCompleted != nil && *req.IsCompleted != *tt.wantCompleted) {
+				t.Errorf("IsCompleted = %v, 期待値 = %v", req.IsCompleted, tt.wantCompleted)
+			}
+			if (req.RemindAt == nil) != (tt.wantRemindAt == nil) || (req.RemindAt != nil && !req.RemindAt.Equal(*tt.wantRemindAt)) {
+				t.Errorf("RemindAt = %v, 期待値 = %v", req.RemindAt, tt.wantRemindAt)
+			}
+		})
+	}
+}
+
 // TestCreateTodoRequest_JSONDeserialization はリクエストのJSONデシリアライゼーションをテストします
 func TestCreateTodoRequest_JSONDeserialization(t *testing.T) {
 	tests := []struct {
@@ -430,6 +577,11 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+// timePtr はtime.Time値のポインタを返すヘルパー関数です
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
 // generateLongString は指定された長さの文字列を生成するヘルパー関数です
 func generateLongString(length int) string {
 	result := ""
@@ -483,3 +635,125 @@ func indexOf(s, substr string) int {
 //    - リクエスト/レスポンス構造の確認
 //    - フィールド名とタイプの検証
 //    - エラーレスポンスの一貫性
+
+// TestEncodingOptions はレスポンスの日時の形式とIDの型の切り替えをテストします
+func TestEncodingOptions(t *testing.T) {
+	fixedTime := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	parentID := 7
+	response := ToTodoResponse(&entity.Todo{ID: 42, Title: "タスク", CreatedAt: fixedTime, UpdatedAt: fixedTime, DueDate: &fixedTime, RecurrenceParentID: &parentID})
+
+	// テスト終了時に設定を元に戻す
+	originalEncoding := Encoding
+	defer func() { Encoding = originalEncoding }()
+
+	tests := []struct {
+		name           string
+		encoding       EncodingOptions
+		expectedFields []string
+	}{
+		{
+			name:           "デフォルト",
+			encoding:       EncodingOptions{TimeFormat: TimeFormatRFC3339},
+			expectedFields: []string{`"id":42`, `"created_at":"2024-05-01T09:30:00Z"`, `"due_date":"2024-05-01T09:30:00Z"`, `"recurrence_parent_id":7`},
+		},
+		{
+			name:           "エポック秒",
+			encoding:       EncodingOptions{TimeFormat: TimeFormatEpochSeconds},
+			expectedFields: []string{`"created_at":1714555800`, `"due_date":1714555800`},
+		},
+		{
+			name:           "エポックミリ秒と文字列のID",
+			encoding:       EncodingOptions{TimeFormat: TimeFormatEpochMillis, StringIDs: true},
+			expectedFields: []string{`"id":"42"`, `"created_at":1714555800000`, `"recurrence_parent_id":"7"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Encoding = tt.encoding
+
+			jsonData, err := json.Marshal(response)
+			if err != nil {
+				t.Fatalf("JSONシリアライゼーションに失敗: %v", err)
+			}
+			for _, field := range tt.expectedFields {
+				if !contains(string(jsonData), field) {
+					t.Errorf("JSONに期待されるフィールドが含まれていません: %s\n%s", field, jsonData)
+				}
+			}
+
+			// どの形式でもデシリアライズで元に戻る
+			var deserialized TodoResponse
+			if err := json.Unmarshal(jsonData, &deserialized); err != nil {
+				t.Fatalf("JSONデシリアライゼーションに失敗: %v", err)
+			}
+			if deserialized.ID != 42 || !deserialized.CreatedAt.Equal(fixedTime) || *deserialized.RecurrenceParentID != 7 {
+				t.Errorf("デシリアライズ結果 = %+v", deserialized)
+			}
+		})
+	}
+}
+
+// TestIDCodec はIDを不透明な文字列で公開する場合の変換をテストします
+func TestIDCodec(t *testing.T) {
+	codec, err := hashids.New("todoapp", 8)
+	if err != nil {
+		t.Fatalf("hashids.New() error = %v", err)
+	}
+	encoded, _ := codec.Encode(42)
+
+	originalEncoding := Encoding
+	defer func() { Encoding = originalEncoding }()
+	Encoding = EncodingOptions{TimeFormat: TimeFormatRFC3339, IDCodec: codec}
+
+	if got := FormatID(42); got != encoded {
+		t.Errorf("FormatID(42) = %q, 期待値 = %q", got, encoded)
+	}
+	if got := ID(42).String(); got != encoded {
+		t.Errorf("ID.String() = %q, 期待値 = %q", got, encoded)
+	}
+	if id, err := ParseID(encoded); err != nil || id != 42 {
+		t.Errorf("ParseID(%q) = %d, %v, 期待値 = 42", encoded, id, err)
+	}
+	if _, err := ParseID("42"); err == nil {
+		t.Error("連番の整数のIDを受け付けてはいけません")
+	}
+
+	jsonData, err := json.Marshal(ToTodoResponse(&entity.Todo{ID: 42, Title: "タスク"}))
+	if err != nil {
+		t.Fatalf("JSONシリアライゼーションに失敗: %v", err)
+	}
+	if !contains(string(jsonData), `"id":"`+encoded+`"`) {
+		t.Errorf("IDが変換されていません: %s", jsonData)
+	}
+
+	// リクエストのIDも変換後の文字列で受け付ける（0 は「指定なし」）
+	projectID := 42
+	tests := []struct {
+		name      string
+		body      string
+		expected  *int
+		expectErr bool
+	}{
+		{name: "変換後の文字列", body: `{"title":"a","project_id":"` + encoded + `"}`, expected: &projectID},
+		{name: "0はプロジェクトから外す", body: `{"title":"a","project_id":0}`, expected: nil},
+		{name: "整数のID", body: `{"title":"a","project_id":42}`, expectErr: true},
+		{name: "不正な文字列", body: `{"title":"a","project_id":"xxxxxxxx"}`, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req CreateTodoRequest
+			err := json.Unmarshal([]byte(tt.body), &req)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("json.Unmarshal() error = %v, エラーを期待 = %v", err, tt.expectErr)
+			}
+			if err != nil {
+				return
+			}
+			todo := req.ToEntity()
+			if (todo.ProjectID == nil) != (tt.expected == nil) || (todo.ProjectID != nil && *todo.ProjectID != *tt.expected) {
+				t.Errorf("ProjectID = %v, 期待値 = %v", todo.ProjectID, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// TodoFields は ?fields= で指定された、レスポンスに含めるTodoのフィールドです（スパースフィールドセット）
+//
+// 学習ポイント：
+//  1. 一覧で必要な項目だけを返すと、通信量とクライアントの処理を減らせる
+//  2. 射影は TodoResponse をJSONにした後で行う（IDの難読化や日時の形式など、通常のレスポンスと同じ変換を保つ）
+//  3. 未知のフィールド名はエラーにする（綴りの誤りで項目が黙って欠けることを防ぐ）
+//
+// フィールドは TodoResponse の定義順に並びます。nil の場合は全フィールドを返します
+// 値が空のため通常のレスポンスで省略されるフィールド（due_date 等）は、指定しても省略されます
+type TodoFields []string
+
+// todoFieldNames は指定できるフィールド名（TodoResponse のJSONのキー）を定義順に並べたものです
+var todoFieldNames = jsonFieldNames(reflect.TypeOf(TodoResponse{}), "meta")
+
+// ParseTodoFields はカンマ区切りのフィールド名（"id,title,is_completed"）を解析します
+// 空の場合は nil（全フィールド）を返し、未知のフィールド名の場合はエラーを返します
+func ParseTodoFields(raw string) (TodoFields, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	requested := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(todoFieldNames, name) {
+			return nil, fmt.Errorf("unknown field %q (must be one of %s)", name, strings.Join(todoFieldNames, ", "))
+		}
+		requested[name] = true
+	}
+	if len(requested) == 0 {
+		return nil, errors.New("fields must name at least one field")
+	}
+
+	fields := make(TodoFields, 0, len(requested))
+	for _, name := range todoFieldNames {
+		if requested[name] {
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+// Project はTodoのうち、指定されたフィールドだけを含むJSONオブジェクトを作成します
+func (f TodoFields) Project(todo TodoResponse) (json.RawMessage, error) {
+	data, err := json.Marshal(todo)
+	if err != nil || f == nil {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, name := range f {
+		value, ok := all[name]
+		if !ok {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// PartialTodoListResponse は各Todoを射影した一覧のレスポンスDTOです
+type PartialTodoListResponse struct {
+	Todos []json.RawMessage `json:"todos"`
+	Meta  ListMetaResponse  `json:"meta"`
+}
+
+// ProjectList は一覧の各Todoを射影したレスポンスを作成します（meta はそのまま含めます）
+func (f TodoFields) ProjectList(response TodoListResponse) (PartialTodoListResponse, error) {
+	todos := make([]json.RawMessage, len(response.Todos))
+	for i, todo := range response.Todos {
+		projected, err := f.Project(todo)
+		if err != nil {
+			return PartialTodoListResponse{}, err
+		}
+		todos[i] = projected
+	}
+	return PartialTodoListResponse{Todos: todos, Meta: response.Meta}, nil
+}
+
+// jsonFieldNames は構造体のJSONのキーを定義順に返します（exclude に指定したキーと "-" は除きます）
+func jsonFieldNames(t reflect.Type, exclude ...string) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || slices.Contains(exclude, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
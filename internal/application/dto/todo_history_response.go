@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoHistoryEntryResponse は変更履歴1件のレスポンスDTOです
+// 変更前後のスナップショットは、通常のTodoのレスポンスと同じ形式で返します
+type TodoHistoryEntryResponse struct {
+	ID     ID     `json:"id"`
+	TodoID ID     `json:"todo_id"`
+	Action string `json:"action"`
+	Actor  string `json:"actor"`
+
+	// Before は変更前のTodo（作成の場合は null）
+	Before *TodoResponse `json:"before"`
+
+	// After は変更後のTodo（削除の場合は null）
+	After *TodoResponse `json:"after"`
+
+	// ChangedFields は変更前後で値が異なるフィールド（作成・削除の場合は空）
+	// スナップショット全体を比較しなくても、何が変わったかを確認できます
+	ChangedFields []string `json:"changed_fields"`
+
+	ChangedAt Timestamp `json:"changed_at"`
+}
+
+// TodoHistoryResponse はTodoの変更履歴一覧のレスポンスDTOです
+type TodoHistoryResponse struct {
+	// History は変更履歴（古い順）
+	History []TodoHistoryEntryResponse `json:"history"`
+
+	// Meta はメタ情報
+	Meta ResponseMeta `json:"meta"`
+}
+
+// ToTodoHistoryResponse は変更履歴の配列をレスポンスDTOに変換します
+func ToTodoHistoryResponse(entries []*entity.TodoHistoryEntry) TodoHistoryResponse {
+	history := make([]TodoHistoryEntryResponse, len(entries))
+	for i, entry := range entries {
+		history[i] = TodoHistoryEntryResponse{
+			ID:            ID(entry.ID),
+			TodoID:        ID(entry.TodoID),
+			Action:        string(entry.Action),
+			Actor:         entry.Actor,
+			Before:        toTodoResponsePtr(entry.Before),
+			After:         toTodoResponsePtr(entry.After),
+			ChangedFields: changedFields(entry.Before, entry.After),
+			ChangedAt:     NewTimestamp(entry.ChangedAt),
+		}
+	}
+
+	return TodoHistoryResponse{
+		History: history,
+		Meta:    NewResponseMeta(),
+	}
+}
+
+// changedFields は変更前後のスナップショットの差分のフィールド名を返します
+// どちらかがない（作成・削除）場合は空配列です
+func changedFields(before, after *entity.Todo) []string {
+	fields := make([]string, 0)
+	if before == nil || after == nil {
+		return fields
+	}
+	for _, field := range entity.DiffTodo(before, after) {
+		fields = append(fields, string(field))
+	}
+	return fields
+}
+
+// toTodoResponsePtr はスナップショットをレスポンスDTOに変換します（nil の場合は nil）
+func toTodoResponsePtr(todo *entity.Todo) *TodoResponse {
+	if todo == nil {
+		return nil
+	}
+	response := ToTodoResponse(todo)
+	return &response
+}
@@ -1,5 +1,13 @@
 package dto
 
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // CreateTodoRequest はTodo作成時のHTTPリクエストボディを表すDTO（Data Transfer Object）です
 // DTOの役割：
 // 1. HTTPリクエスト/レスポンスの構造を定義
@@ -15,6 +23,28 @@ type CreateTodoRequest struct {
 	// Description はTodoの詳細説明（任意項目）
 	// 長さ制限などのバリデーションは実装層で手動実装します
 	Description string `json:"description"`
+
+	// RemindAt はリマインダーの通知日時（任意、RFC3339形式）
+	RemindAt *time.Time `json:"remind_at,omitempty"`
+
+	// DueDate は期限日時（任意、RFC3339形式）
+	DueDate *time.Time `json:"due_date,omitempty"`
+
+	// Recurrence は繰り返し規則（任意、daily / weekly / monthly）
+	// 指定する場合は due_date も必須です
+	Recurrence string `json:"recurrence,omitempty"`
+
+	// Color はカードの色（任意、パレットの名前または "#rrggbb" 形式）
+	Color string `json:"color,omitempty"`
+
+	// EstimateMinutes は見積もり時間（任意、分単位）
+	EstimateMinutes int `json:"estimate_minutes,omitempty"`
+
+	// ActualMinutes は実際にかかった時間（任意、分単位）
+	ActualMinutes int `json:"actual_minutes,omitempty"`
+
+	// ProjectID は所属するプロジェクトのID（任意、アーカイブ済みのプロジェクトは指定できません）
+	ProjectID *ID `json:"project_id,omitempty"`
 }
 
 // UpdateTodoRequest はTodo更新時のHTTPリクエストボディを表すDTOです
@@ -33,6 +63,40 @@ type UpdateTodoRequest struct {
 	// IsCompleted の更新（任意）
 	// bool のポインタ型で、完了状態の変更を任意にします
 	IsCompleted *bool `json:"is_completed,omitempty"`
+
+	// RemindAt の更新（任意）
+	// リマインダーの解除は DELETE /api/v1/todos/{id}/reminder で行います
+	RemindAt *time.Time `json:"remind_at,omitempty"`
+
+	// DueDate の更新（任意）
+	DueDate *time.Time `json:"due_date,omitempty"`
+
+	// Recurrence の更新（任意）
+	// 空文字を送信すると繰り返しを解除します（作成済みのオカレンスは残ります）
+	Recurrence *string `json:"recurrence,omitempty"`
+
+	// Color の更新（任意）
+	// 空文字を送信すると色を解除します
+	Color *string `json:"color,omitempty"`
+
+	// EstimateMinutes・ActualMinutes の更新（任意）
+	// 0 を送信すると未設定に戻します
+	EstimateMinutes *int `json:"estimate_minutes,omitempty"`
+	ActualMinutes   *int `json:"actual_minutes,omitempty"`
+
+	// ProjectID の更新（任意）
+	// 0 を送信するとプロジェクトから外します
+	ProjectID *ID `json:"project_id,omitempty"`
+}
+
+// DuplicateTodoRequest はTodo複製時のHTTPリクエストボディを表すDTOです
+// ボディは省略可能で、省略した場合は元のタイトルのまま、チェックリストなしで複製します
+type DuplicateTodoRequest struct {
+	// Title は複製のタイトル（任意、省略時は元のタイトル）
+	Title *string `json:"title,omitempty"`
+
+	// IncludeChecklist はチェックリスト項目もコピーするかどうか（任意、既定は false）
+	IncludeChecklist bool `json:"include_checklist"`
 }
 
 // CompleteTodoRequest はTodo完了/未完了切り替え専用のリクエストです
@@ -67,6 +131,173 @@ type TodoListRequest struct {
 	SortOrder string `json:"sort_order"`
 }
 
+// --- フォーム（application/x-www-form-urlencoded）からの変換 ---
+// HTMLの<form>やHTMXから送信されたリクエストを、JSONと同じDTOに変換します
+// フォームのフィールド名はJSONのキー名と同じです
+
+// datetimeLocalLayout は <input type="datetime-local"> が送信する日時の書式です
+// タイムゾーンを含まないため、UTCとして解釈します
+const datetimeLocalLayout = "2006-01-02T15:04"
+
+// CreateTodoRequestFromForm はフォームの値から作成リクエストを組み立てます
+func CreateTodoRequestFromForm(values url.Values) (CreateTodoRequest, error) {
+	req := CreateTodoRequest{
+		Title:       values.Get("title"),
+		Description: values.Get("description"),
+		Recurrence:  values.Get("recurrence"),
+		Color:       values.Get("color"),
+	}
+
+	remindAt, err := formTime(values, "remind_at")
+	if err != nil {
+		return CreateTodoRequest{}, err
+	}
+	req.RemindAt = remindAt
+
+	dueDate, err := formTime(values, "due_date")
+	if err != nil {
+		return CreateTodoRequest{}, err
+	}
+	req.DueDate = dueDate
+
+	if req.EstimateMinutes, err = formInt(values, "estimate_minutes"); err != nil {
+		return CreateTodoRequest{}, err
+	}
+	if req.ActualMinutes, err = formInt(values, "actual_minutes"); err != nil {
+		return CreateTodoRequest{}, err
+	}
+	projectID, err := formID(values, "project_id")
+	if err != nil {
+		return CreateTodoRequest{}, err
+	}
+	if projectID != 0 {
+		req.ProjectID = &projectID
+	}
+
+	return req, nil
+}
+
+// UpdateTodoRequestFromForm はフォームの値から更新リクエストを組み立てます
+// 送信されたフィールドのみを設定するため、JSONと同様に部分更新になります
+func UpdateTodoRequestFromForm(values url.Values) (UpdateTodoRequest, error) {
+	var req UpdateTodoRequest
+
+	if _, ok := values["title"]; ok {
+		title := values.Get("title")
+		req.Title = &title
+	}
+	if _, ok := values["description"]; ok {
+		description := values.Get("description")
+		req.Description = &description
+	}
+	if _, ok := values["is_completed"]; ok {
+		isCompleted, err := formBool(values.Get("is_completed"))
+		if err != nil {
+			return UpdateTodoRequest{}, fmt.Errorf("is_completed: %w", err)
+		}
+		req.IsCompleted = &isCompleted
+	}
+	if _, ok := values["recurrence"]; ok {
+		recurrence := values.Get("recurrence")
+		req.Recurrence = &recurrence
+	}
+	if _, ok := values["color"]; ok {
+		color := values.Get("color")
+		req.Color = &color
+	}
+
+	remindAt, err := formTime(values, "remind_at")
+	if err != nil {
+		return UpdateTodoRequest{}, err
+	}
+	req.RemindAt = remindAt
+
+	dueDate, err := formTime(values, "due_date")
+	if err != nil {
+		return UpdateTodoRequest{}, err
+	}
+	req.DueDate = dueDate
+
+	if _, ok := values["project_id"]; ok {
+		projectID, err := formID(values, "project_id")
+		if err != nil {
+			return UpdateTodoRequest{}, err
+		}
+		req.ProjectID = &projectID
+	}
+	for key, dest := range map[string]**int{"estimate_minutes": &req.EstimateMinutes, "actual_minutes": &req.ActualMinutes} {
+		if _, ok := values[key]; !ok {
+			continue
+		}
+		value, err := formInt(values, key)
+		if err != nil {
+			return UpdateTodoRequest{}, err
+		}
+		*dest = &value
+	}
+
+	return req, nil
+}
+
+// formInt はフォームの整数フィールドを解釈します
+// 未送信または空文字の場合は 0 を返します
+func formInt(values url.Values, key string) (int, error) {
+	value := strings.TrimSpace(values.Get(key))
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid integer value %q", key, value)
+	}
+	return i, nil
+}
+
+// formID はフォームのIDフィールドを解釈します（公開する形式のID、dto.ParseID を参照）
+// 未送信・空文字・"0" の場合は 0（指定なし）を返します
+func formID(values url.Values, key string) (ID, error) {
+	value := strings.TrimSpace(values.Get(key))
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	id, err := ParseID(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid id %q", key, value)
+	}
+	return ID(id), nil
+}
+
+// formBool はフォームの真偽値を解釈します
+// チェックボックスが送信する "on" も true として扱います
+func formBool(value string) (bool, error) {
+	if strings.EqualFold(value, "on") {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean value %q", value)
+	}
+	return b, nil
+}
+
+// formTime はフォームの日時フィールドを解釈します
+// 未送信または空文字の場合は nil を返します
+// RFC3339 と datetime-local の書式を受け付けます
+func formTime(values url.Values, key string) (*time.Time, error) {
+	value := strings.TrimSpace(values.Get(key))
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, datetimeLocalLayout} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: invalid date-time value %q", key, value)
+}
+
 // 標準パッケージでのDTO設計の学習ポイント：
 //
 // 1. 構造体タグ：
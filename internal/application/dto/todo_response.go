@@ -6,6 +6,15 @@ import (
 	"todoapp-api-golang/internal/domain/entity"
 )
 
+// SchemaVersion はAPIレスポンスのスキーマバージョンです
+// レスポンス構造に互換性のない変更を加えた場合にインクリメントします
+// オフライン対応クライアントはこの値でキャッシュの移行要否を判断できます
+const SchemaVersion = 1
+
+// Now はメタ情報に記録するサーバー時刻の取得関数です
+// テストで時刻を固定できるよう変数として定義しています
+var Now = time.Now
+
 // TodoResponse はTodo情報をクライアントに返すためのレスポンスDTOです
 // レスポンスDTOの役割：
 // 1. 外部に公開する情報の制御（セキュリティ）
@@ -14,22 +23,58 @@ import (
 // 4. 内部実装の隠蔽（エンティティの変更がAPIに影響しないようにする）
 type TodoResponse struct {
 	// ID はTodoの一意識別子
-	ID int `json:"id"`
+	ID ID `json:"id"`
 
 	// Title はTodoのタイトル
 	Title string `json:"title"`
 
-	// Description はTodoの詳細説明
+	// Description はTodoの詳細説明（Markdown）
 	Description string `json:"description"`
 
+	// DescriptionHTML は説明をサニタイズ済みのHTMLに変換したもの（?render=html の場合のみ、説明が空の場合は省略）
+	DescriptionHTML string `json:"description_html,omitempty"`
+
 	// IsCompleted はTodoの完了状態
 	IsCompleted bool `json:"is_completed"`
 
-	// CreatedAt は作成日時（RFC3339形式でJSONシリアライズ）
-	CreatedAt time.Time `json:"created_at"`
+	// CreatedAt は作成日時（既定はRFC3339形式、Encoding.TimeFormat で変更可能）
+	CreatedAt Timestamp `json:"created_at"`
 
 	// UpdatedAt は最終更新日時
-	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	// ChecklistProgress はチェックリストの進捗（項目がない場合は 0/0）
+	ChecklistProgress ChecklistProgressResponse `json:"checklist_progress"`
+
+	// RemindAt はリマインダーの通知日時（未設定の場合は省略）
+	RemindAt *Timestamp `json:"remind_at,omitempty"`
+
+	// DueDate は期限日時（未設定の場合は省略）
+	DueDate *Timestamp `json:"due_date,omitempty"`
+
+	// Recurrence は繰り返し規則（繰り返しなしの場合は省略）
+	Recurrence string `json:"recurrence,omitempty"`
+
+	// RecurrenceParentID はオカレンスの場合に、元になった繰り返しTodoのID
+	RecurrenceParentID *ID `json:"recurrence_parent_id,omitempty"`
+
+	// Color はカードの色（色なしの場合は省略）
+	Color string `json:"color,omitempty"`
+
+	// EstimateMinutes は見積もり時間（分、未設定の場合は省略）
+	EstimateMinutes int `json:"estimate_minutes,omitempty"`
+
+	// ActualMinutes は実績時間（分、未記録の場合は省略）
+	ActualMinutes int `json:"actual_minutes,omitempty"`
+
+	// Tags はタグ（タグがない場合は省略）
+	Tags []string `json:"tags,omitempty"`
+
+	// ProjectID は所属するプロジェクトのID（プロジェクトに属さない場合は省略）
+	ProjectID *ID `json:"project_id,omitempty"`
+
+	// Meta は更新系のエンドポイントで、変更がなかったことを伝える場合のみ設定します（NotModifiedMeta）
+	Meta *ResponseMeta `json:"meta,omitempty"`
 }
 
 // TodoListResponse はTodo一覧取得時のレスポンスDTOです
@@ -42,9 +87,42 @@ type TodoListResponse struct {
 	Meta ListMetaResponse `json:"meta"`
 }
 
+// ResponseMeta は全てのメタ情報ブロックに共通して含める情報です
+// クライアントは server_time で時計のずれを、schema_version でスキーマ変更を検知できます
+type ResponseMeta struct {
+	// ServerTime はレスポンス生成時のサーバー時刻（UTC）
+	ServerTime Timestamp `json:"server_time"`
+
+	// SchemaVersion はレスポンスのスキーマバージョン
+	SchemaVersion int `json:"schema_version"`
+
+	// NotModified は更新リクエストで値が何も変わらず、保存しなかったことを表します
+	NotModified bool `json:"not_modified,omitempty"`
+}
+
+// NewResponseMeta は現在時刻とスキーマバージョンを設定したResponseMetaを返します
+func NewResponseMeta() ResponseMeta {
+	return ResponseMeta{
+		ServerTime:    NewTimestamp(Now().UTC()),
+		SchemaVersion: SchemaVersion,
+	}
+}
+
+// NotModifiedMeta は更新で値が何も変わらなかったことを表すメタ情報を返します
+// 更新日時も変わらないため、クライアントはキャッシュを破棄する必要がありません
+func NotModifiedMeta() *ResponseMeta {
+	meta := NewResponseMeta()
+	meta.NotModified = true
+	return &meta
+}
+
 // ListMetaResponse は一覧取得時のメタ情報を表すDTOです
 // ページング情報や総件数など、一覧表示に必要な付加情報を含みます
 type ListMetaResponse struct {
+	// ResponseMeta を埋め込み、サーバー時刻とスキーマバージョンを含めます
+	// 埋め込みフィールドはJSONでは同じ階層に展開されます
+	ResponseMeta
+
 	// Total は総件数
 	Total int `json:"total"`
 
@@ -56,6 +134,43 @@ type ListMetaResponse struct {
 
 	// TotalPages は総ページ数
 	TotalPages int `json:"total_pages"`
+
+	// Filters は実際に適用した絞り込み条件です（正規化した値。絞り込みがない場合は空のオブジェクト）
+	Filters map[string]string `json:"filters"`
+
+	// Sort は一覧の並び順です（"-created_at" のように、先頭の "-" は降順を表します）
+	Sort string `json:"sort"`
+
+	// Adjustments はサーバーが既定値に置き換えたクエリパラメータです（置き換えがない場合は省略）
+	// 不正なパラメータでエラーにしない代わりに、クライアントが置き換えに気付けるようにします
+	Adjustments []ParamAdjustment `json:"adjustments,omitempty"`
+
+	// NextCursor はカーソル（?cursor）で取得した場合の、次のページのカーソルです（最後のページとカーソルを使わない場合は省略）
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// 一覧の並び順（ListMetaResponse.Sort）
+const (
+	// SortCreatedAtDesc は作成日時の新しい順です（Todo一覧の既定の並び順）
+	SortCreatedAtDesc = "-created_at"
+
+	// SortDueDateAsc は期限の近い順です（期限切れ・今日・今後の一覧）
+	SortDueDateAsc = "due_date"
+)
+
+// ParamAdjustment はサーバーが既定値に置き換えたクエリパラメータを表すDTOです
+type ParamAdjustment struct {
+	// Param はパラメータ名
+	Param string `json:"param"`
+
+	// Requested はリクエストで指定された値
+	Requested string `json:"requested"`
+
+	// Applied は実際に適用した値
+	Applied string `json:"applied"`
+
+	// Reason は置き換えた理由
+	Reason string `json:"reason"`
 }
 
 // ErrorResponse はエラー発生時のレスポンスDTOです
@@ -98,16 +213,29 @@ type FieldError struct {
 // エンティティ → レスポンスDTO の変換ロジック
 func ToTodoResponse(todo *entity.Todo) TodoResponse {
 	return TodoResponse{
-		ID:          todo.ID,
+		ID:          ID(todo.ID),
 		Title:       todo.Title,
 		Description: todo.Description,
 		IsCompleted: todo.IsCompleted,
-		CreatedAt:   todo.CreatedAt,
-		UpdatedAt:   todo.UpdatedAt,
+		CreatedAt:   NewTimestamp(todo.CreatedAt),
+		UpdatedAt:   NewTimestamp(todo.UpdatedAt),
+
+		ChecklistProgress: ToChecklistProgressResponse(todo.ChecklistProgress),
+		RemindAt:          timestampPtr(todo.RemindAt),
+		DueDate:           timestampPtr(todo.DueDate),
+
+		Recurrence:         string(todo.Recurrence),
+		RecurrenceParentID: idPtr(todo.RecurrenceParentID),
+		Color:              string(todo.Color),
+		EstimateMinutes:    todo.EstimateMinutes,
+		ActualMinutes:      todo.ActualMinutes,
+		Tags:               todo.Tags,
+		ProjectID:          idPtr(todo.ProjectID),
 	}
 }
 
 // ToTodoListResponse はEntity配列をResponseDTOに変換します
+// メタ情報の並び順は作成日時の新しい順、絞り込みは空で初期化します（異なる場合は呼び出し側で設定します）
 func ToTodoListResponse(todos []*entity.Todo, page, limit, total int) TodoListResponse {
 	// Entity配列を Response配列に変換
 	todoResponses := make([]TodoResponse, len(todos))
@@ -124,10 +252,13 @@ func ToTodoListResponse(todos []*entity.Todo, page, limit, total int) TodoListRe
 	return TodoListResponse{
 		Todos: todoResponses,
 		Meta: ListMetaResponse{
-			Total:      total,
-			Page:       page,
-			Limit:      limit,
-			TotalPages: totalPages,
+			ResponseMeta: NewResponseMeta(),
+			Total:        total,
+			Page:         page,
+			Limit:        limit,
+			TotalPages:   totalPages,
+			Filters:      map[string]string{},
+			Sort:         SortCreatedAtDesc,
 		},
 	}
 }
@@ -139,6 +270,14 @@ func (req CreateTodoRequest) ToEntity() *entity.Todo {
 		Description: req.Description,
 		// IsCompleted は新規作成時は常にfalse（デフォルト値）
 		IsCompleted: false,
+		RemindAt:    req.RemindAt,
+		DueDate:     utcTime(req.DueDate),
+		Recurrence:  entity.Recurrence(req.Recurrence),
+		Color:       entity.NormalizeColor(req.Color),
+
+		EstimateMinutes: req.EstimateMinutes,
+		ActualMinutes:   req.ActualMinutes,
+		ProjectID:       projectIDPtr(req.ProjectID),
 	}
 }
 
@@ -159,6 +298,55 @@ func (req UpdateTodoRequest) ApplyToEntity(todo *entity.Todo) {
 	if req.IsCompleted != nil {
 		todo.IsCompleted = *req.IsCompleted
 	}
+
+	// リマインダーが送信された場合のみ再設定
+	if req.RemindAt != nil {
+		todo.ScheduleReminder(*req.RemindAt)
+	}
+
+	// 期限・繰り返し規則が送信された場合のみ更新
+	if req.DueDate != nil {
+		todo.DueDate = utcTime(req.DueDate)
+	}
+	if req.Recurrence != nil {
+		todo.Recurrence = entity.Recurrence(*req.Recurrence)
+	}
+
+	// 色が送信された場合のみ更新（大文字小文字を揃えて保存する）
+	if req.Color != nil {
+		todo.Color = entity.NormalizeColor(*req.Color)
+	}
+
+	// 見積もり・実績時間が送信された場合のみ更新
+	if req.EstimateMinutes != nil {
+		todo.EstimateMinutes = *req.EstimateMinutes
+	}
+	if req.ActualMinutes != nil {
+		todo.ActualMinutes = *req.ActualMinutes
+	}
+
+	// 所属先が送信された場合のみ更新（0 の場合はプロジェクトから外す）
+	if req.ProjectID != nil {
+		todo.ProjectID = projectIDPtr(req.ProjectID)
+	}
+}
+
+// projectIDPtr はリクエストのプロジェクトIDのコピーを返します（nil または 0 の場合は nil）
+func projectIDPtr(id *ID) *int {
+	if id == nil || *id == 0 {
+		return nil
+	}
+	projectID := int(*id)
+	return &projectID
+}
+
+// utcTime は日時をUTCに揃えたコピーを返します（nil の場合は nil）
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
 }
 
 // DTOパターンの利点：
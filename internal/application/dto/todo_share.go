@@ -0,0 +1,42 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// ShareTodoRequest はTodoの共有（PUT /api/v1/todos/{id}/shares/{username}）のリクエストボディです
+type ShareTodoRequest struct {
+	// Permission は共有先のユーザーに許可する操作です（"read" または "write"）
+	Permission string `json:"permission"`
+}
+
+// TodoShareResponse はTodoの共有のレスポンスDTOです
+type TodoShareResponse struct {
+	TodoID     ID        `json:"todo_id"`
+	Username   string    `json:"username"`
+	Permission string    `json:"permission"`
+	CreatedAt  Timestamp `json:"created_at"`
+}
+
+// TodoShareListResponse はTodoの共有の一覧のレスポンスDTOです
+type TodoShareListResponse struct {
+	// Shares は共有のリスト（共有先のユーザー名の順）
+	Shares []TodoShareResponse `json:"shares"`
+}
+
+// ToTodoShareResponse はエンティティをレスポンスDTOに変換します
+func ToTodoShareResponse(share *entity.TodoShare) TodoShareResponse {
+	return TodoShareResponse{
+		TodoID:     ID(share.TodoID),
+		Username:   share.Username,
+		Permission: string(share.Permission),
+		CreatedAt:  NewTimestamp(share.CreatedAt),
+	}
+}
+
+// ToTodoShareListResponse はエンティティのリストを一覧のレスポンスDTOに変換します
+func ToTodoShareListResponse(shares []*entity.TodoShare) TodoShareListResponse {
+	response := TodoShareListResponse{Shares: make([]TodoShareResponse, 0, len(shares))}
+	for _, share := range shares {
+		response.Shares = append(response.Shares, ToTodoShareResponse(share))
+	}
+	return response
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"math"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoStatsResponse はTodoの集計結果のレスポンスDTOです
+type TodoStatsResponse struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Pending   int `json:"pending"`
+
+	// Estimates は見積もりと実績の比較
+	Estimates EstimateStatsResponse `json:"estimates"`
+
+	// Meta はメタ情報
+	Meta ResponseMeta `json:"meta"`
+}
+
+// EstimateStatsResponse は見積もり時間と実績時間の比較のレスポンスDTOです
+type EstimateStatsResponse struct {
+	// EstimatedTodos は見積もりが設定されたTodoの件数
+	EstimatedTodos int `json:"estimated_todos"`
+
+	// RemainingMinutes は未完了Todoの見積もり時間の合計（分）
+	RemainingMinutes int `json:"remaining_minutes"`
+
+	// ComparedTodos は見積もりと実績の両方が記録された完了済みTodoの件数
+	// 以下の項目はこのTodoだけを対象に集計します
+	ComparedTodos    int `json:"compared_todos"`
+	EstimatedMinutes int `json:"estimated_minutes"`
+	ActualMinutes    int `json:"actual_minutes"`
+
+	// AccuracyRatio は実績÷見積もり（小数第2位まで、比較対象がない場合は null）
+	AccuracyRatio *float64 `json:"accuracy_ratio"`
+
+	// Overrun・Underrun・OnTarget は実績が見積もりを超えた・下回った・一致した件数
+	Overrun  int `json:"overrun"`
+	Underrun int `json:"underrun"`
+	OnTarget int `json:"on_target"`
+}
+
+// ToTodoStatsResponse は集計結果をレスポンスDTOに変換します
+func ToTodoStatsResponse(stats entity.TodoStats) TodoStatsResponse {
+	estimates := stats.Estimates
+	response := TodoStatsResponse{
+		Total:     stats.Total,
+		Completed: stats.Completed,
+		Pending:   stats.Pending,
+		Estimates: EstimateStatsResponse{
+			EstimatedTodos:   estimates.Estimated,
+			RemainingMinutes: estimates.RemainingMinutes,
+			ComparedTodos:    estimates.Compared,
+			EstimatedMinutes: estimates.EstimatedMinutes,
+			ActualMinutes:    estimates.ActualMinutes,
+			Overrun:          estimates.Overrun,
+			Underrun:         estimates.Underrun,
+			OnTarget:         estimates.OnTarget,
+		},
+		Meta: NewResponseMeta(),
+	}
+
+	if ratio, ok := estimates.AccuracyRatio(); ok {
+		rounded := math.Round(ratio*100) / 100
+		response.Estimates.AccuracyRatio = &rounded
+	}
+	return response
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// ImportTodosResponse はTodoのインポート結果のレスポンスDTOです
+type ImportTodosResponse struct {
+	// Format はインポートに使った形式の名前
+	Format string `json:"format"`
+
+	// Imported は作成したTodoの件数
+	Imported int `json:"imported"`
+
+	// Todos は作成したTodo（読み込んだ順）
+	Todos []TodoResponse `json:"todos"`
+
+	Meta ResponseMeta `json:"meta"`
+}
+
+// ToImportTodosResponse はインポートで作成したTodoをレスポンスDTOに変換します
+func ToImportTodosResponse(format string, todos []*entity.Todo) ImportTodosResponse {
+	responses := make([]TodoResponse, len(todos))
+	for i, todo := range todos {
+		responses[i] = ToTodoResponse(todo)
+	}
+	return ImportTodosResponse{
+		Format:   format,
+		Imported: len(todos),
+		Todos:    responses,
+		Meta:     NewResponseMeta(),
+	}
+}
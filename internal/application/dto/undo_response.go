@@ -0,0 +1,25 @@
+package dto
+
+// UndoResponse は取り消した操作のレスポンスDTOです
+type UndoResponse struct {
+	// Action は取り消した操作の種類（delete / batch_update）
+	Action string `json:"action"`
+
+	// TodoIDs は元に戻したTodoのID
+	TodoIDs []ID `json:"todo_ids"`
+
+	Meta ResponseMeta `json:"meta"`
+}
+
+// ToUndoResponse は取り消した操作の種類と元に戻したTodoのIDをレスポンスDTOに変換します
+func ToUndoResponse(action string, todoIDs []int) UndoResponse {
+	ids := make([]ID, len(todoIDs))
+	for i, id := range todoIDs {
+		ids[i] = ID(id)
+	}
+	return UndoResponse{
+		Action:  action,
+		TodoIDs: ids,
+		Meta:    NewResponseMeta(),
+	}
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// CreateWebhookRequest はWebhook登録時のリクエストボディです
+type CreateWebhookRequest struct {
+	// URL は通知先のURL（必須、http または https）
+	URL string `json:"url"`
+
+	// Events は通知するイベントの一覧（必須、例: ["todo.created", "todo.completed"]）
+	Events []string `json:"events"`
+}
+
+// ToEntity はリクエストDTOをWebhookSubscriptionエンティティに変換します
+func (req CreateWebhookRequest) ToEntity() *entity.WebhookSubscription {
+	events := make([]entity.WebhookEvent, len(req.Events))
+	for i, event := range req.Events {
+		events[i] = entity.WebhookEvent(event)
+	}
+	return &entity.WebhookSubscription{
+		URL:    req.URL,
+		Events: events,
+	}
+}
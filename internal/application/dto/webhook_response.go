@@ -0,0 +1,43 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// WebhookResponse はWebhookの登録のレスポンスDTOです
+type WebhookResponse struct {
+	ID        ID        `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+// WebhookListResponse はWebhookの登録一覧のレスポンスDTOです
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+	Meta     ResponseMeta      `json:"meta"`
+}
+
+// ToWebhookResponse はエンティティをレスポンスDTOに変換します
+func ToWebhookResponse(subscription *entity.WebhookSubscription) WebhookResponse {
+	events := make([]string, len(subscription.Events))
+	for i, event := range subscription.Events {
+		events[i] = string(event)
+	}
+	return WebhookResponse{
+		ID:        ID(subscription.ID),
+		URL:       subscription.URL,
+		Events:    events,
+		CreatedAt: NewTimestamp(subscription.CreatedAt),
+	}
+}
+
+// ToWebhookListResponse はエンティティ配列を一覧レスポンスに変換します
+func ToWebhookListResponse(subscriptions []*entity.WebhookSubscription) WebhookListResponse {
+	webhooks := make([]WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		webhooks[i] = ToWebhookResponse(subscription)
+	}
+	return WebhookListResponse{
+		Webhooks: webhooks,
+		Meta:     NewResponseMeta(),
+	}
+}
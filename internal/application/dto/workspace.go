@@ -0,0 +1,88 @@
+package dto
+
+import "todoapp-api-golang/internal/domain/entity"
+
+// CreateWorkspaceRequest はワークスペースの作成（POST /api/v1/workspaces）のリクエストボディです
+type CreateWorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+// AcceptInvitationRequest は招待による参加（POST /api/v1/workspaces/join）のリクエストボディです
+type AcceptInvitationRequest struct {
+	// Token はワークスペースの所有者から受け取った招待トークンです
+	Token string `json:"token"`
+}
+
+// WorkspaceResponse はワークスペースのレスポンスDTOです
+type WorkspaceResponse struct {
+	ID        ID        `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+// WorkspaceListResponse はワークスペースの一覧のレスポンスDTOです
+type WorkspaceListResponse struct {
+	// Workspaces は参加しているワークスペースのリスト（作成順）
+	Workspaces []WorkspaceResponse `json:"workspaces"`
+}
+
+// WorkspaceMemberResponse はワークスペースのメンバーのレスポンスDTOです
+type WorkspaceMemberResponse struct {
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	JoinedAt Timestamp `json:"joined_at"`
+}
+
+// WorkspaceMemberListResponse はワークスペースのメンバーの一覧のレスポンスDTOです
+type WorkspaceMemberListResponse struct {
+	// Members はメンバーのリスト（ユーザー名の順）
+	Members []WorkspaceMemberResponse `json:"members"`
+}
+
+// WorkspaceInvitationResponse は作成した招待のレスポンスDTOです
+// 招待トークンはこのレスポンスでしか得られません（サーバーはハッシュだけを保存します）
+type WorkspaceInvitationResponse struct {
+	WorkspaceID ID        `json:"workspace_id"`
+	Token       string    `json:"token"`
+	ExpiresAt   Timestamp `json:"expires_at"`
+}
+
+// ToWorkspaceResponse はエンティティをレスポンスDTOに変換します
+func ToWorkspaceResponse(workspace *entity.Workspace) WorkspaceResponse {
+	return WorkspaceResponse{
+		ID:        ID(workspace.ID),
+		Name:      workspace.Name,
+		CreatedAt: NewTimestamp(workspace.CreatedAt),
+	}
+}
+
+// ToWorkspaceListResponse はエンティティのリストを一覧のレスポンスDTOに変換します
+func ToWorkspaceListResponse(workspaces []*entity.Workspace) WorkspaceListResponse {
+	response := WorkspaceListResponse{Workspaces: make([]WorkspaceResponse, 0, len(workspaces))}
+	for _, workspace := range workspaces {
+		response.Workspaces = append(response.Workspaces, ToWorkspaceResponse(workspace))
+	}
+	return response
+}
+
+// ToWorkspaceMemberListResponse はメンバーシップのリストを一覧のレスポンスDTOに変換します
+func ToWorkspaceMemberListResponse(members []*entity.WorkspaceMember) WorkspaceMemberListResponse {
+	response := WorkspaceMemberListResponse{Members: make([]WorkspaceMemberResponse, 0, len(members))}
+	for _, member := range members {
+		response.Members = append(response.Members, WorkspaceMemberResponse{
+			Username: member.Username,
+			Role:     string(member.Role),
+			JoinedAt: NewTimestamp(member.JoinedAt),
+		})
+	}
+	return response
+}
+
+// ToWorkspaceInvitationResponse は招待と招待トークンをレスポンスDTOに変換します
+func ToWorkspaceInvitationResponse(token string, invitation *entity.WorkspaceInvitation) WorkspaceInvitationResponse {
+	return WorkspaceInvitationResponse{
+		WorkspaceID: ID(invitation.WorkspaceID),
+		Token:       token,
+		ExpiresAt:   NewTimestamp(invitation.ExpiresAt),
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// activityFeedPath はTodoの最近の活動を配信するAtomフィードのパスです
+const activityFeedPath = "/feeds/todos.atom"
+
+// atomFeed はAtomフィード（RFC 4287）のルート要素です
+// encoding/xml の構造体タグで要素名・属性・名前空間を指定します
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomLink はフィードやエントリーのリンクです
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// atomPerson はエントリーの作成者です
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+// atomCategory はエントリーの分類です（操作の種類を入れます）
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// atomEntry はフィードの1件（作成・完了の1回分）です
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Author   atomPerson   `xml:"author"`
+	Link     atomLink     `xml:"link"`
+	Category atomCategory `xml:"category"`
+	Summary  string       `xml:"summary,omitempty"`
+}
+
+// activityTitles はフィードのエントリーのタイトルに付ける、操作ごとの見出しです
+var activityTitles = map[entity.TodoHistoryAction]string{
+	entity.TodoHistoryCreated:   "作成",
+	entity.TodoHistoryCompleted: "完了",
+}
+
+// writeActivityFeed は変更履歴をAtomフィードとして書き込みます
+//
+// 学習ポイント：
+//  1. フィードリーダーはエントリーの id で既読を管理するため、履歴ごとに変わらない値を使う
+//  2. リンクはフィードを取得したURLと切り離して読まれるため、スキームとホストを含めた絶対URLにする
+//  3. updated は最も新しいエントリーの日時にする（リーダーが更新の有無を判断する）
+func writeActivityFeed(w http.ResponseWriter, r *http.Request, entries []*entity.TodoHistoryEntry, now time.Time) {
+	feedURL := absoluteURL(r, activityFeedPath)
+	feed := atomFeed{
+		ID:      feedURL,
+		Title:   "Todo の最近の活動",
+		Updated: now.UTC().Format(time.RFC3339),
+		Links:   []atomLink{{Rel: "self", Href: feedURL, Type: "application/atom+xml"}},
+		Entries: make([]atomEntry, 0, len(entries)),
+	}
+	if len(entries) > 0 {
+		feed.Updated = entries[0].ChangedAt.UTC().Format(time.RFC3339)
+	}
+
+	for _, entry := range entries {
+		todo := entry.After
+		if todo == nil {
+			todo = entry.Before
+		}
+		if todo == nil {
+			continue
+		}
+		todoPath := fmt.Sprintf("%s/todos/%s", apiV1Path, dto.FormatID(entry.TodoID))
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:       absoluteURL(r, fmt.Sprintf("%s/history#%s", todoPath, dto.FormatID(entry.ID))),
+			Title:    activityTitles[entry.Action] + ": " + todo.Title,
+			Updated:  entry.ChangedAt.UTC().Format(time.RFC3339),
+			Author:   atomPerson{Name: entry.Actor},
+			Link:     atomLink{Rel: "alternate", Href: absoluteURL(r, todoPath), Type: "application/json"},
+			Category: atomCategory{Term: string(entry.Action)},
+			Summary:  todo.Description,
+		})
+	}
+
+	output, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		writeServerError(w, "Failed to render feed", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write(output)
+}
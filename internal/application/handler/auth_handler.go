@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// AuthHandler はユーザー登録とトークンの発行を行うハンドラーです
+//
+// 対応するエンドポイント：
+// POST /api/v1/auth/register -> ユーザー登録
+// POST /api/v1/auth/login    -> ログイン（アクセストークンとリフレッシュトークンの発行）
+// POST /api/v1/auth/refresh  -> リフレッシュトークンによる再発行（ローテーション）
+// POST /api/v1/auth/logout   -> ログアウト（リフレッシュトークンの系列を失効）
+// POST /api/v1/tokens        -> スコープを限定したアクセストークンの発行
+//
+// /api/v1/auth/ 配下のエンドポイントはアクセストークンなしで呼び出せます（AuthMiddleware の対象外）
+// /api/v1/tokens は認証されたユーザー自身のトークンを発行するため、アクセストークンが必要です
+type AuthHandler struct {
+	authService service.AuthServiceInterface
+}
+
+// NewAuthHandler はAuthHandlerのコンストラクタです
+func NewAuthHandler(authService service.AuthServiceInterface) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+	}
+}
+
+// Register はユーザーを登録します
+// POST /api/v1/auth/register
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req dto.CredentialsRequest
+	if !decodeAuthRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.authService.Register(r.Context(), req.Username, req.Password)
+	if err != nil {
+		writeAuthServiceError(w, "Failed to register user", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, dto.ToUserResponse(user))
+}
+
+// Login はユーザー名とパスワードを照合し、トークンを発行します
+// POST /api/v1/auth/login
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req dto.CredentialsRequest
+	if !decodeAuthRequest(w, r, &req) {
+		return
+	}
+
+	pair, err := h.authService.Login(r.Context(), req.Username, req.Password)
+	if err != nil {
+		writeAuthServiceError(w, "Failed to log in", err)
+		return
+	}
+
+	writeTokenResponse(w, pair)
+}
+
+// Refresh はリフレッシュトークンを新しいトークンの組に交換します
+// POST /api/v1/auth/refresh
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshTokenRequest
+	if !decodeAuthRequest(w, r, &req) {
+		return
+	}
+	if req.RefreshToken == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request", "refresh_token is required")
+		return
+	}
+
+	pair, err := h.authService.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		writeAuthServiceError(w, "Failed to refresh token", err)
+		return
+	}
+
+	writeTokenResponse(w, pair)
+}
+
+// Logout はリフレッシュトークンの系列を失効させます
+// POST /api/v1/auth/logout
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshTokenRequest
+	if !decodeAuthRequest(w, r, &req) {
+		return
+	}
+	if req.RefreshToken == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request", "refresh_token is required")
+		return
+	}
+
+	if err := h.authService.Logout(r.Context(), req.RefreshToken); err != nil {
+		writeAuthServiceError(w, "Failed to log out", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateToken は認証されたユーザーに、スコープを限定したアクセストークンを発行します
+// POST /api/v1/tokens
+func (h *AuthHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	principal, ok := service.PrincipalFromContext(r.Context())
+	if !ok {
+		writeErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "access token is required")
+		return
+	}
+
+	var req dto.CreateTokenRequest
+	if !decodeAuthRequest(w, r, &req) {
+		return
+	}
+	if req.ExpiresIn < 0 {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request", "expires_in must not be negative")
+		return
+	}
+
+	token, err := h.authService.CreateScopedToken(principal, req.Scopes, time.Duration(req.ExpiresIn)*time.Second)
+	if err != nil {
+		writeAuthServiceError(w, "Failed to create token", err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSONResponse(w, http.StatusCreated, dto.ScopedTokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(token.ExpiresAt.Sub(dto.Now()) / time.Second),
+		ExpiresAt:   dto.NewTimestamp(token.ExpiresAt),
+		Scopes:      token.Scopes,
+	})
+}
+
+// decodeAuthRequest はJSONのリクエストボディを v に読み込みます
+// 失敗した場合はエラーレスポンスを書き込み、false を返します
+func decodeAuthRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// writeTokenResponse は発行したトークンを返します
+// トークンをキャッシュさせないため、RFC 6749 5.1節に従い Cache-Control: no-store を付けます
+func writeTokenResponse(w http.ResponseWriter, pair *service.TokenPair) {
+	expiresIn := int64(pair.AccessTokenExpiresAt.Sub(dto.Now()) / time.Second)
+	if expiresIn < 0 {
+		expiresIn = 0
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSONResponse(w, http.StatusOK, dto.TokenResponse{
+		AccessToken:           pair.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             expiresIn,
+		RefreshToken:          pair.RefreshToken,
+		RefreshTokenExpiresAt: dto.NewTimestamp(pair.RefreshTokenExpiresAt),
+	})
+}
+
+// writeAuthServiceError はサービス層のエラーをHTTPステータスに変換して返します
+func writeAuthServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials),
+		errors.Is(err, service.ErrInvalidRefreshToken),
+		errors.Is(err, service.ErrRefreshTokenReused):
+		writeErrorResponse(w, http.StatusUnauthorized, "Unauthorized", err.Error())
+	case errors.Is(err, service.ErrScopeNotGranted):
+		writeErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+	case errors.Is(err, repository.ErrUsernameTaken):
+		writeErrorResponse(w, http.StatusConflict, "Username already taken", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request", err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// MockAuthService はテスト用のAuthServiceのモック実装です
+// ユーザー alice（パスワード "correct horse"）とリフレッシュトークン "valid" / "reused" が存在する前提で動作します
+type MockAuthService struct{}
+
+func (m *MockAuthService) Register(ctx context.Context, username, password string) (*entity.User, error) {
+	if username == "alice" {
+		return nil, repository.ErrUsernameTaken
+	}
+	if len(password) < entity.MinPasswordLength {
+		return nil, domainerr.Invalid("password", "must be 8-256 characters")
+	}
+	return &entity.User{ID: 2, Username: username}, nil
+}
+
+func (m *MockAuthService) Login(ctx context.Context, username, password string) (*service.TokenPair, error) {
+	if username != "alice" || password != "correct horse" {
+		return nil, service.ErrInvalidCredentials
+	}
+	return mockTokenPair(), nil
+}
+
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string) (*service.TokenPair, error) {
+	switch refreshToken {
+	case "valid":
+		return mockTokenPair(), nil
+	case "reused":
+		return nil, service.ErrRefreshTokenReused
+	default:
+		return nil, service.ErrInvalidRefreshToken
+	}
+}
+
+func (m *MockAuthService) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken != "valid" {
+		return service.ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+func (m *MockAuthService) VerifyAccessToken(token string) (service.Principal, error) {
+	if token != "access" {
+		return service.Principal{}, service.ErrInvalidAccessToken
+	}
+	return service.Principal{UserID: 1, Username: "alice"}, nil
+}
+
+func (m *MockAuthService) CreateScopedToken(principal service.Principal, scopes []string, ttl time.Duration) (*service.ScopedToken, error) {
+	if len(scopes) == 0 {
+		return nil, domainerr.Invalid("scopes", "must contain at least one scope")
+	}
+	for _, scope := range scopes {
+		if !principal.HasScope(scope) {
+			return nil, service.ErrScopeNotGranted
+		}
+	}
+	if ttl == 0 {
+		ttl = 15 * time.Minute
+	}
+	return &service.ScopedToken{AccessToken: "scoped", ExpiresAt: dto.Now().Add(ttl), Scopes: scopes}, nil
+}
+
+func (m *MockAuthService) PurgeExpired(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+// mockTokenPair はモックが発行するトークンの組です（有効期限は15分後と30日後）
+func mockTokenPair() *service.TokenPair {
+	now := dto.Now()
+	return &service.TokenPair{
+		AccessToken:           "access",
+		AccessTokenExpiresAt:  now.Add(15 * time.Minute),
+		RefreshToken:          "next",
+		RefreshTokenExpiresAt: now.Add(30 * 24 * time.Hour),
+		User:                  &entity.User{ID: 1, Username: "alice"},
+	}
+}
+
+// TestAuthHandler はユーザー登録・ログイン・再発行・ログアウトのレスポンスをテストします
+func TestAuthHandler(t *testing.T) {
+	handler := NewAuthHandler(&MockAuthService{})
+
+	tests := []struct {
+		name           string
+		body           string
+		serve          func(w http.ResponseWriter, r *http.Request)
+		expectedStatus int
+	}{
+		{name: "登録", body: `{"username":"bob","password":"correct horse"}`, serve: handler.Register, expectedStatus: http.StatusCreated},
+		{name: "使用済みのユーザー名", body: `{"username":"alice","password":"correct horse"}`, serve: handler.Register, expectedStatus: http.StatusConflict},
+		{name: "短いパスワード", body: `{"username":"bob","password":"short"}`, serve: handler.Register, expectedStatus: http.StatusBadRequest},
+		{name: "不正なJSON", body: `{`, serve: handler.Register, expectedStatus: http.StatusBadRequest},
+		{name: "ログイン", body: `{"username":"alice","password":"correct horse"}`, serve: handler.Login, expectedStatus: http.StatusOK},
+		{name: "パスワードの誤り", body: `{"username":"alice","password":"wrong"}`, serve: handler.Login, expectedStatus: http.StatusUnauthorized},
+		{name: "再発行", body: `{"refresh_token":"valid"}`, serve: handler.Refresh, expectedStatus: http.StatusOK},
+		{name: "再利用の検知", body: `{"refresh_token":"reused"}`, serve: handler.Refresh, expectedStatus: http.StatusUnauthorized},
+		{name: "存在しないトークン", body: `{"refresh_token":"unknown"}`, serve: handler.Refresh, expectedStatus: http.StatusUnauthorized},
+		{name: "トークンなしの再発行", body: `{}`, serve: handler.Refresh, expectedStatus: http.StatusBadRequest},
+		{name: "ログアウト", body: `{"refresh_token":"valid"}`, serve: handler.Logout, expectedStatus: http.StatusNoContent},
+		{name: "存在しないトークンのログアウト", body: `{"refresh_token":"unknown"}`, serve: handler.Logout, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/x", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			tt.serve(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"username":"alice","password":"correct horse"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.Login(rec, req)
+
+	var response dto.TokenResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if response.AccessToken != "access" || response.TokenType != "Bearer" || response.RefreshToken != "next" {
+		t.Errorf("トークンのレスポンス = %+v", response)
+	}
+	// 実行中の時刻の経過で1秒短くなることがある
+	if response.ExpiresIn < 899 || response.ExpiresIn > 900 {
+		t.Errorf("expires_in = %d, 期待値 = 900", response.ExpiresIn)
+	}
+	if cacheControl := rec.Header().Get("Cache-Control"); cacheControl != "no-store" {
+		t.Errorf("Cache-Control = %q, 期待値 = no-store", cacheControl)
+	}
+}
+
+// TestAuthHandler_CreateToken はスコープを限定したアクセストークンの発行をテストします
+func TestAuthHandler_CreateToken(t *testing.T) {
+	handler := NewAuthHandler(&MockAuthService{})
+	alice := service.Principal{UserID: 1, Username: "alice"}
+	readOnly := service.Principal{UserID: 1, Username: "alice", Scopes: []string{entity.ScopeTodosRead}}
+
+	tests := []struct {
+		name           string
+		principal      *service.Principal
+		body           string
+		expectedStatus int
+	}{
+		{name: "発行", principal: &alice, body: `{"scopes":["todos:read"],"expires_in":3600}`, expectedStatus: http.StatusCreated},
+		{name: "スコープなし", principal: &alice, body: `{"scopes":[]}`, expectedStatus: http.StatusBadRequest},
+		{name: "負の有効期間", principal: &alice, body: `{"scopes":["todos:read"],"expires_in":-1}`, expectedStatus: http.StatusBadRequest},
+		{name: "持っていないスコープ", principal: &readOnly, body: `{"scopes":["todos:write"]}`, expectedStatus: http.StatusForbidden},
+		{name: "認証なし", body: `{"scopes":["todos:read"]}`, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tokens", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.principal != nil {
+				req = req.WithContext(service.WithPrincipal(req.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			handler.CreateToken(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if rec.Code != http.StatusCreated {
+				return
+			}
+
+			var response dto.ScopedTokenResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response.AccessToken != "scoped" || response.TokenType != "Bearer" || len(response.Scopes) != 1 || response.Scopes[0] != entity.ScopeTodosRead {
+				t.Errorf("トークンのレスポンス = %+v", response)
+			}
+			if response.ExpiresIn < 3599 || response.ExpiresIn > 3600 {
+				t.Errorf("expires_in = %d, 期待値 = 3600", response.ExpiresIn)
+			}
+			if cacheControl := rec.Header().Get("Cache-Control"); cacheControl != "no-store" {
+				t.Errorf("Cache-Control = %q, 期待値 = no-store", cacheControl)
+			}
+		})
+	}
+}
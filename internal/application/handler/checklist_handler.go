@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// ChecklistHandler はTodoに属するチェックリスト項目のHTTPリクエストを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET    /api/v1/todos/{id}/checklist            -> 一覧取得（進捗付き）
+// POST   /api/v1/todos/{id}/checklist            -> 項目追加
+// GET    /api/v1/todos/{id}/checklist/{itemId}   -> 項目取得
+// PUT    /api/v1/todos/{id}/checklist/{itemId}   -> 項目更新（部分更新）
+// DELETE /api/v1/todos/{id}/checklist/{itemId}   -> 項目削除
+type ChecklistHandler struct {
+	checklistService service.ChecklistServiceInterface
+}
+
+// NewChecklistHandler はChecklistHandlerのコンストラクタです
+func NewChecklistHandler(checklistService service.ChecklistServiceInterface) *ChecklistHandler {
+	return &ChecklistHandler{
+		checklistService: checklistService,
+	}
+}
+
+// ListItems はTodoのチェックリスト項目を進捗付きで返します
+// GET /api/v1/todos/{id}/checklist
+func (h *ChecklistHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	todoID, _, err := parseChecklistPath(r.URL.Path, false)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	items, err := h.checklistService.ListItems(r.Context(), todoID)
+	if err != nil {
+		writeChecklistServiceError(w, "Failed to get checklist", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToChecklistResponse(items))
+}
+
+// AddItem はTodoにチェックリスト項目を追加します
+// POST /api/v1/todos/{id}/checklist
+func (h *ChecklistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	todoID, _, err := parseChecklistPath(r.URL.Path, false)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	var req dto.CreateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	if msg := validateChecklistText(req.Text); msg != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", msg)
+		return
+	}
+
+	created, err := h.checklistService.AddItem(r.Context(), req.ToEntity(todoID))
+	if err != nil {
+		writeChecklistServiceError(w, "Failed to add checklist item", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, dto.ToChecklistItemResponse(created))
+}
+
+// GetItem はチェックリスト項目を1件返します
+// GET /api/v1/todos/{id}/checklist/{itemId}
+func (h *ChecklistHandler) GetItem(w http.ResponseWriter, r *http.Request) {
+	todoID, itemID, err := parseChecklistPath(r.URL.Path, true)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	item, err := h.checklistService.GetItem(r.Context(), todoID, itemID)
+	if err != nil {
+		writeChecklistServiceError(w, "Failed to get checklist item", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToChecklistItemResponse(item))
+}
+
+// UpdateItem はチェックリスト項目のテキストや完了状態を更新します
+// PUT /api/v1/todos/{id}/checklist/{itemId}
+func (h *ChecklistHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	todoID, itemID, err := parseChecklistPath(r.URL.Path, true)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	var req dto.UpdateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	if req.Text != nil {
+		if msg := validateChecklistText(*req.Text); msg != "" {
+			writeErrorResponse(w, http.StatusBadRequest, "Validation failed", msg)
+			return
+		}
+	}
+
+	// 既存の項目を取得して、送信されたフィールドのみを適用（部分更新）
+	item, err := h.checklistService.GetItem(r.Context(), todoID, itemID)
+	if err != nil {
+		writeChecklistServiceError(w, "Failed to get checklist item", err)
+		return
+	}
+	req.ApplyToEntity(item)
+
+	updated, err := h.checklistService.UpdateItem(r.Context(), item)
+	if err != nil {
+		writeChecklistServiceError(w, "Failed to update checklist item", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToChecklistItemResponse(updated))
+}
+
+// DeleteItem はチェックリスト項目を削除します
+// DELETE /api/v1/todos/{id}/checklist/{itemId}
+func (h *ChecklistHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	todoID, itemID, err := parseChecklistPath(r.URL.Path, true)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	if err := h.checklistService.DeleteItem(r.Context(), todoID, itemID); err != nil {
+		writeChecklistServiceError(w, "Failed to delete checklist item", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- ヘルパー関数 ---
+
+// parseChecklistPath はURLパスからTodoIDとチェックリスト項目IDを抽出します
+// パスの構造: /api/v1/todos/{id}/checklist[/{itemId}]
+func parseChecklistPath(path string, withItem bool) (int, int, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[4] != "checklist" {
+		return 0, 0, errors.New("invalid endpoint")
+	}
+
+	todoID, err := dto.ParseID(pathParts[3])
+	if err != nil {
+		return 0, 0, errors.New("todo ID must be a number")
+	}
+
+	if !withItem {
+		return todoID, 0, nil
+	}
+
+	if len(pathParts) < 6 {
+		return 0, 0, errors.New("checklist item ID is required")
+	}
+	itemID, err := dto.ParseID(pathParts[5])
+	if err != nil {
+		return 0, 0, errors.New("checklist item ID must be a number")
+	}
+
+	return todoID, itemID, nil
+}
+
+// validateChecklistText はチェックリスト項目のテキストを検証し、問題があればメッセージを返します
+func validateChecklistText(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return "text is required"
+	}
+	if len(text) > entity.MaxChecklistTextLength {
+		return "text must be " + strconv.Itoa(entity.MaxChecklistTextLength) + " characters or less"
+	}
+	return ""
+}
+
+// writeChecklistServiceError はサービス層のエラーを適切なHTTPステータスに変換して書き込みます
+func writeChecklistServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case domainerr.IsNotFound(err):
+		writeErrorResponse(w, http.StatusNotFound, "Not found", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, message, err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
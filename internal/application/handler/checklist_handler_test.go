@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockChecklistService はテスト用のChecklistServiceのモック実装です
+type MockChecklistService struct {
+	items  map[int]*entity.ChecklistItem
+	nextID int
+}
+
+// NewMockChecklistService はモックサービスのコンストラクタです
+// TodoID=1 のTodoのみ存在する前提で動作します
+func NewMockChecklistService() *MockChecklistService {
+	return &MockChecklistService{
+		items:  make(map[int]*entity.ChecklistItem),
+		nextID: 1,
+	}
+}
+
+func (m *MockChecklistService) AddItem(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	if item.TodoID != 1 {
+		return nil, domainerr.NotFound("todo", 2).Wrap(domainerr.NotFound("todo", nil))
+	}
+	item.ID = m.nextID
+	m.nextID++
+	saved := *item
+	m.items[item.ID] = &saved
+	return &saved, nil
+}
+
+func (m *MockChecklistService) ListItems(ctx context.Context, todoID int) ([]*entity.ChecklistItem, error) {
+	if todoID != 1 {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+	result := make([]*entity.ChecklistItem, 0)
+	for id := 1; id < m.nextID; id++ {
+		if item, ok := m.items[id]; ok {
+			itemCopy := *item
+			result = append(result, &itemCopy)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockChecklistService) GetItem(ctx context.Context, todoID, itemID int) (*entity.ChecklistItem, error) {
+	item, ok := m.items[itemID]
+	if !ok || item.TodoID != todoID {
+		return nil, domainerr.NotFound("checklist item", nil)
+	}
+	result := *item
+	return &result, nil
+}
+
+func (m *MockChecklistService) UpdateItem(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	saved := *item
+	m.items[item.ID] = &saved
+	return &saved, nil
+}
+
+func (m *MockChecklistService) DeleteItem(ctx context.Context, todoID, itemID int) error {
+	if _, ok := m.items[itemID]; !ok {
+		return domainerr.NotFound("checklist item", nil)
+	}
+	delete(m.items, itemID)
+	return nil
+}
+
+// TestChecklistHandler_Flow はチェックリストの追加・更新・一覧・削除の一連の流れをテストします
+func TestChecklistHandler_Flow(t *testing.T) {
+	handler := NewChecklistHandler(NewMockChecklistService())
+
+	// 追加
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos/1/checklist", bytes.NewBufferString(`{"text":"牛乳"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.AddItem(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("追加: ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusCreated)
+	}
+
+	// 部分更新（is_done のみ）
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/todos/1/checklist/1", bytes.NewBufferString(`{"is_done":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	handler.UpdateItem(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("更新: ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+	var item dto.ChecklistItemResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &item); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if !item.IsDone || item.Text != "牛乳" {
+		t.Errorf("部分更新の結果が正しくありません: %+v", item)
+	}
+
+	// 一覧（進捗付き）
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/todos/1/checklist", nil)
+	rec = httptest.NewRecorder()
+	handler.ListItems(rec, req)
+	var list dto.ChecklistResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if list.Progress.Total != 1 || list.Progress.Done != 1 {
+		t.Errorf("進捗 = %+v, 期待値 = {Total:1 Done:1}", list.Progress)
+	}
+
+	// 削除
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/todos/1/checklist/1", nil)
+	rec = httptest.NewRecorder()
+	handler.DeleteItem(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("削除: ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusNoContent)
+	}
+}
+
+// TestChecklistHandler_Errors はチェックリストハンドラーのエラー応答をテストします
+func TestChecklistHandler_Errors(t *testing.T) {
+	handler := NewChecklistHandler(NewMockChecklistService())
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		serve          func(http.ResponseWriter, *http.Request)
+		expectedStatus int
+	}{
+		{
+			name:           "空のテキスト",
+			method:         http.MethodPost,
+			path:           "/api/v1/todos/1/checklist",
+			body:           `{"text":"  "}`,
+			serve:          handler.AddItem,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "存在しないTodo",
+			method:         http.MethodPost,
+			path:           "/api/v1/todos/2/checklist",
+			body:           `{"text":"項目"}`,
+			serve:          handler.AddItem,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "数値でない項目ID",
+			method:         http.MethodGet,
+			path:           "/api/v1/todos/1/checklist/abc",
+			serve:          handler.GetItem,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "存在しない項目の削除",
+			method:         http.MethodDelete,
+			path:           "/api/v1/todos/1/checklist/99",
+			serve:          handler.DeleteItem,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			tt.serve(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/application/middleware"
+)
+
+// コンテンツネゴシエーション（リクエスト・レスポンスの形式の決定）のヘルパーです
+//
+// リクエスト：
+//   - application/json                  -> JSONとしてデコード
+//   - application/x-www-form-urlencoded -> フォームとしてデコード（HTMLフォーム・HTMX）
+//   - application/msgpack               -> middleware.CodecMiddleware がJSONに変換してから届く
+//
+// レスポンス：
+//   - HX-Request: true ヘッダー、または Accept で text/html を優先 -> HTMLフラグメント
+//   - Accept で application/vnd.api+json を優先                  -> JSON:API（jsonapi.go を参照）
+//   - Accept で application/msgpack を優先                        -> JSONを middleware.CodecMiddleware が変換
+//   - それ以外                                                     -> JSON（従来通り）
+
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeForm = "application/x-www-form-urlencoded"
+	mediaTypeHTML = "text/html"
+)
+
+// errUnsupportedContentType は対応していない Content-Type の場合のエラーです
+var errUnsupportedContentType = errors.New("Content-Type must be application/json or application/x-www-form-urlencoded")
+
+// requestMediaType はリクエストの Content-Type からメディアタイプ部分（charset等を除く）を返します
+func requestMediaType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// isFormRequest はリクエストボディがURLエンコードされたフォームかどうかを判定します
+func isFormRequest(r *http.Request) bool {
+	return requestMediaType(r) == mediaTypeForm
+}
+
+// invalidBodyMessage はボディのデコードに失敗した場合のエラーメッセージを返します
+func invalidBodyMessage(r *http.Request) string {
+	if isFormRequest(r) {
+		return "Invalid form data"
+	}
+	return "Invalid JSON format"
+}
+
+// decodeCreateTodoRequest はJSONまたはフォームのボディを作成リクエストに変換します
+func decodeCreateTodoRequest(r *http.Request) (dto.CreateTodoRequest, error) {
+	var req dto.CreateTodoRequest
+
+	switch requestMediaType(r) {
+	case mediaTypeJSON:
+		err := json.NewDecoder(r.Body).Decode(&req)
+		return req, err
+	case mediaTypeForm:
+		if err := r.ParseForm(); err != nil {
+			return req, err
+		}
+		return dto.CreateTodoRequestFromForm(r.PostForm)
+	default:
+		return req, errUnsupportedContentType
+	}
+}
+
+// decodeUpdateTodoRequest はJSONまたはフォームのボディを更新リクエストに変換します
+func decodeUpdateTodoRequest(r *http.Request) (dto.UpdateTodoRequest, error) {
+	var req dto.UpdateTodoRequest
+
+	switch requestMediaType(r) {
+	case mediaTypeJSON:
+		err := json.NewDecoder(r.Body).Decode(&req)
+		return req, err
+	case mediaTypeForm:
+		if err := r.ParseForm(); err != nil {
+			return req, err
+		}
+		return dto.UpdateTodoRequestFromForm(r.PostForm)
+	default:
+		return req, errUnsupportedContentType
+	}
+}
+
+// wantsHTML はレスポンスをHTMLフラグメントで返すべきかを判定します
+// HTMXは全てのリクエストに HX-Request: true を付与するため、これを最優先で判定します
+// それ以外は Accept ヘッダーで text/html の品質値（q）が application/json より高い場合のみHTMLとします
+func wantsHTML(r *http.Request) bool {
+	if r.Header.Get("HX-Request") == "true" {
+		return true
+	}
+
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+	return acceptQuality(accept, mediaTypeHTML) > acceptQuality(accept, mediaTypeJSON)
+}
+
+// acceptQuality は Accept ヘッダーにおける指定メディアタイプの品質値（0〜1）を返します
+// CodecMiddleware と同じ判定を使うため、middleware.AcceptQuality に委ねます
+func acceptQuality(accept, mediaType string) float64 {
+	return middleware.AcceptQuality(accept, mediaType)
+}
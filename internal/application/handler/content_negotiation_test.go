@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/application/middleware"
+)
+
+// TestWantsHTML は Accept / HX-Request ヘッダーによるレスポンス形式の判定をテストします
+func TestWantsHTML(t *testing.T) {
+	tests := []struct {
+		name      string
+		accept    string
+		hxRequest string
+		expected  bool
+	}{
+		{name: "ヘッダーなし", expected: false},
+		{name: "HTMXリクエスト", hxRequest: "true", expected: true},
+		{name: "JSONを要求", accept: "application/json", expected: false},
+		{name: "HTMLを要求", accept: "text/html", expected: true},
+		{name: "ブラウザの既定のAccept", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expected: true},
+		{name: "JSONを優先", accept: "text/html;q=0.5, application/json", expected: false},
+		{name: "ワイルドカードのみ", accept: "*/*", expected: false},
+		{name: "HTMLを拒否", accept: "text/html;q=0, */*", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if tt.hxRequest != "" {
+				req.Header.Set("HX-Request", tt.hxRequest)
+			}
+
+			if result := wantsHTML(req); result != tt.expected {
+				t.Errorf("wantsHTML() = %v, 期待値 = %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_FormAndFragments はフォーム送信とHTMLフラグメントの応答をテストします
+func TestTodoHandler_FormAndFragments(t *testing.T) {
+	mockService := NewMockTodoService()
+	handler := NewTodoHandler(mockService)
+
+	// フォームで作成し、HTMXクライアントとしてフラグメントを受け取る
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", strings.NewReader("title=%3Cb%3E買い物%3C%2Fb%3E&description=牛乳"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	handler.CreateTodo(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("作成: ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %v, 期待値 = text/html", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`id="todo-1"`, "&lt;b&gt;買い物&lt;/b&gt;", "牛乳"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("フラグメントに %q が含まれていません: %s", want, body)
+		}
+	}
+
+	// フォームで部分更新（チェックボックスの on）し、JSONで受け取る
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/todos/1", strings.NewReader("is_completed=on"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	handler.UpdateTodo(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("更新: ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Content-Type = %v, 期待値 = application/json", ct)
+	}
+	if !mockService.todos[1].IsCompleted || mockService.todos[1].Title != "<b>買い物</b>" {
+		t.Errorf("部分更新の結果が正しくありません: %+v", mockService.todos[1])
+	}
+
+	// 一覧をHTMLで取得
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+	req.Header.Set("Accept", "text/html")
+	rec = httptest.NewRecorder()
+	handler.GetAllTodos(rec, req)
+
+	if !strings.Contains(rec.Body.String(), `<ul id="todo-list"`) || !strings.Contains(rec.Body.String(), "todo--completed") {
+		t.Errorf("一覧のフラグメントが正しくありません: %s", rec.Body.String())
+	}
+
+	// HTMXからの削除は要素を差し替えられるよう200で応答する
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/todos/1", nil)
+	req.Header.Set("HX-Request", "true")
+	rec = httptest.NewRecorder()
+	handler.DeleteTodo(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("削除: ステータスコード = %v, body = %q, 期待値 = 200 と空のボディ", rec.Code, rec.Body.String())
+	}
+}
+
+// TestTodoHandler_UnsupportedContentType は未対応の Content-Type が拒否されることをテストします
+func TestTodoHandler_UnsupportedContentType(t *testing.T) {
+	handler := NewTodoHandler(NewMockTodoService())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", strings.NewReader("title=買い物"))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	handler.CreateTodo(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// TestTodoHandler_FragmentLinksWithBasePath はベースパス配下でフラグメントのリンクにベースパスが付与されることをテストします
+func TestTodoHandler_FragmentLinksWithBasePath(t *testing.T) {
+	handler := NewTodoHandler(NewMockTodoService())
+	serve := middleware.BasePathMiddleware("/todoapp", nil)(http.HandlerFunc(handler.CreateTodo))
+
+	req := httptest.NewRequest(http.MethodPost, "/todoapp/api/v1/todos", strings.NewReader("title=買い物"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	serve.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`hx-patch="/todoapp/api/v1/todos/1/complete"`, `hx-delete="/todoapp/api/v1/todos/1"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("フラグメントに %q が含まれていません: %s", want, body)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DBPoolHandler は実行中のデータベースの接続プールの設定を参照・変更するハンドラーです
+//
+// 対応するエンドポイント：
+// GET   /admin/dbpool -> 現在の設定と接続数
+// PATCH /admin/dbpool -> 設定の変更（例: {"max_open_conns":20}、指定しなかった項目はそのまま）
+//
+// 変更は sql.DB の SetMaxOpenConns などをそのまま呼び出すため、再起動なしで負荷に合わせて調整できます
+// 再起動すると DB_MAX_OPEN_CONNS などの環境変数の値に戻ります
+type DBPoolHandler struct {
+	pool ConnPool
+
+	// sql.DB は設定値を返さないため、最後に適用した設定をここで保持します
+	mu       sync.Mutex
+	settings DBPoolSettings
+}
+
+// ConnPool は DBPoolHandler が設定を変更する接続プールです（*sql.DB が実装します）
+type ConnPool interface {
+	SetMaxOpenConns(n int)
+	SetMaxIdleConns(n int)
+	SetConnMaxLifetime(d time.Duration)
+	Stats() sql.DBStats
+}
+
+// DBPoolSettings は接続プールの設定です
+// それぞれ 0 は sql.DB と同じく、無制限（最大オープン接続数・最大生存時間）またはアイドル接続を保持しないことを表します
+type DBPoolSettings struct {
+	MaxOpenConns           int `json:"max_open_conns"`
+	MaxIdleConns           int `json:"max_idle_conns"`
+	ConnMaxLifetimeSeconds int `json:"conn_max_lifetime_seconds"`
+}
+
+// DBPoolRequest は接続プールの設定の変更リクエストです
+type DBPoolRequest struct {
+	MaxOpenConns           *int `json:"max_open_conns"`
+	MaxIdleConns           *int `json:"max_idle_conns"`
+	ConnMaxLifetimeSeconds *int `json:"conn_max_lifetime_seconds"`
+}
+
+// DBPoolResponse は接続プールの設定と現在の接続数を表すレスポンスです
+type DBPoolResponse struct {
+	DBPoolSettings
+	OpenConnections int    `json:"open_connections"`
+	InUse           int    `json:"in_use"`
+	Idle            int    `json:"idle"`
+	WaitCount       int64  `json:"wait_count"`
+	WaitDuration    string `json:"wait_duration"`
+}
+
+// NewDBPoolHandler はDBPoolHandlerのコンストラクタです
+// initial には接続時に pool に設定した値（DB_MAX_OPEN_CONNS など）を渡します
+func NewDBPoolHandler(pool ConnPool, initial DBPoolSettings) *DBPoolHandler {
+	return &DBPoolHandler{
+		pool:     pool,
+		settings: initial,
+	}
+}
+
+// DBPool は接続プールの設定を返す、または変更します
+// GET /admin/dbpool, PATCH /admin/dbpool
+func (h *DBPoolHandler) DBPool(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.mu.Lock()
+		settings := h.settings
+		h.mu.Unlock()
+		writeJSONResponse(w, http.StatusOK, h.response(settings))
+	case http.MethodPatch:
+		h.setDBPool(w, r)
+	default:
+		w.Header().Set("Allow", "GET, PATCH")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// setDBPool はリクエストで指定された項目を変更し、接続プールに適用します
+func (h *DBPoolHandler) setDBPool(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req DBPoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	// 同時に変更された場合に、一方の変更が他方の検証をすり抜けないよう適用までをロックする
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	previous := h.settings
+	settings := previous
+	if req.MaxOpenConns != nil {
+		settings.MaxOpenConns = *req.MaxOpenConns
+	}
+	if req.MaxIdleConns != nil {
+		settings.MaxIdleConns = *req.MaxIdleConns
+	}
+	if req.ConnMaxLifetimeSeconds != nil {
+		settings.ConnMaxLifetimeSeconds = *req.ConnMaxLifetimeSeconds
+	}
+
+	if settings.MaxOpenConns < 0 || settings.MaxIdleConns < 0 || settings.ConnMaxLifetimeSeconds < 0 {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid pool settings", "values must not be negative")
+		return
+	}
+	// sql.DB は最大オープン接続数を超えるアイドル接続数を黙って切り詰めるため、矛盾する指定は受け付けない
+	if settings.MaxOpenConns > 0 && settings.MaxIdleConns > settings.MaxOpenConns {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid pool settings", "max_idle_conns must not exceed max_open_conns")
+		return
+	}
+
+	h.pool.SetMaxOpenConns(settings.MaxOpenConns)
+	h.pool.SetMaxIdleConns(settings.MaxIdleConns)
+	h.pool.SetConnMaxLifetime(time.Duration(settings.ConnMaxLifetimeSeconds) * time.Second)
+	h.settings = settings
+	// ログレベルの変更と同じく、変更自体は WARN で記録する
+	slog.Warn("database pool settings changed",
+		"from", previous, "to", settings, "remote_addr", r.RemoteAddr)
+
+	writeJSONResponse(w, http.StatusOK, h.response(settings))
+}
+
+// response は設定と接続プールの現在の統計からレスポンスを作成します
+func (h *DBPoolHandler) response(settings DBPoolSettings) DBPoolResponse {
+	stats := h.pool.Stats()
+	return DBPoolResponse{
+		DBPoolSettings:  settings,
+		OpenConnections: stats.OpenConnections,
+		InUse:           stats.InUse,
+		Idle:            stats.Idle,
+		WaitCount:       stats.WaitCount,
+		WaitDuration:    stats.WaitDuration.String(),
+	}
+}
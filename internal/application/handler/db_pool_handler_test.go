@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConnPool は設定された値を記録する ConnPool です
+type fakeConnPool struct {
+	maxOpen     int
+	maxIdle     int
+	maxLifetime time.Duration
+}
+
+func (p *fakeConnPool) SetMaxOpenConns(n int)              { p.maxOpen = n }
+func (p *fakeConnPool) SetMaxIdleConns(n int)              { p.maxIdle = n }
+func (p *fakeConnPool) SetConnMaxLifetime(d time.Duration) { p.maxLifetime = d }
+func (p *fakeConnPool) Stats() sql.DBStats {
+	return sql.DBStats{MaxOpenConnections: p.maxOpen, OpenConnections: 3, InUse: 1, Idle: 2}
+}
+
+// TestDBPoolHandler は接続プールの設定の参照と変更をテストします
+func TestDBPoolHandler(t *testing.T) {
+	pool := &fakeConnPool{maxOpen: 10, maxIdle: 5, maxLifetime: time.Hour}
+	handler := NewDBPoolHandler(pool, DBPoolSettings{MaxOpenConns: 10, MaxIdleConns: 5, ConnMaxLifetimeSeconds: 3600})
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expected       DBPoolSettings
+	}{
+		{name: "現在の設定", method: http.MethodGet, expectedStatus: http.StatusOK, expected: DBPoolSettings{10, 5, 3600}},
+		{name: "最大オープン接続数だけ変更", method: http.MethodPatch, body: `{"max_open_conns":20}`, expectedStatus: http.StatusOK, expected: DBPoolSettings{20, 5, 3600}},
+		{name: "全ての項目を変更", method: http.MethodPatch, body: `{"max_open_conns":4,"max_idle_conns":2,"conn_max_lifetime_seconds":300}`, expectedStatus: http.StatusOK, expected: DBPoolSettings{4, 2, 300}},
+		{name: "0は無制限", method: http.MethodPatch, body: `{"max_open_conns":0,"max_idle_conns":8}`, expectedStatus: http.StatusOK, expected: DBPoolSettings{0, 8, 300}},
+		{name: "負の値", method: http.MethodPatch, body: `{"conn_max_lifetime_seconds":-1}`, expectedStatus: http.StatusBadRequest, expected: DBPoolSettings{0, 8, 300}},
+		{name: "アイドル接続数が最大オープン接続数を超える", method: http.MethodPatch, body: `{"max_open_conns":4}`, expectedStatus: http.StatusBadRequest, expected: DBPoolSettings{0, 8, 300}},
+		{name: "不正なJSON", method: http.MethodPatch, body: `{`, expectedStatus: http.StatusBadRequest, expected: DBPoolSettings{0, 8, 300}},
+		{name: "許可されていないメソッド", method: http.MethodPut, body: `{"max_open_conns":1}`, expectedStatus: http.StatusMethodNotAllowed, expected: DBPoolSettings{0, 8, 300}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/admin/dbpool", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.DBPool(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			// 失敗したリクエストでは接続プールの設定を変えない
+			got := DBPoolSettings{pool.maxOpen, pool.maxIdle, int(pool.maxLifetime / time.Second)}
+			if got != tt.expected {
+				t.Errorf("接続プールの設定 = %+v, 期待値 = %+v", got, tt.expected)
+			}
+
+			if rec.Code == http.StatusOK {
+				var response DBPoolResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+					t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+				}
+				if response.DBPoolSettings != tt.expected {
+					t.Errorf("レスポンスの設定 = %+v, 期待値 = %+v", response.DBPoolSettings, tt.expected)
+				}
+				if response.OpenConnections != 3 || response.InUse != 1 || response.Idle != 2 {
+					t.Errorf("レスポンスの接続数 = %+v", response)
+				}
+			}
+			if rec.Code == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != "GET, PATCH" {
+				t.Errorf("Allow = %q", rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
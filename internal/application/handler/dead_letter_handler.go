@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// DeadLetterHandler は再送の上限に達した送信（デッドレター）を管理する管理者向けハンドラーです
+//
+// 対応するエンドポイント：
+// GET    /api/v1/admin/dead-letters              -> デッドレター一覧
+// POST   /api/v1/admin/dead-letters/{id}/requeue -> 再送待ちに戻す
+// DELETE /api/v1/admin/dead-letters/{id}         -> 破棄
+type DeadLetterHandler struct {
+	deliveryService service.DeliveryServiceInterface
+}
+
+// NewDeadLetterHandler はDeadLetterHandlerのコンストラクタです
+func NewDeadLetterHandler(deliveryService service.DeliveryServiceInterface) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deliveryService: deliveryService,
+	}
+}
+
+// ListDeadLetters はデッドレターの一覧を返します
+// GET /api/v1/admin/dead-letters
+func (h *DeadLetterHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.deliveryService.ListDeadLetters(r.Context())
+	if err != nil {
+		writeServerError(w, "Failed to list dead letters", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToDeadLetterListResponse(deliveries))
+}
+
+// RequeueDeadLetter はデッドレターを再送待ちに戻します（次回のスキャンで再送されます）
+// POST /api/v1/admin/dead-letters/{id}/requeue
+func (h *DeadLetterHandler) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := parseDeadLetterPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	delivery, err := h.deliveryService.Requeue(r.Context(), id)
+	if err != nil {
+		writeDeadLetterServiceError(w, "Failed to requeue dead letter", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToDeadLetterResponse(delivery))
+}
+
+// DiscardDeadLetter はデッドレターを破棄します
+// DELETE /api/v1/admin/dead-letters/{id}
+func (h *DeadLetterHandler) DiscardDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := parseDeadLetterPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	if err := h.deliveryService.Discard(r.Context(), id); err != nil {
+		writeDeadLetterServiceError(w, "Failed to discard dead letter", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseDeadLetterPath はURLパスからデッドレターのIDを抽出します
+// パスの構造: /api/v1/admin/dead-letters/{id}[/requeue]
+func parseDeadLetterPath(path string) (int, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] != "dead-letters" {
+		return 0, errors.New("invalid endpoint")
+	}
+
+	id, err := dto.ParseID(pathParts[4])
+	if err != nil {
+		return 0, errors.New("dead letter ID must be a number")
+	}
+
+	return id, nil
+}
+
+// writeDeadLetterServiceError はサービス層のエラーをHTTPステータスに変換して返します
+func writeDeadLetterServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case domainerr.IsNotFound(err):
+		writeErrorResponse(w, http.StatusNotFound, "Dead letter not found", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request", err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockDeliveryService はテスト用のDeliveryServiceのモック実装です
+// ID=1 のデッドレターのみ存在する前提で動作します
+type MockDeliveryService struct{}
+
+func (m *MockDeliveryService) Enqueue(ctx context.Context, kind entity.DeliveryKind, todoID int, payload any, cause error) error {
+	return nil
+}
+
+func (m *MockDeliveryService) RetryDue(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *MockDeliveryService) ListDeadLetters(ctx context.Context) ([]*entity.FailedDelivery, error) {
+	return []*entity.FailedDelivery{
+		{ID: 1, Kind: entity.DeliveryKindReminder, TodoID: 3, Payload: []byte(`{"id":3,"title":"請求書"}`), Attempts: 8, LastError: "503", Status: entity.DeliveryStatusDead},
+	}, nil
+}
+
+func (m *MockDeliveryService) Requeue(ctx context.Context, id int) (*entity.FailedDelivery, error) {
+	if id != 1 {
+		return nil, domainerr.NotFound("dead letter", 2).Wrap(domainerr.NotFound("failed delivery", nil))
+	}
+	return &entity.FailedDelivery{ID: 1, Kind: entity.DeliveryKindReminder, Payload: []byte(`{}`), Status: entity.DeliveryStatusRetrying}, nil
+}
+
+func (m *MockDeliveryService) Discard(ctx context.Context, id int) error {
+	if id != 1 {
+		return domainerr.NotFound("dead letter", 2).Wrap(domainerr.NotFound("failed delivery", nil))
+	}
+	return nil
+}
+
+// TestDeadLetterHandler はデッドレターの一覧・再投入・破棄のレスポンスをテストします
+func TestDeadLetterHandler(t *testing.T) {
+	handler := NewDeadLetterHandler(&MockDeliveryService{})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		serve          func(w http.ResponseWriter, r *http.Request)
+		expectedStatus int
+	}{
+		{name: "一覧", method: http.MethodGet, path: "/api/v1/admin/dead-letters", serve: handler.ListDeadLetters, expectedStatus: http.StatusOK},
+		{name: "再投入", method: http.MethodPost, path: "/api/v1/admin/dead-letters/1/requeue", serve: handler.RequeueDeadLetter, expectedStatus: http.StatusOK},
+		{name: "存在しないものの再投入", method: http.MethodPost, path: "/api/v1/admin/dead-letters/2/requeue", serve: handler.RequeueDeadLetter, expectedStatus: http.StatusNotFound},
+		{name: "数値でないID", method: http.MethodPost, path: "/api/v1/admin/dead-letters/abc/requeue", serve: handler.RequeueDeadLetter, expectedStatus: http.StatusBadRequest},
+		{name: "破棄", method: http.MethodDelete, path: "/api/v1/admin/dead-letters/1", serve: handler.DiscardDeadLetter, expectedStatus: http.StatusNoContent},
+		{name: "存在しないものの破棄", method: http.MethodDelete, path: "/api/v1/admin/dead-letters/2", serve: handler.DiscardDeadLetter, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.serve(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+
+	// ペイロードはJSONのまま返す
+	rec := httptest.NewRecorder()
+	handler.ListDeadLetters(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/dead-letters", nil))
+	var response dto.DeadLetterListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if len(response.DeadLetters) != 1 || string(response.DeadLetters[0].Payload) != `{"id":3,"title":"請求書"}` {
+		t.Errorf("デッドレター = %+v", response.DeadLetters)
+	}
+}
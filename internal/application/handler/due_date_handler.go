@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// DueDateHandler はTodoの期限に基づくビューのHTTPリクエストを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET /api/v1/todos/overdue              -> 期限切れの未完了Todo（期限の早い順）
+// GET /api/v1/todos/today?tz=Asia/Tokyo    -> 今日が期限の未完了Todo
+// GET /api/v1/todos/upcoming?days=7&tz=... -> 明日から days 日間が期限の未完了Todo
+// GET /api/v1/todos/calendar.ics          -> 期限のあるTodoのiCalendarフィード（カレンダーアプリから購読）
+//
+// 「今日」の区切りは tz クエリパラメータ（IANAタイムゾーン名）で指定し、省略時はUTCです
+// WithDueDatePreferences を設定した場合、省略時はユーザーが保存したタイムゾーンを使います
+type DueDateHandler struct {
+	dueDateService service.DueDateServiceInterface
+
+	// preferencesService は tz を省略した場合のタイムゾーンを取得するサービスです（nil の場合はUTC）
+	preferencesService service.PreferencesServiceInterface
+}
+
+// DueDateHandlerOption はDueDateHandlerに任意の機能を設定する関数型オプションです
+type DueDateHandlerOption func(*DueDateHandler)
+
+// WithDueDatePreferences は tz を省略した場合に、ユーザーが保存したタイムゾーン（GET /api/v1/me/preferences）を使うようにします
+func WithDueDatePreferences(preferencesService service.PreferencesServiceInterface) DueDateHandlerOption {
+	return func(h *DueDateHandler) {
+		h.preferencesService = preferencesService
+	}
+}
+
+// NewDueDateHandler はDueDateHandlerのコンストラクタです
+func NewDueDateHandler(dueDateService service.DueDateServiceInterface, opts ...DueDateHandlerOption) *DueDateHandler {
+	h := &DueDateHandler{
+		dueDateService: dueDateService,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ListOverdue は期限切れの未完了Todoを返します
+// GET /api/v1/todos/overdue
+// 一覧と同じ形式（HTMXクライアントにはHTMLフラグメント）で、ページングせずに全件を返します
+func (h *DueDateHandler) ListOverdue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	fields, ok := parseFieldsParam(w, r)
+	if !ok {
+		return
+	}
+
+	todos, err := h.dueDateService.ListOverdue(r.Context())
+	if err != nil {
+		writeServerError(w, "Failed to get overdue todos", err)
+		return
+	}
+
+	response := dueDateListResponse(todos, map[string]string{"due": "overdue"})
+	writeTodoListResponse(w, r, http.StatusOK, response, fields)
+}
+
+// ListDueToday は利用者のタイムゾーンで今日が期限の未完了Todoを返します
+// GET /api/v1/todos/today?tz=Asia/Tokyo
+func (h *DueDateHandler) ListDueToday(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	fields, ok := parseFieldsParam(w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := h.location(w, r)
+	if !ok {
+		return
+	}
+
+	todos, err := h.dueDateService.ListDueToday(r.Context(), loc)
+	if err != nil {
+		writeServerError(w, "Failed to get todos due today", err)
+		return
+	}
+
+	response := dueDateListResponse(todos, map[string]string{"due": "today", "tz": loc.String()})
+	writeTodoListResponse(w, r, http.StatusOK, response, fields)
+}
+
+// ListUpcoming は利用者のタイムゾーンで明日から days 日間が期限の未完了Todoを返します
+// GET /api/v1/todos/upcoming?days=7&tz=Asia/Tokyo
+func (h *DueDateHandler) ListUpcoming(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	fields, ok := parseFieldsParam(w, r)
+	if !ok {
+		return
+	}
+
+	loc, ok := h.location(w, r)
+	if !ok {
+		return
+	}
+
+	days := service.DefaultUpcomingDays
+	if d := r.URL.Query().Get("days"); d != "" {
+		var err error
+		days, err = strconv.Atoi(d)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid days", "days must be a number")
+			return
+		}
+	}
+
+	todos, err := h.dueDateService.ListUpcoming(r.Context(), loc, days)
+	if err != nil {
+		if domainerr.IsInvalid(err) {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid days", err.Error())
+			return
+		}
+		writeServerError(w, "Failed to get upcoming todos", err)
+		return
+	}
+
+	response := dueDateListResponse(todos, map[string]string{"due": "upcoming", "tz": loc.String(), "days": strconv.Itoa(days)})
+	writeTodoListResponse(w, r, http.StatusOK, response, fields)
+}
+
+// Calendar は期限のあるTodoをiCalendar形式で返します
+// GET /api/v1/todos/calendar.ics?component=event&include_completed=false
+// GoogleカレンダーやAppleカレンダーに、このURLを「URLで追加（購読）」して使用します
+func (h *DueDateHandler) Calendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	component, err := parseCalendarComponent(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid component", err.Error())
+		return
+	}
+
+	includeCompleted := false
+	if v := r.URL.Query().Get("include_completed"); v != "" {
+		includeCompleted, err = strconv.ParseBool(v)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid include_completed", "include_completed must be true or false")
+			return
+		}
+	}
+
+	todos, err := h.dueDateService.ListCalendar(r.Context(), includeCompleted)
+	if err != nil {
+		writeServerError(w, "Failed to get calendar", err)
+		return
+	}
+
+	writeCalendar(w, r, todos, component)
+}
+
+// dueDateListResponse は期限で絞り込んだ一覧のレスポンスを作成します
+// ページングせずに全件を返し、メタ情報には適用した条件（既定値を補った tz・days を含む）と期限の近い順を設定します
+func dueDateListResponse(todos []*entity.Todo, filters map[string]string) dto.TodoListResponse {
+	response := dto.ToTodoListResponse(todos, 1, max(len(todos), 1), len(todos))
+	response.Meta.Filters = filters
+	response.Meta.Sort = dto.SortDueDateAsc
+	return response
+}
+
+// location は「今日」の区切りに使うタイムゾーンを返します
+// tz クエリパラメータを優先し、省略時は保存した設定のタイムゾーン（設定がない場合はUTC）を使います
+// 失敗した場合はエラーレスポンスを書き込み、false を返します
+func (h *DueDateHandler) location(w http.ResponseWriter, r *http.Request) (*time.Location, bool) {
+	if r.URL.Query().Get("tz") == "" && h.preferencesService != nil {
+		preferences, err := h.preferencesService.Get(r.Context())
+		if err != nil {
+			writeServerError(w, "Failed to get preferences", err)
+			return nil, false
+		}
+		return preferences.Location(), true
+	}
+
+	loc, err := parseTimezone(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid timezone", err.Error())
+		return nil, false
+	}
+	return loc, true
+}
+
+// parseTimezone は tz クエリパラメータからタイムゾーンを取得します（省略時はUTC）
+func parseTimezone(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	// "Local" はサーバーのタイムゾーンになってしまうため受け付けない
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, fmt.Errorf("unknown timezone: %q (must be an IANA time zone name such as Asia/Tokyo)", name)
+	}
+	return loc, nil
+}
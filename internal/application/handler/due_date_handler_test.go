@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockDueDateService はテスト用のDueDateServiceのモック実装です
+type MockDueDateService struct {
+	overdue  []*entity.Todo
+	calendar []*entity.Todo
+}
+
+func (m *MockDueDateService) ListOverdue(ctx context.Context) ([]*entity.Todo, error) {
+	return m.overdue, nil
+}
+
+func (m *MockDueDateService) ListDueToday(ctx context.Context, loc *time.Location) ([]*entity.Todo, error) {
+	return []*entity.Todo{}, nil
+}
+
+func (m *MockDueDateService) ListUpcoming(ctx context.Context, loc *time.Location, days int) ([]*entity.Todo, error) {
+	if days < 1 || days > 90 {
+		return nil, domainerr.Invalid("days", "must be between 1 and 90")
+	}
+	return []*entity.Todo{}, nil
+}
+
+func (m *MockDueDateService) ListCalendar(ctx context.Context, includeCompleted bool) ([]*entity.Todo, error) {
+	result := make([]*entity.Todo, 0, len(m.calendar))
+	for _, todo := range m.calendar {
+		if includeCompleted || !todo.IsCompleted {
+			result = append(result, todo)
+		}
+	}
+	return result, nil
+}
+
+// TestDueDateHandler_ListOverdue は期限切れ一覧のレスポンスをテストします
+func TestDueDateHandler_ListOverdue(t *testing.T) {
+	dueDate := time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		overdue        []*entity.Todo
+		expectedStatus int
+		expectedCount  int
+	}{
+		{name: "期限切れあり", method: http.MethodGet, overdue: []*entity.Todo{{ID: 1, Title: "期限切れ", DueDate: &dueDate}}, expectedStatus: http.StatusOK, expectedCount: 1},
+		{name: "期限切れなし", method: http.MethodGet, overdue: []*entity.Todo{}, expectedStatus: http.StatusOK, expectedCount: 0},
+		{name: "不正なHTTPメソッド", method: http.MethodPost, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewDueDateHandler(&MockDueDateService{overdue: tt.overdue})
+			rec := httptest.NewRecorder()
+			handler.ListOverdue(rec, httptest.NewRequest(tt.method, "/api/v1/todos/overdue", nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.TodoListResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if len(response.Todos) != tt.expectedCount || response.Meta.Total != tt.expectedCount {
+				t.Errorf("件数 = %d (total %d), 期待値 = %d", len(response.Todos), response.Meta.Total, tt.expectedCount)
+			}
+		})
+	}
+}
+
+// TestDueDateHandler_TodayAndUpcoming はタイムゾーンと日数のパラメータの検証をテストします
+func TestDueDateHandler_TodayAndUpcoming(t *testing.T) {
+	handler := NewDueDateHandler(&MockDueDateService{})
+
+	tests := []struct {
+		name           string
+		path           string
+		serve          func(w http.ResponseWriter, r *http.Request)
+		expectedStatus int
+	}{
+		{name: "今日（UTC）", path: "/api/v1/todos/today", serve: handler.ListDueToday, expectedStatus: http.StatusOK},
+		{name: "今日（東京）", path: "/api/v1/todos/today?tz=Asia/Tokyo", serve: handler.ListDueToday, expectedStatus: http.StatusOK},
+		{name: "不明なタイムゾーン", path: "/api/v1/todos/today?tz=Mars/Olympus", serve: handler.ListDueToday, expectedStatus: http.StatusBadRequest},
+		{name: "サーバーのタイムゾーン", path: "/api/v1/todos/today?tz=Local", serve: handler.ListDueToday, expectedStatus: http.StatusBadRequest},
+		{name: "今後の予定（既定の日数）", path: "/api/v1/todos/upcoming", serve: handler.ListUpcoming, expectedStatus: http.StatusOK},
+		{name: "今後の予定（30日）", path: "/api/v1/todos/upcoming?days=30", serve: handler.ListUpcoming, expectedStatus: http.StatusOK},
+		{name: "数値でない日数", path: "/api/v1/todos/upcoming?days=week", serve: handler.ListUpcoming, expectedStatus: http.StatusBadRequest},
+		{name: "範囲外の日数", path: "/api/v1/todos/upcoming?days=365", serve: handler.ListUpcoming, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.serve(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+// TestDueDateHandler_Calendar はiCalendarフィードの内容とパラメータの検証をテストします
+func TestDueDateHandler_Calendar(t *testing.T) {
+	dueDate := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	remindAt := dueDate.Add(-time.Hour)
+	calendar := []*entity.Todo{
+		{ID: 1, Title: "会議の準備, 資料; 印刷", Description: "1行目\n2行目", DueDate: &dueDate, RemindAt: &remindAt},
+		{ID: 2, Title: "完了済み", DueDate: &dueDate, IsCompleted: true},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		expectedStatus int
+		contains       []string
+		notContains    []string
+	}{
+		{
+			name:           "既定はVEVENTで未完了のみ",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+			contains: []string{
+				"BEGIN:VCALENDAR\r\n", "BEGIN:VEVENT\r\n", "UID:todo-1@example.com\r\n", "DTSTART:20240501T090000Z\r\n",
+				`SUMMARY:会議の準備\, 資料\; 印刷`, `DESCRIPTION:1行目\n2行目`, "TRIGGER;VALUE=DATE-TIME:20240501T080000Z\r\n", "END:VCALENDAR\r\n",
+			},
+			notContains: []string{"VTODO", "todo-2@"},
+		},
+		{
+			name:           "VTODOで完了済みを含む",
+			method:         http.MethodGet,
+			query:          "?component=todo&include_completed=true",
+			expectedStatus: http.StatusOK,
+			contains:       []string{"BEGIN:VTODO\r\n", "DUE:20240501T090000Z\r\n", "STATUS:NEEDS-ACTION\r\n", "UID:todo-2@example.com\r\n", "STATUS:COMPLETED\r\n"},
+			notContains:    []string{"VEVENT"},
+		},
+		{name: "不正なcomponent", method: http.MethodGet, query: "?component=journal", expectedStatus: http.StatusBadRequest},
+		{name: "不正なinclude_completed", method: http.MethodGet, query: "?include_completed=maybe", expectedStatus: http.StatusBadRequest},
+		{name: "不正なHTTPメソッド", method: http.MethodPost, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewDueDateHandler(&MockDueDateService{calendar: calendar})
+			rec := httptest.NewRecorder()
+			handler.Calendar(rec, httptest.NewRequest(tt.method, "/api/v1/todos/calendar.ics"+tt.query, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			body := rec.Body.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(body, want) {
+					t.Errorf("レスポンスに %q が含まれていません:\n%s", want, body)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(body, unwanted) {
+					t.Errorf("レスポンスに %q が含まれています", unwanted)
+				}
+			}
+		})
+	}
+}
+
+// TestWriteICalLine は75バイトを超える行がUTF-8の文字の境界で折り返されることをテストします
+func TestWriteICalLine(t *testing.T) {
+	var b strings.Builder
+	writeICalLine(&b, "SUMMARY:"+strings.Repeat("あ", 60))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("折り返されていません: %q", b.String())
+	}
+	var joined strings.Builder
+	for i, line := range lines {
+		if len(line) > icalMaxLineOctets {
+			t.Errorf("%d行目の長さ = %d バイト, 上限 = %d", i, len(line), icalMaxLineOctets)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("継続行が空白で始まっていません: %q", line)
+			}
+			line = line[1:]
+		}
+		joined.WriteString(line)
+	}
+	if joined.String() != "SUMMARY:"+strings.Repeat("あ", 60) {
+		t.Errorf("折り返しを戻した値が元の行と一致しません: %q", joined.String())
+	}
+}
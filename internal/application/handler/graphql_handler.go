@@ -0,0 +1,414 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/pkg/graphql"
+)
+
+// GraphQLHandler はTodoのGraphQL APIを提供するハンドラーです
+//
+// 対応するエンドポイント：
+// POST /graphql                 -> {"query": "...", "variables": {...}} を実行
+// GET  /graphql?query=...       -> クエリのみ実行（ミューテーションは POST のみ）
+//
+// RESTのエンドポイントと同じ TodoServiceInterface を使うため、ビジネスルールは共通です
+// クライアントは必要なフィールドだけを選択して、1回のリクエストで取得できます
+//
+// スキーマ（概要）：
+//
+//	type Query {
+//	  todo(id: ID!): Todo
+//	  todos(color: String, completed: Boolean, search: String, page: Int = 1, limit: Int = 10): TodoPage!
+//	  stats: TodoStats!
+//	}
+//	type Mutation {
+//	  createTodo(input: CreateTodoInput!): Todo
+//	  updateTodo(id: ID!, input: UpdateTodoInput!): Todo
+//	  completeTodo(id: ID!): Todo
+//	  incompleteTodo(id: ID!): Todo
+//	  deleteTodo(id: ID!): ID
+//	}
+//
+// Todo のフィールドはRESTのレスポンスのキーをキャメルケースにしたものです（is_completed -> isCompleted）
+// 入力（CreateTodoInput・UpdateTodoInput）も同様に、RESTのリクエストボディのキーをキャメルケースにしたものです
+type GraphQLHandler struct {
+	todoService service.TodoServiceInterface
+	schema      *graphql.Schema
+}
+
+// NewGraphQLHandler はGraphQLHandlerのコンストラクタです
+func NewGraphQLHandler(todoService service.TodoServiceInterface) *GraphQLHandler {
+	h := &GraphQLHandler{todoService: todoService}
+	h.schema = h.newSchema()
+	return h
+}
+
+// GraphQL はGraphQLのリクエストを実行します
+// 解析・検証のエラーは 400、実行できた場合はフィールドのエラーがあっても 200 を返します
+func (h *GraphQLHandler) GraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	var opts []graphql.ExecuteOption
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := decodeJSONNumbers(strings.NewReader(variables), &req.Variables); err != nil {
+				writeGraphQLError(w, "Variables are invalid JSON: "+err.Error())
+				return
+			}
+		}
+		opts = append(opts, graphql.QueryOnly())
+	case http.MethodPost:
+		if requestMediaType(r) != mediaTypeJSON {
+			writeErrorResponse(w, http.StatusUnsupportedMediaType, "Unsupported Content-Type", "GraphQL requests must be application/json")
+			return
+		}
+		if err := decodeJSONNumbers(r.Body, &req); err != nil {
+			writeBodyDecodeError(w, r, err)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		writeGraphQLError(w, "Must provide query string")
+		return
+	}
+
+	resp := h.schema.Execute(r.Context(), req, opts...)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeJSONResponse(w, status, resp)
+}
+
+// decodeJSONNumbers はJSONを、数値を json.Number のまま（float64 に丸めずに）デコードします
+// Int の変数が整数かどうかを正確に検証するためです
+func decodeJSONNumbers(r io.Reader, v any) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// writeGraphQLError はリクエストのエラーをGraphQLのレスポンス形式で返します
+func writeGraphQLError(w http.ResponseWriter, message string) {
+	writeJSONResponse(w, http.StatusBadRequest, graphql.Response{Errors: []*graphql.Error{{Message: message}}})
+}
+
+// todoPage は todos クエリの結果（ページング済みの一覧）です
+type todoPage struct {
+	items []dto.TodoResponse
+	total int
+	page  int
+	limit int
+}
+
+// newSchema はTodoのスキーマを組み立てます
+func (h *GraphQLHandler) newSchema() *graphql.Schema {
+	checklistProgress := &graphql.Object{Name: "ChecklistProgress", Fields: map[string]*graphql.FieldDef{
+		"total": sourceField(func(p dto.ChecklistProgressResponse) any { return p.Total }),
+		"done":  sourceField(func(p dto.ChecklistProgressResponse) any { return p.Done }),
+	}}
+
+	todo := &graphql.Object{Name: "Todo", Fields: map[string]*graphql.FieldDef{
+		"id":                 sourceField(func(t dto.TodoResponse) any { return t.ID.String() }),
+		"title":              sourceField(func(t dto.TodoResponse) any { return t.Title }),
+		"description":        sourceField(func(t dto.TodoResponse) any { return t.Description }),
+		"isCompleted":        sourceField(func(t dto.TodoResponse) any { return t.IsCompleted }),
+		"createdAt":          sourceField(func(t dto.TodoResponse) any { return t.CreatedAt }),
+		"updatedAt":          sourceField(func(t dto.TodoResponse) any { return t.UpdatedAt }),
+		"remindAt":           sourceField(func(t dto.TodoResponse) any { return t.RemindAt }),
+		"dueDate":            sourceField(func(t dto.TodoResponse) any { return t.DueDate }),
+		"recurrence":         sourceField(func(t dto.TodoResponse) any { return nullIfZero(t.Recurrence) }),
+		"color":              sourceField(func(t dto.TodoResponse) any { return nullIfZero(t.Color) }),
+		"estimateMinutes":    sourceField(func(t dto.TodoResponse) any { return nullIfZero(t.EstimateMinutes) }),
+		"actualMinutes":      sourceField(func(t dto.TodoResponse) any { return nullIfZero(t.ActualMinutes) }),
+		"recurrenceParentId": sourceField(func(t dto.TodoResponse) any { return optionalID(t.RecurrenceParentID) }),
+		"checklistProgress":  objectField(checklistProgress, func(t dto.TodoResponse) any { return t.ChecklistProgress }),
+	}}
+
+	page := &graphql.Object{Name: "TodoPage", Fields: map[string]*graphql.FieldDef{
+		"items":       objectField(todo, func(p todoPage) any { return p.items }),
+		"totalCount":  sourceField(func(p todoPage) any { return p.total }),
+		"page":        sourceField(func(p todoPage) any { return p.page }),
+		"limit":       sourceField(func(p todoPage) any { return p.limit }),
+		"hasNextPage": sourceField(func(p todoPage) any { return p.page*p.limit < p.total }),
+	}}
+
+	stats := &graphql.Object{Name: "TodoStats", Fields: map[string]*graphql.FieldDef{
+		"total":     sourceField(func(s entity.TodoStats) any { return s.Total }),
+		"completed": sourceField(func(s entity.TodoStats) any { return s.Completed }),
+		"pending":   sourceField(func(s entity.TodoStats) any { return s.Pending }),
+	}}
+
+	idArg := []graphql.Arg{{Name: "id", Type: "ID!"}}
+	return &graphql.Schema{
+		Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.FieldDef{
+			"todo": {Type: todo, Args: idArg, Resolve: h.resolveTodo},
+			"todos": {Type: page, Resolve: h.resolveTodos, Args: []graphql.Arg{
+				{Name: "color", Type: "String"},
+				{Name: "completed", Type: "Boolean"},
+				{Name: "search", Type: "String"},
+				{Name: "page", Type: "Int", Default: 1},
+				{Name: "limit", Type: "Int", Default: 10},
+			}},
+			"stats": {Type: stats, Resolve: func(ctx context.Context, source any, args graphql.Args) (any, error) {
+				return h.todoService.GetTodoStats(ctx)
+			}},
+		}},
+		Mutation: &graphql.Object{Name: "Mutation", Fields: map[string]*graphql.FieldDef{
+			"createTodo":     {Type: todo, Args: []graphql.Arg{{Name: "input", Type: "CreateTodoInput!"}}, Resolve: h.resolveCreateTodo},
+			"updateTodo":     {Type: todo, Args: []graphql.Arg{{Name: "id", Type: "ID!"}, {Name: "input", Type: "UpdateTodoInput!"}}, Resolve: h.resolveUpdateTodo},
+			"completeTodo":   {Type: todo, Args: idArg, Resolve: h.resolveStateChange(h.todoService.CompleteTodo)},
+			"incompleteTodo": {Type: todo, Args: idArg, Resolve: h.resolveStateChange(h.todoService.IncompleteTodo)},
+			"deleteTodo":     {Args: idArg, Resolve: h.resolveDeleteTodo},
+		}},
+	}
+}
+
+// sourceField は親の値（型 S）から値を取り出すだけのフィールドを作成します
+func sourceField[S any](get func(source S) any) *graphql.FieldDef {
+	return &graphql.FieldDef{Resolve: func(ctx context.Context, source any, args graphql.Args) (any, error) {
+		return get(source.(S)), nil
+	}}
+}
+
+// objectField は親の値（型 S）から、オブジェクト型 typ の値を取り出すフィールドを作成します
+func objectField[S any](typ *graphql.Object, get func(source S) any) *graphql.FieldDef {
+	field := sourceField(get)
+	field.Type = typ
+	return field
+}
+
+// nullIfZero はRESTのレスポンスで省略される未設定の値（空文字・0）を null にします
+func nullIfZero[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return v
+}
+
+// optionalID はIDを GraphQL の ID（文字列）にします（nil の場合は null）
+func optionalID(id *dto.ID) any {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+// todoIDArg は引数 id をTodoのIDとして解析します
+func todoIDArg(args graphql.Args) (int, error) {
+	raw, _ := args.String("id")
+	id, err := dto.ParseID(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid todo ID %q", raw)
+	}
+	return id, nil
+}
+
+// resolveTodo は todo(id:) クエリです（存在しない場合は null）
+func (h *GraphQLHandler) resolveTodo(ctx context.Context, source any, args graphql.Args) (any, error) {
+	id, err := todoIDArg(args)
+	if err != nil {
+		return nil, err
+	}
+	todo, err := h.todoService.GetTodoByID(ctx, id)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dto.ToTodoResponse(todo), nil
+}
+
+// resolveTodos は todos クエリです（色・完了状態・文字列で絞り込み、page と limit でページングする）
+func (h *GraphQLHandler) resolveTodos(ctx context.Context, source any, args graphql.Args) (any, error) {
+	page, _ := args.Int("page")
+	limit, _ := args.Int("limit")
+	if page < 1 {
+		return nil, errors.New("page must be 1 or greater")
+	}
+	if limit < 1 || limit > 100 {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+
+	// 絞り込みは全てリポジトリに渡し、1回の取得で済ませる
+	var filter repository.TodoFilter
+	if raw, ok := args.String("color"); ok {
+		filter.Color = entity.NormalizeColor(raw)
+		if filter.Color == entity.ColorNone || !filter.Color.IsValid() {
+			return nil, fmt.Errorf("color must be one of %v or a hex color such as #1e90ff", entity.ColorPalette)
+		}
+	}
+	if completed, ok := args.Bool("completed"); ok {
+		filter.Completed = &completed
+	}
+	filter.Search, _ = args.String("search")
+
+	todos, err := h.todoService.FindTodos(ctx, filter, "")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.TodoResponse, 0, len(todos))
+	for _, todo := range todos {
+		items = append(items, dto.ToTodoResponse(todo))
+	}
+
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+	return todoPage{items: items[start:end], total: len(items), page: page, limit: limit}, nil
+}
+
+// resolveCreateTodo は createTodo ミューテーションです（検証はRESTの POST /api/v1/todos と同じ）
+func (h *GraphQLHandler) resolveCreateTodo(ctx context.Context, source any, args graphql.Args) (any, error) {
+	var req dto.CreateTodoRequest
+	input, _ := args.Object("input")
+	if err := decodeGraphQLInput(input, &req); err != nil {
+		return nil, err
+	}
+	if msg := validateCreateTodoRequest(req); msg != "" {
+		return nil, errors.New(msg)
+	}
+	todo := req.ToEntity()
+	if msg := validateTodoFields(todo); msg != "" {
+		return nil, errors.New(msg)
+	}
+
+	created, err := h.todoService.CreateTodo(ctx, todo)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToTodoResponse(created), nil
+}
+
+// resolveUpdateTodo は updateTodo ミューテーションです（入力に含めたフィールドだけを更新する）
+func (h *GraphQLHandler) resolveUpdateTodo(ctx context.Context, source any, args graphql.Args) (any, error) {
+	id, err := todoIDArg(args)
+	if err != nil {
+		return nil, err
+	}
+	var req dto.UpdateTodoRequest
+	input, _ := args.Object("input")
+	if err := decodeGraphQLInput(input, &req); err != nil {
+		return nil, err
+	}
+
+	todo, err := h.todoService.GetTodoByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	original := *todo
+	req.ApplyToEntity(todo)
+	if todo.Title == "" || len(todo.Title) > entity.MaxTitleLength {
+		return nil, fmt.Errorf("title is required and must be %d characters or less", entity.MaxTitleLength)
+	}
+	if len(todo.Description) > entity.MaxDescriptionLength {
+		return nil, fmt.Errorf("description must be %d characters or less", entity.MaxDescriptionLength)
+	}
+	if msg := validateTodoFields(todo); msg != "" {
+		return nil, errors.New(msg)
+	}
+	if len(entity.DiffTodo(&original, todo)) == 0 {
+		return dto.ToTodoResponse(&original), nil
+	}
+
+	updated, err := h.todoService.UpdateTodo(ctx, todo)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToTodoResponse(updated), nil
+}
+
+// resolveStateChange は completeTodo・incompleteTodo ミューテーションです
+func (h *GraphQLHandler) resolveStateChange(change func(ctx context.Context, id int) (*entity.Todo, error)) graphql.ResolveFunc {
+	return func(ctx context.Context, source any, args graphql.Args) (any, error) {
+		id, err := todoIDArg(args)
+		if err != nil {
+			return nil, err
+		}
+		todo, err := change(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return dto.ToTodoResponse(todo), nil
+	}
+}
+
+// resolveDeleteTodo は deleteTodo ミューテーションです（削除したTodoのIDを返す）
+func (h *GraphQLHandler) resolveDeleteTodo(ctx context.Context, source any, args graphql.Args) (any, error) {
+	id, err := todoIDArg(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.todoService.DeleteTodo(ctx, id); err != nil {
+		return nil, err
+	}
+	return dto.FormatID(id), nil
+}
+
+// decodeGraphQLInput は入力オブジェクト（キャメルケースのキー）を、RESTのリクエストDTOにデコードします
+// キーをDTOのJSONのキー（スネークケース）に変換してからデコードするため、日時の形式などはRESTと同じです
+func decodeGraphQLInput(input map[string]any, dst any) error {
+	known := make(map[string]bool)
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(dst).Elem()) {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		known[name] = true
+	}
+
+	converted := make(map[string]any, len(input))
+	for key, value := range input {
+		name := snakeCase(key)
+		if !known[name] {
+			return fmt.Errorf("unknown input field %q", key)
+		}
+		converted[name] = value
+	}
+
+	data, err := json.Marshal(converted)
+	if err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+	return nil
+}
+
+// snakeCase はキャメルケースの名前をスネークケースにします（isCompleted -> is_completed）
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// postGraphQL はGraphQLのリクエストを POST で送信し、ステータスコードとレスポンスの本文を返します
+func postGraphQL(t *testing.T, h *GraphQLHandler, query string, variables map[string]any) (int, string) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.GraphQL(rec, req)
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+// TestGraphQLHandler_Query は選択したフィールドだけが返ること、絞り込みとページングをテストします
+func TestGraphQLHandler_Query(t *testing.T) {
+	mockService := NewMockTodoService()
+	ctx := context.Background()
+	mockService.CreateTodo(ctx, &entity.Todo{Title: "牛乳を買う", Color: entity.Color("red")})
+	mockService.CreateTodo(ctx, &entity.Todo{Title: "請求書を送る", Description: "月末まで", IsCompleted: true})
+	mockService.CreateTodo(ctx, &entity.Todo{Title: "請求書を確認する"})
+	h := NewGraphQLHandler(mockService)
+
+	tests := []struct {
+		name           string
+		query          string
+		variables      map[string]any
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "IDで取得",
+			query:          `query ($id: ID!) { todo(id: $id) { id title color isCompleted estimateMinutes } }`,
+			variables:      map[string]any{"id": "1"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"todo":{"id":"1","title":"牛乳を買う","color":"red","isCompleted":false,"estimateMinutes":null}}}`,
+		},
+		{
+			name:           "存在しないIDは null",
+			query:          `{ todo(id: 99) { id } }`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"todo":null}}`,
+		},
+		{
+			name:           "完了状態と文字列で絞り込み",
+			query:          `{ todos(completed: true, search: "月末") { totalCount hasNextPage items { title checklistProgress { total } } } }`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"todos":{"totalCount":1,"hasNextPage":false,"items":[{"title":"請求書を送る","checklistProgress":{"total":0}}]}}}`,
+		},
+		{
+			name:           "ページング",
+			query:          `{ todos(search: "請求書", limit: 1, page: 2) { totalCount page limit hasNextPage } stats { total completed } }`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"todos":{"totalCount":2,"page":2,"limit":1,"hasNextPage":false},"stats":{"total":3,"completed":1}}}`,
+		},
+		{
+			name:           "不正な limit はフィールドのエラー",
+			query:          `{ todos(limit: 500) { totalCount } }`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"todos":null},"errors":[{"message":"limit must be between 1 and 100","locations":[{"line":1,"column":3}],"path":["todos"]}]}`,
+		},
+		{
+			name:           "存在しないフィールドはリクエストのエラー",
+			query:          `{ todos { items { owner } } }`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":[{"message":"Cannot query field \"owner\" on type \"Todo\"","locations":[{"line":1,"column":19}]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := postGraphQL(t, h, tt.query, tt.variables)
+			if status != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", status, tt.expectedStatus)
+			}
+			if body != tt.expectedBody {
+				t.Errorf("レスポンス =\n%s\n期待値 =\n%s", body, tt.expectedBody)
+			}
+		})
+	}
+}
+
+// TestGraphQLHandler_Mutations は作成・更新・完了・削除のミューテーションをテストします
+func TestGraphQLHandler_Mutations(t *testing.T) {
+	mockService := NewMockTodoService()
+	h := NewGraphQLHandler(mockService)
+
+	steps := []struct {
+		name         string
+		query        string
+		expectedBody string
+	}{
+		{
+			name:         "作成",
+			query:        `mutation { createTodo(input: {title: "牛乳を買う", color: "Blue", estimateMinutes: 15}) { id title color estimateMinutes } }`,
+			expectedBody: `{"data":{"createTodo":{"id":"1","title":"牛乳を買う","color":"blue","estimateMinutes":15}}}`,
+		},
+		{
+			name:         "作成の検証エラー",
+			query:        `mutation { createTodo(input: {title: ""}) { id } }`,
+			expectedBody: `{"data":{"createTodo":null},"errors":[{"message":"title is required","locations":[{"line":1,"column":12}],"path":["createTodo"]}]}`,
+		},
+		{
+			name:         "未知の入力フィールド",
+			query:        `mutation { createTodo(input: {title: "x", owner: "me"}) { id } }`,
+			expectedBody: `{"data":{"createTodo":null},"errors":[{"message":"unknown input field \"owner\"","locations":[{"line":1,"column":12}],"path":["createTodo"]}]}`,
+		},
+		{
+			name:         "部分更新",
+			query:        `mutation { updateTodo(id: "1", input: {description: "低脂肪", actualMinutes: 20}) { title description actualMinutes } }`,
+			expectedBody: `{"data":{"updateTodo":{"title":"牛乳を買う","description":"低脂肪","actualMinutes":20}}}`,
+		},
+		{
+			name:         "完了",
+			query:        `mutation { completeTodo(id: 1) { isCompleted } }`,
+			expectedBody: `{"data":{"completeTodo":{"isCompleted":true}}}`,
+		},
+		{
+			name:         "削除",
+			query:        `mutation { deleteTodo(id: "1") }`,
+			expectedBody: `{"data":{"deleteTodo":"1"}}`,
+		},
+	}
+
+	for _, step := range steps {
+		status, body := postGraphQL(t, h, step.query, nil)
+		if status != http.StatusOK {
+			t.Errorf("%s: ステータスコード = %v, 期待値 = 200", step.name, status)
+		}
+		if body != step.expectedBody {
+			t.Errorf("%s: レスポンス =\n%s\n期待値 =\n%s", step.name, body, step.expectedBody)
+		}
+	}
+	if len(mockService.todos) != 0 {
+		t.Errorf("削除後に %d 件のTodoが残っています", len(mockService.todos))
+	}
+}
+
+// TestGraphQLHandler_HTTP は GET でのクエリ、GET でのミューテーションの拒否、メソッドと Content-Type の検証をテストします
+func TestGraphQLHandler_HTTP(t *testing.T) {
+	mockService := NewMockTodoService()
+	mockService.CreateTodo(context.Background(), &entity.Todo{Title: "牛乳を買う"})
+	h := NewGraphQLHandler(mockService)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{name: "GETでクエリ", method: http.MethodGet, target: "/graphql?" + url.Values{"query": {`query ($id: ID!) { todo(id: $id) { title } }`}, "variables": {`{"id": 1}`}}.Encode(), expectedStatus: http.StatusOK},
+		{name: "GETでミューテーション", method: http.MethodGet, target: "/graphql?" + url.Values{"query": {`mutation { deleteTodo(id: 1) }`}}.Encode(), expectedStatus: http.StatusBadRequest},
+		{name: "クエリの省略", method: http.MethodGet, target: "/graphql", expectedStatus: http.StatusBadRequest},
+		{name: "JSON以外のボディ", method: http.MethodPost, target: "/graphql", contentType: "text/plain", body: "{ todos { totalCount } }", expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "不正なJSON", method: http.MethodPost, target: "/graphql", contentType: "application/json", body: "{", expectedStatus: http.StatusBadRequest},
+		{name: "許可されていないメソッド", method: http.MethodDelete, target: "/graphql", expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.GraphQL(rec, req)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+	if len(mockService.todos) != 1 {
+		t.Errorf("GET のミューテーションでTodoが削除されました")
+	}
+}
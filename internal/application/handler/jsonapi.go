@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// JSON:API 形式でのレスポンスの書き込みです
+// Accept: application/vnd.api+json を指定したリクエストに、既存のレスポンスDTOを変換して返します（dto/jsonapi.go を参照）
+// エラーレスポンスは JSON:API を指定した場合も従来の形式（application/json）で返します
+
+// wantsJSONAPI はレスポンスを JSON:API 形式で返すべきかを判定します
+// Accept ヘッダーで application/vnd.api+json の品質値が application/json より高い場合のみ JSON:API とします
+// （*/* のみの場合は両者が同じ品質値になるため、従来の JSON を返します）
+func wantsJSONAPI(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+	return acceptQuality(accept, dto.MediaTypeJSONAPI) > acceptQuality(accept, mediaTypeJSON)
+}
+
+// writeJSONAPIResponse は JSON:API のドキュメントを書き込みます
+// 変換に失敗した場合は、従来の形式のエラーレスポンスを返します
+func writeJSONAPIResponse(w http.ResponseWriter, statusCode int, document dto.JSONAPIDocument, err error) {
+	if err != nil {
+		writeServerError(w, "Failed to encode JSON:API document", err)
+		return
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		writeServerError(w, "Failed to encode JSON:API document", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", dto.MediaTypeJSONAPI)
+	w.WriteHeader(statusCode)
+	w.Write(append(data, '\n'))
+}
+
+// writeTodoDocument はTodo1件を JSON:API 形式で返します
+func writeTodoDocument(w http.ResponseWriter, r *http.Request, statusCode int, response dto.TodoResponse) {
+	document, err := dto.NewTodoDocument(response, withBasePath(r, apiV1Path))
+	writeJSONAPIResponse(w, statusCode, document, err)
+}
+
+// writeTodoListDocument はTodo一覧を JSON:API 形式で返します
+// 一覧が複数ページに分かれる場合は、同じ条件で他のページを取得するリンクを links に設定します
+func writeTodoListDocument(w http.ResponseWriter, r *http.Request, statusCode int, response dto.TodoListResponse) {
+	document, err := dto.NewTodoListDocument(response, withBasePath(r, apiV1Path))
+	if err == nil {
+		document.Links = paginationLinks(r, response.Meta.Page, response.Meta.TotalPages)
+	}
+	writeJSONAPIResponse(w, statusCode, document, err)
+}
+
+// paginationLinks はリクエストのURLの page パラメータを置き換えた、ページングのリンクを返します
+// 他のクエリパラメータ（limit や color 等）はそのまま引き継ぎます
+func paginationLinks(r *http.Request, page, totalPages int) map[string]string {
+	link := func(page int) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		return withBasePath(r, r.URL.Path) + "?" + query.Encode()
+	}
+
+	links := map[string]string{"self": link(page)}
+	if totalPages <= 1 {
+		return links
+	}
+	links["first"] = link(1)
+	links["last"] = link(totalPages)
+	if page > 1 {
+		links["prev"] = link(min(page-1, totalPages))
+	}
+	if page < totalPages {
+		links["next"] = link(page + 1)
+	}
+	return links
+}
+
+// writeProjectResponse はネゴシエーション結果に応じて、プロジェクト1件をJSONまたは JSON:API 形式で返します
+func writeProjectResponse(w http.ResponseWriter, r *http.Request, statusCode int, response dto.ProjectResponse) {
+	w.Header().Add("Vary", "Accept")
+
+	if wantsJSONAPI(r) {
+		document, err := dto.NewProjectDocument(response)
+		writeJSONAPIResponse(w, statusCode, document, err)
+		return
+	}
+	writeJSONResponse(w, statusCode, response)
+}
+
+// writeProjectListResponse はネゴシエーション結果に応じて、プロジェクト一覧をJSONまたは JSON:API 形式で返します
+func writeProjectListResponse(w http.ResponseWriter, r *http.Request, statusCode int, response dto.ProjectListResponse) {
+	w.Header().Add("Vary", "Accept")
+
+	if wantsJSONAPI(r) {
+		document, err := dto.NewProjectListDocument(response)
+		writeJSONAPIResponse(w, statusCode, document, err)
+		return
+	}
+	writeJSONResponse(w, statusCode, response)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// TestWantsJSONAPI は Accept ヘッダーによる JSON:API 形式の判定をテストします
+func TestWantsJSONAPI(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		expected bool
+	}{
+		{name: "ヘッダーなし", expected: false},
+		{name: "JSON:APIを要求", accept: "application/vnd.api+json", expected: true},
+		{name: "JSONを要求", accept: "application/json", expected: false},
+		{name: "ワイルドカードのみ", accept: "*/*", expected: false},
+		{name: "JSONを優先", accept: "application/vnd.api+json;q=0.5, application/json", expected: false},
+		{name: "JSON:APIを優先", accept: "application/vnd.api+json, application/json;q=0.5", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if result := wantsJSONAPI(req); result != tt.expected {
+				t.Errorf("wantsJSONAPI() = %v, 期待値 = %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_JSONAPI はTodoの作成・一覧が JSON:API のドキュメントで返されることをテストします
+func TestTodoHandler_JSONAPI(t *testing.T) {
+	handler := NewTodoHandler(NewMockTodoService())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", bytes.NewBufferString(`{"title":"買い物","project_id":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", dto.MediaTypeJSONAPI)
+	rec := httptest.NewRecorder()
+	handler.CreateTodo(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("作成: ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != dto.MediaTypeJSONAPI {
+		t.Errorf("Content-Type = %v, 期待値 = %v", ct, dto.MediaTypeJSONAPI)
+	}
+
+	var single struct {
+		Data struct {
+			Type          string                                   `json:"type"`
+			ID            string                                   `json:"id"`
+			Attributes    map[string]any                           `json:"attributes"`
+			Relationships map[string]struct{ Data map[string]any } `json:"relationships"`
+			Links         map[string]string                        `json:"links"`
+		} `json:"data"`
+		JSONAPI map[string]string `json:"jsonapi"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &single); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if single.Data.Type != "todos" || single.Data.ID != "1" {
+		t.Errorf("type / id = %q / %q, 期待値 = todos / 1", single.Data.Type, single.Data.ID)
+	}
+	if single.Data.Attributes["title"] != "買い物" {
+		t.Errorf("attributes に title がありません: %v", single.Data.Attributes)
+	}
+	for _, key := range []string{"id", "project_id"} {
+		if _, ok := single.Data.Attributes[key]; ok {
+			t.Errorf("attributes に %s が含まれています", key)
+		}
+	}
+	if project := single.Data.Relationships["project"].Data; project["type"] != "projects" || project["id"] != "3" {
+		t.Errorf("relationships.project = %v, 期待値 = {type: projects, id: 3}", project)
+	}
+	if single.Data.Relationships["recurrence_parent"].Data != nil {
+		t.Errorf("関連がない場合は data を null にする必要があります: %v", single.Data.Relationships["recurrence_parent"])
+	}
+	if single.Data.Links["self"] != "/api/v1/todos/1" {
+		t.Errorf("links.self = %q", single.Data.Links["self"])
+	}
+	if single.JSONAPI["version"] == "" {
+		t.Error("jsonapi.version がありません")
+	}
+
+	// 一覧は data が配列になり、ページングのリンクが付く
+	handler.CreateTodo(httptest.NewRecorder(), newJSONRequest(http.MethodPost, "/api/v1/todos", `{"title":"掃除"}`))
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/todos?limit=1&page=2", nil)
+	req.Header.Set("Accept", dto.MediaTypeJSONAPI)
+	rec = httptest.NewRecorder()
+	handler.GetAllTodos(rec, req)
+
+	var list struct {
+		Data  []map[string]any  `json:"data"`
+		Meta  map[string]any    `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if len(list.Data) != 2 || list.Meta["total"] != float64(2) {
+		t.Errorf("一覧の data / meta が正しくありません: %s", rec.Body.String())
+	}
+	if !strings.Contains(list.Links["prev"], "page=1") || !strings.Contains(list.Links["prev"], "limit=1") || list.Links["next"] != "" {
+		t.Errorf("ページングのリンクが正しくありません: %v", list.Links)
+	}
+}
+
+// newJSONRequest はJSONのボディを持つリクエストを作成します
+func newJSONRequest(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"todoapp-api-golang/internal/application/middleware"
+)
+
+// apiV1Path はAPI v1のパスのプレフィックスです
+const apiV1Path = "/api/v1"
+
+// withBasePath はアプリ内の絶対パスに、リクエストのベースパス（例: /todoapp）を付与します
+// レスポンスに含めるリンク（Location ヘッダー、URL フィールド、HTMLの hx-* 属性）は
+// リバースプロキシ配下でもクライアントから辿れるよう、必ずこの関数を通して生成します
+func withBasePath(r *http.Request, path string) string {
+	return middleware.BasePath(r.Context()) + path
+}
+
+// absoluteURL はアプリ内の絶対パスから、スキームとホストを含むURLを組み立てます
+// フィードのように、レスポンスを取得したURLと切り離して読まれるリンクに使用します
+// TLSを終端するリバースプロキシ配下では、X-Forwarded-Proto のスキームを使用します
+func absoluteURL(r *http.Request, path string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + withBasePath(r, path)
+}
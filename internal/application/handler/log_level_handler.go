@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// LogLevelHandler は実行中のログレベルを参照・変更するハンドラーです
+//
+// 対応するエンドポイント：
+// GET /admin/loglevel -> 現在のログレベル
+// PUT /admin/loglevel -> ログレベルの変更（例: {"level":"debug"}）
+//
+// slog.LevelVar はアトミックに読み書きされるため、処理中のリクエストのログ出力と競合せずに
+// 再起動なしで本番環境の障害調査時だけ debug ログを有効にできます
+type LogLevelHandler struct {
+	level *slog.LevelVar
+}
+
+// LogLevelRequest はログレベルの変更リクエストです
+type LogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// LogLevelResponse は現在のログレベルを表すレスポンスです
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+// NewLogLevelHandler はLogLevelHandlerのコンストラクタです
+// level にはロガーの slog.HandlerOptions.Level に設定したものと同じ LevelVar を渡します
+func NewLogLevelHandler(level *slog.LevelVar) *LogLevelHandler {
+	return &LogLevelHandler{
+		level: level,
+	}
+}
+
+// LogLevel はログレベルを返す、または変更します
+// GET /admin/loglevel, PUT /admin/loglevel
+func (h *LogLevelHandler) LogLevel(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSONResponse(w, http.StatusOK, LogLevelResponse{Level: levelName(h.level.Level())})
+	case http.MethodPut:
+		h.setLogLevel(w, r)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// setLogLevel はリクエストで指定されたログレベルに切り替えます
+func (h *LogLevelHandler) setLogLevel(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req LogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	// LOG_LEVEL と同じ4種類のみ受け付ける（slog が解釈できる "info+2" などの表記は不可）
+	var level slog.Level
+	switch strings.ToLower(req.Level) {
+	case "debug":
+		level = slog.LevelDebug
+	case "info":
+		level = slog.LevelInfo
+	case "warn":
+		level = slog.LevelWarn
+	case "error":
+		level = slog.LevelError
+	default:
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid log level", "level must be debug, info, warn, or error")
+		return
+	}
+
+	previous := h.level.Level()
+	h.level.Set(level)
+	// 変更自体は新旧どちらのレベルでも残るよう WARN で記録する
+	slog.Warn("log level changed", "from", levelName(previous), "to", levelName(level), "remote_addr", r.RemoteAddr)
+
+	writeJSONResponse(w, http.StatusOK, LogLevelResponse{Level: levelName(level)})
+}
+
+// levelName はログレベルを LOG_LEVEL と同じ小文字の名前で返します
+func levelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
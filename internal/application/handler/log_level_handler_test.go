@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestLogLevelHandler はログレベルの参照と変更をテストします
+func TestLogLevelHandler(t *testing.T) {
+	level := new(slog.LevelVar)
+	handler := NewLogLevelHandler(level)
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedLevel  slog.Level
+	}{
+		{name: "現在のレベル", method: http.MethodGet, expectedStatus: http.StatusOK, expectedLevel: slog.LevelInfo},
+		{name: "debugに変更", method: http.MethodPut, body: `{"level":"debug"}`, expectedStatus: http.StatusOK, expectedLevel: slog.LevelDebug},
+		{name: "大文字も受け付ける", method: http.MethodPut, body: `{"level":"WARN"}`, expectedStatus: http.StatusOK, expectedLevel: slog.LevelWarn},
+		{name: "未知のレベル", method: http.MethodPut, body: `{"level":"trace"}`, expectedStatus: http.StatusBadRequest, expectedLevel: slog.LevelWarn},
+		{name: "slog独自の表記", method: http.MethodPut, body: `{"level":"info+2"}`, expectedStatus: http.StatusBadRequest, expectedLevel: slog.LevelWarn},
+		{name: "不正なJSON", method: http.MethodPut, body: `{`, expectedStatus: http.StatusBadRequest, expectedLevel: slog.LevelWarn},
+		{name: "許可されていないメソッド", method: http.MethodPost, body: `{"level":"error"}`, expectedStatus: http.StatusMethodNotAllowed, expectedLevel: slog.LevelWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/admin/loglevel", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.LogLevel(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if got := level.Level(); got != tt.expectedLevel {
+				t.Errorf("ログレベル = %v, 期待値 = %v", got, tt.expectedLevel)
+			}
+
+			if rec.Code == http.StatusOK {
+				var response LogLevelResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+					t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+				}
+				if response.Level != levelName(tt.expectedLevel) {
+					t.Errorf("レスポンスのレベル = %q, 期待値 = %q", response.Level, levelName(tt.expectedLevel))
+				}
+			}
+			if rec.Code == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != "GET, PUT" {
+				t.Errorf("Allow = %q", rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
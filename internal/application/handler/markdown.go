@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"html"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// MarkdownRenderer はTodoの説明（Markdown）をHTMLに変換するインターフェースです
+//
+// 学習ポイント：
+// ハンドラーは変換の方法を知らず、このインターフェースだけに依存します
+// 実装（internal/infrastructure/markdown）を差し替えても、ハンドラーやテストを変更する必要はありません
+//
+// 実装は、ブラウザにそのまま埋め込んでも安全なHTML（サニタイズ済み）を返す必要があります
+type MarkdownRenderer interface {
+	Render(markdown string) string
+}
+
+// plainTextRenderer は MarkdownRenderer が設定されていない場合に使用する実装です
+// 記法は解釈せず、エスケープしたテキストを段落として返します
+type plainTextRenderer struct{}
+
+// Render は説明をエスケープし、空行で区切られた段落ごとに <p> で囲みます
+func (plainTextRenderer) Render(text string) string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, "<p>"+html.EscapeString(paragraph)+"</p>")
+		}
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+// TodoHandlerOption はTodoHandlerに任意の機能を設定する関数型オプションです
+type TodoHandlerOption func(*TodoHandler)
+
+// WithMarkdownRenderer は ?render=html で説明をHTMLに変換する MarkdownRenderer を設定します
+// 設定しない場合は記法を解釈せず、エスケープしたテキストを返します
+func WithMarkdownRenderer(renderer MarkdownRenderer) TodoHandlerOption {
+	return func(h *TodoHandler) {
+		h.markdown = renderer
+	}
+}
+
+// renderParam は説明の変換を指定するクエリパラメータです
+const renderParam = "render"
+
+// parseRenderParam は ?render= の値を解析し、説明をHTMLに変換するかどうかを返します
+// 対応していない値の場合は 400 を書き込み、ok に false を返します
+//
+// 作成・更新の前に呼び出し、不正な値のリクエストで変更だけが行われることを防ぎます
+func parseRenderParam(w http.ResponseWriter, r *http.Request) (render bool, ok bool) {
+	switch r.URL.Query().Get(renderParam) {
+	case "":
+		return false, true
+	case "html":
+		return true, true
+	}
+	writeErrorResponse(w, http.StatusBadRequest, "Invalid render option", "render must be html")
+	return false, false
+}
+
+// renderDescription は render が true の場合に、説明を変換したHTMLをレスポンスに設定します
+func (h *TodoHandler) renderDescription(render bool, todo *dto.TodoResponse) {
+	if render {
+		todo.DescriptionHTML = h.markdown.Render(todo.Description)
+	}
+}
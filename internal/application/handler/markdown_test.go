@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// stubMarkdownRenderer は受け取った説明を目印で囲んで返す MarkdownRenderer です
+type stubMarkdownRenderer struct{}
+
+func (stubMarkdownRenderer) Render(markdown string) string {
+	return "<rendered>" + markdown + "</rendered>"
+}
+
+// TestTodoHandler_RenderDescription は ?render=html による説明のHTML変換をテストします
+func TestTodoHandler_RenderDescription(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []TodoHandlerOption
+		target       string
+		description  string
+		expectedCode int
+		expectedHTML string
+	}{
+		{
+			name: "指定なしの場合は変換しない", opts: []TodoHandlerOption{WithMarkdownRenderer(stubMarkdownRenderer{})},
+			target: "/api/v1/todos/1", description: "**重要**", expectedCode: http.StatusOK, expectedHTML: "",
+		},
+		{
+			name: "設定したレンダラーで変換", opts: []TodoHandlerOption{WithMarkdownRenderer(stubMarkdownRenderer{})},
+			target: "/api/v1/todos/1?render=html", description: "**重要**", expectedCode: http.StatusOK, expectedHTML: "<rendered>**重要**</rendered>",
+		},
+		{
+			name:   "レンダラー未設定の場合はエスケープしたテキスト",
+			target: "/api/v1/todos/1?render=html", description: "<b>a</b>\n\nb", expectedCode: http.StatusOK, expectedHTML: "<p>&lt;b&gt;a&lt;/b&gt;</p>\n<p>b</p>",
+		},
+		{
+			name: "説明が空の場合は省略", opts: []TodoHandlerOption{WithMarkdownRenderer(plainTextRenderer{})},
+			target: "/api/v1/todos/1?render=html", description: "", expectedCode: http.StatusOK, expectedHTML: "",
+		},
+		{
+			name:   "対応していない値",
+			target: "/api/v1/todos/1?render=pdf", description: "a", expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockTodoService()
+			mockService.todos[1] = &entity.Todo{ID: 1, Title: "テスト", Description: tt.description}
+			handler := NewTodoHandler(mockService, tt.opts...)
+
+			rec := httptest.NewRecorder()
+			handler.GetTodoByID(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.expectedCode {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedCode, rec.Body.String())
+			}
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+			var response dto.TodoResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response.DescriptionHTML != tt.expectedHTML {
+				t.Errorf("description_html = %q, 期待値 = %q", response.DescriptionHTML, tt.expectedHTML)
+			}
+			if response.Description != tt.description {
+				t.Errorf("description = %q, 期待値 = %q（元のMarkdownも返す）", response.Description, tt.description)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_RenderDescription_List は一覧でも全てのTodoの説明が変換されることをテストします
+func TestTodoHandler_RenderDescription_List(t *testing.T) {
+	mockService := NewMockTodoService()
+	mockService.todos[1] = &entity.Todo{ID: 1, Title: "a", Description: "一つ目"}
+	mockService.todos[2] = &entity.Todo{ID: 2, Title: "b", Description: "二つ目"}
+	handler := NewTodoHandler(mockService, WithMarkdownRenderer(stubMarkdownRenderer{}))
+
+	rec := httptest.NewRecorder()
+	handler.GetAllTodos(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos?render=html", nil))
+
+	var response dto.TodoListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if len(response.Todos) != 2 {
+		t.Fatalf("件数 = %d, 期待値 = 2", len(response.Todos))
+	}
+	for _, todo := range response.Todos {
+		if todo.DescriptionHTML != "<rendered>"+todo.Description+"</rendered>" {
+			t.Errorf("ID %v の description_html = %q", todo.ID, todo.DescriptionHTML)
+		}
+	}
+}
+
+// TestTodoHandler_RenderDescription_InvalidBeforeCreate は不正な ?render= の場合にTodoを作成しないことをテストします
+func TestTodoHandler_RenderDescription_InvalidBeforeCreate(t *testing.T) {
+	mockService := NewMockTodoService()
+	handler := NewTodoHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos?render=markdown", strings.NewReader(`{"title":"作成"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.CreateTodo(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusBadRequest)
+	}
+	if mockService.callCounts["CreateTodo"] != 0 {
+		t.Errorf("CreateTodo の呼び出し回数 = %d, 期待値 = 0", mockService.callCounts["CreateTodo"])
+	}
+}
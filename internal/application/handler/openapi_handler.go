@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// swaggerUIVersion は Swagger UI（swagger-ui-dist）のバージョンです
+// 更新する場合は、画面の表示と「Try it out」の動作を確認してください
+const swaggerUIVersion = "5.17.14"
+
+// swaggerUIPage は API 仕様書を対話的に確認できる Swagger UI のページです
+// ページ自体はバイナリに埋め込み、Swagger UI のスクリプトとスタイルはバージョンを固定したCDNから読み込みます
+// 仕様書のURLは相対パスのため、ベースパス（BASE_PATH）の配下でもそのまま動作します
+var swaggerUIPage = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Todo API - API仕様書</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@{{.Version}}/swagger-ui.css" crossorigin="anonymous">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js" crossorigin="anonymous"></script>
+  <script>
+    window.ui = SwaggerUIBundle({
+      url: {{.SpecURL}},
+      dom_id: "#swagger-ui",
+      deepLinking: true
+    });
+  </script>
+</body>
+</html>
+`))
+
+// OpenAPIHandler はAPI仕様書（OpenAPI 3.1）とその閲覧用の Swagger UI を公開するハンドラーです
+// GET /api/v1/openapi.json と GET /api/v1/docs へのリクエストを処理します
+//
+// 仕様書は api/openapi.json を起動時に読み込んだもので、契約テストで実装との一致を確認しています
+// 内容はデプロイ単位でしか変わらないため、ETag による条件付きリクエストで再取得を省けるようにします
+type OpenAPIHandler struct {
+	spec []byte
+	etag string
+	page []byte
+}
+
+// OpenAPIHandlerOption はOpenAPIHandlerの任意の設定を行う関数型オプションです
+type OpenAPIHandlerOption func(*openAPIHandlerConfig)
+
+// openAPIHandlerConfig はオプションで設定する値です（仕様書の加工は構築時に1回だけ行います）
+type openAPIHandlerConfig struct {
+	serverURL string
+}
+
+// WithOpenAPIServerURL は仕様書の servers に公開先のURL（例: /todoapp）を設定します
+// ベースパスの配下で公開する場合に設定すると、Swagger UI の「Try it out」が正しいURLへリクエストを送ります
+func WithOpenAPIServerURL(url string) OpenAPIHandlerOption {
+	return func(cfg *openAPIHandlerConfig) {
+		cfg.serverURL = url
+	}
+}
+
+// NewOpenAPIHandler はOpenAPIHandlerのコンストラクタです
+// spec が JSON として読み込めない場合はエラーを返します
+func NewOpenAPIHandler(spec []byte, opts ...OpenAPIHandlerOption) (*OpenAPIHandler, error) {
+	var cfg openAPIHandlerConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(spec, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	if cfg.serverURL != "" {
+		document["servers"] = []map[string]string{{"url": cfg.serverURL}}
+		var err error
+		if spec, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+		}
+	}
+
+	var page bytes.Buffer
+	if err := swaggerUIPage.Execute(&page, struct{ Version, SpecURL string }{swaggerUIVersion, "openapi.json"}); err != nil {
+		return nil, fmt.Errorf("failed to render Swagger UI page: %w", err)
+	}
+
+	sum := sha256.Sum256(spec)
+	return &OpenAPIHandler{
+		spec: spec,
+		etag: `"` + hex.EncodeToString(sum[:8]) + `"`,
+		page: page.Bytes(),
+	}, nil
+}
+
+// Spec はAPI仕様書（JSON）を返します
+func (h *OpenAPIHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	header := w.Header()
+	header.Set("ETag", h.etag)
+	// 毎回ETagで再検証させ、デプロイ後に古い仕様書が使われないようにする
+	header.Set("Cache-Control", "no-cache")
+	if etagListContains(r.Header.Get("If-None-Match"), h.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Type", "application/json")
+	header.Set("Content-Length", strconv.Itoa(len(h.spec)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(h.spec)
+}
+
+// SwaggerUI は仕様書を閲覧・試行できる Swagger UI のページを返します
+func (h *OpenAPIHandler) SwaggerUI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/html; charset=utf-8")
+	header.Set("Content-Length", strconv.Itoa(len(h.page)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(h.page)
+}
+
+// etagListContains は If-None-Match の値（カンマ区切りのETag、または *）に etag が含まれるかを判定します
+// 弱いETag（W/ 付き）も同じものとして比較します（RFC 9110 の弱い比較）
+func etagListContains(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
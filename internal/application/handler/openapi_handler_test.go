@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testOpenAPISpec = `{"openapi":"3.1.0","info":{"title":"Todo API","version":"1.0.0"},"paths":{}}`
+
+// TestOpenAPIHandler_Spec はAPI仕様書の配信をテストします
+func TestOpenAPIHandler_Spec(t *testing.T) {
+	handler, err := NewOpenAPIHandler([]byte(testOpenAPISpec))
+	if err != nil {
+		t.Fatalf("NewOpenAPIHandler() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	handler.Spec(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+	if rec.Body.String() != testOpenAPISpec {
+		t.Errorf("servers を指定しない場合は仕様書をそのまま返す必要があります: %s", rec.Body.String())
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag ヘッダーがありません")
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		ifNoneMatch    string
+		expectedStatus int
+		expectBody     bool
+	}{
+		{name: "同じETag", method: http.MethodGet, ifNoneMatch: etag, expectedStatus: http.StatusNotModified},
+		{name: "弱いETag", method: http.MethodGet, ifNoneMatch: `"other", W/` + etag, expectedStatus: http.StatusNotModified},
+		{name: "異なるETag", method: http.MethodGet, ifNoneMatch: `"other"`, expectedStatus: http.StatusOK, expectBody: true},
+		{name: "HEAD", method: http.MethodHead, expectedStatus: http.StatusOK},
+		{name: "不正なHTTPメソッド", method: http.MethodPost, expectedStatus: http.StatusMethodNotAllowed, expectBody: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/openapi.json", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			handler.Spec(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if hasBody := rec.Body.Len() > 0; hasBody != tt.expectBody {
+				t.Errorf("ボディの有無 = %v, 期待値 = %v", hasBody, tt.expectBody)
+			}
+		})
+	}
+}
+
+// TestOpenAPIHandler_ServerURL はベースパスの配下で公開する場合に servers が設定されることをテストします
+func TestOpenAPIHandler_ServerURL(t *testing.T) {
+	handler, err := NewOpenAPIHandler([]byte(testOpenAPISpec), WithOpenAPIServerURL("/todoapp"))
+	if err != nil {
+		t.Fatalf("NewOpenAPIHandler() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.Spec(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+
+	var document struct {
+		OpenAPI string              `json:"openapi"`
+		Servers []map[string]string `json:"servers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &document); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if document.OpenAPI != "3.1.0" {
+		t.Errorf("元の仕様書の内容が失われています: %s", rec.Body.String())
+	}
+	if len(document.Servers) != 1 || document.Servers[0]["url"] != "/todoapp" {
+		t.Errorf("servers = %v, 期待値 = [{url: /todoapp}]", document.Servers)
+	}
+}
+
+// TestOpenAPIHandler_SwaggerUI は Swagger UI のページが仕様書を相対パスで参照することをテストします
+func TestOpenAPIHandler_SwaggerUI(t *testing.T) {
+	handler, err := NewOpenAPIHandler([]byte(testOpenAPISpec))
+	if err != nil {
+		t.Fatalf("NewOpenAPIHandler() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.SwaggerUI(rec, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `url: "openapi.json"`) {
+		t.Errorf("ベースパスの配下でも動作するよう、仕様書は相対パスで参照する必要があります:\n%s", body)
+	}
+	if !strings.Contains(body, "swagger-ui-dist@"+swaggerUIVersion) {
+		t.Error("Swagger UI のバージョンが固定されていません")
+	}
+}
+
+// TestNewOpenAPIHandler_InvalidSpec は読み込めない仕様書を起動時に検出することをテストします
+func TestNewOpenAPIHandler_InvalidSpec(t *testing.T) {
+	if _, err := NewOpenAPIHandler([]byte(`{`)); err == nil {
+		t.Error("不正なJSONの仕様書でエラーになりませんでした")
+	}
+}
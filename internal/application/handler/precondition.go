@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// 条件付きリクエスト（If-Match）による更新の競合の検出です
+//
+// 学習ポイント：
+//  1. Todoのレスポンスには、その時点の内容を表す ETag ヘッダーを付ける
+//  2. クライアントは更新・削除のリクエストに、取得時の ETag を If-Match で付けて送る
+//  3. 他のクライアントが先に変更していた場合は ETag が一致しないため、上書きせずに 412 Precondition Failed を返す
+//     （クライアントは最新の内容を取得し直し、変更を反映してから再送信する）
+//
+// ETag は内容のハッシュから作るため、更新日時の精度（データベースによっては秒単位）に関係なく変更を検出できます
+
+// WithIfMatchRequired は更新・削除に If-Match を必須にします（付けていない場合は 428 Precondition Required）
+// 設定しない場合、If-Match を付けたリクエストだけを検証します
+func WithIfMatchRequired() TodoHandlerOption {
+	return func(h *TodoHandler) {
+		h.requireIfMatch = true
+	}
+}
+
+// todoETag はTodoのレスポンスの内容から ETag（強いETag）を作成します
+// 表示用の説明のHTMLとメタ情報（サーバー時刻など）は、Todoの内容ではないため含めません
+// 日時は保存先による精度の違い（作成直後はナノ秒、読み込み後は秒など）で変わらないよう、UTCの秒単位に揃えます
+func todoETag(response dto.TodoResponse) string {
+	response.DescriptionHTML = ""
+	response.Meta = nil
+	response.CreatedAt = canonicalTimestamp(response.CreatedAt)
+	response.UpdatedAt = canonicalTimestamp(response.UpdatedAt)
+	if response.RemindAt != nil {
+		remindAt := canonicalTimestamp(*response.RemindAt)
+		response.RemindAt = &remindAt
+	}
+	if response.DueDate != nil {
+		dueDate := canonicalTimestamp(*response.DueDate)
+		response.DueDate = &dueDate
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// canonicalTimestamp は日時をUTCの秒単位に揃えます
+func canonicalTimestamp(t dto.Timestamp) dto.Timestamp {
+	return dto.NewTimestamp(t.UTC().Truncate(time.Second))
+}
+
+// hasIfMatch は If-Match の検証が必要かどうか（ヘッダーがある、または必須にしている）を返します
+// 検証が不要な場合は、現在のTodoを取得せずに処理できます
+func (h *TodoHandler) hasIfMatch(r *http.Request) bool {
+	return h.requireIfMatch || r.Header.Get("If-Match") != ""
+}
+
+// checkIfMatch は If-Match を現在のTodoの ETag と比較します
+// 条件を満たさない場合はエラーレスポンスを書き込み、false を返します
+func (h *TodoHandler) checkIfMatch(w http.ResponseWriter, r *http.Request, current *entity.Todo) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		if h.requireIfMatch {
+			writeErrorResponse(w, http.StatusPreconditionRequired, "Precondition required", "If-Match header with the ETag of the todo is required")
+			return false
+		}
+		return true
+	}
+
+	if !etagMatches(ifMatch, todoETag(dto.ToTodoResponse(current))) {
+		writeErrorResponse(w, http.StatusPreconditionFailed, "Precondition failed", "the todo has been modified by another request; fetch it again and retry")
+		return false
+	}
+	return true
+}
+
+// etagMatches は If-Match の値（カンマ区切りのETag、または *）に etag が含まれるかを判定します
+// If-Match は強い比較のため、弱いETag（W/ 付き）は一致しません（RFC 9110）
+func etagMatches(ifMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
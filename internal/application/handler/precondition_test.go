@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestTodoHandler_IfMatch は If-Match による更新・削除の競合の検出をテストします
+func TestTodoHandler_IfMatch(t *testing.T) {
+	const staleETag = `"0123456789abcdef0123456789abcdef"`
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		ifMatch        string // "current" の場合は取得時の ETag を付ける
+		require        bool
+		expectedStatus int
+		expectedCall   string // 実行される（または実行されない）サービスのメソッド
+		expectCalled   bool
+	}{
+		{name: "更新: 一致するETag", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新"}`, ifMatch: "current", expectedStatus: http.StatusOK, expectedCall: "UpdateTodo", expectCalled: true},
+		{name: "更新: 古いETagは412", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新"}`, ifMatch: staleETag, expectedStatus: http.StatusPreconditionFailed, expectedCall: "UpdateTodo"},
+		{name: "更新: 複数のETagのいずれかに一致", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新"}`, ifMatch: staleETag + ", current", expectedStatus: http.StatusOK, expectedCall: "UpdateTodo", expectCalled: true},
+		{name: "更新: * は存在すれば一致", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新"}`, ifMatch: "*", expectedStatus: http.StatusOK, expectedCall: "UpdateTodo", expectCalled: true},
+		{name: "更新: 弱いETagは一致しない", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新"}`, ifMatch: "W/current", expectedStatus: http.StatusPreconditionFailed, expectedCall: "UpdateTodo"},
+		{name: "更新: 付けない場合は検証しない", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新"}`, expectedStatus: http.StatusOK, expectedCall: "UpdateTodo", expectCalled: true},
+		{name: "更新: 必須の場合に付けないと428", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新"}`, require: true, expectedStatus: http.StatusPreconditionRequired, expectedCall: "UpdateTodo"},
+		{name: "更新: 存在しないTodoは404", method: http.MethodPut, path: "/api/v1/todos/99", body: `{"title":"更新"}`, ifMatch: "*", expectedStatus: http.StatusNotFound, expectedCall: "UpdateTodo"},
+		{name: "完了: 古いETagは412", method: http.MethodPatch, path: "/api/v1/todos/1/complete", ifMatch: staleETag, expectedStatus: http.StatusPreconditionFailed, expectedCall: "CompleteTodo"},
+		{name: "完了: 一致するETag", method: http.MethodPatch, path: "/api/v1/todos/1/complete", ifMatch: "current", expectedStatus: http.StatusOK, expectedCall: "CompleteTodo", expectCalled: true},
+		{name: "完了: 必須の場合に付けないと428", method: http.MethodPatch, path: "/api/v1/todos/1/complete", require: true, expectedStatus: http.StatusPreconditionRequired, expectedCall: "CompleteTodo"},
+		{name: "削除: 古いETagは412", method: http.MethodDelete, path: "/api/v1/todos/1", ifMatch: staleETag, expectedStatus: http.StatusPreconditionFailed, expectedCall: "DeleteTodo"},
+		{name: "削除: 一致するETag", method: http.MethodDelete, path: "/api/v1/todos/1", ifMatch: "current", expectedStatus: http.StatusNoContent, expectedCall: "DeleteTodo", expectCalled: true},
+		{name: "削除: 必須の場合に付けないと428", method: http.MethodDelete, path: "/api/v1/todos/1", require: true, expectedStatus: http.StatusPreconditionRequired, expectedCall: "DeleteTodo"},
+		{name: "削除: 存在しないTodoは404", method: http.MethodDelete, path: "/api/v1/todos/99", ifMatch: staleETag, expectedStatus: http.StatusNotFound, expectedCall: "DeleteTodo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockTodoService()
+			now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+			mockService.todos[1] = &entity.Todo{ID: 1, Title: "テスト", CreatedAt: now, UpdatedAt: now}
+
+			var opts []TodoHandlerOption
+			if tt.require {
+				opts = append(opts, WithIfMatchRequired())
+			}
+			handler := NewTodoHandler(mockService, opts...)
+
+			// 取得時の ETag
+			getRec := httptest.NewRecorder()
+			handler.GetTodoByID(getRec, httptest.NewRequest(http.MethodGet, "/api/v1/todos/1", nil))
+			etag := getRec.Header().Get("ETag")
+			if etag == "" {
+				t.Fatal("GETのレスポンスに ETag がない")
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", strings.ReplaceAll(tt.ifMatch, "current", etag))
+			}
+			rec := httptest.NewRecorder()
+			switch tt.method {
+			case http.MethodPut:
+				handler.UpdateTodo(rec, req)
+			case http.MethodPatch:
+				handler.CompleteTodo(rec, req)
+			case http.MethodDelete:
+				handler.DeleteTodo(rec, req)
+			}
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if called := mockService.callCounts[tt.expectedCall] > 0; called != tt.expectCalled {
+				t.Errorf("%s の呼び出し = %v, 期待値 = %v", tt.expectedCall, called, tt.expectCalled)
+			}
+			if rec.Code == http.StatusOK {
+				if got := rec.Header().Get("ETag"); got == "" || got == etag {
+					t.Errorf("変更後の ETag = %q, 変更前の %q と異なる値を期待", got, etag)
+				}
+			}
+		})
+	}
+}
+
+// TestTodoETag は ETag が内容の変更だけで変わることをテストします
+func TestTodoETag(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	todo := &entity.Todo{ID: 1, Title: "テスト", CreatedAt: base, UpdatedAt: base}
+	etag := todoETag(dto.ToTodoResponse(todo))
+
+	tests := []struct {
+		name       string
+		modify     func(*entity.Todo)
+		expectSame bool
+	}{
+		{name: "タイトルの変更", modify: func(t *entity.Todo) { t.Title = "変更" }},
+		{name: "完了状態の変更", modify: func(t *entity.Todo) { t.IsCompleted = true }},
+		{name: "更新日時の変更", modify: func(t *entity.Todo) { t.UpdatedAt = base.Add(time.Second) }},
+		{name: "日時の秒未満の違い（保存先の精度）", modify: func(t *entity.Todo) { t.UpdatedAt = base.Add(123456 * time.Nanosecond) }, expectSame: true},
+		{name: "タイムゾーンの違い", modify: func(t *entity.Todo) { t.CreatedAt = base.In(time.FixedZone("JST", 9*60*60)) }, expectSame: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modified := *todo
+			tt.modify(&modified)
+			response := dto.ToTodoResponse(&modified)
+			response.DescriptionHTML = "<p>表示用</p>"
+			if got := todoETag(response); (got == etag) != tt.expectSame {
+				t.Errorf("ETag = %s, 変更前 = %s, 同じ値 = %v を期待", got, etag, tt.expectSame)
+			}
+		})
+	}
+}
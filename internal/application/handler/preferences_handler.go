@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// PreferencesHandler は認証されたユーザー自身の設定のHTTPリクエストを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET /api/v1/me/preferences -> 設定の取得（保存していない場合は既定の設定）
+// PUT /api/v1/me/preferences -> 設定の置き換え
+//
+// 保存したタイムゾーンは期限の一覧（today・upcoming）の tz の既定値に、
+// 並び順は /api/v1/todos の一覧の並び順に使われます
+type PreferencesHandler struct {
+	preferencesService service.PreferencesServiceInterface
+}
+
+// WithTodoPreferences はTodo一覧（GET /api/v1/todos）を、ユーザーが保存した並び順で返すようにします
+func WithTodoPreferences(preferencesService service.PreferencesServiceInterface) TodoHandlerOption {
+	return func(h *TodoHandler) {
+		h.preferencesService = preferencesService
+	}
+}
+
+// NewPreferencesHandler はPreferencesHandlerのコンストラクタです
+func NewPreferencesHandler(preferencesService service.PreferencesServiceInterface) *PreferencesHandler {
+	return &PreferencesHandler{
+		preferencesService: preferencesService,
+	}
+}
+
+// GetPreferences は認証されたユーザーの設定を返します
+// GET /api/v1/me/preferences
+func (h *PreferencesHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	preferences, err := h.preferencesService.Get(r.Context())
+	if err != nil {
+		writePreferencesServiceError(w, "Failed to get preferences", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToPreferencesResponse(preferences))
+}
+
+// UpdatePreferences は認証されたユーザーの設定を置き換えます
+// PUT /api/v1/me/preferences
+// ボディ: {"timezone": "Asia/Tokyo", "sort_order": "due_date", "notifications": {"reminders": true}}
+func (h *PreferencesHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.PreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	preferences, err := h.preferencesService.Update(r.Context(), req.ToEntity())
+	if err != nil {
+		writePreferencesServiceError(w, "Failed to update preferences", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToPreferencesResponse(preferences))
+}
+
+// writePreferencesServiceError はサービス層のエラーをHTTPステータスに変換して返します
+func writePreferencesServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, service.ErrPreferencesRequireUser):
+		writeErrorResponse(w, http.StatusUnauthorized, "Unauthorized", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid preferences", err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
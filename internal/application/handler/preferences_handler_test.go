@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// MockPreferencesService はテスト用のPreferencesServiceのモック実装です
+type MockPreferencesService struct {
+	preferences *entity.Preferences
+	anonymous   bool
+}
+
+func (m *MockPreferencesService) Get(ctx context.Context) (*entity.Preferences, error) {
+	if m.preferences == nil {
+		return entity.DefaultPreferences(1), nil
+	}
+	return m.preferences, nil
+}
+
+func (m *MockPreferencesService) Update(ctx context.Context, preferences *entity.Preferences) (*entity.Preferences, error) {
+	if m.anonymous {
+		return nil, service.ErrPreferencesRequireUser
+	}
+	if _, err := time.LoadLocation(preferences.Timezone); err != nil {
+		return nil, domainerr.Invalid("timezone", err.Error())
+	}
+	preferences.UserID = 1
+	m.preferences = preferences
+	return preferences, nil
+}
+
+// TestPreferencesHandler_UpdatePreferences は設定の置き換えとエラーの変換をテストします
+func TestPreferencesHandler_UpdatePreferences(t *testing.T) {
+	tests := []struct {
+		name              string
+		body              string
+		anonymous         bool
+		expectedStatus    int
+		expectedTimezone  string
+		expectedSortOrder string
+	}{
+		{name: "全ての項目を指定", body: `{"timezone":"Asia/Tokyo","sort_order":"title","notifications":{"reminders":false}}`, expectedStatus: http.StatusOK, expectedTimezone: "Asia/Tokyo", expectedSortOrder: "title"},
+		{name: "省略した項目は既定値", body: `{}`, expectedStatus: http.StatusOK, expectedTimezone: "UTC", expectedSortOrder: "-created_at"},
+		{name: "不明なタイムゾーン", body: `{"timezone":"Mars/Olympus"}`, expectedStatus: http.StatusBadRequest},
+		{name: "不正なJSON", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "ユーザーを特定できない", body: `{}`, anonymous: true, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewPreferencesHandler(&MockPreferencesService{anonymous: tt.anonymous})
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/me/preferences", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.UpdatePreferences(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v (body: %s)", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.PreferencesResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response.Timezone != tt.expectedTimezone || response.SortOrder != tt.expectedSortOrder {
+				t.Errorf("設定 = %+v, 期待値 = %s / %s", response, tt.expectedTimezone, tt.expectedSortOrder)
+			}
+		})
+	}
+}
+
+// TestPreferencesHandler_StoredPreferences は保存した設定が期限の一覧とTodo一覧に使われることをテストします
+func TestPreferencesHandler_StoredPreferences(t *testing.T) {
+	preferencesService := &MockPreferencesService{preferences: &entity.Preferences{
+		UserID:    1,
+		Timezone:  "Asia/Tokyo",
+		SortOrder: entity.TodoSortTitle,
+	}}
+
+	t.Run("tz を省略した場合は保存したタイムゾーン", func(t *testing.T) {
+		handler := NewDueDateHandler(&MockDueDateService{}, WithDueDatePreferences(preferencesService))
+		for path, expected := range map[string]string{
+			"/api/v1/todos/today":                     "Asia/Tokyo",
+			"/api/v1/todos/today?tz=America/New_York": "America/New_York",
+			"/api/v1/todos/upcoming":                  "Asia/Tokyo",
+			"/api/v1/todos/upcoming?tz=Europe/London": "Europe/London",
+		} {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if strings.Contains(path, "upcoming") {
+				handler.ListUpcoming(rec, req)
+			} else {
+				handler.ListDueToday(rec, req)
+			}
+
+			var response dto.TodoListResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("%s: レスポンスのJSONパースに失敗: %v", path, err)
+			}
+			if got := response.Meta.Filters["tz"]; got != expected {
+				t.Errorf("%s: tz = %q, 期待値 = %q", path, got, expected)
+			}
+		}
+	})
+
+	t.Run("Todo一覧は保存した並び順", func(t *testing.T) {
+		todoService := NewMockTodoService()
+		for i, title := range []string{"Charlie", "alpha", "Bravo"} {
+			todoService.todos[i+1] = &entity.Todo{ID: i + 1, Title: title}
+		}
+		handler := NewTodoHandler(todoService, WithTodoPreferences(preferencesService))
+		rec := httptest.NewRecorder()
+		handler.GetAllTodos(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil))
+
+		var response dto.TodoListResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+		}
+		if response.Meta.Sort != "title" {
+			t.Errorf("meta.sort = %q, 期待値 = %q", response.Meta.Sort, "title")
+		}
+		var titles []string
+		for _, todo := range response.Todos {
+			titles = append(titles, todo.Title)
+		}
+		if strings.Join(titles, ",") != "alpha,Bravo,Charlie" {
+			t.Errorf("並び順 = %v, 期待値 = [alpha Bravo Charlie]", titles)
+		}
+	})
+}
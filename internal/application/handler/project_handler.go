@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// ProjectHandler はプロジェクト関連のHTTPリクエストを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET  /api/v1/projects               -> 一覧取得
+// POST /api/v1/projects               -> 作成（スラッグ自動生成）
+// GET  /api/v1/projects/{id|slug}     -> 詳細取得（IDまたはスラッグ）
+// POST /api/v1/projects/{id|slug}/archive -> アーカイブ（Todoを既定の一覧から除外）
+// POST /api/v1/projects/{id|slug}/restore -> アーカイブの解除
+type ProjectHandler struct {
+	projectService service.ProjectServiceInterface
+}
+
+// NewProjectHandler はProjectHandlerのコンストラクタです
+func NewProjectHandler(projectService service.ProjectServiceInterface) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+	}
+}
+
+// CreateProject は新しいプロジェクトを作成します
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	if strings.TrimSpace(req.Name) == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "name is required")
+		return
+	}
+	if len(req.Name) > entity.MaxProjectNameLength {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", fmt.Sprintf("name must be %d characters or less", entity.MaxProjectNameLength))
+		return
+	}
+
+	created, err := h.projectService.CreateProject(r.Context(), req.ToEntity())
+	if err != nil {
+		writeServerError(w, "Failed to create project", err)
+		return
+	}
+
+	// 作成したリソースの場所をスラッグのURLで通知
+	response := dto.ToProjectResponse(created)
+	response.URL = withBasePath(r, response.URL)
+	w.Header().Set("Location", response.URL)
+	writeProjectResponse(w, r, http.StatusCreated, response)
+}
+
+// GetAllProjects は全てのプロジェクトを返します
+func (h *ProjectHandler) GetAllProjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projects, err := h.projectService.GetAllProjects(r.Context())
+	if err != nil {
+		writeServerError(w, "Failed to get projects", err)
+		return
+	}
+
+	response := dto.ToProjectListResponse(projects)
+	for i := range response.Projects {
+		response.Projects[i].URL = withBasePath(r, response.Projects[i].URL)
+	}
+	writeProjectListResponse(w, r, http.StatusOK, response)
+}
+
+// GetProject はIDまたはスラッグで指定されたプロジェクトを返します
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// パスの構造: /api/v1/projects/{id|slug}
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", "project ID or slug is required")
+		return
+	}
+
+	key, ok := projectKey(pathParts[3])
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "Project not found", "")
+		return
+	}
+
+	project, err := h.projectService.GetProject(r.Context(), key)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			writeErrorResponse(w, http.StatusNotFound, "Project not found", "")
+		} else {
+			writeServerError(w, "Failed to get project", err)
+		}
+		return
+	}
+
+	response := dto.ToProjectResponse(project)
+	response.URL = withBasePath(r, response.URL)
+	writeProjectResponse(w, r, http.StatusOK, response)
+}
+
+// ArchiveProject はプロジェクトをアーカイブし、アーカイブ後のプロジェクトを返します
+// 既にアーカイブ済みの場合も成功として扱います
+func (h *ProjectHandler) ArchiveProject(w http.ResponseWriter, r *http.Request) {
+	h.changeArchiveState(w, r, h.projectService.ArchiveProject, "Failed to archive project")
+}
+
+// RestoreProject はアーカイブしたプロジェクトを元に戻し、復元後のプロジェクトを返します
+// アーカイブされていない場合も成功として扱います
+func (h *ProjectHandler) RestoreProject(w http.ResponseWriter, r *http.Request) {
+	h.changeArchiveState(w, r, h.projectService.RestoreProject, "Failed to restore project")
+}
+
+// changeArchiveState はアーカイブ・復元に共通の処理です
+// パスの構造: /api/v1/projects/{id|slug}/{archive|restore}
+func (h *ProjectHandler) changeArchiveState(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, idOrSlug string) (*entity.Project, error), failure string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[3] == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", "project ID or slug is required")
+		return
+	}
+
+	key, ok := projectKey(pathParts[3])
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "Project not found", "")
+		return
+	}
+
+	project, err := change(r.Context(), key)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			writeErrorResponse(w, http.StatusNotFound, "Project not found", "")
+		} else {
+			writeServerError(w, failure, err)
+		}
+		return
+	}
+
+	response := dto.ToProjectResponse(project)
+	response.URL = withBasePath(r, response.URL)
+	writeProjectResponse(w, r, http.StatusOK, response)
+}
+
+// projectKey はパスのIDまたはスラッグを、サービスに渡す形式（整数のIDの文字列またはスラッグ）に変換します
+// IDを不透明な文字列で公開している場合（dto.Encoding.IDCodec）、連番の整数では参照できないよう ok を false にします
+func projectKey(idOrSlug string) (key string, ok bool) {
+	if dto.Encoding.IDCodec == nil {
+		return idOrSlug, true
+	}
+	if id, err := dto.ParseID(idOrSlug); err == nil {
+		return strconv.Itoa(id), true
+	}
+	if _, err := strconv.Atoi(idOrSlug); err == nil {
+		return "", false
+	}
+	return idOrSlug, true
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockProjectService はテスト用のProjectServiceのモック実装です
+type MockProjectService struct {
+	projects []*entity.Project
+}
+
+func (m *MockProjectService) CreateProject(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	project.ID = len(m.projects) + 1
+	project.Slug = entity.GenerateSlug(project.Name)
+	saved := *project
+	m.projects = append(m.projects, &saved)
+	return &saved, nil
+}
+
+func (m *MockProjectService) GetProject(ctx context.Context, idOrSlug string) (*entity.Project, error) {
+	for _, p := range m.projects {
+		if p.Slug == idOrSlug || strconv.Itoa(p.ID) == idOrSlug {
+			result := *p
+			return &result, nil
+		}
+	}
+	return nil, domainerr.NotFound("project", nil)
+}
+
+func (m *MockProjectService) GetAllProjects(ctx context.Context) ([]*entity.Project, error) {
+	return m.projects, nil
+}
+
+func (m *MockProjectService) ArchiveProject(ctx context.Context, idOrSlug string) (*entity.Project, error) {
+	return m.setArchivedAt(idOrSlug, &time.Time{})
+}
+
+func (m *MockProjectService) RestoreProject(ctx context.Context, idOrSlug string) (*entity.Project, error) {
+	return m.setArchivedAt(idOrSlug, nil)
+}
+
+func (m *MockProjectService) setArchivedAt(idOrSlug string, archivedAt *time.Time) (*entity.Project, error) {
+	for _, p := range m.projects {
+		if p.Slug == idOrSlug || strconv.Itoa(p.ID) == idOrSlug {
+			p.ArchivedAt = archivedAt
+			result := *p
+			return &result, nil
+		}
+	}
+	return nil, domainerr.NotFound("project", nil)
+}
+
+// TestProjectHandler_CreateAndGet はプロジェクト作成とスラッグによる取得をテストします
+func TestProjectHandler_CreateAndGet(t *testing.T) {
+	handler := NewProjectHandler(&MockProjectService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/projects", bytes.NewBufferString(`{"name":"Home Tasks"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.CreateProject(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("作成: ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusCreated)
+	}
+
+	var created dto.ProjectResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if created.Slug != "home-tasks" {
+		t.Errorf("スラッグ = %v, 期待値 = %v", created.Slug, "home-tasks")
+	}
+	if got := rec.Header().Get("Location"); got != "/api/v1/projects/home-tasks" {
+		t.Errorf("Location = %v, 期待値 = %v", got, "/api/v1/projects/home-tasks")
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "スラッグで取得", path: "/api/v1/projects/home-tasks", expectedStatus: http.StatusOK},
+		{name: "IDで取得", path: "/api/v1/projects/1", expectedStatus: http.StatusOK},
+		{name: "存在しないスラッグ", path: "/api/v1/projects/unknown", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+			handler.GetProject(rec, req)
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+// TestProjectHandler_CreateValidation は不正な名前での作成が400になることをテストします
+func TestProjectHandler_CreateValidation(t *testing.T) {
+	handler := NewProjectHandler(&MockProjectService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/projects", bytes.NewBufferString(`{"name":"  "}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.CreateProject(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// TestProjectHandler_ArchiveAndRestore はアーカイブと復元のエンドポイントをテストします
+func TestProjectHandler_ArchiveAndRestore(t *testing.T) {
+	service := &MockProjectService{}
+	service.CreateProject(context.Background(), &entity.Project{Name: "Home Tasks"})
+	handler := NewProjectHandler(service)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		restore        bool
+		expectedStatus int
+		wantArchived   bool
+	}{
+		{name: "アーカイブ", method: http.MethodPost, path: "/api/v1/projects/home-tasks/archive", expectedStatus: http.StatusOK, wantArchived: true},
+		{name: "IDで復元", method: http.MethodPost, path: "/api/v1/projects/1/restore", restore: true, expectedStatus: http.StatusOK},
+		{name: "存在しないプロジェクト", method: http.MethodPost, path: "/api/v1/projects/unknown/archive", expectedStatus: http.StatusNotFound},
+		{name: "不正なHTTPメソッド", method: http.MethodGet, path: "/api/v1/projects/home-tasks/archive", expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			rec := httptest.NewRecorder()
+			if tt.restore {
+				handler.RestoreProject(rec, req)
+			} else {
+				handler.ArchiveProject(rec, req)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.ProjectResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if archived := response.ArchivedAt != nil; archived != tt.wantArchived {
+				t.Errorf("archived_at の有無 = %v, 期待値 = %v", archived, tt.wantArchived)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// ReminderHandler はTodoのリマインダー操作のHTTPリクエストを処理するハンドラーです
+// リマインダーの設定自体は Todo の作成・更新時に remind_at で行います
+//
+// 対応するエンドポイント：
+// POST   /api/v1/todos/{id}/reminder/snooze -> スヌーズ（通知時刻の延期）
+// DELETE /api/v1/todos/{id}/reminder        -> リマインダーの解除
+type ReminderHandler struct {
+	reminderService service.ReminderServiceInterface
+}
+
+// NewReminderHandler はReminderHandlerのコンストラクタです
+func NewReminderHandler(reminderService service.ReminderServiceInterface) *ReminderHandler {
+	return &ReminderHandler{
+		reminderService: reminderService,
+	}
+}
+
+// SnoozeReminder はリマインダーの通知時刻を延期します
+// POST /api/v1/todos/{id}/reminder/snooze
+// ボディは省略可能で、省略時は既定の時間だけ延期します
+func (h *ReminderHandler) SnoozeReminder(w http.ResponseWriter, r *http.Request) {
+	todoID, err := parseReminderPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	var req dto.SnoozeReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	until, err := req.ResolveUntil(dto.Now())
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	todo, err := h.reminderService.Snooze(r.Context(), todoID, until)
+	if err != nil {
+		writeReminderServiceError(w, "Failed to snooze reminder", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToTodoResponse(todo))
+}
+
+// CancelReminder はリマインダーを解除します
+// DELETE /api/v1/todos/{id}/reminder
+func (h *ReminderHandler) CancelReminder(w http.ResponseWriter, r *http.Request) {
+	todoID, err := parseReminderPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	if err := h.reminderService.Cancel(r.Context(), todoID); err != nil {
+		writeReminderServiceError(w, "Failed to cancel reminder", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseReminderPath はURLパスからTodoIDを抽出します
+// パスの構造: /api/v1/todos/{id}/reminder[/snooze]
+func parseReminderPath(path string) (int, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[4] != "reminder" {
+		return 0, errors.New("invalid endpoint")
+	}
+
+	todoID, err := dto.ParseID(pathParts[3])
+	if err != nil {
+		return 0, errors.New("todo ID must be a number")
+	}
+
+	return todoID, nil
+}
+
+// writeReminderServiceError はサービス層のエラーを適切なHTTPステータスに変換して書き込みます
+func writeReminderServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case domainerr.IsNotFound(err):
+		writeErrorResponse(w, http.StatusNotFound, "Todo not found", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, message, err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
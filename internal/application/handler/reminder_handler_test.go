@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockReminderService はテスト用のReminderServiceのモック実装です
+// TodoID=1 のTodoのみ存在する前提で動作します
+type MockReminderService struct {
+	lastUntil time.Time
+}
+
+func (m *MockReminderService) DispatchDue(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *MockReminderService) Snooze(ctx context.Context, todoID int, until time.Time) (*entity.Todo, error) {
+	if todoID != 1 {
+		return nil, domainerr.NotFound("todo", 2).Wrap(domainerr.NotFound("todo", nil))
+	}
+	m.lastUntil = until
+	todo := &entity.Todo{ID: todoID, Title: "リマインダー"}
+	todo.ScheduleReminder(until)
+	return todo, nil
+}
+
+func (m *MockReminderService) Cancel(ctx context.Context, todoID int) error {
+	if todoID != 1 {
+		return domainerr.NotFound("todo", nil)
+	}
+	return nil
+}
+
+// TestReminderHandler_Snooze はスヌーズの延期先の決定とエラー応答をテストします
+func TestReminderHandler_Snooze(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	originalNow := dto.Now
+	dto.Now = func() time.Time { return now }
+	defer func() { dto.Now = originalNow }()
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		expectedStatus int
+		expectedUntil  time.Time
+	}{
+		{name: "ボディ省略時は既定の時間", path: "/api/v1/todos/1/reminder/snooze", body: "", expectedStatus: http.StatusOK, expectedUntil: now.Add(dto.DefaultSnoozeMinutes * time.Minute)},
+		{name: "分で指定", path: "/api/v1/todos/1/reminder/snooze", body: `{"minutes":30}`, expectedStatus: http.StatusOK, expectedUntil: now.Add(30 * time.Minute)},
+		{name: "日時で指定", path: "/api/v1/todos/1/reminder/snooze", body: `{"until":"2024-05-02T08:00:00Z"}`, expectedStatus: http.StatusOK, expectedUntil: time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)},
+		{name: "両方指定", path: "/api/v1/todos/1/reminder/snooze", body: `{"minutes":30,"until":"2024-05-02T08:00:00Z"}`, expectedStatus: http.StatusBadRequest},
+		{name: "0分", path: "/api/v1/todos/1/reminder/snooze", body: `{"minutes":0}`, expectedStatus: http.StatusBadRequest},
+		{name: "存在しないTodo", path: "/api/v1/todos/2/reminder/snooze", body: "", expectedStatus: http.StatusNotFound},
+		{name: "数値でないID", path: "/api/v1/todos/abc/reminder/snooze", body: "", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &MockReminderService{}
+			handler := NewReminderHandler(service)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			handler.SnoozeReminder(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response dto.TodoResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response.RemindAt == nil || !response.RemindAt.Equal(tt.expectedUntil) {
+				t.Errorf("remind_at = %v, 期待値 = %v", response.RemindAt, tt.expectedUntil)
+			}
+		})
+	}
+}
+
+// TestReminderHandler_Cancel はリマインダー解除の応答をテストします
+func TestReminderHandler_Cancel(t *testing.T) {
+	handler := NewReminderHandler(&MockReminderService{})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "正常な解除", path: "/api/v1/todos/1/reminder", expectedStatus: http.StatusNoContent},
+		{name: "存在しないTodo", path: "/api/v1/todos/2/reminder", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			handler.CancelReminder(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
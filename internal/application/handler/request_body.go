@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// リクエストボディの受信が打ち切られた場合のエラーコードです（dto.ErrorResponse の code）
+const (
+	// errorCodeBodyTooLarge はボディが上限のバイト数を超えた場合のコードです（413）
+	errorCodeBodyTooLarge = "body_too_large"
+
+	// errorCodeBodyTooSlow はボディが期限内に届かなかった場合のコードです（408）
+	errorCodeBodyTooSlow = "body_too_slow"
+)
+
+// isBodyLimitError はリクエストボディの受信が RequestBodyMiddleware の上限・期限で打ち切られたかどうかを判定します
+func isBodyLimitError(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge) || errors.Is(err, os.ErrDeadlineExceeded)
+}
+
+// writeBodyDecodeError はリクエストボディのデコードに失敗した場合のエラーレスポンスを返します
+// RequestBodyMiddleware による打ち切りは、形式の誤りの 400 と区別して 413 / 408 で返します
+func writeBodyDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeJSONResponse(w, http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Error:   "Request body too large",
+			Code:    errorCodeBodyTooLarge,
+			Details: fmt.Sprintf("request body must be %d bytes or less", tooLarge.Limit),
+		})
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		// ボディの残りは届かないため、接続を使い回さずに閉じる
+		w.Header().Set("Connection", "close")
+		writeJSONResponse(w, http.StatusRequestTimeout, dto.ErrorResponse{
+			Error:   "Request body too slow",
+			Code:    errorCodeBodyTooSlow,
+			Details: err.Error(),
+		})
+	default:
+		writeErrorResponse(w, http.StatusBadRequest, invalidBodyMessage(r), err.Error())
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// TestWriteBodyDecodeError はボディの打ち切りの理由ごとのエラーレスポンスをテストします
+func TestWriteBodyDecodeError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "大きすぎる", err: &http.MaxBytesError{Limit: 1024}, expectedStatus: http.StatusRequestEntityTooLarge, expectedCode: "body_too_large"},
+		{name: "遅すぎる", err: fmt.Errorf("request body was not received within 10s: %w", os.ErrDeadlineExceeded), expectedStatus: http.StatusRequestTimeout, expectedCode: "body_too_slow"},
+		{name: "形式の誤り", err: errors.New("unexpected EOF"), expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", nil)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			writeBodyDecodeError(rec, req, tt.err)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			var response dto.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response.Code != tt.expectedCode {
+				t.Errorf("エラーコード = %q, 期待値 = %q", response.Code, tt.expectedCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// SchemaHandler はリソースのフィールド制約（バリデーションスキーマ）を公開するハンドラーです
+// GET /api/v1/schema/{resource} へのリクエストを処理します
+//
+// サービス層に依存しない読み取り専用のハンドラーのため、依存関係はありません
+type SchemaHandler struct{}
+
+// NewSchemaHandler はSchemaHandlerのコンストラクタです
+func NewSchemaHandler() *SchemaHandler {
+	return &SchemaHandler{}
+}
+
+// GetSchema は指定されたリソースのスキーマを返します
+func (h *SchemaHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// パスの構造: /api/v1/schema/{resource}
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 4 || pathParts[3] == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", "resource name is required")
+		return
+	}
+
+	schema, ok := dto.LookupResourceSchema(pathParts[3])
+	if !ok {
+		writeErrorResponse(w, http.StatusNotFound, "Schema not found", "unknown resource: "+pathParts[3])
+		return
+	}
+
+	// スキーマはデプロイ単位でしか変わらないため、短時間のキャッシュを許可
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSONResponse(w, http.StatusOK, schema)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestSchemaHandler_GetSchema はスキーマ公開エンドポイントをテストします
+func TestSchemaHandler_GetSchema(t *testing.T) {
+	handler := NewSchemaHandler()
+
+	t.Run("Todoのスキーマ取得", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/schema/todo", nil)
+		rec := httptest.NewRecorder()
+		handler.GetSchema(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+		}
+
+		var schema dto.ResourceSchema
+		if err := json.Unmarshal(rec.Body.Bytes(), &schema); err != nil {
+			t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+		}
+
+		// バリデーション層の定数と一致していることを確認
+		for _, field := range schema.Fields {
+			if field.Name == "title" {
+				if !field.Required || field.MaxLength == nil || *field.MaxLength != entity.MaxTitleLength {
+					t.Errorf("title の制約が正しくありません: %+v", field)
+				}
+				return
+			}
+		}
+		t.Error("title フィールドがスキーマに含まれていません")
+	})
+
+	t.Run("未知のリソース", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/schema/unknown", nil)
+		rec := httptest.NewRecorder()
+		handler.GetSchema(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("不正なHTTPメソッド", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/schema/todo", nil)
+		rec := httptest.NewRecorder()
+		handler.GetSchema(rec, req)
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// RequireScope は認証されたユーザーのアクセストークンに scope が許可されているかを確認します
+// 許可されていない場合は 403 Forbidden を書き込み、false を返します
+//
+// scope が空の場合は、スコープを限定していないトークン（ログイン・セッション）だけを許可します
+// 認証が無効な場合（コンテキストにユーザーがない場合）は確認せずに true を返します
+//
+// 403 には RFC 6750 3.1節の WWW-Authenticate（error="insufficient_scope"）を付け、必要なスコープを知らせます
+func RequireScope(w http.ResponseWriter, r *http.Request, scope string) bool {
+	principal, ok := service.PrincipalFromContext(r.Context())
+	if !ok || principal.HasScope(scope) {
+		return true
+	}
+
+	if scope == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="insufficient_scope"`)
+		writeErrorResponse(w, http.StatusForbidden, "Forbidden", "this endpoint is not available to scoped access tokens")
+		return false
+	}
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="api", error="insufficient_scope", scope=%q`, scope))
+	writeErrorResponse(w, http.StatusForbidden, "Forbidden", fmt.Sprintf("access token does not have the required scope %q", scope))
+	return false
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TestRequireScope はアクセストークンのスコープの確認をテストします
+func TestRequireScope(t *testing.T) {
+	readOnly := service.Principal{UserID: 1, Username: "alice", Scopes: []string{entity.ScopeTodosRead}}
+	unrestricted := service.Principal{UserID: 1, Username: "alice"}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		scope          string
+		expectedStatus int
+		expectedAllow  bool
+	}{
+		{name: "認証なし", ctx: context.Background(), scope: entity.ScopeTodosWrite, expectedStatus: http.StatusOK, expectedAllow: true},
+		{name: "限定なしのトークン", ctx: service.WithPrincipal(context.Background(), unrestricted), scope: entity.ScopeTodosWrite, expectedStatus: http.StatusOK, expectedAllow: true},
+		{name: "許可されたスコープ", ctx: service.WithPrincipal(context.Background(), readOnly), scope: entity.ScopeTodosRead, expectedStatus: http.StatusOK, expectedAllow: true},
+		{name: "許可されていないスコープ", ctx: service.WithPrincipal(context.Background(), readOnly), scope: entity.ScopeTodosWrite, expectedStatus: http.StatusForbidden},
+		{name: "スコープのない操作", ctx: service.WithPrincipal(context.Background(), readOnly), scope: "", expectedStatus: http.StatusForbidden},
+		{name: "限定なしのトークンでスコープのない操作", ctx: service.WithPrincipal(context.Background(), unrestricted), scope: "", expectedStatus: http.StatusOK, expectedAllow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", nil).WithContext(tt.ctx)
+			rec := httptest.NewRecorder()
+
+			if allowed := RequireScope(rec, req, tt.scope); allowed != tt.expectedAllow {
+				t.Errorf("RequireScope() = %v, 期待値 = %v", allowed, tt.expectedAllow)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code == http.StatusForbidden && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("403 には WWW-Authenticate ヘッダーが必要です")
+			}
+		})
+	}
+}
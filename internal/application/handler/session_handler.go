@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/application/middleware"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// SessionHandler はCookieのセッションによるログイン・ログアウトを行うハンドラーです
+// アクセストークンの代わりに、ブラウザからCookieだけで認証するための方式です
+//
+// 対応するエンドポイント：
+// POST   /api/v1/auth/session -> ログイン（セッションIDを Set-Cookie で返す）
+// DELETE /api/v1/auth/session -> ログアウト（セッションを削除し、Cookieを消す）
+//
+// セッションIDのCookieには次の属性を付けます
+//   - HttpOnly: JavaScript から読めないようにする（XSS でセッションIDを盗まれないため）
+//   - Secure: HTTPS の接続でだけ送る（開発環境の HTTP では無効にする）
+//   - SameSite=Lax: 他のサイトからの POST などにCookieを付けない（CSRF の緩和）
+type SessionHandler struct {
+	sessionService service.SessionServiceInterface
+	cookiePath     string
+	secure         bool
+}
+
+// NewSessionHandler はSessionHandlerのコンストラクタです
+// cookiePath はCookieを送るパス（ベースパスの配下で動かす場合はそのパス）、secure はCookieに Secure 属性を付けるかです
+func NewSessionHandler(sessionService service.SessionServiceInterface, cookiePath string, secure bool) *SessionHandler {
+	if cookiePath == "" {
+		cookiePath = "/"
+	}
+	return &SessionHandler{
+		sessionService: sessionService,
+		cookiePath:     cookiePath,
+		secure:         secure,
+	}
+}
+
+// Login はユーザー名とパスワードを照合し、セッションのCookieを設定します
+// POST /api/v1/auth/session
+func (h *SessionHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req dto.CredentialsRequest
+	if !decodeAuthRequest(w, r, &req) {
+		return
+	}
+
+	sessionID, session, err := h.sessionService.Login(r.Context(), req.Username, req.Password)
+	if err != nil {
+		writeAuthServiceError(w, "Failed to log in", err)
+		return
+	}
+
+	h.setCookie(w, sessionID, session.ExpiresAt)
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSONResponse(w, http.StatusOK, dto.ToSessionResponse(session))
+}
+
+// Logout はセッションを削除し、Cookieを消します
+// Cookieがない・セッションが既にない場合も 204 を返します
+// DELETE /api/v1/auth/session
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(middleware.SessionCookieName); err == nil && cookie.Value != "" {
+		if err := h.sessionService.Logout(r.Context(), cookie.Value); err != nil {
+			writeAuthServiceError(w, "Failed to log out", err)
+			return
+		}
+	}
+
+	h.setCookie(w, "", time.Time{})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setCookie はセッションIDのCookieを設定します（sessionID が空の場合はCookieを削除する）
+func (h *SessionHandler) setCookie(w http.ResponseWriter, sessionID string, expiresAt time.Time) {
+	cookie := &http.Cookie{
+		Name:     middleware.SessionCookieName,
+		Value:    sessionID,
+		Path:     h.cookiePath,
+		HttpOnly: true,
+		Secure:   h.secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if sessionID == "" {
+		cookie.MaxAge = -1
+	} else {
+		cookie.Expires = expiresAt
+	}
+	http.SetCookie(w, cookie)
+}
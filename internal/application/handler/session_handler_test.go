@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/middleware"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// MockSessionService はテスト用のSessionServiceのモック実装です
+// ユーザー alice（パスワード "correct horse"）だけがログインでき、ログアウトしたセッションIDを記録します
+type MockSessionService struct {
+	loggedOut []string
+}
+
+func (m *MockSessionService) Login(ctx context.Context, username, password string) (string, *entity.Session, error) {
+	if username != "alice" || password != "correct horse" {
+		return "", nil, service.ErrInvalidCredentials
+	}
+	return "session-id", &entity.Session{UserID: 1, Username: "alice", ExpiresAt: time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)}, nil
+}
+
+func (m *MockSessionService) VerifySession(ctx context.Context, sessionID string) (service.Principal, error) {
+	return service.Principal{UserID: 1, Username: "alice"}, nil
+}
+
+func (m *MockSessionService) Logout(ctx context.Context, sessionID string) error {
+	m.loggedOut = append(m.loggedOut, sessionID)
+	return nil
+}
+
+func (m *MockSessionService) PurgeExpired(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+// TestSessionHandler_Login はログインの成功時のCookieの属性と、失敗時のステータスコードをテストします
+func TestSessionHandler_Login(t *testing.T) {
+	h := NewSessionHandler(&MockSessionService{}, "/todoapp/", true)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "ログイン", body: `{"username":"alice","password":"correct horse"}`, expectedStatus: http.StatusOK},
+		{name: "パスワードの誤り", body: `{"username":"alice","password":"wrong"}`, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/session", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.Login(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			cookies := rec.Result().Cookies()
+			if rec.Code != http.StatusOK {
+				if len(cookies) != 0 {
+					t.Errorf("失敗時のCookie = %v, 期待値 = なし", cookies)
+				}
+				return
+			}
+
+			if len(cookies) != 1 {
+				t.Fatalf("Cookie = %v, 期待値 = 1件", cookies)
+			}
+			c := cookies[0]
+			if c.Name != middleware.SessionCookieName || c.Value != "session-id" || c.Path != "/todoapp/" ||
+				!c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteLaxMode {
+				t.Errorf("Cookie = %+v, 期待値 = HttpOnly・Secure・SameSite=Lax のセッションID", c)
+			}
+			if strings.Contains(rec.Body.String(), "session-id") {
+				t.Errorf("レスポンスのボディにセッションIDが含まれています: %s", rec.Body.String())
+			}
+			if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+				t.Errorf("Cache-Control = %q, 期待値 = no-store", got)
+			}
+		})
+	}
+}
+
+// TestSessionHandler_Logout はログアウトでセッションを削除し、Cookieを消すことをテストします
+func TestSessionHandler_Logout(t *testing.T) {
+	sessions := &MockSessionService{}
+	h := NewSessionHandler(sessions, "", false)
+
+	for _, cookie := range []string{"session-id", ""} {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/auth/session", nil)
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: cookie})
+		}
+		rec := httptest.NewRecorder()
+		h.Logout(rec, req)
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("Cookie %q のステータスコード = %v, 期待値 = 204", cookie, rec.Code)
+		}
+		if cookies := rec.Result().Cookies(); len(cookies) != 1 || cookies[0].MaxAge >= 0 || cookies[0].Path != "/" {
+			t.Errorf("Cookie %q の Set-Cookie = %v, 期待値 = Cookieの削除", cookie, cookies)
+		}
+	}
+	if len(sessions.loggedOut) != 1 || sessions.loggedOut[0] != "session-id" {
+		t.Errorf("削除したセッション = %v, 期待値 = [session-id]", sessions.loggedOut)
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TagHandler はTodoのタグを一括で操作するハンドラーです
+//
+// 対応するエンドポイント：
+// POST   /api/v1/tags/{tag}/todos -> 選択したTodoにタグを追加
+// DELETE /api/v1/tags/{tag}/todos -> 選択したTodoからタグを削除
+// POST   /api/v1/tags/merge       -> タグの統合
+//
+// 変更はTodoの更新として1つのトランザクションで保存され、Todoごとに変更履歴へ記録されます
+type TagHandler struct {
+	tagService service.TagServiceInterface
+}
+
+// NewTagHandler はTagHandlerのコンストラクタです
+func NewTagHandler(tagService service.TagServiceInterface) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// AddTag は選択したTodoにタグを追加します
+// POST /api/v1/tags/{tag}/todos
+func (h *TagHandler) AddTag(w http.ResponseWriter, r *http.Request) {
+	tag, sel, ok := parseBulkTagRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.tagService.AddTagToTodos(r.Context(), tag, sel)
+	if err != nil {
+		writeTagServiceError(w, "Failed to add tag", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toBulkTagResponse(entity.NormalizeTag(tag), "", result))
+}
+
+// RemoveTag は選択したTodoからタグを削除します
+// DELETE /api/v1/tags/{tag}/todos
+func (h *TagHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	tag, sel, ok := parseBulkTagRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.tagService.RemoveTagFromTodos(r.Context(), tag, sel)
+	if err != nil {
+		writeTagServiceError(w, "Failed to remove tag", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toBulkTagResponse(entity.NormalizeTag(tag), "", result))
+}
+
+// MergeTags はタグ source の付いた全てのTodoで、source を target に置き換えます
+// POST /api/v1/tags/merge
+func (h *TagHandler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	if requestMediaType(r) != mediaTypeJSON {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.MergeTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+	if strings.TrimSpace(req.Source) == "" || strings.TrimSpace(req.Target) == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "source and target are required")
+		return
+	}
+
+	result, err := h.tagService.MergeTags(r.Context(), req.Source, req.Target)
+	if err != nil {
+		writeTagServiceError(w, "Failed to merge tags", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, toBulkTagResponse(entity.NormalizeTag(req.Target), entity.NormalizeTag(req.Source), result))
+}
+
+// parseBulkTagRequest はURLパスのタグと、リクエストボディの対象の指定を解析します
+// 解析に失敗した場合はエラーレスポンスを書き込み、ok に false を返します
+func parseBulkTagRequest(w http.ResponseWriter, r *http.Request) (string, service.TodoSelection, bool) {
+	tag, err := parseTagPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return "", service.TodoSelection{}, false
+	}
+
+	if requestMediaType(r) != mediaTypeJSON {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return "", service.TodoSelection{}, false
+	}
+
+	var req dto.BulkTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return "", service.TodoSelection{}, false
+	}
+
+	sel, msg := toTodoSelection(req)
+	if msg != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", msg)
+		return "", service.TodoSelection{}, false
+	}
+	return tag, sel, true
+}
+
+// toTodoSelection はリクエストの対象の指定を検証し、サービス層の TodoSelection に変換します
+// 検証に失敗した場合は理由を返します
+func toTodoSelection(req dto.BulkTagRequest) (service.TodoSelection, string) {
+	var sel service.TodoSelection
+	switch {
+	case len(req.IDs) > 0 && req.Filter != nil:
+		return sel, "specify either ids or filter, not both"
+	case len(req.IDs) > dto.MaxBatchSize:
+		return sel, fmt.Sprintf("ids may contain at most %d items", dto.MaxBatchSize)
+	case len(req.IDs) > 0:
+		sel.IDs = make([]int, len(req.IDs))
+		for i, id := range req.IDs {
+			if id <= 0 {
+				return sel, "ids must be greater than 0"
+			}
+			sel.IDs[i] = int(id)
+		}
+		return sel, ""
+	case req.Filter == nil:
+		return sel, "ids or filter is required"
+	}
+
+	if req.Filter.Color != "" {
+		sel.Color = entity.NormalizeColor(req.Filter.Color)
+		if !sel.Color.IsValid() {
+			return sel, fmt.Sprintf("filter.color must be one of %v or a hex color such as #1e90ff", entity.ColorPalette)
+		}
+	}
+	sel.Completed = req.Filter.IsCompleted
+	if req.Filter.Tag != "" {
+		sel.Tag = entity.NormalizeTag(req.Filter.Tag)
+		if !entity.IsValidTag(sel.Tag) {
+			return sel, "filter.tag is not a valid tag"
+		}
+	}
+	if sel.IsEmpty() {
+		return sel, "filter must have at least one condition"
+	}
+	return sel, ""
+}
+
+// parseTagPath はURLパスからタグを抽出します
+// パスの構造: /api/v1/tags/{tag}/todos
+func parseTagPath(path string) (string, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) != 5 || pathParts[2] != "tags" || pathParts[4] != "todos" {
+		return "", errors.New("invalid endpoint")
+	}
+	return pathParts[3], nil
+}
+
+// toBulkTagResponse は一括タグ操作の結果をレスポンスDTOに変換します
+func toBulkTagResponse(tag, mergedFrom string, result *service.BulkTagResult) dto.BulkTagResponse {
+	todos := make([]dto.TodoResponse, len(result.Updated))
+	for i, todo := range result.Updated {
+		todos[i] = dto.ToTodoResponse(todo)
+	}
+	return dto.BulkTagResponse{
+		Tag:        tag,
+		MergedFrom: mergedFrom,
+		Matched:    result.Matched,
+		Updated:    len(result.Updated),
+		Todos:      todos,
+		Meta:       dto.NewResponseMeta(),
+	}
+}
+
+// writeTagServiceError はサービス層のエラーをHTTPステータスに変換して返します
+// 存在しないTodoを含む場合は、どのTodoも変更されていません
+func writeTagServiceError(w http.ResponseWriter, message string, err error) {
+	var batchErr *service.BatchError
+	switch {
+	case errors.As(err, &batchErr):
+		writeErrorResponse(w, http.StatusNotFound, "Todo not found", err.Error())
+	case errors.Is(err, service.ErrInvalidTag):
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid tag", err.Error())
+	case errors.Is(err, service.ErrTooManyTags):
+		writeErrorResponse(w, http.StatusUnprocessableEntity, "Too many tags", err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
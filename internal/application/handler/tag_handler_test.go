@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// MockTagService はテスト用のタグの一括操作のモック実装です
+// ID=1 のTodoのみ存在する前提で動作し、最後に受け取った対象の指定を記録します
+type MockTagService struct {
+	lastSelection service.TodoSelection
+}
+
+func (m *MockTagService) AddTagToTodos(ctx context.Context, tag string, sel service.TodoSelection) (*service.BulkTagResult, error) {
+	m.lastSelection = sel
+	if !entity.IsValidTag(entity.NormalizeTag(tag)) {
+		return nil, fmt.Errorf("%w: %q", service.ErrInvalidTag, tag)
+	}
+	for i, id := range sel.IDs {
+		if id != 1 {
+			return nil, &service.BatchError{Items: []*service.BatchItemError{{Index: i, ID: id, Err: domainerr.NotFound("todo", id)}}}
+		}
+	}
+	todo := &entity.Todo{ID: 1, Title: "買い物", Tags: []string{entity.NormalizeTag(tag)}}
+	return &service.BulkTagResult{Matched: 1, Updated: []*entity.Todo{todo}}, nil
+}
+
+func (m *MockTagService) RemoveTagFromTodos(ctx context.Context, tag string, sel service.TodoSelection) (*service.BulkTagResult, error) {
+	m.lastSelection = sel
+	return &service.BulkTagResult{Matched: 1}, nil
+}
+
+func (m *MockTagService) MergeTags(ctx context.Context, source, target string) (*service.BulkTagResult, error) {
+	if entity.NormalizeTag(source) == entity.NormalizeTag(target) {
+		return nil, fmt.Errorf("%w: cannot merge tag %q into itself", service.ErrInvalidTag, source)
+	}
+	todo := &entity.Todo{ID: 1, Title: "買い物", Tags: []string{entity.NormalizeTag(target)}}
+	return &service.BulkTagResult{Matched: 1, Updated: []*entity.Todo{todo}}, nil
+}
+
+// TestTagHandler はタグの一括操作のリクエストの検証とレスポンスをテストします
+func TestTagHandler(t *testing.T) {
+	handler := NewTagHandler(&MockTagService{})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		serve          func(w http.ResponseWriter, r *http.Request)
+		expectedStatus int
+	}{
+		{name: "IDで追加", method: http.MethodPost, path: "/api/v1/tags/work/todos", body: `{"ids":[1]}`, serve: handler.AddTag, expectedStatus: http.StatusOK},
+		{name: "条件で追加", method: http.MethodPost, path: "/api/v1/tags/work/todos", body: `{"filter":{"color":"red","is_completed":false}}`, serve: handler.AddTag, expectedStatus: http.StatusOK},
+		{name: "存在しないTodo", method: http.MethodPost, path: "/api/v1/tags/work/todos", body: `{"ids":[1,2]}`, serve: handler.AddTag, expectedStatus: http.StatusNotFound},
+		{name: "無効なタグ", method: http.MethodPost, path: "/api/v1/tags/a,b/todos", body: `{"ids":[1]}`, serve: handler.AddTag, expectedStatus: http.StatusBadRequest},
+		{name: "対象の指定なし", method: http.MethodPost, path: "/api/v1/tags/work/todos", body: `{}`, serve: handler.AddTag, expectedStatus: http.StatusBadRequest},
+		{name: "空の条件", method: http.MethodPost, path: "/api/v1/tags/work/todos", body: `{"filter":{}}`, serve: handler.AddTag, expectedStatus: http.StatusBadRequest},
+		{name: "IDと条件の両方", method: http.MethodPost, path: "/api/v1/tags/work/todos", body: `{"ids":[1],"filter":{"tag":"home"}}`, serve: handler.AddTag, expectedStatus: http.StatusBadRequest},
+		{name: "無効な色", method: http.MethodPost, path: "/api/v1/tags/work/todos", body: `{"filter":{"color":"navy"}}`, serve: handler.AddTag, expectedStatus: http.StatusBadRequest},
+		{name: "削除", method: http.MethodDelete, path: "/api/v1/tags/work/todos", body: `{"filter":{"tag":"work"}}`, serve: handler.RemoveTag, expectedStatus: http.StatusOK},
+		{name: "統合", method: http.MethodPost, path: "/api/v1/tags/merge", body: `{"source":"Home","target":"family"}`, serve: handler.MergeTags, expectedStatus: http.StatusOK},
+		{name: "同じタグへの統合", method: http.MethodPost, path: "/api/v1/tags/merge", body: `{"source":"home","target":"HOME"}`, serve: handler.MergeTags, expectedStatus: http.StatusBadRequest},
+		{name: "統合先なし", method: http.MethodPost, path: "/api/v1/tags/merge", body: `{"source":"home"}`, serve: handler.MergeTags, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			tt.serve(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+// TestTagHandler_Response は対象の指定の変換とレスポンスの内容をテストします
+func TestTagHandler_Response(t *testing.T) {
+	tagService := &MockTagService{}
+	handler := NewTagHandler(tagService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tags/Work/todos", strings.NewReader(`{"filter":{"color":"Red","tag":" Home ","is_completed":true}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.AddTag(rec, req)
+
+	completed := true
+	want := service.TodoSelection{Color: entity.Color("red"), Completed: &completed, Tag: "home"}
+	if !reflect.DeepEqual(tagService.lastSelection, want) {
+		t.Errorf("対象の指定 = %+v, 期待値 = %+v", tagService.lastSelection, want)
+	}
+
+	var response dto.BulkTagResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if response.Tag != "work" || response.Matched != 1 || response.Updated != 1 || len(response.Todos) != 1 {
+		t.Errorf("レスポンス = %+v", response)
+	}
+	if !reflect.DeepEqual(response.Todos[0].Tags, []string{"work"}) {
+		t.Errorf("Todoのタグ = %v, 期待値 = [work]", response.Todos[0].Tags)
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// BatchUpdateTodos は複数のTodoを1つのトランザクションでまとめて部分更新するHTTPハンドラーです
+// PATCH /api/v1/todos へのリクエストを処理します
+//
+// リクエストボディは [{"id": 1, "title": "..."}, {"id": 2, "is_completed": true}] の形式です
+// 全ての項目が成功した場合のみ保存し（200）、1件でも失敗した場合はどれも保存せずに
+// 422 と項目ごとの結果を返します（失敗していない項目は 424 = 未適用）
+// 値が何も変わらない項目は保存せず、not_modified: true として返します
+func (h *TodoHandler) BatchUpdateTodos(w http.ResponseWriter, r *http.Request) {
+	// 1. HTTPメソッドの確認
+	if r.Method != http.MethodPatch {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
+		return
+	}
+
+	// 2. Content-Typeの確認（配列を表現できるのはJSONのみ）
+	if requestMediaType(r) != mediaTypeJSON {
+		http.Error(w, errUnsupportedContentType.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 3. リクエストボディの解析
+	var items []dto.BatchUpdateTodoItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+	if len(items) == 0 {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "at least one item is required")
+		return
+	}
+	if len(items) > dto.MaxBatchSize {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed",
+			fmt.Sprintf("a batch may contain at most %d items", dto.MaxBatchSize))
+		return
+	}
+
+	// 4. 項目ごとに既存Todoを取得して部分更新を適用
+	results := make([]dto.BatchItemResult, len(items))
+	todos := make([]*entity.Todo, 0, len(items))
+	// positions は todos の各要素がリクエストの何番目の項目かを表します
+	positions := make([]int, 0, len(items))
+	unchanged := make(map[int]*entity.Todo)
+	failed := false
+	for i, item := range items {
+		results[i].ID = item.ID
+		if item.ID <= 0 {
+			results[i].Status, results[i].Error = http.StatusBadRequest, "id is required and must be greater than 0"
+			failed = true
+			continue
+		}
+
+		todo, err := h.todoService.GetTodoByID(r.Context(), int(item.ID))
+		if err != nil {
+			if !domainerr.IsNotFound(err) {
+				writeServerError(w, "Failed to get todo", err)
+				return
+			}
+			results[i].Status, results[i].Error = http.StatusNotFound, "todo not found"
+			failed = true
+			continue
+		}
+
+		original := *todo
+		item.ApplyToEntity(todo)
+		if msg := validateTodoFields(todo); msg != "" {
+			results[i].Status, results[i].Error = http.StatusBadRequest, msg
+			failed = true
+			continue
+		}
+		if len(entity.DiffTodo(&original, todo)) == 0 {
+			unchanged[i] = &original
+			continue
+		}
+		todos = append(todos, todo)
+		positions = append(positions, i)
+	}
+	if failed {
+		writeBatchFailure(w, results)
+		return
+	}
+
+	// 5. ドメインサービスで一括更新を実行（変更のある項目のみ）
+	var updated []*entity.Todo
+	if len(todos) > 0 {
+		var err error
+		updated, err = h.todoService.UpdateTodos(r.Context(), todos)
+		if err != nil {
+			var batchErr *service.BatchError
+			if !errors.As(err, &batchErr) {
+				writeServerError(w, "Failed to update todos", err)
+				return
+			}
+			for _, itemErr := range batchErr.Items {
+				position := positions[itemErr.Index]
+				results[position].Status = batchItemStatus(itemErr.Err)
+				results[position].Error = itemErr.Err.Error()
+			}
+			writeBatchFailure(w, results)
+			return
+		}
+	}
+
+	// 6. レスポンス返却
+	for i, todo := range updated {
+		h.setBatchItemTodo(&results[positions[i]], render, todo, false)
+	}
+	for position, todo := range unchanged {
+		h.setBatchItemTodo(&results[position], render, todo, true)
+	}
+	writeJSONResponse(w, http.StatusOK, dto.NewBatchResultResponse(results))
+}
+
+// setBatchItemTodo は成功した項目の結果にTodoを設定します
+func (h *TodoHandler) setBatchItemTodo(result *dto.BatchItemResult, render bool, todo *entity.Todo, notModified bool) {
+	response := dto.ToTodoResponse(todo)
+	h.renderDescription(render, &response)
+	result.Status = http.StatusOK
+	result.Todo = &response
+	result.NotModified = notModified
+}
+
+// batchItemStatus はサービス層から返された項目のエラーをステータスコードに変換します
+func batchItemStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrDuplicateTitle):
+		return http.StatusConflict
+	case domainerr.IsNotFound(err):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// writeBatchFailure は一括操作が適用されなかったことを 422 で返します
+// 結果が設定されていない項目（自身は問題のない項目）は 424 として返します
+func writeBatchFailure(w http.ResponseWriter, results []dto.BatchItemResult) {
+	for i := range results {
+		if results[i].Status == 0 {
+			results[i].Status = http.StatusFailedDependency
+			results[i].Error = "not applied because another item in the batch failed"
+		}
+	}
+	writeJSONResponse(w, http.StatusUnprocessableEntity, dto.NewBatchResultResponse(results))
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TestTodoHandler_BatchUpdateTodos はTodo一括更新ハンドラーをテストします
+func TestTodoHandler_BatchUpdateTodos(t *testing.T) {
+	tooMany := make([]string, dto.MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf(`{"id":%d}`, i+1)
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		contentType    string
+		body           string
+		setupMock      func(*MockTodoService)
+		expectedStatus int
+		// expectedItems は項目ごとの期待ステータスです（nil の場合は確認しない）
+		expectedItems []int
+		// expectedUpdates は UpdateTodos の期待呼び出し回数です
+		expectedUpdates int
+	}{
+		{
+			name:            "全ての項目を更新",
+			method:          http.MethodPatch,
+			body:            `[{"id":1,"title":"新しいタイトル"},{"id":"2","is_completed":true}]`,
+			setupMock:       func(m *MockTodoService) {},
+			expectedStatus:  http.StatusOK,
+			expectedItems:   []int{http.StatusOK, http.StatusOK},
+			expectedUpdates: 1,
+		},
+		{
+			name:            "値が変わらない項目は保存しない",
+			method:          http.MethodPatch,
+			body:            `[{"id":1,"title":"一つ目"},{"id":2,"title":"変更"}]`,
+			setupMock:       func(m *MockTodoService) {},
+			expectedStatus:  http.StatusOK,
+			expectedItems:   []int{http.StatusOK, http.StatusOK},
+			expectedUpdates: 1,
+		},
+		{
+			name:            "全ての項目の値が変わらない場合はサービスを呼び出さない",
+			method:          http.MethodPatch,
+			body:            `[{"id":1,"title":"一つ目"},{"id":2}]`,
+			setupMock:       func(m *MockTodoService) {},
+			expectedStatus:  http.StatusOK,
+			expectedItems:   []int{http.StatusOK, http.StatusOK},
+			expectedUpdates: 0,
+		},
+		{
+			name:            "存在しない項目と不正な項目があれば何も更新しない",
+			method:          http.MethodPatch,
+			body:            `[{"id":1,"title":"新しいタイトル"},{"id":99,"title":"x"},{"id":2,"color":"not-a-color"},{"title":"IDなし"}]`,
+			setupMock:       func(m *MockTodoService) {},
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedItems:   []int{http.StatusFailedDependency, http.StatusNotFound, http.StatusBadRequest, http.StatusBadRequest},
+			expectedUpdates: 0,
+		},
+		{
+			name:   "サービス層で重複タイトルと判定された項目は409",
+			method: http.MethodPatch,
+			body:   `[{"id":1,"title":"同じ"},{"id":2,"title":"同じ"}]`,
+			setupMock: func(m *MockTodoService) {
+				m.batchErr = &service.BatchError{Items: []*service.BatchItemError{
+					{Index: 1, ID: 2, Err: fmt.Errorf("%w: %q", service.ErrDuplicateTitle, "同じ")},
+				}}
+			},
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedItems:   []int{http.StatusFailedDependency, http.StatusConflict},
+			expectedUpdates: 1,
+		},
+		{
+			name:           "空の配列",
+			method:         http.MethodPatch,
+			body:           `[]`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "上限を超える項目数",
+			method:         http.MethodPatch,
+			body:           "[" + strings.Join(tooMany, ",") + "]",
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "配列ではないボディ",
+			method:         http.MethodPatch,
+			body:           `{"id":1,"title":"x"}`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "フォームは受け付けない",
+			method:         http.MethodPatch,
+			contentType:    "application/x-www-form-urlencoded",
+			body:           "id=1&title=x",
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "不正なHTTPメソッド",
+			method:         http.MethodPut,
+			body:           `[{"id":1}]`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "サービス層エラー",
+			method: http.MethodPatch,
+			body:   `[{"id":1,"title":"x"}]`,
+			setupMock: func(m *MockTodoService) {
+				m.SetError(true, "database unavailable")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockTodoService()
+			mockService.todos[1] = &entity.Todo{ID: 1, Title: "一つ目"}
+			mockService.todos[2] = &entity.Todo{ID: 2, Title: "二つ目"}
+			tt.setupMock(mockService)
+			handler := NewTodoHandler(mockService)
+
+			req := httptest.NewRequest(tt.method, "/api/v1/todos", strings.NewReader(tt.body))
+			contentType := tt.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+
+			handler.BatchUpdateTodos(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v (body: %s)", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if got := mockService.callCounts["UpdateTodos"]; got != tt.expectedUpdates {
+				t.Errorf("UpdateTodos の呼び出し回数 = %d, 期待値 = %d", got, tt.expectedUpdates)
+			}
+			if tt.expectedItems == nil {
+				return
+			}
+
+			var response dto.BatchResultResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスの解析に失敗: %v", err)
+			}
+			if len(response.Results) != len(tt.expectedItems) {
+				t.Fatalf("結果の件数 = %d, 期待値 = %d", len(response.Results), len(tt.expectedItems))
+			}
+			for i, want := range tt.expectedItems {
+				result := response.Results[i]
+				if result.Status != want {
+					t.Errorf("項目 %d のステータス = %d, 期待値 = %d (error: %s)", i, result.Status, want, result.Error)
+				}
+				if want == http.StatusOK && result.Todo == nil {
+					t.Errorf("項目 %d: 成功した項目に更新後のTodoが含まれていません", i)
+				}
+				if want != http.StatusOK && result.Error == "" {
+					t.Errorf("項目 %d: 失敗した項目にエラーが含まれていません", i)
+				}
+			}
+		})
+	}
+
+	t.Run("失敗した場合は既存のTodoを変更しない", func(t *testing.T) {
+		mockService := NewMockTodoService()
+		mockService.todos[1] = &entity.Todo{ID: 1, Title: "一つ目"}
+		handler := NewTodoHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/todos", strings.NewReader(`[{"id":1,"title":"変更"},{"id":99}]`))
+		req.Header.Set("Content-Type", "application/json")
+		handler.BatchUpdateTodos(httptest.NewRecorder(), req)
+
+		if got := mockService.todos[1].Title; got != "一つ目" {
+			t.Errorf("タイトル = %q, 期待値 = %q", got, "一つ目")
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// カレンダーの配信で、Todoを表すコンポーネントの種類
+const (
+	// calendarComponentEvent は期限の時刻の予定（VEVENT）として配信します（Googleカレンダー等、多くのカレンダーが対応）
+	calendarComponentEvent = "event"
+
+	// calendarComponentTodo はタスク（VTODO）として配信します（Appleのリマインダー等、VTODOに対応したアプリ向け）
+	calendarComponentTodo = "todo"
+)
+
+// icalTimeFormat はiCalendarのUTCの日時の形式です（RFC 5545 3.3.5）
+const icalTimeFormat = "20060102T150405Z"
+
+// icalMaxLineOctets は1行の最大長（バイト）です。これを超える行は折り返します（RFC 5545 3.1）
+const icalMaxLineOctets = 75
+
+// writeCalendar は期限のあるTodoをiCalendar（RFC 5545）形式で書き込みます
+//
+// 学習ポイント：
+//  1. iCalendarの行は CRLF で区切り、75バイトを超える行は「CRLF + 空白」で折り返す
+//  2. テキストの値ではバックスラッシュ・セミコロン・カンマ・改行をエスケープする
+//  3. UID は購読側が同じ予定を識別するためのキーなので、TodoのIDから常に同じ値を作る
+func writeCalendar(w http.ResponseWriter, r *http.Request, todos []*entity.Todo, component string) {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeICalLine(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//todoapp-api-golang//Todo API//JA")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "Todo")
+
+	for _, todo := range todos {
+		if todo.DueDate == nil {
+			continue
+		}
+		due := todo.DueDate.UTC().Format(icalTimeFormat)
+
+		name := "VEVENT"
+		if component == calendarComponentTodo {
+			name = "VTODO"
+		}
+		line("BEGIN", name)
+		line("UID", fmt.Sprintf("todo-%s@%s", dto.FormatID(todo.ID), r.Host))
+		line("DTSTAMP", todo.UpdatedAt.UTC().Format(icalTimeFormat))
+		line("CREATED", todo.CreatedAt.UTC().Format(icalTimeFormat))
+		line("LAST-MODIFIED", todo.UpdatedAt.UTC().Format(icalTimeFormat))
+		line("SUMMARY", escapeICalText(todo.Title))
+		if todo.Description != "" {
+			line("DESCRIPTION", escapeICalText(todo.Description))
+		}
+		if component == calendarComponentTodo {
+			// VTODO は期限を DUE で表し、完了状態を STATUS で伝える
+			line("DUE", due)
+			if todo.IsCompleted {
+				line("STATUS", "COMPLETED")
+			} else {
+				line("STATUS", "NEEDS-ACTION")
+			}
+		} else {
+			// DTEND のない VEVENT は DTSTART の時刻ちょうどの予定になる
+			line("DTSTART", due)
+		}
+		if todo.RemindAt != nil && !todo.IsCompleted {
+			// リマインダーはカレンダー側の通知（VALARM）として配信する
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("DESCRIPTION", escapeICalText(todo.Title))
+			line("TRIGGER;VALUE=DATE-TIME", todo.RemindAt.UTC().Format(icalTimeFormat))
+			line("END", "VALARM")
+		}
+		line("END", name)
+	}
+
+	line("END", "VCALENDAR")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(b.String()))
+}
+
+// icalTextEscaper はTEXT型の値で特別な意味を持つ文字をエスケープします
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeICalText はTEXT型の値をエスケープします（RFC 5545 3.3.11）
+func escapeICalText(s string) string {
+	return icalTextEscaper.Replace(s)
+}
+
+// writeICalLine は1行を書き込み、75バイトを超える場合は折り返します
+// UTF-8の文字の途中で分割しないよう、文字の境界で折り返します
+func writeICalLine(b *strings.Builder, line string) {
+	limit := icalMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// 継続行は先頭の空白の分だけ短くする
+		limit = icalMaxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// parseCalendarComponent は component クエリパラメータを検証します（省略時は event）
+func parseCalendarComponent(r *http.Request) (string, error) {
+	switch component := r.URL.Query().Get("component"); component {
+	case "", calendarComponentEvent:
+		return calendarComponentEvent, nil
+	case calendarComponentTodo:
+		return calendarComponentTodo, nil
+	default:
+		return "", fmt.Errorf("unknown component: %q (must be %s or %s)", component, calendarComponentEvent, calendarComponentTodo)
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// todoFragments はHTMXクライアント向けのHTMLフラグメントのテンプレートです
+// html/template は値を自動でエスケープするため、タイトル等に含まれるHTMLは無害化されます
+//
+// フラグメントはページ全体ではなく、HTMXが差し替える要素（hx-swap の対象）だけを返します：
+//   - todo      : 1件分の <li>（作成・更新・完了切り替えの応答）
+//   - todo_list : 一覧の <ul>（一覧取得の応答）
+var todoFragments = template.Must(template.New("fragments").Parse(`
+{{define "todo"}}<li id="todo-{{.ID}}" class="todo{{if .IsCompleted}} todo--completed{{end}}" data-id="{{.ID}}"{{if .Color}} data-color="{{.Color}}"{{end}} hx-headers="{{.IfMatchHeaders}}">
+  <input type="checkbox" class="todo__toggle"{{if .IsCompleted}} checked{{end}} hx-patch="{{.APIPath}}/todos/{{.ID}}/{{if .IsCompleted}}incomplete{{else}}complete{{end}}" hx-target="#todo-{{.ID}}" hx-swap="outerHTML">
+  <span class="todo__title">{{.Title}}</span>
+  {{- if .Description}}
+  <p class="todo__description">{{.Description}}</p>
+  {{- end}}
+  {{- if .ChecklistProgress.Total}}
+  <span class="todo__progress">{{.ChecklistProgress.Done}}/{{.ChecklistProgress.Total}}</span>
+  {{- end}}
+  {{- if .RemindAt}}
+  <time class="todo__remind-at" datetime="{{.RemindAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.RemindAt.Format "2006-01-02 15:04"}}</time>
+  {{- end}}
+  <button class="todo__delete" hx-delete="{{.APIPath}}/todos/{{.ID}}" hx-target="#todo-{{.ID}}" hx-swap="outerHTML">削除</button>
+</li>{{end}}
+{{define "todo_list"}}<ul id="todo-list" class="todo-list">
+{{range .}}{{template "todo" .}}
+{{end}}</ul>{{end}}
+`))
+
+// todoFragment はフラグメント1件分の描画データです
+// リンクにベースパスを含めるため、レスポンスDTOにAPIのパスを添えて渡します
+type todoFragment struct {
+	dto.TodoResponse
+
+	// APIPath はベースパスを含むAPI v1のパス（例: /todoapp/api/v1）
+	APIPath string
+
+	// IfMatchHeaders は完了切り替え・削除のリクエストに付ける If-Match ヘッダー（hx-headers のJSON）
+	// 子要素の hx-patch / hx-delete に継承され、REQUIRE_IF_MATCH の場合も画面から操作できます
+	IfMatchHeaders string
+}
+
+// newTodoFragments はレスポンスDTOを描画データに変換します
+func newTodoFragments(r *http.Request, todos ...dto.TodoResponse) []todoFragment {
+	apiPath := withBasePath(r, apiV1Path)
+	fragments := make([]todoFragment, len(todos))
+	for i, todo := range todos {
+		headers, _ := json.Marshal(map[string]string{"If-Match": todoETag(todo)})
+		fragments[i] = todoFragment{TodoResponse: todo, APIPath: apiPath, IfMatchHeaders: string(headers)}
+	}
+	return fragments
+}
+
+// writeHTMLFragment はテンプレートを描画してHTMLレスポンスを書き込みます
+// 描画が途中で失敗した場合に壊れたHTMLを返さないよう、一度バッファに描画してから書き込みます
+func writeHTMLFragment(w http.ResponseWriter, statusCode int, name string, data interface{}) {
+	var buf bytes.Buffer
+	if err := todoFragments.ExecuteTemplate(&buf, name, data); err != nil {
+		writeServerError(w, "Failed to render HTML fragment", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+	w.Write(buf.Bytes())
+}
+
+// writeTodoResponse はネゴシエーション結果に応じて、Todo1件をJSON・HTMLフラグメント・JSON:API のいずれかで返します
+func writeTodoResponse(w http.ResponseWriter, r *http.Request, statusCode int, response dto.TodoResponse) {
+	writeTodoFieldsResponse(w, r, statusCode, response, nil)
+}
+
+// writeTodoFieldsResponse は writeTodoResponse と同じですが、JSONの場合は fields のフィールドだけを返します
+// fields が nil の場合は全フィールドを返します（HTMLフラグメントと JSON:API には適用しません）
+func writeTodoFieldsResponse(w http.ResponseWriter, r *http.Request, statusCode int, response dto.TodoResponse, fields dto.TodoFields) {
+	// 同じURLでも Accept や HX-Request によって応答が変わることをキャッシュに伝える
+	w.Header().Add("Vary", "Accept, HX-Request")
+	// 更新・削除時の If-Match に使う ETag（内容から作るため、どの形式・フィールドで返しても同じ値）
+	w.Header().Set("ETag", todoETag(response))
+
+	if wantsHTML(r) {
+		writeHTMLFragment(w, statusCode, "todo", newTodoFragments(r, response)[0])
+		return
+	}
+	if wantsJSONAPI(r) {
+		writeTodoDocument(w, r, statusCode, response)
+		return
+	}
+	if fields != nil {
+		projected, err := fields.Project(response)
+		if err != nil {
+			writeServerError(w, "Failed to project fields", err)
+			return
+		}
+		writeJSONResponse(w, statusCode, projected)
+		return
+	}
+	writeJSONResponse(w, statusCode, response)
+}
+
+// writeTodoListResponse はネゴシエーション結果に応じて、Todo一覧をJSON・HTMLフラグメント・JSON:API のいずれかで返します
+// JSONの場合は、各Todoを fields のフィールドだけに絞ります（nil の場合は全フィールド）
+func writeTodoListResponse(w http.ResponseWriter, r *http.Request, statusCode int, response dto.TodoListResponse, fields dto.TodoFields) {
+	w.Header().Add("Vary", "Accept, HX-Request")
+
+	if wantsHTML(r) {
+		writeHTMLFragment(w, statusCode, "todo_list", newTodoFragments(r, response.Todos...))
+		return
+	}
+	if wantsJSONAPI(r) {
+		writeTodoListDocument(w, r, statusCode, response)
+		return
+	}
+	if fields != nil {
+		projected, err := fields.ProjectList(response)
+		if err != nil {
+			writeServerError(w, "Failed to project fields", err)
+			return
+		}
+		writeJSONResponse(w, statusCode, projected)
+		return
+	}
+	writeJSONResponse(w, statusCode, response)
+}
+
+// fieldsParam はレスポンスに含めるフィールドを指定するクエリパラメータです
+const fieldsParam = "fields"
+
+// parseFieldsParam は ?fields= の値を解析し、レスポンスに含めるフィールドを返します
+// 未指定の場合は nil（全フィールド）を返し、未知のフィールド名の場合は 400 を書き込み、ok に false を返します
+func parseFieldsParam(w http.ResponseWriter, r *http.Request) (fields dto.TodoFields, ok bool) {
+	fields, err := dto.ParseTodoFields(r.URL.Query().Get(fieldsParam))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid fields", err.Error())
+		return nil, false
+	}
+	return fields, true
+}
@@ -2,11 +2,19 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
 	"todoapp-api-golang/internal/domain/service"
 )
 
@@ -23,14 +31,29 @@ type TodoHandler struct {
 	// todoService はビジネスロジック処理を担当するドメインサービス
 	// 依存性注入によってサービス実装を受け取ります
 	todoService service.TodoServiceInterface
+
+	// markdown は ?render=html で説明をHTMLに変換するレンダラー（WithMarkdownRenderer で設定）
+	markdown MarkdownRenderer
+
+	// requireIfMatch は更新・削除に If-Match を必須にするかどうか（WithIfMatchRequired で設定）
+	requireIfMatch bool
+
+	// preferencesService は一覧の並び順を取得するサービスです（WithTodoPreferences で設定）
+	preferencesService service.PreferencesServiceInterface
 }
 
 // NewTodoHandler はTodoHandlerのコンストラクタです
 // 標準パッケージを使った依存性注入の実装例
-func NewTodoHandler(todoService service.TodoServiceInterface) *TodoHandler {
-	return &TodoHandler{
+// 任意の機能は TodoHandlerOption で設定します
+func NewTodoHandler(todoService service.TodoServiceInterface, opts ...TodoHandlerOption) *TodoHandler {
+	h := &TodoHandler{
 		todoService: todoService,
+		markdown:    plainTextRenderer{},
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // CreateTodo は新しいTodoを作成するHTTPハンドラーです
@@ -49,54 +72,105 @@ func (h *TodoHandler) CreateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 2. Content-Typeの確認（JSON以外を拒否）
-	contentType := r.Header.Get("Content-Type")
-	if !strings.Contains(contentType, "application/json") {
-		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
 		return
 	}
 
-	// 3. JSONリクエストボディをDTOにデコード
-	var req dto.CreateTodoRequest
-
-	// json.NewDecoder を使ってストリームからJSONを読み取り
-	// 標準パッケージでのJSONデコード処理
-	decoder := json.NewDecoder(r.Body)
-	if err := decoder.Decode(&req); err != nil {
-		// JSONパースエラーの場合は400 Bad Requestを返す
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON format", err.Error())
+	// 2-3. リクエストボディをDTOにデコード
+	// JSON と URLエンコードされたフォーム（HTMLフォーム・HTMX）の両方を受け付け、それ以外は拒否
+	req, err := decodeCreateTodoRequest(r)
+	if err != nil {
+		if errors.Is(err, errUnsupportedContentType) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// パースエラーの場合は400 Bad Requestを返す
+		writeBodyDecodeError(w, r, err)
 		return
 	}
 
 	// 4. 基本的なバリデーション（手動実装）
-	if req.Title == "" {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "title is required")
-		return
-	}
-	if len(req.Title) > 100 {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "title must be 100 characters or less")
-		return
-	}
-	if len(req.Description) > 500 {
-		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "description must be 500 characters or less")
+	if msg := validateCreateTodoRequest(req); msg != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", msg)
 		return
 	}
 
 	// 5. DTOからエンティティへの変換
 	todo := req.ToEntity()
+	if msg := validateTodoFields(todo); msg != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", msg)
+		return
+	}
 
 	// 6. ドメインサービスを呼び出してビジネスロジック実行
 	createdTodo, err := h.todoService.CreateTodo(r.Context(), todo)
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to create todo", err.Error())
+		if errors.Is(err, service.ErrDuplicateTitle) {
+			writeErrorResponse(w, http.StatusConflict, "Duplicate title", err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrInvalidProject) {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid project", err.Error())
+			return
+		}
+		writeServerError(w, "Failed to create todo", err)
 		return
 	}
 
 	// 7. エンティティからレスポンスDTOへの変換
 	response := dto.ToTodoResponse(createdTodo)
+	h.renderDescription(render, &response)
+
+	// 8. レスポンスの書き込み（JSON または HTMLフラグメント）
+	writeTodoResponse(w, r, http.StatusCreated, response)
+}
+
+// validateCreateTodoRequest は作成リクエストのタイトルと説明を検証し、問題があればエラーメッセージを返します
+func validateCreateTodoRequest(req dto.CreateTodoRequest) string {
+	if req.Title == "" {
+		return "title is required"
+	}
+	if len(req.Title) > entity.MaxTitleLength {
+		return fmt.Sprintf("title must be %d characters or less", entity.MaxTitleLength)
+	}
+	if len(req.Description) > entity.MaxDescriptionLength {
+		return fmt.Sprintf("description must be %d characters or less", entity.MaxDescriptionLength)
+	}
+	return ""
+}
 
-	// 8. JSON レスポンスの書き込み
-	writeJSONResponse(w, http.StatusCreated, response)
+// validateTodoFields は繰り返し設定・色・見積もり時間を検証し、問題があればエラーメッセージを返します
+// 作成時と更新時（部分更新の適用後）の両方で使用します
+func validateTodoFields(todo *entity.Todo) string {
+	if msg := validateRecurrence(todo); msg != "" {
+		return msg
+	}
+	if !todo.Color.IsValid() {
+		return fmt.Sprintf("color must be one of %v or a hex color such as #1e90ff", entity.ColorPalette)
+	}
+	if !entity.IsValidMinutes(todo.EstimateMinutes) {
+		return fmt.Sprintf("estimate_minutes must be between 0 and %d", entity.MaxEstimateMinutes)
+	}
+	if !entity.IsValidMinutes(todo.ActualMinutes) {
+		return fmt.Sprintf("actual_minutes must be between 0 and %d", entity.MaxEstimateMinutes)
+	}
+	if todo.ProjectID != nil && *todo.ProjectID < 0 {
+		return "project_id must be a positive integer"
+	}
+	return ""
+}
+
+// validateRecurrence は繰り返し設定を検証し、問題があればエラーメッセージを返します
+func validateRecurrence(todo *entity.Todo) string {
+	if !todo.Recurrence.IsValid() {
+		return fmt.Sprintf("recurrence must be one of %v", entity.Recurrences)
+	}
+	if todo.Recurrence != entity.RecurrenceNone && todo.DueDate == nil {
+		return "due_date is required for recurring todos"
+	}
+	return ""
 }
 
 // GetTodoByID は指定されたIDのTodoを取得するHTTPハンドラーです
@@ -111,6 +185,17 @@ func (h *TodoHandler) GetTodoByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
+		return
+	}
+	// 返すフィールドの指定（?fields=id,title）の確認
+	fields, ok := parseFieldsParam(w, r)
+	if !ok {
+		return
+	}
+
 	// 2. URLパスからIDを抽出
 	// パスの構造: /api/v1/todos/{id}
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
@@ -120,7 +205,7 @@ func (h *TodoHandler) GetTodoByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 3. 文字列を整数に変換
-	id, err := strconv.Atoi(pathParts[3])
+	id, err := dto.ParseID(pathParts[3])
 	if err != nil {
 		writeErrorResponse(w, http.StatusBadRequest, "Invalid todo ID", "ID must be a number")
 		return
@@ -130,17 +215,18 @@ func (h *TodoHandler) GetTodoByID(w http.ResponseWriter, r *http.Request) {
 	todo, err := h.todoService.GetTodoByID(r.Context(), id)
 	if err != nil {
 		// エラーメッセージの内容に応じてHTTPステータスを決定
-		if strings.Contains(err.Error(), "not found") {
+		if domainerr.IsNotFound(err) {
 			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
 		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to get todo", err.Error())
+			writeServerError(w, "Failed to get todo", err)
 		}
 		return
 	}
 
 	// 5. レスポンス返却
 	response := dto.ToTodoResponse(todo)
-	writeJSONResponse(w, http.StatusOK, response)
+	h.renderDescription(render, &response)
+	writeTodoFieldsResponse(w, r, http.StatusOK, response, fields)
 }
 
 // GetAllTodos は全てのTodoを取得するHTTPハンドラーです
@@ -155,34 +241,202 @@ func (h *TodoHandler) GetAllTodos(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
+		return
+	}
+	// 返すフィールドの指定（?fields=id,title,is_completed）の確認
+	fields, ok := parseFieldsParam(w, r)
+	if !ok {
+		return
+	}
+
 	// 2. クエリパラメータの解析
 	query := r.URL.Query()
 
-	// ページング用パラメータの取得（将来拡張用）
-	page := 1
+	// ページング用パラメータの取得（不正な値は既定値に置き換え、メタ情報で知らせる）
+	page, limit, adjustments := parsePagination(query)
+
+	// 絞り込みの条件（color・completed・tag・q・due_from・due_to）の取得
+	filter, filters, msg := parseTodoFilter(query)
+	if msg != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid filter", msg)
+		return
+	}
+
+	// カーソルの指定（?cursor。最初のページは空）がある場合は、保存先のページングで1ページだけ取得する
+	if _, ok := query["cursor"]; ok {
+		if len(filters) > 0 || query.Get("sort") != "" {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid cursor", "cursor cannot be combined with filters or sort")
+			return
+		}
+		h.getTodoPage(w, r, query.Get("cursor"), limit, adjustments, render, fields)
+		return
+	}
+
+	// 並び順は ?sort の指定を優先し、指定がない場合は保存した並び順を使う
+	sortOrder := entity.TodoSortOrder(query.Get("sort"))
+	if sortOrder != "" && !sortOrder.IsValid() {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid sort", fmt.Sprintf("sort must be one of %v", entity.TodoSortOrders))
+		return
+	}
+	if sortOrder == "" && h.preferencesService != nil {
+		preferences, err := h.preferencesService.Get(r.Context())
+		if err != nil {
+			writeServerError(w, "Failed to get preferences", err)
+			return
+		}
+		sortOrder = preferences.SortOrder
+	}
+
+	// 3. ドメインサービスで条件に一致するTodoを取得（絞り込みと並び替えはリポジトリが1回の取得で行う）
+	todos, err := h.todoService.FindTodos(r.Context(), filter, sortOrder)
+	if err != nil {
+		writeServerError(w, "Failed to get todos", err)
+		return
+	}
+
+	// 4. レスポンス生成
+	response := dto.ToTodoListResponse(todos, page, limit, len(todos))
+	response.Meta.Filters = filters
+	response.Meta.Adjustments = adjustments
+	if sortOrder != "" {
+		response.Meta.Sort = string(sortOrder)
+	}
+	for i := range response.Todos {
+		h.renderDescription(render, &response.Todos[i])
+	}
+	writeTodoListResponse(w, r, http.StatusOK, response, fields)
+}
+
+// getTodoPage はカーソルの続きから一覧の1ページ（最大 limit 件、作成日時の新しい順）を返します
+// 保存先がカーソルでの取得に対応しない場合（DB_DRIVER=dynamodb 以外）と、不正なカーソルの場合は 400 Bad Request です
+// メタ情報の total はこのページの件数で、次のページのカーソルを next_cursor に含めます（最後のページでは省略）
+func (h *TodoHandler) getTodoPage(w http.ResponseWriter, r *http.Request, cursor string, limit int, adjustments []dto.ParamAdjustment, render bool, fields dto.TodoFields) {
+	page, err := h.todoService.GetTodoPage(r.Context(), cursor, limit)
+	if err != nil {
+		if domainerr.IsInvalid(err) {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid cursor", err.Error())
+			return
+		}
+		writeServerError(w, "Failed to get todos", err)
+		return
+	}
+
+	response := dto.ToTodoListResponse(page.Todos, 1, limit, len(page.Todos))
+	response.Meta.NextCursor = page.NextCursor
+	response.Meta.Adjustments = adjustments
+	for i := range response.Todos {
+		h.renderDescription(render, &response.Todos[i])
+	}
+	writeTodoListResponse(w, r, http.StatusOK, response, fields)
+}
+
+// parseTodoFilter は一覧の絞り込みのクエリパラメータを解析し、条件と、メタ情報に含める適用した条件を返します
+// 不正な値がある場合は、エラーの詳細を msg に返します
+//
+//   - color: 色（パレットの名前か16進カラーコード）
+//   - completed: 完了状態（true / false）
+//   - tag: 付いているタグ
+//   - q: タイトルか説明に含まれる文字列
+//   - due_from / due_to: 期限の範囲 [due_from, due_to)（RFC3339）
+func parseTodoFilter(query url.Values) (filter repository.TodoFilter, applied map[string]string, msg string) {
+	applied = map[string]string{}
+
+	if _, ok := query["color"]; ok {
+		filter.Color = entity.NormalizeColor(query.Get("color"))
+		if filter.Color == entity.ColorNone || !filter.Color.IsValid() {
+			return filter, nil, fmt.Sprintf("color must be one of %v or a hex color such as #1e90ff", entity.ColorPalette)
+		}
+		applied["color"] = string(filter.Color)
+	}
+	if raw := query.Get("completed"); raw != "" {
+		completed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, nil, "completed must be true or false"
+		}
+		filter.Completed = &completed
+		applied["completed"] = strconv.FormatBool(completed)
+	}
+	if raw := query.Get("tag"); raw != "" {
+		filter.Tag = entity.NormalizeTag(raw)
+		if !entity.IsValidTag(filter.Tag) {
+			return filter, nil, fmt.Sprintf("tag must be 1 to %d characters without spaces, commas or slashes", entity.MaxTagLength)
+		}
+		applied["tag"] = filter.Tag
+	}
+	if q := strings.TrimSpace(query.Get("q")); q != "" {
+		filter.Search = q
+		applied["q"] = q
+	}
+	for _, bound := range []struct {
+		param string
+		dst   **time.Time
+	}{{"due_from", &filter.DueFrom}, {"due_to", &filter.DueTo}} {
+		raw := query.Get(bound.param)
+		if raw == "" {
+			continue
+		}
+		due, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return filter, nil, fmt.Sprintf("%s must be an RFC3339 timestamp such as 2024-01-02T15:04:05Z", bound.param)
+		}
+		*bound.dst = &due
+		applied[bound.param] = due.UTC().Format(time.RFC3339)
+	}
+	return filter, applied, ""
+}
+
+// 一覧のページングの既定値と上限
+const (
+	defaultListLimit = 10
+	maxListLimit     = 100
+)
+
+// parsePagination はクエリパラメータ page と limit を解析します
+// 不正な値はエラーにせず既定値（page=1、limit=10）に置き換え、置き換えたパラメータを adjustments として返します
+func parsePagination(query url.Values) (page, limit int, adjustments []dto.ParamAdjustment) {
+	page, limit = 1, defaultListLimit
+
 	if p := query.Get("page"); p != "" {
 		if pageNum, err := strconv.Atoi(p); err == nil && pageNum > 0 {
 			page = pageNum
+		} else {
+			adjustments = append(adjustments, dto.ParamAdjustment{
+				Param: "page", Requested: p, Applied: strconv.Itoa(page), Reason: "page must be a positive integer",
+			})
 		}
 	}
 
-	limit := 10
 	if l := query.Get("limit"); l != "" {
-		if limitNum, err := strconv.Atoi(l); err == nil && limitNum > 0 && limitNum <= 100 {
+		if limitNum, err := strconv.Atoi(l); err == nil && limitNum > 0 && limitNum <= maxListLimit {
 			limit = limitNum
+		} else {
+			adjustments = append(adjustments, dto.ParamAdjustment{
+				Param: "limit", Requested: l, Applied: strconv.Itoa(limit), Reason: fmt.Sprintf("limit must be an integer between 1 and %d", maxListLimit),
+			})
 		}
 	}
 
-	// 3. ドメインサービスで全Todo取得
-	todos, err := h.todoService.GetAllTodos(r.Context())
+	return page, limit, adjustments
+}
+
+// GetTodoStats はTodoの件数と見積もり・実績時間の集計を返すHTTPハンドラーです
+// GET /api/v1/todos/stats へのリクエストを処理します
+func (h *TodoHandler) GetTodoStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stats, err := h.todoService.GetTodoStats(r.Context())
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to get todos", err.Error())
+		writeServerError(w, "Failed to get todo stats", err)
 		return
 	}
 
-	// 4. レスポンス生成
-	response := dto.ToTodoListResponse(todos, page, limit, len(todos))
-	writeJSONResponse(w, http.StatusOK, response)
+	writeJSONResponse(w, http.StatusOK, dto.ToTodoStatsResponse(stats))
 }
 
 // UpdateTodo は既存のTodoを更新するHTTPハンドラーです
@@ -194,10 +448,17 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 2. Content-Typeの確認
-	contentType := r.Header.Get("Content-Type")
-	if !strings.Contains(contentType, "application/json") {
-		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
+		return
+	}
+
+	// 2. Content-Typeの確認（JSON またはフォームのみ受け付ける）
+	switch requestMediaType(r) {
+	case mediaTypeJSON, mediaTypeForm:
+	default:
+		http.Error(w, errUnsupportedContentType.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -208,44 +469,72 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := strconv.Atoi(pathParts[3])
+	id, err := dto.ParseID(pathParts[3])
 	if err != nil {
 		writeErrorResponse(w, http.StatusBadRequest, "Invalid todo ID", "ID must be a number")
 		return
 	}
 
 	// 4. リクエストボディの解析
-	var req dto.UpdateTodoRequest
-	decoder := json.NewDecoder(r.Body)
-	if err := decoder.Decode(&req); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON format", err.Error())
+	req, err := decodeUpdateTodoRequest(r)
+	if err != nil {
+		writeBodyDecodeError(w, r, err)
 		return
 	}
 
 	// 5. 更新対象のTodoを取得
 	todo, err := h.todoService.GetTodoByID(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if domainerr.IsNotFound(err) {
 			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
 		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to get todo", err.Error())
+			writeServerError(w, "Failed to get todo", err)
 		}
 		return
 	}
 
+	// 他のリクエストが取得後に変更していないかを If-Match で確認（上書きの防止）
+	if !h.checkIfMatch(w, r, todo) {
+		return
+	}
+
 	// 6. リクエストの内容を既存Todoに適用（部分更新）
+	original := *todo
 	req.ApplyToEntity(todo)
+	if msg := validateTodoFields(todo); msg != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Validation failed", msg)
+		return
+	}
+
+	// 値が何も変わらない場合は保存せず、現在の状態を「変更なし」として返す
+	if len(entity.DiffTodo(&original, todo)) == 0 {
+		h.writeNotModified(w, r, render, &original)
+		return
+	}
 
 	// 7. ドメインサービスで更新実行
 	updatedTodo, err := h.todoService.UpdateTodo(r.Context(), todo)
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to update todo", err.Error())
+		if errors.Is(err, service.ErrDuplicateTitle) {
+			writeErrorResponse(w, http.StatusConflict, "Duplicate title", err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrInvalidProject) {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid project", err.Error())
+			return
+		}
+		if errors.Is(err, service.ErrTodoForbidden) {
+			writeErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+			return
+		}
+		writeServerError(w, "Failed to update todo", err)
 		return
 	}
 
 	// 8. レスポンス返却
 	response := dto.ToTodoResponse(updatedTodo)
-	writeJSONResponse(w, http.StatusOK, response)
+	h.renderDescription(render, &response)
+	writeTodoResponse(w, r, http.StatusOK, response)
 }
 
 // DeleteTodo は指定されたIDのTodoを削除するHTTPハンドラーです
@@ -264,24 +553,42 @@ func (h *TodoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := strconv.Atoi(pathParts[3])
+	id, err := dto.ParseID(pathParts[3])
 	if err != nil {
 		writeErrorResponse(w, http.StatusBadRequest, "Invalid todo ID", "ID must be a number")
 		return
 	}
 
+	// If-Match がある（または必須の）場合は、削除前に現在のTodoと比較する
+	if h.hasIfMatch(r) {
+		current, ok := h.getTodoForStateChange(w, r, id)
+		if !ok || !h.checkIfMatch(w, r, current) {
+			return
+		}
+	}
+
 	// 3. ドメインサービスで削除実行
 	err = h.todoService.DeleteTodo(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		switch {
+		case domainerr.IsNotFound(err):
 			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
-		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete todo", err.Error())
+		case errors.Is(err, service.ErrTodoForbidden):
+			writeErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+		default:
+			writeServerError(w, "Failed to delete todo", err)
 		}
 		return
 	}
 
 	// 4. 削除成功時は204 No Contentを返却（レスポンスボディなし）
+	// HTMXは204の場合に要素を差し替えないため、HTMLを求めるクライアントには
+	// 空のフラグメント（200）を返して対象の要素を削除させる
+	if wantsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -294,6 +601,12 @@ func (h *TodoHandler) CompleteTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
+		return
+	}
+
 	// 2. URLパスからIDを抽出
 	// パスの構造: /api/v1/todos/{id}/complete
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
@@ -302,26 +615,40 @@ func (h *TodoHandler) CompleteTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := strconv.Atoi(pathParts[3])
+	id, err := dto.ParseID(pathParts[3])
 	if err != nil {
 		writeErrorResponse(w, http.StatusBadRequest, "Invalid todo ID", "ID must be a number")
 		return
 	}
 
-	// 3. ドメインサービスでTodo完了処理
+	// 3. 既に完了済みの場合は保存せず「変更なし」として返す
+	current, ok := h.getTodoForStateChange(w, r, id)
+	if !ok || !h.checkIfMatch(w, r, current) {
+		return
+	}
+	if current.IsCompleted {
+		h.writeNotModified(w, r, render, current)
+		return
+	}
+
+	// 4. ドメインサービスでTodo完了処理
 	completedTodo, err := h.todoService.CompleteTodo(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		switch {
+		case domainerr.IsNotFound(err):
 			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
-		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to complete todo", err.Error())
+		case errors.Is(err, service.ErrTodoForbidden):
+			writeErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+		default:
+			writeServerError(w, "Failed to complete todo", err)
 		}
 		return
 	}
 
-	// 4. レスポンス返却
+	// 5. レスポンス返却
 	response := dto.ToTodoResponse(completedTodo)
-	writeJSONResponse(w, http.StatusOK, response)
+	h.renderDescription(render, &response)
+	writeTodoResponse(w, r, http.StatusOK, response)
 }
 
 // IncompleteTodo はTodoを未完了状態に戻すHTTPハンドラーです
@@ -333,6 +660,12 @@ func (h *TodoHandler) IncompleteTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
+		return
+	}
+
 	// 2. URLパスからIDを抽出
 	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 	if len(pathParts) < 5 || pathParts[4] != "incomplete" {
@@ -340,26 +673,139 @@ func (h *TodoHandler) IncompleteTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := strconv.Atoi(pathParts[3])
+	id, err := dto.ParseID(pathParts[3])
 	if err != nil {
 		writeErrorResponse(w, http.StatusBadRequest, "Invalid todo ID", "ID must be a number")
 		return
 	}
 
-	// 3. ドメインサービスでTodo未完了処理
+	// 3. 既に未完了の場合は保存せず「変更なし」として返す
+	current, ok := h.getTodoForStateChange(w, r, id)
+	if !ok || !h.checkIfMatch(w, r, current) {
+		return
+	}
+	if !current.IsCompleted {
+		h.writeNotModified(w, r, render, current)
+		return
+	}
+
+	// 4. ドメインサービスでTodo未完了処理
 	incompleteTodo, err := h.todoService.IncompleteTodo(r.Context(), id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		switch {
+		case domainerr.IsNotFound(err):
 			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
-		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, "Failed to mark todo as incomplete", err.Error())
+		case errors.Is(err, service.ErrTodoForbidden):
+			writeErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+		default:
+			writeServerError(w, "Failed to mark todo as incomplete", err)
 		}
 		return
 	}
 
-	// 4. レスポンス返却
+	// 5. レスポンス返却
 	response := dto.ToTodoResponse(incompleteTodo)
-	writeJSONResponse(w, http.StatusOK, response)
+	h.renderDescription(render, &response)
+	writeTodoResponse(w, r, http.StatusOK, response)
+}
+
+// getTodoForStateChange は完了状態を切り替える前に、現在のTodoを取得します
+// 取得できなかった場合はエラーレスポンスを書き込み、ok = false を返します
+func (h *TodoHandler) getTodoForStateChange(w http.ResponseWriter, r *http.Request, id int) (*entity.Todo, bool) {
+	todo, err := h.todoService.GetTodoByID(r.Context(), id)
+	if err != nil {
+		if domainerr.IsNotFound(err) || domainerr.IsInvalid(err) {
+			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
+		} else {
+			writeServerError(w, "Failed to get todo", err)
+		}
+		return nil, false
+	}
+	return todo, true
+}
+
+// writeNotModified は更新で値が何も変わらなかった場合のレスポンスを返します
+// 304 Not Modified は条件付きGET用でボディを返せないため、200 と meta.not_modified で伝えます
+func (h *TodoHandler) writeNotModified(w http.ResponseWriter, r *http.Request, render bool, todo *entity.Todo) {
+	response := dto.ToTodoResponse(todo)
+	response.Meta = dto.NotModifiedMeta()
+	h.renderDescription(render, &response)
+	writeTodoResponse(w, r, http.StatusOK, response)
+}
+
+// DuplicateTodo は既存のTodoを複製するHTTPハンドラーです
+// POST /api/v1/todos/{id}/duplicate へのリクエストを処理します
+// ボディ（任意）: {"title": "新しいタイトル", "include_checklist": true}
+func (h *TodoHandler) DuplicateTodo(w http.ResponseWriter, r *http.Request) {
+	// 1. HTTPメソッドの確認
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// 説明の変換指定（?render=html）の確認
+	render, ok := parseRenderParam(w, r)
+	if !ok {
+		return
+	}
+
+	// 2. URLパスからIDを抽出
+	// パスの構造: /api/v1/todos/{id}/duplicate
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[4] != "duplicate" {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", "invalid endpoint")
+		return
+	}
+
+	id, err := dto.ParseID(pathParts[3])
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid todo ID", "ID must be a number")
+		return
+	}
+
+	// 3. リクエストボディ（省略可能）のデコードとバリデーション
+	var req dto.DuplicateTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	opts := service.DuplicateTodoOptions{IncludeChecklist: req.IncludeChecklist}
+	if req.Title != nil {
+		if strings.TrimSpace(*req.Title) == "" {
+			writeErrorResponse(w, http.StatusBadRequest, "Validation failed", "title must not be empty")
+			return
+		}
+		if len(*req.Title) > entity.MaxTitleLength {
+			writeErrorResponse(w, http.StatusBadRequest, "Validation failed", fmt.Sprintf("title must be %d characters or less", entity.MaxTitleLength))
+			return
+		}
+		opts.Title = *req.Title
+	}
+
+	// 4. ドメインサービスで複製処理
+	duplicatedTodo, err := h.todoService.DuplicateTodo(r.Context(), id, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDuplicateTitle):
+			writeErrorResponse(w, http.StatusConflict, "Duplicate title", err.Error())
+		case errors.Is(err, service.ErrInvalidProject):
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid project", err.Error())
+		case domainerr.IsNotFound(err):
+			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
+		case domainerr.IsInvalid(err):
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid request", err.Error())
+		default:
+			writeServerError(w, "Failed to duplicate todo", err)
+		}
+		return
+	}
+
+	// 5. 作成したリソースの場所を返す
+	w.Header().Set("Location", withBasePath(r, fmt.Sprintf("%s/todos/%s", apiV1Path, dto.FormatID(duplicatedTodo.ID))))
+	response := dto.ToTodoResponse(duplicatedTodo)
+	h.renderDescription(render, &response)
+	writeTodoResponse(w, r, http.StatusCreated, response)
 }
 
 // --- ヘルパー関数 ---
@@ -382,6 +828,16 @@ func writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{})
 	}
 }
 
+// writeServerError はサービスのエラーを 500 Internal Server Error のエラーレスポンスとして書き込みます
+// データベースのクエリが期限（DB_QUERY_TIMEOUT）を過ぎたエラー（domainerr.KindTimeout）は 504 Gateway Timeout にします
+func writeServerError(w http.ResponseWriter, message string, err error) {
+	if domainerr.IsTimeout(err) {
+		writeErrorResponse(w, http.StatusGatewayTimeout, message, err.Error())
+		return
+	}
+	writeErrorResponse(w, http.StatusInternalServerError, message, err.Error())
+}
+
 // writeErrorResponse はエラーレスポンスを書き込むヘルパー関数です
 func writeErrorResponse(w http.ResponseWriter, statusCode int, message, details string) {
 	errorResponse := dto.ErrorResponse{
@@ -5,12 +5,22 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
 	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/pkg/hashids"
 )
 
 // MockTodoService はテスト用のTodoServiceのモック実装です
@@ -20,7 +30,11 @@ type MockTodoService struct {
 	nextID      int
 	shouldError bool
 	errorMsg    string
+	err         error
 	callCounts  map[string]int
+
+	// batchErr は UpdateTodos だけが返すエラーです（項目ごとの取得は成功させたい場合に使用）
+	batchErr error
 }
 
 // NewMockTodoService はモックサービスのコンストラクタです
@@ -36,6 +50,21 @@ func NewMockTodoService() *MockTodoService {
 func (m *MockTodoService) SetError(shouldError bool, errorMsg string) {
 	m.shouldError = shouldError
 	m.errorMsg = errorMsg
+	m.err = nil
+}
+
+// SetErrorValue はモックが指定したエラー（errors.Is で判定する番兵エラー等）を返すように設定します
+func (m *MockTodoService) SetErrorValue(err error) {
+	m.shouldError = true
+	m.err = err
+}
+
+// failure はモックが返すエラーです
+func (m *MockTodoService) failure() error {
+	if m.err != nil {
+		return m.err
+	}
+	return errors.New(m.errorMsg)
 }
 
 // CreateTodo のモック実装
@@ -43,7 +72,7 @@ func (m *MockTodoService) CreateTodo(ctx context.Context, todo *entity.Todo) (*e
 	m.callCounts["CreateTodo"]++
 
 	if m.shouldError {
-		return nil, errors.New(m.errorMsg)
+		return nil, m.failure()
 	}
 
 	todo.ID = m.nextID
@@ -62,12 +91,12 @@ func (m *MockTodoService) GetTodoByID(ctx context.Context, id int) (*entity.Todo
 	m.callCounts["GetTodoByID"]++
 
 	if m.shouldError {
-		return nil, errors.New(m.errorMsg)
+		return nil, m.failure()
 	}
 
 	todo, exists := m.todos[id]
 	if !exists {
-		return nil, errors.New("todo not found")
+		return nil, domainerr.NotFound("todo", nil)
 	}
 
 	result := *todo
@@ -79,7 +108,7 @@ func (m *MockTodoService) GetAllTodos(ctx context.Context) ([]*entity.Todo, erro
 	m.callCounts["GetAllTodos"]++
 
 	if m.shouldError {
-		return nil, errors.New(m.errorMsg)
+		return nil, m.failure()
 	}
 
 	result := make([]*entity.Todo, 0, len(m.todos))
@@ -91,17 +120,106 @@ func (m *MockTodoService) GetAllTodos(ctx context.Context) ([]*entity.Todo, erro
 	return result, nil
 }
 
+// GetTodoStats のモック実装
+func (m *MockTodoService) GetTodoStats(ctx context.Context) (entity.TodoStats, error) {
+	m.callCounts["GetTodoStats"]++
+
+	if m.shouldError {
+		return entity.TodoStats{}, m.failure()
+	}
+
+	todos := make([]*entity.Todo, 0, len(m.todos))
+	for _, todo := range m.todos {
+		todos = append(todos, todo)
+	}
+	return entity.NewTodoStats(todos), nil
+}
+
+// GetTodoPage のモック実装（IDの昇順。カーソルは次のページの先頭のID）
+func (m *MockTodoService) GetTodoPage(ctx context.Context, cursor string, limit int) (repository.TodoPage, error) {
+	m.callCounts["GetTodoPage"]++
+
+	if m.shouldError {
+		return repository.TodoPage{}, m.failure()
+	}
+
+	start := 0
+	if cursor != "" {
+		var err error
+		if start, err = strconv.Atoi(cursor); err != nil {
+			return repository.TodoPage{}, domainerr.Invalid("cursor", cursor)
+		}
+	}
+	ids := make([]int, 0, len(m.todos))
+	for id := range m.todos {
+		if id >= start {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	page := repository.TodoPage{Todos: make([]*entity.Todo, 0, limit)}
+	for i, id := range ids {
+		if i == limit {
+			page.NextCursor = strconv.Itoa(id)
+			break
+		}
+		todoCopy := *m.todos[id]
+		page.Todos = append(page.Todos, &todoCopy)
+	}
+	return page, nil
+}
+
+// GetTodosByColor のモック実装
+func (m *MockTodoService) GetTodosByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	m.callCounts["GetTodosByColor"]++
+
+	if m.shouldError {
+		return nil, m.failure()
+	}
+
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todos {
+		if todo.Color == color {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+
+	return result, nil
+}
+
+// FindTodos のモック実装（同じ順位のTodoはIDの昇順）
+func (m *MockTodoService) FindTodos(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	m.callCounts["FindTodos"]++
+
+	if m.shouldError {
+		return nil, m.failure()
+	}
+
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todos {
+		if filter.Matches(todo) {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	order.Sort(result)
+	return result, nil
+}
+
 // UpdateTodo のモック実装
 func (m *MockTodoService) UpdateTodo(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
 	m.callCounts["UpdateTodo"]++
 
 	if m.shouldError {
-		return nil, errors.New(m.errorMsg)
+		return nil, m.failure()
 	}
 
 	_, exists := m.todos[todo.ID]
 	if !exists {
-		return nil, errors.New("todo not found")
+		return nil, domainerr.NotFound("todo", nil)
 	}
 
 	todo.UpdatedAt = time.Now()
@@ -111,17 +229,41 @@ func (m *MockTodoService) UpdateTodo(ctx context.Context, todo *entity.Todo) (*e
 	return &savedTodo, nil
 }
 
+// UpdateTodos のモック実装
+func (m *MockTodoService) UpdateTodos(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	m.callCounts["UpdateTodos"]++
+
+	if m.shouldError {
+		return nil, m.failure()
+	}
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+
+	updated := make([]*entity.Todo, len(todos))
+	for i, todo := range todos {
+		if _, exists := m.todos[todo.ID]; !exists {
+			return nil, domainerr.NotFound("todo", nil)
+		}
+		todo.UpdatedAt = time.Now()
+		savedTodo := *todo
+		m.todos[todo.ID] = &savedTodo
+		updated[i] = &savedTodo
+	}
+	return updated, nil
+}
+
 // DeleteTodo のモック実装
 func (m *MockTodoService) DeleteTodo(ctx context.Context, id int) error {
 	m.callCounts["DeleteTodo"]++
 
 	if m.shouldError {
-		return errors.New(m.errorMsg)
+		return m.failure()
 	}
 
 	_, exists := m.todos[id]
 	if !exists {
-		return errors.New("todo not found")
+		return domainerr.NotFound("todo", nil)
 	}
 
 	delete(m.todos, id)
@@ -133,12 +275,12 @@ func (m *MockTodoService) CompleteTodo(ctx context.Context, id int) (*entity.Tod
 	m.callCounts["CompleteTodo"]++
 
 	if m.shouldError {
-		return nil, errors.New(m.errorMsg)
+		return nil, m.failure()
 	}
 
 	todo, exists := m.todos[id]
 	if !exists {
-		return nil, errors.New("todo not found")
+		return nil, domainerr.NotFound("todo", nil)
 	}
 
 	todo.MarkAsCompleted()
@@ -153,12 +295,12 @@ func (m *MockTodoService) IncompleteTodo(ctx context.Context, id int) (*entity.T
 	m.callCounts["IncompleteTodo"]++
 
 	if m.shouldError {
-		return nil, errors.New(m.errorMsg)
+		return nil, m.failure()
 	}
 
 	todo, exists := m.todos[id]
 	if !exists {
-		return nil, errors.New("todo not found")
+		return nil, domainerr.NotFound("todo", nil)
 	}
 
 	todo.MarkAsIncomplete()
@@ -168,6 +310,47 @@ func (m *MockTodoService) IncompleteTodo(ctx context.Context, id int) (*entity.T
 	return &result, nil
 }
 
+// ImportTodos のモック実装
+// CreateTodo と CompleteTodo を順に呼び出し、失敗した項目は *service.BatchError として返します
+func (m *MockTodoService) ImportTodos(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	created := make([]*entity.Todo, 0, len(todos))
+	for i, todo := range todos {
+		completed := todo.IsCompleted
+		saved, err := m.CreateTodo(ctx, todo)
+		if err == nil && completed {
+			saved, err = m.CompleteTodo(ctx, saved.ID)
+		}
+		if err != nil {
+			if errors.Is(err, service.ErrDuplicateTitle) {
+				return nil, &service.BatchError{Items: []*service.BatchItemError{{Index: i, Err: err}}}
+			}
+			return nil, err
+		}
+		created = append(created, saved)
+	}
+	return created, nil
+}
+
+// DuplicateTodo のモック実装
+func (m *MockTodoService) DuplicateTodo(ctx context.Context, id int, opts service.DuplicateTodoOptions) (*entity.Todo, error) {
+	m.callCounts["DuplicateTodo"]++
+
+	if m.shouldError {
+		return nil, m.failure()
+	}
+
+	source, exists := m.todos[id]
+	if !exists {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+
+	duplicate := source.Duplicate()
+	if opts.Title != "" {
+		duplicate.Title = opts.Title
+	}
+	return m.CreateTodo(ctx, duplicate)
+}
+
 // TestNewTodoHandler はTodoHandlerのコンストラクタをテストします
 func TestNewTodoHandler(t *testing.T) {
 	mockService := NewMockTodoService()
@@ -208,6 +391,38 @@ func TestTodoHandler_CreateTodo(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:           "色を指定して作成",
+			method:         http.MethodPost,
+			body:           `{"title":"色付きタスク","color":"#1E90FF"}`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var response map[string]interface{}
+				if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+					t.Errorf("レスポンスのJSONパースに失敗: %v", err)
+				}
+				if response["color"] != "#1e90ff" {
+					t.Errorf("色は小文字に正規化されるべきです: %v", response["color"])
+				}
+			},
+		},
+		{
+			name:           "不正な色",
+			method:         http.MethodPost,
+			body:           `{"title":"色付きタスク","color":"rgb(0,0,0)"}`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
+		},
+		{
+			name:           "見積もりが上限超過",
+			method:         http.MethodPost,
+			body:           `{"title":"見積もり","estimate_minutes":100000}`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
+		},
 		{
 			name:           "不正なHTTPメソッド",
 			method:         http.MethodGet,
@@ -232,6 +447,38 @@ func TestTodoHandler_CreateTodo(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
 		},
+		{
+			name:           "繰り返しTodoの作成",
+			method:         http.MethodPost,
+			body:           `{"title":"日報","due_date":"2024-05-01T09:00:00+09:00","recurrence":"daily"}`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var response map[string]interface{}
+				if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+					t.Errorf("レスポンスのJSONパースに失敗: %v", err)
+				}
+				if response["recurrence"] != "daily" || response["due_date"] != "2024-05-01T00:00:00Z" {
+					t.Errorf("繰り返し設定が正しくありません: %v, %v", response["recurrence"], response["due_date"])
+				}
+			},
+		},
+		{
+			name:           "未対応の繰り返し規則",
+			method:         http.MethodPost,
+			body:           `{"title":"日報","due_date":"2024-05-01T09:00:00Z","recurrence":"hourly"}`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
+		},
+		{
+			name:           "期限のない繰り返しTodo",
+			method:         http.MethodPost,
+			body:           `{"title":"日報","recurrence":"weekly"}`,
+			setupMock:      func(m *MockTodoService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
+		},
 		{
 			name:   "サービス層エラー",
 			method: http.MethodPost,
@@ -242,6 +489,16 @@ func TestTodoHandler_CreateTodo(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
 		},
+		{
+			name:   "タイトルの重複",
+			method: http.MethodPost,
+			body:   `{"title":"テスト"}`,
+			setupMock: func(m *MockTodoService) {
+				m.SetErrorValue(fmt.Errorf("%w: %q", service.ErrDuplicateTitle, "テスト"))
+			},
+			expectedStatus: http.StatusConflict,
+			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
+		},
 	}
 
 	for _, tt := range tests {
@@ -383,6 +640,276 @@ func TestTodoHandler_GetAllTodos(t *testing.T) {
 	}
 }
 
+// TestTodoHandler_GetAllTodos_Color は色による一覧の絞り込みをテストします
+func TestTodoHandler_GetAllTodos_Color(t *testing.T) {
+	mockService := NewMockTodoService()
+	mockService.todos[1] = &entity.Todo{ID: 1, Title: "青いカード", Color: "blue"}
+	mockService.todos[2] = &entity.Todo{ID: 2, Title: "赤いカード", Color: "#ff0000"}
+	handler := NewTodoHandler(mockService)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{name: "パレットの色", query: "?color=blue", expectedStatus: http.StatusOK, expectedCount: 1},
+		{name: "16進カラーコード（大文字・エンコード）", query: "?color=%23FF0000", expectedStatus: http.StatusOK, expectedCount: 1},
+		{name: "該当なし", query: "?color=green", expectedStatus: http.StatusOK, expectedCount: 0},
+		{name: "不正な色", query: "?color=navy", expectedStatus: http.StatusBadRequest},
+		{name: "空の色", query: "?color=", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.GetAllTodos(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos"+tt.query, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response dto.TodoListResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if len(response.Todos) != tt.expectedCount {
+				t.Errorf("件数 = %d, 期待値 = %d", len(response.Todos), tt.expectedCount)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_GetAllTodos_Filters は絞り込みの条件の組み合わせと並び順の指定をテストします
+func TestTodoHandler_GetAllTodos_Filters(t *testing.T) {
+	due := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	mockService := NewMockTodoService()
+	mockService.todos[1] = &entity.Todo{ID: 1, Title: "buy milk", Tags: []string{"shop"}, DueDate: &due}
+	mockService.todos[2] = &entity.Todo{ID: 2, Title: "Apple", Description: "MILK と一緒に", Tags: []string{"shop"}}
+	mockService.todos[3] = &entity.Todo{ID: 3, Title: "完了済み", Tags: []string{"shop"}, IsCompleted: true}
+	handler := NewTodoHandler(mockService)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedTitles  []string
+		expectedFilters map[string]string
+	}{
+		{
+			name: "タグ・完了状態・文字列・並び順の組み合わせ", query: "?tag=Shop&completed=false&q=milk&sort=title",
+			expectedStatus: http.StatusOK, expectedTitles: []string{"Apple", "buy milk"},
+			expectedFilters: map[string]string{"tag": "shop", "completed": "false", "q": "milk"},
+		},
+		{
+			name: "期限の範囲", query: "?due_from=2024-01-01T00:00:00Z&due_to=2024-02-01T09:00:00%2B09:00",
+			expectedStatus: http.StatusOK, expectedTitles: []string{"buy milk"},
+			expectedFilters: map[string]string{"due_from": "2024-01-01T00:00:00Z", "due_to": "2024-02-01T00:00:00Z"},
+		},
+		{name: "不正な完了状態", query: "?completed=yes", expectedStatus: http.StatusBadRequest},
+		{name: "不正なタグ", query: "?tag=a,b", expectedStatus: http.StatusBadRequest},
+		{name: "不正な期限", query: "?due_from=2024-01-01", expectedStatus: http.StatusBadRequest},
+		{name: "未知の並び順", query: "?sort=priority", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.GetAllTodos(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos"+tt.query, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response dto.TodoListResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			titles := make([]string, 0, len(response.Todos))
+			for _, todo := range response.Todos {
+				titles = append(titles, todo.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.expectedTitles) {
+				t.Errorf("タイトル = %v, 期待値 = %v", titles, tt.expectedTitles)
+			}
+			if !reflect.DeepEqual(response.Meta.Filters, tt.expectedFilters) {
+				t.Errorf("meta.filters = %v, 期待値 = %v", response.Meta.Filters, tt.expectedFilters)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_GetAllTodos_Cursor はカーソル（?cursor）で一覧を1ページずつ取得でき、絞り込みとの併用と不正なカーソルが 400 になることをテストします
+func TestTodoHandler_GetAllTodos_Cursor(t *testing.T) {
+	mockService := NewMockTodoService()
+	for i := 0; i < 3; i++ {
+		_, _ = mockService.CreateTodo(context.Background(), &entity.Todo{Title: fmt.Sprintf("Todo %d", i)})
+	}
+	handler := NewTodoHandler(mockService)
+
+	get := func(query string) (*httptest.ResponseRecorder, dto.TodoListResponse) {
+		rec := httptest.NewRecorder()
+		handler.GetAllTodos(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos"+query, nil))
+		var response dto.TodoListResponse
+		_ = json.Unmarshal(rec.Body.Bytes(), &response)
+		return rec, response
+	}
+
+	rec, first := get("?cursor=&limit=2")
+	if rec.Code != http.StatusOK || len(first.Todos) != 2 || first.Meta.NextCursor == "" {
+		t.Fatalf("最初のページ = %d, %s", rec.Code, rec.Body.String())
+	}
+	rec, second := get("?cursor=" + first.Meta.NextCursor + "&limit=2")
+	if rec.Code != http.StatusOK || len(second.Todos) != 1 || second.Meta.NextCursor != "" || strings.Contains(rec.Body.String(), "next_cursor") {
+		t.Errorf("最後のページ = %d, %s", rec.Code, rec.Body.String())
+	}
+
+	for _, query := range []string{"?cursor=&completed=true", "?cursor=&sort=title", "?cursor=abc"} {
+		if rec, _ := get(query); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s のステータスコード = %d, 期待値 = 400", query, rec.Code)
+		}
+	}
+}
+
+// TestTodoHandler_GetAllTodos_Meta は実際に適用した条件と、既定値に置き換えたパラメータがメタ情報に含まれることをテストします
+func TestTodoHandler_GetAllTodos_Meta(t *testing.T) {
+	handler := NewTodoHandler(NewMockTodoService())
+
+	tests := []struct {
+		name                string
+		query               string
+		expectedPage        int
+		expectedLimit       int
+		expectedFilters     map[string]string
+		expectedAdjustments []dto.ParamAdjustment
+	}{
+		{name: "指定なし", query: "", expectedPage: 1, expectedLimit: 10, expectedFilters: map[string]string{}},
+		{
+			name: "有効な指定と正規化した色", query: "?page=2&limit=50&color=%23FF0000",
+			expectedPage: 2, expectedLimit: 50, expectedFilters: map[string]string{"color": "#ff0000"},
+		},
+		{
+			name: "不正な値は既定値に置き換える", query: "?page=0&limit=500",
+			expectedPage: 1, expectedLimit: 10, expectedFilters: map[string]string{},
+			expectedAdjustments: []dto.ParamAdjustment{
+				{Param: "page", Requested: "0", Applied: "1", Reason: "page must be a positive integer"},
+				{Param: "limit", Requested: "500", Applied: "10", Reason: "limit must be an integer between 1 and 100"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.GetAllTodos(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos"+tt.query, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("ステータスコード = %v, body = %s", rec.Code, rec.Body.String())
+			}
+
+			var response dto.TodoListResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			meta := response.Meta
+			if meta.Page != tt.expectedPage || meta.Limit != tt.expectedLimit {
+				t.Errorf("page = %d, limit = %d, 期待値 = %d, %d", meta.Page, meta.Limit, tt.expectedPage, tt.expectedLimit)
+			}
+			if meta.Sort != dto.SortCreatedAtDesc {
+				t.Errorf("sort = %q, 期待値 = %q", meta.Sort, dto.SortCreatedAtDesc)
+			}
+			if !reflect.DeepEqual(meta.Filters, tt.expectedFilters) {
+				t.Errorf("filters = %v, 期待値 = %v", meta.Filters, tt.expectedFilters)
+			}
+			if !reflect.DeepEqual(meta.Adjustments, tt.expectedAdjustments) {
+				t.Errorf("adjustments = %+v, 期待値 = %+v", meta.Adjustments, tt.expectedAdjustments)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_Fields は ?fields= によるスパースフィールドセットをテストします
+func TestTodoHandler_Fields(t *testing.T) {
+	mockService := NewMockTodoService()
+	mockService.todos[1] = &entity.Todo{ID: 1, Title: "牛乳を買う", Description: "2本"}
+	handler := NewTodoHandler(mockService)
+
+	tests := []struct {
+		name           string
+		path           string
+		handle         http.HandlerFunc
+		expectedStatus int
+		expectedBody   string // 部分一致
+	}{
+		{
+			name: "一覧", path: "/api/v1/todos?fields=id,title,is_completed", handle: handler.GetAllTodos,
+			expectedStatus: http.StatusOK, expectedBody: `"todos":[{"id":1,"title":"牛乳を買う","is_completed":false}]`,
+		},
+		{
+			name: "1件", path: "/api/v1/todos/1?fields=title", handle: handler.GetTodoByID,
+			expectedStatus: http.StatusOK, expectedBody: `{"title":"牛乳を買う"}`,
+		},
+		{
+			name: "未知のフィールド", path: "/api/v1/todos?fields=title,secret", handle: handler.GetAllTodos,
+			expectedStatus: http.StatusBadRequest, expectedBody: `unknown field \"secret\"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handle(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.expectedBody) {
+				t.Errorf("body = %s, 期待値 = %s を含む", rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_GetTodoStats は集計取得ハンドラーをテストします
+func TestTodoHandler_GetTodoStats(t *testing.T) {
+	mockService := NewMockTodoService()
+	mockService.todos[1] = &entity.Todo{ID: 1, Title: "見積もり超過", IsCompleted: true, EstimateMinutes: 30, ActualMinutes: 50}
+	mockService.todos[2] = &entity.Todo{ID: 2, Title: "未完了", EstimateMinutes: 20}
+	handler := NewTodoHandler(mockService)
+
+	rec := httptest.NewRecorder()
+	handler.GetTodoStats(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos/stats", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+
+	var response dto.TodoStatsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if response.Total != 2 || response.Completed != 1 || response.Pending != 1 {
+		t.Errorf("件数 = %d/%d/%d, 期待値 = 2/1/1", response.Total, response.Completed, response.Pending)
+	}
+	if response.Estimates.RemainingMinutes != 20 || response.Estimates.Overrun != 1 {
+		t.Errorf("Estimates = %+v, 残り20分・超過1件を期待しました", response.Estimates)
+	}
+	if response.Estimates.AccuracyRatio == nil || *response.Estimates.AccuracyRatio != 1.67 {
+		t.Errorf("accuracy_ratio = %v, 期待値 = 1.67", response.Estimates.AccuracyRatio)
+	}
+
+	mockService.SetError(true, "database error")
+	rec = httptest.NewRecorder()
+	handler.GetTodoStats(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos/stats", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusInternalServerError)
+	}
+}
+
 // TestTodoHandler_GetTodoByID はID指定Todo取得ハンドラーをテストします
 func TestTodoHandler_GetTodoByID(t *testing.T) {
 	mockService := NewMockTodoService()
@@ -433,11 +960,20 @@ func TestTodoHandler_GetTodoByID(t *testing.T) {
 			name:   "サービス層エラー",
 			method: http.MethodGet,
 			setupMock: func(m *MockTodoService) {
-				m.SetError(true, "todo not found")
+				m.SetErrorValue(domainerr.NotFound("todo", nil))
 			},
 			expectedStatus: http.StatusNotFound,
 			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
 		},
+		{
+			name:   "データベースのクエリの期限切れ",
+			method: http.MethodGet,
+			setupMock: func(m *MockTodoService) {
+				m.SetErrorValue(fmt.Errorf("failed to get todo: %w", domainerr.Timeout("database query").Wrap(context.DeadlineExceeded)))
+			},
+			expectedStatus: http.StatusGatewayTimeout,
+			checkResponse:  func(t *testing.T, rec *httptest.ResponseRecorder) {},
+		},
 	}
 
 	for _, tt := range tests {
@@ -534,6 +1070,65 @@ func TestTodoHandler_UpdateTodo(t *testing.T) {
 	}
 }
 
+// TestTodoHandler_NotModified は値が何も変わらない更新を保存せずに返すことをテストします
+func TestTodoHandler_NotModified(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		completed       bool
+		wantNotModified bool
+		// wantCall はサービスの更新メソッド名と、期待する呼び出し回数です
+		wantCall  string
+		wantCalls int
+	}{
+		{name: "同じ値でのPUT", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"買い物","description":"牛乳"}`, wantNotModified: true, wantCall: "UpdateTodo", wantCalls: 0},
+		{name: "空のボディでのPUT", method: http.MethodPut, path: "/api/v1/todos/1", body: `{}`, wantNotModified: true, wantCall: "UpdateTodo", wantCalls: 0},
+		{name: "値を変更するPUT", method: http.MethodPut, path: "/api/v1/todos/1", body: `{"description":"卵"}`, wantCall: "UpdateTodo", wantCalls: 1},
+		{name: "完了済みTodoの完了", method: http.MethodPatch, path: "/api/v1/todos/1/complete", completed: true, wantNotModified: true, wantCall: "CompleteTodo", wantCalls: 0},
+		{name: "未完了Todoの完了", method: http.MethodPatch, path: "/api/v1/todos/1/complete", wantCall: "CompleteTodo", wantCalls: 1},
+		{name: "未完了Todoの未完了", method: http.MethodPatch, path: "/api/v1/todos/1/incomplete", wantNotModified: true, wantCall: "IncompleteTodo", wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockTodoService()
+			mockService.todos[1] = &entity.Todo{ID: 1, Title: "買い物", Description: "牛乳", IsCompleted: tt.completed}
+			handler := NewTodoHandler(mockService)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			switch {
+			case tt.method == http.MethodPut:
+				handler.UpdateTodo(rec, req)
+			case tt.wantCall == "CompleteTodo":
+				handler.CompleteTodo(rec, req)
+			default:
+				handler.IncompleteTodo(rec, req)
+			}
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+			}
+			if got := mockService.callCounts[tt.wantCall]; got != tt.wantCalls {
+				t.Errorf("%s の呼び出し回数 = %d, 期待値 = %d", tt.wantCall, got, tt.wantCalls)
+			}
+
+			var response dto.TodoResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスの解析に失敗: %v", err)
+			}
+			notModified := response.Meta != nil && response.Meta.NotModified
+			if notModified != tt.wantNotModified {
+				t.Errorf("meta.not_modified = %v, 期待値 = %v", notModified, tt.wantNotModified)
+			}
+		})
+	}
+}
+
 // TestTodoHandler_DeleteTodo はTodo削除ハンドラーをテストします
 func TestTodoHandler_DeleteTodo(t *testing.T) {
 	mockService := NewMockTodoService()
@@ -614,3 +1209,108 @@ func TestTodoHandler_DeleteTodo(t *testing.T) {
 //    - 外部依存（サービス層）の分離
 //    - テスト専用のモック実装
 //    - エラーケースのシミュレーション
+
+// TestTodoHandler_DuplicateTodo はTodo複製のレスポンスをテストします
+func TestTodoHandler_DuplicateTodo(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedTitle  string
+	}{
+		{name: "ボディなしで複製", method: http.MethodPost, path: "/api/v1/todos/1/duplicate", expectedStatus: http.StatusCreated, expectedTitle: "元のタスク"},
+		{name: "タイトルを指定して複製", method: http.MethodPost, path: "/api/v1/todos/1/duplicate", body: `{"title":"コピー","include_checklist":true}`, expectedStatus: http.StatusCreated, expectedTitle: "コピー"},
+		{name: "空のタイトル", method: http.MethodPost, path: "/api/v1/todos/1/duplicate", body: `{"title":" "}`, expectedStatus: http.StatusBadRequest},
+		{name: "不正なJSON", method: http.MethodPost, path: "/api/v1/todos/1/duplicate", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "存在しないTodo", method: http.MethodPost, path: "/api/v1/todos/999/duplicate", expectedStatus: http.StatusNotFound},
+		{name: "数値でないID", method: http.MethodPost, path: "/api/v1/todos/abc/duplicate", expectedStatus: http.StatusBadRequest},
+		{name: "不正なHTTPメソッド", method: http.MethodGet, path: "/api/v1/todos/1/duplicate", expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockTodoService()
+			mockService.CreateTodo(context.Background(), &entity.Todo{Title: "元のタスク"})
+			handler := NewTodoHandler(mockService)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.DuplicateTodo(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v (%s)", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if rec.Code != http.StatusCreated {
+				return
+			}
+
+			var response map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response["title"] != tt.expectedTitle || response["id"] != float64(2) {
+				t.Errorf("レスポンス = %v", response)
+			}
+			if location := rec.Header().Get("Location"); location != "/api/v1/todos/2" {
+				t.Errorf("Location = %q, 期待値 = /api/v1/todos/2", location)
+			}
+		})
+	}
+}
+
+// TestTodoHandler_IDCodec はIDを不透明な文字列で公開する場合に、URL・レスポンス・HTMLのIDが変換されることをテストします
+func TestTodoHandler_IDCodec(t *testing.T) {
+	codec, err := hashids.New("todoapp", 8)
+	if err != nil {
+		t.Fatalf("hashids.New() error = %v", err)
+	}
+	originalEncoding := dto.Encoding
+	defer func() { dto.Encoding = originalEncoding }()
+	dto.Encoding = dto.EncodingOptions{TimeFormat: dto.TimeFormatRFC3339, IDCodec: codec}
+
+	mockService := NewMockTodoService()
+	mockService.CreateTodo(context.Background(), &entity.Todo{Title: "元のタスク"})
+	handler := NewTodoHandler(mockService)
+	first, _ := codec.Encode(1)
+	second, _ := codec.Encode(2)
+
+	tests := []struct {
+		name           string
+		path           string
+		accept         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "変換後のIDで取得", path: "/api/v1/todos/" + first, expectedStatus: http.StatusOK, expectedBody: `"id":"` + first + `"`},
+		{name: "HTMLフラグメント", path: "/api/v1/todos/" + first, accept: "text/html", expectedStatus: http.StatusOK, expectedBody: `hx-delete="/api/v1/todos/` + first + `"`},
+		{name: "連番の整数のID", path: "/api/v1/todos/1", expectedStatus: http.StatusBadRequest},
+		{name: "存在しないTodo", path: "/api/v1/todos/" + second, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.GetTodoByID(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v (%s)", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.expectedBody) {
+				t.Errorf("レスポンスに %s が含まれていません: %s", tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+
+	// 複製したTodoの Location も変換後のIDになる
+	rec := httptest.NewRecorder()
+	handler.DuplicateTodo(rec, httptest.NewRequest(http.MethodPost, "/api/v1/todos/"+first+"/duplicate", nil))
+	if location := rec.Header().Get("Location"); location != "/api/v1/todos/"+second {
+		t.Errorf("Location = %q, 期待値 = /api/v1/todos/%s", location, second)
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TodoHistoryHandler はTodoの変更履歴のHTTPリクエストを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET /api/v1/todos/{id}/history -> 変更履歴の取得（古い順）
+// GET /feeds/todos.atom           -> 最近作成・完了されたTodoのAtomフィード
+type TodoHistoryHandler struct {
+	historyService service.TodoHistoryServiceInterface
+
+	// now は現在時刻の取得関数です（エントリーがないフィードの更新日時に使用、テストで固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewTodoHistoryHandler はTodoHistoryHandlerのコンストラクタです
+func NewTodoHistoryHandler(historyService service.TodoHistoryServiceInterface) *TodoHistoryHandler {
+	return &TodoHistoryHandler{
+		historyService: historyService,
+		now:            time.Now,
+	}
+}
+
+// GetHistory は指定されたTodoの変更履歴を返します
+// GET /api/v1/todos/{id}/history
+func (h *TodoHistoryHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed", "")
+		return
+	}
+
+	todoID, err := parseHistoryPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	entries, err := h.historyService.GetHistory(r.Context(), todoID)
+	if err != nil {
+		switch {
+		case domainerr.IsNotFound(err):
+			writeErrorResponse(w, http.StatusNotFound, "Todo not found", "")
+		case domainerr.IsInvalid(err):
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid todo ID", err.Error())
+		default:
+			writeServerError(w, "Failed to get todo history", err)
+		}
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToTodoHistoryResponse(entries))
+}
+
+// ActivityFeed は最近作成・完了されたTodoをAtomフィードで返します
+// GET /feeds/todos.atom
+// フィードリーダーにこのURLを登録すると、Todoの作成と完了を追いかけられます
+func (h *TodoHistoryHandler) ActivityFeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed", "")
+		return
+	}
+
+	entries, err := h.historyService.RecentActivity(r.Context())
+	if err != nil {
+		writeServerError(w, "Failed to get recent activity", err)
+		return
+	}
+
+	writeActivityFeed(w, r, entries, h.now())
+}
+
+// parseHistoryPath はURLパスからTodoIDを抽出します
+// パスの構造: /api/v1/todos/{id}/history
+func parseHistoryPath(path string) (int, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[4] != "history" {
+		return 0, errors.New("invalid endpoint")
+	}
+
+	todoID, err := dto.ParseID(pathParts[3])
+	if err != nil {
+		return 0, errors.New("todo ID must be a number")
+	}
+
+	return todoID, nil
+}
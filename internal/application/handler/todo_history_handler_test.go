@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockTodoHistoryService はテスト用のTodoHistoryServiceのモック実装です
+// TodoID=1 のTodoのみ履歴を持つ前提で動作します
+type MockTodoHistoryService struct{}
+
+func (m *MockTodoHistoryService) GetHistory(ctx context.Context, todoID int) ([]*entity.TodoHistoryEntry, error) {
+	if todoID != 1 {
+		return nil, domainerr.NotFound("todo", 2).Wrap(domainerr.NotFound("todo", nil))
+	}
+	before := &entity.Todo{ID: 1, Title: "変更前"}
+	after := &entity.Todo{ID: 1, Title: "変更後"}
+	return []*entity.TodoHistoryEntry{
+		entity.NewTodoHistoryEntry(entity.TodoHistoryCreated, "alice", nil, before),
+		entity.NewTodoHistoryEntry(entity.TodoHistoryUpdated, "bob", before, after),
+	}, nil
+}
+
+func (m *MockTodoHistoryService) RecentActivity(ctx context.Context) ([]*entity.TodoHistoryEntry, error) {
+	todo := &entity.Todo{ID: 1, Title: "牛乳を買う", Description: "低脂肪"}
+	completed := entity.NewTodoHistoryEntry(entity.TodoHistoryCompleted, "alice", nil, todo)
+	completed.ID, completed.TodoID = 2, 1
+	completed.ChangedAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	created := entity.NewTodoHistoryEntry(entity.TodoHistoryCreated, "alice", nil, todo)
+	created.ID, created.TodoID = 1, 1
+	created.ChangedAt = time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	return []*entity.TodoHistoryEntry{completed, created}, nil
+}
+
+// TestTodoHistoryHandler_GetHistory は変更履歴の取得とエラー応答をテストします
+func TestTodoHistoryHandler_GetHistory(t *testing.T) {
+	handler := NewTodoHistoryHandler(&MockTodoHistoryService{})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "履歴の取得", method: http.MethodGet, path: "/api/v1/todos/1/history", expectedStatus: http.StatusOK},
+		{name: "存在しないTodo", method: http.MethodGet, path: "/api/v1/todos/2/history", expectedStatus: http.StatusNotFound},
+		{name: "数値でないID", method: http.MethodGet, path: "/api/v1/todos/abc/history", expectedStatus: http.StatusBadRequest},
+		{name: "不正なHTTPメソッド", method: http.MethodPost, path: "/api/v1/todos/1/history", expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.GetHistory(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handler.GetHistory(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos/1/history", nil))
+	var response dto.TodoHistoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if len(response.History) != 2 {
+		t.Fatalf("件数 = %d, 期待値 = 2", len(response.History))
+	}
+	first, second := response.History[0], response.History[1]
+	if first.Action != "create" || first.Before != nil || first.After == nil || first.Actor != "alice" {
+		t.Errorf("作成の履歴が正しくありません: %+v", first)
+	}
+	if second.Before == nil || second.Before.Title != "変更前" || second.After.Title != "変更後" {
+		t.Errorf("更新の履歴が正しくありません: %+v", second)
+	}
+}
+
+// TestTodoHistoryHandler_ActivityFeed は最近の活動のAtomフィードをテストします
+func TestTodoHistoryHandler_ActivityFeed(t *testing.T) {
+	handler := NewTodoHistoryHandler(&MockTodoHistoryService{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/feeds/todos.atom", nil)
+	req.Host = "todo.example.com"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	handler.ActivityFeed(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/atom+xml; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.HasPrefix(rec.Body.String(), xml.Header) {
+		t.Error("XML宣言が出力されていません")
+	}
+
+	var feed atomFeed
+	if err := xml.Unmarshal(rec.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("フィードのXMLパースに失敗: %v", err)
+	}
+	if feed.ID != "https://todo.example.com/feeds/todos.atom" {
+		t.Errorf("フィードのID = %q", feed.ID)
+	}
+	if feed.Updated != "2024-05-01T10:00:00Z" {
+		t.Errorf("フィードの更新日時 = %q, 期待値 = 最新のエントリーの日時", feed.Updated)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("エントリー数 = %d, 期待値 = 2", len(feed.Entries))
+	}
+	first := feed.Entries[0]
+	if first.Title != "完了: 牛乳を買う" || first.Category.Term != "complete" || first.Author.Name != "alice" {
+		t.Errorf("完了のエントリーが正しくありません: %+v", first)
+	}
+	if first.ID != "https://todo.example.com/api/v1/todos/1/history#2" || first.Link.Href != "https://todo.example.com/api/v1/todos/1" {
+		t.Errorf("エントリーのIDまたはリンクが正しくありません: %+v", first)
+	}
+	if feed.Entries[1].Title != "作成: 牛乳を買う" {
+		t.Errorf("作成のエントリーのタイトル = %q", feed.Entries[1].Title)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ActivityFeed(rec, httptest.NewRequest(http.MethodPost, "/feeds/todos.atom", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET" || !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		t.Errorf("POST: ステータスコード = %v, Allow = %q, Content-Type = %q", rec.Code, rec.Header().Get("Allow"), rec.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TodoShareHandler はTodoを他のユーザーと共有する設定のHTTPリクエストを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET    /api/v1/todos/{id}/shares              -> 共有の一覧
+// PUT    /api/v1/todos/{id}/shares/{username}   -> 共有（既に共有している場合は権限を置き換える）
+// DELETE /api/v1/todos/{id}/shares/{username}   -> 共有の解除
+type TodoShareHandler struct {
+	shareService service.TodoShareServiceInterface
+}
+
+// NewTodoShareHandler はTodoShareHandlerのコンストラクタです
+func NewTodoShareHandler(shareService service.TodoShareServiceInterface) *TodoShareHandler {
+	return &TodoShareHandler{
+		shareService: shareService,
+	}
+}
+
+// ListShares はTodoの共有の一覧を返します
+// GET /api/v1/todos/{id}/shares
+func (h *TodoShareHandler) ListShares(w http.ResponseWriter, r *http.Request) {
+	todoID, _, err := parseSharePath(r.URL.Path, false)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	shares, err := h.shareService.ListShares(r.Context(), todoID)
+	if err != nil {
+		writeShareServiceError(w, "Failed to get shares", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToTodoShareListResponse(shares))
+}
+
+// ShareTodo はTodoをユーザーと共有します
+// PUT /api/v1/todos/{id}/shares/{username}
+// ボディ: {"permission": "read"} または {"permission": "write"}
+func (h *TodoShareHandler) ShareTodo(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	todoID, username, err := parseSharePath(r.URL.Path, true)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	var req dto.ShareTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	share, err := h.shareService.ShareTodo(r.Context(), todoID, username, entity.SharePermission(req.Permission))
+	if err != nil {
+		writeShareServiceError(w, "Failed to share todo", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToTodoShareResponse(share))
+}
+
+// UnshareTodo はユーザーとの共有を解除します
+// DELETE /api/v1/todos/{id}/shares/{username}
+func (h *TodoShareHandler) UnshareTodo(w http.ResponseWriter, r *http.Request) {
+	todoID, username, err := parseSharePath(r.URL.Path, true)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	if err := h.shareService.UnshareTodo(r.Context(), todoID, username); err != nil {
+		writeShareServiceError(w, "Failed to unshare todo", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseSharePath はURLパスからTodoIDと共有先のユーザー名を抽出します
+// パスの構造: /api/v1/todos/{id}/shares[/{username}]
+func parseSharePath(path string, withUsername bool) (int, string, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[4] != "shares" {
+		return 0, "", errors.New("invalid endpoint")
+	}
+
+	todoID, err := dto.ParseID(pathParts[3])
+	if err != nil {
+		return 0, "", errors.New("todo ID must be a number")
+	}
+
+	if !withUsername {
+		return todoID, "", nil
+	}
+	if len(pathParts) < 6 || pathParts[5] == "" {
+		return 0, "", errors.New("username is required")
+	}
+	return todoID, pathParts[5], nil
+}
+
+// writeShareServiceError はサービス層のエラーを適切なHTTPステータスに変換して書き込みます
+func writeShareServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTodoForbidden):
+		writeErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+	case domainerr.IsNotFound(err):
+		writeErrorResponse(w, http.StatusNotFound, "Not found", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, message, err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// MockTodoShareService はテスト用のTodoShareServiceのモック実装です
+// TodoID=1 のTodoのみ存在し、ユーザー "bob" と "carol" だけが存在する前提で動作します
+type MockTodoShareService struct {
+	shares map[string]*entity.TodoShare
+}
+
+func NewMockTodoShareService() *MockTodoShareService {
+	return &MockTodoShareService{shares: make(map[string]*entity.TodoShare)}
+}
+
+func (m *MockTodoShareService) check(todoID int, username string) error {
+	switch {
+	case todoID == 2:
+		return fmt.Errorf("%w: only the owner can manage shares of todo 2", service.ErrTodoForbidden)
+	case todoID != 1:
+		return domainerr.NotFound("todo", todoID)
+	case username != "" && username != "bob" && username != "carol":
+		return domainerr.NotFound("user", username)
+	}
+	return nil
+}
+
+func (m *MockTodoShareService) ShareTodo(ctx context.Context, todoID int, username string, permission entity.SharePermission) (*entity.TodoShare, error) {
+	if !permission.IsValid() {
+		return nil, domainerr.Invalid("permission", "must be one of [read write]")
+	}
+	if err := m.check(todoID, username); err != nil {
+		return nil, err
+	}
+	share := &entity.TodoShare{TodoID: todoID, Username: username, Permission: permission, CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	m.shares[username] = share
+	return share, nil
+}
+
+func (m *MockTodoShareService) ListShares(ctx context.Context, todoID int) ([]*entity.TodoShare, error) {
+	if err := m.check(todoID, ""); err != nil {
+		return nil, err
+	}
+	shares := make([]*entity.TodoShare, 0, len(m.shares))
+	for _, share := range m.shares {
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+func (m *MockTodoShareService) UnshareTodo(ctx context.Context, todoID int, username string) error {
+	if err := m.check(todoID, username); err != nil {
+		return err
+	}
+	if _, ok := m.shares[username]; !ok {
+		return domainerr.NotFound("todo share", username)
+	}
+	delete(m.shares, username)
+	return nil
+}
+
+// TestTodoShareHandler は共有の設定・一覧・解除と、サービスのエラーのステータスコードへの変換をテストします
+func TestTodoShareHandler(t *testing.T) {
+	h := NewTodoShareHandler(NewMockTodoShareService())
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		handle         func(http.ResponseWriter, *http.Request)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "共有", method: http.MethodPut, path: "/api/v1/todos/1/shares/bob", body: `{"permission":"read"}`, handle: h.ShareTodo, expectedStatus: http.StatusOK, expectedBody: `"permission":"read"`},
+		{name: "権限の置き換え", method: http.MethodPut, path: "/api/v1/todos/1/shares/bob", body: `{"permission":"write"}`, handle: h.ShareTodo, expectedStatus: http.StatusOK, expectedBody: `"permission":"write"`},
+		{name: "不正な権限", method: http.MethodPut, path: "/api/v1/todos/1/shares/bob", body: `{"permission":"admin"}`, handle: h.ShareTodo, expectedStatus: http.StatusBadRequest},
+		{name: "存在しないユーザー", method: http.MethodPut, path: "/api/v1/todos/1/shares/dave", body: `{"permission":"read"}`, handle: h.ShareTodo, expectedStatus: http.StatusNotFound},
+		{name: "所有者以外", method: http.MethodPut, path: "/api/v1/todos/2/shares/bob", body: `{"permission":"read"}`, handle: h.ShareTodo, expectedStatus: http.StatusForbidden},
+		{name: "不正なTodoID", method: http.MethodPut, path: "/api/v1/todos/abc/shares/bob", body: `{"permission":"read"}`, handle: h.ShareTodo, expectedStatus: http.StatusBadRequest},
+		{name: "一覧", method: http.MethodGet, path: "/api/v1/todos/1/shares", handle: h.ListShares, expectedStatus: http.StatusOK, expectedBody: `"username":"bob"`},
+		{name: "存在しないTodoの一覧", method: http.MethodGet, path: "/api/v1/todos/3/shares", handle: h.ListShares, expectedStatus: http.StatusNotFound},
+		{name: "解除", method: http.MethodDelete, path: "/api/v1/todos/1/shares/bob", handle: h.UnshareTodo, expectedStatus: http.StatusNoContent},
+		{name: "共有していないユーザーの解除", method: http.MethodDelete, path: "/api/v1/todos/1/shares/carol", handle: h.UnshareTodo, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			tt.handle(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedBody != "" && !strings.Contains(rec.Body.String(), tt.expectedBody) {
+				t.Errorf("レスポンス = %s, 期待値 = %s を含む", rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// defaultTransferFormat は ?format= を省略した場合の形式です
+const defaultTransferFormat = "json"
+
+// TodoTransferHandler はTodoのインポート・エクスポートを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET  /api/v1/todos/export?format=json -> 全てのTodoを指定した形式のファイルとしてダウンロード
+// POST /api/v1/todos/import?format=json -> 指定した形式のボディからTodoを作成
+//
+// 形式は transfer.Registry に登録されたものから名前で選びます
+// 新しい形式を追加してもこのハンドラーを変更する必要はありません
+type TodoTransferHandler struct {
+	todoService service.TodoServiceInterface
+	formats     *transfer.Registry
+}
+
+// NewTodoTransferHandler はTodoTransferHandlerのコンストラクタです
+func NewTodoTransferHandler(todoService service.TodoServiceInterface, formats *transfer.Registry) *TodoTransferHandler {
+	return &TodoTransferHandler{
+		todoService: todoService,
+		formats:     formats,
+	}
+}
+
+// Export は全てのTodoを指定した形式で返します
+// GET /api/v1/todos/export?format=json
+func (h *TodoTransferHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := transferFormat(r)
+	exporter, ok := h.formats.Exporter(name)
+	if !ok {
+		writeUnsupportedFormat(w, name, h.formats.ExportFormats())
+		return
+	}
+
+	todos, err := h.todoService.GetAllTodos(r.Context())
+	if err != nil {
+		writeServerError(w, "Failed to get todos", err)
+		return
+	}
+
+	// 途中で失敗した場合に 500 を返せるよう、書き出しが終わるまでバッファに溜める
+	var body bytes.Buffer
+	if err := exporter.Export(&body, todos); err != nil {
+		writeServerError(w, "Failed to export todos", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", exporter.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="todos.%s"`, exporter.FileExtension()))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// Import はボディを指定した形式で読み込み、Todoを作成します
+// POST /api/v1/todos/import?format=json
+//
+// 全てのTodoを1つのトランザクションで作成するため、1件でも不正なTodoや作成の失敗がある場合は何も作成しません
+// 完了済みのTodoは作成した後に完了にします（変更履歴とWebhookには作成と完了の両方が記録されます）
+func (h *TodoTransferHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := transferFormat(r)
+	importer, ok := h.formats.Importer(name)
+	if !ok {
+		writeUnsupportedFormat(w, name, h.formats.ImportFormats())
+		return
+	}
+
+	todos, err := importer.Import(r.Body)
+	if err != nil {
+		if isBodyLimitError(err) {
+			writeBodyDecodeError(w, r, err)
+			return
+		}
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid import data", err.Error())
+		return
+	}
+	for i, todo := range todos {
+		if !todo.IsValid() {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid import data",
+				fmt.Sprintf("todo %d: title is required and must be 100 characters or less", i+1))
+			return
+		}
+	}
+
+	created, err := h.todoService.ImportTodos(r.Context(), todos)
+	if err != nil {
+		var batchErr *service.BatchError
+		if !errors.As(err, &batchErr) {
+			writeServerError(w, "Failed to import todos", err)
+			return
+		}
+		// 最初に失敗した項目の内容を返す（どの項目も作成されていない）
+		item := batchErr.Items[0]
+		details := fmt.Sprintf("todo %d: %v", item.Index+1, item.Err)
+		if errors.Is(item.Err, service.ErrDuplicateTitle) {
+			writeErrorResponse(w, http.StatusConflict, "Duplicate title", details)
+			return
+		}
+		writeErrorResponse(w, batchItemStatus(item.Err), "Invalid import data", details)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, dto.ToImportTodosResponse(name, created))
+}
+
+// transferFormat は ?format= の形式の名前を返します（省略時は json）
+func transferFormat(r *http.Request) string {
+	if name := r.URL.Query().Get("format"); name != "" {
+		return strings.ToLower(name)
+	}
+	return defaultTransferFormat
+}
+
+// writeUnsupportedFormat は登録されていない形式が指定された場合の 400 を返します
+func writeUnsupportedFormat(w http.ResponseWriter, name string, supported []string) {
+	writeErrorResponse(w, http.StatusBadRequest, "Unsupported format",
+		fmt.Sprintf("format %q is not supported (must be one of %s)", name, strings.Join(supported, ", ")))
+}
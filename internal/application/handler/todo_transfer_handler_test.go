@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// linesFormat はテスト用の形式です（1行に1件のタイトル、"x " で始まる行は完了済み）
+type linesFormat struct{}
+
+func (linesFormat) Name() string          { return "lines" }
+func (linesFormat) ContentType() string   { return "text/plain; charset=utf-8" }
+func (linesFormat) FileExtension() string { return "txt" }
+
+func (linesFormat) Export(w io.Writer, todos []*entity.Todo) error {
+	for _, todo := range todos {
+		prefix := ""
+		if todo.IsCompleted {
+			prefix = "x "
+		}
+		fmt.Fprintln(w, prefix+todo.Title)
+	}
+	return nil
+}
+
+func (linesFormat) Import(r io.Reader) ([]*entity.Todo, error) {
+	var todos []*entity.Todo
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		title, completed := strings.CutPrefix(scanner.Text(), "x ")
+		todos = append(todos, &entity.Todo{Title: title, IsCompleted: completed})
+	}
+	return todos, scanner.Err()
+}
+
+// TestTodoTransferHandler_Export はエクスポートの形式の選択とレスポンスをテストします
+func TestTodoTransferHandler_Export(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		serviceErr     bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "形式を指定", method: http.MethodGet, query: "?format=LINES", expectedStatus: http.StatusOK, expectedBody: "x 牛乳を買う\n"},
+		{name: "登録されていない形式", method: http.MethodGet, query: "?format=yaml", expectedStatus: http.StatusBadRequest},
+		{name: "省略時の json が登録されていない", method: http.MethodGet, expectedStatus: http.StatusBadRequest},
+		{name: "取得の失敗", method: http.MethodGet, query: "?format=lines", serviceErr: true, expectedStatus: http.StatusInternalServerError},
+		{name: "GET以外のメソッド", method: http.MethodPost, query: "?format=lines", expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockTodoService()
+			mockService.CreateTodo(context.Background(), &entity.Todo{Title: "牛乳を買う", IsCompleted: true})
+			mockService.SetError(tt.serviceErr, "database error")
+			h := NewTodoTransferHandler(mockService, transfer.NewRegistry(linesFormat{}))
+
+			rec := httptest.NewRecorder()
+			h.Export(rec, httptest.NewRequest(tt.method, "/api/v1/todos/export"+tt.query, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="todos.txt"` {
+				t.Errorf("Content-Disposition = %q", got)
+			}
+			if got := rec.Header().Get("Content-Type"); got != "text/plain; charset=utf-8" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if rec.Body.String() != tt.expectedBody {
+				t.Errorf("ボディ = %q, 期待値 = %q", rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+// TestTodoTransferHandler_Import はインポートの検証と作成をテストします
+func TestTodoTransferHandler_Import(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		query           string
+		body            string
+		serviceErr      error
+		expectedStatus  int
+		expectedCreated int
+	}{
+		{name: "インポート成功", method: http.MethodPost, query: "?format=lines", body: "牛乳を買う\nx 請求書を送る\n", expectedStatus: http.StatusCreated, expectedCreated: 2},
+		{name: "不正なTodoがあれば何も作成しない", method: http.MethodPost, query: "?format=lines", body: "牛乳を買う\n\n", expectedStatus: http.StatusBadRequest},
+		{name: "登録されていない形式", method: http.MethodPost, query: "?format=yaml", body: "牛乳を買う\n", expectedStatus: http.StatusBadRequest},
+		{name: "タイトルの重複", method: http.MethodPost, query: "?format=lines", body: "牛乳を買う\n", serviceErr: service.ErrDuplicateTitle, expectedStatus: http.StatusConflict},
+		{name: "作成の失敗", method: http.MethodPost, query: "?format=lines", body: "牛乳を買う\n", serviceErr: fmt.Errorf("database error"), expectedStatus: http.StatusInternalServerError},
+		{name: "POST以外のメソッド", method: http.MethodGet, query: "?format=lines", expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := NewMockTodoService()
+			if tt.serviceErr != nil {
+				mockService.SetErrorValue(tt.serviceErr)
+			}
+			h := NewTodoTransferHandler(mockService, transfer.NewRegistry(linesFormat{}))
+
+			rec := httptest.NewRecorder()
+			h.Import(rec, httptest.NewRequest(tt.method, "/api/v1/todos/import"+tt.query, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedStatus == http.StatusBadRequest && mockService.callCounts["CreateTodo"] != 0 {
+				t.Errorf("検証エラーの場合は作成しないべきです（作成 %d 回）", mockService.callCounts["CreateTodo"])
+			}
+			if tt.expectedStatus != http.StatusCreated {
+				return
+			}
+
+			var response dto.ImportTodosResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response.Format != "lines" || response.Imported != tt.expectedCreated || len(response.Todos) != tt.expectedCreated {
+				t.Errorf("レスポンス = %+v", response)
+			}
+			if response.Todos[0].IsCompleted || !response.Todos[1].IsCompleted {
+				t.Errorf("完了状態 = %v, %v, 期待値 = false, true", response.Todos[0].IsCompleted, response.Todos[1].IsCompleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// UndoHandler は直前の破壊的な操作の取り消しを処理するハンドラーです
+//
+// 対応するエンドポイント：
+// POST /api/v1/undo -> 操作者の直前の操作（削除）を取り消す
+type UndoHandler struct {
+	undoService service.UndoServiceInterface
+}
+
+// NewUndoHandler はUndoHandlerのコンストラクタです
+func NewUndoHandler(undoService service.UndoServiceInterface) *UndoHandler {
+	return &UndoHandler{
+		undoService: undoService,
+	}
+}
+
+// Undo は操作者の直前の操作を取り消し、元に戻したTodoのIDを返します
+// POST /api/v1/undo
+func (h *UndoHandler) Undo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.undoService.Undo(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrNothingToUndo) {
+			writeErrorResponse(w, http.StatusNotFound, "Nothing to undo", "no recent operation to undo, or the undo window has passed")
+			return
+		}
+		writeServerError(w, "Failed to undo", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToUndoResponse(string(result.Action), result.TodoIDs))
+}
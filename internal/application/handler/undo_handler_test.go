@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// MockUndoService はテスト用のUndoServiceのモック実装です
+type MockUndoService struct {
+	result *service.UndoResult
+	err    error
+}
+
+func (m *MockUndoService) Undo(ctx context.Context) (*service.UndoResult, error) {
+	return m.result, m.err
+}
+
+// TestUndoHandler_Undo は取り消しの結果とエラーのステータスコードをテストします
+func TestUndoHandler_Undo(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		mock           *MockUndoService
+		expectedStatus int
+	}{
+		{
+			name:           "取り消し成功",
+			method:         http.MethodPost,
+			mock:           &MockUndoService{result: &service.UndoResult{Action: service.UndoActionDelete, TodoIDs: []int{3}}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "取り消せる操作がない",
+			method:         http.MethodPost,
+			mock:           &MockUndoService{err: service.ErrNothingToUndo},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "元に戻す処理の失敗",
+			method:         http.MethodPost,
+			mock:           &MockUndoService{err: errors.New("failed to undo delete: database is down")},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "POST以外のメソッド",
+			method:         http.MethodGet,
+			mock:           &MockUndoService{},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewUndoHandler(tt.mock).Undo(rec, httptest.NewRequest(tt.method, "/api/v1/undo", nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response dto.UndoResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if response.Action != "delete" || len(response.TodoIDs) != 1 || response.TodoIDs[0] != 3 {
+				t.Errorf("レスポンス = %+v, 期待値 = delete [3]", response)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// WebhookHandler はWebhookの登録を管理するハンドラーです
+//
+// 対応するエンドポイント：
+// GET    /api/v1/webhooks      -> 登録一覧
+// POST   /api/v1/webhooks      -> 登録
+// GET    /api/v1/webhooks/{id} -> 登録の取得
+// DELETE /api/v1/webhooks/{id} -> 登録の削除
+type WebhookHandler struct {
+	webhookService service.WebhookServiceInterface
+}
+
+// NewWebhookHandler はWebhookHandlerのコンストラクタです
+func NewWebhookHandler(webhookService service.WebhookServiceInterface) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// RegisterWebhook はWebhookを登録します
+// POST /api/v1/webhooks
+func (h *WebhookHandler) RegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	created, err := h.webhookService.Register(r.Context(), req.ToEntity())
+	if err != nil {
+		writeWebhookServiceError(w, "Failed to register webhook", err)
+		return
+	}
+
+	w.Header().Set("Location", withBasePath(r, fmt.Sprintf("%s/webhooks/%s", apiV1Path, dto.FormatID(created.ID))))
+	writeJSONResponse(w, http.StatusCreated, dto.ToWebhookResponse(created))
+}
+
+// ListWebhooks はWebhookの登録一覧を返します
+// GET /api/v1/webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.webhookService.List(r.Context())
+	if err != nil {
+		writeServerError(w, "Failed to list webhooks", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToWebhookListResponse(subscriptions))
+}
+
+// GetWebhook はWebhookの登録を返します
+// GET /api/v1/webhooks/{id}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	subscription, err := h.webhookService.Get(r.Context(), id)
+	if err != nil {
+		writeWebhookServiceError(w, "Failed to get webhook", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToWebhookResponse(subscription))
+}
+
+// DeleteWebhook はWebhookの登録を削除します
+// DELETE /api/v1/webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookPath(r.URL.Path)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	if err := h.webhookService.Delete(r.Context(), id); err != nil {
+		writeWebhookServiceError(w, "Failed to delete webhook", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseWebhookPath はURLパスからWebhookの登録のIDを抽出します
+// パスの構造: /api/v1/webhooks/{id}
+func parseWebhookPath(path string) (int, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) != 4 || pathParts[2] != "webhooks" {
+		return 0, errors.New("invalid endpoint")
+	}
+
+	id, err := dto.ParseID(pathParts[3])
+	if err != nil {
+		return 0, errors.New("webhook ID must be a number")
+	}
+
+	return id, nil
+}
+
+// writeWebhookServiceError はサービス層のエラーをHTTPステータスに変換して返します
+func writeWebhookServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case domainerr.IsNotFound(err):
+		writeErrorResponse(w, http.StatusNotFound, "Webhook not found", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request", err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
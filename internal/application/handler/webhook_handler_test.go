@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockWebhookService はテスト用のWebhookServiceのモック実装です
+// ID=1 の登録のみ存在する前提で動作します
+type MockWebhookService struct{}
+
+func (m *MockWebhookService) Register(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if !subscription.IsValid() {
+		return nil, domainerr.ValidationFailed("webhook", "url must be an absolute http(s) URL")
+	}
+	subscription.ID = 2
+	return subscription, nil
+}
+
+func (m *MockWebhookService) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return []*entity.WebhookSubscription{
+		{ID: 1, URL: "https://example.com/hooks", Events: []entity.WebhookEvent{entity.WebhookEventTodoCompleted}},
+	}, nil
+}
+
+func (m *MockWebhookService) Get(ctx context.Context, id int) (*entity.WebhookSubscription, error) {
+	if id != 1 {
+		return nil, domainerr.NotFound("webhook", 2).Wrap(domainerr.NotFound("webhook", nil))
+	}
+	return &entity.WebhookSubscription{ID: 1, URL: "https://example.com/hooks", Events: []entity.WebhookEvent{entity.WebhookEventTodoCompleted}}, nil
+}
+
+func (m *MockWebhookService) Delete(ctx context.Context, id int) error {
+	_, err := m.Get(ctx, id)
+	return err
+}
+
+// TestWebhookHandler はWebhookの登録・一覧・取得・削除のレスポンスをテストします
+func TestWebhookHandler(t *testing.T) {
+	handler := NewWebhookHandler(&MockWebhookService{})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		serve          func(w http.ResponseWriter, r *http.Request)
+		expectedStatus int
+	}{
+		{name: "登録", method: http.MethodPost, path: "/api/v1/webhooks", body: `{"url":"https://example.com/hooks","events":["todo.created"]}`, serve: handler.RegisterWebhook, expectedStatus: http.StatusCreated},
+		{name: "未知のイベント", method: http.MethodPost, path: "/api/v1/webhooks", body: `{"url":"https://example.com/hooks","events":["todo.archived"]}`, serve: handler.RegisterWebhook, expectedStatus: http.StatusBadRequest},
+		{name: "不正なJSON", method: http.MethodPost, path: "/api/v1/webhooks", body: `{`, serve: handler.RegisterWebhook, expectedStatus: http.StatusBadRequest},
+		{name: "一覧", method: http.MethodGet, path: "/api/v1/webhooks", serve: handler.ListWebhooks, expectedStatus: http.StatusOK},
+		{name: "取得", method: http.MethodGet, path: "/api/v1/webhooks/1", serve: handler.GetWebhook, expectedStatus: http.StatusOK},
+		{name: "存在しない登録", method: http.MethodGet, path: "/api/v1/webhooks/2", serve: handler.GetWebhook, expectedStatus: http.StatusNotFound},
+		{name: "数値でないID", method: http.MethodGet, path: "/api/v1/webhooks/abc", serve: handler.GetWebhook, expectedStatus: http.StatusBadRequest},
+		{name: "削除", method: http.MethodDelete, path: "/api/v1/webhooks/1", serve: handler.DeleteWebhook, expectedStatus: http.StatusNoContent},
+		{name: "存在しない登録の削除", method: http.MethodDelete, path: "/api/v1/webhooks/2", serve: handler.DeleteWebhook, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			tt.serve(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(`{"url":"https://example.com/hooks","events":["todo.created","todo.deleted"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.RegisterWebhook(rec, req)
+
+	var response dto.WebhookResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+	}
+	if response.URL != "https://example.com/hooks" || len(response.Events) != 2 || response.Events[1] != "todo.deleted" {
+		t.Errorf("登録のレスポンス = %+v", response)
+	}
+	if location := rec.Header().Get("Location"); location != "/api/v1/webhooks/2" {
+		t.Errorf("Location = %q", location)
+	}
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// WorkspaceHandler はワークスペース（チーム）とメンバーシップのHTTPリクエストを処理するハンドラーです
+// ワークスペースのTodoは、X-Workspace-ID ヘッダーを付けて /api/v1/todos 配下を呼び出して操作します
+//
+// 対応するエンドポイント：
+// GET    /api/v1/workspaces                             -> 参加しているワークスペースの一覧
+// POST   /api/v1/workspaces                             -> 作成（作成したユーザーが所有者になる）
+// POST   /api/v1/workspaces/join                        -> 招待トークンで参加
+// GET    /api/v1/workspaces/{id}/members                -> メンバーの一覧
+// DELETE /api/v1/workspaces/{id}/members/{username}     -> メンバーの削除・ワークスペースから抜ける
+// POST   /api/v1/workspaces/{id}/invitations            -> 招待の作成（所有者のみ）
+type WorkspaceHandler struct {
+	workspaceService service.WorkspaceServiceInterface
+}
+
+// NewWorkspaceHandler はWorkspaceHandlerのコンストラクタです
+func NewWorkspaceHandler(workspaceService service.WorkspaceServiceInterface) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+	}
+}
+
+// ListWorkspaces は参加しているワークスペースの一覧を返します
+// GET /api/v1/workspaces
+func (h *WorkspaceHandler) ListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	workspaces, err := h.workspaceService.ListWorkspaces(r.Context())
+	if err != nil {
+		writeWorkspaceServiceError(w, "Failed to get workspaces", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToWorkspaceListResponse(workspaces))
+}
+
+// CreateWorkspace はワークスペースを作成します
+// POST /api/v1/workspaces
+// ボディ: {"name": "開発チーム"}
+func (h *WorkspaceHandler) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.CreateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	workspace, err := h.workspaceService.CreateWorkspace(r.Context(), req.Name)
+	if err != nil {
+		writeWorkspaceServiceError(w, "Failed to create workspace", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, dto.ToWorkspaceResponse(workspace))
+}
+
+// AcceptInvitation は招待トークンを使ってワークスペースに参加し、参加したワークスペースを返します
+// POST /api/v1/workspaces/join
+// ボディ: {"token": "..."}
+func (h *WorkspaceHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBodyDecodeError(w, r, err)
+		return
+	}
+
+	workspace, err := h.workspaceService.AcceptInvitation(r.Context(), req.Token)
+	if err != nil {
+		writeWorkspaceServiceError(w, "Failed to join workspace", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToWorkspaceResponse(workspace))
+}
+
+// ListMembers はワークスペースのメンバーの一覧を返します
+// GET /api/v1/workspaces/{id}/members
+func (h *WorkspaceHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	workspaceID, _, err := parseWorkspacePath(r.URL.Path, "members", false)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	members, err := h.workspaceService.ListMembers(r.Context(), workspaceID)
+	if err != nil {
+		writeWorkspaceServiceError(w, "Failed to get members", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, dto.ToWorkspaceMemberListResponse(members))
+}
+
+// RemoveMember はユーザーをワークスペースから外します（自分自身を指定した場合はワークスペースから抜けます）
+// DELETE /api/v1/workspaces/{id}/members/{username}
+func (h *WorkspaceHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	workspaceID, username, err := parseWorkspacePath(r.URL.Path, "members", true)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	if err := h.workspaceService.RemoveMember(r.Context(), workspaceID, username); err != nil {
+		writeWorkspaceServiceError(w, "Failed to remove member", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateInvitation はワークスペースへの招待を作成し、招待トークンを返します
+// POST /api/v1/workspaces/{id}/invitations
+func (h *WorkspaceHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	workspaceID, _, err := parseWorkspacePath(r.URL.Path, "invitations", false)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid URL", err.Error())
+		return
+	}
+
+	token, invitation, err := h.workspaceService.CreateInvitation(r.Context(), workspaceID)
+	if err != nil {
+		writeWorkspaceServiceError(w, "Failed to create invitation", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, dto.ToWorkspaceInvitationResponse(token, invitation))
+}
+
+// parseWorkspacePath はURLパスからワークスペースIDと、サブリソースの後のユーザー名を抽出します
+// パスの構造: /api/v1/workspaces/{id}/{subresource}[/{username}]
+func parseWorkspacePath(path, subresource string, withUsername bool) (int, string, error) {
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < 5 || pathParts[4] != subresource {
+		return 0, "", errors.New("invalid endpoint")
+	}
+
+	workspaceID, err := dto.ParseID(pathParts[3])
+	if err != nil {
+		return 0, "", errors.New("workspace ID must be a number")
+	}
+
+	if !withUsername {
+		return workspaceID, "", nil
+	}
+	if len(pathParts) < 6 || pathParts[5] == "" {
+		return 0, "", errors.New("username is required")
+	}
+	return workspaceID, pathParts[5], nil
+}
+
+// writeWorkspaceServiceError はサービス層のエラーを適切なHTTPステータスに変換して書き込みます
+func writeWorkspaceServiceError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, service.ErrWorkspaceForbidden):
+		writeErrorResponse(w, http.StatusForbidden, "Forbidden", err.Error())
+	case domainerr.IsNotFound(err):
+		writeErrorResponse(w, http.StatusNotFound, "Not found", err.Error())
+	case domainerr.IsInvalid(err):
+		writeErrorResponse(w, http.StatusBadRequest, message, err.Error())
+	default:
+		writeServerError(w, message, err)
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// MockWorkspaceService はテスト用のWorkspaceServiceのモック実装です
+// ワークスペース 1 のみ存在し、呼び出したユーザーはその所有者、ワークスペース 2 ではメンバー（所有者ではない）として動作します
+type MockWorkspaceService struct{}
+
+var mockWorkspace = &entity.Workspace{ID: 1, Name: "開発チーム", CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+func (MockWorkspaceService) CreateWorkspace(ctx context.Context, name string) (*entity.Workspace, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, domainerr.Invalid("name", "must be 1-100 characters")
+	}
+	return &entity.Workspace{ID: 1, Name: name, CreatedAt: mockWorkspace.CreatedAt}, nil
+}
+
+func (MockWorkspaceService) ListWorkspaces(ctx context.Context) ([]*entity.Workspace, error) {
+	return []*entity.Workspace{mockWorkspace}, nil
+}
+
+func (MockWorkspaceService) ListMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error) {
+	if workspaceID != 1 {
+		return nil, domainerr.NotFound("workspace", workspaceID)
+	}
+	return []*entity.WorkspaceMember{{WorkspaceID: 1, UserID: 1, Username: "alice", Role: entity.WorkspaceRoleOwner, JoinedAt: mockWorkspace.CreatedAt}}, nil
+}
+
+func (MockWorkspaceService) RemoveMember(ctx context.Context, workspaceID int, username string) error {
+	switch {
+	case workspaceID == 2:
+		return fmt.Errorf("%w: only the owner can remove members", service.ErrWorkspaceForbidden)
+	case workspaceID != 1:
+		return domainerr.NotFound("workspace", workspaceID)
+	case username != "bob":
+		return domainerr.NotFound("workspace member", username)
+	}
+	return nil
+}
+
+func (MockWorkspaceService) CreateInvitation(ctx context.Context, workspaceID int) (string, *entity.WorkspaceInvitation, error) {
+	if workspaceID == 2 {
+		return "", nil, fmt.Errorf("%w: only the owner can invite members", service.ErrWorkspaceForbidden)
+	}
+	return "invitation-token", &entity.WorkspaceInvitation{WorkspaceID: workspaceID, ExpiresAt: mockWorkspace.CreatedAt.Add(time.Hour)}, nil
+}
+
+func (MockWorkspaceService) AcceptInvitation(ctx context.Context, token string) (*entity.Workspace, error) {
+	if token != "invitation-token" {
+		return nil, domainerr.NotFound("workspace invitation", nil)
+	}
+	return mockWorkspace, nil
+}
+
+func (MockWorkspaceService) EnterWorkspace(ctx context.Context, workspaceID int) (context.Context, error) {
+	return repository.WithWorkspace(ctx, workspaceID), nil
+}
+
+// TestWorkspaceHandler はワークスペースのエンドポイントと、サービスのエラーのステータスコードへの変換をテストします
+func TestWorkspaceHandler(t *testing.T) {
+	h := NewWorkspaceHandler(MockWorkspaceService{})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		handle         func(http.ResponseWriter, *http.Request)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "作成", method: http.MethodPost, path: "/api/v1/workspaces", body: `{"name":"開発チーム"}`, handle: h.CreateWorkspace, expectedStatus: http.StatusCreated, expectedBody: `"name":"開発チーム"`},
+		{name: "名前なしの作成", method: http.MethodPost, path: "/api/v1/workspaces", body: `{"name":""}`, handle: h.CreateWorkspace, expectedStatus: http.StatusBadRequest},
+		{name: "一覧", method: http.MethodGet, path: "/api/v1/workspaces", handle: h.ListWorkspaces, expectedStatus: http.StatusOK, expectedBody: `"workspaces":[{"id":1`},
+		{name: "招待で参加", method: http.MethodPost, path: "/api/v1/workspaces/join", body: `{"token":"invitation-token"}`, handle: h.AcceptInvitation, expectedStatus: http.StatusOK, expectedBody: `"id":1`},
+		{name: "不正な招待", method: http.MethodPost, path: "/api/v1/workspaces/join", body: `{"token":"unknown"}`, handle: h.AcceptInvitation, expectedStatus: http.StatusNotFound},
+		{name: "メンバーの一覧", method: http.MethodGet, path: "/api/v1/workspaces/1/members", handle: h.ListMembers, expectedStatus: http.StatusOK, expectedBody: `"role":"owner"`},
+		{name: "メンバーでないワークスペース", method: http.MethodGet, path: "/api/v1/workspaces/3/members", handle: h.ListMembers, expectedStatus: http.StatusNotFound},
+		{name: "不正なワークスペースID", method: http.MethodGet, path: "/api/v1/workspaces/abc/members", handle: h.ListMembers, expectedStatus: http.StatusBadRequest},
+		{name: "メンバーの削除", method: http.MethodDelete, path: "/api/v1/workspaces/1/members/bob", handle: h.RemoveMember, expectedStatus: http.StatusNoContent},
+		{name: "所有者以外による削除", method: http.MethodDelete, path: "/api/v1/workspaces/2/members/bob", handle: h.RemoveMember, expectedStatus: http.StatusForbidden},
+		{name: "招待の作成", method: http.MethodPost, path: "/api/v1/workspaces/1/invitations", handle: h.CreateInvitation, expectedStatus: http.StatusCreated, expectedBody: `"token":"invitation-token"`},
+		{name: "所有者以外による招待", method: http.MethodPost, path: "/api/v1/workspaces/2/invitations", handle: h.CreateInvitation, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			tt.handle(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedBody != "" && !strings.Contains(rec.Body.String(), tt.expectedBody) {
+				t.Errorf("レスポンス = %s, 期待値 = %s を含む", rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
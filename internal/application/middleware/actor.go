@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// ActorHeader は操作者（変更履歴の「誰が」）を伝えるリクエストヘッダーです
+// このAPI自体は認証を行わないため、前段の認証プロキシ等が設定することを想定しています
+const ActorHeader = "X-Actor"
+
+// maxActorLength は記録する操作者名の最大文字数です（todo_history.actor の列幅）
+const maxActorLength = 255
+
+// ActorMiddleware は X-Actor ヘッダーの値を操作者としてリクエストのコンテキストに設定するミドルウェアです
+// ヘッダーがない場合は何も設定せず、サービス層では匿名（anonymous）として扱われます
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := strings.TrimSpace(r.Header.Get(ActorHeader))
+		if len(actor) > maxActorLength {
+			actor = actor[:maxActorLength]
+		}
+		if actor != "" {
+			r = r.WithContext(service.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
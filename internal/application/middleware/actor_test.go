@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TestActorMiddleware は X-Actor ヘッダーの値がコンテキストに設定されることをテストします
+func TestActorMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "ヘッダーあり", header: "alice", expected: "alice"},
+		{name: "前後の空白は除去", header: "  bob ", expected: "bob"},
+		{name: "ヘッダーなし", header: "", expected: entity.AnonymousActor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = service.ActorFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+			if tt.header != "" {
+				req.Header.Set(ActorHeader, tt.header)
+			}
+			ActorMiddleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			if actor != tt.expected {
+				t.Errorf("操作者 = %q, 期待値 = %q", actor, tt.expected)
+			}
+		})
+	}
+}
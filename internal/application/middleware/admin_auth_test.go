@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestAdminTokenMiddleware は管理用トークンの検証をテストします
+func TestAdminTokenMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		token          string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "正しいトークン", token: "0123456789abcdef", authorization: "Bearer 0123456789abcdef", expectedStatus: http.StatusNoContent},
+		{name: "異なるトークン", token: "0123456789abcdef", authorization: "Bearer 0123456789abcdeX", expectedStatus: http.StatusUnauthorized},
+		{name: "ヘッダーなし", token: "0123456789abcdef", expectedStatus: http.StatusUnauthorized},
+		{name: "Bearer以外の方式", token: "0123456789abcdef", authorization: "Basic 0123456789abcdef", expectedStatus: http.StatusUnauthorized},
+		{name: "トークン未設定では常に拒否", token: "", authorization: "Bearer ", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/loglevel", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			AdminTokenMiddleware(tt.token)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 には WWW-Authenticate ヘッダーが必要です")
+			}
+		})
+	}
+}
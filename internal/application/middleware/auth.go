@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// AccessTokenVerifier はアクセストークンを検証するインターフェースです（service.AuthService が実装します）
+type AccessTokenVerifier interface {
+	VerifyAccessToken(token string) (service.Principal, error)
+}
+
+// SessionVerifier はCookieのセッションIDを検証するインターフェースです（service.SessionService が実装します）
+type SessionVerifier interface {
+	VerifySession(ctx context.Context, sessionID string) (service.Principal, error)
+}
+
+// SessionCookieName はログインのセッションIDを保存するCookieの名前です
+const SessionCookieName = "session_id"
+
+// AuthMiddleware は Authorization: Bearer <access_token> のアクセストークンを検証するミドルウェアです
+//
+// 検証に成功すると、認証されたユーザー（service.WithPrincipal）と操作者（ユーザー名）をコンテキストに設定します
+// 操作者は X-Actor ヘッダーより優先されるため、認証済みのリクエストでは他のユーザーを名乗れません
+//
+// トークンがない・不正・有効期限切れの場合は WWW-Authenticate ヘッダー付きの 401 を返します
+// （RFC 6750 3.1節。有効期限切れの場合、クライアントはリフレッシュトークンで再発行してから再送する）
+// OPTIONS は対応するメソッドを知らせるだけのため、トークンなしでも通します
+func AuthMiddleware(verifier AccessTokenVerifier) func(http.Handler) http.Handler {
+	return SessionAuthMiddleware(verifier, nil)
+}
+
+// SessionAuthMiddleware は AuthMiddleware に加えて、Cookie（SessionCookieName）のセッションでも認証するミドルウェアです
+// Authorization ヘッダーがある場合はアクセストークンだけを検証し、ない場合にセッションのCookieを検証します
+// sessions が nil の場合は AuthMiddleware と同じです
+func SessionAuthMiddleware(verifier AccessTokenVerifier, sessions SessionVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var principal service.Principal
+			authorization := r.Header.Get("Authorization")
+			cookie, cookieErr := r.Cookie(SessionCookieName)
+			switch {
+			case authorization == "" && sessions != nil && cookieErr == nil && cookie.Value != "":
+				p, err := sessions.VerifySession(r.Context(), cookie.Value)
+				if err != nil {
+					if !errors.Is(err, service.ErrInvalidSession) {
+						writeAuthErrorStatus(w, http.StatusInternalServerError, "Failed to verify session", err.Error())
+						return
+					}
+					w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+					writeAuthError(w, err.Error())
+					return
+				}
+				principal = p
+
+			default:
+				token, ok := strings.CutPrefix(authorization, "Bearer ")
+				if !ok || token == "" {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+					writeAuthError(w, "access token is required")
+					return
+				}
+				p, err := verifier.VerifyAccessToken(token)
+				if err != nil {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+					writeAuthError(w, err.Error())
+					return
+				}
+				principal = p
+			}
+
+			ctx := service.WithPrincipal(r.Context(), principal)
+			ctx = service.WithActor(ctx, principal.Username)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// writeAuthError は認証に失敗した場合の 401 レスポンスを書き込みます
+func writeAuthError(w http.ResponseWriter, details string) {
+	writeAuthErrorStatus(w, http.StatusUnauthorized, "Unauthorized", details)
+}
+
+// writeAuthErrorStatus は認証の処理のエラーレスポンスを書き込みます（セッションのストアの障害などは 500）
+func writeAuthErrorStatus(w http.ResponseWriter, status int, message, details string) {
+	writeErrorResponse(w, status, message, details)
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// stubVerifier は "valid" だけを alice のアクセストークンとして受け入れるテスト用の実装です
+type stubVerifier struct{}
+
+func (stubVerifier) VerifyAccessToken(token string) (service.Principal, error) {
+	if token != "valid" {
+		return service.Principal{}, service.ErrInvalidAccessToken
+	}
+	return service.Principal{UserID: 1, Username: "alice"}, nil
+}
+
+// TestAuthMiddleware はアクセストークンの検証とコンテキストへの設定をテストします
+func TestAuthMiddleware(t *testing.T) {
+	var gotPrincipal service.Principal
+	var gotActor string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPrincipal, _ = service.PrincipalFromContext(r.Context())
+		gotActor = service.ActorFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		authorization  string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "有効なトークン", method: http.MethodGet, authorization: "Bearer valid", expectedStatus: http.StatusNoContent},
+		{name: "ヘッダーなし", method: http.MethodGet, expectedStatus: http.StatusUnauthorized},
+		{name: "Bearer以外の方式", method: http.MethodGet, authorization: "Basic valid", expectedStatus: http.StatusUnauthorized},
+		{name: "不正なトークン", method: http.MethodGet, authorization: "Bearer expired", expectedStatus: http.StatusUnauthorized, expectedError: `error="invalid_token"`},
+		{name: "OPTIONS はトークンなしで通す", method: http.MethodOptions, expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPrincipal, gotActor = service.Principal{}, ""
+			req := httptest.NewRequest(tt.method, "/api/v1/todos", nil)
+			req.Header.Set(ActorHeader, "mallory")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			ActorMiddleware(AuthMiddleware(stubVerifier{})(next)).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code == http.StatusUnauthorized {
+				challenge := rec.Header().Get("WWW-Authenticate")
+				if !strings.HasPrefix(challenge, "Bearer") || !strings.Contains(challenge, tt.expectedError) {
+					t.Errorf("WWW-Authenticate = %q", challenge)
+				}
+				if contentType := rec.Header().Get("Content-Type"); contentType != "application/json" {
+					t.Errorf("Content-Type = %q, 期待値 = application/json", contentType)
+				}
+			}
+			if tt.authorization == "Bearer valid" {
+				// 認証済みのユーザー名が X-Actor より優先される
+				if gotPrincipal.UserID != 1 || gotActor != "alice" {
+					t.Errorf("コンテキストのユーザー = %+v, 操作者 = %q", gotPrincipal, gotActor)
+				}
+			}
+		})
+	}
+}
+
+// stubSessionVerifier は "alive" を bob のセッション、"broken" をストアの障害として扱うテスト用の実装です
+type stubSessionVerifier struct{}
+
+func (stubSessionVerifier) VerifySession(ctx context.Context, sessionID string) (service.Principal, error) {
+	switch sessionID {
+	case "alive":
+		return service.Principal{UserID: 2, Username: "bob"}, nil
+	case "broken":
+		return service.Principal{}, errors.New("session store unavailable")
+	}
+	return service.Principal{}, service.ErrInvalidSession
+}
+
+// TestSessionAuthMiddleware はCookieのセッションによる認証と、Authorization ヘッダーの優先をテストします
+func TestSessionAuthMiddleware(t *testing.T) {
+	var gotPrincipal service.Principal
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPrincipal, _ = service.PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		authorization  string
+		cookie         string
+		expectedStatus int
+		expectedUser   string
+	}{
+		{name: "有効なセッション", cookie: "alive", expectedStatus: http.StatusNoContent, expectedUser: "bob"},
+		{name: "無効なセッション", cookie: "expired", expectedStatus: http.StatusUnauthorized},
+		{name: "ストアの障害", cookie: "broken", expectedStatus: http.StatusInternalServerError},
+		{name: "アクセストークンが優先", authorization: "Bearer valid", cookie: "alive", expectedStatus: http.StatusNoContent, expectedUser: "alice"},
+		{name: "不正なアクセストークンではセッションを使わない", authorization: "Bearer expired", cookie: "alive", expectedStatus: http.StatusUnauthorized},
+		{name: "どちらもない", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPrincipal = service.Principal{}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			SessionAuthMiddleware(stubVerifier{}, stubSessionVerifier{})(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if gotPrincipal.Username != tt.expectedUser {
+				t.Errorf("コンテキストのユーザー = %q, 期待値 = %q", gotPrincipal.Username, tt.expectedUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// basePathKey はリクエストのコンテキストにベースパスを格納するためのキーです
+// 他のパッケージのキーと衝突しないよう、非公開の型を使用します
+type basePathKey struct{}
+
+// BasePath はリクエストのコンテキストからベースパス（例: /todoapp）を取得します
+// BasePathMiddleware を通っていない場合や、ベースパスが未設定の場合は空文字を返します
+// ハンドラーはレスポンスに含めるリンクの先頭にこの値を付与します
+func BasePath(ctx context.Context) string {
+	basePath, _ := ctx.Value(basePathKey{}).(string)
+	return basePath
+}
+
+// BasePathMiddleware はアプリケーションをURLのプレフィックス（ベースパス）配下で公開するためのミドルウェアです
+// パスでルーティングするリバースプロキシの配下（例: https://example.com/todoapp/api/v1/todos）で使用します
+//
+// 処理内容：
+// 1. ベースパス外へのリクエストは notFound で 404 を返し、ベースパスちょうどへのリクエストは末尾に / を付けてリダイレクト
+// 2. パスからベースパスを取り除いて次のハンドラーへ渡す（ルーターやハンドラーはベースパスを意識しない）
+// 3. ベースパスをコンテキストに格納する（レスポンス内のリンク生成に使用）
+// 4. リダイレクト（3xx）の Location ヘッダーがアプリ内の絶対パスの場合、ベースパスを付与する
+//
+// notFound にはルーターの未定義のパスと同じハンドラーを渡し、ベースパス外へのリクエストにも同じ形式のエラーを返します
+// （nil の場合は http.NotFound）。basePath が空文字の場合は何もしません
+func BasePathMiddleware(basePath string, notFound http.Handler) func(http.Handler) http.Handler {
+	if notFound == nil {
+		notFound = http.NotFoundHandler()
+	}
+	return func(next http.Handler) http.Handler {
+		if basePath == "" {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == basePath {
+				target := basePath + "/"
+				if r.URL.RawQuery != "" {
+					target += "?" + r.URL.RawQuery
+				}
+				http.Redirect(w, r, target, http.StatusMovedPermanently)
+				return
+			}
+
+			rest, ok := strings.CutPrefix(r.URL.Path, basePath)
+			if !ok || !strings.HasPrefix(rest, "/") {
+				notFound.ServeHTTP(w, r)
+				return
+			}
+
+			// 元のリクエストは変更せず、パスを書き換えたコピーを渡す（http.StripPrefix と同じ方法）
+			r2 := r.WithContext(context.WithValue(r.Context(), basePathKey{}, basePath))
+			r2.URL = new(url.URL)
+			*r2.URL = *r.URL
+			r2.URL.Path = rest
+			r2.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, basePath)
+
+			next.ServeHTTP(&basePathResponseWriter{ResponseWriter: w, basePath: basePath}, r2)
+		})
+	}
+}
+
+// basePathResponseWriter はリダイレクト先にベースパスを付与する ResponseWriter です
+// ServeMux の末尾スラッシュのリダイレクトなど、ルーター内部で生成されるリダイレクトを補正します
+type basePathResponseWriter struct {
+	http.ResponseWriter
+	basePath    string
+	wroteHeader bool
+}
+
+// WriteHeader はステータスコードを書き込む前に Location ヘッダーを補正します
+func (w *basePathResponseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if statusCode >= 300 && statusCode < 400 {
+			w.rewriteLocation()
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write はヘッダー未送信の場合に 200 として送信します（http.ResponseWriter の規約どおり）
+func (w *basePathResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap は元の ResponseWriter を返します
+// http.ResponseController が Flush などをラップ元に委譲できるようにします
+func (w *basePathResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// rewriteLocation はアプリ内の絶対パス（/ で始まり // で始まらない）にベースパスを付与します
+// 完全なURLや相対パスは変更しません
+func (w *basePathResponseWriter) rewriteLocation() {
+	location := w.Header().Get("Location")
+	if strings.HasPrefix(location, "/") && !strings.HasPrefix(location, "//") {
+		w.Header().Set("Location", w.basePath+location)
+	}
+}
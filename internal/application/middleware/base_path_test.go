@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBasePathMiddleware はベースパスの除去・コンテキストへの格納・リダイレクトの補正をテストします
+func TestBasePathMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.Path)
+		w.Header().Set("X-Base-Path", BasePath(r.Context()))
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/api/v1/todos", http.StatusFound)
+	})
+	mux.HandleFunc("/external", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://example.com/login", http.StatusFound)
+	})
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Not-Found", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	handler := BasePathMiddleware("/todoapp", notFound)(mux)
+
+	tests := []struct {
+		name             string
+		path             string
+		expectedStatus   int
+		expectedPath     string
+		expectedLocation string
+	}{
+		{name: "ベースパス配下", path: "/todoapp/api/v1/todos", expectedStatus: http.StatusOK, expectedPath: "/api/v1/todos"},
+		{name: "ベースパス外", path: "/api/v1/todos", expectedStatus: http.StatusNotFound},
+		{name: "前方一致だが別のパス", path: "/todoappx/api/v1/todos", expectedStatus: http.StatusNotFound},
+		{name: "ベースパスちょうど", path: "/todoapp?x=1", expectedStatus: http.StatusMovedPermanently, expectedLocation: "/todoapp/?x=1"},
+		{name: "ServeMuxの末尾スラッシュのリダイレクト", path: "/todoapp/api/v1", expectedStatus: http.StatusTemporaryRedirect, expectedLocation: "/todoapp/api/v1/"},
+		{name: "ハンドラーのリダイレクト", path: "/todoapp/moved", expectedStatus: http.StatusFound, expectedLocation: "/todoapp/api/v1/todos"},
+		{name: "外部へのリダイレクトは変更しない", path: "/todoapp/external", expectedStatus: http.StatusFound, expectedLocation: "https://example.com/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code == http.StatusNotFound && rec.Header().Get("X-Not-Found") == "" {
+				t.Errorf("ベースパス外のリクエストが notFound で処理されていません")
+			}
+			if tt.expectedPath != "" {
+				if got := rec.Header().Get("X-Path"); got != tt.expectedPath {
+					t.Errorf("ハンドラーが受け取ったパス = %q, 期待値 = %q", got, tt.expectedPath)
+				}
+				if got := rec.Header().Get("X-Base-Path"); got != "/todoapp" {
+					t.Errorf("コンテキストのベースパス = %q, 期待値 = %q", got, "/todoapp")
+				}
+			}
+			if got := rec.Header().Get("Location"); got != tt.expectedLocation {
+				t.Errorf("Location = %q, 期待値 = %q", got, tt.expectedLocation)
+			}
+		})
+	}
+}
+
+// TestBasePathMiddleware_Empty はベースパスが空の場合に何もしないことをテストします
+func TestBasePathMiddleware_Empty(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if BasePath(r.Context()) != "" {
+			t.Errorf("ベースパスが設定されています: %q", BasePath(r.Context()))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	BasePathMiddleware("", nil)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+)
+
+// BasicAuthMiddleware は HTTP Basic 認証の資格情報が username / password と一致するリクエストだけを通すミドルウェアです
+// /metrics や /debug/pprof/ などの運用向けエンドポイントを、ユーザー管理の仕組みを使わずに保護するために使用します
+//
+// ユーザー名とパスワードは両方とも subtle.ConstantTimeCompare で比較し、
+// どちらが誤っていたかが応答時間から推測されないよう、片方の不一致で比較を打ち切りません
+func BasicAuthMiddleware(username, password, realm string) func(http.Handler) http.Handler {
+	challenge := fmt.Sprintf(`Basic realm=%q, charset="UTF-8"`, realm)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			userMatch := subtle.ConstantTimeCompare([]byte(user), []byte(username))
+			passMatch := subtle.ConstantTimeCompare([]byte(pass), []byte(password))
+			if !ok || username == "" || password == "" || userMatch&passMatch != 1 {
+				w.Header().Set("WWW-Authenticate", challenge)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBasicAuthMiddleware は Basic 認証の資格情報の検証をテストします
+func TestBasicAuthMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name           string
+		username       string
+		password       string
+		setAuth        bool
+		givenUser      string
+		givenPass      string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "正しい資格情報", username: "ops", password: "s3cret-passw0rd", setAuth: true, givenUser: "ops", givenPass: "s3cret-passw0rd", expectedStatus: http.StatusNoContent},
+		{name: "パスワードが異なる", username: "ops", password: "s3cret-passw0rd", setAuth: true, givenUser: "ops", givenPass: "wrong", expectedStatus: http.StatusUnauthorized},
+		{name: "ユーザー名が異なる", username: "ops", password: "s3cret-passw0rd", setAuth: true, givenUser: "admin", givenPass: "s3cret-passw0rd", expectedStatus: http.StatusUnauthorized},
+		{name: "ヘッダーなし", username: "ops", password: "s3cret-passw0rd", expectedStatus: http.StatusUnauthorized},
+		{name: "Basic以外の方式", username: "ops", password: "s3cret-passw0rd", authorization: "Bearer s3cret-passw0rd", expectedStatus: http.StatusUnauthorized},
+		{name: "資格情報未設定では常に拒否", username: "", password: "", setAuth: true, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.setAuth {
+				req.SetBasicAuth(tt.givenUser, tt.givenPass)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			BasicAuthMiddleware(tt.username, tt.password, "ops")(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Code == http.StatusUnauthorized {
+				if got, want := rec.Header().Get("WWW-Authenticate"), `Basic realm="ops", charset="UTF-8"`; got != want {
+					t.Errorf("WWW-Authenticate = %q, 期待値 = %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// BodyCodec はJSON以外のボディの形式（MessagePack 等）と、JSONを相互に変換します
+// pkg/msgpack の msgpack.Codec が実装しています
+type BodyCodec interface {
+	// MediaType は Content-Type / Accept で指定するメディアタイプです（例: application/msgpack）
+	MediaType() string
+
+	// FromJSON はJSONのレスポンスボディをこの形式に変換します
+	FromJSON(data []byte) ([]byte, error)
+
+	// ToJSON はこの形式のリクエストボディをJSONに変換します
+	ToJSON(data []byte) ([]byte, error)
+}
+
+// CodecMiddleware はリクエスト・レスポンスのボディを、JSONと登録した形式の間で変換するミドルウェアです
+//
+// 学習ポイント：
+//  1. ハンドラーはJSONだけを扱い、形式の違いはAPIの境界（このミドルウェア）で吸収する
+//     DTOのJSONタグ・日時の形式・IDの変換がそのまま反映されるため、形式ごとに内容がずれることはない
+//  2. リクエストの形式は Content-Type、レスポンスの形式は Accept で選ぶ（両者は独立して選べる）
+//  3. 変換するのはJSONのレスポンスのみ（HTMLフラグメントや iCalendar 等はそのまま返す）
+//
+// Accept で登録した形式の品質値が application/json より高い場合のみ、レスポンスを変換します
+func CodecMiddleware(codecs ...BodyCodec) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(codecs) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			response := responseCodec(r, codecs)
+			if response != nil {
+				w.Header().Add("Vary", "Accept")
+				recorder := &codecResponseWriter{ResponseWriter: w, codec: response, statusCode: http.StatusOK}
+				defer recorder.flush()
+				w = recorder
+			}
+
+			if request := requestCodec(r, codecs); request != nil {
+				if err := decodeRequestBody(r, request); err != nil {
+					writeCodecError(w, err)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestCodec は Content-Type に対応する形式を返します（JSON等、登録していない形式の場合は nil）
+func requestCodec(r *http.Request, codecs []BodyCodec) BodyCodec {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil
+	}
+	for _, codec := range codecs {
+		if codec.MediaType() == mediaType {
+			return codec
+		}
+	}
+	return nil
+}
+
+// responseCodec は Accept でJSONより優先された形式を返します（該当しない場合は nil）
+func responseCodec(r *http.Request, codecs []BodyCodec) BodyCodec {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return nil
+	}
+
+	var best BodyCodec
+	bestQuality := AcceptQuality(accept, "application/json")
+	for _, codec := range codecs {
+		if q := AcceptQuality(accept, codec.MediaType()); q > bestQuality {
+			best, bestQuality = codec, q
+		}
+	}
+	return best
+}
+
+// decodeRequestBody はリクエストボディをJSONに変換し、Content-Type を application/json に置き換えます
+// 上限・期限による受信の打ち切り（RequestBodyMiddleware）は、ハンドラーが 413 / 408 を返せるよう
+// 読み取りのエラーとしてそのまま引き渡します
+func decodeRequestBody(r *http.Request, codec BodyCodec) error {
+	data, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), &errorReader{err: err}))
+		r.Header.Set("Content-Type", "application/json")
+		return nil
+	}
+
+	converted, err := codec.ToJSON(data)
+	if err != nil {
+		return fmt.Errorf("invalid %s body: %w", codec.MediaType(), err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(converted))
+	r.ContentLength = int64(len(converted))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Del("Content-Length")
+	return nil
+}
+
+// errorReader は常に同じエラーを返す io.Reader です
+type errorReader struct {
+	err error
+}
+
+func (e *errorReader) Read([]byte) (int, error) {
+	return 0, e.err
+}
+
+// writeCodecError はリクエストボディを変換できなかった場合の 400 レスポンスを書き込みます
+func writeCodecError(w http.ResponseWriter, err error) {
+	writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+}
+
+// codecResponseWriter はJSONのレスポンスをバッファリングし、最後に登録した形式へ変換して書き込みます
+type codecResponseWriter struct {
+	http.ResponseWriter
+	codec       BodyCodec
+	statusCode  int
+	wroteHeader bool
+	passthrough bool // JSON以外のレスポンスの場合は変換せずにそのまま書き込む
+	body        bytes.Buffer
+}
+
+// WriteHeader はステータスコードを記録します（JSON以外のレスポンスはそのまま書き込みます）
+func (c *codecResponseWriter) WriteHeader(statusCode int) {
+	if c.wroteHeader {
+		return
+	}
+	c.wroteHeader = true
+	c.statusCode = statusCode
+
+	mediaType, _, _ := mime.ParseMediaType(c.Header().Get("Content-Type"))
+	if mediaType != "application/json" {
+		c.passthrough = true
+		c.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+// Write はJSONのレスポンスをバッファに書き込みます
+func (c *codecResponseWriter) Write(data []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+	if c.passthrough {
+		return c.ResponseWriter.Write(data)
+	}
+	return c.body.Write(data)
+}
+
+// Unwrap は元の ResponseWriter を返します
+func (c *codecResponseWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// flush はバッファリングしたJSONを変換して書き込みます
+// 変換に失敗した場合（JSONとして不正なボディ等）は、JSONのまま返します
+func (c *codecResponseWriter) flush() {
+	if c.passthrough || !c.wroteHeader {
+		return
+	}
+
+	header := c.ResponseWriter.Header()
+	header.Del("Content-Length")
+	if c.body.Len() == 0 {
+		c.ResponseWriter.WriteHeader(c.statusCode)
+		return
+	}
+
+	converted, err := c.codec.FromJSON(c.body.Bytes())
+	if err != nil {
+		c.ResponseWriter.WriteHeader(c.statusCode)
+		c.ResponseWriter.Write(c.body.Bytes())
+		return
+	}
+	header.Set("Content-Type", c.codec.MediaType())
+	c.ResponseWriter.WriteHeader(c.statusCode)
+	c.ResponseWriter.Write(converted)
+}
+
+// AcceptQuality は Accept ヘッダーにおける指定メディアタイプの品質値（0〜1）を返します
+// 完全一致、type/*、*/* の順に具体的な指定を優先します
+func AcceptQuality(accept, mediaType string) float64 {
+	mainType := strings.SplitN(mediaType, "/", 2)[0]
+
+	best, bestSpecificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		rangeType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		specificity := -1
+		switch rangeType {
+		case mediaType:
+			specificity = 2
+		case mainType + "/*":
+			specificity = 1
+		case "*/*":
+			specificity = 0
+		}
+		if specificity < bestSpecificity || specificity < 0 {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		best, bestSpecificity = q, specificity
+	}
+	return best
+}
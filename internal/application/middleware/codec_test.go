@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// upperCodec はテスト用の形式です（JSONを大文字にした "application/x-upper"）
+type upperCodec struct{}
+
+func (upperCodec) MediaType() string { return "application/x-upper" }
+
+func (upperCodec) FromJSON(data []byte) ([]byte, error) {
+	return []byte(strings.ToUpper(string(data))), nil
+}
+
+func (upperCodec) ToJSON(data []byte) ([]byte, error) {
+	if !strings.HasPrefix(string(data), "{") {
+		return nil, errors.New("not an object")
+	}
+	return []byte(strings.ToLower(string(data))), nil
+}
+
+// TestCodecMiddleware は Content-Type / Accept による変換をテストします
+func TestCodecMiddleware(t *testing.T) {
+	tests := []struct {
+		name                string
+		contentType         string
+		accept              string
+		body                string
+		responseContentType string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name: "JSONのまま", contentType: "application/json", accept: "application/json", body: `{"a":1}`,
+			responseContentType: "application/json", expectedStatus: http.StatusOK,
+			expectedContentType: "application/json", expectedBody: `{"a":1}`,
+		},
+		{
+			name: "リクエストとレスポンスを変換", contentType: "application/x-upper", accept: "application/x-upper", body: `{"A":1}`,
+			responseContentType: "application/json", expectedStatus: http.StatusOK,
+			expectedContentType: "application/x-upper", expectedBody: `{"A":1}`,
+		},
+		{
+			name: "JSONの品質値が高い場合は変換しない", contentType: "application/x-upper", accept: "application/x-upper;q=0.5, application/json", body: `{"A":1}`,
+			responseContentType: "application/json", expectedStatus: http.StatusOK,
+			expectedContentType: "application/json", expectedBody: `{"a":1}`,
+		},
+		{
+			name: "JSON以外のレスポンスは変換しない", accept: "application/x-upper",
+			responseContentType: "text/html", expectedStatus: http.StatusOK,
+			expectedContentType: "text/html", expectedBody: "",
+		},
+		{
+			name: "変換できないリクエストは400", contentType: "application/x-upper", body: `[1]`,
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json", expectedBody: `{"error":"Invalid request body","details":"invalid application/x-upper body: not an object"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CodecMiddleware(upperCodec{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Content-Type") != "" && r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("ハンドラーに届いた Content-Type = %q, 期待値 = application/json", r.Header.Get("Content-Type"))
+				}
+				body, _ := io.ReadAll(r.Body)
+				w.Header().Set("Content-Type", tt.responseContentType)
+				w.WriteHeader(http.StatusOK)
+				w.Write(body)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.expectedContentType {
+				t.Errorf("Content-Type = %q, 期待値 = %q", got, tt.expectedContentType)
+			}
+			if got := rec.Body.String(); got != tt.expectedBody {
+				t.Errorf("ボディ = %q, 期待値 = %q", got, tt.expectedBody)
+			}
+		})
+	}
+}
+
+// TestCodecMiddleware_BodyLimit はボディの上限による打ち切りが、変換のエラーではなく読み取りのエラーとしてハンドラーに届くことをテストします
+func TestCodecMiddleware_BodyLimit(t *testing.T) {
+	var readErr error
+	handler := RequestBodyMiddleware(RequestBodyLimits{MaxBytes: 4})(CodecMiddleware(upperCodec{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", strings.NewReader(`{"title":"too long"}`))
+	req.Header.Set("Content-Type", "application/x-upper")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var tooLarge *http.MaxBytesError
+	if !errors.As(readErr, &tooLarge) {
+		t.Errorf("読み取りのエラー = %v, 期待値 = *http.MaxBytesError", readErr)
+	}
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+// TestAcceptQuality は Accept ヘッダーの品質値の判定をテストします
+func TestAcceptQuality(t *testing.T) {
+	tests := []struct {
+		accept    string
+		mediaType string
+		expected  float64
+	}{
+		{accept: "application/msgpack", mediaType: "application/msgpack", expected: 1},
+		{accept: "application/*;q=0.5, application/msgpack;q=0.8", mediaType: "application/msgpack", expected: 0.8},
+		{accept: "application/*;q=0.5", mediaType: "application/msgpack", expected: 0.5},
+		{accept: "*/*;q=0.1", mediaType: "application/msgpack", expected: 0.1},
+		{accept: "text/html", mediaType: "application/msgpack", expected: 0},
+	}
+	for _, tt := range tests {
+		if got := AcceptQuality(tt.accept, tt.mediaType); got != tt.expected {
+			t.Errorf("AcceptQuality(%q, %q) = %v, 期待値 = %v", tt.accept, tt.mediaType, got, tt.expected)
+		}
+	}
+}
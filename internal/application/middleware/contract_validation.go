@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ResponseValidator はレスポンスが契約（OpenAPI仕様書）どおりかを検証します
+// contract.Validator が実装します
+type ResponseValidator interface {
+	ValidateResponse(method, path string, status int, header http.Header, body []byte) error
+}
+
+// ContractValidationMiddleware は /api/ 配下のレスポンスを仕様書と照合し、違反を report に渡すミドルウェアです
+//
+// 学習ポイント：
+//  1. レスポンスはクライアントにそのまま返し、検証は書き込み後に行う（動作を変えずに観測だけする）
+//  2. テストでは report で t.Errorf を呼び、実装と仕様書のずれをテストの失敗として検出する
+//  3. 開発環境では report でログに出力し、手動での動作確認中にも違反に気付けるようにする
+//
+// ボディが MaxRecordedBodyBytes を超えたレスポンスは検証しません
+// 全てのレスポンスを検証するため処理が重く、本番環境では使用しないでください
+func ContractValidationMiddleware(validator ResponseValidator, report func(r *http.Request, err error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &trafficResponseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			if recorder.truncated {
+				return
+			}
+
+			if err := validator.ValidateResponse(r.Method, r.URL.Path, recorder.status, w.Header(), recorder.body.Bytes()); err != nil {
+				report(r, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubResponseValidator は受け取った内容を記録し、設定したエラーを返す ResponseValidator です
+type stubResponseValidator struct {
+	err    error
+	calls  int
+	status int
+	body   string
+}
+
+func (v *stubResponseValidator) ValidateResponse(method, path string, status int, header http.Header, body []byte) error {
+	v.calls++
+	v.status = status
+	v.body = string(body)
+	return v.err
+}
+
+// TestContractValidationMiddleware はレスポンスの検証と違反の報告をテストします
+func TestContractValidationMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		validatorErr    error
+		expectedCalls   int
+		expectedReports int
+	}{
+		{name: "仕様どおり", path: "/api/v1/todos", expectedCalls: 1},
+		{name: "仕様違反を報告", path: "/api/v1/todos", validatorErr: errors.New("undocumented property"), expectedCalls: 1, expectedReports: 1},
+		{name: "API以外は検証しない", path: "/health", validatorErr: errors.New("x")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &stubResponseValidator{err: tt.validatorErr}
+			reports := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id":1}`))
+			})
+			handler := ContractValidationMiddleware(validator, func(r *http.Request, err error) { reports++ })(next)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			// 検証結果に関わらずレスポンスはそのまま返す
+			if rec.Code != http.StatusCreated || rec.Body.String() != `{"id":1}` {
+				t.Errorf("レスポンスが変わっています: %d %s", rec.Code, rec.Body.String())
+			}
+			if validator.calls != tt.expectedCalls {
+				t.Errorf("検証回数 = %d, 期待値 = %d", validator.calls, tt.expectedCalls)
+			}
+			if tt.expectedCalls > 0 && (validator.status != http.StatusCreated || validator.body != `{"id":1}`) {
+				t.Errorf("検証に渡された内容 = %d %s", validator.status, validator.body)
+			}
+			if reports != tt.expectedReports {
+				t.Errorf("報告回数 = %d, 期待値 = %d", reports, tt.expectedReports)
+			}
+		})
+	}
+}
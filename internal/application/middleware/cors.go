@@ -27,6 +27,10 @@ type CORSConfig struct {
 	// AllowedHeaders は許可するリクエストヘッダーのリスト
 	AllowedHeaders []string
 
+	// ExposedHeaders はブラウザのスクリプトから読み取れるようにするレスポンスヘッダーのリスト
+	// （Content-Type などの基本的なヘッダー以外は、ここに含めないとクロスオリジンで読み取れない）
+	ExposedHeaders []string
+
 	// AllowCredentials は認証情報を含むリクエストを許可するか
 	AllowCredentials bool
 
@@ -56,7 +60,20 @@ func DefaultCORSConfig() CORSConfig {
 			"Accept",
 			"Cache-Control",
 			"X-Requested-With",
+			// HTMX が付与するリクエストヘッダー
+			"HX-Request",
+			"HX-Target",
+			"HX-Trigger",
+			"HX-Current-URL",
+			// クライアントが処理の期限を伝えるヘッダー
+			DeadlineHeader,
+			TimeoutHeader,
+			// 更新・削除の前提条件（取得時の ETag と一致する場合のみ実行する）
+			"If-Match",
+			// 再送の重複排除に使うキー
+			IdempotencyKeyHeader,
 		},
+		ExposedHeaders:   []string{"ETag", IdempotentReplayedHeader},
 		AllowCredentials: false,
 		MaxAge:           86400, // 24時間
 	}
@@ -84,6 +101,9 @@ func CORSMiddleware(config CORSConfig) func(http.Handler) http.Handler {
 			// 2. 基本的なCORSヘッダーを設定
 			w.Header().Set("Access-Control-Allow-Methods", joinStrings(config.AllowedMethods, ", "))
 			w.Header().Set("Access-Control-Allow-Headers", joinStrings(config.AllowedHeaders, ", "))
+			if len(config.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", joinStrings(config.ExposedHeaders, ", "))
+			}
 
 			// 3. 認証情報の許可設定
 			if config.AllowCredentials {
@@ -99,7 +119,8 @@ func CORSMiddleware(config CORSConfig) func(http.Handler) http.Handler {
 
 			// 5. プリフライトリクエスト（OPTIONS）の処理
 			// ブラウザが実際のリクエスト前に送信する事前チェックリクエスト
-			if r.Method == http.MethodOptions {
+			// （それ以外の OPTIONS はルーターが Allow ヘッダーで対応するメソッドを返す）
+			if isPreflight(r) {
 				// プリフライトリクエストには200 OKで即座に応答
 				// 実際のハンドラー処理は実行しない
 				w.WriteHeader(http.StatusOK)
@@ -119,11 +140,16 @@ func SimpleCORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// 開発環境用の緩い設定
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
+		// HX-* はHTMXが付与するヘッダー（HTMLフラグメントのネゴシエーションに使用）
+		// X-Request-Deadline / X-Request-Timeout はクライアントが処理の期限を伝えるヘッダー
+		// If-Match は更新・削除の前提条件で、取得時の ETag を読み取れるよう公開する
+		// Idempotency-Key は再送の重複排除に使うキーで、保存したレスポンスかどうかを Idempotent-Replayed で伝える
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, HX-Request, HX-Target, HX-Trigger, HX-Current-URL, "+DeadlineHeader+", "+TimeoutHeader+", If-Match, "+IdempotencyKeyHeader+", "+WorkspaceHeader)
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, "+IdempotentReplayedHeader)
 
 		// プリフライトリクエストの処理
-		if r.Method == http.MethodOptions {
+		if isPreflight(r) {
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -135,6 +161,11 @@ func SimpleCORSMiddleware(next http.Handler) http.Handler {
 
 // --- ヘルパー関数 ---
 
+// isPreflight はCORSのプリフライトリクエスト（Access-Control-Request-Method 付きの OPTIONS）かを判定します
+func isPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+}
+
 // isOriginAllowed は指定されたオリジンが許可リストに含まれているかチェックします
 func isOriginAllowed(origin string, allowedOrigins []string) bool {
 	if len(allowedOrigins) == 0 {
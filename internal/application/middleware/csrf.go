@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+)
+
+// CSRFCookieName は CSRF トークンを保存するCookieの名前です
+// JavaScript から読み取って CSRFHeaderName のヘッダーに付けるため、HttpOnly にはしません
+const CSRFCookieName = "csrf_token"
+
+// CSRFHeaderName は状態を変更するリクエストで CSRF トークンを送るヘッダーの名前です
+const CSRFHeaderName = "X-CSRF-Token"
+
+// CSRFMiddleware はCookieのセッションで認証するクライアントを CSRF（クロスサイトリクエストフォージェリ）から守るミドルウェアです
+//
+// Double Submit Cookie 方式の学習ポイント：
+// 1. サーバーはランダムなトークンをCookie（CSRFCookieName）で渡す
+// 2. クライアントは状態を変更するリクエスト（POST・PUT・PATCH・DELETE）で、Cookieの値を X-CSRF-Token ヘッダーにも付ける
+// 3. 他のサイトのページはCookieを送らせることはできても、Cookieの値を読んでヘッダーに付けることはできない
+//
+// 検証するのはセッションのCookie（SessionCookieName）が付いているリクエストだけです
+// Authorization ヘッダーで認証するクライアントはブラウザが自動で認証情報を付けることがないため、検証もトークンの発行も行いません
+// トークンのCookieがないリクエストには、レスポンスで新しいトークンを発行します（ログインのレスポンスでも受け取れる）
+//
+// SameSite=Lax のセッションのCookieと組み合わせ、Cookieの属性とトークンの二重で防ぎます
+func CSRFMiddleware(cookiePath string, secure bool) func(http.Handler) http.Handler {
+	if cookiePath == "" {
+		cookiePath = "/"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var token string
+			if cookie, err := r.Cookie(CSRFCookieName); err == nil {
+				token = cookie.Value
+			}
+
+			if !isSafeMethod(r.Method) && hasSessionCookie(r) {
+				given := r.Header.Get(CSRFHeaderName)
+				if token == "" || given == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+					writeAuthErrorStatus(w, http.StatusForbidden, "Forbidden", "CSRF token is missing or does not match (send the "+CSRFCookieName+" cookie value in the "+CSRFHeaderName+" header)")
+					return
+				}
+			}
+
+			if token == "" {
+				issued, err := newCSRFToken()
+				if err != nil {
+					writeAuthErrorStatus(w, http.StatusInternalServerError, "Failed to issue CSRF token", err.Error())
+					return
+				}
+				http.SetCookie(w, &http.Cookie{
+					Name:     CSRFCookieName,
+					Value:    issued,
+					Path:     cookiePath,
+					Secure:   secure,
+					SameSite: http.SameSiteLaxMode,
+				})
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isSafeMethod はサーバーの状態を変更しないメソッド（RFC 9110 9.2.1節）かを判定します
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// hasSessionCookie はリクエストにセッションのCookieが付いているかを判定します
+func hasSessionCookie(r *http.Request) bool {
+	cookie, err := r.Cookie(SessionCookieName)
+	return err == nil && cookie.Value != ""
+}
+
+// newCSRFToken は推測できないランダムな CSRF トークンを生成します
+func newCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
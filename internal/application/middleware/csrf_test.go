@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestCSRFMiddleware はセッションのCookieで認証するリクエストの CSRF トークンの検証をテストします
+func TestCSRFMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	const token = "csrf-token-value"
+
+	tests := []struct {
+		name           string
+		method         string
+		session        bool
+		cookie         string
+		header         string
+		authorization  string
+		expectedStatus int
+		expectIssued   bool
+	}{
+		{name: "セッションのPOST（トークン一致）", method: http.MethodPost, session: true, cookie: token, header: token, expectedStatus: http.StatusNoContent},
+		{name: "セッションのDELETE（ヘッダーなし）", method: http.MethodDelete, session: true, cookie: token, expectedStatus: http.StatusForbidden},
+		{name: "セッションのPUT（トークン不一致）", method: http.MethodPut, session: true, cookie: token, header: "other", expectedStatus: http.StatusForbidden},
+		{name: "セッションのPATCH（Cookieなし）", method: http.MethodPatch, session: true, header: token, expectedStatus: http.StatusForbidden},
+		{name: "セッションのGETは検証しない", method: http.MethodGet, session: true, expectedStatus: http.StatusNoContent, expectIssued: true},
+		{name: "セッションなしのPOST（ログイン）", method: http.MethodPost, expectedStatus: http.StatusNoContent, expectIssued: true},
+		{name: "アクセストークンのPOSTは検証も発行もしない", method: http.MethodPost, session: true, authorization: "Bearer token", expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/todos", nil)
+			if tt.session {
+				req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "session-id"})
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set(CSRFHeaderName, tt.header)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			CSRFMiddleware("/todoapp/", true)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+
+			cookies := rec.Result().Cookies()
+			if !tt.expectIssued {
+				if len(cookies) != 0 {
+					t.Errorf("Set-Cookie = %v, 期待値 = なし", cookies)
+				}
+				return
+			}
+			if len(cookies) != 1 {
+				t.Fatalf("Set-Cookie = %v, 期待値 = 1件", cookies)
+			}
+			c := cookies[0]
+			if c.Name != CSRFCookieName || c.Value == "" || c.Path != "/todoapp/" || c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteLaxMode {
+				t.Errorf("Cookie = %+v, 期待値 = JavaScript から読める Secure・SameSite=Lax のトークン", c)
+			}
+		})
+	}
+}
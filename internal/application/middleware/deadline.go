@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// クライアントが自身のタイムアウトをサーバーに伝えるためのリクエストヘッダーです
+const (
+	// DeadlineHeader は処理の期限を絶対時刻（RFC3339形式）で指定します
+	// 例: X-Request-Deadline: 2024-05-01T09:30:00.500Z
+	DeadlineHeader = "X-Request-Deadline"
+
+	// TimeoutHeader は処理の期限を相対時間（gRPC の grpc-timeout と同じ形式）で指定します
+	// 例: X-Request-Timeout: 500m（500ミリ秒）、X-Request-Timeout: 3S（3秒）
+	TimeoutHeader = "X-Request-Timeout"
+)
+
+// maxRequestTimeout は相対時間の上限です（これより長い指定は上限に丸めます）
+// 99999999H のような指定で time.Duration があふれないようにするためのものです
+const maxRequestTimeout = 24 * time.Hour
+
+// grpcTimeoutUnits は grpc-timeout 形式の単位です
+var grpcTimeoutUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+// DeadlineMiddleware はクライアントが指定した期限をリクエストのコンテキストに設定するミドルウェアです
+//
+// 学習ポイント：
+//  1. クライアントが諦めた後もサーバーが処理を続けるのは無駄なので、期限をコンテキストで伝播させる
+//  2. リポジトリは QueryContext 等にコンテキストを渡しているため、DBのクエリも期限で中断される
+//  3. context.WithDeadline は既存の期限より延ばすことはできない（短くする方向にのみ働く）
+//
+// ヘッダーが不正な場合は 400、受信時点ですでに期限を過ぎている場合は処理せずに 504 を返します
+// 両方のヘッダーがある場合は早い方の期限を使用します
+func DeadlineMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok, err := requestDeadline(r, time.Now())
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid request deadline", err.Error())
+			return
+		}
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !time.Now().Before(deadline) {
+			writeErrorResponse(w, http.StatusGatewayTimeout, "Gateway Timeout", "request deadline exceeded")
+			return
+		}
+
+		ctx, cancel := context.WithDeadline(r.Context(), deadline)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestDeadline はリクエストヘッダーから期限を取得します
+// ヘッダーがない場合は ok が false になります
+func requestDeadline(r *http.Request, now time.Time) (deadline time.Time, ok bool, err error) {
+	if value := r.Header.Get(DeadlineHeader); value != "" {
+		deadline, err = time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s header: must be an RFC3339 timestamp", DeadlineHeader)
+		}
+		ok = true
+	}
+
+	if value := r.Header.Get(TimeoutHeader); value != "" {
+		timeout, err := parseGRPCTimeout(value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s header: %w", TimeoutHeader, err)
+		}
+		if timeoutDeadline := now.Add(timeout); !ok || timeoutDeadline.Before(deadline) {
+			deadline = timeoutDeadline
+		}
+		ok = true
+	}
+
+	return deadline, ok, nil
+}
+
+// parseGRPCTimeout は grpc-timeout 形式（最大8桁の正の整数 + 単位1文字）の時間を解析します
+func parseGRPCTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, fmt.Errorf("must be up to 8 digits followed by a unit (H, M, S, m, u, n)")
+	}
+
+	unit, ok := grpcTimeoutUnits[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q (must be H, M, S, m, u, or n)", value[len(value)-1:])
+	}
+
+	n, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("must be a positive integer followed by a unit")
+	}
+
+	if n > int64(maxRequestTimeout/unit) {
+		return maxRequestTimeout, nil
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDeadlineMiddleware はヘッダーで指定した期限がコンテキストに設定されることをテストします
+func TestDeadlineMiddleware(t *testing.T) {
+	future := time.Now().Add(time.Minute).UTC().Format(time.RFC3339Nano)
+	past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339Nano)
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+		expectDeadline bool
+		maxRemaining   time.Duration
+	}{
+		{name: "ヘッダーなし", headers: nil, expectedStatus: http.StatusOK, expectDeadline: false},
+		{name: "絶対時刻", headers: map[string]string{DeadlineHeader: future}, expectedStatus: http.StatusOK, expectDeadline: true, maxRemaining: time.Minute},
+		{name: "相対時間（ミリ秒）", headers: map[string]string{TimeoutHeader: "500m"}, expectedStatus: http.StatusOK, expectDeadline: true, maxRemaining: 500 * time.Millisecond},
+		{name: "両方指定した場合は早い方", headers: map[string]string{DeadlineHeader: future, TimeoutHeader: "2S"}, expectedStatus: http.StatusOK, expectDeadline: true, maxRemaining: 2 * time.Second},
+		{name: "非常に長い相対時間は上限に丸める", headers: map[string]string{TimeoutHeader: "99999999H"}, expectedStatus: http.StatusOK, expectDeadline: true, maxRemaining: maxRequestTimeout},
+		{name: "期限切れ", headers: map[string]string{DeadlineHeader: past}, expectedStatus: http.StatusGatewayTimeout},
+		{name: "不正な絶対時刻", headers: map[string]string{DeadlineHeader: "tomorrow"}, expectedStatus: http.StatusBadRequest},
+		{name: "不明な単位", headers: map[string]string{TimeoutHeader: "5s"}, expectedStatus: http.StatusBadRequest},
+		{name: "9桁以上", headers: map[string]string{TimeoutHeader: "123456789S"}, expectedStatus: http.StatusBadRequest},
+		{name: "0", headers: map[string]string{TimeoutHeader: "0S"}, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				deadline, ok := r.Context().Deadline()
+				if ok != tt.expectDeadline {
+					t.Fatalf("期限の有無 = %v, 期待値 = %v", ok, tt.expectDeadline)
+				}
+				if ok && time.Until(deadline) > tt.maxRemaining {
+					t.Errorf("残り時間 = %v, 上限 = %v", time.Until(deadline), tt.maxRemaining)
+				}
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+			DeadlineMiddleware(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if called != (tt.expectedStatus == http.StatusOK) {
+				t.Errorf("次のハンドラーの呼び出し = %v", called)
+			}
+			// エラーはハンドラーと同じJSONの形式で返す
+			if tt.expectedStatus != http.StatusOK && (rec.Header().Get("Content-Type") != "application/json" || !strings.HasPrefix(rec.Body.String(), `{"error":`)) {
+				t.Errorf("エラーレスポンス = %q, %s, 期待値 = JSONの ErrorResponse", rec.Header().Get("Content-Type"), rec.Body.String())
+			}
+		})
+	}
+}
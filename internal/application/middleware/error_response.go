@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// writeErrorResponse はハンドラーと同じ形式（dto.ErrorResponse）のJSONのエラーレスポンスを書き込みます
+// ミドルウェアがハンドラーに渡さずに応答する場合も、クライアントが同じ形式でエラーを読めるようにします
+func writeErrorResponse(w http.ResponseWriter, status int, message, details string) {
+	body, _ := json.Marshal(dto.ErrorResponse{Error: message, Details: details})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
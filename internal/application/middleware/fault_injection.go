@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// FaultInjectionConfig はモックサーバーで再現する遅延とエラーの設定です
+type FaultInjectionConfig struct {
+	// Latency はすべてのAPIリクエストに加える遅延です
+	Latency time.Duration
+
+	// Jitter は遅延に加えるランダムな揺らぎの最大値です（0〜Jitter の範囲で加算）
+	Jitter time.Duration
+
+	// ErrorRate は 503 Service Unavailable を返すリクエストの割合です（0.0〜1.0）
+	ErrorRate float64
+
+	// Rand は [0.0, 1.0) の乱数を返す関数です（nil の場合は math/rand を使用、テストで固定するためのフィールド）
+	Rand func() float64
+}
+
+// FaultInjectionMiddleware は /api/ 配下のリクエストに遅延とエラーを注入するミドルウェアです
+//
+// 学習ポイント：
+//  1. フロントエンドのローディング表示や再試行処理は、遅くて不安定なAPIでないと確認しにくい
+//  2. 遅延はクライアントの切断（コンテキストのキャンセル）で打ち切り、無駄に待たない
+//  3. 注入したエラーであることが分かるよう、X-Fault-Injected ヘッダーを付けて返す
+//
+// 開発用のモックサーバー（-mock）でのみ使用してください
+func FaultInjectionMiddleware(cfg FaultInjectionConfig) func(http.Handler) http.Handler {
+	random := cfg.Rand
+	if random == nil {
+		random = rand.Float64
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			delay := cfg.Latency
+			if cfg.Jitter > 0 {
+				delay += time.Duration(random() * float64(cfg.Jitter))
+			}
+			if delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-r.Context().Done():
+					timer.Stop()
+					return
+				}
+			}
+
+			if cfg.ErrorRate > 0 && random() < cfg.ErrorRate {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Fault-Injected", "true")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"error":"Service unavailable","details":"injected by mock server"}`))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestFaultInjectionMiddleware は遅延とエラーの注入をテストします
+func TestFaultInjectionMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		cfg            FaultInjectionConfig
+		expectedStatus int
+		minDuration    time.Duration
+	}{
+		{name: "注入なし", path: "/api/v1/todos", cfg: FaultInjectionConfig{}, expectedStatus: http.StatusOK},
+		{name: "遅延", path: "/api/v1/todos", cfg: FaultInjectionConfig{Latency: 20 * time.Millisecond, Jitter: 10 * time.Millisecond, Rand: func() float64 { return 0.5 }}, expectedStatus: http.StatusOK, minDuration: 25 * time.Millisecond},
+		{name: "エラー率に該当", path: "/api/v1/todos", cfg: FaultInjectionConfig{ErrorRate: 0.3, Rand: func() float64 { return 0.2 }}, expectedStatus: http.StatusServiceUnavailable},
+		{name: "エラー率に該当しない", path: "/api/v1/todos", cfg: FaultInjectionConfig{ErrorRate: 0.3, Rand: func() float64 { return 0.5 }}, expectedStatus: http.StatusOK},
+		{name: "API以外は対象外", path: "/health", cfg: FaultInjectionConfig{ErrorRate: 1}, expectedStatus: http.StatusOK},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			start := time.Now()
+			FaultInjectionMiddleware(tt.cfg)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minDuration {
+				t.Errorf("処理時間 = %s, 期待値 = %s 以上", elapsed, tt.minDuration)
+			}
+			if tt.expectedStatus == http.StatusServiceUnavailable && rec.Header().Get("X-Fault-Injected") != "true" {
+				t.Error("X-Fault-Injected ヘッダーがありません")
+			}
+		})
+	}
+}
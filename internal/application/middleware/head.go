@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// HeadMiddleware は HEAD リクエストを GET として処理し、ボディを除いたレスポンスを返すミドルウェアです
+//
+// 学習ポイント：
+//  1. HEAD は GET と同じヘッダー（Content-Length, ETag 等）をボディなしで返すメソッド（RFC 9110）
+//  2. ハンドラーごとに HEAD を実装せず、GET の処理結果からボディだけを捨てる
+//  3. Content-Length はボディの長さを数えて設定する（ボディ自体はバッファリングしない）
+//
+// ハンドラーには Method が GET のリクエストを渡すため、GET に対応した全てのエンドポイントが HEAD にも対応します
+func HeadMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		get := r.Clone(r.Context())
+		get.Method = http.MethodGet
+
+		hw := &headResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(hw, get)
+		hw.flush()
+	})
+}
+
+// headResponseWriter はボディを捨てて長さだけを数え、最後にヘッダーを書き込みます
+type headResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	length      int
+}
+
+// WriteHeader はステータスコードを記録します（Content-Length を設定するまで書き込みを遅らせます）
+func (h *headResponseWriter) WriteHeader(statusCode int) {
+	if h.wroteHeader {
+		return
+	}
+	h.wroteHeader = true
+	h.statusCode = statusCode
+}
+
+// Write はボディの長さを数えます（内容は捨てます）
+func (h *headResponseWriter) Write(data []byte) (int, error) {
+	if !h.wroteHeader {
+		h.WriteHeader(http.StatusOK)
+	}
+	h.length += len(data)
+	return len(data), nil
+}
+
+// Unwrap は元の ResponseWriter を返します
+func (h *headResponseWriter) Unwrap() http.ResponseWriter {
+	return h.ResponseWriter
+}
+
+// flush は GET の場合と同じ Content-Length を設定してヘッダーを書き込みます
+// ハンドラーが Content-Length を設定した場合（静的ファイル等）はその値を使います
+func (h *headResponseWriter) flush() {
+	header := h.ResponseWriter.Header()
+	if header.Get("Content-Length") == "" && bodyAllowed(h.statusCode) {
+		header.Set("Content-Length", strconv.Itoa(h.length))
+	}
+	h.ResponseWriter.WriteHeader(h.statusCode)
+}
+
+// bodyAllowed はステータスコードのレスポンスにボディ（Content-Length）を含められるかを返します
+func bodyAllowed(statusCode int) bool {
+	return statusCode >= http.StatusOK && statusCode != http.StatusNoContent && statusCode != http.StatusNotModified
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// TestHeadMiddleware は HEAD リクエストが GET と同じヘッダーをボディなしで返すことをテストします
+func TestHeadMiddleware(t *testing.T) {
+	const body = `{"id":1,"title":"牛乳を買う"}`
+
+	tests := []struct {
+		name                  string
+		method                string
+		handler               http.HandlerFunc
+		expectedStatus        int
+		expectedBody          string
+		expectedContentLength string
+	}{
+		{
+			name: "HEAD は GET として処理しボディを除く", method: http.MethodHead,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					return
+				}
+				w.Header().Set("ETag", `"abc"`)
+				w.Write([]byte(body))
+			},
+			expectedStatus: http.StatusOK, expectedContentLength: strconv.Itoa(len(body)),
+		},
+		{
+			name: "ハンドラーが設定した Content-Length を使う", method: http.MethodHead,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "1024")
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedStatus: http.StatusOK, expectedContentLength: "1024",
+		},
+		{
+			name: "エラーのステータスコードもそのまま返す", method: http.MethodHead,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+			expectedStatus: http.StatusNotFound, expectedContentLength: "19",
+		},
+		{
+			name: "304 には Content-Length を付けない", method: http.MethodHead,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotModified)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "GET はそのまま処理する", method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			},
+			expectedStatus: http.StatusOK, expectedBody: body,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			HeadMiddleware(tt.handler).ServeHTTP(rec, httptest.NewRequest(tt.method, "/api/v1/todos/1", nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if rec.Body.String() != tt.expectedBody {
+				t.Errorf("ボディ = %q, 期待値 = %q", rec.Body.String(), tt.expectedBody)
+			}
+			if got := rec.Header().Get("Content-Length"); got != tt.expectedContentLength {
+				t.Errorf("Content-Length = %q, 期待値 = %q", got, tt.expectedContentLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// Idempotency-Key に関するヘッダーです
+const (
+	// IdempotencyKeyHeader は再送の重複排除に使うキーを指定するリクエストヘッダーです
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader は保存したレスポンスを返した（処理を繰り返さなかった）ことを示すレスポンスヘッダーです
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength はキーの最大文字数です（idempotency_keys.idempotency_key の列幅）
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotentResponseBytes は保存するレスポンスボディの上限です
+// 超えた場合は保存せず、同じキーの再送ではもう一度処理します
+const maxIdempotentResponseBytes = 1 << 20
+
+// idempotentResponseHeaders は保存し、再送時にも返すレスポンスヘッダーです
+var idempotentResponseHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// IdempotencyMiddleware は Idempotency-Key ヘッダーを付けた POST / PATCH リクエストの再送を重複排除するミドルウェアです
+//
+// 学習ポイント：
+//  1. クライアントは操作ごとに一意なキー（UUID等）を生成し、再送時も同じキーを付ける
+//  2. 処理済みのキーでは処理を繰り返さず、保存したレスポンスを Idempotent-Replayed: true を付けて返す
+//     （タイムアウト後の再送でTodoが二重に作成されない）
+//  3. サーバーのエラー（5xx）や一時的なエラー（408・429）は保存しない（再送で処理をやり直せる）
+//
+// キーは操作者（X-Actor）ごとに区別します。ヘッダーのないリクエストはそのまま処理します
+// 同じキーで内容の違うリクエストは 422、同じキーのリクエストが処理中の場合は 409 を返します
+func IdempotencyMiddleware(idempotencyService service.IdempotencyServiceInterface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !validIdempotencyKey(key) {
+				writeIdempotencyError(w, http.StatusBadRequest, "Invalid idempotency key", "Idempotency-Key must be 1-255 printable ASCII characters")
+				return
+			}
+
+			// 上限・期限による受信の打ち切り（RequestBodyMiddleware）は、ハンドラーが 413 / 408 を返せるよう
+			// キーを記録せずにそのまま引き渡す
+			body, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), &errorReader{err: err}))
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			stored, err := idempotencyService.Begin(ctx, key, requestFingerprint(r, body))
+			switch {
+			case errors.Is(err, service.ErrIdempotencyKeyReused):
+				writeIdempotencyError(w, http.StatusUnprocessableEntity, "Idempotency key reused", err.Error())
+				return
+			case errors.Is(err, service.ErrIdempotencyInProgress):
+				w.Header().Set("Retry-After", "1")
+				writeIdempotencyError(w, http.StatusConflict, "Idempotency key in use", err.Error())
+				return
+			case err != nil:
+				writeIdempotencyError(w, http.StatusInternalServerError, "Failed to process idempotency key", err.Error())
+				return
+			case stored != nil:
+				replayIdempotentResponse(w, stored.StatusCode, stored.Header, stored.Body)
+				return
+			}
+
+			recorder := &idempotencyResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			// クライアントが切断しても結果を保存・解放できるよう、キャンセルされないコンテキストを使う
+			saveCtx := context.WithoutCancel(ctx)
+			completed := false
+			defer func() {
+				// パニック等で保存できなかった場合も、処理中のまま残さない
+				if !completed {
+					if err := idempotencyService.Release(saveCtx, key); err != nil {
+						slog.Warn("failed to release idempotency key", "error", err)
+					}
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			if !recorder.storable() {
+				return
+			}
+			header := make(map[string]string)
+			for _, name := range idempotentResponseHeaders {
+				if value := recorder.Header().Get(name); value != "" {
+					header[name] = value
+				}
+			}
+			if err := idempotencyService.Complete(saveCtx, key, recorder.statusCode, header, recorder.body.Bytes()); err != nil {
+				slog.Warn("failed to save idempotent response", "error", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// validIdempotencyKey はキーが1〜255文字の表示可能なASCII文字だけでできているかを判定します
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestFingerprint はリクエストのメソッド・パス・クエリ・ボディから、キーの使い回しを検出するハッシュを作ります
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replayIdempotentResponse は保存したレスポンスを返します
+func replayIdempotentResponse(w http.ResponseWriter, statusCode int, header map[string]string, body []byte) {
+	for name, value := range header {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+// writeIdempotencyError はキーを処理できなかった場合のエラーレスポンスを書き込みます
+func writeIdempotencyError(w http.ResponseWriter, statusCode int, message, details string) {
+	writeErrorResponse(w, statusCode, message, details)
+}
+
+// idempotencyResponseWriter はレスポンスをクライアントに書き込みながら、保存用に記録します
+type idempotencyResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	overflow    bool // ボディが上限を超えた（保存しない）
+	body        bytes.Buffer
+}
+
+// WriteHeader はステータスコードを記録して書き込みます
+func (i *idempotencyResponseWriter) WriteHeader(statusCode int) {
+	if i.wroteHeader {
+		return
+	}
+	i.wroteHeader = true
+	i.statusCode = statusCode
+	i.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write はボディを記録して書き込みます
+func (i *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	if !i.wroteHeader {
+		i.WriteHeader(http.StatusOK)
+	}
+	if !i.overflow {
+		if i.body.Len()+len(data) > maxIdempotentResponseBytes {
+			i.overflow = true
+			i.body.Reset()
+		} else {
+			i.body.Write(data)
+		}
+	}
+	return i.ResponseWriter.Write(data)
+}
+
+// Unwrap は元の ResponseWriter を返します
+func (i *idempotencyResponseWriter) Unwrap() http.ResponseWriter {
+	return i.ResponseWriter
+}
+
+// storable はレスポンスを保存して再送時に返してよいかを返します
+// サーバーのエラーと一時的なエラー（408 Request Timeout・429 Too Many Requests）は、再送で処理をやり直せるよう保存しません
+func (i *idempotencyResponseWriter) storable() bool {
+	switch {
+	case i.overflow:
+		return false
+	case i.statusCode >= http.StatusInternalServerError:
+		return false
+	case i.statusCode == http.StatusRequestTimeout, i.statusCode == http.StatusTooManyRequests:
+		return false
+	}
+	return true
+}
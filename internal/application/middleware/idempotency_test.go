@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// fakeIdempotencyService はメモリ上でキーを管理する IdempotencyServiceInterface のテスト用の実装です
+type fakeIdempotencyService struct {
+	records map[string]*entity.IdempotencyRecord
+}
+
+func newFakeIdempotencyService() *fakeIdempotencyService {
+	return &fakeIdempotencyService{records: make(map[string]*entity.IdempotencyRecord)}
+}
+
+func (f *fakeIdempotencyService) Begin(ctx context.Context, key, fingerprint string) (*entity.IdempotencyRecord, error) {
+	record, exists := f.records[key]
+	switch {
+	case !exists:
+		f.records[key] = &entity.IdempotencyRecord{Key: key, Fingerprint: fingerprint}
+		return nil, nil
+	case record.Fingerprint != fingerprint:
+		return nil, service.ErrIdempotencyKeyReused
+	case !record.Completed():
+		return nil, service.ErrIdempotencyInProgress
+	}
+	return record, nil
+}
+
+func (f *fakeIdempotencyService) Complete(ctx context.Context, key string, statusCode int, header map[string]string, body []byte) error {
+	record := f.records[key]
+	record.StatusCode, record.Header, record.Body = statusCode, header, body
+	return nil
+}
+
+func (f *fakeIdempotencyService) Release(ctx context.Context, key string) error {
+	delete(f.records, key)
+	return nil
+}
+
+func (f *fakeIdempotencyService) PurgeExpired(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+// TestIdempotencyMiddleware は同じキーの再送で処理を繰り返さずにレスポンスを返すことをテストします
+func TestIdempotencyMiddleware(t *testing.T) {
+	type request struct {
+		method string
+		key    string
+		body   string
+	}
+
+	tests := []struct {
+		name           string
+		requests       []request
+		handlerStatus  int
+		inProgress     string // 事前に処理中にしておくキー
+		expectedStatus int    // 最後のリクエストのステータスコード
+		expectedCalls  int
+		expectReplayed bool
+	}{
+		{
+			name:           "同じキーの再送は保存したレスポンスを返す",
+			requests:       []request{{http.MethodPost, "k1", `{"title":"a"}`}, {http.MethodPost, "k1", `{"title":"a"}`}},
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusCreated, expectedCalls: 1, expectReplayed: true,
+		},
+		{
+			name:           "キーがない場合は毎回処理する",
+			requests:       []request{{http.MethodPost, "", `{"title":"a"}`}, {http.MethodPost, "", `{"title":"a"}`}},
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusCreated, expectedCalls: 2,
+		},
+		{
+			name:           "一括更新（PATCH）も対象",
+			requests:       []request{{http.MethodPatch, "k1", `[{"id":1}]`}, {http.MethodPatch, "k1", `[{"id":1}]`}},
+			handlerStatus:  http.StatusOK,
+			expectedStatus: http.StatusOK, expectedCalls: 1, expectReplayed: true,
+		},
+		{
+			name:           "POST・PATCH 以外は対象外",
+			requests:       []request{{http.MethodPut, "k1", `{"title":"a"}`}, {http.MethodPut, "k1", `{"title":"a"}`}},
+			handlerStatus:  http.StatusOK,
+			expectedStatus: http.StatusOK, expectedCalls: 2,
+		},
+		{
+			name:           "同じキーで内容の違うリクエストは422",
+			requests:       []request{{http.MethodPost, "k1", `{"title":"a"}`}, {http.MethodPost, "k1", `{"title":"b"}`}},
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusUnprocessableEntity, expectedCalls: 1,
+		},
+		{
+			name:           "サーバーのエラーは保存せずに再送で処理し直す",
+			requests:       []request{{http.MethodPost, "k1", `{"title":"a"}`}, {http.MethodPost, "k1", `{"title":"a"}`}},
+			handlerStatus:  http.StatusInternalServerError,
+			expectedStatus: http.StatusInternalServerError, expectedCalls: 2,
+		},
+		{
+			name:           "処理中のキーは409",
+			requests:       []request{{http.MethodPost, "k1", `{"title":"a"}`}},
+			handlerStatus:  http.StatusCreated,
+			inProgress:     "k1",
+			expectedStatus: http.StatusConflict, expectedCalls: 0,
+		},
+		{
+			name:           "不正なキーは400",
+			requests:       []request{{http.MethodPost, "キー", `{"title":"a"}`}},
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusBadRequest, expectedCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newFakeIdempotencyService()
+			if tt.inProgress != "" {
+				first := tt.requests[0]
+				fingerprint := requestFingerprint(httptest.NewRequest(first.method, "/api/v1/todos", nil), []byte(first.body))
+				svc.records[tt.inProgress] = &entity.IdempotencyRecord{Key: tt.inProgress, Fingerprint: fingerprint}
+			}
+			calls := 0
+			handler := IdempotencyMiddleware(svc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				body, _ := io.ReadAll(r.Body)
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Location", "/api/v1/todos/1")
+				w.WriteHeader(tt.handlerStatus)
+				w.Write([]byte(`{"call":` + strconv.Itoa(calls) + `,"body":` + string(body) + `}`))
+			}))
+
+			var first, rec *httptest.ResponseRecorder
+			for _, req := range tt.requests {
+				r := httptest.NewRequest(req.method, "/api/v1/todos", strings.NewReader(req.body))
+				if req.key != "" {
+					r.Header.Set(IdempotencyKeyHeader, req.key)
+				}
+				rec = httptest.NewRecorder()
+				handler.ServeHTTP(rec, r)
+				if first == nil {
+					first = rec
+				}
+			}
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("ハンドラーの呼び出し回数 = %d, 期待値 = %d", calls, tt.expectedCalls)
+			}
+			if replayed := rec.Header().Get(IdempotentReplayedHeader) == "true"; replayed != tt.expectReplayed {
+				t.Errorf("%s = %q, 再送のレスポンス = %v を期待", IdempotentReplayedHeader, rec.Header().Get(IdempotentReplayedHeader), tt.expectReplayed)
+			}
+			if tt.expectReplayed {
+				if rec.Body.String() != first.Body.String() {
+					t.Errorf("再送のボディ = %s, 最初のレスポンス = %s", rec.Body.String(), first.Body.String())
+				}
+				if rec.Header().Get("Location") != "/api/v1/todos/1" || rec.Header().Get("Content-Type") != "application/json" {
+					t.Errorf("再送のヘッダー = %v", rec.Header())
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -45,6 +46,12 @@ func (r *ResponseRecorder) Write(data []byte) (int, error) {
 	return size, err
 }
 
+// Unwrap は元の ResponseWriter を返します
+// http.ResponseController が Hijack（WebSocket への切り替え）などをラップ元に委譲できるようにします
+func (r *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // LoggingMiddleware はHTTPリクエストとレスポンスをログ出力するミドルウェアです
 //
 // 標準パッケージでのログ機能の学習ポイント：
@@ -77,6 +84,17 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 			recorder.responseSize, // レスポンスサイズ（バイト）
 			duration,              // 処理時間
 		)
+
+		// 障害調査用の詳細は DEBUG レベルでのみ出力する（PUT /admin/loglevel で一時的に有効化できる）
+		slog.Debug("request details",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"query", r.URL.RawQuery,
+			"user_agent", r.UserAgent(),
+			"content_type", r.Header.Get("Content-Type"),
+			"content_length", r.ContentLength,
+			"request_id", recorder.Header().Get("X-Request-ID"),
+		)
 	})
 }
 
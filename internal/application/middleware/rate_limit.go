@@ -0,0 +1,328 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// RateLimitMode はレート制限の動作モードです
+type RateLimitMode string
+
+const (
+	// RateLimitOff はレート制限を行いません
+	RateLimitOff RateLimitMode = "off"
+
+	// RateLimitWarn は上限を超えたリクエストも処理し、警告のヘッダーとログだけを出力します
+	RateLimitWarn RateLimitMode = "warn"
+
+	// RateLimitEnforce は上限を超えたリクエストを 429 Too Many Requests で拒否します
+	RateLimitEnforce RateLimitMode = "enforce"
+)
+
+// レート制限の状態をクライアントに伝えるレスポンスヘッダーです
+const (
+	// RateLimitLimitHeader は1分あたりのリクエスト数の上限です
+	RateLimitLimitHeader = "X-RateLimit-Limit"
+
+	// RateLimitRemainingHeader は続けて送信できる残りのリクエスト数です
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+
+	// RateLimitResetHeader は残りのリクエスト数が上限まで回復するまでの秒数です
+	RateLimitResetHeader = "X-RateLimit-Reset"
+
+	// RateLimitWarningHeader は warn モードで上限を超えた場合に付けるヘッダーです
+	RateLimitWarningHeader = "X-RateLimit-Warning"
+)
+
+// rateLimitSweepInterval は使われなくなったクライアントの状態を削除する間隔です
+const rateLimitSweepInterval = time.Minute
+
+// RateLimitConfig はレート制限の設定です
+type RateLimitConfig struct {
+	// Mode はレート制限の動作モードです（空の場合は RateLimitOff）
+	Mode RateLimitMode
+
+	// RequestsPerMinute はクライアントごとの1分あたりのリクエスト数の上限です
+	RequestsPerMinute int
+
+	// Burst は続けて送信できるリクエスト数の上限です（0 以下の場合は RequestsPerMinute と同じ）
+	Burst int
+
+	// Plans は認証されたユーザーに適用するプランごとの上限です（キーはプラン名）
+	Plans map[string]RateLimitPlan
+
+	// UserPlans はユーザー名ごとのプラン名です
+	UserPlans map[string]string
+
+	// DefaultPlan は UserPlans にないユーザーのプラン名です
+	// 空の場合は、認証されたユーザーにも RequestsPerMinute / Burst を適用します（数えるのはユーザーごと）
+	DefaultPlan string
+
+	// WarnPeriod は warn モードを続ける期間です（慣らし期間）
+	// 起動からこの期間が過ぎると enforce モードに切り替わり、0 の場合は warn モードのままです
+	WarnPeriod time.Duration
+
+	// Now は現在時刻を返す関数です（nil の場合は time.Now、テストで固定するためのフィールド）
+	Now func() time.Time
+}
+
+// RateLimitPlan はプラン（ユーザーの契約・役割）ごとのレート制限の上限です
+type RateLimitPlan struct {
+	// RequestsPerMinute は1分あたりのリクエスト数の上限です（0 の場合は制限しない）
+	RequestsPerMinute int
+
+	// Burst は続けて送信できるリクエスト数の上限です（0 以下の場合は RequestsPerMinute と同じ）
+	Burst int
+}
+
+// RateLimitMiddleware は /api/ 配下のリクエスト数をクライアントごとに制限するミドルウェアです
+//
+// 学習ポイント：
+//  1. トークンバケット：バケットには最大 Burst 個のトークンがあり、1分あたり RequestsPerMinute 個の割合で補充される
+//     リクエストごとにトークンを1つ使い、トークンがなければ上限を超えたとみなす
+//  2. いきなり 429 を返すと、上限が厳しすぎた場合に正常な利用者を締め出してしまう
+//     warn モードではヘッダーとログで「拒否されるはずだった」リクエストを確認してから上限を調整できる
+//  3. WarnPeriod を指定すると、慣らし期間の後に自動で enforce モードに切り替わる
+//  4. 認証されたリクエストはユーザーごとに数え、ユーザーのプラン（UserPlans・DefaultPlan）の上限を適用する
+//     同じIPアドレスの背後にいる複数のユーザー（社内ネットワーク等）が互いの上限を使い切らないようにするため
+//
+// 認証されていないクライアントは接続元のIPアドレス（RemoteAddr）で識別します
+// リバースプロキシの配下ではプロキシのアドレスになるため、プロキシ側で制限してください
+// ユーザーを識別するため、認証のミドルウェアより内側（WithMiddleware）に置きます
+func RateLimitMiddleware(cfg RateLimitConfig) func(http.Handler) http.Handler {
+	if cfg.Mode == "" || cfg.Mode == RateLimitOff || (cfg.RequestsPerMinute <= 0 && len(cfg.Plans) == 0) {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	limiter := newRateLimiter(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client, limit := limiter.identify(r)
+			if limit == nil {
+				// 制限しないプラン
+				next.ServeHTTP(w, r)
+				return
+			}
+			now := limiter.now()
+			result := limiter.take(client, limit, now)
+
+			w.Header().Set(RateLimitLimitHeader, strconv.Itoa(limit.requestsPerMinute))
+			w.Header().Set(RateLimitRemainingHeader, strconv.Itoa(result.remaining))
+			w.Header().Set(RateLimitResetHeader, strconv.Itoa(int(math.Ceil(result.reset.Seconds()))))
+			if result.allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			retryAfter := strconv.Itoa(int(math.Ceil(result.retryAfter.Seconds())))
+			if limiter.enforcing(now) {
+				if result.first {
+					slog.Warn("rate limit exceeded", "client", client, "mode", RateLimitEnforce, "path", r.URL.Path)
+				}
+				w.Header().Set("Retry-After", retryAfter)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error":"Too many requests","details":"retry after ` + retryAfter + ` seconds"}`))
+				return
+			}
+
+			// warn モード：拒否せずに処理し、拒否されるはずだったことだけを伝える
+			if result.first {
+				slog.Warn("rate limit exceeded", "client", client, "mode", RateLimitWarn, "path", r.URL.Path)
+			}
+			w.Header().Set(RateLimitWarningHeader, limiter.warning(retryAfter))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimiter はクライアントごとのトークンバケットを管理します
+type rateLimiter struct {
+	cfg       RateLimitConfig
+	base      *rateLimit            // 認証されていないクライアントと、プランのないユーザーの上限
+	plans     map[string]*rateLimit // プラン名ごとの上限（制限しないプランは nil）
+	enforceAt time.Time             // warn モードから enforce モードに切り替わる時刻（ゼロ値の場合は切り替えない）
+	now       func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// rateLimit はトークンバケットの大きさと補充の速さです
+type rateLimit struct {
+	requestsPerMinute int
+	burst             float64
+	perSecond         float64
+}
+
+// newRateLimit は1分あたりのリクエスト数とバーストから rateLimit を作成します（requestsPerMinute が 0 以下の場合は nil）
+func newRateLimit(requestsPerMinute, burst int) *rateLimit {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = requestsPerMinute
+	}
+	return &rateLimit{
+		requestsPerMinute: requestsPerMinute,
+		burst:             float64(burst),
+		perSecond:         float64(requestsPerMinute) / 60,
+	}
+}
+
+// tokenBucket は1つのクライアントのトークンバケットです
+type tokenBucket struct {
+	limit    *rateLimit
+	tokens   float64
+	updated  time.Time
+	exceeded bool // 上限を超えた状態が続いている間は true（ログを1回だけ出力するため）
+}
+
+// rateLimitResult はトークンを取得した結果です
+type rateLimitResult struct {
+	allowed    bool
+	remaining  int
+	retryAfter time.Duration // 次のトークンが補充されるまでの時間（allowed が false の場合のみ）
+	reset      time.Duration // トークンが満タンに戻るまでの時間
+	first      bool          // 上限を超えた状態になった最初のリクエストの場合は true
+}
+
+// newRateLimiter は rateLimiter のコンストラクタです
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	limiter := &rateLimiter{
+		cfg:       cfg,
+		base:      newRateLimit(cfg.RequestsPerMinute, cfg.Burst),
+		plans:     make(map[string]*rateLimit, len(cfg.Plans)),
+		now:       now,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: now(),
+	}
+	for name, plan := range cfg.Plans {
+		limiter.plans[name] = newRateLimit(plan.RequestsPerMinute, plan.Burst)
+	}
+	if cfg.Mode == RateLimitWarn && cfg.WarnPeriod > 0 {
+		limiter.enforceAt = limiter.lastSweep.Add(cfg.WarnPeriod)
+	}
+	return limiter
+}
+
+// enforcing は now の時点で上限を超えたリクエストを拒否するかを返します
+func (l *rateLimiter) enforcing(now time.Time) bool {
+	if l.cfg.Mode == RateLimitEnforce {
+		return true
+	}
+	return !l.enforceAt.IsZero() && !now.Before(l.enforceAt)
+}
+
+// warning は warn モードで上限を超えた場合の X-RateLimit-Warning ヘッダーの値を返します
+func (l *rateLimiter) warning(retryAfter string) string {
+	message := "rate limit exceeded; retry after " + retryAfter + " seconds"
+	if !l.enforceAt.IsZero() {
+		message += "; requests will be rejected with 429 from " + l.enforceAt.UTC().Format(time.RFC3339)
+	}
+	return message
+}
+
+// identify はリクエストのクライアントを識別するキーと、適用する上限を返します（制限しない場合は nil）
+// 認証されたリクエストはユーザーID、それ以外は接続元のIPアドレスで識別します
+func (l *rateLimiter) identify(r *http.Request) (string, *rateLimit) {
+	principal, ok := service.PrincipalFromContext(r.Context())
+	if !ok {
+		return "ip:" + clientAddress(r), l.base
+	}
+
+	key := "user:" + strconv.Itoa(principal.UserID)
+	plan, ok := l.cfg.UserPlans[principal.Username]
+	if !ok {
+		plan = l.cfg.DefaultPlan
+	}
+	if limit, ok := l.plans[plan]; ok {
+		return key, limit
+	}
+	return key, l.base
+}
+
+// take はクライアントのバケットからトークンを1つ取得します
+func (l *rateLimiter) take(client string, limit *rateLimit, now time.Time) rateLimitResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	// プランが変わった場合（設定の変更後の最初のリクエスト）は、新しい上限のバケットで数え直す
+	bucket, ok := l.buckets[client]
+	if !ok || bucket.limit != limit {
+		bucket = &tokenBucket{limit: limit, tokens: limit.burst, updated: now}
+		l.buckets[client] = bucket
+	}
+	l.refill(bucket, now)
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		bucket.exceeded = false
+		return rateLimitResult{allowed: true, remaining: int(bucket.tokens), reset: bucket.untilFull()}
+	}
+
+	first := !bucket.exceeded
+	bucket.exceeded = true
+	wait := time.Duration((1 - bucket.tokens) / limit.perSecond * float64(time.Second))
+	return rateLimitResult{retryAfter: wait, reset: bucket.untilFull(), first: first}
+}
+
+// refill は前回の更新からの経過時間に応じてトークンを補充します
+func (l *rateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		bucket.tokens = min(bucket.limit.burst, bucket.tokens+elapsed.Seconds()*bucket.limit.perSecond)
+		bucket.updated = now
+	}
+}
+
+// untilFull はトークンが満タンに戻るまでの時間を返します
+func (b *tokenBucket) untilFull() time.Duration {
+	return time.Duration((b.limit.burst - b.tokens) / b.limit.perSecond * float64(time.Second))
+}
+
+// sweep はトークンが満タンに戻ったバケットを削除します（呼び出し側でロックを取得していること）
+// 満タンのバケットは新しく作り直したものと同じ状態なので、削除しても制限の結果は変わりません
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for client, bucket := range l.buckets {
+		l.refill(bucket, now)
+		if bucket.tokens >= bucket.limit.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// clientAddress はリクエストの接続元のIPアドレスを返します
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
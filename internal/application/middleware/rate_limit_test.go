@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TestRateLimitMiddleware はモードごとの上限を超えたリクエストの扱いをテストします
+func TestRateLimitMiddleware(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		mode            RateLimitMode
+		warnPeriod      time.Duration
+		elapsed         time.Duration // 上限を超えたリクエストを送信するまでの経過時間
+		expectedStatus  int
+		expectWarning   bool
+		expectEnforceAt bool
+	}{
+		{name: "enforce", mode: RateLimitEnforce, expectedStatus: http.StatusTooManyRequests},
+		{name: "warn", mode: RateLimitWarn, expectedStatus: http.StatusOK, expectWarning: true},
+		{name: "慣らし期間中のwarn", mode: RateLimitWarn, warnPeriod: time.Hour, elapsed: 30 * time.Minute, expectedStatus: http.StatusOK, expectWarning: true, expectEnforceAt: true},
+		{name: "慣らし期間の後はenforce", mode: RateLimitWarn, warnPeriod: time.Hour, elapsed: time.Hour, expectedStatus: http.StatusTooManyRequests},
+		{name: "off", mode: RateLimitOff, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			handler := RateLimitMiddleware(RateLimitConfig{
+				Mode:              tt.mode,
+				RequestsPerMinute: 60,
+				Burst:             2,
+				WarnPeriod:        tt.warnPeriod,
+				Now:               func() time.Time { return now },
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			send := func() *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+				req.RemoteAddr = "192.0.2.1:1234"
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				return rec
+			}
+
+			// 経過時間の直前にバーストの上限までリクエストを送信する
+			now = start.Add(tt.elapsed)
+			for i := 0; i < 2; i++ {
+				if rec := send(); rec.Code != http.StatusOK {
+					t.Fatalf("%d回目: ステータスコード = %v, 期待値 = %v", i+1, rec.Code, http.StatusOK)
+				}
+			}
+
+			rec := send()
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			warning := rec.Header().Get(RateLimitWarningHeader)
+			if (warning != "") != tt.expectWarning {
+				t.Errorf("%s = %q, 期待値 = %v", RateLimitWarningHeader, warning, tt.expectWarning)
+			}
+			if strings.Contains(warning, "2024-05-01T10:00:00Z") != tt.expectEnforceAt {
+				t.Errorf("%s に enforce モードに切り替わる時刻が含まれていません: %q", RateLimitWarningHeader, warning)
+			}
+			if tt.expectedStatus == http.StatusTooManyRequests && rec.Header().Get("Retry-After") != "1" {
+				t.Errorf("Retry-After = %q, 期待値 = 1", rec.Header().Get("Retry-After"))
+			}
+			if tt.mode != RateLimitOff && rec.Header().Get(RateLimitRemainingHeader) != "0" {
+				t.Errorf("%s = %q, 期待値 = 0", RateLimitRemainingHeader, rec.Header().Get(RateLimitRemainingHeader))
+			}
+		})
+	}
+}
+
+// TestRateLimitMiddleware_Refill はトークンの補充と、クライアント・パスごとの扱いをテストします
+func TestRateLimitMiddleware_Refill(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	handler := RateLimitMiddleware(RateLimitConfig{
+		Mode:              RateLimitEnforce,
+		RequestsPerMinute: 60,
+		Burst:             1,
+		Now:               func() time.Time { return now },
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(path, remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	steps := []struct {
+		name       string
+		advance    time.Duration
+		path       string
+		remoteAddr string
+		expected   int
+	}{
+		{name: "最初のリクエスト", path: "/api/v1/todos", remoteAddr: "192.0.2.1:1000", expected: http.StatusOK},
+		{name: "上限を超えた", path: "/api/v1/todos", remoteAddr: "192.0.2.1:1001", expected: http.StatusTooManyRequests},
+		{name: "別のクライアント", path: "/api/v1/todos", remoteAddr: "192.0.2.2:1000", expected: http.StatusOK},
+		{name: "API以外は対象外", path: "/health", remoteAddr: "192.0.2.1:1002", expected: http.StatusOK},
+		{name: "補充前", advance: 500 * time.Millisecond, path: "/api/v1/todos", remoteAddr: "192.0.2.1:1003", expected: http.StatusTooManyRequests},
+		{name: "補充後", advance: 500 * time.Millisecond, path: "/api/v1/todos", remoteAddr: "192.0.2.1:1004", expected: http.StatusOK},
+		{name: "使われなくなった状態を削除した後", advance: 2 * time.Minute, path: "/api/v1/todos", remoteAddr: "192.0.2.1:1005", expected: http.StatusOK},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if code := send(step.path, step.remoteAddr); code != step.expected {
+			t.Errorf("%s: ステータスコード = %v, 期待値 = %v", step.name, code, step.expected)
+		}
+	}
+}
+
+// TestRateLimitMiddleware_Plans は認証されたユーザーごとの数え方と、プランごとの上限・ヘッダーをテストします
+func TestRateLimitMiddleware_Plans(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	handler := RateLimitMiddleware(RateLimitConfig{
+		Mode:              RateLimitEnforce,
+		RequestsPerMinute: 60,
+		Burst:             1,
+		Plans: map[string]RateLimitPlan{
+			"free":     {RequestsPerMinute: 60, Burst: 2},
+			"pro":      {RequestsPerMinute: 600, Burst: 3},
+			"internal": {},
+		},
+		UserPlans:   map[string]string{"bob": "pro", "ci": "internal"},
+		DefaultPlan: "free",
+		Now:         func() time.Time { return now },
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// send は同じIPアドレスから、username のユーザーとして（空の場合は認証なしで）送信します
+	send := func(userID int, username string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+		req.RemoteAddr = "192.0.2.1:1000"
+		if username != "" {
+			req = req.WithContext(service.WithPrincipal(req.Context(), service.Principal{UserID: userID, Username: username}))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name     string
+		userID   int
+		username string
+		allowed  int    // 上限を超えるまでに受け付けるリクエスト数
+		limit    string // X-RateLimit-Limit（空の場合はヘッダーなし）
+	}{
+		{name: "認証なし（IPアドレスごと）", allowed: 1, limit: "60"},
+		{name: "既定のプラン", userID: 1, username: "alice", allowed: 2, limit: "60"},
+		{name: "ユーザーのプラン", userID: 2, username: "bob", allowed: 3, limit: "600"},
+		{name: "同じIPアドレスの別のユーザー", userID: 3, username: "carol", allowed: 2, limit: "60"},
+		{name: "制限しないプラン", userID: 4, username: "ci", allowed: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < tt.allowed; i++ {
+				rec := send(tt.userID, tt.username)
+				if rec.Code != http.StatusOK {
+					t.Fatalf("%d回目: ステータスコード = %v, 期待値 = %v", i+1, rec.Code, http.StatusOK)
+				}
+				if got := rec.Header().Get(RateLimitLimitHeader); got != tt.limit {
+					t.Errorf("%s = %q, 期待値 = %q", RateLimitLimitHeader, got, tt.limit)
+				}
+			}
+			if tt.limit == "" {
+				return
+			}
+
+			rec := send(tt.userID, tt.username)
+			if rec.Code != http.StatusTooManyRequests {
+				t.Errorf("上限を超えたステータスコード = %v, 期待値 = %v", rec.Code, http.StatusTooManyRequests)
+			}
+			if rec.Header().Get(RateLimitRemainingHeader) != "0" || rec.Header().Get(RateLimitResetHeader) == "" {
+				t.Errorf("%s = %q, %s = %q", RateLimitRemainingHeader, rec.Header().Get(RateLimitRemainingHeader),
+					RateLimitResetHeader, rec.Header().Get(RateLimitResetHeader))
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// RequestBodyLimits はリクエストボディの大きさと受信時間の上限です
+type RequestBodyLimits struct {
+	// MaxBytes はボディの最大バイト数です（超えた分は読み取らずに 413 とします）
+	MaxBytes int64
+
+	// ReadTimeout はボディの受信を開始してから読み終えるまでの上限です（超えた場合は 408 とします）
+	ReadTimeout time.Duration
+}
+
+// RequestBodyMiddleware はリクエストボディの大きさと受信時間を制限するミドルウェアです
+//
+// 学習ポイント：
+//  1. http.MaxBytesReader は上限を超えた時点で読み取りを打ち切り、*http.MaxBytesError を返す
+//  2. http.Server の ReadTimeout はヘッダーとボディの合計の上限のため、ボディだけを1バイトずつ送り続ける
+//     slowloris 型のアップロードに対しては、ボディ単位の期限を別に設ける
+//  3. 期限は接続の読み取り期限（http.ResponseController）で止まったままの送信を打ち切り、
+//     少しずつ届く送信は読み取りのたびに経過時間を確認して打ち切る
+//
+// 上限に達した読み取りは、ハンドラーでボディのデコードエラーとして扱われます
+// ハンドラーは *http.MaxBytesError と os.ErrDeadlineExceeded で 413 と 408 を区別して返します
+func RequestBodyMiddleware(limits RequestBodyLimits) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body := r.Body
+			if limits.MaxBytes > 0 {
+				body = http.MaxBytesReader(w, body, limits.MaxBytes)
+			}
+			if limits.ReadTimeout > 0 {
+				controller := http.NewResponseController(w)
+				deadline := time.Now().Add(limits.ReadTimeout)
+				// httptest.ResponseRecorder などの未対応の ResponseWriter では、経過時間の確認のみで打ち切る
+				// 処理の後は期限を解除し、ハンドラーの処理中に接続が切断扱いにならないようにする
+				if controller.SetReadDeadline(deadline) == nil {
+					defer controller.SetReadDeadline(time.Time{})
+				}
+				body = &deadlineBody{ReadCloser: body, deadline: deadline, timeout: limits.ReadTimeout, controller: controller}
+			}
+			r.Body = body
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// deadlineBody は期限を過ぎた後の読み取りを os.ErrDeadlineExceeded で打ち切るリクエストボディです
+type deadlineBody struct {
+	io.ReadCloser
+	deadline   time.Time
+	timeout    time.Duration
+	controller *http.ResponseController
+	done       bool
+}
+
+// Read は期限内であればボディを読み取ります
+func (b *deadlineBody) Read(p []byte) (int, error) {
+	if b.done {
+		return b.ReadCloser.Read(p)
+	}
+	if time.Now().After(b.deadline) {
+		return 0, b.timeoutError()
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	switch {
+	case err == io.EOF:
+		// 読み終えた後は期限を解除する（接続の切断検知の読み取りが期限で失敗しないように）
+		b.done = true
+		b.controller.SetReadDeadline(time.Time{})
+	case err != nil && os.IsTimeout(err):
+		return n, b.timeoutError()
+	}
+	return n, err
+}
+
+// timeoutError は受信時間の上限を超えたことを表すエラーを返します
+func (b *deadlineBody) timeoutError() error {
+	return fmt.Errorf("request body was not received within %s: %w", b.timeout, os.ErrDeadlineExceeded)
+}
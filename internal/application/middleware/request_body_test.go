@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowReader は1バイトずつ、読み取りのたびに遅延して返すリーダーです（slowloris 型の送信の再現）
+type slowReader struct {
+	data  string
+	delay time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, io.EOF
+	}
+	time.Sleep(r.delay)
+	p[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
+
+// TestRequestBodyMiddleware はボディの大きさと受信時間の上限をテストします
+func TestRequestBodyMiddleware(t *testing.T) {
+	limits := RequestBodyLimits{MaxBytes: 16, ReadTimeout: 50 * time.Millisecond}
+
+	tests := []struct {
+		name        string
+		body        io.Reader
+		expectedErr func(error) bool
+	}{
+		{name: "上限内", body: strings.NewReader(`{"title":"a"}`), expectedErr: func(err error) bool { return err == nil }},
+		{name: "大きすぎる", body: strings.NewReader(`{"title":"` + strings.Repeat("a", 32) + `"}`), expectedErr: func(err error) bool {
+			var tooLarge *http.MaxBytesError
+			return errors.As(err, &tooLarge) && tooLarge.Limit == 16
+		}},
+		{name: "遅すぎる", body: &slowReader{data: `{"title":"a"}`, delay: 10 * time.Millisecond}, expectedErr: func(err error) bool {
+			return errors.Is(err, os.ErrDeadlineExceeded)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readErr error
+			handler := RequestBodyMiddleware(limits)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, readErr = io.ReadAll(r.Body)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", io.NopCloser(tt.body))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.expectedErr(readErr) {
+				t.Errorf("読み取りのエラー = %v", readErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxRecordedBodyBytes は記録するリクエスト・レスポンスボディの最大バイト数です
+// これを超えた分は記録せず、BodyTruncated を true にします
+const MaxRecordedBodyBytes = 1 << 20
+
+// redactedHeaders は記録時に値を伏せるヘッダーです（認証情報をディスクに残さないため）
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization", "X-Api-Key"}
+
+// RedactedValue は伏せたヘッダーの代わりに記録する値です
+const RedactedValue = "[REDACTED]"
+
+// RecordedExchange は記録したリクエストとレスポンスの1組です
+// 1件ずつJSONファイルとして保存し、リプレイツール（cmd/replay）が読み込みます
+type RecordedExchange struct {
+	// RecordedAt はリクエストを受信した日時です
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// DurationMillis は処理時間（ミリ秒）です
+	DurationMillis int64 `json:"duration_ms"`
+
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest は記録したリクエストです
+type RecordedRequest struct {
+	Method string `json:"method"`
+
+	// URI はクライアントが送信したままのパスとクエリです（ベースパスを含む）
+	URI string `json:"uri"`
+
+	Header http.Header `json:"header"`
+	RecordedBody
+}
+
+// RecordedResponse は記録したレスポンスです
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	RecordedBody
+}
+
+// RecordedBody は記録したボディです
+// UTF-8 のテキスト（JSON・フォーム・HTML）はそのまま、それ以外は base64 で保存します
+type RecordedBody struct {
+	Body          string `json:"body,omitempty"`
+	BodyEncoding  string `json:"body_encoding,omitempty"`
+	BodyTruncated bool   `json:"body_truncated,omitempty"`
+}
+
+// newRecordedBody はボディの内容から RecordedBody を作成します
+func newRecordedBody(data []byte, truncated bool) RecordedBody {
+	body := RecordedBody{BodyTruncated: truncated}
+	if utf8.Valid(data) {
+		body.Body = string(data)
+	} else {
+		body.Body = base64.StdEncoding.EncodeToString(data)
+		body.BodyEncoding = "base64"
+	}
+	return body
+}
+
+// Bytes は記録したボディの内容を返します
+func (b RecordedBody) Bytes() ([]byte, error) {
+	if b.BodyEncoding == "base64" {
+		return base64.StdEncoding.DecodeString(b.Body)
+	}
+	return []byte(b.Body), nil
+}
+
+// TrafficRecorderMiddleware は /api/ 配下のリクエストとレスポンスを dir にJSONファイルとして記録するミドルウェアです
+//
+// 学習ポイント：
+//  1. リクエストボディは一度読むと消えるため、読み取った内容から作り直して次のハンドラーに渡す
+//  2. レスポンスは ResponseWriter をラップし、クライアントへ書き込みながら複製を残す
+//  3. 認証情報を含むヘッダーは伏せて保存する（記録ファイルを共有しても安全なように）
+//
+// ファイル名は受信日時と連番のため、名前順に並べると受信順になります
+// 利用者から報告された不具合の再現用です。本番環境では使用しないでください
+func TrafficRecorderMiddleware(dir string) func(http.Handler) http.Handler {
+	var seq atomic.Int64
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+
+			// 1. リクエストボディを読み取り、同じ内容を次のハンドラーに渡す
+			var reqBody []byte
+			var reqTruncated bool
+			if r.Body != nil {
+				reqBody, _ = io.ReadAll(io.LimitReader(r.Body, MaxRecordedBodyBytes+1))
+				if len(reqBody) > MaxRecordedBodyBytes {
+					reqTruncated = true
+				}
+				r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(reqBody), r.Body), Closer: r.Body}
+				if reqTruncated {
+					reqBody = reqBody[:MaxRecordedBodyBytes]
+				}
+			}
+
+			// 2. レスポンスを複製しながら処理する
+			recorder := &trafficResponseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			// 3. 記録をファイルに保存する（失敗してもレスポンスには影響させない）
+			exchange := RecordedExchange{
+				RecordedAt:     start.UTC(),
+				DurationMillis: time.Since(start).Milliseconds(),
+				Request: RecordedRequest{
+					Method:       r.Method,
+					URI:          r.RequestURI,
+					Header:       redactHeader(r.Header),
+					RecordedBody: newRecordedBody(reqBody, reqTruncated),
+				},
+				Response: RecordedResponse{
+					Status:       recorder.status,
+					Header:       redactHeader(w.Header()),
+					RecordedBody: newRecordedBody(recorder.body.Bytes(), recorder.truncated),
+				},
+			}
+			name := fmt.Sprintf("%s-%06d.json", start.UTC().Format("20060102T150405.000000000"), seq.Add(1))
+			if err := writeRecordedExchange(filepath.Join(dir, name), exchange); err != nil {
+				log.Printf("Traffic recorder: failed to save %s %s: %v", r.Method, r.RequestURI, err)
+			}
+		})
+	}
+}
+
+// readCloser は読み取り元と Close の対象が異なる io.ReadCloser です
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// trafficResponseRecorder はクライアントへの書き込みと同時に、ステータスとボディを記録します
+type trafficResponseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	truncated   bool
+}
+
+// WriteHeader はステータスコードを記録します
+func (r *trafficResponseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.wroteHeader = true
+		r.status = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write はボディを書き込み、上限までの内容を記録します
+func (r *trafficResponseRecorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	if remaining := MaxRecordedBodyBytes - r.body.Len(); remaining < len(data) {
+		r.body.Write(data[:max(remaining, 0)])
+		r.truncated = true
+	} else {
+		r.body.Write(data)
+	}
+	return r.ResponseWriter.Write(data)
+}
+
+// Unwrap は http.ResponseController が元の ResponseWriter の機能（Flush 等）を使えるようにします
+func (r *trafficResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// redactHeader は認証情報を伏せたヘッダーのコピーを返します
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = []string{RedactedValue}
+		}
+	}
+	return redacted
+}
+
+// writeRecordedExchange は記録を1つのJSONファイルとして保存します
+func writeRecordedExchange(path string, exchange RecordedExchange) error {
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
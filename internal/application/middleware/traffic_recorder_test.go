@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTrafficRecorderMiddleware はリクエストとレスポンスの記録をテストします
+func TestTrafficRecorderMiddleware(t *testing.T) {
+	dir := t.TempDir()
+
+	// 次のハンドラーが記録後もリクエストボディを読めることを確認する
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"echo":` + string(body) + `}`))
+	})
+	handler := TrafficRecorderMiddleware(dir)(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos?debug=1", strings.NewReader(`{"title":"記録"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated || rec.Body.String() != `{"echo":{"title":"記録"}}` {
+		t.Fatalf("レスポンスが変わっています: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// API以外は記録しない
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(paths) != 1 {
+		t.Fatalf("記録ファイル数 = %d, 期待値 = 1", len(paths))
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatalf("記録ファイルの読み込みに失敗: %v", err)
+	}
+	var exchange RecordedExchange
+	if err := json.Unmarshal(data, &exchange); err != nil {
+		t.Fatalf("記録ファイルのJSONパースに失敗: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{name: "メソッド", got: exchange.Request.Method, expected: http.MethodPost},
+		{name: "URI（クエリを含む）", got: exchange.Request.URI, expected: "/api/v1/todos?debug=1"},
+		{name: "リクエストボディ", got: exchange.Request.Body, expected: `{"title":"記録"}`},
+		{name: "認証ヘッダーは伏せる", got: exchange.Request.Header.Get("Authorization"), expected: RedactedValue},
+		{name: "レスポンスボディ", got: exchange.Response.Body, expected: `{"echo":{"title":"記録"}}`},
+		{name: "Set-Cookie は伏せる", got: exchange.Response.Header.Get("Set-Cookie"), expected: RedactedValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("取得値 = %q, 期待値 = %q", tt.got, tt.expected)
+			}
+		})
+	}
+	if exchange.Response.Status != http.StatusCreated {
+		t.Errorf("記録したステータスコード = %d, 期待値 = %d", exchange.Response.Status, http.StatusCreated)
+	}
+}
+
+// TestRecordedBody_Bytes はUTF-8以外のボディが base64 で往復できることをテストします
+func TestRecordedBody_Bytes(t *testing.T) {
+	data := []byte{0x1f, 0x8b, 0xff, 0x00}
+
+	body := newRecordedBody(data, false)
+	if body.BodyEncoding != "base64" {
+		t.Errorf("BodyEncoding = %q, 期待値 = base64", body.BodyEncoding)
+	}
+	decoded, err := body.Bytes()
+	if err != nil || string(decoded) != string(data) {
+		t.Errorf("Bytes() = %v, %v, 期待値 = %v", decoded, err, data)
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// WorkspaceHeader は操作するワークスペースを指定するリクエストヘッダーです
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceResolver はワークスペースへのメンバーシップを確認するインターフェースです（service.WorkspaceService が実装します）
+type WorkspaceResolver interface {
+	EnterWorkspace(ctx context.Context, workspaceID int) (context.Context, error)
+}
+
+// WorkspaceMiddleware は X-Workspace-ID ヘッダーで指定されたワークスペースで、リクエストを処理させるミドルウェアです
+//
+// 認証されたユーザーがメンバーであることを確認し、以降のTodoの操作をそのワークスペースのTodoに限定します
+// ヘッダーがない場合は、従来どおりユーザー本人の個人のTodoを操作します
+// AuthMiddleware より内側（認証されたユーザーが設定された後）に置いてください
+//
+// ヘッダーの値はレスポンスのワークスペースのIDと同じ形式（ID_OBFUSCATION_SALT を設定した場合は Hashids の文字列）で、dto.ParseID で解析します
+// ヘッダーが不正な場合は 400、メンバーでない（存在しない）ワークスペースの場合は 404、
+// 認証されていない場合は 403 を返します
+func WorkspaceMiddleware(resolver WorkspaceResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(WorkspaceHeader)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			workspaceID, err := dto.ParseID(value)
+			if err != nil || workspaceID <= 0 {
+				writeWorkspaceError(w, http.StatusBadRequest, "Bad Request", WorkspaceHeader+" must be a workspace ID")
+				return
+			}
+			ctx, err := resolver.EnterWorkspace(r.Context(), workspaceID)
+			if err != nil {
+				switch {
+				case domainerr.IsNotFound(err):
+					writeWorkspaceError(w, http.StatusNotFound, "Not Found", err.Error())
+				case errors.Is(err, service.ErrWorkspaceForbidden):
+					writeWorkspaceError(w, http.StatusForbidden, "Forbidden", err.Error())
+				default:
+					writeWorkspaceError(w, http.StatusInternalServerError, "Internal Server Error", err.Error())
+				}
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// writeWorkspaceError はワークスペースを確認できなかった場合のエラーレスポンスを書き込みます
+func writeWorkspaceError(w http.ResponseWriter, status int, message, details string) {
+	writeErrorResponse(w, status, message, details)
+}
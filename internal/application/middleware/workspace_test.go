@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/pkg/hashids"
+)
+
+// stubWorkspaceResolver はワークスペース 1 だけをメンバーとして受け入れるテスト用の実装です
+type stubWorkspaceResolver struct{}
+
+func (stubWorkspaceResolver) EnterWorkspace(ctx context.Context, workspaceID int) (context.Context, error) {
+	switch workspaceID {
+	case 1:
+		return repository.WithWorkspace(ctx, workspaceID), nil
+	case 2:
+		return nil, fmt.Errorf("%w: workspaces require an authenticated user", service.ErrWorkspaceForbidden)
+	default:
+		return nil, domainerr.NotFound("workspace", workspaceID)
+	}
+}
+
+// TestWorkspaceMiddleware はヘッダーで指定したワークスペースがコンテキストに設定されることをテストします
+func TestWorkspaceMiddleware(t *testing.T) {
+	var gotWorkspace int
+	var gotOK bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotWorkspace, gotOK = repository.WorkspaceFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name              string
+		header            string
+		expectedStatus    int
+		expectedWorkspace bool
+	}{
+		{name: "ヘッダーなしは個人のTodo", expectedStatus: http.StatusNoContent},
+		{name: "メンバーのワークスペース", header: "1", expectedStatus: http.StatusNoContent, expectedWorkspace: true},
+		{name: "メンバーでないワークスペース", header: "3", expectedStatus: http.StatusNotFound},
+		{name: "認証されていない", header: "2", expectedStatus: http.StatusForbidden},
+		{name: "数値でない", header: "team", expectedStatus: http.StatusBadRequest},
+		{name: "0以下", header: "0", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOK = false
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+			if tt.header != "" {
+				req.Header.Set(WorkspaceHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			WorkspaceMiddleware(stubWorkspaceResolver{})(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if gotOK != tt.expectedWorkspace || (gotOK && gotWorkspace != 1) {
+				t.Errorf("ワークスペース = %d, %v, 期待値の有無 = %v", gotWorkspace, gotOK, tt.expectedWorkspace)
+			}
+		})
+	}
+}
+
+// TestWorkspaceMiddleware_IDCodec はIDを不透明な文字列で公開する場合に、ヘッダーのワークスペースのIDも変換されることをテストします
+func TestWorkspaceMiddleware_IDCodec(t *testing.T) {
+	codec, err := hashids.New("todoapp", 8)
+	if err != nil {
+		t.Fatalf("hashids.New() error = %v", err)
+	}
+	originalEncoding := dto.Encoding
+	defer func() { dto.Encoding = originalEncoding }()
+	dto.Encoding = dto.EncodingOptions{TimeFormat: dto.TimeFormatRFC3339, IDCodec: codec}
+
+	var gotWorkspace int
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotWorkspace, _ = repository.WorkspaceFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	first, _ := codec.Encode(1)
+
+	for header, expectedStatus := range map[string]int{first: http.StatusNoContent, "1": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+		req.Header.Set(WorkspaceHeader, header)
+		rec := httptest.NewRecorder()
+		WorkspaceMiddleware(stubWorkspaceResolver{})(next).ServeHTTP(rec, req)
+		if rec.Code != expectedStatus {
+			t.Errorf("%s: ステータスコード = %v, 期待値 = %v, body = %s", header, rec.Code, expectedStatus, rec.Body.String())
+		}
+	}
+	if gotWorkspace != 1 {
+		t.Errorf("ワークスペース = %d, 期待値 = 1", gotWorkspace)
+	}
+}
@@ -0,0 +1,126 @@
+// Package transfer はTodoのインポート・エクスポートの形式（プラグイン）と、その登録先のレジストリを定義します
+//
+// 学習ポイント：
+// ハンドラーは形式の中身を知らず、?format= の名前でレジストリから形式を探すだけです
+// 新しい形式は Exporter / Importer を実装したパッケージを追加し、起動時に Register するだけで使えるようになり、
+// ハンドラーやルーティングを変更する必要はありません（database/sql のドライバー登録と同じ考え方）
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// Format はインポート・エクスポートの形式の共通部分です
+type Format interface {
+	// Name は ?format= で指定する形式の名前です（例: json, opml）
+	Name() string
+}
+
+// Exporter はTodoの一覧を形式に従って書き出します
+type Exporter interface {
+	Format
+
+	// ContentType はエクスポートしたデータのメディアタイプです
+	ContentType() string
+
+	// FileExtension はダウンロードするファイルの拡張子です（"." を含まない）
+	FileExtension() string
+
+	// Export は todos を w へ書き出します
+	Export(w io.Writer, todos []*entity.Todo) error
+}
+
+// Importer は形式に従って読み込んだデータを、作成するTodoに変換します
+type Importer interface {
+	Format
+
+	// Import は r を読み込み、作成するTodoを返します
+	// ID や作成日時などの保存時に決まる項目は無視されます
+	Import(r io.Reader) ([]*entity.Todo, error)
+}
+
+// ImporterExporter はインポートとエクスポートの両方に対応する形式です
+type ImporterExporter interface {
+	Importer
+	Exporter
+}
+
+// Registry は名前ごとに形式を管理します
+// 起動時に Register で登録し、以降は読み取りだけを行うため、ロックは使いません
+type Registry struct {
+	exporters map[string]Exporter
+	importers map[string]Importer
+}
+
+// NewRegistry は formats を登録したレジストリを作成します
+func NewRegistry(formats ...Format) *Registry {
+	registry := &Registry{
+		exporters: make(map[string]Exporter),
+		importers: make(map[string]Importer),
+	}
+	for _, format := range formats {
+		registry.Register(format)
+	}
+	return registry
+}
+
+// Register は形式を登録します
+// Exporter と Importer のどちらを実装しているかに応じて、エクスポート・インポートに使えるようになります
+// 同じ名前の二重登録や、どちらも実装していない形式は設定の誤りのため panic します
+func (r *Registry) Register(format Format) {
+	name := format.Name()
+	exporter, canExport := format.(Exporter)
+	importer, canImport := format.(Importer)
+	if !canExport && !canImport {
+		panic(fmt.Sprintf("transfer: format %q implements neither Exporter nor Importer", name))
+	}
+	if _, exists := r.exporters[name]; exists && canExport {
+		panic(fmt.Sprintf("transfer: exporter %q registered twice", name))
+	}
+	if _, exists := r.importers[name]; exists && canImport {
+		panic(fmt.Sprintf("transfer: importer %q registered twice", name))
+	}
+
+	if canExport {
+		r.exporters[name] = exporter
+	}
+	if canImport {
+		r.importers[name] = importer
+	}
+}
+
+// Exporter は名前に対応するエクスポートの形式を返します
+func (r *Registry) Exporter(name string) (Exporter, bool) {
+	exporter, ok := r.exporters[name]
+	return exporter, ok
+}
+
+// Importer は名前に対応するインポートの形式を返します
+func (r *Registry) Importer(name string) (Importer, bool) {
+	importer, ok := r.importers[name]
+	return importer, ok
+}
+
+// ExportFormats はエクスポートに使える形式の名前を名前順で返します
+func (r *Registry) ExportFormats() []string {
+	return sortedKeys(r.exporters)
+}
+
+// ImportFormats はインポートに使える形式の名前を名前順で返します
+func (r *Registry) ImportFormats() []string {
+	return sortedKeys(r.importers)
+}
+
+// sortedKeys はマップのキーを名前順で返します
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,97 @@
+package transfer
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// exportOnly はエクスポートだけに対応するテスト用の形式です
+type exportOnly struct{ name string }
+
+func (f exportOnly) Name() string                                 { return f.name }
+func (exportOnly) ContentType() string                            { return "text/plain" }
+func (exportOnly) FileExtension() string                          { return "txt" }
+func (exportOnly) Export(w io.Writer, todos []*entity.Todo) error { return nil }
+
+// importOnly はインポートだけに対応するテスト用の形式です
+type importOnly struct{ name string }
+
+func (f importOnly) Name() string                             { return f.name }
+func (importOnly) Import(r io.Reader) ([]*entity.Todo, error) { return nil, nil }
+
+// both はインポートとエクスポートの両方に対応するテスト用の形式です
+type both struct {
+	exportOnly
+	importOnly
+}
+
+func (f both) Name() string { return f.exportOnly.name }
+
+// neither はどちらにも対応しないテスト用の形式です
+type neither struct{}
+
+func (neither) Name() string { return "neither" }
+
+// TestRegistry は形式が実装しているインターフェースに応じて登録されることをテストします
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(
+		both{exportOnly{"json"}, importOnly{"json"}},
+		exportOnly{"org"},
+		importOnly{"csv"},
+	)
+
+	if _, ok := registry.Exporter("json"); !ok {
+		t.Error("json はエクスポートに使えるべきです")
+	}
+	if _, ok := registry.Importer("json"); !ok {
+		t.Error("json はインポートに使えるべきです")
+	}
+	if _, ok := registry.Importer("org"); ok {
+		t.Error("エクスポート専用の org はインポートに使えないべきです")
+	}
+	if _, ok := registry.Exporter("csv"); ok {
+		t.Error("インポート専用の csv はエクスポートに使えないべきです")
+	}
+	if _, ok := registry.Exporter("yaml"); ok {
+		t.Error("登録していない形式は見つからないべきです")
+	}
+
+	if got := registry.ExportFormats(); !slices.Equal(got, []string{"json", "org"}) {
+		t.Errorf("ExportFormats() = %v, 期待値 = [json org]", got)
+	}
+	if got := registry.ImportFormats(); !slices.Equal(got, []string{"csv", "json"}) {
+		t.Errorf("ImportFormats() = %v, 期待値 = [csv json]", got)
+	}
+
+	// 同じ名前でもインポートとエクスポートで別々に登録できる
+	registry.Register(importOnly{"org"})
+	if _, ok := registry.Importer("org"); !ok {
+		t.Error("org のインポートを追加で登録できるべきです")
+	}
+}
+
+// TestRegistry_RegisterPanics は設定の誤りで panic することをテストします
+func TestRegistry_RegisterPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+	}{
+		{name: "二重登録", format: exportOnly{"json"}},
+		{name: "どちらにも対応しない形式", format: neither{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(exportOnly{"json"})
+			defer func() {
+				if recover() == nil {
+					t.Error("panic するべきです")
+				}
+			}()
+			registry.Register(tt.format)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package domainerr はサービス層・リポジトリ層が返すエラーの分類（エラーの種類）を提供します
+//
+// 学習ポイント：
+//  1. エラーメッセージの文字列（"not found" 等）で分類すると、メッセージの変更で分類が壊れる
+//     種類を持つエラー型を返し、呼び出し側は errors.As で種類を判定する
+//  2. 分類はドメイン層で定義し、HTTPステータスやgRPCのコードへの変換は各層（ハンドラー等）が行う
+//  3. 原因のエラー（リポジトリのエラー等）は Wrap で保持し、errors.Is / errors.As で辿れるようにする
+//
+// メッセージは従来と同じ形式（"todo with ID 1 not found"、"invalid todo ID: must be greater than 0" 等）です
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind はエラーの種類です
+type Kind int
+
+// エラーの種類
+const (
+	// KindUnknown は分類されていないエラー（データベースの障害等）です
+	KindUnknown Kind = iota
+
+	// KindNotFound は対象のリソースが存在しないエラーです（HTTP 404）
+	KindNotFound
+
+	// KindInvalid は入力が不正なエラーです（HTTP 400）
+	KindInvalid
+
+	// KindTimeout は処理（データベースのクエリ等）が期限内に終わらなかったエラーです（HTTP 504）
+	KindTimeout
+)
+
+// String はメトリクスのラベルやログに使う種類の名前を返します
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindInvalid:
+		return "invalid"
+	case KindTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
+// Error は種類を持つエラーです
+type Error struct {
+	Kind Kind
+
+	// Resource は対象のリソースです（"todo"、"checklist item" 等）
+	Resource string
+
+	// ID は対象のIDです（空の場合はメッセージにIDを含めません）
+	ID string
+
+	// Field は不正な項目です（"todo ID"、"title" 等）
+	Field string
+
+	// Reason は不正な理由です
+	Reason string
+
+	// Err は原因となったエラーです
+	Err error
+}
+
+// NotFound はリソースが存在しないエラーを作成します
+// id が nil の場合は "todo not found"、それ以外は "todo with ID 1 not found" の形式のメッセージになります
+func NotFound(resource string, id any) *Error {
+	e := &Error{Kind: KindNotFound, Resource: resource}
+	if id != nil {
+		e.ID = fmt.Sprint(id)
+	}
+	return e
+}
+
+// Invalid は入力が不正なエラーを作成します
+// メッセージは "invalid todo ID: must be greater than 0" の形式です
+func Invalid(field, reason string) *Error {
+	return &Error{Kind: KindInvalid, Field: field, Reason: reason}
+}
+
+// ValidationFailed はリソースの検証に失敗したエラーを作成します（種類は KindInvalid）
+// メッセージは "todo validation failed: title is required" の形式です
+func ValidationFailed(resource, reason string) *Error {
+	return &Error{Kind: KindInvalid, Resource: resource, Reason: reason}
+}
+
+// Timeout は処理が期限内に終わらなかったエラーを作成します
+// メッセージは "database query timed out" の形式です（resource には期限を過ぎた処理を指定します）
+func Timeout(resource string) *Error {
+	return &Error{Kind: KindTimeout, Resource: resource}
+}
+
+// Wrap は原因となったエラーを保持したコピーを返します
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// Error はエラーメッセージを返します
+func (e *Error) Error() string {
+	var msg string
+	switch {
+	case e.Kind == KindNotFound && e.ID != "":
+		msg = fmt.Sprintf("%s with ID %s not found", e.Resource, e.ID)
+	case e.Kind == KindNotFound:
+		msg = e.Resource + " not found"
+	case e.Kind == KindTimeout:
+		msg = e.Resource + " timed out"
+	case e.Field != "":
+		msg = fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+	case e.Resource != "":
+		msg = fmt.Sprintf("%s validation failed: %s", e.Resource, e.Reason)
+	default:
+		msg = e.Reason
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap は原因となったエラーを返します
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf はエラー（ラップされたものを含む）の種類を返します
+// 種類を持つエラーが複数ある場合は、最も外側のものを返します
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindUnknown
+}
+
+// IsNotFound はエラーが KindNotFound かを判定します
+func IsNotFound(err error) bool {
+	return KindOf(err) == KindNotFound
+}
+
+// IsInvalid はエラーが KindInvalid かを判定します
+func IsInvalid(err error) bool {
+	return KindOf(err) == KindInvalid
+}
+
+// IsTimeout はエラーが KindTimeout かを判定します
+func IsTimeout(err error) bool {
+	return KindOf(err) == KindTimeout
+}
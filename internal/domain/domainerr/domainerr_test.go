@@ -0,0 +1,65 @@
+package domainerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestError はエラーの種類とメッセージをテストします
+func TestError(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		err          error
+		expectedKind Kind
+		expectedMsg  string
+	}{
+		{name: "IDなしのNotFound", err: NotFound("todo", nil), expectedKind: KindNotFound, expectedMsg: "todo not found"},
+		{name: "IDありのNotFound", err: NotFound("dead letter", 3), expectedKind: KindNotFound, expectedMsg: "dead letter with ID 3 not found"},
+		{
+			name: "原因を保持したNotFound", err: NotFound("todo", 2).Wrap(NotFound("todo", nil)),
+			expectedKind: KindNotFound, expectedMsg: "todo with ID 2 not found: todo not found",
+		},
+		{name: "Invalid", err: Invalid("todo ID", "must be greater than 0"), expectedKind: KindInvalid, expectedMsg: "invalid todo ID: must be greater than 0"},
+		{
+			name: "ValidationFailed", err: ValidationFailed("todo", "title is required"),
+			expectedKind: KindInvalid, expectedMsg: "todo validation failed: title is required",
+		},
+		{
+			name: "fmt.Errorf でラップしても種類を判定できる", err: fmt.Errorf("failed to update todo: %w", NotFound("todo", nil)),
+			expectedKind: KindNotFound, expectedMsg: "failed to update todo: todo not found",
+		},
+		{
+			name: "原因を保持したTimeout", err: fmt.Errorf("failed to get todo: %w", Timeout("database query").Wrap(context.DeadlineExceeded)),
+			expectedKind: KindTimeout, expectedMsg: "failed to get todo: database query timed out: context deadline exceeded",
+		},
+		{name: "分類されていないエラー", err: cause, expectedKind: KindUnknown, expectedMsg: "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if kind := KindOf(tt.err); kind != tt.expectedKind {
+				t.Errorf("KindOf() = %v, 期待値 = %v", kind, tt.expectedKind)
+			}
+			if msg := tt.err.Error(); msg != tt.expectedMsg {
+				t.Errorf("Error() = %q, 期待値 = %q", msg, tt.expectedMsg)
+			}
+			if IsNotFound(tt.err) != (tt.expectedKind == KindNotFound) || IsInvalid(tt.err) != (tt.expectedKind == KindInvalid) || IsTimeout(tt.err) != (tt.expectedKind == KindTimeout) {
+				t.Errorf("IsNotFound() = %v, IsInvalid() = %v, IsTimeout() = %v, 種類 = %v", IsNotFound(tt.err), IsInvalid(tt.err), IsTimeout(tt.err), tt.expectedKind)
+			}
+		})
+	}
+
+	// Wrap は元のエラーを変更せず、原因を errors.Is で辿れるようにする
+	base := NotFound("webhook", 1)
+	wrapped := base.Wrap(cause)
+	if base.Err != nil {
+		t.Errorf("Wrap が元のエラーを変更しました: %v", base)
+	}
+	if !errors.Is(wrapped, cause) {
+		t.Errorf("errors.Is(%v, cause) = false", wrapped)
+	}
+}
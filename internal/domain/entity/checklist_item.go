@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// ChecklistItem はTodoの中に含まれる軽量なチェックリスト項目を表すエンティティです
+// サブタスクほど重くない「やることの小さな区切り」をテキストと完了フラグだけで表現します
+//
+// ChecklistItem は Todo 集約の一部として扱います：
+// 1. 必ずどれか1つのTodoに属する（TodoID）
+// 2. 親のTodoが削除されると一緒に削除される
+// 3. 進捗（完了数/総数）は親のTodoのレスポンスに集計して含める
+type ChecklistItem struct {
+	// ID はチェックリスト項目の一意識別子です
+	ID int `json:"id"`
+
+	// TodoID は所属するTodoのIDです（外部キー）
+	TodoID int `json:"todo_id"`
+
+	// Text はチェックリスト項目の内容です
+	Text string `json:"text"`
+
+	// IsDone はチェック済みかどうかを表します
+	IsDone bool `json:"is_done"`
+
+	// CreatedAt は作成日時です
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt は更新日時です
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MaxChecklistTextLength はチェックリスト項目のテキストの最大文字数です
+const MaxChecklistTextLength = 200
+
+// IsValid はチェックリスト項目のビジネスルールを検証します
+// 空白のみのテキストは無効とします
+func (c *ChecklistItem) IsValid() bool {
+	text := strings.TrimSpace(c.Text)
+	return len(text) > 0 && len(c.Text) <= MaxChecklistTextLength
+}
+
+// CopyTo は指定したTodoに属する、未チェックの複製を作成します（Todoの複製で使用）
+func (c *ChecklistItem) CopyTo(todoID int) *ChecklistItem {
+	return &ChecklistItem{
+		TodoID: todoID,
+		Text:   c.Text,
+	}
+}
+
+// Check はチェックリスト項目を完了状態にします
+func (c *ChecklistItem) Check() {
+	c.IsDone = true
+}
+
+// Uncheck はチェックリスト項目を未完了状態に戻します
+func (c *ChecklistItem) Uncheck() {
+	c.IsDone = false
+}
+
+// ChecklistProgress はTodoに含まれるチェックリストの進捗を表す値オブジェクトです
+type ChecklistProgress struct {
+	// Total はチェックリスト項目の総数です
+	Total int `json:"total"`
+
+	// Done はチェック済みの項目数です
+	Done int `json:"done"`
+}
+
+// IsComplete は全ての項目がチェック済みかどうかを返します
+// 項目が1つもない場合は false を返します
+func (p ChecklistProgress) IsComplete() bool {
+	return p.Total > 0 && p.Done == p.Total
+}
@@ -0,0 +1,62 @@
+package entity
+
+import "testing"
+
+// TestChecklistItem_IsValid はチェックリスト項目のバリデーションをテストします
+func TestChecklistItem_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		item   ChecklistItem
+		expect bool
+	}{
+		{name: "有効な項目", item: ChecklistItem{Text: "牛乳を買う"}, expect: true},
+		{name: "空文字", item: ChecklistItem{Text: ""}, expect: false},
+		{name: "空白のみ", item: ChecklistItem{Text: "   "}, expect: false},
+		{name: "最大文字数ちょうど", item: ChecklistItem{Text: generateString(MaxChecklistTextLength)}, expect: true},
+		{name: "最大文字数超過", item: ChecklistItem{Text: generateString(MaxChecklistTextLength + 1)}, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.IsValid(); got != tt.expect {
+				t.Errorf("IsValid() = %v, 期待値 = %v", got, tt.expect)
+			}
+		})
+	}
+}
+
+// TestChecklistItem_CheckUncheck はチェック状態の切り替えをテストします
+func TestChecklistItem_CheckUncheck(t *testing.T) {
+	item := ChecklistItem{Text: "項目"}
+
+	item.Check()
+	if !item.IsDone {
+		t.Error("Check() 後は IsDone が true であるべきです")
+	}
+
+	item.Uncheck()
+	if item.IsDone {
+		t.Error("Uncheck() 後は IsDone が false であるべきです")
+	}
+}
+
+// TestChecklistProgress_IsComplete はチェックリスト進捗の完了判定をテストします
+func TestChecklistProgress_IsComplete(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress ChecklistProgress
+		expect   bool
+	}{
+		{name: "項目なし", progress: ChecklistProgress{Total: 0, Done: 0}, expect: false},
+		{name: "一部完了", progress: ChecklistProgress{Total: 3, Done: 1}, expect: false},
+		{name: "全て完了", progress: ChecklistProgress{Total: 2, Done: 2}, expect: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.progress.IsComplete(); got != tt.expect {
+				t.Errorf("IsComplete() = %v, 期待値 = %v", got, tt.expect)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "strings"
+
+// Color はフロントエンドがカードの色分けに使用するTodoの色です
+// 名前付きパレットの色（"blue" など）または "#RRGGBB" 形式の16進カラーコードで指定します
+// 空文字は色なしを表します
+type Color string
+
+// ColorNone は色なしを表します
+const ColorNone Color = ""
+
+// ColorPalette は名前で指定できる色の一覧です（スキーマ公開やバリデーションで使用）
+// 実際の表示色はフロントエンドのテーマに合わせて決められるよう、名前で保存します
+var ColorPalette = []Color{"red", "orange", "yellow", "green", "teal", "blue", "purple", "pink", "gray"}
+
+// ColorHexPattern は16進カラーコードの形式です（スキーマ公開用）
+const ColorHexPattern = "^#[0-9a-f]{6}$"
+
+// NormalizeColor は大文字小文字の違いと前後の空白を揃えます
+// "#FFAA00" と "#ffaa00"、"Blue" と "blue" を同じ色として扱い、フィルタで一致させるためです
+func NormalizeColor(value string) Color {
+	return Color(strings.ToLower(strings.TrimSpace(value)))
+}
+
+// IsValid は色なし、パレットの色、または "#rrggbb" 形式かどうかを判定します
+// 正規化（NormalizeColor）後の値を渡してください
+func (c Color) IsValid() bool {
+	if c == ColorNone {
+		return true
+	}
+	for _, candidate := range ColorPalette {
+		if c == candidate {
+			return true
+		}
+	}
+	return c.isHex()
+}
+
+// isHex は "#rrggbb" 形式（小文字）かどうかを判定します
+func (c Color) isHex() bool {
+	if len(c) != 7 || c[0] != '#' {
+		return false
+	}
+	for _, r := range c[1:] {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "testing"
+
+// TestColor_IsValid は色の正規化とバリデーションをテストします
+func TestColor_IsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		normalized Color
+		expected   bool
+	}{
+		{name: "色なし", input: "", normalized: ColorNone, expected: true},
+		{name: "パレットの色", input: "blue", normalized: "blue", expected: true},
+		{name: "パレットの色（大文字・空白）", input: " Blue ", normalized: "blue", expected: true},
+		{name: "16進カラーコード", input: "#1E90FF", normalized: "#1e90ff", expected: true},
+		{name: "パレットにない名前", input: "navy", normalized: "navy", expected: false},
+		{name: "3桁の16進カラーコード", input: "#fff", normalized: "#fff", expected: false},
+		{name: "16進数以外の文字", input: "#12345g", normalized: "#12345g", expected: false},
+		{name: "#なし", input: "1e90ff", normalized: "1e90ff", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			color := NormalizeColor(tt.input)
+			if color != tt.normalized {
+				t.Errorf("NormalizeColor(%q) = %q, 期待値 = %q", tt.input, color, tt.normalized)
+			}
+			if got := color.IsValid(); got != tt.expected {
+				t.Errorf("IsValid() = %v, 期待値 = %v", got, tt.expected)
+			}
+
+			todo := Todo{Title: "色分け", Color: color}
+			if got := todo.IsValid(); got != tt.expected {
+				t.Errorf("Todo.IsValid() = %v, 期待値 = %v", got, tt.expected)
+			}
+		})
+	}
+}
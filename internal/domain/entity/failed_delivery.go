@@ -0,0 +1,79 @@
+package entity
+
+import "time"
+
+// DeliveryKind は外部への送信（副作用）の種類です
+// 種類ごとに再送処理（DeliveryHandler）が登録されます
+type DeliveryKind string
+
+// 対応している送信の種類です
+const (
+	// DeliveryKindReminder はリマインダーの通知です（ペイロードはTodoのスナップショット）
+	DeliveryKindReminder DeliveryKind = "reminder"
+
+	// DeliveryKindWebhook はWebhookのイベント通知です（ペイロードは登録のIDと送信する本文）
+	DeliveryKindWebhook DeliveryKind = "webhook"
+)
+
+// DeliveryStatus は失敗した送信の状態です
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusRetrying は次回の再送予定時刻を待っている状態です
+	DeliveryStatusRetrying DeliveryStatus = "retrying"
+
+	// DeliveryStatusDead は再送の上限に達し、デッドレター（手動での対応待ち）になった状態です
+	DeliveryStatusDead DeliveryStatus = "dead"
+)
+
+// FailedDelivery は送信に失敗したWebhook・メール・プッシュ通知などの副作用です
+// 指数バックオフで再送し、上限回数を超えたものはデッドレターとして管理画面から確認・再投入します
+// 送信に成功したものは削除されるため、このテーブルには失敗中・失敗済みのものだけが残ります
+type FailedDelivery struct {
+	ID     int          `json:"id"`
+	Kind   DeliveryKind `json:"kind"`
+	TodoID int          `json:"todo_id"`
+
+	// Payload は再送に必要な内容（JSON）です。形式は Kind ごとに異なります
+	Payload []byte `json:"payload"`
+
+	// Attempts はこれまでに失敗した回数です（最初の送信を含みます）
+	Attempts int `json:"attempts"`
+
+	// LastError は最後に失敗したときのエラーメッセージです
+	LastError string `json:"last_error"`
+
+	Status DeliveryStatus `json:"status"`
+
+	// NextAttemptAt は次回の再送予定時刻です（デッドレターの場合は意味を持ちません）
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RecordFailure は送信の失敗を記録します
+// 失敗回数が maxAttempts に達した場合はデッドレターに移し、それ以外は nextAttemptAt に再送を予定します
+func (d *FailedDelivery) RecordFailure(cause string, maxAttempts int, nextAttemptAt time.Time) {
+	d.Attempts++
+	d.LastError = cause
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryStatusDead
+		return
+	}
+	d.Status = DeliveryStatusRetrying
+	d.NextAttemptAt = nextAttemptAt.UTC()
+}
+
+// Requeue はデッドレターを再送待ちに戻します
+// 失敗回数はリセットされ、now 以降の最初のスキャンで再送されます
+func (d *FailedDelivery) Requeue(now time.Time) {
+	d.Attempts = 0
+	d.Status = DeliveryStatusRetrying
+	d.NextAttemptAt = now.UTC()
+}
+
+// IsDead はデッドレターかどうかを判定します
+func (d *FailedDelivery) IsDead() bool {
+	return d.Status == DeliveryStatusDead
+}
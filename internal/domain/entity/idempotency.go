@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord は Idempotency-Key ヘッダーを付けたリクエストの記録です
+// 同じキーで再送されたリクエストは処理を繰り返さず、保存したレスポンスをそのまま返します
+//
+// 記録はまず処理中（StatusCode = 0）として保存し、処理が終わるとレスポンスを保存します
+// 処理中の記録があるキーへの同時リクエストは、二重に処理しないよう拒否します
+type IdempotencyRecord struct {
+	// Actor はリクエストの操作者です。キーは操作者ごとに区別します（他のクライアントとキーが重なっても影響しない）
+	Actor string `json:"actor"`
+
+	// Key は Idempotency-Key ヘッダーの値です
+	Key string `json:"key"`
+
+	// Fingerprint はリクエスト（メソッド・パス・ボディ）のハッシュです
+	// 同じキーで内容の異なるリクエストが送られた場合（キーの使い回し）の検出に使います
+	Fingerprint string `json:"fingerprint"`
+
+	// StatusCode は保存したレスポンスのステータスコードです（処理中の場合は 0）
+	StatusCode int `json:"status_code"`
+
+	// Header は保存したレスポンスのヘッダーのうち、再送時にも返すもの（Content-Type 等）です
+	Header map[string]string `json:"header,omitempty"`
+
+	// Body は保存したレスポンスのボディです
+	Body []byte `json:"body,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt を過ぎた記録は削除され、同じキーで新しいリクエストとして処理されます
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Completed はレスポンスを保存済み（処理が終わっている）かどうかを返します
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// OutboxMessage はデータの変更と同じトランザクションで保存された、発行待ちのドメインイベントです
+// リレー（OutboxRelay）が作成順に読み出して発行し、発行に成功したものは削除します
+type OutboxMessage struct {
+	ID int `json:"id"`
+
+	// EventName はイベントの種類の名前です（例: todo.completed）
+	EventName string `json:"event_name"`
+
+	// Payload はイベントの内容（JSON）です。形式はイベントの種類ごとに異なります
+	Payload []byte `json:"payload"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TodoSortOrder はTodo一覧の並び順です（"-created_at" のように、先頭の "-" は降順を表します）
+type TodoSortOrder string
+
+// Todo一覧の並び順です
+const (
+	// TodoSortNewest は作成日時の新しい順です（既定の並び順）
+	TodoSortNewest TodoSortOrder = "-created_at"
+
+	// TodoSortOldest は作成日時の古い順です
+	TodoSortOldest TodoSortOrder = "created_at"
+
+	// TodoSortDueDate は期限の近い順です（期限のないTodoは最後）
+	TodoSortDueDate TodoSortOrder = "due_date"
+
+	// TodoSortTitle はタイトルの順です（大文字・小文字を区別しない）
+	TodoSortTitle TodoSortOrder = "title"
+)
+
+// TodoSortOrders は指定できる並び順の一覧です
+var TodoSortOrders = []TodoSortOrder{TodoSortNewest, TodoSortOldest, TodoSortDueDate, TodoSortTitle}
+
+// IsValid は並び順が定義されたものかを判定します
+func (o TodoSortOrder) IsValid() bool {
+	return slices.Contains(TodoSortOrders, o)
+}
+
+// Sort はTodoを並び順に並べ替えます（同じ順位のTodoは元の順序を保つ）
+func (o TodoSortOrder) Sort(todos []*Todo) {
+	switch o {
+	case TodoSortNewest:
+		slices.SortStableFunc(todos, func(a, b *Todo) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	case TodoSortOldest:
+		slices.SortStableFunc(todos, func(a, b *Todo) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	case TodoSortDueDate:
+		slices.SortStableFunc(todos, func(a, b *Todo) int {
+			switch {
+			case a.DueDate == nil && b.DueDate == nil:
+				return 0
+			case a.DueDate == nil:
+				return 1
+			case b.DueDate == nil:
+				return -1
+			}
+			return a.DueDate.Compare(*b.DueDate)
+		})
+	case TodoSortTitle:
+		slices.SortStableFunc(todos, func(a, b *Todo) int { return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) })
+	}
+}
+
+// Preferences はユーザーごとの表示と通知の設定です
+// 保存していないユーザーには DefaultPreferences の値を使います
+type Preferences struct {
+	// UserID は設定を持つユーザーのIDです
+	UserID int `json:"user_id"`
+
+	// Timezone は「今日」の区切りに使うIANAタイムゾーン名です（例: Asia/Tokyo）
+	Timezone string `json:"timezone"`
+
+	// SortOrder はTodo一覧の既定の並び順です
+	SortOrder TodoSortOrder `json:"sort_order"`
+
+	// NotifyReminders が false の場合、リマインダーの時刻になっても通知しません
+	NotifyReminders bool `json:"notify_reminders"`
+
+	// UpdatedAt は最後に保存した日時です（保存していない場合はゼロ値）
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultPreferences は設定を保存していないユーザーの設定を返します（UTC・作成日時の新しい順・リマインダーを通知）
+func DefaultPreferences(userID int) *Preferences {
+	return &Preferences{
+		UserID:          userID,
+		Timezone:        "UTC",
+		SortOrder:       TodoSortNewest,
+		NotifyReminders: true,
+	}
+}
+
+// Location は Timezone のタイムゾーンを返します（読み込めない場合はUTC）
+func (p *Preferences) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil || p.Timezone == "Local" {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTodoSortOrder_Sort は並び順ごとのTodoの並べ替えをテストします
+func TestTodoSortOrder_Sort(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	due := base.Add(24 * time.Hour)
+	earlier := base.Add(time.Hour)
+	newTodos := func() []*Todo {
+		return []*Todo{
+			{ID: 1, Title: "banana", CreatedAt: base},
+			{ID: 2, Title: "Apple", CreatedAt: base.Add(2 * time.Minute), DueDate: &due},
+			{ID: 3, Title: "cherry", CreatedAt: base.Add(time.Minute), DueDate: &earlier},
+		}
+	}
+
+	tests := []struct {
+		order    TodoSortOrder
+		expected []int
+	}{
+		{order: TodoSortNewest, expected: []int{2, 3, 1}},
+		{order: TodoSortOldest, expected: []int{1, 3, 2}},
+		{order: TodoSortDueDate, expected: []int{3, 2, 1}},
+		{order: TodoSortTitle, expected: []int{2, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			todos := newTodos()
+			tt.order.Sort(todos)
+			for i, todo := range todos {
+				if todo.ID != tt.expected[i] {
+					t.Fatalf("並び順 = %v, 期待値 = %v", todoIDs(todos), tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// TestPreferences_Location はタイムゾーン名の読み込みと、読み込めない場合のUTCへの置き換えをテストします
+func TestPreferences_Location(t *testing.T) {
+	tests := []struct {
+		timezone string
+		expected string
+	}{
+		{timezone: "Asia/Tokyo", expected: "Asia/Tokyo"},
+		{timezone: "Mars/Olympus", expected: "UTC"},
+		{timezone: "Local", expected: "UTC"},
+	}
+
+	for _, tt := range tests {
+		if got := (&Preferences{Timezone: tt.timezone}).Location().String(); got != tt.expected {
+			t.Errorf("Location(%q) = %q, 期待値 = %q", tt.timezone, got, tt.expected)
+		}
+	}
+}
+
+// todoIDs はTodoのIDの一覧を返します（失敗時のメッセージ用）
+func todoIDs(todos []*Todo) []int {
+	ids := make([]int, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	return ids
+}
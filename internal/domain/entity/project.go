@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Project はTodoをまとめるプロジェクトを表すエンティティです
+// 共有リンクや分かりやすいURLのために、IDとは別に一意なスラッグを持ちます
+type Project struct {
+	// ID はプロジェクトの一意識別子です
+	ID int `json:"id"`
+
+	// Name はプロジェクト名です（日本語も可）
+	Name string `json:"name"`
+
+	// Slug はURLで使用する一意な識別子です（例: "weekly-review"）
+	// 作成時に Name から自動生成され、以後は変更されません
+	Slug string `json:"slug"`
+
+	// Description はプロジェクトの説明です
+	Description string `json:"description"`
+
+	// CreatedAt は作成日時です
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt は更新日時です
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// ArchivedAt はアーカイブした日時です（アーカイブされていない場合は nil）
+	// アーカイブしたプロジェクトのTodoは既定の一覧・検索に表示されませんが、削除はされず復元できます
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	// UserID は作成したユーザーのIDです（認証が無効な状態で作成した場合は nil）
+	UserID *int `json:"-"`
+
+	// WorkspaceID は所属するワークスペースのIDです（個人のプロジェクトの場合は nil）
+	WorkspaceID *int `json:"-"`
+}
+
+// IsArchived はプロジェクトがアーカイブされているかを判定します
+func (p *Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// プロジェクトのフィールド制約です
+const (
+	// MaxProjectNameLength はプロジェクト名の最大文字数です
+	MaxProjectNameLength = 100
+
+	// MaxSlugLength はスラッグの最大文字数です（衝突回避用の接尾辞を含む）
+	MaxSlugLength = 60
+
+	// DefaultProjectSlug は名前からスラッグを生成できない場合（記号や日本語のみ等）の基底値です
+	DefaultProjectSlug = "project"
+)
+
+// IsValid はプロジェクトのビジネスルールを検証します
+func (p *Project) IsValid() bool {
+	name := strings.TrimSpace(p.Name)
+	return len(name) > 0 && len(p.Name) <= MaxProjectNameLength
+}
+
+// GenerateSlug は名前からURLセーフなスラッグを生成します
+//
+// 生成ルール：
+// 1. 英字は小文字に変換し、英数字のみを残す
+// 2. それ以外の文字の連続は1つのハイフンにまとめる
+// 3. 先頭・末尾のハイフンは除去する
+// 4. 英数字が1文字も残らない場合は DefaultProjectSlug を使用する
+// 5. 数字のみの場合は DefaultProjectSlug を前置する（IDとの区別のため）
+func GenerateSlug(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	slug := b.String()
+	if slug == "" {
+		return DefaultProjectSlug
+	}
+	if strings.Trim(slug, "0123456789-") == "" {
+		// "2024" のような数字のみのスラッグはURL上でIDと区別できないため前置詞を付ける
+		return DefaultProjectSlug + "-" + slug
+	}
+	return slug
+}
+
+// SlugCandidate は衝突回避のための n 番目のスラッグ候補を返します
+// n が1以下の場合は基底のスラッグをそのまま返し、2以上では "-n" を付与します
+// 接尾辞を付けても MaxSlugLength を超えないよう基底部分を切り詰めます
+func SlugCandidate(base string, n int) string {
+	suffix := ""
+	if n > 1 {
+		suffix = "-" + strconv.Itoa(n)
+	}
+
+	if len(base)+len(suffix) > MaxSlugLength {
+		base = strings.TrimRight(base[:MaxSlugLength-len(suffix)], "-")
+	}
+	return base + suffix
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateSlug はスラッグ生成ルールをテストします
+func TestGenerateSlug(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{name: "英単語", input: "Weekly Review", expect: "weekly-review"},
+		{name: "記号の連続", input: "  Q3 -- Planning!! ", expect: "q3-planning"},
+		{name: "数字を含む", input: "Release 2.0", expect: "release-2-0"},
+		{name: "日本語と英字の混在", input: "家事 Home Tasks", expect: "home-tasks"},
+		{name: "日本語のみ", input: "買い物リスト", expect: DefaultProjectSlug},
+		{name: "数字のみ", input: "2024", expect: DefaultProjectSlug + "-2024"},
+		{name: "空文字", input: "", expect: DefaultProjectSlug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateSlug(tt.input); got != tt.expect {
+				t.Errorf("GenerateSlug(%q) = %q, 期待値 = %q", tt.input, got, tt.expect)
+			}
+		})
+	}
+}
+
+// TestSlugCandidate は衝突回避用のスラッグ候補生成をテストします
+func TestSlugCandidate(t *testing.T) {
+	if got := SlugCandidate("home", 1); got != "home" {
+		t.Errorf("1番目の候補 = %q, 期待値 = %q", got, "home")
+	}
+	if got := SlugCandidate("home", 3); got != "home-3" {
+		t.Errorf("3番目の候補 = %q, 期待値 = %q", got, "home-3")
+	}
+
+	// 最大長を超える場合は基底部分が切り詰められる
+	long := strings.Repeat("a", MaxSlugLength)
+	got := SlugCandidate(long, 12)
+	if len(got) != MaxSlugLength || !strings.HasSuffix(got, "-12") {
+		t.Errorf("切り詰め結果が正しくありません: %q (長さ %d)", got, len(got))
+	}
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+// Recurrence は繰り返しTodoの繰り返し規則です
+// 空文字は繰り返しなしを表します
+type Recurrence string
+
+// 対応している繰り返し規則です
+const (
+	RecurrenceNone    Recurrence = ""
+	RecurrenceDaily   Recurrence = "daily"
+	RecurrenceWeekly  Recurrence = "weekly"
+	RecurrenceMonthly Recurrence = "monthly"
+)
+
+// Recurrences は指定可能な繰り返し規則の一覧です（スキーマ公開やバリデーションで使用）
+var Recurrences = []Recurrence{RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly}
+
+// IsValid は対応している繰り返し規則（または繰り返しなし）かどうかを判定します
+func (r Recurrence) IsValid() bool {
+	if r == RecurrenceNone {
+		return true
+	}
+	for _, candidate := range Recurrences {
+		if r == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// Next は t の次の発生日時を返します
+// 毎月の場合、月末を超える日付は月の最終日に丸めます（例: 1/31 -> 2/28 -> 3/31 ではなく 3/28）
+// 繰り返しなしの場合は t をそのまま返します
+func (r Recurrence) Next(t time.Time) time.Time {
+	switch r {
+	case RecurrenceDaily:
+		return t.AddDate(0, 0, 1)
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7)
+	case RecurrenceMonthly:
+		next := t.AddDate(0, 1, 0)
+		// AddDate は 1/31 + 1ヶ月 を 3/3 のように正規化するため、翌月の末日に戻す
+		if next.Day() != t.Day() {
+			next = next.AddDate(0, 0, -next.Day())
+		}
+		return next
+	default:
+		return t
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRecurrence_Next は繰り返し規則ごとの次回発生日時の計算をテストします
+func TestRecurrence_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence Recurrence
+		from       time.Time
+		expected   time.Time
+	}{
+		{name: "毎日", recurrence: RecurrenceDaily, from: date(2024, 1, 31), expected: date(2024, 2, 1)},
+		{name: "毎週", recurrence: RecurrenceWeekly, from: date(2024, 12, 28), expected: date(2025, 1, 4)},
+		{name: "毎月", recurrence: RecurrenceMonthly, from: date(2024, 1, 15), expected: date(2024, 2, 15)},
+		{name: "毎月（月末の丸め）", recurrence: RecurrenceMonthly, from: date(2024, 1, 31), expected: date(2024, 2, 29)},
+		{name: "毎月（年跨ぎ）", recurrence: RecurrenceMonthly, from: date(2024, 12, 31), expected: date(2025, 1, 31)},
+		{name: "繰り返しなし", recurrence: RecurrenceNone, from: date(2024, 1, 1), expected: date(2024, 1, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.recurrence.Next(tt.from); !result.Equal(tt.expected) {
+				t.Errorf("Next() = %v, 期待値 = %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTodo_IsValid_Recurrence は繰り返し規則に関するバリデーションをテストします
+func TestTodo_IsValid_Recurrence(t *testing.T) {
+	due := date(2024, 1, 1)
+
+	tests := []struct {
+		name     string
+		todo     Todo
+		expected bool
+	}{
+		{name: "繰り返しなし", todo: Todo{Title: "タスク"}, expected: true},
+		{name: "期限付きの繰り返し", todo: Todo{Title: "タスク", Recurrence: RecurrenceWeekly, DueDate: &due}, expected: true},
+		{name: "期限なしの繰り返し", todo: Todo{Title: "タスク", Recurrence: RecurrenceDaily}, expected: false},
+		{name: "未知の繰り返し規則", todo: Todo{Title: "タスク", Recurrence: "hourly", DueDate: &due}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.todo.IsValid(); result != tt.expected {
+				t.Errorf("IsValid() = %v, 期待値 = %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// date はUTCの日付を作成するテスト用ヘルパーです
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// RefreshToken はアクセストークンを再発行するためのリフレッシュトークンの記録です
+//
+// トークン自体はクライアントだけが持ち、サーバーはSHA-256のハッシュ（TokenHash）だけを保存します
+// （データベースが漏洩しても、保存された値からトークンを使うことはできない）
+//
+// リフレッシュトークンは一度しか使えません（ローテーション）
+// 使うたびに同じ系列（FamilyID）の新しいトークンを発行し、使ったトークンには UsedAt を記録します
+// 使用済みのトークンが再び使われた場合は盗まれたものとみなし、系列の全てのトークンを失効させます
+type RefreshToken struct {
+	// ID は記録の一意識別子です
+	ID int `json:"id"`
+
+	// UserID はトークンを発行したユーザーのIDです
+	UserID int `json:"user_id"`
+
+	// FamilyID はログイン1回ごとの系列の識別子です（ローテーションで発行したトークンは同じ系列になる）
+	FamilyID string `json:"family_id"`
+
+	// TokenHash はトークンのSHA-256のハッシュ（16進数）です
+	TokenHash string `json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt を過ぎたトークンは使えません
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// UsedAt はトークンを使って新しいトークンを発行した日時です（未使用の場合は nil）
+	UsedAt *time.Time `json:"used_at,omitempty"`
+
+	// RevokedAt は系列ごと失効させた日時です（ログアウト・再利用の検出。失効していない場合は nil）
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Expired は now の時点でトークンの有効期限を過ぎているかを返します
+func (t *RefreshToken) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
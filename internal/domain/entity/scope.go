@@ -0,0 +1,21 @@
+package entity
+
+import "slices"
+
+// スコープはアクセストークンで許可する操作の範囲です
+// ログインで発行したトークンとCookieのセッションはスコープを限定せず、全ての操作を許可します
+const (
+	// ScopeTodosRead はTodoの取得（GET）を許可するスコープです
+	ScopeTodosRead = "todos:read"
+
+	// ScopeTodosWrite はTodoの作成・更新・削除を許可するスコープです（取得には ScopeTodosRead も必要）
+	ScopeTodosWrite = "todos:write"
+)
+
+// Scopes はアクセストークンに付けられるスコープの一覧です
+var Scopes = []string{ScopeTodosRead, ScopeTodosWrite}
+
+// ValidScope はスコープが定義されたもの（Scopes のいずれか）かを判定します
+func ValidScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Session はCookieによるログインのセッションです（アクセストークンの代わりに使える認証方式）
+//
+// セッションIDはクライアントのCookieだけが持ち、サーバーはSHA-256のハッシュ（TokenHash）だけを保存します
+// アクセストークンと違い、サーバー側で削除すれば有効期限の前でもすぐに使えなくなります（ログアウト）
+type Session struct {
+	// TokenHash はセッションIDのSHA-256のハッシュ（16進数）です
+	TokenHash string `json:"-"`
+
+	// UserID / Username はログインしたユーザーです
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt を過ぎたセッションは使えません
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired は now の時点でセッションの有効期限を過ぎているかを返します
+func (s *Session) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
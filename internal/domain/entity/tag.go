@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// タグのフィールド制約です
+const (
+	// MaxTagLength はタグの最大文字数です
+	MaxTagLength = 30
+
+	// MaxTagsPerTodo は1つのTodoに付けられるタグの最大数です
+	MaxTagsPerTodo = 20
+)
+
+// NormalizeTag はタグを比較・保存用の形式に正規化します
+// 前後の空白を除去し、英字を小文字に揃えます（"Work" と "work" は同じタグ）
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// IsValidTag は正規化済みのタグが制約を満たすかを判定します
+// 保存形式（カンマ区切り）とURLのパスセグメントに使用するため、
+// 空白・制御文字・カンマ・スラッシュは使用できません
+func IsValidTag(tag string) bool {
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return false
+	}
+	for _, r := range tag {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == ',' || r == '/' {
+			return false
+		}
+	}
+	return true
+}
+
+// HasTag はTodoに指定したタグが付いているかを返します
+func (t *Todo) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag はタグを末尾に追加します（既に付いている場合は何もせず false を返します）
+//
+// 変更履歴のスナップショットと配列を共有しないよう、Tags は常に新しいスライスに置き換えます
+func (t *Todo) AddTag(tag string) bool {
+	if t.HasTag(tag) {
+		return false
+	}
+	tags := make([]string, 0, len(t.Tags)+1)
+	t.Tags = append(append(tags, t.Tags...), tag)
+	return true
+}
+
+// RemoveTag はタグを取り除きます（付いていない場合は何もせず false を返します）
+func (t *Todo) RemoveTag(tag string) bool {
+	if !t.HasTag(tag) {
+		return false
+	}
+	tags := make([]string, 0, len(t.Tags)-1)
+	for _, existing := range t.Tags {
+		if existing != tag {
+			tags = append(tags, existing)
+		}
+	}
+	t.Tags = tags
+	return true
+}
+
+// ReplaceTag はタグ from を to に置き換えます（from が付いていない場合は false を返します）
+// 既に to も付いている場合は from を取り除くだけにし、同じタグが重複しないようにします
+func (t *Todo) ReplaceTag(from, to string) bool {
+	if !t.HasTag(from) {
+		return false
+	}
+	if t.HasTag(to) {
+		return t.RemoveTag(from)
+	}
+	tags := make([]string, len(t.Tags))
+	for i, existing := range t.Tags {
+		if existing == from {
+			existing = to
+		}
+		tags[i] = existing
+	}
+	t.Tags = tags
+	return true
+}
+
+// validTags はタグの一覧が制約（数・形式・重複なし）を満たすかを判定します
+func validTags(tags []string) bool {
+	if len(tags) > MaxTagsPerTodo {
+		return false
+	}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if !IsValidTag(tag) || tag != NormalizeTag(tag) || seen[tag] {
+			return false
+		}
+		seen[tag] = true
+	}
+	return true
+}
+
+// equalTags は2つのタグの一覧が（順序も含めて）等しいかを判定します
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// copyTags はタグの一覧のコピーを返します（空の場合は nil）
+func copyTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	return append([]string(nil), tags...)
+}
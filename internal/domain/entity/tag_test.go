@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestIsValidTag はタグの正規化とバリデーションをテストします
+func TestIsValidTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		normalized string
+		expected   bool
+	}{
+		{name: "英字", input: "work", normalized: "work", expected: true},
+		{name: "大文字・空白を正規化", input: " Work ", normalized: "work", expected: true},
+		{name: "日本語", input: "買い物", normalized: "買い物", expected: true},
+		{name: "記号", input: "q3-review_2", normalized: "q3-review_2", expected: true},
+		{name: "空", input: "  ", normalized: "", expected: false},
+		{name: "途中の空白", input: "two words", normalized: "two words", expected: false},
+		{name: "カンマ", input: "a,b", normalized: "a,b", expected: false},
+		{name: "スラッシュ", input: "a/b", normalized: "a/b", expected: false},
+		{name: "最大文字数", input: strings.Repeat("あ", MaxTagLength), normalized: strings.Repeat("あ", MaxTagLength), expected: true},
+		{name: "最大文字数超過", input: strings.Repeat("a", MaxTagLength+1), normalized: strings.Repeat("a", MaxTagLength+1), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := NormalizeTag(tt.input)
+			if tag != tt.normalized {
+				t.Errorf("NormalizeTag(%q) = %q, 期待値 = %q", tt.input, tag, tt.normalized)
+			}
+			if got := IsValidTag(tag); got != tt.expected {
+				t.Errorf("IsValidTag(%q) = %v, 期待値 = %v", tag, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTodo_Tags はタグの追加・削除・置き換えと、スナップショットと配列を共有しないことをテストします
+func TestTodo_Tags(t *testing.T) {
+	todo := &Todo{Title: "タグ", Tags: []string{"home", "work"}}
+	snapshot := *todo
+
+	if todo.AddTag("home") {
+		t.Error("AddTag() = true, 既に付いているタグは追加しない")
+	}
+	if !todo.AddTag("urgent") || !reflect.DeepEqual(todo.Tags, []string{"home", "work", "urgent"}) {
+		t.Errorf("AddTag() 後のタグ = %v", todo.Tags)
+	}
+	if !todo.ReplaceTag("home", "family") || !reflect.DeepEqual(todo.Tags, []string{"family", "work", "urgent"}) {
+		t.Errorf("ReplaceTag() 後のタグ = %v", todo.Tags)
+	}
+	if !todo.ReplaceTag("family", "work") || !reflect.DeepEqual(todo.Tags, []string{"work", "urgent"}) {
+		t.Errorf("統合先が付いている場合の ReplaceTag() 後のタグ = %v", todo.Tags)
+	}
+	if todo.RemoveTag("missing") || !todo.RemoveTag("urgent") || !reflect.DeepEqual(todo.Tags, []string{"work"}) {
+		t.Errorf("RemoveTag() 後のタグ = %v", todo.Tags)
+	}
+	if !reflect.DeepEqual(snapshot.Tags, []string{"home", "work"}) {
+		t.Errorf("スナップショットのタグ = %v, 期待値 = 変更なし", snapshot.Tags)
+	}
+
+	if got := DiffTodo(&snapshot, todo); !reflect.DeepEqual(got, []TodoField{TodoFieldTags}) {
+		t.Errorf("DiffTodo() = %v, 期待値 = [tags]", got)
+	}
+	if !todo.IsValid() {
+		t.Error("IsValid() = false, 期待値 = true")
+	}
+	todo.Tags = []string{"work", "work"}
+	if todo.IsValid() {
+		t.Error("重複したタグの IsValid() = true, 期待値 = false")
+	}
+}
@@ -39,15 +39,108 @@ type Todo struct {
 	// UpdatedAt はレコードの更新日時を記録します
 	// 更新時には明示的に現在時刻を設定する必要があります
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// ChecklistProgress はこのTodoに含まれるチェックリストの進捗です
+	// チェックリスト項目はTodo集約の一部のため、取得時に集計して設定されます
+	ChecklistProgress ChecklistProgress `json:"checklist_progress"`
+
+	// RemindAt はリマインダーの通知予定日時です（未設定の場合は nil）
+	// 通知が送信されるとクリアされます
+	RemindAt *time.Time `json:"remind_at,omitempty"`
+
+	// DueDate は期限日時です（未設定の場合は nil）
+	DueDate *time.Time `json:"due_date,omitempty"`
+
+	// Recurrence は繰り返し規則です（繰り返しなしの場合は空文字）
+	// 繰り返し規則と期限を持つTodoは「シリーズ」として扱われ、
+	// バックグラウンドワーカーが今後の発生分（オカレンス）を事前に作成します
+	Recurrence Recurrence `json:"recurrence,omitempty"`
+
+	// RecurrenceParentID はオカレンスの場合に、元になったシリーズのTodoのIDです
+	RecurrenceParentID *int `json:"recurrence_parent_id,omitempty"`
+
+	// Color はカードの色分けに使用する色です（色なしの場合は空文字）
+	Color Color `json:"color,omitempty"`
+
+	// EstimateMinutes は見積もり時間（分）です（未設定の場合は 0）
+	EstimateMinutes int `json:"estimate_minutes,omitempty"`
+
+	// ActualMinutes は実際にかかった時間（分）です（未記録の場合は 0）
+	// 見積もりとの比較は統計（TodoStats）で集計します
+	ActualMinutes int `json:"actual_minutes,omitempty"`
+
+	// Tags はTodoに付けたタグです（正規化済み・付けた順）
+	// タグの追加・削除・統合は一括操作（TodoService.AddTagToTodos 等）で行います
+	Tags []string `json:"tags,omitempty"`
+
+	// ProjectID は所属するプロジェクトのIDです（どのプロジェクトにも属さない場合は nil）
+	// プロジェクトがアーカイブされると、既定の一覧・検索には表示されなくなります
+	ProjectID *int `json:"project_id,omitempty"`
+
+	// UserID は所有するユーザーのIDです（認証が無効な状態で作成された場合は nil）
+	// 認証されたリクエストでは、所有するユーザー本人のTodoだけが表示・変更の対象になります
+	// エクスポートや変更履歴のスナップショットに含めないよう、JSONには出力しません
+	UserID *int `json:"-"`
+
+	// WorkspaceID は所属するワークスペースのIDです（個人のTodoの場合は nil）
+	// ワークスペースのTodoは、作成したユーザーに関わらずワークスペースの全てのメンバーが操作できます
+	WorkspaceID *int `json:"-"`
+
+	// DeletedAt は削除日時です（削除されていない場合は nil）
+	// 削除したTodoは既定の取得・一覧には含まれず、削除済みの一覧（TodoRepository.GetDeleted）でだけ参照できます
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
+// Todoのフィールド制約です
+// バリデーション（エンティティ・ハンドラー）とスキーマ公開エンドポイントの両方が
+// この定数を参照することで、ルールの二重管理を防ぎます
+const (
+	// MaxTitleLength はタイトルの最大文字数です
+	MaxTitleLength = 100
+
+	// MaxDescriptionLength は説明の最大文字数です
+	MaxDescriptionLength = 500
+
+	// MaxEstimateMinutes は見積もり時間・実績時間の上限（分）です（1週間分）
+	// これより大きな作業は分割して登録することを想定しています
+	MaxEstimateMinutes = 7 * 24 * 60
+)
+
 // IsValid はTodoエンティティのビジネスルールを検証するメソッドです
 // ドメイン層でのバリデーションロジックを担当します
 // 戻り値がtrueなら有効、falseなら無効なデータです
 func (t *Todo) IsValid() bool {
 	// タイトルが空文字でないかチェック
 	// strings.TrimSpace() で前後の空白を除去してから長さをチェックしています
-	return len(t.Title) > 0 && len(t.Title) <= 100
+	if len(t.Title) == 0 || len(t.Title) > MaxTitleLength {
+		return false
+	}
+
+	// 繰り返し規則は既知の値で、起点となる期限が必要
+	if !t.Recurrence.IsValid() || (t.Recurrence != RecurrenceNone && t.DueDate == nil) {
+		return false
+	}
+
+	// 色はパレットの名前または16進カラーコード
+	if !t.Color.IsValid() {
+		return false
+	}
+
+	// 見積もり・実績時間は 0（未設定）以上、上限以下
+	if !IsValidMinutes(t.EstimateMinutes) || !IsValidMinutes(t.ActualMinutes) {
+		return false
+	}
+
+	// タグは正規化済みで重複がなく、上限の数以下
+	if !validTags(t.Tags) {
+		return false
+	}
+	return true
+}
+
+// IsValidMinutes は見積もり・実績時間（分）が範囲内かどうかを判定します
+func IsValidMinutes(minutes int) bool {
+	return minutes >= 0 && minutes <= MaxEstimateMinutes
 }
 
 // MarkAsCompleted はタスクを完了状態にするビジネスロジックです
@@ -60,3 +153,73 @@ func (t *Todo) MarkAsCompleted() {
 func (t *Todo) MarkAsIncomplete() {
 	t.IsCompleted = false
 }
+
+// ScheduleReminder は指定日時にリマインダーを設定します
+// 既にリマインダーがある場合は上書きします（スヌーズも同じ操作です）
+func (t *Todo) ScheduleReminder(at time.Time) {
+	remindAt := at.UTC()
+	t.RemindAt = &remindAt
+}
+
+// CancelReminder はリマインダーを解除します
+func (t *Todo) CancelReminder() {
+	t.RemindAt = nil
+}
+
+// IsReminderDue はリマインダーの通知時刻を過ぎているかを判定します
+// 完了済みのTodoには通知しません
+func (t *Todo) IsReminderDue(now time.Time) bool {
+	return t.RemindAt != nil && !t.IsCompleted && !t.RemindAt.After(now)
+}
+
+// IsRecurringSeries は今後の発生分を作成する対象（シリーズ）かどうかを判定します
+func (t *Todo) IsRecurringSeries() bool {
+	return t.Recurrence != RecurrenceNone && t.DueDate != nil
+}
+
+// NewOccurrence はシリーズから指定期限のオカレンスを作成します
+// オカレンス自体は繰り返さず、シリーズのIDを参照します。所有するユーザーはシリーズと同じです
+func (t *Todo) NewOccurrence(dueDate time.Time) *Todo {
+	seriesID := t.ID
+	due := dueDate.UTC()
+	occurrence := &Todo{
+		Title:              t.Title,
+		Description:        t.Description,
+		DueDate:            &due,
+		RecurrenceParentID: &seriesID,
+		Color:              t.Color,
+		EstimateMinutes:    t.EstimateMinutes,
+	}
+	if t.UserID != nil {
+		userID := *t.UserID
+		occurrence.UserID = &userID
+	}
+	if t.WorkspaceID != nil {
+		workspaceID := *t.WorkspaceID
+		occurrence.WorkspaceID = &workspaceID
+	}
+	return occurrence
+}
+
+// Duplicate は複製用の新しいTodoを作成します（未保存のためIDは0です）
+// 内容（タイトル・説明・期限・繰り返し規則・色・見積もり）のみをコピーし、
+// 完了状態・リマインダー・実績時間は引き継がず、オカレンスを複製した場合もシリーズとの関連は外れます
+func (t *Todo) Duplicate() *Todo {
+	duplicate := &Todo{
+		Title:       t.Title,
+		Description: t.Description,
+		Recurrence:  t.Recurrence,
+		Color:       t.Color,
+
+		EstimateMinutes: t.EstimateMinutes,
+	}
+	if t.DueDate != nil {
+		due := *t.DueDate
+		duplicate.DueDate = &due
+	}
+	if t.ProjectID != nil {
+		projectID := *t.ProjectID
+		duplicate.ProjectID = &projectID
+	}
+	return duplicate
+}
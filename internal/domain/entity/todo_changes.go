@@ -0,0 +1,183 @@
+package entity
+
+import "time"
+
+// TodoField は利用者が変更できるTodoのフィールドです
+// 値はJSONのキー名・データベースの列名と同じです
+type TodoField string
+
+// 変更を追跡するフィールドです
+// ID・作成日時・シリーズとの関連（RecurrenceParentID）・チェックリストの進捗は更新で変わらないため含みません
+// UpdatedAt は変更の結果として設定されるため、変更の有無の判定には使用しません
+const (
+	TodoFieldTitle           TodoField = "title"
+	TodoFieldDescription     TodoField = "description"
+	TodoFieldIsCompleted     TodoField = "is_completed"
+	TodoFieldRemindAt        TodoField = "remind_at"
+	TodoFieldDueDate         TodoField = "due_date"
+	TodoFieldRecurrence      TodoField = "recurrence"
+	TodoFieldColor           TodoField = "color"
+	TodoFieldEstimateMinutes TodoField = "estimate_minutes"
+	TodoFieldActualMinutes   TodoField = "actual_minutes"
+	TodoFieldTags            TodoField = "tags"
+	TodoFieldProjectID       TodoField = "project_id"
+)
+
+// todoFieldDef はフィールドごとの比較・コピー・値の取得方法です
+type todoFieldDef struct {
+	field TodoField
+	equal func(a, b *Todo) bool
+	copy  func(dst, src *Todo)
+	value func(t *Todo) any
+}
+
+// todoFieldDefs は変更を追跡する全てのフィールドです（この順序で差分を返します）
+// フィールドを追加した場合はここにも追加します
+var todoFieldDefs = []todoFieldDef{
+	{
+		field: TodoFieldTitle,
+		equal: func(a, b *Todo) bool { return a.Title == b.Title },
+		copy:  func(dst, src *Todo) { dst.Title = src.Title },
+		value: func(t *Todo) any { return t.Title },
+	},
+	{
+		field: TodoFieldDescription,
+		equal: func(a, b *Todo) bool { return a.Description == b.Description },
+		copy:  func(dst, src *Todo) { dst.Description = src.Description },
+		value: func(t *Todo) any { return t.Description },
+	},
+	{
+		field: TodoFieldIsCompleted,
+		equal: func(a, b *Todo) bool { return a.IsCompleted == b.IsCompleted },
+		copy:  func(dst, src *Todo) { dst.IsCompleted = src.IsCompleted },
+		value: func(t *Todo) any { return t.IsCompleted },
+	},
+	{
+		field: TodoFieldRemindAt,
+		equal: func(a, b *Todo) bool { return equalTime(a.RemindAt, b.RemindAt) },
+		copy:  func(dst, src *Todo) { dst.RemindAt = copyTime(src.RemindAt) },
+		value: func(t *Todo) any { return t.RemindAt },
+	},
+	{
+		field: TodoFieldDueDate,
+		equal: func(a, b *Todo) bool { return equalTime(a.DueDate, b.DueDate) },
+		copy:  func(dst, src *Todo) { dst.DueDate = copyTime(src.DueDate) },
+		value: func(t *Todo) any { return t.DueDate },
+	},
+	{
+		field: TodoFieldRecurrence,
+		equal: func(a, b *Todo) bool { return a.Recurrence == b.Recurrence },
+		copy:  func(dst, src *Todo) { dst.Recurrence = src.Recurrence },
+		value: func(t *Todo) any { return t.Recurrence },
+	},
+	{
+		field: TodoFieldColor,
+		equal: func(a, b *Todo) bool { return a.Color == b.Color },
+		copy:  func(dst, src *Todo) { dst.Color = src.Color },
+		value: func(t *Todo) any { return t.Color },
+	},
+	{
+		field: TodoFieldEstimateMinutes,
+		equal: func(a, b *Todo) bool { return a.EstimateMinutes == b.EstimateMinutes },
+		copy:  func(dst, src *Todo) { dst.EstimateMinutes = src.EstimateMinutes },
+		value: func(t *Todo) any { return t.EstimateMinutes },
+	},
+	{
+		field: TodoFieldActualMinutes,
+		equal: func(a, b *Todo) bool { return a.ActualMinutes == b.ActualMinutes },
+		copy:  func(dst, src *Todo) { dst.ActualMinutes = src.ActualMinutes },
+		value: func(t *Todo) any { return t.ActualMinutes },
+	},
+	{
+		field: TodoFieldTags,
+		equal: func(a, b *Todo) bool { return equalTags(a.Tags, b.Tags) },
+		copy:  func(dst, src *Todo) { dst.Tags = copyTags(src.Tags) },
+		value: func(t *Todo) any { return t.Tags },
+	},
+	{
+		field: TodoFieldProjectID,
+		equal: func(a, b *Todo) bool { return equalInt(a.ProjectID, b.ProjectID) },
+		copy:  func(dst, src *Todo) { dst.ProjectID = copyInt(src.ProjectID) },
+		value: func(t *Todo) any { return t.ProjectID },
+	},
+}
+
+// DiffTodo は before と after で値が異なるフィールドを返します（変更がない場合は空）
+// 日時は同じ時刻であればタイムゾーンの表現が異なっても等しいとみなします
+//
+// 部分更新では、差分のフィールドだけを保存することで、変更のない列の書き込みや
+// 変更がない場合の更新日時の更新を避けられます
+func DiffTodo(before, after *Todo) []TodoField {
+	var changed []TodoField
+	for _, def := range todoFieldDefs {
+		if !def.equal(before, after) {
+			changed = append(changed, def.field)
+		}
+	}
+	return changed
+}
+
+// CopyFields は src の指定したフィールドの値を t にコピーします
+// 変更を追跡しないフィールドは無視します
+func (t *Todo) CopyFields(src *Todo, fields []TodoField) {
+	for _, field := range fields {
+		if def, ok := lookupTodoField(field); ok {
+			def.copy(t, src)
+		}
+	}
+}
+
+// FieldValue は指定したフィールドの値を返します
+// 戻り値の型はフィールドと同じです（例: RemindAt は *time.Time、Color は Color）
+// 変更を追跡しないフィールドの場合、ok は false です
+func (t *Todo) FieldValue(field TodoField) (value any, ok bool) {
+	def, ok := lookupTodoField(field)
+	if !ok {
+		return nil, false
+	}
+	return def.value(t), true
+}
+
+// lookupTodoField はフィールドの定義を探します
+func lookupTodoField(field TodoField) (todoFieldDef, bool) {
+	for _, def := range todoFieldDefs {
+		if def.field == field {
+			return def, true
+		}
+	}
+	return todoFieldDef{}, false
+}
+
+// equalTime は未設定（nil）を含めて2つの日時が等しいかを判定します
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// equalInt は2つの整数が等しいかを判定します（どちらも nil の場合も等しい）
+func equalInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// copyInt は整数のコピーを返します（nil の場合は nil）
+func copyInt(n *int) *int {
+	if n == nil {
+		return nil
+	}
+	copied := *n
+	return &copied
+}
+
+// copyTime は日時のコピーを返します（nil の場合は nil）
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
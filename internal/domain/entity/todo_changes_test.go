@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestDiffTodo は変更されたフィールドの判定をテストします
+func TestDiffTodo(t *testing.T) {
+	due := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	sameDueInJST := due.In(time.FixedZone("JST", 9*60*60))
+	later := due.Add(time.Hour)
+
+	base := func() *Todo {
+		d := due
+		return &Todo{ID: 1, Title: "買い物", Description: "牛乳", DueDate: &d, Color: "#1e90ff", EstimateMinutes: 30}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(todo *Todo)
+		want   []TodoField
+	}{
+		{name: "変更なし", modify: func(todo *Todo) {}, want: nil},
+		{name: "更新日時だけの違いは変更ではない", modify: func(todo *Todo) { todo.UpdatedAt = later }, want: nil},
+		{name: "同じ時刻の別のタイムゾーン表現", modify: func(todo *Todo) { todo.DueDate = &sameDueInJST }, want: nil},
+		{name: "タイトルと完了状態", modify: func(todo *Todo) { todo.Title = "掃除"; todo.IsCompleted = true }, want: []TodoField{TodoFieldTitle, TodoFieldIsCompleted}},
+		{name: "期限の変更", modify: func(todo *Todo) { todo.DueDate = &later }, want: []TodoField{TodoFieldDueDate}},
+		{name: "期限の解除とリマインダーの設定", modify: func(todo *Todo) { todo.DueDate = nil; todo.RemindAt = &later }, want: []TodoField{TodoFieldRemindAt, TodoFieldDueDate}},
+		{name: "色と見積もりの解除", modify: func(todo *Todo) { todo.Color = ""; todo.EstimateMinutes = 0 }, want: []TodoField{TodoFieldColor, TodoFieldEstimateMinutes}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := base()
+			tt.modify(after)
+			if got := DiffTodo(base(), after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffTodo() = %v, 期待値 = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTodo_CopyFields は指定したフィールドだけがコピーされることをテストします
+func TestTodo_CopyFields(t *testing.T) {
+	due := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	dst := &Todo{ID: 1, Title: "買い物", Description: "牛乳", EstimateMinutes: 30}
+	src := &Todo{ID: 1, Title: "掃除", Description: "別の説明", DueDate: &due, EstimateMinutes: 60}
+
+	dst.CopyFields(src, []TodoField{TodoFieldTitle, TodoFieldDueDate, "unknown"})
+
+	if dst.Title != "掃除" || dst.DueDate == nil || !dst.DueDate.Equal(due) {
+		t.Errorf("指定したフィールドがコピーされていません: %+v", dst)
+	}
+	if dst.Description != "牛乳" || dst.EstimateMinutes != 30 {
+		t.Errorf("指定していないフィールドが変更されました: %+v", dst)
+	}
+	if dst.DueDate == src.DueDate {
+		t.Error("日時はポインタを共有せずにコピーする必要があります")
+	}
+
+	if value, ok := src.FieldValue(TodoFieldEstimateMinutes); !ok || value != 60 {
+		t.Errorf("FieldValue() = %v, %v, 期待値 = 60, true", value, ok)
+	}
+	if _, ok := src.FieldValue("unknown"); ok {
+		t.Error("追跡しないフィールドの FieldValue() は ok = false であるべきです")
+	}
+}
@@ -0,0 +1,78 @@
+package entity
+
+import "time"
+
+// TodoHistoryAction はTodoに対して行われた操作の種類です
+type TodoHistoryAction string
+
+// 履歴に記録する操作です
+const (
+	TodoHistoryCreated     TodoHistoryAction = "create"
+	TodoHistoryUpdated     TodoHistoryAction = "update"
+	TodoHistoryDeleted     TodoHistoryAction = "delete"
+	TodoHistoryCompleted   TodoHistoryAction = "complete"
+	TodoHistoryIncompleted TodoHistoryAction = "incomplete"
+	TodoHistoryRestored    TodoHistoryAction = "restore"
+)
+
+// AnonymousActor は操作者を特定できない場合に記録する操作者名です
+const AnonymousActor = "anonymous"
+
+// TodoHistoryEntry はTodoの変更履歴1件を表すエンティティです
+// 変更前後のTodoの状態をスナップショットとして丸ごと保持するため、
+// 後からフィールドが追加されても過去の履歴の形式を変える必要がありません
+//
+// 履歴はTodoが削除された後も残ります（削除の記録自体が履歴の一部のため）
+type TodoHistoryEntry struct {
+	// ID は履歴の一意識別子です（記録順に増加）
+	ID int `json:"id"`
+
+	// TodoID は変更されたTodoのIDです
+	TodoID int `json:"todo_id"`
+
+	// Action は操作の種類です
+	Action TodoHistoryAction `json:"action"`
+
+	// Actor は操作者です（特定できない場合は AnonymousActor）
+	Actor string `json:"actor"`
+
+	// Before は変更前のTodoです（作成の場合は nil）
+	Before *Todo `json:"before,omitempty"`
+
+	// After は変更後のTodoです（削除の場合は nil）
+	After *Todo `json:"after,omitempty"`
+
+	// ChangedAt は変更日時です
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// NewTodoHistoryEntry は変更前後のTodoから履歴を作成します
+// スナップショットは呼び出し元が後から変更しても影響を受けないようコピーして保持します
+func NewTodoHistoryEntry(action TodoHistoryAction, actor string, before, after *Todo) *TodoHistoryEntry {
+	if actor == "" {
+		actor = AnonymousActor
+	}
+
+	entry := &TodoHistoryEntry{
+		Action: action,
+		Actor:  actor,
+		Before: snapshotTodo(before),
+		After:  snapshotTodo(after),
+	}
+	switch {
+	case after != nil:
+		entry.TodoID = after.ID
+	case before != nil:
+		entry.TodoID = before.ID
+	}
+	return entry
+}
+
+// snapshotTodo はTodoのコピーを返します（nil の場合は nil）
+func snapshotTodo(todo *Todo) *Todo {
+	if todo == nil {
+		return nil
+	}
+	snapshot := *todo
+	return &snapshot
+}
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// SharePermission は共有したTodoに対して、共有先のユーザーに許可する操作です
+type SharePermission string
+
+// 対応している共有の権限です
+const (
+	// SharePermissionRead は参照（取得・複製）のみを許可します
+	SharePermissionRead SharePermission = "read"
+
+	// SharePermissionWrite は参照に加えて、更新・完了状態の切り替えを許可します
+	// 削除と共有の設定は、権限によらず所有者だけが行えます
+	SharePermissionWrite SharePermission = "write"
+)
+
+// SharePermissions は指定可能な共有の権限の一覧です（スキーマ公開やバリデーションで使用）
+var SharePermissions = []SharePermission{SharePermissionRead, SharePermissionWrite}
+
+// IsValid は対応している共有の権限かどうかを判定します
+func (p SharePermission) IsValid() bool {
+	for _, candidate := range SharePermissions {
+		if p == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows はこの権限で required の操作を行えるかを判定します
+// 書き込みの権限は参照も含みます。required が空の場合（所有者だけの操作）は常に false です
+func (p SharePermission) Allows(required SharePermission) bool {
+	switch required {
+	case SharePermissionRead:
+		return p == SharePermissionRead || p == SharePermissionWrite
+	case SharePermissionWrite:
+		return p == SharePermissionWrite
+	default:
+		return false
+	}
+}
+
+// TodoShare はTodoを所有者以外のユーザーと共有する設定です
+// 1つのTodoとユーザーの組み合わせに対して1件だけ存在し、共有し直すと権限が置き換わります
+type TodoShare struct {
+	// TodoID は共有するTodoのIDです
+	TodoID int `json:"todo_id"`
+
+	// UserID は共有先のユーザーのIDです
+	UserID int `json:"user_id"`
+
+	// Username は共有先のユーザー名です（取得時に users テーブルから設定されます）
+	Username string `json:"username"`
+
+	// Permission は共有先のユーザーに許可する操作です
+	Permission SharePermission `json:"permission"`
+
+	// CreatedAt は最初に共有した日時です（権限を変更しても変わりません）
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,94 @@
+package entity
+
+// TodoStats はTodo全体の集計結果です
+type TodoStats struct {
+	// Total は全Todoの件数です
+	Total int
+
+	// Completed は完了済みTodoの件数です
+	Completed int
+
+	// Pending は未完了Todoの件数です
+	Pending int
+
+	// Estimates は見積もりと実績の比較です
+	Estimates EstimateStats
+}
+
+// EstimateStats は見積もり時間と実績時間の集計です
+//
+// 見積もりの精度は「見積もりと実績の両方が記録された完了済みTodo」だけで比較します
+// どちらかが未記録のTodoを含めると、比率が実態より良く（または悪く）見えてしまうためです
+type EstimateStats struct {
+	// Estimated は見積もりが設定されたTodoの件数です
+	Estimated int
+
+	// RemainingMinutes は未完了Todoの見積もり時間の合計（分）です
+	RemainingMinutes int
+
+	// Compared は見積もりと実績の両方が記録された完了済みTodoの件数です
+	Compared int
+
+	// EstimatedMinutes は比較対象のTodoの見積もり時間の合計（分）です
+	EstimatedMinutes int
+
+	// ActualMinutes は比較対象のTodoの実績時間の合計（分）です
+	ActualMinutes int
+
+	// Overrun は実績が見積もりを超えたTodoの件数です
+	Overrun int
+
+	// Underrun は実績が見積もりより短かったTodoの件数です
+	Underrun int
+
+	// OnTarget は実績が見積もりと一致したTodoの件数です
+	OnTarget int
+}
+
+// AccuracyRatio は実績時間の合計を見積もり時間の合計で割った値です
+// 1.0 より大きければ見積もりが甘く、小さければ見積もりが過大です
+// 比較対象がない場合は ok=false を返します
+func (s EstimateStats) AccuracyRatio() (ratio float64, ok bool) {
+	if s.Compared == 0 || s.EstimatedMinutes == 0 {
+		return 0, false
+	}
+	return float64(s.ActualMinutes) / float64(s.EstimatedMinutes), true
+}
+
+// NewTodoStats はTodoの一覧から集計結果を作成します
+func NewTodoStats(todos []*Todo) TodoStats {
+	var stats TodoStats
+	for _, todo := range todos {
+		stats.Total++
+		if todo.IsCompleted {
+			stats.Completed++
+		} else {
+			stats.Pending++
+		}
+
+		if todo.EstimateMinutes == 0 {
+			continue
+		}
+		stats.Estimates.Estimated++
+		if !todo.IsCompleted {
+			stats.Estimates.RemainingMinutes += todo.EstimateMinutes
+			continue
+		}
+		if todo.ActualMinutes == 0 {
+			continue
+		}
+
+		stats.Estimates.Compared++
+		stats.Estimates.EstimatedMinutes += todo.EstimateMinutes
+		stats.Estimates.ActualMinutes += todo.ActualMinutes
+		switch {
+		case todo.ActualMinutes > todo.EstimateMinutes:
+			stats.Estimates.Overrun++
+		case todo.ActualMinutes < todo.EstimateMinutes:
+			stats.Estimates.Underrun++
+		default:
+			stats.Estimates.OnTarget++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "testing"
+
+// TestNewTodoStats は件数と見積もり・実績の集計をテストします
+func TestNewTodoStats(t *testing.T) {
+	todos := []*Todo{
+		{Title: "見積もりなし"},
+		{Title: "未完了", EstimateMinutes: 30},
+		{Title: "未完了2", EstimateMinutes: 15, ActualMinutes: 5},
+		{Title: "実績なし", IsCompleted: true, EstimateMinutes: 60},
+		{Title: "超過", IsCompleted: true, EstimateMinutes: 60, ActualMinutes: 90},
+		{Title: "短縮", IsCompleted: true, EstimateMinutes: 30, ActualMinutes: 20},
+		{Title: "一致", IsCompleted: true, EstimateMinutes: 10, ActualMinutes: 10},
+		{Title: "見積もりなしで完了", IsCompleted: true, ActualMinutes: 45},
+	}
+
+	stats := NewTodoStats(todos)
+
+	if stats.Total != 8 || stats.Completed != 5 || stats.Pending != 3 {
+		t.Errorf("件数 = %d/%d/%d, 期待値 = 8/5/3", stats.Total, stats.Completed, stats.Pending)
+	}
+
+	expected := EstimateStats{
+		Estimated:        6,
+		RemainingMinutes: 45,
+		Compared:         3,
+		EstimatedMinutes: 100,
+		ActualMinutes:    120,
+		Overrun:          1,
+		Underrun:         1,
+		OnTarget:         1,
+	}
+	if stats.Estimates != expected {
+		t.Errorf("Estimates = %+v, 期待値 = %+v", stats.Estimates, expected)
+	}
+
+	ratio, ok := stats.Estimates.AccuracyRatio()
+	if !ok || ratio != 1.2 {
+		t.Errorf("AccuracyRatio() = %v, %v, 期待値 = 1.2, true", ratio, ok)
+	}
+}
+
+// TestEstimateStats_AccuracyRatio_NoData は比較対象がない場合をテストします
+func TestEstimateStats_AccuracyRatio_NoData(t *testing.T) {
+	stats := NewTodoStats([]*Todo{{Title: "見積もりのみ", EstimateMinutes: 30}})
+
+	if _, ok := stats.Estimates.AccuracyRatio(); ok {
+		t.Error("比較対象がない場合は ok=false を期待しましたが、true でした")
+	}
+}
@@ -65,6 +65,21 @@ func TestTodo_IsValid(t *testing.T) {
 			},
 			expect: true,
 		},
+		{
+			name:   "見積もり・実績時間が上限ちょうど（有効）",
+			todo:   Todo{Title: "見積もり", EstimateMinutes: MaxEstimateMinutes, ActualMinutes: MaxEstimateMinutes},
+			expect: true,
+		},
+		{
+			name:   "見積もり時間が上限超過",
+			todo:   Todo{Title: "見積もり", EstimateMinutes: MaxEstimateMinutes + 1},
+			expect: false,
+		},
+		{
+			name:   "実績時間が負の値",
+			todo:   Todo{Title: "見積もり", ActualMinutes: -1},
+			expect: false,
+		},
 	}
 
 	// 各テストケースを実行
@@ -155,7 +170,7 @@ func TestTodo_JSONMarshaling(t *testing.T) {
 	}
 
 	// JSON形式の期待値（時刻フォーマットに注意）
-	expected := `{"id":1,"title":"テストタスク","description":"JSON変換テスト","is_completed":false,"created_at":"2023-01-01T12:00:00Z","updated_at":"2023-01-01T12:00:00Z"}`
+	expected := `{"id":1,"title":"テストタスク","description":"JSON変換テスト","is_completed":false,"created_at":"2023-01-01T12:00:00Z","updated_at":"2023-01-01T12:00:00Z","checklist_progress":{"total":0,"done":0}}`
 
 	// 構造体からJSONに変換
 	jsonData, err := json.Marshal(todo)
@@ -169,6 +184,49 @@ func TestTodo_JSONMarshaling(t *testing.T) {
 	}
 }
 
+// TestTodo_IsReminderDue はリマインダーの通知判定をテストします
+func TestTodo_IsReminderDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		setup    func(todo *Todo)
+		expected bool
+	}{
+		{name: "リマインダー未設定", setup: func(todo *Todo) {}, expected: false},
+		{name: "通知時刻ちょうど", setup: func(todo *Todo) { todo.ScheduleReminder(now) }, expected: true},
+		{name: "通知時刻を過ぎている", setup: func(todo *Todo) { todo.ScheduleReminder(now.Add(-time.Minute)) }, expected: true},
+		{name: "通知時刻前", setup: func(todo *Todo) { todo.ScheduleReminder(now.Add(time.Minute)) }, expected: false},
+		{
+			name: "完了済みのTodo",
+			setup: func(todo *Todo) {
+				todo.ScheduleReminder(now.Add(-time.Minute))
+				todo.MarkAsCompleted()
+			},
+			expected: false,
+		},
+		{
+			name: "解除済み",
+			setup: func(todo *Todo) {
+				todo.ScheduleReminder(now.Add(-time.Minute))
+				todo.CancelReminder()
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &Todo{Title: "リマインダー"}
+			tt.setup(todo)
+
+			if result := todo.IsReminderDue(now); result != tt.expected {
+				t.Errorf("IsReminderDue() = %v, 期待値 = %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 // generateString は指定された長さの文字列を生成するヘルパー関数です
 // テスト用のデータ生成に使用
 func generateString(length int) string {
@@ -210,3 +268,35 @@ func generateString(length int) string {
 // このテストファイルにより、Todoエンティティの全機能が
 // 適切にテストされ、リファクタリングや機能追加時の
 // 安全性が確保されます。
+
+// TestTodo_Duplicate は複製で引き継ぐ項目と引き継がない項目をテストします
+func TestTodo_Duplicate(t *testing.T) {
+	dueDate := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	seriesID := 3
+	original := &Todo{
+		ID:                 10,
+		Title:              "週次レポート",
+		Description:        "金曜まで",
+		IsCompleted:        true,
+		RemindAt:           &dueDate,
+		DueDate:            &dueDate,
+		Recurrence:         RecurrenceWeekly,
+		RecurrenceParentID: &seriesID,
+		ChecklistProgress:  ChecklistProgress{Total: 2, Done: 1},
+	}
+
+	duplicate := original.Duplicate()
+
+	if duplicate.ID != 0 || duplicate.Title != original.Title || duplicate.Description != original.Description || duplicate.Recurrence != RecurrenceWeekly {
+		t.Errorf("内容が引き継がれていません: %+v", duplicate)
+	}
+	if duplicate.IsCompleted || duplicate.RemindAt != nil || duplicate.RecurrenceParentID != nil || duplicate.ChecklistProgress.Total != 0 {
+		t.Errorf("完了状態・リマインダー・シリーズとの関連は引き継がないべきです: %+v", duplicate)
+	}
+
+	// 期限はコピーされ、元のTodoと共有しない
+	*duplicate.DueDate = dueDate.Add(time.Hour)
+	if !original.DueDate.Equal(dueDate) {
+		t.Error("複製の期限を変更すると元のTodoの期限も変わってしまいます")
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+)
+
+// ユーザー名とパスワードの制約です
+const (
+	// MinUsernameLength / MaxUsernameLength はユーザー名の文字数の範囲です
+	MinUsernameLength = 3
+	MaxUsernameLength = 64
+
+	// MinPasswordLength はパスワードの最小文字数です（上限はハッシュ化の負荷を抑えるためのもの）
+	MinPasswordLength = 8
+	MaxPasswordLength = 256
+)
+
+// usernamePattern はユーザー名に使える文字です（URLやログにそのまま含められる文字に限定する）
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// User はAPIを利用するユーザーです
+// パスワードは平文では保存せず、PasswordHash（pkg/password の形式）だけを保存します
+type User struct {
+	// ID はユーザーの一意識別子です
+	ID int `json:"id"`
+
+	// Username はログインに使う名前です（一意）
+	Username string `json:"username"`
+
+	// PasswordHash はパスワードのハッシュです（レスポンスには含めません）
+	PasswordHash string `json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValidUsername はユーザー名が制約（文字数と使える文字）を満たすかを判定します
+func ValidUsername(username string) bool {
+	return len(username) >= MinUsernameLength && len(username) <= MaxUsernameLength && usernamePattern.MatchString(username)
+}
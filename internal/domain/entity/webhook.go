@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"net/url"
+	"slices"
+	"time"
+)
+
+// WebhookEvent はWebhookで通知するTodoのイベントの種類です
+type WebhookEvent string
+
+// 通知できるイベントです
+const (
+	// WebhookEventTodoCreated はTodoが作成されたことを表します（複製・削除の取り消しを含む）
+	WebhookEventTodoCreated WebhookEvent = "todo.created"
+
+	// WebhookEventTodoUpdated はTodoが更新されたことを表します（未完了に戻した場合を含む）
+	WebhookEventTodoUpdated WebhookEvent = "todo.updated"
+
+	// WebhookEventTodoCompleted はTodoが完了になったことを表します
+	WebhookEventTodoCompleted WebhookEvent = "todo.completed"
+
+	// WebhookEventTodoDeleted はTodoが削除されたことを表します
+	WebhookEventTodoDeleted WebhookEvent = "todo.deleted"
+)
+
+// WebhookEvents は通知できるイベントの一覧です
+var WebhookEvents = []WebhookEvent{
+	WebhookEventTodoCreated,
+	WebhookEventTodoUpdated,
+	WebhookEventTodoCompleted,
+	WebhookEventTodoDeleted,
+}
+
+// IsValid は通知できるイベントかどうかを判定します
+func (e WebhookEvent) IsValid() bool {
+	return slices.Contains(WebhookEvents, e)
+}
+
+// MaxWebhookURLLength はWebhookのURLの最大文字数です
+const MaxWebhookURLLength = 2048
+
+// WebhookSubscription はTodoのイベントを外部のURLへ通知するWebhookの登録です
+// Events に含まれるイベントが発生したときだけ、URL へJSONをPOSTします
+type WebhookSubscription struct {
+	// ID は登録の一意識別子です
+	ID int `json:"id"`
+
+	// URL は通知先のURLです（http または https）
+	URL string `json:"url"`
+
+	// Events は通知するイベントの一覧です（1つ以上）
+	Events []WebhookEvent `json:"events"`
+
+	// CreatedAt は登録日時です
+	CreatedAt time.Time `json:"created_at"`
+
+	// UserID は登録したユーザーのIDです（認証が無効な状態で登録した場合は nil）
+	UserID *int `json:"-"`
+
+	// WorkspaceID は登録したワークスペースのIDです（個人の登録の場合は nil）
+	WorkspaceID *int `json:"-"`
+}
+
+// IsValid はWebhookの登録のビジネスルールを検証します
+// URL は http または https の絶対URL、イベントは1つ以上の通知できるイベントである必要があります
+func (s *WebhookSubscription) IsValid() bool {
+	if len(s.URL) > MaxWebhookURLLength {
+		return false
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+
+	if len(s.Events) == 0 {
+		return false
+	}
+	for _, event := range s.Events {
+		if !event.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Subscribes は event を通知する登録かどうかを判定します
+func (s *WebhookSubscription) Subscribes(event WebhookEvent) bool {
+	return slices.Contains(s.Events, event)
+}
+
+// Covers は todo のイベントをこの登録に通知してよいかどうかを判定します
+// 登録した範囲（Todoの一覧と同じ）のTodoだけが対象です
+//   - ワークスペースの登録: そのワークスペースのTodo
+//   - 個人の登録: 登録したユーザーの個人のTodo
+//   - 認証が無効な状態の登録: 所有者のないTodo
+func (s *WebhookSubscription) Covers(todo *Todo) bool {
+	if s.WorkspaceID != nil {
+		return todo.WorkspaceID != nil && *todo.WorkspaceID == *s.WorkspaceID
+	}
+	if todo.WorkspaceID != nil {
+		return false
+	}
+	if s.UserID == nil || todo.UserID == nil {
+		return s.UserID == nil && todo.UserID == nil
+	}
+	return *s.UserID == *todo.UserID
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxWorkspaceNameLength はワークスペース名の最大文字数です
+const MaxWorkspaceNameLength = 100
+
+// Workspace は複数のユーザーでTodoを共同で管理するワークスペース（チーム）です
+// ワークスペースのTodoは、作成したユーザーに関わらずワークスペースの全てのメンバーが操作できます
+type Workspace struct {
+	// ID はワークスペースの一意識別子です
+	ID int `json:"id"`
+
+	// Name はワークスペース名です（一意である必要はありません）
+	Name string `json:"name"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsValid はワークスペース名が空でなく、最大文字数以内かを判定します
+func (w *Workspace) IsValid() bool {
+	name := strings.TrimSpace(w.Name)
+	return len(name) > 0 && len(w.Name) <= MaxWorkspaceNameLength
+}
+
+// WorkspaceRole はワークスペースでのメンバーの役割です
+type WorkspaceRole string
+
+// ワークスペースの役割です
+const (
+	// WorkspaceRoleOwner はワークスペースを作成したユーザーです
+	// メンバーの招待・削除を行えます。ワークスペースから抜けることはできません
+	WorkspaceRoleOwner WorkspaceRole = "owner"
+
+	// WorkspaceRoleMember は招待を受けて参加したユーザーです
+	WorkspaceRoleMember WorkspaceRole = "member"
+)
+
+// WorkspaceMember はワークスペースへのユーザーの参加（メンバーシップ）です
+type WorkspaceMember struct {
+	// WorkspaceID は参加しているワークスペースのIDです
+	WorkspaceID int `json:"workspace_id"`
+
+	// UserID はメンバーのユーザーIDです
+	UserID int `json:"user_id"`
+
+	// Username はメンバーのユーザー名です（取得時に users テーブルから設定されます）
+	Username string `json:"username"`
+
+	// Role はワークスペースでの役割です
+	Role WorkspaceRole `json:"role"`
+
+	// JoinedAt は参加した日時です
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// WorkspaceInvitation はワークスペースへの招待です
+//
+// 招待トークン自体は招待したユーザーにだけ一度返し、サーバーはSHA-256のハッシュ（TokenHash）だけを保存します
+// 招待は一度しか使えず、参加に使うと削除されます
+type WorkspaceInvitation struct {
+	// ID は招待の一意識別子です
+	ID int `json:"id"`
+
+	// WorkspaceID は招待先のワークスペースのIDです
+	WorkspaceID int `json:"workspace_id"`
+
+	// TokenHash は招待トークンのSHA-256のハッシュ（16進数）です
+	TokenHash string `json:"-"`
+
+	// InvitedBy は招待を作成したユーザーのIDです
+	InvitedBy int `json:"invited_by"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt を過ぎた招待は使えません
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired は now の時点で招待の有効期限を過ぎているかを返します
+func (i *WorkspaceInvitation) Expired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
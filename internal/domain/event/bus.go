@@ -0,0 +1,79 @@
+package event
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// Event はドメインで起きた出来事（Todoが作成された、完了した など）を表すインターフェースです
+type Event interface {
+	// EventName はイベントの種類の名前です（購読はこの名前の単位で行います）
+	// 値レシーバーで実装し、ゼロ値でも名前を返せるようにしてください
+	EventName() string
+}
+
+// Handler はイベントを受け取る購読者の関数です
+type Handler func(ctx context.Context, e Event)
+
+// Bus はプロセス内の Publish/Subscribe 型のイベントバスです
+//
+// 学習ポイント：
+//  1. CRUD の処理はイベントを発行するだけにし、変更履歴・Webhook・指標などの付随的な処理は購読者として分離する
+//     （新しい反応を追加するときに TodoService を変更しなくてよい）
+//  2. 配信は同期的に、購読した順に行う（発行から戻った時点で全ての購読者が処理を終えている）
+//     時間のかかる購読者（外部への通知など）は自分で goroutine を起動する
+//  3. 購読者のパニックはここで回復してログに残し、他の購読者と発行元（保存済みの変更）に影響させない
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus はBusのコンストラクタです
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Subscribe は name の種類のイベントを受け取る購読者を登録します
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish はイベントをその種類の購読者へ、購読した順に配信します
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	b.mu.RLock()
+	handlers := b.handlers[e.EventName()]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		deliver(ctx, handler, e)
+	}
+}
+
+// deliver は1つの購読者にイベントを配信し、パニックを回復します
+func deliver(ctx context.Context, handler Handler, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event handler for %s panicked: %v", e.EventName(), r)
+		}
+	}()
+	handler(ctx, e)
+}
+
+// Subscribe は型 E のイベントを、型付きの関数で購読します
+//
+// 使用例：
+//
+//	event.Subscribe(bus, func(ctx context.Context, e event.TodoCompleted) { ... })
+func Subscribe[E Event](b *Bus, handler func(ctx context.Context, e E)) {
+	var zero E
+	b.Subscribe(zero.EventName(), func(ctx context.Context, e Event) {
+		if typed, ok := e.(E); ok {
+			handler(ctx, typed)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package event
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestBus_Publish は購読した順での配信、型付きの購読、購読者のパニックの回復をテストします
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus()
+	ctx := context.Background()
+	var received []string
+
+	bus.Subscribe("todo.created", func(ctx context.Context, e Event) {
+		received = append(received, "first:"+e.EventName())
+	})
+	bus.Subscribe("todo.created", func(ctx context.Context, e Event) {
+		panic("購読者の不具合")
+	})
+	Subscribe(bus, func(ctx context.Context, e TodoCreated) {
+		received = append(received, "typed:"+e.Todo().Title)
+	})
+	Subscribe(bus, func(ctx context.Context, e TodoDeleted) {
+		received = append(received, "deleted:"+e.Todo().Title)
+	})
+
+	bus.Publish(ctx, TodoCreated{TodoChange{After: &entity.Todo{ID: 1, Title: "牛乳を買う"}}})
+	bus.Publish(ctx, TodoUpdated{TodoChange{After: &entity.Todo{ID: 1, Title: "購読者なし"}}})
+
+	want := []string{"first:todo.created", "typed:牛乳を買う"}
+	if !slices.Equal(received, want) {
+		t.Errorf("受け取ったイベント = %v, 期待値 = %v", received, want)
+	}
+}
+
+// TestNewTodoEvent は変更履歴の操作からイベントの種類への対応をテストします
+func TestNewTodoEvent(t *testing.T) {
+	incomplete := &entity.Todo{ID: 1, Title: "牛乳を買う"}
+	completed := &entity.Todo{ID: 1, Title: "牛乳を買う", IsCompleted: true}
+
+	tests := []struct {
+		name            string
+		action          entity.TodoHistoryAction
+		before, after   *entity.Todo
+		expectedName    string
+		becameCompleted bool
+	}{
+		{name: "作成", action: entity.TodoHistoryCreated, after: incomplete, expectedName: "todo.created"},
+		{name: "復元", action: entity.TodoHistoryRestored, after: incomplete, expectedName: "todo.restored"},
+		{name: "更新で完了", action: entity.TodoHistoryUpdated, before: incomplete, after: completed, expectedName: "todo.updated", becameCompleted: true},
+		{name: "完了", action: entity.TodoHistoryCompleted, before: incomplete, after: completed, expectedName: "todo.completed", becameCompleted: true},
+		{name: "未完了に戻す", action: entity.TodoHistoryIncompleted, before: completed, after: incomplete, expectedName: "todo.incompleted"},
+		{name: "削除", action: entity.TodoHistoryDeleted, before: completed, expectedName: "todo.deleted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewTodoEvent(tt.action, tt.before, tt.after)
+			if e.EventName() != tt.expectedName || e.Action() != tt.action {
+				t.Errorf("イベント = %s (%s), 期待値 = %s (%s)", e.EventName(), e.Action(), tt.expectedName, tt.action)
+			}
+			if e.Change().BecameCompleted() != tt.becameCompleted {
+				t.Errorf("BecameCompleted = %v, 期待値 = %v", e.Change().BecameCompleted(), tt.becameCompleted)
+			}
+			if e.Change().Todo() == nil {
+				t.Error("対象のTodoがありません")
+			}
+		})
+	}
+}
+
+// TestMarshalTodoEvent はアウトボックスに保存したイベントを同じ種類・内容で復元できることをテストします
+func TestMarshalTodoEvent(t *testing.T) {
+	before := &entity.Todo{ID: 1, Title: "牛乳を買う"}
+	after := &entity.Todo{ID: 1, Title: "牛乳を買う", IsCompleted: true}
+
+	for _, kind := range TodoEvents {
+		t.Run(kind.EventName(), func(t *testing.T) {
+			payload, err := MarshalTodoEvent(NewTodoEvent(kind.Action(), before, after))
+			if err != nil {
+				t.Fatalf("MarshalTodoEvent() error = %v", err)
+			}
+			e, err := UnmarshalTodoEvent(kind.EventName(), payload)
+			if err != nil {
+				t.Fatalf("UnmarshalTodoEvent() error = %v", err)
+			}
+			if e.EventName() != kind.EventName() {
+				t.Errorf("復元したイベント = %s, 期待値 = %s", e.EventName(), kind.EventName())
+			}
+			change := e.Change()
+			if change.Before.Title != before.Title || !change.After.IsCompleted {
+				t.Errorf("復元した変更 = %+v", change)
+			}
+		})
+	}
+
+	if _, err := UnmarshalTodoEvent("todo.archived", []byte(`{}`)); err == nil {
+		t.Error("未知のイベントの復元はエラーになるべきです")
+	}
+	if _, err := UnmarshalTodoEvent("todo.created", []byte(`{`)); err == nil {
+		t.Error("不正なJSONの復元はエラーになるべきです")
+	}
+}
@@ -0,0 +1,143 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// Debouncer は同じTodoに対して短時間に続いたイベントを1つにまとめてから配信するバッファです
+//
+// 学習ポイント（重複の抑制）：
+//  1. Todoごとに最初のイベントから window の間だけイベントを溜め、期間が終わったら1つにまとめて配信する
+//     （編集を続けている間ずっと配信が遅れないよう、期間は延長しない）
+//  2. まとめたイベントは「最初の変更前」から「最後の変更後」への変更になる
+//     作成から window 以内に削除された場合のように、外から見て何も変わっていなければ配信しない
+//  3. まとめるのは外部への通知（Webhook など）向けで、変更履歴のように全ての変更を残したい購読者には使わない
+type Debouncer struct {
+	window  time.Duration
+	handler func(ctx context.Context, e TodoEvent)
+
+	mu      sync.Mutex
+	pending map[int]*debouncedTodoEvent
+}
+
+// debouncedTodoEvent は配信を待っている、1つのTodoのまとめたイベントです
+type debouncedTodoEvent struct {
+	ctx   context.Context
+	first TodoEvent
+	last  TodoEvent
+
+	// sameKind は溜めたイベントが全て同じ種類かどうかです
+	sameKind bool
+
+	timer *time.Timer
+}
+
+// NewDebouncer はDebouncerのコンストラクタです
+// window 以下の場合はまとめずに、受け取ったイベントをすぐに handler へ渡します
+func NewDebouncer(window time.Duration, handler func(ctx context.Context, e TodoEvent)) *Debouncer {
+	return &Debouncer{
+		window:  window,
+		handler: handler,
+		pending: make(map[int]*debouncedTodoEvent),
+	}
+}
+
+// Handle はイベントを溜めます（SubscribeTodoEvents の購読者として登録します）
+// 溜めている間に対象のTodoが変更されても影響しないよう、変更前後の状態は複製して保持します
+func (d *Debouncer) Handle(ctx context.Context, e TodoEvent) {
+	if d.window <= 0 {
+		d.handler(ctx, e)
+		return
+	}
+
+	e = snapshotTodoEvent(e)
+	id := e.Change().Todo().ID
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if p, ok := d.pending[id]; ok {
+		p.sameKind = p.sameKind && p.last.EventName() == e.EventName()
+		p.last = e
+		return
+	}
+	p := &debouncedTodoEvent{ctx: context.WithoutCancel(ctx), first: e, last: e, sameKind: true}
+	p.timer = time.AfterFunc(d.window, func() { d.fire(id, p) })
+	d.pending[id] = p
+}
+
+// Flush は溜めている全てのイベントを、期間の終了を待たずに配信します（シャットダウン時に呼び出します）
+func (d *Debouncer) Flush() {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = make(map[int]*debouncedTodoEvent)
+	d.mu.Unlock()
+
+	for _, p := range pending {
+		p.timer.Stop()
+		d.deliver(p)
+	}
+}
+
+// fire は期間が終わったTodoのイベントを配信します（Flush で配信済みの場合は何もしない）
+func (d *Debouncer) fire(id int, p *debouncedTodoEvent) {
+	d.mu.Lock()
+	if d.pending[id] != p {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.pending, id)
+	d.mu.Unlock()
+
+	d.deliver(p)
+}
+
+// deliver はまとめたイベントを handler へ渡します
+func (d *Debouncer) deliver(p *debouncedTodoEvent) {
+	if e := mergeTodoEvents(p.first, p.last, p.sameKind); e != nil {
+		d.handler(p.ctx, e)
+	}
+}
+
+// mergeTodoEvents は最初と最後のイベントを、最初の変更前から最後の変更後への1つのイベントにまとめます
+//   - 1件だけ、または全て同じ種類: その種類（例: 3回の更新は1回の更新）
+//   - 変更前がない: 最初のイベントの種類（作成・復元）
+//   - 変更後がない: 削除
+//   - それ以外の組み合わせ（更新と完了など）: 更新（完了に変わっていれば、通知側で完了としても扱われる）
+//
+// 作成してから削除したなど、変更前も変更後もない場合は nil を返します
+func mergeTodoEvents(first, last TodoEvent, sameKind bool) TodoEvent {
+	before, after := first.Change().Before, last.Change().After
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case first == last:
+		return first
+	case sameKind:
+		return NewTodoEvent(first.Action(), before, after)
+	case before == nil:
+		return NewTodoEvent(first.Action(), nil, after)
+	case after == nil:
+		return TodoDeleted{TodoChange{Before: before}}
+	default:
+		return TodoUpdated{TodoChange{Before: before, After: after}}
+	}
+}
+
+// snapshotTodoEvent はイベントの変更前後のTodoを複製したイベントを返します
+func snapshotTodoEvent(e TodoEvent) TodoEvent {
+	change := e.Change()
+	return NewTodoEvent(e.Action(), copyTodo(change.Before), copyTodo(change.After))
+}
+
+// copyTodo はTodoの浅いコピーを返します（nil の場合は nil）
+func copyTodo(todo *entity.Todo) *entity.Todo {
+	if todo == nil {
+		return nil
+	}
+	c := *todo
+	return &c
+}
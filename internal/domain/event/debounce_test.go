@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestDebouncer_Merge は同じTodoに続いたイベントのまとめ方をテストします
+func TestDebouncer_Merge(t *testing.T) {
+	v1 := &entity.Todo{ID: 1, Title: "買い物"}
+	v2 := &entity.Todo{ID: 1, Title: "牛乳を買う"}
+	v3 := &entity.Todo{ID: 1, Title: "牛乳を買う", IsCompleted: true}
+
+	tests := []struct {
+		name          string
+		events        []TodoEvent
+		expectedName  string // 空の場合は配信されない
+		expectedTitle string
+		before        bool
+	}{
+		{name: "1件", events: []TodoEvent{TodoUpdated{TodoChange{v1, v2}}}, expectedName: "todo.updated", expectedTitle: "牛乳を買う", before: true},
+		{name: "3回の更新", events: []TodoEvent{TodoUpdated{TodoChange{v1, v2}}, TodoUpdated{TodoChange{v2, v2}}, TodoUpdated{TodoChange{v2, v3}}}, expectedName: "todo.updated", expectedTitle: "牛乳を買う", before: true},
+		{name: "作成と更新", events: []TodoEvent{TodoCreated{TodoChange{nil, v1}}, TodoUpdated{TodoChange{v1, v2}}}, expectedName: "todo.created", expectedTitle: "牛乳を買う"},
+		{name: "更新と完了", events: []TodoEvent{TodoUpdated{TodoChange{v1, v2}}, TodoCompleted{TodoChange{v2, v3}}}, expectedName: "todo.updated", expectedTitle: "牛乳を買う", before: true},
+		{name: "更新と削除", events: []TodoEvent{TodoUpdated{TodoChange{v1, v2}}, TodoDeleted{TodoChange{v2, nil}}}, expectedName: "todo.deleted", expectedTitle: "買い物", before: true},
+		{name: "作成と削除", events: []TodoEvent{TodoCreated{TodoChange{nil, v1}}, TodoDeleted{TodoChange{v1, nil}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delivered []TodoEvent
+			d := NewDebouncer(time.Hour, func(ctx context.Context, e TodoEvent) {
+				delivered = append(delivered, e)
+			})
+			for _, e := range tt.events {
+				d.Handle(context.Background(), e)
+			}
+			if len(delivered) != 0 {
+				t.Fatalf("期間の終了前に %d 件配信されました", len(delivered))
+			}
+			d.Flush()
+
+			if tt.expectedName == "" {
+				if len(delivered) != 0 {
+					t.Errorf("配信されないはずのイベントが配信されました: %v", delivered[0].EventName())
+				}
+				return
+			}
+			if len(delivered) != 1 {
+				t.Fatalf("配信された件数 = %d, 期待値 = 1", len(delivered))
+			}
+			e := delivered[0]
+			if e.EventName() != tt.expectedName {
+				t.Errorf("イベント = %s, 期待値 = %s", e.EventName(), tt.expectedName)
+			}
+			if e.Change().Todo().Title != tt.expectedTitle {
+				t.Errorf("タイトル = %s, 期待値 = %s", e.Change().Todo().Title, tt.expectedTitle)
+			}
+			if (e.Change().Before != nil) != tt.before || (tt.before && e.Change().Before.Title != "買い物") {
+				t.Errorf("変更前 = %+v（最初のイベントの変更前になっていません）", e.Change().Before)
+			}
+		})
+	}
+}
+
+// TestDebouncer_Window は期間の終了後にTodoごとに配信されること、期間0ではすぐに配信されることをテストします
+func TestDebouncer_Window(t *testing.T) {
+	var mu sync.Mutex
+	delivered := make(map[int]int)
+	done := make(chan struct{}, 2)
+	d := NewDebouncer(20*time.Millisecond, func(ctx context.Context, e TodoEvent) {
+		mu.Lock()
+		delivered[e.Change().Todo().ID]++
+		mu.Unlock()
+		done <- struct{}{}
+	})
+
+	todo := &entity.Todo{ID: 1, Title: "牛乳を買う"}
+	d.Handle(context.Background(), TodoUpdated{TodoChange{todo, todo}})
+	d.Handle(context.Background(), TodoUpdated{TodoChange{todo, todo}})
+	d.Handle(context.Background(), TodoCreated{TodoChange{nil, &entity.Todo{ID: 2}}})
+	todo.Title = "溜めた後の変更" // 溜めているイベントには影響しない
+
+	for range 2 {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("期間が終わっても配信されません")
+		}
+	}
+	mu.Lock()
+	if delivered[1] != 1 || delivered[2] != 1 {
+		t.Errorf("Todoごとの配信回数 = %v, 期待値 = map[1:1 2:1]", delivered)
+	}
+	mu.Unlock()
+
+	var immediate []TodoEvent
+	NewDebouncer(0, func(ctx context.Context, e TodoEvent) { immediate = append(immediate, e) }).
+		Handle(context.Background(), TodoDeleted{TodoChange{todo, nil}})
+	if len(immediate) != 1 {
+		t.Errorf("期間0で配信された件数 = %d, 期待値 = 1", len(immediate))
+	}
+}
@@ -0,0 +1,131 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoChange は保存済みのTodoの変更前後の状態です
+// 作成・復元では Before が、削除では After が nil になります
+type TodoChange struct {
+	Before *entity.Todo `json:"before"`
+	After  *entity.Todo `json:"after"`
+}
+
+// Todo はイベントの対象のTodoを返します（削除の場合は削除前のTodo）
+func (c TodoChange) Todo() *entity.Todo {
+	if c.After != nil {
+		return c.After
+	}
+	return c.Before
+}
+
+// BecameCompleted は未完了から完了に変わった変更かどうかを返します
+func (c TodoChange) BecameCompleted() bool {
+	return c.Before != nil && c.After != nil && !c.Before.IsCompleted && c.After.IsCompleted
+}
+
+// TodoEvent はTodoの変更を表すイベントに共通のインターフェースです
+// 全ての種類のTodoの変更に同じ反応をする購読者（変更履歴の記録など）が使います
+type TodoEvent interface {
+	Event
+
+	// Action は変更履歴での操作の種類です
+	Action() entity.TodoHistoryAction
+
+	// Change は変更前後の状態です
+	Change() TodoChange
+}
+
+// TodoCreated はTodoが作成されたイベントです（複製を含む）
+type TodoCreated struct{ TodoChange }
+
+// TodoRestored は削除されたTodoが取り消しで復元されたイベントです
+type TodoRestored struct{ TodoChange }
+
+// TodoUpdated はTodoが更新されたイベントです（更新で完了になった場合も含む）
+type TodoUpdated struct{ TodoChange }
+
+// TodoCompleted は /complete でTodoが完了になったイベントです
+type TodoCompleted struct{ TodoChange }
+
+// TodoIncompleted は /incomplete でTodoが未完了に戻ったイベントです
+type TodoIncompleted struct{ TodoChange }
+
+// TodoDeleted はTodoが削除されたイベントです
+type TodoDeleted struct{ TodoChange }
+
+func (TodoCreated) EventName() string     { return "todo.created" }
+func (TodoRestored) EventName() string    { return "todo.restored" }
+func (TodoUpdated) EventName() string     { return "todo.updated" }
+func (TodoCompleted) EventName() string   { return "todo.completed" }
+func (TodoIncompleted) EventName() string { return "todo.incompleted" }
+func (TodoDeleted) EventName() string     { return "todo.deleted" }
+
+func (TodoCreated) Action() entity.TodoHistoryAction     { return entity.TodoHistoryCreated }
+func (TodoRestored) Action() entity.TodoHistoryAction    { return entity.TodoHistoryRestored }
+func (TodoUpdated) Action() entity.TodoHistoryAction     { return entity.TodoHistoryUpdated }
+func (TodoCompleted) Action() entity.TodoHistoryAction   { return entity.TodoHistoryCompleted }
+func (TodoIncompleted) Action() entity.TodoHistoryAction { return entity.TodoHistoryIncompleted }
+func (TodoDeleted) Action() entity.TodoHistoryAction     { return entity.TodoHistoryDeleted }
+
+// Change は変更前後の状態を返します
+func (c TodoChange) Change() TodoChange { return c }
+
+// TodoEvents は全ての種類のTodoのイベントのゼロ値です（全種類を購読する際に使います）
+var TodoEvents = []TodoEvent{
+	TodoCreated{}, TodoRestored{}, TodoUpdated{}, TodoCompleted{}, TodoIncompleted{}, TodoDeleted{},
+}
+
+// NewTodoEvent は変更履歴の操作と変更前後の状態から、対応する種類のイベントを作成します
+func NewTodoEvent(action entity.TodoHistoryAction, before, after *entity.Todo) TodoEvent {
+	change := TodoChange{Before: before, After: after}
+	switch action {
+	case entity.TodoHistoryCreated:
+		return TodoCreated{change}
+	case entity.TodoHistoryRestored:
+		return TodoRestored{change}
+	case entity.TodoHistoryCompleted:
+		return TodoCompleted{change}
+	case entity.TodoHistoryIncompleted:
+		return TodoIncompleted{change}
+	case entity.TodoHistoryDeleted:
+		return TodoDeleted{change}
+	default:
+		return TodoUpdated{change}
+	}
+}
+
+// SubscribeTodoEvents は全ての種類のTodoのイベントを1つの関数で購読します
+func SubscribeTodoEvents(b *Bus, handler func(ctx context.Context, e TodoEvent)) {
+	for _, kind := range TodoEvents {
+		b.Subscribe(kind.EventName(), func(ctx context.Context, e Event) {
+			if typed, ok := e.(TodoEvent); ok {
+				handler(ctx, typed)
+			}
+		})
+	}
+}
+
+// MarshalTodoEvent はイベントの変更前後の状態を、アウトボックスに保存するJSONに変換します
+// イベントの種類は EventName() として別に保存します
+func MarshalTodoEvent(e TodoEvent) ([]byte, error) {
+	return json.Marshal(e.Change())
+}
+
+// UnmarshalTodoEvent は保存されたイベントの種類の名前とJSONから、イベントを復元します
+func UnmarshalTodoEvent(name string, payload []byte) (TodoEvent, error) {
+	var change TodoChange
+	if err := json.Unmarshal(payload, &change); err != nil {
+		return nil, fmt.Errorf("invalid %s event payload: %w", name, err)
+	}
+	for _, kind := range TodoEvents {
+		if kind.EventName() == name {
+			return NewTodoEvent(kind.Action(), change.Before, change.After), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown todo event: %s", name)
+}
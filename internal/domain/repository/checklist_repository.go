@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ChecklistRepository はTodoに属するチェックリスト項目のデータアクセスを抽象化するインターフェースです
+// 全てのメソッドは親のTodoIDを受け取り、別のTodoの項目を誤って操作しないようにしています
+type ChecklistRepository interface {
+	// Create は新しいチェックリスト項目を作成します
+	// item.TodoID は必須です
+	Create(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error)
+
+	// GetByID は指定されたTodoに属するチェックリスト項目を1件取得します
+	GetByID(ctx context.Context, todoID, itemID int) (*entity.ChecklistItem, error)
+
+	// ListByTodoID は指定されたTodoのチェックリスト項目を作成順に取得します
+	ListByTodoID(ctx context.Context, todoID int) ([]*entity.ChecklistItem, error)
+
+	// Update はチェックリスト項目のテキストと完了状態を更新します
+	Update(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error)
+
+	// Delete は指定されたTodoに属するチェックリスト項目を削除します
+	Delete(ctx context.Context, todoID, itemID int) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// DueDateRepository はTodoの期限（due_date）に基づく一覧取得を抽象化するインターフェースです
+// 期限切れなどのビューは現在時刻を基準にした絞り込みのため、
+// TodoRepository とは別の小さなインターフェースとして定義しています
+type DueDateRepository interface {
+	// ListOverdue は期限が now より前の未完了Todoを期限の早い順に取得します
+	ListOverdue(ctx context.Context, now time.Time) ([]*entity.Todo, error)
+
+	// ListDueBetween は期限が from 以上 to 未満の未完了Todoを期限の早い順に取得します
+	ListDueBetween(ctx context.Context, from, to time.Time) ([]*entity.Todo, error)
+
+	// ListWithDueDate は期限のある全てのTodoを期限の早い順に取得します（カレンダーの配信用）
+	// includeCompleted が false の場合は未完了のTodoのみを返します
+	ListWithDueDate(ctx context.Context, includeCompleted bool) ([]*entity.Todo, error)
+}
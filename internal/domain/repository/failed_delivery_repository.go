@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// FailedDeliveryRepository は送信に失敗した副作用（再送待ち・デッドレター）のデータアクセスを抽象化するインターフェースです
+type FailedDeliveryRepository interface {
+	// Create は失敗した送信を保存し、採番されたIDと作成日時を設定して返します
+	Create(ctx context.Context, delivery *entity.FailedDelivery) (*entity.FailedDelivery, error)
+
+	// GetByID はIDで失敗した送信を取得します
+	// 存在しない場合は domainerr.NotFound("failed delivery") のエラーを返します
+	GetByID(ctx context.Context, id int) (*entity.FailedDelivery, error)
+
+	// ListDue は再送予定時刻が now 以前の再送待ちを予定時刻の早い順に最大 limit 件取得します
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.FailedDelivery, error)
+
+	// ListByStatus は指定した状態の失敗した送信をID順に取得します（デッドレターの一覧など）
+	ListByStatus(ctx context.Context, status entity.DeliveryStatus) ([]*entity.FailedDelivery, error)
+
+	// Update は失敗回数・エラー・状態・再送予定時刻を更新します
+	// 存在しない場合は domainerr.NotFound("failed delivery") のエラーを返します
+	Update(ctx context.Context, delivery *entity.FailedDelivery) (*entity.FailedDelivery, error)
+
+	// Delete は失敗した送信を削除します（再送に成功した場合や破棄する場合）
+	// 存在しない場合は domainerr.NotFound("failed delivery") のエラーを返します
+	Delete(ctx context.Context, id int) error
+}
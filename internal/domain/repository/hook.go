@@ -0,0 +1,70 @@
+package repository
+
+import "context"
+
+// Operation はフックに渡すリポジトリの操作の情報です
+type Operation struct {
+	// Repository はリポジトリの名前です（例: TodoRepository）
+	Repository string
+
+	// Method は呼び出されたメソッドの名前です（例: GetByID）
+	Method string
+
+	// ID は操作対象のIDです（1件のIDを指定する操作の場合のみ。それ以外は 0）
+	ID int
+
+	// ReadOnly はデータを変更しない操作（取得・存在確認）の場合に true です
+	// キャッシュのように、書き込みがあった場合にだけ処理するフックで使用します
+	ReadOnly bool
+}
+
+// Hook はリポジトリの操作の前後に処理を差し込むインターフェースです
+// トレース・メトリクス・キャッシュの無効化のような横断的な処理を、
+// 各リポジトリのCRUDの実装を変更せずに追加できます（WithTodoHooks で適用します）
+type Hook interface {
+	// Before は操作の前に呼ばれます
+	// 戻り値のコンテキストが操作と After に渡されるため、トレースのスパン等を格納できます
+	Before(ctx context.Context, op Operation) context.Context
+
+	// After は操作の後に呼ばれます（操作がエラーになった場合は err にそのエラーが渡されます）
+	After(ctx context.Context, op Operation, err error)
+}
+
+// HookFuncs は関数を Hook として使うためのアダプターです
+// nil の関数は何もしません（エラーだけを記録するフック等で、必要な関数だけを設定できます）
+type HookFuncs struct {
+	BeforeFunc func(ctx context.Context, op Operation) context.Context
+	AfterFunc  func(ctx context.Context, op Operation, err error)
+}
+
+// Before は BeforeFunc を呼び出します
+func (h HookFuncs) Before(ctx context.Context, op Operation) context.Context {
+	if h.BeforeFunc == nil {
+		return ctx
+	}
+	return h.BeforeFunc(ctx, op)
+}
+
+// After は AfterFunc を呼び出します
+func (h HookFuncs) After(ctx context.Context, op Operation, err error) {
+	if h.AfterFunc != nil {
+		h.AfterFunc(ctx, op, err)
+	}
+}
+
+// hooks は複数のフックをまとめて呼び出します
+// Before は登録順に、After は逆順に呼ぶため、先に登録したフックほど外側で操作を囲みます
+type hooks []Hook
+
+// run は op の前後にフックを呼び出して fn を実行します
+// fn にはフックが返したコンテキストを渡します
+func (hs hooks) run(ctx context.Context, op Operation, fn func(ctx context.Context) error) error {
+	for _, h := range hs {
+		ctx = h.Before(ctx, op)
+	}
+	err := fn(ctx)
+	for i := len(hs) - 1; i >= 0; i-- {
+		hs[i].After(ctx, op, err)
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ErrIdempotencyKeyExists は同じ操作者・キーの記録が既にある場合に Create が返すエラーです
+// 同時に届いた同じキーのリクエストのうち、一意制約で1つだけが処理を始められます
+var ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+
+// IdempotencyRepository は Idempotency-Key の記録のデータアクセスを抽象化するインターフェースです
+type IdempotencyRepository interface {
+	// Create は処理中の記録を保存します
+	// 同じ操作者・キーの記録が既にある場合は ErrIdempotencyKeyExists を返します
+	Create(ctx context.Context, record *entity.IdempotencyRecord) error
+
+	// Get は操作者・キーで記録を取得します
+	// 存在しない場合は domainerr.NotFound("idempotency record") のエラーを返します
+	Get(ctx context.Context, actor, key string) (*entity.IdempotencyRecord, error)
+
+	// Complete は記録にレスポンス（StatusCode・Header・Body）を保存します
+	Complete(ctx context.Context, record *entity.IdempotencyRecord) error
+
+	// Delete は記録を削除します（処理に失敗し、再送で処理をやり直せるようにする場合）
+	// 存在しない場合は domainerr.NotFound("idempotency record") のエラーを返します
+	Delete(ctx context.Context, actor, key string) error
+
+	// DeleteExpired は有効期限（ExpiresAt）が before 以前の記録を削除し、削除した件数を返します
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
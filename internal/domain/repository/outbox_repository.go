@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// OutboxRepository は発行待ちのドメインイベント（アウトボックス）のデータアクセスを抽象化するインターフェースです
+//
+// Add はデータの変更と同じトランザクション（Transactor.InTransaction のコンテキスト）で呼び出し、
+// 変更が保存された場合にだけイベントも保存されるようにします
+type OutboxRepository interface {
+	// Add は発行待ちのイベントを保存し、採番されたIDと作成日時を設定して返します
+	Add(ctx context.Context, message *entity.OutboxMessage) (*entity.OutboxMessage, error)
+
+	// ListPending は発行待ちのイベントを保存された順に最大 limit 件取得します
+	ListPending(ctx context.Context, limit int) ([]*entity.OutboxMessage, error)
+
+	// Delete は発行済みのイベントを削除します
+	// 存在しない場合は domainerr.NotFound("outbox message") のエラーを返します
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import "context"
+
+// ownerKey はコンテキストにデータの所有者を格納するためのキーです
+type ownerKey struct{}
+
+// WithOwner はデータの所有者（ユーザーID）をコンテキストに設定します
+// 所有者が設定されたコンテキストでは、TodoRepository の全ての操作がそのユーザーのTodoに限定され、
+// 作成したTodoはそのユーザーの所有になります
+// HTTPリクエストでは service.WithPrincipal が認証されたユーザーを所有者として設定します
+func WithOwner(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, ownerKey{}, userID)
+}
+
+// OwnerFromContext はコンテキストからデータの所有者を取得します
+// 設定されていない場合（認証が無効な場合や、全ユーザーのデータを扱うワーカー）は false を返し、
+// リポジトリは所有者で絞り込みません
+func OwnerFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(ownerKey{}).(int)
+	return userID, ok
+}
+
+// WithoutOwner は所有者・ワークスペースによる絞り込みを解除したコンテキストを返します
+// 共有されたTodoのように、サービスが操作の権限を確認したうえで他のユーザーのデータを扱う場合に使います
+// このコンテキストで作成したTodoは所有者なしになるため、作成には使用しないでください
+func WithoutOwner(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, ownerKey{}, nil)
+	return context.WithValue(ctx, workspaceKey{}, nil)
+}
+
+// workspaceKey はコンテキストに操作対象のワークスペースを格納するためのキーです
+type workspaceKey struct{}
+
+// WithWorkspace は操作対象のワークスペース（ワークスペースID）をコンテキストに設定します
+// ワークスペースが設定されたコンテキストでは、TodoRepository の全ての操作が所有者ではなく
+// そのワークスペースのTodoに限定され、作成したTodoはそのワークスペースに属します
+// HTTPリクエストでは WorkspaceMiddleware がメンバーであることを確認してから設定します
+func WithWorkspace(ctx context.Context, workspaceID int) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, workspaceID)
+}
+
+// WorkspaceFromContext はコンテキストから操作対象のワークスペースを取得します
+// 設定されていない場合は false を返し、リポジトリは所有者の個人のTodoに絞り込みます
+func WorkspaceFromContext(ctx context.Context) (int, bool) {
+	workspaceID, ok := ctx.Value(workspaceKey{}).(int)
+	return workspaceID, ok
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// PreferencesRepository はユーザーごとの設定の永続化を抽象化するインターフェースです
+type PreferencesRepository interface {
+	// Get はユーザーの設定を取得します
+	// 保存していない場合は domainerr.NotFound("preferences") のエラーを返します
+	Get(ctx context.Context, userID int) (*entity.Preferences, error)
+
+	// Save はユーザーの設定を保存します（既にある場合は置き換える）
+	Save(ctx context.Context, preferences *entity.Preferences) (*entity.Preferences, error)
+}
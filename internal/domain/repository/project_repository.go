@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ErrSlugConflict はスラッグの一意制約に違反した場合に返されるエラーです
+// サービス層はこのエラーを受け取った場合、別のスラッグ候補で再試行します
+var ErrSlugConflict = errors.New("slug already exists")
+
+// ProjectRepository はProjectエンティティのデータアクセスを抽象化するインターフェースです
+// TodoRepository と同じく、コンテキストに所有者・ワークスペース（WithOwner・WithWorkspace）が設定されている場合は
+// その範囲のプロジェクトだけを扱い、作成したプロジェクトはその所有者・ワークスペースのものになります（SlugExists を除く）
+type ProjectRepository interface {
+	// Create は新しいプロジェクトを作成します
+	// スラッグが既に使われている場合は ErrSlugConflict を返します
+	Create(ctx context.Context, project *entity.Project) (*entity.Project, error)
+
+	// GetByID はIDでプロジェクトを取得します
+	GetByID(ctx context.Context, id int) (*entity.Project, error)
+
+	// GetBySlug はスラッグでプロジェクトを取得します
+	GetBySlug(ctx context.Context, slug string) (*entity.Project, error)
+
+	// GetAll はプロジェクトを取得します（アーカイブ済みのプロジェクトを含む）
+	GetAll(ctx context.Context) ([]*entity.Project, error)
+
+	// SlugExists は指定されたスラッグが既に使用されているかを返します（スラッグは全てのプロジェクトで一意のため、所有者で絞り込みません）
+	SlugExists(ctx context.Context, slug string) (bool, error)
+
+	// SetArchivedAt はプロジェクトのアーカイブ日時を設定します（nil の場合はアーカイブを解除）
+	// アーカイブ済みのプロジェクトのTodoは、TodoRepository の一覧を返すメソッドで除外されます
+	// プロジェクトが見つからない場合は domainerr.NotFound("project") のエラーを返します
+	SetArchivedAt(ctx context.Context, id int, archivedAt *time.Time) error
+}
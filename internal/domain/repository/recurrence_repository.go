@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// RecurrenceRepository は繰り返しTodo（シリーズ）とそのオカレンスに関するデータアクセスを抽象化するインターフェースです
+// シリーズもオカレンスも todos テーブルに保存されますが、先行作成ワーカーが必要とする
+// 操作だけを TodoRepository とは別の小さなインターフェースとして定義しています
+type RecurrenceRepository interface {
+	// ListSeries は繰り返し設定と期限日を持つTodo（シリーズの元）をすべて取得します
+	ListSeries(ctx context.Context) ([]*entity.Todo, error)
+
+	// LatestOccurrence はシリーズから作成済みのオカレンスのうち最も遅い期限日を返します
+	// オカレンスがまだない場合は nil を返します
+	LatestOccurrence(ctx context.Context, seriesID int) (*time.Time, error)
+
+	// CreateOccurrences はオカレンスをまとめて作成します
+	// 1件でも失敗した場合はすべてロールバックします
+	CreateOccurrences(ctx context.Context, occurrences []*entity.Todo) error
+}
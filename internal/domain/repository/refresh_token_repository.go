@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ErrRefreshTokenUsed は使用済み・失効済みのトークンに MarkUsed を呼んだ場合のエラーです
+// 同じトークンで同時にリフレッシュした場合も、一方だけが成功し、もう一方はこのエラーになります
+var ErrRefreshTokenUsed = errors.New("refresh token was already used")
+
+// RefreshTokenRepository はリフレッシュトークンの記録のデータアクセスを抽象化するインターフェースです
+type RefreshTokenRepository interface {
+	// Create は記録を保存し、採番されたIDを設定して返します
+	Create(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error)
+
+	// GetByHash はトークンのハッシュで記録を取得します
+	// 存在しない場合は domainerr.NotFound("refresh token") のエラーを返します
+	GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+
+	// MarkUsed は未使用・未失効の記録に使用日時を記録します
+	// 使用済み・失効済みの場合は ErrRefreshTokenUsed を返します
+	MarkUsed(ctx context.Context, id int, usedAt time.Time) error
+
+	// RevokeFamily は系列の未失効の記録を全て失効させ、失効させた件数を返します
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) (int, error)
+
+	// DeleteExpired は有効期限が before 以前の記録を削除し、削除した件数を返します
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
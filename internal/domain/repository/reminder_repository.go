@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ReminderRepository はTodoのリマインダー（remind_at）に関するデータアクセスを抽象化するインターフェースです
+// リマインダーはTodoの一部として保存されますが、定期スキャンと状態変更だけを行う
+// スケジューラーのために、TodoRepository とは別の小さなインターフェースとして定義しています
+type ReminderRepository interface {
+	// ListDue は通知時刻が now 以前の未完了Todoを通知時刻の早い順に最大 limit 件取得します
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.Todo, error)
+
+	// SetRemindAt はTodoの通知時刻を設定します
+	// remindAt が nil の場合はリマインダーを解除します
+	// Todo が存在しない場合は domainerr.NotFound("todo") のエラーを返します
+	SetRemindAt(ctx context.Context, todoID int, remindAt *time.Time) error
+}
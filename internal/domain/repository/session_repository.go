@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// SessionRepository はCookieのセッションを保存するストアを抽象化するインターフェースです
+// 1台で動かす場合はメモリ上（memory.NewSessionRepository）、複数台で動かす場合や
+// 再起動後もログインを保ちたい場合はデータベース（database.NewSessionRepository）の実装を使います
+type SessionRepository interface {
+	// Create はセッションを保存します
+	Create(ctx context.Context, session *entity.Session) error
+
+	// GetByHash はセッションIDのハッシュでセッションを取得します
+	// 存在しない場合は domainerr.NotFound("session") のエラーを返します
+	GetByHash(ctx context.Context, tokenHash string) (*entity.Session, error)
+
+	// Delete はセッションを削除します
+	// 存在しない場合は domainerr.NotFound("session") のエラーを返します
+	Delete(ctx context.Context, tokenHash string) error
+
+	// DeleteExpired は有効期限が before 以前のセッションを削除し、削除した件数を返します
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoFilter は一覧・件数・集計の対象にするTodoの条件です
+// 指定した条件を全て満たすTodoが対象になり、何も指定しない場合は一覧（GetAll）と同じTodoが対象です
+type TodoFilter struct {
+	// Color は色による絞り込みです（空の場合は絞り込まない）
+	Color entity.Color
+
+	// Completed は完了状態による絞り込みです（nil の場合は絞り込まない）
+	Completed *bool
+
+	// ProjectID は所属するプロジェクトによる絞り込みです（nil の場合は絞り込まない）
+	ProjectID *int
+
+	// Tag は付いているタグによる絞り込みです（正規化済み。空の場合は絞り込まない）
+	Tag string
+
+	// Search はタイトルか説明に含まれる文字列による絞り込みです（大文字・小文字を区別しない。空の場合は絞り込まない）
+	Search string
+
+	// DueFrom は期限がこの日時以降のTodoへの絞り込みです（nil の場合は絞り込まない。期限のないTodoは含まない）
+	DueFrom *time.Time
+
+	// DueTo は期限がこの日時より前のTodoへの絞り込みです（nil の場合は絞り込まない。期限のないTodoは含まない）
+	DueTo *time.Time
+}
+
+// Matches はTodoが条件に一致するかを返します（メモリ上の実装やテストで使用します）
+func (f TodoFilter) Matches(todo *entity.Todo) bool {
+	if f.Color != entity.ColorNone && todo.Color != f.Color {
+		return false
+	}
+	if f.Completed != nil && todo.IsCompleted != *f.Completed {
+		return false
+	}
+	if f.ProjectID != nil && (todo.ProjectID == nil || *todo.ProjectID != *f.ProjectID) {
+		return false
+	}
+	if f.Tag != "" && !todo.HasTag(f.Tag) {
+		return false
+	}
+	if f.Search != "" && !containsFold(todo.Title, f.Search) && !containsFold(todo.Description, f.Search) {
+		return false
+	}
+	if f.DueFrom != nil && (todo.DueDate == nil || todo.DueDate.Before(*f.DueFrom)) {
+		return false
+	}
+	if f.DueTo != nil && (todo.DueDate == nil || !todo.DueDate.Before(*f.DueTo)) {
+		return false
+	}
+	return true
+}
+
+// containsFold は s に substr が大文字・小文字を区別せずに含まれるかを返します
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// TodoGroup は件数を集計する単位（グループ化する項目）です
+type TodoGroup string
+
+const (
+	// TodoGroupColor は色ごとに集計します（キーは色。色なしは空文字）
+	TodoGroupColor TodoGroup = "color"
+
+	// TodoGroupProject はプロジェクトごとに集計します（キーはプロジェクトID。どのプロジェクトにも属さないものは空文字）
+	TodoGroupProject TodoGroup = "project"
+
+	// TodoGroupCompleted は完了状態ごとに集計します（キーは "true" / "false"）
+	TodoGroupCompleted TodoGroup = "completed"
+)
+
+// IsValid は集計の単位が既知の値かどうかを判定します
+func (g TodoGroup) IsValid() bool {
+	switch g {
+	case TodoGroupColor, TodoGroupProject, TodoGroupCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// TodoGroupCount はグループごとの件数です
+type TodoGroupCount struct {
+	// Key はグループのキーです（TodoGroup の各定数の説明を参照）
+	Key string
+
+	// Count はグループに含まれるTodoの件数です
+	Count int
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoHistoryRepository はTodoの変更履歴の永続化を抽象化するインターフェースです
+// 履歴は追記のみで、更新・削除の操作は提供しません
+type TodoHistoryRepository interface {
+	// Record は変更履歴を1件保存し、IDと変更日時を設定して返します
+	Record(ctx context.Context, entry *entity.TodoHistoryEntry) (*entity.TodoHistoryEntry, error)
+
+	// ListByTodoID は指定されたTodoの変更履歴を記録順（古い順）に取得します
+	// 履歴がない場合は空のスライスを返します
+	ListByTodoID(ctx context.Context, todoID int) ([]*entity.TodoHistoryEntry, error)
+
+	// ListRecent はTodoの変更履歴のうち、指定した操作のものを新しい順に最大 limit 件取得します
+	// コンテキストに所有者・ワークスペースが設定されている場合は、そのTodoの履歴に限定します
+	// actions が空の場合は全ての操作を対象にします
+	ListRecent(ctx context.Context, actions []entity.TodoHistoryAction, limit int) ([]*entity.TodoHistoryEntry, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoPage はカーソルで取得した一覧の1ページです
+type TodoPage struct {
+	// Todos はこのページのTodoです（GetAll と同じ並び順）
+	Todos []*entity.Todo
+
+	// NextCursor は次のページを取得するカーソルです（最後のページの場合は空）
+	NextCursor string
+}
+
+// TodoPager はカーソルで一覧を1ページずつ取得できる保存先が実装する、TodoRepository の任意のインターフェースです
+// 全件を読み込んでから返す GetAll と違い、保存先のページングをそのまま使います（DynamoDB の実装では LastEvaluatedKey がカーソルです）
+type TodoPager interface {
+	// GetPage は GetAll と同じTodoを、cursor（前のページの NextCursor。最初のページは空）の続きから最大 limit 件取得します
+	// 削除済みのTodoを読み飛ばすため、続きがあっても limit 件より少ない場合があります
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - cursor: 前のページの NextCursor（最初のページは空）
+	//   - limit: 1ページの最大件数
+	// 戻り値:
+	//   - TodoPage: このページのTodoと次のページのカーソル
+	//   - error: 不正なカーソル（domainerr.Invalid）や保存先のエラーの場合
+	GetPage(ctx context.Context, cursor string, limit int) (TodoPage, error)
+}
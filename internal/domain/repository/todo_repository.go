@@ -2,10 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"todoapp-api-golang/internal/domain/entity"
 )
 
+// ErrNoChange は UpdateWithLock の変更関数が「書き込み不要」を示すために返すエラーです
+// このエラーを受け取ったリポジトリは、何も書き込まずにロック中のTodoをそのまま返します
+var ErrNoChange = errors.New("no change")
+
 // TodoRepository はTodoエンティティのデータアクセスを抽象化するインターフェースです
 // Clean Architectureでは、ドメイン層でインターフェースを定義し、
 // インフラストラクチャ層で具体的な実装を行います（依存関係逆転の原則）
@@ -24,16 +29,37 @@ type TodoRepository interface {
 	//   - error: エラーが発生した場合のエラー情報
 	Create(ctx context.Context, todo *entity.Todo) (*entity.Todo, error)
 
+	// CreateMany は複数のTodoを1つのトランザクションでまとめて作成します（一括作成・インポート用）
+	// 1件ずつ Create を呼ぶより往復が少なく、1件でも失敗した場合はどのTodoも作成されません
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - todos: 作成するTodoエンティティ（IDは自動生成される）
+	// 戻り値:
+	//   - []*entity.Todo: 作成されたTodo（IDが設定済み。引数と同じ順序）
+	//   - error: DBエラーの場合
+	CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error)
+
 	// GetByID は指定されたIDのTodoを1件取得します
 	// 引数:
 	//   - ctx: コンテキスト（リクエストライフサイクル管理）
 	//   - id: 取得したいTodoのID
 	// 戻り値:
 	//   - *entity.Todo: 見つかったTodoエンティティ
-	//   - error: Todo が見つからない場合やDBエラーの場合
+	//   - error: Todo が見つからない場合（domainerr.NotFound）やDBエラーの場合
 	GetByID(ctx context.Context, id int) (*entity.Todo, error)
 
+	// Exists は指定されたIDのTodoが存在するかを返します（GetByID と同じく、削除済みと他のユーザーのTodoは存在しない扱い）
+	// 行を読み込まないため、存在の確認だけが必要な場合（権限の確認・子リソースの親の確認）は GetByID より軽量です
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - id: 確認するTodoのID
+	// 戻り値:
+	//   - bool: 存在する場合は true
+	//   - error: DBエラーの場合（存在しない場合はエラーではなく false）
+	Exists(ctx context.Context, id int) (bool, error)
+
 	// GetAll は全てのTodoを取得します
+	// アーカイブ済みのプロジェクトに属するTodoは含みません（GetByColor 等の一覧を返すメソッドも同様）
 	// 実際のアプリケーションでは、ページング（limit/offset）や
 	// フィルタリング、ソート機能を追加することが多いです
 	// 引数:
@@ -43,23 +69,156 @@ type TodoRepository interface {
 	//   - error: DBエラーの場合
 	GetAll(ctx context.Context) ([]*entity.Todo, error)
 
+	// GetByColor は指定した色のTodoを取得します（並び順は GetAll と同じ）
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - color: 正規化済みの色（entity.NormalizeColor）
+	// 戻り値:
+	//   - []*entity.Todo: Todoのスライス（該当なしの場合は空）
+	//   - error: DBエラーの場合
+	GetByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error)
+
+	// Find は条件に一致するTodoを指定した並び順で取得します（一覧と同じく、削除済みとアーカイブ済みのプロジェクトのTodoは含みません）
+	// 色・完了状態・タグ・期限の範囲・文字列検索を組み合わせた絞り込みと並び替えを、DBの実装では1つのSQL文で行います
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - filter: 取得するTodoの条件（ゼロ値の場合は GetAll と同じTodo）
+	//   - order: 並び順（空の場合は GetAll と同じ作成日時の新しい順）
+	// 戻り値:
+	//   - []*entity.Todo: Todoのスライス（該当なしの場合は空）
+	//   - error: 未知の並び順（domainerr.Invalid）やDBエラーの場合
+	Find(ctx context.Context, filter TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error)
+
+	// Count は条件に一致するTodoの件数を返します（一覧と同じく、削除済みとアーカイブ済みのプロジェクトのTodoは数えません）
+	// 件数だけが必要な場合（ページングのメタ情報・利用状況の集計）に、全件を読み込まずに数えるために使用します
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - filter: 件数を数えるTodoの条件（ゼロ値の場合は一覧の全件）
+	// 戻り値:
+	//   - int: 件数
+	//   - error: DBエラーの場合
+	Count(ctx context.Context, filter TodoFilter) (int, error)
+
+	// CountGrouped は条件に一致するTodoの件数をグループごとに返します（キーの昇順。該当なしのグループは含みません）
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - group: 集計の単位（色・プロジェクト・完了状態）
+	//   - filter: 集計するTodoの条件
+	// 戻り値:
+	//   - []TodoGroupCount: グループごとの件数
+	//   - error: 未知の集計の単位（domainerr.Invalid）やDBエラーの場合
+	CountGrouped(ctx context.Context, group TodoGroup, filter TodoFilter) ([]TodoGroupCount, error)
+
+	// GetStats は一覧と同じTodoの件数と見積もり・実績時間の集計を返します
+	// 結果は entity.NewTodoStats(GetAll の結果) と同じですが、DBの実装では集計をSQLで行うためTodoを読み込みません
+	// 引数:
+	//   - ctx: コンテキスト
+	// 戻り値:
+	//   - entity.TodoStats: 集計結果
+	//   - error: DBエラーの場合
+	GetStats(ctx context.Context) (entity.TodoStats, error)
+
+	// ExistsByTitle は同じタイトル（大文字・小文字を区別しない）のTodoが存在するかを返します
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - title: 確認するタイトル
+	//   - excludeID: 判定から除外するTodoのID（更新時の自分自身、作成時は 0）
+	// 戻り値:
+	//   - bool: 存在する場合は true
+	//   - error: DBエラーの場合
+	ExistsByTitle(ctx context.Context, title string, excludeID int) (bool, error)
+
 	// Update は既存のTodoを更新します
 	// 引数:
 	//   - ctx: コンテキスト
 	//   - todo: 更新するTodoエンティティ（IDは必須）
 	// 戻り値:
 	//   - *entity.Todo: 更新されたTodo
-	//   - error: Todo が見つからない場合やDBエラーの場合
+	//   - error: Todo が見つからない場合（domainerr.NotFound）やDBエラーの場合
 	Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error)
 
-	// Delete は指定されたIDのTodoを削除します
+	// UpdateFields は指定したフィールドだけを更新します（他の列は書き込みません）
+	// 変更されたフィールドは entity.DiffTodo で求めます
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - todo: 更新後の値を持つTodoエンティティ（IDは必須）
+	//   - fields: 更新するフィールド（1つ以上）
+	// 戻り値:
+	//   - *entity.Todo: 更新されたTodo
+	//   - error: Todo が見つからない場合（domainerr.NotFound）やDBエラーの場合
+	UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (*entity.Todo, error)
+
+	// UpdateMany は複数のTodoを1つのトランザクションで更新します
+	// 1件でも失敗した場合は、全ての更新を取り消します
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - todos: 更新するTodoエンティティ（IDは必須）
+	// 戻り値:
+	//   - []*entity.Todo: 更新されたTodo（引数と同じ順序）
+	//   - error: いずれかのTodoが見つからない場合やDBエラーの場合
+	UpdateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error)
+
+	// UpdateWithLock は対象の行をロックした状態でTodoを読み込み、mutate で変更してから保存します
+	// 読み込みから書き込みまでを1つのトランザクションで行うため、同じTodoへの並行した更新は順番に処理されます
+	// （DBの実装では SELECT ... FOR UPDATE を使用します）
+	// mutate が ErrNoChange を返した場合は書き込まず、mutate が他のエラーを返した場合はロールバックします
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - id: 更新するTodoのID
+	//   - mutate: ロック中のTodoを変更する関数（トランザクション内で呼ばれるため、長時間の処理は避けます）
+	// 戻り値:
+	//   - *entity.Todo: 更新後のTodo（書き込まなかった場合はロック中に読み込んだTodo）
+	//   - error: Todo が見つからない場合（domainerr.NotFound）、mutate のエラー、DBエラーの場合
+	UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (*entity.Todo, error)
+
+	// Upsert はIDを指定してTodoを作成し、同じIDのTodoが既にある場合はその内容を更新します（インポート・同期用）
+	// 作成時は完了状態も指定どおりに保存します。更新時は作成日時・所有者を変更せず、削除済みのTodoは復元します
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - todo: 保存するTodoエンティティ（IDは必須）
+	// 戻り値:
+	//   - *entity.Todo: 保存されたTodo
+	//   - error: IDが指定されていない場合（domainerr.Invalid）、同じIDの他のユーザーのTodoがある場合（domainerr.NotFound）やDBエラーの場合
+	Upsert(ctx context.Context, todo *entity.Todo) (*entity.Todo, error)
+
+	// Delete は指定されたIDのTodoを削除します（ソフト削除）
+	// 行は残したまま削除日時（DeletedAt）を記録し、以降は GetByID・GetAll 等の既定の取得から除外します
+	// チェックリスト項目や共有も残るため、Restore で削除前の状態に戻せます
 	// 引数:
 	//   - ctx: コンテキスト
 	//   - id: 削除するTodoのID
 	// 戻り値:
-	//   - error: Todo が見つからない場合やDBエラーの場合
+	//   - error: Todo が見つからない場合（削除済みを含む。domainerr.NotFound）やDBエラーの場合
 	// Note: 戻り値はerrorのみです（削除されたレコードの情報は不要なため）
 	Delete(ctx context.Context, id int) error
+
+	// GetDeleted は削除済み（ソフト削除）のTodoを削除日時の新しい順に取得します
+	// ごみ箱の一覧のように、Delete したTodoを参照・復元する画面で使用します
+	// 引数:
+	//   - ctx: コンテキスト
+	// 戻り値:
+	//   - []*entity.Todo: 削除済みのTodo（DeletedAt が設定済み。該当なしの場合は空）
+	//   - error: DBエラーの場合
+	GetDeleted(ctx context.Context) ([]*entity.Todo, error)
+
+	// Restore は削除したTodoを、削除前と同じIDと作成日時のまま保存し直します（取り消し・ごみ箱からの復元用）
+	// ソフト削除された行がある場合は todo の内容で書き戻して削除日時を解除し、
+	// 行がない場合（HardDelete 済みやスナップショットからの読み込み）は同じIDで作成し直します
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - todo: 削除前のTodoエンティティ（IDは必須）
+	// 戻り値:
+	//   - error: 同じIDの削除されていないTodoが既に存在する場合やDBエラーの場合
+	Restore(ctx context.Context, todo *entity.Todo) error
+
+	// HardDelete は指定されたIDのTodoを、チェックリスト項目・共有とともに完全に削除します
+	// 削除済み（ソフト削除）のTodoも対象です。完全に削除したTodoは GetDeleted にも表示されません
+	// 引数:
+	//   - ctx: コンテキスト
+	//   - id: 削除するTodoのID
+	// 戻り値:
+	//   - error: Todo が見つからない場合（domainerr.NotFound）やDBエラーの場合
+	HardDelete(ctx context.Context, id int) error
 }
 
 // メモ：なぜcontextパッケージを使うのか？
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// hookedTodoRepository はTodoRepositoryの各メソッドの前後にフックを呼び出すデコレーターです
+type hookedTodoRepository struct {
+	next  TodoRepository
+	hooks hooks
+}
+
+// WithTodoHooks は next の各操作の前後に hooks を呼び出すようにラップします
+// DB・インメモリのどちらの実装にも適用でき、フックがない場合は next をそのまま返します
+// （例: repository.WithTodoHooks(database.NewTodoRepository(db), tracingHook, metricsHook)）
+func WithTodoHooks(next TodoRepository, hs ...Hook) TodoRepository {
+	if len(hs) == 0 {
+		return next
+	}
+	return &hookedTodoRepository{next: next, hooks: hs}
+}
+
+// op はTodoRepositoryの操作の情報を作成します
+func (r *hookedTodoRepository) op(method string, id int, readOnly bool) Operation {
+	return Operation{Repository: "TodoRepository", Method: method, ID: id, ReadOnly: readOnly}
+}
+
+func (r *hookedTodoRepository) Create(ctx context.Context, todo *entity.Todo) (created *entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("Create", 0, false), func(ctx context.Context) error {
+		created, err = r.next.Create(ctx, todo)
+		return err
+	})
+	return created, err
+}
+
+func (r *hookedTodoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) (created []*entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("CreateMany", 0, false), func(ctx context.Context) error {
+		created, err = r.next.CreateMany(ctx, todos)
+		return err
+	})
+	return created, err
+}
+
+func (r *hookedTodoRepository) GetByID(ctx context.Context, id int) (todo *entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("GetByID", id, true), func(ctx context.Context) error {
+		todo, err = r.next.GetByID(ctx, id)
+		return err
+	})
+	return todo, err
+}
+
+func (r *hookedTodoRepository) Exists(ctx context.Context, id int) (exists bool, err error) {
+	err = r.hooks.run(ctx, r.op("Exists", id, true), func(ctx context.Context) error {
+		exists, err = r.next.Exists(ctx, id)
+		return err
+	})
+	return exists, err
+}
+
+func (r *hookedTodoRepository) GetAll(ctx context.Context) (todos []*entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("GetAll", 0, true), func(ctx context.Context) error {
+		todos, err = r.next.GetAll(ctx)
+		return err
+	})
+	return todos, err
+}
+
+func (r *hookedTodoRepository) GetByColor(ctx context.Context, color entity.Color) (todos []*entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("GetByColor", 0, true), func(ctx context.Context) error {
+		todos, err = r.next.GetByColor(ctx, color)
+		return err
+	})
+	return todos, err
+}
+
+func (r *hookedTodoRepository) Find(ctx context.Context, filter TodoFilter, order entity.TodoSortOrder) (todos []*entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("Find", 0, true), func(ctx context.Context) error {
+		todos, err = r.next.Find(ctx, filter, order)
+		return err
+	})
+	return todos, err
+}
+
+func (r *hookedTodoRepository) Count(ctx context.Context, filter TodoFilter) (count int, err error) {
+	err = r.hooks.run(ctx, r.op("Count", 0, true), func(ctx context.Context) error {
+		count, err = r.next.Count(ctx, filter)
+		return err
+	})
+	return count, err
+}
+
+func (r *hookedTodoRepository) CountGrouped(ctx context.Context, group TodoGroup, filter TodoFilter) (counts []TodoGroupCount, err error) {
+	err = r.hooks.run(ctx, r.op("CountGrouped", 0, true), func(ctx context.Context) error {
+		counts, err = r.next.CountGrouped(ctx, group, filter)
+		return err
+	})
+	return counts, err
+}
+
+func (r *hookedTodoRepository) GetStats(ctx context.Context) (stats entity.TodoStats, err error) {
+	err = r.hooks.run(ctx, r.op("GetStats", 0, true), func(ctx context.Context) error {
+		stats, err = r.next.GetStats(ctx)
+		return err
+	})
+	return stats, err
+}
+
+func (r *hookedTodoRepository) ExistsByTitle(ctx context.Context, title string, excludeID int) (exists bool, err error) {
+	err = r.hooks.run(ctx, r.op("ExistsByTitle", 0, true), func(ctx context.Context) error {
+		exists, err = r.next.ExistsByTitle(ctx, title, excludeID)
+		return err
+	})
+	return exists, err
+}
+
+func (r *hookedTodoRepository) Update(ctx context.Context, todo *entity.Todo) (updated *entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("Update", todo.ID, false), func(ctx context.Context) error {
+		updated, err = r.next.Update(ctx, todo)
+		return err
+	})
+	return updated, err
+}
+
+func (r *hookedTodoRepository) UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (updated *entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("UpdateFields", todo.ID, false), func(ctx context.Context) error {
+		updated, err = r.next.UpdateFields(ctx, todo, fields)
+		return err
+	})
+	return updated, err
+}
+
+func (r *hookedTodoRepository) UpdateMany(ctx context.Context, todos []*entity.Todo) (updated []*entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("UpdateMany", 0, false), func(ctx context.Context) error {
+		updated, err = r.next.UpdateMany(ctx, todos)
+		return err
+	})
+	return updated, err
+}
+
+func (r *hookedTodoRepository) UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (updated *entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("UpdateWithLock", id, false), func(ctx context.Context) error {
+		updated, err = r.next.UpdateWithLock(ctx, id, mutate)
+		return err
+	})
+	return updated, err
+}
+
+func (r *hookedTodoRepository) Upsert(ctx context.Context, todo *entity.Todo) (upserted *entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("Upsert", todo.ID, false), func(ctx context.Context) error {
+		upserted, err = r.next.Upsert(ctx, todo)
+		return err
+	})
+	return upserted, err
+}
+
+func (r *hookedTodoRepository) Delete(ctx context.Context, id int) error {
+	return r.hooks.run(ctx, r.op("Delete", id, false), func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+func (r *hookedTodoRepository) GetDeleted(ctx context.Context) (todos []*entity.Todo, err error) {
+	err = r.hooks.run(ctx, r.op("GetDeleted", 0, true), func(ctx context.Context) error {
+		todos, err = r.next.GetDeleted(ctx)
+		return err
+	})
+	return todos, err
+}
+
+func (r *hookedTodoRepository) Restore(ctx context.Context, todo *entity.Todo) error {
+	return r.hooks.run(ctx, r.op("Restore", todo.ID, false), func(ctx context.Context) error {
+		return r.next.Restore(ctx, todo)
+	})
+}
+
+func (r *hookedTodoRepository) HardDelete(ctx context.Context, id int) error {
+	return r.hooks.run(ctx, r.op("HardDelete", id, false), func(ctx context.Context) error {
+		return r.next.HardDelete(ctx, id)
+	})
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// stubTodoRepository はテスト用のTodoRepositoryです（使用しないメソッドは埋め込んだ nil のインターフェースに委譲します）
+type stubTodoRepository struct {
+	TodoRepository
+	calls []string
+}
+
+type traceKey struct{}
+
+func (r *stubTodoRepository) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
+	r.calls = append(r.calls, fmt.Sprintf("GetByID(trace=%v)", ctx.Value(traceKey{})))
+	if id == 0 {
+		return nil, errors.New("not found")
+	}
+	return &entity.Todo{ID: id}, nil
+}
+
+func (r *stubTodoRepository) Delete(ctx context.Context, id int) error {
+	r.calls = append(r.calls, "Delete")
+	return nil
+}
+
+// recordingHook は呼び出しを記録するフックです
+func recordingHook(name string, calls *[]string) Hook {
+	return HookFuncs{
+		BeforeFunc: func(ctx context.Context, op Operation) context.Context {
+			*calls = append(*calls, fmt.Sprintf("%s.Before(%s.%s id=%d readOnly=%v)", name, op.Repository, op.Method, op.ID, op.ReadOnly))
+			return context.WithValue(ctx, traceKey{}, name)
+		},
+		AfterFunc: func(ctx context.Context, op Operation, err error) {
+			*calls = append(*calls, fmt.Sprintf("%s.After(%s err=%v trace=%v)", name, op.Method, err, ctx.Value(traceKey{})))
+		},
+	}
+}
+
+// TestWithTodoHooks はフックが操作の前後に登録順（After は逆順）で呼ばれることをテストします
+func TestWithTodoHooks(t *testing.T) {
+	stub := &stubTodoRepository{}
+	repo := WithTodoHooks(stub, recordingHook("outer", &stub.calls), recordingHook("inner", &stub.calls))
+	ctx := context.Background()
+
+	todo, err := repo.GetByID(ctx, 7)
+	if err != nil || todo.ID != 7 {
+		t.Fatalf("GetByID() = %v, %v", todo, err)
+	}
+	if _, err := repo.GetByID(ctx, 0); err == nil {
+		t.Fatal("GetByID() のエラーがフックから返されませんでした")
+	}
+	if err := repo.Delete(ctx, 3); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	expected := []string{
+		"outer.Before(TodoRepository.GetByID id=7 readOnly=true)",
+		"inner.Before(TodoRepository.GetByID id=7 readOnly=true)",
+		"GetByID(trace=inner)",
+		"inner.After(GetByID err=<nil> trace=inner)",
+		"outer.After(GetByID err=<nil> trace=inner)",
+		"outer.Before(TodoRepository.GetByID id=0 readOnly=true)",
+		"inner.Before(TodoRepository.GetByID id=0 readOnly=true)",
+		"GetByID(trace=inner)",
+		"inner.After(GetByID err=not found trace=inner)",
+		"outer.After(GetByID err=not found trace=inner)",
+		"outer.Before(TodoRepository.Delete id=3 readOnly=false)",
+		"inner.Before(TodoRepository.Delete id=3 readOnly=false)",
+		"Delete",
+		"inner.After(Delete err=<nil> trace=inner)",
+		"outer.After(Delete err=<nil> trace=inner)",
+	}
+	if !reflect.DeepEqual(stub.calls, expected) {
+		t.Errorf("呼び出し =\n%v\n期待値 =\n%v", stub.calls, expected)
+	}
+}
+
+// TestWithTodoHooks_NoHooks はフックがない場合に元のリポジトリをそのまま返すことをテストします
+func TestWithTodoHooks_NoHooks(t *testing.T) {
+	stub := &stubTodoRepository{}
+	if repo := WithTodoHooks(stub); repo != stub {
+		t.Errorf("WithTodoHooks() = %T, 期待値 = 元のリポジトリ", repo)
+	}
+}
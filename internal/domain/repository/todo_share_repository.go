@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoShareRepository はTodoの共有設定のデータアクセスを抽象化するインターフェースです
+// 共有するTodoの所有者の確認はサービス層（TodoShareService）で行います
+type TodoShareRepository interface {
+	// Save は共有を作成します。同じTodoとユーザーの共有が既にある場合は権限だけを置き換えます
+	// 保存した共有（作成日時は最初に共有した日時）を返します
+	Save(ctx context.Context, share *entity.TodoShare) (*entity.TodoShare, error)
+
+	// Get はTodoとユーザーの組み合わせで共有を取得します
+	// 存在しない場合は domainerr.NotFound("todo share") のエラーを返します
+	Get(ctx context.Context, todoID, userID int) (*entity.TodoShare, error)
+
+	// ListByTodoID はTodoの共有を共有先のユーザー名の順に取得します
+	ListByTodoID(ctx context.Context, todoID int) ([]*entity.TodoShare, error)
+
+	// Delete は共有を削除します
+	// 存在しない場合は domainerr.NotFound("todo share") のエラーを返します
+	Delete(ctx context.Context, todoID, userID int) error
+}
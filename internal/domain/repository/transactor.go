@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// Transactor は複数のリポジトリへの書き込みを1つのトランザクションにまとめるインターフェースです
+type Transactor interface {
+	// InTransaction は fn を1つのトランザクションで実行します
+	// fn に渡すコンテキストを使ったリポジトリの処理は全て同じトランザクションに参加し、
+	// fn がエラーを返した場合は全てロールバックされます
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ErrUsernameTaken は同じユーザー名のユーザーが既にいる場合に Create が返すエラーです
+var ErrUsernameTaken = errors.New("username is already taken")
+
+// UserRepository はユーザーのデータアクセスを抽象化するインターフェースです
+type UserRepository interface {
+	// Create はユーザーを作成し、採番されたIDと作成日時を設定して返します
+	// 同じユーザー名のユーザーが既にいる場合は ErrUsernameTaken を返します
+	Create(ctx context.Context, user *entity.User) (*entity.User, error)
+
+	// GetByID はIDでユーザーを取得します
+	// 存在しない場合は domainerr.NotFound("user") のエラーを返します
+	GetByID(ctx context.Context, id int) (*entity.User, error)
+
+	// GetByUsername はユーザー名でユーザーを取得します
+	// 存在しない場合は domainerr.NotFound("user") のエラーを返します
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+}
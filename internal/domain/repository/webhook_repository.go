@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// WebhookRepository はWebhookの登録のデータアクセスを抽象化するインターフェースです
+// TodoRepository と同じく、コンテキストに所有者・ワークスペース（WithOwner・WithWorkspace）が設定されている場合は
+// その範囲の登録だけを扱い、作成した登録はその所有者・ワークスペースのものになります
+type WebhookRepository interface {
+	// Create はWebhookを登録し、採番されたIDと登録日時を設定して返します
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+
+	// GetByID はIDでWebhookの登録を取得します
+	// 存在しない場合は domainerr.NotFound("webhook") のエラーを返します
+	GetByID(ctx context.Context, id int) (*entity.WebhookSubscription, error)
+
+	// List はWebhookの登録をID順に取得します
+	List(ctx context.Context) ([]*entity.WebhookSubscription, error)
+
+	// Delete はWebhookの登録を削除します
+	// 存在しない場合は domainerr.NotFound("webhook") のエラーを返します
+	Delete(ctx context.Context, id int) error
+}
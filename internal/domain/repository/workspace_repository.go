@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// WorkspaceRepository はワークスペース・メンバーシップ・招待のデータアクセスを抽象化するインターフェースです
+// 操作するユーザーがメンバーか・所有者かの確認はサービス層（WorkspaceService）で行います
+type WorkspaceRepository interface {
+	// Create はワークスペースを作成し、ownerID のユーザーを所有者として参加させます（1つのトランザクションで行います）
+	// 採番されたIDと作成日時を設定して返します
+	Create(ctx context.Context, workspace *entity.Workspace, ownerID int) (*entity.Workspace, error)
+
+	// GetByID はIDでワークスペースを取得します
+	// 存在しない場合は domainerr.NotFound("workspace") のエラーを返します
+	GetByID(ctx context.Context, id int) (*entity.Workspace, error)
+
+	// ListByUser はユーザーが参加しているワークスペースを作成順に取得します
+	ListByUser(ctx context.Context, userID int) ([]*entity.Workspace, error)
+
+	// AddMember はユーザーをワークスペースに参加させます
+	// 既に参加している場合は何もしません（役割も変わりません）
+	AddMember(ctx context.Context, member *entity.WorkspaceMember) error
+
+	// GetMember はワークスペースとユーザーの組み合わせでメンバーシップを取得します
+	// 参加していない場合は domainerr.NotFound("workspace member") のエラーを返します
+	GetMember(ctx context.Context, workspaceID, userID int) (*entity.WorkspaceMember, error)
+
+	// ListMembers はワークスペースのメンバーをユーザー名の順に取得します
+	ListMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error)
+
+	// RemoveMember はユーザーをワークスペースから外します
+	// 参加していない場合は domainerr.NotFound("workspace member") のエラーを返します
+	RemoveMember(ctx context.Context, workspaceID, userID int) error
+
+	// CreateInvitation は招待を保存し、採番されたIDを設定して返します
+	CreateInvitation(ctx context.Context, invitation *entity.WorkspaceInvitation) (*entity.WorkspaceInvitation, error)
+
+	// GetInvitationByHash は招待トークンのハッシュで招待を取得します
+	// 存在しない場合は domainerr.NotFound("workspace invitation") のエラーを返します
+	GetInvitationByHash(ctx context.Context, tokenHash string) (*entity.WorkspaceInvitation, error)
+
+	// DeleteInvitation は招待を削除します
+	// 存在しない（既に使われた）場合は domainerr.NotFound("workspace invitation") のエラーを返します
+	DeleteInvitation(ctx context.Context, id int) error
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// actorKey はコンテキストに操作者を格納するためのキーです
+type actorKey struct{}
+
+// WithActor は操作者（変更履歴の「誰が」）をコンテキストに設定します
+// HTTPリクエストではミドルウェアが設定し、サービスはこの値を履歴に記録します
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext はコンテキストから操作者を取得します
+// 設定されていない場合は entity.AnonymousActor を返します
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return entity.AnonymousActor
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/pkg/jwt"
+	"todoapp-api-golang/pkg/password"
+)
+
+// ErrInvalidCredentials はユーザー名またはパスワードが一致しない場合のエラーです
+// どちらが違うかは区別しません（存在するユーザー名を推測させないため）
+// ハンドラー層はこのエラーを 401 Unauthorized として返します
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// ErrInvalidRefreshToken はリフレッシュトークンが存在しない・有効期限切れ・失効済みの場合のエラーです
+// ハンドラー層はこのエラーを 401 Unauthorized として返します
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+// ErrRefreshTokenReused は使用済みのリフレッシュトークンが再び使われた場合のエラーです
+// トークンが盗まれた可能性があるため、同じ系列のトークンは全て失効させています
+// ハンドラー層はこのエラーを 401 Unauthorized として返します（再ログインが必要）
+var ErrRefreshTokenReused = errors.New("refresh token reuse detected; all sessions from this login have been revoked")
+
+// ErrInvalidAccessToken はアクセストークンの署名が不正・有効期限切れの場合のエラーです
+var ErrInvalidAccessToken = errors.New("invalid or expired access token")
+
+// ErrScopeNotGranted は自分のトークンに許可されていないスコープのトークンを発行しようとした場合のエラーです
+// ハンドラー層はこのエラーを 403 Forbidden として返します
+var ErrScopeNotGranted = errors.New("cannot grant a scope that the current token does not have")
+
+// refreshTokenBytes はリフレッシュトークンのランダムなバイト数です
+const refreshTokenBytes = 32
+
+// MaxScopedTokenTTL はスコープを限定したアクセストークンの有効期間の上限です
+// 保存せずに署名だけで検証するため失効させられず、長すぎる有効期間は許可しません
+const MaxScopedTokenTTL = 90 * 24 * time.Hour
+
+// TokenPair は発行したアクセストークンとリフレッシュトークンです
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+	User                  *entity.User
+}
+
+// ScopedToken はスコープを限定して発行したアクセストークンです
+type ScopedToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	Scopes      []string
+}
+
+// AuthService はユーザーの登録・ログインとトークンの発行を行うサービスです
+//
+// 学習ポイント：
+//  1. アクセストークンは署名付きのJWTで、有効期限を短くする（サーバーは保存せずに署名だけで検証する）
+//     代わりに失効させられないため、漏洩しても被害は有効期限の間に限られるようにする
+//  2. リフレッシュトークンはランダムな値で、SHA-256 のハッシュだけをデータベースに保存する
+//     （データベースが漏洩しても、ハッシュからトークンを復元して使うことはできない）
+//  3. リフレッシュのたびに新しいリフレッシュトークンを発行し、古いものは使用済みにする（ローテーション）
+//  4. 使用済みのトークンが再び使われたら、正規の利用者と攻撃者のどちらかがトークンを盗んでいる
+//     どちらが正規かは判別できないため、同じログインから発行した系列のトークンを全て失効させる（再利用の検知）
+type AuthService struct {
+	users         repository.UserRepository
+	refreshTokens repository.RefreshTokenRepository
+	secret        []byte
+	accessTTL     time.Duration
+	refreshTTL    time.Duration
+
+	// hashPassword はパスワードのハッシュ化関数です（テストで繰り返し回数を減らすためのフィールド）
+	hashPassword func(string) (string, error)
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewAuthService はAuthServiceのコンストラクタです
+// secret はアクセストークンの署名鍵、accessTTL / refreshTTL はそれぞれのトークンの有効期間です
+func NewAuthService(users repository.UserRepository, refreshTokens repository.RefreshTokenRepository, secret []byte, accessTTL, refreshTTL time.Duration) *AuthService {
+	return &AuthService{
+		users:         users,
+		refreshTokens: refreshTokens,
+		secret:        secret,
+		accessTTL:     accessTTL,
+		refreshTTL:    refreshTTL,
+		hashPassword:  password.Hash,
+		now:           time.Now,
+	}
+}
+
+// Register はユーザーを登録します
+// ユーザー名・パスワードが制約を満たさない場合は domainerr.Invalid、ユーザー名が使用済みの場合は repository.ErrUsernameTaken を返します
+func (s *AuthService) Register(ctx context.Context, username, pw string) (*entity.User, error) {
+	if !entity.ValidUsername(username) {
+		return nil, domainerr.Invalid("username", fmt.Sprintf("must be %d-%d characters of letters, digits, '_', '.' or '-'", entity.MinUsernameLength, entity.MaxUsernameLength))
+	}
+	if n := utf8.RuneCountInString(pw); n < entity.MinPasswordLength || n > entity.MaxPasswordLength {
+		return nil, domainerr.Invalid("password", fmt.Sprintf("must be %d-%d characters", entity.MinPasswordLength, entity.MaxPasswordLength))
+	}
+
+	hash, err := s.hashPassword(pw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user, err := s.users.Create(ctx, &entity.User{Username: username, PasswordHash: hash})
+	if err != nil {
+		if errors.Is(err, repository.ErrUsernameTaken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return user, nil
+}
+
+// Login はユーザー名とパスワードを照合し、新しい系列のトークンを発行します
+// 一致しない場合は ErrInvalidCredentials を返します
+func (s *AuthService) Login(ctx context.Context, username, pw string) (*TokenPair, error) {
+	user, err := s.users.GetByUsername(ctx, username)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !password.Verify(pw, user.PasswordHash) {
+		return nil, ErrInvalidCredentials
+	}
+
+	familyID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(ctx, user, familyID)
+}
+
+// Refresh はリフレッシュトークンを使用済みにし、同じ系列の新しいトークンの組を発行します
+//
+// 存在しない・有効期限切れ・失効済みのトークンは ErrInvalidRefreshToken、
+// 使用済みのトークンは系列を全て失効させて ErrRefreshTokenReused を返します
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	now := s.now().UTC()
+	record, err := s.refreshTokens.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if record.RevokedAt != nil || record.Expired(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if record.UsedAt != nil {
+		return nil, s.revokeReused(ctx, record.FamilyID, now)
+	}
+
+	// 同時に同じトークンでリフレッシュした場合も、使用済みにできるのは1つだけ
+	if err := s.refreshTokens.MarkUsed(ctx, record.ID, now); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenUsed) {
+			return nil, s.revokeReused(ctx, record.FamilyID, now)
+		}
+		return nil, fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+
+	user, err := s.users.GetByID(ctx, record.UserID)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return s.issue(ctx, user, record.FamilyID)
+}
+
+// Logout はリフレッシュトークンの系列を失効させます
+// 存在しないトークンの場合は ErrInvalidRefreshToken を返します（失効済みのトークンは成功として扱う）
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	record, err := s.refreshTokens.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return ErrInvalidRefreshToken
+		}
+		return fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if _, err := s.refreshTokens.RevokeFamily(ctx, record.FamilyID, s.now().UTC()); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// VerifyAccessToken はアクセストークンの署名と有効期限を検証し、認証されたユーザーを返します
+// 不正・有効期限切れの場合は ErrInvalidAccessToken を返します
+func (s *AuthService) VerifyAccessToken(token string) (Principal, error) {
+	claims, err := jwt.Parse(token, s.secret, s.now())
+	if err != nil {
+		return Principal{}, ErrInvalidAccessToken
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || userID <= 0 {
+		return Principal{}, ErrInvalidAccessToken
+	}
+	principal := Principal{UserID: userID, Username: claims.Name}
+	if claims.Scope != "" {
+		principal.Scopes = strings.Fields(claims.Scope)
+	}
+	return principal, nil
+}
+
+// CreateScopedToken は認証されたユーザー principal に、スコープを scopes に限定したアクセストークンを発行します
+// 外部のツールやスクリプトに、必要な操作だけを許可したトークンを渡すためのものです（リフレッシュトークンは発行しない）
+//
+// ttl が 0 の場合はログインのアクセストークンと同じ有効期間にします
+// スコープが空・未定義、ttl が範囲外の場合は domainerr.Invalid、
+// principal 自身に許可されていないスコープを含む場合は ErrScopeNotGranted を返します
+func (s *AuthService) CreateScopedToken(principal Principal, scopes []string, ttl time.Duration) (*ScopedToken, error) {
+	if len(scopes) == 0 {
+		return nil, domainerr.Invalid("scopes", "must contain at least one of "+strings.Join(entity.Scopes, ", "))
+	}
+	granted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !entity.ValidScope(scope) {
+			return nil, domainerr.Invalid("scopes", fmt.Sprintf("unknown scope %q (must be one of %s)", scope, strings.Join(entity.Scopes, ", ")))
+		}
+		if !principal.HasScope(scope) {
+			return nil, ErrScopeNotGranted
+		}
+		if !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	slices.Sort(granted)
+
+	if ttl == 0 {
+		ttl = s.accessTTL
+	}
+	if ttl < time.Second || ttl > MaxScopedTokenTTL {
+		return nil, domainerr.Invalid("expires_in", fmt.Sprintf("must be between 1 and %d seconds", int64(MaxScopedTokenTTL/time.Second)))
+	}
+
+	now := s.now().UTC().Truncate(time.Second)
+	expiresAt := now.Add(ttl)
+	token, err := jwt.Sign(jwt.Claims{
+		Subject:   strconv.Itoa(principal.UserID),
+		Name:      principal.Username,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		Scope:     strings.Join(granted, " "),
+	}, s.secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return &ScopedToken{AccessToken: token, ExpiresAt: expiresAt, Scopes: granted}, nil
+}
+
+// PurgeExpired は有効期限を過ぎたリフレッシュトークンの記録を削除します
+func (s *AuthService) PurgeExpired(ctx context.Context) (int, error) {
+	return s.refreshTokens.DeleteExpired(ctx, s.now().UTC())
+}
+
+// issue はアクセストークンと、系列 familyID の新しいリフレッシュトークンを発行します
+func (s *AuthService) issue(ctx context.Context, user *entity.User, familyID string) (*TokenPair, error) {
+	now := s.now().UTC().Truncate(time.Second)
+	accessExpiresAt := now.Add(s.accessTTL)
+	accessToken, err := jwt.Sign(jwt.Claims{
+		Subject:   strconv.Itoa(user.ID),
+		Name:      user.Username,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: accessExpiresAt.Unix(),
+	}, s.secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	refreshToken, err := randomToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	refreshExpiresAt := now.Add(s.refreshTTL)
+	if _, err := s.refreshTokens.Create(ctx, &entity.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		CreatedAt: now,
+		ExpiresAt: refreshExpiresAt,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+		User:                  user,
+	}, nil
+}
+
+// revokeReused は再利用を検知した系列を全て失効させ、ErrRefreshTokenReused を返します
+func (s *AuthService) revokeReused(ctx context.Context, familyID string, now time.Time) error {
+	if _, err := s.refreshTokens.RevokeFamily(ctx, familyID, now); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// randomToken は n バイトの暗号論的な乱数を base64url（パディングなし）で返します
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken はリフレッシュトークンの保存・検索に使う SHA-256 のハッシュ（16進数）を返します
+// トークンは十分に長いランダムな値のため、パスワードと違いソルトや繰り返しは不要です
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
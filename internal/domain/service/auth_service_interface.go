@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// AuthServiceInterface はユーザー認証サービスのインターフェースです
+// ハンドラーとミドルウェアのテストでモック実装に差し替えられるように定義しています
+type AuthServiceInterface interface {
+	// Register はユーザーを登録します
+	Register(ctx context.Context, username, password string) (*entity.User, error)
+
+	// Login はユーザー名とパスワードを照合し、アクセストークンとリフレッシュトークンを発行します
+	Login(ctx context.Context, username, password string) (*TokenPair, error)
+
+	// Refresh はリフレッシュトークンを新しいトークンの組に交換します（ローテーション）
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+
+	// Logout はリフレッシュトークンの系列を失効させます
+	Logout(ctx context.Context, refreshToken string) error
+
+	// VerifyAccessToken はアクセストークンを検証し、認証されたユーザーを返します
+	VerifyAccessToken(token string) (Principal, error)
+
+	// CreateScopedToken は認証されたユーザーに、スコープを限定したアクセストークンを発行します
+	CreateScopedToken(principal Principal, scopes []string, ttl time.Duration) (*ScopedToken, error)
+
+	// PurgeExpired は有効期限を過ぎたリフレッシュトークンの記録を削除します
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ AuthServiceInterface = (*AuthService)(nil)
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/pkg/password"
+)
+
+// MockUserRepository はテスト用のUserRepositoryのモック実装です
+type MockUserRepository struct {
+	users  []*entity.User
+	nextID int
+}
+
+// NewMockUserRepository はモックリポジトリを作成します
+func NewMockUserRepository() *MockUserRepository {
+	return &MockUserRepository{nextID: 1}
+}
+
+// Create はユーザーを保存します（モック実装）
+func (m *MockUserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	for _, u := range m.users {
+		if u.Username == user.Username {
+			return nil, repository.ErrUsernameTaken
+		}
+	}
+	user.ID = m.nextID
+	m.nextID++
+	stored := *user
+	m.users = append(m.users, &stored)
+	return user, nil
+}
+
+// GetByID はIDでユーザーを取得します（モック実装）
+func (m *MockUserRepository) GetByID(ctx context.Context, id int) (*entity.User, error) {
+	for _, u := range m.users {
+		if u.ID == id {
+			userCopy := *u
+			return &userCopy, nil
+		}
+	}
+	return nil, domainerr.NotFound("user", nil)
+}
+
+// GetByUsername はユーザー名でユーザーを取得します（モック実装）
+func (m *MockUserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	for _, u := range m.users {
+		if u.Username == username {
+			userCopy := *u
+			return &userCopy, nil
+		}
+	}
+	return nil, domainerr.NotFound("user", nil)
+}
+
+// MockRefreshTokenRepository はテスト用のRefreshTokenRepositoryのモック実装です
+type MockRefreshTokenRepository struct {
+	tokens []*entity.RefreshToken
+}
+
+// Create は記録を保存します（モック実装）
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	token.ID = len(m.tokens) + 1
+	stored := *token
+	m.tokens = append(m.tokens, &stored)
+	return token, nil
+}
+
+// GetByHash はハッシュで記録を取得します（モック実装）
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			tokenCopy := *t
+			return &tokenCopy, nil
+		}
+	}
+	return nil, domainerr.NotFound("refresh token", nil)
+}
+
+// MarkUsed は使用日時を記録します（モック実装）
+func (m *MockRefreshTokenRepository) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	for _, t := range m.tokens {
+		if t.ID == id {
+			if t.UsedAt != nil || t.RevokedAt != nil {
+				return repository.ErrRefreshTokenUsed
+			}
+			t.UsedAt = &usedAt
+			return nil
+		}
+	}
+	return repository.ErrRefreshTokenUsed
+}
+
+// RevokeFamily は系列を失効させます（モック実装）
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) (int, error) {
+	n := 0
+	for _, t := range m.tokens {
+		if t.FamilyID == familyID && t.RevokedAt == nil {
+			t.RevokedAt = &revokedAt
+			n++
+		}
+	}
+	return n, nil
+}
+
+// DeleteExpired は有効期限を過ぎた記録を削除します（モック実装）
+func (m *MockRefreshTokenRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	kept := m.tokens[:0]
+	for _, t := range m.tokens {
+		if t.ExpiresAt.After(before) {
+			kept = append(kept, t)
+		}
+	}
+	n := len(m.tokens) - len(kept)
+	m.tokens = kept
+	return n, nil
+}
+
+// newTestAuthService はテスト用に、繰り返し回数を減らして時刻を固定したAuthServiceを作成します
+func newTestAuthService(now *time.Time) (*AuthService, *MockRefreshTokenRepository) {
+	tokens := &MockRefreshTokenRepository{}
+	s := NewAuthService(NewMockUserRepository(), tokens, []byte("0123456789abcdef0123456789abcdef"), 15*time.Minute, 24*time.Hour)
+	s.hashPassword = func(pw string) (string, error) { return password.HashWithIterations(pw, 1000) }
+	s.now = func() time.Time { return *now }
+	return s, tokens
+}
+
+// TestAuthService_Register はユーザー登録の入力検証と重複をテストします
+func TestAuthService_Register(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	s, _ := newTestAuthService(&now)
+	ctx := context.Background()
+
+	user, err := s.Register(ctx, "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if user.ID == 0 || user.PasswordHash == "correct horse" || !password.Verify("correct horse", user.PasswordHash) {
+		t.Errorf("登録したユーザー = %+v", user)
+	}
+
+	tests := []struct {
+		name        string
+		username    string
+		password    string
+		expectedErr func(error) bool
+	}{
+		{name: "使用済みのユーザー名", username: "alice", password: "correct horse", expectedErr: func(err error) bool { return errors.Is(err, repository.ErrUsernameTaken) }},
+		{name: "短いユーザー名", username: "al", password: "correct horse", expectedErr: domainerr.IsInvalid},
+		{name: "使えない文字", username: "al ice", password: "correct horse", expectedErr: domainerr.IsInvalid},
+		{name: "短いパスワード", username: "bob", password: "short", expectedErr: domainerr.IsInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Register(ctx, tt.username, tt.password); !tt.expectedErr(err) {
+				t.Errorf("Register() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestAuthService_LoginAndVerify はログインとアクセストークンの検証をテストします
+func TestAuthService_LoginAndVerify(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	s, _ := newTestAuthService(&now)
+	ctx := context.Background()
+	user, _ := s.Register(ctx, "alice", "correct horse")
+
+	if _, err := s.Login(ctx, "alice", "wrong password"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("パスワードの誤り error = %v, 期待値 = ErrInvalidCredentials", err)
+	}
+	if _, err := s.Login(ctx, "nobody", "correct horse"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("存在しないユーザー error = %v, 期待値 = ErrInvalidCredentials", err)
+	}
+
+	pair, err := s.Login(ctx, "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if !pair.AccessTokenExpiresAt.Equal(now.Add(15*time.Minute)) || !pair.RefreshTokenExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("有効期限 = %v / %v", pair.AccessTokenExpiresAt, pair.RefreshTokenExpiresAt)
+	}
+
+	principal, err := s.VerifyAccessToken(pair.AccessToken)
+	if err != nil || principal.UserID != user.ID || principal.Username != "alice" || principal.Scopes != nil {
+		t.Errorf("VerifyAccessToken() = %+v, %v", principal, err)
+	}
+	if _, err := s.VerifyAccessToken(pair.RefreshToken); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Errorf("リフレッシュトークンをアクセストークンとして検証 error = %v", err)
+	}
+	now = now.Add(15 * time.Minute)
+	if _, err := s.VerifyAccessToken(pair.AccessToken); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Errorf("有効期限切れのアクセストークン error = %v", err)
+	}
+}
+
+// TestAuthService_Refresh はリフレッシュトークンのローテーションと再利用の検知をテストします
+func TestAuthService_Refresh(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	s, tokens := newTestAuthService(&now)
+	ctx := context.Background()
+	s.Register(ctx, "alice", "correct horse")
+
+	first, err := s.Login(ctx, "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	// トークンはハッシュだけを保存する
+	if tokens.tokens[0].TokenHash == first.RefreshToken || tokens.tokens[0].TokenHash != hashToken(first.RefreshToken) {
+		t.Errorf("保存したハッシュ = %s", tokens.tokens[0].TokenHash)
+	}
+
+	now = now.Add(time.Hour)
+	second, err := s.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken || second.User.Username != "alice" {
+		t.Errorf("新しいトークンが発行されていません: %+v", second)
+	}
+	if !second.RefreshTokenExpiresAt.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("新しいリフレッシュトークンの有効期限 = %v", second.RefreshTokenExpiresAt)
+	}
+	if tokens.tokens[1].FamilyID != tokens.tokens[0].FamilyID {
+		t.Error("ローテーションで発行したトークンが同じ系列になっていません")
+	}
+
+	// 使用済みのトークンの再利用で、系列の全てのトークンが失効する
+	if _, err := s.Refresh(ctx, first.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("使用済みのトークン error = %v, 期待値 = ErrRefreshTokenReused", err)
+	}
+	if _, err := s.Refresh(ctx, second.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("失効した系列のトークン error = %v, 期待値 = ErrInvalidRefreshToken", err)
+	}
+
+	// 別のログインの系列は影響を受けない
+	other, _ := s.Login(ctx, "alice", "correct horse")
+	if _, err := s.Refresh(ctx, other.RefreshToken); err != nil {
+		t.Errorf("別の系列のリフレッシュ error = %v", err)
+	}
+
+	if _, err := s.Refresh(ctx, "unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("存在しないトークン error = %v, 期待値 = ErrInvalidRefreshToken", err)
+	}
+}
+
+// TestAuthService_RefreshExpired は有効期限を過ぎたリフレッシュトークンの拒否と削除をテストします
+func TestAuthService_RefreshExpired(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	s, tokens := newTestAuthService(&now)
+	ctx := context.Background()
+	s.Register(ctx, "alice", "correct horse")
+	pair, _ := s.Login(ctx, "alice", "correct horse")
+
+	now = now.Add(24 * time.Hour)
+	if _, err := s.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("有効期限切れのトークン error = %v, 期待値 = ErrInvalidRefreshToken", err)
+	}
+	if n, err := s.PurgeExpired(ctx); err != nil || n != 1 || len(tokens.tokens) != 0 {
+		t.Errorf("PurgeExpired() = %d, %v, 期待値 = 1", n, err)
+	}
+}
+
+// TestAuthService_Logout はログアウトで系列のトークンが失効することをテストします
+func TestAuthService_Logout(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	s, _ := newTestAuthService(&now)
+	ctx := context.Background()
+	s.Register(ctx, "alice", "correct horse")
+	pair, _ := s.Login(ctx, "alice", "correct horse")
+
+	if err := s.Logout(ctx, pair.RefreshToken); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := s.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("ログアウト後のリフレッシュ error = %v, 期待値 = ErrInvalidRefreshToken", err)
+	}
+	// 失効済みのトークンでのログアウトも成功する（クライアントの再試行）
+	if err := s.Logout(ctx, pair.RefreshToken); err != nil {
+		t.Errorf("2回目の Logout() error = %v", err)
+	}
+	if err := s.Logout(ctx, "unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("存在しないトークン error = %v, 期待値 = ErrInvalidRefreshToken", err)
+	}
+}
+
+// TestAuthService_CreateScopedToken はスコープを限定したアクセストークンの発行と検証をテストします
+func TestAuthService_CreateScopedToken(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	s, _ := newTestAuthService(&now)
+	alice := Principal{UserID: 1, Username: "alice"}
+	readOnly := Principal{UserID: 1, Username: "alice", Scopes: []string{entity.ScopeTodosRead}}
+
+	tests := []struct {
+		name           string
+		principal      Principal
+		scopes         []string
+		ttl            time.Duration
+		expectedScopes []string
+		expectedTTL    time.Duration
+		expectedErr    func(error) bool
+	}{
+		{name: "読み取りのみ", principal: alice, scopes: []string{entity.ScopeTodosRead}, expectedScopes: []string{entity.ScopeTodosRead}, expectedTTL: 15 * time.Minute},
+		{name: "重複は1つにまとめて並べ替える", principal: alice, scopes: []string{entity.ScopeTodosWrite, entity.ScopeTodosRead, entity.ScopeTodosWrite}, ttl: time.Hour, expectedScopes: []string{entity.ScopeTodosRead, entity.ScopeTodosWrite}, expectedTTL: time.Hour},
+		{name: "スコープなし", principal: alice, expectedErr: domainerr.IsInvalid},
+		{name: "未定義のスコープ", principal: alice, scopes: []string{"admin"}, expectedErr: domainerr.IsInvalid},
+		{name: "有効期間の上限超過", principal: alice, scopes: []string{entity.ScopeTodosRead}, ttl: MaxScopedTokenTTL + time.Second, expectedErr: domainerr.IsInvalid},
+		{name: "持っていないスコープ", principal: readOnly, scopes: []string{entity.ScopeTodosWrite}, expectedErr: func(err error) bool { return errors.Is(err, ErrScopeNotGranted) }},
+		{name: "持っているスコープ", principal: readOnly, scopes: []string{entity.ScopeTodosRead}, expectedScopes: []string{entity.ScopeTodosRead}, expectedTTL: 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := s.CreateScopedToken(tt.principal, tt.scopes, tt.ttl)
+			if tt.expectedErr != nil {
+				if !tt.expectedErr(err) {
+					t.Errorf("CreateScopedToken() error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateScopedToken() error = %v", err)
+			}
+			if !slices.Equal(token.Scopes, tt.expectedScopes) || !token.ExpiresAt.Equal(now.Add(tt.expectedTTL)) {
+				t.Errorf("CreateScopedToken() = %v / %v, 期待値 = %v / %v", token.Scopes, token.ExpiresAt, tt.expectedScopes, now.Add(tt.expectedTTL))
+			}
+
+			principal, err := s.VerifyAccessToken(token.AccessToken)
+			if err != nil || principal.UserID != 1 || !slices.Equal(principal.Scopes, tt.expectedScopes) {
+				t.Errorf("VerifyAccessToken() = %+v, %v", principal, err)
+			}
+		})
+	}
+}
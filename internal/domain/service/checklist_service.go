@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// ChecklistService はTodoに属するチェックリスト項目のビジネスロジックを管理します
+// 親のTodoの存在確認が必要なため、チェックリストとTodoの両方のリポジトリに依存します
+type ChecklistService struct {
+	checklistRepo repository.ChecklistRepository
+	todoRepo      repository.TodoRepository
+}
+
+// NewChecklistService はChecklistServiceのコンストラクタです
+func NewChecklistService(checklistRepo repository.ChecklistRepository, todoRepo repository.TodoRepository) *ChecklistService {
+	return &ChecklistService{
+		checklistRepo: checklistRepo,
+		todoRepo:      todoRepo,
+	}
+}
+
+// AddItem はTodoにチェックリスト項目を追加します
+func (s *ChecklistService) AddItem(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	// 1. ドメインルールの検証
+	if !item.IsValid() {
+		return nil, domainerr.ValidationFailed("checklist item", fmt.Sprintf("text is required and must be %d characters or less", entity.MaxChecklistTextLength))
+	}
+
+	// 2. 親のTodoの存在確認
+	if err := s.ensureTodoExists(ctx, item.TodoID); err != nil {
+		return nil, err
+	}
+
+	// 3. 永続化
+	created, err := s.checklistRepo.Create(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checklist item: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListItems はTodoのチェックリスト項目を取得します
+func (s *ChecklistService) ListItems(ctx context.Context, todoID int) ([]*entity.ChecklistItem, error) {
+	if err := s.ensureTodoExists(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.checklistRepo.ListByTodoID(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetItem はチェックリスト項目を1件取得します
+func (s *ChecklistService) GetItem(ctx context.Context, todoID, itemID int) (*entity.ChecklistItem, error) {
+	if itemID <= 0 {
+		return nil, domainerr.Invalid("checklist item ID", "must be greater than 0")
+	}
+
+	if err := s.ensureTodoExists(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.checklistRepo.GetByID(ctx, todoID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist item with ID %d: %w", itemID, err)
+	}
+
+	return item, nil
+}
+
+// UpdateItem はチェックリスト項目を更新します
+func (s *ChecklistService) UpdateItem(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	if item.ID <= 0 {
+		return nil, domainerr.Invalid("checklist item ID", "must be greater than 0")
+	}
+
+	if !item.IsValid() {
+		return nil, domainerr.ValidationFailed("checklist item", fmt.Sprintf("text is required and must be %d characters or less", entity.MaxChecklistTextLength))
+	}
+
+	if err := s.ensureTodoExists(ctx, item.TodoID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.checklistRepo.Update(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update checklist item: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteItem はチェックリスト項目を削除します
+func (s *ChecklistService) DeleteItem(ctx context.Context, todoID, itemID int) error {
+	if itemID <= 0 {
+		return domainerr.Invalid("checklist item ID", "must be greater than 0")
+	}
+
+	if err := s.ensureTodoExists(ctx, todoID); err != nil {
+		return err
+	}
+
+	if err := s.checklistRepo.Delete(ctx, todoID, itemID); err != nil {
+		return fmt.Errorf("failed to delete checklist item: %w", err)
+	}
+
+	return nil
+}
+
+// ensureTodoExists は親のTodoが存在するかを確認します
+// 所有者が設定されている場合、他のユーザーのTodoは存在しないものとして扱われるため、
+// そのチェックリスト項目も参照・変更できません
+func (s *ChecklistService) ensureTodoExists(ctx context.Context, todoID int) error {
+	if todoID <= 0 {
+		return domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+
+	return requireTodo(ctx, s.todoRepo, todoID)
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ChecklistServiceInterface はチェックリストサービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type ChecklistServiceInterface interface {
+	// AddItem はTodoにチェックリスト項目を追加します
+	AddItem(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error)
+
+	// ListItems はTodoのチェックリスト項目を取得します
+	ListItems(ctx context.Context, todoID int) ([]*entity.ChecklistItem, error)
+
+	// GetItem はチェックリスト項目を1件取得します
+	GetItem(ctx context.Context, todoID, itemID int) (*entity.ChecklistItem, error)
+
+	// UpdateItem はチェックリスト項目を更新します
+	UpdateItem(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error)
+
+	// DeleteItem はチェックリスト項目を削除します
+	DeleteItem(ctx context.Context, todoID, itemID int) error
+}
+
+// コンパイル時インターフェース実装確認
+var _ ChecklistServiceInterface = (*ChecklistService)(nil)
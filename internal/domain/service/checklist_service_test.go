@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockChecklistRepository はテスト用のChecklistRepositoryのモック実装です
+type MockChecklistRepository struct {
+	items  map[int]*entity.ChecklistItem
+	nextID int
+}
+
+// NewMockChecklistRepository はモックリポジトリのコンストラクタです
+func NewMockChecklistRepository() *MockChecklistRepository {
+	return &MockChecklistRepository{
+		items:  make(map[int]*entity.ChecklistItem),
+		nextID: 1,
+	}
+}
+
+// Create はチェックリスト項目を作成します（モック実装）
+func (m *MockChecklistRepository) Create(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	item.ID = m.nextID
+	m.nextID++
+	saved := *item
+	m.items[item.ID] = &saved
+	return &saved, nil
+}
+
+// GetByID はチェックリスト項目を取得します（モック実装）
+func (m *MockChecklistRepository) GetByID(ctx context.Context, todoID, itemID int) (*entity.ChecklistItem, error) {
+	item, exists := m.items[itemID]
+	if !exists || item.TodoID != todoID {
+		return nil, domainerr.NotFound("checklist item", nil)
+	}
+	result := *item
+	return &result, nil
+}
+
+// ListByTodoID はTodoのチェックリスト項目を取得します（モック実装）
+func (m *MockChecklistRepository) ListByTodoID(ctx context.Context, todoID int) ([]*entity.ChecklistItem, error) {
+	result := make([]*entity.ChecklistItem, 0)
+	for id := 1; id < m.nextID; id++ {
+		if item, exists := m.items[id]; exists && item.TodoID == todoID {
+			itemCopy := *item
+			result = append(result, &itemCopy)
+		}
+	}
+	return result, nil
+}
+
+// Update はチェックリスト項目を更新します（モック実装）
+func (m *MockChecklistRepository) Update(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	existing, exists := m.items[item.ID]
+	if !exists || existing.TodoID != item.TodoID {
+		return nil, domainerr.NotFound("checklist item", nil)
+	}
+	saved := *item
+	m.items[item.ID] = &saved
+	return &saved, nil
+}
+
+// Delete はチェックリスト項目を削除します（モック実装）
+func (m *MockChecklistRepository) Delete(ctx context.Context, todoID, itemID int) error {
+	item, exists := m.items[itemID]
+	if !exists || item.TodoID != todoID {
+		return domainerr.NotFound("checklist item", nil)
+	}
+	delete(m.items, itemID)
+	return nil
+}
+
+// TestChecklistService_AddItem はチェックリスト項目の追加をテストします
+func TestChecklistService_AddItem(t *testing.T) {
+	todoRepo := NewMockTodoRepository()
+	checklistRepo := NewMockChecklistRepository()
+	service := NewChecklistService(checklistRepo, todoRepo)
+	ctx := context.Background()
+
+	todo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "親タスク"})
+
+	tests := []struct {
+		name    string
+		item    *entity.ChecklistItem
+		wantErr bool
+	}{
+		{name: "正常な追加", item: &entity.ChecklistItem{TodoID: todo.ID, Text: "項目"}, wantErr: false},
+		{name: "空のテキスト", item: &entity.ChecklistItem{TodoID: todo.ID, Text: " "}, wantErr: true},
+		{name: "存在しないTodo", item: &entity.ChecklistItem{TodoID: 999, Text: "項目"}, wantErr: true},
+		{name: "無効なTodoID", item: &entity.ChecklistItem{TodoID: 0, Text: "項目"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.AddItem(ctx, tt.item)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("エラーが期待されましたが、発生しませんでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラーが発生しました: %v", err)
+			}
+			if result.ID <= 0 {
+				t.Error("IDが設定されていません")
+			}
+		})
+	}
+}
+
+// TestChecklistService_UpdateAndDelete はチェックリスト項目の更新と削除をテストします
+func TestChecklistService_UpdateAndDelete(t *testing.T) {
+	todoRepo := NewMockTodoRepository()
+	checklistRepo := NewMockChecklistRepository()
+	service := NewChecklistService(checklistRepo, todoRepo)
+	ctx := context.Background()
+
+	todo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "親タスク"})
+	item, err := service.AddItem(ctx, &entity.ChecklistItem{TodoID: todo.ID, Text: "項目"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	item.Check()
+	updated, err := service.UpdateItem(ctx, item)
+	if err != nil {
+		t.Fatalf("更新に失敗: %v", err)
+	}
+	if !updated.IsDone {
+		t.Error("完了状態が更新されていません")
+	}
+
+	if _, err := service.UpdateItem(ctx, &entity.ChecklistItem{ID: item.ID, TodoID: todo.ID, Text: ""}); err == nil {
+		t.Error("空のテキストへの更新でエラーが期待されました")
+	}
+
+	if err := service.DeleteItem(ctx, todo.ID, item.ID); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+
+	items, err := service.ListItems(ctx, todo.ID)
+	if err != nil {
+		t.Fatalf("一覧取得に失敗: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("削除後の項目数 = %d, 期待値 = 0", len(items))
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// deliveryBatchSize は1回のスキャンで再送する最大件数です
+const deliveryBatchSize = 100
+
+// DeliveryHandler は種類ごとの再送処理です
+// payload は Enqueue で渡した内容をJSONにしたものです
+// nil を返すと送信完了として削除され、エラーを返すと失敗として記録されます
+type DeliveryHandler func(ctx context.Context, payload []byte) error
+
+// DeliveryQueue は送信に失敗した副作用を再送キューに登録するインターフェースです
+// 通知などを行うサービスはこのインターフェースにだけ依存します
+type DeliveryQueue interface {
+	// Enqueue は最初の送信に失敗した副作用を登録します（cause は失敗の原因）
+	Enqueue(ctx context.Context, kind entity.DeliveryKind, todoID int, payload any, cause error) error
+}
+
+// RetryPolicy は再送の間隔（指数バックオフ）と上限回数です
+type RetryPolicy struct {
+	// MaxAttempts は最初の送信を含めた送信回数の上限です。到達するとデッドレターになります
+	MaxAttempts int
+
+	// BaseDelay は最初の再送までの待ち時間です（以降は失敗するたびに2倍になります）
+	BaseDelay time.Duration
+
+	// MaxDelay は待ち時間の上限です
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy は 1分, 2分, 4分, ... と間隔を空けて合計8回まで送信する設定です
+// 受信側の数時間程度の障害であれば、デッドレターにならずに復旧できます
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 8,
+		BaseDelay:   time.Minute,
+		MaxDelay:    6 * time.Hour,
+	}
+}
+
+// Delay は attempts 回失敗した後、次の再送までの待ち時間を返します
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// DeliveryService は失敗した副作用の再送（指数バックオフ）とデッドレターの管理を行います
+//
+// 学習ポイント：
+// 1. 失敗した送信をテーブルに保存し、プロセスが再起動しても再送を続けられるようにする
+// 2. 失敗するたびに待ち時間を倍にして、障害中の受信側に負荷をかけ続けない（指数バックオフ）
+// 3. 上限に達したものはデッドレターとして残し、原因の解消後に手動で再投入できるようにする
+type DeliveryService struct {
+	deliveryRepo repository.FailedDeliveryRepository
+	policy       RetryPolicy
+	handlers     map[entity.DeliveryKind]DeliveryHandler
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewDeliveryService はDeliveryServiceのコンストラクタです
+// 再送処理は RegisterHandler で種類ごとに登録します
+func NewDeliveryService(deliveryRepo repository.FailedDeliveryRepository, policy RetryPolicy) *DeliveryService {
+	return &DeliveryService{
+		deliveryRepo: deliveryRepo,
+		policy:       policy,
+		handlers:     make(map[entity.DeliveryKind]DeliveryHandler),
+		now:          time.Now,
+	}
+}
+
+// RegisterHandler は種類ごとの再送処理を登録します（起動時に呼び出します）
+func (s *DeliveryService) RegisterHandler(kind entity.DeliveryKind, handler DeliveryHandler) {
+	s.handlers[kind] = handler
+}
+
+// Enqueue は最初の送信に失敗した副作用を再送待ちとして登録します
+func (s *DeliveryService) Enqueue(ctx context.Context, kind entity.DeliveryKind, todoID int, payload any, cause error) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode delivery payload: %w", err)
+	}
+
+	delivery := &entity.FailedDelivery{
+		Kind:    kind,
+		TodoID:  todoID,
+		Payload: data,
+	}
+	delivery.RecordFailure(cause.Error(), s.policy.MaxAttempts, s.now().Add(s.policy.Delay(1)))
+
+	if _, err := s.deliveryRepo.Create(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to enqueue delivery: %w", err)
+	}
+	return nil
+}
+
+// RetryDue は再送予定時刻を過ぎたものを再送します
+// 戻り値は再送に成功した件数です。再送の失敗はバックオフして記録するため、エラーには含めません
+func (s *DeliveryService) RetryDue(ctx context.Context) (int, error) {
+	deliveries, err := s.deliveryRepo.ListDue(ctx, s.now(), deliveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due deliveries: %w", err)
+	}
+
+	delivered := 0
+	var errs []error
+	for _, delivery := range deliveries {
+		handler, ok := s.handlers[delivery.Kind]
+		if !ok {
+			errs = append(errs, s.recordFailure(ctx, delivery, fmt.Errorf("no handler registered for kind %q", delivery.Kind)))
+			continue
+		}
+
+		if err := handler(ctx, delivery.Payload); err != nil {
+			errs = append(errs, s.recordFailure(ctx, delivery, err))
+			continue
+		}
+
+		if err := s.deliveryRepo.Delete(ctx, delivery.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove delivered item %d: %w", delivery.ID, err))
+			continue
+		}
+		delivered++
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+// recordFailure は再送の失敗を記録し、上限に達した場合はデッドレターに移します
+// 保存に失敗した場合のみエラーを返します
+func (s *DeliveryService) recordFailure(ctx context.Context, delivery *entity.FailedDelivery, cause error) error {
+	delivery.RecordFailure(cause.Error(), s.policy.MaxAttempts, s.now().Add(s.policy.Delay(delivery.Attempts+1)))
+	if delivery.IsDead() {
+		log.Printf("Delivery %d (%s, todo %d) moved to dead letters after %d attempts: %v",
+			delivery.ID, delivery.Kind, delivery.TodoID, delivery.Attempts, cause)
+	}
+
+	if _, err := s.deliveryRepo.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to record delivery failure %d: %w", delivery.ID, err)
+	}
+	return nil
+}
+
+// ListDeadLetters はデッドレターの一覧を取得します
+func (s *DeliveryService) ListDeadLetters(ctx context.Context) ([]*entity.FailedDelivery, error) {
+	deliveries, err := s.deliveryRepo.ListByStatus(ctx, entity.DeliveryStatusDead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Requeue はデッドレターを再送待ちに戻し、次回のスキャンで再送されるようにします
+func (s *DeliveryService) Requeue(ctx context.Context, id int) (*entity.FailedDelivery, error) {
+	delivery, err := s.getDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Requeue(s.now())
+	updated, err := s.deliveryRepo.Update(ctx, delivery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue dead letter: %w", err)
+	}
+	return updated, nil
+}
+
+// Discard はデッドレターを破棄します
+func (s *DeliveryService) Discard(ctx context.Context, id int) error {
+	if _, err := s.getDeadLetter(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.deliveryRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to discard dead letter: %w", err)
+	}
+	return nil
+}
+
+// getDeadLetter は操作対象のデッドレターを取得します
+// 再送待ちのものはワーカーが処理中の可能性があるため、デッドレターのみを操作対象にします
+func (s *DeliveryService) getDeadLetter(ctx context.Context, id int) (*entity.FailedDelivery, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("dead letter ID", "must be greater than 0")
+	}
+
+	delivery, err := s.deliveryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("dead letter", id, err)
+	}
+	if !delivery.IsDead() {
+		return nil, domainerr.NotFound("dead letter", id).Wrap(errors.New("delivery is still being retried"))
+	}
+	return delivery, nil
+}
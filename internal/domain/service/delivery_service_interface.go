@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// DeliveryServiceInterface は再送・デッドレター管理サービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type DeliveryServiceInterface interface {
+	DeliveryQueue
+
+	// RetryDue は再送予定時刻を過ぎたものを再送し、成功した件数を返します
+	RetryDue(ctx context.Context) (int, error)
+
+	// ListDeadLetters はデッドレターの一覧を取得します
+	ListDeadLetters(ctx context.Context) ([]*entity.FailedDelivery, error)
+
+	// Requeue はデッドレターを再送待ちに戻します
+	Requeue(ctx context.Context, id int) (*entity.FailedDelivery, error)
+
+	// Discard はデッドレターを破棄します
+	Discard(ctx context.Context, id int) error
+}
+
+// コンパイル時インターフェース実装確認
+var _ DeliveryServiceInterface = (*DeliveryService)(nil)
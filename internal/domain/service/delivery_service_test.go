@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockFailedDeliveryRepository はテスト用のFailedDeliveryRepositoryのモック実装です
+type MockFailedDeliveryRepository struct {
+	deliveries map[int]*entity.FailedDelivery
+	nextID     int
+}
+
+// NewMockFailedDeliveryRepository はモックリポジトリを作成します
+func NewMockFailedDeliveryRepository() *MockFailedDeliveryRepository {
+	return &MockFailedDeliveryRepository{
+		deliveries: make(map[int]*entity.FailedDelivery),
+		nextID:     1,
+	}
+}
+
+// Create は失敗した送信を保存します（モック実装）
+func (m *MockFailedDeliveryRepository) Create(ctx context.Context, delivery *entity.FailedDelivery) (*entity.FailedDelivery, error) {
+	delivery.ID = m.nextID
+	m.nextID++
+	stored := *delivery
+	m.deliveries[delivery.ID] = &stored
+	return delivery, nil
+}
+
+// GetByID は失敗した送信を取得します（モック実装）
+func (m *MockFailedDeliveryRepository) GetByID(ctx context.Context, id int) (*entity.FailedDelivery, error) {
+	delivery, exists := m.deliveries[id]
+	if !exists {
+		return nil, domainerr.NotFound("failed delivery", nil)
+	}
+	deliveryCopy := *delivery
+	return &deliveryCopy, nil
+}
+
+// ListDue は再送予定時刻を過ぎた再送待ちを取得します（モック実装）
+func (m *MockFailedDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.FailedDelivery, error) {
+	result := make([]*entity.FailedDelivery, 0)
+	for _, delivery := range m.deliveries {
+		if delivery.Status == entity.DeliveryStatusRetrying && !delivery.NextAttemptAt.After(now) {
+			deliveryCopy := *delivery
+			result = append(result, &deliveryCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// ListByStatus は指定した状態の失敗した送信を取得します（モック実装）
+func (m *MockFailedDeliveryRepository) ListByStatus(ctx context.Context, status entity.DeliveryStatus) ([]*entity.FailedDelivery, error) {
+	result := make([]*entity.FailedDelivery, 0)
+	for _, delivery := range m.deliveries {
+		if delivery.Status == status {
+			deliveryCopy := *delivery
+			result = append(result, &deliveryCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// Update は失敗した送信を更新します（モック実装）
+func (m *MockFailedDeliveryRepository) Update(ctx context.Context, delivery *entity.FailedDelivery) (*entity.FailedDelivery, error) {
+	if _, exists := m.deliveries[delivery.ID]; !exists {
+		return nil, domainerr.NotFound("failed delivery", nil)
+	}
+	stored := *delivery
+	m.deliveries[delivery.ID] = &stored
+	return delivery, nil
+}
+
+// Delete は失敗した送信を削除します（モック実装）
+func (m *MockFailedDeliveryRepository) Delete(ctx context.Context, id int) error {
+	if _, exists := m.deliveries[id]; !exists {
+		return domainerr.NotFound("failed delivery", nil)
+	}
+	delete(m.deliveries, id)
+	return nil
+}
+
+// TestRetryPolicy_Delay は指数バックオフの待ち時間と上限をテストします
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Minute, MaxDelay: 10 * time.Minute}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Minute},
+		{attempts: 2, expected: 2 * time.Minute},
+		{attempts: 4, expected: 8 * time.Minute},
+		{attempts: 5, expected: 10 * time.Minute},
+		{attempts: 60, expected: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Delay(tt.attempts); got != tt.expected {
+			t.Errorf("Delay(%d) = %v, 期待値 = %v", tt.attempts, got, tt.expected)
+		}
+	}
+}
+
+// TestDeliveryService_RetryUntilDead は再送の失敗でバックオフし、上限でデッドレターになることをテストします
+func TestDeliveryService_RetryUntilDead(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewMockFailedDeliveryRepository()
+	service := NewDeliveryService(repo, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour})
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	calls := 0
+	service.RegisterHandler(entity.DeliveryKindReminder, func(ctx context.Context, payload []byte) error {
+		calls++
+		if string(payload) != `{"id":1}` {
+			t.Errorf("ペイロード = %s", payload)
+		}
+		return errors.New("receiver down")
+	})
+
+	if err := service.Enqueue(ctx, entity.DeliveryKindReminder, 1, map[string]int{"id": 1}, errors.New("timeout")); err != nil {
+		t.Fatalf("登録に失敗: %v", err)
+	}
+	delivery := repo.deliveries[1]
+	if delivery.Attempts != 1 || delivery.LastError != "timeout" || !delivery.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("登録内容 = %+v", delivery)
+	}
+
+	// 予定時刻前は再送しない
+	if _, err := service.RetryDue(ctx); err != nil || calls != 0 {
+		t.Fatalf("予定時刻前に再送されました: calls=%d, err=%v", calls, err)
+	}
+
+	// 2回目の失敗: 待ち時間は2倍
+	now = now.Add(time.Minute)
+	if _, err := service.RetryDue(ctx); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	delivery = repo.deliveries[1]
+	if delivery.Attempts != 2 || delivery.IsDead() || !delivery.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("2回目の失敗の記録 = %+v", delivery)
+	}
+
+	// 3回目の失敗: 上限に達してデッドレターになる
+	now = now.Add(2 * time.Minute)
+	if _, err := service.RetryDue(ctx); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	dead, _ := service.ListDeadLetters(ctx)
+	if len(dead) != 1 || dead[0].Attempts != 3 || dead[0].LastError != "receiver down" {
+		t.Fatalf("デッドレター = %+v", dead)
+	}
+
+	// デッドレターは再送されない
+	now = now.Add(24 * time.Hour)
+	if _, err := service.RetryDue(ctx); err != nil || calls != 2 {
+		t.Errorf("デッドレターが再送されました: calls=%d, err=%v", calls, err)
+	}
+}
+
+// TestDeliveryService_RequeueAndDiscard はデッドレターの再投入と破棄をテストします
+func TestDeliveryService_RequeueAndDiscard(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewMockFailedDeliveryRepository()
+	service := NewDeliveryService(repo, DefaultRetryPolicy())
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	delivered := 0
+	service.RegisterHandler(entity.DeliveryKindReminder, func(ctx context.Context, payload []byte) error {
+		delivered++
+		return nil
+	})
+
+	dead, _ := repo.Create(ctx, &entity.FailedDelivery{Kind: entity.DeliveryKindReminder, TodoID: 1, Payload: []byte(`{}`), Attempts: 8, Status: entity.DeliveryStatusDead})
+	retrying, _ := repo.Create(ctx, &entity.FailedDelivery{Kind: entity.DeliveryKindReminder, TodoID: 2, Payload: []byte(`{}`), Attempts: 1, Status: entity.DeliveryStatusRetrying, NextAttemptAt: now.Add(time.Hour)})
+
+	tests := []struct {
+		name          string
+		id            int
+		expectedError string
+	}{
+		{name: "不正なID", id: 0, expectedError: "invalid"},
+		{name: "存在しないID", id: 99, expectedError: "not found"},
+		{name: "再送待ちは対象外", id: retrying.ID, expectedError: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Requeue(ctx, tt.id); err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Requeue のエラー = %v, 期待値に %q を含む", err, tt.expectedError)
+			}
+			if err := service.Discard(ctx, tt.id); err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Discard のエラー = %v, 期待値に %q を含む", err, tt.expectedError)
+			}
+		})
+	}
+
+	requeued, err := service.Requeue(ctx, dead.ID)
+	if err != nil {
+		t.Fatalf("再投入に失敗: %v", err)
+	}
+	if requeued.Status != entity.DeliveryStatusRetrying || requeued.Attempts != 0 {
+		t.Errorf("再投入後の状態 = %+v", requeued)
+	}
+
+	// 再投入したものは次回のスキャンで再送され、成功すると削除される
+	sent, err := service.RetryDue(ctx)
+	if err != nil || sent != 1 || delivered != 1 {
+		t.Fatalf("再送結果: sent=%d, delivered=%d, err=%v", sent, delivered, err)
+	}
+	if _, exists := repo.deliveries[dead.ID]; exists {
+		t.Error("再送に成功したものは削除されるべきです")
+	}
+
+	// 破棄
+	repo.deliveries[retrying.ID].Status = entity.DeliveryStatusDead
+	if err := service.Discard(ctx, retrying.ID); err != nil {
+		t.Fatalf("破棄に失敗: %v", err)
+	}
+	if len(repo.deliveries) != 0 {
+		t.Errorf("破棄後に残っています: %v", repo.deliveries)
+	}
+}
+
+// TestReminderService_DispatchDueWithDeliveryQueue は通知の失敗が再送キューに登録され、再送で通知されることをテストします
+func TestReminderService_DispatchDueWithDeliveryQueue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	todoRepo := NewMockTodoRepository()
+	notifier := &MockReminderNotifier{failIDs: make(map[int]bool)}
+	deliveryRepo := NewMockFailedDeliveryRepository()
+	deliveries := NewDeliveryService(deliveryRepo, DefaultRetryPolicy())
+	deliveries.now = func() time.Time { return now }
+	reminders := NewReminderService(&MockReminderRepository{todoRepo: todoRepo}, todoRepo, notifier, WithDeliveryQueue(deliveries))
+	reminders.now = func() time.Time { return now }
+	deliveries.RegisterHandler(entity.DeliveryKindReminder, reminders.Redeliver)
+	ctx := context.Background()
+
+	due := now.Add(-time.Minute)
+	failTodo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "通知失敗", RemindAt: &due})
+	completedTodo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "再送前に完了", RemindAt: &due})
+	notifier.failIDs[failTodo.ID] = true
+	notifier.failIDs[completedTodo.ID] = true
+
+	sent, err := reminders.DispatchDue(ctx)
+	if err != nil || sent != 0 {
+		t.Fatalf("キューに登録された失敗はエラーにしない: sent=%d, err=%v", sent, err)
+	}
+	if len(deliveryRepo.deliveries) != 2 {
+		t.Fatalf("再送キューの件数 = %d, 期待値 = 2", len(deliveryRepo.deliveries))
+	}
+	if todoRepo.todos[failTodo.ID].RemindAt != nil {
+		t.Error("キューに登録したリマインダーは解除されるべきです（スキャンでの二重送信の防止）")
+	}
+
+	// 受信側が復旧した後の再送
+	notifier.failIDs = map[int]bool{}
+	todoRepo.todos[completedTodo.ID].IsCompleted = true
+	deliveries.now = func() time.Time { return now.Add(time.Hour) }
+
+	sent, err = deliveries.RetryDue(ctx)
+	if err != nil || sent != 2 {
+		t.Fatalf("再送結果: sent=%d, err=%v", sent, err)
+	}
+	if len(notifier.notified) != 1 || notifier.notified[0] != failTodo.ID {
+		t.Errorf("通知されたTodo = %v, 期待値 = [%d]（完了済みは通知しない）", notifier.notified, failTodo.ID)
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// 今後の予定のビューで指定できる日数の範囲
+const (
+	DefaultUpcomingDays = 7
+	MaxUpcomingDays     = 90
+)
+
+// DueDateService はTodoの期限に基づくビュー（期限切れ・今日・今後の予定）を提供します
+// 基準となる現在時刻はサーバーの時計で、テストでは now フィールドで固定できます
+// 「今日」「明日」の区切りは、リクエストごとに指定された利用者のタイムゾーンで計算します
+type DueDateService struct {
+	dueDateRepo repository.DueDateRepository
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewDueDateService はDueDateServiceのコンストラクタです
+func NewDueDateService(dueDateRepo repository.DueDateRepository) *DueDateService {
+	return &DueDateService{
+		dueDateRepo: dueDateRepo,
+		now:         time.Now,
+	}
+}
+
+// ListOverdue は期限を過ぎた未完了Todoを期限の早い順に取得します
+func (s *DueDateService) ListOverdue(ctx context.Context) ([]*entity.Todo, error) {
+	todos, err := s.dueDateRepo.ListOverdue(ctx, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue todos: %w", err)
+	}
+	return todos, nil
+}
+
+// ListDueToday は利用者のタイムゾーンで今日が期限の未完了Todoを期限の早い順に取得します
+// 今日より前が期限のもの（期限切れ）は含みません
+func (s *DueDateService) ListDueToday(ctx context.Context, loc *time.Location) ([]*entity.Todo, error) {
+	today := startOfDay(s.now(), loc)
+
+	todos, err := s.dueDateRepo.ListDueBetween(ctx, today, today.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos due today: %w", err)
+	}
+	return todos, nil
+}
+
+// ListUpcoming は利用者のタイムゾーンで明日から days 日間が期限の未完了Todoを期限の早い順に取得します
+func (s *DueDateService) ListUpcoming(ctx context.Context, loc *time.Location, days int) ([]*entity.Todo, error) {
+	if days < 1 || days > MaxUpcomingDays {
+		return nil, domainerr.Invalid("days", fmt.Sprintf("%d (must be between 1 and %d)", days, MaxUpcomingDays))
+	}
+
+	tomorrow := startOfDay(s.now(), loc).AddDate(0, 0, 1)
+
+	todos, err := s.dueDateRepo.ListDueBetween(ctx, tomorrow, tomorrow.AddDate(0, 0, days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming todos: %w", err)
+	}
+	return todos, nil
+}
+
+// ListCalendar はカレンダーとして配信する、期限のあるTodoを期限の早い順に取得します
+// 購読側のカレンダーが過去の予定も表示できるよう、期限切れのTodoも含めます
+func (s *DueDateService) ListCalendar(ctx context.Context, includeCompleted bool) ([]*entity.Todo, error) {
+	todos, err := s.dueDateRepo.ListWithDueDate(ctx, includeCompleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos for calendar: %w", err)
+	}
+	return todos, nil
+}
+
+// startOfDay は t を loc で表したときの日付の 0 時を返します
+// time.Date で組み立てるため、夏時間の切り替わる日も正しく扱えます
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// DueDateServiceInterface は期限に基づくビューのサービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type DueDateServiceInterface interface {
+	// ListOverdue は期限を過ぎた未完了Todoを取得します
+	ListOverdue(ctx context.Context) ([]*entity.Todo, error)
+
+	// ListDueToday は利用者のタイムゾーンで今日が期限の未完了Todoを取得します
+	ListDueToday(ctx context.Context, loc *time.Location) ([]*entity.Todo, error)
+
+	// ListUpcoming は利用者のタイムゾーンで明日から days 日間が期限の未完了Todoを取得します
+	ListUpcoming(ctx context.Context, loc *time.Location, days int) ([]*entity.Todo, error)
+
+	// ListCalendar はカレンダーとして配信する、期限のあるTodoを取得します
+	ListCalendar(ctx context.Context, includeCompleted bool) ([]*entity.Todo, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ DueDateServiceInterface = (*DueDateService)(nil)
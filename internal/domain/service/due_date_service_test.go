@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockDueDateRepository はテスト用のDueDateRepositoryのモック実装です
+// MockTodoRepository と同じデータを参照します
+type MockDueDateRepository struct {
+	todoRepo *MockTodoRepository
+}
+
+// ListOverdue は期限切れの未完了Todoを取得します（モック実装）
+func (m *MockDueDateRepository) ListOverdue(ctx context.Context, now time.Time) ([]*entity.Todo, error) {
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todoRepo.todos {
+		if todo.DueDate != nil && todo.DueDate.Before(now) && !todo.IsCompleted {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DueDate.Before(*result[j].DueDate) })
+	return result, nil
+}
+
+// ListDueBetween は期限が [from, to) の未完了Todoを取得します（モック実装）
+func (m *MockDueDateRepository) ListDueBetween(ctx context.Context, from, to time.Time) ([]*entity.Todo, error) {
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todoRepo.todos {
+		if todo.DueDate != nil && !todo.DueDate.Before(from) && todo.DueDate.Before(to) && !todo.IsCompleted {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DueDate.Before(*result[j].DueDate) })
+	return result, nil
+}
+
+// ListWithDueDate は期限のあるTodoを取得します（モック実装）
+func (m *MockDueDateRepository) ListWithDueDate(ctx context.Context, includeCompleted bool) ([]*entity.Todo, error) {
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todoRepo.todos {
+		if todo.DueDate != nil && (includeCompleted || !todo.IsCompleted) {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DueDate.Before(*result[j].DueDate) })
+	return result, nil
+}
+
+// TestDueDateService_ListOverdue はサービスの時計を基準に期限切れを判定することをテストします
+func TestDueDateService_ListOverdue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	todoRepo := NewMockTodoRepository()
+	service := NewDueDateService(&MockDueDateRepository{todoRepo: todoRepo})
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+	overdue, _ := todoRepo.Create(ctx, &entity.Todo{Title: "期限切れ", DueDate: &yesterday})
+	todoRepo.Create(ctx, &entity.Todo{Title: "期限前", DueDate: &tomorrow})
+
+	todos, err := service.ListOverdue(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if len(todos) != 1 || todos[0].ID != overdue.ID {
+		t.Errorf("期限切れのTodo = %v, 期待値 = [%d]", todos, overdue.ID)
+	}
+
+	// 時計を進めると、翌日が期限のTodoも期限切れになる
+	service.now = func() time.Time { return tomorrow.Add(time.Second) }
+	todos, err = service.ListOverdue(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if len(todos) != 2 {
+		t.Errorf("件数 = %d, 期待値 = 2", len(todos))
+	}
+}
+
+// TestDueDateService_TodayAndUpcoming は利用者のタイムゾーンで日付の区切りを計算することをテストします
+func TestDueDateService_TodayAndUpcoming(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("タイムゾーン情報がありません: %v", err)
+	}
+
+	// UTCでは4月30日、東京では5月1日の朝
+	now := time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC)
+	todoRepo := NewMockTodoRepository()
+	service := NewDueDateService(&MockDueDateRepository{todoRepo: todoRepo})
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	create := func(title string, dueDate time.Time) *entity.Todo {
+		todo, _ := todoRepo.Create(ctx, &entity.Todo{Title: title, DueDate: &dueDate})
+		return todo
+	}
+	utcToday := create("UTCの4月30日", time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC))
+	tokyoToday := create("東京の5月1日", time.Date(2024, 5, 1, 18, 0, 0, 0, tokyo))
+	tokyoDay3 := create("東京の5月3日", time.Date(2024, 5, 3, 9, 0, 0, 0, tokyo))
+	create("東京の5月10日", time.Date(2024, 5, 10, 9, 0, 0, 0, tokyo))
+
+	ids := func(todos []*entity.Todo) []int {
+		result := make([]int, len(todos))
+		for i, todo := range todos {
+			result[i] = todo.ID
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		list     func() ([]*entity.Todo, error)
+		expected []int
+	}{
+		{name: "UTCの今日", list: func() ([]*entity.Todo, error) { return service.ListDueToday(ctx, time.UTC) }, expected: []int{utcToday.ID}},
+		{name: "東京の今日", list: func() ([]*entity.Todo, error) { return service.ListDueToday(ctx, tokyo) }, expected: []int{tokyoToday.ID}},
+		{name: "東京の明日から3日間", list: func() ([]*entity.Todo, error) { return service.ListUpcoming(ctx, tokyo, 3) }, expected: []int{tokyoDay3.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todos, err := tt.list()
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if got := ids(todos); len(got) != len(tt.expected) || (len(got) > 0 && got[0] != tt.expected[0]) {
+				t.Errorf("Todo = %v, 期待値 = %v", got, tt.expected)
+			}
+		})
+	}
+
+	for _, days := range []int{0, MaxUpcomingDays + 1} {
+		if _, err := service.ListUpcoming(ctx, tokyo, days); err == nil {
+			t.Errorf("days=%d でエラーが返されませんでした", days)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// lookupError はリポジトリからの取得で発生したエラーを、サービスのエラーに変換します
+// 存在しない場合は domainerr.NotFound（"todo with ID 1 not found: ..."）を返します
+// データベースの障害等は NotFound として扱わず（404 ではなく 500 にするため）、原因のエラーを含めて返します
+func lookupError(resource string, id int, err error) error {
+	if domainerr.IsNotFound(err) {
+		return domainerr.NotFound(resource, id).Wrap(err)
+	}
+	return fmt.Errorf("failed to get %s %d: %w", resource, id, err)
+}
+
+// requireTodo はTodoが存在することを TodoRepository.Exists で確認します（行は読み込みません）
+// 存在しない場合は、GetByID のエラーを lookupError で変換した場合と同じ domainerr.NotFound を返します
+// 更新前の状態が必要な場合（変更履歴・取り消し）は、代わりに GetByID を使用してください
+func requireTodo(ctx context.Context, todoRepo repository.TodoRepository, id int) error {
+	exists, err := todoRepo.Exists(ctx, id)
+	if err != nil {
+		return lookupError("todo", id, err)
+	}
+	if !exists {
+		return lookupError("todo", id, domainerr.NotFound("todo", nil))
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// ErrIdempotencyKeyReused は同じキーで内容の異なるリクエストが送られた場合のエラーです
+// ハンドラー層はこのエラーを 422 Unprocessable Entity として返します
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// ErrIdempotencyInProgress は同じキーのリクエストがまだ処理中の場合のエラーです
+// ハンドラー層はこのエラーを 409 Conflict として返します（クライアントは少し待ってから再送する）
+var ErrIdempotencyInProgress = errors.New("a request with the same idempotency key is still in progress")
+
+// idempotencyLockTimeout は処理中の記録を放棄されたとみなすまでの時間です
+// 処理の途中でプロセスが停止した場合も、この時間が過ぎれば同じキーで処理をやり直せます
+const idempotencyLockTimeout = time.Minute
+
+// IdempotencyService は Idempotency-Key による再送の重複排除を行うサービスです
+//
+// 学習ポイント：
+//  1. ネットワークの切断などでレスポンスを受け取れなかったクライアントは、同じリクエストを再送する
+//     キーで記録を引き、処理済みであれば保存したレスポンスを返すことで、Todoが二重に作成されない
+//  2. 記録は処理の前に「処理中」として一意制約付きで保存する（同時の再送のうち1つだけが処理を始める）
+//  3. 同じキーで内容の違うリクエストはクライアントの誤りとして拒否する（別の操作の結果を返さない）
+//
+// 記録は ttl の間だけ保持し、PurgeExpired で削除します
+type IdempotencyService struct {
+	repo repository.IdempotencyRepository
+	ttl  time.Duration
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewIdempotencyService はIdempotencyServiceのコンストラクタです
+// ttl はレスポンスを保持し、同じキーの再送に返す期間です
+func NewIdempotencyService(repo repository.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		repo: repo,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// Begin はキーの処理を始めます
+//
+// 初めてのキーの場合は処理中として記録し、(nil, nil) を返します（呼び出し側はリクエストを処理し、Complete か Release を呼ぶ）
+// 処理済みのキーの場合は保存したレスポンスを含む記録を返します（呼び出し側はそれをそのまま返す）
+// 内容の違うリクエストの場合は ErrIdempotencyKeyReused、処理中の場合は ErrIdempotencyInProgress を返します
+func (s *IdempotencyService) Begin(ctx context.Context, key, fingerprint string) (*entity.IdempotencyRecord, error) {
+	actor := ActorFromContext(ctx)
+	now := s.now().UTC()
+	record := &entity.IdempotencyRecord{
+		Actor:       actor,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+
+	// 期限切れ・放棄された記録を削除してやり直すため、作成は2回まで試みる
+	for attempt := 0; attempt < 2; attempt++ {
+		err := s.repo.Create(ctx, record)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, repository.ErrIdempotencyKeyExists) {
+			return nil, fmt.Errorf("failed to save idempotency key: %w", err)
+		}
+
+		existing, err := s.repo.Get(ctx, actor, key)
+		if err != nil {
+			if domainerr.IsNotFound(err) {
+				continue // 作成と取得の間に削除された
+			}
+			return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+
+		expired := !now.Before(existing.ExpiresAt)
+		abandoned := !existing.Completed() && !now.Before(existing.CreatedAt.Add(idempotencyLockTimeout))
+		if expired || abandoned {
+			if err := s.repo.Delete(ctx, actor, key); err != nil && !domainerr.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to delete idempotency key: %w", err)
+			}
+			continue
+		}
+
+		if existing.Fingerprint != fingerprint {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if !existing.Completed() {
+			return nil, ErrIdempotencyInProgress
+		}
+		return existing, nil
+	}
+	return nil, ErrIdempotencyInProgress
+}
+
+// Complete はリクエストの処理結果（レスポンス）をキーの記録に保存します
+func (s *IdempotencyService) Complete(ctx context.Context, key string, statusCode int, header map[string]string, body []byte) error {
+	record := &entity.IdempotencyRecord{
+		Actor:      ActorFromContext(ctx),
+		Key:        key,
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       body,
+	}
+	if err := s.repo.Complete(ctx, record); err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Release は処理中の記録を削除し、同じキーの再送で処理をやり直せるようにします
+// サーバーのエラーなど、結果を保存すべきでない場合に呼び出します
+func (s *IdempotencyService) Release(ctx context.Context, key string) error {
+	if err := s.repo.Delete(ctx, ActorFromContext(ctx), key); err != nil && !domainerr.IsNotFound(err) {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired は有効期限を過ぎた記録を削除し、削除した件数を返します（ワーカーが定期的に呼び出します）
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int, error) {
+	n, err := s.repo.DeleteExpired(ctx, s.now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return n, nil
+}
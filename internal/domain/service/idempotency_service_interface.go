@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// IdempotencyServiceInterface は Idempotency-Key による重複排除サービスのインターフェースです
+// ミドルウェアのテストでモック実装に差し替えられるように定義しています
+type IdempotencyServiceInterface interface {
+	// Begin はキーの処理を始めます（処理済みの場合は保存したレスポンスを含む記録を返します）
+	Begin(ctx context.Context, key, fingerprint string) (*entity.IdempotencyRecord, error)
+
+	// Complete はリクエストの処理結果をキーの記録に保存します
+	Complete(ctx context.Context, key string, statusCode int, header map[string]string, body []byte) error
+
+	// Release は処理中の記録を削除し、同じキーの再送で処理をやり直せるようにします
+	Release(ctx context.Context, key string) error
+
+	// PurgeExpired は有効期限を過ぎた記録を削除します
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ IdempotencyServiceInterface = (*IdempotencyService)(nil)
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// MockIdempotencyRepository はテスト用のIdempotencyRepositoryのモック実装です
+type MockIdempotencyRepository struct {
+	records map[string]*entity.IdempotencyRecord
+}
+
+// NewMockIdempotencyRepository はモックリポジトリを作成します
+func NewMockIdempotencyRepository() *MockIdempotencyRepository {
+	return &MockIdempotencyRepository{records: make(map[string]*entity.IdempotencyRecord)}
+}
+
+// Create は処理中の記録を保存します（モック実装）
+func (m *MockIdempotencyRepository) Create(ctx context.Context, record *entity.IdempotencyRecord) error {
+	if _, exists := m.records[record.Actor+"/"+record.Key]; exists {
+		return repository.ErrIdempotencyKeyExists
+	}
+	stored := *record
+	m.records[record.Actor+"/"+record.Key] = &stored
+	return nil
+}
+
+// Get は記録を取得します（モック実装）
+func (m *MockIdempotencyRepository) Get(ctx context.Context, actor, key string) (*entity.IdempotencyRecord, error) {
+	record, exists := m.records[actor+"/"+key]
+	if !exists {
+		return nil, domainerr.NotFound("idempotency record", nil)
+	}
+	recordCopy := *record
+	return &recordCopy, nil
+}
+
+// Complete はレスポンスを保存します（モック実装）
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	stored, exists := m.records[record.Actor+"/"+record.Key]
+	if !exists {
+		return domainerr.NotFound("idempotency record", nil)
+	}
+	stored.StatusCode, stored.Header, stored.Body = record.StatusCode, record.Header, record.Body
+	return nil
+}
+
+// Delete は記録を削除します（モック実装）
+func (m *MockIdempotencyRepository) Delete(ctx context.Context, actor, key string) error {
+	if _, exists := m.records[actor+"/"+key]; !exists {
+		return domainerr.NotFound("idempotency record", nil)
+	}
+	delete(m.records, actor+"/"+key)
+	return nil
+}
+
+// DeleteExpired は有効期限を過ぎた記録を削除します（モック実装）
+func (m *MockIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	deleted := 0
+	for k, record := range m.records {
+		if !record.ExpiresAt.After(before) {
+			delete(m.records, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// TestIdempotencyService_Begin はキーの状態ごとの Begin の結果をテストします
+func TestIdempotencyService_Begin(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		existing    *entity.IdempotencyRecord // 既にある記録（nil の場合は初めてのキー）
+		actor       string
+		fingerprint string
+		elapsed     time.Duration // 既にある記録の作成からの経過時間
+		wantErr     error
+		wantReplay  bool
+	}{
+		{name: "初めてのキーは処理を始める", fingerprint: "a"},
+		{
+			name:     "処理済みのキーは保存したレスポンスを返す",
+			existing: &entity.IdempotencyRecord{Fingerprint: "a", StatusCode: 201, Body: []byte(`{"id":1}`)}, fingerprint: "a",
+			wantReplay: true,
+		},
+		{
+			name:     "内容の違うリクエストはキーの使い回し",
+			existing: &entity.IdempotencyRecord{Fingerprint: "a", StatusCode: 201}, fingerprint: "b",
+			wantErr: ErrIdempotencyKeyReused,
+		},
+		{
+			name:     "処理中のキー",
+			existing: &entity.IdempotencyRecord{Fingerprint: "a"}, fingerprint: "a", elapsed: 10 * time.Second,
+			wantErr: ErrIdempotencyInProgress,
+		},
+		{
+			name:     "放棄された処理中のキーはやり直す",
+			existing: &entity.IdempotencyRecord{Fingerprint: "a"}, fingerprint: "a", elapsed: 2 * time.Minute,
+		},
+		{
+			name:     "有効期限を過ぎたキーは新しいリクエストとして処理する",
+			existing: &entity.IdempotencyRecord{Fingerprint: "a", StatusCode: 201}, fingerprint: "b", elapsed: 25 * time.Hour,
+		},
+		{
+			name:     "他の操作者のキーとは区別する",
+			existing: &entity.IdempotencyRecord{Fingerprint: "a", StatusCode: 201}, actor: "bob", fingerprint: "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockIdempotencyRepository()
+			svc := NewIdempotencyService(repo, 24*time.Hour)
+			svc.now = func() time.Time { return base.Add(tt.elapsed) }
+			if tt.existing != nil {
+				tt.existing.Actor, tt.existing.Key = "alice", "key-1"
+				tt.existing.CreatedAt, tt.existing.ExpiresAt = base, base.Add(24*time.Hour)
+				repo.records["alice/key-1"] = tt.existing
+			}
+			actor := tt.actor
+			if actor == "" {
+				actor = "alice"
+			}
+			ctx := WithActor(context.Background(), actor)
+
+			record, err := svc.Begin(ctx, "key-1", tt.fingerprint)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Begin() error = %v, 期待値 = %v", err, tt.wantErr)
+			}
+			if (record != nil) != tt.wantReplay {
+				t.Fatalf("Begin() = %+v, 保存したレスポンスを返す = %v を期待", record, tt.wantReplay)
+			}
+			if tt.wantReplay && string(record.Body) != `{"id":1}` {
+				t.Errorf("保存したボディ = %s", record.Body)
+			}
+			if tt.wantErr == nil && !tt.wantReplay {
+				stored, err := repo.Get(ctx, actor, "key-1")
+				if err != nil || stored.Completed() || stored.Fingerprint != tt.fingerprint {
+					t.Errorf("処理中の記録 = %+v, %v", stored, err)
+				}
+			}
+		})
+	}
+}
+
+// TestIdempotencyService_CompleteRelease はレスポンスの保存と、処理中の記録の解放をテストします
+func TestIdempotencyService_CompleteRelease(t *testing.T) {
+	repo := NewMockIdempotencyRepository()
+	svc := NewIdempotencyService(repo, time.Hour)
+	ctx := WithActor(context.Background(), "alice")
+
+	if _, err := svc.Begin(ctx, "create", "a"); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := svc.Complete(ctx, "create", 201, map[string]string{"Content-Type": "application/json"}, []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	record, err := svc.Begin(ctx, "create", "a")
+	if err != nil || record == nil || record.StatusCode != 201 || record.Header["Content-Type"] != "application/json" {
+		t.Fatalf("再送時の Begin() = %+v, %v", record, err)
+	}
+
+	// 解放したキーは、同じキーの再送で処理をやり直せる
+	if _, err := svc.Begin(ctx, "failed", "a"); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := svc.Release(ctx, "failed"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if record, err := svc.Begin(ctx, "failed", "a"); err != nil || record != nil {
+		t.Errorf("解放後の Begin() = %+v, %v, 処理を始めることを期待", record, err)
+	}
+
+	// 有効期限を過ぎた記録の削除
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if n, err := svc.PurgeExpired(ctx); err != nil || n != 2 {
+		t.Errorf("PurgeExpired() = %d, %v, 期待値 = 2", n, err)
+	}
+}
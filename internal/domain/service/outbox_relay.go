@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"todoapp-api-golang/internal/domain/event"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// outboxBatchSize は1回のリレーで発行する最大件数です
+const outboxBatchSize = 100
+
+// OutboxRelay はアウトボックスに保存されたイベントを、保存された順にイベントバスへ発行します
+//
+// 学習ポイント（トランザクショナル・アウトボックス）：
+// 1. イベントはデータの変更と同じトランザクションでテーブルに保存されるため、変更とイベントのどちらかだけが残ることはない
+// 2. リレーは発行に成功したイベントだけを削除するため、発行の途中でプロセスが停止しても次回に発行し直せる
+// 3. その代わり同じイベントが2回以上発行されることがある（at-least-once）。購読者は重複を許容する必要がある
+type OutboxRelay struct {
+	outboxRepo repository.OutboxRepository
+	bus        *event.Bus
+}
+
+// NewOutboxRelay はOutboxRelayのコンストラクタです
+// bus の購読者はリレーのワーカーから同期的に呼び出されます
+func NewOutboxRelay(outboxRepo repository.OutboxRepository, bus *event.Bus) *OutboxRelay {
+	return &OutboxRelay{outboxRepo: outboxRepo, bus: bus}
+}
+
+// RelayPending は発行待ちのイベントを発行して削除し、発行した件数を返します
+// 削除に失敗した場合は、順序を保つためにそこで中断します（そのイベントは次回に再び発行されます）
+// 復元できないイベントは発行し直しても成功しないため、ログに残して削除します
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	messages, err := r.outboxRepo.ListPending(ctx, outboxBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+
+	relayed := 0
+	for _, message := range messages {
+		if err := ctx.Err(); err != nil {
+			return relayed, err
+		}
+
+		e, err := event.UnmarshalTodoEvent(message.EventName, message.Payload)
+		if err != nil {
+			log.Printf("Discarding outbox message %d: %v", message.ID, err)
+		} else {
+			r.bus.Publish(ctx, e)
+			relayed++
+		}
+
+		if err := r.outboxRepo.Delete(ctx, message.ID); err != nil {
+			return relayed, fmt.Errorf("failed to delete outbox message %d: %w", message.ID, err)
+		}
+	}
+	return relayed, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+)
+
+// MockOutboxRepository はテスト用のOutboxRepositoryのモック実装です
+type MockOutboxRepository struct {
+	messages  []*entity.OutboxMessage
+	nextID    int
+	deleteErr error
+}
+
+func (m *MockOutboxRepository) Add(ctx context.Context, message *entity.OutboxMessage) (*entity.OutboxMessage, error) {
+	m.nextID++
+	saved := *message
+	saved.ID = m.nextID
+	m.messages = append(m.messages, &saved)
+	return &saved, nil
+}
+
+func (m *MockOutboxRepository) ListPending(ctx context.Context, limit int) ([]*entity.OutboxMessage, error) {
+	return m.messages[:min(limit, len(m.messages))], nil
+}
+
+func (m *MockOutboxRepository) Delete(ctx context.Context, id int) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	for i, message := range m.messages {
+		if message.ID == id {
+			m.messages = append(m.messages[:i:i], m.messages[i+1:]...)
+			return nil
+		}
+	}
+	return domainerr.NotFound("outbox message", nil)
+}
+
+// MockTransactor はテスト用のTransactorのモック実装です
+// fn がエラーを返した場合は、アウトボックスを開始前の状態に戻します（ロールバックの再現）
+type MockTransactor struct {
+	outbox *MockOutboxRepository
+}
+
+func (m *MockTransactor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	snapshot := append([]*entity.OutboxMessage(nil), m.outbox.messages...)
+	if err := fn(ctx); err != nil {
+		m.outbox.messages = snapshot
+		return err
+	}
+	return nil
+}
+
+// TestTodoService_Outbox はアウトボックスが有効な場合に、変更のイベントがアウトボックスへ保存されることをテストします
+func TestTodoService_Outbox(t *testing.T) {
+	ctx := context.Background()
+	outbox := &MockOutboxRepository{}
+	repo := NewMockTodoRepository()
+	service := NewTodoService(repo, WithTodoOutbox(&MockTransactor{outbox: outbox}, outbox))
+
+	created, err := service.CreateTodo(ctx, &entity.Todo{Title: "牛乳を買う"})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+	if _, err := service.CompleteTodo(ctx, created.ID); err != nil {
+		t.Fatalf("完了に失敗: %v", err)
+	}
+	// 既に完了済みの場合は変更がないため、イベントも保存しない
+	if _, err := service.CompleteTodo(ctx, created.ID); err != nil {
+		t.Fatalf("再完了に失敗: %v", err)
+	}
+	if err := service.DeleteTodo(ctx, created.ID); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+
+	expected := []string{"todo.created", "todo.completed", "todo.deleted"}
+	if len(outbox.messages) != len(expected) {
+		t.Fatalf("保存されたイベント = %d件, 期待値 = %d件", len(outbox.messages), len(expected))
+	}
+	for i, name := range expected {
+		if outbox.messages[i].EventName != name {
+			t.Errorf("%d件目のイベント = %s, 期待値 = %s", i+1, outbox.messages[i].EventName, name)
+		}
+	}
+
+	// 保存に失敗した変更のイベントはアウトボックスに残らない
+	repo.SetError(true, "database error")
+	if _, err := service.CreateTodo(ctx, &entity.Todo{Title: "請求書を送る"}); err == nil {
+		t.Fatal("作成がエラーになるべきです")
+	}
+	if len(outbox.messages) != len(expected) {
+		t.Errorf("失敗後のイベント = %d件, 期待値 = %d件", len(outbox.messages), len(expected))
+	}
+}
+
+// TestOutboxRelay_RelayPending は保存順の発行と、発行したイベントの削除をテストします
+func TestOutboxRelay_RelayPending(t *testing.T) {
+	ctx := context.Background()
+	todo := &entity.Todo{ID: 1, Title: "牛乳を買う"}
+
+	newOutbox := func() *MockOutboxRepository {
+		outbox := &MockOutboxRepository{}
+		for _, e := range []event.TodoEvent{event.NewTodoEvent(entity.TodoHistoryCreated, nil, todo), event.NewTodoEvent(entity.TodoHistoryDeleted, todo, nil)} {
+			payload, _ := event.MarshalTodoEvent(e)
+			outbox.Add(ctx, &entity.OutboxMessage{EventName: e.EventName(), Payload: payload})
+		}
+		// 復元できないイベントは発行せずに削除する
+		outbox.Add(ctx, &entity.OutboxMessage{EventName: "todo.archived", Payload: []byte(`{}`)})
+		return outbox
+	}
+
+	t.Run("発行して削除", func(t *testing.T) {
+		outbox := newOutbox()
+		bus := event.NewBus()
+		var received []string
+		event.SubscribeTodoEvents(bus, func(ctx context.Context, e event.TodoEvent) {
+			received = append(received, e.EventName())
+		})
+
+		relayed, err := NewOutboxRelay(outbox, bus).RelayPending(ctx)
+		if err != nil {
+			t.Fatalf("RelayPending() error = %v", err)
+		}
+		if relayed != 2 || len(received) != 2 || received[0] != "todo.created" || received[1] != "todo.deleted" {
+			t.Errorf("発行数 = %d, 受信したイベント = %v", relayed, received)
+		}
+		if len(outbox.messages) != 0 {
+			t.Errorf("発行後に残ったイベント = %d件, 期待値 = 0件", len(outbox.messages))
+		}
+	})
+
+	t.Run("削除に失敗した場合は中断して次回に再発行", func(t *testing.T) {
+		outbox := newOutbox()
+		outbox.deleteErr = errors.New("database error")
+
+		relayed, err := NewOutboxRelay(outbox, event.NewBus()).RelayPending(ctx)
+		if err == nil || relayed != 1 {
+			t.Errorf("RelayPending() = %d, %v, 期待値 = 1件発行してエラー", relayed, err)
+		}
+		if len(outbox.messages) != 3 {
+			t.Errorf("残ったイベント = %d件, 期待値 = 3件", len(outbox.messages))
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// ErrPreferencesRequireUser は認証されていないリクエストで設定を保存しようとした場合のエラーです
+// ハンドラー層はこのエラーを 401 Unauthorized として返します
+var ErrPreferencesRequireUser = errors.New("preferences require an authenticated user")
+
+// PreferencesService は認証されたユーザーの設定（タイムゾーン・一覧の並び順・通知）を管理するサービスです
+//
+// 設定を保存していないユーザーと、認証が無効な場合（ユーザーがいない場合）は entity.DefaultPreferences を返します
+// そのため一覧や期限のハンドラーは、設定の有無を区別せずに Get の結果を使えます
+type PreferencesService struct {
+	preferences repository.PreferencesRepository
+}
+
+// NewPreferencesService はPreferencesServiceのコンストラクタです
+func NewPreferencesService(preferences repository.PreferencesRepository) *PreferencesService {
+	return &PreferencesService{
+		preferences: preferences,
+	}
+}
+
+// Get は認証されたユーザーの設定を返します（保存していない場合は既定の設定）
+func (s *PreferencesService) Get(ctx context.Context) (*entity.Preferences, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return entity.DefaultPreferences(0), nil
+	}
+
+	preferences, err := s.preferences.Get(ctx, principal.UserID)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return entity.DefaultPreferences(principal.UserID), nil
+		}
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return preferences, nil
+}
+
+// Update は認証されたユーザーの設定を preferences で置き換えます
+// タイムゾーン・並び順が不正な場合は domainerr.Invalid、認証されていない場合は ErrPreferencesRequireUser を返します
+func (s *PreferencesService) Update(ctx context.Context, preferences *entity.Preferences) (*entity.Preferences, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrPreferencesRequireUser
+	}
+
+	// "Local" はサーバーのタイムゾーンになってしまうため受け付けない
+	if _, err := time.LoadLocation(preferences.Timezone); err != nil || preferences.Timezone == "" || preferences.Timezone == "Local" {
+		return nil, domainerr.Invalid("timezone", fmt.Sprintf("unknown timezone %q (must be an IANA time zone name such as Asia/Tokyo)", preferences.Timezone))
+	}
+	if !preferences.SortOrder.IsValid() {
+		return nil, domainerr.Invalid("sort_order", fmt.Sprintf("must be one of %v", entity.TodoSortOrders))
+	}
+
+	preferences.UserID = principal.UserID
+	saved, err := s.preferences.Save(ctx, preferences)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// PreferencesServiceInterface はユーザーの設定のサービスのインターフェースです
+// ハンドラーのテストでモック実装に差し替えられるように定義しています
+type PreferencesServiceInterface interface {
+	// Get は認証されたユーザーの設定を返します（保存していない場合は既定の設定）
+	Get(ctx context.Context) (*entity.Preferences, error)
+
+	// Update は認証されたユーザーの設定を置き換えます
+	Update(ctx context.Context, preferences *entity.Preferences) (*entity.Preferences, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ PreferencesServiceInterface = (*PreferencesService)(nil)
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockPreferencesRepository はテスト用のPreferencesRepositoryのモック実装です
+type MockPreferencesRepository struct {
+	preferences map[int]*entity.Preferences
+}
+
+// Get はユーザーの設定を取得します（モック実装）
+func (m *MockPreferencesRepository) Get(ctx context.Context, userID int) (*entity.Preferences, error) {
+	preferences, ok := m.preferences[userID]
+	if !ok {
+		return nil, domainerr.NotFound("preferences", nil)
+	}
+	preferencesCopy := *preferences
+	return &preferencesCopy, nil
+}
+
+// Save はユーザーの設定を保存します（モック実装）
+func (m *MockPreferencesRepository) Save(ctx context.Context, preferences *entity.Preferences) (*entity.Preferences, error) {
+	saved := *preferences
+	saved.UpdatedAt = time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	m.preferences[preferences.UserID] = &saved
+	return m.Get(ctx, preferences.UserID)
+}
+
+// TestPreferencesService は設定の既定値・保存・入力検証をテストします
+func TestPreferencesService(t *testing.T) {
+	s := NewPreferencesService(&MockPreferencesRepository{preferences: make(map[int]*entity.Preferences)})
+	alice := WithPrincipal(context.Background(), Principal{UserID: 1, Username: "alice"})
+
+	// 保存していない場合・認証がない場合は既定の設定
+	for name, ctx := range map[string]context.Context{"保存前": alice, "認証なし": context.Background()} {
+		got, err := s.Get(ctx)
+		if err != nil || got.Timezone != "UTC" || got.SortOrder != entity.TodoSortNewest || !got.NotifyReminders {
+			t.Errorf("%s の Get() = %+v, %v, 期待値 = 既定の設定", name, got, err)
+		}
+	}
+	if _, err := s.Update(context.Background(), entity.DefaultPreferences(0)); !errors.Is(err, ErrPreferencesRequireUser) {
+		t.Errorf("認証なしの Update() error = %v, 期待値 = ErrPreferencesRequireUser", err)
+	}
+
+	tests := []struct {
+		name        string
+		preferences entity.Preferences
+		expectedErr func(error) bool
+	}{
+		{name: "保存", preferences: entity.Preferences{Timezone: "Asia/Tokyo", SortOrder: entity.TodoSortDueDate}},
+		{name: "未知のタイムゾーン", preferences: entity.Preferences{Timezone: "Mars/Olympus", SortOrder: entity.TodoSortNewest}, expectedErr: domainerr.IsInvalid},
+		{name: "サーバーのタイムゾーン", preferences: entity.Preferences{Timezone: "Local", SortOrder: entity.TodoSortNewest}, expectedErr: domainerr.IsInvalid},
+		{name: "未知の並び順", preferences: entity.Preferences{Timezone: "UTC", SortOrder: "priority"}, expectedErr: domainerr.IsInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferences := tt.preferences
+			_, err := s.Update(alice, &preferences)
+			if tt.expectedErr != nil {
+				if !tt.expectedErr(err) {
+					t.Errorf("Update() error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+		})
+	}
+
+	// 不正な設定では保存した設定を変えない
+	got, err := s.Get(alice)
+	if err != nil || got.UserID != 1 || got.Timezone != "Asia/Tokyo" || got.SortOrder != entity.TodoSortDueDate || got.NotifyReminders {
+		t.Errorf("保存後の Get() = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// Principal はアクセストークンで認証されたユーザーです
+type Principal struct {
+	UserID   int
+	Username string
+
+	// Scopes はアクセストークンで許可された操作の範囲です（entity.ScopeTodosRead 等）
+	// 空の場合はスコープを限定しない（ログインで発行したトークン・セッション）ため、全ての操作を許可します
+	Scopes []string
+}
+
+// HasScope は scope の操作が許可されているかを判定します
+// scope が空の場合は、スコープを限定していない場合だけ true を返します（スコープを定義していない操作用）
+func (p Principal) HasScope(scope string) bool {
+	if len(p.Scopes) == 0 {
+		return true
+	}
+	return scope != "" && slices.Contains(p.Scopes, scope)
+}
+
+// principalKey はコンテキストに認証されたユーザーを格納するためのキーです
+type principalKey struct{}
+
+// WithPrincipal は認証されたユーザーをコンテキストに設定します
+// HTTPリクエストでは AuthMiddleware がアクセストークンを検証して設定します
+// 同時にそのユーザーをデータの所有者として設定するため、以降のTodoの操作は本人のTodoに限定されます
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = repository.WithOwner(ctx, principal.UserID)
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext はコンテキストから認証されたユーザーを取得します
+// 認証が無効な場合など、設定されていない場合は false を返します
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// maxSlugAttempts はスラッグ衝突時に試行する候補数の上限です
+// "home", "home-2", ..., "home-20" まで試して全て使用済みならエラーとします
+const maxSlugAttempts = 20
+
+// ProjectService はプロジェクトに関するビジネスロジックを管理するドメインサービスです
+type ProjectService struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewProjectService はProjectServiceのコンストラクタです
+func NewProjectService(projectRepo repository.ProjectRepository) *ProjectService {
+	return &ProjectService{
+		projectRepo: projectRepo,
+	}
+}
+
+// CreateProject はプロジェクト名からスラッグを生成してプロジェクトを作成します
+//
+// スラッグの衝突処理：
+//  1. 名前から基底スラッグを生成（例: "Home Tasks" → "home-tasks"）
+//  2. 使用済みなら "-2", "-3" ... と接尾辞を付けた候補を順に確認
+//  3. 確認と作成の間に他のリクエストが同じスラッグを登録した場合は
+//     リポジトリが ErrSlugConflict を返すため、次の候補で再試行する
+func (s *ProjectService) CreateProject(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	if !project.IsValid() {
+		return nil, domainerr.ValidationFailed("project", fmt.Sprintf("name is required and must be %d characters or less", entity.MaxProjectNameLength))
+	}
+
+	base := entity.GenerateSlug(project.Name)
+
+	for n := 1; n <= maxSlugAttempts; n++ {
+		candidate := entity.SlugCandidate(base, n)
+
+		exists, err := s.projectRepo.SlugExists(ctx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create project: %w", err)
+		}
+		if exists {
+			continue
+		}
+
+		project.Slug = candidate
+		created, err := s.projectRepo.Create(ctx, project)
+		if errors.Is(err, repository.ErrSlugConflict) {
+			// 確認後に他のリクエストに先を越された場合は次の候補へ
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create project: %w", err)
+		}
+
+		return created, nil
+	}
+
+	return nil, fmt.Errorf("failed to create project: could not find a free slug for %q after %d attempts", base, maxSlugAttempts)
+}
+
+// GetProject はIDまたはスラッグでプロジェクトを取得します
+// 数値として解釈できる場合はID、それ以外はスラッグとして扱います
+// （GenerateSlug は数字のみのスラッグを生成しないため、IDと衝突しません）
+func (s *ProjectService) GetProject(ctx context.Context, idOrSlug string) (*entity.Project, error) {
+	if idOrSlug == "" {
+		return nil, domainerr.Invalid("project identifier", "must not be empty")
+	}
+
+	var (
+		project *entity.Project
+		err     error
+	)
+	if id, convErr := strconv.Atoi(idOrSlug); convErr == nil {
+		project, err = s.projectRepo.GetByID(ctx, id)
+	} else {
+		project, err = s.projectRepo.GetBySlug(ctx, idOrSlug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", idOrSlug, err)
+	}
+
+	return project, nil
+}
+
+// GetAllProjects は全てのプロジェクトを取得します
+func (s *ProjectService) GetAllProjects(ctx context.Context) ([]*entity.Project, error) {
+	projects, err := s.projectRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all projects: %w", err)
+	}
+	return projects, nil
+}
+
+// ArchiveProject はプロジェクトをアーカイブします
+// アーカイブしたプロジェクトのTodoは既定の一覧・検索に表示されなくなりますが、削除はされません
+// 既にアーカイブ済みの場合は何もせず、最初にアーカイブした日時を維持します
+func (s *ProjectService) ArchiveProject(ctx context.Context, idOrSlug string) (*entity.Project, error) {
+	project, err := s.GetProject(ctx, idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+	if project.IsArchived() {
+		return project, nil
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := s.projectRepo.SetArchivedAt(ctx, project.ID, &now); err != nil {
+		return nil, fmt.Errorf("failed to archive project %s: %w", idOrSlug, err)
+	}
+	project.ArchivedAt = &now
+	project.UpdatedAt = now
+	return project, nil
+}
+
+// RestoreProject はアーカイブしたプロジェクトを元に戻します
+// プロジェクトのTodoは再び既定の一覧・検索に表示されます
+// アーカイブされていない場合は何もしません
+func (s *ProjectService) RestoreProject(ctx context.Context, idOrSlug string) (*entity.Project, error) {
+	project, err := s.GetProject(ctx, idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !project.IsArchived() {
+		return project, nil
+	}
+
+	if err := s.projectRepo.SetArchivedAt(ctx, project.ID, nil); err != nil {
+		return nil, fmt.Errorf("failed to restore project %s: %w", idOrSlug, err)
+	}
+	project.ArchivedAt = nil
+	project.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	return project, nil
+}
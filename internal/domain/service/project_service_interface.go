@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ProjectServiceInterface はプロジェクトサービスのインターフェースです
+type ProjectServiceInterface interface {
+	// CreateProject はスラッグを自動生成してプロジェクトを作成します
+	CreateProject(ctx context.Context, project *entity.Project) (*entity.Project, error)
+
+	// GetProject はIDまたはスラッグでプロジェクトを取得します
+	GetProject(ctx context.Context, idOrSlug string) (*entity.Project, error)
+
+	// GetAllProjects は全てのプロジェクトを取得します
+	GetAllProjects(ctx context.Context) ([]*entity.Project, error)
+
+	// ArchiveProject はプロジェクトをアーカイブし、そのTodoを既定の一覧・検索から除外します
+	ArchiveProject(ctx context.Context, idOrSlug string) (*entity.Project, error)
+
+	// RestoreProject はアーカイブしたプロジェクトを元に戻します
+	RestoreProject(ctx context.Context, idOrSlug string) (*entity.Project, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ ProjectServiceInterface = (*ProjectService)(nil)
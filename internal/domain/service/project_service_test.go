@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// MockProjectRepository はテスト用のProjectRepositoryのモック実装です
+// raceSlugs に含まれるスラッグは SlugExists では未使用と報告しつつ、
+// Create では ErrSlugConflict を返して競合状態を再現します
+type MockProjectRepository struct {
+	projects  map[int]*entity.Project
+	nextID    int
+	raceSlugs map[string]bool
+}
+
+// NewMockProjectRepository はモックリポジトリのコンストラクタです
+func NewMockProjectRepository() *MockProjectRepository {
+	return &MockProjectRepository{
+		projects:  make(map[int]*entity.Project),
+		nextID:    1,
+		raceSlugs: make(map[string]bool),
+	}
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	if m.raceSlugs[project.Slug] {
+		return nil, repository.ErrSlugConflict
+	}
+	for _, p := range m.projects {
+		if p.Slug == project.Slug {
+			return nil, repository.ErrSlugConflict
+		}
+	}
+	project.ID = m.nextID
+	m.nextID++
+	saved := *project
+	m.projects[project.ID] = &saved
+	return &saved, nil
+}
+
+func (m *MockProjectRepository) GetByID(ctx context.Context, id int) (*entity.Project, error) {
+	if p, ok := m.projects[id]; ok {
+		result := *p
+		return &result, nil
+	}
+	return nil, domainerr.NotFound("project", nil)
+}
+
+func (m *MockProjectRepository) GetBySlug(ctx context.Context, slug string) (*entity.Project, error) {
+	for _, p := range m.projects {
+		if p.Slug == slug {
+			result := *p
+			return &result, nil
+		}
+	}
+	return nil, domainerr.NotFound("project", nil)
+}
+
+func (m *MockProjectRepository) GetAll(ctx context.Context) ([]*entity.Project, error) {
+	result := make([]*entity.Project, 0, len(m.projects))
+	for _, p := range m.projects {
+		pCopy := *p
+		result = append(result, &pCopy)
+	}
+	return result, nil
+}
+
+func (m *MockProjectRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	for _, p := range m.projects {
+		if p.Slug == slug {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockProjectRepository) SetArchivedAt(ctx context.Context, id int, archivedAt *time.Time) error {
+	p, ok := m.projects[id]
+	if !ok {
+		return domainerr.NotFound("project", nil)
+	}
+	p.ArchivedAt = archivedAt
+	return nil
+}
+
+// TestProjectService_CreateProject はスラッグの自動生成と衝突処理をテストします
+func TestProjectService_CreateProject(t *testing.T) {
+	repo := NewMockProjectRepository()
+	service := NewProjectService(repo)
+	ctx := context.Background()
+
+	first, err := service.CreateProject(ctx, &entity.Project{Name: "Home Tasks"})
+	if err != nil {
+		t.Fatalf("プロジェクトの作成に失敗: %v", err)
+	}
+	if first.Slug != "home-tasks" {
+		t.Errorf("スラッグ = %q, 期待値 = %q", first.Slug, "home-tasks")
+	}
+
+	// 同じ名前では接尾辞付きのスラッグになる
+	second, err := service.CreateProject(ctx, &entity.Project{Name: "Home  Tasks!"})
+	if err != nil {
+		t.Fatalf("プロジェクトの作成に失敗: %v", err)
+	}
+	if second.Slug != "home-tasks-2" {
+		t.Errorf("スラッグ = %q, 期待値 = %q", second.Slug, "home-tasks-2")
+	}
+
+	// 確認後に先を越された場合（競合）は次の候補で再試行する
+	repo.raceSlugs["home-tasks-3"] = true
+	third, err := service.CreateProject(ctx, &entity.Project{Name: "home tasks"})
+	if err != nil {
+		t.Fatalf("プロジェクトの作成に失敗: %v", err)
+	}
+	if third.Slug != "home-tasks-4" {
+		t.Errorf("スラッグ = %q, 期待値 = %q", third.Slug, "home-tasks-4")
+	}
+
+	// 名前が空の場合はエラー
+	if _, err := service.CreateProject(ctx, &entity.Project{Name: "  "}); err == nil {
+		t.Error("空の名前でエラーが期待されました")
+	}
+}
+
+// TestProjectService_GetProject はIDとスラッグの両方での取得をテストします
+func TestProjectService_GetProject(t *testing.T) {
+	repo := NewMockProjectRepository()
+	service := NewProjectService(repo)
+	ctx := context.Background()
+
+	created, _ := service.CreateProject(ctx, &entity.Project{Name: "Work"})
+
+	byID, err := service.GetProject(ctx, "1")
+	if err != nil || byID.ID != created.ID {
+		t.Errorf("IDでの取得に失敗: %v", err)
+	}
+
+	bySlug, err := service.GetProject(ctx, "work")
+	if err != nil || bySlug.ID != created.ID {
+		t.Errorf("スラッグでの取得に失敗: %v", err)
+	}
+
+	if _, err := service.GetProject(ctx, "missing"); err == nil {
+		t.Error("存在しないスラッグでエラーが期待されました")
+	}
+}
+
+// TestProjectService_ArchiveAndRestore はアーカイブと復元が冪等に動作することをテストします
+func TestProjectService_ArchiveAndRestore(t *testing.T) {
+	repo := NewMockProjectRepository()
+	service := NewProjectService(repo)
+	ctx := context.Background()
+
+	if _, err := service.CreateProject(ctx, &entity.Project{Name: "Work"}); err != nil {
+		t.Fatalf("プロジェクトの作成に失敗: %v", err)
+	}
+
+	archived, err := service.ArchiveProject(ctx, "work")
+	if err != nil {
+		t.Fatalf("アーカイブに失敗: %v", err)
+	}
+	if !archived.IsArchived() {
+		t.Fatal("アーカイブ後もアーカイブ日時が設定されていません")
+	}
+	stored, _ := repo.GetByID(ctx, archived.ID)
+	if !stored.IsArchived() {
+		t.Error("アーカイブ日時が保存されていません")
+	}
+
+	// 2回目のアーカイブでは最初の日時を維持する
+	first := *archived.ArchivedAt
+	again, err := service.ArchiveProject(ctx, "1")
+	if err != nil {
+		t.Fatalf("2回目のアーカイブに失敗: %v", err)
+	}
+	if !again.ArchivedAt.Equal(first) {
+		t.Errorf("アーカイブ日時 = %v, 期待値 = %v", again.ArchivedAt, first)
+	}
+
+	restored, err := service.RestoreProject(ctx, "work")
+	if err != nil {
+		t.Fatalf("復元に失敗: %v", err)
+	}
+	if restored.IsArchived() {
+		t.Error("復元後もアーカイブされたままです")
+	}
+	if _, err := service.RestoreProject(ctx, "work"); err != nil {
+		t.Errorf("アーカイブされていないプロジェクトの復元でエラー: %v", err)
+	}
+
+	if _, err := service.ArchiveProject(ctx, "missing"); err == nil {
+		t.Error("存在しないプロジェクトでエラーが期待されました")
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// InternalError は業務ロジックで発生したパニックを変換したエラーです
+//
+// 学習ポイント：
+//  1. パニックをミドルウェアの RecoveryMiddleware まで伝播させると、どの処理で起きたかが失われ、
+//     レスポンスも http.Error の素のテキストになる
+//  2. サービスの境界で recover してエラーに変換すれば、ハンドラーは通常のエラーと同じ経路で 500 を返せる
+//  3. 原因の調査に必要なパニックの値とスタックトレースはエラーに保持し、ログにも出力する
+//     （クライアントに返す Error() には含めない）
+type InternalError struct {
+	// Op はパニックが発生した操作です（例: TodoService.CreateTodo）
+	Op string
+
+	// Value は recover() で受け取ったパニックの値です
+	Value any
+
+	// Stack はパニックが発生した時点のスタックトレースです
+	Stack []byte
+}
+
+// Error はエラーメッセージを返します（パニックの値やスタックトレースは含めません）
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("internal error in %s", e.Op)
+}
+
+// Unwrap はパニックの値がエラーの場合に、そのエラーを返します
+func (e *InternalError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// recoverInternal はパニックを InternalError に変換して *err に設定します
+// 名前付きの戻り値 err を持つ関数で defer recoverInternal("操作名", &err) として使用します
+func recoverInternal(op string, err *error) {
+	if r := recover(); r != nil {
+		internal := &InternalError{Op: op, Value: r, Stack: debug.Stack()}
+		log.Printf("PANIC in %s: %v\n%s", op, r, internal.Stack)
+		*err = internal
+	}
+}
+
+// recoveringTodoService はTodoServiceInterfaceの各メソッドのパニックをInternalErrorに変換するデコレーターです
+type recoveringTodoService struct {
+	next TodoServiceInterface
+}
+
+// WithPanicRecovery は next の業務ロジックで発生したパニックを InternalError として返すようにラップします
+// ハンドラーに渡す直前に適用します（例: handler.NewTodoHandler(service.WithPanicRecovery(todoService))）
+func WithPanicRecovery(next TodoServiceInterface) TodoServiceInterface {
+	return &recoveringTodoService{next: next}
+}
+
+func (s *recoveringTodoService) CreateTodo(ctx context.Context, todo *entity.Todo) (_ *entity.Todo, err error) {
+	defer recoverInternal("TodoService.CreateTodo", &err)
+	return s.next.CreateTodo(ctx, todo)
+}
+
+func (s *recoveringTodoService) GetTodoByID(ctx context.Context, id int) (_ *entity.Todo, err error) {
+	defer recoverInternal("TodoService.GetTodoByID", &err)
+	return s.next.GetTodoByID(ctx, id)
+}
+
+func (s *recoveringTodoService) GetAllTodos(ctx context.Context) (_ []*entity.Todo, err error) {
+	defer recoverInternal("TodoService.GetAllTodos", &err)
+	return s.next.GetAllTodos(ctx)
+}
+
+func (s *recoveringTodoService) GetTodoPage(ctx context.Context, cursor string, limit int) (_ repository.TodoPage, err error) {
+	defer recoverInternal("TodoService.GetTodoPage", &err)
+	return s.next.GetTodoPage(ctx, cursor, limit)
+}
+
+func (s *recoveringTodoService) GetTodosByColor(ctx context.Context, color entity.Color) (_ []*entity.Todo, err error) {
+	defer recoverInternal("TodoService.GetTodosByColor", &err)
+	return s.next.GetTodosByColor(ctx, color)
+}
+
+func (s *recoveringTodoService) FindTodos(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) (_ []*entity.Todo, err error) {
+	defer recoverInternal("TodoService.FindTodos", &err)
+	return s.next.FindTodos(ctx, filter, order)
+}
+
+func (s *recoveringTodoService) GetTodoStats(ctx context.Context) (_ entity.TodoStats, err error) {
+	defer recoverInternal("TodoService.GetTodoStats", &err)
+	return s.next.GetTodoStats(ctx)
+}
+
+func (s *recoveringTodoService) UpdateTodo(ctx context.Context, todo *entity.Todo) (_ *entity.Todo, err error) {
+	defer recoverInternal("TodoService.UpdateTodo", &err)
+	return s.next.UpdateTodo(ctx, todo)
+}
+
+func (s *recoveringTodoService) UpdateTodos(ctx context.Context, todos []*entity.Todo) (_ []*entity.Todo, err error) {
+	defer recoverInternal("TodoService.UpdateTodos", &err)
+	return s.next.UpdateTodos(ctx, todos)
+}
+
+func (s *recoveringTodoService) ImportTodos(ctx context.Context, todos []*entity.Todo) (_ []*entity.Todo, err error) {
+	defer recoverInternal("TodoService.ImportTodos", &err)
+	return s.next.ImportTodos(ctx, todos)
+}
+
+func (s *recoveringTodoService) DeleteTodo(ctx context.Context, id int) (err error) {
+	defer recoverInternal("TodoService.DeleteTodo", &err)
+	return s.next.DeleteTodo(ctx, id)
+}
+
+func (s *recoveringTodoService) CompleteTodo(ctx context.Context, id int) (_ *entity.Todo, err error) {
+	defer recoverInternal("TodoService.CompleteTodo", &err)
+	return s.next.CompleteTodo(ctx, id)
+}
+
+func (s *recoveringTodoService) IncompleteTodo(ctx context.Context, id int) (_ *entity.Todo, err error) {
+	defer recoverInternal("TodoService.IncompleteTodo", &err)
+	return s.next.IncompleteTodo(ctx, id)
+}
+
+func (s *recoveringTodoService) DuplicateTodo(ctx context.Context, id int, opts DuplicateTodoOptions) (_ *entity.Todo, err error) {
+	defer recoverInternal("TodoService.DuplicateTodo", &err)
+	return s.next.DuplicateTodo(ctx, id, opts)
+}
+
+// コンパイル時インターフェース実装確認
+var _ TodoServiceInterface = (*recoveringTodoService)(nil)
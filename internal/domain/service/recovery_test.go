@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// panickingTodoService は業務ロジックの不具合でパニックするTodoServiceInterfaceです
+// CreateTodo は文字列で、それ以外のメソッドは nil のインターフェースの呼び出しでパニックします
+type panickingTodoService struct {
+	TodoServiceInterface
+}
+
+func (s *panickingTodoService) CreateTodo(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	panic("title rule is broken")
+}
+
+// TestWithPanicRecovery は業務ロジックのパニックが InternalError に変換されることをテストします
+func TestWithPanicRecovery(t *testing.T) {
+	todoService := WithPanicRecovery(&panickingTodoService{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		call          func() error
+		expectedOp    string
+		expectRuntime bool
+	}{
+		{
+			name: "文字列でのパニック",
+			call: func() error {
+				_, err := todoService.CreateTodo(ctx, &entity.Todo{Title: "牛乳を買う"})
+				return err
+			},
+			expectedOp: "TodoService.CreateTodo",
+		},
+		{
+			name:          "ランタイムエラーでのパニック",
+			call:          func() error { return todoService.DeleteTodo(ctx, 1) },
+			expectedOp:    "TodoService.DeleteTodo",
+			expectRuntime: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var internal *InternalError
+			if !errors.As(err, &internal) {
+				t.Fatalf("エラー = %v, InternalError を期待", err)
+			}
+			if internal.Op != tt.expectedOp {
+				t.Errorf("Op = %q, 期待値 = %q", internal.Op, tt.expectedOp)
+			}
+			if !strings.Contains(string(internal.Stack), "recovery_test.go") {
+				t.Error("スタックトレースにパニックの発生箇所が含まれていません")
+			}
+			if strings.Contains(err.Error(), "rule is broken") || strings.Contains(err.Error(), "goroutine") {
+				t.Errorf("エラーメッセージにパニックの詳細が含まれています: %q", err.Error())
+			}
+
+			var runtimeErr runtime.Error
+			if errors.As(err, &runtimeErr) != tt.expectRuntime {
+				t.Errorf("runtime.Error への Unwrap = %v, 期待値 = %v", !tt.expectRuntime, tt.expectRuntime)
+			}
+		})
+	}
+
+	// パニックしない呼び出しの結果とエラーはそのまま返す
+	healthy := WithPanicRecovery(NewTodoService(NewMockTodoRepository()))
+	if _, err := healthy.GetTodoByID(ctx, 99); err == nil || !domainerr.IsNotFound(err) {
+		t.Errorf("通常のエラーが変換されています: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// maxOccurrencesPerSeries は1回の実行で1つのシリーズから作成するオカレンスの最大件数です
+// 先行作成期間を長く設定した場合でも、1回の実行で大量の行が作られないようにします
+const maxOccurrencesPerSeries = 60
+
+// RecurrenceService は繰り返しTodoのオカレンスを先行作成するドメインサービスです
+// 完了時に次回分を作るのではなく、horizon の期間内に期限を迎える分をあらかじめ作成しておくことで、
+// 一覧やカレンダーに今後の予定が表示されるようにします
+type RecurrenceService struct {
+	recurrenceRepo repository.RecurrenceRepository
+	horizon        time.Duration
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewRecurrenceService はRecurrenceServiceのコンストラクタです
+// horizon は現在時刻からどれだけ先の期限までオカレンスを作成するかを表します
+func NewRecurrenceService(recurrenceRepo repository.RecurrenceRepository, horizon time.Duration) *RecurrenceService {
+	return &RecurrenceService{
+		recurrenceRepo: recurrenceRepo,
+		horizon:        horizon,
+		now:            time.Now,
+	}
+}
+
+// MaterializeUpcoming は各シリーズについて、現在時刻から horizon 先までに期限を迎える
+// オカレンスのうち、まだ作成されていないものを作成します
+// 戻り値は作成したオカレンスの件数です
+// 一部のシリーズで失敗しても残りのシリーズは続行し、失敗はまとめてエラーとして返します
+func (s *RecurrenceService) MaterializeUpcoming(ctx context.Context) (int, error) {
+	series, err := s.recurrenceRepo.ListSeries(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list recurring todos: %w", err)
+	}
+
+	now := s.now().UTC()
+	until := now.Add(s.horizon)
+
+	created := 0
+	var errs []error
+	for _, todo := range series {
+		occurrences, err := s.pendingOccurrences(ctx, todo, now, until)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.recurrenceRepo.CreateOccurrences(ctx, occurrences); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create occurrences for todo %d: %w", todo.ID, err))
+			continue
+		}
+		created += len(occurrences)
+	}
+
+	return created, errors.Join(errs...)
+}
+
+// pendingOccurrences はシリーズについて未作成のオカレンスを組み立てます
+// 最新のオカレンス（なければシリーズ自身の期限）の次回から数え、
+// 過去の期限は作成せずに読み飛ばします（ワーカー停止中の分をまとめて作らないため）
+func (s *RecurrenceService) pendingOccurrences(ctx context.Context, series *entity.Todo, now, until time.Time) ([]*entity.Todo, error) {
+	if !series.IsRecurringSeries() {
+		return nil, nil
+	}
+
+	last := *series.DueDate
+	latest, err := s.recurrenceRepo.LatestOccurrence(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest occurrence for todo %d: %w", series.ID, err)
+	}
+	if latest != nil && latest.After(last) {
+		last = *latest
+	}
+
+	occurrences := make([]*entity.Todo, 0)
+	for next := series.Recurrence.Next(last); !next.After(until); next = series.Recurrence.Next(next) {
+		if next.Before(now) {
+			continue
+		}
+		occurrences = append(occurrences, series.NewOccurrence(next))
+		if len(occurrences) >= maxOccurrencesPerSeries {
+			break
+		}
+	}
+
+	return occurrences, nil
+}
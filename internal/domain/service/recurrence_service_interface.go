@@ -0,0 +1,13 @@
+package service
+
+import "context"
+
+// RecurrenceServiceInterface は繰り返しTodoサービスのインターフェースです
+// バックグラウンドワーカーのテストでモック実装に差し替えられるように定義しています
+type RecurrenceServiceInterface interface {
+	// MaterializeUpcoming は今後のオカレンスを先行作成し、作成した件数を返します
+	MaterializeUpcoming(ctx context.Context) (int, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ RecurrenceServiceInterface = (*RecurrenceService)(nil)
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockRecurrenceRepository はテスト用のRecurrenceRepositoryのモック実装です
+// MockTodoRepository と同じデータを参照します
+type MockRecurrenceRepository struct {
+	todoRepo *MockTodoRepository
+	failIDs  map[int]bool
+}
+
+// ListSeries はシリーズをID順に取得します（モック実装）
+func (m *MockRecurrenceRepository) ListSeries(ctx context.Context) ([]*entity.Todo, error) {
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todoRepo.todos {
+		if todo.IsRecurringSeries() && todo.RecurrenceParentID == nil {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// LatestOccurrence は最新オカレンスの期限日を取得します（モック実装）
+func (m *MockRecurrenceRepository) LatestOccurrence(ctx context.Context, seriesID int) (*time.Time, error) {
+	var latest *time.Time
+	for _, todo := range m.todoRepo.todos {
+		if todo.RecurrenceParentID == nil || *todo.RecurrenceParentID != seriesID || todo.DueDate == nil {
+			continue
+		}
+		if latest == nil || todo.DueDate.After(*latest) {
+			latest = todo.DueDate
+		}
+	}
+	return latest, nil
+}
+
+// CreateOccurrences はオカレンスを保存します（モック実装）
+func (m *MockRecurrenceRepository) CreateOccurrences(ctx context.Context, occurrences []*entity.Todo) error {
+	for _, occurrence := range occurrences {
+		if m.failIDs[*occurrence.RecurrenceParentID] {
+			return errors.New("database unavailable")
+		}
+	}
+	for _, occurrence := range occurrences {
+		if _, err := m.todoRepo.Create(context.Background(), occurrence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newTestRecurrenceService は時刻を固定したRecurrenceServiceを作成します
+func newTestRecurrenceService(now time.Time, horizon time.Duration) (*RecurrenceService, *MockTodoRepository, *MockRecurrenceRepository) {
+	todoRepo := NewMockTodoRepository()
+	recurrenceRepo := &MockRecurrenceRepository{todoRepo: todoRepo, failIDs: make(map[int]bool)}
+	service := NewRecurrenceService(recurrenceRepo, horizon)
+	service.now = func() time.Time { return now }
+	return service, todoRepo, recurrenceRepo
+}
+
+// occurrenceDueDates はシリーズのオカレンスの期限日を昇順で返します
+func occurrenceDueDates(todoRepo *MockTodoRepository, seriesID int) []time.Time {
+	dates := make([]time.Time, 0)
+	for _, todo := range todoRepo.todos {
+		if todo.RecurrenceParentID != nil && *todo.RecurrenceParentID == seriesID {
+			dates = append(dates, *todo.DueDate)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// TestRecurrenceService_MaterializeUpcoming は先行作成期間内のオカレンスが作成されることをテストします
+func TestRecurrenceService_MaterializeUpcoming(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service, todoRepo, _ := newTestRecurrenceService(now, 3*24*time.Hour)
+	ctx := context.Background()
+
+	due := now
+	series, _ := todoRepo.Create(ctx, &entity.Todo{Title: "日報", DueDate: &due, Recurrence: entity.RecurrenceDaily})
+	todoRepo.Create(ctx, &entity.Todo{Title: "単発", DueDate: &due})
+
+	created, err := service.MaterializeUpcoming(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if created != 3 {
+		t.Errorf("作成件数 = %d, 期待値 = 3", created)
+	}
+
+	dates := occurrenceDueDates(todoRepo, series.ID)
+	expected := []time.Time{now.AddDate(0, 0, 1), now.AddDate(0, 0, 2), now.AddDate(0, 0, 3)}
+	if len(dates) != len(expected) {
+		t.Fatalf("オカレンス = %v, 期待値 = %v", dates, expected)
+	}
+	for i := range expected {
+		if !dates[i].Equal(expected[i]) {
+			t.Errorf("オカレンス[%d] = %v, 期待値 = %v", i, dates[i], expected[i])
+		}
+	}
+
+	// 2回目の実行では作成済みの分を作り直さない
+	created, err = service.MaterializeUpcoming(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if created != 0 {
+		t.Errorf("2回目の作成件数 = %d, 期待値 = 0", created)
+	}
+
+	// 時間が進むと、新たに期間内に入った分だけ作成される
+	service.now = func() time.Time { return now.AddDate(0, 0, 1) }
+	created, err = service.MaterializeUpcoming(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if created != 1 {
+		t.Errorf("翌日の作成件数 = %d, 期待値 = 1", created)
+	}
+}
+
+// TestRecurrenceService_MaterializeUpcoming_SkipsPast は過去の期限のオカレンスを作成しないことをテストします
+func TestRecurrenceService_MaterializeUpcoming_SkipsPast(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service, todoRepo, _ := newTestRecurrenceService(now, 14*24*time.Hour)
+	ctx := context.Background()
+
+	due := now.AddDate(0, 0, -21)
+	series, _ := todoRepo.Create(ctx, &entity.Todo{Title: "週報", DueDate: &due, Recurrence: entity.RecurrenceWeekly})
+
+	if _, err := service.MaterializeUpcoming(ctx); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	dates := occurrenceDueDates(todoRepo, series.ID)
+	expected := []time.Time{now, now.AddDate(0, 0, 7), now.AddDate(0, 0, 14)}
+	if len(dates) != len(expected) {
+		t.Fatalf("オカレンス = %v, 期待値 = %v", dates, expected)
+	}
+	for i := range expected {
+		if !dates[i].Equal(expected[i]) {
+			t.Errorf("オカレンス[%d] = %v, 期待値 = %v", i, dates[i], expected[i])
+		}
+	}
+}
+
+// TestRecurrenceService_MaterializeUpcoming_PartialFailure は一部のシリーズが失敗しても残りを処理することをテストします
+func TestRecurrenceService_MaterializeUpcoming_PartialFailure(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service, todoRepo, recurrenceRepo := newTestRecurrenceService(now, 24*time.Hour)
+	ctx := context.Background()
+
+	due := now
+	failing, _ := todoRepo.Create(ctx, &entity.Todo{Title: "失敗", DueDate: &due, Recurrence: entity.RecurrenceDaily})
+	ok, _ := todoRepo.Create(ctx, &entity.Todo{Title: "成功", DueDate: &due, Recurrence: entity.RecurrenceDaily})
+	recurrenceRepo.failIDs[failing.ID] = true
+
+	created, err := service.MaterializeUpcoming(ctx)
+	if err == nil {
+		t.Error("失敗したシリーズのエラーが返されるべきです")
+	}
+	if created != 1 {
+		t.Errorf("作成件数 = %d, 期待値 = 1", created)
+	}
+	if len(occurrenceDueDates(todoRepo, ok.ID)) != 1 {
+		t.Error("成功したシリーズのオカレンスが作成されていません")
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// reminderBatchSize は1回のスキャンで通知するリマインダーの最大件数です
+// 残りは次回のスキャンで処理されます
+const reminderBatchSize = 100
+
+// ReminderNotifier はリマインダーの通知先を抽象化するインターフェースです
+// ログ出力、メール、Webhook など、通知手段はインフラストラクチャ層で実装します
+type ReminderNotifier interface {
+	// Notify はTodoのリマインダーを通知します
+	// エラーを返した場合、再送キュー（WithDeliveryQueue）があればそちらで再送され、
+	// なければリマインダーは解除されず次回のスキャンで再通知されます
+	Notify(ctx context.Context, todo *entity.Todo) error
+}
+
+// ReminderService はリマインダーのスケジュール・通知・スヌーズ・解除を管理します
+type ReminderService struct {
+	reminderRepo repository.ReminderRepository
+	todoRepo     repository.TodoRepository
+	notifier     ReminderNotifier
+
+	// deliveryQueue は通知に失敗したリマインダーの再送キューです（nil の場合は次回のスキャンで再通知）
+	deliveryQueue DeliveryQueue
+
+	// preferences はTodoの所有者の通知の設定です（nil の場合は全てのリマインダーを通知）
+	preferences repository.PreferencesRepository
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// ReminderServiceOption はReminderServiceに任意の機能を設定する関数型オプションです
+type ReminderServiceOption func(*ReminderService)
+
+// WithDeliveryQueue は通知に失敗したリマインダーを再送キューに登録するようにします
+// 再送は指数バックオフで行われ、上限に達したものはデッドレターになります
+func WithDeliveryQueue(queue DeliveryQueue) ReminderServiceOption {
+	return func(s *ReminderService) {
+		s.deliveryQueue = queue
+	}
+}
+
+// WithReminderPreferences はTodoの所有者の設定（Preferences.NotifyReminders）でリマインダーを止められるようにします
+// 通知しないリマインダーも通知済みと同じく解除し、次回のスキャンで再び対象にならないようにします
+func WithReminderPreferences(preferences repository.PreferencesRepository) ReminderServiceOption {
+	return func(s *ReminderService) {
+		s.preferences = preferences
+	}
+}
+
+// NewReminderService はReminderServiceのコンストラクタです
+func NewReminderService(reminderRepo repository.ReminderRepository, todoRepo repository.TodoRepository, notifier ReminderNotifier, opts ...ReminderServiceOption) *ReminderService {
+	s := &ReminderService{
+		reminderRepo: reminderRepo,
+		todoRepo:     todoRepo,
+		notifier:     notifier,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// DispatchDue は通知時刻を過ぎたリマインダーを通知し、通知済みのリマインダーを解除します
+// 戻り値は通知に成功した件数です
+// 一部の通知に失敗しても残りの通知は続行し、失敗はまとめてエラーとして返します
+func (s *ReminderService) DispatchDue(ctx context.Context) (int, error) {
+	todos, err := s.reminderRepo.ListDue(ctx, s.now(), reminderBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due reminders: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, todo := range todos {
+		notify, err := s.notifyEnabled(ctx, todo)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !notify {
+			if err := s.reminderRepo.SetRemindAt(ctx, todo.ID, nil); err != nil {
+				errs = append(errs, fmt.Errorf("failed to clear reminder for todo %d: %w", todo.ID, err))
+			}
+			continue
+		}
+
+		if err := s.notifier.Notify(ctx, todo); err != nil {
+			if s.deliveryQueue == nil {
+				errs = append(errs, fmt.Errorf("failed to notify reminder for todo %d: %w", todo.ID, err))
+				continue
+			}
+
+			// 再送はキューに任せ、以降のスキャンで同じリマインダーを毎回送り直さないようにする
+			if err := s.deliveryQueue.Enqueue(ctx, entity.DeliveryKindReminder, todo.ID, todo, err); err != nil {
+				errs = append(errs, fmt.Errorf("failed to enqueue reminder for todo %d: %w", todo.ID, err))
+				continue
+			}
+			if err := s.reminderRepo.SetRemindAt(ctx, todo.ID, nil); err != nil {
+				errs = append(errs, fmt.Errorf("failed to clear reminder for todo %d: %w", todo.ID, err))
+			}
+			continue
+		}
+
+		// 通知済みのリマインダーを解除して、二重通知を防ぐ
+		if err := s.reminderRepo.SetRemindAt(ctx, todo.ID, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clear reminder for todo %d: %w", todo.ID, err))
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// notifyEnabled はTodoの所有者がリマインダーの通知を有効にしているかを判定します
+// 所有者のいないTodo・設定を保存していないユーザーは通知します
+func (s *ReminderService) notifyEnabled(ctx context.Context, todo *entity.Todo) (bool, error) {
+	if s.preferences == nil || todo.UserID == nil {
+		return true, nil
+	}
+	preferences, err := s.preferences.Get(ctx, *todo.UserID)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get preferences for todo %d: %w", todo.ID, err)
+	}
+	return preferences.NotifyReminders, nil
+}
+
+// Redeliver は再送キューに登録されたリマインダーを再通知します（DeliveryHandler として登録します）
+// 再送までの間にTodoが削除・完了された場合は、通知せずに完了として扱います
+func (s *ReminderService) Redeliver(ctx context.Context, payload []byte) error {
+	var snapshot entity.Todo
+	if err := json.Unmarshal(payload, &snapshot); err != nil {
+		return fmt.Errorf("invalid reminder payload: %w", err)
+	}
+
+	current, err := s.todoRepo.GetByID(ctx, snapshot.ID)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get todo %d: %w", snapshot.ID, err)
+	}
+	if current.IsCompleted {
+		return nil
+	}
+
+	// 通知内容は最新のTodo、通知時刻は失敗した時点のリマインダーを使う
+	current.RemindAt = snapshot.RemindAt
+	return s.notifier.Notify(ctx, current)
+}
+
+// Snooze はリマインダーの通知時刻を until に延期します
+// リマインダーが未設定の場合も、until に新しいリマインダーを設定します
+func (s *ReminderService) Snooze(ctx context.Context, todoID int, until time.Time) (*entity.Todo, error) {
+	if !until.After(s.now()) {
+		return nil, domainerr.Invalid("snooze time", "must be in the future")
+	}
+
+	todo, err := s.getTodo(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if todo.IsCompleted {
+		return nil, domainerr.Invalid("operation", "cannot snooze a reminder of a completed todo")
+	}
+
+	todo.ScheduleReminder(until)
+	if err := s.reminderRepo.SetRemindAt(ctx, todo.ID, todo.RemindAt); err != nil {
+		return nil, fmt.Errorf("failed to snooze reminder: %w", err)
+	}
+
+	return todo, nil
+}
+
+// Cancel はTodoのリマインダーを解除します
+// リマインダーが未設定の場合も成功として扱います（冪等な操作）
+func (s *ReminderService) Cancel(ctx context.Context, todoID int) error {
+	todo, err := s.getTodo(ctx, todoID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reminderRepo.SetRemindAt(ctx, todo.ID, nil); err != nil {
+		return fmt.Errorf("failed to cancel reminder: %w", err)
+	}
+
+	return nil
+}
+
+// getTodo はリマインダー操作の対象となるTodoを取得します
+func (s *ReminderService) getTodo(ctx context.Context, todoID int) (*entity.Todo, error) {
+	if todoID <= 0 {
+		return nil, domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+
+	todo, err := s.todoRepo.GetByID(ctx, todoID)
+	if err != nil {
+		return nil, lookupError("todo", todoID, err)
+	}
+
+	return todo, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ReminderServiceInterface はリマインダーサービスのインターフェースです
+// ハンドラー層やスケジューラーのテストでモック実装に差し替えられるように定義しています
+type ReminderServiceInterface interface {
+	// DispatchDue は通知時刻を過ぎたリマインダーを通知し、通知に成功した件数を返します
+	DispatchDue(ctx context.Context) (int, error)
+
+	// Snooze はリマインダーの通知時刻を延期します
+	Snooze(ctx context.Context, todoID int, until time.Time) (*entity.Todo, error)
+
+	// Cancel はリマインダーを解除します
+	Cancel(ctx context.Context, todoID int) error
+}
+
+// コンパイル時インターフェース実装確認
+var _ ReminderServiceInterface = (*ReminderService)(nil)
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockReminderRepository はテスト用のReminderRepositoryのモック実装です
+// MockTodoRepository と同じデータを参照し、remind_at の列だけを操作します
+type MockReminderRepository struct {
+	todoRepo *MockTodoRepository
+}
+
+// ListDue は通知時刻を過ぎたTodoを取得します（モック実装）
+func (m *MockReminderRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.Todo, error) {
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todoRepo.todos {
+		if todo.IsReminderDue(now) {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].RemindAt.Before(*result[j].RemindAt) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// SetRemindAt は通知時刻を設定します（モック実装）
+func (m *MockReminderRepository) SetRemindAt(ctx context.Context, todoID int, remindAt *time.Time) error {
+	todo, exists := m.todoRepo.todos[todoID]
+	if !exists {
+		return domainerr.NotFound("todo", nil)
+	}
+	todo.RemindAt = remindAt
+	return nil
+}
+
+// MockReminderNotifier はテスト用の通知先です
+// failIDs に含まれるTodoの通知は失敗します
+type MockReminderNotifier struct {
+	notified []int
+	failIDs  map[int]bool
+}
+
+// Notify は通知したTodoのIDを記録します（モック実装）
+func (m *MockReminderNotifier) Notify(ctx context.Context, todo *entity.Todo) error {
+	if m.failIDs[todo.ID] {
+		return errors.New("notifier unavailable")
+	}
+	m.notified = append(m.notified, todo.ID)
+	return nil
+}
+
+// newTestReminderService は時刻を固定したReminderServiceを作成します
+func newTestReminderService(now time.Time) (*ReminderService, *MockTodoRepository, *MockReminderNotifier) {
+	todoRepo := NewMockTodoRepository()
+	notifier := &MockReminderNotifier{failIDs: make(map[int]bool)}
+	service := NewReminderService(&MockReminderRepository{todoRepo: todoRepo}, todoRepo, notifier)
+	service.now = func() time.Time { return now }
+	return service, todoRepo, notifier
+}
+
+// TestReminderService_DispatchDue は期限を過ぎたリマインダーのみが通知・解除されることをテストします
+func TestReminderService_DispatchDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service, todoRepo, notifier := newTestReminderService(now)
+	ctx := context.Background()
+
+	due := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+	dueTodo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "通知対象", RemindAt: &due})
+	futureTodo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "まだ先", RemindAt: &future})
+	failTodo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "通知失敗", RemindAt: &due})
+	notifier.failIDs[failTodo.ID] = true
+
+	sent, err := service.DispatchDue(ctx)
+	if err == nil {
+		t.Error("通知失敗のエラーが期待されました")
+	}
+	if sent != 1 {
+		t.Errorf("通知件数 = %d, 期待値 = 1", sent)
+	}
+	if len(notifier.notified) != 1 || notifier.notified[0] != dueTodo.ID {
+		t.Errorf("通知されたTodo = %v, 期待値 = [%d]", notifier.notified, dueTodo.ID)
+	}
+
+	if todoRepo.todos[dueTodo.ID].RemindAt != nil {
+		t.Error("通知済みのリマインダーが解除されていません")
+	}
+	if todoRepo.todos[futureTodo.ID].RemindAt == nil {
+		t.Error("期限前のリマインダーが解除されました")
+	}
+	if todoRepo.todos[failTodo.ID].RemindAt == nil {
+		t.Error("通知に失敗したリマインダーは再通知のため残す必要があります")
+	}
+
+	// 2回目のスキャンでは通知済みのTodoは再通知されない
+	notifier.failIDs = map[int]bool{}
+	sent, err = service.DispatchDue(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラーが発生しました: %v", err)
+	}
+	if sent != 1 || notifier.notified[len(notifier.notified)-1] != failTodo.ID {
+		t.Errorf("再通知の結果が正しくありません: sent=%d, notified=%v", sent, notifier.notified)
+	}
+}
+
+// TestReminderService_DispatchDueMuted は所有者が通知を止めたリマインダーを、通知せずに解除することをテストします
+func TestReminderService_DispatchDueMuted(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service, todoRepo, notifier := newTestReminderService(now)
+	service.preferences = &MockPreferencesRepository{preferences: map[int]*entity.Preferences{
+		2: {UserID: 2, Timezone: "UTC", SortOrder: entity.TodoSortNewest, NotifyReminders: false},
+	}}
+	ctx := context.Background()
+
+	due := now.Add(-time.Minute)
+	alice, bob := 1, 2
+	aliceTodo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "設定なし", RemindAt: &due, UserID: &alice})
+	bobTodo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "通知を止めた", RemindAt: &due, UserID: &bob})
+
+	sent, err := service.DispatchDue(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラーが発生しました: %v", err)
+	}
+	if sent != 1 || len(notifier.notified) != 1 || notifier.notified[0] != aliceTodo.ID {
+		t.Errorf("通知されたTodo = %v（%d件）, 期待値 = [%d]", notifier.notified, sent, aliceTodo.ID)
+	}
+	if todoRepo.todos[bobTodo.ID].RemindAt != nil {
+		t.Error("通知を止めたリマインダーが解除されていません")
+	}
+}
+
+// TestReminderService_Snooze はスヌーズの検証と通知時刻の更新をテストします
+func TestReminderService_Snooze(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service, todoRepo, _ := newTestReminderService(now)
+	ctx := context.Background()
+
+	todo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "スヌーズ対象"})
+	completed, _ := todoRepo.Create(ctx, &entity.Todo{Title: "完了済み", IsCompleted: true})
+
+	tests := []struct {
+		name    string
+		todoID  int
+		until   time.Time
+		wantErr bool
+	}{
+		{name: "正常なスヌーズ", todoID: todo.ID, until: now.Add(10 * time.Minute), wantErr: false},
+		{name: "過去の時刻", todoID: todo.ID, until: now.Add(-time.Minute), wantErr: true},
+		{name: "現在時刻", todoID: todo.ID, until: now, wantErr: true},
+		{name: "存在しないTodo", todoID: 999, until: now.Add(time.Minute), wantErr: true},
+		{name: "完了済みのTodo", todoID: completed.ID, until: now.Add(time.Minute), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Snooze(ctx, tt.todoID, tt.until)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("エラーが期待されましたが、発生しませんでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラーが発生しました: %v", err)
+			}
+			if result.RemindAt == nil || !result.RemindAt.Equal(tt.until) {
+				t.Errorf("RemindAt = %v, 期待値 = %v", result.RemindAt, tt.until)
+			}
+			if stored := todoRepo.todos[tt.todoID].RemindAt; stored == nil || !stored.Equal(tt.until) {
+				t.Errorf("保存されたRemindAt = %v, 期待値 = %v", stored, tt.until)
+			}
+		})
+	}
+}
+
+// TestReminderService_Cancel はリマインダーの解除をテストします
+func TestReminderService_Cancel(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	service, todoRepo, _ := newTestReminderService(now)
+	ctx := context.Background()
+
+	remindAt := now.Add(time.Hour)
+	todo, _ := todoRepo.Create(ctx, &entity.Todo{Title: "解除対象", RemindAt: &remindAt})
+
+	if err := service.Cancel(ctx, todo.ID); err != nil {
+		t.Fatalf("解除に失敗: %v", err)
+	}
+	if todoRepo.todos[todo.ID].RemindAt != nil {
+		t.Error("リマインダーが解除されていません")
+	}
+
+	// 解除済みでも成功する（冪等）
+	if err := service.Cancel(ctx, todo.ID); err != nil {
+		t.Errorf("2回目の解除でエラーが発生しました: %v", err)
+	}
+
+	if err := service.Cancel(ctx, 999); err == nil {
+		t.Error("存在しないTodoでエラーが期待されました")
+	}
+}
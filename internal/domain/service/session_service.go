@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/pkg/password"
+)
+
+// ErrInvalidSession はセッションIDが存在しない・有効期限切れ・ログアウト済みの場合のエラーです
+// ミドルウェアはこのエラーを 401 Unauthorized として返します
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// sessionIDBytes はセッションIDの乱数のバイト数です
+const sessionIDBytes = 32
+
+// SessionService はCookieによるログイン（サーバー側のセッション）を管理するサービスです
+//
+// アクセストークン（AuthService）の代わりに、ブラウザから使うための認証方式です
+//  1. ログインするとランダムなセッションIDを発行し、SHA-256 のハッシュだけをストアに保存する
+//  2. リクエストのたびにストアを参照して検証するため、ログアウトするとすぐに使えなくなる
+//  3. ストアは repository.SessionRepository を実装していれば差し替えられる（メモリ上・データベース）
+type SessionService struct {
+	sessions repository.SessionRepository
+	users    repository.UserRepository
+	ttl      time.Duration
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewSessionService はSessionServiceのコンストラクタです
+// ttl はログインしてからセッションが有効な期間です
+func NewSessionService(sessions repository.SessionRepository, users repository.UserRepository, ttl time.Duration) *SessionService {
+	return &SessionService{
+		sessions: sessions,
+		users:    users,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Login はユーザー名とパスワードを照合し、新しいセッションを作成してセッションIDとセッションを返します
+// 一致しない場合は ErrInvalidCredentials を返します
+func (s *SessionService) Login(ctx context.Context, username, pw string) (string, *entity.Session, error) {
+	user, err := s.users.GetByUsername(ctx, username)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return "", nil, ErrInvalidCredentials
+		}
+		return "", nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !password.Verify(pw, user.PasswordHash) {
+		return "", nil, ErrInvalidCredentials
+	}
+
+	sessionID, err := randomToken(sessionIDBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	now := s.now().UTC().Truncate(time.Second)
+	session := &entity.Session{
+		TokenHash: hashToken(sessionID),
+		UserID:    user.ID,
+		Username:  user.Username,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.ttl),
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return "", nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	return sessionID, session, nil
+}
+
+// VerifySession はセッションIDを検証し、ログインしているユーザーを返します
+// 存在しない・有効期限切れの場合は ErrInvalidSession を返します
+func (s *SessionService) VerifySession(ctx context.Context, sessionID string) (Principal, error) {
+	session, err := s.sessions.GetByHash(ctx, hashToken(sessionID))
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return Principal{}, ErrInvalidSession
+		}
+		return Principal{}, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.Expired(s.now()) {
+		return Principal{}, ErrInvalidSession
+	}
+	return Principal{UserID: session.UserID, Username: session.Username}, nil
+}
+
+// Logout はセッションを削除します（以降、同じセッションIDは使えません）
+// 存在しない・削除済みのセッションも成功として扱います（ログアウトの再送で失敗させないため）
+func (s *SessionService) Logout(ctx context.Context, sessionID string) error {
+	if err := s.sessions.Delete(ctx, hashToken(sessionID)); err != nil && !domainerr.IsNotFound(err) {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired は有効期限を過ぎたセッションを削除します
+func (s *SessionService) PurgeExpired(ctx context.Context) (int, error) {
+	return s.sessions.DeleteExpired(ctx, s.now().UTC())
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// SessionServiceInterface はCookieのセッションのサービスのインターフェースです
+// ハンドラーとミドルウェアのテストでモック実装に差し替えられるように定義しています
+type SessionServiceInterface interface {
+	// Login はユーザー名とパスワードを照合し、新しいセッションを作成します
+	Login(ctx context.Context, username, password string) (string, *entity.Session, error)
+
+	// VerifySession はセッションIDを検証し、ログインしているユーザーを返します
+	VerifySession(ctx context.Context, sessionID string) (Principal, error)
+
+	// Logout はセッションを削除します
+	Logout(ctx context.Context, sessionID string) error
+
+	// PurgeExpired は有効期限を過ぎたセッションを削除します
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ SessionServiceInterface = (*SessionService)(nil)
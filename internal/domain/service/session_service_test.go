@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/pkg/password"
+)
+
+// MockSessionRepository はテスト用のSessionRepositoryのモック実装です
+type MockSessionRepository struct {
+	sessions map[string]*entity.Session
+}
+
+// Create はセッションを保存します（モック実装）
+func (m *MockSessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	m.sessions[session.TokenHash] = session
+	return nil
+}
+
+// GetByHash はセッションを取得します（モック実装）
+func (m *MockSessionRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.Session, error) {
+	session, ok := m.sessions[tokenHash]
+	if !ok {
+		return nil, domainerr.NotFound("session", nil)
+	}
+	return session, nil
+}
+
+// Delete はセッションを削除します（モック実装）
+func (m *MockSessionRepository) Delete(ctx context.Context, tokenHash string) error {
+	if _, ok := m.sessions[tokenHash]; !ok {
+		return domainerr.NotFound("session", nil)
+	}
+	delete(m.sessions, tokenHash)
+	return nil
+}
+
+// DeleteExpired は有効期限を過ぎたセッションを削除します（モック実装）
+func (m *MockSessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	n := 0
+	for tokenHash, session := range m.sessions {
+		if !session.ExpiresAt.After(before) {
+			delete(m.sessions, tokenHash)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// TestSessionService はログイン・セッションの検証・有効期限・ログアウトをテストします
+func TestSessionService(t *testing.T) {
+	ctx := context.Background()
+	users := NewMockUserRepository()
+	hash, _ := password.HashWithIterations("correct horse", 1000)
+	users.Create(ctx, &entity.User{Username: "alice", PasswordHash: hash})
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	sessions := &MockSessionRepository{sessions: make(map[string]*entity.Session)}
+	s := NewSessionService(sessions, users, time.Hour)
+	s.now = func() time.Time { return now }
+
+	for _, tt := range []struct{ username, password string }{{"alice", "wrong password"}, {"bob", "correct horse"}} {
+		if _, _, err := s.Login(ctx, tt.username, tt.password); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("Login(%q) error = %v, 期待値 = ErrInvalidCredentials", tt.username, err)
+		}
+	}
+
+	sessionID, session, err := s.Login(ctx, "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if session.TokenHash == sessionID || !session.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("セッション = %+v, 期待値 = セッションIDはハッシュで保存し、1時間後に期限切れ", session)
+	}
+	if principal, err := s.VerifySession(ctx, sessionID); err != nil || principal.Username != "alice" {
+		t.Errorf("VerifySession() = %+v, %v", principal, err)
+	}
+	if _, err := s.VerifySession(ctx, "unknown"); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("存在しないセッションの VerifySession() error = %v, 期待値 = ErrInvalidSession", err)
+	}
+
+	// 有効期限を過ぎたセッションは使えず、PurgeExpired で削除される
+	now = now.Add(time.Hour)
+	if _, err := s.VerifySession(ctx, sessionID); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("期限切れの VerifySession() error = %v, 期待値 = ErrInvalidSession", err)
+	}
+	if n, err := s.PurgeExpired(ctx); err != nil || n != 1 {
+		t.Errorf("PurgeExpired() = %d, %v, 期待値 = 1", n, err)
+	}
+
+	// ログアウトしたセッションはすぐに使えなくなる（ログアウトの再送も成功する）
+	sessionID, _, _ = s.Login(ctx, "alice", "correct horse")
+	for i := 0; i < 2; i++ {
+		if err := s.Logout(ctx, sessionID); err != nil {
+			t.Fatalf("%d回目の Logout() error = %v", i+1, err)
+		}
+	}
+	if _, err := s.VerifySession(ctx, sessionID); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("ログアウト後の VerifySession() error = %v, 期待値 = ErrInvalidSession", err)
+	}
+}
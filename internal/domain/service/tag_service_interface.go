@@ -0,0 +1,20 @@
+package service
+
+import "context"
+
+// TagServiceInterface はTodoのタグの一括操作のインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+// 実装は TodoService です（タグの変更はTodoの更新として変更履歴・イベントに記録されます）
+type TagServiceInterface interface {
+	// AddTagToTodos は選択したTodoにタグを付けます
+	AddTagToTodos(ctx context.Context, tag string, sel TodoSelection) (*BulkTagResult, error)
+
+	// RemoveTagFromTodos は選択したTodoからタグを外します
+	RemoveTagFromTodos(ctx context.Context, tag string, sel TodoSelection) (*BulkTagResult, error)
+
+	// MergeTags はタグ source を target に統合します
+	MergeTags(ctx context.Context, source, target string) (*BulkTagResult, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ TagServiceInterface = (*TodoService)(nil)
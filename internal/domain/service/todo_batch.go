@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// UndoActionBatchUpdate は一括更新の取り消しを表す操作の種類です
+const UndoActionBatchUpdate UndoAction = "batch_update"
+
+// BatchItemError は一括操作のうち、失敗した1件のエラーです
+type BatchItemError struct {
+	// Index はリクエスト内の位置です（0始まり）
+	Index int
+
+	// ID は対象のTodoのIDです
+	ID int
+
+	// Err は失敗の原因です（ErrDuplicateTitle などを errors.Is で判定できます）
+	Err error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("item %d (todo %d): %v", e.Index, e.ID, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError は一括操作の検証で失敗した全ての項目のエラーです
+// いずれかの項目が失敗した場合、どの項目も保存されません
+type BatchError struct {
+	Items []*BatchItemError
+}
+
+func (e *BatchError) Error() string {
+	messages := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		messages[i] = item.Error()
+	}
+	return fmt.Sprintf("%d of the batch items failed: %s", len(e.Items), strings.Join(messages, "; "))
+}
+
+// UpdateTodos は複数のTodoを1つのトランザクションでまとめて更新します
+//
+// 全ての項目を検証してから保存するため、一部だけが更新されることはありません
+// 検証に失敗した項目がある場合は、失敗した全ての項目を含む *BatchError を返します
+// （クライアントは1回のリクエストで全ての問題を確認できます）
+func (s *TodoService) UpdateTodos(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	// 1. 全ての項目を検証し、変更履歴と取り消し用に更新前の状態を取得
+	befores := make([]*entity.Todo, len(todos))
+	var failures []*BatchItemError
+	fail := func(index int, err error) {
+		failures = append(failures, &BatchItemError{Index: index, ID: todos[index].ID, Err: err})
+	}
+
+	seenIDs := make(map[int]bool, len(todos))
+	seenTitles := make(map[string]bool, len(todos))
+	for i, todo := range todos {
+		if todo.ID <= 0 {
+			fail(i, domainerr.Invalid("todo ID", "must be greater than 0"))
+			continue
+		}
+		if seenIDs[todo.ID] {
+			fail(i, domainerr.Invalid("batch", fmt.Sprintf("todo ID %d appears more than once", todo.ID)))
+			continue
+		}
+		seenIDs[todo.ID] = true
+
+		if !todo.IsValid() {
+			fail(i, domainerr.ValidationFailed("todo", "title is required and must be 100 characters or less"))
+			continue
+		}
+
+		existing, err := s.todoRepo.GetByID(ctx, todo.ID)
+		if err != nil {
+			fail(i, lookupError("todo", todo.ID, err))
+			continue
+		}
+		befores[i] = existing
+
+		// タイトルを変更する項目は、既存のTodoとも同じ一括更新内の他の項目とも重複してはならない
+		if todo.Title != existing.Title {
+			key := strings.ToLower(todo.Title)
+			if s.uniqueTitles && seenTitles[key] {
+				fail(i, fmt.Errorf("%w: %q", ErrDuplicateTitle, todo.Title))
+				continue
+			}
+			seenTitles[key] = true
+			if err := s.checkUniqueTitle(ctx, todo.Title, todo.ID); err != nil {
+				fail(i, err)
+				continue
+			}
+		}
+		if !equalProjectID(todo.ProjectID, existing.ProjectID) {
+			if err := s.checkProject(ctx, todo.ProjectID); err != nil {
+				fail(i, err)
+				continue
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return nil, &BatchError{Items: failures}
+	}
+
+	// 2. リポジトリを通じて1つのトランザクションで更新し、項目ごとに更新のイベントを発行
+	var updated []*entity.Todo
+	err := s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		var err error
+		updated, err = s.todoRepo.UpdateMany(ctx, todos)
+		if err != nil {
+			return fmt.Errorf("failed to update todos: %w", err)
+		}
+		for i, todo := range updated {
+			record(entity.TodoHistoryUpdated, befores[i], todo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 取り消しの対象のIDを集める
+	ids := make([]int, len(updated))
+	for i, todo := range updated {
+		ids[i] = todo.ID
+	}
+
+	// 4. 取り消しが有効な場合は、更新前の状態に戻す処理を記録
+	if s.undo != nil {
+		s.undo.record(ctx, UndoActionBatchUpdate, ids, func(ctx context.Context) error {
+			return s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+				reverted, err := s.todoRepo.UpdateMany(ctx, befores)
+				if err != nil {
+					return err
+				}
+				for i, todo := range reverted {
+					record(entity.TodoHistoryUpdated, updated[i], todo)
+				}
+				return nil
+			})
+		})
+	}
+	return updated, nil
+}
+
+// ImportTodos は複数のTodoを1つのトランザクションでまとめて作成します
+// 完了済みのTodoは作成した後に完了にします（変更履歴には作成と完了の両方が記録されます）
+//
+// 全ての項目を検証してから保存するため、検証に失敗した項目がある場合は何も作成せず、
+// 失敗した全ての項目を含む *BatchError を返します
+// 保存の途中で失敗した場合も、トランザクションが有効であれば作成済みの項目はロールバックされます
+func (s *TodoService) ImportTodos(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	// 1. 全ての項目を検証（同じインポート内でのタイトルの重複も確認）
+	var failures []*BatchItemError
+	seenTitles := make(map[string]bool, len(todos))
+	for i, todo := range todos {
+		err := s.validateNewTodo(ctx, todo)
+		if err == nil && s.uniqueTitles {
+			key := strings.ToLower(todo.Title)
+			if seenTitles[key] {
+				err = fmt.Errorf("%w: %q", ErrDuplicateTitle, todo.Title)
+			}
+			seenTitles[key] = true
+		}
+		if err != nil {
+			failures = append(failures, &BatchItemError{Index: i, Err: err})
+		}
+	}
+	if len(failures) > 0 {
+		return nil, &BatchError{Items: failures}
+	}
+
+	// 2. 1つのトランザクションでまとめて作成し、項目ごとに作成（と完了）のイベントを発行
+	completed := make([]bool, len(todos))
+	for i, todo := range todos {
+		completed[i] = todo.IsCompleted
+		todo.MarkAsIncomplete()
+	}
+	var created []*entity.Todo
+	err := s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		var err error
+		created, err = s.todoRepo.CreateMany(ctx, todos)
+		if err != nil {
+			return fmt.Errorf("failed to import todos: %w", err)
+		}
+		for i, saved := range created {
+			record(entity.TodoHistoryCreated, nil, saved)
+
+			if completed[i] {
+				update := *saved
+				update.MarkAsCompleted()
+				done, err := s.todoRepo.Update(ctx, &update)
+				if err != nil {
+					return fmt.Errorf("failed to complete imported todo %d: %w", i+1, err)
+				}
+				record(entity.TodoHistoryCompleted, saved, done)
+				created[i] = done
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestTodoService_UpdateTodos は一括更新の検証と全件または0件の保存をテストします
+func TestTodoService_UpdateTodos(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		todos []*entity.Todo
+		// wantFailed は失敗が期待される項目の位置です（空の場合は成功を期待）
+		wantFailed []int
+		// wantDuplicate は重複タイトルとして失敗する項目の位置です
+		wantDuplicate []int
+	}{
+		{
+			name: "全ての項目を更新",
+			todos: []*entity.Todo{
+				{ID: 1, Title: "買い物（済）", IsCompleted: true},
+				{ID: 2, Title: "Review"},
+			},
+		},
+		{
+			name: "失敗した全ての項目を報告",
+			todos: []*entity.Todo{
+				{ID: 1, Title: "買い物"},
+				{ID: 99, Title: "存在しない"},
+				{ID: 2, Title: ""},
+				{ID: 0, Title: "IDなし"},
+			},
+			wantFailed: []int{1, 2, 3},
+		},
+		{
+			name: "同じIDを2回指定",
+			todos: []*entity.Todo{
+				{ID: 1, Title: "買い物"},
+				{ID: 1, Title: "買い物2"},
+			},
+			wantFailed: []int{1},
+		},
+		{
+			name: "既存のTodoと同じタイトルへの変更を拒否",
+			todos: []*entity.Todo{
+				{ID: 1, Title: "review"},
+			},
+			wantFailed:    []int{0},
+			wantDuplicate: []int{0},
+		},
+		{
+			name: "一括更新内で同じタイトルへの変更を拒否",
+			todos: []*entity.Todo{
+				{ID: 1, Title: "掃除"},
+				{ID: 2, Title: "掃除"},
+			},
+			wantFailed:    []int{1},
+			wantDuplicate: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockTodoRepository()
+			mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "買い物"}
+			mockRepo.todos[2] = &entity.Todo{ID: 2, Title: "Review"}
+			service := NewTodoService(mockRepo, WithUniqueTitles())
+
+			updated, err := service.UpdateTodos(ctx, tt.todos)
+
+			if len(tt.wantFailed) == 0 {
+				if err != nil {
+					t.Fatalf("UpdateTodos() error = %v", err)
+				}
+				if len(updated) != len(tt.todos) {
+					t.Fatalf("更新件数 = %d, 期待値 = %d", len(updated), len(tt.todos))
+				}
+				if got := mockRepo.todos[1]; got.Title != "買い物（済）" || !got.IsCompleted {
+					t.Errorf("保存されたTodo = %+v", got)
+				}
+				return
+			}
+
+			var batchErr *BatchError
+			if !errors.As(err, &batchErr) {
+				t.Fatalf("エラー = %v, 期待値 = *BatchError", err)
+			}
+			if len(batchErr.Items) != len(tt.wantFailed) {
+				t.Fatalf("失敗した項目 = %v, 期待値の位置 = %v", batchErr, tt.wantFailed)
+			}
+			for i, index := range tt.wantFailed {
+				if batchErr.Items[i].Index != index {
+					t.Errorf("失敗した項目の位置 = %d, 期待値 = %d", batchErr.Items[i].Index, index)
+				}
+			}
+			for _, index := range tt.wantDuplicate {
+				for _, item := range batchErr.Items {
+					if item.Index == index && !errors.Is(item, ErrDuplicateTitle) {
+						t.Errorf("項目 %d のエラー = %v, 期待値 = ErrDuplicateTitle", index, item.Err)
+					}
+				}
+			}
+			if mockRepo.GetCallCount("UpdateMany") != 0 {
+				t.Error("失敗した項目がある場合は保存してはいけません")
+			}
+		})
+	}
+}
+
+// TestTodoService_UpdateTodos_HistoryAndUndo は一括更新の変更履歴と取り消しをテストします
+func TestTodoService_UpdateTodos_HistoryAndUndo(t *testing.T) {
+	mockRepo := NewMockTodoRepository()
+	historyRepo := &MockTodoHistoryRepository{}
+	undo := NewUndoService(time.Minute)
+	service := NewTodoService(mockRepo, WithTodoHistory(historyRepo), WithTodoUndo(undo))
+	ctx := context.Background()
+
+	mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "買い物"}
+	mockRepo.todos[2] = &entity.Todo{ID: 2, Title: "掃除"}
+
+	_, err := service.UpdateTodos(ctx, []*entity.Todo{
+		{ID: 1, Title: "買い物", IsCompleted: true},
+		{ID: 2, Title: "部屋の掃除"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateTodos() error = %v", err)
+	}
+
+	for _, id := range []int{1, 2} {
+		entries, _ := historyRepo.ListByTodoID(ctx, id)
+		if len(entries) != 1 || entries[0].Action != entity.TodoHistoryUpdated {
+			t.Errorf("Todo %d の履歴 = %+v, 期待値 = 更新1件", id, entries)
+		}
+	}
+
+	result, err := undo.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if result.Action != UndoActionBatchUpdate || len(result.TodoIDs) != 2 {
+		t.Errorf("Undo() = %+v, 期待値 = batch_update [1 2]", result)
+	}
+	if got := mockRepo.todos[1]; got.IsCompleted {
+		t.Error("取り消し後も完了状態のままです")
+	}
+	if got := mockRepo.todos[2]; got.Title != "掃除" {
+		t.Errorf("取り消し後のタイトル = %q, 期待値 = %q", got.Title, "掃除")
+	}
+}
+
+// TestTodoService_ImportTodos はインポートの検証と、作成・完了の変更履歴をテストします
+func TestTodoService_ImportTodos(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		todos []*entity.Todo
+		// wantFailed は失敗が期待される項目の位置です（空の場合は成功を期待）
+		wantFailed []int
+	}{
+		{
+			name: "完了済みのTodoを含めて作成",
+			todos: []*entity.Todo{
+				{Title: "牛乳を買う"},
+				{Title: "請求書を送る", IsCompleted: true},
+			},
+		},
+		{
+			name: "失敗した全ての項目を報告",
+			todos: []*entity.Todo{
+				{Title: ""},
+				{Title: "牛乳を買う"},
+				{Title: "review"},
+			},
+			wantFailed: []int{0, 2},
+		},
+		{
+			name: "インポート内で同じタイトルを拒否",
+			todos: []*entity.Todo{
+				{Title: "掃除"},
+				{Title: "掃除"},
+			},
+			wantFailed: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockTodoRepository()
+			existing, _ := mockRepo.Create(ctx, &entity.Todo{Title: "Review"})
+			historyRepo := &MockTodoHistoryRepository{}
+			service := NewTodoService(mockRepo, WithUniqueTitles(), WithTodoHistory(historyRepo))
+
+			created, err := service.ImportTodos(ctx, tt.todos)
+
+			if len(tt.wantFailed) > 0 {
+				var batchErr *BatchError
+				if !errors.As(err, &batchErr) {
+					t.Fatalf("エラー = %v, 期待値 = *BatchError", err)
+				}
+				if len(batchErr.Items) != len(tt.wantFailed) {
+					t.Fatalf("失敗した項目 = %v, 期待値の位置 = %v", batchErr, tt.wantFailed)
+				}
+				for i, index := range tt.wantFailed {
+					if batchErr.Items[i].Index != index {
+						t.Errorf("失敗した項目の位置 = %d, 期待値 = %d", batchErr.Items[i].Index, index)
+					}
+				}
+				if mockRepo.GetCallCount("Create") != 1 || mockRepo.GetCallCount("CreateMany") != 0 {
+					t.Error("失敗した項目がある場合は何も作成してはいけません")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ImportTodos() error = %v", err)
+			}
+			if len(created) != 2 || created[0].IsCompleted || !created[1].IsCompleted {
+				t.Fatalf("作成したTodo = %+v", created)
+			}
+			if got := mockRepo.GetCallCount("CreateMany"); got != 1 {
+				t.Errorf("CreateMany の呼び出し回数 = %d, 期待値 = 1（1件ずつ作成しない）", got)
+			}
+			if got := mockRepo.todos[created[1].ID]; !got.IsCompleted {
+				t.Errorf("保存されたTodo = %+v, 期待値 = 完了済み", got)
+			}
+			entries, _ := historyRepo.ListByTodoID(ctx, created[1].ID)
+			if len(entries) != 2 || entries[0].Action != entity.TodoHistoryCreated || entries[1].Action != entity.TodoHistoryCompleted {
+				t.Errorf("Todo %d の履歴 = %+v, 期待値 = 作成と完了", created[1].ID, entries)
+			}
+			if len(mockRepo.todos) != 1+len(tt.todos) || existing.ID == created[0].ID {
+				t.Errorf("Todoの件数 = %d, 期待値 = %d", len(mockRepo.todos), 1+len(tt.todos))
+			}
+		})
+	}
+}
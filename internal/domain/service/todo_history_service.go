@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// ActivityFeedSize はフィードに含める最近の活動の件数です
+const ActivityFeedSize = 50
+
+// activityFeedActions はフィードに含める操作です（利用者が追いかけたい作成と完了のみ）
+var activityFeedActions = []entity.TodoHistoryAction{entity.TodoHistoryCreated, entity.TodoHistoryCompleted}
+
+// TodoHistoryService はTodoの変更履歴を参照するドメインサービスです
+// 履歴の記録は SubscribeTodoHistory で登録したイベントの購読者が行い、このサービスは参照のみを担当します
+type TodoHistoryService struct {
+	historyRepo repository.TodoHistoryRepository
+	todoRepo    repository.TodoRepository
+}
+
+// SubscribeTodoHistory は全ての種類のTodoのイベントを購読し、変更履歴として記録します
+// 変更自体はすでに保存済みのため、履歴の記録に失敗しても操作は失敗扱いにせず、ログに残します
+// （失敗として返すと、クライアントが成功済みの変更を再試行してしまうため）
+func SubscribeTodoHistory(bus *event.Bus, historyRepo repository.TodoHistoryRepository) {
+	event.SubscribeTodoEvents(bus, func(ctx context.Context, e event.TodoEvent) {
+		change := e.Change()
+		entry := entity.NewTodoHistoryEntry(e.Action(), ActorFromContext(ctx), change.Before, change.After)
+		if _, err := historyRepo.Record(ctx, entry); err != nil {
+			log.Printf("Failed to record %s history for todo %d: %v", e.Action(), entry.TodoID, err)
+		}
+	})
+}
+
+// NewTodoHistoryService はTodoHistoryServiceのコンストラクタです
+func NewTodoHistoryService(historyRepo repository.TodoHistoryRepository, todoRepo repository.TodoRepository) *TodoHistoryService {
+	return &TodoHistoryService{
+		historyRepo: historyRepo,
+		todoRepo:    todoRepo,
+	}
+}
+
+// GetHistory は指定されたTodoの変更履歴を古い順に取得します
+// 削除済みのTodoでも履歴があれば返します
+// 履歴がなく、Todoも存在しない場合は domainerr.NotFound のエラーを返します
+func (s *TodoHistoryService) GetHistory(ctx context.Context, todoID int) ([]*entity.TodoHistoryEntry, error) {
+	if todoID <= 0 {
+		return nil, domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+
+	// 所有者が設定されている場合は、本人のTodoであることを先に確認する
+	// （削除済みのTodoは所有者を確認できないため、履歴も返さない）
+	if _, ok := repository.OwnerFromContext(ctx); ok {
+		if err := requireTodo(ctx, s.todoRepo, todoID); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.historyRepo.ListByTodoID(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of todo %d: %w", todoID, err)
+	}
+
+	// 履歴の記録を有効にする前に作成されたTodoは履歴が空のため、存在確認で404と区別する
+	if len(entries) == 0 {
+		if err := requireTodo(ctx, s.todoRepo, todoID); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// RecentActivity は最近作成・完了されたTodoの履歴を新しい順に最大 ActivityFeedSize 件取得します
+func (s *TodoHistoryService) RecentActivity(ctx context.Context) ([]*entity.TodoHistoryEntry, error) {
+	entries, err := s.historyRepo.ListRecent(ctx, activityFeedActions, ActivityFeedSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent activity: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoHistoryServiceInterface は変更履歴サービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type TodoHistoryServiceInterface interface {
+	// GetHistory は指定されたTodoの変更履歴を古い順に取得します
+	GetHistory(ctx context.Context, todoID int) ([]*entity.TodoHistoryEntry, error)
+
+	// RecentActivity は最近作成・完了されたTodoの履歴を新しい順に取得します（フィードの配信用）
+	RecentActivity(ctx context.Context) ([]*entity.TodoHistoryEntry, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ TodoHistoryServiceInterface = (*TodoHistoryService)(nil)
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockTodoHistoryRepository はテスト用のTodoHistoryRepositoryのモック実装です
+type MockTodoHistoryRepository struct {
+	entries     []*entity.TodoHistoryEntry
+	shouldError bool
+}
+
+// Record は履歴を保存します（モック実装）
+func (m *MockTodoHistoryRepository) Record(ctx context.Context, entry *entity.TodoHistoryEntry) (*entity.TodoHistoryEntry, error) {
+	if m.shouldError {
+		return nil, errors.New("database unavailable")
+	}
+	entry.ID = len(m.entries) + 1
+	m.entries = append(m.entries, entry)
+	return entry, nil
+}
+
+// ListByTodoID は指定されたTodoの履歴を取得します（モック実装）
+func (m *MockTodoHistoryRepository) ListByTodoID(ctx context.Context, todoID int) ([]*entity.TodoHistoryEntry, error) {
+	result := make([]*entity.TodoHistoryEntry, 0)
+	for _, entry := range m.entries {
+		if entry.TodoID == todoID {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// ListRecent は指定した操作の履歴を新しい順に取得します（モック実装）
+func (m *MockTodoHistoryRepository) ListRecent(ctx context.Context, actions []entity.TodoHistoryAction, limit int) ([]*entity.TodoHistoryEntry, error) {
+	result := make([]*entity.TodoHistoryEntry, 0)
+	for i := len(m.entries) - 1; i >= 0 && len(result) < limit; i-- {
+		if len(actions) == 0 || slices.Contains(actions, m.entries[i].Action) {
+			result = append(result, m.entries[i])
+		}
+	}
+	return result, nil
+}
+
+// TestTodoService_RecordsHistory は各操作で変更前後のスナップショットと操作者が記録されることをテストします
+func TestTodoService_RecordsHistory(t *testing.T) {
+	todoRepo := NewMockTodoRepository()
+	historyRepo := &MockTodoHistoryRepository{}
+	todoService := NewTodoService(todoRepo, WithTodoHistory(historyRepo))
+	ctx := WithActor(context.Background(), "alice")
+
+	created, err := todoService.CreateTodo(ctx, &entity.Todo{Title: "変更前"})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+
+	update := *created
+	update.Title = "変更後"
+	if _, err := todoService.UpdateTodo(ctx, &update); err != nil {
+		t.Fatalf("更新に失敗: %v", err)
+	}
+	if _, err := todoService.CompleteTodo(ctx, created.ID); err != nil {
+		t.Fatalf("完了に失敗: %v", err)
+	}
+	if _, err := todoService.IncompleteTodo(context.Background(), created.ID); err != nil {
+		t.Fatalf("未完了に失敗: %v", err)
+	}
+	if err := todoService.DeleteTodo(ctx, created.ID); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+
+	history, _ := historyRepo.ListByTodoID(ctx, created.ID)
+	expected := []entity.TodoHistoryAction{
+		entity.TodoHistoryCreated,
+		entity.TodoHistoryUpdated,
+		entity.TodoHistoryCompleted,
+		entity.TodoHistoryIncompleted,
+		entity.TodoHistoryDeleted,
+	}
+	if len(history) != len(expected) {
+		t.Fatalf("履歴の件数 = %d, 期待値 = %d", len(history), len(expected))
+	}
+	for i, action := range expected {
+		if history[i].Action != action {
+			t.Errorf("履歴[%d].Action = %v, 期待値 = %v", i, history[i].Action, action)
+		}
+	}
+
+	if history[1].Before.Title != "変更前" || history[1].After.Title != "変更後" {
+		t.Errorf("更新のスナップショットが正しくありません: before=%q, after=%q", history[1].Before.Title, history[1].After.Title)
+	}
+	if history[2].Before.IsCompleted || !history[2].After.IsCompleted {
+		t.Error("完了のスナップショットが正しくありません")
+	}
+	if history[0].Actor != "alice" || history[3].Actor != entity.AnonymousActor {
+		t.Errorf("操作者が正しくありません: %q, %q", history[0].Actor, history[3].Actor)
+	}
+	if history[4].Before == nil || history[4].After != nil {
+		t.Error("削除の履歴は変更前のみを持つべきです")
+	}
+}
+
+// TestTodoService_HistoryFailureDoesNotFailOperation は履歴の記録に失敗しても操作自体は成功することをテストします
+func TestTodoService_HistoryFailureDoesNotFailOperation(t *testing.T) {
+	todoService := NewTodoService(NewMockTodoRepository(), WithTodoHistory(&MockTodoHistoryRepository{shouldError: true}))
+
+	if _, err := todoService.CreateTodo(context.Background(), &entity.Todo{Title: "タスク"}); err != nil {
+		t.Errorf("履歴の記録失敗で操作が失敗しています: %v", err)
+	}
+}
+
+// TestTodoHistoryService_GetHistory は履歴の取得と存在しないTodoの扱いをテストします
+func TestTodoHistoryService_GetHistory(t *testing.T) {
+	todoRepo := NewMockTodoRepository()
+	historyRepo := &MockTodoHistoryRepository{}
+	todoService := NewTodoService(todoRepo, WithTodoHistory(historyRepo))
+	historyService := NewTodoHistoryService(historyRepo, todoRepo)
+	ctx := context.Background()
+
+	deleted, _ := todoService.CreateTodo(ctx, &entity.Todo{Title: "削除するTodo"})
+	todoService.DeleteTodo(ctx, deleted.ID)
+	// 履歴の記録を有効にする前に作成されたTodo
+	legacy, _ := todoRepo.Create(ctx, &entity.Todo{Title: "履歴なし"})
+
+	tests := []struct {
+		name          string
+		todoID        int
+		expectedCount int
+		expectedError string
+	}{
+		{name: "削除済みのTodoの履歴", todoID: deleted.ID, expectedCount: 2},
+		{name: "履歴のない既存のTodo", todoID: legacy.ID, expectedCount: 0},
+		{name: "存在しないTodo", todoID: 99, expectedError: "not found"},
+		{name: "不正なID", todoID: 0, expectedError: "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := historyService.GetHistory(ctx, tt.todoID)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("エラー = %v, 期待値 = %q を含むエラー", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(entries) != tt.expectedCount {
+				t.Errorf("件数 = %d, 期待値 = %d", len(entries), tt.expectedCount)
+			}
+		})
+	}
+}
+
+// TestTodoHistoryService_RecentActivity は作成と完了の履歴だけが新しい順に返されることをテストします
+func TestTodoHistoryService_RecentActivity(t *testing.T) {
+	todoRepo := NewMockTodoRepository()
+	historyRepo := &MockTodoHistoryRepository{}
+	todoService := NewTodoService(todoRepo, WithTodoHistory(historyRepo))
+	historyService := NewTodoHistoryService(historyRepo, todoRepo)
+	ctx := context.Background()
+
+	first, _ := todoService.CreateTodo(ctx, &entity.Todo{Title: "一つ目"})
+	second, _ := todoService.CreateTodo(ctx, &entity.Todo{Title: "二つ目"})
+	update := *first
+	update.Title = "一つ目（変更）"
+	todoService.UpdateTodo(ctx, &update)
+	todoService.CompleteTodo(ctx, first.ID)
+	todoService.IncompleteTodo(ctx, first.ID)
+
+	entries, err := historyService.RecentActivity(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	expected := []struct {
+		todoID int
+		action entity.TodoHistoryAction
+	}{
+		{first.ID, entity.TodoHistoryCompleted},
+		{second.ID, entity.TodoHistoryCreated},
+		{first.ID, entity.TodoHistoryCreated},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("件数 = %d, 期待値 = %d", len(entries), len(expected))
+	}
+	for i, want := range expected {
+		if entries[i].TodoID != want.todoID || entries[i].Action != want.action {
+			t.Errorf("%d件目 = (%d, %s), 期待値 = (%d, %s)", i, entries[i].TodoID, entries[i].Action, want.todoID, want.action)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/event"
+)
+
+// TodoMetrics はTodoに関するビジネス指標（KPI）の記録先です
+//
+// 学習ポイント：
+//  1. HTTPのメトリクス（リクエスト数・レイテンシ）はエンドポイント単位だが、
+//     「Todoが完了した」はPATCHでも /complete でも起こるため、サービス層で記録する
+//  2. ドメイン層はインターフェースだけを定義し、Prometheus 形式での公開はインフラ層が実装する
+//     （リポジトリと同じ依存関係逆転の考え方）
+//
+// 記録はリクエストの処理中に同期的に呼ばれるため、実装はブロックしないようにしてください
+type TodoMetrics interface {
+	// TodoCreated はTodoが1件作成されたことを記録します（複製を含む）
+	TodoCreated()
+
+	// TodoCompleted は未完了のTodoが完了になったことを記録します
+	TodoCompleted()
+
+	// TodoListObserved は一覧取得で返したTodoの件数を記録します
+	TodoListObserved(size int)
+}
+
+// WithTodoMetrics はビジネス指標の記録を有効にします
+// 作成数・完了数はイベントの購読者として、一覧の件数は一覧取得の中で記録します
+func WithTodoMetrics(metrics TodoMetrics) TodoServiceOption {
+	return func(s *TodoService) {
+		s.metrics = metrics
+		s.subscriptions = append(s.subscriptions, func(bus *event.Bus) {
+			SubscribeTodoMetrics(bus, metrics)
+		})
+	}
+}
+
+// SubscribeTodoMetrics はTodoの作成と完了のイベントを購読し、ビジネス指標に記録します
+// 復元は作成として数えず、すでに完了済みのTodoを再度完了にした場合も数えません
+func SubscribeTodoMetrics(bus *event.Bus, metrics TodoMetrics) {
+	event.Subscribe(bus, func(ctx context.Context, e event.TodoCreated) {
+		metrics.TodoCreated()
+	})
+	recordCompletion := func(change event.TodoChange) {
+		if change.BecameCompleted() {
+			metrics.TodoCompleted()
+		}
+	}
+	event.Subscribe(bus, func(ctx context.Context, e event.TodoUpdated) {
+		recordCompletion(e.TodoChange)
+	})
+	event.Subscribe(bus, func(ctx context.Context, e event.TodoCompleted) {
+		recordCompletion(e.TodoChange)
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 
+	"todoapp-api-golang/internal/domain/domainerr"
 	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
 	"todoapp-api-golang/internal/domain/repository"
 )
 
@@ -20,52 +22,238 @@ type TodoService struct {
 	// インターフェース経由で実装することで、依存関係を逆転させています
 	// （ドメイン層がインフラ層に依存しない設計）
 	todoRepo repository.TodoRepository
+
+	// projectRepo は所属先のプロジェクトの確認に使用します（nil の場合は確認しない）
+	projectRepo repository.ProjectRepository
+
+	// checklistRepo は複製時のチェックリストのコピー元・コピー先です（nil の場合はコピーしない）
+	checklistRepo repository.ChecklistRepository
+
+	// metrics はビジネス指標の記録先です（nil の場合は記録しない）
+	metrics TodoMetrics
+
+	// uniqueTitles が true の場合、同じタイトルのTodoの作成・更新を拒否します
+	uniqueTitles bool
+
+	// undo は削除の取り消しの記録先です（nil の場合は取り消せない）
+	undo *UndoService
+
+	// events は保存済みの変更をイベントとして発行する先です
+	// 変更履歴・Webhook・指標などの付随的な処理は、このバスの購読者として実装します
+	events *event.Bus
+
+	// subscriptions は WithTodoHistory などのオプションが登録する購読者です
+	// オプションの順序によらず同じバスに登録されるよう、コンストラクタで最後にまとめて登録します
+	subscriptions []func(bus *event.Bus)
+
+	// transactor は複数の書き込みを1つのトランザクションにまとめます
+	// nil の場合は書き込みを1件ずつ実行します（途中で失敗すると、それまでの書き込みは残ります）
+	transactor repository.Transactor
+
+	// outbox はイベントの保存先です（nil の場合はアウトボックスを使わない）
+	outbox repository.OutboxRepository
+
+	// shareRepo は共有されたTodoの権限の確認に使用します（nil の場合は所有者本人だけが操作できる）
+	shareRepo repository.TodoShareRepository
+}
+
+// ErrDuplicateTitle は一意なタイトルのルールが有効なときに、同じタイトルのTodoが既に存在する場合のエラーです
+// ハンドラーはこのエラーを 409 Conflict として返します
+var ErrDuplicateTitle = errors.New("todo title already exists")
+
+// ErrInvalidProject は所属先に指定したプロジェクトが存在しない、またはアーカイブ済みの場合のエラーです
+// ハンドラーはこのエラーを 400 Bad Request として返します
+var ErrInvalidProject = errors.New("invalid project")
+
+// ErrTodoForbidden は共有されたTodoに対して、共有の権限で許可されていない操作を行った場合のエラーです
+// （読み取りのみの共有での更新、所有者以外による削除・共有の設定など）
+// ハンドラーはこのエラーを 403 Forbidden として返します
+var ErrTodoForbidden = errors.New("operation not permitted on shared todo")
+
+// DuplicateTodoOptions はTodoの複製方法の指定です
+type DuplicateTodoOptions struct {
+	// Title は複製のタイトルです（空の場合は元のタイトルのまま）
+	Title string
+
+	// IncludeChecklist が true の場合、チェックリスト項目も未チェックの状態でコピーします
+	IncludeChecklist bool
+}
+
+// TodoServiceOption はTodoServiceに任意の機能を設定する関数型オプションです
+type TodoServiceOption func(*TodoService)
+
+// WithTodoEvents は保存済みの変更を bus へ発行するようにします
+// 指定しない場合はTodoService専用のバスを使います（他のサブシステムから購読する場合に指定します）
+func WithTodoEvents(bus *event.Bus) TodoServiceOption {
+	return func(s *TodoService) {
+		s.events = bus
+	}
+}
+
+// WithTodoHistory は作成・更新・削除・完了切り替えの変更履歴の記録を有効にします
+// SubscribeTodoHistory でイベントバスに変更履歴の記録を購読させる場合の簡易版です
+func WithTodoHistory(historyRepo repository.TodoHistoryRepository) TodoServiceOption {
+	return func(s *TodoService) {
+		s.subscriptions = append(s.subscriptions, func(bus *event.Bus) {
+			SubscribeTodoHistory(bus, historyRepo)
+		})
+	}
+}
+
+// WithTodoChecklist はTodoの複製でチェックリストをコピーできるようにします
+func WithTodoChecklist(checklistRepo repository.ChecklistRepository) TodoServiceOption {
+	return func(s *TodoService) {
+		s.checklistRepo = checklistRepo
+	}
+}
+
+// WithTodoProjects はTodoの所属先のプロジェクトを確認するようにします
+// 作成時と所属先の変更時に、存在しない・アーカイブ済みのプロジェクトを指定すると ErrInvalidProject を返します
+// （アーカイブ済みのプロジェクトのTodoは一覧に表示されないため、作成しても見えなくなるのを防ぎます）
+func WithTodoProjects(projectRepo repository.ProjectRepository) TodoServiceOption {
+	return func(s *TodoService) {
+		s.projectRepo = projectRepo
+	}
+}
+
+// WithUniqueTitles はタイトルの重複を禁止するビジネスルールを有効にします
+// 作成・更新・複製で、同じタイトル（大文字・小文字を区別しない）のTodoが既にある場合は ErrDuplicateTitle を返します
+//
+// 確認と保存の間に別のリクエストが同じタイトルで作成した場合は重複し得ます
+// 厳密に防ぐ必要がある場合は、データベースの一意制約と組み合わせてください
+func WithUniqueTitles() TodoServiceOption {
+	return func(s *TodoService) {
+		s.uniqueTitles = true
+	}
+}
+
+// WithTodoUndo は削除を UndoService に記録し、一定期間取り消せるようにします
+// WithTodoChecklist と組み合わせた場合は、チェックリスト項目も元に戻します（項目のIDは新しくなります）
+func WithTodoUndo(undo *UndoService) TodoServiceOption {
+	return func(s *TodoService) {
+		s.undo = undo
+	}
+}
+
+// WithTodoWebhooks は作成・更新・完了・削除を登録されたWebhookへ通知するようにします
+// 通知は別の goroutine で送信されるため、APIの応答は受信側の応答を待ちません
+// webhooks.Subscribe でイベントバスを購読させる場合の簡易版です
+func WithTodoWebhooks(webhooks *WebhookService) TodoServiceOption {
+	return func(s *TodoService) {
+		s.subscriptions = append(s.subscriptions, webhooks.Subscribe)
+	}
+}
+
+// WithTodoTransactor は複数の書き込みを伴う操作を、transactor の1つのトランザクションで実行するようにします
+// 複製とチェックリストのコピー、取り消しによる復元、一括更新、インポートなどが途中で失敗した場合、
+// それまでの書き込みも全てロールバックされ、中途半端な状態が残りません
+//
+// トランザクションに参加するのは、transactor と同じ接続プールを使うリポジトリだけです
+func WithTodoTransactor(transactor repository.Transactor) TodoServiceOption {
+	return func(s *TodoService) {
+		s.transactor = transactor
+	}
+}
+
+// WithTodoOutbox は変更とそのイベントを、transactor の1つのトランザクションで outbox へ保存するようにします
+// 保存したイベントは OutboxRelay が後から発行するため、Webhook などの購読者はプロセスが停止しても変更を取りこぼしません
+// WithTodoTransactor も同時に設定します
+func WithTodoOutbox(transactor repository.Transactor, outbox repository.OutboxRepository) TodoServiceOption {
+	return func(s *TodoService) {
+		s.transactor = transactor
+		s.outbox = outbox
+	}
+}
+
+// WithTodoShares は共有されたTodoを、共有先のユーザーが共有の権限の範囲で操作できるようにします
+// 読み取りの権限では取得・複製、書き込みの権限では更新・完了状態の切り替えもできます
+// 一覧・検索には含まれず、IDを指定した操作だけが対象です
+func WithTodoShares(shareRepo repository.TodoShareRepository) TodoServiceOption {
+	return func(s *TodoService) {
+		s.shareRepo = shareRepo
+	}
 }
 
 // NewTodoService はTodoServiceのコンストラクタ関数です
 // 依存性注入（Dependency Injection）のパターンを使用しています
 // 引数:
 //   - todoRepo: TodoRepositoryインターフェースの実装
+//   - opts: 任意の機能（変更履歴の記録など）
 //
 // 戻り値:
 //   - *TodoService: 初期化されたTodoService
-func NewTodoService(todoRepo repository.TodoRepository) *TodoService {
-	return &TodoService{
+func NewTodoService(todoRepo repository.TodoRepository, opts ...TodoServiceOption) *TodoService {
+	s := &TodoService{
 		todoRepo: todoRepo,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.events == nil {
+		s.events = event.NewBus()
+	}
+	for _, subscribe := range s.subscriptions {
+		subscribe(s.events)
+	}
+	return s
 }
 
 // CreateTodo は新しいTodoを作成するビジネスロジックです
 // ここではドメインルールの検証を行った後、リポジトリに処理を委譲します
 func (s *TodoService) CreateTodo(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
-	// 1. 入力値のドメインレベルバリデーション
-	// エンティティのIsValid()メソッドでビジネスルールをチェック
-	if !todo.IsValid() {
-		return nil, errors.New("todo validation failed: title is required and must be 100 characters or less")
+	// 1〜2. ドメインルールの検証
+	if err := s.validateNewTodo(ctx, todo); err != nil {
+		return nil, err
 	}
 
-	// 2. 追加のビジネスルールチェック（例：タイトルの重複チェックなど）
-	// 実際のアプリケーションでは、「同じタイトルのTodoは作成できない」
-	// などのルールがある場合があります
-
 	// 3. リポジトリを通じてデータ永続化
-	createdTodo, err := s.todoRepo.Create(ctx, todo)
+	var createdTodo *entity.Todo
+	err := s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		var err error
+		createdTodo, err = s.todoRepo.Create(ctx, todo)
+		if err != nil {
+			// エラーラッピング：下位層のエラーに追加情報を付与
+			return fmt.Errorf("failed to create todo: %w", err)
+		}
+		record(entity.TodoHistoryCreated, nil, createdTodo)
+		return nil
+	})
 	if err != nil {
-		// エラーラッピング：下位層のエラーに追加情報を付与
-		return nil, fmt.Errorf("failed to create todo: %w", err)
+		return nil, err
 	}
-
 	return createdTodo, nil
 }
 
+// validateNewTodo は作成するTodoがドメインルールを満たすかを確認します
+func (s *TodoService) validateNewTodo(ctx context.Context, todo *entity.Todo) error {
+	// 1. 入力値のドメインレベルバリデーション
+	// エンティティのIsValid()メソッドでビジネスルールをチェック
+	if !todo.IsValid() {
+		return domainerr.ValidationFailed("todo", "title is required and must be 100 characters or less")
+	}
+
+	// 2. 追加のビジネスルールチェック
+	// 「同じタイトルのTodoは作成できない」ルール（WithUniqueTitles で有効にした場合のみ）
+	if err := s.checkUniqueTitle(ctx, todo.Title, 0); err != nil {
+		return err
+	}
+	return s.checkProject(ctx, todo.ProjectID)
+}
+
 // GetTodoByID は指定されたIDのTodoを取得します
 func (s *TodoService) GetTodoByID(ctx context.Context, id int) (*entity.Todo, error) {
 	// 1. 入力値の基本バリデーション
 	if id <= 0 {
-		return nil, errors.New("invalid todo ID: must be greater than 0")
+		return nil, domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+
+	// 2. 共有されたTodoの場合は読み取りの権限を確認
+	ctx, err := s.authorize(ctx, id, entity.SharePermissionRead)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. リポジトリから取得
+	// 3. リポジトリから取得
 	todo, err := s.todoRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get todo with ID %d: %w", id, err)
@@ -85,37 +273,141 @@ func (s *TodoService) GetAllTodos(ctx context.Context) ([]*entity.Todo, error) {
 	// 例：完了済みのTodoを先頭に移動、期限切れのチェックなど
 	// この例では単純に取得した結果をそのまま返します
 
+	if s.metrics != nil {
+		s.metrics.TodoListObserved(len(todos))
+	}
+	return todos, nil
+}
+
+// GetTodoPage は一覧（GetAllTodos と同じTodoと並び順）を cursor の続きから最大 limit 件取得します
+// 保存先のページングをそのまま使うため、保存先が repository.TodoPager を実装しない場合は domainerr.Invalid を返します
+func (s *TodoService) GetTodoPage(ctx context.Context, cursor string, limit int) (repository.TodoPage, error) {
+	pager, ok := s.todoRepo.(repository.TodoPager)
+	if !ok {
+		return repository.TodoPage{}, domainerr.Invalid("cursor", "this storage does not support cursor pagination")
+	}
+
+	page, err := pager.GetPage(ctx, cursor, limit)
+	if err != nil {
+		return repository.TodoPage{}, fmt.Errorf("failed to get todo page: %w", err)
+	}
+
+	if s.metrics != nil {
+		s.metrics.TodoListObserved(len(page.Todos))
+	}
+	return page, nil
+}
+
+// GetTodosByColor は指定した色のTodoを取得します
+// 色は正規化（entity.NormalizeColor）済みの値を渡してください
+func (s *TodoService) GetTodosByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	if color == entity.ColorNone || !color.IsValid() {
+		return nil, domainerr.Invalid("color", fmt.Sprintf("%q", color))
+	}
+
+	todos, err := s.todoRepo.GetByColor(ctx, color)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todos by color: %w", err)
+	}
+
+	if s.metrics != nil {
+		s.metrics.TodoListObserved(len(todos))
+	}
+	return todos, nil
+}
+
+// FindTodos は条件に一致するTodoを指定した並び順で取得します
+// 色・タグは正規化（entity.NormalizeColor / entity.NormalizeTag）済みの値を渡してください
+func (s *TodoService) FindTodos(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	if filter.Color != entity.ColorNone && !filter.Color.IsValid() {
+		return nil, domainerr.Invalid("color", fmt.Sprintf("%q", filter.Color))
+	}
+	if filter.Tag != "" && !entity.IsValidTag(filter.Tag) {
+		return nil, domainerr.Invalid("tag", fmt.Sprintf("%q", filter.Tag))
+	}
+	if order != "" && !order.IsValid() {
+		return nil, domainerr.Invalid("sort order", fmt.Sprintf("must be one of %v", entity.TodoSortOrders))
+	}
+
+	todos, err := s.todoRepo.Find(ctx, filter, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find todos: %w", err)
+	}
+
+	if s.metrics != nil {
+		s.metrics.TodoListObserved(len(todos))
+	}
 	return todos, nil
 }
 
+// GetTodoStats は全Todoの件数と見積もり・実績時間の集計を取得します
+// 一覧の取得ではないため、一覧件数のメトリクスは記録しません
+func (s *TodoService) GetTodoStats(ctx context.Context) (entity.TodoStats, error) {
+	// 集計はリポジトリに任せ、全てのTodoを読み込まない
+	stats, err := s.todoRepo.GetStats(ctx)
+	if err != nil {
+		return entity.TodoStats{}, fmt.Errorf("failed to get todo stats: %w", err)
+	}
+	return stats, nil
+}
+
 // UpdateTodo は既存のTodoを更新します
 func (s *TodoService) UpdateTodo(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
 	// 1. 入力値バリデーション
 	if todo.ID <= 0 {
-		return nil, errors.New("invalid todo ID: must be greater than 0")
+		return nil, domainerr.Invalid("todo ID", "must be greater than 0")
 	}
 
 	if !todo.IsValid() {
-		return nil, errors.New("todo validation failed: title is required and must be 100 characters or less")
+		return nil, domainerr.ValidationFailed("todo", "title is required and must be 100 characters or less")
 	}
 
-	// 2. 存在チェック（更新前にレコードが存在するか確認）
+	// 2. 共有されたTodoの場合は書き込みの権限を確認し、存在チェック（更新前にレコードが存在するか確認）
+	ctx, err := s.authorize(ctx, todo.ID, entity.SharePermissionWrite)
+	if err != nil {
+		return nil, err
+	}
 	existingTodo, err := s.todoRepo.GetByID(ctx, todo.ID)
 	if err != nil {
-		return nil, fmt.Errorf("todo with ID %d not found: %w", todo.ID, err)
+		return nil, lookupError("todo", todo.ID, err)
 	}
 
 	// 3. ビジネスルールに基づく更新制御
-	// 例：「完了済みのTodoは編集できない」などのルールがある場合
-	// この例では特に制約を設けていません
-	_ = existingTodo // 存在チェックのみで使用
+	// タイトルを変更する場合のみ重複を確認する（自分自身は除外）
+	// 取得した更新前の状態は変更履歴のスナップショットに使用します
+	if todo.Title != existingTodo.Title {
+		if err := s.checkUniqueTitle(ctx, todo.Title, todo.ID); err != nil {
+			return nil, err
+		}
+	}
+	// 所属先を変更する場合のみプロジェクトを確認する（アーカイブ済みのプロジェクトのTodoも、所属先を変えなければ更新できる）
+	if !equalProjectID(todo.ProjectID, existingTodo.ProjectID) {
+		if err := s.checkProject(ctx, todo.ProjectID); err != nil {
+			return nil, err
+		}
+	}
 
-	// 4. リポジトリを通じて更新実行
-	updatedTodo, err := s.todoRepo.Update(ctx, todo)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update todo: %w", err)
+	// 4. 変更されたフィールドを求める
+	// 変更がない場合は書き込まず、更新日時も変えずに現在の状態を返す
+	changed := entity.DiffTodo(existingTodo, todo)
+	if len(changed) == 0 {
+		return existingTodo, nil
 	}
 
+	// 5. リポジトリを通じて、変更されたフィールドだけを更新
+	var updatedTodo *entity.Todo
+	err = s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		var err error
+		updatedTodo, err = s.todoRepo.UpdateFields(ctx, todo, changed)
+		if err != nil {
+			return fmt.Errorf("failed to update todo: %w", err)
+		}
+		record(entity.TodoHistoryUpdated, existingTodo, updatedTodo)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return updatedTodo, nil
 }
 
@@ -123,65 +415,331 @@ func (s *TodoService) UpdateTodo(ctx context.Context, todo *entity.Todo) (*entit
 func (s *TodoService) DeleteTodo(ctx context.Context, id int) error {
 	// 1. 入力値バリデーション
 	if id <= 0 {
-		return errors.New("invalid todo ID: must be greater than 0")
+		return domainerr.Invalid("todo ID", "must be greater than 0")
 	}
 
 	// 2. 存在チェック（削除前にレコードが存在するか確認）
-	_, err := s.todoRepo.GetByID(ctx, id)
+	// 削除は所有者だけが行える（共有先のユーザーは権限によらず ErrTodoForbidden）
+	// 取得した削除前の状態は変更履歴のスナップショットに使用します
+	if _, err := s.authorize(ctx, id, ""); err != nil {
+		return err
+	}
+	existingTodo, err := s.todoRepo.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("todo with ID %d not found: %w", id, err)
+		return lookupError("todo", id, err)
 	}
 
 	// 3. ビジネスルールチェック
 	// 例：「作成から24時間以内のTodoは削除できない」などのルール
 	// この例では特に制約を設けていません
 
-	// 4. リポジトリを通じて削除実行
-	err = s.todoRepo.Delete(ctx, id)
+	// 4. 取り消しに備えて、チェックリスト項目を取得しておく（リポジトリが項目ごと削除する場合に作成し直すため）
+	var items []*entity.ChecklistItem
+	if s.undo != nil && s.checklistRepo != nil {
+		if items, err = s.checklistRepo.ListByTodoID(ctx, id); err != nil {
+			return fmt.Errorf("failed to get checklist of todo %d: %w", id, err)
+		}
+	}
+
+	// 5. リポジトリを通じて削除実行
+	err = s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		if err := s.todoRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete todo: %w", err)
+		}
+		record(entity.TodoHistoryDeleted, existingTodo, nil)
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete todo: %w", err)
+		return err
 	}
 
+	if s.undo != nil {
+		s.undo.record(ctx, UndoActionDelete, []int{id}, func(ctx context.Context) error {
+			return s.restoreTodo(ctx, existingTodo, items)
+		})
+	}
 	return nil
 }
 
+// restoreTodo は削除したTodoを同じIDで保存し直し、チェックリスト項目を作成し直します
+// ソフト削除でチェックリスト項目が残っている場合は、重複しないよう作成し直しません
+// トランザクションが有効な場合、項目の作成に失敗するとTodoの復元もロールバックされます
+func (s *TodoService) restoreTodo(ctx context.Context, todo *entity.Todo, items []*entity.ChecklistItem) error {
+	return s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		if err := s.todoRepo.Restore(ctx, todo); err != nil {
+			return fmt.Errorf("failed to restore todo %d: %w", todo.ID, err)
+		}
+		recreate := items
+		if len(recreate) > 0 {
+			remaining, err := s.checklistRepo.ListByTodoID(ctx, todo.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get checklist of todo %d: %w", todo.ID, err)
+			}
+			if len(remaining) > 0 {
+				recreate = nil
+			}
+		}
+		for _, item := range recreate {
+			restored := &entity.ChecklistItem{TodoID: todo.ID, Text: item.Text, IsDone: item.IsDone}
+			if _, err := s.checklistRepo.Create(ctx, restored); err != nil {
+				return fmt.Errorf("failed to restore checklist of todo %d: %w", todo.ID, err)
+			}
+		}
+		record(entity.TodoHistoryRestored, nil, todo)
+		return nil
+	})
+}
+
 // CompleteTodo はTodoを完了状態にする専用メソッドです
 // エンティティのビジネスロジック（MarkAsCompleted）を使用した例
 func (s *TodoService) CompleteTodo(ctx context.Context, id int) (*entity.Todo, error) {
-	// 1. 対象のTodoを取得
-	todo, err := s.todoRepo.GetByID(ctx, id)
+	return s.changeCompletion(ctx, id, true)
+}
+
+// IncompleteTodo はTodoを未完了状態に戻す専用メソッドです
+func (s *TodoService) IncompleteTodo(ctx context.Context, id int) (*entity.Todo, error) {
+	return s.changeCompletion(ctx, id, false)
+}
+
+// changeCompletion は対象の行をロックしたまま、Todoの完了状態を変更します
+// 読み込みから保存までを UpdateWithLock の1つのトランザクションで行うため、
+// 同じTodoを同時に完了にするリクエストが来ても、変更履歴と完了数の指標は1回だけ記録されます
+func (s *TodoService) changeCompletion(ctx context.Context, id int, completed bool) (*entity.Todo, error) {
+	ctx, err := s.authorize(ctx, id, entity.SharePermissionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, updatedTodo *entity.Todo
+	err = s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		var err error
+		updatedTodo, err = s.todoRepo.UpdateWithLock(ctx, id, func(todo *entity.Todo) error {
+			// 1. 既に目的の状態の場合は保存しない（更新日時も変えない）
+			if todo.IsCompleted == completed {
+				return repository.ErrNoChange
+			}
+
+			// 2. エンティティのビジネスロジックを使用して状態変更
+			snapshot := *todo
+			before = &snapshot
+			if completed {
+				todo.MarkAsCompleted()
+			} else {
+				todo.MarkAsIncomplete()
+			}
+			return nil
+		})
+		if err != nil || before == nil {
+			return err
+		}
+
+		// 3. 状態が変わった場合だけ、変更を記録（変更履歴と指標はイベントの購読者が記録する）
+		action := entity.TodoHistoryIncompleted
+		if completed {
+			action = entity.TodoHistoryCompleted
+		}
+		record(action, before, updatedTodo)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("todo with ID %d not found: %w", id, err)
+		if completed {
+			return nil, fmt.Errorf("failed to complete todo with ID %d: %w", id, err)
+		}
+		return nil, fmt.Errorf("failed to mark todo with ID %d as incomplete: %w", id, err)
 	}
+	return updatedTodo, nil
+}
 
-	// 2. エンティティのビジネスロジックを使用して状態変更
-	todo.MarkAsCompleted()
+// DuplicateTodo は既存のTodoを複製して新しいTodoを作成します
+// 複製は作成として変更履歴に記録されます
+func (s *TodoService) DuplicateTodo(ctx context.Context, id int, opts DuplicateTodoOptions) (*entity.Todo, error) {
+	if opts.IncludeChecklist && s.checklistRepo == nil {
+		return nil, domainerr.Invalid("duplicate option", "checklist is not available")
+	}
 
-	// 3. 変更をデータベースに保存
-	updatedTodo, err := s.todoRepo.Update(ctx, todo)
+	source, err := s.GetTodoByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to complete todo: %w", err)
+		return nil, err
 	}
 
-	return updatedTodo, nil
+	duplicate := source.Duplicate()
+	if opts.Title != "" {
+		duplicate.Title = opts.Title
+	}
+
+	var items []*entity.ChecklistItem
+	if opts.IncludeChecklist {
+		items, err = s.checklistRepo.ListByTodoID(ctx, source.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checklist of todo %d: %w", source.ID, err)
+		}
+	}
+
+	if err := s.validateNewTodo(ctx, duplicate); err != nil {
+		return nil, err
+	}
+
+	// 複製の作成とチェックリストのコピーを1つのトランザクションで行い、
+	// コピーに失敗した場合は途中までコピーされた複製を残さない
+	var created *entity.Todo
+	err = s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		var err error
+		created, err = s.todoRepo.Create(ctx, duplicate)
+		if err != nil {
+			return fmt.Errorf("failed to create todo: %w", err)
+		}
+		for _, item := range items {
+			if _, err := s.checklistRepo.Create(ctx, item.CopyTo(created.ID)); err != nil {
+				return fmt.Errorf("failed to copy checklist to todo %d: %w", created.ID, err)
+			}
+		}
+		record(entity.TodoHistoryCreated, nil, created)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) > 0 {
+		created.ChecklistProgress = entity.ChecklistProgress{Total: len(items)}
+	}
+	return created, nil
 }
 
-// IncompleteTodo はTodoを未完了状態に戻す専用メソッドです
-func (s *TodoService) IncompleteTodo(ctx context.Context, id int) (*entity.Todo, error) {
-	// 1. 対象のTodoを取得
-	todo, err := s.todoRepo.GetByID(ctx, id)
+// authorize は id のTodoに対して permission の操作を行えるかを確認し、リポジトリの操作に使うコンテキストを返します
+//
+//   - 所有者本人のTodo、または所有者が設定されていない（認証が無効な）場合は ctx をそのまま返します
+//   - 共有されたTodoで権限が足りる場合は、所有者による絞り込みを解除したコンテキストを返します
+//   - 共有されていない場合も ctx をそのまま返し、続くリポジトリの操作が "todo not found" を返します
+//     （他のユーザーのTodoの存在を明かさないため）
+//   - 共有されているが権限が足りない場合は ErrTodoForbidden を返します
+//
+// permission が空の場合は所有者だけの操作として扱い、共有先のユーザーには常に ErrTodoForbidden を返します
+func (s *TodoService) authorize(ctx context.Context, id int, permission entity.SharePermission) (context.Context, error) {
+	userID, ok := repository.OwnerFromContext(ctx)
+	if s.shareRepo == nil || !ok {
+		return ctx, nil
+	}
+
+	// 1. 本人のTodoかを確認（他のユーザーのTodoは存在しない扱いになる）
+	// 続く更新・削除で改めて取得するため、ここでは行を読み込まずに存在だけを確認する
+	if exists, err := s.todoRepo.Exists(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get todo with ID %d: %w", id, err)
+	} else if exists {
+		return ctx, nil
+	}
+
+	// 2. 共有されているかを確認
+	share, err := s.shareRepo.Get(ctx, id, userID)
 	if err != nil {
-		return nil, fmt.Errorf("todo with ID %d not found: %w", id, err)
+		if domainerr.IsNotFound(err) {
+			return ctx, nil
+		}
+		return nil, fmt.Errorf("failed to get share of todo %d: %w", id, err)
+	}
+	if !share.Permission.Allows(permission) {
+		return nil, fmt.Errorf("%w: todo %d is shared with %s permission", ErrTodoForbidden, id, share.Permission)
 	}
+	return repository.WithoutOwner(ctx), nil
+}
 
-	// 2. エンティティのビジネスロジックを使用して状態変更
-	todo.MarkAsIncomplete()
+// checkUniqueTitle は一意なタイトルのルールが有効な場合に、タイトルの重複を確認します
+func (s *TodoService) checkUniqueTitle(ctx context.Context, title string, excludeID int) error {
+	if !s.uniqueTitles {
+		return nil
+	}
+	exists, err := s.todoRepo.ExistsByTitle(ctx, title, excludeID)
+	if err != nil {
+		return fmt.Errorf("failed to check todo title: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %q", ErrDuplicateTitle, title)
+	}
+	return nil
+}
 
-	// 3. 変更をデータベースに保存
-	updatedTodo, err := s.todoRepo.Update(ctx, todo)
+// checkProject はプロジェクトの確認が有効な場合に、所属先のプロジェクトが存在し、アーカイブされていないことを確認します
+// プロジェクトに属さない（projectID が nil の）場合は確認しません
+func (s *TodoService) checkProject(ctx context.Context, projectID *int) error {
+	if s.projectRepo == nil || projectID == nil {
+		return nil
+	}
+	project, err := s.projectRepo.GetByID(ctx, *projectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to mark todo as incomplete: %w", err)
+		if domainerr.IsNotFound(err) {
+			return fmt.Errorf("%w: project %d does not exist", ErrInvalidProject, *projectID)
+		}
+		return fmt.Errorf("failed to get project %d: %w", *projectID, err)
+	}
+	if project.IsArchived() {
+		return fmt.Errorf("%w: project %q is archived", ErrInvalidProject, project.Slug)
 	}
+	return nil
+}
 
-	return updatedTodo, nil
+// equalProjectID は2つの所属先が同じかを判定します（どちらも nil の場合も同じ）
+func equalProjectID(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// recordFunc は saveChanges の書き込み処理が、保存した変更を記録するための関数です
+type recordFunc func(action entity.TodoHistoryAction, before, after *entity.Todo)
+
+// saveChanges は write を実行し、write が record で記録した変更を、操作に対応する種類のイベントとして発行します
+// 変更履歴・Webhook・指標への記録はイベントの購読者が行います
+//
+// トランザクションが有効な場合は、write の全ての書き込みを1つのトランザクションで行います
+// write がエラーを返すと、それまでの書き込みも全てロールバックされます
+//
+// アウトボックスが有効な場合は、イベントのアウトボックスへの保存も同じトランザクションで行います
+// 書き込みがコミットされたのにイベントだけが失われる（またはその逆の）ことがないため、
+// 直後にプロセスが停止しても、OutboxRelay が後からイベントを届けます
+// イベントバスへの発行は、コミットの後に行います（ロールバックされた変更は発行しない）
+func (s *TodoService) saveChanges(ctx context.Context, write func(ctx context.Context, record recordFunc) error) error {
+	var events []event.TodoEvent
+	record := func(action entity.TodoHistoryAction, before, after *entity.Todo) {
+		events = append(events, event.NewTodoEvent(action, before, after))
+	}
+
+	if s.transactor == nil {
+		if err := write(ctx, record); err != nil {
+			return err
+		}
+	} else {
+		err := s.transactor.InTransaction(ctx, func(ctx context.Context) error {
+			if err := write(ctx, record); err != nil {
+				return err
+			}
+			if s.outbox == nil {
+				return nil
+			}
+			return s.stageEvents(ctx, events)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, e := range events {
+		s.events.Publish(ctx, e)
+	}
+	return nil
+}
+
+// stageEvents はイベントをアウトボックスへ保存します
+func (s *TodoService) stageEvents(ctx context.Context, events []event.TodoEvent) error {
+	for _, e := range events {
+		payload, err := event.MarshalTodoEvent(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", e.EventName(), err)
+		}
+		message := &entity.OutboxMessage{EventName: e.EventName(), Payload: payload}
+		if _, err := s.outbox.Add(ctx, message); err != nil {
+			return fmt.Errorf("failed to stage %s event: %w", e.EventName(), err)
+		}
+	}
+	return nil
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
 )
 
 // TodoServiceInterface は Todo サービスのインターフェースです
@@ -21,9 +22,27 @@ type TodoServiceInterface interface {
 	// GetAllTodos は全てのTodoを取得します
 	GetAllTodos(ctx context.Context) ([]*entity.Todo, error)
 
+	// GetTodoPage はカーソルの続きから一覧の1ページを取得します
+	GetTodoPage(ctx context.Context, cursor string, limit int) (repository.TodoPage, error)
+
+	// GetTodosByColor は指定した色のTodoを取得します
+	GetTodosByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error)
+
+	// FindTodos は条件に一致するTodoを指定した並び順で取得します
+	FindTodos(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error)
+
+	// GetTodoStats は件数と見積もり・実績時間の集計を取得します
+	GetTodoStats(ctx context.Context) (entity.TodoStats, error)
+
 	// UpdateTodo は既存のTodoを更新します
 	UpdateTodo(ctx context.Context, todo *entity.Todo) (*entity.Todo, error)
 
+	// UpdateTodos は複数のTodoを1つのトランザクションでまとめて更新します
+	UpdateTodos(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error)
+
+	// ImportTodos は複数のTodoを1つのトランザクションでまとめて作成します
+	ImportTodos(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error)
+
 	// DeleteTodo は指定されたIDのTodoを削除します
 	DeleteTodo(ctx context.Context, id int) error
 
@@ -32,6 +51,9 @@ type TodoServiceInterface interface {
 
 	// IncompleteTodo はTodoを未完了状態にします
 	IncompleteTodo(ctx context.Context, id int) (*entity.Todo, error)
+
+	// DuplicateTodo は既存のTodoを複製して新しいTodoを作成します
+	DuplicateTodo(ctx context.Context, id int, opts DuplicateTodoOptions) (*entity.Todo, error)
 }
 
 // コンパイル時インターフェース実装確認
@@ -3,9 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"todoapp-api-golang/internal/domain/domainerr"
 	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
 )
 
 // MockTodoRepository はテスト用のTodoRepositoryのモック実装です
@@ -69,6 +75,26 @@ func (m *MockTodoRepository) Create(ctx context.Context, todo *entity.Todo) (*en
 	return &savedTodo, nil
 }
 
+// CreateMany は複数のTodoを作成します（モック実装）
+func (m *MockTodoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	m.callCounts["CreateMany"]++
+	m.lastCalls["CreateMany"] = []interface{}{ctx, todos}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	created := make([]*entity.Todo, len(todos))
+	for i, todo := range todos {
+		todo.ID = m.nextID
+		m.nextID++
+		savedTodo := *todo
+		m.todos[todo.ID] = &savedTodo
+		created[i] = &savedTodo
+	}
+	return created, nil
+}
+
 // GetByID はIDによってTodoを取得します（モック実装）
 func (m *MockTodoRepository) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
 	m.callCounts["GetByID"]++
@@ -80,7 +106,7 @@ func (m *MockTodoRepository) GetByID(ctx context.Context, id int) (*entity.Todo,
 
 	todo, exists := m.todos[id]
 	if !exists {
-		return nil, errors.New("todo not found")
+		return nil, domainerr.NotFound("todo", nil)
 	}
 
 	// コピーを返す（参照の問題を避ける）
@@ -88,6 +114,19 @@ func (m *MockTodoRepository) GetByID(ctx context.Context, id int) (*entity.Todo,
 	return &result, nil
 }
 
+// Exists はTodoの存在を確認します（モック実装）
+func (m *MockTodoRepository) Exists(ctx context.Context, id int) (bool, error) {
+	m.callCounts["Exists"]++
+	m.lastCalls["Exists"] = []interface{}{ctx, id}
+
+	if m.shouldError {
+		return false, errors.New(m.errorMsg)
+	}
+
+	_, exists := m.todos[id]
+	return exists, nil
+}
+
 // GetAll は全てのTodoを取得します（モック実装）
 func (m *MockTodoRepository) GetAll(ctx context.Context) ([]*entity.Todo, error) {
 	m.callCounts["GetAll"]++
@@ -107,6 +146,122 @@ func (m *MockTodoRepository) GetAll(ctx context.Context) ([]*entity.Todo, error)
 	return result, nil
 }
 
+// Count は条件に一致するTodoの件数を返します（モック実装）
+func (m *MockTodoRepository) Count(ctx context.Context, filter repository.TodoFilter) (int, error) {
+	m.callCounts["Count"]++
+	m.lastCalls["Count"] = []interface{}{ctx, filter}
+
+	if m.shouldError {
+		return 0, errors.New(m.errorMsg)
+	}
+
+	count := 0
+	for _, todo := range m.todos {
+		if filter.Matches(todo) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountGrouped はグループごとの件数を返します（モック実装。色ごとの集計のみ）
+func (m *MockTodoRepository) CountGrouped(ctx context.Context, group repository.TodoGroup, filter repository.TodoFilter) ([]repository.TodoGroupCount, error) {
+	m.callCounts["CountGrouped"]++
+	m.lastCalls["CountGrouped"] = []interface{}{ctx, group, filter}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	counts := make(map[string]int)
+	for _, todo := range m.todos {
+		if filter.Matches(todo) {
+			counts[string(todo.Color)]++
+		}
+	}
+	result := make([]repository.TodoGroupCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, repository.TodoGroupCount{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+// GetStats はTodoの集計を返します（モック実装）
+func (m *MockTodoRepository) GetStats(ctx context.Context) (entity.TodoStats, error) {
+	m.callCounts["GetStats"]++
+	m.lastCalls["GetStats"] = []interface{}{ctx}
+
+	if m.shouldError {
+		return entity.TodoStats{}, errors.New(m.errorMsg)
+	}
+
+	todos := make([]*entity.Todo, 0, len(m.todos))
+	for _, todo := range m.todos {
+		todos = append(todos, todo)
+	}
+	return entity.NewTodoStats(todos), nil
+}
+
+// GetByColor は指定した色のTodoを取得します（モック実装）
+func (m *MockTodoRepository) GetByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	m.callCounts["GetByColor"]++
+	m.lastCalls["GetByColor"] = []interface{}{ctx, color}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todos {
+		if todo.Color == color {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+
+	return result, nil
+}
+
+// Find は条件に一致するTodoを並び順どおりに返します（モック実装。同じ順位のTodoはIDの昇順）
+func (m *MockTodoRepository) Find(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	m.callCounts["Find"]++
+	m.lastCalls["Find"] = []interface{}{ctx, filter, order}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	result := make([]*entity.Todo, 0)
+	for _, todo := range m.todos {
+		if filter.Matches(todo) {
+			todoCopy := *todo
+			result = append(result, &todoCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	order.Sort(result)
+
+	return result, nil
+}
+
+// ExistsByTitle は同じタイトルのTodoが存在するかを返します（モック実装）
+func (m *MockTodoRepository) ExistsByTitle(ctx context.Context, title string, excludeID int) (bool, error) {
+	m.callCounts["ExistsByTitle"]++
+	m.lastCalls["ExistsByTitle"] = []interface{}{ctx, title, excludeID}
+
+	if m.shouldError {
+		return false, errors.New(m.errorMsg)
+	}
+
+	for id, todo := range m.todos {
+		if id != excludeID && strings.EqualFold(todo.Title, title) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Update はTodoを更新します（モック実装）
 func (m *MockTodoRepository) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
 	m.callCounts["Update"]++
@@ -118,7 +273,7 @@ func (m *MockTodoRepository) Update(ctx context.Context, todo *entity.Todo) (*en
 
 	_, exists := m.todos[todo.ID]
 	if !exists {
-		return nil, errors.New("todo not found")
+		return nil, domainerr.NotFound("todo", nil)
 	}
 
 	// コピーを作成して保存
@@ -128,6 +283,100 @@ func (m *MockTodoRepository) Update(ctx context.Context, todo *entity.Todo) (*en
 	return &savedTodo, nil
 }
 
+// UpdateFields は指定したフィールドだけを更新します（モック実装）
+func (m *MockTodoRepository) UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (*entity.Todo, error) {
+	m.callCounts["UpdateFields"]++
+	m.lastCalls["UpdateFields"] = []interface{}{ctx, todo, fields}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	existing, exists := m.todos[todo.ID]
+	if !exists {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+
+	savedTodo := *existing
+	savedTodo.CopyFields(todo, fields)
+	m.todos[todo.ID] = &savedTodo
+
+	result := savedTodo
+	return &result, nil
+}
+
+// UpdateWithLock はロック中のTodoを変更して保存します（モック実装）
+// mutate が ErrNoChange を返した場合は保存しません
+func (m *MockTodoRepository) UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (*entity.Todo, error) {
+	m.callCounts["UpdateWithLock"]++
+	m.lastCalls["UpdateWithLock"] = []interface{}{ctx, id}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	existing, exists := m.todos[id]
+	if !exists {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+
+	savedTodo := *existing
+	if err := mutate(&savedTodo); err != nil {
+		if errors.Is(err, repository.ErrNoChange) {
+			result := *existing
+			return &result, nil
+		}
+		return nil, err
+	}
+	m.todos[id] = &savedTodo
+
+	result := savedTodo
+	return &result, nil
+}
+
+// UpdateMany は複数のTodoをまとめて更新します（モック実装）
+// 1件でも存在しない場合は、どれも更新しません
+func (m *MockTodoRepository) UpdateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	m.callCounts["UpdateMany"]++
+	m.lastCalls["UpdateMany"] = []interface{}{ctx, todos}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	for _, todo := range todos {
+		if _, exists := m.todos[todo.ID]; !exists {
+			return nil, domainerr.NotFound("todo", todo.ID)
+		}
+	}
+
+	updated := make([]*entity.Todo, len(todos))
+	for i, todo := range todos {
+		savedTodo := *todo
+		m.todos[todo.ID] = &savedTodo
+		result := savedTodo
+		updated[i] = &result
+	}
+	return updated, nil
+}
+
+// Upsert はTodoを作成または更新します（モック実装）
+func (m *MockTodoRepository) Upsert(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	m.callCounts["Upsert"]++
+	m.lastCalls["Upsert"] = []interface{}{ctx, todo}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+
+	savedTodo := *todo
+	m.todos[todo.ID] = &savedTodo
+	if todo.ID >= m.nextID {
+		m.nextID = todo.ID + 1
+	}
+	return &savedTodo, nil
+}
+
 // Delete はTodoを削除します（モック実装）
 func (m *MockTodoRepository) Delete(ctx context.Context, id int) error {
 	m.callCounts["Delete"]++
@@ -139,9 +388,54 @@ func (m *MockTodoRepository) Delete(ctx context.Context, id int) error {
 
 	_, exists := m.todos[id]
 	if !exists {
-		return errors.New("todo not found")
+		return domainerr.NotFound("todo", nil)
+	}
+
+	delete(m.todos, id)
+	return nil
+}
+
+// Restore は削除したTodoを同じIDで保存し直します（モック実装）
+func (m *MockTodoRepository) Restore(ctx context.Context, todo *entity.Todo) error {
+	m.callCounts["Restore"]++
+	m.lastCalls["Restore"] = []interface{}{ctx, todo}
+
+	if m.shouldError {
+		return errors.New(m.errorMsg)
+	}
+
+	if _, exists := m.todos[todo.ID]; exists {
+		return errors.New("todo already exists")
+	}
+
+	savedTodo := *todo
+	m.todos[todo.ID] = &savedTodo
+	return nil
+}
+
+// GetDeleted は削除済みのTodoを取得します（モック実装。Delete は完全に削除するため常に空）
+func (m *MockTodoRepository) GetDeleted(ctx context.Context) ([]*entity.Todo, error) {
+	m.callCounts["GetDeleted"]++
+	m.lastCalls["GetDeleted"] = []interface{}{ctx}
+
+	if m.shouldError {
+		return nil, errors.New(m.errorMsg)
+	}
+	return []*entity.Todo{}, nil
+}
+
+// HardDelete はTodoを完全に削除します（モック実装）
+func (m *MockTodoRepository) HardDelete(ctx context.Context, id int) error {
+	m.callCounts["HardDelete"]++
+	m.lastCalls["HardDelete"] = []interface{}{ctx, id}
+
+	if m.shouldError {
+		return errors.New(m.errorMsg)
 	}
 
+	if _, exists := m.todos[id]; !exists {
+		return domainerr.NotFound("todo", nil)
+	}
 	delete(m.todos, id)
 	return nil
 }
@@ -397,6 +691,32 @@ func TestTodoService_GetAllTodos(t *testing.T) {
 	}
 }
 
+// TestTodoService_GetTodoStats は集計の取得をテストします
+func TestTodoService_GetTodoStats(t *testing.T) {
+	mockRepo := NewMockTodoRepository()
+	service := NewTodoService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "タスク1", EstimateMinutes: 30}
+	mockRepo.todos[2] = &entity.Todo{ID: 2, Title: "タスク2", IsCompleted: true, EstimateMinutes: 60, ActualMinutes: 45}
+
+	stats, err := service.GetTodoStats(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラーが発生しました: %v", err)
+	}
+	if stats.Total != 2 || stats.Completed != 1 {
+		t.Errorf("件数 = %d/%d, 期待値 = 2/1", stats.Total, stats.Completed)
+	}
+	if stats.Estimates.Compared != 1 || stats.Estimates.RemainingMinutes != 30 {
+		t.Errorf("Estimates = %+v, 比較対象1件・残り30分を期待しました", stats.Estimates)
+	}
+
+	mockRepo.SetError(true, "database error")
+	if _, err := service.GetTodoStats(ctx); err == nil {
+		t.Error("リポジトリエラー時にエラーが期待されましたが、発生しませんでした")
+	}
+}
+
 // TestTodoService_UpdateTodo はTodo更新機能をテストします
 func TestTodoService_UpdateTodo(t *testing.T) {
 	mockRepo := NewMockTodoRepository()
@@ -489,6 +809,68 @@ func TestTodoService_UpdateTodo(t *testing.T) {
 	}
 }
 
+// TestTodoService_UpdateTodo_ChangedFields は変更されたフィールドだけを保存することをテストします
+func TestTodoService_UpdateTodo_ChangedFields(t *testing.T) {
+	mockRepo := NewMockTodoRepository()
+	historyRepo := &MockTodoHistoryRepository{}
+	service := NewTodoService(mockRepo, WithTodoHistory(historyRepo))
+	ctx := context.Background()
+
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "買い物", Description: "牛乳", UpdatedAt: updatedAt}
+
+	// 変更がない場合は保存も履歴の記録も行わない
+	result, err := service.UpdateTodo(ctx, &entity.Todo{ID: 1, Title: "買い物", Description: "牛乳"})
+	if err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	if !result.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("更新日時 = %v, 期待値 = %v（変更なし）", result.UpdatedAt, updatedAt)
+	}
+	if mockRepo.GetCallCount("UpdateFields") != 0 {
+		t.Error("変更がない場合は保存してはいけません")
+	}
+	if entries, _ := historyRepo.ListByTodoID(ctx, 1); len(entries) != 0 {
+		t.Errorf("履歴 = %d件, 期待値 = 0件", len(entries))
+	}
+
+	// 変更されたフィールドだけをリポジトリに渡す
+	if _, err := service.UpdateTodo(ctx, &entity.Todo{ID: 1, Title: "買い物", Description: "卵", IsCompleted: true}); err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	fields := mockRepo.lastCalls["UpdateFields"][2].([]entity.TodoField)
+	want := []entity.TodoField{entity.TodoFieldDescription, entity.TodoFieldIsCompleted}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("保存したフィールド = %v, 期待値 = %v", fields, want)
+	}
+}
+
+// TestTodoService_CompleteTodo_AlreadyInState は完了状態が変わらない場合に保存しないことをテストします
+func TestTodoService_CompleteTodo_AlreadyInState(t *testing.T) {
+	mockRepo := NewMockTodoRepository()
+	historyRepo := &MockTodoHistoryRepository{}
+	service := NewTodoService(mockRepo, WithTodoHistory(historyRepo))
+	ctx := context.Background()
+
+	mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "完了済み", IsCompleted: true}
+	mockRepo.todos[2] = &entity.Todo{ID: 2, Title: "未完了"}
+
+	if todo, err := service.CompleteTodo(ctx, 1); err != nil || !todo.IsCompleted {
+		t.Errorf("CompleteTodo() = %+v, %v", todo, err)
+	}
+	if todo, err := service.IncompleteTodo(ctx, 2); err != nil || todo.IsCompleted {
+		t.Errorf("IncompleteTodo() = %+v, %v", todo, err)
+	}
+	if mockRepo.GetCallCount("Update") != 0 {
+		t.Error("状態が変わらない場合は保存してはいけません")
+	}
+	for _, id := range []int{1, 2} {
+		if entries, _ := historyRepo.ListByTodoID(ctx, id); len(entries) != 0 {
+			t.Errorf("Todo %d の履歴 = %d件, 期待値 = 0件", id, len(entries))
+		}
+	}
+}
+
 // TestTodoService_DeleteTodo はTodo削除機能をテストします
 func TestTodoService_DeleteTodo(t *testing.T) {
 	mockRepo := NewMockTodoRepository()
@@ -635,6 +1017,314 @@ func TestTodoService_CompleteTodo(t *testing.T) {
 	}
 }
 
+// TestTodoService_DuplicateTodo はTodoの複製（チェックリストのコピーを含む）をテストします
+func TestTodoService_DuplicateTodo(t *testing.T) {
+	ctx := context.Background()
+	dueDate := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		opts          DuplicateTodoOptions
+		withChecklist bool
+		id            int
+		wantErr       bool
+		wantTitle     string
+		wantItems     int
+	}{
+		{name: "内容のみ複製", opts: DuplicateTodoOptions{}, withChecklist: true, id: 1, wantTitle: "請求書を送る", wantItems: 0},
+		{name: "タイトルを変えて複製", opts: DuplicateTodoOptions{Title: "来月の請求書を送る"}, withChecklist: true, id: 1, wantTitle: "来月の請求書を送る", wantItems: 0},
+		{name: "チェックリストも複製", opts: DuplicateTodoOptions{IncludeChecklist: true}, withChecklist: true, id: 1, wantTitle: "請求書を送る", wantItems: 2},
+		{name: "チェックリスト未設定", opts: DuplicateTodoOptions{IncludeChecklist: true}, withChecklist: false, id: 1, wantErr: true},
+		{name: "存在しないTodo", opts: DuplicateTodoOptions{}, withChecklist: true, id: 999, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todoRepo := NewMockTodoRepository()
+			checklistRepo := NewMockChecklistRepository()
+			opts := []TodoServiceOption{}
+			if tt.withChecklist {
+				opts = append(opts, WithTodoChecklist(checklistRepo))
+			}
+			service := NewTodoService(todoRepo, opts...)
+
+			source, _ := todoRepo.Create(ctx, &entity.Todo{Title: "請求書を送る", Description: "経理へ", DueDate: &dueDate})
+			source.MarkAsCompleted()
+			source.ScheduleReminder(dueDate)
+			todoRepo.Update(ctx, source)
+			checklistRepo.Create(ctx, &entity.ChecklistItem{TodoID: source.ID, Text: "金額を確認", IsDone: true})
+			checklistRepo.Create(ctx, &entity.ChecklistItem{TodoID: source.ID, Text: "送付"})
+
+			duplicate, err := service.DuplicateTodo(ctx, tt.id, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("エラーが期待されましたが、発生しませんでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラーが発生しました: %v", err)
+			}
+
+			if duplicate.ID == source.ID || duplicate.Title != tt.wantTitle || duplicate.Description != "経理へ" {
+				t.Errorf("複製 = %+v", duplicate)
+			}
+			if duplicate.IsCompleted || duplicate.RemindAt != nil || duplicate.DueDate == nil || !duplicate.DueDate.Equal(dueDate) {
+				t.Errorf("完了状態・リマインダーは引き継がず、期限は引き継ぐべきです: %+v", duplicate)
+			}
+
+			items, _ := checklistRepo.ListByTodoID(ctx, duplicate.ID)
+			if len(items) != tt.wantItems || duplicate.ChecklistProgress.Total != tt.wantItems {
+				t.Fatalf("チェックリスト項目数 = %d (進捗 %d), 期待値 = %d", len(items), duplicate.ChecklistProgress.Total, tt.wantItems)
+			}
+			for _, item := range items {
+				if item.IsDone {
+					t.Errorf("複製した項目は未チェックであるべきです: %+v", item)
+				}
+			}
+		})
+	}
+}
+
+// MockTodoMetrics はテスト用のTodoMetricsのモック実装です
+type MockTodoMetrics struct {
+	created   int
+	completed int
+	listSizes []int
+}
+
+func (m *MockTodoMetrics) TodoCreated()              { m.created++ }
+func (m *MockTodoMetrics) TodoCompleted()            { m.completed++ }
+func (m *MockTodoMetrics) TodoListObserved(size int) { m.listSizes = append(m.listSizes, size) }
+
+// TestTodoService_Metrics はビジネス指標が記録されることをテストします
+func TestTodoService_Metrics(t *testing.T) {
+	ctx := context.Background()
+	metrics := &MockTodoMetrics{}
+	service := NewTodoService(NewMockTodoRepository(), WithTodoMetrics(metrics))
+
+	first, _ := service.CreateTodo(ctx, &entity.Todo{Title: "牛乳を買う"})
+	second, _ := service.CreateTodo(ctx, &entity.Todo{Title: "請求書を送る"})
+	service.DuplicateTodo(ctx, first.ID, DuplicateTodoOptions{})
+	if metrics.created != 3 {
+		t.Errorf("作成数 = %d, 期待値 = 3（複製を含む）", metrics.created)
+	}
+
+	// 完了エンドポイントとPATCHのどちらでも数え、完了済みの再完了は数えない
+	service.CompleteTodo(ctx, first.ID)
+	service.CompleteTodo(ctx, first.ID)
+	update := *second // モックは保存したTodoのポインタを返すため、コピーを変更する
+	update.MarkAsCompleted()
+	service.UpdateTodo(ctx, &update)
+	update.Title = "請求書を再送する"
+	service.UpdateTodo(ctx, &update)
+	if metrics.completed != 2 {
+		t.Errorf("完了数 = %d, 期待値 = 2", metrics.completed)
+	}
+
+	// 作成に失敗した場合は数えない
+	service.CreateTodo(ctx, &entity.Todo{Title: ""})
+	if metrics.created != 3 {
+		t.Errorf("失敗後の作成数 = %d, 期待値 = 3", metrics.created)
+	}
+
+	service.GetAllTodos(ctx)
+	if len(metrics.listSizes) != 1 || metrics.listSizes[0] != 3 {
+		t.Errorf("一覧の件数 = %v, 期待値 = [3]", metrics.listSizes)
+	}
+}
+
+// TestTodoService_UniqueTitles はタイトルの重複を禁止するルールをテストします
+func TestTodoService_UniqueTitles(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		opts    []TodoServiceOption
+		run     func(s *TodoService) error
+		wantErr bool
+	}{
+		{
+			name: "ルール無効の場合は同じタイトルで作成できる",
+			run: func(s *TodoService) error {
+				_, err := s.CreateTodo(ctx, &entity.Todo{Title: "買い物"})
+				return err
+			},
+		},
+		{
+			name: "同じタイトルの作成を拒否",
+			opts: []TodoServiceOption{WithUniqueTitles()},
+			run: func(s *TodoService) error {
+				_, err := s.CreateTodo(ctx, &entity.Todo{Title: "買い物"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "大文字・小文字だけが異なるタイトルも拒否",
+			opts: []TodoServiceOption{WithUniqueTitles()},
+			run: func(s *TodoService) error {
+				_, err := s.CreateTodo(ctx, &entity.Todo{Title: "REVIEW"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "異なるタイトルは作成できる",
+			opts: []TodoServiceOption{WithUniqueTitles()},
+			run: func(s *TodoService) error {
+				_, err := s.CreateTodo(ctx, &entity.Todo{Title: "掃除"})
+				return err
+			},
+		},
+		{
+			name: "タイトルを変更しない更新は許可",
+			opts: []TodoServiceOption{WithUniqueTitles()},
+			run: func(s *TodoService) error {
+				_, err := s.UpdateTodo(ctx, &entity.Todo{ID: 1, Title: "買い物", IsCompleted: true})
+				return err
+			},
+		},
+		{
+			name: "他のTodoと同じタイトルへの更新を拒否",
+			opts: []TodoServiceOption{WithUniqueTitles()},
+			run: func(s *TodoService) error {
+				_, err := s.UpdateTodo(ctx, &entity.Todo{ID: 1, Title: "review"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "タイトルを指定しない複製を拒否",
+			opts: []TodoServiceOption{WithUniqueTitles()},
+			run: func(s *TodoService) error {
+				_, err := s.DuplicateTodo(ctx, 1, DuplicateTodoOptions{})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "別のタイトルを指定した複製は許可",
+			opts: []TodoServiceOption{WithUniqueTitles()},
+			run: func(s *TodoService) error {
+				_, err := s.DuplicateTodo(ctx, 1, DuplicateTodoOptions{Title: "買い物（2回目）"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockTodoRepository()
+			mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "買い物"}
+			mockRepo.todos[2] = &entity.Todo{ID: 2, Title: "Review"}
+			mockRepo.nextID = 3
+			service := NewTodoService(mockRepo, tt.opts...)
+
+			err := tt.run(service)
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrDuplicateTitle) {
+					t.Errorf("エラー = %v, 期待値 = ErrDuplicateTitle", err)
+				}
+				if mockRepo.GetCallCount("Create")+mockRepo.GetCallCount("UpdateFields") != 0 {
+					t.Error("重複の場合は保存してはいけません")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+			}
+		})
+	}
+}
+
+// TestTodoService_Projects は所属先のプロジェクトの確認をテストします
+func TestTodoService_Projects(t *testing.T) {
+	ctx := context.Background()
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		run     func(s *TodoService) error
+		wantErr bool
+	}{
+		{
+			name: "プロジェクトを指定して作成",
+			run: func(s *TodoService) error {
+				_, err := s.CreateTodo(ctx, &entity.Todo{Title: "仕事", ProjectID: intPtr(1)})
+				return err
+			},
+		},
+		{
+			name: "存在しないプロジェクトでの作成を拒否",
+			run: func(s *TodoService) error {
+				_, err := s.CreateTodo(ctx, &entity.Todo{Title: "仕事", ProjectID: intPtr(99)})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "アーカイブ済みのプロジェクトでの作成を拒否",
+			run: func(s *TodoService) error {
+				_, err := s.CreateTodo(ctx, &entity.Todo{Title: "仕事", ProjectID: intPtr(2)})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "アーカイブ済みのプロジェクトへの移動を拒否",
+			run: func(s *TodoService) error {
+				_, err := s.UpdateTodo(ctx, &entity.Todo{ID: 1, Title: "買い物", ProjectID: intPtr(2)})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name: "アーカイブ済みのプロジェクトのTodoも所属先を変えなければ更新できる",
+			run: func(s *TodoService) error {
+				_, err := s.UpdateTodo(ctx, &entity.Todo{ID: 2, Title: "資料作成", IsCompleted: true, ProjectID: intPtr(2)})
+				return err
+			},
+		},
+		{
+			name: "プロジェクトから外す",
+			run: func(s *TodoService) error {
+				_, err := s.UpdateTodo(ctx, &entity.Todo{ID: 2, Title: "資料作成"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectRepo := NewMockProjectRepository()
+			projectRepo.Create(ctx, &entity.Project{Name: "Work", Slug: "work"})
+			archived, _ := projectRepo.Create(ctx, &entity.Project{Name: "Old", Slug: "old"})
+			archivedAt := time.Now()
+			projectRepo.SetArchivedAt(ctx, archived.ID, &archivedAt)
+
+			mockRepo := NewMockTodoRepository()
+			mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "買い物"}
+			mockRepo.todos[2] = &entity.Todo{ID: 2, Title: "資料作成", ProjectID: intPtr(2)}
+			mockRepo.nextID = 3
+			service := NewTodoService(mockRepo, WithTodoProjects(projectRepo))
+
+			err := tt.run(service)
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidProject) {
+					t.Errorf("エラー = %v, 期待値 = ErrInvalidProject", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+			}
+		})
+	}
+}
+
 // generateLongString は指定された長さの文字列を生成するヘルパー関数です
 func generateLongString(length int) string {
 	result := ""
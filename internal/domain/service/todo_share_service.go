@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TodoShareService はTodoを他のユーザーと共有する設定のビジネスロジックを管理します
+// 共有の設定・一覧・解除は、Todoの所有者だけが行えます
+// 共有されたTodoに対する操作の権限の確認は TodoService（WithTodoShares）が行います
+type TodoShareService struct {
+	todoRepo  repository.TodoRepository
+	shareRepo repository.TodoShareRepository
+	userRepo  repository.UserRepository
+}
+
+// NewTodoShareService はTodoShareServiceのコンストラクタです
+func NewTodoShareService(todoRepo repository.TodoRepository, shareRepo repository.TodoShareRepository, userRepo repository.UserRepository) *TodoShareService {
+	return &TodoShareService{
+		todoRepo:  todoRepo,
+		shareRepo: shareRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// ShareTodo はTodoを username のユーザーと permission の権限で共有します
+// 既に共有している場合は権限を置き換えます
+func (s *TodoShareService) ShareTodo(ctx context.Context, todoID int, username string, permission entity.SharePermission) (*entity.TodoShare, error) {
+	// 1. 入力値の検証
+	if !permission.IsValid() {
+		return nil, domainerr.Invalid("permission", fmt.Sprintf("must be one of %v", entity.SharePermissions))
+	}
+	ownerID, err := s.ensureOwner(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 共有先のユーザーを確認（所有者自身とは共有できない）
+	user, err := s.lookupUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == ownerID {
+		return nil, domainerr.Invalid("username", "cannot share a todo with its owner")
+	}
+
+	// 3. 永続化
+	share, err := s.shareRepo.Save(ctx, &entity.TodoShare{TodoID: todoID, UserID: user.ID, Permission: permission})
+	if err != nil {
+		return nil, fmt.Errorf("failed to share todo %d: %w", todoID, err)
+	}
+	return share, nil
+}
+
+// ListShares はTodoの共有を共有先のユーザー名の順に取得します
+func (s *TodoShareService) ListShares(ctx context.Context, todoID int) ([]*entity.TodoShare, error) {
+	if _, err := s.ensureOwner(ctx, todoID); err != nil {
+		return nil, err
+	}
+
+	shares, err := s.shareRepo.ListByTodoID(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shares of todo %d: %w", todoID, err)
+	}
+	return shares, nil
+}
+
+// UnshareTodo は username のユーザーとの共有を解除します
+// 共有していない場合は domainerr.NotFound のエラーを返します
+func (s *TodoShareService) UnshareTodo(ctx context.Context, todoID int, username string) error {
+	if _, err := s.ensureOwner(ctx, todoID); err != nil {
+		return err
+	}
+	user, err := s.lookupUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if err := s.shareRepo.Delete(ctx, todoID, user.ID); err != nil {
+		if domainerr.IsNotFound(err) {
+			return domainerr.NotFound("todo share", username).Wrap(err)
+		}
+		return fmt.Errorf("failed to unshare todo %d: %w", todoID, err)
+	}
+	return nil
+}
+
+// ensureOwner はコンテキストのユーザーがTodoの所有者であることを確認し、そのユーザーIDを返します
+// 共有先のユーザーには ErrTodoForbidden、共有されていない他のユーザーのTodoには domainerr.NotFound を返します
+func (s *TodoShareService) ensureOwner(ctx context.Context, todoID int) (int, error) {
+	if todoID <= 0 {
+		return 0, domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+	userID, ok := repository.OwnerFromContext(ctx)
+	if !ok {
+		return 0, fmt.Errorf("%w: sharing requires an authenticated user", ErrTodoForbidden)
+	}
+
+	// 所有者で絞り込んで確認するため、他のユーザーのTodoは存在しない扱いになる
+	if err := requireTodo(ctx, s.todoRepo, todoID); err != nil {
+		if !domainerr.IsNotFound(err) {
+			return 0, err
+		}
+		if _, shareErr := s.shareRepo.Get(ctx, todoID, userID); shareErr == nil {
+			return 0, fmt.Errorf("%w: only the owner can manage shares of todo %d", ErrTodoForbidden, todoID)
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// lookupUser はユーザー名でユーザーを取得します
+func (s *TodoShareService) lookupUser(ctx context.Context, username string) (*entity.User, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, domainerr.NotFound("user", username).Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to get user %q: %w", username, err)
+	}
+	return user, nil
+}
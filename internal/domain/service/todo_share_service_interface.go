@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TodoShareServiceInterface はTodoの共有サービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type TodoShareServiceInterface interface {
+	// ShareTodo はTodoをユーザーと共有します（既に共有している場合は権限を置き換えます）
+	ShareTodo(ctx context.Context, todoID int, username string, permission entity.SharePermission) (*entity.TodoShare, error)
+
+	// ListShares はTodoの共有を取得します
+	ListShares(ctx context.Context, todoID int) ([]*entity.TodoShare, error)
+
+	// UnshareTodo はユーザーとの共有を解除します
+	UnshareTodo(ctx context.Context, todoID int, username string) error
+}
+
+// コンパイル時インターフェース実装確認
+var _ TodoShareServiceInterface = (*TodoShareService)(nil)
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// MockTodoShareRepository はテスト用のTodoShareRepositoryのモック実装です
+type MockTodoShareRepository struct {
+	shares map[[2]int]*entity.TodoShare
+}
+
+// NewMockTodoShareRepository はモックリポジトリを作成します
+func NewMockTodoShareRepository() *MockTodoShareRepository {
+	return &MockTodoShareRepository{shares: make(map[[2]int]*entity.TodoShare)}
+}
+
+// Save は共有を保存します（モック実装）
+func (m *MockTodoShareRepository) Save(ctx context.Context, share *entity.TodoShare) (*entity.TodoShare, error) {
+	stored := *share
+	m.shares[[2]int{share.TodoID, share.UserID}] = &stored
+	return share, nil
+}
+
+// Get は共有を取得します（モック実装）
+func (m *MockTodoShareRepository) Get(ctx context.Context, todoID, userID int) (*entity.TodoShare, error) {
+	share, ok := m.shares[[2]int{todoID, userID}]
+	if !ok {
+		return nil, domainerr.NotFound("todo share", nil)
+	}
+	shareCopy := *share
+	return &shareCopy, nil
+}
+
+// ListByTodoID はTodoの共有を取得します（モック実装）
+func (m *MockTodoShareRepository) ListByTodoID(ctx context.Context, todoID int) ([]*entity.TodoShare, error) {
+	var shares []*entity.TodoShare
+	for key, share := range m.shares {
+		if key[0] == todoID {
+			shares = append(shares, share)
+		}
+	}
+	return shares, nil
+}
+
+// Delete は共有を削除します（モック実装）
+func (m *MockTodoShareRepository) Delete(ctx context.Context, todoID, userID int) error {
+	if _, ok := m.shares[[2]int{todoID, userID}]; !ok {
+		return domainerr.NotFound("todo share", nil)
+	}
+	delete(m.shares, [2]int{todoID, userID})
+	return nil
+}
+
+// ownedMockTodoRepository はコンテキストの所有者で GetByID・Exists を絞り込むモックです
+// 実際のリポジトリと同様に、他のユーザーのTodoは存在しないものとして扱います
+type ownedMockTodoRepository struct {
+	*MockTodoRepository
+}
+
+func (m ownedMockTodoRepository) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
+	todo, err := m.MockTodoRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if userID, ok := repository.OwnerFromContext(ctx); ok && (todo.UserID == nil || *todo.UserID != userID) {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+	return todo, nil
+}
+
+func (m ownedMockTodoRepository) Exists(ctx context.Context, id int) (bool, error) {
+	if _, err := m.GetByID(ctx, id); err != nil {
+		if domainerr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// newShareTestFixture はアリス（ID 1）が所有するTodo（ID 1）と、ボブ（ID 2）・キャロル（ID 3）を用意します
+func newShareTestFixture(t *testing.T) (ownedMockTodoRepository, *MockTodoShareRepository, *MockUserRepository) {
+	t.Helper()
+	todoRepo := ownedMockTodoRepository{NewMockTodoRepository()}
+	aliceID := 1
+	todoRepo.Create(context.Background(), &entity.Todo{Title: "アリスのTodo", UserID: &aliceID})
+
+	users := NewMockUserRepository()
+	for _, name := range []string{"alice", "bob", "carol"} {
+		users.Create(context.Background(), &entity.User{Username: name})
+	}
+	return todoRepo, NewMockTodoShareRepository(), users
+}
+
+// TestTodoShareService は所有者だけが共有を設定・解除でき、入力が検証されることをテストします
+func TestTodoShareService(t *testing.T) {
+	todoRepo, shareRepo, users := newShareTestFixture(t)
+	svc := NewTodoShareService(todoRepo, shareRepo, users)
+	alice := WithPrincipal(context.Background(), Principal{UserID: 1, Username: "alice"})
+	bob := WithPrincipal(context.Background(), Principal{UserID: 2, Username: "bob"})
+	carol := WithPrincipal(context.Background(), Principal{UserID: 3, Username: "carol"})
+
+	share, err := svc.ShareTodo(alice, 1, "bob", entity.SharePermissionRead)
+	if err != nil || share.UserID != 2 || share.Permission != entity.SharePermissionRead {
+		t.Fatalf("ShareTodo() = %+v, err = %v", share, err)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		todoID  int
+		user    string
+		perm    entity.SharePermission
+		checkFn func(error) bool
+	}{
+		{name: "不正な権限", ctx: alice, todoID: 1, user: "bob", perm: "admin", checkFn: domainerr.IsInvalid},
+		{name: "存在しないユーザー", ctx: alice, todoID: 1, user: "dave", perm: entity.SharePermissionRead, checkFn: domainerr.IsNotFound},
+		{name: "所有者自身", ctx: alice, todoID: 1, user: "alice", perm: entity.SharePermissionRead, checkFn: domainerr.IsInvalid},
+		{name: "共有先のユーザーは共有を設定できない", ctx: bob, todoID: 1, user: "carol", perm: entity.SharePermissionRead, checkFn: func(err error) bool { return errors.Is(err, ErrTodoForbidden) }},
+		{name: "共有されていないユーザーには存在を明かさない", ctx: carol, todoID: 1, user: "bob", perm: entity.SharePermissionWrite, checkFn: domainerr.IsNotFound},
+		{name: "認証されていない", ctx: context.Background(), todoID: 1, user: "bob", perm: entity.SharePermissionRead, checkFn: func(err error) bool { return errors.Is(err, ErrTodoForbidden) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ShareTodo(tt.ctx, tt.todoID, tt.user, tt.perm)
+			if err == nil || !tt.checkFn(err) {
+				t.Errorf("ShareTodo() error = %v", err)
+			}
+		})
+	}
+
+	if shares, err := svc.ListShares(alice, 1); err != nil || len(shares) != 1 {
+		t.Errorf("ListShares() = %d件, err = %v, 期待値 = 1件", len(shares), err)
+	}
+	if err := svc.UnshareTodo(alice, 1, "bob"); err != nil {
+		t.Fatalf("UnshareTodo() error = %v", err)
+	}
+	if err := svc.UnshareTodo(alice, 1, "bob"); !domainerr.IsNotFound(err) {
+		t.Errorf("共有していないユーザーの UnshareTodo() error = %v, 期待値 = not found", err)
+	}
+}
+
+// TestTodoService_SharedTodo は共有されたTodoを、共有の権限の範囲でだけ操作できることをテストします
+func TestTodoService_SharedTodo(t *testing.T) {
+	todoRepo, shareRepo, _ := newShareTestFixture(t)
+	svc := NewTodoService(todoRepo, WithTodoShares(shareRepo))
+	shareRepo.Save(context.Background(), &entity.TodoShare{TodoID: 1, UserID: 2, Permission: entity.SharePermissionRead})
+	shareRepo.Save(context.Background(), &entity.TodoShare{TodoID: 1, UserID: 3, Permission: entity.SharePermissionWrite})
+	reader := WithPrincipal(context.Background(), Principal{UserID: 2, Username: "bob"})
+	writer := WithPrincipal(context.Background(), Principal{UserID: 3, Username: "carol"})
+	stranger := WithPrincipal(context.Background(), Principal{UserID: 4, Username: "dave"})
+
+	// 読み取り
+	for name, ctx := range map[string]context.Context{"読み取りの権限": reader, "書き込みの権限": writer} {
+		if _, err := svc.GetTodoByID(ctx, 1); err != nil {
+			t.Errorf("%s の GetTodoByID() error = %v", name, err)
+		}
+	}
+	if _, err := svc.GetTodoByID(stranger, 1); !domainerr.IsNotFound(err) {
+		t.Errorf("共有されていないユーザーの GetTodoByID() error = %v, 期待値 = not found", err)
+	}
+
+	// 書き込み
+	if _, err := svc.CompleteTodo(reader, 1); !errors.Is(err, ErrTodoForbidden) {
+		t.Errorf("読み取りの権限での CompleteTodo() error = %v, 期待値 = ErrTodoForbidden", err)
+	}
+	if _, err := svc.UpdateTodo(reader, &entity.Todo{ID: 1, Title: "変更"}); !errors.Is(err, ErrTodoForbidden) {
+		t.Errorf("読み取りの権限での UpdateTodo() error = %v, 期待値 = ErrTodoForbidden", err)
+	}
+	if _, err := svc.CompleteTodo(writer, 1); err != nil {
+		t.Errorf("書き込みの権限での CompleteTodo() error = %v", err)
+	}
+
+	// 削除は所有者だけ
+	if err := svc.DeleteTodo(writer, 1); !errors.Is(err, ErrTodoForbidden) {
+		t.Errorf("書き込みの権限での DeleteTodo() error = %v, 期待値 = ErrTodoForbidden", err)
+	}
+	if err := svc.DeleteTodo(stranger, 1); !domainerr.IsNotFound(err) {
+		t.Errorf("共有されていないユーザーの DeleteTodo() error = %v, 期待値 = not found", err)
+	}
+}
+
+// TestTodoService_SharedTodo_OwnerReadsOnce は所有者の更新・削除で、権限の確認が行を読み込まない（Exists を使う）ことをテストします
+func TestTodoService_SharedTodo_OwnerReadsOnce(t *testing.T) {
+	todoRepo := NewMockTodoRepository()
+	svc := NewTodoService(todoRepo, WithTodoShares(NewMockTodoShareRepository()))
+	alice := WithPrincipal(context.Background(), Principal{UserID: 1, Username: "alice"})
+	created, _ := todoRepo.Create(alice, &entity.Todo{Title: "アリスのTodo"})
+
+	if _, err := svc.UpdateTodo(alice, &entity.Todo{ID: created.ID, Title: "変更"}); err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	if err := svc.DeleteTodo(alice, created.ID); err != nil {
+		t.Fatalf("DeleteTodo() error = %v", err)
+	}
+	if got := todoRepo.GetCallCount("GetByID"); got != 2 {
+		t.Errorf("GetByID の呼び出し回数 = %d, 期待値 = 2（更新・削除の前の状態の取得のみ）", got)
+	}
+	if got := todoRepo.GetCallCount("Exists"); got != 2 {
+		t.Errorf("Exists の呼び出し回数 = %d, 期待値 = 2", got)
+	}
+}
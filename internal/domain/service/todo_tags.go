@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// ErrInvalidTag はタグが空、または使用できない文字を含む場合のエラーです
+var ErrInvalidTag = errors.New("invalid tag")
+
+// ErrTooManyTags はタグの追加によって、Todoのタグの数が上限（entity.MaxTagsPerTodo）を超える場合のエラーです
+var ErrTooManyTags = errors.New("too many tags")
+
+// TodoSelection は一括タグ操作の対象のTodoの指定です
+// IDs を指定した場合はそのTodoだけを、指定しない場合は条件（Color・Completed・Tag）に一致する全てのTodoを対象にします
+type TodoSelection struct {
+	// IDs は対象のTodoのIDです（存在しないIDが含まれる場合は操作全体が失敗します）
+	IDs []int
+
+	// Color は色による絞り込みです（空の場合は絞り込まない）
+	Color entity.Color
+
+	// Completed は完了状態による絞り込みです（nil の場合は絞り込まない）
+	Completed *bool
+
+	// Tag は付いているタグによる絞り込みです（空の場合は絞り込まない）
+	Tag string
+}
+
+// IsEmpty は対象の指定が何もないかを返します
+// 全てのTodoを意図せず変更しないよう、一括タグ操作では空の指定を受け付けません
+func (sel TodoSelection) IsEmpty() bool {
+	return len(sel.IDs) == 0 && sel.Color == entity.ColorNone && sel.Completed == nil && sel.Tag == ""
+}
+
+// matches はTodoが条件に一致するかを返します（IDs は含みません）
+func (sel TodoSelection) matches(todo *entity.Todo) bool {
+	if sel.Color != entity.ColorNone && todo.Color != sel.Color {
+		return false
+	}
+	if sel.Completed != nil && todo.IsCompleted != *sel.Completed {
+		return false
+	}
+	if sel.Tag != "" && !todo.HasTag(sel.Tag) {
+		return false
+	}
+	return true
+}
+
+// BulkTagResult は一括タグ操作の結果です
+type BulkTagResult struct {
+	// Matched は対象として選ばれたTodoの数です（変更がなかったものを含む）
+	Matched int
+
+	// Updated は実際にタグが変わったTodoです（変更がなかったTodoは含みません）
+	Updated []*entity.Todo
+}
+
+// AddTagToTodos は選択したTodoにタグを付けます
+// 既にタグが付いているTodoは変更しません
+func (s *TodoService) AddTagToTodos(ctx context.Context, tag string, sel TodoSelection) (*BulkTagResult, error) {
+	tag, err := normalizeTagArg(tag)
+	if err != nil {
+		return nil, err
+	}
+	return s.retagTodos(ctx, sel, func(todo *entity.Todo) (bool, error) {
+		if !todo.AddTag(tag) {
+			return false, nil
+		}
+		if len(todo.Tags) > entity.MaxTagsPerTodo {
+			return false, fmt.Errorf("%w: todo %d already has %d tags", ErrTooManyTags, todo.ID, entity.MaxTagsPerTodo)
+		}
+		return true, nil
+	})
+}
+
+// RemoveTagFromTodos は選択したTodoからタグを外します
+// タグが付いていないTodoは変更しません
+func (s *TodoService) RemoveTagFromTodos(ctx context.Context, tag string, sel TodoSelection) (*BulkTagResult, error) {
+	tag, err := normalizeTagArg(tag)
+	if err != nil {
+		return nil, err
+	}
+	return s.retagTodos(ctx, sel, func(todo *entity.Todo) (bool, error) {
+		return todo.RemoveTag(tag), nil
+	})
+}
+
+// MergeTags はタグ source を target に統合します
+// source が付いた全てのTodoで source を target に置き換えます（既に target も付いている場合は source を外すだけ）
+// 統合後、source はどのTodoにも残りません
+func (s *TodoService) MergeTags(ctx context.Context, source, target string) (*BulkTagResult, error) {
+	source, err := normalizeTagArg(source)
+	if err != nil {
+		return nil, err
+	}
+	target, err = normalizeTagArg(target)
+	if err != nil {
+		return nil, err
+	}
+	if source == target {
+		return nil, fmt.Errorf("%w: cannot merge tag %q into itself", ErrInvalidTag, source)
+	}
+	return s.retagTodos(ctx, TodoSelection{Tag: source}, func(todo *entity.Todo) (bool, error) {
+		return todo.ReplaceTag(source, target), nil
+	})
+}
+
+// retagTodos は選択したTodoのタグを change で変更し、変わったTodoだけを1つのトランザクションで保存します
+// Todoごとに更新のイベントを発行するため、変更履歴には1件ずつタグの変更前後が記録されます
+func (s *TodoService) retagTodos(ctx context.Context, sel TodoSelection, change func(todo *entity.Todo) (bool, error)) (*BulkTagResult, error) {
+	// 1. 対象のTodoを取得
+	todos, err := s.selectTodos(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. タグを変更（変更前の状態は変更履歴のスナップショットに使用）
+	var befores, changed []*entity.Todo
+	for _, todo := range todos {
+		before := *todo
+		ok, err := change(todo)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			befores = append(befores, &before)
+			changed = append(changed, todo)
+		}
+	}
+
+	result := &BulkTagResult{Matched: len(todos)}
+	if len(changed) == 0 {
+		return result, nil
+	}
+
+	// 3. リポジトリを通じて1つのトランザクションで更新し、項目ごとに更新のイベントを発行
+	err = s.saveChanges(ctx, func(ctx context.Context, record recordFunc) error {
+		updated, err := s.todoRepo.UpdateMany(ctx, changed)
+		if err != nil {
+			return fmt.Errorf("failed to update tags: %w", err)
+		}
+		for i, todo := range updated {
+			record(entity.TodoHistoryUpdated, befores[i], todo)
+		}
+		result.Updated = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// selectTodos は一括操作の対象のTodoを取得します
+// IDs を指定した場合、存在しないIDがあれば失敗した全てのIDを含む *BatchError を返します
+func (s *TodoService) selectTodos(ctx context.Context, sel TodoSelection) ([]*entity.Todo, error) {
+	if sel.IsEmpty() {
+		return nil, domainerr.Invalid("selection", "specify todo IDs or a filter")
+	}
+	sel.Tag = entity.NormalizeTag(sel.Tag)
+
+	if len(sel.IDs) > 0 {
+		todos := make([]*entity.Todo, 0, len(sel.IDs))
+		seen := make(map[int]bool, len(sel.IDs))
+		var failures []*BatchItemError
+		for i, id := range sel.IDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			todo, err := s.todoRepo.GetByID(ctx, id)
+			if err != nil {
+				failures = append(failures, &BatchItemError{Index: i, ID: id, Err: lookupError("todo", id, err)})
+				continue
+			}
+			todos = append(todos, todo)
+		}
+		if len(failures) > 0 {
+			return nil, &BatchError{Items: failures}
+		}
+		return todos, nil
+	}
+
+	var todos []*entity.Todo
+	var err error
+	if sel.Color != entity.ColorNone {
+		todos, err = s.todoRepo.GetByColor(ctx, sel.Color)
+	} else {
+		todos, err = s.todoRepo.GetAll(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todos: %w", err)
+	}
+
+	matched := todos[:0]
+	for _, todo := range todos {
+		if sel.matches(todo) {
+			matched = append(matched, todo)
+		}
+	}
+	return matched, nil
+}
+
+// normalizeTagArg はタグを正規化し、制約を満たさない場合は ErrInvalidTag を返します
+func normalizeTagArg(tag string) (string, error) {
+	normalized := entity.NormalizeTag(tag)
+	if !entity.IsValidTag(normalized) {
+		return "", fmt.Errorf("%w: %q (must be 1-%d characters without spaces, commas or slashes)", ErrInvalidTag, tag, entity.MaxTagLength)
+	}
+	return normalized, nil
+}
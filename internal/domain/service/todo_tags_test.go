@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// newTagTestService はタグの一括操作のテスト用に、タグ付きのTodoを保存したサービスを作成します
+func newTagTestService() (*TodoService, *MockTodoRepository, *MockTodoHistoryRepository) {
+	mockRepo := NewMockTodoRepository()
+	mockRepo.todos[1] = &entity.Todo{ID: 1, Title: "買い物", Color: entity.Color("red"), Tags: []string{"home"}}
+	mockRepo.todos[2] = &entity.Todo{ID: 2, Title: "Review", IsCompleted: true, Tags: []string{"work", "urgent"}}
+	mockRepo.todos[3] = &entity.Todo{ID: 3, Title: "掃除", Color: entity.Color("red")}
+	historyRepo := &MockTodoHistoryRepository{}
+	return NewTodoService(mockRepo, WithTodoHistory(historyRepo)), mockRepo, historyRepo
+}
+
+// TestTodoService_AddTagToTodos はIDまたは条件で選択したTodoへのタグの追加をテストします
+func TestTodoService_AddTagToTodos(t *testing.T) {
+	completed := false
+
+	tests := []struct {
+		name        string
+		tag         string
+		sel         TodoSelection
+		wantMatched int
+		wantTags    map[int][]string
+		wantErr     error
+	}{
+		{
+			name:        "IDで選択（正規化して追加・付いているTodoは変更しない）",
+			tag:         " Home ",
+			sel:         TodoSelection{IDs: []int{1, 3, 3}},
+			wantMatched: 2,
+			wantTags:    map[int][]string{1: {"home"}, 3: {"home"}},
+		},
+		{
+			name:        "色と完了状態で選択",
+			tag:         "週末",
+			sel:         TodoSelection{Color: entity.Color("red"), Completed: &completed},
+			wantMatched: 2,
+			wantTags:    map[int][]string{1: {"home", "週末"}, 3: {"週末"}, 2: {"work", "urgent"}},
+		},
+		{
+			name:        "タグで選択",
+			tag:         "q3",
+			sel:         TodoSelection{Tag: "WORK"},
+			wantMatched: 1,
+			wantTags:    map[int][]string{2: {"work", "urgent", "q3"}, 3: nil},
+		},
+		{
+			name:    "選択なしを拒否",
+			tag:     "home",
+			sel:     TodoSelection{},
+			wantErr: errors.New("invalid selection"),
+		},
+		{
+			name:    "空白を含むタグを拒否",
+			tag:     "two words",
+			sel:     TodoSelection{IDs: []int{1}},
+			wantErr: ErrInvalidTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := newTagTestService()
+
+			result, err := service.AddTagToTodos(context.Background(), tt.tag, tt.sel)
+
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("エラーが期待されましたが、nil が返されました")
+				}
+				if errors.Is(tt.wantErr, ErrInvalidTag) && !errors.Is(err, ErrInvalidTag) {
+					t.Errorf("エラー = %v, 期待値 = ErrInvalidTag", err)
+				}
+				if mockRepo.GetCallCount("UpdateMany") != 0 {
+					t.Error("失敗した場合は保存してはいけません")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddTagToTodos() error = %v", err)
+			}
+			if result.Matched != tt.wantMatched {
+				t.Errorf("Matched = %d, 期待値 = %d", result.Matched, tt.wantMatched)
+			}
+			for id, want := range tt.wantTags {
+				if got := mockRepo.todos[id].Tags; !reflect.DeepEqual(got, want) {
+					t.Errorf("Todo %d のタグ = %v, 期待値 = %v", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestTodoService_AddTagToTodos_NotFound は存在しないIDを含む場合に何も保存しないことをテストします
+func TestTodoService_AddTagToTodos_NotFound(t *testing.T) {
+	service, mockRepo, _ := newTagTestService()
+
+	_, err := service.AddTagToTodos(context.Background(), "home", TodoSelection{IDs: []int{3, 98, 99}})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Items) != 2 {
+		t.Fatalf("エラー = %v, 期待値 = 2件の *BatchError", err)
+	}
+	if mockRepo.todos[3].Tags != nil {
+		t.Errorf("Todo 3 のタグ = %v, 期待値 = 変更なし", mockRepo.todos[3].Tags)
+	}
+}
+
+// TestTodoService_AddTagToTodos_TooManyTags はタグの数の上限を超える追加を拒否することをテストします
+func TestTodoService_AddTagToTodos_TooManyTags(t *testing.T) {
+	service, mockRepo, _ := newTagTestService()
+	full := make([]string, entity.MaxTagsPerTodo)
+	for i := range full {
+		full[i] = string(rune('a' + i))
+	}
+	mockRepo.todos[3].Tags = full
+
+	_, err := service.AddTagToTodos(context.Background(), "extra", TodoSelection{IDs: []int{1, 3}})
+
+	if !errors.Is(err, ErrTooManyTags) {
+		t.Fatalf("エラー = %v, 期待値 = ErrTooManyTags", err)
+	}
+	if !reflect.DeepEqual(mockRepo.todos[1].Tags, []string{"home"}) {
+		t.Errorf("Todo 1 のタグ = %v, 期待値 = 変更なし", mockRepo.todos[1].Tags)
+	}
+}
+
+// TestTodoService_RemoveTagFromTodos はタグの削除と変更履歴の記録をテストします
+func TestTodoService_RemoveTagFromTodos(t *testing.T) {
+	service, mockRepo, historyRepo := newTagTestService()
+	ctx := context.Background()
+
+	result, err := service.RemoveTagFromTodos(ctx, "urgent", TodoSelection{IDs: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("RemoveTagFromTodos() error = %v", err)
+	}
+
+	if result.Matched != 2 || len(result.Updated) != 1 || result.Updated[0].ID != 2 {
+		t.Errorf("結果 = %+v, 期待値 = 2件中 Todo 2 のみ更新", result)
+	}
+	if got := mockRepo.todos[2].Tags; !reflect.DeepEqual(got, []string{"work"}) {
+		t.Errorf("Todo 2 のタグ = %v, 期待値 = [work]", got)
+	}
+
+	// 変更したTodoだけに、タグの変更前後を含む更新の履歴が記録される
+	entries, _ := historyRepo.ListByTodoID(ctx, 2)
+	if len(entries) != 1 || entries[0].Action != entity.TodoHistoryUpdated {
+		t.Fatalf("Todo 2 の履歴 = %+v, 期待値 = 更新1件", entries)
+	}
+	if !reflect.DeepEqual(entries[0].Before.Tags, []string{"work", "urgent"}) || !reflect.DeepEqual(entries[0].After.Tags, []string{"work"}) {
+		t.Errorf("履歴のタグ = %v → %v, 期待値 = [work urgent] → [work]", entries[0].Before.Tags, entries[0].After.Tags)
+	}
+	if entries, _ := historyRepo.ListByTodoID(ctx, 1); len(entries) != 0 {
+		t.Errorf("変更のない Todo 1 の履歴 = %+v, 期待値 = なし", entries)
+	}
+}
+
+// TestTodoService_MergeTags はタグの統合をテストします
+func TestTodoService_MergeTags(t *testing.T) {
+	service, mockRepo, historyRepo := newTagTestService()
+	ctx := context.Background()
+	mockRepo.todos[3].Tags = []string{"urgent", "home"}
+
+	result, err := service.MergeTags(ctx, "Home", "work")
+	if err != nil {
+		t.Fatalf("MergeTags() error = %v", err)
+	}
+
+	if result.Matched != 2 || len(result.Updated) != 2 {
+		t.Errorf("結果 = %+v, 期待値 = 2件を更新", result)
+	}
+	want := map[int][]string{1: {"work"}, 2: {"work", "urgent"}, 3: {"urgent", "work"}}
+	for id, tags := range want {
+		if got := mockRepo.todos[id].Tags; !reflect.DeepEqual(got, tags) {
+			t.Errorf("Todo %d のタグ = %v, 期待値 = %v", id, got, tags)
+		}
+	}
+	for _, id := range []int{1, 3} {
+		if entries, _ := historyRepo.ListByTodoID(ctx, id); len(entries) != 1 {
+			t.Errorf("Todo %d の履歴 = %+v, 期待値 = 更新1件", id, entries)
+		}
+	}
+
+	if _, err := service.MergeTags(ctx, "work", "WORK"); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("同じタグへの統合のエラー = %v, 期待値 = ErrInvalidTag", err)
+	}
+}
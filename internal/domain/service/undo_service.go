@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNothingToUndo は取り消せる操作がない場合（記録がない・取り消し期間を過ぎた）のエラーです
+// ハンドラーはこのエラーを 404 Not Found として返します
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// UndoAction は取り消しの対象になる操作の種類です
+type UndoAction string
+
+// 取り消しの対象になる操作です（一括更新は todo_batch.go の UndoActionBatchUpdate）
+const (
+	UndoActionDelete UndoAction = "delete"
+)
+
+// UndoResult は取り消した操作の内容です
+type UndoResult struct {
+	// Action は取り消した操作の種類です
+	Action UndoAction
+
+	// TodoIDs は元に戻したTodoのIDです
+	TodoIDs []int
+}
+
+// undoEntry は取り消しに必要な情報の記録です
+type undoEntry struct {
+	action    UndoAction
+	todoIDs   []int
+	expiresAt time.Time
+
+	// revert は操作を元に戻す関数です（操作の直前の状態を閉じ込めたクロージャ）
+	revert func(ctx context.Context) error
+}
+
+// UndoService は直前の破壊的な操作（削除など）を短い期間だけ取り消せるようにするサービスです
+//
+// 学習ポイント：
+// 1. 操作を行ったサービスが「元に戻す関数」を記録し、このサービスはいつ実行してよいかだけを管理する
+// 2. 記録は操作者（ActorFromContext）ごとに直前の1件のみで、新しい操作を行うと古い記録は上書きされる
+// 3. 記録はメモリ上にのみ保持するため、プロセスの再起動や別のインスタンスへのリクエストでは取り消せない
+//
+// 操作者を特定できないリクエストは entity.AnonymousActor として1つの記録を共有します
+type UndoService struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	entries map[string]undoEntry
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewUndoService はUndoServiceのコンストラクタです
+// window は操作の後、取り消しを受け付ける期間です
+func NewUndoService(window time.Duration) *UndoService {
+	return &UndoService{
+		window:  window,
+		entries: make(map[string]undoEntry),
+		now:     time.Now,
+	}
+}
+
+// record は操作者の直前の操作として、取り消し用の関数を記録します
+func (s *UndoService) record(ctx context.Context, action UndoAction, todoIDs []int, revert func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	// 取り消されないまま期限を過ぎた記録がたまらないよう、記録のたびに掃除する
+	for actor, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, actor)
+		}
+	}
+
+	s.entries[ActorFromContext(ctx)] = undoEntry{
+		action:    action,
+		todoIDs:   todoIDs,
+		expiresAt: now.Add(s.window),
+		revert:    revert,
+	}
+}
+
+// Undo は操作者の直前の操作を取り消します
+// 取り消せる操作がない場合は ErrNothingToUndo を返します
+func (s *UndoService) Undo(ctx context.Context) (*UndoResult, error) {
+	actor := ActorFromContext(ctx)
+
+	// 同時に2回取り消されないよう、実行する前に記録を取り出しておく
+	s.mu.Lock()
+	entry, ok := s.entries[actor]
+	delete(s.entries, actor)
+	s.mu.Unlock()
+
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return nil, ErrNothingToUndo
+	}
+
+	if err := entry.revert(ctx); err != nil {
+		// 失敗した場合は、その間に新しい操作が記録されていなければ再度取り消せるようにする
+		s.mu.Lock()
+		if _, exists := s.entries[actor]; !exists {
+			s.entries[actor] = entry
+		}
+		s.mu.Unlock()
+		return nil, fmt.Errorf("failed to undo %s: %w", entry.action, err)
+	}
+
+	return &UndoResult{Action: entry.action, TodoIDs: entry.todoIDs}, nil
+}
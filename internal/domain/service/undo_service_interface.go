@@ -0,0 +1,13 @@
+package service
+
+import "context"
+
+// UndoServiceInterface は直前の操作の取り消しサービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type UndoServiceInterface interface {
+	// Undo は操作者の直前の操作を取り消します
+	Undo(ctx context.Context) (*UndoResult, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ UndoServiceInterface = (*UndoService)(nil)
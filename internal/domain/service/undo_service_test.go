@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestUndoService_Undo は取り消しの期間・操作者ごとの記録・失敗時の扱いをテストします
+func TestUndoService_Undo(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	alice := WithActor(context.Background(), "alice")
+	bob := WithActor(context.Background(), "bob")
+
+	tests := []struct {
+		name      string
+		record    context.Context
+		undo      context.Context
+		elapsed   time.Duration
+		revertErr error
+		wantErr   error
+		wantRetry bool
+	}{
+		{name: "期間内の取り消し", record: alice, undo: alice, elapsed: 29 * time.Second},
+		{name: "期間を過ぎた取り消し", record: alice, undo: alice, elapsed: 30 * time.Second, wantErr: ErrNothingToUndo},
+		{name: "別の操作者の操作は取り消せない", record: alice, undo: bob, wantErr: ErrNothingToUndo},
+		{name: "元に戻す処理の失敗は再試行できる", record: alice, undo: alice, revertErr: errors.New("database is down"), wantRetry: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undo := NewUndoService(30 * time.Second)
+			undo.now = func() time.Time { return now }
+
+			reverted := 0
+			undo.record(tt.record, UndoActionDelete, []int{7}, func(ctx context.Context) error {
+				reverted++
+				return tt.revertErr
+			})
+			undo.now = func() time.Time { return now.Add(tt.elapsed) }
+
+			result, err := undo.Undo(tt.undo)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Undo() error = %v, 期待値 = %v", err, tt.wantErr)
+				}
+				if reverted != 0 {
+					t.Errorf("元に戻す処理の呼び出し回数 = %d, 期待値 = 0", reverted)
+				}
+				return
+			case tt.revertErr != nil:
+				if err == nil {
+					t.Fatal("エラーが期待されましたが、発生しませんでした")
+				}
+			default:
+				if err != nil {
+					t.Fatalf("Undo() error = %v", err)
+				}
+				if result.Action != UndoActionDelete || len(result.TodoIDs) != 1 || result.TodoIDs[0] != 7 {
+					t.Errorf("Undo() = %+v, 期待値 = delete [7]", result)
+				}
+			}
+
+			// 成功した取り消しは2回目はできず、失敗した取り消しはもう一度試せる
+			_, err = undo.Undo(tt.undo)
+			if tt.wantRetry {
+				if errors.Is(err, ErrNothingToUndo) {
+					t.Error("失敗した取り消しを再試行できません")
+				}
+			} else if !errors.Is(err, ErrNothingToUndo) {
+				t.Errorf("2回目の Undo() error = %v, 期待値 = %v", err, ErrNothingToUndo)
+			}
+		})
+	}
+}
+
+// TestUndoService_RecordOverwrites は新しい操作の記録で、同じ操作者の古い記録が上書きされることをテストします
+func TestUndoService_RecordOverwrites(t *testing.T) {
+	undo := NewUndoService(time.Minute)
+	ctx := WithActor(context.Background(), "alice")
+
+	undo.record(ctx, UndoActionDelete, []int{1}, func(context.Context) error { return nil })
+	undo.record(ctx, UndoActionDelete, []int{2}, func(context.Context) error { return nil })
+
+	result, err := undo.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if result.TodoIDs[0] != 2 {
+		t.Errorf("取り消したTodoのID = %v, 期待値 = 2（直前の操作）", result.TodoIDs)
+	}
+	if _, err := undo.Undo(ctx); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("2回目の Undo() error = %v, 期待値 = %v（古い記録は残らない）", err, ErrNothingToUndo)
+	}
+}
+
+// TestTodoService_DeleteTodo_Undo は削除の取り消しでTodoとチェックリストが元に戻ることをテストします
+func TestTodoService_DeleteTodo_Undo(t *testing.T) {
+	mockRepo := NewMockTodoRepository()
+	checklistRepo := NewMockChecklistRepository()
+	historyRepo := &MockTodoHistoryRepository{}
+	undo := NewUndoService(time.Minute)
+	service := NewTodoService(mockRepo, WithTodoChecklist(checklistRepo), WithTodoHistory(historyRepo), WithTodoUndo(undo))
+	ctx := context.Background()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.todos[5] = &entity.Todo{ID: 5, Title: "買い物", IsCompleted: true, CreatedAt: createdAt}
+	checklistRepo.Create(ctx, &entity.ChecklistItem{TodoID: 5, Text: "牛乳", IsDone: true})
+
+	if err := service.DeleteTodo(ctx, 5); err != nil {
+		t.Fatalf("DeleteTodo() error = %v", err)
+	}
+	// データベースと同様に、Todoの削除でチェックリスト項目も削除された状態にする
+	checklistRepo.items = make(map[int]*entity.ChecklistItem)
+
+	result, err := undo.Undo(ctx)
+	if err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if result.Action != UndoActionDelete || result.TodoIDs[0] != 5 {
+		t.Errorf("Undo() = %+v, 期待値 = delete [5]", result)
+	}
+
+	restored, err := mockRepo.GetByID(ctx, 5)
+	if err != nil {
+		t.Fatalf("復元したTodoの取得に失敗: %v", err)
+	}
+	if restored.Title != "買い物" || !restored.IsCompleted || !restored.CreatedAt.Equal(createdAt) {
+		t.Errorf("復元したTodo = %+v, 期待値 = 削除前と同じ内容", restored)
+	}
+
+	items, _ := checklistRepo.ListByTodoID(ctx, 5)
+	if len(items) != 1 || items[0].Text != "牛乳" || !items[0].IsDone {
+		t.Errorf("復元したチェックリスト = %+v, 期待値 = 完了済みの「牛乳」1件", items)
+	}
+
+	entries, _ := historyRepo.ListByTodoID(ctx, 5)
+	if last := entries[len(entries)-1]; last.Action != entity.TodoHistoryRestored {
+		t.Errorf("最後の履歴 = %v, 期待値 = %v", last.Action, entity.TodoHistoryRestored)
+	}
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// WebhookMessage はWebhookで送信する1回分の通知です
+type WebhookMessage struct {
+	// ID は通知の一意識別子です（再送しても変わらないため、受信側は重複の排除に使えます）
+	ID string `json:"id"`
+
+	// Event は通知するイベントの種類です
+	Event entity.WebhookEvent `json:"event"`
+
+	// Body は送信するJSONの本文です
+	Body json.RawMessage `json:"body"`
+}
+
+// WebhookSender はWebhookの送信手段を抽象化するインターフェースです
+// HTTPでのPOSTはインフラストラクチャ層で実装します
+type WebhookSender interface {
+	// Send は通知を url へ送信します（2xx 以外の応答はエラーとして返します）
+	Send(ctx context.Context, url string, message WebhookMessage) error
+}
+
+// webhookPayload はWebhookで送信する本文です
+type webhookPayload struct {
+	ID         string              `json:"id"`
+	Event      entity.WebhookEvent `json:"event"`
+	OccurredAt time.Time           `json:"occurred_at"`
+
+	// Todo はイベント発生後のTodoです（削除の場合は削除前のTodo）
+	Todo *entity.Todo `json:"todo"`
+}
+
+// webhookRedelivery は再送キューに登録する、送信に失敗した通知です
+type webhookRedelivery struct {
+	SubscriptionID int            `json:"subscription_id"`
+	Message        WebhookMessage `json:"message"`
+}
+
+// WebhookService はWebhookの登録の管理と、Todoのイベントの通知を行います
+//
+// 学習ポイント：
+//  1. 登録ごとに通知するイベントを選べるようにし、受信側が必要なイベントだけを受け取れるようにする
+//  2. 通知はリクエストの処理とは別の goroutine で送信し、受信側が遅くてもAPIの応答を遅らせない
+//  3. 送信に失敗した通知は再送キュー（DeliveryQueue）に任せ、指数バックオフで再送する
+type WebhookService struct {
+	webhookRepo repository.WebhookRepository
+	sender      WebhookSender
+
+	// deliveryQueue は送信に失敗した通知の再送キューです（nil の場合は再送しない）
+	deliveryQueue DeliveryQueue
+
+	// debouncer は同じTodoに短時間に続いたイベントを1つにまとめるバッファです（nil の場合はまとめない）
+	debouncer *event.Debouncer
+
+	// pending は送信中の通知です（シャットダウン時に Wait で完了を待ちます）
+	pending sync.WaitGroup
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// WebhookServiceOption はWebhookServiceに任意の機能を設定する関数型オプションです
+type WebhookServiceOption func(*WebhookService)
+
+// WithWebhookDeliveryQueue は送信に失敗した通知を再送キューに登録するようにします
+// 再送処理として Redeliver を DeliveryKindWebhook に登録してください
+func WithWebhookDeliveryQueue(queue DeliveryQueue) WebhookServiceOption {
+	return func(s *WebhookService) {
+		s.deliveryQueue = queue
+	}
+}
+
+// WithWebhookDebounce は同じTodoに window 以内に続いたイベント（続けて3回編集した など）を1つの通知にまとめます
+// まとめた通知は最初のイベントから window 後に送信されます（Subscribe で購読したイベントのみ）
+func WithWebhookDebounce(window time.Duration) WebhookServiceOption {
+	return func(s *WebhookService) {
+		if window > 0 {
+			s.debouncer = event.NewDebouncer(window, s.publishTodoEvent)
+		}
+	}
+}
+
+// NewWebhookService はWebhookServiceのコンストラクタです
+func NewWebhookService(webhookRepo repository.WebhookRepository, sender WebhookSender, opts ...WebhookServiceOption) *WebhookService {
+	s := &WebhookService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register はWebhookを登録します
+func (s *WebhookService) Register(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if !subscription.IsValid() {
+		return nil, domainerr.ValidationFailed("webhook", fmt.Sprintf("url must be an absolute http(s) URL and events must be one or more of %s", joinEventNames(entity.WebhookEvents)))
+	}
+
+	created, err := s.webhookRepo.Create(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register webhook: %w", err)
+	}
+	return created, nil
+}
+
+// List は認証されたユーザー（ワークスペース）のWebhookの登録を取得します
+func (s *WebhookService) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	subscriptions, err := s.webhookRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// Get はIDでWebhookの登録を取得します
+func (s *WebhookService) Get(ctx context.Context, id int) (*entity.WebhookSubscription, error) {
+	if id <= 0 {
+		return nil, domainerr.Invalid("webhook ID", "must be greater than 0")
+	}
+
+	subscription, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, lookupError("webhook", id, err)
+	}
+	return subscription, nil
+}
+
+// Delete はWebhookの登録を削除します（以降の通知は送信されません）
+func (s *WebhookService) Delete(ctx context.Context, id int) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// Subscribe は全ての種類のTodoのイベントを購読し、対応するWebhookのイベントとして通知します
+// WithWebhookDebounce を設定した場合は、まとめてから通知します
+func (s *WebhookService) Subscribe(bus *event.Bus) {
+	if s.debouncer != nil {
+		event.SubscribeTodoEvents(bus, s.debouncer.Handle)
+		return
+	}
+	event.SubscribeTodoEvents(bus, s.publishTodoEvent)
+}
+
+// publishTodoEvent はTodoのイベントを、対応するWebhookのイベントとして非同期に通知します
+func (s *WebhookService) publishTodoEvent(ctx context.Context, e event.TodoEvent) {
+	todo := e.Change().Todo()
+	for _, webhookEvent := range webhookEventsFor(e) {
+		s.Publish(ctx, webhookEvent, todo)
+	}
+}
+
+// SubscribeOutbox は OutboxRelay が発行するイベントを購読し、対応するWebhookのイベントとして通知します
+// Subscribe と異なり、リレーのワーカーの中で同期的に送信するため、イベントの順序どおりに通知されます
+// 送信に失敗した通知は再送キューに登録されます
+func (s *WebhookService) SubscribeOutbox(bus *event.Bus) {
+	event.SubscribeTodoEvents(bus, func(ctx context.Context, e event.TodoEvent) {
+		todo := e.Change().Todo()
+		for _, webhookEvent := range webhookEventsFor(e) {
+			if _, err := s.Dispatch(ctx, webhookEvent, todo); err != nil {
+				log.Printf("Failed to dispatch %s webhook for todo %d: %v", webhookEvent, todo.ID, err)
+			}
+		}
+	})
+}
+
+// Publish はイベントを別の goroutine で通知します（Dispatch の非同期版）
+// リクエストのキャンセルで通知が中断されないよう、ctx のキャンセルは引き継ぎません
+func (s *WebhookService) Publish(ctx context.Context, event entity.WebhookEvent, todo *entity.Todo) {
+	snapshot := *todo
+	ctx = context.WithoutCancel(ctx)
+
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		if _, err := s.Dispatch(ctx, event, &snapshot); err != nil {
+			log.Printf("Failed to dispatch %s webhook for todo %d: %v", event, snapshot.ID, err)
+		}
+	}()
+}
+
+// Wait は Publish で送信中の通知が終わるまで待機します（シャットダウン時に呼び出します）
+// まとめるために溜めているイベントは、待機の前に通知を始めます
+// ctx がタイムアウトした場合は待機を打ち切り、ctx のエラーを返します
+func (s *WebhookService) Wait(ctx context.Context) error {
+	if s.debouncer != nil {
+		s.debouncer.Flush()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dispatch はイベントを通知する登録を探し、それぞれのURLへ送信します
+// 通知するのは、Todoを参照できる（Todoの所有者・ワークスペースが登録したものと同じ）登録だけです
+// 戻り値は送信に成功した件数です
+// 失敗した送信は再送キューに登録し、登録にも失敗したものだけをエラーとして返します
+func (s *WebhookService) Dispatch(ctx context.Context, event entity.WebhookEvent, todo *entity.Todo) (int, error) {
+	// イベントを発生させた操作者ではなくTodoで通知先を決めるため、全ての登録から探す
+	subscriptions, err := s.webhookRepo.List(repository.WithoutOwner(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	occurredAt := s.now().UTC()
+	id := fmt.Sprintf("%s-%d-%d", event, todo.ID, occurredAt.UnixNano())
+	body, err := json.Marshal(webhookPayload{ID: id, Event: event, OccurredAt: occurredAt, Todo: todo})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	message := WebhookMessage{ID: id, Event: event, Body: body}
+
+	sent := 0
+	var errs []error
+	for _, subscription := range subscriptions {
+		if !subscription.Subscribes(event) || !subscription.Covers(todo) {
+			continue
+		}
+
+		if err := s.sender.Send(ctx, subscription.URL, message); err != nil {
+			if s.deliveryQueue == nil {
+				errs = append(errs, fmt.Errorf("failed to send webhook %d: %w", subscription.ID, err))
+				continue
+			}
+			redelivery := webhookRedelivery{SubscriptionID: subscription.ID, Message: message}
+			if err := s.deliveryQueue.Enqueue(ctx, entity.DeliveryKindWebhook, todo.ID, redelivery, err); err != nil {
+				errs = append(errs, fmt.Errorf("failed to enqueue webhook %d: %w", subscription.ID, err))
+			}
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// Redeliver は再送キューに登録された通知を再送します（DeliveryHandler として登録します）
+// 再送までの間に登録が削除された場合や、イベントの通知をやめた場合は送信せずに完了として扱います
+// 通知先は登録の現在のURLを使います
+func (s *WebhookService) Redeliver(ctx context.Context, payload []byte) error {
+	var redelivery webhookRedelivery
+	if err := json.Unmarshal(payload, &redelivery); err != nil {
+		return fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	subscription, err := s.webhookRepo.GetByID(repository.WithoutOwner(ctx), redelivery.SubscriptionID)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get webhook %d: %w", redelivery.SubscriptionID, err)
+	}
+	if !subscription.Subscribes(redelivery.Message.Event) {
+		return nil
+	}
+
+	return s.sender.Send(ctx, subscription.URL, redelivery.Message)
+}
+
+// webhookEventsFor はTodoのイベントを、通知するWebhookのイベントに対応付けます
+// 更新で未完了から完了に変わった場合は、更新に加えて完了も通知します
+func webhookEventsFor(e event.TodoEvent) []entity.WebhookEvent {
+	switch e := e.(type) {
+	case event.TodoCreated, event.TodoRestored:
+		return []entity.WebhookEvent{entity.WebhookEventTodoCreated}
+	case event.TodoUpdated:
+		if e.BecameCompleted() {
+			return []entity.WebhookEvent{entity.WebhookEventTodoUpdated, entity.WebhookEventTodoCompleted}
+		}
+		return []entity.WebhookEvent{entity.WebhookEventTodoUpdated}
+	case event.TodoCompleted:
+		return []entity.WebhookEvent{entity.WebhookEventTodoCompleted}
+	case event.TodoIncompleted:
+		return []entity.WebhookEvent{entity.WebhookEventTodoUpdated}
+	case event.TodoDeleted:
+		return []entity.WebhookEvent{entity.WebhookEventTodoDeleted}
+	default:
+		return nil
+	}
+}
+
+// joinEventNames はエラーメッセージ用にイベント名をカンマ区切りで連結します
+func joinEventNames(events []entity.WebhookEvent) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ", ")
+}
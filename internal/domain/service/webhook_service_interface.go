@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// WebhookServiceInterface はWebhookの登録を管理するサービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type WebhookServiceInterface interface {
+	// Register はWebhookを登録します
+	Register(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+
+	// List は全てのWebhookの登録を取得します
+	List(ctx context.Context) ([]*entity.WebhookSubscription, error)
+
+	// Get はIDでWebhookの登録を取得します
+	Get(ctx context.Context, id int) (*entity.WebhookSubscription, error)
+
+	// Delete はWebhookの登録を削除します
+	Delete(ctx context.Context, id int) error
+}
+
+// コンパイル時インターフェース実装確認
+var _ WebhookServiceInterface = (*WebhookService)(nil)
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// MockWebhookRepository はテスト用のWebhookRepositoryのモック実装です
+type MockWebhookRepository struct {
+	subscriptions map[int]*entity.WebhookSubscription
+	nextID        int
+}
+
+// NewMockWebhookRepository はモックリポジトリを作成します
+func NewMockWebhookRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{
+		subscriptions: make(map[int]*entity.WebhookSubscription),
+		nextID:        1,
+	}
+}
+
+// Create はWebhookを登録します（モック実装）
+func (m *MockWebhookRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	subscription.ID = m.nextID
+	m.nextID++
+	stored := *subscription
+	m.subscriptions[subscription.ID] = &stored
+	return subscription, nil
+}
+
+// GetByID はWebhookの登録を取得します（モック実装）
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id int) (*entity.WebhookSubscription, error) {
+	subscription, exists := m.subscriptions[id]
+	if !exists {
+		return nil, domainerr.NotFound("webhook", nil)
+	}
+	subscriptionCopy := *subscription
+	return &subscriptionCopy, nil
+}
+
+// List は全てのWebhookの登録を取得します（モック実装）
+func (m *MockWebhookRepository) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	result := make([]*entity.WebhookSubscription, 0, len(m.subscriptions))
+	for _, subscription := range m.subscriptions {
+		subscriptionCopy := *subscription
+		result = append(result, &subscriptionCopy)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// Delete はWebhookの登録を削除します（モック実装）
+func (m *MockWebhookRepository) Delete(ctx context.Context, id int) error {
+	if _, exists := m.subscriptions[id]; !exists {
+		return domainerr.NotFound("webhook", nil)
+	}
+	delete(m.subscriptions, id)
+	return nil
+}
+
+// sentWebhook はモックの送信手段が受け取った通知です
+type sentWebhook struct {
+	url     string
+	message WebhookMessage
+}
+
+// MockWebhookSender はテスト用のWebhookSenderのモック実装です
+// failURLs に含まれるURLへの送信は失敗します
+type MockWebhookSender struct {
+	mu       sync.Mutex
+	failURLs map[string]bool
+	sent     []sentWebhook
+}
+
+// Send は送信内容を記録します（モック実装）
+func (m *MockWebhookSender) Send(ctx context.Context, url string, message WebhookMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failURLs[url] {
+		return errors.New("connection refused")
+	}
+	m.sent = append(m.sent, sentWebhook{url: url, message: message})
+	return nil
+}
+
+// events は送信されたイベントを送信順に返します
+func (m *MockWebhookSender) events() []entity.WebhookEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	events := make([]entity.WebhookEvent, len(m.sent))
+	for i, sent := range m.sent {
+		events[i] = sent.message.Event
+	}
+	return events
+}
+
+// TestWebhookService_Register はWebhookの登録の検証をテストします
+func TestWebhookService_Register(t *testing.T) {
+	webhooks := NewWebhookService(NewMockWebhookRepository(), &MockWebhookSender{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		url         string
+		events      []entity.WebhookEvent
+		expectError bool
+	}{
+		{name: "正常な登録", url: "https://example.com/hooks", events: []entity.WebhookEvent{entity.WebhookEventTodoCreated}},
+		{name: "複数のイベント", url: "http://localhost:9000/", events: entity.WebhookEvents},
+		{name: "相対URL", url: "/hooks", events: []entity.WebhookEvent{entity.WebhookEventTodoCreated}, expectError: true},
+		{name: "http(s)以外のスキーム", url: "ftp://example.com/hooks", events: []entity.WebhookEvent{entity.WebhookEventTodoCreated}, expectError: true},
+		{name: "イベントなし", url: "https://example.com/hooks", expectError: true},
+		{name: "未知のイベント", url: "https://example.com/hooks", events: []entity.WebhookEvent{"todo.archived"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := webhooks.Register(ctx, &entity.WebhookSubscription{URL: tt.url, Events: tt.events})
+			if (err != nil) != tt.expectError {
+				t.Fatalf("エラー = %v, エラーを期待 = %v", err, tt.expectError)
+			}
+			if err != nil && !strings.Contains(err.Error(), "validation failed") {
+				t.Errorf("検証エラーのメッセージ = %v", err)
+			}
+			if err == nil && created.ID == 0 {
+				t.Error("IDが採番されていません")
+			}
+		})
+	}
+
+	if err := webhooks.Delete(ctx, 99); err == nil || !domainerr.IsNotFound(err) {
+		t.Errorf("存在しない登録の削除で not found エラーになるべきです: %v", err)
+	}
+}
+
+// TestWebhookService_Dispatch はイベントで絞り込んだ送信と、失敗した送信の再送をテストします
+func TestWebhookService_Dispatch(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	webhookRepo := NewMockWebhookRepository()
+	sender := &MockWebhookSender{failURLs: map[string]bool{"https://down.example.com/": true}}
+	deliveryRepo := NewMockFailedDeliveryRepository()
+	deliveries := NewDeliveryService(deliveryRepo, DefaultRetryPolicy())
+	deliveries.now = func() time.Time { return now }
+	webhooks := NewWebhookService(webhookRepo, sender, WithWebhookDeliveryQueue(deliveries))
+	webhooks.now = func() time.Time { return now }
+	deliveries.RegisterHandler(entity.DeliveryKindWebhook, webhooks.Redeliver)
+	ctx := context.Background()
+
+	all, _ := webhooks.Register(ctx, &entity.WebhookSubscription{URL: "https://all.example.com/", Events: entity.WebhookEvents})
+	webhooks.Register(ctx, &entity.WebhookSubscription{URL: "https://created.example.com/", Events: []entity.WebhookEvent{entity.WebhookEventTodoCreated}})
+	webhooks.Register(ctx, &entity.WebhookSubscription{URL: "https://down.example.com/", Events: []entity.WebhookEvent{entity.WebhookEventTodoCompleted}})
+
+	todo := &entity.Todo{ID: 7, Title: "請求書を送る", IsCompleted: true}
+	sent, err := webhooks.Dispatch(ctx, entity.WebhookEventTodoCompleted, todo)
+	if err != nil || sent != 1 {
+		t.Fatalf("送信結果: sent=%d, err=%v", sent, err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].url != all.URL {
+		t.Fatalf("送信先 = %+v, 期待値 = 全イベントを登録したURLのみ", sender.sent)
+	}
+
+	var payload struct {
+		ID    string       `json:"id"`
+		Event string       `json:"event"`
+		Todo  *entity.Todo `json:"todo"`
+	}
+	if err := json.Unmarshal(sender.sent[0].message.Body, &payload); err != nil {
+		t.Fatalf("本文のJSONパースに失敗: %v", err)
+	}
+	if payload.Event != "todo.completed" || payload.Todo.ID != 7 || payload.ID != sender.sent[0].message.ID {
+		t.Errorf("本文 = %+v", payload)
+	}
+
+	// 失敗した送信は再送キューに登録され、受信側の復旧後に同じ通知が再送される
+	if len(deliveryRepo.deliveries) != 1 {
+		t.Fatalf("再送キューの件数 = %d, 期待値 = 1", len(deliveryRepo.deliveries))
+	}
+	sender.failURLs = nil
+	deliveries.now = func() time.Time { return now.Add(time.Hour) }
+	if sent, err := deliveries.RetryDue(ctx); err != nil || sent != 1 {
+		t.Fatalf("再送結果: sent=%d, err=%v", sent, err)
+	}
+	if len(sender.sent) != 2 || sender.sent[1].url != "https://down.example.com/" || sender.sent[1].message.ID != payload.ID {
+		t.Errorf("再送内容 = %+v", sender.sent)
+	}
+}
+
+// TestWebhookService_DispatchToOwners は、Todoの所有者・ワークスペースが登録したWebhookにだけ通知されることをテストします
+func TestWebhookService_DispatchToOwners(t *testing.T) {
+	webhookRepo := NewMockWebhookRepository()
+	sender := &MockWebhookSender{}
+	webhooks := NewWebhookService(webhookRepo, sender)
+	ctx := context.Background()
+
+	alice, bob, workspace := 1, 2, 10
+	for _, subscription := range []*entity.WebhookSubscription{
+		{URL: "https://alice.example.com/", UserID: &alice},
+		{URL: "https://bob.example.com/", UserID: &bob},
+		{URL: "https://workspace.example.com/", UserID: &bob, WorkspaceID: &workspace},
+		{URL: "https://anonymous.example.com/"},
+	} {
+		subscription.Events = entity.WebhookEvents
+		webhookRepo.Create(ctx, subscription)
+	}
+
+	tests := []struct {
+		name string
+		todo *entity.Todo
+		want string
+	}{
+		{name: "個人のTodo", todo: &entity.Todo{ID: 1, UserID: &alice}, want: "https://alice.example.com/"},
+		{name: "ワークスペースのTodo", todo: &entity.Todo{ID: 2, UserID: &alice, WorkspaceID: &workspace}, want: "https://workspace.example.com/"},
+		{name: "所有者のないTodo", todo: &entity.Todo{ID: 3}, want: "https://anonymous.example.com/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender.sent = nil
+			if sent, err := webhooks.Dispatch(ctx, entity.WebhookEventTodoCreated, tt.todo); err != nil || sent != 1 {
+				t.Fatalf("送信結果: sent=%d, err=%v", sent, err)
+			}
+			if sender.sent[0].url != tt.want {
+				t.Errorf("送信先 = %s, 期待値 = %s", sender.sent[0].url, tt.want)
+			}
+		})
+	}
+}
+
+// TestTodoService_Webhooks はTodoの操作ごとに通知されるイベントをテストします
+func TestTodoService_Webhooks(t *testing.T) {
+	sender := &MockWebhookSender{}
+	webhooks := NewWebhookService(NewMockWebhookRepository(), sender)
+	todoService := NewTodoService(NewMockTodoRepository(), WithTodoWebhooks(webhooks))
+	ctx := context.Background()
+
+	webhooks.Register(ctx, &entity.WebhookSubscription{URL: "https://example.com/hooks", Events: entity.WebhookEvents})
+
+	todo, _ := todoService.CreateTodo(ctx, &entity.Todo{Title: "牛乳を買う"})
+	webhooks.Wait(ctx)
+	todoService.UpdateTodo(ctx, &entity.Todo{ID: todo.ID, Title: "低脂肪乳を買う"})
+	webhooks.Wait(ctx)
+	todoService.CompleteTodo(ctx, todo.ID)
+	webhooks.Wait(ctx)
+	todoService.CompleteTodo(ctx, todo.ID) // 変更のない完了は通知しない
+	webhooks.Wait(ctx)
+	todoService.IncompleteTodo(ctx, todo.ID)
+	webhooks.Wait(ctx)
+	todoService.DeleteTodo(ctx, todo.ID)
+	webhooks.Wait(ctx)
+
+	want := []entity.WebhookEvent{
+		entity.WebhookEventTodoCreated,
+		entity.WebhookEventTodoUpdated,
+		entity.WebhookEventTodoCompleted,
+		entity.WebhookEventTodoUpdated,
+		entity.WebhookEventTodoDeleted,
+	}
+	if got := sender.events(); !slices.Equal(got, want) {
+		t.Errorf("通知されたイベント = %v, 期待値 = %v", got, want)
+	}
+}
+
+// TestWebhookService_Debounce は同じTodoに続いた変更が1つの通知にまとめられることをテストします
+func TestWebhookService_Debounce(t *testing.T) {
+	sender := &MockWebhookSender{}
+	webhooks := NewWebhookService(NewMockWebhookRepository(), sender, WithWebhookDebounce(time.Hour))
+	todoService := NewTodoService(NewMockTodoRepository(), WithTodoWebhooks(webhooks))
+	ctx := context.Background()
+
+	webhooks.Register(ctx, &entity.WebhookSubscription{URL: "https://example.com/hooks", Events: entity.WebhookEvents})
+
+	kept, _ := todoService.CreateTodo(ctx, &entity.Todo{Title: "牛乳を買う"})
+	webhooks.Wait(ctx)
+	for _, title := range []string{"低脂肪乳を買う", "豆乳を買う", "オーツミルクを買う"} {
+		todoService.UpdateTodo(ctx, &entity.Todo{ID: kept.ID, Title: title})
+	}
+	todoService.CompleteTodo(ctx, kept.ID)
+	discarded, _ := todoService.CreateTodo(ctx, &entity.Todo{Title: "すぐに消すTodo"})
+	todoService.DeleteTodo(ctx, discarded.ID)
+	webhooks.Wait(ctx)
+
+	// 3回の更新と完了は、更新と完了の1組にまとまる（作成してすぐに削除したTodoは通知しない）
+	// 更新と完了は別の goroutine で送信されるため、順序は問わない
+	want := []entity.WebhookEvent{
+		entity.WebhookEventTodoCompleted,
+		entity.WebhookEventTodoCreated,
+		entity.WebhookEventTodoUpdated,
+	}
+	got := sender.events()
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("通知されたイベント = %v, 期待値 = %v", got, want)
+	}
+}
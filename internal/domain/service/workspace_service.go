@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// ErrWorkspaceForbidden はワークスペースで役割が許可していない操作を行った場合のエラーです
+// （所有者以外によるメンバーの招待・削除、認証されていないリクエスト）
+var ErrWorkspaceForbidden = errors.New("operation not permitted in workspace")
+
+// invitationTokenBytes は招待トークンの乱数のバイト数です
+const invitationTokenBytes = 32
+
+// WorkspaceService はワークスペース（チーム）とメンバーシップのビジネスロジックを管理します
+//
+// ワークスペースのメンバーでないユーザーには、ワークスペースの存在を明かさず domainerr.NotFound を返します
+// ワークスペースのTodoへの絞り込みは、EnterWorkspace が設定したコンテキストを使ってリポジトリが行います
+type WorkspaceService struct {
+	repo          repository.WorkspaceRepository
+	userRepo      repository.UserRepository
+	invitationTTL time.Duration
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewWorkspaceService はWorkspaceServiceのコンストラクタです
+// invitationTTL は作成した招待の有効期間です
+func NewWorkspaceService(repo repository.WorkspaceRepository, userRepo repository.UserRepository, invitationTTL time.Duration) *WorkspaceService {
+	return &WorkspaceService{
+		repo:          repo,
+		userRepo:      userRepo,
+		invitationTTL: invitationTTL,
+		now:           time.Now,
+	}
+}
+
+// CreateWorkspace はワークスペースを作成し、作成したユーザーを所有者として参加させます
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, name string) (*entity.Workspace, error) {
+	principal, err := s.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workspace := &entity.Workspace{Name: strings.TrimSpace(name)}
+	if !workspace.IsValid() {
+		return nil, domainerr.Invalid("name", fmt.Sprintf("must be 1-%d characters", entity.MaxWorkspaceNameLength))
+	}
+
+	created, err := s.repo.Create(ctx, workspace, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return created, nil
+}
+
+// ListWorkspaces はコンテキストのユーザーが参加しているワークスペースを取得します
+func (s *WorkspaceService) ListWorkspaces(ctx context.Context) ([]*entity.Workspace, error) {
+	principal, err := s.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.repo.ListByUser(ctx, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+// ListMembers はワークスペースのメンバーを取得します（メンバーのみ）
+func (s *WorkspaceService) ListMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error) {
+	if _, err := s.membership(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members of workspace %d: %w", workspaceID, err)
+	}
+	return members, nil
+}
+
+// RemoveMember は username のユーザーをワークスペースから外します
+// 所有者は他のメンバーを外すことができ、メンバーは自分自身を外して（ワークスペースから抜けて）もかまいません
+// 所有者自身は抜けられません
+func (s *WorkspaceService) RemoveMember(ctx context.Context, workspaceID int, username string) error {
+	caller, err := s.membership(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return domainerr.NotFound("user", username).Wrap(err)
+		}
+		return fmt.Errorf("failed to get user %q: %w", username, err)
+	}
+
+	switch {
+	case user.ID == caller.UserID && caller.Role == entity.WorkspaceRoleOwner:
+		return domainerr.Invalid("username", "the owner cannot leave the workspace")
+	case user.ID != caller.UserID && caller.Role != entity.WorkspaceRoleOwner:
+		return fmt.Errorf("%w: only the owner can remove members of workspace %d", ErrWorkspaceForbidden, workspaceID)
+	}
+
+	if err := s.repo.RemoveMember(ctx, workspaceID, user.ID); err != nil {
+		if domainerr.IsNotFound(err) {
+			return domainerr.NotFound("workspace member", username).Wrap(err)
+		}
+		return fmt.Errorf("failed to remove member from workspace %d: %w", workspaceID, err)
+	}
+	return nil
+}
+
+// CreateInvitation はワークスペースへの招待を作成し、招待トークンと招待を返します（所有者のみ）
+// 招待トークンはこの戻り値でしか得られないため、招待するユーザーに安全な方法で渡してください
+func (s *WorkspaceService) CreateInvitation(ctx context.Context, workspaceID int) (string, *entity.WorkspaceInvitation, error) {
+	caller, err := s.membership(ctx, workspaceID)
+	if err != nil {
+		return "", nil, err
+	}
+	if caller.Role != entity.WorkspaceRoleOwner {
+		return "", nil, fmt.Errorf("%w: only the owner can invite members to workspace %d", ErrWorkspaceForbidden, workspaceID)
+	}
+
+	token, err := randomToken(invitationTokenBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	now := s.now().UTC()
+	invitation, err := s.repo.CreateInvitation(ctx, &entity.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		TokenHash:   hashToken(token),
+		InvitedBy:   caller.UserID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.invitationTTL),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create invitation to workspace %d: %w", workspaceID, err)
+	}
+	return token, invitation, nil
+}
+
+// AcceptInvitation は招待トークンを使ってコンテキストのユーザーをワークスペースに参加させ、参加したワークスペースを返します
+// 招待は一度しか使えません。既にメンバーの場合も招待は使用済みになり、役割は変わりません
+func (s *WorkspaceService) AcceptInvitation(ctx context.Context, token string) (*entity.Workspace, error) {
+	principal, err := s.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, domainerr.Invalid("token", "is required")
+	}
+
+	invitation, err := s.repo.GetInvitationByHash(ctx, hashToken(token))
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get workspace invitation: %w", err)
+	}
+	if invitation.Expired(s.now()) {
+		return nil, domainerr.Invalid("token", "invitation has expired")
+	}
+
+	// 先に招待を削除することで、同じ招待で同時に参加しても1人だけが参加できる
+	if err := s.repo.DeleteInvitation(ctx, invitation.ID); err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to use workspace invitation: %w", err)
+	}
+	if err := s.repo.AddMember(ctx, &entity.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      principal.UserID,
+		Role:        entity.WorkspaceRoleMember,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to join workspace %d: %w", invitation.WorkspaceID, err)
+	}
+
+	workspace, err := s.repo.GetByID(ctx, invitation.WorkspaceID)
+	if err != nil {
+		return nil, lookupError("workspace", invitation.WorkspaceID, err)
+	}
+	return workspace, nil
+}
+
+// EnterWorkspace はコンテキストのユーザーがワークスペースのメンバーであることを確認し、
+// 以降のTodoの操作をそのワークスペースに限定するコンテキスト（repository.WithWorkspace）を返します
+// メンバーでない場合は domainerr.NotFound を返します
+func (s *WorkspaceService) EnterWorkspace(ctx context.Context, workspaceID int) (context.Context, error) {
+	if _, err := s.membership(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	return repository.WithWorkspace(ctx, workspaceID), nil
+}
+
+// principal はコンテキストの認証されたユーザーを返します（認証されていない場合は ErrWorkspaceForbidden）
+func (s *WorkspaceService) principal(ctx context.Context) (Principal, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return Principal{}, fmt.Errorf("%w: workspaces require an authenticated user", ErrWorkspaceForbidden)
+	}
+	return principal, nil
+}
+
+// membership はコンテキストのユーザーのワークスペースでのメンバーシップを返します
+// メンバーでない場合は、ワークスペースが存在しない場合と同じ domainerr.NotFound("workspace") を返します
+func (s *WorkspaceService) membership(ctx context.Context, workspaceID int) (*entity.WorkspaceMember, error) {
+	if workspaceID <= 0 {
+		return nil, domainerr.Invalid("workspace ID", "must be greater than 0")
+	}
+	principal, err := s.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.GetMember(ctx, workspaceID, principal.UserID)
+	if err != nil {
+		if domainerr.IsNotFound(err) {
+			return nil, domainerr.NotFound("workspace", workspaceID).Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to get membership of workspace %d: %w", workspaceID, err)
+	}
+	return member, nil
+}
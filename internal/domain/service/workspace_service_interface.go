@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// WorkspaceServiceInterface はワークスペースのサービスのインターフェースです
+// ハンドラー層のテストでモック実装に差し替えられるように定義しています
+type WorkspaceServiceInterface interface {
+	// CreateWorkspace はワークスペースを作成し、作成したユーザーを所有者として参加させます
+	CreateWorkspace(ctx context.Context, name string) (*entity.Workspace, error)
+
+	// ListWorkspaces は参加しているワークスペースを取得します
+	ListWorkspaces(ctx context.Context) ([]*entity.Workspace, error)
+
+	// ListMembers はワークスペースのメンバーを取得します
+	ListMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error)
+
+	// RemoveMember はユーザーをワークスペースから外します
+	RemoveMember(ctx context.Context, workspaceID int, username string) error
+
+	// CreateInvitation はワークスペースへの招待を作成し、招待トークンを返します
+	CreateInvitation(ctx context.Context, workspaceID int) (string, *entity.WorkspaceInvitation, error)
+
+	// AcceptInvitation は招待トークンを使ってワークスペースに参加します
+	AcceptInvitation(ctx context.Context, token string) (*entity.Workspace, error)
+
+	// EnterWorkspace はメンバーであることを確認し、ワークスペースに限定したコンテキストを返します
+	EnterWorkspace(ctx context.Context, workspaceID int) (context.Context, error)
+}
+
+// コンパイル時インターフェース実装確認
+var _ WorkspaceServiceInterface = (*WorkspaceService)(nil)
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// MockWorkspaceRepository はテスト用のWorkspaceRepositoryのモック実装です
+type MockWorkspaceRepository struct {
+	workspaces  map[int]*entity.Workspace
+	members     map[[2]int]*entity.WorkspaceMember
+	invitations map[int]*entity.WorkspaceInvitation
+	nextID      int
+}
+
+// NewMockWorkspaceRepository はモックリポジトリを作成します
+func NewMockWorkspaceRepository() *MockWorkspaceRepository {
+	return &MockWorkspaceRepository{
+		workspaces:  make(map[int]*entity.Workspace),
+		members:     make(map[[2]int]*entity.WorkspaceMember),
+		invitations: make(map[int]*entity.WorkspaceInvitation),
+		nextID:      1,
+	}
+}
+
+// Create はワークスペースと所有者のメンバーシップを作成します（モック実装）
+func (m *MockWorkspaceRepository) Create(ctx context.Context, workspace *entity.Workspace, ownerID int) (*entity.Workspace, error) {
+	workspace.ID = m.nextID
+	m.nextID++
+	m.workspaces[workspace.ID] = workspace
+	m.members[[2]int{workspace.ID, ownerID}] = &entity.WorkspaceMember{WorkspaceID: workspace.ID, UserID: ownerID, Role: entity.WorkspaceRoleOwner}
+	return workspace, nil
+}
+
+// GetByID はワークスペースを取得します（モック実装）
+func (m *MockWorkspaceRepository) GetByID(ctx context.Context, id int) (*entity.Workspace, error) {
+	workspace, ok := m.workspaces[id]
+	if !ok {
+		return nil, domainerr.NotFound("workspace", id)
+	}
+	return workspace, nil
+}
+
+// ListByUser は参加しているワークスペースを取得します（モック実装）
+func (m *MockWorkspaceRepository) ListByUser(ctx context.Context, userID int) ([]*entity.Workspace, error) {
+	var workspaces []*entity.Workspace
+	for id := 1; id < m.nextID; id++ {
+		if _, ok := m.members[[2]int{id, userID}]; ok {
+			workspaces = append(workspaces, m.workspaces[id])
+		}
+	}
+	return workspaces, nil
+}
+
+// AddMember はメンバーを追加します（モック実装）
+func (m *MockWorkspaceRepository) AddMember(ctx context.Context, member *entity.WorkspaceMember) error {
+	key := [2]int{member.WorkspaceID, member.UserID}
+	if _, ok := m.members[key]; !ok {
+		m.members[key] = member
+	}
+	return nil
+}
+
+// GetMember はメンバーシップを取得します（モック実装）
+func (m *MockWorkspaceRepository) GetMember(ctx context.Context, workspaceID, userID int) (*entity.WorkspaceMember, error) {
+	member, ok := m.members[[2]int{workspaceID, userID}]
+	if !ok {
+		return nil, domainerr.NotFound("workspace member", nil)
+	}
+	return member, nil
+}
+
+// ListMembers はメンバーを取得します（モック実装）
+func (m *MockWorkspaceRepository) ListMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error) {
+	var members []*entity.WorkspaceMember
+	for key, member := range m.members {
+		if key[0] == workspaceID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// RemoveMember はメンバーを削除します（モック実装）
+func (m *MockWorkspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID int) error {
+	key := [2]int{workspaceID, userID}
+	if _, ok := m.members[key]; !ok {
+		return domainerr.NotFound("workspace member", nil)
+	}
+	delete(m.members, key)
+	return nil
+}
+
+// CreateInvitation は招待を保存します（モック実装）
+func (m *MockWorkspaceRepository) CreateInvitation(ctx context.Context, invitation *entity.WorkspaceInvitation) (*entity.WorkspaceInvitation, error) {
+	invitation.ID = len(m.invitations) + 1
+	m.invitations[invitation.ID] = invitation
+	return invitation, nil
+}
+
+// GetInvitationByHash は招待を取得します（モック実装）
+func (m *MockWorkspaceRepository) GetInvitationByHash(ctx context.Context, tokenHash string) (*entity.WorkspaceInvitation, error) {
+	for _, invitation := range m.invitations {
+		if invitation.TokenHash == tokenHash {
+			return invitation, nil
+		}
+	}
+	return nil, domainerr.NotFound("workspace invitation", nil)
+}
+
+// DeleteInvitation は招待を削除します（モック実装）
+func (m *MockWorkspaceRepository) DeleteInvitation(ctx context.Context, id int) error {
+	if _, ok := m.invitations[id]; !ok {
+		return domainerr.NotFound("workspace invitation", nil)
+	}
+	delete(m.invitations, id)
+	return nil
+}
+
+// TestWorkspaceService はワークスペースの作成・招待による参加・メンバーの削除と、役割による制限をテストします
+func TestWorkspaceService(t *testing.T) {
+	users := NewMockUserRepository()
+	for _, name := range []string{"alice", "bob", "carol"} {
+		users.Create(context.Background(), &entity.User{Username: name})
+	}
+	svc := NewWorkspaceService(NewMockWorkspaceRepository(), users, time.Hour)
+	alice := WithPrincipal(context.Background(), Principal{UserID: 1, Username: "alice"})
+	bob := WithPrincipal(context.Background(), Principal{UserID: 2, Username: "bob"})
+	carol := WithPrincipal(context.Background(), Principal{UserID: 3, Username: "carol"})
+
+	workspace, err := svc.CreateWorkspace(alice, "  開発チーム  ")
+	if err != nil || workspace.Name != "開発チーム" {
+		t.Fatalf("CreateWorkspace() = %+v, err = %v", workspace, err)
+	}
+	if _, err := svc.CreateWorkspace(alice, " "); !domainerr.IsInvalid(err) {
+		t.Errorf("空の名前の CreateWorkspace() error = %v, 期待値 = invalid", err)
+	}
+	if _, err := svc.CreateWorkspace(context.Background(), "匿名"); !errors.Is(err, ErrWorkspaceForbidden) {
+		t.Errorf("認証されていない CreateWorkspace() error = %v, 期待値 = ErrWorkspaceForbidden", err)
+	}
+
+	// メンバーでないユーザーには存在を明かさない
+	if _, err := svc.EnterWorkspace(bob, workspace.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("メンバーでない EnterWorkspace() error = %v, 期待値 = not found", err)
+	}
+	if _, _, err := svc.CreateInvitation(bob, workspace.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("メンバーでない CreateInvitation() error = %v, 期待値 = not found", err)
+	}
+
+	// 招待は一度だけ使える
+	token, invitation, err := svc.CreateInvitation(alice, workspace.ID)
+	if err != nil || token == "" || invitation.TokenHash == token {
+		t.Fatalf("CreateInvitation() = %q, %+v, err = %v, 期待値 = トークンはハッシュで保存", token, invitation, err)
+	}
+	if joined, err := svc.AcceptInvitation(bob, token); err != nil || joined.ID != workspace.ID {
+		t.Fatalf("AcceptInvitation() = %+v, err = %v", joined, err)
+	}
+	if _, err := svc.AcceptInvitation(carol, token); !domainerr.IsNotFound(err) {
+		t.Errorf("使用済みの招待の AcceptInvitation() error = %v, 期待値 = not found", err)
+	}
+	ctx, err := svc.EnterWorkspace(bob, workspace.ID)
+	if err != nil {
+		t.Fatalf("メンバーの EnterWorkspace() error = %v", err)
+	}
+	if id, ok := repository.WorkspaceFromContext(ctx); !ok || id != workspace.ID {
+		t.Errorf("EnterWorkspace() のワークスペース = %d, %v, 期待値 = %d", id, ok, workspace.ID)
+	}
+	if workspaces, err := svc.ListWorkspaces(bob); err != nil || len(workspaces) != 1 {
+		t.Errorf("ListWorkspaces() = %d件, err = %v, 期待値 = 1件", len(workspaces), err)
+	}
+
+	// 期限切れの招待は使えない
+	expiredToken, _, _ := svc.CreateInvitation(alice, workspace.ID)
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := svc.AcceptInvitation(carol, expiredToken); !domainerr.IsInvalid(err) {
+		t.Errorf("期限切れの招待の AcceptInvitation() error = %v, 期待値 = invalid", err)
+	}
+	svc.now = time.Now
+
+	// メンバーの削除は所有者だけ（メンバーは自分自身なら抜けられる。所有者は抜けられない）
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		user    string
+		checkFn func(error) bool
+	}{
+		{name: "メンバーが所有者を削除", ctx: bob, user: "alice", checkFn: func(err error) bool { return errors.Is(err, ErrWorkspaceForbidden) }},
+		{name: "所有者が抜ける", ctx: alice, user: "alice", checkFn: domainerr.IsInvalid},
+		{name: "参加していないユーザー", ctx: alice, user: "carol", checkFn: domainerr.IsNotFound},
+		{name: "存在しないユーザー", ctx: alice, user: "dave", checkFn: domainerr.IsNotFound},
+		{name: "メンバーが抜ける", ctx: bob, user: "bob", checkFn: func(err error) bool { return err == nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.RemoveMember(tt.ctx, workspace.ID, tt.user); !tt.checkFn(err) {
+				t.Errorf("RemoveMember() error = %v", err)
+			}
+		})
+	}
+	if _, err := svc.ListMembers(bob, workspace.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("抜けた後の ListMembers() error = %v, 期待値 = not found", err)
+	}
+}
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// checklistRepositoryImpl は database/sql を使用した
+// ChecklistRepository インターフェースの実装です
+//
+// 全ての操作は sqlrepo.Conn を通すため、Transactor のトランザクションの中で呼び出すと
+// Todoの複製・復元と同じトランザクションで保存されます
+type checklistRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewChecklistRepository はchecklistRepositoryImplのコンストラクタです
+func NewChecklistRepository(db *sql.DB) repository.ChecklistRepository {
+	return &checklistRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create は新しいチェックリスト項目を保存します
+// 作成日時・更新日時はアプリケーション側で決定した時刻を保存し、そのまま返却します
+func (r *checklistRepositoryImpl) Create(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	query := `
+		INSERT INTO checklist_items (todo_id, text, is_done, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx, query, item.TodoID, item.Text, item.IsDone, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert checklist item: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	item.ID = int(id)
+	item.CreatedAt = now
+	item.UpdatedAt = now
+
+	return item, nil
+}
+
+// GetByID は指定されたTodoに属するチェックリスト項目を1件取得します
+// WHERE句に todo_id も含めることで、別のTodoの項目IDを指定された場合は見つからない扱いにします
+func (r *checklistRepositoryImpl) GetByID(ctx context.Context, todoID, itemID int) (*entity.ChecklistItem, error) {
+	query := `
+		SELECT id, todo_id, text, is_done, created_at, updated_at
+		FROM checklist_items
+		WHERE id = ? AND todo_id = ?
+	`
+
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, query, itemID, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checklist item: %w", err)
+	}
+
+	item, err := sqlrepo.ScanOne(rows, scanChecklistItem)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("checklist item", nil)
+		}
+		return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+	}
+
+	return item, nil
+}
+
+// ListByTodoID は指定されたTodoのチェックリスト項目を作成順（ID昇順）に取得します
+func (r *checklistRepositoryImpl) ListByTodoID(ctx context.Context, todoID int) ([]*entity.ChecklistItem, error) {
+	query := `
+		SELECT id, todo_id, text, is_done, created_at, updated_at
+		FROM checklist_items
+		WHERE todo_id = ?
+		ORDER BY id ASC
+	`
+
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, query, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checklist items: %w", err)
+	}
+	// 項目がない場合も null ではなく空配列になる（sqlrepo.ScanAll は空スライスを返す）
+	return sqlrepo.ScanAll(rows, scanChecklistItem)
+}
+
+// scanChecklistItem は1行を列名で対応付けてチェックリスト項目にスキャンします
+func scanChecklistItem(rows *sql.Rows) (*entity.ChecklistItem, error) {
+	var item entity.ChecklistItem
+	err := sqlrepo.ScanColumns(rows, "checklist_items", sqlrepo.Columns{
+		"id":         &item.ID,
+		"todo_id":    &item.TodoID,
+		"text":       &item.Text,
+		"is_done":    &item.IsDone,
+		"created_at": &item.CreatedAt,
+		"updated_at": &item.UpdatedAt,
+	}, "id", "todo_id")
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Update はチェックリスト項目のテキストと完了状態を更新します
+func (r *checklistRepositoryImpl) Update(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	query := `
+		UPDATE checklist_items
+		SET text = ?, is_done = ?, updated_at = ?
+		WHERE id = ? AND todo_id = ?
+	`
+
+	err := sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "update checklist item", domainerr.NotFound("checklist item", nil), query,
+		item.Text, item.IsDone, now, item.ID, item.TodoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, item.TodoID, item.ID)
+}
+
+// Delete は指定されたTodoに属するチェックリスト項目を削除します
+func (r *checklistRepositoryImpl) Delete(ctx context.Context, todoID, itemID int) error {
+	query := `DELETE FROM checklist_items WHERE id = ? AND todo_id = ?`
+
+	return sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "delete checklist item", domainerr.NotFound("checklist item", nil), query, itemID, todoID)
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestChecklistRepository_CRUD はチェックリスト項目の作成・取得・更新・削除をテストします
+func TestChecklistRepository_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewChecklistRepository(db)
+	ctx := context.Background()
+
+	todo, err := todoRepo.Create(ctx, &entity.Todo{Title: "買い物"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	// 作成
+	milk, err := repo.Create(ctx, &entity.ChecklistItem{TodoID: todo.ID, Text: "牛乳"})
+	if err != nil {
+		t.Fatalf("チェックリスト項目の作成に失敗: %v", err)
+	}
+	if milk.ID <= 0 {
+		t.Error("IDが正しく生成されていません")
+	}
+	if _, err := repo.Create(ctx, &entity.ChecklistItem{TodoID: todo.ID, Text: "卵"}); err != nil {
+		t.Fatalf("チェックリスト項目の作成に失敗: %v", err)
+	}
+
+	// 一覧取得（作成順）
+	items, err := repo.ListByTodoID(ctx, todo.ID)
+	if err != nil {
+		t.Fatalf("一覧取得に失敗: %v", err)
+	}
+	if len(items) != 2 || items[0].Text != "牛乳" || items[1].Text != "卵" {
+		t.Errorf("一覧の内容が正しくありません: %+v", items)
+	}
+
+	// 更新
+	milk.IsDone = true
+	updated, err := repo.Update(ctx, milk)
+	if err != nil {
+		t.Fatalf("更新に失敗: %v", err)
+	}
+	if !updated.IsDone {
+		t.Error("完了状態が更新されていません")
+	}
+
+	// Todo取得時に進捗が集計されること
+	fetched, err := todoRepo.GetByID(ctx, todo.ID)
+	if err != nil {
+		t.Fatalf("Todoの取得に失敗: %v", err)
+	}
+	if fetched.ChecklistProgress.Total != 2 || fetched.ChecklistProgress.Done != 1 {
+		t.Errorf("進捗 = %+v, 期待値 = {Total:2 Done:1}", fetched.ChecklistProgress)
+	}
+
+	// 別のTodoのIDでは取得できないこと
+	if _, err := repo.GetByID(ctx, todo.ID+1, milk.ID); err == nil {
+		t.Error("別のTodoに属する項目が取得できてしまいました")
+	}
+
+	// 削除
+	if err := repo.Delete(ctx, todo.ID, milk.ID); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+	if err := repo.Delete(ctx, todo.ID, milk.ID); err == nil {
+		t.Error("削除済みの項目の削除でエラーが期待されました")
+	}
+}
+
+// TestTodoRepository_DeleteCascadesChecklist はTodoの完全な削除でチェックリスト項目も削除されることをテストします
+func TestTodoRepository_DeleteCascadesChecklist(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewChecklistRepository(db)
+	ctx := context.Background()
+
+	todo, err := todoRepo.Create(ctx, &entity.Todo{Title: "削除対象"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	if _, err := repo.Create(ctx, &entity.ChecklistItem{TodoID: todo.ID, Text: "項目"}); err != nil {
+		t.Fatalf("チェックリスト項目の作成に失敗: %v", err)
+	}
+
+	if err := todoRepo.HardDelete(ctx, todo.ID); err != nil {
+		t.Fatalf("Todoの削除に失敗: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM checklist_items WHERE todo_id = ?", todo.ID).Scan(&count); err != nil {
+		t.Fatalf("件数取得に失敗: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("チェックリスト項目が %d 件残っています", count)
+	}
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// driver.Connector・driver.Conn・driver.Stmt のラッパー（クエリの期限・計測・フェイルオーバー）の共通部分です
+//
+// database/sql は接続が任意のインターフェース（driver.Pinger・driver.SessionResetter など）を実装しているかを
+// 型アサーションで確認するため、ラッパーは元の接続が実装するインターフェースを全て委譲する必要があります
+// 委譲はここにまとめ、各ラッパーは baseConn・baseStmt を埋め込んで関心のあるメソッドだけを上書きします
+
+// baseConnector は元のコネクターのドライバーを返す driver.Connector のラッパーの共通部分です
+// 各ラッパーは Connect だけを実装します
+type baseConnector struct {
+	base driver.Connector
+}
+
+// Driver は元のドライバーを返します（driver.Connector の実装）
+func (c baseConnector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// baseConn は全ての呼び出しを元の接続に委譲する driver.Conn のラッパーです
+type baseConn struct {
+	driver.Conn
+
+	// wrapStmt は準備したステートメントを包む関数です（nil の場合はそのまま返します）
+	wrapStmt func(stmt driver.Stmt) driver.Stmt
+
+	// check は元の接続が返したエラーを受け取り、呼び出し元に返すエラーを返す関数です（nil の場合はそのまま返します）
+	check func(err error) error
+}
+
+// checkErr は元の接続が返したエラーを check に渡します
+func (c *baseConn) checkErr(err error) error {
+	if err == nil || c.check == nil {
+		return err
+	}
+	return c.check(err)
+}
+
+// wrap は準備したステートメントを wrapStmt で包みます
+func (c *baseConn) wrap(stmt driver.Stmt) driver.Stmt {
+	if c.wrapStmt == nil {
+		return stmt
+	}
+	return c.wrapStmt(stmt)
+}
+
+// Prepare はステートメントを準備します
+func (c *baseConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.Prepare(query)
+	if err != nil {
+		return nil, c.checkErr(err)
+	}
+	return c.wrap(stmt), nil
+}
+
+// PrepareContext はステートメントを準備します（driver.ConnPrepareContext）
+func (c *baseConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	preparer, ok := c.Conn.(driver.ConnPrepareContext)
+	if !ok {
+		return c.Prepare(query)
+	}
+	stmt, err := preparer.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, c.checkErr(err)
+	}
+	return c.wrap(stmt), nil
+}
+
+// BeginTx はトランザクションを開始します（driver.ConnBeginTx）
+func (c *baseConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err := beginner.BeginTx(ctx, opts)
+		return tx, c.checkErr(err)
+	}
+	tx, err := c.Conn.Begin() //nolint:staticcheck // ConnBeginTx を実装しないドライバー向けの代替
+	return tx, c.checkErr(err)
+}
+
+// ExecContext はクエリを実行します（driver.ExecerContext）
+// 元の接続が実装していない場合は driver.ErrSkip を返し、database/sql にプリペアドステートメントで実行させます
+func (c *baseConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	result, err := execer.ExecContext(ctx, query, args)
+	return result, c.checkErr(err)
+}
+
+// QueryContext はクエリを実行します（driver.QueryerContext）
+// 元の接続が実装していない場合は driver.ErrSkip を返し、database/sql にプリペアドステートメントで実行させます
+func (c *baseConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := queryer.QueryContext(ctx, query, args)
+	return rows, c.checkErr(err)
+}
+
+// Ping は接続を確認します（driver.Pinger）
+func (c *baseConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return c.checkErr(pinger.Ping(ctx))
+	}
+	return nil
+}
+
+// ResetSession はプールから再利用する前に呼ばれます（driver.SessionResetter）
+func (c *baseConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid はプールに戻す際に呼ばれます（driver.Validator）
+func (c *baseConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue は引数の型変換を元の接続に任せます（driver.NamedValueChecker）
+func (c *baseConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// baseStmt は全ての呼び出しを元のステートメントに委譲する driver.Stmt のラッパーです
+// 引数付きのクエリはプリペアドステートメント経由で実行されることがあるため、ステートメントも包みます
+type baseStmt struct {
+	driver.Stmt
+}
+
+// ExecContext はステートメントを実行します（driver.StmtExecContext）
+func (s *baseStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Exec(values) //nolint:staticcheck // StmtExecContext を実装しないドライバー向けの代替
+}
+
+// QueryContext はステートメントを実行します（driver.StmtQueryContext）
+func (s *baseStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Query(values) //nolint:staticcheck // StmtQueryContext を実装しないドライバー向けの代替
+}
+
+// CheckNamedValue は引数の型変換を元のステートメントに任せます（driver.NamedValueChecker）
+func (s *baseStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// namedValuesToValues は名前付き引数を位置引数に変換します（名前付き引数は未対応）
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("named arguments are not supported")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
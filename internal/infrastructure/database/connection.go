@@ -3,15 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
+	"math/rand/v2"
 	"time"
 
-	// MySQL ドライバーをインポート
-	// _（ブランクインポート）で副作用のみを利用
-	// init()関数でdriver登録が実行される
-	_ "github.com/go-sql-driver/mysql"
-
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
 	"todoapp-api-golang/pkg/config"
 )
 
@@ -25,39 +23,103 @@ import (
 type DatabaseManager struct {
 	DB     *sql.DB
 	config *config.Config
+
+	// failover はフェイルオーバーを検知して接続プールを張り直すコネクターです（対応しないエンジンでは nil）
+	failover *FailoverConnector
+
+	// dialect と quirks は接続しているデータベースエンジンのSQLの書き方と挙動の違いです
+	dialect sqlrepo.Dialect
+	quirks  Quirks
+
+	// queryObserver はクエリの実行時間を受け取る関数です（nil の場合は計測しない）
+	queryObserver QueryObserver
+
+	// now と sleep は接続の再試行の時刻の取得と待機処理です（テストで待ち時間をなくすためのフィールド）
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// 起動時の接続の再試行の待ち時間（指数バックオフ）です
+const (
+	connectRetryBaseDelay = 500 * time.Millisecond
+	connectRetryMaxDelay  = 10 * time.Second
+)
+
+// DatabaseManagerOption はDatabaseManagerの任意設定を行う関数です
+type DatabaseManagerOption func(*DatabaseManager)
+
+// WithQueryObserver は接続プールの全てのクエリの実行時間を observe に渡します
+func WithQueryObserver(observe QueryObserver) DatabaseManagerOption {
+	return func(dm *DatabaseManager) {
+		dm.queryObserver = observe
+	}
 }
 
 // NewDatabaseManager はDatabaseManagerのコンストラクタです
 // 標準パッケージを使った依存性注入の実装
-func NewDatabaseManager(cfg *config.Config) *DatabaseManager {
-	return &DatabaseManager{
+func NewDatabaseManager(cfg *config.Config, opts ...DatabaseManagerOption) *DatabaseManager {
+	dm := &DatabaseManager{
 		config: cfg,
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+	for _, opt := range opts {
+		opt(dm)
+	}
+	return dm
+}
+
+// openConnector は connector の *sql.DB を作成します
+// クエリの期限（DB_QUERY_TIMEOUT）と計測が有効な場合は、コネクターをそれぞれのラッパーで包みます
+// 期限切れのクエリも計測するため、期限のラッパーを内側にします
+func (dm *DatabaseManager) openConnector(connector driver.Connector) *sql.DB {
+	if timeout := time.Duration(dm.config.Database.QueryTimeout) * time.Second; timeout > 0 {
+		connector = NewTimeoutConnector(connector, timeout)
 	}
+	if dm.queryObserver != nil {
+		connector = NewInstrumentedConnector(connector, dm.queryObserver)
+	}
+	return sql.OpenDB(connector)
 }
 
 // Connect はデータベースへの接続を確立します
 // database/sqlパッケージを使った接続処理の学習
+//
+// 接続できない場合は、DB_CONNECT_RETRY_WINDOW 秒の間、指数バックオフで再試行します
+// （docker compose などでデータベースがAPIより遅れて起動する場合でも、起動に失敗しないようにするため）
 func (dm *DatabaseManager) Connect() error {
 	// 1. データベースドライバーの確認
-	if dm.config.Database.Driver != "mysql" {
-		return fmt.Errorf("unsupported database driver: %s (only mysql supported in standard package version)", dm.config.Database.Driver)
+	// エンジンごとの接続方法は RegisterDriver で登録された Driver にまとめている
+	d, err := LookupDriver(dm.config.Database.Driver)
+	if err != nil {
+		return err
+	}
+	quirks := d.Quirks()
+	if !quirks.MultiServer && dm.config.IsSharded() {
+		return fmt.Errorf("invalid database driver: %s does not support DB_SHARDS", dm.config.Database.Driver)
 	}
 
 	// 2. データソース名（DSN）の構築
-	dsn := dm.config.GetDSN()
-	log.Printf("Connecting to database: %s@%s:%d/%s",
-		dm.config.Database.User,
-		dm.config.Database.Host,
-		dm.config.Database.Port,
-		dm.config.Database.Name)
+	log.Printf("Connecting to %s", d.Describe(dm.config))
+	connector, err := d.Connector(d.DSN(dm.config))
+	if err != nil {
+		return fmt.Errorf("failed to open database connection: %w", err)
+	}
+	// リポジトリのSQLは ? のプレースホルダーで書くため、番号付きのプレースホルダーを使うエンジンでは実行前に書き換える
+	dialect := d.Dialect()
+	connector = NewRebindConnector(connector, dialect)
 
 	// 3. データベース接続を開く
-	// sql.Open() は実際には接続せず、DB構造体を作成するだけ
+	// sql.OpenDB() は実際には接続せず、DB構造体を作成するだけ
 	// 実際の接続は最初のクエリ実行時に行われる
-	db, err := sql.Open(dm.config.Database.Driver, dsn)
-	if err != nil {
-		return fmt.Errorf("failed to open database connection: %w", err)
+	// フェイルオーバーに対応するエンジンでは、コネクターをFailoverConnectorで包み、プライマリの切り替わりを検知して接続を張り直す
+	if quirks.Failover {
+		policy := DefaultFailoverPolicy()
+		policy.MaxReconnectAttempts = dm.config.Database.ReconnectMaxAttempts
+		dm.failover = NewFailoverConnector(connector, policy)
+		connector = dm.failover
 	}
+	db := dm.openConnector(connector)
 
 	// 4. コネクションプールの設定
 	// これらの設定はパフォーマンスとリソース使用量に重要な影響を与える
@@ -74,44 +136,59 @@ func (dm *DatabaseManager) Connect() error {
 	// 長時間の接続による問題（タイムアウト等）を防ぐ
 	db.SetConnMaxLifetime(time.Duration(dm.config.Database.ConnMaxLifetime) * time.Minute)
 
-	// 5. 接続テスト（重要：実際にDBに接続を試行）
-	if err := dm.pingWithTimeout(db, 10*time.Second); err != nil {
+	// 5. 接続テスト（重要：実際にDBに接続を試行し、準備ができるまで待つ）
+	if err := dm.waitForDatabase(db); err != nil {
 		db.Close() // 接続に失敗した場合はリソースを解放
 		return fmt.Errorf("database connection test failed: %w", err)
 	}
 
 	dm.DB = db
-	log.Printf("Successfully connected to MySQL database")
+	dm.dialect = dialect
+	dm.quirks = quirks
+	log.Printf("Successfully connected to %s", d.Describe(dm.config))
 	return nil
 }
 
-// CreateTables はテーブルを作成します
-// 標準パッケージを使ったDDL（データ定義言語）の実行を学習
-func (dm *DatabaseManager) CreateTables() error {
-	// todos テーブル作成用のSQL
-	// CREATE TABLE IF NOT EXISTS で既存テーブルがある場合はエラーを回避
-	createTodosTable := `
-		CREATE TABLE IF NOT EXISTS todos (
-			id INT AUTO_INCREMENT PRIMARY KEY,
-			title VARCHAR(100) NOT NULL,
-			description TEXT,
-			is_completed BOOLEAN NOT NULL DEFAULT FALSE,
-			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-			
-			-- インデックスの作成（検索性能向上）
-			INDEX idx_is_completed (is_completed),
-			INDEX idx_created_at (created_at)
-		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
-	`
-
-	// DDLの実行
-	_, err := dm.DB.Exec(createTodosTable)
+// IsSQLite はSQLiteに接続しているかどうかを返します
+func (dm *DatabaseManager) IsSQLite() bool {
+	return dm.config.IsSQLite()
+}
+
+// Dialect は接続しているデータベースエンジンのSQLの書き方の違いを返します
+// UPSERT などエンジンごとに構文が異なるSQLを使うリポジトリに渡します
+func (dm *DatabaseManager) Dialect() sqlrepo.Dialect {
+	return dm.dialect
+}
+
+// Quirks は接続しているデータベースエンジンの挙動の違いを返します
+// リポジトリでエンジン向けのSQL（行ロックの方法等）に切り替える場合に使用します
+func (dm *DatabaseManager) Quirks() Quirks {
+	return dm.quirks
+}
+
+// Migrate は未適用のスキーマのマイグレーションを全て適用します
+// 適用済みのバージョンは schema_migrations テーブルに記録し、次回以降は新しいマイグレーションだけを適用します
+// マイグレーションはドライバー（DB_DRIVER）ごとに migrations/mysql・migrations/sqlite から読み込みます
+func (dm *DatabaseManager) Migrate(ctx context.Context) error {
+	if dm.DB == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	migrator, err := NewMigrator(dm.DB, dm.config.Database.Driver)
 	if err != nil {
-		return fmt.Errorf("failed to create todos table: %w", err)
+		return err
 	}
 
-	log.Println("Database tables created successfully")
+	// 大きなテーブルの変更は1回のクエリの期限より長くかかり得るため、期限を設けない
+	applied, err := migrator.Up(WithoutQueryTimeout(ctx))
+	for _, m := range applied {
+		log.Printf("Applied migration %s", m)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		log.Println("Database schema is up to date")
+	}
 	return nil
 }
 
@@ -143,6 +220,36 @@ func (dm *DatabaseManager) pingWithTimeout(db *sql.DB, timeout time.Duration) er
 	return db.PingContext(ctx)
 }
 
+// waitForDatabase はデータベースに接続できるまで、DB_CONNECT_RETRY_WINDOW 秒の間再試行します
+// 待ち時間は再試行のたびに2倍（上限 connectRetryMaxDelay）になり、複数のレプリカが同時に起動しても
+// 一斉に接続し直さないよう、待ち時間の後半の範囲でランダムにずらします
+func (dm *DatabaseManager) waitForDatabase(db *sql.DB) error {
+	deadline := dm.now().Add(time.Duration(dm.config.Database.ConnectRetryWindow) * time.Second)
+	delay := connectRetryBaseDelay
+
+	for attempt := 1; ; attempt++ {
+		err := dm.pingWithTimeout(db, 10*time.Second)
+		if err == nil {
+			return nil
+		}
+
+		remaining := deadline.Sub(dm.now())
+		if remaining <= 0 {
+			if attempt > 1 {
+				return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+
+		wait := min(delay/2+time.Duration(rand.Int64N(int64(delay/2)+1)), remaining)
+		log.Printf("Database is not ready (attempt %d): %v; retrying in %s", attempt, err, wait.Round(time.Millisecond))
+		if err := dm.sleep(context.Background(), wait); err != nil {
+			return err
+		}
+		delay = min(delay*2, connectRetryMaxDelay)
+	}
+}
+
 // HealthCheck はデータベースの健全性をチェックします
 // アプリケーションの監視で使用するヘルスチェック機能
 func (dm *DatabaseManager) HealthCheck() error {
@@ -165,6 +272,28 @@ func (dm *DatabaseManager) HealthCheck() error {
 	return nil
 }
 
+// FailoverStatus はフェイルオーバーの状態と統計を返します
+func (dm *DatabaseManager) FailoverStatus() FailoverStatus {
+	if dm.failover == nil {
+		return FailoverStatus{State: FailoverStateUnavailable}
+	}
+	return dm.failover.Status()
+}
+
+// HealthStatus はヘルスチェックエンドポイント向けに接続状態を返します
+// 接続できない場合もフェイルオーバーの状態は詳細として返します
+func (dm *DatabaseManager) HealthStatus(ctx context.Context) (any, error) {
+	status := dm.FailoverStatus()
+	if dm.DB == nil {
+		return status, fmt.Errorf("database connection is nil")
+	}
+	if err := dm.DB.PingContext(ctx); err != nil {
+		return status, fmt.Errorf("database ping failed: %w", err)
+	}
+	// Ping によって状態が変わることがあるため取得し直す
+	return dm.FailoverStatus(), nil
+}
+
 // GetStats は接続プールの統計情報を返します
 // パフォーマンスチューニングと監視に活用
 func (dm *DatabaseManager) GetStats() (map[string]interface{}, error) {
@@ -175,7 +304,7 @@ func (dm *DatabaseManager) GetStats() (map[string]interface{}, error) {
 	// sql.DB.Stats() で詳細な接続プール情報を取得
 	stats := dm.DB.Stats()
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"max_open_connections": stats.MaxOpenConnections,    // 設定された最大オープン接続数
 		"open_connections":     stats.OpenConnections,       // 現在のオープン接続数
 		"in_use":               stats.InUse,                 // 現在使用中の接続数
@@ -185,43 +314,8 @@ func (dm *DatabaseManager) GetStats() (map[string]interface{}, error) {
 		"max_idle_closed":      stats.MaxIdleClosed,         // アイドル上限で閉じられた接続数
 		"max_idle_time_closed": stats.MaxIdleTimeClosed,     // アイドル時間で閉じられた接続数
 		"max_lifetime_closed":  stats.MaxLifetimeClosed,     // 生存時間で閉じられた接続数
-	}, nil
-}
-
-// ExecuteMigration はマイグレーションSQLを実行します（将来の拡張用）
-// バージョン管理されたスキーマ変更の実装例
-func (dm *DatabaseManager) ExecuteMigration(migrationSQL string) error {
-	if dm.DB == nil {
-		return fmt.Errorf("database connection is nil")
 	}
-
-	// トランザクション内でマイグレーションを実行
-	// 失敗した場合は自動的にロールバック
-	tx, err := dm.DB.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-
-	// defer でのトランザクション管理
-	// パニックやエラーが発生した場合の安全な処理
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p) // パニックを再発生させる
-		} else if err != nil {
-			tx.Rollback()
-		} else {
-			err = tx.Commit()
-		}
-	}()
-
-	// マイグレーションSQL実行
-	_, err = tx.Exec(migrationSQL)
-	if err != nil {
-		return fmt.Errorf("failed to execute migration: %w", err)
-	}
-
-	return nil
+	return result, nil
 }
 
 // database/sql パッケージ使用時のベストプラクティス：
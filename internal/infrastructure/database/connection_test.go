@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/pkg/config"
+)
+
+// TestDatabaseManager_SQLite は DB_DRIVER=sqlite で接続・マイグレーション・再起動後の読み込みができることをテストします
+func TestDatabaseManager_SQLite(t *testing.T) {
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Driver:          "sqlite",
+		Name:            filepath.Join(t.TempDir(), "todoapp"),
+		MaxOpenConns:    4,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 60,
+	}}
+	ctx := context.Background()
+
+	// open は起動時と同じ手順で接続し、マイグレーションを適用します
+	open := func() *DatabaseManager {
+		t.Helper()
+		dm := NewDatabaseManager(cfg)
+		if err := dm.Connect(); err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+		if err := dm.Migrate(ctx); err != nil {
+			t.Fatalf("Migrate() error = %v", err)
+		}
+		if err := dm.HealthCheck(); err != nil {
+			t.Fatalf("HealthCheck() error = %v", err)
+		}
+		return dm
+	}
+
+	dm := open()
+	if !dm.IsSQLite() {
+		t.Errorf("IsSQLite() = false, 期待値 = true")
+	}
+	created, err := NewTodoRepository(dm.DB, WithSQLiteLocking()).Create(ctx, &entity.Todo{Title: "ローカル開発"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := dm.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// 2回目の起動では適用済みのマイグレーションを飛ばし、既存のデータをそのまま使う
+	dm = open()
+	defer dm.Close()
+	got, err := NewTodoRepository(dm.DB, WithSQLiteLocking()).GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != "ローカル開発" {
+		t.Errorf("タイトル = %q, 期待値 = %q", got.Title, "ローカル開発")
+	}
+}
+
+// TestDatabaseManager_UnsupportedDriver は未対応のドライバーで接続がエラーになることをテストします
+func TestDatabaseManager_UnsupportedDriver(t *testing.T) {
+	dm := NewDatabaseManager(&config.Config{Database: config.DatabaseConfig{Driver: "postgres", Name: "todoapp"}})
+	if err := dm.Connect(); err == nil {
+		t.Fatal("Connect() がエラーを返しませんでした")
+	}
+}
+
+// TestDatabaseManager_ConnectRetry はデータベースの準備ができるまで、再試行の期間内で接続を再試行することをテストします
+// SQLiteのファイルを置くディレクトリがない間は接続に失敗するため、遅れて起動するデータベースの代わりに使用します
+func TestDatabaseManager_ConnectRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		// window は再試行の期間（秒）です
+		window int
+		// readyAfter は何回目の待機でディレクトリを作成するかです（0 の場合は作成しない）
+		readyAfter   int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{name: "準備ができるまで再試行", window: 60, readyAfter: 3, wantAttempts: 4},
+		{name: "期間を過ぎたら諦める", window: 3, wantErr: true, wantAttempts: 4},
+		{name: "期間が0なら再試行しない", window: 0, wantErr: true, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "data")
+			dm := NewDatabaseManager(&config.Config{Database: config.DatabaseConfig{
+				Driver:             "sqlite",
+				Name:               filepath.Join(dir, "todoapp"),
+				MaxOpenConns:       1,
+				MaxIdleConns:       1,
+				ConnectRetryWindow: tt.window,
+			}})
+
+			// 待機のたびに時計を進め、readyAfter 回目の待機でデータベースを使えるようにする
+			clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			var waits []time.Duration
+			dm.now = func() time.Time { return clock }
+			dm.sleep = func(ctx context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				clock = clock.Add(time.Second)
+				if len(waits) == tt.readyAfter {
+					return os.MkdirAll(dir, 0o755)
+				}
+				return nil
+			}
+
+			err := dm.Connect()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Connect() がエラーを返しませんでした")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Connect() error = %v", err)
+				}
+				defer dm.Close()
+			}
+
+			if attempts := len(waits) + 1; attempts != tt.wantAttempts {
+				t.Errorf("接続の試行回数 = %d, 期待値 = %d", attempts, tt.wantAttempts)
+			}
+			for i, wait := range waits {
+				// 待ち時間は 500ms から2倍ずつ増え、その後半の範囲でずらす
+				base := connectRetryBaseDelay << i
+				if wait < base/2 || wait > base {
+					t.Errorf("%d回目の待ち時間 = %s, 期待値 = %s〜%s", i+1, wait, base/2, base)
+				}
+			}
+		})
+	}
+}
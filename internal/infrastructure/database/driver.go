@@ -0,0 +1,94 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"todoapp-api-golang/internal/infrastructure/database/migration"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+	"todoapp-api-golang/pkg/config"
+)
+
+// Driver はデータベースエンジン（DB_DRIVER）ごとの接続方法・スキーマ・エンジン間の差異をまとめたインターフェースです
+//
+// 新しいエンジンに対応する場合は、Driver を実装して init で RegisterDriver を呼び出し、
+// migrations/<名前>/ にマイグレーションを追加します（DatabaseManager や設定の読み込みを変更する必要はありません）
+type Driver interface {
+	// DSN は設定から接続文字列を作成します
+	DSN(cfg *config.Config) string
+
+	// Describe は接続先をログに出力するための文字列を返します（パスワードは含めない）
+	Describe(cfg *config.Config) string
+
+	// Connector は DSN に接続するコネクターを作成します
+	Connector(dsn string) (driver.Connector, error)
+
+	// Migrations はスキーマのマイグレーション（DDL）をバージョン順に返します
+	Migrations() ([]migration.Migration, error)
+
+	// Dialect はSQLの書き方の違い（プレースホルダー・現在時刻・UPSERT）を返します
+	Dialect() sqlrepo.Dialect
+
+	// Quirks はエンジン固有の挙動の違いを返します
+	Quirks() Quirks
+}
+
+// Quirks はデータベースエンジンごとの挙動の違いです
+// ゼロ値は最も制約の多いエンジン（行ロック・フェイルオーバー・複数の接続先のいずれもなし）を表します
+type Quirks struct {
+	// SelectForUpdate は SELECT ... FOR UPDATE による行ロックに対応しているかどうかです
+	// 対応していない場合、TodoRepository は WithSQLiteLocking の方法で行をロックします
+	SelectForUpdate bool
+
+	// Failover はプライマリの切り替わりを検知して接続を張り直すかどうかです
+	Failover bool
+
+	// MultiServer はシャーディング（DB_SHARDS）に対応しているかどうかです
+	// 1つのファイルに保存するエンジンでは false にします
+	MultiServer bool
+}
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]Driver)
+)
+
+// RegisterDriver は DB_DRIVER に name を指定した場合に使用する Driver を登録します
+// database/sql の sql.Register と同じく、同じ名前を2回登録した場合や d が nil の場合は panic します
+func RegisterDriver(name string, d Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if d == nil {
+		panic("database: RegisterDriver driver is nil")
+	}
+	if _, dup := drivers[name]; dup {
+		panic("database: RegisterDriver called twice for driver " + name)
+	}
+	drivers[name] = d
+}
+
+// LookupDriver は name で登録された Driver を返します
+func LookupDriver(name string) (Driver, error) {
+	driversMu.RLock()
+	d, ok := drivers[name]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver: %s (must be one of %s)", name, strings.Join(Drivers(), ", "))
+	}
+	return d, nil
+}
+
+// Drivers は登録されている Driver の名前を昇順で返します
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
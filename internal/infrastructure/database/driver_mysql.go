@@ -0,0 +1,56 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	// MySQL ドライバーをインポート
+	// フェイルオーバー検知のため、DSNの解析とコネクターの作成に直接使用する
+	"github.com/go-sql-driver/mysql"
+
+	"todoapp-api-golang/internal/infrastructure/database/migration"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+	"todoapp-api-golang/pkg/config"
+)
+
+func init() {
+	RegisterDriver("mysql", mysqlDriver{})
+}
+
+// mysqlDriver は DB_DRIVER=mysql（デフォルト）の Driver です
+type mysqlDriver struct{}
+
+// DSN は user:password@tcp(host:port)/dbname?parseTime=true の形式の接続文字列を返します
+func (mysqlDriver) DSN(cfg *config.Config) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4",
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Host,
+		cfg.Database.Port,
+		cfg.Database.Name,
+	)
+}
+
+func (mysqlDriver) Describe(cfg *config.Config) string {
+	return fmt.Sprintf("MySQL database %s@%s:%d/%s", cfg.Database.User, cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
+}
+
+func (mysqlDriver) Connector(dsn string) (driver.Connector, error) {
+	mysqlConfig, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database DSN: %w", err)
+	}
+	return mysql.NewConnector(mysqlConfig)
+}
+
+func (mysqlDriver) Migrations() ([]migration.Migration, error) {
+	return migration.Load(migrationFiles, "migrations/mysql")
+}
+
+func (mysqlDriver) Dialect() sqlrepo.Dialect {
+	return sqlrepo.MySQL
+}
+
+func (mysqlDriver) Quirks() Quirks {
+	return Quirks{SelectForUpdate: true, Failover: true, MultiServer: true}
+}
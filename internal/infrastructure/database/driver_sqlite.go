@@ -0,0 +1,48 @@
+package database
+
+import (
+	"database/sql/driver"
+
+	// SQLite ドライバーをインポート（DB_DRIVER=sqlite の場合に使用する）
+	// クエリの計測用のラッパーで包めるよう、sql.Open ではなくドライバーから直接接続する
+	"github.com/mattn/go-sqlite3"
+
+	"todoapp-api-golang/internal/infrastructure/database/migration"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+	"todoapp-api-golang/pkg/config"
+)
+
+func init() {
+	RegisterDriver("sqlite", sqliteDriver{})
+}
+
+// sqliteDriver は DB_DRIVER=sqlite の Driver です
+// 外部のサービスなしでローカル開発に使えるよう、DB_NAME に .db を付けたファイルに直接接続します
+type sqliteDriver struct{}
+
+// DSN は外部キー制約を有効にし、書き込みの競合は5秒まで待つ接続文字列を返します
+// （WALモードで読み込みは書き込みを待たない）
+func (sqliteDriver) DSN(cfg *config.Config) string {
+	return cfg.Database.Name + ".db?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL"
+}
+
+func (sqliteDriver) Describe(cfg *config.Config) string {
+	return "SQLite database " + cfg.Database.Name + ".db"
+}
+
+func (sqliteDriver) Connector(dsn string) (driver.Connector, error) {
+	return dsnConnector{driver: &sqlite3.SQLiteDriver{}, dsn: dsn}, nil
+}
+
+func (sqliteDriver) Migrations() ([]migration.Migration, error) {
+	return migration.Load(migrationFiles, "migrations/sqlite")
+}
+
+func (sqliteDriver) Dialect() sqlrepo.Dialect {
+	return sqlrepo.SQLite
+}
+
+// Quirks は SELECT ... FOR UPDATE を使えず、1つのファイルのためフェイルオーバーと複数の接続先にも対応しないことを表します
+func (sqliteDriver) Quirks() Quirks {
+	return Quirks{}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"todoapp-api-golang/pkg/config"
+)
+
+// countingDriver は SQLite のファイルに接続し、DSN を作成した回数を数える Driver です
+type countingDriver struct {
+	sqliteDriver
+	dsnCalls int
+}
+
+func (d *countingDriver) DSN(cfg *config.Config) string {
+	d.dsnCalls++
+	return d.sqliteDriver.DSN(cfg)
+}
+
+// ドライバーの登録はプロセス全体で共有されるため、-count で繰り返し実行しても1回だけ登録する
+var (
+	countingSQLite         = &countingDriver{}
+	registerCountingSQLite sync.Once
+)
+
+// TestRegisterDriver は登録したドライバーで DatabaseManager が接続・マイグレーションできることをテストします
+func TestRegisterDriver(t *testing.T) {
+	registerCountingSQLite.Do(func() { RegisterDriver("counting-sqlite", countingSQLite) })
+	d := countingSQLite
+	d.dsnCalls = 0
+
+	if names := Drivers(); strings.Join(names, ",") != "counting-sqlite,mysql,sqlite" {
+		t.Errorf("Drivers() = %v", names)
+	}
+
+	dm := NewDatabaseManager(&config.Config{Database: config.DatabaseConfig{
+		Driver:       "counting-sqlite",
+		Name:         filepath.Join(t.TempDir(), "todoapp"),
+		MaxOpenConns: 1,
+	}})
+	if err := dm.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer dm.Close()
+	if err := dm.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if d.dsnCalls != 1 {
+		t.Errorf("DSN の呼び出し回数 = %d, 期待値 = 1", d.dsnCalls)
+	}
+	if dm.Quirks().SelectForUpdate {
+		t.Error("Quirks().SelectForUpdate = true, 期待値 = false")
+	}
+	if dm.Dialect().Name != "sqlite" {
+		t.Errorf("Dialect().Name = %q, 期待値 = sqlite", dm.Dialect().Name)
+	}
+
+	// 同じ名前の2回目の登録は panic する
+	defer func() {
+		if recover() == nil {
+			t.Error("同じ名前の RegisterDriver が panic しませんでした")
+		}
+	}()
+	RegisterDriver("counting-sqlite", d)
+}
+
+// TestLookupDriver は未登録のドライバーが登録済みのドライバーの一覧とともにエラーになることをテストします
+func TestLookupDriver(t *testing.T) {
+	if _, err := LookupDriver("mysql"); err != nil {
+		t.Errorf("LookupDriver(mysql) error = %v", err)
+	}
+	_, err := LookupDriver("postgres")
+	if err == nil || !strings.Contains(err.Error(), "sqlite") {
+		t.Errorf("LookupDriver(postgres) error = %v, 期待値 = 登録済みのドライバーを含むエラー", err)
+	}
+}
+
+// TestDatabaseManager_UnsupportedTopology は複数の接続先に対応しないドライバーで
+// シャーディングを指定すると接続がエラーになることをテストします
+func TestDatabaseManager_UnsupportedTopology(t *testing.T) {
+	dm := NewDatabaseManager(&config.Config{Database: config.DatabaseConfig{
+		Driver: "sqlite",
+		Name:   filepath.Join(t.TempDir(), "todoapp"),
+		Shards: []config.ShardConfig{{Name: "shard-a", Database: filepath.Join(t.TempDir(), "shard-a")}},
+	}})
+	if err := dm.Connect(); err == nil || !strings.Contains(err.Error(), "DB_SHARDS") {
+		t.Errorf("Connect() error = %v, 期待値 = シャーディングに対応しないエラー", err)
+	}
+}
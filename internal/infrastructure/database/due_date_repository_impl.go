@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// dueDateRepositoryImpl は todos テーブルの due_date 列を使用した
+// DueDateRepository インターフェースの実装です
+type dueDateRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewDueDateRepository はdueDateRepositoryImplのコンストラクタです
+func NewDueDateRepository(db *sql.DB) repository.DueDateRepository {
+	return &dueDateRepositoryImpl{
+		db: db,
+	}
+}
+
+// ListOverdue は期限切れの未完了Todoを取得します
+// 基準時刻はアプリケーション側から渡し、DBサーバーの時計（NOW()）には依存しません
+func (r *dueDateRepositoryImpl) ListOverdue(ctx context.Context, now time.Time) ([]*entity.Todo, error) {
+	owner, ownerArgs := scopeCondition(ctx, "t.")
+	query := `
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.due_date IS NOT NULL AND t.due_date < ? AND t.is_completed = ? AND ` + visibleTodoCondition + ` AND ` + owner + `
+		ORDER BY t.due_date ASC, t.id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, append([]any{now.UTC(), false}, ownerArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overdue todos: %w", err)
+	}
+
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
+
+// ListDueBetween は期限が指定の期間 [from, to) に含まれる未完了Todoを取得します
+// 期間の境界（利用者のタイムゾーンでの日付の区切り）はサービス層で計算します
+func (r *dueDateRepositoryImpl) ListDueBetween(ctx context.Context, from, to time.Time) ([]*entity.Todo, error) {
+	owner, ownerArgs := scopeCondition(ctx, "t.")
+	query := `
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.due_date >= ? AND t.due_date < ? AND t.is_completed = ? AND ` + visibleTodoCondition + ` AND ` + owner + `
+		ORDER BY t.due_date ASC, t.id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, append([]any{from.UTC(), to.UTC(), false}, ownerArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todos due between %s and %s: %w", from.Format(time.RFC3339), to.Format(time.RFC3339), err)
+	}
+
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
+
+// ListWithDueDate は期限のあるTodoを取得します
+// includeCompleted が false の場合は未完了のTodoに絞り込みます
+func (r *dueDateRepositoryImpl) ListWithDueDate(ctx context.Context, includeCompleted bool) ([]*entity.Todo, error) {
+	owner, args := scopeCondition(ctx, "t.")
+	query := `
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.due_date IS NOT NULL AND ` + visibleTodoCondition + ` AND ` + owner
+	if !includeCompleted {
+		query += ` AND t.is_completed = ?`
+		args = append(args, false)
+	}
+	query += `
+		ORDER BY t.due_date ASC, t.id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todos with due date: %w", err)
+	}
+
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestDueDateRepository_ListOverdue は期限切れの未完了Todoのみが期限順に取得されることをテストします
+func TestDueDateRepository_ListOverdue(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewDueDateRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	create := func(title string, dueDate *time.Time, completed bool) *entity.Todo {
+		created, err := todoRepo.Create(ctx, &entity.Todo{Title: title, DueDate: dueDate})
+		if err != nil {
+			t.Fatalf("テストデータの作成に失敗: %v", err)
+		}
+		if completed {
+			created.MarkAsCompleted()
+			if _, err := todoRepo.Update(ctx, created); err != nil {
+				t.Fatalf("テストデータの更新に失敗: %v", err)
+			}
+		}
+		return created
+	}
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+
+	recent := create("昨日が期限", at(-24*time.Hour), false)
+	oldest := create("先週が期限", at(-7*24*time.Hour), false)
+	create("ちょうど今が期限", at(0), false)
+	create("明日が期限", at(24*time.Hour), false)
+	create("完了済み", at(-24*time.Hour), true)
+	create("期限なし", nil, false)
+
+	todos, err := repo.ListOverdue(ctx, now)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("件数 = %d, 期待値 = 2", len(todos))
+	}
+	if todos[0].ID != oldest.ID || todos[1].ID != recent.ID {
+		t.Errorf("期限の昇順になっていません: %v, %v", todos[0].Title, todos[1].Title)
+	}
+}
+
+// TestDueDateRepository_ListDueBetween は期間 [from, to) に期限がある未完了Todoのみが取得されることをテストします
+func TestDueDateRepository_ListDueBetween(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewDueDateRepository(db)
+	ctx := context.Background()
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	create := func(title string, dueDate time.Time) *entity.Todo {
+		created, err := todoRepo.Create(ctx, &entity.Todo{Title: title, DueDate: &dueDate})
+		if err != nil {
+			t.Fatalf("テストデータの作成に失敗: %v", err)
+		}
+		return created
+	}
+
+	evening := create("夕方が期限", from.Add(18*time.Hour))
+	midnight := create("0時ちょうどが期限", from)
+	create("前日が期限", from.Add(-time.Second))
+	create("翌日0時が期限", to)
+
+	todos, err := repo.ListDueBetween(ctx, from, to)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("件数 = %d, 期待値 = 2", len(todos))
+	}
+	if todos[0].ID != midnight.ID || todos[1].ID != evening.ID {
+		t.Errorf("期限の昇順になっていません: %v, %v", todos[0].Title, todos[1].Title)
+	}
+}
+
+// TestDueDateRepository_ListWithDueDate は期限のあるTodoが期限順に取得され、完了済みの扱いを切り替えられることをテストします
+func TestDueDateRepository_ListWithDueDate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewDueDateRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	create := func(title string, dueDate *time.Time) *entity.Todo {
+		created, err := todoRepo.Create(ctx, &entity.Todo{Title: title, DueDate: dueDate})
+		if err != nil {
+			t.Fatalf("テストデータの作成に失敗: %v", err)
+		}
+		return created
+	}
+	later := base.Add(48 * time.Hour)
+	earlier := base.Add(-24 * time.Hour)
+	upcoming := create("明後日が期限", &later)
+	overdue := create("昨日が期限", &earlier)
+	completed := create("完了済み", &base)
+	completed.MarkAsCompleted()
+	if _, err := todoRepo.Update(ctx, completed); err != nil {
+		t.Fatalf("テストデータの更新に失敗: %v", err)
+	}
+	create("期限なし", nil)
+
+	tests := []struct {
+		name             string
+		includeCompleted bool
+		expected         []int
+	}{
+		{name: "未完了のみ", includeCompleted: false, expected: []int{overdue.ID, upcoming.ID}},
+		{name: "完了済みを含む", includeCompleted: true, expected: []int{overdue.ID, completed.ID, upcoming.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todos, err := repo.ListWithDueDate(ctx, tt.includeCompleted)
+			if err != nil {
+				t.Fatalf("取得に失敗: %v", err)
+			}
+			if len(todos) != len(tt.expected) {
+				t.Fatalf("件数 = %d, 期待値 = %d", len(todos), len(tt.expected))
+			}
+			for i, todo := range todos {
+				if todo.ID != tt.expected[i] {
+					t.Errorf("%d件目 = %q, 期待値のID = %d", i, todo.Title, tt.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// failedDeliveryColumns は failed_deliveries テーブルのSELECT対象列です（scanFailedDelivery が列名で対応付けます）
+const failedDeliveryColumns = `id, kind, todo_id, payload, attempts, last_error, status, next_attempt_at, created_at, updated_at`
+
+// failedDeliveryRepositoryImpl は failed_deliveries テーブルを使用した
+// FailedDeliveryRepository インターフェースの実装です
+type failedDeliveryRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewFailedDeliveryRepository はfailedDeliveryRepositoryImplのコンストラクタです
+func NewFailedDeliveryRepository(db *sql.DB) repository.FailedDeliveryRepository {
+	return &failedDeliveryRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create は失敗した送信を保存します
+func (r *failedDeliveryRepositoryImpl) Create(ctx context.Context, delivery *entity.FailedDelivery) (*entity.FailedDelivery, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	query := `
+		INSERT INTO failed_deliveries (kind, todo_id, payload, attempts, last_error, status, next_attempt_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		string(delivery.Kind),
+		delivery.TodoID,
+		string(delivery.Payload),
+		delivery.Attempts,
+		delivery.LastError,
+		string(delivery.Status),
+		delivery.NextAttemptAt.UTC(),
+		now,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert failed delivery: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	delivery.ID = int(id)
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+	return delivery, nil
+}
+
+// GetByID はIDで失敗した送信を取得します
+func (r *failedDeliveryRepositoryImpl) GetByID(ctx context.Context, id int) (*entity.FailedDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+failedDeliveryColumns+` FROM failed_deliveries WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failed delivery: %w", err)
+	}
+
+	delivery, err := sqlrepo.ScanOne(rows, scanFailedDelivery)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.NotFound("failed delivery", nil)
+		}
+		return nil, fmt.Errorf("failed to get failed delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDue は再送予定時刻を過ぎた再送待ちを取得します
+func (r *failedDeliveryRepositoryImpl) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.FailedDelivery, error) {
+	query := `
+		SELECT ` + failedDeliveryColumns + `
+		FROM failed_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT ?
+	`
+	return r.query(ctx, query, string(entity.DeliveryStatusRetrying), now.UTC(), limit)
+}
+
+// ListByStatus は指定した状態の失敗した送信を取得します
+func (r *failedDeliveryRepositoryImpl) ListByStatus(ctx context.Context, status entity.DeliveryStatus) ([]*entity.FailedDelivery, error) {
+	query := `SELECT ` + failedDeliveryColumns + ` FROM failed_deliveries WHERE status = ? ORDER BY id ASC`
+	return r.query(ctx, query, string(status))
+}
+
+// Update は失敗回数・エラー・状態・再送予定時刻を更新します
+func (r *failedDeliveryRepositoryImpl) Update(ctx context.Context, delivery *entity.FailedDelivery) (*entity.FailedDelivery, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	query := `
+		UPDATE failed_deliveries
+		SET attempts = ?, last_error = ?, status = ?, next_attempt_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	err := sqlrepo.ExecAffecting(ctx, r.db, "update failed delivery", domainerr.NotFound("failed delivery", nil), query,
+		delivery.Attempts,
+		delivery.LastError,
+		string(delivery.Status),
+		delivery.NextAttemptAt.UTC(),
+		now,
+		delivery.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.UpdatedAt = now
+	return delivery, nil
+}
+
+// Delete は失敗した送信を削除します
+func (r *failedDeliveryRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return sqlrepo.ExecAffecting(ctx, r.db, "delete failed delivery", domainerr.NotFound("failed delivery", nil),
+		`DELETE FROM failed_deliveries WHERE id = ?`, id)
+}
+
+// query は複数行の結果を FailedDelivery のスライスに変換します
+func (r *failedDeliveryRepositoryImpl) query(ctx context.Context, query string, args ...any) ([]*entity.FailedDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failed deliveries: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanFailedDelivery)
+}
+
+// scanFailedDelivery は1行を列名で対応付けて FailedDelivery に変換します
+func scanFailedDelivery(rows *sql.Rows) (*entity.FailedDelivery, error) {
+	var delivery entity.FailedDelivery
+	var kind, status, payload string
+	if err := sqlrepo.ScanColumns(rows, "failed_deliveries", sqlrepo.Columns{
+		"id":              &delivery.ID,
+		"kind":            &kind,
+		"todo_id":         &delivery.TodoID,
+		"payload":         &payload,
+		"attempts":        &delivery.Attempts,
+		"last_error":      &delivery.LastError,
+		"status":          &status,
+		"next_attempt_at": &delivery.NextAttemptAt,
+		"created_at":      &delivery.CreatedAt,
+		"updated_at":      &delivery.UpdatedAt,
+	}, "id", "kind", "status"); err != nil {
+		return nil, err
+	}
+
+	delivery.Kind = entity.DeliveryKind(kind)
+	delivery.Status = entity.DeliveryStatus(status)
+	delivery.Payload = []byte(payload)
+	return &delivery, nil
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestFailedDeliveryRepository は再送待ちの取得・更新とデッドレターの一覧・削除をテストします
+func TestFailedDeliveryRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewFailedDeliveryRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	deliveries := []*entity.FailedDelivery{
+		{Kind: entity.DeliveryKindReminder, TodoID: 1, Payload: []byte(`{"id":1}`), Attempts: 1, LastError: "timeout", Status: entity.DeliveryStatusRetrying, NextAttemptAt: now.Add(time.Minute)},
+		{Kind: entity.DeliveryKindReminder, TodoID: 2, Payload: []byte(`{"id":2}`), Attempts: 1, LastError: "timeout", Status: entity.DeliveryStatusRetrying, NextAttemptAt: now.Add(-time.Minute)},
+		{Kind: entity.DeliveryKindReminder, TodoID: 3, Payload: []byte(`{"id":3}`), Attempts: 8, LastError: "503", Status: entity.DeliveryStatusDead, NextAttemptAt: now.Add(-time.Hour)},
+	}
+	for _, d := range deliveries {
+		if _, err := repo.Create(ctx, d); err != nil {
+			t.Fatalf("保存に失敗: %v", err)
+		}
+	}
+
+	// 予定時刻を過ぎた再送待ちのみ（デッドレターは含まない）
+	due, err := repo.ListDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("再送待ちの取得に失敗: %v", err)
+	}
+	if len(due) != 1 || due[0].TodoID != 2 || string(due[0].Payload) != `{"id":2}` {
+		t.Fatalf("再送待ち = %+v, 期待値 = TodoID 2 のみ", due)
+	}
+
+	// 失敗を記録してデッドレターに移す
+	due[0].RecordFailure("connection refused", 2, now.Add(time.Hour))
+	if _, err := repo.Update(ctx, due[0]); err != nil {
+		t.Fatalf("更新に失敗: %v", err)
+	}
+
+	dead, err := repo.ListByStatus(ctx, entity.DeliveryStatusDead)
+	if err != nil {
+		t.Fatalf("デッドレターの取得に失敗: %v", err)
+	}
+	if len(dead) != 2 || dead[0].TodoID != 2 || dead[0].Attempts != 2 || dead[0].LastError != "connection refused" {
+		t.Fatalf("デッドレター = %+v", dead)
+	}
+
+	if err := repo.Delete(ctx, dead[0].ID); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, dead[0].ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("削除後の取得で not found エラーになるべきです: %v", err)
+	}
+	if err := repo.Delete(ctx, dead[0].ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("存在しないIDの削除で not found エラーになるべきです: %v", err)
+	}
+	if _, err := repo.Update(ctx, &entity.FailedDelivery{ID: 999}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("存在しないIDの更新で not found エラーになるべきです: %v", err)
+	}
+}
@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQLのエラー番号のうち、接続先が読み取り専用（フェイルオーバーで降格した旧プライマリ）であることを示すもの
+const (
+	mysqlErrOptionPreventsStatement = 1290 // --read-only オプションのため実行できない
+	mysqlErrReadOnlyTransaction     = 1792 // 読み取り専用トランザクションでは実行できない
+	mysqlErrReadOnlyMode            = 1836 // 読み取り専用モード
+)
+
+// FailoverState はデータベース接続のフェイルオーバーの状態です
+type FailoverState string
+
+const (
+	// FailoverStateConnected は正常に接続できている状態です
+	FailoverStateConnected FailoverState = "connected"
+
+	// FailoverStateFailingOver はフェイルオーバーを検知し、接続プールを張り直している状態です
+	FailoverStateFailingOver FailoverState = "failing_over"
+
+	// FailoverStateUnavailable は再接続の上限回数に達しても接続できなかった状態です
+	// 次の接続要求で再び再接続を試みます
+	FailoverStateUnavailable FailoverState = "unavailable"
+)
+
+// FailoverPolicy は再接続の試行回数と待ち時間（指数バックオフ）です
+type FailoverPolicy struct {
+	// MaxReconnectAttempts は1回の接続要求で接続を試みる最大回数です
+	MaxReconnectAttempts int
+
+	// BaseDelay は最初の再試行までの待ち時間です（以降は2倍ずつ増えます）
+	BaseDelay time.Duration
+
+	// MaxDelay は待ち時間の上限です
+	MaxDelay time.Duration
+}
+
+// DefaultFailoverPolicy は一般的なマネージドDBのフェイルオーバー（数十秒程度）を想定した設定です
+func DefaultFailoverPolicy() FailoverPolicy {
+	return FailoverPolicy{
+		MaxReconnectAttempts: 5,
+		BaseDelay:            200 * time.Millisecond,
+		MaxDelay:             5 * time.Second,
+	}
+}
+
+// FailoverStatus はフェイルオーバーの状態と統計です（ヘルスチェックで公開します）
+type FailoverStatus struct {
+	State FailoverState `json:"state"`
+
+	// Failovers はフェイルオーバーを検知した回数です
+	Failovers int64 `json:"failovers"`
+
+	// Reconnects は新しい接続を確立した回数です
+	Reconnects int64 `json:"reconnects"`
+
+	// ReconnectFailures は接続の確立に失敗した回数です
+	ReconnectFailures int64 `json:"reconnect_failures"`
+
+	LastFailoverAt *time.Time `json:"last_failover_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// FailoverConnector はフェイルオーバーを検知して接続プールを張り直す driver.Connector です
+//
+// 学習ポイント：
+//  1. プライマリが切り替わると、既存の接続は降格した旧プライマリ（読み取り専用）につながったまま残る
+//  2. 読み取り専用エラーを検知したら「世代」を進め、古い世代の接続をプールから破棄させる
+//     （driver.Validator / driver.SessionResetter で database/sql に不要な接続を伝える）
+//  3. 新しい接続は改めてダイヤルされるため、DNSも引き直され新しいプライマリにつながる
+//     （Goのリゾルバーは名前解決の結果をキャッシュしません）
+//  4. 接続の確立は上限回数まで指数バックオフで再試行する
+//
+// リポジトリは同じ *sql.DB を使い続けられるため、張り直しを意識する必要はありません
+type FailoverConnector struct {
+	baseConnector
+	policy FailoverPolicy
+
+	// generation はフェイルオーバーを検知するたびに増える接続の世代です
+	generation atomic.Uint64
+
+	mu     sync.Mutex
+	status FailoverStatus
+
+	// sleep は再試行の待機処理です（テストで待ち時間をなくすためのフィールド）
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewFailoverConnector はFailoverConnectorのコンストラクタです
+// sql.OpenDB(connector) で *sql.DB を作成して使用します
+func NewFailoverConnector(base driver.Connector, policy FailoverPolicy) *FailoverConnector {
+	return &FailoverConnector{
+		baseConnector: baseConnector{base: base},
+		policy:        policy,
+		status:        FailoverStatus{State: FailoverStateConnected},
+		sleep:         sleepContext,
+	}
+}
+
+// Connect は新しい接続を確立します
+// 失敗した場合は上限回数まで待ち時間を増やしながら再試行します
+func (c *FailoverConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	delay := c.policy.BaseDelay
+	var lastErr error
+
+	for attempt := 1; ; attempt++ {
+		generation := c.generation.Load()
+		conn, err := c.base.Connect(ctx)
+		if err == nil {
+			c.recordReconnect()
+			return newFailoverConn(conn, c, generation), nil
+		}
+
+		lastErr = err
+		c.recordReconnectFailure(err)
+		if attempt >= c.policy.MaxReconnectAttempts || ctx.Err() != nil {
+			break
+		}
+
+		if err := c.sleep(ctx, delay); err != nil {
+			break
+		}
+		delay = min(delay*2, c.policy.MaxDelay)
+	}
+
+	c.setState(FailoverStateUnavailable, lastErr)
+	return nil, fmt.Errorf("failed to connect after %d attempts: %w", c.policy.MaxReconnectAttempts, lastErr)
+}
+
+// Status は現在の状態と統計のコピーを返します
+func (c *FailoverConnector) Status() FailoverStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// reportFailover はフェイルオーバーを検知したことを記録し、既存の接続をすべて古い世代にします
+func (c *FailoverConnector) reportFailover(generation uint64, cause error) {
+	// 同じ世代の複数の接続から同時に報告された場合は1回として数える
+	if !c.generation.CompareAndSwap(generation, generation+1) {
+		return
+	}
+
+	now := time.Now().UTC()
+	c.mu.Lock()
+	c.status.Failovers++
+	c.status.LastFailoverAt = &now
+	c.mu.Unlock()
+
+	c.setState(FailoverStateFailingOver, cause)
+}
+
+// isStale は接続がフェイルオーバー前の古い世代かどうかを判定します
+func (c *FailoverConnector) isStale(generation uint64) bool {
+	return generation != c.generation.Load()
+}
+
+// recordReconnect は接続の確立を記録し、状態を正常に戻します
+func (c *FailoverConnector) recordReconnect() {
+	c.mu.Lock()
+	c.status.Reconnects++
+	c.mu.Unlock()
+	c.setState(FailoverStateConnected, nil)
+}
+
+// recordReconnectFailure は接続の確立の失敗を記録します
+func (c *FailoverConnector) recordReconnectFailure(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status.ReconnectFailures++
+	c.status.LastError = err.Error()
+}
+
+// setState は状態を変更し、変化があった場合はログに記録します
+func (c *FailoverConnector) setState(state FailoverState, cause error) {
+	c.mu.Lock()
+	previous := c.status.State
+	c.status.State = state
+	if cause != nil {
+		c.status.LastError = cause.Error()
+	}
+	c.mu.Unlock()
+
+	if previous != state {
+		if cause != nil {
+			log.Printf("Database connection state changed: %s -> %s (%v)", previous, state, cause)
+		} else {
+			log.Printf("Database connection state changed: %s -> %s", previous, state)
+		}
+	}
+}
+
+// isFailoverError はプライマリの切り替わりを示すエラーかどうかを判定します
+func isFailoverError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	switch mysqlErr.Number {
+	case mysqlErrOptionPreventsStatement, mysqlErrReadOnlyTransaction, mysqlErrReadOnlyMode:
+		return true
+	}
+	return false
+}
+
+// sleepContext は ctx がキャンセルされるまで最大 d だけ待機します
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// failoverConn はエラーを監視し、古い世代になったらプールから破棄される driver.Conn のラッパーです
+// 元の接続・ステートメントが返したエラーは全て check で確認します
+type failoverConn struct {
+	baseConn
+	connector  *FailoverConnector
+	generation uint64
+}
+
+// newFailoverConn は conn を generation の世代の接続として包みます
+func newFailoverConn(conn driver.Conn, connector *FailoverConnector, generation uint64) *failoverConn {
+	c := &failoverConn{connector: connector, generation: generation}
+	c.baseConn = baseConn{
+		Conn:  conn,
+		check: c.check,
+		wrapStmt: func(stmt driver.Stmt) driver.Stmt {
+			return &failoverStmt{baseStmt: baseStmt{Stmt: stmt}, conn: c}
+		},
+	}
+	return c
+}
+
+// check はエラーがフェイルオーバーを示す場合に接続元へ報告します
+func (c *failoverConn) check(err error) error {
+	if err != nil && isFailoverError(err) {
+		c.connector.reportFailover(c.generation, err)
+	}
+	return err
+}
+
+// ResetSession はプールから再利用する前に呼ばれます（driver.SessionResetter）
+// 古い世代の接続は ErrBadConn を返して破棄させ、新しい接続を確立させます
+func (c *failoverConn) ResetSession(ctx context.Context) error {
+	if c.connector.isStale(c.generation) {
+		return driver.ErrBadConn
+	}
+	return c.baseConn.ResetSession(ctx)
+}
+
+// IsValid はプールに戻す際に呼ばれます（driver.Validator）
+func (c *failoverConn) IsValid() bool {
+	return !c.connector.isStale(c.generation) && c.baseConn.IsValid()
+}
+
+// failoverStmt は実行時のエラーを監視する driver.Stmt のラッパーです
+// 引数付きのクエリはプリペアドステートメント経由で実行されるため、ステートメントも監視します
+type failoverStmt struct {
+	baseStmt
+	conn *failoverConn
+}
+
+// ExecContext はステートメントを実行します（driver.StmtExecContext）
+func (s *failoverStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	result, err := s.baseStmt.ExecContext(ctx, args)
+	return result, s.conn.check(err)
+}
+
+// QueryContext はステートメントを実行します（driver.StmtQueryContext）
+func (s *failoverStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.baseStmt.QueryContext(ctx, args)
+	return rows, s.conn.check(err)
+}
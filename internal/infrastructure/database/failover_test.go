@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// fakeConnector はテスト用の driver.Connector です
+// readOnly の間に作成した接続は、書き込みで読み取り専用エラーを返します（降格した旧プライマリの再現）
+type fakeConnector struct {
+	mu           sync.Mutex
+	readOnly     bool
+	failConnects int
+	connects     int
+}
+
+func (c *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failConnects > 0 {
+		c.failConnects--
+		return nil, errors.New("dial tcp: connection refused")
+	}
+	c.connects++
+	return &fakeConn{readOnly: c.readOnly}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver { return fakeDriver{} }
+
+func (c *fakeConnector) setReadOnly(readOnly bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readOnly = readOnly
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return nil, errors.New("not supported") }
+
+type fakeConn struct {
+	readOnly bool
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.readOnly {
+		return nil, &mysql.MySQLError{Number: mysqlErrOptionPreventsStatement, Message: "The MySQL server is running with the --read-only option"}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// newTestFailoverConnector は待機しないFailoverConnectorと、待機時間の記録を返します
+func newTestFailoverConnector(base driver.Connector, policy FailoverPolicy) (*FailoverConnector, *[]time.Duration) {
+	connector := NewFailoverConnector(base, policy)
+	var delays []time.Duration
+	connector.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return connector, &delays
+}
+
+// TestFailoverConnector_RecyclesPoolOnReadOnlyError は読み取り専用エラーで接続が張り直されることをテストします
+func TestFailoverConnector_RecyclesPoolOnReadOnlyError(t *testing.T) {
+	base := &fakeConnector{readOnly: true}
+	connector, _ := newTestFailoverConnector(base, DefaultFailoverPolicy())
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	ctx := context.Background()
+
+	// 旧プライマリへの書き込みは失敗し、フェイルオーバーとして検知される
+	if _, err := db.ExecContext(ctx, "UPDATE todos SET title = 'a'"); err == nil {
+		t.Fatal("旧プライマリへの書き込みでエラーが返されませんでした")
+	}
+	status := connector.Status()
+	if status.State != FailoverStateFailingOver || status.Failovers != 1 || status.LastFailoverAt == nil {
+		t.Errorf("検知後の状態 = %+v", status)
+	}
+
+	// DNSが新しいプライマリを指すようになった後は、新しい接続で成功する
+	base.setReadOnly(false)
+	if _, err := db.ExecContext(ctx, "UPDATE todos SET title = 'a'"); err != nil {
+		t.Fatalf("張り直し後の書き込みでエラーが発生: %v", err)
+	}
+	if base.connects != 2 {
+		t.Errorf("接続回数 = %d, 期待値 = 2（古い接続が再利用されています）", base.connects)
+	}
+	status = connector.Status()
+	if status.State != FailoverStateConnected || status.Failovers != 1 || status.Reconnects != 2 {
+		t.Errorf("復旧後の状態 = %+v", status)
+	}
+}
+
+// TestFailoverConnector_CappedRetries は再接続が上限回数まで指数バックオフで行われることをテストします
+func TestFailoverConnector_CappedRetries(t *testing.T) {
+	policy := FailoverPolicy{MaxReconnectAttempts: 4, BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+
+	t.Run("上限回数に達すると unavailable になる", func(t *testing.T) {
+		connector, delays := newTestFailoverConnector(&fakeConnector{failConnects: 10}, policy)
+
+		if _, err := connector.Connect(context.Background()); err == nil {
+			t.Fatal("エラーが返されませんでした")
+		}
+		if got := fmt.Sprint(*delays); got != "[1s 2s 3s]" {
+			t.Errorf("待ち時間 = %s, 期待値 = [1s 2s 3s]", got)
+		}
+		status := connector.Status()
+		if status.State != FailoverStateUnavailable || status.ReconnectFailures != 4 || status.LastError == "" {
+			t.Errorf("状態 = %+v", status)
+		}
+	})
+
+	t.Run("上限回数内に接続できれば connected に戻る", func(t *testing.T) {
+		connector, delays := newTestFailoverConnector(&fakeConnector{failConnects: 2}, policy)
+		connector.setState(FailoverStateUnavailable, nil)
+
+		conn, err := connector.Connect(context.Background())
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		conn.Close()
+		if len(*delays) != 2 {
+			t.Errorf("再試行回数 = %d, 期待値 = 2", len(*delays))
+		}
+		if status := connector.Status(); status.State != FailoverStateConnected || status.Reconnects != 1 {
+			t.Errorf("状態 = %+v", status)
+		}
+	})
+}
+
+// TestIsFailoverError はフェイルオーバーを示すエラーの判定をテストします
+func TestIsFailoverError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "--read-only", err: &mysql.MySQLError{Number: 1290}, expected: true},
+		{name: "読み取り専用トランザクション", err: &mysql.MySQLError{Number: 1792}, expected: true},
+		{name: "読み取り専用モード", err: &mysql.MySQLError{Number: 1836}, expected: true},
+		{name: "ラップされたエラー", err: fmt.Errorf("failed to update todo: %w", &mysql.MySQLError{Number: 1290}), expected: true},
+		{name: "一意制約違反", err: &mysql.MySQLError{Number: 1062}, expected: false},
+		{name: "MySQL以外のエラー", err: errors.New("read-only"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFailoverError(tt.err); got != tt.expected {
+				t.Errorf("isFailoverError() = %v, 期待値 = %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// idempotencyColumns は idempotency_keys テーブルのSELECT対象列です（scanIdempotencyRecord が列名で対応付けます）
+const idempotencyColumns = `actor, idempotency_key, fingerprint, status_code, response_header, response_body, created_at, expires_at`
+
+// errIdempotencyRecordNotFound は記録が存在しない場合のエラーです
+var errIdempotencyRecordNotFound = domainerr.NotFound("idempotency record", nil)
+
+// idempotencyRepositoryImpl は idempotency_keys テーブルを使用した
+// IdempotencyRepository インターフェースの実装です
+//
+// (actor, idempotency_key) の一意制約により、同時に届いた同じキーのリクエストのうち1つだけが記録を作成できます
+type idempotencyRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository はidempotencyRepositoryImplのコンストラクタです
+func NewIdempotencyRepository(db *sql.DB) repository.IdempotencyRepository {
+	return &idempotencyRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create は処理中の記録を保存します
+func (r *idempotencyRepositoryImpl) Create(ctx context.Context, record *entity.IdempotencyRecord) error {
+	query := `INSERT INTO idempotency_keys (actor, idempotency_key, fingerprint, status_code, response_header, response_body, created_at, expires_at)
+		VALUES (?, ?, ?, 0, '', ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query, record.Actor, record.Key, record.Fingerprint, []byte{},
+		record.CreatedAt.UTC().Truncate(time.Second), record.ExpiresAt.UTC().Truncate(time.Second))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrIdempotencyKeyExists
+		}
+		return fmt.Errorf("failed to insert idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Get は操作者・キーで記録を取得します
+func (r *idempotencyRepositoryImpl) Get(ctx context.Context, actor, key string) (*entity.IdempotencyRecord, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+idempotencyColumns+` FROM idempotency_keys WHERE actor = ? AND idempotency_key = ?`, actor, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idempotency key: %w", err)
+	}
+
+	record, err := sqlrepo.ScanOne(rows, scanIdempotencyRecord)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errIdempotencyRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return record, nil
+}
+
+// Complete は記録にレスポンスを保存します
+// ヘッダーは JSON のオブジェクトとして1列に保存します
+func (r *idempotencyRepositoryImpl) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	header, err := json.Marshal(record.Header)
+	if err != nil {
+		return fmt.Errorf("failed to encode response header: %w", err)
+	}
+	body := record.Body
+	if body == nil {
+		body = []byte{}
+	}
+
+	return sqlrepo.ExecAffecting(ctx, r.db, "save idempotent response", errIdempotencyRecordNotFound,
+		`UPDATE idempotency_keys SET status_code = ?, response_header = ?, response_body = ? WHERE actor = ? AND idempotency_key = ?`,
+		record.StatusCode, string(header), body, record.Actor, record.Key)
+}
+
+// Delete は記録を削除します
+func (r *idempotencyRepositoryImpl) Delete(ctx context.Context, actor, key string) error {
+	return sqlrepo.ExecAffecting(ctx, r.db, "delete idempotency key", errIdempotencyRecordNotFound,
+		`DELETE FROM idempotency_keys WHERE actor = ? AND idempotency_key = ?`, actor, key)
+}
+
+// DeleteExpired は有効期限が before 以前の記録を削除します
+func (r *idempotencyRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// scanIdempotencyRecord は1行を列名で対応付けて IdempotencyRecord に変換します
+func scanIdempotencyRecord(rows *sql.Rows) (*entity.IdempotencyRecord, error) {
+	var record entity.IdempotencyRecord
+	var header string
+	if err := sqlrepo.ScanColumns(rows, "idempotency_keys", sqlrepo.Columns{
+		"actor":           &record.Actor,
+		"idempotency_key": &record.Key,
+		"fingerprint":     &record.Fingerprint,
+		"status_code":     &record.StatusCode,
+		"response_header": &header,
+		"response_body":   &record.Body,
+		"created_at":      &record.CreatedAt,
+		"expires_at":      &record.ExpiresAt,
+	}, "actor", "idempotency_key", "fingerprint", "status_code", "expires_at"); err != nil {
+		return nil, err
+	}
+
+	if header != "" {
+		if err := json.Unmarshal([]byte(header), &record.Header); err != nil {
+			return nil, fmt.Errorf("failed to decode response header: %w", err)
+		}
+	}
+	record.CreatedAt = record.CreatedAt.UTC()
+	record.ExpiresAt = record.ExpiresAt.UTC()
+	return &record, nil
+}
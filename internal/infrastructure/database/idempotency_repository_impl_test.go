@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestIdempotencyRepository は Idempotency-Key の記録の作成・レスポンスの保存・削除をテストします
+func TestIdempotencyRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	record := &entity.IdempotencyRecord{Actor: "alice", Key: "key-1", Fingerprint: "abc", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	if err := repo.Create(ctx, record); err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+	if err := repo.Create(ctx, record); !errors.Is(err, repository.ErrIdempotencyKeyExists) {
+		t.Errorf("同じキーの作成 error = %v, 期待値 = ErrIdempotencyKeyExists", err)
+	}
+	// 操作者が違えば同じキーでも作成できる
+	if err := repo.Create(ctx, &entity.IdempotencyRecord{Actor: "bob", Key: "key-1", Fingerprint: "def", CreatedAt: now, ExpiresAt: now.Add(2 * time.Hour)}); err != nil {
+		t.Fatalf("他の操作者の作成に失敗: %v", err)
+	}
+
+	got, err := repo.Get(ctx, "alice", "key-1")
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if got.Completed() || got.Fingerprint != "abc" || !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("処理中の記録 = %+v", got)
+	}
+
+	header := map[string]string{"Content-Type": "application/json", "Location": "/api/v1/todos/1"}
+	if err := repo.Complete(ctx, &entity.IdempotencyRecord{Actor: "alice", Key: "key-1", StatusCode: 201, Header: header, Body: []byte(`{"id":1}`)}); err != nil {
+		t.Fatalf("レスポンスの保存に失敗: %v", err)
+	}
+	got, err = repo.Get(ctx, "alice", "key-1")
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if got.StatusCode != 201 || string(got.Body) != `{"id":1}` || got.Header["Location"] != "/api/v1/todos/1" {
+		t.Errorf("保存したレスポンス = %+v", got)
+	}
+	if err := repo.Complete(ctx, &entity.IdempotencyRecord{Actor: "carol", Key: "key-1", StatusCode: 201}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("存在しない記録への保存で not found エラーになるべきです: %v", err)
+	}
+
+	// 有効期限を過ぎた記録だけを削除する
+	if n, err := repo.DeleteExpired(ctx, now.Add(time.Hour)); err != nil || n != 1 {
+		t.Fatalf("DeleteExpired() = %d, %v, 期待値 = 1", n, err)
+	}
+	if _, err := repo.Get(ctx, "alice", "key-1"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("期限切れの記録が残っています: %v", err)
+	}
+
+	if err := repo.Delete(ctx, "bob", "key-1"); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+	if err := repo.Delete(ctx, "bob", "key-1"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("存在しない記録の削除で not found エラーになるべきです: %v", err)
+	}
+}
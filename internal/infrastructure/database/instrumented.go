@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// QueryObserver はクエリを1回実行するたびに、実行時間と結果のエラーを受け取る関数です
+// クエリを実行するgoroutineから同期的に呼ばれるため、時間のかかる処理は行わないでください
+type QueryObserver func(d time.Duration, err error)
+
+// instrumentedConnector はクエリの実行時間を QueryObserver に渡す driver.Connector のラッパーです
+//
+// database/sql はドライバーの接続を直接呼び出すため、接続を包むことで
+// リポジトリのSQLを変更せずに全てのクエリ（トランザクション内を含む）を計測できます
+// 実行時間は結果の行を受け取るまでで、rows.Next() で行を読み進める時間は含みません
+type instrumentedConnector struct {
+	baseConnector
+	observe QueryObserver
+}
+
+// NewInstrumentedConnector はクエリの実行時間を observe に渡すコネクターを返します
+// sql.OpenDB(connector) で *sql.DB を作成して使用します
+func NewInstrumentedConnector(base driver.Connector, observe QueryObserver) driver.Connector {
+	return &instrumentedConnector{baseConnector: baseConnector{base: base}, observe: observe}
+}
+
+// Connect は新しい接続を確立し、計測用のラッパーで包みます
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{
+		baseConn: baseConn{Conn: conn, wrapStmt: func(stmt driver.Stmt) driver.Stmt {
+			return &instrumentedStmt{baseStmt: baseStmt{Stmt: stmt}, observe: c.observe}
+		}},
+		observe: c.observe,
+	}, nil
+}
+
+// dsnConnector は driver.DriverContext を実装しないドライバー（go-sqlite3 等）を driver.Connector として扱います
+// sql.Open が内部で行うのと同じく、接続のたびに DSN で driver.Open を呼び出します
+type dsnConnector struct {
+	driver driver.Driver
+	dsn    string
+}
+
+// Connect は DSN で新しい接続を開きます
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver は元のドライバーを返します
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// instrumentedConn はクエリの実行時間を計測する driver.Conn のラッパーです
+type instrumentedConn struct {
+	baseConn
+	observe QueryObserver
+}
+
+// observeQuery は start からの経過時間とエラーを記録し、エラーをそのまま返します
+// driver.ErrSkip はドライバーが別の方法（プリペアドステートメント）での実行を求める合図のため、記録しません
+func observeQuery(observe QueryObserver, start time.Time, err error) error {
+	if !errors.Is(err, driver.ErrSkip) {
+		observe(time.Since(start), err)
+	}
+	return err
+}
+
+// ExecContext はクエリを実行します（driver.ExecerContext）
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := c.baseConn.ExecContext(ctx, query, args)
+	return result, observeQuery(c.observe, start, err)
+}
+
+// QueryContext はクエリを実行します（driver.QueryerContext）
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.baseConn.QueryContext(ctx, query, args)
+	return rows, observeQuery(c.observe, start, err)
+}
+
+// instrumentedStmt は実行時間を計測する driver.Stmt のラッパーです
+type instrumentedStmt struct {
+	baseStmt
+	observe QueryObserver
+}
+
+// ExecContext はステートメントを実行します（driver.StmtExecContext）
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := s.baseStmt.ExecContext(ctx, args)
+	return result, observeQuery(s.observe, start, err)
+}
+
+// QueryContext はステートメントを実行します（driver.StmtQueryContext）
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.baseStmt.QueryContext(ctx, args)
+	return rows, observeQuery(s.observe, start, err)
+}
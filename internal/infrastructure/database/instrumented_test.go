@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/pkg/config"
+)
+
+// TestDatabaseManager_WithQueryObserver はトランザクション内を含む全てのクエリが計測されることをテストします
+func TestDatabaseManager_WithQueryObserver(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		queries int
+		errs    int
+	)
+	observe := func(d time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		queries++
+		if err != nil {
+			errs++
+		}
+	}
+
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Driver:       "sqlite",
+		Name:         filepath.Join(t.TempDir(), "todoapp"),
+		MaxOpenConns: 1,
+	}}
+	dm := NewDatabaseManager(cfg, WithQueryObserver(observe))
+	if err := dm.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer dm.Close()
+	ctx := context.Background()
+
+	// observed は計測の件数を初期化してから fn を実行し、計測されたクエリ数とエラー数を返します
+	observed := func(fn func()) (int, int) {
+		mu.Lock()
+		queries, errs = 0, 0
+		mu.Unlock()
+		fn()
+		mu.Lock()
+		defer mu.Unlock()
+		return queries, errs
+	}
+
+	gotQueries, gotErrs := observed(func() {
+		if _, err := dm.DB.ExecContext(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+			t.Fatalf("ExecContext() error = %v", err)
+		}
+		tx, err := dm.DB.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatalf("BeginTx() error = %v", err)
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO items (name) VALUES (?)", "a"); err != nil {
+			t.Fatalf("ExecContext() error = %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+		var count int
+		if err := dm.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+			t.Fatalf("QueryRowContext() error = %v", err)
+		}
+	})
+	if gotQueries != 3 || gotErrs != 0 {
+		t.Errorf("計測 = %d件（エラー %d件）, 期待値 = 3件（エラー 0件）", gotQueries, gotErrs)
+	}
+
+	// エラーになったクエリもエラーとして計測する
+	gotQueries, gotErrs = observed(func() {
+		if _, err := dm.DB.ExecContext(ctx, "INSERT INTO items (name) VALUES (NULL)"); err == nil {
+			t.Fatal("NOT NULL 制約違反がエラーになりませんでした")
+		}
+	})
+	if gotQueries != 1 || gotErrs != 1 {
+		t.Errorf("計測 = %d件（エラー %d件）, 期待値 = 1件（エラー 1件）", gotQueries, gotErrs)
+	}
+}
@@ -0,0 +1,100 @@
+// Package migration はバージョン管理されたスキーマのマイグレーションを適用・ロールバックします
+//
+// マイグレーションは番号（バージョン）の順に適用し、適用済みのバージョンを schema_migrations テーブルに記録します
+// 起動のたびに全てのDDLを実行する方法と違い、列の追加や変更も「まだ適用していないものだけ」を1度ずつ実行できます
+//
+// マイグレーションはSQLファイル（Load）で書くほか、データの変換などSQLだけでは書きにくいものは
+// Go の関数（Step）で書いて同じ一覧に含められます
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Step はマイグレーションの1方向（適用またはロールバック）の処理です
+// 渡されたトランザクションの中で実行し、schema_migrations の記録と同時にコミットされます
+//
+// MySQLのDDL（CREATE TABLE・ALTER TABLE 等）は暗黙的にコミットされるため、途中で失敗した場合に
+// それまでのDDLは取り消されません。1つのマイグレーションには、なるべく1つの変更だけを書いてください
+type Step func(ctx context.Context, tx *sql.Tx) error
+
+// Migration は1つのバージョンのマイグレーションです
+type Migration struct {
+	// Version は適用の順番を決める番号です（ファイル名の先頭の数字。例: 0002_add_priority → 2）
+	Version int64
+
+	// Name はマイグレーションの名前です（ファイル名のバージョン以降。例: add_priority）
+	Name string
+
+	// Up はマイグレーションを適用する処理です
+	Up Step
+
+	// Down はマイグレーションを取り消す処理です（nil の場合はロールバックできません）
+	Down Step
+}
+
+// String は "0002_add_priority" の形式でマイグレーションを表します
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// SQL は1つ以上のSQL文を順に実行する Step を返します
+// SQL文は行末の ; で区切ります（文字列リテラルの中の ; は考慮しないため、行末に置かないでください）
+// コメント（-- で始まる行）だけの部分は実行しません
+func SQL(script string) Step {
+	statements := splitStatements(script)
+	return func(ctx context.Context, tx *sql.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("failed to execute %q: %w", firstLine(statement), err)
+			}
+		}
+		return nil
+	}
+}
+
+// splitStatements はSQLスクリプトを行末の ; で区切ってSQL文に分割します
+// MySQLのドライバーは既定で1回の Exec に1文しか受け付けないため、1文ずつ実行します
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); hasCode(statement) {
+			statements = append(statements, strings.TrimSuffix(statement, ";"))
+		}
+		current.Reset()
+	}
+	for _, line := range strings.Split(script, "\n") {
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") && !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			flush()
+		}
+	}
+	flush()
+	return statements
+}
+
+// hasCode はSQL文にコメント以外の内容があるかどうかを返します
+func hasCode(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && line != ";" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+// firstLine はエラーメッセージ用に、SQL文のコメントを除いた最初の行を返します
+func firstLine(statement string) string {
+	for _, line := range strings.Split(statement, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+			return line
+		}
+	}
+	return statement
+}
@@ -0,0 +1,200 @@
+package migration
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// TableName は適用済みのバージョンを記録するテーブルの名前です
+const TableName = "schema_migrations"
+
+// createTableSQL は schema_migrations テーブルの定義です（MySQLとSQLiteの両方で使える型だけを使います）
+const createTableSQL = `CREATE TABLE IF NOT EXISTS ` + TableName + ` (
+	version BIGINT NOT NULL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	applied_at DATETIME NOT NULL
+)`
+
+var (
+	// ErrNothingToRollBack は適用済みのマイグレーションがない場合のエラーです
+	ErrNothingToRollBack = errors.New("no applied migration to roll back")
+
+	// ErrIrreversible は .down.sql（Down）のないマイグレーションをロールバックしようとした場合のエラーです
+	ErrIrreversible = errors.New("migration is irreversible")
+
+	// ErrUnknownVersion は記録されているバージョンのマイグレーションがこのバイナリにない場合のエラーです
+	// 新しいバージョンのバイナリで適用したマイグレーションを、古いバイナリでロールバックしようとした場合などに返します
+	ErrUnknownVersion = errors.New("applied migration is unknown to this binary")
+)
+
+// Status は1つのマイグレーションの適用状況です
+type Status struct {
+	// Version と Name はマイグレーションのバージョンと名前です
+	Version int64
+	Name    string
+
+	// Applied は適用済みかどうかです
+	Applied bool
+
+	// AppliedAt は適用した日時です（未適用の場合はゼロ値）
+	AppliedAt time.Time
+
+	// Unknown は適用済みとして記録されているが、このバイナリにないマイグレーションかどうかです
+	Unknown bool
+}
+
+// Migrator はデータベースにマイグレーションを適用・ロールバックします
+//
+// 1つのマイグレーションの処理（Up / Down）と schema_migrations への記録は同じトランザクションで実行するため、
+// 途中で失敗したマイグレーションは適用済みとして記録されません（MySQLのDDLの制約は Step を参照）
+// 複数のプロセスから同時に実行することは想定していません。デプロイでは cmd/migrate 等で1か所から実行してください
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New はMigratorのコンストラクタです
+// migrations はバージョン順に並べ替えて使用し、同じバージョンが複数ある場合はエラーを返します
+func New(db *sql.DB, migrations []Migration) (*Migrator, error) {
+	sorted := slices.Clone(migrations)
+	slices.SortFunc(sorted, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	for i, m := range sorted {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %s has no up step", m)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", m.Version, sorted[i-1].Name, m.Name)
+		}
+	}
+	return &Migrator{db: db, migrations: sorted}, nil
+}
+
+// Up は未適用のマイグレーションを全てバージョン順に適用し、適用したものを返します
+// 失敗した場合は、それまでに適用したものとエラーを返します（失敗したマイグレーション以降は適用しません）
+//
+// 適用済みのバージョンより小さい未適用のバージョン（別のブランチで追加されたもの等）も適用します
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		err := sqlrepo.InTx(ctx, m.db, "migration "+migration.String(), func(tx *sql.Tx) error {
+			if err := migration.Up(ctx, tx); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO `+TableName+` (version, name, applied_at) VALUES (?, ?, ?)`,
+				migration.Version, migration.Name, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to apply migration %s: %w", migration, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down は最後に適用した（バージョンが最大の）マイグレーションを1つ取り消し、取り消したものを返します
+func (m *Migrator) Down(ctx context.Context) (Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return Migration{}, err
+	}
+	if len(applied) == 0 {
+		return Migration{}, ErrNothingToRollBack
+	}
+
+	latest := slices.Max(slices.Collect(maps.Keys(applied)))
+	i := slices.IndexFunc(m.migrations, func(migration Migration) bool { return migration.Version == latest })
+	if i < 0 {
+		return Migration{}, fmt.Errorf("%w: version %d (%s)", ErrUnknownVersion, latest, applied[latest].name)
+	}
+	migration := m.migrations[i]
+	if migration.Down == nil {
+		return Migration{}, fmt.Errorf("%w: %s", ErrIrreversible, migration)
+	}
+
+	err = sqlrepo.InTx(ctx, m.db, "rollback of migration "+migration.String(), func(tx *sql.Tx) error {
+		if err := migration.Down(ctx, tx); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM `+TableName+` WHERE version = ?`, migration.Version)
+		return err
+	})
+	if err != nil {
+		return Migration{}, fmt.Errorf("failed to roll back migration %s: %w", migration, err)
+	}
+	return migration, nil
+}
+
+// Status は全てのマイグレーションの適用状況をバージョン順に返します
+// 記録されているがこのバイナリにないマイグレーションも Unknown として含めます
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.appliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, record := range applied {
+		statuses = append(statuses, Status{Version: version, Name: record.name, Applied: true, AppliedAt: record.appliedAt, Unknown: true})
+	}
+	slices.SortFunc(statuses, func(a, b Status) int { return cmp.Compare(a.Version, b.Version) })
+	return statuses, nil
+}
+
+// appliedRecord は schema_migrations の1行です
+type appliedRecord struct {
+	name      string
+	appliedAt time.Time
+}
+
+// applied は schema_migrations テーブルを（なければ作成して）読み込み、適用済みのバージョンを返します
+func (m *Migrator) applied(ctx context.Context) (map[int64]appliedRecord, error) {
+	if _, err := m.db.ExecContext(ctx, createTableSQL); err != nil {
+		return nil, fmt.Errorf("failed to create %s table: %w", TableName, err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, applied_at FROM `+TableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]appliedRecord{}
+	for rows.Next() {
+		var version int64
+		var record appliedRecord
+		if err := rows.Scan(&version, &record.name, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[version] = record
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	return applied, nil
+}
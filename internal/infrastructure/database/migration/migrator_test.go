@@ -0,0 +1,255 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testFiles はテスト用のマイグレーションのファイルです
+var testFiles = fstest.MapFS{
+	"migrations/0001_create_notes.up.sql": {Data: []byte(`
+-- notes テーブル
+CREATE TABLE notes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	body TEXT NOT NULL
+);
+CREATE INDEX idx_notes_body ON notes (body);
+`)},
+	"migrations/0001_create_notes.down.sql": {Data: []byte(`DROP TABLE notes;`)},
+	"migrations/0002_add_pinned.up.sql":     {Data: []byte(`ALTER TABLE notes ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT 0;`)},
+	"migrations/0002_add_pinned.down.sql":   {Data: []byte(`ALTER TABLE notes DROP COLUMN pinned;`)},
+	"migrations/README.md":                  {Data: []byte(`マイグレーション以外のファイルは無視する`)},
+}
+
+// setupMigrationDB はテスト用のインメモリSQLiteデータベースを作成します
+func setupMigrationDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("テストデータベースの作成に失敗: %v", err)
+	}
+	// インメモリのデータベースは接続ごとに別になるため、接続を1つに固定する
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestMigrator はテスト用のファイルとGoのマイグレーションを組み合わせたMigratorを作成します
+func newTestMigrator(t *testing.T, db *sql.DB, extra ...Migration) *Migrator {
+	t.Helper()
+	migrations, err := Load(testFiles, "migrations")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	migrator, err := New(db, append(migrations, extra...))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return migrator
+}
+
+// versions はマイグレーションのバージョンを列挙します
+func versions(migrations []Migration) []int64 {
+	result := make([]int64, 0, len(migrations))
+	for _, m := range migrations {
+		result = append(result, m.Version)
+	}
+	return result
+}
+
+// TestMigrator_UpDown は適用・再適用・ロールバックの流れをテストします
+func TestMigrator_UpDown(t *testing.T) {
+	db := setupMigrationDB(t)
+	ctx := context.Background()
+
+	// Goのマイグレーション（データの変換）もSQLファイルと同じ順番で適用する
+	seed := Migration{
+		Version: 3,
+		Name:    "pin_welcome_note",
+		Up: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `INSERT INTO notes (body, pinned) VALUES ('ようこそ', 1)`)
+			return err
+		},
+	}
+	migrator := newTestMigrator(t, db, seed)
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if got := versions(applied); len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Fatalf("適用したバージョン = %v, 期待値 = [1 2 3]", got)
+	}
+
+	// 2回目は何も適用しない
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("2回目の Up() = %v, %v, 期待値 = 適用なし", versions(applied), err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Applied || status.AppliedAt.IsZero() {
+			t.Errorf("Status() = %+v, 期待値 = 適用済み", status)
+		}
+	}
+
+	// Down のないマイグレーションはロールバックできない
+	if _, err := migrator.Down(ctx); !errors.Is(err, ErrIrreversible) {
+		t.Fatalf("Down() error = %v, 期待値 = ErrIrreversible", err)
+	}
+
+	// 最後のマイグレーションから1つずつ取り消す
+	migrator = newTestMigrator(t, db, Migration{Version: 3, Name: "pin_welcome_note", Up: seed.Up, Down: SQL(`DELETE FROM notes;`)})
+	for _, expected := range []int64{3, 2, 1} {
+		rolledBack, err := migrator.Down(ctx)
+		if err != nil {
+			t.Fatalf("Down() error = %v", err)
+		}
+		if rolledBack.Version != expected {
+			t.Errorf("取り消したバージョン = %d, 期待値 = %d", rolledBack.Version, expected)
+		}
+	}
+	if _, err := db.Exec(`SELECT 1 FROM notes`); err == nil {
+		t.Error("全て取り消した後も notes テーブルが残っています")
+	}
+	if _, err := migrator.Down(ctx); !errors.Is(err, ErrNothingToRollBack) {
+		t.Errorf("Down() error = %v, 期待値 = ErrNothingToRollBack", err)
+	}
+}
+
+// TestMigrator_FailedMigration は失敗したマイグレーションが記録されず、それ以降を適用しないことをテストします
+func TestMigrator_FailedMigration(t *testing.T) {
+	db := setupMigrationDB(t)
+	ctx := context.Background()
+
+	broken := Migration{Version: 2, Name: "broken", Up: SQL(`
+ALTER TABLE notes ADD COLUMN archived BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE missing ADD COLUMN x INTEGER;
+`)}
+	later := Migration{Version: 3, Name: "later", Up: SQL(`CREATE TABLE later (id INTEGER);`)}
+	files := fstest.MapFS{"migrations/0001_create_notes.up.sql": testFiles["migrations/0001_create_notes.up.sql"]}
+	migrations, err := Load(files, "migrations")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	migrator, err := New(db, append(migrations, broken, later))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err == nil || !strings.Contains(err.Error(), "0002_broken") {
+		t.Fatalf("Up() error = %v, 期待値 = 0002_broken の失敗", err)
+	}
+	if got := versions(applied); len(got) != 1 || got[0] != 1 {
+		t.Errorf("適用したバージョン = %v, 期待値 = [1]", got)
+	}
+
+	// SQLiteではDDLもロールバックされ、失敗したマイグレーションの途中の変更は残らない
+	if _, err := db.Exec(`SELECT archived FROM notes`); err == nil {
+		t.Error("失敗したマイグレーションの列が残っています")
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, status := range statuses {
+		if status.Applied != (status.Version == 1) {
+			t.Errorf("Status() = %+v, 期待値 = バージョン1だけ適用済み", status)
+		}
+	}
+}
+
+// TestMigrator_UnknownVersion は新しいバイナリで適用したマイグレーションを古いバイナリで扱う場合をテストします
+func TestMigrator_UnknownVersion(t *testing.T) {
+	db := setupMigrationDB(t)
+	ctx := context.Background()
+
+	newer := newTestMigrator(t, db, Migration{Version: 3, Name: "newer", Up: SQL(`CREATE TABLE newer (id INTEGER);`), Down: SQL(`DROP TABLE newer;`)})
+	if _, err := newer.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	older := newTestMigrator(t, db)
+	statuses, err := older.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if last := statuses[len(statuses)-1]; last.Version != 3 || !last.Unknown || last.Name != "newer" {
+		t.Errorf("Status() の最後 = %+v, 期待値 = 未知のバージョン3", last)
+	}
+	if _, err := older.Down(ctx); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Down() error = %v, 期待値 = ErrUnknownVersion", err)
+	}
+}
+
+// TestLoad はマイグレーションのファイルの読み込みと検証をテストします
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		wantErr string
+	}{
+		{name: "正常", files: testFiles},
+		{name: "up のないマイグレーション", files: fstest.MapFS{
+			"migrations/0001_create_notes.down.sql": {Data: []byte(`DROP TABLE notes;`)},
+		}, wantErr: "no .up.sql file"},
+		{name: "バージョンの重複", files: fstest.MapFS{
+			"migrations/0001_create_notes.up.sql": {Data: []byte(`CREATE TABLE notes (id INTEGER);`)},
+			"migrations/0001_create_tags.up.sql":  {Data: []byte(`CREATE TABLE tags (id INTEGER);`)},
+		}, wantErr: "duplicate migration version 1"},
+		{name: "バージョン0", files: fstest.MapFS{
+			"migrations/0000_empty.up.sql": {Data: []byte(`SELECT 1;`)},
+		}, wantErr: "invalid migration version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files, "migrations")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, 期待値 = %q を含むエラー", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := versions(migrations); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+				t.Errorf("バージョン = %v, 期待値 = [1 2]", got)
+			}
+			if migrations[0].String() != "0001_create_notes" || migrations[1].Down == nil {
+				t.Errorf("マイグレーション = %v, 期待値 = 0001_create_notes と Down 付きの 0002", migrations)
+			}
+		})
+	}
+}
+
+// TestSplitStatements はSQLスクリプトのSQL文への分割をテストします
+func TestSplitStatements(t *testing.T) {
+	script := `-- コメントだけの行;
+CREATE TABLE a (
+	-- 列のコメント
+	id INTEGER
+);
+
+INSERT INTO a (id) VALUES (1);
+-- 末尾のコメント
+`
+	got := splitStatements(script)
+	if len(got) != 2 {
+		t.Fatalf("SQL文の数 = %d, 期待値 = 2: %q", len(got), got)
+	}
+	if !strings.HasPrefix(firstLine(got[0]), "CREATE TABLE a") || strings.HasSuffix(got[1], ";") {
+		t.Errorf("SQL文 = %q", got)
+	}
+}
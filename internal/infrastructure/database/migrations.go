@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"todoapp-api-golang/internal/infrastructure/database/migration"
+)
+
+// migrationFiles はスキーマのマイグレーション（migrations/<ドライバー>/<バージョン>_<名前>.up.sql / .down.sql）です
+// バイナリに埋め込むため、実行環境にファイルを配置する必要はありません
+//
+// テーブルや列を変更する場合は、新しいバージョンのファイルを全てのドライバー（mysql と sqlite）のディレクトリに追加します
+// 適用済みのファイルは書き換えないでください（既存のデータベースには再度適用されません）
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// Migrations は RegisterDriver で登録されたドライバー（mysql や sqlite）のマイグレーションをバージョン順に返します
+func Migrations(driver string) ([]migration.Migration, error) {
+	d, err := LookupDriver(driver)
+	if err != nil {
+		return nil, err
+	}
+	return d.Migrations()
+}
+
+// NewMigrator はドライバーのマイグレーションを db に適用するMigratorを返します
+func NewMigrator(db *sql.DB, driver string) (*migration.Migrator, error) {
+	migrations, err := Migrations(driver)
+	if err != nil {
+		return nil, err
+	}
+	return migration.New(db, migrations)
+}
+
+// CreateSQLiteTables はSQLiteのデータベースに全てのマイグレーションを適用します
+// リポジトリやAPI全体のテストで、MySQLを用意せずに実際のSQLを実行するために使用します
+func CreateSQLiteTables(db *sql.DB) error {
+	migrator, err := NewMigrator(db, "sqlite")
+	if err != nil {
+		return err
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		return fmt.Errorf("failed to migrate sqlite database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+-- 初期スキーマの全てのテーブルを削除します（外部キーの参照元から順に削除する）
+
+DROP TABLE IF EXISTS user_preferences;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
+DROP TABLE IF EXISTS todo_shares;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS failed_deliveries;
+DROP TABLE IF EXISTS todo_history;
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS checklist_items;
+DROP TABLE IF EXISTS todos;
@@ -0,0 +1,237 @@
+-- 初期スキーマ（MySQL）
+-- マイグレーションを導入する前に CreateTables で作成していたテーブルです
+-- 導入前のデータベースにもそのまま適用できるよう、既存のテーブルは作成しません（IF NOT EXISTS）
+
+-- todos テーブル
+CREATE TABLE IF NOT EXISTS todos (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	title VARCHAR(100) NOT NULL,
+	description TEXT,
+	is_completed BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	remind_at DATETIME NULL,
+	due_date DATETIME NULL,
+	recurrence VARCHAR(16) NOT NULL DEFAULT '',
+	recurrence_parent_id INT NULL,
+	color VARCHAR(7) NOT NULL DEFAULT '',
+	estimate_minutes INT NOT NULL DEFAULT 0,
+	actual_minutes INT NOT NULL DEFAULT 0,
+	-- タグはカンマ区切りで保存（最大20個 × 30文字）
+	tags VARCHAR(650) NOT NULL DEFAULT '',
+	-- 所属するプロジェクト（アーカイブ済みのプロジェクトのTodoを一覧から除外する際に参照）
+	project_id INT NULL,
+	-- 所有するユーザー（認証が無効な状態で作成されたTodoは NULL）
+	user_id INT NULL,
+	-- 所属するワークスペース（個人のTodoは NULL）
+	workspace_id INT NULL,
+
+	-- インデックスの作成（検索性能向上）
+	INDEX idx_is_completed (is_completed),
+	INDEX idx_created_at (created_at),
+	INDEX idx_remind_at (remind_at),
+	INDEX idx_due_date (due_date),
+	INDEX idx_color (color),
+	INDEX idx_project_id (project_id),
+	INDEX idx_user_id (user_id),
+	INDEX idx_workspace_id (workspace_id),
+	-- 同じシリーズの同じ期限のオカレンスが二重に作成されるのを防ぐ
+	UNIQUE INDEX uq_todos_recurrence_occurrence (recurrence_parent_id, due_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- checklist_items テーブル
+-- Todoが削除された場合は ON DELETE CASCADE で項目も削除される
+CREATE TABLE IF NOT EXISTS checklist_items (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	todo_id INT NOT NULL,
+	text VARCHAR(200) NOT NULL,
+	is_done BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+	INDEX idx_checklist_items_todo_id (todo_id),
+	CONSTRAINT fk_checklist_items_todo FOREIGN KEY (todo_id) REFERENCES todos(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- projects テーブル
+-- slug には一意制約を設定し、アプリケーション側の重複チェックをすり抜けた競合も防ぐ
+CREATE TABLE IF NOT EXISTS projects (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	slug VARCHAR(60) NOT NULL,
+	description TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	archived_at DATETIME NULL,
+
+	UNIQUE INDEX uq_projects_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- todo_history テーブル
+-- 削除されたTodoの履歴も残すため、todos への外部キーは設定しない
+CREATE TABLE IF NOT EXISTS todo_history (
+	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	todo_id INT NOT NULL,
+	action VARCHAR(20) NOT NULL,
+	actor VARCHAR(255) NOT NULL,
+	before_snapshot JSON NULL,
+	after_snapshot JSON NULL,
+	changed_at DATETIME NOT NULL,
+
+	INDEX idx_todo_history_todo_id (todo_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- failed_deliveries テーブル
+-- 再送待ち（status = 'retrying'）のスキャンと、デッドレター（status = 'dead'）の一覧に使うインデックスを持つ
+CREATE TABLE IF NOT EXISTS failed_deliveries (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	kind VARCHAR(50) NOT NULL,
+	todo_id INT NOT NULL,
+	payload JSON NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	next_attempt_at DATETIME NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+
+	INDEX idx_failed_deliveries_due (status, next_attempt_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- webhooks テーブル
+-- 通知するイベントはカンマ区切りで保存する（例: "todo.created,todo.completed"）
+CREATE TABLE IF NOT EXISTS webhooks (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	url VARCHAR(2048) NOT NULL,
+	events VARCHAR(255) NOT NULL,
+	created_at DATETIME NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- outbox テーブル
+-- Todoの変更と同じトランザクションで保存した発行待ちのイベント（発行後に削除するため、行数は少ないまま）
+CREATE TABLE IF NOT EXISTS outbox (
+	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	event_name VARCHAR(100) NOT NULL,
+	payload JSON NOT NULL,
+	created_at DATETIME NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- idempotency_keys テーブル
+-- Idempotency-Key を付けたリクエストの処理中の印と、再送時に返すレスポンス（有効期限を過ぎると削除する）
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	actor VARCHAR(255) NOT NULL,
+	idempotency_key VARCHAR(255) NOT NULL,
+	fingerprint CHAR(64) NOT NULL,
+	status_code INT NOT NULL DEFAULT 0,
+	response_header TEXT NOT NULL,
+	response_body MEDIUMBLOB NOT NULL,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+
+	PRIMARY KEY (actor, idempotency_key),
+	INDEX idx_idempotency_keys_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+-- users テーブル
+-- ユーザー名は一意（登録時の重複は一意制約で検出する）、パスワードはハッシュのみを保存する
+CREATE TABLE IF NOT EXISTS users (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	username VARCHAR(64) NOT NULL,
+	password_hash VARCHAR(255) NOT NULL,
+	created_at DATETIME NOT NULL,
+
+	UNIQUE KEY uq_users_username (username)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+-- refresh_tokens テーブル
+-- トークンそのものではなく SHA-256 のハッシュを保存し、family_id でローテーションの系列をまとめる
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	user_id INT NOT NULL,
+	family_id VARCHAR(32) NOT NULL,
+	token_hash CHAR(64) NOT NULL,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+	used_at DATETIME NULL,
+	revoked_at DATETIME NULL,
+
+	UNIQUE KEY uq_refresh_tokens_token_hash (token_hash),
+	INDEX idx_refresh_tokens_family_id (family_id),
+	INDEX idx_refresh_tokens_expires_at (expires_at),
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+-- todo_shares テーブル
+-- Todoとユーザーの組み合わせごとに1件（主キー）。Todoまたはユーザーが削除された場合は共有も削除される
+CREATE TABLE IF NOT EXISTS todo_shares (
+	todo_id INT NOT NULL,
+	user_id INT NOT NULL,
+	permission VARCHAR(8) NOT NULL,
+	created_at DATETIME NOT NULL,
+
+	PRIMARY KEY (todo_id, user_id),
+	INDEX idx_todo_shares_user_id (user_id),
+	FOREIGN KEY (todo_id) REFERENCES todos(id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- workspaces テーブル
+CREATE TABLE IF NOT EXISTS workspaces (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	created_at DATETIME NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- workspace_members テーブル
+-- ワークスペースとユーザーの組み合わせごとに1件（主キー）。どちらかが削除された場合はメンバーシップも削除される
+CREATE TABLE IF NOT EXISTS workspace_members (
+	workspace_id INT NOT NULL,
+	user_id INT NOT NULL,
+	role VARCHAR(16) NOT NULL,
+	joined_at DATETIME NOT NULL,
+
+	PRIMARY KEY (workspace_id, user_id),
+	INDEX idx_workspace_members_user_id (user_id),
+	FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- workspace_invitations テーブル
+-- リフレッシュトークンと同様に、招待トークンそのものではなく SHA-256 のハッシュを保存する
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	workspace_id INT NOT NULL,
+	token_hash CHAR(64) NOT NULL,
+	invited_by INT NOT NULL,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+
+	UNIQUE KEY uq_workspace_invitations_token_hash (token_hash),
+	FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE,
+	FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+-- sessions テーブル
+-- Cookieのセッションも、セッションIDそのものではなく SHA-256 のハッシュを主キーとして保存する
+CREATE TABLE IF NOT EXISTS sessions (
+	token_hash CHAR(64) NOT NULL PRIMARY KEY,
+	user_id INT NOT NULL,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+
+	INDEX idx_sessions_user_id (user_id),
+	INDEX idx_sessions_expires_at (expires_at),
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+-- user_preferences テーブル
+-- ユーザーごとに1件（主キー）。保存していないユーザーは既定の設定を使う
+CREATE TABLE IF NOT EXISTS user_preferences (
+	user_id INT NOT NULL PRIMARY KEY,
+	timezone VARCHAR(64) NOT NULL,
+	sort_order VARCHAR(16) NOT NULL,
+	notify_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+	updated_at DATETIME NOT NULL,
+
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
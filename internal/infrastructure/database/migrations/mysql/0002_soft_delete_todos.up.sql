@@ -0,0 +1,4 @@
+-- Todoのソフト削除（MySQL）
+-- 削除したTodoは行を残したまま deleted_at に削除日時を記録し、既定の取得・一覧から除外します
+
+ALTER TABLE todos ADD COLUMN deleted_at DATETIME NULL;
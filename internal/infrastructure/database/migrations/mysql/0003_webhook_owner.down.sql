@@ -0,0 +1,7 @@
+-- Webhookの所有者の列を削除します（全てのWebhookが全てのTodoのイベントを受け取るようになります）
+
+ALTER TABLE webhooks
+	DROP INDEX idx_webhooks_user_id,
+	DROP INDEX idx_webhooks_workspace_id,
+	DROP COLUMN user_id,
+	DROP COLUMN workspace_id;
@@ -0,0 +1,9 @@
+-- Webhookの所有者（MySQL）
+-- 登録したユーザー・ワークスペースを記録し、一覧・取得・削除と通知をそのユーザー（ワークスペース）のTodoに限定します
+-- 認証が無効な状態で登録したWebhookはどちらも NULL です
+
+ALTER TABLE webhooks
+	ADD COLUMN user_id INT NULL,
+	ADD COLUMN workspace_id INT NULL,
+	ADD INDEX idx_webhooks_user_id (user_id),
+	ADD INDEX idx_webhooks_workspace_id (workspace_id);
@@ -0,0 +1,7 @@
+-- プロジェクトの所有者の列を削除します（全てのプロジェクトが全てのユーザーから見えるようになります）
+
+ALTER TABLE projects
+	DROP INDEX idx_projects_user_id,
+	DROP INDEX idx_projects_workspace_id,
+	DROP COLUMN user_id,
+	DROP COLUMN workspace_id;
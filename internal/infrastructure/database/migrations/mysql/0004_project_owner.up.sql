@@ -0,0 +1,9 @@
+-- プロジェクトの所有者（MySQL）
+-- 作成したユーザー・ワークスペースを記録し、取得・一覧・アーカイブをそのユーザー（ワークスペース）のプロジェクトに限定します
+-- 認証が無効な状態で作成したプロジェクトはどちらも NULL です
+
+ALTER TABLE projects
+	ADD COLUMN user_id INT NULL,
+	ADD COLUMN workspace_id INT NULL,
+	ADD INDEX idx_projects_user_id (user_id),
+	ADD INDEX idx_projects_workspace_id (workspace_id);
@@ -0,0 +1,179 @@
+-- 初期スキーマ（SQLite）
+-- MySQL（migrations/mysql）と同じテーブル・列を、SQLiteの型と構文で定義します
+-- 導入前のデータベースにもそのまま適用できるよう、既存のテーブルは作成しません（IF NOT EXISTS）
+
+-- todos テーブル（繰り返しのオカレンスの重複を防ぐ一意制約付き）
+CREATE TABLE IF NOT EXISTS todos (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	title TEXT NOT NULL,
+	description TEXT,
+	is_completed BOOLEAN NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	remind_at DATETIME,
+	due_date DATETIME,
+	recurrence TEXT NOT NULL DEFAULT '',
+	recurrence_parent_id INTEGER,
+	color TEXT NOT NULL DEFAULT '',
+	estimate_minutes INTEGER NOT NULL DEFAULT 0,
+	actual_minutes INTEGER NOT NULL DEFAULT 0,
+	tags TEXT NOT NULL DEFAULT '',
+	project_id INTEGER,
+	user_id INTEGER,
+	workspace_id INTEGER,
+	UNIQUE (recurrence_parent_id, due_date)
+);
+
+-- checklist_items テーブル
+CREATE TABLE IF NOT EXISTS checklist_items (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+	text TEXT NOT NULL,
+	is_done BOOLEAN NOT NULL DEFAULT 0,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- projects テーブル（スラッグの一意制約付き）
+CREATE TABLE IF NOT EXISTS projects (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	slug TEXT NOT NULL UNIQUE,
+	description TEXT,
+	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	archived_at DATETIME
+);
+
+-- todo_history テーブル（スナップショットはJSON文字列）
+CREATE TABLE IF NOT EXISTS todo_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	todo_id INTEGER NOT NULL,
+	action TEXT NOT NULL,
+	actor TEXT NOT NULL,
+	before_snapshot TEXT,
+	after_snapshot TEXT,
+	changed_at DATETIME NOT NULL
+);
+
+-- failed_deliveries テーブル
+CREATE TABLE IF NOT EXISTS failed_deliveries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	kind TEXT NOT NULL,
+	todo_id INTEGER NOT NULL,
+	payload TEXT NOT NULL,
+	attempts INTEGER NOT NULL,
+	last_error TEXT NOT NULL,
+	status TEXT NOT NULL,
+	next_attempt_at DATETIME NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+
+-- webhooks テーブル（イベントはカンマ区切り）
+CREATE TABLE IF NOT EXISTS webhooks (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	url TEXT NOT NULL,
+	events TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+
+-- outbox テーブル（発行待ちのドメインイベント）
+CREATE TABLE IF NOT EXISTS outbox (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	event_name TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+
+-- idempotency_keys テーブル（Idempotency-Key の記録と再送時に返すレスポンス）
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	actor TEXT NOT NULL,
+	idempotency_key TEXT NOT NULL,
+	fingerprint TEXT NOT NULL,
+	status_code INTEGER NOT NULL DEFAULT 0,
+	response_header TEXT NOT NULL,
+	response_body BLOB NOT NULL,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+	PRIMARY KEY (actor, idempotency_key)
+);
+
+-- users テーブル（ログインするユーザーとパスワードのハッシュ）
+CREATE TABLE IF NOT EXISTS users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	username TEXT NOT NULL UNIQUE,
+	password_hash TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+
+-- refresh_tokens テーブル（リフレッシュトークンのハッシュとローテーションの系列）
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	family_id TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+	used_at DATETIME NULL,
+	revoked_at DATETIME NULL
+);
+
+-- todo_shares テーブル
+CREATE TABLE IF NOT EXISTS todo_shares (
+	todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	permission TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	PRIMARY KEY (todo_id, user_id)
+);
+
+-- workspaces テーブル
+CREATE TABLE IF NOT EXISTS workspaces (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+
+-- workspace_members テーブル（ワークスペースのメンバーと役割）
+CREATE TABLE IF NOT EXISTS workspace_members (
+	workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	role TEXT NOT NULL,
+	joined_at DATETIME NOT NULL,
+	PRIMARY KEY (workspace_id, user_id)
+);
+
+-- workspace_invitations テーブル（招待トークンのハッシュと有効期限）
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	workspace_id INTEGER NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+	token_hash TEXT NOT NULL UNIQUE,
+	invited_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL
+);
+
+-- sessions テーブル（Cookieのセッションのハッシュと有効期限）
+CREATE TABLE IF NOT EXISTS sessions (
+	token_hash TEXT NOT NULL PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL
+);
+
+-- user_preferences テーブル（ユーザーごとの設定）
+CREATE TABLE IF NOT EXISTS user_preferences (
+	user_id INTEGER NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+	timezone TEXT NOT NULL,
+	sort_order TEXT NOT NULL,
+	notify_reminders BOOLEAN NOT NULL DEFAULT 1,
+	updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_todos_user_id ON todos (user_id);
+CREATE INDEX IF NOT EXISTS idx_todos_workspace_id ON todos (workspace_id);
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members (user_id);
+CREATE INDEX IF NOT EXISTS idx_todo_shares_user_id ON todo_shares (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
@@ -0,0 +1,3 @@
+-- ソフト削除の列を削除します（削除済みのTodoは通常のTodoに戻ります）
+
+ALTER TABLE todos DROP COLUMN deleted_at;
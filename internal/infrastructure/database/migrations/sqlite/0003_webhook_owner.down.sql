@@ -0,0 +1,6 @@
+-- Webhookの所有者の列を削除します（全てのWebhookが全てのTodoのイベントを受け取るようになります）
+
+DROP INDEX idx_webhooks_user_id;
+DROP INDEX idx_webhooks_workspace_id;
+ALTER TABLE webhooks DROP COLUMN user_id;
+ALTER TABLE webhooks DROP COLUMN workspace_id;
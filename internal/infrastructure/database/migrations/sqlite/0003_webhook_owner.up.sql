@@ -0,0 +1,8 @@
+-- Webhookの所有者（SQLite）
+-- 登録したユーザー・ワークスペースを記録し、一覧・取得・削除と通知をそのユーザー（ワークスペース）のTodoに限定します
+-- 認証が無効な状態で登録したWebhookはどちらも NULL です
+
+ALTER TABLE webhooks ADD COLUMN user_id INTEGER;
+ALTER TABLE webhooks ADD COLUMN workspace_id INTEGER;
+CREATE INDEX idx_webhooks_user_id ON webhooks (user_id);
+CREATE INDEX idx_webhooks_workspace_id ON webhooks (workspace_id);
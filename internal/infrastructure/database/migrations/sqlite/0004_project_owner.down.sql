@@ -0,0 +1,6 @@
+-- プロジェクトの所有者の列を削除します（全てのプロジェクトが全てのユーザーから見えるようになります）
+
+DROP INDEX idx_projects_user_id;
+DROP INDEX idx_projects_workspace_id;
+ALTER TABLE projects DROP COLUMN user_id;
+ALTER TABLE projects DROP COLUMN workspace_id;
@@ -0,0 +1,8 @@
+-- プロジェクトの所有者（SQLite）
+-- 作成したユーザー・ワークスペースを記録し、取得・一覧・アーカイブをそのユーザー（ワークスペース）のプロジェクトに限定します
+-- 認証が無効な状態で作成したプロジェクトはどちらも NULL です
+
+ALTER TABLE projects ADD COLUMN user_id INTEGER;
+ALTER TABLE projects ADD COLUMN workspace_id INTEGER;
+CREATE INDEX idx_projects_user_id ON projects (user_id);
+CREATE INDEX idx_projects_workspace_id ON projects (workspace_id);
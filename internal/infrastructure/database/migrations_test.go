@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+// TestMigrations_DriversInSync はMySQLとSQLiteに同じバージョンのマイグレーションがあることをテストします
+// 片方のドライバーだけにマイグレーションを追加すると、SQLiteで動かすテストと本番のスキーマがずれるためです
+func TestMigrations_DriversInSync(t *testing.T) {
+	mysqlMigrations, err := Migrations("mysql")
+	if err != nil {
+		t.Fatalf("Migrations(mysql) error = %v", err)
+	}
+	sqliteMigrations, err := Migrations("sqlite")
+	if err != nil {
+		t.Fatalf("Migrations(sqlite) error = %v", err)
+	}
+
+	if len(mysqlMigrations) != len(sqliteMigrations) {
+		t.Fatalf("マイグレーションの数 mysql = %d, sqlite = %d", len(mysqlMigrations), len(sqliteMigrations))
+	}
+	for i := range mysqlMigrations {
+		if mysqlMigrations[i].String() != sqliteMigrations[i].String() {
+			t.Errorf("マイグレーション mysql = %s, sqlite = %s", mysqlMigrations[i], sqliteMigrations[i])
+		}
+		if (mysqlMigrations[i].Down == nil) != (sqliteMigrations[i].Down == nil) {
+			t.Errorf("%s: ロールバックできるかどうかがドライバーで異なります", mysqlMigrations[i])
+		}
+	}
+}
+
+// TestMigrations_SQLiteRoundTrip はSQLiteのマイグレーションを全て取り消してから再適用できることをテストします
+func TestMigrations_SQLiteRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	migrator, err := NewMigrator(db, "sqlite")
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for range statuses {
+		if _, err := migrator.Down(ctx); err != nil {
+			t.Fatalf("Down() error = %v", err)
+		}
+	}
+
+	var tables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations', 'sqlite_sequence')`).Scan(&tables); err != nil {
+		t.Fatalf("テーブル数の取得に失敗: %v", err)
+	}
+	if tables != 0 {
+		t.Errorf("全て取り消した後のテーブル数 = %d, 期待値 = 0", tables)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(applied) != len(statuses) {
+		t.Errorf("再適用したマイグレーションの数 = %d, 期待値 = %d", len(applied), len(statuses))
+	}
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// outboxColumns は outbox テーブルのSELECT対象列です（scanOutboxMessage が列名で対応付けます）
+const outboxColumns = `id, event_name, payload, created_at`
+
+// outboxRepositoryImpl は outbox テーブルを使用した
+// OutboxRepository インターフェースの実装です
+//
+// 全ての操作は sqlrepo.Conn を通すため、Transactor のトランザクションの中で呼び出すと
+// Todoの変更と同じトランザクションで保存されます
+type outboxRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository はoutboxRepositoryImplのコンストラクタです
+func NewOutboxRepository(db *sql.DB) repository.OutboxRepository {
+	return &outboxRepositoryImpl{
+		db: db,
+	}
+}
+
+// Add は発行待ちのイベントを保存します
+func (r *outboxRepositoryImpl) Add(ctx context.Context, message *entity.OutboxMessage) (*entity.OutboxMessage, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	query := `INSERT INTO outbox (event_name, payload, created_at) VALUES (?, ?, ?)`
+
+	result, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx, query, message.EventName, string(message.Payload), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert outbox message: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	message.ID = int(id)
+	message.CreatedAt = now
+	return message, nil
+}
+
+// ListPending は発行待ちのイベントをID順（保存された順）に最大 limit 件取得します
+func (r *outboxRepositoryImpl) ListPending(ctx context.Context, limit int) ([]*entity.OutboxMessage, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, `SELECT `+outboxColumns+` FROM outbox ORDER BY id ASC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanOutboxMessage)
+}
+
+// Delete は発行済みのイベントを削除します
+func (r *outboxRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "delete outbox message", domainerr.NotFound("outbox message", nil),
+		`DELETE FROM outbox WHERE id = ?`, id)
+}
+
+// scanOutboxMessage は1行を列名で対応付けて OutboxMessage に変換します
+func scanOutboxMessage(rows *sql.Rows) (*entity.OutboxMessage, error) {
+	var message entity.OutboxMessage
+	var payload string
+	if err := sqlrepo.ScanColumns(rows, "outbox", sqlrepo.Columns{
+		"id":         &message.ID,
+		"event_name": &message.EventName,
+		"payload":    &payload,
+		"created_at": &message.CreatedAt,
+	}, "id", "event_name", "payload"); err != nil {
+		return nil, err
+	}
+
+	message.Payload = []byte(payload)
+	return &message, nil
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestOutboxRepository は発行待ちのイベントの保存・取得・削除をテストします
+func TestOutboxRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewOutboxRepository(db)
+	ctx := context.Background()
+
+	first, err := repo.Add(ctx, &entity.OutboxMessage{EventName: "todo.created", Payload: []byte(`{"before":null,"after":{"id":1}}`)})
+	if err != nil {
+		t.Fatalf("保存に失敗: %v", err)
+	}
+	if first.ID == 0 || first.CreatedAt.IsZero() {
+		t.Errorf("保存したイベントにIDと作成日時が設定されるべきです: %+v", first)
+	}
+	if _, err := repo.Add(ctx, &entity.OutboxMessage{EventName: "todo.deleted", Payload: []byte(`{"before":{"id":1},"after":null}`)}); err != nil {
+		t.Fatalf("保存に失敗: %v", err)
+	}
+
+	pending, err := repo.ListPending(ctx, 10)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != first.ID || pending[1].EventName != "todo.deleted" {
+		t.Fatalf("発行待ちのイベント = %+v", pending)
+	}
+	if string(pending[0].Payload) != `{"before":null,"after":{"id":1}}` {
+		t.Errorf("Payload = %s", pending[0].Payload)
+	}
+	if limited, _ := repo.ListPending(ctx, 1); len(limited) != 1 || limited[0].ID != first.ID {
+		t.Errorf("limit = 1 の取得結果 = %+v", limited)
+	}
+
+	if err := repo.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+	if pending, _ := repo.ListPending(ctx, 10); len(pending) != 1 {
+		t.Errorf("削除後の発行待ちの件数 = %d, 期待値 = 1", len(pending))
+	}
+	if err := repo.Delete(ctx, first.ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("存在しないイベントの削除で not found エラーになるべきです: %v", err)
+	}
+}
+
+// TestOutboxRepository_Transaction はTodoの変更とイベントが同じトランザクションでロールバック・コミットされることをテストします
+func TestOutboxRepository_Transaction(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	// :memory: は接続ごとに別のデータベースになるため、接続を1つに制限する
+	db.SetMaxOpenConns(1)
+	todoRepo := NewTodoRepository(db)
+	outboxRepo := NewOutboxRepository(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+	errStop := errors.New("stop")
+
+	save := func(ctx context.Context) error {
+		if _, err := todoRepo.Create(ctx, &entity.Todo{Title: "買い物"}); err != nil {
+			return err
+		}
+		_, err := outboxRepo.Add(ctx, &entity.OutboxMessage{EventName: "todo.created", Payload: []byte(`{}`)})
+		return err
+	}
+
+	err := transactor.InTransaction(ctx, func(ctx context.Context) error {
+		if err := save(ctx); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("InTransaction() error = %v, 期待値 = %v", err, errStop)
+	}
+	todos, _ := todoRepo.GetAll(ctx)
+	pending, _ := outboxRepo.ListPending(ctx, 10)
+	if len(todos) != 0 || len(pending) != 0 {
+		t.Errorf("ロールバック後のTodo = %d件, イベント = %d件, 期待値 = 0件", len(todos), len(pending))
+	}
+
+	if err := transactor.InTransaction(ctx, save); err != nil {
+		t.Fatalf("InTransaction() error = %v", err)
+	}
+	todos, _ = todoRepo.GetAll(ctx)
+	pending, _ = outboxRepo.ListPending(ctx, 10)
+	if len(todos) != 1 || len(pending) != 1 {
+		t.Errorf("コミット後のTodo = %d件, イベント = %d件, 期待値 = 1件", len(todos), len(pending))
+	}
+}
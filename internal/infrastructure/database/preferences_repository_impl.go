@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// preferencesRepositoryImpl は user_preferences テーブルを使用した PreferencesRepository インターフェースの実装です
+type preferencesRepositoryImpl struct {
+	db      *sql.DB
+	dialect sqlrepo.Dialect
+}
+
+// NewPreferencesRepository はpreferencesRepositoryImplのコンストラクタです
+// dialect には接続先のエンジンの方言（DatabaseManager.Dialect）を渡します
+func NewPreferencesRepository(db *sql.DB, dialect sqlrepo.Dialect) repository.PreferencesRepository {
+	return &preferencesRepositoryImpl{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Get はユーザーの設定を取得します
+func (r *preferencesRepositoryImpl) Get(ctx context.Context, userID int) (*entity.Preferences, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, `
+		SELECT user_id, timezone, sort_order, notify_reminders, updated_at
+		FROM user_preferences
+		WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query preferences: %w", err)
+	}
+
+	preferences, err := sqlrepo.ScanOne(rows, scanPreferences)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("preferences", nil)
+		}
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return preferences, nil
+}
+
+// Save はユーザーの設定を保存し、既にある場合は置き換えます
+// todo_shares と同様に、方言のUPSERTで作成と置き換えを1つの文で行います
+func (r *preferencesRepositoryImpl) Save(ctx context.Context, preferences *entity.Preferences) (*entity.Preferences, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	query := r.dialect.Upsert("user_preferences", []string{"user_id", "timezone", "sort_order", "notify_reminders", "updated_at"},
+		[]string{"user_id"}, []string{"timezone", "sort_order", "notify_reminders", "updated_at"})
+
+	if _, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx, query,
+		preferences.UserID, preferences.Timezone, string(preferences.SortOrder), preferences.NotifyReminders, now); err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	return r.Get(ctx, preferences.UserID)
+}
+
+// scanPreferences は1行を列名で対応付けて Preferences にスキャンします
+func scanPreferences(rows *sql.Rows) (*entity.Preferences, error) {
+	var preferences entity.Preferences
+	var sortOrder string
+	if err := sqlrepo.ScanColumns(rows, "user_preferences", sqlrepo.Columns{
+		"user_id":          &preferences.UserID,
+		"timezone":         &preferences.Timezone,
+		"sort_order":       &sortOrder,
+		"notify_reminders": &preferences.NotifyReminders,
+		"updated_at":       &preferences.UpdatedAt,
+	}, "user_id"); err != nil {
+		return nil, err
+	}
+	preferences.SortOrder = entity.TodoSortOrder(sortOrder)
+	preferences.UpdatedAt = preferences.UpdatedAt.UTC()
+	return &preferences, nil
+}
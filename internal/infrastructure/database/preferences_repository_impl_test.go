@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// TestPreferencesRepository は設定の保存・置き換え・取得をテストします
+func TestPreferencesRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	user, err := NewUserRepository(db).Create(context.Background(), &entity.User{Username: "alice", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("ユーザーの作成に失敗: %v", err)
+	}
+	repo := NewPreferencesRepository(db, sqlrepo.SQLite)
+	ctx := context.Background()
+
+	if _, err := repo.Get(ctx, user.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("保存前の Get() error = %v, 期待値 = not found", err)
+	}
+
+	saved, err := repo.Save(ctx, &entity.Preferences{UserID: user.ID, Timezone: "Asia/Tokyo", SortOrder: entity.TodoSortDueDate, NotifyReminders: false})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if saved.Timezone != "Asia/Tokyo" || saved.SortOrder != entity.TodoSortDueDate || saved.NotifyReminders || saved.UpdatedAt.IsZero() {
+		t.Errorf("保存した設定 = %+v", saved)
+	}
+
+	// 2回目の保存は置き換える
+	if _, err := repo.Save(ctx, &entity.Preferences{UserID: user.ID, Timezone: "UTC", SortOrder: entity.TodoSortTitle, NotifyReminders: true}); err != nil {
+		t.Fatalf("2回目の Save() error = %v", err)
+	}
+	got, err := repo.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Timezone != "UTC" || got.SortOrder != entity.TodoSortTitle || !got.NotifyReminders {
+		t.Errorf("置き換えた設定 = %+v", got)
+	}
+}
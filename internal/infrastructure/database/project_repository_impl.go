@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// projectColumns は projects テーブルのSELECT対象列です（scanProject が列名で対応付けます）
+const projectColumns = `id, name, slug, description, created_at, updated_at, archived_at, user_id, workspace_id`
+
+// projectRepositoryImpl は database/sql を使用した
+// ProjectRepository インターフェースの実装です
+//
+// Todoと同じく、コンテキストの所有者・ワークスペースでプロジェクトを絞り込みます（scopeCondition）
+type projectRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewProjectRepository はprojectRepositoryImplのコンストラクタです
+func NewProjectRepository(db *sql.DB) repository.ProjectRepository {
+	return &projectRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create は新しいプロジェクトを保存します
+// slug 列の一意制約違反は repository.ErrSlugConflict に変換します
+// （SlugExists での確認後に別リクエストが同じスラッグを登録した場合の競合対策）
+// 所有者・ワークスペースはコンテキストから設定します（設定されていない場合は NULL）
+func (r *projectRepositoryImpl) Create(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	project.UserID, project.WorkspaceID = nil, nil
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		project.UserID = &userID
+	}
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		project.WorkspaceID = &workspaceID
+	}
+
+	query := `
+		INSERT INTO projects (name, slug, description, created_at, updated_at, user_id, workspace_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, project.Name, project.Slug, project.Description, now, now,
+		nullableInt(project.UserID), nullableInt(project.WorkspaceID))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrSlugConflict
+		}
+		return nil, fmt.Errorf("failed to insert project: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	project.ID = int(id)
+	project.CreatedAt = now
+	project.UpdatedAt = now
+
+	return project, nil
+}
+
+// GetByID はIDでプロジェクトを取得します
+func (r *projectRepositoryImpl) GetByID(ctx context.Context, id int) (*entity.Project, error) {
+	return r.getOne(ctx, "id", id)
+}
+
+// GetBySlug はスラッグでプロジェクトを取得します
+func (r *projectRepositoryImpl) GetBySlug(ctx context.Context, slug string) (*entity.Project, error) {
+	return r.getOne(ctx, "slug", slug)
+}
+
+// GetAll はプロジェクトを作成日時の降順で取得します
+func (r *projectRepositoryImpl) GetAll(ctx context.Context) ([]*entity.Project, error) {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE ` + owner + `
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, ownerArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanProject)
+}
+
+// SlugExists は指定されたスラッグが既に使用されているかを返します
+// 行全体を取得せず、EXISTS で存在の有無だけを確認します
+// slug 列の一意制約は全てのプロジェクトに掛かるため、他のユーザーのプロジェクトも含めて確認します
+func (r *projectRepositoryImpl) SlugExists(ctx context.Context, slug string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM projects WHERE slug = ?)`, slug).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check slug existence: %w", err)
+	}
+	return exists, nil
+}
+
+// SetArchivedAt はプロジェクトのアーカイブ日時を設定します（nil の場合はアーカイブを解除）
+// Todo側の列は変更しないため、アーカイブを解除するとTodoは元どおり一覧に表示されます
+func (r *projectRepositoryImpl) SetArchivedAt(ctx context.Context, id int, archivedAt *time.Time) error {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	query := `UPDATE projects SET archived_at = ?, updated_at = ? WHERE id = ? AND ` + owner
+	args := append([]any{nullableTime(archivedAt), time.Now().UTC().Truncate(time.Second), id}, ownerArgs...)
+	return sqlrepo.ExecAffecting(ctx, r.db, "update project", domainerr.NotFound("project", nil), query, args...)
+}
+
+// getOne は column の値が arg のプロジェクトを1件取得する共通処理です（所有者・ワークスペースで絞り込みます）
+func (r *projectRepositoryImpl) getOne(ctx context.Context, column string, arg any) (*entity.Project, error) {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE ` + column + ` = ? AND ` + owner
+	rows, err := r.db.QueryContext(ctx, query, append([]any{arg}, ownerArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project: %w", err)
+	}
+
+	project, err := sqlrepo.ScanOne(rows, scanProject)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("project", nil)
+		}
+		return nil, fmt.Errorf("failed to scan project: %w", err)
+	}
+	return project, nil
+}
+
+// scanProject は1行を列名で対応付けてProjectエンティティにスキャンします
+func scanProject(rows *sql.Rows) (*entity.Project, error) {
+	var project entity.Project
+	var archivedAt sql.NullTime
+	var userID, workspaceID sql.NullInt64
+	err := sqlrepo.ScanColumns(rows, "projects", sqlrepo.Columns{
+		"id":           &project.ID,
+		"name":         &project.Name,
+		"slug":         &project.Slug,
+		"description":  &project.Description,
+		"created_at":   &project.CreatedAt,
+		"updated_at":   &project.UpdatedAt,
+		"archived_at":  &archivedAt,
+		"user_id":      &userID,
+		"workspace_id": &workspaceID,
+	}, "id", "name", "slug")
+	if err != nil {
+		return nil, err
+	}
+	if archivedAt.Valid {
+		archived := archivedAt.Time.UTC()
+		project.ArchivedAt = &archived
+	}
+	if userID.Valid {
+		id := int(userID.Int64)
+		project.UserID = &id
+	}
+	if workspaceID.Valid {
+		id := int(workspaceID.Int64)
+		project.WorkspaceID = &id
+	}
+	return &project, nil
+}
+
+// isUniqueViolation はエラーが一意制約違反かどうかを判定します
+// ドライバーごとにエラー型が異なるため、メッセージで判定しています
+// - MySQL:  "Error 1062 (23000): Duplicate entry ..."
+// - SQLite: "UNIQUE constraint failed: ..."
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "Duplicate entry") || strings.Contains(msg, "UNIQUE constraint failed")
+}
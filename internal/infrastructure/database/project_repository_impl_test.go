@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestProjectRepository_SlugUniqueness はスラッグの一意制約と存在確認をテストします
+func TestProjectRepository_SlugUniqueness(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Project{Name: "Home", Slug: "home"})
+	if err != nil {
+		t.Fatalf("プロジェクトの作成に失敗: %v", err)
+	}
+
+	exists, err := repo.SlugExists(ctx, "home")
+	if err != nil || !exists {
+		t.Errorf("SlugExists(home) = %v, %v, 期待値 = true, nil", exists, err)
+	}
+	exists, err = repo.SlugExists(ctx, "work")
+	if err != nil || exists {
+		t.Errorf("SlugExists(work) = %v, %v, 期待値 = false, nil", exists, err)
+	}
+
+	// 同じスラッグでの作成は ErrSlugConflict になる
+	_, err = repo.Create(ctx, &entity.Project{Name: "Home again", Slug: "home"})
+	if !errors.Is(err, repository.ErrSlugConflict) {
+		t.Errorf("ErrSlugConflict が期待されましたが、%v が返されました", err)
+	}
+
+	// スラッグでの取得
+	found, err := repo.GetBySlug(ctx, "home")
+	if err != nil {
+		t.Fatalf("スラッグでの取得に失敗: %v", err)
+	}
+	if found.ID != created.ID {
+		t.Errorf("ID = %v, 期待値 = %v", found.ID, created.ID)
+	}
+
+	if _, err := repo.GetBySlug(ctx, "missing"); err == nil {
+		t.Error("存在しないスラッグでエラーが期待されました")
+	}
+}
+
+// TestProjectRepository_ArchivedTodosHidden はアーカイブ済みのプロジェクトのTodoが一覧から除外され、
+// アーカイブを解除すると元どおり表示されることをテストします
+func TestProjectRepository_ArchivedTodosHidden(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	projects := NewProjectRepository(db)
+	// GetByCompleteStatus・GetWithPagination はインターフェースにないため実装の型で使用する
+	todos := &todoRepositoryImpl{db: db}
+	dueDates := NewDueDateRepository(db)
+	ctx := context.Background()
+
+	project, err := projects.Create(ctx, &entity.Project{Name: "Work", Slug: "work"})
+	if err != nil {
+		t.Fatalf("プロジェクトの作成に失敗: %v", err)
+	}
+	due := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	inProject, err := todos.Create(ctx, &entity.Todo{Title: "仕事", Color: entity.Color("red"), DueDate: &due, ProjectID: &project.ID})
+	if err != nil {
+		t.Fatalf("Todoの作成に失敗: %v", err)
+	}
+	if _, err := todos.Create(ctx, &entity.Todo{Title: "私用", Color: entity.Color("red"), DueDate: &due}); err != nil {
+		t.Fatalf("Todoの作成に失敗: %v", err)
+	}
+
+	// countVisible は一覧を返す各メソッドの件数を返します
+	countVisible := func() map[string]int {
+		t.Helper()
+		all, err := todos.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("GetAll に失敗: %v", err)
+		}
+		byColor, err := todos.GetByColor(ctx, entity.Color("red"))
+		if err != nil {
+			t.Fatalf("GetByColor に失敗: %v", err)
+		}
+		byStatus, err := todos.GetByCompleteStatus(ctx, false)
+		if err != nil {
+			t.Fatalf("GetByCompleteStatus に失敗: %v", err)
+		}
+		page, total, err := todos.GetWithPagination(ctx, 0, 10)
+		if err != nil {
+			t.Fatalf("GetWithPagination に失敗: %v", err)
+		}
+		overdue, err := dueDates.ListOverdue(ctx, due.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("ListOverdue に失敗: %v", err)
+		}
+		return map[string]int{
+			"GetAll":              len(all),
+			"GetByColor":          len(byColor),
+			"GetByCompleteStatus": len(byStatus),
+			"GetWithPagination":   len(page),
+			"total":               int(total),
+			"ListOverdue":         len(overdue),
+		}
+	}
+
+	for name, got := range countVisible() {
+		if got != 2 {
+			t.Errorf("アーカイブ前の %s = %d, 期待値 = 2", name, got)
+		}
+	}
+
+	archivedAt := time.Now().UTC().Truncate(time.Second)
+	if err := projects.SetArchivedAt(ctx, project.ID, &archivedAt); err != nil {
+		t.Fatalf("アーカイブに失敗: %v", err)
+	}
+	found, err := projects.GetByID(ctx, project.ID)
+	if err != nil || found.ArchivedAt == nil || !found.ArchivedAt.Equal(archivedAt) {
+		t.Fatalf("アーカイブ日時 = %v, %v, 期待値 = %v", found.ArchivedAt, err, archivedAt)
+	}
+	for name, got := range countVisible() {
+		if got != 1 {
+			t.Errorf("アーカイブ後の %s = %d, 期待値 = 1", name, got)
+		}
+	}
+
+	// IDを指定した取得では、アーカイブ中もTodoを参照できる
+	hidden, err := todos.GetByID(ctx, inProject.ID)
+	if err != nil {
+		t.Fatalf("アーカイブ中のTodoの取得に失敗: %v", err)
+	}
+	if hidden.ProjectID == nil || *hidden.ProjectID != project.ID {
+		t.Errorf("ProjectID = %v, 期待値 = %d", hidden.ProjectID, project.ID)
+	}
+
+	if err := projects.SetArchivedAt(ctx, project.ID, nil); err != nil {
+		t.Fatalf("アーカイブの解除に失敗: %v", err)
+	}
+	for name, got := range countVisible() {
+		if got != 2 {
+			t.Errorf("復元後の %s = %d, 期待値 = 2", name, got)
+		}
+	}
+
+	if err := projects.SetArchivedAt(ctx, 999, nil); err == nil {
+		t.Error("存在しないプロジェクトでエラーが期待されました")
+	}
+}
+
+// TestProjectRepository_Scope はプロジェクトがコンテキストの所有者・ワークスペースに限定されることをテストします
+func TestProjectRepository_Scope(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewProjectRepository(db)
+	alice := repository.WithOwner(context.Background(), 1)
+	bob := repository.WithOwner(context.Background(), 2)
+
+	created, err := repo.Create(alice, &entity.Project{Name: "Home", Slug: "home"})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+	if created.UserID == nil || *created.UserID != 1 {
+		t.Errorf("所有者が設定されていません: %+v", created)
+	}
+	if _, err := repo.Create(repository.WithWorkspace(bob, 10), &entity.Project{Name: "Team", Slug: "team"}); err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+
+	if _, err := repo.GetByID(bob, created.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのプロジェクトは取得できないべきです: %v", err)
+	}
+	if _, err := repo.GetBySlug(bob, "home"); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのプロジェクトはスラッグでも取得できないべきです: %v", err)
+	}
+	if err := repo.SetArchivedAt(bob, created.ID, &created.CreatedAt); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのプロジェクトはアーカイブできないべきです: %v", err)
+	}
+	if projects, err := repo.GetAll(bob); err != nil || len(projects) != 0 {
+		t.Errorf("他のユーザー・ワークスペースのプロジェクトが一覧に含まれています: %+v, err = %v", projects, err)
+	}
+	if projects, err := repo.GetAll(alice); err != nil || len(projects) != 1 || projects[0].ID != created.ID {
+		t.Errorf("本人の一覧 = %+v, err = %v", projects, err)
+	}
+	// スラッグは全てのプロジェクトで一意のため、他のユーザーのものも使用済みとして扱う
+	if exists, err := repo.SlugExists(bob, "home"); err != nil || !exists {
+		t.Errorf("SlugExists() = %v, err = %v", exists, err)
+	}
+}
@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+)
+
+// timeoutConnector は全てのクエリに期限を設ける driver.Connector のラッパーです
+//
+// リクエストのコンテキストに期限がない場合、遅いデータベースへのクエリは
+// サーバーの WriteTimeout まで応答を止めてしまいます。接続を包んでクエリごとに期限付きのコンテキストを渡すことで、
+// リポジトリのSQLを変更せずに全てのクエリ（トランザクション内を含む）を期限で打ち切ります
+//
+// 期限はクエリ1回ごとで、SELECT の期限は結果の行を読み終えて閉じるまでです
+// トランザクション自体（BEGIN から COMMIT まで）には期限を設けません
+type timeoutConnector struct {
+	baseConnector
+	timeout time.Duration
+}
+
+// NewTimeoutConnector は全てのクエリを timeout で打ち切るコネクターを返します
+// 期限を過ぎたクエリは domainerr.KindTimeout のエラー（"database query timed out: ..."）になります
+func NewTimeoutConnector(base driver.Connector, timeout time.Duration) driver.Connector {
+	return &timeoutConnector{baseConnector: baseConnector{base: base}, timeout: timeout}
+}
+
+// Connect は新しい接続を確立し、期限を設けるラッパーで包みます
+func (c *timeoutConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &timeoutConn{
+		baseConn: baseConn{Conn: conn, wrapStmt: func(stmt driver.Stmt) driver.Stmt {
+			return &timeoutStmt{baseStmt: baseStmt{Stmt: stmt}, timeout: c.timeout}
+		}},
+		timeout: c.timeout,
+	}, nil
+}
+
+// noQueryTimeoutKey はクエリの期限を設けないことを示すコンテキストのキーです
+type noQueryTimeoutKey struct{}
+
+// WithoutQueryTimeout はクエリの期限（DB_QUERY_TIMEOUT）を設けないコンテキストを返します
+// マイグレーションのように、大きなテーブルの変更で期限より長くかかり得る処理に使用します
+// （呼び出し元のコンテキスト自体の期限は、これまでどおり有効です）
+func WithoutQueryTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noQueryTimeoutKey{}, true)
+}
+
+// withQueryTimeout は timeout の期限を設けたコンテキストを返します（WithoutQueryTimeout の場合は期限を設けない）
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if disabled, _ := ctx.Value(noQueryTimeoutKey{}).(bool); disabled {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutError は期限付きのコンテキスト ctx で実行したクエリのエラーを、期限切れの場合は domainerr.Timeout に変換します
+// 呼び出し元のコンテキストの期限（X-Request-Timeout 等）を過ぎた場合も、同じく期限切れとして扱います
+func timeoutError(ctx context.Context, err error) error {
+	if err == nil || errors.Is(err, driver.ErrSkip) || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return domainerr.Timeout("database query").Wrap(err)
+}
+
+// timeoutConn は全てのクエリに期限を設ける driver.Conn のラッパーです
+// トランザクションは複数のクエリにまたがるため期限を設けず（BeginTx は baseConn のまま）、トランザクション内の各クエリに期限を設けます
+type timeoutConn struct {
+	baseConn
+	timeout time.Duration
+}
+
+// PrepareContext はステートメントを期限付きで準備します（driver.ConnPrepareContext）
+func (c *timeoutConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ctx, cancel := withQueryTimeout(ctx, c.timeout)
+	defer cancel()
+	stmt, err := c.baseConn.PrepareContext(ctx, query)
+	return stmt, timeoutError(ctx, err)
+}
+
+// ExecContext はクエリを期限付きで実行します（driver.ExecerContext）
+func (c *timeoutConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ctx, cancel := withQueryTimeout(ctx, c.timeout)
+	defer cancel()
+	result, err := c.baseConn.ExecContext(ctx, query, args)
+	return result, timeoutError(ctx, err)
+}
+
+// QueryContext はクエリを期限付きで実行します（driver.QueryerContext）
+// 行を読み進める間もドライバーがコンテキストを参照するため、期限は結果の行を閉じるまで有効にします
+func (c *timeoutConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, cancel := withQueryTimeout(ctx, c.timeout)
+	rows, err := c.baseConn.QueryContext(ctx, query, args)
+	if err != nil {
+		cancel()
+		return nil, timeoutError(ctx, err)
+	}
+	return &timeoutRows{Rows: rows, ctx: ctx, cancel: cancel}, nil
+}
+
+// timeoutStmt は実行ごとに期限を設ける driver.Stmt のラッパーです
+type timeoutStmt struct {
+	baseStmt
+	timeout time.Duration
+}
+
+// ExecContext はステートメントを期限付きで実行します（driver.StmtExecContext）
+func (s *timeoutStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, cancel := withQueryTimeout(ctx, s.timeout)
+	defer cancel()
+	result, err := s.baseStmt.ExecContext(ctx, args)
+	return result, timeoutError(ctx, err)
+}
+
+// QueryContext はステートメントを期限付きで実行します（driver.StmtQueryContext）
+func (s *timeoutStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, cancel := withQueryTimeout(ctx, s.timeout)
+	rows, err := s.baseStmt.QueryContext(ctx, args)
+	if err != nil {
+		cancel()
+		return nil, timeoutError(ctx, err)
+	}
+	return &timeoutRows{Rows: rows, ctx: ctx, cancel: cancel}, nil
+}
+
+// timeoutRows はクエリの期限を行を閉じるまで保持する driver.Rows のラッパーです
+// 列の型の情報（ColumnTypes）と複数の結果セットは、元の行が実装していれば委譲します
+type timeoutRows struct {
+	driver.Rows
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Next は次の行を読み込みます（期限を過ぎた場合は domainerr.Timeout を返します）
+func (r *timeoutRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if errors.Is(err, io.EOF) {
+		return err
+	}
+	return timeoutError(r.ctx, err)
+}
+
+// Close は行を閉じ、クエリの期限を解放します
+func (r *timeoutRows) Close() error {
+	defer r.cancel()
+	return r.Rows.Close()
+}
+
+// HasNextResultSet は次の結果セットがあるかを返します（driver.RowsNextResultSet）
+func (r *timeoutRows) HasNextResultSet() bool {
+	next, ok := r.Rows.(driver.RowsNextResultSet)
+	return ok && next.HasNextResultSet()
+}
+
+// NextResultSet は次の結果セットに進みます（driver.RowsNextResultSet）
+func (r *timeoutRows) NextResultSet() error {
+	if next, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return timeoutError(r.ctx, next.NextResultSet())
+	}
+	return io.EOF
+}
+
+// ColumnTypeScanType は列のスキャンに適した型を返します（driver.RowsColumnTypeScanType）
+// 元の行が実装していない場合は、database/sql の既定と同じ any を返します
+func (r *timeoutRows) ColumnTypeScanType(index int) reflect.Type {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return typed.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf((*any)(nil)).Elem()
+}
+
+// ColumnTypeDatabaseTypeName はデータベースでの列の型名を返します（driver.RowsColumnTypeDatabaseTypeName）
+func (r *timeoutRows) ColumnTypeDatabaseTypeName(index int) string {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return typed.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+// ColumnTypeLength は可変長の列の長さを返します（driver.RowsColumnTypeLength）
+func (r *timeoutRows) ColumnTypeLength(index int) (int64, bool) {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return typed.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+// ColumnTypeNullable は列が NULL を許容するかを返します（driver.RowsColumnTypeNullable）
+func (r *timeoutRows) ColumnTypeNullable(index int) (bool, bool) {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return typed.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+// ColumnTypePrecisionScale は数値の列の精度と位取りを返します（driver.RowsColumnTypePrecisionScale）
+func (r *timeoutRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if typed, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return typed.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+)
+
+// slowQuery は SQLite で数秒かかる集計です（再帰CTEで1億行を数える）
+const slowQuery = `WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < 100000000) SELECT COUNT(*) FROM c`
+
+// TestTimeoutConnector は期限を過ぎたクエリが domainerr.KindTimeout のエラーで打ち切られることをテストします
+func TestTimeoutConnector(t *testing.T) {
+	db := sql.OpenDB(NewTimeoutConnector(dsnConnector{driver: &sqlite3.SQLiteDriver{}, dsn: ":memory:"}, 50*time.Millisecond))
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	ctx := context.Background()
+
+	// 期限内に終わるクエリと、結果の行の読み込みは通常どおり
+	if _, err := db.ExecContext(ctx, `CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO items (name) VALUES ('a'), ('b')`); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	var count int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM items`).Scan(&count); err != nil || count != 2 {
+		t.Fatalf("COUNT(*) = %d, %v, 期待値 = 2", count, err)
+	}
+
+	// 期限を過ぎたクエリは打ち切られ、期限切れのエラーになる
+	start := time.Now()
+	err := db.QueryRowContext(ctx, slowQuery).Scan(&count)
+	if !domainerr.IsTimeout(err) {
+		t.Fatalf("遅いクエリの error = %v, 期待値 = timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("クエリの打ち切りまで %s かかりました", elapsed)
+	}
+
+	// トランザクション内のクエリも打ち切られ、トランザクション自体は期限で終わらない
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	if err := tx.QueryRowContext(ctx, slowQuery).Scan(&count); !domainerr.IsTimeout(err) {
+		t.Errorf("トランザクション内の遅いクエリの error = %v, 期待値 = timeout", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := tx.ExecContext(ctx, `INSERT INTO items (name) VALUES ('c')`); err != nil {
+		t.Errorf("期限より長いトランザクションの ExecContext() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("Commit() error = %v", err)
+	}
+
+	// WithoutQueryTimeout では期限を設けない（呼び出し元のキャンセルは有効）
+	cancelled, cancel := context.WithTimeout(WithoutQueryTimeout(ctx), 300*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	err = db.QueryRowContext(cancelled, slowQuery).Scan(&count)
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("WithoutQueryTimeout のクエリが %s で打ち切られました (error = %v)", elapsed, err)
+	}
+	if err == nil {
+		t.Error("呼び出し元の期限を過ぎたクエリが成功しました")
+	}
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// rebindConnector は全てのクエリの ? のプレースホルダーを方言の形式（$1, $2 ...）に書き換える driver.Connector のラッパーです
+// リポジトリのSQLを ? のまま、番号付きのプレースホルダーを使うエンジンでも実行できるようにします
+type rebindConnector struct {
+	base    driver.Connector
+	dialect sqlrepo.Dialect
+}
+
+// NewRebindConnector は実行するクエリを dialect.Rebind で書き換えるコネクターを返します
+// ? のプレースホルダーを使う方言の場合は base をそのまま返します
+func NewRebindConnector(base driver.Connector, dialect sqlrepo.Dialect) driver.Connector {
+	if !dialect.NumberedPlaceholders {
+		return base
+	}
+	return &rebindConnector{base: base, dialect: dialect}
+}
+
+// Connect は新しい接続を確立し、クエリを書き換えるラッパーで包みます
+func (c *rebindConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rebindConn{Conn: conn, dialect: c.dialect}, nil
+}
+
+// Driver は元のドライバーを返します（driver.Connector の実装）
+func (c *rebindConnector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// rebindConn はクエリを書き換えてから実行する driver.Conn のラッパーです
+// database/sql が利用する任意のインターフェースは、元の接続が実装していれば委譲します（timeoutConn と同じ）
+type rebindConn struct {
+	driver.Conn
+	dialect sqlrepo.Dialect
+}
+
+// Prepare はクエリを書き換えてステートメントを準備します
+func (c *rebindConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(c.dialect.Rebind(query))
+}
+
+// PrepareContext はクエリを書き換えてステートメントを準備します（driver.ConnPrepareContext）
+func (c *rebindConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, c.dialect.Rebind(query))
+	}
+	return c.Prepare(query)
+}
+
+// BeginTx はトランザクションを開始します（driver.ConnBeginTx）
+func (c *rebindConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // ConnBeginTx を実装しないドライバー向けの代替
+}
+
+// ExecContext はクエリを書き換えて実行します（driver.ExecerContext）
+func (c *rebindConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return execer.ExecContext(ctx, c.dialect.Rebind(query), args)
+}
+
+// QueryContext はクエリを書き換えて実行します（driver.QueryerContext）
+func (c *rebindConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return queryer.QueryContext(ctx, c.dialect.Rebind(query), args)
+}
+
+// Ping は接続を確認します（driver.Pinger）
+func (c *rebindConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession はプールから再利用する前に呼ばれます（driver.SessionResetter）
+func (c *rebindConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid はプールに戻す際に呼ばれます（driver.Validator）
+func (c *rebindConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue は引数の型変換を元の接続に任せます（driver.NamedValueChecker）
+func (c *rebindConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// TestRebindConnector は ? で書いたクエリが番号付きのプレースホルダーに書き換えて実行されることをテストします
+// SQLiteは $1 の形式も受け付けるため、Postgres の方言で書き換えたクエリをそのまま実行して確認します
+func TestRebindConnector(t *testing.T) {
+	base := dsnConnector{driver: &sqlite3.SQLiteDriver{}, dsn: ":memory:"}
+	if NewRebindConnector(base, sqlrepo.SQLite) != driver.Connector(base) {
+		t.Error("? の方言でコネクターが包まれました")
+	}
+
+	db := sql.OpenDB(NewRebindConnector(base, sqlrepo.Postgres))
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	ctx := context.Background()
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	// 文字列リテラルの中の ? はそのまま保存される
+	if _, err := db.ExecContext(ctx, `INSERT INTO items (id, name) VALUES (?, ?), (?, 'what?')`, 1, "a", 2); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+
+	// 準備したステートメントとトランザクション内のクエリも書き換える
+	stmt, err := db.PrepareContext(ctx, `SELECT name FROM items WHERE id = ?`)
+	if err != nil {
+		t.Fatalf("PrepareContext() error = %v", err)
+	}
+	defer stmt.Close()
+	var name string
+	if err := stmt.QueryRowContext(ctx, 2).Scan(&name); err != nil || name != "what?" {
+		t.Errorf("id=2 の name = %q, %v, 期待値 = %q", name, err, "what?")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM items WHERE id >= ? AND name <> ?`, 1, "a").Scan(&count); err != nil || count != 1 {
+		t.Errorf("トランザクション内の件数 = %d, %v, 期待値 = 1", count, err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("Commit() error = %v", err)
+	}
+}
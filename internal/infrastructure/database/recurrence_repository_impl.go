@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// recurrenceRepositoryImpl は todos テーブルの繰り返し関連の列を扱う
+// RecurrenceRepository インターフェースの実装です
+type recurrenceRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewRecurrenceRepository はrecurrenceRepositoryImplのコンストラクタです
+func NewRecurrenceRepository(db *sql.DB) repository.RecurrenceRepository {
+	return &recurrenceRepositoryImpl{
+		db: db,
+	}
+}
+
+// ListSeries は繰り返しTodoのシリーズを取得します
+// オカレンス自身（recurrence_parent_id を持つ行）は繰り返し設定を持たないため対象外になります
+// 削除済みのシリーズも対象外です（復元すると、次の実行から再びオカレンスが作成されます）
+func (r *recurrenceRepositoryImpl) ListSeries(ctx context.Context) ([]*entity.Todo, error) {
+	query := `
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.recurrence <> '' AND t.due_date IS NOT NULL AND t.recurrence_parent_id IS NULL AND ` + liveTodoCondition + `
+		ORDER BY t.id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring todos: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
+
+// LatestOccurrence はシリーズの最新オカレンスの期限日を取得します
+// MAX() の結果はドライバーによって文字列で返ることがあるため、
+// 並び替えて先頭1件の列を直接読み取ります
+// 削除済みのオカレンスも含めるため、利用者が削除したオカレンスが作成し直されることはありません
+func (r *recurrenceRepositoryImpl) LatestOccurrence(ctx context.Context, seriesID int) (*time.Time, error) {
+	query := `
+		SELECT due_date FROM todos
+		WHERE recurrence_parent_id = ? AND due_date IS NOT NULL
+		ORDER BY due_date DESC
+		LIMIT 1
+	`
+
+	var dueDate time.Time
+	err := r.db.QueryRowContext(ctx, query, seriesID).Scan(&dueDate)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest occurrence: %w", err)
+	}
+
+	dueDate = dueDate.UTC()
+	return &dueDate, nil
+}
+
+// CreateOccurrences はオカレンスをトランザクション内でまとめて作成します
+// 作成されたIDは各エンティティに設定されます
+func (r *recurrenceRepositoryImpl) CreateOccurrences(ctx context.Context, occurrences []*entity.Todo) error {
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	query := `
+		INSERT INTO todos (title, description, is_completed, due_date, recurrence, recurrence_parent_id, user_id, workspace_id, created_at, updated_at)
+		VALUES (?, ?, false, ?, '', ?, ?, ?, ?, ?)
+	`
+
+	return sqlrepo.InTx(ctx, r.db, "occurrences", func(tx *sql.Tx) error {
+		for _, occurrence := range occurrences {
+			result, err := tx.ExecContext(ctx, query,
+				occurrence.Title,
+				occurrence.Description,
+				nullableTime(occurrence.DueDate),
+				nullableInt(occurrence.RecurrenceParentID),
+				nullableInt(occurrence.UserID),
+				nullableInt(occurrence.WorkspaceID),
+				now,
+				now,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create occurrence: %w", err)
+			}
+
+			id, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to get last insert id: %w", err)
+			}
+			occurrence.ID = int(id)
+			occurrence.CreatedAt = now
+			occurrence.UpdatedAt = now
+		}
+		return nil
+	})
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestRecurrenceRepository_Flow はシリーズの取得・オカレンスの作成・最新期限日の取得をテストします
+func TestRecurrenceRepository_Flow(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewRecurrenceRepository(db)
+	ctx := context.Background()
+
+	due := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	series, err := todoRepo.Create(ctx, &entity.Todo{Title: "日報", DueDate: &due, Recurrence: entity.RecurrenceDaily})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	if _, err := todoRepo.Create(ctx, &entity.Todo{Title: "単発", DueDate: &due}); err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	seriesList, err := repo.ListSeries(ctx)
+	if err != nil {
+		t.Fatalf("シリーズの取得に失敗: %v", err)
+	}
+	if len(seriesList) != 1 || seriesList[0].ID != series.ID {
+		t.Fatalf("シリーズ = %v, 期待値 = [%d]", seriesList, series.ID)
+	}
+	if seriesList[0].Recurrence != entity.RecurrenceDaily || seriesList[0].DueDate == nil || !seriesList[0].DueDate.Equal(due) {
+		t.Errorf("繰り返し設定が復元されていません: %+v", seriesList[0])
+	}
+
+	latest, err := repo.LatestOccurrence(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("最新オカレンスの取得に失敗: %v", err)
+	}
+	if latest != nil {
+		t.Errorf("オカレンスがない場合は nil であるべきです: %v", latest)
+	}
+
+	occurrences := []*entity.Todo{
+		seriesList[0].NewOccurrence(due.AddDate(0, 0, 1)),
+		seriesList[0].NewOccurrence(due.AddDate(0, 0, 2)),
+	}
+	if err := repo.CreateOccurrences(ctx, occurrences); err != nil {
+		t.Fatalf("オカレンスの作成に失敗: %v", err)
+	}
+	if occurrences[0].ID == 0 || occurrences[1].ID == 0 {
+		t.Errorf("作成されたIDが設定されていません")
+	}
+
+	latest, err = repo.LatestOccurrence(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("最新オカレンスの取得に失敗: %v", err)
+	}
+	if latest == nil || !latest.Equal(due.AddDate(0, 0, 2)) {
+		t.Errorf("最新の期限日 = %v, 期待値 = %v", latest, due.AddDate(0, 0, 2))
+	}
+
+	// オカレンスはシリーズとして扱われない
+	seriesList, err = repo.ListSeries(ctx)
+	if err != nil {
+		t.Fatalf("シリーズの取得に失敗: %v", err)
+	}
+	if len(seriesList) != 1 {
+		t.Errorf("シリーズ件数 = %d, 期待値 = 1", len(seriesList))
+	}
+
+	occurrence, err := todoRepo.GetByID(ctx, occurrences[0].ID)
+	if err != nil {
+		t.Fatalf("オカレンスの取得に失敗: %v", err)
+	}
+	if occurrence.RecurrenceParentID == nil || *occurrence.RecurrenceParentID != series.ID {
+		t.Errorf("RecurrenceParentID = %v, 期待値 = %d", occurrence.RecurrenceParentID, series.ID)
+	}
+}
+
+// TestRecurrenceRepository_CreateOccurrences_Duplicate は同じ期限日のオカレンスが二重に作成されないことをテストします
+func TestRecurrenceRepository_CreateOccurrences_Duplicate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewRecurrenceRepository(db)
+	ctx := context.Background()
+
+	due := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	series, err := todoRepo.Create(ctx, &entity.Todo{Title: "週報", DueDate: &due, Recurrence: entity.RecurrenceWeekly})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	next := due.AddDate(0, 0, 7)
+	if err := repo.CreateOccurrences(ctx, []*entity.Todo{series.NewOccurrence(next)}); err != nil {
+		t.Fatalf("オカレンスの作成に失敗: %v", err)
+	}
+
+	// 重複を含むバッチは全体がロールバックされる
+	batch := []*entity.Todo{series.NewOccurrence(next.AddDate(0, 0, 7)), series.NewOccurrence(next)}
+	if err := repo.CreateOccurrences(ctx, batch); err == nil {
+		t.Fatal("重複したオカレンスの作成はエラーになるべきです")
+	}
+
+	latest, err := repo.LatestOccurrence(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("最新オカレンスの取得に失敗: %v", err)
+	}
+	if latest == nil || !latest.Equal(next) {
+		t.Errorf("ロールバックされていません: 最新の期限日 = %v, 期待値 = %v", latest, next)
+	}
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// refreshTokenColumns は refresh_tokens テーブルのSELECT対象列です（scanRefreshToken が列名で対応付けます）
+const refreshTokenColumns = `id, user_id, family_id, token_hash, created_at, expires_at, used_at, revoked_at`
+
+// refreshTokenRepositoryImpl は refresh_tokens テーブルを使用した
+// RefreshTokenRepository インターフェースの実装です
+type refreshTokenRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewRefreshTokenRepository はrefreshTokenRepositoryImplのコンストラクタです
+func NewRefreshTokenRepository(db *sql.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create は記録を保存します
+func (r *refreshTokenRepositoryImpl) Create(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO refresh_tokens (user_id, family_id, token_hash, created_at, expires_at) VALUES (?, ?, ?, ?, ?)`,
+		token.UserID, token.FamilyID, token.TokenHash, token.CreatedAt.UTC().Truncate(time.Second), token.ExpiresAt.UTC().Truncate(time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert refresh token: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+	token.ID = int(id)
+	return token, nil
+}
+
+// GetByHash はトークンのハッシュで記録を取得します
+func (r *refreshTokenRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = ?`, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query refresh token: %w", err)
+	}
+
+	token, err := sqlrepo.ScanOne(rows, scanRefreshToken)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.NotFound("refresh token", nil)
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// MarkUsed は未使用・未失効の記録に使用日時を記録します
+// 条件付きの UPDATE で判定するため、同じトークンで同時にリフレッシュしても1つだけが成功します
+func (r *refreshTokenRepositoryImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	return sqlrepo.ExecAffecting(ctx, r.db, "mark refresh token as used", repository.ErrRefreshTokenUsed,
+		`UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL`, usedAt.UTC().Truncate(time.Second), id)
+}
+
+// RevokeFamily は系列の未失効の記録を全て失効させます
+func (r *refreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`,
+		revokedAt.UTC().Truncate(time.Second), familyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// DeleteExpired は有効期限が before 以前の記録を削除します
+func (r *refreshTokenRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE expires_at <= ?`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired refresh tokens: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// scanRefreshToken は1行を列名で対応付けて RefreshToken にスキャンします
+func scanRefreshToken(rows *sql.Rows) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	var usedAt, revokedAt sql.NullTime
+	if err := sqlrepo.ScanColumns(rows, "refresh_tokens", sqlrepo.Columns{
+		"id":         &token.ID,
+		"user_id":    &token.UserID,
+		"family_id":  &token.FamilyID,
+		"token_hash": &token.TokenHash,
+		"created_at": &token.CreatedAt,
+		"expires_at": &token.ExpiresAt,
+		"used_at":    &usedAt,
+		"revoked_at": &revokedAt,
+	}, "id", "user_id", "family_id", "token_hash", "expires_at"); err != nil {
+		return nil, err
+	}
+
+	token.CreatedAt = token.CreatedAt.UTC()
+	token.ExpiresAt = token.ExpiresAt.UTC()
+	if usedAt.Valid {
+		used := usedAt.Time.UTC()
+		token.UsedAt = &used
+	}
+	if revokedAt.Valid {
+		revoked := revokedAt.Time.UTC()
+		token.RevokedAt = &revoked
+	}
+	return &token, nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestRefreshTokenRepository はリフレッシュトークンの保存・使用済みの記録・系列の失効・削除をテストします
+func TestRefreshTokenRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	user, err := NewUserRepository(db).Create(context.Background(), &entity.User{Username: "alice", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("ユーザーの作成に失敗: %v", err)
+	}
+	repo := NewRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	first, err := repo.Create(ctx, &entity.RefreshToken{UserID: user.ID, FamilyID: "family-1", TokenHash: "hash-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+	if _, err := repo.Create(ctx, &entity.RefreshToken{UserID: user.ID, FamilyID: "family-1", TokenHash: "hash-2", CreatedAt: now, ExpiresAt: now.Add(2 * time.Hour)}); err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+
+	got, err := repo.GetByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if got.ID != first.ID || got.UserID != user.ID || got.UsedAt != nil || got.RevokedAt != nil || !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("取得した記録 = %+v", got)
+	}
+	if _, err := repo.GetByHash(ctx, "unknown"); !domainerr.IsNotFound(err) {
+		t.Errorf("存在しないトークン error = %v, 期待値 = not found", err)
+	}
+
+	// 使用済みの記録は1回だけ成功する
+	if err := repo.MarkUsed(ctx, first.ID, now); err != nil {
+		t.Fatalf("MarkUsed() error = %v", err)
+	}
+	if err := repo.MarkUsed(ctx, first.ID, now); !errors.Is(err, repository.ErrRefreshTokenUsed) {
+		t.Errorf("2回目の MarkUsed() error = %v, 期待値 = ErrRefreshTokenUsed", err)
+	}
+	if got, _ := repo.GetByHash(ctx, "hash-1"); got.UsedAt == nil || !got.UsedAt.Equal(now) {
+		t.Errorf("使用日時 = %v, 期待値 = %v", got.UsedAt, now)
+	}
+
+	// 系列の失効は未失効の記録だけが対象で、失効後は使用済みにできない
+	if n, err := repo.RevokeFamily(ctx, "family-1", now); err != nil || n != 2 {
+		t.Fatalf("RevokeFamily() = %d, %v, 期待値 = 2", n, err)
+	}
+	if n, err := repo.RevokeFamily(ctx, "family-1", now); err != nil || n != 0 {
+		t.Errorf("2回目の RevokeFamily() = %d, %v, 期待値 = 0", n, err)
+	}
+	second, _ := repo.GetByHash(ctx, "hash-2")
+	if second.RevokedAt == nil {
+		t.Errorf("系列の記録が失効していません: %+v", second)
+	}
+	if err := repo.MarkUsed(ctx, second.ID, now); !errors.Is(err, repository.ErrRefreshTokenUsed) {
+		t.Errorf("失効した記録の MarkUsed() error = %v, 期待値 = ErrRefreshTokenUsed", err)
+	}
+
+	// 有効期限を過ぎた記録だけを削除する
+	if n, err := repo.DeleteExpired(ctx, now.Add(time.Hour)); err != nil || n != 1 {
+		t.Fatalf("DeleteExpired() = %d, %v, 期待値 = 1", n, err)
+	}
+	if _, err := repo.GetByHash(ctx, "hash-2"); err != nil {
+		t.Errorf("有効期限内の記録が削除されました: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// reminderRepositoryImpl は todos テーブルの remind_at 列を扱う
+// ReminderRepository インターフェースの実装です
+type reminderRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewReminderRepository はreminderRepositoryImplのコンストラクタです
+func NewReminderRepository(db *sql.DB) repository.ReminderRepository {
+	return &reminderRepositoryImpl{
+		db: db,
+	}
+}
+
+// ListDue は通知時刻を過ぎた未完了Todoを取得します（削除済みのTodoは通知しません）
+// remind_at のインデックスを利用できるよう、範囲条件と並び順は remind_at のみで指定します
+func (r *reminderRepositoryImpl) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.Todo, error) {
+	query := `
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.remind_at IS NOT NULL AND t.remind_at <= ? AND t.is_completed = ? AND ` + liveTodoCondition + `
+		ORDER BY t.remind_at ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now.UTC(), false, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due reminders: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
+
+// SetRemindAt はTodoの通知時刻を設定または解除します
+// リマインダーの操作はTodoの内容の変更ではないため、updated_at は更新しません
+// 所有者が設定されている場合、他のユーザーのTodoは "todo not found" になります
+func (r *reminderRepositoryImpl) SetRemindAt(ctx context.Context, todoID int, remindAt *time.Time) error {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	return sqlrepo.ExecAffecting(ctx, r.db, "update reminder", domainerr.NotFound("todo", nil),
+		`UPDATE todos SET remind_at = ? WHERE id = ? AND deleted_at IS NULL AND `+owner, append([]any{nullableTime(remindAt), todoID}, ownerArgs...)...)
+}
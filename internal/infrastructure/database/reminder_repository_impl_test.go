@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestReminderRepository_ListDue は通知時刻を過ぎた未完了Todoのみが取得されることをテストします
+func TestReminderRepository_ListDue(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewReminderRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	create := func(title string, remindAt *time.Time, completed bool) *entity.Todo {
+		todo := &entity.Todo{Title: title, RemindAt: remindAt}
+		created, err := todoRepo.Create(ctx, todo)
+		if err != nil {
+			t.Fatalf("テストデータの作成に失敗: %v", err)
+		}
+		if completed {
+			created.MarkAsCompleted()
+			if _, err := todoRepo.Update(ctx, created); err != nil {
+				t.Fatalf("テストデータの更新に失敗: %v", err)
+			}
+		}
+		return created
+	}
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+
+	later := create("後で通知", at(-time.Minute), false)
+	earlier := create("先に通知", at(-time.Hour), false)
+	create("未来の通知", at(time.Hour), false)
+	create("完了済み", at(-time.Hour), true)
+	create("リマインダーなし", nil, false)
+
+	todos, err := repo.ListDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("件数 = %d, 期待値 = 2", len(todos))
+	}
+	if todos[0].ID != earlier.ID || todos[1].ID != later.ID {
+		t.Errorf("通知時刻の昇順になっていません: %v, %v", todos[0].Title, todos[1].Title)
+	}
+	if todos[0].RemindAt == nil || !todos[0].RemindAt.Equal(*at(-time.Hour)) {
+		t.Errorf("RemindAt = %v, 期待値 = %v", todos[0].RemindAt, at(-time.Hour))
+	}
+
+	// limit が適用されること
+	todos, err = repo.ListDue(ctx, now, 1)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if len(todos) != 1 {
+		t.Errorf("件数 = %d, 期待値 = 1", len(todos))
+	}
+}
+
+// TestReminderRepository_SetRemindAt は通知時刻の設定と解除をテストします
+func TestReminderRepository_SetRemindAt(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewReminderRepository(db)
+	ctx := context.Background()
+
+	todo, err := todoRepo.Create(ctx, &entity.Todo{Title: "スヌーズ対象"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	remindAt := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	if err := repo.SetRemindAt(ctx, todo.ID, &remindAt); err != nil {
+		t.Fatalf("設定に失敗: %v", err)
+	}
+	fetched, err := todoRepo.GetByID(ctx, todo.ID)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if fetched.RemindAt == nil || !fetched.RemindAt.Equal(remindAt) {
+		t.Errorf("RemindAt = %v, 期待値 = %v", fetched.RemindAt, remindAt)
+	}
+
+	if err := repo.SetRemindAt(ctx, todo.ID, nil); err != nil {
+		t.Fatalf("解除に失敗: %v", err)
+	}
+	fetched, err = todoRepo.GetByID(ctx, todo.ID)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if fetched.RemindAt != nil {
+		t.Errorf("RemindAt = %v, 期待値 = nil", fetched.RemindAt)
+	}
+
+	if err := repo.SetRemindAt(ctx, todo.ID+100, nil); err == nil {
+		t.Error("存在しないTodoでエラーが期待されました")
+	}
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// TestTodoRepository_NewerSchema はDBに未知の列が追加されていてもTodoを読み込めることをテストします
+// （マイグレーション適用後、古いバイナリがまだ動いている状態）
+func TestTodoRepository_NewerSchema(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "移行中", Color: entity.Color("#1e90ff")})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	// 途中の列として追加されても、末尾に追加されても位置には依存しない
+	if _, err := db.Exec(`ALTER TABLE todos ADD COLUMN priority INTEGER NOT NULL DEFAULT 3`); err != nil {
+		t.Fatalf("列の追加に失敗: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != "移行中" || got.Color != entity.Color("#1e90ff") {
+		t.Errorf("GetByID() = %+v, 期待値のタイトル = 移行中, 色 = #1e90ff", got)
+	}
+
+	todos, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if len(todos) != 1 || todos[0].ID != created.ID {
+		t.Errorf("GetAll() の件数 = %d, 期待値 = 1", len(todos))
+	}
+}
+
+// TestTodoRepository_OlderSchema はDBに任意の列がなくても、ゼロ値のまま読み込めることをテストします
+// （新しいバイナリを先にデプロイし、マイグレーションがまだの状態）
+func TestTodoRepository_OlderSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		ddl     string
+		insert  string
+		wantErr string
+	}{
+		{
+			name: "任意の列がない",
+			ddl: `CREATE TABLE todos (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				title TEXT NOT NULL,
+				description TEXT NOT NULL DEFAULT '',
+				is_completed BOOLEAN NOT NULL DEFAULT 0,
+				created_at DATETIME NOT NULL,
+				updated_at DATETIME NOT NULL
+			)`,
+			insert: `INSERT INTO todos (title, created_at, updated_at) VALUES ('古いDB', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`,
+		},
+		{
+			name: "必須の列がない",
+			ddl: `CREATE TABLE todos (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				title TEXT NOT NULL,
+				created_at DATETIME NOT NULL
+			)`,
+			insert:  `INSERT INTO todos (title, created_at) VALUES ('古いDB', CURRENT_TIMESTAMP)`,
+			wantErr: "todos.updated_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := sql.Open("sqlite3", ":memory:")
+			if err != nil {
+				t.Fatalf("テストデータベースの作成に失敗: %v", err)
+			}
+			defer db.Close()
+			db.SetMaxOpenConns(1)
+
+			for _, ddl := range []string{
+				tt.ddl,
+				`CREATE TABLE checklist_items (id INTEGER PRIMARY KEY, todo_id INTEGER NOT NULL, is_done BOOLEAN NOT NULL DEFAULT 0)`,
+			} {
+				if _, err := db.Exec(ddl); err != nil {
+					t.Fatalf("テーブルの作成に失敗: %v", err)
+				}
+			}
+			if _, err := db.Exec(tt.insert); err != nil {
+				t.Fatalf("テストデータの作成に失敗: %v", err)
+			}
+
+			got, err := NewTodoRepository(db).GetByID(context.Background(), 1)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("GetByID() error = %v, 期待値に含む文字列 = %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if got.Title != "古いDB" || got.Color != "" || got.DueDate != nil || got.EstimateMinutes != 0 {
+				t.Errorf("GetByID() = %+v, 期待値 = 任意の列はゼロ値", got)
+			}
+		})
+	}
+}
+
+// TestScanColumns はSELECT文の列の順序に関係なく、列名で対応付けてスキャンすることをテストします
+func TestScanColumns(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	project, err := NewProjectRepository(db).Create(ctx, &entity.Project{Name: "仕事", Slug: "work", Description: "説明"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "定義と同じ順序", query: `SELECT id, name, slug, description, created_at, updated_at FROM projects`},
+		{name: "逆の順序", query: `SELECT updated_at, created_at, description, slug, name, id FROM projects`},
+		{name: "任意の列の省略", query: `SELECT id, name, slug FROM projects`},
+		{name: "必須の列の省略", query: `SELECT id, name FROM projects`, wantErr: "projects.slug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := db.QueryContext(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("クエリの実行に失敗: %v", err)
+			}
+			got, err := sqlrepo.ScanOne(rows, scanProject)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("sqlrepo.ScanOne() error = %v, 期待値に含む文字列 = %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sqlrepo.ScanOne() error = %v", err)
+			}
+			if got.ID != project.ID || got.Name != "仕事" || got.Slug != "work" {
+				t.Errorf("sqlrepo.ScanOne() = %+v, 期待値 = ID %d の 仕事/work", got, project.ID)
+			}
+		})
+	}
+}
+
+// TestScanOneAndScanAll_NoRows は行がない場合の ScanOne と ScanAll の結果をテストします
+func TestScanOneAndScanAll_NoRows(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	query := `SELECT id, name, slug FROM projects`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		t.Fatalf("クエリの実行に失敗: %v", err)
+	}
+	if _, err := sqlrepo.ScanOne(rows, scanProject); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("sqlrepo.ScanOne() error = %v, 期待値 = sql.ErrNoRows", err)
+	}
+
+	rows, err = db.QueryContext(ctx, query)
+	if err != nil {
+		t.Fatalf("クエリの実行に失敗: %v", err)
+	}
+	projects, err := sqlrepo.ScanAll(rows, scanProject)
+	if err != nil {
+		t.Fatalf("sqlrepo.ScanAll() error = %v", err)
+	}
+	if projects == nil || len(projects) != 0 {
+		t.Errorf("sqlrepo.ScanAll() = %#v, 期待値 = 空スライス", projects)
+	}
+}
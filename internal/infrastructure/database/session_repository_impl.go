@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// sessionRepositoryImpl は sessions テーブルを使用した SessionRepository インターフェースの実装です
+// セッションはユーザーIDだけを保存し、ユーザー名は取得時に users テーブルから結合します
+type sessionRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewSessionRepository はsessionRepositoryImplのコンストラクタです
+func NewSessionRepository(db *sql.DB) repository.SessionRepository {
+	return &sessionRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create はセッションを保存します
+func (r *sessionRepositoryImpl) Create(ctx context.Context, session *entity.Session) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO sessions (token_hash, user_id, created_at, expires_at) VALUES (?, ?, ?, ?)`,
+		session.TokenHash, session.UserID, session.CreatedAt.UTC().Truncate(time.Second), session.ExpiresAt.UTC().Truncate(time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to insert session: %w", err)
+	}
+	return nil
+}
+
+// GetByHash はセッションIDのハッシュでセッションを取得します
+func (r *sessionRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*entity.Session, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.token_hash, s.user_id, u.username, s.created_at, s.expires_at
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.token_hash = ?`, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session: %w", err)
+	}
+
+	session, err := sqlrepo.ScanOne(rows, scanSession)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("session", nil)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// Delete はセッションを削除します
+func (r *sessionRepositoryImpl) Delete(ctx context.Context, tokenHash string) error {
+	return sqlrepo.ExecAffecting(ctx, r.db, "delete session", domainerr.NotFound("session", nil),
+		`DELETE FROM sessions WHERE token_hash = ?`, tokenHash)
+}
+
+// DeleteExpired は有効期限が before 以前のセッションを削除します
+func (r *sessionRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE expires_at <= ?`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// scanSession は1行を列名で対応付けて Session にスキャンします
+func scanSession(rows *sql.Rows) (*entity.Session, error) {
+	var session entity.Session
+	if err := sqlrepo.ScanColumns(rows, "sessions", sqlrepo.Columns{
+		"token_hash": &session.TokenHash,
+		"user_id":    &session.UserID,
+		"username":   &session.Username,
+		"created_at": &session.CreatedAt,
+		"expires_at": &session.ExpiresAt,
+	}, "token_hash", "user_id", "expires_at"); err != nil {
+		return nil, err
+	}
+	session.CreatedAt = session.CreatedAt.UTC()
+	session.ExpiresAt = session.ExpiresAt.UTC()
+	return &session, nil
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestSessionRepository はセッションの保存・ユーザー名を結合した取得・削除・期限切れの削除をテストします
+func TestSessionRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	user, err := NewUserRepository(db).Create(context.Background(), &entity.User{Username: "alice", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("ユーザーの作成に失敗: %v", err)
+	}
+	repo := NewSessionRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	for _, session := range []*entity.Session{
+		{TokenHash: "hash-1", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{TokenHash: "hash-2", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(2 * time.Hour)},
+	} {
+		if err := repo.Create(ctx, session); err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+	}
+
+	got, err := repo.GetByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if got.UserID != user.ID || got.Username != "alice" || !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("取得したセッション = %+v", got)
+	}
+	if _, err := repo.GetByHash(ctx, "unknown"); !domainerr.IsNotFound(err) {
+		t.Errorf("存在しないセッション error = %v, 期待値 = not found", err)
+	}
+
+	if err := repo.Delete(ctx, "hash-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete(ctx, "hash-1"); !domainerr.IsNotFound(err) {
+		t.Errorf("削除済みの Delete() error = %v, 期待値 = not found", err)
+	}
+
+	if n, err := repo.DeleteExpired(ctx, now.Add(2*time.Hour)); err != nil || n != 1 {
+		t.Errorf("DeleteExpired() = %d, %v, 期待値 = 1", n, err)
+	}
+	if _, err := repo.GetByHash(ctx, "hash-2"); !domainerr.IsNotFound(err) {
+		t.Errorf("期限切れの削除後の取得 error = %v, 期待値 = not found", err)
+	}
+}
@@ -0,0 +1,336 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"log"
+	"sort"
+	"strconv"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+	"todoapp-api-golang/pkg/config"
+)
+
+// defaultVirtualNodes は1シャードあたりに配置する仮想ノード数です
+// 仮想ノードを増やすほどキーの分布が均一になります
+const defaultVirtualNodes = 100
+
+// HashRing はコンシステントハッシュ法によるシャード選択を行う構造体です
+//
+// コンシステントハッシュの学習ポイント：
+// 1. キーとノードを同じハッシュ空間（リング）に配置する
+// 2. キーはリング上で時計回りに最初に見つかるノードへ割り当てる
+// 3. ノード追加・削除時に移動するキーが一部に限定される
+// 4. 仮想ノードによって偏りを抑える
+type HashRing struct {
+	virtualNodes int
+	hashes       []uint32          // ソート済みのハッシュ値（リング）
+	nodes        map[uint32]string // ハッシュ値 → ノード名
+}
+
+// NewHashRing はHashRingのコンストラクタです
+// virtualNodes が0以下の場合はデフォルト値を使用します
+func NewHashRing(virtualNodes int) *HashRing {
+	if virtualNodes <= 0 {
+		virtualNodes = defaultVirtualNodes
+	}
+	return &HashRing{
+		virtualNodes: virtualNodes,
+		nodes:        make(map[uint32]string),
+	}
+}
+
+// Add はリングにノードを追加します
+func (h *HashRing) Add(nodes ...string) {
+	for _, node := range nodes {
+		for i := 0; i < h.virtualNodes; i++ {
+			hash := crc32.ChecksumIEEE([]byte(node + "#" + strconv.Itoa(i)))
+			h.nodes[hash] = node
+			h.hashes = append(h.hashes, hash)
+		}
+	}
+	sort.Slice(h.hashes, func(i, j int) bool { return h.hashes[i] < h.hashes[j] })
+}
+
+// Get はキーを担当するノード名を返します
+// ノードが1つも登録されていない場合は空文字を返します
+func (h *HashRing) Get(key string) string {
+	if len(h.hashes) == 0 {
+		return ""
+	}
+
+	hash := crc32.ChecksumIEEE([]byte(key))
+
+	// 二分探索でハッシュ値以上の最初の位置を探す
+	idx := sort.Search(len(h.hashes), func(i int) bool { return h.hashes[i] >= hash })
+	if idx == len(h.hashes) {
+		// リングの終端を超えた場合は先頭に戻る
+		idx = 0
+	}
+	return h.nodes[h.hashes[idx]]
+}
+
+// ShardedDatabaseManager は複数シャードのDatabaseManagerを束ねる構造体です
+// 接続、テーブル作成、ヘルスチェックを全シャードに対して実行します
+type ShardedDatabaseManager struct {
+	ring     *HashRing
+	names    []string                    // 設定順のシャード名
+	managers map[string]*DatabaseManager // シャード名 → 接続管理
+}
+
+// NewShardedDatabaseManager はShardedDatabaseManagerのコンストラクタです
+// cfg.Database.Shards に設定された各シャードごとにDatabaseManagerを作成します
+func NewShardedDatabaseManager(cfg *config.Config) *ShardedDatabaseManager {
+	sm := &ShardedDatabaseManager{
+		ring:     NewHashRing(defaultVirtualNodes),
+		managers: make(map[string]*DatabaseManager),
+	}
+
+	for _, shard := range cfg.Database.Shards {
+		sm.names = append(sm.names, shard.Name)
+		sm.managers[shard.Name] = NewDatabaseManager(cfg.ShardConfigFor(shard))
+		sm.ring.Add(shard.Name)
+	}
+
+	return sm
+}
+
+// Connect は全シャードへの接続を確立します
+// 1つでも失敗した場合は確立済みの接続を閉じてエラーを返します
+func (sm *ShardedDatabaseManager) Connect() error {
+	for _, name := range sm.names {
+		if err := sm.managers[name].Connect(); err != nil {
+			sm.Close()
+			return fmt.Errorf("failed to connect shard %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Migrate は全シャードに同じマイグレーションを適用します（シャード対応マイグレーション）
+// 適用済みのバージョンはシャードごとに記録するため、途中で失敗しても再実行すれば残りのシャードだけに適用されます
+func (sm *ShardedDatabaseManager) Migrate(ctx context.Context) error {
+	for _, name := range sm.names {
+		if err := sm.managers[name].Migrate(ctx); err != nil {
+			return fmt.Errorf("failed to migrate shard %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// HealthCheck は全シャードの健全性をチェックします
+// 異常なシャードが複数ある場合はすべてのエラーをまとめて返します
+func (sm *ShardedDatabaseManager) HealthCheck() error {
+	var errs []error
+	for _, name := range sm.names {
+		if err := sm.managers[name].HealthCheck(); err != nil {
+			errs = append(errs, fmt.Errorf("shard %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HealthStatus はヘルスチェックエンドポイント向けに全シャードの接続状態を返します
+// 詳細はシャード名ごとの状態で、異常なシャードがある場合はそれらのエラーをまとめて返します
+func (sm *ShardedDatabaseManager) HealthStatus(ctx context.Context) (any, error) {
+	details := make(map[string]any, len(sm.names))
+	var errs []error
+	for _, name := range sm.names {
+		status, err := sm.managers[name].HealthStatus(ctx)
+		details[name] = status
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shard %s: %w", name, err))
+		}
+	}
+	return details, errors.Join(errs...)
+}
+
+// Close は全シャードの接続を閉じます
+func (sm *ShardedDatabaseManager) Close() error {
+	var errs []error
+	for _, name := range sm.names {
+		if err := sm.managers[name].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("shard %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ShardFor はユーザーIDを担当するシャード名を返します
+func (sm *ShardedDatabaseManager) ShardFor(userID int) string {
+	return sm.ring.Get(strconv.Itoa(userID))
+}
+
+// DBFor はユーザーIDを担当するシャードの *sql.DB を返します
+func (sm *ShardedDatabaseManager) DBFor(userID int) *sql.DB {
+	return sm.managers[sm.ShardFor(userID)].DB
+}
+
+// ShardCount は設定されているシャード数を返します
+func (sm *ShardedDatabaseManager) ShardCount() int {
+	return len(sm.names)
+}
+
+// TodoRepositoryFactory はユーザーIDからシャードを解決して
+// TodoRepository を生成するファクトリーです
+type TodoRepositoryFactory struct {
+	shards *ShardedDatabaseManager
+	repos  map[string]repository.TodoRepository
+}
+
+// NewTodoRepositoryFactory はTodoRepositoryFactoryのコンストラクタです
+// Connect() 済みの ShardedDatabaseManager を受け取り、opts は全シャードのリポジトリに適用します
+func NewTodoRepositoryFactory(shards *ShardedDatabaseManager, opts ...TodoRepositoryOption) *TodoRepositoryFactory {
+	repos := make(map[string]repository.TodoRepository, shards.ShardCount())
+	for _, name := range shards.names {
+		repos[name] = NewTodoRepository(shards.managers[name].DB, opts...)
+	}
+
+	log.Printf("Todo repository factory initialized with %d shards", len(repos))
+	return &TodoRepositoryFactory{
+		shards: shards,
+		repos:  repos,
+	}
+}
+
+// ForUser はユーザーIDを担当するシャードのリポジトリを返します
+// 同じユーザーIDは常に同じシャードに解決されます
+func (f *TodoRepositoryFactory) ForUser(userID int) repository.TodoRepository {
+	return f.repos[f.shards.ShardFor(userID)]
+}
+
+// ForContext はコンテキストの所有者（認証されたユーザー）を担当するシャードのリポジトリを返します
+// 所有者が設定されていない場合（認証が無効な場合や、全ユーザーのデータを扱うワーカー）は、ユーザーID 0 のシャードに解決します
+func (f *TodoRepositoryFactory) ForContext(ctx context.Context) repository.TodoRepository {
+	userID, _ := repository.OwnerFromContext(ctx)
+	return f.ForUser(userID)
+}
+
+// Transactor はコンテキストの所有者を担当するシャードでトランザクションを開始する Transactor を返します
+// トランザクションに参加するのは同じシャードのTodoの書き込みだけです（チェックリスト等の共通のDBへの書き込みは参加しません）
+func (f *TodoRepositoryFactory) Transactor() repository.Transactor {
+	return shardedTransactor{factory: f}
+}
+
+// shardedTransactor は所有者のシャードの接続プールでトランザクションを開始する Transactor の実装です
+type shardedTransactor struct {
+	factory *TodoRepositoryFactory
+}
+
+// InTransaction は fn を所有者のシャードの1つのトランザクションで実行します
+func (t shardedTransactor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	userID, _ := repository.OwnerFromContext(ctx)
+	return sqlrepo.RunInTx(ctx, t.factory.shards.DBFor(userID), fn)
+}
+
+// shardedTodoRepository はリクエストごとに所有者のシャードのリポジトリへ処理を振り分ける TodoRepository の実装です
+// TodoService には1つのリポジトリとして注入し、どのシャードを使うかは呼び出しのコンテキストで決まります
+type shardedTodoRepository struct {
+	factory *TodoRepositoryFactory
+}
+
+// NewShardedTodoRepository はshardedTodoRepositoryのコンストラクタです
+// 所有者のいない操作（認証が無効な場合やワーカー）はユーザーID 0 のシャードだけを対象にするため、
+// 他のユーザーの共有されたTodoなど、所有者を外したコンテキストからは他のシャードのTodoを参照できません
+func NewShardedTodoRepository(factory *TodoRepositoryFactory) repository.TodoRepository {
+	return &shardedTodoRepository{factory: factory}
+}
+
+func (r *shardedTodoRepository) Create(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	return r.factory.ForContext(ctx).Create(ctx, todo)
+}
+
+func (r *shardedTodoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	return r.factory.ForContext(ctx).CreateMany(ctx, todos)
+}
+
+func (r *shardedTodoRepository) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
+	return r.factory.ForContext(ctx).GetByID(ctx, id)
+}
+
+func (r *shardedTodoRepository) Exists(ctx context.Context, id int) (bool, error) {
+	return r.factory.ForContext(ctx).Exists(ctx, id)
+}
+
+func (r *shardedTodoRepository) GetAll(ctx context.Context) ([]*entity.Todo, error) {
+	return r.factory.ForContext(ctx).GetAll(ctx)
+}
+
+func (r *shardedTodoRepository) GetByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	return r.factory.ForContext(ctx).GetByColor(ctx, color)
+}
+
+func (r *shardedTodoRepository) Find(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	return r.factory.ForContext(ctx).Find(ctx, filter, order)
+}
+
+func (r *shardedTodoRepository) Count(ctx context.Context, filter repository.TodoFilter) (int, error) {
+	return r.factory.ForContext(ctx).Count(ctx, filter)
+}
+
+func (r *shardedTodoRepository) CountGrouped(ctx context.Context, group repository.TodoGroup, filter repository.TodoFilter) ([]repository.TodoGroupCount, error) {
+	return r.factory.ForContext(ctx).CountGrouped(ctx, group, filter)
+}
+
+func (r *shardedTodoRepository) GetStats(ctx context.Context) (entity.TodoStats, error) {
+	return r.factory.ForContext(ctx).GetStats(ctx)
+}
+
+func (r *shardedTodoRepository) ExistsByTitle(ctx context.Context, title string, excludeID int) (bool, error) {
+	return r.factory.ForContext(ctx).ExistsByTitle(ctx, title, excludeID)
+}
+
+func (r *shardedTodoRepository) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	return r.factory.ForContext(ctx).Update(ctx, todo)
+}
+
+func (r *shardedTodoRepository) UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (*entity.Todo, error) {
+	return r.factory.ForContext(ctx).UpdateFields(ctx, todo, fields)
+}
+
+func (r *shardedTodoRepository) UpdateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	return r.factory.ForContext(ctx).UpdateMany(ctx, todos)
+}
+
+func (r *shardedTodoRepository) UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (*entity.Todo, error) {
+	return r.factory.ForContext(ctx).UpdateWithLock(ctx, id, mutate)
+}
+
+func (r *shardedTodoRepository) Upsert(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	return r.factory.ForContext(ctx).Upsert(ctx, todo)
+}
+
+func (r *shardedTodoRepository) Delete(ctx context.Context, id int) error {
+	return r.factory.ForContext(ctx).Delete(ctx, id)
+}
+
+func (r *shardedTodoRepository) GetDeleted(ctx context.Context) ([]*entity.Todo, error) {
+	return r.factory.ForContext(ctx).GetDeleted(ctx)
+}
+
+func (r *shardedTodoRepository) Restore(ctx context.Context, todo *entity.Todo) error {
+	return r.factory.ForContext(ctx).Restore(ctx, todo)
+}
+
+func (r *shardedTodoRepository) HardDelete(ctx context.Context, id int) error {
+	return r.factory.ForContext(ctx).HardDelete(ctx, id)
+}
+
+// コンシステントハッシュによるシャーディングの学習ポイント：
+//
+// 1. シャードキーの選択：
+//    - ユーザーIDのように、1ユーザーのデータが1シャードに収まるキーを選ぶ
+//    - シャードをまたぐ JOIN や集計は避ける設計にする
+//
+// 2. 剰余（id % N）方式との違い：
+//    - 剰余方式はシャード数の変更でほぼ全キーが移動する
+//    - コンシステントハッシュは移動するキーがおよそ 1/N に収まる
+//
+// 3. 運用上の注意：
+//    - スキーマ変更は全シャードに適用する必要がある
+//    - ヘルスチェックはシャードごとに結果を区別して報告する
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/pkg/config"
+)
+
+// TestHashRing_Get はコンシステントハッシュによるノード選択をテストします
+func TestHashRing_Get(t *testing.T) {
+	t.Run("ノード未登録の場合は空文字", func(t *testing.T) {
+		ring := NewHashRing(10)
+		if got := ring.Get("1"); got != "" {
+			t.Errorf("空文字が期待されましたが、%s が返されました", got)
+		}
+	})
+
+	t.Run("同じキーは常に同じノードに解決される", func(t *testing.T) {
+		ring := NewHashRing(50)
+		ring.Add("shard-a", "shard-b", "shard-c")
+
+		for i := 0; i < 100; i++ {
+			key := strconv.Itoa(i)
+			first := ring.Get(key)
+			if second := ring.Get(key); first != second {
+				t.Errorf("キー %s の解決結果が一致しません: %s != %s", key, first, second)
+			}
+		}
+	})
+
+	t.Run("全ノードにキーが分散される", func(t *testing.T) {
+		ring := NewHashRing(100)
+		ring.Add("shard-a", "shard-b", "shard-c")
+
+		counts := make(map[string]int)
+		for i := 0; i < 3000; i++ {
+			counts[ring.Get(strconv.Itoa(i))]++
+		}
+
+		for _, node := range []string{"shard-a", "shard-b", "shard-c"} {
+			if counts[node] == 0 {
+				t.Errorf("ノード %s にキーが割り当てられていません", node)
+			}
+		}
+	})
+
+	t.Run("ノード追加時に移動するキーは一部に限定される", func(t *testing.T) {
+		ring := NewHashRing(100)
+		ring.Add("shard-a", "shard-b", "shard-c")
+
+		before := make(map[string]string)
+		for i := 0; i < 3000; i++ {
+			key := fmt.Sprintf("user-%d", i)
+			before[key] = ring.Get(key)
+		}
+
+		ring.Add("shard-d")
+
+		moved := 0
+		for key, node := range before {
+			after := ring.Get(key)
+			if after != node {
+				// 移動先は必ず新しいノードであるべき
+				if after != "shard-d" {
+					t.Fatalf("キー %s が既存ノード間で移動しました: %s -> %s", key, node, after)
+				}
+				moved++
+			}
+		}
+
+		// 理想は 1/4 程度。剰余方式（ほぼ全件移動）と区別できれば十分
+		if moved == 0 || moved > len(before)/2 {
+			t.Errorf("移動したキー数が想定外です: %d / %d", moved, len(before))
+		}
+	})
+}
+
+// TestShardedTodoRepository はコンテキストの所有者ごとに担当するシャードのDBへ読み書きすることをテストします
+func TestShardedTodoRepository(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{Database: config.DatabaseConfig{
+		Driver:          "sqlite",
+		MaxOpenConns:    4,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 60,
+		Shards: []config.ShardConfig{
+			{Name: "shard-a", Database: filepath.Join(dir, "shard-a")},
+			{Name: "shard-b", Database: filepath.Join(dir, "shard-b")},
+		},
+	}}
+	ctx := context.Background()
+
+	shards := NewShardedDatabaseManager(cfg)
+	if err := shards.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer shards.Close()
+	if err := shards.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	// 異なるシャードに解決される2人のユーザーを選ぶ
+	alice, bob := 1, 2
+	for shards.ShardFor(bob) == shards.ShardFor(alice) {
+		bob++
+	}
+
+	factory := NewTodoRepositoryFactory(shards, WithSQLiteLocking())
+	repo := NewShardedTodoRepository(factory)
+	aliceCtx, bobCtx := repository.WithOwner(ctx, alice), repository.WithOwner(ctx, bob)
+	if _, err := repo.Create(aliceCtx, &entity.Todo{Title: "aliceのTodo"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := repo.Create(bobCtx, &entity.Todo{Title: "bobのTodo"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 各シャードのDBには担当するユーザーのTodoだけが保存されている
+	for userID, want := range map[int]string{alice: "aliceのTodo", bob: "bobのTodo"} {
+		todos, err := NewTodoRepository(shards.DBFor(userID)).GetAll(ctx)
+		if err != nil {
+			t.Fatalf("GetAll() error = %v", err)
+		}
+		if len(todos) != 1 || todos[0].Title != want {
+			t.Errorf("シャード %s のTodo = %+v, 期待値 = %s の1件", shards.ShardFor(userID), todos, want)
+		}
+	}
+	if todos, _ := repo.GetAll(bobCtx); len(todos) != 1 || todos[0].Title != "bobのTodo" {
+		t.Errorf("bob の一覧 = %+v", todos)
+	}
+
+	status, err := shards.HealthStatus(ctx)
+	if err != nil {
+		t.Fatalf("HealthStatus() error = %v", err)
+	}
+	if details := status.(map[string]any); len(details) != 2 {
+		t.Errorf("HealthStatus() = %+v, 期待値 = 2シャードの状態", details)
+	}
+}
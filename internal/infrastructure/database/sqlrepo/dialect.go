@@ -0,0 +1,116 @@
+package sqlrepo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect はデータベースエンジンごとのSQLの書き方の違いです
+//
+// リポジトリのSQLは ? のプレースホルダーで書き、エンジンごとに異なる部分（現在時刻・UPSERT）だけを Dialect から組み立てます
+// $1, $2 ... のプレースホルダーを使うエンジンでは、接続のラッパーが実行前に Rebind で書き換えます
+type Dialect struct {
+	// Name はエンジンの名前です（DB_DRIVER と同じ）
+	Name string
+
+	// NumberedPlaceholders は $1, $2 ... の形式のプレースホルダーを使うかどうかです（false の場合は ?）
+	NumberedPlaceholders bool
+
+	// Now はデータベースのサーバーの現在時刻（UTC）を返すSQLの式です
+	// リポジトリは時刻をパラメータとして渡すため通常は使いませんが、DEFAULT やサーバー側で完結する集計に使用します
+	Now string
+
+	// OnConflict は UPSERT に ON CONFLICT (...) DO UPDATE を使うかどうかです（false の場合は ON DUPLICATE KEY UPDATE）
+	OnConflict bool
+}
+
+// 標準で対応するエンジンの方言です
+var (
+	// MySQL は ? のプレースホルダーと ON DUPLICATE KEY UPDATE を使います
+	MySQL = Dialect{Name: "mysql", Now: "UTC_TIMESTAMP()"}
+
+	// SQLite は ? のプレースホルダーと ON CONFLICT（SQLite 3.24 以降）を使います
+	SQLite = Dialect{Name: "sqlite", Now: "CURRENT_TIMESTAMP", OnConflict: true}
+
+	// Postgres は $1 のプレースホルダーと ON CONFLICT を使います
+	Postgres = Dialect{Name: "postgres", NumberedPlaceholders: true, Now: "(NOW() AT TIME ZONE 'UTC')", OnConflict: true}
+)
+
+// Rebind は ? のプレースホルダーを方言の形式に書き換えます
+// 文字列リテラル（'...'）と引用符で囲んだ識別子（"..." / `...`）の中の ? は書き換えません
+func (d Dialect) Rebind(query string) string {
+	if !d.NumberedPlaceholders || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			// 引用符の中（'' のような2重の引用符のエスケープは、閉じてすぐ開くのと同じ扱いになる）
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// Upsert は columns に値を1行 INSERT し、keys の一意制約に違反した場合は update の列を INSERT しようとした値で置き換えるSQL文を返します
+// 値は columns の順に ? のプレースホルダーで渡します（MySQLの ON DUPLICATE KEY UPDATE は keys を使わず、テーブルの全ての一意制約が対象です）
+//
+//	query := dialect.Upsert("todo_shares", []string{"todo_id", "user_id", "permission", "created_at"},
+//		[]string{"todo_id", "user_id"}, []string{"permission"})
+func (d Dialect) Upsert(table string, columns, keys, update []string) string {
+	return d.UpsertIf(table, columns, keys, update, "")
+}
+
+// UpsertIf は Upsert と同じSQL文を返しますが、一意制約に違反した既存の行が condition を満たす場合だけ置き換えます
+// 満たさない場合は何も変更しません（エラーにもなりません）。condition が空の場合は Upsert と同じです
+//
+// condition では既存の行の列をテーブル名で修飾して参照し（"todos.user_id" など）、INSERT しようとした値は Inserted で参照します
+// プレースホルダーは使えません。また、MySQLでは列ごとに IF(condition, ...) で置き換えるため、condition は update の列を参照できません
+//
+//	query := dialect.UpsertIf("todos", columns, []string{"id"}, update,
+//		"todos.user_id = "+dialect.Inserted("user_id"))
+func (d Dialect) UpsertIf(table string, columns, keys, update []string, condition string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	query := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + placeholders + ")"
+
+	sets := make([]string, len(update))
+	for i, column := range update {
+		if !d.OnConflict && condition != "" {
+			sets[i] = column + " = IF(" + condition + ", " + d.Inserted(column) + ", " + column + ")"
+		} else {
+			sets[i] = column + " = " + d.Inserted(column)
+		}
+	}
+	if d.OnConflict {
+		query += " ON CONFLICT (" + strings.Join(keys, ", ") + ") DO UPDATE SET " + strings.Join(sets, ", ")
+		if condition != "" {
+			query += " WHERE " + condition
+		}
+		return query
+	}
+	return query + " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+
+// Inserted は UPSERT の更新部分で、INSERT しようとした値の列を参照する式を返します
+func (d Dialect) Inserted(column string) string {
+	if d.OnConflict {
+		return "excluded." + column
+	}
+	return "VALUES(" + column + ")"
+}
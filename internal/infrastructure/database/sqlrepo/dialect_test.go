@@ -0,0 +1,114 @@
+package sqlrepo
+
+import (
+	"testing"
+)
+
+// TestDialect_Rebind は ? のプレースホルダーが方言の形式に書き換えられることをテストします
+func TestDialect_Rebind(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		query    string
+		expected string
+	}{
+		{name: "MySQLは書き換えない", dialect: MySQL, query: `SELECT id FROM items WHERE id = ? AND rank > ?`, expected: `SELECT id FROM items WHERE id = ? AND rank > ?`},
+		{name: "番号付き", dialect: Postgres, query: `SELECT id FROM items WHERE id = ? AND rank > ?`, expected: `SELECT id FROM items WHERE id = $1 AND rank > $2`},
+		{name: "文字列リテラルの中は書き換えない", dialect: Postgres, query: `SELECT '?', 'it''s ?' FROM items WHERE name = ?`, expected: `SELECT '?', 'it''s ?' FROM items WHERE name = $1`},
+		{name: "引用符で囲んだ識別子の中は書き換えない", dialect: Postgres, query: `SELECT "a?" FROM items WHERE id IN (?, ?, ?)`, expected: `SELECT "a?" FROM items WHERE id IN ($1, $2, $3)`},
+		{name: "プレースホルダーなし", dialect: Postgres, query: `SELECT 1`, expected: `SELECT 1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.Rebind(tt.query); got != tt.expected {
+				t.Errorf("Rebind() = %q, 期待値 = %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDialect_Upsert は方言ごとのUPSERT文の組み立てと、SQLiteでの実行をテストします
+func TestDialect_Upsert(t *testing.T) {
+	columns, keys, update := []string{"id", "name", "rank"}, []string{"id"}, []string{"name"}
+
+	expected := map[string]string{
+		"mysql":    `INSERT INTO items (id, name, rank) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)`,
+		"sqlite":   `INSERT INTO items (id, name, rank) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name`,
+		"postgres": `INSERT INTO items (id, name, rank) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name`,
+	}
+	for _, dialect := range []Dialect{MySQL, SQLite, Postgres} {
+		if got := dialect.Upsert("items", columns, keys, update); got != expected[dialect.Name] {
+			t.Errorf("%s の Upsert() = %q, 期待値 = %q", dialect.Name, got, expected[dialect.Name])
+		}
+	}
+
+	// 既にある行は update の列だけを置き換え、ない行は作成する
+	db := setupItems(t)
+	query := SQLite.Upsert("items", columns, keys, update)
+	if _, err := db.Exec(query, 1, "z", 9); err != nil {
+		t.Fatalf("既存の行の Upsert error = %v", err)
+	}
+	if _, err := db.Exec(query, 4, "d", 3); err != nil {
+		t.Fatalf("新しい行の Upsert error = %v", err)
+	}
+	for id, want := range map[int]struct {
+		name string
+		rank int
+	}{1: {"z", 2}, 4: {"d", 3}} {
+		var name string
+		var rank int
+		if err := db.QueryRow(`SELECT name, rank FROM items WHERE id = ?`, id).Scan(&name, &rank); err != nil {
+			t.Fatalf("id=%d の取得に失敗: %v", id, err)
+		}
+		if name != want.name || rank != want.rank {
+			t.Errorf("id=%d = (%q, %d), 期待値 = (%q, %d)", id, name, rank, want.name, want.rank)
+		}
+	}
+
+	// 番号付きのプレースホルダーに書き換えた文も同じ結果になる（SQLiteは $1 の形式も受け付ける）
+	var count int
+	if err := db.QueryRow(Postgres.Rebind(`SELECT COUNT(*) FROM items WHERE rank >= ? AND name <> ?`), 2, "z").Scan(&count); err != nil || count != 2 {
+		t.Errorf("書き換えたクエリの件数 = %d, %v, 期待値 = 2", count, err)
+	}
+	var now string
+	if err := db.QueryRow(`SELECT ` + SQLite.Now).Scan(&now); err != nil || now == "" {
+		t.Errorf("SQLite.Now = %q, %v", now, err)
+	}
+}
+
+// TestDialect_UpsertIf は既存の行が条件を満たす場合だけ置き換えるUPSERT文の組み立てと、SQLiteでの実行をテストします
+func TestDialect_UpsertIf(t *testing.T) {
+	columns, keys, update := []string{"id", "name", "rank"}, []string{"id"}, []string{"name"}
+
+	expected := map[string]string{
+		"mysql":    `INSERT INTO items (id, name, rank) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE name = IF(items.rank = VALUES(rank), VALUES(name), name)`,
+		"sqlite":   `INSERT INTO items (id, name, rank) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE items.rank = excluded.rank`,
+		"postgres": `INSERT INTO items (id, name, rank) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE items.rank = excluded.rank`,
+	}
+	for _, dialect := range []Dialect{MySQL, SQLite, Postgres} {
+		condition := "items.rank = " + dialect.Inserted("rank")
+		if got := dialect.UpsertIf("items", columns, keys, update, condition); got != expected[dialect.Name] {
+			t.Errorf("%s の UpsertIf() = %q, 期待値 = %q", dialect.Name, got, expected[dialect.Name])
+		}
+	}
+
+	// 条件を満たす既存の行だけを置き換え、満たさない行はエラーにせずそのまま残す
+	db := setupItems(t)
+	query := SQLite.UpsertIf("items", columns, keys, update, "items.rank = "+SQLite.Inserted("rank"))
+	if _, err := db.Exec(query, 1, "z", 2); err != nil {
+		t.Fatalf("条件を満たす行の UpsertIf error = %v", err)
+	}
+	if _, err := db.Exec(query, 2, "y", 9); err != nil {
+		t.Fatalf("条件を満たさない行の UpsertIf error = %v", err)
+	}
+	for id, want := range map[int]string{1: "z", 2: "a"} {
+		var name string
+		if err := db.QueryRow(`SELECT name FROM items WHERE id = ?`, id).Scan(&name); err != nil {
+			t.Fatalf("id=%d の取得に失敗: %v", id, err)
+		}
+		if name != want {
+			t.Errorf("id=%d の name = %q, 期待値 = %q", id, name, want)
+		}
+	}
+}
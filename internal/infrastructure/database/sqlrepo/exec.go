@@ -0,0 +1,48 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Execer は *sql.DB と *sql.Tx の共通インターフェースです
+// 同じSQL文を、トランザクションの内外どちらでも実行できるようにします
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Querier は *sql.DB と *sql.Tx の共通インターフェース（SELECT用）です
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// ExecAffecting は UPDATE / DELETE を実行し、影響を受けた行がない場合は notFound を返します
+// op はエラーメッセージに含める操作名です（例: "update checklist item" → "failed to update checklist item: ..."）
+//
+// 主キーを指定した更新・削除で「対象がない」ことを検出する、全てのリポジトリに共通の処理です
+func ExecAffecting(ctx context.Context, db Execer, op string, notFound error, query string, args ...any) error {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// Count は SELECT COUNT(*) を実行して件数を返します
+func Count(ctx context.Context, db Querier, query string, args ...any) (int64, error) {
+	var count int64
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count rows: %w", err)
+	}
+	return count, nil
+}
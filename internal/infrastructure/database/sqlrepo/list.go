@@ -0,0 +1,93 @@
+package sqlrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSort は並び替えに使用できないキーが指定された場合のエラーです
+var ErrInvalidSort = errors.New("invalid sort key")
+
+// Page は一覧取得のページングと並び順の指定です
+type Page struct {
+	// Limit は取得する最大件数です（0 の場合は全件）
+	Limit int
+
+	// Offset は読み飛ばす件数です
+	Offset int
+
+	// SortBy は並び替えのキーです（空の場合は既定の並び順）
+	SortBy string
+
+	// Desc が true の場合は降順にします
+	Desc bool
+}
+
+// Sorting は並び替えに使用できるキーと、対応するSQLの列（式）の定義です
+// キーは利用者の入力（クエリパラメータ等）で、SQL文にはキーではなく対応する列だけを埋め込みます
+type Sorting struct {
+	// Columns は並び替えのキーからSQLの列への対応です（例: "created_at" → "t.created_at"）
+	Columns map[string]string
+
+	// Default は SortBy が空の場合の ORDER BY 句の内容です（例: "t.created_at DESC"）
+	Default string
+
+	// TieBreaker は同じ値の行の順序を安定させるために最後に付ける列です（例: "t.id"）
+	TieBreaker string
+}
+
+// OrderBy は page の並び順を ORDER BY 句に変換します
+// 定義にないキーの場合は ErrInvalidSort を返します（列名をSQL文に埋め込むため、必ず定義で確認する）
+func (s Sorting) OrderBy(page Page) (string, error) {
+	var terms []string
+	switch {
+	case page.SortBy == "":
+		if s.Default != "" {
+			terms = append(terms, s.Default)
+		}
+	default:
+		column, ok := s.Columns[page.SortBy]
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrInvalidSort, page.SortBy)
+		}
+		direction := "ASC"
+		if page.Desc {
+			direction = "DESC"
+		}
+		terms = append(terms, column+" "+direction)
+	}
+	if s.TieBreaker != "" {
+		terms = append(terms, s.TieBreaker+" ASC")
+	}
+	if len(terms) == 0 {
+		return "", nil
+	}
+	return " ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// List は query（ORDER BY を含まない SELECT 文）に並び順とページングを付けて実行し、全ての行を mapper で変換します
+//
+//	todos, err := sqlrepo.List(ctx, db, `SELECT ... FROM todos t WHERE t.is_completed = ?`,
+//		page, todoSorting, scanTodo, false)
+func List[T any](ctx context.Context, db Querier, query string, page Page, sorting Sorting, mapper RowMapper[T], args ...any) ([]T, error) {
+	orderBy, err := sorting.OrderBy(page)
+	if err != nil {
+		return nil, err
+	}
+	query += orderBy
+
+	// LIMIT を省略した OFFSET はデータベースによって書き方が異なるため、Limit が 0 の場合はどちらも付けない
+	if page.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		// 呼び出し側のスライスを書き換えないよう、容量を長さに制限してから追加する
+		args = append(args[:len(args):len(args)], page.Limit, page.Offset)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rows: %w", err)
+	}
+	return ScanAll(rows, mapper)
+}
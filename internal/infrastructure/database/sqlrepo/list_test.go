@@ -0,0 +1,58 @@
+package sqlrepo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var itemSorting = Sorting{
+	Columns:    map[string]string{"name": "name", "rank": "rank"},
+	Default:    "id DESC",
+	TieBreaker: "id",
+}
+
+// TestList は並び順・ページング・並び替えのキーの検証をテストします
+func TestList(t *testing.T) {
+	db := setupItems(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		query   string
+		args    []any
+		page    Page
+		wantIDs []int
+		wantErr error
+	}{
+		{name: "既定の並び順", page: Page{}, wantIDs: []int{3, 2, 1}},
+		{name: "キーによる昇順", page: Page{SortBy: "name"}, wantIDs: []int{2, 3, 1}},
+		{name: "同じ値は TieBreaker の順", page: Page{SortBy: "rank", Desc: true}, wantIDs: []int{1, 3, 2}},
+		{name: "ページング", page: Page{SortBy: "name", Limit: 2, Offset: 1}, wantIDs: []int{3, 1}},
+		{name: "条件と引数", query: ` WHERE rank = ?`, args: []any{2}, page: Page{Limit: 1}, wantIDs: []int{3}},
+		{name: "定義にないキー", page: Page{SortBy: "name; DROP TABLE items"}, wantErr: ErrInvalidSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := List(ctx, db, `SELECT * FROM items`+tt.query, tt.page, itemSorting, scanItem, tt.args...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("List() error = %v, 期待値 = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			ids := make([]int, len(items))
+			for i, it := range items {
+				ids[i] = it.ID
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("List() のID = %v, 期待値 = %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package sqlrepo は database/sql を使うリポジトリ実装に共通する処理をまとめたパッケージです
+//
+// 各リポジトリ（todos, projects, checklist_items, ...）は、列名によるスキャン・影響行数の確認・
+// トランザクション・ページング付きの一覧取得をこのパッケージの関数で行います
+// 新しいエンティティのリポジトリは、SQL文と「列名 → フィールド」の対応を書くだけで実装できます
+package sqlrepo
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// 列名によるスキャン
+//
+// 全てのリポジトリは、SELECT結果を列の位置ではなく列名で構造体に対応付けます
+// 各リポジトリは「列名 → スキャン先」の対応（Columns）を1か所で定義するだけで、
+// 列の順序合わせやループ・エラー処理の重複がなくなり、列を追加した際の順序ずれのバグを防げます
+//
+// スキーマの互換性：
+// ローリングデプロイ中は、マイグレーション済みのDBを古いバイナリが読む（DBの列の方が多い）ことがあります
+// 列の順序に依存した rows.Scan では列が1つ増えただけで全ての読み込みが失敗するため、
+// 列名を手がかりにスキャン先を決めることで、デプロイの途中でもAPIが動き続けるようにします
+//
+//   - バイナリが知らない列（DBの方が新しい）: 値を読み捨て、初回のみログに記録します
+//   - バイナリが知っている列がない（DBの方が古い）: 必須の列でなければゼロ値のままにします
+//   - 必須の列がない: 正しいエンティティを組み立てられないためエラーにします
+
+// reportedColumns は既にログに記録した「テーブル名.列名」の集合です
+// 同じ列について行ごとにログが出力されないようにします
+var reportedColumns sync.Map
+
+// Columns は列名からスキャン先のポインタへの対応です
+type Columns map[string]any
+
+// RowMapper は現在の行を列名で読み取り、エンティティに変換する関数です
+// 各リポジトリの scanXxx 関数がこの形を持ち、ScanAll / ScanOne / List に渡します
+type RowMapper[T any] func(rows *sql.Rows) (T, error)
+
+// ScanAll はクエリ結果の全ての行を mapper で変換し、rows を閉じます
+// 行がない場合は nil ではなく空スライスを返します（JSONで null ではなく [] になる）
+func ScanAll[T any](rows *sql.Rows, mapper RowMapper[T]) ([]T, error) {
+	defer rows.Close()
+
+	results := make([]T, 0)
+	for rows.Next() {
+		result, err := mapper(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return results, nil
+}
+
+// ScanOne はクエリ結果の最初の行を mapper で変換し、rows を閉じます
+// 行がない場合は *sql.Row と同じく sql.ErrNoRows を返します
+//
+// 列名は *sql.Rows からしか取得できないため、1件の取得でも QueryRowContext ではなく
+// QueryContext の結果をこの関数に渡します
+func ScanOne[T any](rows *sql.Rows, mapper RowMapper[T]) (T, error) {
+	defer rows.Close()
+
+	var zero T
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return zero, fmt.Errorf("error during rows iteration: %w", err)
+		}
+		return zero, sql.ErrNoRows
+	}
+	result, err := mapper(rows)
+	if err != nil {
+		return zero, fmt.Errorf("failed to scan row: %w", err)
+	}
+	return result, nil
+}
+
+// ScanColumns は現在の行を列名で対応付けてスキャンします
+// targets は列名からスキャン先のポインタへの対応、required は存在しなければならない列名です
+func ScanColumns(rows *sql.Rows, table string, targets Columns, required ...string) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	found := make(map[string]bool, len(columns))
+	dest := make([]any, len(columns))
+	for i, column := range columns {
+		if target, ok := targets[column]; ok {
+			dest[i] = target
+			found[column] = true
+			continue
+		}
+		// 知らない列は型を問わず受け取れる sql.RawBytes に読み捨てる
+		dest[i] = new(sql.RawBytes)
+		reportColumnOnce(table, column, "ignoring unknown column (database schema is newer than this binary)")
+	}
+
+	for _, column := range required {
+		if !found[column] {
+			return fmt.Errorf("required column %s.%s is missing", table, column)
+		}
+	}
+	for column := range targets {
+		if !found[column] {
+			reportColumnOnce(table, column, "column not found, using zero value (database schema is older than this binary)")
+		}
+	}
+
+	return rows.Scan(dest...)
+}
+
+// reportColumnOnce はスキーマの差異をテーブル名・列名ごとに一度だけログに記録します
+func reportColumnOnce(table, column, message string) {
+	if _, loaded := reportedColumns.LoadOrStore(table+"."+column, struct{}{}); !loaded {
+		log.Printf("Schema compatibility: %s.%s: %s", table, column, message)
+	}
+}
@@ -0,0 +1,83 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DBTX は *sql.DB と *sql.Tx の共通インターフェース（更新・SELECT の両方）です
+type DBTX interface {
+	Execer
+	Querier
+}
+
+// txKey はコンテキストに設定したトランザクションのキーです
+type txKey struct{}
+
+// ambientTx はコンテキストに設定したトランザクションと、その開始元のDBです
+type ambientTx struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+// RunInTx は fn を1つのトランザクションで実行します
+// fn に渡すコンテキストにはトランザクションが設定され、その中で Conn・InTx を使うリポジトリの処理は
+// 全て同じトランザクションに参加します（複数のリポジトリへの書き込みをまとめてコミットできます）
+// ctx にすでに db のトランザクションがある場合は、新しく開始せずにそれに参加します
+func RunInTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := txFrom(ctx, db); ok {
+		return fn(ctx)
+	}
+	return InTx(ctx, db, "transaction", func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, ambientTx{db: db, tx: tx}))
+	})
+}
+
+// Conn は ctx に db のトランザクションがあればそのトランザクションを、なければ db を返します
+// リポジトリは db を直接使わずに Conn(ctx, r.db) を通すことで、RunInTx のトランザクションに参加できます
+func Conn(ctx context.Context, db *sql.DB) DBTX {
+	if tx, ok := txFrom(ctx, db); ok {
+		return tx
+	}
+	return db
+}
+
+// txFrom は ctx に設定された db のトランザクションを返します
+// 別のDB（シャードやテナントの接続プール）のトランザクションには参加しません
+func txFrom(ctx context.Context, db *sql.DB) (*sql.Tx, bool) {
+	ambient, ok := ctx.Value(txKey{}).(ambientTx)
+	if !ok || ambient.db != db {
+		return nil, false
+	}
+	return ambient.tx, true
+}
+
+// InTx は fn を1つのトランザクションで実行します
+// fn がエラーを返した場合はロールバックし、そのエラーをそのまま返します
+// op はコミット失敗時のエラーメッセージに含める操作名です（例: "todo deletion"）
+// ctx に RunInTx のトランザクションがある場合は、そのトランザクションで fn を実行し、
+// コミット・ロールバックは RunInTx に任せます
+//
+// Commit() 済みの場合の Rollback() は何もしないため、defer で常に呼び出しています
+// fn の中では引数の tx を使い、*sql.DB を直接使わないでください（トランザクションの外で実行されます）
+func InTx(ctx context.Context, db *sql.DB, op string, fn func(tx *sql.Tx) error) error {
+	if tx, ok := txFrom(ctx, db); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %s: %w", op, err)
+	}
+	return nil
+}
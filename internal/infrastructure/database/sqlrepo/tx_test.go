@@ -0,0 +1,140 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	// SQLite ドライバーをテスト用に使用
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// item はテスト用のエンティティです
+type item struct {
+	ID   int
+	Name string
+	Rank int
+}
+
+// scanItem はテスト用の RowMapper です
+func scanItem(rows *sql.Rows) (item, error) {
+	var it item
+	err := ScanColumns(rows, "items", Columns{
+		"id":   &it.ID,
+		"name": &it.Name,
+		"rank": &it.Rank,
+	}, "id")
+	return it, err
+}
+
+// setupItems はテスト用のテーブルとデータを作成します
+func setupItems(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("テストデータベースの作成に失敗: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL, rank INTEGER NOT NULL);
+		INSERT INTO items (id, name, rank) VALUES (1, 'c', 2), (2, 'a', 1), (3, 'b', 2);
+	`)
+	if err != nil {
+		t.Fatalf("テストテーブルの作成に失敗: %v", err)
+	}
+	return db
+}
+
+// TestExecAffecting は影響を受けた行がない場合に notFound を返すことをテストします
+func TestExecAffecting(t *testing.T) {
+	db := setupItems(t)
+	ctx := context.Background()
+	errNotFound := errors.New("item not found")
+
+	if err := ExecAffecting(ctx, db, "update item", errNotFound, `UPDATE items SET name = ? WHERE id = ?`, "z", 1); err != nil {
+		t.Errorf("存在する行の更新でエラー: %v", err)
+	}
+	if err := ExecAffecting(ctx, db, "update item", errNotFound, `UPDATE items SET name = ? WHERE id = ?`, "z", 99); !errors.Is(err, errNotFound) {
+		t.Errorf("エラー = %v, 期待値 = %v", err, errNotFound)
+	}
+	if err := ExecAffecting(ctx, db, "update item", errNotFound, `UPDATE missing SET name = ?`, "z"); err == nil || errors.Is(err, errNotFound) {
+		t.Errorf("SQLのエラー = %v, 期待値 = failed to update item", err)
+	}
+}
+
+// TestInTx はエラーの場合にロールバックし、成功した場合にコミットすることをテストします
+func TestInTx(t *testing.T) {
+	db := setupItems(t)
+	ctx := context.Background()
+	errStop := errors.New("stop")
+
+	err := InTx(ctx, db, "items", func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM items WHERE id = 1`); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("InTx() error = %v, 期待値 = %v", err, errStop)
+	}
+	if count, _ := Count(ctx, db, `SELECT COUNT(*) FROM items`); count != 3 {
+		t.Errorf("ロールバック後の件数 = %d, 期待値 = 3", count)
+	}
+
+	err = InTx(ctx, db, "items", func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM items WHERE id = 1`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("InTx() error = %v", err)
+	}
+	if count, _ := Count(ctx, db, `SELECT COUNT(*) FROM items`); count != 2 {
+		t.Errorf("コミット後の件数 = %d, 期待値 = 2", count)
+	}
+}
+
+// TestRunInTx は RunInTx のコンテキストを使った Conn と InTx が同じトランザクションに参加することをテストします
+func TestRunInTx(t *testing.T) {
+	db := setupItems(t)
+	// :memory: は接続ごとに別のデータベースになるため、接続を1つに制限する
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+	errStop := errors.New("stop")
+
+	err := RunInTx(ctx, db, func(ctx context.Context) error {
+		if _, err := Conn(ctx, db).ExecContext(ctx, `DELETE FROM items WHERE id = 1`); err != nil {
+			return err
+		}
+		// 入れ子の InTx は新しいトランザクションを開始せず、外側のトランザクションに参加する
+		err := InTx(ctx, db, "items", func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `DELETE FROM items WHERE id = 2`)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if count, _ := Count(ctx, Conn(ctx, db), `SELECT COUNT(*) FROM items`); count != 1 {
+			t.Errorf("トランザクション内の件数 = %d, 期待値 = 1", count)
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("RunInTx() error = %v, 期待値 = %v", err, errStop)
+	}
+	if count, _ := Count(ctx, db, `SELECT COUNT(*) FROM items`); count != 3 {
+		t.Errorf("ロールバック後の件数 = %d, 期待値 = 3", count)
+	}
+
+	err = RunInTx(ctx, db, func(ctx context.Context) error {
+		_, err := Conn(ctx, db).ExecContext(ctx, `DELETE FROM items WHERE id = 1`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("RunInTx() error = %v", err)
+	}
+	if count, _ := Count(ctx, db, `SELECT COUNT(*) FROM items`); count != 2 {
+		t.Errorf("コミット後の件数 = %d, 期待値 = 2", count)
+	}
+}
@@ -0,0 +1,181 @@
+package sqlrepo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidFilter は条件に使用できない項目・演算子・値が指定された場合のエラーです
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Operator は条件の演算子です
+type Operator string
+
+// 条件に使用できる演算子です
+const (
+	// Eq 〜 Ge は値との比較です（値に nil は指定できません。NULL の判定には IsNull を使用します）
+	Eq Operator = "="
+	Ne Operator = "<>"
+	Lt Operator = "<"
+	Le Operator = "<="
+	Gt Operator = ">"
+	Ge Operator = ">="
+
+	// Contains は大文字・小文字を区別しない部分一致です（値は文字列。% と _ も通常の文字として扱います）
+	// SQLiteの LOWER はASCII文字のみを変換するため、英字以外の大文字・小文字はデータベースによって扱いが異なります
+	Contains Operator = "contains"
+
+	// HasItem はカンマ区切りで保存した列（Todoの tags 等）に、値が要素として含まれるかの判定です（値は文字列）
+	HasItem Operator = "has"
+
+	// IsNull は列が NULL かの判定です（値は bool。false の場合は NULL でないことの判定）
+	IsNull Operator = "null"
+)
+
+// likeEscaper は LIKE のパターンで特別な意味を持つ文字をエスケープします（エスケープ文字は '!'）
+// バックスラッシュはデータベースによって文字列リテラルでの扱いが異なるため、エスケープ文字に使いません
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// Condition は Fields で定義した項目に対する1つの条件です
+type Condition struct {
+	// Field は条件の項目（Fields のキー）です
+	Field string
+
+	// Op は演算子です
+	Op Operator
+
+	// Value は比較する値です（SQL文には埋め込まず、パラメータとして渡します）
+	Value any
+}
+
+// Fields は条件に使用できる項目と、対応するSQLの列（式）の定義です
+// Sorting と同じく、キーは利用者の入力に由来してもよく、SQL文にはキーではなく対応する列だけを埋め込みます
+type Fields map[string]string
+
+// Where は定義した項目の条件を AND でつなぎ、WHERE 句の内容とパラメータを組み立てます
+//
+//	where, args, err := todoFields.Where().
+//		Raw(`t.deleted_at IS NULL`).
+//		And("color", sqlrepo.Eq, "blue").
+//		AnyOf(sqlrepo.Condition{"title", sqlrepo.Contains, q}, sqlrepo.Condition{"description", sqlrepo.Contains, q}).
+//		Build()
+//
+// 途中で不正な条件を追加した場合は、それ以降の追加を無視し、Build が最初のエラーを返します
+func (f Fields) Where() *Where {
+	return &Where{fields: f}
+}
+
+// Where は Fields.Where で作成する条件のビルダーです
+type Where struct {
+	fields Fields
+	terms  []string
+	args   []any
+	err    error
+}
+
+// Raw はリポジトリで固定したSQLの条件をそのまま追加します（所有者の絞り込み等）
+// term に利用者の入力を埋め込んではいけません。値は args で渡します
+func (w *Where) Raw(term string, args ...any) *Where {
+	if w.err == nil && term != "" {
+		w.terms = append(w.terms, term)
+		w.args = append(w.args, args...)
+	}
+	return w
+}
+
+// And は条件を1つ追加します
+func (w *Where) And(field string, op Operator, value any) *Where {
+	return w.add(Condition{Field: field, Op: op, Value: value})
+}
+
+// AnyOf は conds のいずれかを満たす条件（OR でつないで括弧で囲んだもの）を1つ追加します
+func (w *Where) AnyOf(conds ...Condition) *Where {
+	if w.err != nil {
+		return w
+	}
+	if len(conds) == 0 {
+		w.err = fmt.Errorf("%w: no conditions", ErrInvalidFilter)
+		return w
+	}
+
+	terms := make([]string, 0, len(conds))
+	var args []any
+	for _, cond := range conds {
+		term, condArgs, err := w.condition(cond)
+		if err != nil {
+			w.err = err
+			return w
+		}
+		terms = append(terms, term)
+		args = append(args, condArgs...)
+	}
+	return w.Raw("("+strings.Join(terms, " OR ")+")", args...)
+}
+
+// Build は WHERE 句の内容（"WHERE" を含まない）とパラメータを返します（条件がない場合は "1 = 1"）
+func (w *Where) Build() (string, []any, error) {
+	if w.err != nil {
+		return "", nil, w.err
+	}
+	if len(w.terms) == 0 {
+		return "1 = 1", nil, nil
+	}
+	return strings.Join(w.terms, " AND "), w.args, nil
+}
+
+// add は条件を1つ追加します
+func (w *Where) add(cond Condition) *Where {
+	if w.err != nil {
+		return w
+	}
+	term, args, err := w.condition(cond)
+	if err != nil {
+		w.err = err
+		return w
+	}
+	return w.Raw(term, args...)
+}
+
+// condition は条件をSQLの式とパラメータに変換します
+// 定義にない項目や、演算子に合わない型の値の場合は ErrInvalidFilter を返します
+func (w *Where) condition(cond Condition) (string, []any, error) {
+	column, ok := w.fields[cond.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, cond.Field)
+	}
+
+	switch cond.Op {
+	case Eq, Ne, Lt, Le, Gt, Ge:
+		if cond.Value == nil {
+			return "", nil, fmt.Errorf("%w: %s %s nil", ErrInvalidFilter, cond.Field, cond.Op)
+		}
+		return column + " " + string(cond.Op) + " ?", []any{cond.Value}, nil
+	case Contains:
+		s, ok := cond.Value.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s %s requires a string", ErrInvalidFilter, cond.Field, cond.Op)
+		}
+		return "LOWER(" + column + ") LIKE ? ESCAPE '!'", []any{"%" + likeEscaper.Replace(strings.ToLower(s)) + "%"}, nil
+	case HasItem:
+		s, ok := cond.Value.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s %s requires a string", ErrInvalidFilter, cond.Field, cond.Op)
+		}
+		// 文字列の連結はデータベースによって書き方が異なるため、要素の位置（単独・先頭・末尾・途中）ごとに比較する
+		item := likeEscaper.Replace(s)
+		term := "(" + column + " = ? OR " + column + " LIKE ? ESCAPE '!' OR " + column + " LIKE ? ESCAPE '!' OR " + column + " LIKE ? ESCAPE '!')"
+		return term, []any{s, item + ",%", "%," + item, "%," + item + ",%"}, nil
+	case IsNull:
+		isNull, ok := cond.Value.(bool)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s %s requires a bool", ErrInvalidFilter, cond.Field, cond.Op)
+		}
+		if isNull {
+			return column + " IS NULL", nil, nil
+		}
+		return column + " IS NOT NULL", nil, nil
+	default:
+		return "", nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, cond.Op)
+	}
+}
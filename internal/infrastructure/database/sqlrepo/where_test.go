@@ -0,0 +1,98 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var noteFields = Fields{
+	"title":  "title",
+	"tags":   "tags",
+	"rank":   "rank",
+	"due_at": "due_at",
+}
+
+// setupNotes は条件の組み立てのテスト用のテーブルとデータを作成します
+func setupNotes(t *testing.T) *sql.DB {
+	t.Helper()
+	db := setupItems(t)
+	_, err := db.Exec(`
+		CREATE TABLE notes (id INTEGER PRIMARY KEY, title TEXT NOT NULL, tags TEXT NOT NULL, rank INTEGER NOT NULL, due_at TEXT);
+		INSERT INTO notes (id, title, tags, rank, due_at) VALUES
+			(1, 'Buy Milk', 'home,shop', 1, '2024-01-10'),
+			(2, '100% done', 'work', 2, NULL),
+			(3, 'milk_tea', 'shopping,home_office', 3, '2024-02-01');
+	`)
+	if err != nil {
+		t.Fatalf("テストテーブルの作成に失敗: %v", err)
+	}
+	return db
+}
+
+// TestWhere は定義した項目の条件が1つのSQL文に組み立てられることをテストします
+func TestWhere(t *testing.T) {
+	db := setupNotes(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		build   func(w *Where) *Where
+		wantIDs []int
+		wantErr error
+	}{
+		{name: "条件なし", build: func(w *Where) *Where { return w }, wantIDs: []int{1, 2, 3}},
+		{name: "比較", build: func(w *Where) *Where { return w.And("rank", Ge, 2) }, wantIDs: []int{2, 3}},
+		{name: "範囲（AND）", build: func(w *Where) *Where { return w.And("due_at", Ge, "2024-01-01").And("due_at", Lt, "2024-02-01") }, wantIDs: []int{1}},
+		{name: "部分一致（大文字・小文字を区別しない）", build: func(w *Where) *Where { return w.And("title", Contains, "MILK") }, wantIDs: []int{1, 3}},
+		{name: "部分一致の % と _ は通常の文字", build: func(w *Where) *Where { return w.And("title", Contains, "0%") }, wantIDs: []int{2}},
+		{name: "要素の一致（前方一致の要素は含まない）", build: func(w *Where) *Where { return w.And("tags", HasItem, "shop") }, wantIDs: []int{1}},
+		{name: "要素の一致（_ は通常の文字）", build: func(w *Where) *Where { return w.And("tags", HasItem, "home_office") }, wantIDs: []int{3}},
+		{name: "NULL の判定", build: func(w *Where) *Where { return w.And("due_at", IsNull, false) }, wantIDs: []int{1, 3}},
+		{
+			name: "いずれかの条件（OR）と固定の条件",
+			build: func(w *Where) *Where {
+				return w.Raw("id <> ?", 1).AnyOf(Condition{Field: "tags", Op: HasItem, Value: "work"}, Condition{Field: "title", Op: Contains, Value: "milk"})
+			},
+			wantIDs: []int{2, 3},
+		},
+		{name: "定義にない項目", build: func(w *Where) *Where { return w.And("id = 1 OR 1", Eq, 1) }, wantErr: ErrInvalidFilter},
+		{name: "未知の演算子", build: func(w *Where) *Where { return w.And("rank", Operator("; DROP TABLE notes"), 1) }, wantErr: ErrInvalidFilter},
+		{name: "演算子に合わない値", build: func(w *Where) *Where { return w.And("title", Contains, 1) }, wantErr: ErrInvalidFilter},
+		{name: "nil との比較", build: func(w *Where) *Where { return w.And("due_at", Eq, nil) }, wantErr: ErrInvalidFilter},
+		{name: "不正な条件の後の追加は無視", build: func(w *Where) *Where { return w.And("secret", Eq, 1).And("rank", Eq, 1) }, wantErr: ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := tt.build(noteFields.Where()).Build()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Build() error = %v, 期待値 = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			rows, err := db.QueryContext(ctx, `SELECT id FROM notes WHERE `+where+` ORDER BY id`, args...)
+			if err != nil {
+				t.Fatalf("組み立てた条件 %q の実行に失敗: %v", where, err)
+			}
+			ids, err := ScanAll(rows, func(rows *sql.Rows) (int, error) {
+				var id int
+				err := rows.Scan(&id)
+				return id, err
+			})
+			if err != nil {
+				t.Fatalf("ScanAll() error = %v", err)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("条件 %q に一致したID = %v, 期待値 = %v", where, ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// todoHistoryRepositoryImpl は todo_history テーブルを使用した
+// TodoHistoryRepository インターフェースの実装です
+//
+// 変更前後のスナップショットはJSON文字列として保存します
+// （Todoの列が増えても履歴テーブルのスキーマ変更が不要になります）
+type todoHistoryRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewTodoHistoryRepository はtodoHistoryRepositoryImplのコンストラクタです
+func NewTodoHistoryRepository(db *sql.DB) repository.TodoHistoryRepository {
+	return &todoHistoryRepositoryImpl{
+		db: db,
+	}
+}
+
+// Record は変更履歴を保存します
+func (r *todoHistoryRepositoryImpl) Record(ctx context.Context, entry *entity.TodoHistoryEntry) (*entity.TodoHistoryEntry, error) {
+	before, err := marshalSnapshot(entry.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := marshalSnapshot(entry.After)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	query := `
+		INSERT INTO todo_history (todo_id, action, actor, before_snapshot, after_snapshot, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, entry.TodoID, string(entry.Action), entry.Actor, before, after, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert todo history: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	entry.ID = int(id)
+	entry.ChangedAt = now
+	return entry, nil
+}
+
+// ListByTodoID は指定されたTodoの変更履歴を取得します
+// 同じ秒に複数の変更があっても順序が崩れないよう、ID順で並べます
+func (r *todoHistoryRepositoryImpl) ListByTodoID(ctx context.Context, todoID int) ([]*entity.TodoHistoryEntry, error) {
+	query := `
+		SELECT id, todo_id, action, actor, before_snapshot, after_snapshot, changed_at
+		FROM todo_history
+		WHERE todo_id = ?
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todo history: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanTodoHistoryEntry)
+}
+
+// ListRecent は指定した操作の変更履歴を新しい順に取得します
+// todos と結合し、Todoの一覧と同じく認証されたユーザー・ワークスペースのTodoの履歴に限定します
+// （完全に削除されたTodoの履歴は所有者が分からないため含みません）
+func (r *todoHistoryRepositoryImpl) ListRecent(ctx context.Context, actions []entity.TodoHistoryAction, limit int) ([]*entity.TodoHistoryEntry, error) {
+	owner, args := scopeCondition(ctx, "t.")
+	query := `
+		SELECT h.id, h.todo_id, h.action, h.actor, h.before_snapshot, h.after_snapshot, h.changed_at
+		FROM todo_history h
+		JOIN todos t ON t.id = h.todo_id
+		WHERE ` + owner
+	if len(actions) > 0 {
+		placeholders := make([]string, len(actions))
+		for i, action := range actions {
+			placeholders[i] = "?"
+			args = append(args, string(action))
+		}
+		query += ` AND h.action IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	query += `
+		ORDER BY h.id DESC
+		LIMIT ?
+	`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent todo history: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanTodoHistoryEntry)
+}
+
+// scanTodoHistoryEntry は1行を列名で対応付けて変更履歴にスキャンし、スナップショットのJSONを復元します
+func scanTodoHistoryEntry(rows *sql.Rows) (*entity.TodoHistoryEntry, error) {
+	var entry entity.TodoHistoryEntry
+	var action string
+	var before, after sql.NullString
+	err := sqlrepo.ScanColumns(rows, "todo_history", sqlrepo.Columns{
+		"id":              &entry.ID,
+		"todo_id":         &entry.TodoID,
+		"action":          &action,
+		"actor":           &entry.Actor,
+		"before_snapshot": &before,
+		"after_snapshot":  &after,
+		"changed_at":      &entry.ChangedAt,
+	}, "id", "todo_id", "action")
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Action = entity.TodoHistoryAction(action)
+	if entry.Before, err = unmarshalSnapshot(before); err != nil {
+		return nil, err
+	}
+	if entry.After, err = unmarshalSnapshot(after); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// marshalSnapshot はスナップショットをJSON文字列に変換します（nil の場合は NULL）
+func marshalSnapshot(todo *entity.Todo) (sql.NullString, error) {
+	if todo == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(todo)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode todo snapshot: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// unmarshalSnapshot はJSON文字列からスナップショットを復元します（NULL の場合は nil）
+func unmarshalSnapshot(value sql.NullString) (*entity.Todo, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	var todo entity.Todo
+	if err := json.Unmarshal([]byte(value.String), &todo); err != nil {
+		return nil, fmt.Errorf("failed to decode todo snapshot: %w", err)
+	}
+	return &todo, nil
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestTodoHistoryRepository_RecordAndList は履歴の保存とスナップショットの復元をテストします
+func TestTodoHistoryRepository_RecordAndList(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoHistoryRepository(db)
+	ctx := context.Background()
+
+	before := &entity.Todo{ID: 1, Title: "変更前"}
+	after := &entity.Todo{ID: 1, Title: "変更後", IsCompleted: true}
+
+	entries := []*entity.TodoHistoryEntry{
+		entity.NewTodoHistoryEntry(entity.TodoHistoryCreated, "alice", nil, before),
+		entity.NewTodoHistoryEntry(entity.TodoHistoryUpdated, "bob", before, after),
+		entity.NewTodoHistoryEntry(entity.TodoHistoryDeleted, "", after, nil),
+		entity.NewTodoHistoryEntry(entity.TodoHistoryCreated, "alice", nil, &entity.Todo{ID: 2, Title: "別のTodo"}),
+	}
+	for _, entry := range entries {
+		recorded, err := repo.Record(ctx, entry)
+		if err != nil {
+			t.Fatalf("履歴の保存に失敗: %v", err)
+		}
+		if recorded.ID == 0 || recorded.ChangedAt.IsZero() {
+			t.Errorf("IDまたは変更日時が設定されていません: %+v", recorded)
+		}
+	}
+
+	history, err := repo.ListByTodoID(ctx, 1)
+	if err != nil {
+		t.Fatalf("履歴の取得に失敗: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("件数 = %d, 期待値 = 3", len(history))
+	}
+
+	expectedActions := []entity.TodoHistoryAction{entity.TodoHistoryCreated, entity.TodoHistoryUpdated, entity.TodoHistoryDeleted}
+	for i, action := range expectedActions {
+		if history[i].Action != action {
+			t.Errorf("履歴[%d].Action = %v, 期待値 = %v", i, history[i].Action, action)
+		}
+	}
+
+	updated := history[1]
+	if updated.Actor != "bob" || updated.Before == nil || updated.Before.Title != "変更前" || updated.After == nil || !updated.After.IsCompleted {
+		t.Errorf("スナップショットが復元されていません: %+v", updated)
+	}
+	if history[0].Before != nil || history[2].After != nil {
+		t.Error("作成の変更前・削除の変更後は nil であるべきです")
+	}
+	if history[2].Actor != entity.AnonymousActor {
+		t.Errorf("Actor = %q, 期待値 = %q", history[2].Actor, entity.AnonymousActor)
+	}
+
+	empty, err := repo.ListByTodoID(ctx, 99)
+	if err != nil {
+		t.Fatalf("履歴の取得に失敗: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("履歴がない場合は空であるべきです: %v", empty)
+	}
+}
+
+// TestTodoHistoryRepository_ListRecent は最近の履歴が所有者のTodoに限定されることをテストします
+func TestTodoHistoryRepository_ListRecent(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	todoRepo := NewTodoRepository(db)
+	repo := NewTodoHistoryRepository(db)
+	alice := repository.WithOwner(context.Background(), 1)
+	bob := repository.WithOwner(context.Background(), 2)
+
+	for _, ctx := range []context.Context{alice, bob} {
+		todo, err := todoRepo.Create(ctx, &entity.Todo{Title: "Todo"})
+		if err != nil {
+			t.Fatalf("Todoの作成に失敗: %v", err)
+		}
+		for _, action := range []entity.TodoHistoryAction{entity.TodoHistoryCreated, entity.TodoHistoryUpdated} {
+			if _, err := repo.Record(ctx, entity.NewTodoHistoryEntry(action, "", nil, todo)); err != nil {
+				t.Fatalf("履歴の保存に失敗: %v", err)
+			}
+		}
+	}
+
+	recent, err := repo.ListRecent(alice, []entity.TodoHistoryAction{entity.TodoHistoryCreated}, 10)
+	if err != nil {
+		t.Fatalf("最近の履歴の取得に失敗: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Action != entity.TodoHistoryCreated {
+		t.Errorf("本人のTodoの作成の履歴だけを返すべきです: %+v", recent)
+	}
+
+	all, err := repo.ListRecent(context.Background(), nil, 10)
+	if err != nil {
+		t.Fatalf("最近の履歴の取得に失敗: %v", err)
+	}
+	if len(all) != 4 || all[0].ID < all[3].ID {
+		t.Errorf("所有者を指定しない場合は全ての履歴を新しい順に返すべきです: %+v", all)
+	}
+}
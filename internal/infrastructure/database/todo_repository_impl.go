@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"todoapp-api-golang/internal/domain/domainerr"
 	"todoapp-api-golang/internal/domain/entity"
 	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
 )
 
 // todoRepositoryImpl は標準のdatabase/sqlパッケージを使用した
@@ -24,30 +27,210 @@ type todoRepositoryImpl struct {
 	// db は標準のdatabase/sqlのDB接続
 	// *sql.DB はコネクションプールを管理し、並行安全
 	db *sql.DB
+
+	// sqliteLocking は SELECT ... FOR UPDATE の代わりにSQLite向けのロック方法を使うかどうか
+	sqliteLocking bool
+
+	// dialect は Upsert のSQL文を組み立てる接続先のエンジンの方言（既定は sqlrepo.MySQL）
+	dialect sqlrepo.Dialect
+}
+
+// TodoRepositoryOption はtodoRepositoryImplの任意設定を行う関数です
+type TodoRepositoryOption func(*todoRepositoryImpl)
+
+// WithSQLiteLocking は UpdateWithLock の行ロックをSQLite向けの方法に切り替えます
+// SQLiteは SELECT ... FOR UPDATE をサポートしないため、代わりに対象行への空のUPDATEで
+// 書き込みロックを先に取得します（SQLiteの書き込みロックはデータベース全体に掛かります）
+func WithSQLiteLocking() TodoRepositoryOption {
+	return func(r *todoRepositoryImpl) {
+		r.sqliteLocking = true
+	}
+}
+
+// WithDialect は Upsert のSQL文を組み立てる方言を設定します
+// 接続先のエンジンの方言（DatabaseManager.Dialect）を渡します。MySQL以外のエンジンでは必須です
+func WithDialect(dialect sqlrepo.Dialect) TodoRepositoryOption {
+	return func(r *todoRepositoryImpl) {
+		r.dialect = dialect
+	}
 }
 
 // NewTodoRepository はtodoRepositoryImplのコンストラクタです
 // 標準パッケージを使った依存性注入の実装
-func NewTodoRepository(db *sql.DB) repository.TodoRepository {
-	return &todoRepositoryImpl{
-		db: db,
+func NewTodoRepository(db *sql.DB, opts ...TodoRepositoryOption) repository.TodoRepository {
+	r := &todoRepositoryImpl{
+		db:      db,
+		dialect: sqlrepo.MySQL,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// todoSelectColumns はTodoを取得する全てのSELECT文で共通して使用する列リストです
+// todos テーブルは t というエイリアスで参照する前提です
+// チェックリストの進捗（総数・完了数）は相関サブクエリで同時に集計します
+//
+// 列を列挙せず t.* で取得し、scanTodo が列名で対応付けます
+// マイグレーションで列が追加・未適用の状態でも、SELECT文自体は失敗しません（sqlrepo/scan.go を参照）
+const todoSelectColumns = `t.*,
+		(SELECT COUNT(*) FROM checklist_items c WHERE c.todo_id = t.id) AS checklist_total,
+		(SELECT COUNT(*) FROM checklist_items c WHERE c.todo_id = t.id AND c.is_done = 1) AS checklist_done`
+
+// liveTodoCondition は削除されていない（ソフト削除されていない）Todoの条件です（todos テーブルは t というエイリアスで参照）
+// 削除済みのTodoを対象にするのは GetDeleted・Restore・HardDelete だけです
+const liveTodoCondition = `t.deleted_at IS NULL`
+
+// visibleTodoCondition は既定の一覧・検索に表示するTodoの条件です（todos テーブルは t というエイリアスで参照）
+// 削除済みのTodoと、アーカイブ済みのプロジェクトに属するTodoを除外します。プロジェクトに属さないTodoは常に表示します
+//
+// 一覧を返すクエリはこの条件を付けて組み立てます。IDを指定した取得（GetByID）には付けないため、
+// アーカイブ中もTodoを個別に参照でき、プロジェクトを復元すると元どおり一覧に表示されます
+const visibleTodoCondition = liveTodoCondition + ` AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.id = t.project_id AND p.archived_at IS NOT NULL)`
+
+// scopeCondition はコンテキストのワークスペース・所有者のTodoに絞り込む条件と、そのパラメータを返します
+// prefix には列を修飾するテーブルの別名（"t." など。修飾しない場合は ""）を指定します
+//
+//   - ワークスペースが設定されている場合は、そのワークスペースのTodo（作成したユーザーは問わない）
+//   - 所有者だけが設定されている場合は、本人の個人のTodo（ワークスペースに属さないもの）
+//   - どちらも設定されていない場合（認証が無効な場合やワーカー）は、全てのTodo
+//
+// 他のユーザー・ワークスペースのTodoは「存在しない」ものとして扱うため、IDを指定した取得・更新・削除でも
+// この条件を付け、一致しない場合は "todo not found" になります
+func scopeCondition(ctx context.Context, prefix string) (string, []any) {
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		return prefix + "workspace_id = ?", []any{workspaceID}
+	}
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		return prefix + "user_id = ? AND " + prefix + "workspace_id IS NULL", []any{userID}
+	}
+	return "1 = 1", nil
+}
+
+// todoRequiredColumns はTodoの組み立てに欠かせない列です
+// これ以外の列がDBにない場合は、ゼロ値のまま読み込みを続けます
+var todoRequiredColumns = []string{"id", "title", "created_at", "updated_at"}
+
+// todoSorting はTodoの一覧で並び替えに使用できる列です（既定は作成日時の新しい順）
+var todoSorting = sqlrepo.Sorting{
+	Columns: map[string]string{
+		"created_at": "t.created_at",
+		"updated_at": "t.updated_at",
+		"due_date":   "t.due_date",
+		"title":      "t.title",
+	},
+	Default:    "t.created_at DESC",
+	TieBreaker: "t.id",
+}
+
+// scanTodo は todoSelectColumns で取得した1行を、列名で対応付けてTodoエンティティにスキャンします
+// NULL許容の列は sql.NullTime / sql.NullInt64 で受け取り、エンティティではポインタに変換します
+func scanTodo(rows *sql.Rows) (*entity.Todo, error) {
+	var todo entity.Todo
+	var remindAt, dueDate, deletedAt sql.NullTime
+	var recurrence, color, tags string
+	var recurrenceParentID, projectID, userID, workspaceID sql.NullInt64
+	err := sqlrepo.ScanColumns(rows, "todos", sqlrepo.Columns{
+		"id":                   &todo.ID,
+		"title":                &todo.Title,
+		"description":          &todo.Description,
+		"is_completed":         &todo.IsCompleted,
+		"created_at":           &todo.CreatedAt,
+		"updated_at":           &todo.UpdatedAt,
+		"remind_at":            &remindAt,
+		"due_date":             &dueDate,
+		"recurrence":           &recurrence,
+		"recurrence_parent_id": &recurrenceParentID,
+		"color":                &color,
+		"estimate_minutes":     &todo.EstimateMinutes,
+		"actual_minutes":       &todo.ActualMinutes,
+		"tags":                 &tags,
+		"project_id":           &projectID,
+		"user_id":              &userID,
+		"workspace_id":         &workspaceID,
+		"deleted_at":           &deletedAt,
+		"checklist_total":      &todo.ChecklistProgress.Total,
+		"checklist_done":       &todo.ChecklistProgress.Done,
+	}, todoRequiredColumns...)
+	if err != nil {
+		return nil, err
+	}
+	if remindAt.Valid {
+		todo.ScheduleReminder(remindAt.Time)
+	}
+	if dueDate.Valid {
+		due := dueDate.Time.UTC()
+		todo.DueDate = &due
+	}
+	todo.Recurrence = entity.Recurrence(recurrence)
+	todo.Color = entity.Color(color)
+	todo.Tags = splitTags(tags)
+	if recurrenceParentID.Valid {
+		parentID := int(recurrenceParentID.Int64)
+		todo.RecurrenceParentID = &parentID
+	}
+	if projectID.Valid {
+		id := int(projectID.Int64)
+		todo.ProjectID = &id
+	}
+	if userID.Valid {
+		id := int(userID.Int64)
+		todo.UserID = &id
+	}
+	if workspaceID.Valid {
+		id := int(workspaceID.Int64)
+		todo.WorkspaceID = &id
+	}
+	if deletedAt.Valid {
+		deleted := deletedAt.Time.UTC()
+		todo.DeletedAt = &deleted
+	}
+	return &todo, nil
+}
+
+// joinTags はタグの一覧を tags 列に保存するカンマ区切りの文字列に変換します
+// タグにはカンマを使用できないため（entity.IsValidTag）、区切り文字と衝突しません
+func joinTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
+
+// splitTags は tags 列のカンマ区切りの文字列をタグの一覧に変換します（空の場合は nil）
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// nullableInt は *int をSQLのパラメータ値に変換します
+// nil の場合は NULL になります
+func nullableInt(i *int) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*i), Valid: true}
+}
+
+// nullableTime は *time.Time をSQLのパラメータ値に変換します
+// nil の場合は NULL、それ以外はUTCの時刻を返します
+func nullableTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
 }
 
 // Create は新しいTodoをデータベースに保存します
 // 標準パッケージを使ったINSERT操作の学習
 func (r *todoRepositoryImpl) Create(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
-	// 1. INSERT用のSQL文を定義
+	// 1. INSERT用のSQL文（insertTodoQuery）とパラメータを用意
 	// プリペアードステートメント（?プレースホルダー）でSQLインジェクション対策
-	// created_at, updated_atは現在時刻、is_completedはfalseで固定
-	query := `
-		INSERT INTO todos (title, description, is_completed, created_at, updated_at)
-		VALUES (?, ?, false, datetime('now'), datetime('now'))
-	`
+	setTodoScope(ctx, todo)
 
 	// 2. コンテキスト付きでSQL実行
 	// ExecContext はINSERT/UPDATE/DELETE用（結果行を返さない）
-	result, err := r.db.ExecContext(ctx, query, todo.Title, todo.Description)
+	result, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx, insertTodoQuery, insertTodoArgs(todo, currentTimestamp())...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert todo: %w", err)
 	}
@@ -59,253 +242,824 @@ func (r *todoRepositoryImpl) Create(ctx context.Context, todo *entity.Todo) (*en
 		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
 	}
 
-	// 4. IDを設定して作成済みTodoを返却
-	todo.ID = int(id)
-	todo.IsCompleted = false
-	todo.CreatedAt = time.Now()
-	todo.UpdatedAt = time.Now()
-
+	// 4. 保存した行を読み直して返却
+	// 日時や既定値を、レスポンスと保存した値とで食い違わせないため（同じトランザクションの中でも読み直せる）
+	// 渡されたTodoにも保存した値を反映し、これまでどおり同じポインタを返します
+	created, err := r.GetByID(ctx, int(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read created todo %d: %w", id, err)
+	}
+	*todo = *created
 	return todo, nil
 }
 
+// insertTodoQuery はTodoを1件作成するINSERT文です（パラメータは insertTodoArgs）
+// created_at, updated_atは現在時刻、is_completedはfalseで固定
+const insertTodoQuery = `
+	INSERT INTO todos (title, description, is_completed, remind_at, due_date, recurrence, recurrence_parent_id, color, estimate_minutes, actual_minutes, tags, project_id, user_id, workspace_id, created_at, updated_at)
+	VALUES (?, ?, false, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+// setTodoScope は作成するTodoの所有者・ワークスペースをコンテキストから設定します（設定されていない場合は NULL）
+func setTodoScope(ctx context.Context, todo *entity.Todo) {
+	todo.UserID, todo.WorkspaceID = nil, nil
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		todo.UserID = &userID
+	}
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		todo.WorkspaceID = &workspaceID
+	}
+}
+
+// insertTodoArgs は insertTodoQuery のパラメータを返します（now は作成日時・更新日時）
+func insertTodoArgs(todo *entity.Todo, now time.Time) []any {
+	return []any{
+		todo.Title,
+		todo.Description,
+		nullableTime(todo.RemindAt),
+		nullableTime(todo.DueDate),
+		string(todo.Recurrence),
+		nullableInt(todo.RecurrenceParentID),
+		string(todo.Color),
+		todo.EstimateMinutes,
+		todo.ActualMinutes,
+		joinTags(todo.Tags),
+		nullableInt(todo.ProjectID),
+		nullableInt(todo.UserID),
+		nullableInt(todo.WorkspaceID),
+		now,
+		now,
+	}
+}
+
+// CreateMany は複数のTodoを1つのトランザクションで作成します
+// INSERT文は1回だけ準備（Prepare）して各行で再利用し、作成した行は最後に1回のSELECTでまとめて読み直します
+//
+// 複数行のINSERT（VALUES (...), (...)）にしないのは、LastInsertId() では先頭（SQLiteは最後）のIDしか得られず、
+// MySQLの innodb_autoinc_lock_mode の設定によっては残りのIDが連番になる保証がないためです
+func (r *todoRepositoryImpl) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	if len(todos) == 0 {
+		return []*entity.Todo{}, nil
+	}
+
+	ids := make([]int, len(todos))
+	var created []*entity.Todo
+	err := sqlrepo.InTx(ctx, r.db, "todo inserts", func(tx *sql.Tx) error {
+		// 1. 準備したINSERT文で1件ずつ作成（失敗した時点でロールバック）
+		stmt, err := tx.PrepareContext(ctx, insertTodoQuery)
+		if err != nil {
+			return fmt.Errorf("failed to prepare todo insert: %w", err)
+		}
+		defer stmt.Close()
+
+		now := currentTimestamp()
+		for i, todo := range todos {
+			setTodoScope(ctx, todo)
+			result, err := stmt.ExecContext(ctx, insertTodoArgs(todo, now)...)
+			if err != nil {
+				return fmt.Errorf("failed to insert todo %d: %w", i+1, err)
+			}
+			id, err := result.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("failed to get inserted ID: %w", err)
+			}
+			ids[i] = int(id)
+		}
+
+		// 2. 作成した行をまとめて読み直す（Create と同様に、保存した値を返すため）
+		// インポートのように件数が多い場合も、プレースホルダー数の上限を超えないよう区切って読み込む
+		created = make([]*entity.Todo, 0, len(ids))
+		for start := 0; start < len(ids); start += todoReadBatchSize {
+			chunk, err := selectTodosByID(ctx, tx, ids[start:min(start+todoReadBatchSize, len(ids))])
+			if err != nil {
+				return err
+			}
+			created = append(created, chunk...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 渡されたTodoにも保存した値を反映し、引数と同じ順序で返却
+	for i, todo := range todos {
+		*todo = *created[i]
+	}
+	return todos, nil
+}
+
+// todoReadBatchSize は selectTodosByID で1回に読み込むTodoの最大数です
+const todoReadBatchSize = 500
+
+// selectTodosByID は指定したIDのTodoを ids と同じ順序で取得します（1件でも見つからない場合はエラー）
+func selectTodosByID(ctx context.Context, db sqlrepo.Querier, ids []int) ([]*entity.Todo, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	query := `
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.id IN (` + strings.Join(placeholders, ", ") + `)
+	`
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todos: %w", err)
+	}
+	found, err := sqlrepo.ScanAll(rows, scanTodo)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*entity.Todo, len(found))
+	for _, todo := range found {
+		byID[todo.ID] = todo
+	}
+	todos := make([]*entity.Todo, len(ids))
+	for i, id := range ids {
+		if todos[i] = byID[id]; todos[i] == nil {
+			return nil, fmt.Errorf("failed to read created todo %d: %w", id, domainerr.NotFound("todo", nil))
+		}
+	}
+	return todos, nil
+}
+
+// currentTimestamp は作成日時・更新日時として保存する現在時刻です
+// DBサーバーの時計やタイムゾーンの設定に依存しないよう、アプリケーション側でUTCの秒単位の時刻を決めて保存します
+// （DATETIME 列は秒未満を保存しないため、切り捨てておくことで読み直した値と一致します）
+func currentTimestamp() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
 // GetByID は主キーによる1件取得を行います
 // 標準パッケージを使ったSELECT操作とNULL値の扱い方を学習
 func (r *todoRepositoryImpl) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
-	// 1. SELECT用のSQL文を定義
+	// 1. SELECT用のSQL文を定義（他のユーザーのTodoは見つからない扱い）
+	owner, ownerArgs := scopeCondition(ctx, "t.")
 	query := `
-		SELECT id, title, description, is_completed, created_at, updated_at
-		FROM todos
-		WHERE id = ?
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.id = ? AND ` + owner + `
 	`
 
-	// 2. 1行取得用のQueryRowContext を使用
-	row := r.db.QueryRowContext(ctx, query, id)
+	// 2. 列名で対応付けるため、1行の取得でも QueryContext を使用（sqlrepo.ScanOne を参照）
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, query, append([]any{id}, ownerArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todo: %w", err)
+	}
 
 	// 3. 結果を構造体にスキャン
-	var todo entity.Todo
-	err := row.Scan(
-		&todo.ID,
-		&todo.Title,
-		&todo.Description,
-		&todo.IsCompleted,
-		&todo.CreatedAt,
-		&todo.UpdatedAt,
-	)
-
+	todo, err := sqlrepo.ScanOne(rows, scanTodo)
 	if err != nil {
 		// sql.ErrNoRows は「データが見つからない」を示す標準エラー
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("todo not found")
+			return nil, domainerr.NotFound("todo", nil)
 		}
 		return nil, fmt.Errorf("failed to scan todo: %w", err)
 	}
 
-	return &todo, nil
+	// 4. 削除済みのTodoは見つからない扱い
+	// WHERE句ではなくスキャンした deleted_at で判定するため、列を追加するマイグレーションの前でも読み込めます
+	if todo.DeletedAt != nil {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+
+	return todo, nil
+}
+
+// Exists は主キーでTodoの存在を確認します（行は読み込みません）
+// GetByID と同じく、削除済みのTodoと他のユーザーのTodoは存在しないものとして扱います
+func (r *todoRepositoryImpl) Exists(ctx context.Context, id int) (bool, error) {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	query := `SELECT EXISTS(SELECT 1 FROM todos WHERE id = ? AND deleted_at IS NULL AND ` + owner + `)`
+
+	var exists bool
+	if err := sqlrepo.Conn(ctx, r.db).QueryRowContext(ctx, query, append([]any{id}, ownerArgs...)...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check todo %d: %w", id, err)
+	}
+	return exists, nil
 }
 
 // GetAll は全件取得を行います
 // 標準パッケージを使った複数行取得とRowsの適切な処理を学習
 func (r *todoRepositoryImpl) GetAll(ctx context.Context) ([]*entity.Todo, error) {
-	// 1. SELECT用のSQL文（作成日時の降順でソート）
+	// 1. SELECT用のSQL文（作成日時の降順でソート。アーカイブ済みのプロジェクトのTodoと、他のユーザーのTodoは除く）
+	owner, ownerArgs := scopeCondition(ctx, "t.")
 	query := `
-		SELECT id, title, description, is_completed, created_at, updated_at
-		FROM todos
-		ORDER BY created_at DESC
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE ` + visibleTodoCondition + ` AND ` + owner + `
+		ORDER BY t.created_at DESC
 	`
 
 	// 2. 複数行取得用のQueryContext を使用
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, query, ownerArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query todos: %w", err)
 	}
 
-	// 3. 重要：rowsは必ずClose()する（deferで確実に実行）
-	// リソースリーク防止のための必須処理
-	defer rows.Close()
+	// 3. 全ての行を列名でTodoに変換
+	// rows の Close()・rows.Next() のループ・rows.Err() の確認は sqlrepo.ScanAll がまとめて行います
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
+
+// GetByColor は指定した色のTodoを取得します
+// color 列のインデックスで絞り込むため、全件を取得してから絞り込むより効率的です
+func (r *todoRepositoryImpl) GetByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	return r.Find(ctx, repository.TodoFilter{Color: color}, "")
+}
+
+// todoOrders は並び順ごとの ORDER BY 句の内容です（entity.TodoSortOrder.Sort と同じ順序）
+// 同じ順位のTodoは、メモリ上の実装と同じく作成日時の新しい順（同時刻はIDの降順）に並べます
+var todoOrders = map[entity.TodoSortOrder]string{
+	entity.TodoSortNewest:  "t.created_at DESC, t.id DESC",
+	entity.TodoSortOldest:  "t.created_at ASC, t.id DESC",
+	entity.TodoSortDueDate: "CASE WHEN t.due_date IS NULL THEN 1 ELSE 0 END, t.due_date ASC, t.created_at DESC, t.id DESC",
+	entity.TodoSortTitle:   "LOWER(t.title) ASC, t.created_at DESC, t.id DESC",
+}
+
+// Find は条件と並び順を1つのSELECT文に組み立てて、Todoを取得します
+// 条件は filterCondition が定義済みの列だけで組み立て、並び順は todoOrders の定義だけを埋め込みます
+func (r *todoRepositoryImpl) Find(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	if order == "" {
+		order = entity.TodoSortNewest
+	}
+	orderBy, ok := todoOrders[order]
+	if !ok {
+		return nil, domainerr.Invalid("sort order", fmt.Sprintf("%q", order))
+	}
+	where, args, err := filterCondition(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + todoSelectColumns + ` FROM todos t WHERE ` + where + ` ORDER BY ` + orderBy
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find todos: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
 
-	// 4. 結果を格納するスライスを初期化
-	var todos []*entity.Todo
+// ExistsByTitle は同じタイトルのTodoが存在するかを返します
+// 件数を数える必要はないため、EXISTS で1件見つかった時点で検索を打ち切ります
+// SQLiteの LOWER はASCII文字のみを変換するため、英字以外の大文字・小文字はデータベースによって扱いが異なります
+// 所有者が設定されている場合は、そのユーザーのTodoの中での重複だけを確認します（削除済みのTodoとは重複しません）
+func (r *todoRepositoryImpl) ExistsByTitle(ctx context.Context, title string, excludeID int) (bool, error) {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	query := `SELECT EXISTS(SELECT 1 FROM todos WHERE LOWER(title) = LOWER(?) AND id <> ? AND deleted_at IS NULL AND ` + owner + `)`
 
-	// 5. rows.Next()でループして全ての行を処理
-	for rows.Next() {
-		var todo entity.Todo
+	var exists bool
+	if err := sqlrepo.Conn(ctx, r.db).QueryRowContext(ctx, query, append([]any{title, excludeID}, ownerArgs...)...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check todo title: %w", err)
+	}
+	return exists, nil
+}
+
+// todoFilterFields はTodoの絞り込みの条件に使用できる列です（todos テーブルは t というエイリアスで参照）
+var todoFilterFields = sqlrepo.Fields{
+	"color":        "t.color",
+	"is_completed": "t.is_completed",
+	"project_id":   "t.project_id",
+	"tags":         "t.tags",
+	"title":        "t.title",
+	"description":  "t.description",
+	"due_date":     "t.due_date",
+}
 
-		// 各行をScanして構造体に格納
-		err := rows.Scan(
-			&todo.ID,
-			&todo.Title,
-			&todo.Description,
-			&todo.IsCompleted,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
+// filterCondition は一覧と同じTodo（削除済み・アーカイブ済みのプロジェクト・他のユーザーのTodoを除く）のうち、
+// filter に一致するものの条件と、そのパラメータを返します（todos テーブルは t というエイリアスで参照）
+// 指定した条件だけを sqlrepo.Where で AND につなぐため、条件の組み合わせごとにSQL文を用意する必要はありません
+func filterCondition(ctx context.Context, filter repository.TodoFilter) (string, []any, error) {
+	owner, ownerArgs := scopeCondition(ctx, "t.")
+	where := todoFilterFields.Where().Raw(visibleTodoCondition).Raw(owner, ownerArgs...)
+	if filter.Color != entity.ColorNone {
+		where.And("color", sqlrepo.Eq, string(filter.Color))
+	}
+	if filter.Completed != nil {
+		where.And("is_completed", sqlrepo.Eq, *filter.Completed)
+	}
+	if filter.ProjectID != nil {
+		where.And("project_id", sqlrepo.Eq, *filter.ProjectID)
+	}
+	if filter.Tag != "" {
+		where.And("tags", sqlrepo.HasItem, filter.Tag)
+	}
+	if filter.Search != "" {
+		where.AnyOf(
+			sqlrepo.Condition{Field: "title", Op: sqlrepo.Contains, Value: filter.Search},
+			sqlrepo.Condition{Field: "description", Op: sqlrepo.Contains, Value: filter.Search},
 		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan todo row: %w", err)
-		}
+	}
+	if filter.DueFrom != nil {
+		where.And("due_date", sqlrepo.Ge, filter.DueFrom.UTC())
+	}
+	if filter.DueTo != nil {
+		where.And("due_date", sqlrepo.Lt, filter.DueTo.UTC())
+	}
 
-		// スライスに追加
-		todos = append(todos, &todo)
+	condition, args, err := where.Build()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build todo filter: %w", err)
 	}
+	return condition, args, nil
+}
 
-	// 6. ループ終了後にエラーチェック
-	// ネットワークエラーなどでループが中断された場合を検出
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during rows iteration: %w", err)
+// Count は条件に一致するTodoの件数を COUNT(*) で数えます（Todoは読み込みません）
+func (r *todoRepositoryImpl) Count(ctx context.Context, filter repository.TodoFilter) (int, error) {
+	where, args, err := filterCondition(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	count, err := sqlrepo.Count(ctx, sqlrepo.Conn(ctx, r.db), `SELECT COUNT(*) FROM todos t WHERE `+where, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count todos: %w", err)
 	}
+	return int(count), nil
+}
 
-	return todos, nil
+// todoGroupKeys は集計の単位ごとの、グループのキーを求めるSQLの式です
+// キーは文字列にそろえ、NULL（色なし・プロジェクトなし）は空文字にします
+var todoGroupKeys = map[repository.TodoGroup]string{
+	repository.TodoGroupColor:     "COALESCE(t.color, '')",
+	repository.TodoGroupProject:   "COALESCE(CAST(t.project_id AS CHAR), '')",
+	repository.TodoGroupCompleted: "CASE WHEN t.is_completed = 1 THEN 'true' ELSE 'false' END",
 }
 
-// Update は既存レコードの更新を行います
-// 標準パッケージを使ったUPDATE操作と影響行数の確認を学習
-func (r *todoRepositoryImpl) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
-	// 1. UPDATE用のSQL文を定義
-	// updated_at は現在時刻で自動更新
+// CountGrouped は条件に一致するTodoの件数を GROUP BY でグループごとに数えます
+func (r *todoRepositoryImpl) CountGrouped(ctx context.Context, group repository.TodoGroup, filter repository.TodoFilter) ([]repository.TodoGroupCount, error) {
+	key, ok := todoGroupKeys[group]
+	if !ok {
+		return nil, domainerr.Invalid("group", fmt.Sprintf("%q", group))
+	}
+	where, args, err := filterCondition(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		UPDATE todos
-		SET title = ?, description = ?, is_completed = ?, updated_at = datetime('now')
-		WHERE id = ?
+		SELECT ` + key + ` AS group_key, COUNT(*) AS count
+		FROM todos t
+		WHERE ` + where + `
+		GROUP BY group_key
+		ORDER BY group_key
 	`
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos by %s: %w", group, err)
+	}
+	return sqlrepo.ScanAll(rows, func(rows *sql.Rows) (repository.TodoGroupCount, error) {
+		var count repository.TodoGroupCount
+		err := rows.Scan(&count.Key, &count.Count)
+		return count, err
+	})
+}
 
-	// 2. UPDATE実行
-	result, err := r.db.ExecContext(ctx, query,
-		todo.Title,
-		todo.Description,
-		todo.IsCompleted,
-		todo.ID,
-	)
+// GetStats はTodoの件数と見積もり・実績時間を1回のSELECTで集計します
+// 条件付きの集計（SUM(CASE ...)）で entity.NewTodoStats と同じ規則を表し、Todoを読み込まずに済ませます
+func (r *todoRepositoryImpl) GetStats(ctx context.Context) (entity.TodoStats, error) {
+	where, args, err := filterCondition(ctx, repository.TodoFilter{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update todo: %w", err)
+		return entity.TodoStats{}, err
 	}
+	// compared は見積もりと実績の両方が記録された完了済みTodoの条件です（EstimateStats を参照）
+	const compared = `t.is_completed = 1 AND t.estimate_minutes > 0 AND t.actual_minutes > 0`
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN t.is_completed = 1 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN t.estimate_minutes > 0 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN t.is_completed = 0 AND t.estimate_minutes > 0 THEN t.estimate_minutes ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN ` + compared + ` THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN ` + compared + ` THEN t.estimate_minutes ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN ` + compared + ` THEN t.actual_minutes ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN ` + compared + ` AND t.actual_minutes > t.estimate_minutes THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN ` + compared + ` AND t.actual_minutes < t.estimate_minutes THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN ` + compared + ` AND t.actual_minutes = t.estimate_minutes THEN 1 ELSE 0 END), 0)
+		FROM todos t
+		WHERE ` + where
 
-	// 3. 影響を受けた行数を確認
-	// RowsAffected()で実際に更新された行数を取得
-	rowsAffected, err := result.RowsAffected()
+	var stats entity.TodoStats
+	estimates := &stats.Estimates
+	err = sqlrepo.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(
+		&stats.Total,
+		&stats.Completed,
+		&estimates.Estimated,
+		&estimates.RemainingMinutes,
+		&estimates.Compared,
+		&estimates.EstimatedMinutes,
+		&estimates.ActualMinutes,
+		&estimates.Overrun,
+		&estimates.Underrun,
+		&estimates.OnTarget,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		return entity.TodoStats{}, fmt.Errorf("failed to aggregate todos: %w", err)
 	}
+	stats.Pending = stats.Total - stats.Completed
+	return stats, nil
+}
 
-	// 4. 行が更新されなかった場合はエラー
-	if rowsAffected == 0 {
-		return nil, errors.New("todo not found")
+// Update は既存レコードの更新を行います
+// 標準パッケージを使ったUPDATE操作と影響行数の確認を学習
+func (r *todoRepositoryImpl) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	// 1. UPDATE実行（SQL文は UpdateMany と共通）
+	if err := updateTodo(ctx, sqlrepo.Conn(ctx, r.db), todo); err != nil {
+		return nil, err
 	}
 
-	// 5. 更新後のデータを取得して返却
+	// 2. 更新後のデータを取得して返却
 	// updated_at を最新の値にするため再取得
 	return r.GetByID(ctx, todo.ID)
 }
 
-// Delete は主キーによる削除を行います
-// 標準パッケージを使ったDELETE操作を学習
-func (r *todoRepositoryImpl) Delete(ctx context.Context, id int) error {
-	// 1. DELETE用のSQL文を定義
-	query := `DELETE FROM todos WHERE id = ?`
+// UpdateFields は指定したフィールドの列だけを更新するUPDATE文を実行します
+// 変更のない列を書き込まないため、同時に別の列を更新したリクエストの変更を上書きしません
+func (r *todoRepositoryImpl) UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (*entity.Todo, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("no fields to update")
+	}
 
-	// 2. DELETE実行
-	result, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete todo: %w", err)
+	// 1. SET句を組み立てる（列名はフィールドの定義に含まれるもののみ。利用者の入力をSQL文に埋め込まない）
+	assignments := make([]string, 0, len(fields)+1)
+	args := make([]any, 0, len(fields)+2)
+	for _, field := range fields {
+		value, ok := todo.FieldValue(field)
+		if !ok {
+			return nil, fmt.Errorf("unknown todo field: %s", field)
+		}
+		assignments = append(assignments, string(field)+" = ?")
+		args = append(args, todoColumnValue(value))
+	}
+	assignments = append(assignments, "updated_at = ?")
+	owner, ownerArgs := scopeCondition(ctx, "")
+	args = append(args, currentTimestamp(), todo.ID)
+	args = append(args, ownerArgs...)
+
+	// 2. UPDATE実行と影響行数の確認
+	query := `UPDATE todos SET ` + strings.Join(assignments, ", ") + ` WHERE id = ? AND deleted_at IS NULL AND ` + owner
+	if err := sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "update todo", domainerr.NotFound("todo", nil), query, args...); err != nil {
+		return nil, err
 	}
 
-	// 3. 影響を受けた行数を確認
-	rowsAffected, err := result.RowsAffected()
+	// 3. 更新後のデータを取得して返却
+	return r.GetByID(ctx, todo.ID)
+}
+
+// todoColumnValue はフィールドの値を列に書き込める値に変換します
+func todoColumnValue(value any) any {
+	switch v := value.(type) {
+	case *time.Time:
+		return nullableTime(v)
+	case entity.Recurrence:
+		return string(v)
+	case entity.Color:
+		return string(v)
+	case []string:
+		return joinTags(v)
+	case *int:
+		return nullableInt(v)
+	default:
+		return v
+	}
+}
+
+// UpdateMany は複数のTodoを1つのトランザクションで更新します
+// 1件でも失敗した場合はロールバックし、どのTodoも更新されません
+func (r *todoRepositoryImpl) UpdateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	// 1. 同じトランザクションで1件ずつ更新（失敗した時点でロールバック）
+	err := sqlrepo.InTx(ctx, r.db, "todo updates", func(tx *sql.Tx) error {
+		for _, todo := range todos {
+			if err := updateTodo(ctx, tx, todo); err != nil {
+				return fmt.Errorf("todo with ID %d: %w", todo.ID, err)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, err
 	}
 
-	// 4. 削除された行がない場合はエラー
-	if rowsAffected == 0 {
-		return errors.New("todo not found")
+	// 2. 更新後のデータを取得して返却
+	updated := make([]*entity.Todo, len(todos))
+	for i, todo := range todos {
+		if updated[i], err = r.GetByID(ctx, todo.ID); err != nil {
+			return nil, err
+		}
 	}
+	return updated, nil
+}
 
-	return nil
+// UpdateWithLock は SELECT ... FOR UPDATE で対象の行をロックしてから、mutate で変更したTodoを保存します
+// ロックはトランザクションの終了（コミットまたはロールバック）まで保持されるため、
+// 同じTodoに対する他の UpdateWithLock は、このトランザクションが終わるまで読み込みを待ちます
+func (r *todoRepositoryImpl) UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (*entity.Todo, error) {
+	var locked *entity.Todo
+	write := true
+	err := sqlrepo.InTx(ctx, r.db, "locked todo update", func(tx *sql.Tx) error {
+		// 1. 対象の行をロックして読み込み
+		todo, err := r.selectForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// 2. ロック中のTodoを変更（ErrNoChange の場合は書き込まず、読み込んだままのTodoを返す）
+		original := *todo
+		if err := mutate(todo); err != nil {
+			if errors.Is(err, repository.ErrNoChange) {
+				locked, write = &original, false
+				return nil
+			}
+			return err
+		}
+
+		// 3. 同じトランザクションで保存
+		return updateTodo(ctx, tx, todo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !write {
+		return locked, nil
+	}
+
+	// 4. 更新後のデータを取得して返却
+	return r.GetByID(ctx, id)
 }
 
-// GetByCompleteStatus は完了状態による検索を行います（将来の拡張用）
-// WHERE句を使った条件検索の学習
-func (r *todoRepositoryImpl) GetByCompleteStatus(ctx context.Context, isCompleted bool) ([]*entity.Todo, error) {
+// selectForUpdate はトランザクション内で対象の行をロックし、Todoを読み込みます
+func (r *todoRepositoryImpl) selectForUpdate(ctx context.Context, tx *sql.Tx, id int) (*entity.Todo, error) {
+	owner, ownerArgs := scopeCondition(ctx, "t.")
+	args := append([]any{id}, ownerArgs...)
 	query := `
-		SELECT id, title, description, is_completed, created_at, updated_at
-		FROM todos
-		WHERE is_completed = ?
-		ORDER BY created_at DESC
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.id = ? AND ` + liveTodoCondition + ` AND ` + owner + `
+		FOR UPDATE
 	`
+	if r.sqliteLocking {
+		// SQLiteでは最初の書き込みでロックを取得し、FOR UPDATE を付けずに読み込む
+		lockOwner, _ := scopeCondition(ctx, "")
+		lock := `UPDATE todos SET id = id WHERE id = ? AND deleted_at IS NULL AND ` + lockOwner
+		if err := sqlrepo.ExecAffecting(ctx, tx, "lock todo", domainerr.NotFound("todo", nil), lock, args...); err != nil {
+			return nil, err
+		}
+		query = strings.Replace(query, "FOR UPDATE", "", 1)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, isCompleted)
+	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query todos by status: %w", err)
-	}
-	defer rows.Close()
-
-	var todos []*entity.Todo
-	for rows.Next() {
-		var todo entity.Todo
-		err := rows.Scan(
-			&todo.ID,
-			&todo.Title,
-			&todo.Description,
-			&todo.IsCompleted,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan todo row: %w", err)
+		return nil, fmt.Errorf("failed to lock todo: %w", err)
+	}
+	todo, err := sqlrepo.ScanOne(rows, scanTodo)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("todo", nil)
 		}
-		todos = append(todos, &todo)
+		return nil, fmt.Errorf("failed to scan todo: %w", err)
 	}
+	return todo, nil
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during rows iteration: %w", err)
+// updateTodo は1件のTodoを更新するUPDATE文を実行します
+// 更新された行がない場合（他のユーザーのTodoを含む）は "todo not found" を返します
+func updateTodo(ctx context.Context, db sqlrepo.Execer, todo *entity.Todo) error {
+	// 1. UPDATE用のSQL文を定義
+	// updated_at は現在時刻で自動更新（削除済みのTodoは更新しない）
+	owner, ownerArgs := scopeCondition(ctx, "")
+	query := `
+		UPDATE todos
+		SET title = ?, description = ?, is_completed = ?, remind_at = ?, due_date = ?, recurrence = ?, color = ?, estimate_minutes = ?, actual_minutes = ?, tags = ?, project_id = ?, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL AND ` + owner + `
+	`
+
+	// 2. UPDATE実行と影響行数の確認
+	// recurrence_parent_id・user_id・workspace_id は作成時に決まり、以降は変更しない
+	// 更新された行がない（RowsAffected() が 0）場合は "todo not found" を返す
+	args := []any{
+		todo.Title,
+		todo.Description,
+		todo.IsCompleted,
+		nullableTime(todo.RemindAt),
+		nullableTime(todo.DueDate),
+		string(todo.Recurrence),
+		string(todo.Color),
+		todo.EstimateMinutes,
+		todo.ActualMinutes,
+		joinTags(todo.Tags),
+		nullableInt(todo.ProjectID),
+		currentTimestamp(),
+		todo.ID,
 	}
+	return sqlrepo.ExecAffecting(ctx, db, "update todo", domainerr.NotFound("todo", nil), query, append(args, ownerArgs...)...)
+}
 
-	return todos, nil
+// upsertTodoColumns は Upsert で INSERT する列です（deleted_at は常に NULL を渡し、更新時は削除済みのTodoを復元します）
+var upsertTodoColumns = []string{"title", "description", "is_completed", "remind_at", "due_date", "recurrence", "recurrence_parent_id", "color", "estimate_minutes", "actual_minutes", "tags", "project_id", "user_id", "workspace_id", "created_at", "updated_at", "deleted_at", "id"}
+
+// upsertTodoUpdates は Upsert で既存のTodoを置き換える列です
+// 作成日時・オカレンスの元のシリーズ・所有者は updateTodo と同じく変更しません
+var upsertTodoUpdates = []string{"title", "description", "is_completed", "remind_at", "due_date", "recurrence", "color", "estimate_minutes", "actual_minutes", "tags", "project_id", "updated_at", "deleted_at"}
+
+// upsertScopeCondition は Upsert で既存のTodoを置き換える条件（scopeCondition と同じ範囲）を返します
+// UPSERT の条件ではプレースホルダーを使えないため、setTodoScope でコンテキストから設定して INSERT しようとした
+// 所有者・ワークスペースと比べます。どちらも設定されていない場合は "" （常に置き換える）です
+func upsertScopeCondition(ctx context.Context, dialect sqlrepo.Dialect) string {
+	if _, ok := repository.WorkspaceFromContext(ctx); ok {
+		return "todos.workspace_id = " + dialect.Inserted("workspace_id")
+	}
+	if _, ok := repository.OwnerFromContext(ctx); ok {
+		return "todos.user_id = " + dialect.Inserted("user_id") + " AND todos.workspace_id IS NULL"
+	}
+	return ""
 }
 
-// GetWithPagination はページング機能付きの取得を行います（将来の拡張用）
-// LIMIT、OFFSET句を使った標準的なページング実装を学習
-func (r *todoRepositoryImpl) GetWithPagination(ctx context.Context, offset, limit int) ([]*entity.Todo, int64, error) {
-	// 1. 総件数を取得するSQL
-	countQuery := `SELECT COUNT(*) FROM todos`
-	var total int64
+// Upsert はIDを指定してTodoを作成し、同じIDのTodoが既にある場合はその内容を更新します（インポート・同期用）
+//
+// 作成と更新は方言のUPSERT（sqlrepo.Dialect.UpsertIf）の1つの文で行います。INSERT の一意制約違反を捕まえてから
+// UPDATE する方法は、失敗した文でトランザクション全体が中断されるエンジン（PostgreSQL 等）では使えないためです
+// 他のユーザー・ワークスペースのTodoを書き換えないよう、既存の行が所有者の範囲（upsertScopeCondition）にある場合だけ置き換えます
+// 範囲外の行は変更されずに残り、続く読み直しで見つからないため "todo not found" になります。文の実行と読み直しは1つのトランザクションで行います
+func (r *todoRepositoryImpl) Upsert(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	if todo.ID <= 0 {
+		return nil, domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+
+	var upserted *entity.Todo
+	err := sqlrepo.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		db := sqlrepo.Conn(ctx, r.db)
+		now := currentTimestamp()
 
-	err := r.db.QueryRowContext(ctx, countQuery).Scan(&total)
+		// 1. 同じIDで作成し、既にある場合は内容を更新（完了状態も指定どおりに保存し、所有者・ワークスペースはコンテキストから設定）
+		setTodoScope(ctx, todo)
+		query := r.dialect.UpsertIf("todos", upsertTodoColumns, []string{"id"}, upsertTodoUpdates, upsertScopeCondition(ctx, r.dialect))
+		_, err := db.ExecContext(ctx, query,
+			todo.Title,
+			todo.Description,
+			todo.IsCompleted,
+			nullableTime(todo.RemindAt),
+			nullableTime(todo.DueDate),
+			string(todo.Recurrence),
+			nullableInt(todo.RecurrenceParentID),
+			string(todo.Color),
+			todo.EstimateMinutes,
+			todo.ActualMinutes,
+			joinTags(todo.Tags),
+			nullableInt(todo.ProjectID),
+			nullableInt(todo.UserID),
+			nullableInt(todo.WorkspaceID),
+			now,
+			now,
+			nil,
+			todo.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert todo: %w", err)
+		}
+
+		// 2. 保存した行を読み直して返却（他のユーザーのTodoだった場合は "todo not found"）
+		upserted, err = r.GetByID(ctx, todo.ID)
+		return err
+	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count todos: %w", err)
+		return nil, err
 	}
+	return upserted, nil
+}
 
-	// 2. ページング付きでデータを取得するSQL
-	dataQuery := `
-		SELECT id, title, description, is_completed, created_at, updated_at
-		FROM todos
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
+// Delete はTodoをソフト削除します
+// 行は削除せずに deleted_at へ削除日時を記録するため、チェックリスト項目や共有もそのまま残ります
+// 削除済みのTodoや他のユーザーのTodoの場合は "todo not found" になります
+func (r *todoRepositoryImpl) Delete(ctx context.Context, id int) error {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	return sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "delete todo", domainerr.NotFound("todo", nil),
+		`UPDATE todos SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL AND `+owner,
+		append([]any{currentTimestamp(), id}, ownerArgs...)...)
+}
+
+// GetDeleted は削除済みのTodoを削除日時の降順（同時刻はIDの降順）で取得します
+// アーカイブ済みのプロジェクトに属するTodoも含みます（ごみ箱からは所属に関わらず復元できるようにするため）
+func (r *todoRepositoryImpl) GetDeleted(ctx context.Context) ([]*entity.Todo, error) {
+	owner, ownerArgs := scopeCondition(ctx, "t.")
+	query := `
+		SELECT ` + todoSelectColumns + `
+		FROM todos t
+		WHERE t.deleted_at IS NOT NULL AND ` + owner + `
+		ORDER BY t.deleted_at DESC, t.id DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, dataQuery, limit, offset)
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, query, ownerArgs...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query todos with pagination: %w", err)
+		return nil, fmt.Errorf("failed to query deleted todos: %w", err)
 	}
-	defer rows.Close()
-
-	var todos []*entity.Todo
-	for rows.Next() {
-		var todo entity.Todo
-		err := rows.Scan(
-			&todo.ID,
-			&todo.Title,
-			&todo.Description,
-			&todo.IsCompleted,
-			&todo.CreatedAt,
-			&todo.UpdatedAt,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan todo row: %w", err)
+	return sqlrepo.ScanAll(rows, scanTodo)
+}
+
+// Restore は削除したTodoを、削除前と同じIDと作成日時のまま保存し直します
+// ソフト削除された行がある場合は todo の内容で書き戻して deleted_at を解除し、
+// 行がない場合（HardDelete 済みやスナップショットからの読み込み）はIDを明示してINSERTします
+// 削除されていない同じIDの行が既にある場合は一意制約違反になります
+// チェックリスト項目は含みません（HardDelete 済みの場合は、呼び出し側が ChecklistRepository で作成し直します）
+// コンテキストに所有者・ワークスペースがある場合は、そのユーザー・ワークスペースのTodoとして保存し直します
+func (r *todoRepositoryImpl) Restore(ctx context.Context, todo *entity.Todo) error {
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		todo.UserID = &userID
+	}
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		todo.WorkspaceID = &workspaceID
+	}
+	fields := []any{
+		todo.Title,
+		todo.Description,
+		todo.IsCompleted,
+		nullableTime(todo.RemindAt),
+		nullableTime(todo.DueDate),
+		string(todo.Recurrence),
+		nullableInt(todo.RecurrenceParentID),
+		string(todo.Color),
+		todo.EstimateMinutes,
+		todo.ActualMinutes,
+		joinTags(todo.Tags),
+		nullableInt(todo.ProjectID),
+		nullableInt(todo.UserID),
+		nullableInt(todo.WorkspaceID),
+		todo.CreatedAt.UTC(),
+		todo.UpdatedAt.UTC(),
+	}
+
+	// 1. ソフト削除された行を書き戻す（他のユーザーの削除済みのTodoは対象外）
+	owner, ownerArgs := scopeCondition(ctx, "")
+	undelete := `
+		UPDATE todos
+		SET title = ?, description = ?, is_completed = ?, remind_at = ?, due_date = ?, recurrence = ?, recurrence_parent_id = ?, color = ?, estimate_minutes = ?, actual_minutes = ?, tags = ?, project_id = ?, user_id = ?, workspace_id = ?, created_at = ?, updated_at = ?, deleted_at = NULL
+		WHERE id = ? AND deleted_at IS NOT NULL AND ` + owner + `
+	`
+	args := append(append(append([]any{}, fields...), todo.ID), ownerArgs...)
+	err := sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "restore todo", domainerr.NotFound("todo", nil), undelete, args...)
+	if !domainerr.IsNotFound(err) {
+		return err
+	}
+
+	// 2. 行がない場合は同じIDで作成し直す
+	query := `
+		INSERT INTO todos (title, description, is_completed, remind_at, due_date, recurrence, recurrence_parent_id, color, estimate_minutes, actual_minutes, tags, project_id, user_id, workspace_id, created_at, updated_at, id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	if _, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx, query, append(fields, todo.ID)...); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("todo with ID %d already exists", todo.ID)
+		}
+		return fmt.Errorf("failed to restore todo: %w", err)
+	}
+	return nil
+}
+
+// HardDelete はTodoを、チェックリスト項目・共有とともに完全に削除します
+// 削除済み（ソフト削除）のTodoも対象です
+func (r *todoRepositoryImpl) HardDelete(ctx context.Context, id int) error {
+	// Todo集約（チェックリスト項目を含む）をまとめて削除するため1つのトランザクションで実行
+	return sqlrepo.InTx(ctx, r.db, "todo deletion", func(tx *sql.Tx) error {
+		// 1. 子テーブル（チェックリスト項目・共有）を先に削除
+		// 外部キー制約が無効な環境（SQLite等）でも孤児レコードを残さないため明示的に削除
+		if _, err := tx.ExecContext(ctx, `DELETE FROM checklist_items WHERE todo_id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete checklist items: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM todo_shares WHERE todo_id = ?`, id); err != nil {
+			return fmt.Errorf("failed to delete todo shares: %w", err)
 		}
-		todos = append(todos, &todo)
+
+		// 2. DELETE実行（削除された行がない場合はエラーを返し、ロールバックされる）
+		// 他のユーザーのTodoは削除されず、チェックリスト項目の削除もロールバックされる
+		owner, ownerArgs := scopeCondition(ctx, "")
+		return sqlrepo.ExecAffecting(ctx, tx, "delete todo", domainerr.NotFound("todo", nil),
+			`DELETE FROM todos WHERE id = ? AND `+owner, append([]any{id}, ownerArgs...)...)
+	})
+}
+
+// GetByCompleteStatus は完了状態による検索を行います（将来の拡張用）
+// WHERE句を使った条件検索の学習
+func (r *todoRepositoryImpl) GetByCompleteStatus(ctx context.Context, isCompleted bool) ([]*entity.Todo, error) {
+	return r.Find(ctx, repository.TodoFilter{Completed: &isCompleted}, "")
+}
+
+// GetWithPagination はページング機能付きの取得を行います（将来の拡張用）
+// LIMIT、OFFSET句を使った標準的なページング実装を学習
+func (r *todoRepositoryImpl) GetWithPagination(ctx context.Context, offset, limit int) ([]*entity.Todo, int64, error) {
+	// 1. 総件数を取得（一覧と同じく、アーカイブ済みのプロジェクトのTodoと他のユーザーのTodoは数えない）
+	owner, ownerArgs := scopeCondition(ctx, "t.")
+	where := ` WHERE ` + visibleTodoCondition + ` AND ` + owner
+	total, err := sqlrepo.Count(ctx, sqlrepo.Conn(ctx, r.db), `SELECT COUNT(*) FROM todos t`+where, ownerArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count todos: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error during rows iteration: %w", err)
+	// 2. ページング付きでデータを取得（ORDER BY / LIMIT / OFFSET は sqlrepo.List が付ける）
+	todos, err := sqlrepo.List(ctx, sqlrepo.Conn(ctx, r.db), `SELECT `+todoSelectColumns+` FROM todos t`+where,
+		sqlrepo.Page{Offset: offset, Limit: limit}, todoSorting, scanTodo, ownerArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query todos with pagination: %w", err)
 	}
 
 	return todos, total, nil
@@ -3,10 +3,15 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
+	"todoapp-api-golang/internal/domain/domainerr"
 	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
 
 	// SQLite ドライバーをテスト用に使用
 	_ "github.com/mattn/go-sqlite3"
@@ -26,20 +31,8 @@ func setupTestDB(t *testing.T) *sql.DB {
 		t.Fatalf("テストデータベースの作成に失敗: %v", err)
 	}
 
-	// Todosテーブルを作成
-	createTable := `
-		CREATE TABLE todos (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			title TEXT NOT NULL,
-			description TEXT,
-			is_completed BOOLEAN NOT NULL DEFAULT 0,
-			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
-		)
-	`
-
-	_, err = db.Exec(createTable)
-	if err != nil {
+	// 本番と同じ構成のテーブルを作成（SQLite用のDDLは sqlite_schema.go で管理）
+	if err := CreateSQLiteTables(db); err != nil {
 		t.Fatalf("テストテーブルの作成に失敗: %v", err)
 	}
 
@@ -158,6 +151,137 @@ func TestTodoRepository_Create(t *testing.T) {
 	}
 }
 
+// TestTodoRepository_Create_ReturnsStoredValues は作成時に返すTodoが保存した値と一致することをテストします
+func TestTodoRepository_Create_ReturnsStoredValues(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "日時の確認", IsCompleted: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	stored, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !created.CreatedAt.Equal(stored.CreatedAt) || !created.UpdatedAt.Equal(stored.UpdatedAt) {
+		t.Errorf("作成日時・更新日時 = %v / %v, 保存した値 = %v / %v", created.CreatedAt, created.UpdatedAt, stored.CreatedAt, stored.UpdatedAt)
+	}
+	if created.IsCompleted {
+		t.Error("作成したTodoは未完了で保存されるべきです")
+	}
+}
+
+// TestTodoRepository_CreateMany は一括作成で、全てのTodoが引数と同じ順序で保存した値のまま返されることをテストします
+func TestTodoRepository_CreateMany(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := repository.WithOwner(context.Background(), 7)
+
+	due := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	todos := []*entity.Todo{
+		{Title: "牛乳を買う", Tags: []string{"買い物"}},
+		{Title: "請求書を送る", IsCompleted: true, DueDate: &due},
+		{Title: "掃除", EstimateMinutes: 30},
+	}
+	created, err := repo.CreateMany(ctx, todos)
+	if err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+	if len(created) != len(todos) {
+		t.Fatalf("作成した件数 = %d, 期待値 = %d", len(created), len(todos))
+	}
+	for i, todo := range created {
+		stored, err := repo.GetByID(ctx, todo.ID)
+		if err != nil {
+			t.Fatalf("GetByID(%d) error = %v", todo.ID, err)
+		}
+		if !reflect.DeepEqual(todo, stored) {
+			t.Errorf("%d件目 = %+v, 保存した値 = %+v", i, todo, stored)
+		}
+		if todo.Title != todos[i].Title || todo.IsCompleted || todo.UserID == nil || *todo.UserID != 7 {
+			t.Errorf("%d件目 = %+v, 期待値 = 未完了で所有者 7 の %q", i, todo, todos[i].Title)
+		}
+	}
+	if created[1].DueDate == nil || !created[1].DueDate.Equal(due) || created[2].EstimateMinutes != 30 {
+		t.Errorf("作成したTodo = %+v / %+v", created[1], created[2])
+	}
+
+	// 空の場合は何もしない
+	if empty, err := repo.CreateMany(ctx, nil); err != nil || len(empty) != 0 {
+		t.Errorf("CreateMany(nil) = %v, %v", empty, err)
+	}
+
+	// 呼び出し側のトランザクションに参加し、ロールバックされた場合はどのTodoも残らない
+	before := getTodoCount(t, db)
+	err = NewTransactor(db).InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := repo.CreateMany(ctx, []*entity.Todo{{Title: "a"}, {Title: "b"}}); err != nil {
+			return err
+		}
+		return errors.New("中止")
+	})
+	if err == nil {
+		t.Fatal("InTransaction() のエラーが返されませんでした")
+	}
+	if got := getTodoCount(t, db); got != before {
+		t.Errorf("ロールバック後の件数 = %d, 期待値 = %d", got, before)
+	}
+}
+
+// TestTodoRepository_Upsert はIDを指定した作成と、既存のTodoの更新・復元をテストします
+func TestTodoRepository_Upsert(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db, WithDialect(sqlrepo.SQLite))
+	ctx := repository.WithOwner(context.Background(), 7)
+
+	// 1. 存在しないIDは、完了状態も含めて指定どおりに作成
+	created, err := repo.Upsert(ctx, &entity.Todo{ID: 10, Title: "同期したTodo", IsCompleted: true})
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if created.ID != 10 || !created.IsCompleted || created.UserID == nil || *created.UserID != 7 {
+		t.Errorf("作成結果 = %+v", created)
+	}
+
+	// 2. 既にあるIDは内容を更新し、作成日時はそのまま
+	updated, err := repo.Upsert(ctx, &entity.Todo{ID: 10, Title: "更新した同期Todo", Color: "blue"})
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if updated.Title != "更新した同期Todo" || updated.IsCompleted || updated.Color != "blue" || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("更新結果 = %+v", updated)
+	}
+
+	// 3. 削除済みのTodoは復元する
+	if err := repo.Delete(ctx, 10); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if restored, err := repo.Upsert(ctx, &entity.Todo{ID: 10, Title: "復元した同期Todo"}); err != nil || restored.DeletedAt != nil {
+		t.Errorf("削除済みのTodoの Upsert() = %+v, %v", restored, err)
+	}
+
+	// 4. 他のユーザーのTodoは書き換えない
+	other := repository.WithOwner(context.Background(), 8)
+	if _, err := repo.Upsert(other, &entity.Todo{ID: 10, Title: "乗っ取り"}); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのTodoの Upsert() error = %v, 期待値 = not found", err)
+	}
+	if got, _ := repo.GetByID(ctx, 10); got.Title != "復元した同期Todo" {
+		t.Errorf("他のユーザーの Upsert() で書き換わりました: %+v", got)
+	}
+
+	// 5. IDの指定は必須で、指定したIDより後の作成は続きのIDになる
+	if _, err := repo.Upsert(ctx, &entity.Todo{Title: "IDなし"}); !domainerr.IsInvalid(err) {
+		t.Errorf("IDなしの Upsert() error = %v, 期待値 = invalid", err)
+	}
+	if next, err := repo.Create(ctx, &entity.Todo{Title: "次のTodo"}); err != nil || next.ID <= 10 {
+		t.Errorf("Create() = %+v, %v, 期待値 = 10より大きいID", next, err)
+	}
+}
+
 // TestTodoRepository_GetByID はID指定取得機能をテストします
 func TestTodoRepository_GetByID(t *testing.T) {
 	db := setupTestDB(t)
@@ -238,6 +362,43 @@ func TestTodoRepository_GetByID(t *testing.T) {
 	}
 }
 
+// TestTodoRepository_Exists は行を読み込まずに存在を確認できることをテストします
+func TestTodoRepository_Exists(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := repository.WithOwner(context.Background(), 7)
+
+	kept, _ := repo.Create(ctx, &entity.Todo{Title: "残すTodo"})
+	deleted, _ := repo.Create(ctx, &entity.Todo{Title: "削除するTodo"})
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		id   int
+		want bool
+	}{
+		{"存在するTodo", ctx, kept.ID, true},
+		{"存在しないID", ctx, 999, false},
+		{"削除済みのTodo", ctx, deleted.ID, false},
+		{"他のユーザーのTodo", repository.WithOwner(context.Background(), 8), kept.ID, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.Exists(tt.ctx, tt.id)
+			if err != nil {
+				t.Fatalf("Exists() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Exists() = %v, 期待値 = %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestTodoRepository_GetAll は全Todo取得機能をテストします
 func TestTodoRepository_GetAll(t *testing.T) {
 	db := setupTestDB(t)
@@ -292,6 +453,319 @@ func TestTodoRepository_GetAll(t *testing.T) {
 	})
 }
 
+// TestTodoRepository_GetByColor は色による絞り込みと、色の保存・更新をテストします
+func TestTodoRepository_GetByColor(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	blue, _ := repo.Create(ctx, &entity.Todo{Title: "青いカード", Color: "blue"})
+	repo.Create(ctx, &entity.Todo{Title: "赤いカード", Color: "#ff0000"})
+	repo.Create(ctx, &entity.Todo{Title: "色なし"})
+
+	tests := []struct {
+		name     string
+		color    entity.Color
+		expected []string
+	}{
+		{name: "パレットの色", color: "blue", expected: []string{"青いカード"}},
+		{name: "16進カラーコード", color: "#ff0000", expected: []string{"赤いカード"}},
+		{name: "該当なし", color: "green", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.GetByColor(ctx, tt.color)
+			if err != nil {
+				t.Fatalf("予期しないエラーが発生しました: %v", err)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("取得件数 = %d, 期待値 = %d", len(result), len(tt.expected))
+			}
+			for i, todo := range result {
+				if todo.Title != tt.expected[i] || todo.Color != tt.color {
+					t.Errorf("取得値 = %+v", todo)
+				}
+			}
+		})
+	}
+
+	// 色の変更は Update で保存される
+	blue.Color = "green"
+	if _, err := repo.Update(ctx, blue); err != nil {
+		t.Fatalf("更新に失敗: %v", err)
+	}
+	if result, _ := repo.GetByColor(ctx, "green"); len(result) != 1 || result[0].ID != blue.ID {
+		t.Errorf("更新後の色で取得できません: %+v", result)
+	}
+}
+
+// TestTodoRepository_Find は条件の組み合わせと並び順が1つのSQL文で適用されることをテストします
+func TestTodoRepository_Find(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := repository.WithOwner(context.Background(), 7)
+
+	date := func(s string) *time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return &d
+	}
+	inputs := []*entity.Todo{
+		{Title: "buy milk", Color: "blue", Tags: []string{"home", "shop"}, DueDate: date("2024-01-10")},
+		{Title: "Write report", Description: "四半期の MILK の集計", Tags: []string{"work"}, DueDate: date("2024-02-01")},
+		{Title: "Shopping list", Color: "blue", Tags: []string{"shopping"}},
+		{Title: "削除済みの milk", Tags: []string{"shop"}},
+	}
+	for _, todo := range inputs {
+		if _, err := repo.Create(ctx, todo); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	inputs[1].IsCompleted = true
+	if _, err := repo.Update(ctx, inputs[1]); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repo.Delete(ctx, inputs[3].ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	// 他のユーザーのTodoは条件に一致しても含まない
+	if _, err := repo.Create(repository.WithOwner(context.Background(), 8), &entity.Todo{Title: "他のユーザーの milk", Tags: []string{"shop"}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	pending := false
+	tests := []struct {
+		name   string
+		filter repository.TodoFilter
+		order  entity.TodoSortOrder
+		want   []string
+	}{
+		{name: "タグ（前方一致のタグは含まない）", filter: repository.TodoFilter{Tag: "shop"}, want: []string{"buy milk"}},
+		{name: "文字列検索（説明も対象）", filter: repository.TodoFilter{Search: "Milk"}, order: entity.TodoSortTitle, want: []string{"buy milk", "Write report"}},
+		{name: "期限の範囲", filter: repository.TodoFilter{DueFrom: date("2024-01-01"), DueTo: date("2024-02-01")}, want: []string{"buy milk"}},
+		{name: "色と完了状態を期限の順に", filter: repository.TodoFilter{Color: "blue", Completed: &pending}, order: entity.TodoSortDueDate, want: []string{"buy milk", "Shopping list"}},
+		{name: "全ての条件の組み合わせ", filter: repository.TodoFilter{Color: "blue", Completed: &pending, Tag: "home", Search: "MILK", DueFrom: date("2024-01-10")}, want: []string{"buy milk"}},
+		{name: "タイトルの順（大文字・小文字を区別しない）", order: entity.TodoSortTitle, want: []string{"buy milk", "Shopping list", "Write report"}},
+		{name: "該当なし", filter: repository.TodoFilter{Tag: "none"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todos, err := repo.Find(ctx, tt.filter, tt.order)
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+			titles := make([]string, 0, len(todos))
+			for _, todo := range todos {
+				titles = append(titles, todo.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("Find() = %v, 期待値 = %v", titles, tt.want)
+			}
+			// 件数も同じ条件で数える
+			if count, err := repo.Count(ctx, tt.filter); err != nil || count != len(tt.want) {
+				t.Errorf("Count() = %d, %v, 期待値 = %d", count, err, len(tt.want))
+			}
+		})
+	}
+
+	if _, err := repo.Find(ctx, repository.TodoFilter{}, "priority"); !domainerr.IsInvalid(err) {
+		t.Errorf("未知の並び順の Find() error = %v, 期待値 = invalid", err)
+	}
+}
+
+// TestTodoRepository_Aggregates は件数・グループごとの件数・集計が、一覧を読み込んで数えた結果と一致することをテストします
+func TestTodoRepository_Aggregates(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := repository.WithOwner(context.Background(), 7)
+
+	projectID := 3
+	inputs := []*entity.Todo{
+		{Title: "見積もりあり", Color: "blue", EstimateMinutes: 30},
+		{Title: "見積もりどおり", Color: "blue", IsCompleted: true, EstimateMinutes: 60, ActualMinutes: 60, ProjectID: &projectID},
+		{Title: "超過", Color: "red", IsCompleted: true, EstimateMinutes: 30, ActualMinutes: 45, ProjectID: &projectID},
+		{Title: "実績なし", IsCompleted: true, EstimateMinutes: 20},
+		{Title: "削除済み", Color: "red"},
+	}
+	for _, todo := range inputs {
+		completed := todo.IsCompleted
+		created, err := repo.Create(ctx, todo)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if completed {
+			created.IsCompleted = true
+			if _, err := repo.Update(ctx, created); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+		}
+	}
+	if err := repo.Delete(ctx, inputs[4].ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	// 他のユーザーのTodoは数えない
+	if _, err := repo.Create(repository.WithOwner(context.Background(), 8), &entity.Todo{Title: "他のユーザー", Color: "blue"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	completed := true
+	counts := []struct {
+		name   string
+		filter repository.TodoFilter
+		want   int
+	}{
+		{name: "全件", want: 4},
+		{name: "色", filter: repository.TodoFilter{Color: "blue"}, want: 2},
+		{name: "完了状態", filter: repository.TodoFilter{Completed: &completed}, want: 3},
+		{name: "プロジェクトと色", filter: repository.TodoFilter{ProjectID: &projectID, Color: "red"}, want: 1},
+	}
+	for _, tt := range counts {
+		if got, err := repo.Count(ctx, tt.filter); err != nil || got != tt.want {
+			t.Errorf("%s: Count() = %d, %v, 期待値 = %d", tt.name, got, err, tt.want)
+		}
+	}
+
+	groups := []struct {
+		group repository.TodoGroup
+		want  []repository.TodoGroupCount
+	}{
+		{group: repository.TodoGroupColor, want: []repository.TodoGroupCount{{Key: "", Count: 1}, {Key: "blue", Count: 2}, {Key: "red", Count: 1}}},
+		{group: repository.TodoGroupProject, want: []repository.TodoGroupCount{{Key: "", Count: 2}, {Key: "3", Count: 2}}},
+		{group: repository.TodoGroupCompleted, want: []repository.TodoGroupCount{{Key: "false", Count: 1}, {Key: "true", Count: 3}}},
+	}
+	for _, tt := range groups {
+		got, err := repo.CountGrouped(ctx, tt.group, repository.TodoFilter{})
+		if err != nil {
+			t.Fatalf("CountGrouped(%s) error = %v", tt.group, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CountGrouped(%s) = %+v, 期待値 = %+v", tt.group, got, tt.want)
+		}
+	}
+	if _, err := repo.CountGrouped(ctx, "title", repository.TodoFilter{}); !domainerr.IsInvalid(err) {
+		t.Errorf("未知の集計の単位の error = %v, 期待値 = invalid", err)
+	}
+
+	stats, err := repo.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	all, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if want := entity.NewTodoStats(all); stats != want {
+		t.Errorf("GetStats() = %+v, 期待値 = %+v", stats, want)
+	}
+}
+
+// TestTodoRepository_ExistsByTitle はタイトルの重複確認をテストします
+func TestTodoRepository_ExistsByTitle(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	review, _ := repo.Create(ctx, &entity.Todo{Title: "Code Review"})
+
+	tests := []struct {
+		name      string
+		title     string
+		excludeID int
+		expected  bool
+	}{
+		{name: "同じタイトル", title: "Code Review", expected: true},
+		{name: "大文字・小文字だけが異なる", title: "code review", expected: true},
+		{name: "異なるタイトル", title: "Code", expected: false},
+		{name: "自分自身は除外", title: "Code Review", excludeID: review.ID, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := repo.ExistsByTitle(ctx, tt.title, tt.excludeID)
+			if err != nil {
+				t.Fatalf("予期しないエラーが発生しました: %v", err)
+			}
+			if exists != tt.expected {
+				t.Errorf("ExistsByTitle(%q, %d) = %v, 期待値 = %v", tt.title, tt.excludeID, exists, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTodoRepository_EstimateMinutes は見積もり・実績時間の保存と更新をテストします
+func TestTodoRepository_EstimateMinutes(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "見積もり", EstimateMinutes: 45})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+	if created.EstimateMinutes != 45 || created.ActualMinutes != 0 {
+		t.Errorf("作成後の見積もり・実績 = %d/%d, 期待値 = 45/0", created.EstimateMinutes, created.ActualMinutes)
+	}
+
+	created.ActualMinutes = 60
+	if _, err := repo.Update(ctx, created); err != nil {
+		t.Fatalf("更新に失敗: %v", err)
+	}
+	found, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if found.EstimateMinutes != 45 || found.ActualMinutes != 60 {
+		t.Errorf("更新後の見積もり・実績 = %d/%d, 期待値 = 45/60", found.EstimateMinutes, found.ActualMinutes)
+	}
+}
+
+// TestTodoRepository_Tags はタグの保存と、一括更新・部分更新での変更をテストします
+func TestTodoRepository_Tags(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "タグ付き", Tags: []string{"work", "週末"}})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+	untagged, err := repo.Create(ctx, &entity.Todo{Title: "タグなし"})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+
+	found, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if !reflect.DeepEqual(found.Tags, []string{"work", "週末"}) {
+		t.Errorf("作成後のタグ = %v, 期待値 = [work 週末]", found.Tags)
+	}
+
+	found.Tags = []string{"週末"}
+	untagged.Tags = []string{"home"}
+	if _, err := repo.UpdateMany(ctx, []*entity.Todo{found, untagged}); err != nil {
+		t.Fatalf("一括更新に失敗: %v", err)
+	}
+	found.Tags = nil
+	updated, err := repo.UpdateFields(ctx, found, []entity.TodoField{entity.TodoFieldTags})
+	if err != nil {
+		t.Fatalf("部分更新に失敗: %v", err)
+	}
+	if updated.Tags != nil {
+		t.Errorf("部分更新後のタグ = %v, 期待値 = なし", updated.Tags)
+	}
+	if got, _ := repo.GetByID(ctx, untagged.ID); !reflect.DeepEqual(got.Tags, []string{"home"}) {
+		t.Errorf("一括更新後のタグ = %v, 期待値 = [home]", got.Tags)
+	}
+}
+
 // TestTodoRepository_Update はTodo更新機能をテストします
 func TestTodoRepository_Update(t *testing.T) {
 	db := setupTestDB(t)
@@ -467,6 +941,355 @@ func TestTodoRepository_Delete(t *testing.T) {
 	}
 }
 
+// TestTodoRepository_Restore は削除したTodoを同じIDと作成日時のまま復元できることをテストします
+func TestTodoRepository_Restore(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "復元テスト用", Color: entity.Color("#1e90ff"), EstimateMinutes: 15})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	deleted, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("テストデータの取得に失敗: %v", err)
+	}
+	deleted.MarkAsCompleted()
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("テストデータの削除に失敗: %v", err)
+	}
+
+	if err := repo.Restore(ctx, deleted); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	restored, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("復元したTodoの取得に失敗: %v", err)
+	}
+	if restored.Title != "復元テスト用" || !restored.IsCompleted || restored.Color != deleted.Color ||
+		restored.EstimateMinutes != 15 || !restored.CreatedAt.Equal(deleted.CreatedAt) {
+		t.Errorf("復元したTodo = %+v, 期待値 = %+v", restored, deleted)
+	}
+
+	// 同じIDのTodoが既にある場合はエラー
+	if err := repo.Restore(ctx, deleted); err == nil {
+		t.Error("既に存在するIDの復元でエラーが返されませんでした")
+	}
+}
+
+// TestTodoRepository_SoftDelete は削除したTodoが既定の取得から除外され、ごみ箱から復元・完全削除できることをテストします
+func TestTodoRepository_SoftDelete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	checklistRepo := NewChecklistRepository(db)
+	ctx := context.Background()
+
+	kept, _ := repo.Create(ctx, &entity.Todo{Title: "残すTodo"})
+	deleted, err := repo.Create(ctx, &entity.Todo{Title: "削除するTodo", Color: entity.Color("#1e90ff")})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	if _, err := checklistRepo.Create(ctx, &entity.ChecklistItem{TodoID: deleted.ID, Text: "項目"}); err != nil {
+		t.Fatalf("チェックリスト項目の作成に失敗: %v", err)
+	}
+
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete(ctx, deleted.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("削除済みのTodoの Delete() error = %v, 期待値 = not found", err)
+	}
+
+	// 既定の取得・一覧・更新からは除外される
+	if _, err := repo.GetByID(ctx, deleted.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("削除済みのTodoの GetByID() error = %v, 期待値 = not found", err)
+	}
+	if todos, _ := repo.GetAll(ctx); len(todos) != 1 || todos[0].ID != kept.ID {
+		t.Errorf("GetAll() = %+v, 期待値 = 削除していないTodoのみ", todos)
+	}
+	if _, err := repo.Update(ctx, deleted); !domainerr.IsNotFound(err) {
+		t.Errorf("削除済みのTodoの Update() error = %v, 期待値 = not found", err)
+	}
+	if exists, _ := repo.ExistsByTitle(ctx, "削除するTodo", 0); exists {
+		t.Error("削除済みのTodoのタイトルが重複として扱われました")
+	}
+
+	// 削除済みの一覧には削除日時付きで含まれる
+	trash, err := repo.GetDeleted(ctx)
+	if err != nil {
+		t.Fatalf("GetDeleted() error = %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID != deleted.ID || trash[0].DeletedAt == nil {
+		t.Fatalf("GetDeleted() = %+v, 期待値 = 削除日時付きの1件", trash)
+	}
+
+	// 復元すると同じIDのまま元に戻り、チェックリスト項目も残っている
+	if err := repo.Restore(ctx, trash[0]); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	restored, err := repo.GetByID(ctx, deleted.ID)
+	if err != nil {
+		t.Fatalf("復元したTodoの取得に失敗: %v", err)
+	}
+	if restored.DeletedAt != nil || restored.Color != deleted.Color || restored.ChecklistProgress.Total != 1 {
+		t.Errorf("復元したTodo = %+v, 期待値 = 削除前の内容とチェックリスト1件", restored)
+	}
+
+	// 完全に削除すると、削除済みの一覧にも残らない（ソフト削除済みのTodoも対象）
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.HardDelete(ctx, deleted.ID); err != nil {
+		t.Fatalf("HardDelete() error = %v", err)
+	}
+	if trash, _ := repo.GetDeleted(ctx); len(trash) != 0 {
+		t.Errorf("完全に削除した後の GetDeleted() = %+v, 期待値 = 空", trash)
+	}
+	if items, _ := checklistRepo.ListByTodoID(ctx, deleted.ID); len(items) != 0 {
+		t.Errorf("完全に削除したTodoのチェックリスト = %+v, 期待値 = 空", items)
+	}
+	if err := repo.HardDelete(ctx, deleted.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("存在しないTodoの HardDelete() error = %v, 期待値 = not found", err)
+	}
+}
+
+// TestTodoRepository_UpdateFields は指定したフィールドの列だけが書き込まれることをテストします
+func TestTodoRepository_UpdateFields(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "部分更新", Description: "元の説明"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	// 読み込んだ後に別のリクエストが説明を変更した状態を作る
+	stale := *created
+	if _, err := db.Exec(`UPDATE todos SET description = ? WHERE id = ?`, "別のリクエストの変更", created.ID); err != nil {
+		t.Fatalf("テストデータの変更に失敗: %v", err)
+	}
+
+	due := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	stale.Title = "変更後"
+	stale.DueDate = &due
+	stale.Color = entity.Color("#1e90ff")
+	updated, err := repo.UpdateFields(ctx, &stale, []entity.TodoField{entity.TodoFieldTitle, entity.TodoFieldDueDate, entity.TodoFieldColor})
+	if err != nil {
+		t.Fatalf("UpdateFields() error = %v", err)
+	}
+	if updated.Title != "変更後" || updated.DueDate == nil || !updated.DueDate.Equal(due) || updated.Color != "#1e90ff" {
+		t.Errorf("指定したフィールドが更新されていません: %+v", updated)
+	}
+	if updated.Description != "別のリクエストの変更" {
+		t.Errorf("説明 = %q, 期待値 = 指定していない列は書き込まない", updated.Description)
+	}
+
+	if _, err := repo.UpdateFields(ctx, &entity.Todo{ID: 99999, Title: "x"}, []entity.TodoField{entity.TodoFieldTitle}); err == nil {
+		t.Error("存在しないTodoの更新でエラーが返されませんでした")
+	}
+	if _, err := repo.UpdateFields(ctx, &stale, []entity.TodoField{"id = 1; --"}); err == nil {
+		t.Error("追跡しないフィールドの更新でエラーが返されませんでした")
+	}
+}
+
+// TestTodoRepository_UpdateMany は一括更新が1つのトランザクションで行われることをテストします
+func TestTodoRepository_UpdateMany(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db)
+	ctx := context.Background()
+
+	first, err := repo.Create(ctx, &entity.Todo{Title: "一括更新1"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	second, err := repo.Create(ctx, &entity.Todo{Title: "一括更新2"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	// 存在しないIDを含む場合は全てロールバックされる
+	first.Title = "変更後1"
+	if _, err := repo.UpdateMany(ctx, []*entity.Todo{first, {ID: 99999, Title: "存在しない"}}); err == nil {
+		t.Fatal("存在しないTodoを含む一括更新でエラーが返されませんでした")
+	}
+	if got, _ := repo.GetByID(ctx, first.ID); got.Title != "一括更新1" {
+		t.Errorf("ロールバックされていません: %+v", got)
+	}
+
+	second.MarkAsCompleted()
+	updated, err := repo.UpdateMany(ctx, []*entity.Todo{first, second})
+	if err != nil {
+		t.Fatalf("UpdateMany() error = %v", err)
+	}
+	if len(updated) != 2 || updated[0].Title != "変更後1" || !updated[1].IsCompleted {
+		t.Errorf("一括更新の結果 = %+v", updated)
+	}
+}
+
+// TestTodoRepository_UpdateWithLock は行をロックした更新をテストします
+func TestTodoRepository_UpdateWithLock(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db, WithSQLiteLocking())
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.Todo{Title: "ロック対象"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	// mutate の変更はロック中に保存される
+	updated, err := repo.UpdateWithLock(ctx, created.ID, func(todo *entity.Todo) error {
+		todo.MarkAsCompleted()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateWithLock() error = %v", err)
+	}
+	if !updated.IsCompleted {
+		t.Errorf("更新後のTodo = %+v, 完了状態になっていません", updated)
+	}
+
+	// ErrNoChange の場合は書き込まない
+	got, err := repo.UpdateWithLock(ctx, created.ID, func(todo *entity.Todo) error {
+		todo.Title = "保存されない"
+		return repository.ErrNoChange
+	})
+	if err != nil || got.Title != "ロック対象" {
+		t.Errorf("UpdateWithLock(ErrNoChange) = %+v, %v", got, err)
+	}
+
+	// mutate のエラーはロールバックされる
+	mutateErr := errors.New("mutate failed")
+	if _, err := repo.UpdateWithLock(ctx, created.ID, func(todo *entity.Todo) error {
+		todo.MarkAsIncomplete()
+		return mutateErr
+	}); !errors.Is(err, mutateErr) {
+		t.Errorf("error = %v, 期待値 = %v", err, mutateErr)
+	}
+	if current, _ := repo.GetByID(ctx, created.ID); !current.IsCompleted {
+		t.Error("失敗した変更が保存されています")
+	}
+
+	if _, err := repo.UpdateWithLock(ctx, 99999, func(*entity.Todo) error { return nil }); err == nil {
+		t.Error("存在しないTodoでエラーが返されませんでした")
+	}
+}
+
+// TestTodoRepository_Owner はコンテキストに所有者がある場合、そのユーザーのTodoだけが操作の対象になることをテストします
+func TestTodoRepository_Owner(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db, WithSQLiteLocking())
+	alice := repository.WithOwner(context.Background(), 1)
+	bob := repository.WithOwner(context.Background(), 2)
+
+	aliceTodo, err := repo.Create(alice, &entity.Todo{Title: "同じタイトル"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	if aliceTodo.UserID == nil || *aliceTodo.UserID != 1 {
+		t.Errorf("作成したTodoの所有者 = %v, 期待値 = 1", aliceTodo.UserID)
+	}
+	if _, err := repo.Create(bob, &entity.Todo{Title: "ボブのTodo"}); err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	// 一覧・件数は本人のTodoのみ
+	todos, err := repo.GetAll(alice)
+	if err != nil || len(todos) != 1 || todos[0].ID != aliceTodo.ID {
+		t.Errorf("GetAll() = %d件, err = %v, 期待値 = 本人の1件", len(todos), err)
+	}
+	if _, total, err := repo.(*todoRepositoryImpl).GetWithPagination(bob, 0, 10); err != nil || total != 1 {
+		t.Errorf("GetWithPagination() の総件数 = %d, err = %v, 期待値 = 1", total, err)
+	}
+	// 所有者のないコンテキスト（ワーカーなど）では全件
+	if todos, err := repo.GetAll(context.Background()); err != nil || len(todos) != 2 {
+		t.Errorf("所有者なしの GetAll() = %d件, err = %v, 期待値 = 2", len(todos), err)
+	}
+
+	// 他のユーザーのTodoは存在しない扱い
+	if _, err := repo.GetByID(bob, aliceTodo.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーの GetByID() error = %v, 期待値 = not found", err)
+	}
+	other := *aliceTodo
+	other.Title = "書き換え"
+	if _, err := repo.Update(bob, &other); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーの Update() error = %v, 期待値 = not found", err)
+	}
+	if _, err := repo.UpdateFields(bob, &other, []entity.TodoField{entity.TodoFieldTitle}); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーの UpdateFields() error = %v, 期待値 = not found", err)
+	}
+	if _, err := repo.UpdateWithLock(bob, aliceTodo.ID, func(todo *entity.Todo) error { return nil }); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーの UpdateWithLock() error = %v, 期待値 = not found", err)
+	}
+	if err := repo.Delete(bob, aliceTodo.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーの Delete() error = %v, 期待値 = not found", err)
+	}
+	if got, err := repo.GetByID(alice, aliceTodo.ID); err != nil || got.Title != "同じタイトル" {
+		t.Errorf("本人の GetByID() = %+v, err = %v, 期待値 = 変更されていない", got, err)
+	}
+
+	// タイトルの重複はユーザーごとに確認する
+	if exists, err := repo.ExistsByTitle(bob, "同じタイトル", 0); err != nil || exists {
+		t.Errorf("他のユーザーの ExistsByTitle() = %v, err = %v, 期待値 = false", exists, err)
+	}
+	if exists, err := repo.ExistsByTitle(alice, "同じタイトル", 0); err != nil || !exists {
+		t.Errorf("本人の ExistsByTitle() = %v, err = %v, 期待値 = true", exists, err)
+	}
+}
+
+// TestTodoRepository_Workspace はワークスペースのTodoがメンバー全員に共有され、個人のTodoと分かれることをテストします
+func TestTodoRepository_Workspace(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoRepository(db, WithSQLiteLocking())
+	alice := repository.WithOwner(context.Background(), 1)
+	bob := repository.WithOwner(context.Background(), 2)
+	aliceInTeam := repository.WithWorkspace(alice, 10)
+	bobInTeam := repository.WithWorkspace(bob, 10)
+
+	teamTodo, err := repo.Create(aliceInTeam, &entity.Todo{Title: "チームのTodo"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	if teamTodo.WorkspaceID == nil || *teamTodo.WorkspaceID != 10 || teamTodo.UserID == nil || *teamTodo.UserID != 1 {
+		t.Errorf("作成したTodoのワークスペース = %v, 所有者 = %v, 期待値 = 10, 1", teamTodo.WorkspaceID, teamTodo.UserID)
+	}
+	if _, err := repo.Create(alice, &entity.Todo{Title: "アリスの個人のTodo"}); err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+
+	// ワークスペースのTodoは作成したユーザー以外のメンバーも操作できる
+	if _, err := repo.GetByID(bobInTeam, teamTodo.ID); err != nil {
+		t.Errorf("メンバーの GetByID() error = %v", err)
+	}
+	if _, err := repo.UpdateWithLock(bobInTeam, teamTodo.ID, func(todo *entity.Todo) error { todo.MarkAsCompleted(); return nil }); err != nil {
+		t.Errorf("メンバーの UpdateWithLock() error = %v", err)
+	}
+	if todos, err := repo.GetAll(bobInTeam); err != nil || len(todos) != 1 || todos[0].ID != teamTodo.ID {
+		t.Errorf("ワークスペースの GetAll() = %d件, err = %v, 期待値 = チームの1件", len(todos), err)
+	}
+
+	// 個人のスコープにはワークスペースのTodoを含めない（作成したユーザー本人でも）
+	if todos, err := repo.GetAll(alice); err != nil || len(todos) != 1 || todos[0].Title != "アリスの個人のTodo" {
+		t.Errorf("個人の GetAll() = %d件, err = %v, 期待値 = 個人の1件", len(todos), err)
+	}
+	if _, err := repo.GetByID(alice, teamTodo.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("個人のスコープでの GetByID() error = %v, 期待値 = not found", err)
+	}
+	// 他のワークスペースからは存在しない扱い
+	if err := repo.Delete(repository.WithWorkspace(bob, 20), teamTodo.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("他のワークスペースでの Delete() error = %v, 期待値 = not found", err)
+	}
+}
+
 // TestTodoRepository_Transaction はトランザクションを使った処理をテストします
 func TestTodoRepository_Transaction(t *testing.T) {
 	db := setupTestDB(t)
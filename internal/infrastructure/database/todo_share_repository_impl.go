@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// todoShareSelect は共有と共有先のユーザー名を取得するSELECT文です（条件は呼び出し側で付けます）
+const todoShareSelect = `
+	SELECT s.todo_id, s.user_id, u.username, s.permission, s.created_at
+	FROM todo_shares s
+	JOIN users u ON u.id = s.user_id`
+
+// todoShareRepositoryImpl は todo_shares テーブルを使用した
+// TodoShareRepository インターフェースの実装です
+type todoShareRepositoryImpl struct {
+	db      *sql.DB
+	dialect sqlrepo.Dialect
+}
+
+// NewTodoShareRepository はtodoShareRepositoryImplのコンストラクタです
+// dialect には接続先のエンジンの方言（DatabaseManager.Dialect）を渡します
+func NewTodoShareRepository(db *sql.DB, dialect sqlrepo.Dialect) repository.TodoShareRepository {
+	return &todoShareRepositoryImpl{
+		db:      db,
+		dialect: dialect,
+	}
+}
+
+// Save は共有を作成し、既にある場合は権限を置き換えます（共有した日時は最初の共有のまま）
+// 方言のUPSERTで1つの文にするため、一意制約の違反でトランザクションが中断されるエンジンでも実行できます
+func (r *todoShareRepositoryImpl) Save(ctx context.Context, share *entity.TodoShare) (*entity.TodoShare, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	query := r.dialect.Upsert("todo_shares", []string{"todo_id", "user_id", "permission", "created_at"},
+		[]string{"todo_id", "user_id"}, []string{"permission"})
+
+	if _, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx, query, share.TodoID, share.UserID, string(share.Permission), now); err != nil {
+		return nil, fmt.Errorf("failed to save todo share: %w", err)
+	}
+
+	return r.Get(ctx, share.TodoID, share.UserID)
+}
+
+// Get はTodoとユーザーの組み合わせで共有を取得します
+func (r *todoShareRepositoryImpl) Get(ctx context.Context, todoID, userID int) (*entity.TodoShare, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, todoShareSelect+` WHERE s.todo_id = ? AND s.user_id = ?`, todoID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todo share: %w", err)
+	}
+
+	share, err := sqlrepo.ScanOne(rows, scanTodoShare)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("todo share", nil)
+		}
+		return nil, fmt.Errorf("failed to get todo share: %w", err)
+	}
+	return share, nil
+}
+
+// ListByTodoID はTodoの共有を共有先のユーザー名の順に取得します
+func (r *todoShareRepositoryImpl) ListByTodoID(ctx context.Context, todoID int) ([]*entity.TodoShare, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, todoShareSelect+` WHERE s.todo_id = ? ORDER BY u.username ASC`, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todo shares: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanTodoShare)
+}
+
+// Delete は共有を削除します
+func (r *todoShareRepositoryImpl) Delete(ctx context.Context, todoID, userID int) error {
+	return sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "delete todo share", domainerr.NotFound("todo share", nil),
+		`DELETE FROM todo_shares WHERE todo_id = ? AND user_id = ?`, todoID, userID)
+}
+
+// scanTodoShare は todoShareSelect で取得した1行を列名で対応付けてTodoShareエンティティにスキャンします
+func scanTodoShare(rows *sql.Rows) (*entity.TodoShare, error) {
+	var share entity.TodoShare
+	var permission string
+	if err := sqlrepo.ScanColumns(rows, "todo_shares", sqlrepo.Columns{
+		"todo_id":    &share.TodoID,
+		"user_id":    &share.UserID,
+		"username":   &share.Username,
+		"permission": &permission,
+		"created_at": &share.CreatedAt,
+	}, "todo_id", "user_id", "permission"); err != nil {
+		return nil, err
+	}
+	share.Permission = entity.SharePermission(permission)
+	share.CreatedAt = share.CreatedAt.UTC()
+	return &share, nil
+}
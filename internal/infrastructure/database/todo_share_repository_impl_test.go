@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// TestTodoShareRepository は共有の作成・権限の置き換え・一覧・削除と、Todoの削除で共有も消えることをテストします
+func TestTodoShareRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewTodoShareRepository(db, sqlrepo.SQLite)
+	users := NewUserRepository(db)
+	todos := NewTodoRepository(db)
+	ctx := context.Background()
+
+	todo, err := todos.Create(ctx, &entity.Todo{Title: "共有するTodo"})
+	if err != nil {
+		t.Fatalf("テストデータの作成に失敗: %v", err)
+	}
+	bob, _ := users.Create(ctx, &entity.User{Username: "bob", PasswordHash: "hash"})
+	carol, _ := users.Create(ctx, &entity.User{Username: "carol", PasswordHash: "hash"})
+
+	created, err := repo.Save(ctx, &entity.TodoShare{TodoID: todo.ID, UserID: carol.ID, Permission: entity.SharePermissionRead})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if created.Username != "carol" || created.Permission != entity.SharePermissionRead || created.CreatedAt.IsZero() {
+		t.Errorf("Save() = %+v", created)
+	}
+
+	// 同じ組み合わせで保存し直すと権限だけが置き換わる（同じ権限でも失敗しない）
+	for _, permission := range []entity.SharePermission{entity.SharePermissionWrite, entity.SharePermissionWrite} {
+		updated, err := repo.Save(ctx, &entity.TodoShare{TodoID: todo.ID, UserID: carol.ID, Permission: permission})
+		if err != nil || updated.Permission != permission || !updated.CreatedAt.Equal(created.CreatedAt) {
+			t.Errorf("Save() = %+v, err = %v, 期待値 = 権限 %s・作成日時は変わらない", updated, err, permission)
+		}
+	}
+	if _, err := repo.Save(ctx, &entity.TodoShare{TodoID: todo.ID, UserID: bob.ID, Permission: entity.SharePermissionRead}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	shares, err := repo.ListByTodoID(ctx, todo.ID)
+	if err != nil || len(shares) != 2 || shares[0].Username != "bob" || shares[1].Username != "carol" {
+		t.Errorf("ListByTodoID() = %+v, err = %v, 期待値 = ユーザー名の順に2件", shares, err)
+	}
+
+	if err := repo.Delete(ctx, todo.ID, bob.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, todo.ID, bob.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("削除後の Get() error = %v, 期待値 = not found", err)
+	}
+	if err := repo.Delete(ctx, todo.ID, bob.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("存在しない共有の Delete() error = %v, 期待値 = not found", err)
+	}
+
+	// Todoを完全に削除すると共有も削除される（ソフト削除では共有は残り、復元すると元に戻る）
+	if err := todos.HardDelete(ctx, todo.ID); err != nil {
+		t.Fatalf("Todoの削除に失敗: %v", err)
+	}
+	if shares, err := repo.ListByTodoID(ctx, todo.ID); err != nil || len(shares) != 0 {
+		t.Errorf("Todoの削除後の ListByTodoID() = %d件, err = %v, 期待値 = 0件", len(shares), err)
+	}
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// transactor は database/sql のトランザクションを使用した Transactor インターフェースの実装です
+// コンテキストに設定したトランザクションには、sqlrepo.Conn・sqlrepo.InTx を使うリポジトリが参加します
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor はtransactorのコンストラクタです
+// db には、トランザクションにまとめたいリポジトリと同じ接続プールを渡します
+func NewTransactor(db *sql.DB) repository.Transactor {
+	return &transactor{
+		db: db,
+	}
+}
+
+// InTransaction は fn を1つのトランザクションで実行します
+func (t *transactor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return sqlrepo.RunInTx(ctx, t.db, fn)
+}
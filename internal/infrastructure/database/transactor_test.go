@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// failingChecklistRepository は指定した回数だけ作成に成功し、その後の作成を失敗させるChecklistRepositoryです
+type failingChecklistRepository struct {
+	repository.ChecklistRepository
+	remaining int
+}
+
+func (r *failingChecklistRepository) Create(ctx context.Context, item *entity.ChecklistItem) (*entity.ChecklistItem, error) {
+	if r.remaining == 0 {
+		return nil, errors.New("disk full")
+	}
+	r.remaining--
+	return r.ChecklistRepository.Create(ctx, item)
+}
+
+// failingTodoRepository は指定した回数だけ更新に成功し、その後の更新を失敗させるTodoRepositoryです
+type failingTodoRepository struct {
+	repository.TodoRepository
+	remaining int
+}
+
+func (r *failingTodoRepository) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	if r.remaining == 0 {
+		return nil, errors.New("disk full")
+	}
+	r.remaining--
+	return r.TodoRepository.Update(ctx, todo)
+}
+
+// countTodos はtodosテーブルの行数を返します
+func countTodos(t *testing.T, ctx context.Context, repo repository.TodoRepository) int {
+	t.Helper()
+	todos, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	return len(todos)
+}
+
+// TestTransactor_TodoService はTodoServiceの複数の書き込みを伴う操作が、途中で失敗した場合に全てロールバックされることをテストします
+func TestTransactor_TodoService(t *testing.T) {
+	tests := []struct {
+		name string
+		// run は失敗する操作を実行します（todoRepo・checklistRepo は作成済みのTodo1件と項目2件を含む）
+		run func(ctx context.Context, todoRepo repository.TodoRepository, checklistRepo repository.ChecklistRepository, opts []service.TodoServiceOption) error
+	}{
+		{
+			name: "チェックリストのコピーに失敗した複製",
+			run: func(ctx context.Context, todoRepo repository.TodoRepository, checklistRepo repository.ChecklistRepository, opts []service.TodoServiceOption) error {
+				failing := &failingChecklistRepository{ChecklistRepository: checklistRepo, remaining: 1}
+				svc := service.NewTodoService(todoRepo, append(opts, service.WithTodoChecklist(failing))...)
+				_, err := svc.DuplicateTodo(ctx, 1, service.DuplicateTodoOptions{IncludeChecklist: true})
+				return err
+			},
+		},
+		{
+			name: "作成後の完了に失敗したインポート",
+			run: func(ctx context.Context, todoRepo repository.TodoRepository, checklistRepo repository.ChecklistRepository, opts []service.TodoServiceOption) error {
+				failing := &failingTodoRepository{TodoRepository: todoRepo, remaining: 0}
+				svc := service.NewTodoService(failing, opts...)
+				_, err := svc.ImportTodos(ctx, []*entity.Todo{
+					{Title: "牛乳を買う"},
+					{Title: "請求書を送る", IsCompleted: true},
+					{Title: "部屋を掃除する"},
+				})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			defer db.Close()
+			ctx := context.Background()
+
+			todoRepo := NewTodoRepository(db, WithSQLiteLocking())
+			checklistRepo := NewChecklistRepository(db)
+			source, err := todoRepo.Create(ctx, &entity.Todo{Title: "出張の準備"})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			for _, text := range []string{"切符", "宿"} {
+				if _, err := checklistRepo.Create(ctx, &entity.ChecklistItem{TodoID: source.ID, Text: text}); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			}
+
+			var published int
+			bus := event.NewBus()
+			for _, name := range []string{"todo.created", "todo.completed"} {
+				bus.Subscribe(name, func(ctx context.Context, e event.Event) { published++ })
+			}
+			opts := []service.TodoServiceOption{service.WithTodoEvents(bus), service.WithTodoTransactor(NewTransactor(db))}
+
+			if err := tt.run(ctx, todoRepo, checklistRepo, opts); err == nil {
+				t.Fatal("エラーが期待されましたが、発生しませんでした")
+			}
+
+			if got := countTodos(t, ctx, todoRepo); got != 1 {
+				t.Errorf("Todoの件数 = %d, 期待値 = 1（失敗した操作の書き込みはロールバックされるべきです）", got)
+			}
+			var items int
+			if err := db.QueryRow(`SELECT COUNT(*) FROM checklist_items`).Scan(&items); err != nil {
+				t.Fatalf("チェックリスト項目数の取得に失敗: %v", err)
+			}
+			if items != 2 {
+				t.Errorf("チェックリスト項目数 = %d, 期待値 = 2", items)
+			}
+			if published != 0 {
+				t.Errorf("ロールバックした変更のイベントが %d 件発行されました", published)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// userColumns は users テーブルのSELECT対象列です（scanUser が列名で対応付けます）
+const userColumns = `id, username, password_hash, created_at`
+
+// userRepositoryImpl は users テーブルを使用した
+// UserRepository インターフェースの実装です
+type userRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewUserRepository はuserRepositoryImplのコンストラクタです
+func NewUserRepository(db *sql.DB) repository.UserRepository {
+	return &userRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create はユーザーを作成します
+// username の一意制約により、同じユーザー名の同時の登録も1つだけが成功します
+func (r *userRepositoryImpl) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	result, err := r.db.ExecContext(ctx, `INSERT INTO users (username, password_hash, created_at) VALUES (?, ?, ?)`,
+		user.Username, user.PasswordHash, now)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrUsernameTaken
+		}
+		return nil, fmt.Errorf("failed to insert user: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	user.ID = int(id)
+	user.CreatedAt = now
+	return user, nil
+}
+
+// GetByID はIDでユーザーを取得します
+func (r *userRepositoryImpl) GetByID(ctx context.Context, id int) (*entity.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE id = ?`, id)
+}
+
+// GetByUsername はユーザー名でユーザーを取得します
+func (r *userRepositoryImpl) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE username = ?`, username)
+}
+
+// getOne は1件のユーザーを取得します
+func (r *userRepositoryImpl) getOne(ctx context.Context, query string, args ...any) (*entity.User, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user: %w", err)
+	}
+
+	user, err := sqlrepo.ScanOne(rows, scanUser)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.NotFound("user", nil)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// scanUser は1行を列名で対応付けてUserエンティティにスキャンします
+func scanUser(rows *sql.Rows) (*entity.User, error) {
+	var user entity.User
+	if err := sqlrepo.ScanColumns(rows, "users", sqlrepo.Columns{
+		"id":            &user.ID,
+		"username":      &user.Username,
+		"password_hash": &user.PasswordHash,
+		"created_at":    &user.CreatedAt,
+	}, "id", "username", "password_hash"); err != nil {
+		return nil, err
+	}
+	user.CreatedAt = user.CreatedAt.UTC()
+	return &user, nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestUserRepository はユーザーの作成・取得とユーザー名の重複をテストします
+func TestUserRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &entity.User{Username: "alice", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("作成に失敗: %v", err)
+	}
+	if created.ID == 0 || created.CreatedAt.IsZero() {
+		t.Errorf("作成したユーザー = %+v", created)
+	}
+	if _, err := repo.Create(ctx, &entity.User{Username: "alice", PasswordHash: "other"}); !errors.Is(err, repository.ErrUsernameTaken) {
+		t.Errorf("同じユーザー名の作成 error = %v, 期待値 = ErrUsernameTaken", err)
+	}
+
+	byName, err := repo.GetByUsername(ctx, "alice")
+	if err != nil || byName.ID != created.ID || byName.PasswordHash != "hash" {
+		t.Errorf("GetByUsername() = %+v, %v", byName, err)
+	}
+	byID, err := repo.GetByID(ctx, created.ID)
+	if err != nil || byID.Username != "alice" {
+		t.Errorf("GetByID() = %+v, %v", byID, err)
+	}
+
+	if _, err := repo.GetByUsername(ctx, "bob"); !domainerr.IsNotFound(err) {
+		t.Errorf("存在しないユーザー error = %v, 期待値 = not found", err)
+	}
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// webhookColumns は webhooks テーブルのSELECT対象列です（scanWebhook が列名で対応付けます）
+const webhookColumns = `id, url, events, created_at, user_id, workspace_id`
+
+// webhookRepositoryImpl は webhooks テーブルを使用した
+// WebhookRepository インターフェースの実装です
+//
+// Todoと同じく、コンテキストの所有者・ワークスペースで登録を絞り込みます（scopeCondition）
+type webhookRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository はwebhookRepositoryImplのコンストラクタです
+func NewWebhookRepository(db *sql.DB) repository.WebhookRepository {
+	return &webhookRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create はWebhookを登録します
+// 通知するイベントはカンマ区切りの文字列として1列に保存します
+// 所有者・ワークスペースはコンテキストから設定します（設定されていない場合は NULL）
+func (r *webhookRepositoryImpl) Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	subscription.UserID, subscription.WorkspaceID = nil, nil
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		subscription.UserID = &userID
+	}
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		subscription.WorkspaceID = &workspaceID
+	}
+	query := `INSERT INTO webhooks (url, events, created_at, user_id, workspace_id) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, subscription.URL, joinWebhookEvents(subscription.Events), now,
+		nullableInt(subscription.UserID), nullableInt(subscription.WorkspaceID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert webhook: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+
+	subscription.ID = int(id)
+	subscription.CreatedAt = now
+	return subscription, nil
+}
+
+// GetByID はIDでWebhookの登録を取得します
+func (r *webhookRepositoryImpl) GetByID(ctx context.Context, id int) (*entity.WebhookSubscription, error) {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	rows, err := r.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = ? AND `+owner,
+		append([]any{id}, ownerArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook: %w", err)
+	}
+
+	subscription, err := sqlrepo.ScanOne(rows, scanWebhook)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.NotFound("webhook", nil)
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return subscription, nil
+}
+
+// List はWebhookの登録をID順に取得します
+func (r *webhookRepositoryImpl) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	rows, err := r.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE `+owner+` ORDER BY id ASC`, ownerArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanWebhook)
+}
+
+// Delete はWebhookの登録を削除します
+func (r *webhookRepositoryImpl) Delete(ctx context.Context, id int) error {
+	owner, ownerArgs := scopeCondition(ctx, "")
+	return sqlrepo.ExecAffecting(ctx, r.db, "delete webhook", domainerr.NotFound("webhook", nil),
+		`DELETE FROM webhooks WHERE id = ? AND `+owner, append([]any{id}, ownerArgs...)...)
+}
+
+// scanWebhook は1行を列名で対応付けて WebhookSubscription に変換します
+func scanWebhook(rows *sql.Rows) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	var events string
+	var userID, workspaceID sql.NullInt64
+	if err := sqlrepo.ScanColumns(rows, "webhooks", sqlrepo.Columns{
+		"id":           &subscription.ID,
+		"url":          &subscription.URL,
+		"events":       &events,
+		"created_at":   &subscription.CreatedAt,
+		"user_id":      &userID,
+		"workspace_id": &workspaceID,
+	}, "id", "url", "events"); err != nil {
+		return nil, err
+	}
+
+	subscription.Events = splitWebhookEvents(events)
+	if userID.Valid {
+		id := int(userID.Int64)
+		subscription.UserID = &id
+	}
+	if workspaceID.Valid {
+		id := int(workspaceID.Int64)
+		subscription.WorkspaceID = &id
+	}
+	return &subscription, nil
+}
+
+// joinWebhookEvents はイベントの一覧を保存用のカンマ区切りの文字列にします
+func joinWebhookEvents(events []entity.WebhookEvent) string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ",")
+}
+
+// splitWebhookEvents は保存されたカンマ区切りの文字列をイベントの一覧に戻します
+func splitWebhookEvents(s string) []entity.WebhookEvent {
+	var events []entity.WebhookEvent
+	for _, name := range strings.Split(s, ",") {
+		if name != "" {
+			events = append(events, entity.WebhookEvent(name))
+		}
+	}
+	return events
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestWebhookRepository はWebhookの登録の保存・取得・削除をテストします
+func TestWebhookRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewWebhookRepository(db)
+	ctx := context.Background()
+
+	events := []entity.WebhookEvent{entity.WebhookEventTodoCreated, entity.WebhookEventTodoCompleted}
+	created, err := repo.Create(ctx, &entity.WebhookSubscription{URL: "https://example.com/hooks", Events: events})
+	if err != nil {
+		t.Fatalf("登録に失敗: %v", err)
+	}
+	if _, err := repo.Create(ctx, &entity.WebhookSubscription{URL: "https://example.com/all", Events: entity.WebhookEvents}); err != nil {
+		t.Fatalf("登録に失敗: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("取得に失敗: %v", err)
+	}
+	if got.URL != "https://example.com/hooks" || !slices.Equal(got.Events, events) || got.CreatedAt.IsZero() {
+		t.Errorf("取得した登録 = %+v", got)
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("一覧の取得に失敗: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != created.ID || len(list[1].Events) != len(entity.WebhookEvents) {
+		t.Errorf("一覧 = %+v", list)
+	}
+
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("削除に失敗: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, created.ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("削除後の取得で not found エラーになるべきです: %v", err)
+	}
+	if err := repo.Delete(ctx, created.ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("存在しない登録の削除で not found エラーになるべきです: %v", err)
+	}
+}
+
+// TestWebhookRepository_Scope は登録がコンテキストの所有者・ワークスペースに限定されることをテストします
+func TestWebhookRepository_Scope(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewWebhookRepository(db)
+	alice := repository.WithOwner(context.Background(), 1)
+	bob := repository.WithOwner(context.Background(), 2)
+	workspace := repository.WithWorkspace(bob, 10)
+
+	created, err := repo.Create(alice, &entity.WebhookSubscription{URL: "https://alice.example.com/", Events: entity.WebhookEvents})
+	if err != nil {
+		t.Fatalf("登録に失敗: %v", err)
+	}
+	if created.UserID == nil || *created.UserID != 1 || created.WorkspaceID != nil {
+		t.Errorf("所有者が設定されていません: %+v", created)
+	}
+	if _, err := repo.Create(workspace, &entity.WebhookSubscription{URL: "https://workspace.example.com/", Events: entity.WebhookEvents}); err != nil {
+		t.Fatalf("登録に失敗: %v", err)
+	}
+
+	if list, err := repo.List(bob); err != nil || len(list) != 0 {
+		t.Errorf("他のユーザーの登録が一覧に含まれています: %+v, err = %v", list, err)
+	}
+	if list, err := repo.List(workspace); err != nil || len(list) != 1 || *list[0].WorkspaceID != 10 {
+		t.Errorf("ワークスペースの一覧 = %+v, err = %v", list, err)
+	}
+	if _, err := repo.GetByID(bob, created.ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("他のユーザーの登録は取得できないべきです: %v", err)
+	}
+	if err := repo.Delete(bob, created.ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("他のユーザーの登録は削除できないべきです: %v", err)
+	}
+	if list, err := repo.List(context.Background()); err != nil || len(list) != 2 {
+		t.Errorf("所有者を指定しない場合は全ての登録を返すべきです: %+v, err = %v", list, err)
+	}
+}
@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+)
+
+// workspaceMemberSelect はメンバーシップとメンバーのユーザー名を取得するSELECT文です（条件は呼び出し側で付けます）
+const workspaceMemberSelect = `
+	SELECT m.workspace_id, m.user_id, u.username, m.role, m.joined_at
+	FROM workspace_members m
+	JOIN users u ON u.id = m.user_id`
+
+// workspaceRepositoryImpl は workspaces・workspace_members・workspace_invitations テーブルを使用した
+// WorkspaceRepository インターフェースの実装です
+type workspaceRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewWorkspaceRepository はworkspaceRepositoryImplのコンストラクタです
+func NewWorkspaceRepository(db *sql.DB) repository.WorkspaceRepository {
+	return &workspaceRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create はワークスペースと所有者のメンバーシップを1つのトランザクションで作成します
+func (r *workspaceRepositoryImpl) Create(ctx context.Context, workspace *entity.Workspace, ownerID int) (*entity.Workspace, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	err := sqlrepo.InTx(ctx, r.db, "workspace creation", func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `INSERT INTO workspaces (name, created_at) VALUES (?, ?)`, workspace.Name, now)
+		if err != nil {
+			return fmt.Errorf("failed to insert workspace: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get inserted ID: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO workspace_members (workspace_id, user_id, role, joined_at) VALUES (?, ?, ?, ?)`,
+			id, ownerID, string(entity.WorkspaceRoleOwner), now); err != nil {
+			return fmt.Errorf("failed to insert workspace owner: %w", err)
+		}
+		workspace.ID = int(id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.CreatedAt = now
+	return workspace, nil
+}
+
+// GetByID はIDでワークスペースを取得します
+func (r *workspaceRepositoryImpl) GetByID(ctx context.Context, id int) (*entity.Workspace, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, `SELECT id, name, created_at FROM workspaces WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workspace: %w", err)
+	}
+
+	workspace, err := sqlrepo.ScanOne(rows, scanWorkspace)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("workspace", id)
+		}
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return workspace, nil
+}
+
+// ListByUser はユーザーが参加しているワークスペースを作成順に取得します
+func (r *workspaceRepositoryImpl) ListByUser(ctx context.Context, userID int) ([]*entity.Workspace, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, `
+		SELECT w.id, w.name, w.created_at
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = ?
+		ORDER BY w.id ASC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workspaces: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanWorkspace)
+}
+
+// AddMember はユーザーをワークスペースに参加させます
+// 既に参加している場合は主キーの一意制約に違反するため、そのエラーを無視します
+func (r *workspaceRepositoryImpl) AddMember(ctx context.Context, member *entity.WorkspaceMember) error {
+	_, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx, `INSERT INTO workspace_members (workspace_id, user_id, role, joined_at) VALUES (?, ?, ?, ?)`,
+		member.WorkspaceID, member.UserID, string(member.Role), time.Now().UTC().Truncate(time.Second))
+	if err != nil && !isUniqueViolation(err) {
+		return fmt.Errorf("failed to insert workspace member: %w", err)
+	}
+	return nil
+}
+
+// GetMember はワークスペースとユーザーの組み合わせでメンバーシップを取得します
+func (r *workspaceRepositoryImpl) GetMember(ctx context.Context, workspaceID, userID int) (*entity.WorkspaceMember, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, workspaceMemberSelect+` WHERE m.workspace_id = ? AND m.user_id = ?`, workspaceID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workspace member: %w", err)
+	}
+
+	member, err := sqlrepo.ScanOne(rows, scanWorkspaceMember)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("workspace member", nil)
+		}
+		return nil, fmt.Errorf("failed to get workspace member: %w", err)
+	}
+	return member, nil
+}
+
+// ListMembers はワークスペースのメンバーをユーザー名の順に取得します
+func (r *workspaceRepositoryImpl) ListMembers(ctx context.Context, workspaceID int) ([]*entity.WorkspaceMember, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx, workspaceMemberSelect+` WHERE m.workspace_id = ? ORDER BY u.username ASC`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workspace members: %w", err)
+	}
+	return sqlrepo.ScanAll(rows, scanWorkspaceMember)
+}
+
+// RemoveMember はユーザーをワークスペースから外します
+func (r *workspaceRepositoryImpl) RemoveMember(ctx context.Context, workspaceID, userID int) error {
+	return sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "delete workspace member", domainerr.NotFound("workspace member", nil),
+		`DELETE FROM workspace_members WHERE workspace_id = ? AND user_id = ?`, workspaceID, userID)
+}
+
+// CreateInvitation は招待を保存します
+func (r *workspaceRepositoryImpl) CreateInvitation(ctx context.Context, invitation *entity.WorkspaceInvitation) (*entity.WorkspaceInvitation, error) {
+	result, err := sqlrepo.Conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO workspace_invitations (workspace_id, token_hash, invited_by, created_at, expires_at) VALUES (?, ?, ?, ?, ?)`,
+		invitation.WorkspaceID, invitation.TokenHash, invitation.InvitedBy,
+		invitation.CreatedAt.UTC().Truncate(time.Second), invitation.ExpiresAt.UTC().Truncate(time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert workspace invitation: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inserted ID: %w", err)
+	}
+	invitation.ID = int(id)
+	return invitation, nil
+}
+
+// GetInvitationByHash は招待トークンのハッシュで招待を取得します
+func (r *workspaceRepositoryImpl) GetInvitationByHash(ctx context.Context, tokenHash string) (*entity.WorkspaceInvitation, error) {
+	rows, err := sqlrepo.Conn(ctx, r.db).QueryContext(ctx,
+		`SELECT id, workspace_id, token_hash, invited_by, created_at, expires_at FROM workspace_invitations WHERE token_hash = ?`, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workspace invitation: %w", err)
+	}
+
+	invitation, err := sqlrepo.ScanOne(rows, scanWorkspaceInvitation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerr.NotFound("workspace invitation", nil)
+		}
+		return nil, fmt.Errorf("failed to get workspace invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// DeleteInvitation は招待を削除します
+// 影響行数で判定するため、同じ招待で同時に参加しても1つだけが成功します
+func (r *workspaceRepositoryImpl) DeleteInvitation(ctx context.Context, id int) error {
+	return sqlrepo.ExecAffecting(ctx, sqlrepo.Conn(ctx, r.db), "delete workspace invitation", domainerr.NotFound("workspace invitation", nil),
+		`DELETE FROM workspace_invitations WHERE id = ?`, id)
+}
+
+// scanWorkspace は1行を列名で対応付けて Workspace にスキャンします
+func scanWorkspace(rows *sql.Rows) (*entity.Workspace, error) {
+	var workspace entity.Workspace
+	if err := sqlrepo.ScanColumns(rows, "workspaces", sqlrepo.Columns{
+		"id":         &workspace.ID,
+		"name":       &workspace.Name,
+		"created_at": &workspace.CreatedAt,
+	}, "id", "name"); err != nil {
+		return nil, err
+	}
+	workspace.CreatedAt = workspace.CreatedAt.UTC()
+	return &workspace, nil
+}
+
+// scanWorkspaceMember は workspaceMemberSelect で取得した1行を WorkspaceMember にスキャンします
+func scanWorkspaceMember(rows *sql.Rows) (*entity.WorkspaceMember, error) {
+	var member entity.WorkspaceMember
+	var role string
+	if err := sqlrepo.ScanColumns(rows, "workspace_members", sqlrepo.Columns{
+		"workspace_id": &member.WorkspaceID,
+		"user_id":      &member.UserID,
+		"username":     &member.Username,
+		"role":         &role,
+		"joined_at":    &member.JoinedAt,
+	}, "workspace_id", "user_id", "role"); err != nil {
+		return nil, err
+	}
+	member.Role = entity.WorkspaceRole(role)
+	member.JoinedAt = member.JoinedAt.UTC()
+	return &member, nil
+}
+
+// scanWorkspaceInvitation は1行を列名で対応付けて WorkspaceInvitation にスキャンします
+func scanWorkspaceInvitation(rows *sql.Rows) (*entity.WorkspaceInvitation, error) {
+	var invitation entity.WorkspaceInvitation
+	if err := sqlrepo.ScanColumns(rows, "workspace_invitations", sqlrepo.Columns{
+		"id":           &invitation.ID,
+		"workspace_id": &invitation.WorkspaceID,
+		"token_hash":   &invitation.TokenHash,
+		"invited_by":   &invitation.InvitedBy,
+		"created_at":   &invitation.CreatedAt,
+		"expires_at":   &invitation.ExpiresAt,
+	}, "id", "workspace_id", "token_hash", "expires_at"); err != nil {
+		return nil, err
+	}
+	invitation.CreatedAt = invitation.CreatedAt.UTC()
+	invitation.ExpiresAt = invitation.ExpiresAt.UTC()
+	return &invitation, nil
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestWorkspaceRepository はワークスペースの作成・メンバーの追加と削除・招待の保存と使用をテストします
+func TestWorkspaceRepository(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	repo := NewWorkspaceRepository(db)
+	users := NewUserRepository(db)
+	ctx := context.Background()
+
+	alice, _ := users.Create(ctx, &entity.User{Username: "alice", PasswordHash: "hash"})
+	bob, _ := users.Create(ctx, &entity.User{Username: "bob", PasswordHash: "hash"})
+
+	workspace, err := repo.Create(ctx, &entity.Workspace{Name: "開発チーム"}, alice.ID)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if workspace.ID == 0 || workspace.CreatedAt.IsZero() {
+		t.Errorf("Create() = %+v, 期待値 = IDと作成日時が設定される", workspace)
+	}
+	if got, err := repo.GetByID(ctx, workspace.ID); err != nil || got.Name != "開発チーム" {
+		t.Errorf("GetByID() = %+v, err = %v", got, err)
+	}
+	if _, err := repo.GetByID(ctx, 999); !domainerr.IsNotFound(err) {
+		t.Errorf("存在しないワークスペースの GetByID() error = %v, 期待値 = not found", err)
+	}
+
+	// 作成したユーザーは所有者として参加している
+	owner, err := repo.GetMember(ctx, workspace.ID, alice.ID)
+	if err != nil || owner.Role != entity.WorkspaceRoleOwner || owner.Username != "alice" {
+		t.Errorf("GetMember() = %+v, err = %v, 期待値 = alice が所有者", owner, err)
+	}
+
+	// メンバーの追加は重複しても失敗しない
+	for i := 0; i < 2; i++ {
+		if err := repo.AddMember(ctx, &entity.WorkspaceMember{WorkspaceID: workspace.ID, UserID: bob.ID, Role: entity.WorkspaceRoleMember}); err != nil {
+			t.Fatalf("AddMember() error = %v", err)
+		}
+	}
+	members, err := repo.ListMembers(ctx, workspace.ID)
+	if err != nil || len(members) != 2 || members[0].Username != "alice" || members[1].Role != entity.WorkspaceRoleMember {
+		t.Errorf("ListMembers() = %+v, err = %v, 期待値 = ユーザー名の順に2件", members, err)
+	}
+	if workspaces, err := repo.ListByUser(ctx, bob.ID); err != nil || len(workspaces) != 1 || workspaces[0].ID != workspace.ID {
+		t.Errorf("ListByUser() = %+v, err = %v, 期待値 = 参加したワークスペース1件", workspaces, err)
+	}
+
+	if err := repo.RemoveMember(ctx, workspace.ID, bob.ID); err != nil {
+		t.Fatalf("RemoveMember() error = %v", err)
+	}
+	if err := repo.RemoveMember(ctx, workspace.ID, bob.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("参加していないユーザーの RemoveMember() error = %v, 期待値 = not found", err)
+	}
+	if _, err := repo.GetMember(ctx, workspace.ID, bob.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("削除後の GetMember() error = %v, 期待値 = not found", err)
+	}
+
+	// 招待は一度だけ削除（使用）できる
+	now := time.Now().UTC().Truncate(time.Second)
+	invitation, err := repo.CreateInvitation(ctx, &entity.WorkspaceInvitation{WorkspaceID: workspace.ID, TokenHash: "hash-1", InvitedBy: alice.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("CreateInvitation() error = %v", err)
+	}
+	got, err := repo.GetInvitationByHash(ctx, "hash-1")
+	if err != nil || got.ID != invitation.ID || !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("GetInvitationByHash() = %+v, err = %v", got, err)
+	}
+	if err := repo.DeleteInvitation(ctx, invitation.ID); err != nil {
+		t.Fatalf("DeleteInvitation() error = %v", err)
+	}
+	if err := repo.DeleteInvitation(ctx, invitation.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("使用済みの招待の DeleteInvitation() error = %v, 期待値 = not found", err)
+	}
+	if _, err := repo.GetInvitationByHash(ctx, "hash-1"); !domainerr.IsNotFound(err) {
+		t.Errorf("使用済みの招待の GetInvitationByHash() error = %v, 期待値 = not found", err)
+	}
+}
@@ -0,0 +1,238 @@
+// Package devserver はソースコードの変更を監視し、アプリケーションを再ビルド・再起動する開発用のスーパーバイザーです
+//
+// 学習ポイント：
+//  1. 外部のツール（Air 等）や fsnotify を使わず、更新日時とサイズを定期的に比較する方式（ポーリング）で変更を検出する
+//     OSごとのファイル監視APIの違いを気にせず、標準パッケージだけで動作する
+//  2. 保存の途中で再ビルドしないよう、変更が落ち着く（次の確認で変化がない）まで待ってからビルドする
+//  3. ビルドに失敗した場合は、動いているプロセスを止めずにエラーだけを表示する（修正するまで前のバージョンで確認できる）
+//  4. 再起動時はプロセスに SIGINT を送り、グレースフルシャットダウン（データの保存等）を待ってから次を起動する
+package devserver
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Config は開発サーバーの設定です
+type Config struct {
+	// Dirs は監視するディレクトリです（サブディレクトリも含む）
+	Dirs []string
+
+	// Extensions は監視するファイルの拡張子です（例: ".go"）。_test.go の変更では再起動しません
+	Extensions []string
+
+	// Interval は変更を確認する間隔です（デフォルト: 500ms）
+	Interval time.Duration
+
+	// Package はビルドするパッケージです（例: "./cmd/api"）
+	Package string
+
+	// Binary はビルドした実行ファイルの出力先です
+	Binary string
+
+	// Args は実行ファイルに渡す引数です
+	Args []string
+
+	// StopTimeout は SIGINT を送ってから強制終了するまでの待ち時間です（デフォルト: 10秒）
+	StopTimeout time.Duration
+}
+
+// Run はアプリケーションをビルドして起動し、ctx がキャンセルされるまで変更のたびに再起動します
+func Run(ctx context.Context, cfg Config) error {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 500 * time.Millisecond
+	}
+	if cfg.StopTimeout <= 0 {
+		cfg.StopTimeout = 10 * time.Second
+	}
+
+	files, err := scan(cfg.Dirs, cfg.Extensions)
+	if err != nil {
+		return err
+	}
+
+	var current *process
+	defer func() {
+		if current != nil {
+			current.stop(cfg.StopTimeout)
+		}
+	}()
+	restart := func() {
+		if err := build(ctx, cfg); err != nil {
+			log.Printf("dev: build failed, waiting for changes: %v", err)
+			return
+		}
+		if current != nil {
+			current.stop(cfg.StopTimeout)
+		}
+		current, err = start(cfg)
+		if err != nil {
+			log.Printf("dev: failed to start %s: %v", cfg.Binary, err)
+		}
+	}
+	restart()
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	var pending []string // 検出済みで、まだ落ち着いていない変更
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		next, err := scan(cfg.Dirs, cfg.Extensions)
+		if err != nil {
+			log.Printf("dev: failed to scan source files: %v", err)
+			continue
+		}
+		if changed := diff(files, next); len(changed) > 0 {
+			files, pending = next, append(pending, changed...)
+			continue
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		log.Printf("dev: %s changed, rebuilding...", summarize(pending))
+		pending = nil
+		restart()
+	}
+}
+
+// fileState はファイルの変更の検出に使う情報です
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// scan は監視対象のファイルの一覧と、それぞれの更新日時・サイズを返します
+// 隠しディレクトリ（.git 等）・vendor・testdata は対象外です
+func scan(dirs, extensions []string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if path != dir && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !watched(path, extensions) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				// 走査中に削除されたファイルは無視する（次の確認で削除として検出される）
+				return nil
+			}
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// watched は変更を監視するファイルかどうかを返します
+func watched(path string, extensions []string) bool {
+	if strings.HasSuffix(path, "_test.go") {
+		return false
+	}
+	ext := filepath.Ext(path)
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// diff は追加・変更・削除されたファイルのパスを返します
+func diff(prev, next map[string]fileState) []string {
+	var changed []string
+	for path, state := range next {
+		if old, ok := prev[path]; !ok || old != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range prev {
+		if _, ok := next[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	return changed
+}
+
+// summarize は変更されたファイルをログ用に要約します
+func summarize(paths []string) string {
+	if len(paths) == 1 {
+		return paths[0]
+	}
+	return fmt.Sprintf("%s and %d more files", paths[0], len(paths)-1)
+}
+
+// build は go build で実行ファイルを作成します（コンパイルエラーはそのまま表示します）
+func build(ctx context.Context, cfg Config) error {
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", cfg.Binary, cfg.Package)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// process は起動中のアプリケーションのプロセスです
+type process struct {
+	cmd  *exec.Cmd
+	done chan struct{} // プロセスが終了すると閉じられる
+}
+
+// start はビルドした実行ファイルを起動します
+func start(cfg Config) (*process, error) {
+	cmd := exec.Command(cfg.Binary, cfg.Args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &process{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Printf("dev: process exited: %v", err)
+		}
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// stop はプロセスに SIGINT を送り、グレースフルシャットダウンを待ちます（timeout を過ぎた場合は強制終了します）
+func (p *process) stop(timeout time.Duration) {
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+
+	p.cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-p.done:
+	case <-time.After(timeout):
+		log.Printf("dev: process did not stop within %s, killing it", timeout)
+		p.cmd.Process.Kill()
+		<-p.done
+	}
+}
@@ -0,0 +1,74 @@
+package devserver
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestScan は監視対象のファイルの追加・変更・削除が検出されることをテストします
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string, modTime time.Time) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	extensions := []string{".go", ".html"}
+
+	write("main.go", "package main", base)
+	write("internal/handler.go", "package internal", base)
+	write("internal/handler_test.go", "package internal", base)
+	write("README.md", "# readme", base)
+	write(".git/HEAD", "ref", base)
+	before, err := scan([]string{dir}, extensions)
+	if err != nil {
+		t.Fatalf("scan() error = %v", err)
+	}
+	if len(before) != 2 {
+		t.Errorf("監視対象のファイル数 = %d, 期待値 = 2（%v）", len(before), before)
+	}
+
+	// 更新日時の変更・追加・削除は検出し、テストと対象外の拡張子の変更は無視する
+	write("main.go", "package main", base.Add(time.Second))
+	write("internal/handler_test.go", "package internal // x", base)
+	write("README.md", "# readme (updated)", base.Add(time.Second))
+	write("web/index.html", "<html></html>", base)
+	if err := os.Remove(filepath.Join(dir, "internal/handler.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := scan([]string{dir}, extensions)
+	if err != nil {
+		t.Fatalf("scan() error = %v", err)
+	}
+	changed := diff(before, after)
+	sort.Strings(changed)
+	expected := []string{
+		filepath.Join(dir, "internal/handler.go"),
+		filepath.Join(dir, "main.go"),
+		filepath.Join(dir, "web/index.html"),
+	}
+	if len(changed) != len(expected) {
+		t.Fatalf("変更されたファイル = %v, 期待値 = %v", changed, expected)
+	}
+	for i := range expected {
+		if changed[i] != expected[i] {
+			t.Errorf("変更されたファイル[%d] = %s, 期待値 = %s", i, changed[i], expected[i])
+		}
+	}
+
+	if again := diff(after, after); len(again) != 0 {
+		t.Errorf("変更がない場合の結果 = %v, 期待値 = なし", again)
+	}
+}
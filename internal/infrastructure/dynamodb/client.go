@@ -0,0 +1,361 @@
+// Package dynamodb は Amazon DynamoDB にTodoを保存するリポジトリ実装です
+//
+// 学習ポイント：
+//  1. AWS SDK を使わず、DynamoDB の JSON API（POST と X-Amz-Target ヘッダー）を net/http で呼び出す
+//  2. リクエストには署名バージョン4（sigv4.go）で署名する
+//  3. 1回の Query で返る量には上限（1MB）があるため、LastEvaluatedKey（ページングのトークン）をたどって全件を読む
+//
+// サーバーレス環境向けに、データベースの接続を持たずにTodoを保存するためのものです（DB_DRIVER=dynamodb）
+package dynamodb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AttributeValue は DynamoDB の属性の値です（型ごとに1つのフィールドだけを設定します）
+type AttributeValue struct {
+	S    *string          `json:"S,omitempty"`
+	N    *string          `json:"N,omitempty"`
+	BOOL *bool            `json:"BOOL,omitempty"`
+	L    []AttributeValue `json:"L,omitempty"`
+}
+
+// Item は属性名と値の組です（テーブルの1項目、またはキー）
+type Item map[string]AttributeValue
+
+// String は文字列の属性の値を作成します
+func String(s string) AttributeValue {
+	return AttributeValue{S: &s}
+}
+
+// Number は数値の属性の値を作成します
+func Number(n int) AttributeValue {
+	s := strconv.Itoa(n)
+	return AttributeValue{N: &s}
+}
+
+// Bool は真偽値の属性の値を作成します
+func Bool(b bool) AttributeValue {
+	return AttributeValue{BOOL: &b}
+}
+
+// List は文字列のリストの属性の値を作成します（文字列のセット SS と異なり、順序を保ちます）
+func List(values []string) AttributeValue {
+	l := make([]AttributeValue, len(values))
+	for i, v := range values {
+		l[i] = String(v)
+	}
+	return AttributeValue{L: l}
+}
+
+// GetItemInput は GetItem のリクエストです
+type GetItemInput struct {
+	TableName      string
+	Key            Item
+	ConsistentRead bool `json:",omitempty"`
+}
+
+// GetItemOutput は GetItem のレスポンスです（項目がない場合 Item は nil）
+type GetItemOutput struct {
+	Item Item
+}
+
+// PutItemInput は PutItem のリクエストです
+type PutItemInput struct {
+	TableName                 string
+	Item                      Item
+	ConditionExpression       string            `json:",omitempty"`
+	ExpressionAttributeNames  map[string]string `json:",omitempty"`
+	ExpressionAttributeValues Item              `json:",omitempty"`
+}
+
+// DeleteItemInput は DeleteItem のリクエストです
+type DeleteItemInput struct {
+	TableName                 string
+	Key                       Item
+	ConditionExpression       string            `json:",omitempty"`
+	ExpressionAttributeNames  map[string]string `json:",omitempty"`
+	ExpressionAttributeValues Item              `json:",omitempty"`
+}
+
+// UpdateItemInput は UpdateItem のリクエストです
+type UpdateItemInput struct {
+	TableName                 string
+	Key                       Item
+	UpdateExpression          string
+	ExpressionAttributeNames  map[string]string `json:",omitempty"`
+	ExpressionAttributeValues Item              `json:",omitempty"`
+	ReturnValues              string            `json:",omitempty"`
+}
+
+// UpdateItemOutput は UpdateItem のレスポンスです（ReturnValues で指定した属性）
+type UpdateItemOutput struct {
+	Attributes Item
+}
+
+// QueryInput は Query のリクエストです
+type QueryInput struct {
+	TableName                 string
+	IndexName                 string `json:",omitempty"`
+	KeyConditionExpression    string
+	ExpressionAttributeNames  map[string]string `json:",omitempty"`
+	ExpressionAttributeValues Item              `json:",omitempty"`
+	ScanIndexForward          *bool             `json:",omitempty"`
+	ExclusiveStartKey         Item              `json:",omitempty"`
+
+	// Limit は1回に読む項目の最大数です（0 の場合は1MBまで）
+	Limit int `json:",omitempty"`
+}
+
+// PageOutput は Query のレスポンスです
+// LastEvaluatedKey が nil でない場合は続きがあり、次のリクエストの ExclusiveStartKey に指定して読み進めます
+type PageOutput struct {
+	Items            []Item
+	LastEvaluatedKey Item
+}
+
+// TransactWriteItemsInput は TransactWriteItems のリクエストです（1回に100項目まで）
+type TransactWriteItemsInput struct {
+	TransactItems []TransactWriteItem
+}
+
+// TransactWriteItem はトランザクションの1つの書き込みです（Put と Delete のどちらか一方を設定します）
+type TransactWriteItem struct {
+	Put    *PutItemInput    `json:",omitempty"`
+	Delete *DeleteItemInput `json:",omitempty"`
+}
+
+// maxTransactItems は TransactWriteItems の1回の項目数の上限です
+const maxTransactItems = 100
+
+// API はリポジトリが使用する DynamoDB の操作です
+// Client が実装し、テストではメモリ上の実装に差し替えます
+type API interface {
+	GetItem(ctx context.Context, in *GetItemInput) (*GetItemOutput, error)
+	PutItem(ctx context.Context, in *PutItemInput) error
+	DeleteItem(ctx context.Context, in *DeleteItemInput) error
+	UpdateItem(ctx context.Context, in *UpdateItemInput) (*UpdateItemOutput, error)
+	Query(ctx context.Context, in *QueryInput) (*PageOutput, error)
+	TransactWriteItems(ctx context.Context, in *TransactWriteItemsInput) error
+}
+
+// APIError は DynamoDB が返したエラーです
+type APIError struct {
+	// StatusCode はHTTPのステータスコードです
+	StatusCode int
+
+	// Code はエラーの種類です（例: ConditionalCheckFailedException）
+	Code string
+
+	// Message はエラーの説明です
+	Message string
+
+	// CancellationReasons は TransactionCanceledException の場合の、項目ごとの取り消しの理由です
+	CancellationReasons []CancellationReason
+}
+
+// CancellationReason はトランザクションの1項目の取り消しの理由です（理由がない項目の Code は "None"）
+type CancellationReason struct {
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("dynamodb: %s: %s (status %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// IsConditionFailed は条件付きの書き込みの条件を満たさなかったエラーかどうかを判定します
+// トランザクションの場合は、いずれかの項目が条件を満たさずに取り消された場合に true を返します
+func IsConditionFailed(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code == "ConditionalCheckFailedException" {
+		return true
+	}
+	for _, reason := range apiErr.CancellationReasons {
+		if reason.Code == "ConditionalCheckFailed" {
+			return true
+		}
+	}
+	return false
+}
+
+// retryable は時間をおいて再試行すれば成功し得るエラーかどうかを判定します（スロットリングとサーバー側の障害）
+func (e *APIError) retryable() bool {
+	switch e.Code {
+	case "ProvisionedThroughputExceededException", "ThrottlingException", "RequestLimitExceeded":
+		return true
+	}
+	return e.StatusCode >= 500
+}
+
+// Config は Client の設定です
+type Config struct {
+	// Region はテーブルのあるリージョンです（例: ap-northeast-1）
+	Region string
+
+	// Endpoint は接続先のURLです（空の場合は https://dynamodb.<Region>.amazonaws.com）
+	// DynamoDB Local などのエミュレーターに接続する場合に指定します
+	Endpoint string
+
+	// AccessKeyID・SecretAccessKey・SessionToken はリクエストに署名する認証情報です
+	// SessionToken は一時的な認証情報（Lambda の実行ロール等）の場合のみ指定します
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// HTTPClient はリクエストに使用するHTTPクライアントです（nil の場合は http.DefaultClient）
+	HTTPClient *http.Client
+}
+
+// Client は DynamoDB の JSON API を呼び出す API の実装です
+type Client struct {
+	endpoint   string
+	region     string
+	creds      credentials
+	httpClient *http.Client
+
+	// maxAttempts は再試行を含めた最大の試行回数、retryDelay は最初の再試行までの待ち時間です（再試行のたびに2倍）
+	maxAttempts int
+	retryDelay  time.Duration
+
+	// now は署名の日時の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewClient はClientのコンストラクタです
+func NewClient(cfg Config) *Client {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://dynamodb." + cfg.Region + ".amazonaws.com"
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		endpoint:    strings.TrimSuffix(endpoint, "/") + "/",
+		region:      cfg.Region,
+		creds:       credentials{accessKeyID: cfg.AccessKeyID, secretAccessKey: cfg.SecretAccessKey, sessionToken: cfg.SessionToken},
+		httpClient:  httpClient,
+		maxAttempts: 3,
+		retryDelay:  50 * time.Millisecond,
+		now:         time.Now,
+	}
+}
+
+func (c *Client) GetItem(ctx context.Context, in *GetItemInput) (*GetItemOutput, error) {
+	var out GetItemOutput
+	return &out, c.call(ctx, "GetItem", in, &out)
+}
+
+func (c *Client) PutItem(ctx context.Context, in *PutItemInput) error {
+	return c.call(ctx, "PutItem", in, nil)
+}
+
+func (c *Client) DeleteItem(ctx context.Context, in *DeleteItemInput) error {
+	return c.call(ctx, "DeleteItem", in, nil)
+}
+
+func (c *Client) UpdateItem(ctx context.Context, in *UpdateItemInput) (*UpdateItemOutput, error) {
+	var out UpdateItemOutput
+	return &out, c.call(ctx, "UpdateItem", in, &out)
+}
+
+func (c *Client) Query(ctx context.Context, in *QueryInput) (*PageOutput, error) {
+	var out PageOutput
+	return &out, c.call(ctx, "Query", in, &out)
+}
+
+func (c *Client) TransactWriteItems(ctx context.Context, in *TransactWriteItemsInput) error {
+	return c.call(ctx, "TransactWriteItems", in, nil)
+}
+
+// call は操作 operation を呼び出し、レスポンスを out に読み込みます（out が nil の場合は読み捨てます）
+// スロットリングとサーバー側の障害は、待ち時間を2倍にしながら maxAttempts 回まで試行します
+func (c *Client) call(ctx context.Context, operation string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("dynamodb: failed to encode %s request: %w", operation, err)
+	}
+
+	delay := c.retryDelay
+	for attempt := 1; ; attempt++ {
+		err := c.do(ctx, operation, body, out)
+		var apiErr *APIError
+		if err == nil || !errors.As(err, &apiErr) || !apiErr.retryable() || attempt >= c.maxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// do はリクエストを1回送信します
+func (c *Client) do(ctx context.Context, operation string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("dynamodb: failed to create %s request: %w", operation, err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "DynamoDB_20120810."+operation)
+	signV4(req, body, c.creds, c.region, "dynamodb", c.now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("dynamodb: %s request failed: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("dynamodb: failed to read %s response: %w", operation, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp.StatusCode, respBody)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("dynamodb: failed to decode %s response: %w", operation, err)
+	}
+	return nil
+}
+
+// decodeError はエラーのレスポンス（{"__type": "com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException", "message": ...}）を APIError にします
+func decodeError(status int, body []byte) error {
+	var payload struct {
+		Type                string `json:"__type"`
+		Message             string `json:"message"`
+		MessageUpper        string `json:"Message"`
+		CancellationReasons []CancellationReason
+	}
+	apiErr := &APIError{StatusCode: status, Code: http.StatusText(status)}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Type == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+		return apiErr
+	}
+	apiErr.Code = payload.Type[strings.LastIndex(payload.Type, "#")+1:]
+	apiErr.Message = payload.Message
+	if apiErr.Message == "" {
+		apiErr.Message = payload.MessageUpper
+	}
+	apiErr.CancellationReasons = payload.CancellationReasons
+	return apiErr
+}
@@ -0,0 +1,66 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClient はリクエストのヘッダー・署名・本文と、エラーのレスポンスの解釈・再試行をテストします
+func TestClient(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		if got := r.Header.Get("Content-Type"); got != "application/x-amz-json-1.0" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		if got := r.Header.Get("Authorization"); !strings.HasPrefix(got, "AWS4-HMAC-SHA256 Credential=AKID/20240501/ap-northeast-1/dynamodb/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=") {
+			t.Errorf("Authorization = %q", got)
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "DynamoDB_20120810.GetItem":
+			var in GetItemInput
+			if err := json.Unmarshal(body, &in); err != nil || in.TableName != "todos" || *in.Key["pk"].S != "TODO#1" || !in.ConsistentRead {
+				t.Errorf("GetItem のリクエスト = %s, %v", body, err)
+			}
+			// 1回目はサーバー側の障害、2回目で成功する
+			if requests == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#InternalServerError","message":"retry"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Item":{"pk":{"S":"TODO#1"},"id":{"N":"1"},"tags":{"L":[{"S":"a"}]}}}`))
+		case "DynamoDB_20120810.PutItem":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException","message":"The conditional request failed"}`))
+		default:
+			t.Errorf("X-Amz-Target = %q", r.Header.Get("X-Amz-Target"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Region: "ap-northeast-1", Endpoint: server.URL, AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token"})
+	client.retryDelay = time.Millisecond
+	client.now = func() time.Time { return time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	out, err := client.GetItem(ctx, &GetItemInput{TableName: "todos", Key: todoKey(1), ConsistentRead: true})
+	if err != nil || requests != 2 {
+		t.Fatalf("GetItem() error = %v, リクエスト数 = %d, 期待値 = 2（1回再試行）", err, requests)
+	}
+	if id, _ := intAttr(out.Item, "id"); id != 1 || len(out.Item["tags"].L) != 1 {
+		t.Errorf("GetItem() = %+v", out.Item)
+	}
+
+	err = client.PutItem(ctx, &PutItemInput{TableName: "todos", Item: todoKey(1), ConditionExpression: "attribute_not_exists(pk)"})
+	if !IsConditionFailed(err) || requests != 3 {
+		t.Errorf("PutItem() error = %v, リクエスト数 = %d, 期待値 = 再試行しない条件のエラー", err, requests)
+	}
+}
@@ -0,0 +1,207 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fakeAPI はリポジトリが使用する式だけを解釈する、メモリ上の API の実装です
+// 1回の Query で返す件数を pageSize（Limit の指定があればその件数）に制限し、LastEvaluatedKey によるページングを再現します
+type fakeAPI struct {
+	mu       sync.Mutex
+	items    map[string]Item
+	pageSize int
+
+	// pages は Query で返したページの数です
+	pages int
+
+	// beforeWrite は条件付きの書き込みの条件を確認する直前に呼ばれます（他の書き込みの割り込みを再現するため）
+	// ロックを保持したまま呼ばれるため、items を直接変更してください
+	beforeWrite func(items map[string]Item)
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{items: make(map[string]Item), pageSize: 2}
+}
+
+// clone は項目を複製します（呼び出し側の変更が保存内容に影響しないように）
+func clone(item Item) Item {
+	if item == nil {
+		return nil
+	}
+	data, _ := json.Marshal(item)
+	var c Item
+	_ = json.Unmarshal(data, &c)
+	return c
+}
+
+func (f *fakeAPI) GetItem(_ context.Context, in *GetItemInput) (*GetItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &GetItemOutput{Item: clone(f.items[*in.Key["pk"].S])}, nil
+}
+
+func (f *fakeAPI) PutItem(ctx context.Context, in *PutItemInput) error {
+	return f.TransactWriteItems(ctx, &TransactWriteItemsInput{TransactItems: []TransactWriteItem{{Put: in}}})
+}
+
+func (f *fakeAPI) DeleteItem(ctx context.Context, in *DeleteItemInput) error {
+	return f.TransactWriteItems(ctx, &TransactWriteItemsInput{TransactItems: []TransactWriteItem{{Delete: in}}})
+}
+
+func (f *fakeAPI) UpdateItem(_ context.Context, in *UpdateItemInput) (*UpdateItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// ADD <属性> :<値> のみ対応
+	fields := strings.Fields(in.UpdateExpression)
+	if len(fields) != 3 || fields[0] != "ADD" {
+		return nil, fmt.Errorf("fakeAPI: unsupported update expression %q", in.UpdateExpression)
+	}
+	pk := *in.Key["pk"].S
+	item := f.items[pk]
+	if item == nil {
+		item = clone(in.Key)
+	}
+	current, _ := intAttr(item, fields[1])
+	delta, _ := intAttr(in.ExpressionAttributeValues, fields[2])
+	item[fields[1]] = Number(current + delta)
+	f.items[pk] = item
+	return &UpdateItemOutput{Attributes: Item{fields[1]: item[fields[1]]}}, nil
+}
+
+func (f *fakeAPI) TransactWriteItems(_ context.Context, in *TransactWriteItemsInput) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.beforeWrite != nil {
+		f.beforeWrite(f.items)
+	}
+
+	// 全ての条件を確認してから書き込む（1件でも満たさない場合は何も書き込まない）
+	reasons := make([]CancellationReason, len(in.TransactItems))
+	failed := false
+	for i, w := range in.TransactItems {
+		reasons[i].Code = "None"
+		var pk, cond string
+		var values Item
+		var names map[string]string
+		if w.Put != nil {
+			pk, cond, names, values = *w.Put.Item["pk"].S, w.Put.ConditionExpression, w.Put.ExpressionAttributeNames, w.Put.ExpressionAttributeValues
+		} else {
+			pk, cond, names, values = *w.Delete.Key["pk"].S, w.Delete.ConditionExpression, w.Delete.ExpressionAttributeNames, w.Delete.ExpressionAttributeValues
+		}
+		ok, err := evalCondition(f.items[pk], cond, names, values)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			reasons[i].Code = "ConditionalCheckFailed"
+			failed = true
+		}
+	}
+	if failed {
+		if len(in.TransactItems) == 1 {
+			return &APIError{StatusCode: 400, Code: "ConditionalCheckFailedException", Message: "The conditional request failed"}
+		}
+		return &APIError{StatusCode: 400, Code: "TransactionCanceledException", Message: "Transaction cancelled", CancellationReasons: reasons}
+	}
+
+	for _, w := range in.TransactItems {
+		if w.Put != nil {
+			f.items[*w.Put.Item["pk"].S] = clone(w.Put.Item)
+		} else {
+			delete(f.items, *w.Delete.Key["pk"].S)
+		}
+	}
+	return nil
+}
+
+// evalCondition は attribute_not_exists(pk) と "#名前 = :値" の条件を評価します
+func evalCondition(existing Item, cond string, names map[string]string, values Item) (bool, error) {
+	switch {
+	case cond == "":
+		return true, nil
+	case cond == "attribute_not_exists(pk)":
+		return existing == nil, nil
+	}
+	fields := strings.Fields(cond)
+	if len(fields) != 3 || fields[1] != "=" {
+		return false, fmt.Errorf("fakeAPI: unsupported condition %q", cond)
+	}
+	if existing == nil {
+		return false, nil
+	}
+	got, want := existing[names[fields[0]]], values[fields[2]]
+	return got.N != nil && want.N != nil && *got.N == *want.N, nil
+}
+
+func (f *fakeAPI) Query(_ context.Context, in *QueryInput) (*PageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if in.IndexName != ScopeIndex || in.KeyConditionExpression != "#s = :s" {
+		return nil, fmt.Errorf("fakeAPI: unsupported query %s %q", in.IndexName, in.KeyConditionExpression)
+	}
+	attr, value := in.ExpressionAttributeNames["#s"], *in.ExpressionAttributeValues[":s"].S
+	var items []Item
+	for _, item := range f.items {
+		if v := item[attr].S; v != nil && *v == value {
+			items = append(items, item)
+		}
+	}
+	forward := in.ScanIndexForward == nil || *in.ScanIndexForward
+	sort.Slice(items, func(i, j int) bool {
+		a, b := strAttr(items[i], "created"), strAttr(items[j], "created")
+		if forward {
+			return a < b
+		}
+		return a > b
+	})
+	return f.page(items, in.ExclusiveStartKey, in.Limit), nil
+}
+
+// page は startKey の次の項目から pageSize 件（limit の方が小さい場合は limit 件）を返します
+// LastEvaluatedKey は GSI の Query と同じく、テーブルとインデックスのキー（pk・scope・created）です
+func (f *fakeAPI) page(items []Item, startKey Item, limit int) *PageOutput {
+	f.pages++
+	start := 0
+	if startKey != nil {
+		for i, item := range items {
+			if strAttr(item, "pk") == strAttr(startKey, "pk") {
+				start = i + 1
+				break
+			}
+		}
+	}
+	size := f.pageSize
+	if limit > 0 && limit < size {
+		size = limit
+	}
+	end := start + size
+	if end >= len(items) {
+		end = len(items)
+	}
+	out := &PageOutput{Items: make([]Item, 0, end-start)}
+	for _, item := range items[start:end] {
+		out.Items = append(out.Items, clone(item))
+	}
+	if end < len(items) {
+		last := items[end-1]
+		out.LastEvaluatedKey = Item{"pk": last["pk"], "scope": last["scope"], "created": last["created"]}
+	}
+	return out
+}
+
+// version は保存されている項目のバージョンです（テストの確認用）
+func (f *fakeAPI) version(id int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, _ := intAttr(f.items[todoKeyPrefix+strconv.Itoa(id)], "version")
+	return v
+}
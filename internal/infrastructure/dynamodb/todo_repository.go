@@ -0,0 +1,929 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// テーブルの設計（キー）です
+//
+// テーブルはパーティションキー pk（文字列）だけを持ち、Todoは "TODO#<ID>"、IDの採番に使うカウンターは "COUNTER#todo" に保存します
+// 一覧は次のグローバルセカンダリインデックス（GSI）を Query して取得します（テーブル全体の Scan は行いません）
+//
+//	scope-created-index: パーティションキー scope（文字列）、ソートキー created（文字列）
+//
+// scope は所有者の範囲（ワークスペースのTodoは "W#<ワークスペースID>"、個人のTodoは "U#<ユーザーID>"、所有者のないTodoは "-"）で、
+// created は作成日時とIDを固定長にした文字列のため、降順に Query すると一覧と同じ作成日時の降順（同時刻はIDの降順）になります
+// 所有者もワークスペースも設定されていないコンテキスト（認証が無効）は "-" のパーティションを Query します
+// DynamoDB ではユーザー認証を使えない（SQLデータベースが必要）ため、保存される全てのTodoがこのパーティションにあります
+// GetPage（repository.TodoPager）は Query の1回分を1ページとして返し、LastEvaluatedKey を一覧のカーソルにします
+//
+// テーブルの作成例（AWS CLI）:
+//
+//	aws dynamodb create-table --table-name todos --billing-mode PAY_PER_REQUEST \
+//	  --attribute-definitions AttributeName=pk,AttributeType=S AttributeName=scope,AttributeType=S AttributeName=created,AttributeType=S \
+//	  --key-schema AttributeName=pk,KeyType=HASH \
+//	  --global-secondary-indexes 'IndexName=scope-created-index,KeySchema=[{AttributeName=scope,KeyType=HASH},{AttributeName=created,KeyType=RANGE}],Projection={ProjectionType=ALL}'
+const (
+	// ScopeIndex は所有者の範囲ごとにTodoを作成日時の順で引くGSIの名前です
+	ScopeIndex = "scope-created-index"
+
+	todoKeyPrefix = "TODO#"
+	counterKey    = "COUNTER#todo"
+
+	// noScope は所有者もワークスペースもないTodoの scope です
+	noScope = "-"
+
+	// timeLayout は日時を保存する形式です（固定長のため、文字列の比較が日時の比較と一致します）
+	timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+	// maxWriteAttempts は条件付きの書き込みが競合した場合に、読み直して再試行する最大の回数です
+	maxWriteAttempts = 5
+)
+
+// errConcurrentModification は他の書き込みとの競合が maxWriteAttempts 回続いた場合のエラーです
+var errConcurrentModification = errors.New("todo was modified concurrently, please retry")
+
+// todoRepository は DynamoDB にTodoを保存する repository.TodoRepository の実装です
+//
+// 注意点：
+//   - GSI は結果整合性のため、作成・更新の直後の一覧に反映されていない場合があります（ID による取得は強い整合性で読みます）
+//   - 同時の更新は、項目ごとの version 属性による楽観的ロック（条件付きの書き込み）で検知して読み直します
+//   - チェックリストの進捗は保存しません（チェックリストはデータベースの実装でのみ利用できます）
+type todoRepository struct {
+	api   API
+	table string
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewTodoRepository は table にTodoを保存するTodoRepositoryを作成します
+// 返すリポジトリは repository.TodoPager も実装します（カーソルでの一覧の取得）
+func NewTodoRepository(api API, table string) repository.TodoRepository {
+	return newTodoRepository(api, table)
+}
+
+// コンパイル時インターフェース実装確認
+var _ repository.TodoPager = (*todoRepository)(nil)
+
+func newTodoRepository(api API, table string) *todoRepository {
+	return &todoRepository{api: api, table: table, now: time.Now}
+}
+
+// storedTodo は読み込んだTodoと、楽観的ロックに使うバージョンです
+type storedTodo struct {
+	todo    *entity.Todo
+	version int
+}
+
+// Create はカウンターで採番したIDでTodoを保存します
+// データベース実装と同様に、作成時は常に未完了として保存します
+func (r *todoRepository) Create(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	created, err := r.CreateMany(ctx, []*entity.Todo{todo})
+	if err != nil {
+		return nil, err
+	}
+	return created[0], nil
+}
+
+// CreateMany は複数のTodoを1つのトランザクション（TransactWriteItems）でまとめて保存します（引数と同じ順序で返します）
+// Upsert・Restore で保存されたIDと採番したIDが重なった場合は、採番し直して再試行します
+func (r *todoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	if len(todos) == 0 {
+		return []*entity.Todo{}, nil
+	}
+	if len(todos) > maxTransactItems {
+		return nil, domainerr.Invalid("todos", fmt.Sprintf("at most %d todos can be created at once", maxTransactItems))
+	}
+
+	now := r.now().UTC()
+	created := make([]*entity.Todo, len(todos))
+	for i, todo := range todos {
+		c := copyTodo(todo)
+		c.IsCompleted = false
+		c.CreatedAt = now
+		c.UpdatedAt = now
+		c.DeletedAt = nil
+		setOwner(ctx, c)
+		created[i] = c
+	}
+
+	for attempt := 1; ; attempt++ {
+		last, err := r.nextIDs(ctx, len(created))
+		if err != nil {
+			return nil, err
+		}
+		items := make([]TransactWriteItem, len(created))
+		for i, c := range created {
+			c.ID = last - len(created) + 1 + i
+			items[i] = TransactWriteItem{Put: r.putNew(c)}
+		}
+
+		err = r.api.TransactWriteItems(ctx, &TransactWriteItemsInput{TransactItems: items})
+		if err == nil {
+			break
+		}
+		if !IsConditionFailed(err) || attempt >= maxWriteAttempts {
+			return nil, fmt.Errorf("failed to create todos: %w", err)
+		}
+	}
+
+	for i, c := range created {
+		todos[i].ID = c.ID
+		todos[i].IsCompleted = false
+		todos[i].CreatedAt, todos[i].UpdatedAt = now, now
+		todos[i].UserID, todos[i].WorkspaceID = copyInt(c.UserID), copyInt(c.WorkspaceID)
+		created[i] = copyTodo(c)
+	}
+	return created, nil
+}
+
+// nextIDs はカウンターを n だけ進め、採番したIDのうち最大のものを返します（採番したIDは 戻り値-n+1 〜 戻り値）
+// ADD による更新はアトミックなため、複数のサーバーから同時に採番しても同じIDは返りません
+func (r *todoRepository) nextIDs(ctx context.Context, n int) (int, error) {
+	out, err := r.api.UpdateItem(ctx, &UpdateItemInput{
+		TableName:                 r.table,
+		Key:                       Item{"pk": String(counterKey)},
+		UpdateExpression:          "ADD next_id :n",
+		ExpressionAttributeValues: Item{":n": Number(n)},
+		ReturnValues:              "UPDATED_NEW",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate todo ID: %w", err)
+	}
+	last, ok := intAttr(out.Attributes, "next_id")
+	if !ok {
+		return 0, errors.New("failed to allocate todo ID: counter was not returned")
+	}
+	return last, nil
+}
+
+// GetByID はIDでTodoを取得します
+func (r *todoRepository) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
+	stored, err := r.owned(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+	return stored.todo, nil
+}
+
+// Exists はIDでTodoの存在を確認します
+func (r *todoRepository) Exists(ctx context.Context, id int) (bool, error) {
+	stored, err := r.owned(ctx, id)
+	return stored != nil, err
+}
+
+// GetAll は全てのTodoを作成日時の降順で取得します
+func (r *todoRepository) GetAll(ctx context.Context) ([]*entity.Todo, error) {
+	return r.list(ctx, func(*entity.Todo) bool { return true })
+}
+
+// GetByColor は指定した色のTodoを作成日時の降順で取得します
+func (r *todoRepository) GetByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	return r.list(ctx, func(todo *entity.Todo) bool { return todo.Color == color })
+}
+
+// Find は条件に一致するTodoを指定した並び順で返します
+// DynamoDB は任意の属性での絞り込み・並べ替えの索引を持たないため、所有者の範囲を Query してから絞り込みます
+func (r *todoRepository) Find(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	if order != "" && !order.IsValid() {
+		return nil, domainerr.Invalid("sort order", fmt.Sprintf("%q", order))
+	}
+	todos, err := r.list(ctx, filter.Matches)
+	if err != nil {
+		return nil, err
+	}
+	order.Sort(todos)
+	return todos, nil
+}
+
+// Count は条件に一致するTodoの件数を返します
+func (r *todoRepository) Count(ctx context.Context, filter repository.TodoFilter) (int, error) {
+	todos, err := r.list(ctx, filter.Matches)
+	return len(todos), err
+}
+
+// CountGrouped は条件に一致するTodoの件数をグループごとに返します（キーの昇順）
+func (r *todoRepository) CountGrouped(ctx context.Context, group repository.TodoGroup, filter repository.TodoFilter) ([]repository.TodoGroupCount, error) {
+	if !group.IsValid() {
+		return nil, domainerr.Invalid("group", fmt.Sprintf("%q", group))
+	}
+	todos, err := r.list(ctx, filter.Matches)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, todo := range todos {
+		counts[groupKey(group, todo)]++
+	}
+	result := make([]repository.TodoGroupCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, repository.TodoGroupCount{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+// groupKey はTodoが属するグループのキーを返します（データベース実装の todoGroupKeys と同じ値）
+func groupKey(group repository.TodoGroup, todo *entity.Todo) string {
+	switch group {
+	case repository.TodoGroupColor:
+		return string(todo.Color)
+	case repository.TodoGroupProject:
+		if todo.ProjectID == nil {
+			return ""
+		}
+		return strconv.Itoa(*todo.ProjectID)
+	default:
+		return strconv.FormatBool(todo.IsCompleted)
+	}
+}
+
+// GetStats は一覧と同じTodoの件数と見積もり・実績時間の集計を返します
+func (r *todoRepository) GetStats(ctx context.Context) (entity.TodoStats, error) {
+	todos, err := r.list(ctx, func(*entity.Todo) bool { return true })
+	if err != nil {
+		return entity.TodoStats{}, err
+	}
+	return entity.NewTodoStats(todos), nil
+}
+
+// ExistsByTitle は同じタイトル（大文字・小文字を区別しない）のTodoが存在するかを返します
+func (r *todoRepository) ExistsByTitle(ctx context.Context, title string, excludeID int) (bool, error) {
+	todos, err := r.list(ctx, func(todo *entity.Todo) bool {
+		return todo.ID != excludeID && strings.EqualFold(todo.Title, title)
+	})
+	return len(todos) > 0, err
+}
+
+// Update は既存のTodoを更新します
+// データベース実装と同様に、作成日時とシリーズへの参照は変更しません
+func (r *todoRepository) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	return r.modify(ctx, todo.ID, func(existing *entity.Todo) (*entity.Todo, error) {
+		return r.merge(existing, todo), nil
+	})
+}
+
+// UpdateFields は指定したフィールドだけを更新します
+func (r *todoRepository) UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (*entity.Todo, error) {
+	return r.modify(ctx, todo.ID, func(existing *entity.Todo) (*entity.Todo, error) {
+		updated := copyTodo(existing)
+		updated.CopyFields(todo, fields)
+		updated.UpdatedAt = r.now().UTC()
+		return updated, nil
+	})
+}
+
+// UpdateMany は複数のTodoを1つのトランザクションでまとめて更新します
+// 全件のバージョンを条件にして書き込むため、途中で他の書き込みが割り込んだ場合は全件を読み直して再試行します
+func (r *todoRepository) UpdateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	if len(todos) == 0 {
+		return []*entity.Todo{}, nil
+	}
+	if len(todos) > maxTransactItems {
+		return nil, domainerr.Invalid("todos", fmt.Sprintf("at most %d todos can be updated at once", maxTransactItems))
+	}
+
+	for attempt := 1; ; attempt++ {
+		results := make([]*entity.Todo, len(todos))
+		items := make([]TransactWriteItem, len(todos))
+		for i, todo := range todos {
+			stored, err := r.owned(ctx, todo.ID)
+			if err != nil {
+				return nil, err
+			}
+			if stored == nil {
+				return nil, domainerr.NotFound("todo", todo.ID)
+			}
+			results[i] = r.merge(stored.todo, todo)
+			items[i] = TransactWriteItem{Put: r.putVersioned(results[i], stored.version)}
+		}
+
+		err := r.api.TransactWriteItems(ctx, &TransactWriteItemsInput{TransactItems: items})
+		if err == nil {
+			return results, nil
+		}
+		if !IsConditionFailed(err) {
+			return nil, fmt.Errorf("failed to update todos: %w", err)
+		}
+		if attempt >= maxWriteAttempts {
+			return nil, errConcurrentModification
+		}
+	}
+}
+
+// UpdateWithLock は mutate で変更したTodoを、読み込んだ時点から他の書き込みがない場合にだけ保存します
+// DynamoDB には行ロックがないため、書き込みが競合した場合は読み直して mutate を再度呼び出します（mutate は複数回呼ばれることがあります）
+func (r *todoRepository) UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (*entity.Todo, error) {
+	return r.modify(ctx, id, func(existing *entity.Todo) (*entity.Todo, error) {
+		todo := copyTodo(existing)
+		if err := mutate(todo); err != nil {
+			return nil, err
+		}
+		updated := r.merge(existing, todo)
+		updated.ID = id
+		return updated, nil
+	})
+}
+
+// modify は削除されていないTodoを読み込み、change が返したTodoをバージョンを条件にして保存します
+// change が repository.ErrNoChange を返した場合は書き込まずに読み込んだTodoを返します
+func (r *todoRepository) modify(ctx context.Context, id int, change func(existing *entity.Todo) (*entity.Todo, error)) (*entity.Todo, error) {
+	for attempt := 1; ; attempt++ {
+		stored, err := r.owned(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if stored == nil {
+			return nil, domainerr.NotFound("todo", nil)
+		}
+
+		updated, err := change(copyTodo(stored.todo))
+		if err != nil {
+			if errors.Is(err, repository.ErrNoChange) {
+				return stored.todo, nil
+			}
+			return nil, err
+		}
+
+		err = r.api.PutItem(ctx, r.putVersioned(updated, stored.version))
+		if err == nil {
+			return copyTodo(updated), nil
+		}
+		if !IsConditionFailed(err) {
+			return nil, fmt.Errorf("failed to update todo: %w", err)
+		}
+		if attempt >= maxWriteAttempts {
+			return nil, errConcurrentModification
+		}
+	}
+}
+
+// merge は更新内容のコピーに、更新では変わらない項目（作成日時・シリーズへの参照・所有者・ワークスペース）を引き継ぎます
+func (r *todoRepository) merge(existing, todo *entity.Todo) *entity.Todo {
+	updated := copyTodo(todo)
+	updated.CreatedAt = existing.CreatedAt
+	updated.RecurrenceParentID = copyInt(existing.RecurrenceParentID)
+	updated.UserID = copyInt(existing.UserID)
+	updated.WorkspaceID = copyInt(existing.WorkspaceID)
+	updated.ChecklistProgress = existing.ChecklistProgress
+	updated.UpdatedAt = r.now().UTC()
+	return updated
+}
+
+// Upsert はIDを指定してTodoを作成し、同じIDのTodoが既にある場合はその内容を更新します
+// データベース実装と同様に、削除済みのTodoは復元し、他のユーザーのTodoは "todo not found" になります
+func (r *todoRepository) Upsert(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	if todo.ID <= 0 {
+		return nil, domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+
+	for attempt := 1; ; attempt++ {
+		stored, err := r.get(ctx, todo.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var put *PutItemInput
+		var result *entity.Todo
+		if stored == nil {
+			now := r.now().UTC()
+			result = copyTodo(todo)
+			result.CreatedAt = now
+			result.UpdatedAt = now
+			result.DeletedAt = nil
+			setOwner(ctx, result)
+			put = r.putNew(result)
+		} else {
+			if !ownedBy(ctx, stored.todo) {
+				return nil, domainerr.NotFound("todo", nil)
+			}
+			result = r.merge(stored.todo, todo)
+			result.DeletedAt = nil
+			put = r.putVersioned(result, stored.version)
+		}
+
+		err = r.api.PutItem(ctx, put)
+		if err == nil {
+			return copyTodo(result), nil
+		}
+		if !IsConditionFailed(err) {
+			return nil, fmt.Errorf("failed to upsert todo: %w", err)
+		}
+		if attempt >= maxWriteAttempts {
+			return nil, errConcurrentModification
+		}
+	}
+}
+
+// Delete はTodoをソフト削除します（削除日時を記録し、既定の取得・一覧から除外します）
+func (r *todoRepository) Delete(ctx context.Context, id int) error {
+	_, err := r.modify(ctx, id, func(existing *entity.Todo) (*entity.Todo, error) {
+		now := r.now().UTC()
+		existing.DeletedAt = &now
+		return existing, nil
+	})
+	return err
+}
+
+// GetDeleted は削除済みのTodoを削除日時の降順（同時刻はIDの降順）で取得します
+func (r *todoRepository) GetDeleted(ctx context.Context) ([]*entity.Todo, error) {
+	stored, err := r.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todos := make([]*entity.Todo, 0)
+	for _, s := range stored {
+		if s.todo.DeletedAt != nil {
+			todos = append(todos, s.todo)
+		}
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		if !todos[i].DeletedAt.Equal(*todos[j].DeletedAt) {
+			return todos[i].DeletedAt.After(*todos[j].DeletedAt)
+		}
+		return todos[i].ID > todos[j].ID
+	})
+	return todos, nil
+}
+
+// Restore は削除したTodoを同じIDと作成日時のまま保存し直します
+// 削除済みのTodoは渡された内容で置き換え、項目がない場合は同じIDで作成し直します
+func (r *todoRepository) Restore(ctx context.Context, todo *entity.Todo) error {
+	for attempt := 1; ; attempt++ {
+		stored, err := r.get(ctx, todo.ID)
+		if err != nil {
+			return err
+		}
+		if stored != nil && (stored.todo.DeletedAt == nil || !ownedBy(ctx, stored.todo)) {
+			return errors.New("todo already exists")
+		}
+
+		restored := copyTodo(todo)
+		restored.DeletedAt = nil
+		if userID, ok := repository.OwnerFromContext(ctx); ok {
+			restored.UserID = &userID
+		}
+		if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+			restored.WorkspaceID = &workspaceID
+		}
+		put := r.putNew(restored)
+		if stored != nil {
+			put = r.putVersioned(restored, stored.version)
+		}
+
+		err = r.api.PutItem(ctx, put)
+		if err == nil {
+			return nil
+		}
+		if !IsConditionFailed(err) {
+			return fmt.Errorf("failed to restore todo: %w", err)
+		}
+		if attempt >= maxWriteAttempts {
+			return errConcurrentModification
+		}
+	}
+}
+
+// HardDelete はTodoを完全に削除します（削除済みのTodoも対象です）
+func (r *todoRepository) HardDelete(ctx context.Context, id int) error {
+	for attempt := 1; ; attempt++ {
+		stored, err := r.get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stored == nil || !ownedBy(ctx, stored.todo) {
+			return domainerr.NotFound("todo", nil)
+		}
+
+		err = r.api.DeleteItem(ctx, &DeleteItemInput{
+			TableName:                 r.table,
+			Key:                       todoKey(id),
+			ConditionExpression:       "#v = :v",
+			ExpressionAttributeNames:  map[string]string{"#v": "version"},
+			ExpressionAttributeValues: Item{":v": Number(stored.version)},
+		})
+		if err == nil {
+			return nil
+		}
+		if !IsConditionFailed(err) {
+			return fmt.Errorf("failed to delete todo: %w", err)
+		}
+		if attempt >= maxWriteAttempts {
+			return errConcurrentModification
+		}
+	}
+}
+
+// putNew は同じIDの項目がない場合にだけTodoを保存する書き込みを作成します（バージョンは1から始まります）
+func (r *todoRepository) putNew(todo *entity.Todo) *PutItemInput {
+	return &PutItemInput{
+		TableName:           r.table,
+		Item:                marshalTodo(todo, 1),
+		ConditionExpression: "attribute_not_exists(pk)",
+	}
+}
+
+// putVersioned は読み込んだ時点のバージョン version から変わっていない場合にだけTodoを保存する書き込みを作成します
+func (r *todoRepository) putVersioned(todo *entity.Todo, version int) *PutItemInput {
+	return &PutItemInput{
+		TableName:                 r.table,
+		Item:                      marshalTodo(todo, version+1),
+		ConditionExpression:       "#v = :v",
+		ExpressionAttributeNames:  map[string]string{"#v": "version"},
+		ExpressionAttributeValues: Item{":v": Number(version)},
+	}
+}
+
+// get はIDでTodoを強い整合性で読み込みます（削除済み・他のユーザーのTodoも返します。項目がない場合は nil）
+func (r *todoRepository) get(ctx context.Context, id int) (*storedTodo, error) {
+	out, err := r.api.GetItem(ctx, &GetItemInput{TableName: r.table, Key: todoKey(id), ConsistentRead: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get todo: %w", err)
+	}
+	if out.Item == nil {
+		return nil, nil
+	}
+	return unmarshalTodo(out.Item)
+}
+
+// owned はIDでTodoを読み込み、コンテキストの所有者の削除されていないTodoであれば返します（それ以外は nil）
+// 他のユーザーのTodoと削除済みのTodoは、データベース実装と同様に存在しないものとして扱います
+func (r *todoRepository) owned(ctx context.Context, id int) (*storedTodo, error) {
+	stored, err := r.get(ctx, id)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	if stored.todo.DeletedAt != nil || !ownedBy(ctx, stored.todo) {
+		return nil, nil
+	}
+	return stored, nil
+}
+
+// ownedBy はTodoがコンテキストのワークスペース・所有者のものかを判定します
+// データベース実装の scopeCondition と同じく、ワークスペースが設定されている場合はそのワークスペースのTodo、
+// 所有者だけの場合は本人の個人のTodo、どちらも設定されていない場合は全てのTodoが対象です
+func ownedBy(ctx context.Context, todo *entity.Todo) bool {
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		return todo.WorkspaceID != nil && *todo.WorkspaceID == workspaceID
+	}
+	userID, ok := repository.OwnerFromContext(ctx)
+	return !ok || (todo.UserID != nil && *todo.UserID == userID && todo.WorkspaceID == nil)
+}
+
+// setOwner はコンテキストの所有者とワークスペースをTodoに設定します（作成時）
+func setOwner(ctx context.Context, todo *entity.Todo) {
+	todo.UserID, todo.WorkspaceID = nil, nil
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		todo.UserID = &userID
+	}
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		todo.WorkspaceID = &workspaceID
+	}
+}
+
+// list はコンテキストの所有者の削除されていないTodoのうち、条件に一致するものを作成日時の降順（同時刻はIDの降順）で返します
+func (r *todoRepository) list(ctx context.Context, match func(*entity.Todo) bool) ([]*entity.Todo, error) {
+	stored, err := r.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todos := make([]*entity.Todo, 0, len(stored))
+	for _, s := range stored {
+		if s.todo.DeletedAt == nil && match(s.todo) {
+			todos = append(todos, s.todo)
+		}
+	}
+	sort.SliceStable(todos, func(i, j int) bool {
+		if !todos[i].CreatedAt.Equal(todos[j].CreatedAt) {
+			return todos[i].CreatedAt.After(todos[j].CreatedAt)
+		}
+		return todos[i].ID > todos[j].ID
+	})
+	return todos, nil
+}
+
+// scope はコンテキストの所有者の範囲の全てのTodo（削除済みを含む）を読み込みます
+// GSI の1つのパーティションを作成日時の降順に Query し、LastEvaluatedKey がなくなるまでページをたどります
+func (r *todoRepository) scope(ctx context.Context) ([]*storedTodo, error) {
+	key := scopeKey(ctx)
+	var todos []*storedTodo
+	var startKey Item
+	for {
+		page, err := r.query(ctx, key, startKey, 0)
+		if err != nil {
+			return nil, err
+		}
+		stored, err := unmarshalPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, stored...)
+		if len(page.LastEvaluatedKey) == 0 {
+			return todos, nil
+		}
+		startKey = page.LastEvaluatedKey
+	}
+}
+
+// GetPage は一覧を GSI の1回の Query（最大 limit 件）で取得し、LastEvaluatedKey を次のページのカーソルにします
+// カーソルは LastEvaluatedKey のJSONをURLで使えるBase64にしたもので、他の所有者の範囲のカーソルは不正なカーソルとして扱います
+func (r *todoRepository) GetPage(ctx context.Context, cursor string, limit int) (repository.TodoPage, error) {
+	if limit < 1 {
+		return repository.TodoPage{}, domainerr.Invalid("limit", "must be a positive integer")
+	}
+	key := scopeKey(ctx)
+	startKey, err := decodeCursor(cursor, key)
+	if err != nil {
+		return repository.TodoPage{}, err
+	}
+
+	page, err := r.query(ctx, key, startKey, limit)
+	if err != nil {
+		return repository.TodoPage{}, err
+	}
+	stored, err := unmarshalPage(ctx, page)
+	if err != nil {
+		return repository.TodoPage{}, err
+	}
+	todos := make([]*entity.Todo, 0, len(stored))
+	for _, s := range stored {
+		if s.todo.DeletedAt == nil {
+			todos = append(todos, s.todo)
+		}
+	}
+	next, err := encodeCursor(page.LastEvaluatedKey)
+	if err != nil {
+		return repository.TodoPage{}, err
+	}
+	return repository.TodoPage{Todos: todos, NextCursor: next}, nil
+}
+
+// query は GSI の所有者の範囲 key のパーティションを、startKey の続きから作成日時の降順に Query します（limit が 0 の場合は件数を指定しません）
+func (r *todoRepository) query(ctx context.Context, key string, startKey Item, limit int) (*PageOutput, error) {
+	forward := false
+	page, err := r.api.Query(ctx, &QueryInput{
+		TableName:                 r.table,
+		IndexName:                 ScopeIndex,
+		KeyConditionExpression:    "#s = :s",
+		ExpressionAttributeNames:  map[string]string{"#s": "scope"},
+		ExpressionAttributeValues: Item{":s": String(key)},
+		ScanIndexForward:          &forward,
+		ExclusiveStartKey:         startKey,
+		Limit:                     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+	return page, nil
+}
+
+// unmarshalPage は Query の結果のうち、コンテキストの所有者の範囲のTodoを読み込みます
+func unmarshalPage(ctx context.Context, page *PageOutput) ([]*storedTodo, error) {
+	todos := make([]*storedTodo, 0, len(page.Items))
+	for _, item := range page.Items {
+		if pk := item["pk"].S; pk == nil || !strings.HasPrefix(*pk, todoKeyPrefix) {
+			continue // カウンターの項目
+		}
+		stored, err := unmarshalTodo(item)
+		if err != nil {
+			return nil, err
+		}
+		// 作成後に所有者の範囲が変わることはないが、GetByID と同じ条件で確認する
+		if ownedBy(ctx, stored.todo) {
+			todos = append(todos, stored)
+		}
+	}
+	return todos, nil
+}
+
+// scopeKey はコンテキストの所有者の範囲（GSI のパーティションキー）を返します
+// 所有者もワークスペースも設定されていない場合は、所有者のないTodoの範囲（noScope）です
+func scopeKey(ctx context.Context) string {
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		return "W#" + strconv.Itoa(workspaceID)
+	}
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		return "U#" + strconv.Itoa(userID)
+	}
+	return noScope
+}
+
+// encodeCursor は LastEvaluatedKey を一覧のカーソルにします（続きがない場合は空）
+func encodeCursor(key Item) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor はカーソルを Query の ExclusiveStartKey に戻します（空の場合は最初のページ）
+// 所有者の範囲 scope のパーティションのキーでない場合は domainerr.Invalid を返します
+func decodeCursor(cursor, scope string) (Item, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	invalid := domainerr.Invalid("cursor", "must be the next_cursor of a previous page of the same list")
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+	var key Item
+	if err := json.Unmarshal(data, &key); err != nil || strAttr(key, "scope") != scope || strAttr(key, "pk") == "" {
+		return nil, invalid
+	}
+	return key, nil
+}
+
+// todoKey はTodoの項目のキーです
+func todoKey(id int) Item {
+	return Item{"pk": String(todoKeyPrefix + strconv.Itoa(id))}
+}
+
+// scopeOf はTodoの所有者の範囲（GSI のパーティションキー）を返します
+func scopeOf(todo *entity.Todo) string {
+	switch {
+	case todo.WorkspaceID != nil:
+		return "W#" + strconv.Itoa(*todo.WorkspaceID)
+	case todo.UserID != nil:
+		return "U#" + strconv.Itoa(*todo.UserID)
+	default:
+		return noScope
+	}
+}
+
+// marshalTodo はTodoを項目に変換します（値のない任意の属性は保存しません）
+func marshalTodo(todo *entity.Todo, version int) Item {
+	item := Item{
+		"pk":           String(todoKeyPrefix + strconv.Itoa(todo.ID)),
+		"scope":        String(scopeOf(todo)),
+		"created":      String(fmt.Sprintf("%s#%010d", formatTime(todo.CreatedAt), todo.ID)),
+		"version":      Number(version),
+		"id":           Number(todo.ID),
+		"title":        String(todo.Title),
+		"is_completed": Bool(todo.IsCompleted),
+		"created_at":   String(formatTime(todo.CreatedAt)),
+		"updated_at":   String(formatTime(todo.UpdatedAt)),
+	}
+	// DynamoDB は空の文字列をキー以外の属性に保存できるが、省略して項目を小さくする
+	if todo.Description != "" {
+		item["description"] = String(todo.Description)
+	}
+	if todo.Recurrence != entity.RecurrenceNone {
+		item["recurrence"] = String(string(todo.Recurrence))
+	}
+	if todo.Color != entity.ColorNone {
+		item["color"] = String(string(todo.Color))
+	}
+	if todo.EstimateMinutes != 0 {
+		item["estimate_minutes"] = Number(todo.EstimateMinutes)
+	}
+	if todo.ActualMinutes != 0 {
+		item["actual_minutes"] = Number(todo.ActualMinutes)
+	}
+	if len(todo.Tags) > 0 {
+		item["tags"] = List(todo.Tags)
+	}
+	for name, t := range map[string]*time.Time{"remind_at": todo.RemindAt, "due_date": todo.DueDate, "deleted_at": todo.DeletedAt} {
+		if t != nil {
+			item[name] = String(formatTime(*t))
+		}
+	}
+	for name, n := range map[string]*int{"recurrence_parent_id": todo.RecurrenceParentID, "project_id": todo.ProjectID, "user_id": todo.UserID, "workspace_id": todo.WorkspaceID} {
+		if n != nil {
+			item[name] = Number(*n)
+		}
+	}
+	return item
+}
+
+// unmarshalTodo は項目をTodoに変換します
+func unmarshalTodo(item Item) (*storedTodo, error) {
+	todo := &entity.Todo{
+		Title:       strAttr(item, "title"),
+		Description: strAttr(item, "description"),
+		Recurrence:  entity.Recurrence(strAttr(item, "recurrence")),
+		Color:       entity.Color(strAttr(item, "color")),
+	}
+	var ok bool
+	if todo.ID, ok = intAttr(item, "id"); !ok {
+		return nil, fmt.Errorf("invalid todo item %v: missing id", item["pk"].S)
+	}
+	version, _ := intAttr(item, "version")
+	todo.EstimateMinutes, _ = intAttr(item, "estimate_minutes")
+	todo.ActualMinutes, _ = intAttr(item, "actual_minutes")
+	if v := item["is_completed"].BOOL; v != nil {
+		todo.IsCompleted = *v
+	}
+	for _, v := range item["tags"].L {
+		if v.S != nil {
+			todo.Tags = append(todo.Tags, *v.S)
+		}
+	}
+
+	var err error
+	if todo.CreatedAt, err = parseTime(strAttr(item, "created_at")); err != nil {
+		return nil, err
+	}
+	if todo.UpdatedAt, err = parseTime(strAttr(item, "updated_at")); err != nil {
+		return nil, err
+	}
+	for name, dst := range map[string]**time.Time{"remind_at": &todo.RemindAt, "due_date": &todo.DueDate, "deleted_at": &todo.DeletedAt} {
+		if item[name].S == nil {
+			continue
+		}
+		t, err := parseTime(*item[name].S)
+		if err != nil {
+			return nil, err
+		}
+		*dst = &t
+	}
+	for name, dst := range map[string]**int{"recurrence_parent_id": &todo.RecurrenceParentID, "project_id": &todo.ProjectID, "user_id": &todo.UserID, "workspace_id": &todo.WorkspaceID} {
+		if n, ok := intAttr(item, name); ok {
+			*dst = &n
+		}
+	}
+	return &storedTodo{todo: todo, version: version}, nil
+}
+
+// strAttr は文字列の属性の値を返します（属性がない場合は空文字）
+func strAttr(item Item, name string) string {
+	if v := item[name].S; v != nil {
+		return *v
+	}
+	return ""
+}
+
+// intAttr は数値の属性の値を返します（属性がない・整数でない場合は false）
+func intAttr(item Item, name string) (int, bool) {
+	v := item[name].N
+	if v == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(*v)
+	return n, err == nil
+}
+
+// formatTime は日時を保存する形式（UTC・固定長）の文字列にします
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// parseTime は保存した日時を読み込みます
+func parseTime(s string) (time.Time, error) {
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q in todo item: %w", s, err)
+	}
+	return t, nil
+}
+
+// copyTodo はポインタ・スライスのフィールドも含めてTodoを複製します
+func copyTodo(todo *entity.Todo) *entity.Todo {
+	c := *todo
+	for _, p := range []**time.Time{&c.RemindAt, &c.DueDate, &c.DeletedAt} {
+		if *p != nil {
+			t := **p
+			*p = &t
+		}
+	}
+	c.RecurrenceParentID = copyInt(todo.RecurrenceParentID)
+	c.ProjectID = copyInt(todo.ProjectID)
+	c.UserID = copyInt(todo.UserID)
+	c.WorkspaceID = copyInt(todo.WorkspaceID)
+	if todo.Tags != nil {
+		c.Tags = append([]string(nil), todo.Tags...)
+	}
+	return &c
+}
+
+// copyInt は *int を複製します（nil の場合は nil）
+func copyInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+	v := *i
+	return &v
+}
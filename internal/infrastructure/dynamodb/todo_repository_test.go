@@ -0,0 +1,340 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// newTestRepository は fakeAPI に保存するリポジトリを作成します（時刻は1分ずつ進みます）
+func newTestRepository() (*todoRepository, *fakeAPI) {
+	api := newFakeAPI()
+	repo := newTodoRepository(api, "todos")
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	return repo, api
+}
+
+// TestTodoRepository_CRUD は作成・取得・更新・削除・復元と、保存した属性の往復をテストします
+func TestTodoRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestRepository()
+
+	due := time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC)
+	projectID := 3
+	first, err := repo.Create(ctx, &entity.Todo{Title: "牛乳を買う", IsCompleted: true, DueDate: &due, Tags: []string{"shop", "daily"}, ProjectID: &projectID, EstimateMinutes: 15})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second, _ := repo.Create(ctx, &entity.Todo{Title: "請求書を送る", Color: "blue"})
+	if first.ID != 1 || second.ID != 2 || first.IsCompleted {
+		t.Fatalf("作成結果 = %+v, %+v", first, second)
+	}
+
+	got, err := repo.GetByID(ctx, 1)
+	if err != nil || got.Title != "牛乳を買う" || !got.DueDate.Equal(due) || *got.ProjectID != 3 || got.EstimateMinutes != 15 ||
+		len(got.Tags) != 2 || got.Tags[0] != "shop" || !got.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("GetByID() = %+v, %v", got, err)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 2 || all[0].ID != 2 {
+		t.Errorf("一覧は作成日時の降順であるべきです: %+v", all)
+	}
+	if blue, _ := repo.GetByColor(ctx, "blue"); len(blue) != 1 || blue[0].ID != 2 {
+		t.Errorf("色による取得 = %+v", blue)
+	}
+
+	second.MarkAsCompleted()
+	updated, err := repo.Update(ctx, second)
+	if err != nil || !updated.IsCompleted || !updated.CreatedAt.Equal(second.CreatedAt) || !updated.UpdatedAt.After(second.CreatedAt) {
+		t.Errorf("更新結果 = %+v, エラー = %v", updated, err)
+	}
+
+	if err := repo.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetByID(ctx, 2); !domainerr.IsNotFound(err) {
+		t.Errorf("削除したTodoの GetByID() error = %v, 期待値 = not found", err)
+	}
+	if trash, _ := repo.GetDeleted(ctx); len(trash) != 1 || trash[0].ID != 2 || trash[0].DeletedAt == nil {
+		t.Errorf("削除済みの一覧 = %+v, 期待値 = 削除日時付きのID 2", trash)
+	}
+
+	// 削除したTodoは同じIDと作成日時のまま復元できる
+	if err := repo.Restore(ctx, updated); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, 2); got == nil || !got.CreatedAt.Equal(updated.CreatedAt) || !got.IsCompleted {
+		t.Errorf("復元結果 = %+v", got)
+	}
+	if err := repo.Restore(ctx, updated); err == nil {
+		t.Error("削除されていないTodoの復元でエラーが返されませんでした")
+	}
+
+	if err := repo.HardDelete(ctx, 2); err != nil {
+		t.Fatalf("HardDelete() error = %v", err)
+	}
+	if err := repo.HardDelete(ctx, 2); !domainerr.IsNotFound(err) {
+		t.Errorf("存在しないTodoの HardDelete() error = %v, 期待値 = not found", err)
+	}
+}
+
+// TestTodoRepository_Pagination は Query の結果が複数のページに分かれても全件を読むことをテストします
+func TestTodoRepository_Pagination(t *testing.T) {
+	repo, api := newTestRepository()
+	alice := repository.WithOwner(context.Background(), 1)
+
+	for i := 0; i < 5; i++ {
+		if _, err := repo.Create(alice, &entity.Todo{Title: "Todo " + strconv.Itoa(i)}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if _, err := repo.Create(context.Background(), &entity.Todo{Title: "所有者のないTodo"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	api.pages = 0
+	todos, err := repo.GetAll(alice)
+	if err != nil || len(todos) != 5 || todos[0].ID != 5 || todos[4].ID != 1 {
+		t.Errorf("GetAll() = %d件, %v, 期待値 = 作成日時の降順の5件", len(todos), err)
+	}
+	if api.pages != 3 {
+		t.Errorf("Query のページ数 = %d, 期待値 = 3（1ページ2件）", api.pages)
+	}
+
+	// 所有者のないコンテキストは所有者のないTodoのパーティションを Query する（カウンターの項目は含まない）
+	if todos, err := repo.GetAll(context.Background()); err != nil || len(todos) != 1 || todos[0].ID != 6 {
+		t.Errorf("所有者なしの GetAll() = %+v, %v, 期待値 = 所有者のないTodoのみ", todos, err)
+	}
+}
+
+// TestTodoRepository_GetPage は LastEvaluatedKey をカーソルにして一覧を1ページずつ取得できることをテストします
+func TestTodoRepository_GetPage(t *testing.T) {
+	repo, api := newTestRepository()
+	api.pageSize = 10
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if _, err := repo.Create(ctx, &entity.Todo{Title: "Todo " + strconv.Itoa(i)}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if err := repo.Delete(ctx, 4); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// 3件ずつ読むと、削除済みのID 4 を読み飛ばして 5, 3 → 2, 1 の2ページになる
+	api.pages = 0
+	var ids []int
+	cursor := ""
+	for {
+		page, err := repo.GetPage(ctx, cursor, 3)
+		if err != nil {
+			t.Fatalf("GetPage(%q) error = %v", cursor, err)
+		}
+		for _, todo := range page.Todos {
+			ids = append(ids, todo.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if len(ids) != 4 || ids[0] != 5 || ids[1] != 3 || ids[3] != 1 {
+		t.Errorf("取得したID = %v, 期待値 = [5 3 2 1]", ids)
+	}
+	if api.pages != 2 {
+		t.Errorf("Query のページ数 = %d, 期待値 = 2（1ページ3件）", api.pages)
+	}
+
+	// 他の所有者の範囲のカーソルや壊れたカーソルは不正なカーソル
+	first, _ := repo.GetPage(ctx, "", 1)
+	if _, err := repo.GetPage(repository.WithOwner(ctx, 1), first.NextCursor, 1); !domainerr.IsInvalid(err) {
+		t.Errorf("他の所有者のカーソルの GetPage() error = %v, 期待値 = invalid", err)
+	}
+	if _, err := repo.GetPage(ctx, "not a cursor", 1); !domainerr.IsInvalid(err) {
+		t.Errorf("壊れたカーソルの GetPage() error = %v, 期待値 = invalid", err)
+	}
+}
+
+// TestTodoRepository_Owner はコンテキストの所有者・ワークスペースのTodoだけが操作の対象になることをテストします
+func TestTodoRepository_Owner(t *testing.T) {
+	repo, _ := newTestRepository()
+	alice := repository.WithOwner(context.Background(), 1)
+	bob := repository.WithOwner(context.Background(), 2)
+	team := repository.WithWorkspace(alice, 10)
+
+	created, _ := repo.Create(alice, &entity.Todo{Title: "アリスのTodo"})
+	_, _ = repo.Create(bob, &entity.Todo{Title: "ボブのTodo"})
+	shared, _ := repo.Create(team, &entity.Todo{Title: "チームのTodo"})
+
+	if todos, _ := repo.GetAll(alice); len(todos) != 1 || todos[0].ID != created.ID {
+		t.Errorf("本人の GetAll() = %+v, 期待値 = アリスの個人のTodoのみ", todos)
+	}
+	if todos, _ := repo.GetAll(repository.WithWorkspace(bob, 10)); len(todos) != 1 || todos[0].ID != shared.ID {
+		t.Errorf("ワークスペースの GetAll() = %+v, 期待値 = チームのTodoのみ", todos)
+	}
+	if _, err := repo.GetByID(bob, created.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのTodoの GetByID() error = %v, 期待値 = not found", err)
+	}
+	if err := repo.Delete(bob, created.ID); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのTodoの Delete() error = %v, 期待値 = not found", err)
+	}
+	if _, err := repo.Upsert(bob, &entity.Todo{ID: created.ID, Title: "乗っ取り"}); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのTodoの Upsert() error = %v, 期待値 = not found", err)
+	}
+	if exists, _ := repo.ExistsByTitle(bob, "アリスのTodo", 0); exists {
+		t.Error("他のユーザーのTodoのタイトルが重複と判定されました")
+	}
+
+	// 更新しても所有者は変わらない
+	updated, err := repo.Update(alice, &entity.Todo{ID: created.ID, Title: "変更後"})
+	if err != nil || updated.UserID == nil || *updated.UserID != 1 || updated.WorkspaceID != nil {
+		t.Errorf("Update() = %+v, err = %v, 期待値 = 所有者 1 のまま", updated, err)
+	}
+}
+
+// TestTodoRepository_Upsert はIDを指定した作成と、採番したIDとの衝突を避けることをテストします
+func TestTodoRepository_Upsert(t *testing.T) {
+	ctx := repository.WithOwner(context.Background(), 7)
+	repo, _ := newTestRepository()
+
+	created, err := repo.Upsert(ctx, &entity.Todo{ID: 2, Title: "同期したTodo", IsCompleted: true})
+	if err != nil || created.ID != 2 || !created.IsCompleted || *created.UserID != 7 {
+		t.Fatalf("作成結果 = %+v, エラー = %v", created, err)
+	}
+	if err := repo.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	updated, err := repo.Upsert(ctx, &entity.Todo{ID: 2, Title: "更新した同期Todo"})
+	if err != nil || updated.Title != "更新した同期Todo" || updated.DeletedAt != nil || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("更新結果 = %+v, エラー = %v", updated, err)
+	}
+
+	// 採番したIDが Upsert したIDと重なる場合は、次のIDで作成する
+	first, _ := repo.Create(ctx, &entity.Todo{Title: "一つ目"})
+	second, err := repo.Create(ctx, &entity.Todo{Title: "二つ目"})
+	if err != nil || first.ID != 1 || second.ID != 3 {
+		t.Errorf("作成したTodoのID = %d, %d (%v), 期待値 = 1, 3", first.ID, second.ID, err)
+	}
+	if _, err := repo.Upsert(ctx, &entity.Todo{Title: "IDなし"}); !domainerr.IsInvalid(err) {
+		t.Errorf("IDなしの Upsert() error = %v, 期待値 = invalid", err)
+	}
+}
+
+// TestTodoRepository_CreateManyAndUpdateMany は一括の作成・更新が全件または0件で反映されることをテストします
+func TestTodoRepository_CreateManyAndUpdateMany(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestRepository()
+
+	created, err := repo.CreateMany(ctx, []*entity.Todo{{Title: "一つ目", IsCompleted: true}, {Title: "二つ目"}})
+	if err != nil || len(created) != 2 || created[0].ID != 1 || created[1].ID != 2 || created[0].IsCompleted {
+		t.Fatalf("CreateMany() = %+v, %v", created, err)
+	}
+
+	first, second := created[0], created[1]
+	first.Title = "変更"
+	if _, err := repo.UpdateMany(ctx, []*entity.Todo{first, {ID: 99, Title: "存在しない"}}); !domainerr.IsNotFound(err) {
+		t.Fatalf("存在しないTodoを含む UpdateMany() error = %v, 期待値 = not found", err)
+	}
+	if got, _ := repo.GetByID(ctx, first.ID); got.Title != "一つ目" {
+		t.Errorf("失敗した一括更新が一部反映されました: %+v", got)
+	}
+
+	second.MarkAsCompleted()
+	updated, err := repo.UpdateMany(ctx, []*entity.Todo{first, second})
+	if err != nil || len(updated) != 2 || updated[0].Title != "変更" || !updated[1].IsCompleted {
+		t.Errorf("UpdateMany() = %+v, %v", updated, err)
+	}
+
+	// トランザクションの上限を超える件数はまとめて書き込めない
+	if _, err := repo.CreateMany(ctx, make([]*entity.Todo, maxTransactItems+1)); !domainerr.IsInvalid(err) {
+		t.Errorf("上限を超える CreateMany() error = %v, 期待値 = invalid", err)
+	}
+}
+
+// TestTodoRepository_UpdateWithLock は他の書き込みが割り込んだ場合に、読み直して変更し直すことをテストします
+func TestTodoRepository_UpdateWithLock(t *testing.T) {
+	ctx := context.Background()
+	repo, api := newTestRepository()
+	created, _ := repo.Create(ctx, &entity.Todo{Title: "実績", ActualMinutes: 10})
+
+	// 最初の書き込みの直前に、他のサーバーが実績時間を更新する
+	api.beforeWrite = func(items map[string]Item) {
+		api.beforeWrite = nil
+		item := items[todoKeyPrefix+"1"]
+		item["actual_minutes"] = Number(30)
+		item["version"] = Number(2)
+	}
+	calls := 0
+	updated, err := repo.UpdateWithLock(ctx, created.ID, func(todo *entity.Todo) error {
+		calls++
+		todo.ActualMinutes += 5
+		return nil
+	})
+	if err != nil || updated.ActualMinutes != 35 || calls != 2 {
+		t.Errorf("UpdateWithLock() = %+v, %v, 呼び出し回数 = %d, 期待値 = 35分・2回", updated, err, calls)
+	}
+	if v := api.version(created.ID); v != 3 {
+		t.Errorf("version = %d, 期待値 = 3", v)
+	}
+
+	// 書き込みが競合し続ける場合はエラーを返す
+	api.beforeWrite = func(items map[string]Item) {
+		v, _ := intAttr(items[todoKeyPrefix+"1"], "version")
+		items[todoKeyPrefix+"1"]["version"] = Number(v + 1)
+	}
+	if _, err := repo.Update(ctx, &entity.Todo{ID: created.ID, Title: "競合"}); !errors.Is(err, errConcurrentModification) {
+		t.Errorf("競合し続ける Update() error = %v, 期待値 = %v", err, errConcurrentModification)
+	}
+	api.beforeWrite = nil
+
+	if _, err := repo.UpdateWithLock(ctx, created.ID, func(todo *entity.Todo) error {
+		todo.Title = "保存されない"
+		return repository.ErrNoChange
+	}); err != nil {
+		t.Fatalf("UpdateWithLock(ErrNoChange) error = %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, created.ID); got.Title != "実績" {
+		t.Errorf("ErrNoChange の変更が保存されています: %+v", got)
+	}
+}
+
+// TestTodoRepository_Aggregates は絞り込み・件数・グループごとの件数・集計をテストします
+func TestTodoRepository_Aggregates(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestRepository()
+	for _, todo := range []*entity.Todo{{Title: "buy milk", Color: "blue"}, {Title: "Apple", Color: "blue", EstimateMinutes: 30}, {Title: "c"}} {
+		if _, err := repo.Create(ctx, todo); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if err := repo.Delete(ctx, 3); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	todos, err := repo.Find(ctx, repository.TodoFilter{Color: "blue"}, entity.TodoSortTitle)
+	if err != nil || len(todos) != 2 || todos[0].Title != "Apple" {
+		t.Errorf("Find() = %+v, %v, 期待値 = Apple, buy milk の順", todos, err)
+	}
+	if got, _ := repo.Count(ctx, repository.TodoFilter{Search: "MILK"}); got != 1 {
+		t.Errorf("Count() = %d, 期待値 = 1", got)
+	}
+	groups, err := repo.CountGrouped(ctx, repository.TodoGroupColor, repository.TodoFilter{})
+	if err != nil || len(groups) != 1 || groups[0] != (repository.TodoGroupCount{Key: "blue", Count: 2}) {
+		t.Errorf("CountGrouped() = %+v, %v", groups, err)
+	}
+	if stats, _ := repo.GetStats(ctx); stats.Total != 2 || stats.Estimates.RemainingMinutes != 30 {
+		t.Errorf("GetStats() = %+v", stats)
+	}
+}
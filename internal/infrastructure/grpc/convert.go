@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/infrastructure/grpc/todopb"
+)
+
+// toProtoTodo はエンティティを gRPC のメッセージに変換します（REST の dto.ToTodoResponse に相当）
+func toProtoTodo(todo *entity.Todo) *todopb.Todo {
+	msg := &todopb.Todo{
+		Id:          int64(todo.ID),
+		Title:       todo.Title,
+		Description: todo.Description,
+		IsCompleted: todo.IsCompleted,
+		CreatedAt:   timestamppb.New(todo.CreatedAt),
+		UpdatedAt:   timestamppb.New(todo.UpdatedAt),
+		ChecklistProgress: &todopb.ChecklistProgress{
+			Total: int32(todo.ChecklistProgress.Total),
+			Done:  int32(todo.ChecklistProgress.Done),
+		},
+		RemindAt:        toProtoTime(todo.RemindAt),
+		DueDate:         toProtoTime(todo.DueDate),
+		Recurrence:      string(todo.Recurrence),
+		Color:           string(todo.Color),
+		EstimateMinutes: int32(todo.EstimateMinutes),
+		ActualMinutes:   int32(todo.ActualMinutes),
+		Tags:            todo.Tags,
+	}
+	if todo.RecurrenceParentID != nil {
+		parentID := int64(*todo.RecurrenceParentID)
+		msg.RecurrenceParentId = &parentID
+	}
+	return msg
+}
+
+// toProtoTodos はエンティティの一覧を gRPC のメッセージに変換します
+func toProtoTodos(todos []*entity.Todo) []*todopb.Todo {
+	msgs := make([]*todopb.Todo, len(todos))
+	for i, todo := range todos {
+		msgs[i] = toProtoTodo(todo)
+	}
+	return msgs
+}
+
+// toProtoStats は集計結果を gRPC のメッセージに変換します
+func toProtoStats(stats entity.TodoStats) *todopb.TodoStats {
+	return &todopb.TodoStats{
+		Total:            int32(stats.Total),
+		Completed:        int32(stats.Completed),
+		Pending:          int32(stats.Pending),
+		RemainingMinutes: int32(stats.Estimates.RemainingMinutes),
+		Compared:         int32(stats.Estimates.Compared),
+		EstimatedMinutes: int32(stats.Estimates.EstimatedMinutes),
+		ActualMinutes:    int32(stats.Estimates.ActualMinutes),
+	}
+}
+
+// toProtoTime は日時を Timestamp に変換します（nil の場合は nil）
+func toProtoTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// fromProtoTime は Timestamp を日時に変換します（nil の場合は nil）
+func fromProtoTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// fromCreateRequest は作成リクエストをエンティティに変換します（REST の dto.CreateTodoRequest.ToEntity に相当）
+func fromCreateRequest(req *todopb.CreateTodoRequest) *entity.Todo {
+	return &entity.Todo{
+		Title:           req.GetTitle(),
+		Description:     req.GetDescription(),
+		DueDate:         fromProtoTime(req.GetDueDate()),
+		Recurrence:      entity.Recurrence(req.GetRecurrence()),
+		Color:           entity.NormalizeColor(req.GetColor()),
+		EstimateMinutes: int(req.GetEstimateMinutes()),
+	}
+}
+
+// applyUpdateRequest は更新リクエストで指定されたフィールドだけをエンティティに反映します
+// optional のフィールドは、指定されなかった場合に現在の値を維持します
+func applyUpdateRequest(todo *entity.Todo, req *todopb.UpdateTodoRequest) {
+	if req.Title != nil {
+		todo.Title = req.GetTitle()
+	}
+	if req.Description != nil {
+		todo.Description = req.GetDescription()
+	}
+	if req.IsCompleted != nil {
+		todo.IsCompleted = req.GetIsCompleted()
+	}
+	switch {
+	case req.GetClearDueDate():
+		todo.DueDate = nil
+	case req.GetDueDate() != nil:
+		todo.DueDate = fromProtoTime(req.GetDueDate())
+	}
+	if req.Recurrence != nil {
+		todo.Recurrence = entity.Recurrence(req.GetRecurrence())
+	}
+	if req.Color != nil {
+		todo.Color = entity.NormalizeColor(req.GetColor())
+	}
+	if req.EstimateMinutes != nil {
+		todo.EstimateMinutes = int(req.GetEstimateMinutes())
+	}
+	if req.ActualMinutes != nil {
+		todo.ActualMinutes = int(req.GetActualMinutes())
+	}
+}
+
+// validateTodo はエンティティのフィールドを検証し、問題があればエラーメッセージを返します
+// REST API のハンドラーと同じメッセージを返すことで、クライアントがどちらを使っても原因が分かるようにします
+func validateTodo(todo *entity.Todo) string {
+	switch {
+	case todo.Title == "":
+		return "title is required"
+	case len(todo.Title) > entity.MaxTitleLength:
+		return fmt.Sprintf("title must be %d characters or less", entity.MaxTitleLength)
+	case len(todo.Description) > entity.MaxDescriptionLength:
+		return fmt.Sprintf("description must be %d characters or less", entity.MaxDescriptionLength)
+	case !todo.Recurrence.IsValid():
+		return fmt.Sprintf("recurrence must be one of %v", entity.Recurrences)
+	case todo.Recurrence != entity.RecurrenceNone && todo.DueDate == nil:
+		return "due_date is required for recurring todos"
+	case !todo.Color.IsValid():
+		return fmt.Sprintf("color must be one of %v or a hex color such as #1e90ff", entity.ColorPalette)
+	case !entity.IsValidMinutes(todo.EstimateMinutes):
+		return fmt.Sprintf("estimate_minutes must be between 0 and %d", entity.MaxEstimateMinutes)
+	case !entity.IsValidMinutes(todo.ActualMinutes):
+		return fmt.Sprintf("actual_minutes must be between 0 and %d", entity.MaxEstimateMinutes)
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+// Package grpc は Todo API の gRPC サーバーです
+//
+// REST API と同じドメインサービス（service.TodoServiceInterface）を使用し、
+// api/proto/todo/v1/todo.proto で定義したサービスを HTTP とは別のポートで公開します
+// メッセージの型（todopb）は protoc で生成したコードです（make proto で再生成）
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/internal/infrastructure/grpc/todopb"
+)
+
+// ActorMetadataKey は操作者（変更履歴の「誰が」）を指定するメタデータのキーです
+// REST API の X-Actor ヘッダーに相当します
+const ActorMetadataKey = "x-actor"
+
+// AuthorizationMetadataKey はアクセストークンを指定するメタデータのキーです（値は "Bearer <access_token>"）
+// REST API の Authorization ヘッダーに相当します
+const AuthorizationMetadataKey = "authorization"
+
+// AccessTokenVerifier はアクセストークンを検証するインターフェースです（service.AuthService が実装します）
+type AccessTokenVerifier interface {
+	VerifyAccessToken(token string) (service.Principal, error)
+}
+
+// readMethods はTodoを変更しないRPCです（スコープを限定したトークンでは todos:read が必要。それ以外は todos:write）
+var readMethods = map[string]bool{
+	todopb.TodoService_GetTodo_FullMethodName:      true,
+	todopb.TodoService_ListTodos_FullMethodName:    true,
+	todopb.TodoService_GetTodoStats_FullMethodName: true,
+}
+
+// Server は TodoService を gRPC で公開するサーバーです
+type Server struct {
+	todopb.UnimplementedTodoServiceServer
+
+	todoService service.TodoServiceInterface
+	server      *grpclib.Server
+}
+
+// NewServer は新しい gRPC サーバーを作成します
+// todoService は REST API と同じインスタンス（パニック復旧などのラッパーを含む）を渡します
+func NewServer(todoService service.TodoServiceInterface, opts ...grpclib.ServerOption) *Server {
+	s := &Server{todoService: todoService}
+
+	opts = append([]grpclib.ServerOption{grpclib.ChainUnaryInterceptor(actorInterceptor, loggingInterceptor)}, opts...)
+	s.server = grpclib.NewServer(opts...)
+	todopb.RegisterTodoServiceServer(s.server, s)
+	return s
+}
+
+// Serve はリスナーでリクエストの受け付けを開始します
+// Shutdown が呼ばれるまでブロックし、正常に停止した場合は nil を返します
+func (s *Server) Serve(lis net.Listener) error {
+	if err := s.server.Serve(lis); err != nil && !errors.Is(err, grpclib.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// ListenAndServe は addr（例: ":9090"）で待ち受けを開始します
+func (s *Server) ListenAndServe(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return s.Serve(lis)
+}
+
+// Shutdown はサーバーをグレースフルに停止します
+// 新しい接続の受け付けを止め、処理中のRPCの完了を待ちます
+// ctx の期限までに完了しない場合は、残りの接続を強制的に閉じてエラーを返します
+func (s *Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// WithAuth は全てのRPCでメタデータの authorization のアクセストークンを検証するオプションです
+// ユーザー認証が有効な場合に指定します。REST API の AuthMiddleware と同じく、検証に成功すると
+// 認証されたユーザー（service.WithPrincipal）と操作者をコンテキストに設定するため、Todoの操作は本人のTodoに限定されます
+//
+// トークンがない・不正・有効期限切れの場合は Unauthenticated、スコープが足りない場合は PermissionDenied を返します
+func WithAuth(verifier AccessTokenVerifier) grpclib.ServerOption {
+	return grpclib.ChainUnaryInterceptor(authInterceptor(verifier))
+}
+
+// authInterceptor はアクセストークンを検証し、認証されたユーザーをコンテキストに設定します
+// 操作者はユーザー名にするため、x-actor で他のユーザーを名乗ることはできません（actorInterceptor より後に実行されます）
+func authInterceptor(verifier AccessTokenVerifier) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(AuthorizationMetadataKey); len(values) > 0 {
+				token, _ = strings.CutPrefix(values[0], "Bearer ")
+			}
+		}
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "access token is required")
+		}
+		principal, err := verifier.VerifyAccessToken(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		scope := entity.ScopeTodosWrite
+		if readMethods[info.FullMethod] {
+			scope = entity.ScopeTodosRead
+		}
+		if !principal.HasScope(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "access token does not have the required scope %q", scope)
+		}
+
+		ctx = service.WithPrincipal(ctx, principal)
+		ctx = service.WithActor(ctx, principal.Username)
+		return handler(ctx, req)
+	}
+}
+
+// actorInterceptor はメタデータの x-actor をコンテキストの操作者に設定します
+func actorInterceptor(ctx context.Context, req any, _ *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ActorMetadataKey); len(values) > 0 && values[0] != "" {
+			ctx = service.WithActor(ctx, values[0])
+		}
+	}
+	return handler(ctx, req)
+}
+
+// loggingInterceptor はRPCごとにメソッド名・ステータスコード・処理時間をログに出力します
+func loggingInterceptor(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s %s", info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+// toStatus はサービスのエラーを gRPC のステータスに変換します
+// REST API のハンドラーと同じ基準でステータスを決めます（404 → NotFound、409 → AlreadyExists、403 → PermissionDenied、504 → DeadlineExceeded 等）
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrDuplicateTitle):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrTodoForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case domainerr.IsNotFound(err):
+		return status.Error(codes.NotFound, "todo not found")
+	case domainerr.IsInvalid(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case domainerr.IsTimeout(err):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
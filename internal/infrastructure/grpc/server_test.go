@@ -0,0 +1,243 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/internal/infrastructure/grpc/todopb"
+	"todoapp-api-golang/internal/infrastructure/memory"
+)
+
+// newTestClient はインメモリのリポジトリを使うサーバーを bufconn で起動し、接続したクライアントを返します
+func newTestClient(t *testing.T, opts ...grpclib.ServerOption) todopb.TodoServiceClient {
+	t.Helper()
+	todoService := service.NewTodoService(memory.NewTodoRepository(), service.WithUniqueTitles())
+	server := NewServer(todoService, opts...)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go server.Serve(lis)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	})
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return todopb.NewTodoServiceClient(conn)
+}
+
+func TestServer_TodoLifecycle(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateTodo(ctx, &todopb.CreateTodoRequest{Title: "牛乳を買う", Color: "Red", EstimateMinutes: 30})
+	if err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+	if created.GetId() == 0 || created.GetColor() != "red" || created.GetCreatedAt() == nil {
+		t.Fatalf("作成したTodoが正しく変換されていません: %v", created)
+	}
+
+	got, err := client.GetTodo(ctx, &todopb.GetTodoRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if got.GetTitle() != "牛乳を買う" {
+		t.Errorf("GetTodo() のタイトルが %q になっています", got.GetTitle())
+	}
+
+	// 指定したフィールドだけが更新され、他のフィールドは維持される
+	updated, err := client.UpdateTodo(ctx, &todopb.UpdateTodoRequest{Id: created.GetId(), ActualMinutes: proto.Int32(45)})
+	if err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	if updated.GetActualMinutes() != 45 || updated.GetTitle() != "牛乳を買う" || updated.GetEstimateMinutes() != 30 {
+		t.Errorf("UpdateTodo() で指定していないフィールドが変わっています: %v", updated)
+	}
+
+	completed, err := client.CompleteTodo(ctx, &todopb.CompleteTodoRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("CompleteTodo() error = %v", err)
+	}
+	if !completed.GetIsCompleted() {
+		t.Error("CompleteTodo() の後も未完了になっています")
+	}
+
+	stats, err := client.GetTodoStats(ctx, &todopb.GetTodoStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetTodoStats() error = %v", err)
+	}
+	if stats.GetTotal() != 1 || stats.GetCompleted() != 1 || stats.GetCompared() != 1 {
+		t.Errorf("GetTodoStats() = %v", stats)
+	}
+
+	if _, err := client.DeleteTodo(ctx, &todopb.DeleteTodoRequest{Id: created.GetId()}); err != nil {
+		t.Fatalf("DeleteTodo() error = %v", err)
+	}
+	if _, err := client.GetTodo(ctx, &todopb.GetTodoRequest{Id: created.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("削除後の GetTodo() のステータスが %v になっています", status.Code(err))
+	}
+}
+
+func TestServer_ListTodos(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	for _, req := range []*todopb.CreateTodoRequest{
+		{Title: "a", Color: "red"},
+		{Title: "b", Color: "blue"},
+		{Title: "c", Color: "red"},
+	} {
+		if _, err := client.CreateTodo(ctx, req); err != nil {
+			t.Fatalf("CreateTodo() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		req       *todopb.ListTodosRequest
+		wantTotal int32
+		wantLen   int
+		wantLimit int32
+	}{
+		{name: "全件", req: &todopb.ListTodosRequest{}, wantTotal: 3, wantLen: 3, wantLimit: 10},
+		{name: "色で絞り込み", req: &todopb.ListTodosRequest{Color: "red"}, wantTotal: 2, wantLen: 2, wantLimit: 10},
+		{name: "2ページ目", req: &todopb.ListTodosRequest{Page: 2, Limit: 2}, wantTotal: 3, wantLen: 1, wantLimit: 2},
+		{name: "範囲外のページ", req: &todopb.ListTodosRequest{Page: 5, Limit: 2}, wantTotal: 3, wantLen: 0, wantLimit: 2},
+		{name: "上限を超える件数は既定値", req: &todopb.ListTodosRequest{Limit: 1000}, wantTotal: 3, wantLen: 3, wantLimit: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.ListTodos(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("ListTodos() error = %v", err)
+			}
+			if resp.GetTotal() != tt.wantTotal || len(resp.GetTodos()) != tt.wantLen || resp.GetLimit() != tt.wantLimit {
+				t.Errorf("ListTodos() = total %d, len %d, limit %d, want %d, %d, %d",
+					resp.GetTotal(), len(resp.GetTodos()), resp.GetLimit(), tt.wantTotal, tt.wantLen, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestServer_ErrorCodes(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateTodo(ctx, &todopb.CreateTodoRequest{Title: "重複"}); err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{name: "タイトルなし", call: func() error {
+			_, err := client.CreateTodo(ctx, &todopb.CreateTodoRequest{})
+			return err
+		}, want: codes.InvalidArgument},
+		{name: "期限のない繰り返し", call: func() error {
+			_, err := client.CreateTodo(ctx, &todopb.CreateTodoRequest{Title: "毎日", Recurrence: "daily"})
+			return err
+		}, want: codes.InvalidArgument},
+		{name: "タイトルの重複", call: func() error {
+			_, err := client.CreateTodo(ctx, &todopb.CreateTodoRequest{Title: "重複"})
+			return err
+		}, want: codes.AlreadyExists},
+		{name: "存在しないID", call: func() error {
+			_, err := client.CompleteTodo(ctx, &todopb.CompleteTodoRequest{Id: 999})
+			return err
+		}, want: codes.NotFound},
+		{name: "不正なID", call: func() error {
+			_, err := client.GetTodo(ctx, &todopb.GetTodoRequest{Id: -1})
+			return err
+		}, want: codes.InvalidArgument},
+		{name: "不正な色での絞り込み", call: func() error {
+			_, err := client.ListTodos(ctx, &todopb.ListTodosRequest{Color: "not-a-color"})
+			return err
+		}, want: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.want {
+				t.Errorf("ステータスが %v になっています（want %v）", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeVerifier はトークンをキーにユーザーを返すテスト用の AccessTokenVerifier です
+type fakeVerifier map[string]service.Principal
+
+func (v fakeVerifier) VerifyAccessToken(token string) (service.Principal, error) {
+	principal, ok := v[token]
+	if !ok {
+		return service.Principal{}, errors.New("invalid access token")
+	}
+	return principal, nil
+}
+
+func TestServer_WithAuth(t *testing.T) {
+	client := newTestClient(t, WithAuth(fakeVerifier{
+		"alice-token":    {UserID: 1, Username: "alice"},
+		"bob-token":      {UserID: 2, Username: "bob"},
+		"readonly-token": {UserID: 1, Username: "alice", Scopes: []string{entity.ScopeTodosRead}},
+	}))
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), AuthorizationMetadataKey, "Bearer "+token)
+	}
+
+	// トークンがない・不正な場合は Unauthenticated
+	if _, err := client.ListTodos(context.Background(), &todopb.ListTodosRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("トークンなしの ListTodos() のコードが %v になっています", status.Code(err))
+	}
+	if _, err := client.ListTodos(withToken("unknown"), &todopb.ListTodosRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("不正なトークンの ListTodos() のコードが %v になっています", status.Code(err))
+	}
+
+	created, err := client.CreateTodo(withToken("alice-token"), &todopb.CreateTodoRequest{Title: "aliceのTodo"})
+	if err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+
+	// 他のユーザーのTodoは見えない
+	if _, err := client.GetTodo(withToken("bob-token"), &todopb.GetTodoRequest{Id: created.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("他のユーザーの GetTodo() のコードが %v になっています", status.Code(err))
+	}
+	list, err := client.ListTodos(withToken("bob-token"), &todopb.ListTodosRequest{})
+	if err != nil {
+		t.Fatalf("ListTodos() error = %v", err)
+	}
+	if len(list.GetTodos()) != 0 {
+		t.Errorf("他のユーザーのTodoが一覧に含まれています: %v", list.GetTodos())
+	}
+
+	// 読み取り専用のトークンでは参照できるが変更できない
+	if _, err := client.GetTodo(withToken("readonly-token"), &todopb.GetTodoRequest{Id: created.GetId()}); err != nil {
+		t.Errorf("読み取り専用トークンの GetTodo() error = %v", err)
+	}
+	if _, err := client.DeleteTodo(withToken("readonly-token"), &todopb.DeleteTodoRequest{Id: created.GetId()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("読み取り専用トークンの DeleteTodo() のコードが %v になっています", status.Code(err))
+	}
+}
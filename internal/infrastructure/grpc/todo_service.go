@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/infrastructure/grpc/todopb"
+)
+
+// ページングの既定値です（REST API の ?page= / ?limit= と同じ）
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+// CreateTodo は新しいTodoを作成します
+func (s *Server) CreateTodo(ctx context.Context, req *todopb.CreateTodoRequest) (*todopb.Todo, error) {
+	todo := fromCreateRequest(req)
+	if msg := validateTodo(todo); msg != "" {
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	created, err := s.todoService.CreateTodo(ctx, todo)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTodo(created), nil
+}
+
+// GetTodo は指定されたIDのTodoを取得します
+func (s *Server) GetTodo(ctx context.Context, req *todopb.GetTodoRequest) (*todopb.Todo, error) {
+	todo, err := s.todoService.GetTodoByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTodo(todo), nil
+}
+
+// ListTodos はTodoの一覧をページ単位で取得します
+// 範囲外の page / limit は REST API と同様に既定値として扱います
+func (s *Server) ListTodos(ctx context.Context, req *todopb.ListTodosRequest) (*todopb.ListTodosResponse, error) {
+	var todos []*entity.Todo
+	var err error
+	if req.GetColor() != "" {
+		color := entity.NormalizeColor(req.GetColor())
+		if !color.IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "color must be one of %v or a hex color such as #1e90ff", entity.ColorPalette)
+		}
+		todos, err = s.todoService.GetTodosByColor(ctx, color)
+	} else {
+		todos, err = s.todoService.GetAllTodos(ctx)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	page := int(req.GetPage())
+	if page <= 0 {
+		page = 1
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+
+	start := min((page-1)*limit, len(todos))
+	end := min(start+limit, len(todos))
+	return &todopb.ListTodosResponse{
+		Todos: toProtoTodos(todos[start:end]),
+		Total: int32(len(todos)),
+		Page:  int32(page),
+		Limit: int32(limit),
+	}, nil
+}
+
+// UpdateTodo は指定されたフィールドだけを更新します（REST API の PATCH に相当）
+func (s *Server) UpdateTodo(ctx context.Context, req *todopb.UpdateTodoRequest) (*todopb.Todo, error) {
+	todo, err := s.todoService.GetTodoByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	applyUpdateRequest(todo, req)
+	if msg := validateTodo(todo); msg != "" {
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	updated, err := s.todoService.UpdateTodo(ctx, todo)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTodo(updated), nil
+}
+
+// DeleteTodo は指定されたIDのTodoを削除します
+func (s *Server) DeleteTodo(ctx context.Context, req *todopb.DeleteTodoRequest) (*todopb.DeleteTodoResponse, error) {
+	if err := s.todoService.DeleteTodo(ctx, int(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &todopb.DeleteTodoResponse{}, nil
+}
+
+// CompleteTodo はTodoを完了にします
+func (s *Server) CompleteTodo(ctx context.Context, req *todopb.CompleteTodoRequest) (*todopb.Todo, error) {
+	todo, err := s.todoService.CompleteTodo(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTodo(todo), nil
+}
+
+// IncompleteTodo はTodoを未完了に戻します
+func (s *Server) IncompleteTodo(ctx context.Context, req *todopb.IncompleteTodoRequest) (*todopb.Todo, error) {
+	todo, err := s.todoService.IncompleteTodo(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoTodo(todo), nil
+}
+
+// GetTodoStats はTodo全体の集計結果を取得します
+func (s *Server) GetTodoStats(ctx context.Context, _ *todopb.GetTodoStatsRequest) (*todopb.TodoStats, error) {
+	stats, err := s.todoService.GetTodoStats(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoStats(stats), nil
+}
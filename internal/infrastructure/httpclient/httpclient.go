@@ -0,0 +1,161 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Config は外部サービスへのHTTPクライアントの設定です
+// Webhook・OAuth・プッシュ通知・Slack連携などはすべてこの設定から作成したクライアントを使用します
+type Config struct {
+	// Timeout は1回の呼び出し全体（再試行を含む）のタイムアウトです
+	Timeout time.Duration
+
+	// DialTimeout はTCP接続確立のタイムアウトです
+	DialTimeout time.Duration
+
+	// TLSHandshakeTimeout はTLSハンドシェイクのタイムアウトです
+	TLSHandshakeTimeout time.Duration
+
+	// MaxIdleConns は全体で保持するアイドル接続の上限です
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost は接続先ホストごとに保持するアイドル接続の上限です
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout はアイドル接続を閉じるまでの時間です
+	IdleConnTimeout time.Duration
+
+	// ProxyURL は経由するプロキシのURLです（空の場合は HTTP_PROXY / HTTPS_PROXY 環境変数に従います）
+	ProxyURL string
+
+	// CAFile は追加で信頼するCA証明書（PEM）のパスです（社内CAなど、空の場合はシステムの証明書のみ）
+	CAFile string
+
+	// MaxRetries は失敗時に再試行する最大回数です（0で再試行しない）
+	// 再試行するのは冪等なリクエストのみです（retry.go を参照）
+	MaxRetries int
+
+	// RetryBackoff は最初の再試行までの待ち時間です（以降は2倍ずつ増えます）
+	RetryBackoff time.Duration
+}
+
+// DefaultConfig は外部サービス呼び出しの標準的な設定を返します
+// http.DefaultClient はタイムアウトがなく、応答しない接続先でgoroutineが滞留するため使用しません
+func DefaultConfig() Config {
+	return Config{
+		Timeout:             10 * time.Second,
+		DialTimeout:         5 * time.Second,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		MaxRetries:          2,
+		RetryBackoff:        200 * time.Millisecond,
+	}
+}
+
+// Factory は共有の接続プールを持つHTTPクライアントを連携先ごとに作成します
+//
+// 学習ポイント：
+// 1. http.Transport（接続プール）はプロセス内で共有し、クライアントごとに作らない
+// 2. http.Client は Transport を包む軽量な値で、連携先ごとに名前を付けてメトリクスを分ける
+// 3. RoundTripper を重ねて再試行と計測を追加する（ミドルウェアと同じ考え方）
+type Factory struct {
+	config    Config
+	transport http.RoundTripper
+	metrics   *Metrics
+}
+
+// NewFactory はFactoryのコンストラクタです
+// プロキシURLやCA証明書が不正な場合はエラーを返します
+func NewFactory(cfg Config) (*Factory, error) {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Factory{
+		config:    cfg,
+		transport: transport,
+		metrics:   NewMetrics(),
+	}, nil
+}
+
+// Client は連携先の名前（"webhook", "slack" など）付きのHTTPクライアントを返します
+// 名前はメトリクスの集計単位です。同じ名前で何度呼び出しても構いません
+func (f *Factory) Client(name string) *http.Client {
+	return &http.Client{
+		Timeout: f.config.Timeout,
+		Transport: &retryTransport{
+			name:       name,
+			next:       f.transport,
+			maxRetries: f.config.MaxRetries,
+			backoff:    f.config.RetryBackoff,
+			metrics:    f.metrics,
+		},
+	}
+}
+
+// Stats は連携先ごとの呼び出し統計を返します
+func (f *Factory) Stats() map[string]Stats {
+	return f.metrics.Snapshot()
+}
+
+// newTransport は設定から共有の http.Transport を作成します
+func newTransport(cfg Config) (*http.Transport, error) {
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url: %q", cfg.ProxyURL)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   cfg.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		ForceAttemptHTTP2:     true,
+	}, nil
+}
+
+// loadCertPool はシステムの証明書に cafile の証明書を加えた証明書プールを作成します
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("invalid ca file: %s (no PEM certificates found)", caFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestFactory は待ち時間を短くしたテスト用のFactoryを作成します
+func newTestFactory(t *testing.T) *Factory {
+	t.Helper()
+
+	cfg := DefaultConfig()
+	cfg.RetryBackoff = time.Millisecond
+	factory, err := NewFactory(cfg)
+	if err != nil {
+		t.Fatalf("Factoryの作成に失敗: %v", err)
+	}
+	return factory
+}
+
+// TestClient_Retry は一時的な失敗の再試行条件をテストします
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		idempotencyKey   string
+		statuses         []int
+		expectedStatus   int
+		expectedAttempts int32
+		expectedFailures int64
+		expectedRetries  int64
+	}{
+		{name: "GETは503の後に成功", method: http.MethodGet, statuses: []int{503, 200}, expectedStatus: 200, expectedAttempts: 2, expectedRetries: 1},
+		{name: "上限まで再試行して失敗", method: http.MethodGet, statuses: []int{502, 502, 502, 502}, expectedStatus: 502, expectedAttempts: 3, expectedFailures: 1, expectedRetries: 2},
+		{name: "POSTは再試行しない", method: http.MethodPost, statuses: []int{503, 200}, expectedStatus: 503, expectedAttempts: 1, expectedFailures: 1},
+		{name: "Idempotency-Key付きのPOSTは再試行", method: http.MethodPost, idempotencyKey: "key-1", statuses: []int{429, 200}, expectedStatus: 200, expectedAttempts: 2, expectedRetries: 1},
+		{name: "500は再試行しない", method: http.MethodGet, statuses: []int{500, 200}, expectedStatus: 500, expectedAttempts: 1, expectedFailures: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				// 再試行時もリクエストボディが送られていること
+				if body, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(body) != "payload" {
+					t.Errorf("%d回目のリクエストボディ = %q", n, body)
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			factory := newTestFactory(t)
+			req, _ := http.NewRequest(tt.method, server.URL, strings.NewReader("payload"))
+			if tt.idempotencyKey != "" {
+				req.Header.Set("Idempotency-Key", tt.idempotencyKey)
+			}
+
+			resp, err := factory.Client("test").Do(req)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", resp.StatusCode, tt.expectedStatus)
+			}
+			if attempts.Load() != tt.expectedAttempts {
+				t.Errorf("送信回数 = %d, 期待値 = %d", attempts.Load(), tt.expectedAttempts)
+			}
+
+			stats := factory.Stats()["test"]
+			if stats.Requests != 1 || stats.Failures != tt.expectedFailures || stats.Retries != tt.expectedRetries {
+				t.Errorf("統計 = %+v, 期待値 = {Requests:1 Failures:%d Retries:%d}", stats, tt.expectedFailures, tt.expectedRetries)
+			}
+		})
+	}
+}
+
+// TestFactory_StatsPerClient は連携先の名前ごとに統計が分かれることをテストします
+func TestFactory_StatsPerClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	factory := newTestFactory(t)
+	for _, name := range []string{"webhook", "webhook", "slack"} {
+		resp, err := factory.Client(name).Get(server.URL)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	stats := factory.Stats()
+	if stats["webhook"].Requests != 2 || stats["slack"].Requests != 1 {
+		t.Errorf("統計 = %+v", stats)
+	}
+}
+
+// TestNewFactory_InvalidConfig は不正な設定でエラーになることをテストします
+func TestNewFactory_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{name: "スキームのないプロキシURL", modify: func(cfg *Config) { cfg.ProxyURL = "proxy.local:8080" }},
+		{name: "存在しないCA証明書", modify: func(cfg *Config) { cfg.CAFile = "/nonexistent/ca.pem" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			if _, err := NewFactory(cfg); err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+// TestRetryAfter は Retry-After ヘッダーの解釈をテストします
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: 0},
+		{value: "2", expected: 2 * time.Second},
+		{value: "3600", expected: maxRetryAfter},
+		{value: "invalid", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{"Retry-After": []string{tt.value}}}
+			if got := retryAfter(resp); got != tt.expected {
+				t.Errorf("retryAfter() = %v, 期待値 = %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// errServerError は 5xx のレスポンスを失敗として記録するための内部エラーです
+var errServerError = errors.New("server error response")
+
+// Stats は連携先ごとの外部呼び出しの統計です
+type Stats struct {
+	// Requests は呼び出し回数です（再試行は含みません）
+	Requests int64 `json:"requests"`
+
+	// Failures はネットワークエラーまたは 5xx で終わった呼び出しの回数です
+	Failures int64 `json:"failures"`
+
+	// Retries は再試行した回数の合計です
+	Retries int64 `json:"retries"`
+
+	// TotalDuration は呼び出しにかかった時間の合計です（再試行の待ち時間を含みます）
+	TotalDuration time.Duration `json:"total_duration"`
+}
+
+// AverageDuration は1回の呼び出しにかかった平均時間を返します
+func (s Stats) AverageDuration() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Requests)
+}
+
+// Metrics は外部呼び出しの統計を連携先ごとに集計します
+// 複数のgoroutineから同時に記録されるため、Mutexで保護します
+type Metrics struct {
+	mu    sync.Mutex
+	stats map[string]*Stats
+}
+
+// NewMetrics はMetricsのコンストラクタです
+func NewMetrics() *Metrics {
+	return &Metrics{
+		stats: make(map[string]*Stats),
+	}
+}
+
+// record は1回の呼び出し結果を記録します
+func (m *Metrics) record(name string, duration time.Duration, retries int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.stats[name]
+	if !ok {
+		s = &Stats{}
+		m.stats[name] = s
+	}
+	s.Requests++
+	s.Retries += int64(retries)
+	s.TotalDuration += duration
+	if err != nil {
+		s.Failures++
+	}
+}
+
+// Snapshot は現時点の統計のコピーを返します
+func (m *Metrics) Snapshot() map[string]Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]Stats, len(m.stats))
+	for name, s := range m.stats {
+		snapshot[name] = *s
+	}
+	return snapshot
+}
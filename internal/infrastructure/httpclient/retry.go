@@ -0,0 +1,119 @@
+package httpclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryAfter は Retry-After ヘッダーに従って待つ時間の上限です
+const maxRetryAfter = 30 * time.Second
+
+// retryTransport は一時的な失敗を再試行し、呼び出しをメトリクスに記録する RoundTripper です
+//
+// 再試行の条件：
+//   - 冪等なメソッド（GET, HEAD, OPTIONS, PUT, DELETE）か、Idempotency-Key ヘッダー付きのリクエスト
+//   - ネットワークエラー、または 429 / 502 / 503 / 504 のレスポンス
+//
+// POST を無条件に再試行すると、相手側で処理が二重に実行される可能性があるため対象外です
+type retryTransport struct {
+	name       string
+	next       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+	metrics    *Metrics
+}
+
+// RoundTrip はリクエストを送信し、条件を満たす場合は待ち時間を増やしながら再試行します
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	retries := 0
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		resp, err = t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !isRetryable(req, resp, err) {
+			break
+		}
+
+		// 2回目以降はリクエストボディを読み直す必要がある
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				break
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				break
+			}
+			retryReq := req.Clone(req.Context())
+			retryReq.Body = body
+			req = retryReq
+		}
+
+		wait := t.backoff << attempt
+		if resp != nil {
+			wait = max(wait, retryAfter(resp))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			t.metrics.record(t.name, time.Since(start), retries, req.Context().Err())
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		retries++
+	}
+
+	recordErr := err
+	if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+		recordErr = errServerError
+	}
+	t.metrics.record(t.name, time.Since(start), retries, recordErr)
+	return resp, err
+}
+
+// isRetryable は再試行してよい失敗かどうかを判定します
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if !isIdempotent(req) {
+		return false
+	}
+	if err != nil {
+		// キャンセルやタイムアウトで打ち切られた場合は再試行しない
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent は同じリクエストを複数回送っても結果が変わらないかどうかを判定します
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryAfter は Retry-After ヘッダー（秒数またはHTTP日付）の待ち時間を返します
+// ヘッダーがない・解釈できない場合は 0 を返し、上限は maxRetryAfter です
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = time.Until(at)
+	}
+	return min(max(wait, 0), maxRetryAfter)
+}
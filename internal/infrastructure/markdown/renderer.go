@@ -0,0 +1,246 @@
+// Package markdown はTodoの説明（Markdown）を安全なHTMLに変換する MarkdownRenderer の実装です
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"todoapp-api-golang/internal/application/handler"
+)
+
+// safeSchemes はリンクとして出力するURLのスキームです
+// javascript: や data: のようにブラウザでスクリプトを実行できるURLはリンクにしません
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	ruleLinePattern    = regexp.MustCompile(`^\s*([-*_])(\s*([-*_]))*\s*$`)
+	unorderedPattern   = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	orderedPattern     = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	fencePattern       = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	blockquotePattern  = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	continuationIndent = regexp.MustCompile(`^\s{2,}\S`)
+)
+
+// Renderer はMarkdownのサブセットをHTMLに変換します
+//
+// 学習ポイント：
+//  1. 入力の文字は全てエスケープし、HTMLのタグはこのレンダラーが生成したもの（許可リスト）だけにする
+//     そのため説明に書かれた <script> 等のHTMLはタグとして解釈されず、文字として表示される
+//  2. リンクのURLはスキームを検証し、http / https / mailto と相対URL以外はリンクにしない
+//  3. 「変換してから危険なタグを取り除く」のではなく「安全なものだけを組み立てる」ほうが漏れが起きにくい
+//
+// 対応する記法：
+//   - ブロック: 段落、見出し（#）、箇条書き（- * +）、番号付きリスト（1.）、引用（>）、コードブロック（```）、水平線（---）
+//   - インライン: 強調（**太字** / *斜体*）、コード（`code`）、リンク（[text](url)）、バックスラッシュによるエスケープ
+type Renderer struct{}
+
+// NewRenderer はRendererのコンストラクタです
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// Render はMarkdownを安全なHTMLに変換します
+func (r *Renderer) Render(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	return strings.Join(renderBlocks(strings.Split(source, "\n")), "\n")
+}
+
+// renderBlocks は行の並びをブロック要素のHTMLに変換します
+func renderBlocks(lines []string) []string {
+	var blocks []string
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, "<p>"+renderInline(strings.Join(paragraph, "\n"))+"</p>")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case fencePattern.MatchString(line):
+			flush()
+			fence := fencePattern.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, "<pre><code>"+html.EscapeString(strings.Join(code, "\n"))+"</code></pre>")
+
+		case headingPattern.MatchString(line):
+			flush()
+			m := headingPattern.FindStringSubmatch(line)
+			tag := "h" + strconv.Itoa(len(m[1]))
+			blocks = append(blocks, "<"+tag+">"+renderInline(m[2])+"</"+tag+">")
+
+		case isRule(line):
+			flush()
+			blocks = append(blocks, "<hr>")
+
+		case blockquotePattern.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && blockquotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, blockquotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			blocks = append(blocks, "<blockquote>\n"+strings.Join(renderBlocks(quoted), "\n")+"\n</blockquote>")
+
+		case unorderedPattern.MatchString(line), orderedPattern.MatchString(line):
+			flush()
+			pattern, tag := unorderedPattern, "ul"
+			if orderedPattern.MatchString(line) {
+				pattern, tag = orderedPattern, "ol"
+			}
+			var items []string
+			for ; i < len(lines); i++ {
+				if m := pattern.FindStringSubmatch(lines[i]); m != nil {
+					items = append(items, m[1])
+				} else if len(items) > 0 && continuationIndent.MatchString(lines[i]) {
+					// インデントされた行は直前の項目の続きとして扱う
+					items[len(items)-1] += "\n" + strings.TrimSpace(lines[i])
+				} else {
+					break
+				}
+			}
+			i--
+			var list strings.Builder
+			list.WriteString("<" + tag + ">\n")
+			for _, item := range items {
+				list.WriteString("<li>" + renderInline(item) + "</li>\n")
+			}
+			list.WriteString("</" + tag + ">")
+			blocks = append(blocks, list.String())
+
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flush()
+	return blocks
+}
+
+// isRule は行が水平線（同じ記号 - * _ の3つ以上の並び）かどうかを判定します
+func isRule(line string) bool {
+	if !ruleLinePattern.MatchString(line) {
+		return false
+	}
+	marks := strings.Join(strings.Fields(line), "")
+	return len(marks) >= 3 && strings.Count(marks, marks[:1]) == len(marks)
+}
+
+// renderInline は1つのブロック内のテキストのインライン記法をHTMLに変換します
+// 記法として解釈しなかった文字は全てエスケープして出力します
+func renderInline(text string) string {
+	var out strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		// バックスラッシュの後の記号は記法として解釈しない
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_[]()#+-.!>", text[i+1]) >= 0:
+			out.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				out.WriteString("<code>" + html.EscapeString(text[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			delim := rest[:2]
+			if end := strings.Index(text[i+2:], delim); end > 0 && isEmphasis(text[i+2:i+2+end]) {
+				out.WriteString("<strong>" + renderInline(text[i+2:i+2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case c == '*' || c == '_':
+			// snake_case のような単語中の _ は強調として扱わない
+			if c == '_' && i > 0 && isWordByte(text[i-1]) {
+				break
+			}
+			if end := strings.IndexByte(text[i+1:], c); end > 0 && isEmphasis(text[i+1:i+1+end]) {
+				out.WriteString("<em>" + renderInline(text[i+1:i+1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+
+		case c == '[':
+			if label, href, n, ok := parseLink(rest); ok {
+				if isSafeURL(href) {
+					out.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">` + renderInline(label) + "</a>")
+				} else {
+					out.WriteString(renderInline(label))
+				}
+				i += n
+				continue
+			}
+		}
+
+		out.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+	return out.String()
+}
+
+// parseLink は "[label](url)" 形式のリンクを解析し、ラベル・URL・消費したバイト数を返します
+func parseLink(text string) (label, href string, n int, ok bool) {
+	closeLabel := strings.Index(text, "](")
+	if closeLabel < 0 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(text[closeLabel+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	label = text[1:closeLabel]
+	href = strings.TrimSpace(text[closeLabel+2 : closeLabel+2+closeURL])
+	if label == "" || href == "" || strings.ContainsAny(href, " \n") {
+		return "", "", 0, false
+	}
+	return label, href, closeLabel + 2 + closeURL + 1, true
+}
+
+// isSafeURL はリンクとして出力してよいURLかどうかを判定します
+// スキームのない相対URL（/todos/1 や #section）と、safeSchemes のスキームのURLだけを許可します
+func isSafeURL(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// スキームとして解釈されなかった ":" を含む値は、ブラウザとの解釈の違いを避けるためリンクにしない
+		return !strings.Contains(href, ":")
+	}
+	return safeSchemes[strings.ToLower(u.Scheme)]
+}
+
+// isEmphasis は強調の内側のテキストとして妥当かどうかを判定します
+// "2 * 3 * 4" のように記号の内側が空白で始まる・終わる場合は強調として扱いません
+func isEmphasis(inner string) bool {
+	return strings.TrimSpace(inner) == inner
+}
+
+// isWordByte は英数字かどうかを判定します
+func isWordByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// コンパイル時インターフェース実装確認
+var _ handler.MarkdownRenderer = (*Renderer)(nil)
@@ -0,0 +1,74 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderer_Render はMarkdownの変換をテストします
+func TestRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "段落", input: "一行目\n二行目\n\n次の段落", expected: "<p>一行目\n二行目</p>\n<p>次の段落</p>"},
+		{name: "見出し", input: "## 準備 ##", expected: "<h2>準備</h2>"},
+		{name: "見出しの中の #", input: "# C#", expected: "<h1>C#</h1>"},
+		{name: "強調", input: "**太字** と *斜体* と __太字__", expected: "<p><strong>太字</strong> と <em>斜体</em> と <strong>太字</strong></p>"},
+		{name: "空白で囲まれた * は強調ではない", input: "2 * 3 * 4", expected: "<p>2 * 3 * 4</p>"},
+		{name: "単語中の _ は強調ではない", input: "snake_case_name", expected: "<p>snake_case_name</p>"},
+		{name: "コード", input: "`<br>` を使う", expected: "<p><code>&lt;br&gt;</code> を使う</p>"},
+		{name: "コードブロック", input: "```go\nfmt.Println(\"<a>\")\n```", expected: "<pre><code>fmt.Println(&#34;&lt;a&gt;&#34;)</code></pre>"},
+		{name: "箇条書き", input: "- 牛乳\n- パン\n  （全粒粉）", expected: "<ul>\n<li>牛乳</li>\n<li>パン\n（全粒粉）</li>\n</ul>"},
+		{name: "番号付きリスト", input: "1. 準備\n2. 実行", expected: "<ol>\n<li>準備</li>\n<li>実行</li>\n</ol>"},
+		{name: "引用", input: "> **注意**\n> 期限厳守", expected: "<blockquote>\n<p><strong>注意</strong>\n期限厳守</p>\n</blockquote>"},
+		{name: "水平線", input: "上\n\n---\n\n下", expected: "<p>上</p>\n<hr>\n<p>下</p>"},
+		{name: "リンク", input: "[仕様](https://example.com/a?b=1&c=2)", expected: `<p><a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener noreferrer">仕様</a></p>`},
+		{name: "相対URLのリンク", input: "[Todo](/todos/1)", expected: `<p><a href="/todos/1" rel="nofollow noopener noreferrer">Todo</a></p>`},
+		{name: "バックスラッシュのエスケープ", input: `\*記号\*`, expected: "<p>*記号*</p>"},
+		{name: "空の入力", input: "", expected: ""},
+	}
+
+	renderer := NewRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderer.Render(tt.input); got != tt.expected {
+				t.Errorf("Render(%q) = %q, 期待値 = %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRenderer_Render_Sanitize は危険なHTMLが出力されないことをテストします
+func TestRenderer_Render_Sanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "scriptタグ", input: "<script>alert(1)</script>", expected: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{name: "イベント属性", input: `<img src=x onerror="alert(1)">`, expected: "<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>"},
+		{name: "javascript: のリンク", input: "[押す](javascript:alert(1))", expected: "<p>押す)</p>"},
+		{name: "大文字の JavaScript: のリンク", input: "[押す](JavaScript:alert)", expected: "<p>押す</p>"},
+		{name: "data: のリンク", input: "[押す](data:text/html;base64,PHNjcmlwdD4=)", expected: "<p>押す</p>"},
+		{name: "リンクのURLでの属性の挿入", input: `[a](https://example.com/"onmouseover="alert(1))`, expected: `<p><a href="https://example.com/&#34;onmouseover=&#34;alert(1" rel="nofollow noopener noreferrer">a</a>)</p>`},
+		{name: "リンクのラベル内のHTML", input: "[<b>太字</b>](https://example.com)", expected: `<p><a href="https://example.com" rel="nofollow noopener noreferrer">&lt;b&gt;太字&lt;/b&gt;</a></p>`},
+		{name: "見出し内のHTML", input: "# <iframe>", expected: "<h1>&lt;iframe&gt;</h1>"},
+	}
+
+	renderer := NewRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderer.Render(tt.input)
+			if got != tt.expected {
+				t.Errorf("Render(%q) = %q, 期待値 = %q", tt.input, got, tt.expected)
+			}
+			for _, forbidden := range []string{"<script", "<img", "<iframe", "javascript:", "JavaScript:", "data:"} {
+				if strings.Contains(got, forbidden) {
+					t.Errorf("出力に %q が含まれています: %s", forbidden, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// idempotencyKey は記録を区別するキー（操作者とキーの組）です
+type idempotencyKey struct {
+	actor string
+	key   string
+}
+
+// idempotencyRepository はメモリ上に Idempotency-Key の記録を保持する repository.IdempotencyRepository の実装です
+type idempotencyRepository struct {
+	mu      sync.Mutex
+	records map[idempotencyKey]*entity.IdempotencyRecord
+}
+
+// NewIdempotencyRepository はメモリ上のIdempotencyRepositoryを作成します
+func NewIdempotencyRepository() repository.IdempotencyRepository {
+	return &idempotencyRepository{
+		records: make(map[idempotencyKey]*entity.IdempotencyRecord),
+	}
+}
+
+// Create は処理中の記録を保存します（同じ操作者・キーの記録がある場合は ErrIdempotencyKeyExists）
+func (r *idempotencyRepository) Create(ctx context.Context, record *entity.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := idempotencyKey{actor: record.Actor, key: record.Key}
+	if _, exists := r.records[k]; exists {
+		return repository.ErrIdempotencyKeyExists
+	}
+	r.records[k] = copyIdempotencyRecord(record)
+	return nil
+}
+
+// Get は操作者・キーで記録を取得します
+func (r *idempotencyRepository) Get(ctx context.Context, actor, key string) (*entity.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.records[idempotencyKey{actor: actor, key: key}]
+	if !exists {
+		return nil, domainerr.NotFound("idempotency record", nil)
+	}
+	return copyIdempotencyRecord(record), nil
+}
+
+// Complete は記録にレスポンスを保存します
+func (r *idempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.records[idempotencyKey{actor: record.Actor, key: record.Key}]
+	if !exists {
+		return domainerr.NotFound("idempotency record", nil)
+	}
+	stored.StatusCode = record.StatusCode
+	stored.Header = maps.Clone(record.Header)
+	stored.Body = append([]byte(nil), record.Body...)
+	return nil
+}
+
+// Delete は記録を削除します
+func (r *idempotencyRepository) Delete(ctx context.Context, actor, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := idempotencyKey{actor: actor, key: key}
+	if _, exists := r.records[k]; !exists {
+		return domainerr.NotFound("idempotency record", nil)
+	}
+	delete(r.records, k)
+	return nil
+}
+
+// DeleteExpired は有効期限が before 以前の記録を削除します
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for k, record := range r.records {
+		if !record.ExpiresAt.After(before) {
+			delete(r.records, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// copyIdempotencyRecord は保存内容が呼び出し側から変更されないよう、記録をコピーします
+func copyIdempotencyRecord(record *entity.IdempotencyRecord) *entity.IdempotencyRecord {
+	c := *record
+	c.Header = maps.Clone(record.Header)
+	c.Body = append([]byte(nil), record.Body...)
+	return &c
+}
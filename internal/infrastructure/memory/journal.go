@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// journal はTodoの変更を1行1件のJSON（JSON Lines）で追記するファイルです
+//
+// 学習ポイント：
+//  1. 変更をメモリに反映する前にファイルへ追記して fsync するため、プロセスが異常終了しても書き込みの完了を返した変更は失われない
+//  2. 1回の書き込み（一括作成・一括更新を含む）を1行にまとめるため、途中で終了しても一部だけが反映されることはない
+//  3. 起動時に全ての行を読み直してメモリ上の状態を復元し、現在の状態だけの1行に書き直して（コンパクション）ファイルの肥大化を防ぐ
+//
+// 外部のデータベースやCGOを必要としないため、1つのバイナリだけで動かす環境（エッジデバイス等）向けです
+// bbolt 等の組み込みのキー・バリューストアは、標準パッケージだけで実装する方針（CLAUDE.md）とオフラインでのビルドのため採用していません
+type journal struct {
+	path string
+	file *os.File
+
+	// lock は他のプロセスが同じジャーナルを開かないように掛けるロック（path + ".lock"）です
+	lock io.Closer
+
+	// size は最後に追記を完了した時点のファイルの大きさです（追記に失敗した場合に、書きかけの行を切り捨てるため）
+	size int64
+}
+
+// ErrJournalLocked は他のプロセス（または同じプロセスの別の OpenTodoJournal）が開いているジャーナルを開こうとした場合のエラーです
+var ErrJournalLocked = errors.New("journal is already opened by another process")
+
+// journalRecord はジャーナルの1行（1回の書き込み）です
+type journalRecord struct {
+	// NextID は書き込み後に次に採番するIDです（完全に削除したTodoのIDを再起動後に再利用しないため）
+	NextID int `json:"next_id"`
+
+	// Put は保存したTodoです
+	Put []journalTodo `json:"put,omitempty"`
+
+	// Delete は完全に削除したTodoのIDです
+	Delete []int `json:"delete,omitempty"`
+}
+
+// journalTodo はTodoと、JSONに出力されない所有者・ワークスペースです
+type journalTodo struct {
+	*entity.Todo
+	UserID      *int `json:"user_id,omitempty"`
+	WorkspaceID *int `json:"workspace_id,omitempty"`
+}
+
+// journalTodos はTodoをジャーナルに書き込む形式にします
+func journalTodos(todos []*entity.Todo) []journalTodo {
+	records := make([]journalTodo, len(todos))
+	for i, todo := range todos {
+		records[i] = journalTodo{Todo: todo, UserID: todo.UserID, WorkspaceID: todo.WorkspaceID}
+	}
+	return records
+}
+
+// OpenTodoJournal は path のジャーナルから復元したTodoを保持し、以降の変更を同じファイルに追記するTodoRepositoryを作成します
+// ファイルがない場合は空の状態から作成します。終了時は Close でファイルを閉じてください
+//
+// 最後の行が書き込みの途中で終了して壊れている場合は、その行（完了を返していない書き込み）を捨てて起動します
+// 1つのファイルを複数のプロセスで同時に開くことはできません（ロックファイル path + ".lock" で確認し、開いている場合は ErrJournalLocked を返します）
+func OpenTodoJournal(path string) (repository.TodoRepository, io.Closer, error) {
+	r, err := openTodoJournal(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, r.journal, nil
+}
+
+func openTodoJournal(path string) (*todoRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	// コンパクションはファイルを置き換えるため、ジャーナル自体ではなく別のロックファイルをロックする
+	lock, err := lockJournal(path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	r, err := openLockedJournal(path)
+	if err != nil {
+		lock.Close()
+		return nil, err
+	}
+	r.journal.lock = lock
+	return r, nil
+}
+
+// openLockedJournal はロックを取得したジャーナルを復元し、追記用に開きます
+func openLockedJournal(path string) (*todoRepository, error) {
+	r := newTodoRepository()
+	if err := r.replay(path); err != nil {
+		return nil, err
+	}
+
+	// 現在の状態だけの1行に書き直してから、追記用に開き直す
+	if err := r.compact(path); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	r.journal = &journal{path: path, file: file, size: info.Size()}
+	return r, nil
+}
+
+// replay はジャーナルの全ての行を順に適用します（ファイルがない場合は何もしません）
+func (r *todoRepository) replay(path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// 改行で終わらない最後の行は、書き込みの途中で終了したもの（完了を返していない）として捨てる
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read journal: %w", err)
+		}
+
+		var record journalRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			if _, peekErr := reader.Peek(1); errors.Is(peekErr, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("corrupted journal %s at line %d: %w", path, line, err)
+		}
+		r.apply(record)
+	}
+}
+
+// apply はジャーナルの1行をメモリ上の状態に反映します
+func (r *todoRepository) apply(record journalRecord) {
+	for _, put := range record.Put {
+		if put.Todo == nil {
+			continue
+		}
+		todo := copyTodo(put.Todo)
+		todo.UserID = copyInt(put.UserID)
+		todo.WorkspaceID = copyInt(put.WorkspaceID)
+		r.todos[todo.ID] = todo
+		if todo.ID >= r.nextID {
+			r.nextID = todo.ID + 1
+		}
+	}
+	for _, id := range record.Delete {
+		delete(r.todos, id)
+	}
+	if record.NextID > r.nextID {
+		r.nextID = record.NextID
+	}
+}
+
+// compact は現在の全てのTodo（削除済みを含む）を1行にしたファイルで path を置き換えます
+// 書き込み途中で終了しても元のファイルが壊れないよう、一時ファイルに書き込んでから置き換えます
+func (r *todoRepository) compact(path string) error {
+	todos := make([]*entity.Todo, 0, len(r.todos))
+	for _, todo := range r.todos {
+		todos = append(todos, todo)
+	}
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID < todos[j].ID })
+	data, err := encodeRecord(journalRecord{NextID: r.nextID, Put: journalTodos(todos)})
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, data); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+	return nil
+}
+
+// append は1回の書き込みをジャーナルに追記し、ディスクに書き込まれるまで待ちます
+// 失敗した場合は書きかけの行を切り捨て、次の追記が壊れた行の後ろに続かないようにします
+func (j *journal) append(record journalRecord) error {
+	data, err := encodeRecord(record)
+	if err != nil {
+		return err
+	}
+	_, err = j.file.Write(data)
+	if err == nil {
+		err = j.file.Sync()
+	}
+	if err != nil {
+		_ = j.file.Truncate(j.size)
+		return fmt.Errorf("failed to write journal %s: %w", j.path, err)
+	}
+	j.size += int64(len(data))
+	return nil
+}
+
+// Close はジャーナルのファイルを閉じ、ロックを解放します（io.Closer の実装）
+func (j *journal) Close() error {
+	err := j.file.Close()
+	if j.lock != nil {
+		if lockErr := j.lock.Close(); err == nil {
+			err = lockErr
+		}
+	}
+	return err
+}
+
+// encodeRecord はジャーナルの1行を改行付きのJSONにします
+func encodeRecord(record journalRecord) ([]byte, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode journal record: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// writeFileSync は data をファイルに書き込み、ディスクに書き込まれるまで待ちます
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,35 @@
+//go:build !unix
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// lockJournal は path のロックファイルを新規に作成して、他のプロセスが同じジャーナルを開けないようにします
+// flock のない環境向けのため、プロセスが異常終了した場合はロックファイルが残ります（他に開いているプロセスがないことを確認して削除してください）
+func lockJournal(path string) (io.Closer, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("%w: %s", ErrJournalLocked, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock journal: %w", err)
+	}
+	return &lockFile{file: file, path: path}, nil
+}
+
+// lockFile は閉じるときに削除するロックファイルです
+type lockFile struct {
+	file *os.File
+	path string
+}
+
+// Close はロックファイルを閉じて削除し、ロックを解放します（io.Closer の実装）
+func (l *lockFile) Close() error {
+	l.file.Close()
+	return os.Remove(l.path)
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// lockJournal は path のロックファイルに排他的なロック（flock）を掛けます
+// ロックはプロセスが異常終了した場合もOSが解放するため、ロックファイルが残っていても次の起動は妨げません
+func lockJournal(path string) (io.Closer, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal lock: %w", err)
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%w: %s", ErrJournalLocked, path)
+		}
+		return nil, fmt.Errorf("failed to lock journal: %w", err)
+	}
+	// ファイルを閉じるとロックも解放される
+	return file, nil
+}
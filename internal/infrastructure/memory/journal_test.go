@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestOpenTodoJournal は変更がファイルに追記され、開き直すと同じ状態に復元されることをテストします
+func TestOpenTodoJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "todos.journal")
+	ctx := repository.WithWorkspace(repository.WithOwner(context.Background(), 7), 3)
+
+	repo, closer, err := OpenTodoJournal(path)
+	if err != nil {
+		t.Fatalf("OpenTodoJournal() error = %v", err)
+	}
+	created, _ := repo.CreateMany(ctx, []*entity.Todo{{Title: "牛乳を買う", Tags: []string{"shop"}}, {Title: "請求書を送る"}, {Title: "削除する"}})
+	created[0].MarkAsCompleted()
+	if _, err := repo.Update(ctx, created[0]); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repo.Delete(ctx, created[1].ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.HardDelete(ctx, created[2].ID); err != nil {
+		t.Fatalf("HardDelete() error = %v", err)
+	}
+	if err := closer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	repo, closer, err = OpenTodoJournal(path)
+	if err != nil {
+		t.Fatalf("開き直した OpenTodoJournal() error = %v", err)
+	}
+	defer closer.Close()
+
+	got, err := repo.GetByID(ctx, created[0].ID)
+	if err != nil || !got.IsCompleted || len(got.Tags) != 1 || *got.UserID != 7 || *got.WorkspaceID != 3 || !got.CreatedAt.Equal(created[0].CreatedAt) {
+		t.Errorf("復元したTodo = %+v, %v", got, err)
+	}
+	if trash, _ := repo.GetDeleted(ctx); len(trash) != 1 || trash[0].ID != created[1].ID {
+		t.Errorf("復元した削除済みの一覧 = %+v", trash)
+	}
+	if _, err := repo.GetByID(repository.WithOwner(context.Background(), 8), created[0].ID); err == nil {
+		t.Error("復元後に他のユーザーのTodoを取得できました")
+	}
+
+	// 完全に削除したTodoのIDは再利用しない
+	if next, _ := repo.Create(ctx, &entity.Todo{Title: "次のTodo"}); next.ID != 4 {
+		t.Errorf("次に作成したTodoのID = %d, 期待値 = 4", next.ID)
+	}
+
+	// 開き直すと現在の状態だけの1行に書き直される（その後の作成で1行追記）
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("ジャーナルの行数 = %d, 期待値 = 2", lines)
+	}
+}
+
+// TestOpenTodoJournal_Corrupted は書きかけの最後の行を捨て、途中の壊れた行はエラーにすることをテストします
+func TestOpenTodoJournal_Corrupted(t *testing.T) {
+	dir := t.TempDir()
+	valid := `{"next_id":2,"put":[{"id":1,"title":"残るTodo","created_at":"2024-05-01T09:00:00Z","updated_at":"2024-05-01T09:00:00Z","user_id":7}]}` + "\n"
+
+	truncated := filepath.Join(dir, "truncated.journal")
+	if err := os.WriteFile(truncated, []byte(valid+`{"next_id":3,"put":[{"id":2,"ti`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	repo, closer, err := OpenTodoJournal(truncated)
+	if err != nil {
+		t.Fatalf("書きかけの行がある OpenTodoJournal() error = %v", err)
+	}
+	defer closer.Close()
+	if todos, _ := repo.GetAll(context.Background()); len(todos) != 1 || todos[0].Title != "残るTodo" || *todos[0].UserID != 7 {
+		t.Errorf("復元した一覧 = %+v, 期待値 = 書き込みを完了した1件のみ", todos)
+	}
+
+	corrupted := filepath.Join(dir, "corrupted.journal")
+	if err := os.WriteFile(corrupted, []byte("not json\n"+valid), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := OpenTodoJournal(corrupted); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("壊れた行がある OpenTodoJournal() error = %v, 期待値 = line 1 のエラー", err)
+	}
+}
+
+// TestOpenTodoJournal_Locked は開いているジャーナルを別に開けず、閉じた後は開けることをテストします
+func TestOpenTodoJournal_Locked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todos.journal")
+
+	_, closer, err := OpenTodoJournal(path)
+	if err != nil {
+		t.Fatalf("OpenTodoJournal() error = %v", err)
+	}
+	if _, _, err := OpenTodoJournal(path); !errors.Is(err, ErrJournalLocked) {
+		t.Errorf("開いているジャーナルの OpenTodoJournal() error = %v, 期待値 = ErrJournalLocked", err)
+	}
+	if err := closer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	_, closer, err = OpenTodoJournal(path)
+	if err != nil {
+		t.Fatalf("閉じた後の OpenTodoJournal() error = %v", err)
+	}
+	closer.Close()
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// seedTitles はダミーデータのタイトルの候補です
+var seedTitles = []string{
+	"請求書を送る", "週次レポートを作成する", "歯医者の予約を取る", "牛乳を買う", "デザインレビューに参加する",
+	"経費精算を提出する", "プレゼン資料を仕上げる", "ジムに行く", "部屋の掃除をする", "誕生日プレゼントを選ぶ",
+	"本を返却する", "PRをレビューする", "ミーティングの議事録を共有する", "航空券を予約する", "観葉植物に水をやる",
+	"確定申告の書類を集める", "新人研修の準備をする", "バックアップを確認する", "車検の予約をする", "ブログ記事を書く",
+}
+
+// seedDescriptions はダミーデータの説明の候補です（空文字は説明なし）
+var seedDescriptions = []string{
+	"", "", "先方の担当者にも共有する", "金曜日までに終わらせる", "詳細はチャットのスレッドを参照",
+	"前回の指摘事項を反映する", "忘れずに領収書を添付する", "",
+}
+
+// SeedTodos はフロントエンドの開発用に、それらしいダミーのTodoを n 件作成します
+//
+// 期限・色・見積もり・完了状態・繰り返しは rng でばらつかせるため、同じシードを使えば毎回同じデータになります
+// 期限は now の2週間前〜4週間後に分布し、期限切れ・今日・今後のビューにもデータが入ります
+func SeedTodos(ctx context.Context, repo repository.TodoRepository, n int, rng *rand.Rand, now time.Time) error {
+	for i := 0; i < n; i++ {
+		todo := &entity.Todo{
+			Title:       seedTitles[rng.Intn(len(seedTitles))],
+			Description: seedDescriptions[rng.Intn(len(seedDescriptions))],
+		}
+
+		// 7割のTodoに期限を設定する（9:00〜18:00 の正時）
+		if rng.Float64() < 0.7 {
+			day := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, rng.Intn(42)-14)
+			due := day.Add(time.Duration(9+rng.Intn(10)) * time.Hour)
+			todo.DueDate = &due
+
+			if rng.Float64() < 0.1 {
+				todo.Recurrence = entity.Recurrences[rng.Intn(len(entity.Recurrences))]
+			}
+		}
+
+		// 半分程度のTodoに色を付ける
+		if rng.Float64() < 0.5 {
+			todo.Color = entity.ColorPalette[rng.Intn(len(entity.ColorPalette))]
+		}
+
+		// 4割のTodoに15分単位の見積もりを設定する
+		if rng.Float64() < 0.4 {
+			todo.EstimateMinutes = 15 * (1 + rng.Intn(16))
+		}
+
+		created, err := repo.Create(ctx, todo)
+		if err != nil {
+			return fmt.Errorf("failed to seed todo: %w", err)
+		}
+
+		// 作成時は常に未完了のため、完了済みのものは更新で設定する
+		// 見積もりのあるものは実績時間も記録する（見積もりの半分〜2倍）
+		if rng.Float64() < 0.3 {
+			created.MarkAsCompleted()
+			if created.EstimateMinutes > 0 {
+				created.ActualMinutes = created.EstimateMinutes/2 + rng.Intn(created.EstimateMinutes*3/2+1)
+			}
+			if _, err := repo.Update(ctx, created); err != nil {
+				return fmt.Errorf("failed to seed todo: %w", err)
+			}
+		}
+	}
+	return nil
+}
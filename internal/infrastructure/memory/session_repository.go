@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// sessionRepository はメモリ上にセッションを保持する repository.SessionRepository の実装です
+// プロセスを再起動すると全てのセッションが失われ、複数台のサーバーの間でも共有されません
+type sessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]entity.Session
+}
+
+// NewSessionRepository はメモリ上のSessionRepositoryを作成します
+func NewSessionRepository() repository.SessionRepository {
+	return &sessionRepository{
+		sessions: make(map[string]entity.Session),
+	}
+}
+
+// Create はセッションを保存します
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.TokenHash] = *session
+	return nil
+}
+
+// GetByHash はセッションIDのハッシュでセッションを取得します
+func (r *sessionRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, exists := r.sessions[tokenHash]
+	if !exists {
+		return nil, domainerr.NotFound("session", nil)
+	}
+	return &session, nil
+}
+
+// Delete はセッションを削除します
+func (r *sessionRepository) Delete(ctx context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.sessions[tokenHash]; !exists {
+		return domainerr.NotFound("session", nil)
+	}
+	delete(r.sessions, tokenHash)
+	return nil
+}
+
+// DeleteExpired は有効期限が before 以前のセッションを削除します
+func (r *sessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for tokenHash, session := range r.sessions {
+		if !session.ExpiresAt.After(before) {
+			delete(r.sessions, tokenHash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
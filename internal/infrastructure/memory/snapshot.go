@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// snapshotVersion はスナップショットの形式のバージョンです（形式を変更した場合に上げる）
+const snapshotVersion = 1
+
+// snapshot はメモリ上のTodoをファイルに保存する際の形式です
+type snapshot struct {
+	Version int            `json:"version"`
+	SavedAt time.Time      `json:"saved_at"`
+	Todos   []*entity.Todo `json:"todos"`
+}
+
+// SaveSnapshot は repo の全てのTodo（削除済みのTodoは除く）を path にJSONで保存します
+//
+// 開発サーバー（-dev）の再起動をまたいでデータを引き継ぐためのものです
+// 書き込み途中で終了しても前回のファイルが壊れないよう、一時ファイルに書き込んでから置き換えます
+func SaveSnapshot(ctx context.Context, repo repository.TodoRepository, path string) error {
+	todos, err := repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snapshot{Version: snapshotVersion, SavedAt: time.Now().UTC(), Todos: todos}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot は SaveSnapshot で保存したTodoを、同じIDと作成日時のまま repo に復元します
+// 復元した件数を返します。ファイルがない場合は os.ErrNotExist を含むエラーを返します
+func LoadSnapshot(ctx context.Context, repo repository.TodoRepository, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var s snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return 0, fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	if s.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %d in %s", s.Version, path)
+	}
+
+	for _, todo := range s.Todos {
+		if err := repo.Restore(ctx, todo); err != nil {
+			return 0, fmt.Errorf("failed to restore todo %d: %w", todo.ID, err)
+		}
+	}
+	return len(s.Todos), nil
+}
@@ -0,0 +1,513 @@
+// Package memory はデータベースを使用しない、メモリ上のリポジトリ実装です
+//
+// 学習ポイント：
+//  1. ドメイン層はインターフェース（repository.TodoRepository）にのみ依存するため、
+//     保存先をMySQLからメモリに差し替えてもサービス・ハンドラーは変更不要
+//  2. 複数のリクエストから同時に呼ばれるため、sync.RWMutex で排他制御する
+//  3. 呼び出し側が返されたエンティティを変更しても保存内容が変わらないよう、常にコピーを返す
+//
+// プロセスの終了とともにデータは失われます（モックサーバーやデモ向け、SaveSnapshot でファイルに保存できます）
+// OpenTodoJournal で作成した場合は、全ての変更をファイルに追記して再起動後も保持します（DB_DRIVER=embedded）
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// todoRepository はメモリ上にTodoを保持する repository.TodoRepository の実装です
+type todoRepository struct {
+	mu     sync.RWMutex
+	todos  map[int]*entity.Todo
+	nextID int
+
+	// journal は変更を追記するファイルです（OpenTodoJournal で作成した場合のみ。nil の場合はメモリ上だけに保持します）
+	journal *journal
+
+	// now は現在時刻の取得関数です（テストで時刻を固定するためのフィールド）
+	now func() time.Time
+}
+
+// NewTodoRepository はメモリ上のTodoRepositoryを作成します
+func NewTodoRepository() repository.TodoRepository {
+	return newTodoRepository()
+}
+
+func newTodoRepository() *todoRepository {
+	return &todoRepository{
+		todos:  make(map[int]*entity.Todo),
+		nextID: 1,
+		now:    time.Now,
+	}
+}
+
+// Create はTodoを保存し、IDと作成日時を設定します
+// データベース実装と同様に、作成時は常に未完了として保存します
+func (r *todoRepository) Create(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := r.newTodo(ctx, todo, r.nextID, r.now().UTC())
+	if err := r.commit([]*entity.Todo{created}); err != nil {
+		return nil, err
+	}
+	return copyTodo(created), nil
+}
+
+// CreateMany は複数のTodoをまとめて保存します（引数と同じ順序で返します）
+// ロックを1回だけ取得するため、途中で他の書き込みが割り込むことはありません
+func (r *todoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now().UTC()
+	created := make([]*entity.Todo, len(todos))
+	for i, todo := range todos {
+		created[i] = r.newTodo(ctx, todo, r.nextID+i, now)
+	}
+	if err := r.commit(created); err != nil {
+		return nil, err
+	}
+	for i, todo := range created {
+		created[i] = copyTodo(todo)
+	}
+	return created, nil
+}
+
+// newTodo はIDと作成日時を設定したTodoのコピーを返します（保存は commit で行います）
+func (r *todoRepository) newTodo(ctx context.Context, todo *entity.Todo, id int, now time.Time) *entity.Todo {
+	todo.ID = id
+	todo.IsCompleted = false
+	todo.CreatedAt = now
+	todo.UpdatedAt = now
+	todo.UserID, todo.WorkspaceID = nil, nil
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		todo.UserID = &userID
+	}
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		todo.WorkspaceID = &workspaceID
+	}
+	return copyTodo(todo)
+}
+
+// GetByID はIDでTodoを取得します
+func (r *todoRepository) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	todo, ok := r.owned(ctx, id)
+	if !ok {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+	return copyTodo(todo), nil
+}
+
+// Exists はIDでTodoの存在を確認します
+func (r *todoRepository) Exists(ctx context.Context, id int) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.owned(ctx, id)
+	return ok, nil
+}
+
+// GetAll は全てのTodoを作成日時の降順で取得します
+func (r *todoRepository) GetAll(ctx context.Context) ([]*entity.Todo, error) {
+	return r.list(ctx, func(*entity.Todo) bool { return true }), nil
+}
+
+// GetByColor は指定した色のTodoを作成日時の降順で取得します
+func (r *todoRepository) GetByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	return r.list(ctx, func(todo *entity.Todo) bool { return todo.Color == color }), nil
+}
+
+// Find は条件に一致するTodoを指定した並び順で返します
+func (r *todoRepository) Find(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	if order != "" && !order.IsValid() {
+		return nil, domainerr.Invalid("sort order", fmt.Sprintf("%q", order))
+	}
+	todos := r.list(ctx, filter.Matches)
+	order.Sort(todos)
+	return todos, nil
+}
+
+// Count は条件に一致するTodoの件数を返します
+func (r *todoRepository) Count(ctx context.Context, filter repository.TodoFilter) (int, error) {
+	return len(r.list(ctx, filter.Matches)), nil
+}
+
+// CountGrouped は条件に一致するTodoの件数をグループごとに返します（キーの昇順）
+func (r *todoRepository) CountGrouped(ctx context.Context, group repository.TodoGroup, filter repository.TodoFilter) ([]repository.TodoGroupCount, error) {
+	if !group.IsValid() {
+		return nil, domainerr.Invalid("group", fmt.Sprintf("%q", group))
+	}
+
+	counts := make(map[string]int)
+	for _, todo := range r.list(ctx, filter.Matches) {
+		counts[groupKey(group, todo)]++
+	}
+	result := make([]repository.TodoGroupCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, repository.TodoGroupCount{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+// groupKey はTodoが属するグループのキーを返します（データベース実装の todoGroupKeys と同じ値）
+func groupKey(group repository.TodoGroup, todo *entity.Todo) string {
+	switch group {
+	case repository.TodoGroupColor:
+		return string(todo.Color)
+	case repository.TodoGroupProject:
+		if todo.ProjectID == nil {
+			return ""
+		}
+		return strconv.Itoa(*todo.ProjectID)
+	default:
+		return strconv.FormatBool(todo.IsCompleted)
+	}
+}
+
+// GetStats は一覧と同じTodoの件数と見積もり・実績時間の集計を返します
+func (r *todoRepository) GetStats(ctx context.Context) (entity.TodoStats, error) {
+	return entity.NewTodoStats(r.list(ctx, func(*entity.Todo) bool { return true })), nil
+}
+
+// ExistsByTitle は同じタイトル（大文字・小文字を区別しない）のTodoが存在するかを返します
+func (r *todoRepository) ExistsByTitle(ctx context.Context, title string, excludeID int) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for id, todo := range r.todos {
+		if id != excludeID && todo.DeletedAt == nil && ownedBy(ctx, todo) && strings.EqualFold(todo.Title, title) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Update は既存のTodoを更新します
+// データベース実装と同様に、作成日時とシリーズへの参照は変更しません
+func (r *todoRepository) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.owned(ctx, todo.ID)
+	if !ok {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+
+	updated := r.merge(existing, todo)
+	if err := r.commit([]*entity.Todo{updated}); err != nil {
+		return nil, err
+	}
+	return copyTodo(updated), nil
+}
+
+// UpdateFields は指定したフィールドだけを更新します
+func (r *todoRepository) UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (*entity.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.owned(ctx, todo.ID)
+	if !ok {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+
+	updated := copyTodo(existing)
+	updated.CopyFields(todo, fields)
+	updated.UpdatedAt = r.now().UTC()
+	if err := r.commit([]*entity.Todo{updated}); err != nil {
+		return nil, err
+	}
+	return copyTodo(updated), nil
+}
+
+// UpdateMany は複数のTodoをまとめて更新します
+// ロックを保持したまま全件の存在を確認してから書き込むため、途中で失敗して一部だけ更新されることはありません
+func (r *todoRepository) UpdateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, todo := range todos {
+		if _, ok := r.owned(ctx, todo.ID); !ok {
+			return nil, domainerr.NotFound("todo", todo.ID)
+		}
+	}
+
+	updates := make([]*entity.Todo, len(todos))
+	for i, todo := range todos {
+		updates[i] = r.merge(r.todos[todo.ID], todo)
+	}
+	if err := r.commit(updates); err != nil {
+		return nil, err
+	}
+	results := make([]*entity.Todo, len(updates))
+	for i, updated := range updates {
+		results[i] = copyTodo(updated)
+	}
+	return results, nil
+}
+
+// UpdateWithLock はリポジトリのロックを保持したまま、mutate で変更したTodoを保存します
+// mutate にはコピーを渡すため、エラーを返した場合に保存済みのTodoは変わりません
+func (r *todoRepository) UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (*entity.Todo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.owned(ctx, id)
+	if !ok {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+
+	todo := copyTodo(existing)
+	if err := mutate(todo); err != nil {
+		if errors.Is(err, repository.ErrNoChange) {
+			return copyTodo(existing), nil
+		}
+		return nil, err
+	}
+
+	updated := r.merge(existing, todo)
+	updated.ID = id
+	if err := r.commit([]*entity.Todo{updated}); err != nil {
+		return nil, err
+	}
+	return copyTodo(updated), nil
+}
+
+// merge は更新内容のコピーに、更新では変わらない項目（作成日時・シリーズへの参照・所有者・ワークスペース・チェックリストの進捗）を引き継ぎます
+func (r *todoRepository) merge(existing, todo *entity.Todo) *entity.Todo {
+	updated := copyTodo(todo)
+	updated.CreatedAt = existing.CreatedAt
+	updated.RecurrenceParentID = existing.RecurrenceParentID
+	updated.UserID = copyInt(existing.UserID)
+	updated.WorkspaceID = copyInt(existing.WorkspaceID)
+	updated.ChecklistProgress = existing.ChecklistProgress
+	updated.UpdatedAt = r.now().UTC()
+	return updated
+}
+
+// Upsert はIDを指定してTodoを作成し、同じIDのTodoが既にある場合はその内容を更新します
+// データベース実装と同様に、削除済みのTodoは復元し、他のユーザーのTodoは "todo not found" になります
+func (r *todoRepository) Upsert(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	if todo.ID <= 0 {
+		return nil, domainerr.Invalid("todo ID", "must be greater than 0")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.todos[todo.ID]
+	if !ok {
+		now := r.now().UTC()
+		created := copyTodo(todo)
+		created.CreatedAt = now
+		created.UpdatedAt = now
+		created.DeletedAt = nil
+		created.UserID, created.WorkspaceID = nil, nil
+		if userID, ok := repository.OwnerFromContext(ctx); ok {
+			created.UserID = &userID
+		}
+		if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+			created.WorkspaceID = &workspaceID
+		}
+		if err := r.commit([]*entity.Todo{created}); err != nil {
+			return nil, err
+		}
+		return copyTodo(created), nil
+	}
+
+	if !ownedBy(ctx, existing) {
+		return nil, domainerr.NotFound("todo", nil)
+	}
+	updated := r.merge(existing, todo)
+	updated.DeletedAt = nil
+	if err := r.commit([]*entity.Todo{updated}); err != nil {
+		return nil, err
+	}
+	return copyTodo(updated), nil
+}
+
+// Delete はTodoをソフト削除します（削除日時を記録し、既定の取得・一覧から除外します）
+func (r *todoRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	todo, ok := r.owned(ctx, id)
+	if !ok {
+		return domainerr.NotFound("todo", nil)
+	}
+	deleted := copyTodo(todo)
+	now := r.now().UTC()
+	deleted.DeletedAt = &now
+	return r.commit([]*entity.Todo{deleted})
+}
+
+// GetDeleted は削除済みのTodoを削除日時の降順（同時刻はIDの降順）で取得します
+func (r *todoRepository) GetDeleted(ctx context.Context) ([]*entity.Todo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	todos := make([]*entity.Todo, 0)
+	for _, todo := range r.todos {
+		if todo.DeletedAt != nil && ownedBy(ctx, todo) {
+			todos = append(todos, copyTodo(todo))
+		}
+	}
+
+	sort.Slice(todos, func(i, j int) bool {
+		if !todos[i].DeletedAt.Equal(*todos[j].DeletedAt) {
+			return todos[i].DeletedAt.After(*todos[j].DeletedAt)
+		}
+		return todos[i].ID > todos[j].ID
+	})
+	return todos, nil
+}
+
+// Restore は削除したTodoを同じIDと作成日時のまま保存し直します
+// 削除済みのTodoは渡された内容で置き換え、行がない場合は同じIDで作成し直します
+func (r *todoRepository) Restore(ctx context.Context, todo *entity.Todo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.todos[todo.ID]; ok && (existing.DeletedAt == nil || !ownedBy(ctx, existing)) {
+		return errors.New("todo already exists")
+	}
+	restored := copyTodo(todo)
+	restored.DeletedAt = nil
+	if userID, ok := repository.OwnerFromContext(ctx); ok {
+		restored.UserID = &userID
+	}
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		restored.WorkspaceID = &workspaceID
+	}
+	return r.commit([]*entity.Todo{restored})
+}
+
+// HardDelete はTodoを完全に削除します（削除済みのTodoも対象です）
+func (r *todoRepository) HardDelete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if todo, ok := r.todos[id]; !ok || !ownedBy(ctx, todo) {
+		return domainerr.NotFound("todo", nil)
+	}
+	return r.commit(nil, id)
+}
+
+// commit は puts のTodoを保存し、deletes のIDのTodoを完全に削除します（呼び出し側で書き込みのロックを取得してください）
+// 保存したIDが採番済みのID以上の場合は、次に採番するIDを進めます
+// ジャーナルがある場合は先にファイルへ追記し、追記に失敗した場合はメモリ上の状態も変更しません
+func (r *todoRepository) commit(puts []*entity.Todo, deletes ...int) error {
+	nextID := r.nextID
+	for _, todo := range puts {
+		if todo.ID >= nextID {
+			nextID = todo.ID + 1
+		}
+	}
+	if r.journal != nil {
+		if err := r.journal.append(journalRecord{NextID: nextID, Put: journalTodos(puts), Delete: deletes}); err != nil {
+			return err
+		}
+	}
+
+	for _, todo := range puts {
+		r.todos[todo.ID] = todo
+	}
+	for _, id := range deletes {
+		delete(r.todos, id)
+	}
+	r.nextID = nextID
+	return nil
+}
+
+// owned はIDでTodoを探し、コンテキストの所有者の削除されていないTodoであれば返します
+// 他のユーザーのTodoと削除済みのTodoは、データベース実装と同様に存在しないものとして扱います
+func (r *todoRepository) owned(ctx context.Context, id int) (*entity.Todo, bool) {
+	todo, ok := r.todos[id]
+	if !ok || todo.DeletedAt != nil || !ownedBy(ctx, todo) {
+		return nil, false
+	}
+	return todo, true
+}
+
+// ownedBy はTodoがコンテキストのワークスペース・所有者のものかを判定します
+// データベース実装の scopeCondition と同じく、ワークスペースが設定されている場合はそのワークスペースのTodo、
+// 所有者だけの場合は本人の個人のTodo、どちらも設定されていない場合は全てのTodoが対象です
+func ownedBy(ctx context.Context, todo *entity.Todo) bool {
+	if workspaceID, ok := repository.WorkspaceFromContext(ctx); ok {
+		return todo.WorkspaceID != nil && *todo.WorkspaceID == workspaceID
+	}
+	userID, ok := repository.OwnerFromContext(ctx)
+	return !ok || (todo.UserID != nil && *todo.UserID == userID && todo.WorkspaceID == nil)
+}
+
+// list はコンテキストの所有者の削除されていないTodoのうち、条件に一致するもののコピーを作成日時の降順（同時刻はIDの降順）で返します
+func (r *todoRepository) list(ctx context.Context, match func(*entity.Todo) bool) []*entity.Todo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	todos := make([]*entity.Todo, 0, len(r.todos))
+	for _, todo := range r.todos {
+		if todo.DeletedAt == nil && ownedBy(ctx, todo) && match(todo) {
+			todos = append(todos, copyTodo(todo))
+		}
+	}
+
+	sort.Slice(todos, func(i, j int) bool {
+		if !todos[i].CreatedAt.Equal(todos[j].CreatedAt) {
+			return todos[i].CreatedAt.After(todos[j].CreatedAt)
+		}
+		return todos[i].ID > todos[j].ID
+	})
+	return todos
+}
+
+// copyTodo はポインタ・スライスのフィールドも含めてTodoを複製します
+func copyTodo(todo *entity.Todo) *entity.Todo {
+	c := *todo
+	if todo.RemindAt != nil {
+		remindAt := *todo.RemindAt
+		c.RemindAt = &remindAt
+	}
+	if todo.DueDate != nil {
+		dueDate := *todo.DueDate
+		c.DueDate = &dueDate
+	}
+	if todo.RecurrenceParentID != nil {
+		parentID := *todo.RecurrenceParentID
+		c.RecurrenceParentID = &parentID
+	}
+	if todo.DeletedAt != nil {
+		deletedAt := *todo.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	c.ProjectID = copyInt(todo.ProjectID)
+	c.UserID = copyInt(todo.UserID)
+	c.WorkspaceID = copyInt(todo.WorkspaceID)
+	if todo.Tags != nil {
+		c.Tags = append([]string(nil), todo.Tags...)
+	}
+	return &c
+}
+
+// copyInt は *int を複製します（nil の場合は nil）
+func copyInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+	v := *i
+	return &v
+}
@@ -0,0 +1,412 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/domainerr"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// TestTodoRepository_CRUD は作成・取得・更新・削除と、コピーを返すことをテストします
+func TestTodoRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := newTodoRepository()
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	first, _ := repo.Create(ctx, &entity.Todo{Title: "牛乳を買う", IsCompleted: true})
+	now = now.Add(time.Minute)
+	second, _ := repo.Create(ctx, &entity.Todo{Title: "請求書を送る", Color: "blue"})
+
+	if first.ID != 1 || second.ID != 2 || first.IsCompleted || !first.CreatedAt.Equal(now.Add(-time.Minute)) {
+		t.Errorf("作成結果 = %+v, %+v", first, second)
+	}
+
+	// 返されたTodoを変更しても保存内容は変わらない
+	first.Title = "変更"
+	if got, _ := repo.GetByID(ctx, 1); got.Title != "牛乳を買う" {
+		t.Errorf("保存内容が呼び出し側の変更で書き換わりました: %+v", got)
+	}
+
+	all, _ := repo.GetAll(ctx)
+	if len(all) != 2 || all[0].ID != 2 {
+		t.Errorf("一覧は作成日時の降順であるべきです: %+v", all)
+	}
+	if blue, _ := repo.GetByColor(ctx, "blue"); len(blue) != 1 || blue[0].ID != 2 {
+		t.Errorf("色による取得 = %+v", blue)
+	}
+
+	now = now.Add(time.Hour)
+	second.MarkAsCompleted()
+	updated, err := repo.Update(ctx, second)
+	if err != nil || !updated.IsCompleted || !updated.UpdatedAt.Equal(now) || updated.CreatedAt.Equal(now) {
+		t.Errorf("更新結果 = %+v, エラー = %v", updated, err)
+	}
+
+	if err := repo.Delete(ctx, 2); err != nil {
+		t.Fatalf("削除でエラーが発生: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, 2); err == nil {
+		t.Error("削除したTodoが取得できました")
+	}
+	if _, err := repo.Update(ctx, second); err == nil {
+		t.Error("削除したTodoの更新でエラーが返されませんでした")
+	}
+	if err := repo.Delete(ctx, 2); err == nil {
+		t.Error("存在しないTodoの削除でエラーが返されませんでした")
+	}
+	if trash, _ := repo.GetDeleted(ctx); len(trash) != 1 || trash[0].ID != 2 || !trash[0].DeletedAt.Equal(now) {
+		t.Errorf("削除済みの一覧 = %+v, 期待値 = 削除日時付きのID 2", trash)
+	}
+	if all, _ := repo.GetAll(ctx); len(all) != 1 || all[0].ID != 1 {
+		t.Errorf("削除後の一覧 = %+v, 期待値 = ID 1 のみ", all)
+	}
+
+	// 削除したTodoは同じIDと作成日時のまま復元できる
+	if err := repo.Restore(ctx, updated); err != nil {
+		t.Fatalf("復元でエラーが発生: %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, 2); got == nil || !got.CreatedAt.Equal(updated.CreatedAt) || !got.IsCompleted {
+		t.Errorf("復元結果 = %+v", got)
+	}
+	if err := repo.Restore(ctx, updated); err == nil {
+		t.Error("既に存在するIDの復元でエラーが返されませんでした")
+	}
+
+	// 完全に削除したTodoは削除済みの一覧にも残らない
+	if err := repo.HardDelete(ctx, 2); err != nil {
+		t.Fatalf("完全な削除でエラーが発生: %v", err)
+	}
+	if trash, _ := repo.GetDeleted(ctx); len(trash) != 0 {
+		t.Errorf("完全な削除後の削除済みの一覧 = %+v", trash)
+	}
+	if err := repo.HardDelete(ctx, 2); err == nil {
+		t.Error("存在しないTodoの完全な削除でエラーが返されませんでした")
+	}
+}
+
+// TestTodoRepository_CreateMany は一括作成で連番のIDが引数と同じ順序で設定されることをテストします
+func TestTodoRepository_CreateMany(t *testing.T) {
+	ctx := repository.WithOwner(context.Background(), 7)
+	repo := newTodoRepository()
+
+	created, err := repo.CreateMany(ctx, []*entity.Todo{{Title: "牛乳を買う", IsCompleted: true}, {Title: "請求書を送る"}})
+	if err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+	if len(created) != 2 || created[0].ID != 1 || created[1].ID != 2 || created[0].IsCompleted {
+		t.Fatalf("作成結果 = %+v", created)
+	}
+	for _, todo := range created {
+		if got, err := repo.GetByID(ctx, todo.ID); err != nil || got.Title != todo.Title || *got.UserID != 7 {
+			t.Errorf("保存内容 = %+v, エラー = %v", got, err)
+		}
+	}
+}
+
+// TestTodoRepository_Upsert はIDを指定した作成と既存のTodoの更新をテストします
+func TestTodoRepository_Upsert(t *testing.T) {
+	ctx := repository.WithOwner(context.Background(), 7)
+	repo := newTodoRepository()
+
+	created, err := repo.Upsert(ctx, &entity.Todo{ID: 5, Title: "同期したTodo", IsCompleted: true})
+	if err != nil || created.ID != 5 || !created.IsCompleted || *created.UserID != 7 {
+		t.Fatalf("作成結果 = %+v, エラー = %v", created, err)
+	}
+	if err := repo.Delete(ctx, 5); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	updated, err := repo.Upsert(ctx, &entity.Todo{ID: 5, Title: "更新した同期Todo"})
+	if err != nil || updated.Title != "更新した同期Todo" || updated.DeletedAt != nil || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("更新結果 = %+v, エラー = %v", updated, err)
+	}
+	if _, err := repo.Upsert(repository.WithOwner(context.Background(), 8), &entity.Todo{ID: 5, Title: "乗っ取り"}); !domainerr.IsNotFound(err) {
+		t.Errorf("他のユーザーのTodoの Upsert() error = %v, 期待値 = not found", err)
+	}
+	if next, _ := repo.Create(ctx, &entity.Todo{Title: "次のTodo"}); next.ID != 6 {
+		t.Errorf("次に作成したTodoのID = %d, 期待値 = 6", next.ID)
+	}
+}
+
+// TestTodoRepository_Exists は削除済み・他のユーザーのTodoが存在しないものとして扱われることをテストします
+func TestTodoRepository_Exists(t *testing.T) {
+	ctx := repository.WithOwner(context.Background(), 7)
+	repo := newTodoRepository()
+
+	kept, _ := repo.Create(ctx, &entity.Todo{Title: "残すTodo"})
+	deleted, _ := repo.Create(ctx, &entity.Todo{Title: "削除するTodo"})
+	_ = repo.Delete(ctx, deleted.ID)
+
+	if ok, err := repo.Exists(ctx, kept.ID); err != nil || !ok {
+		t.Errorf("Exists(存在するTodo) = %v, %v, 期待値 = true", ok, err)
+	}
+	if ok, _ := repo.Exists(ctx, deleted.ID); ok {
+		t.Error("削除済みのTodoが存在するものとして扱われました")
+	}
+	if ok, _ := repo.Exists(repository.WithOwner(context.Background(), 8), kept.ID); ok {
+		t.Error("他のユーザーのTodoが存在するものとして扱われました")
+	}
+}
+
+// TestTodoRepository_Find は条件の組み合わせと並び順をテストします
+func TestTodoRepository_Find(t *testing.T) {
+	ctx := context.Background()
+	repo := newTodoRepository()
+
+	_, _ = repo.Create(ctx, &entity.Todo{Title: "buy milk", Tags: []string{"shop"}})
+	_, _ = repo.Create(ctx, &entity.Todo{Title: "Apple", Description: "milk と一緒に", Tags: []string{"shop"}})
+	_, _ = repo.Create(ctx, &entity.Todo{Title: "Shopping", Tags: []string{"shopping"}})
+
+	todos, err := repo.Find(ctx, repository.TodoFilter{Tag: "shop", Search: "MILK"}, entity.TodoSortTitle)
+	if err != nil || len(todos) != 2 || todos[0].Title != "Apple" || todos[1].Title != "buy milk" {
+		t.Errorf("Find() = %+v, %v, 期待値 = Apple, buy milk の順", todos, err)
+	}
+	if _, err := repo.Find(ctx, repository.TodoFilter{}, "priority"); !domainerr.IsInvalid(err) {
+		t.Errorf("未知の並び順の Find() error = %v, 期待値 = invalid", err)
+	}
+}
+
+// TestTodoRepository_Aggregates は件数・グループごとの件数・集計をテストします
+func TestTodoRepository_Aggregates(t *testing.T) {
+	ctx := context.Background()
+	repo := newTodoRepository()
+	for _, todo := range []*entity.Todo{{Title: "a", Color: "blue"}, {Title: "b", Color: "blue", EstimateMinutes: 30}, {Title: "c"}} {
+		if _, err := repo.Create(ctx, todo); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if err := repo.Delete(ctx, 3); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if got, _ := repo.Count(ctx, repository.TodoFilter{Color: "blue"}); got != 2 {
+		t.Errorf("Count() = %d, 期待値 = 2", got)
+	}
+	groups, err := repo.CountGrouped(ctx, repository.TodoGroupCompleted, repository.TodoFilter{})
+	if err != nil || len(groups) != 1 || groups[0] != (repository.TodoGroupCount{Key: "false", Count: 2}) {
+		t.Errorf("CountGrouped() = %+v, %v", groups, err)
+	}
+	if stats, _ := repo.GetStats(ctx); stats.Total != 2 || stats.Estimates.RemainingMinutes != 30 {
+		t.Errorf("GetStats() = %+v", stats)
+	}
+}
+
+// TestTodoRepository_UpdateFields は指定したフィールドだけが更新されることをテストします
+func TestTodoRepository_UpdateFields(t *testing.T) {
+	ctx := context.Background()
+	repo := newTodoRepository()
+	created, _ := repo.Create(ctx, &entity.Todo{Title: "部分更新", Description: "元の説明"})
+
+	change := *created
+	change.Title = "変更後"
+	change.Description = "書き込まれない説明"
+	updated, err := repo.UpdateFields(ctx, &change, []entity.TodoField{entity.TodoFieldTitle})
+	if err != nil || updated.Title != "変更後" || updated.Description != "元の説明" {
+		t.Errorf("部分更新の結果 = %+v, エラー = %v", updated, err)
+	}
+	if _, err := repo.UpdateFields(ctx, &entity.Todo{ID: 99}, []entity.TodoField{entity.TodoFieldTitle}); err == nil {
+		t.Error("存在しないTodoの更新でエラーが返されませんでした")
+	}
+}
+
+// TestTodoRepository_UpdateMany は一括更新が全件または0件で反映されることをテストします
+func TestTodoRepository_UpdateMany(t *testing.T) {
+	ctx := context.Background()
+	repo := newTodoRepository()
+	first, _ := repo.Create(ctx, &entity.Todo{Title: "一つ目"})
+	second, _ := repo.Create(ctx, &entity.Todo{Title: "二つ目"})
+
+	first.Title = "変更"
+	if _, err := repo.UpdateMany(ctx, []*entity.Todo{first, {ID: 99, Title: "存在しない"}}); err == nil {
+		t.Fatal("存在しないTodoを含む一括更新でエラーが返されませんでした")
+	}
+	if got, _ := repo.GetByID(ctx, first.ID); got.Title != "一つ目" {
+		t.Errorf("失敗した一括更新が一部反映されました: %+v", got)
+	}
+
+	second.MarkAsCompleted()
+	updated, err := repo.UpdateMany(ctx, []*entity.Todo{first, second})
+	if err != nil {
+		t.Fatalf("一括更新でエラーが発生: %v", err)
+	}
+	if len(updated) != 2 || updated[0].Title != "変更" || !updated[1].IsCompleted {
+		t.Errorf("一括更新の結果 = %+v", updated)
+	}
+}
+
+// TestTodoRepository_UpdateWithLock は並行した UpdateWithLock が順番に適用されることをテストします
+func TestTodoRepository_UpdateWithLock(t *testing.T) {
+	ctx := context.Background()
+	repo := newTodoRepository()
+	created, _ := repo.Create(ctx, &entity.Todo{Title: "実績"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo.UpdateWithLock(ctx, created.ID, func(todo *entity.Todo) error {
+				todo.ActualMinutes += 5
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	got, _ := repo.GetByID(ctx, created.ID)
+	if got.ActualMinutes != 100 {
+		t.Errorf("ActualMinutes = %d, 期待値 = 100（更新が失われています）", got.ActualMinutes)
+	}
+
+	if _, err := repo.UpdateWithLock(ctx, created.ID, func(todo *entity.Todo) error {
+		todo.Title = "保存されない"
+		return repository.ErrNoChange
+	}); err != nil {
+		t.Fatalf("UpdateWithLock(ErrNoChange) error = %v", err)
+	}
+	if got, _ := repo.GetByID(ctx, created.ID); got.Title != "実績" {
+		t.Errorf("ErrNoChange の変更が保存されています: %+v", got)
+	}
+}
+
+// TestTodoRepository_Owner はコンテキストに所有者がある場合、そのユーザーのTodoだけが操作の対象になることをテストします
+func TestTodoRepository_Owner(t *testing.T) {
+	repo := NewTodoRepository()
+	alice := repository.WithOwner(context.Background(), 1)
+	bob := repository.WithOwner(context.Background(), 2)
+
+	created, _ := repo.Create(alice, &entity.Todo{Title: "アリスのTodo"})
+	repo.Create(bob, &entity.Todo{Title: "ボブのTodo"})
+
+	if todos, _ := repo.GetAll(alice); len(todos) != 1 || todos[0].ID != created.ID {
+		t.Errorf("本人の GetAll() = %d件, 期待値 = 1", len(todos))
+	}
+	if todos, _ := repo.GetAll(context.Background()); len(todos) != 2 {
+		t.Errorf("所有者なしの GetAll() = %d件, 期待値 = 2", len(todos))
+	}
+	if _, err := repo.GetByID(bob, created.ID); err == nil {
+		t.Error("他のユーザーのTodoを取得できました")
+	}
+	if err := repo.Delete(bob, created.ID); err == nil {
+		t.Error("他のユーザーのTodoを削除できました")
+	}
+	if exists, _ := repo.ExistsByTitle(bob, "アリスのTodo", 0); exists {
+		t.Error("他のユーザーのTodoのタイトルが重複と判定されました")
+	}
+
+	// 更新しても所有者は変わらない
+	updated, err := repo.Update(alice, &entity.Todo{ID: created.ID, Title: "変更後"})
+	if err != nil || updated.UserID == nil || *updated.UserID != 1 {
+		t.Errorf("Update() = %+v, err = %v, 期待値 = 所有者 1 のまま", updated, err)
+	}
+}
+
+// TestTodoRepository_Concurrent は同時に作成してもIDが重複しないことをテストします（go test -race で確認）
+func TestTodoRepository_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTodoRepository()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo.Create(ctx, &entity.Todo{Title: "並行"})
+			repo.GetAll(ctx)
+		}()
+	}
+	wg.Wait()
+
+	all, _ := repo.GetAll(ctx)
+	ids := make(map[int]bool)
+	for _, todo := range all {
+		ids[todo.ID] = true
+	}
+	if len(ids) != 50 {
+		t.Errorf("一意なIDの数 = %d, 期待値 = 50", len(ids))
+	}
+}
+
+// TestSeedTodos は同じシードで同じダミーデータが作成されることをテストします
+func TestSeedTodos(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	seed := func() []*entity.Todo {
+		repo := NewTodoRepository()
+		if err := SeedTodos(ctx, repo, 30, rand.New(rand.NewSource(42)), now); err != nil {
+			t.Fatalf("ダミーデータの作成に失敗: %v", err)
+		}
+		todos, _ := repo.GetAll(ctx)
+		return todos
+	}
+
+	first, second := seed(), seed()
+	if len(first) != 30 {
+		t.Fatalf("件数 = %d, 期待値 = 30", len(first))
+	}
+	completed := 0
+	for i := range first {
+		if first[i].Title != second[i].Title || first[i].Color != second[i].Color || first[i].IsCompleted != second[i].IsCompleted {
+			t.Errorf("同じシードで異なるデータが作成されました: %+v, %+v", first[i], second[i])
+		}
+		if !first[i].IsValid() {
+			t.Errorf("不正なダミーデータ: %+v", first[i])
+		}
+		if first[i].IsCompleted {
+			completed++
+		}
+	}
+	if completed == 0 || completed == len(first) {
+		t.Errorf("完了・未完了が混在していません（完了 %d 件）", completed)
+	}
+}
+
+// TestSnapshot は保存したTodoが同じIDと日時のまま復元され、続けて作成したTodoのIDが重複しないことをテストします
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dev", "snapshot.json")
+
+	if _, err := LoadSnapshot(ctx, NewTodoRepository(), path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ファイルがない場合のエラー = %v, 期待値 = os.ErrNotExist", err)
+	}
+
+	source := NewTodoRepository()
+	dueDate := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	source.Create(ctx, &entity.Todo{Title: "牛乳を買う"})
+	source.Create(ctx, &entity.Todo{Title: "請求書を送る", DueDate: &dueDate, Tags: []string{"仕事"}})
+	source.Create(ctx, &entity.Todo{Title: "削除する"})
+	source.Delete(ctx, 3)
+	if err := SaveSnapshot(ctx, source, path); err != nil {
+		t.Fatalf("保存でエラーが発生: %v", err)
+	}
+
+	restored := NewTodoRepository()
+	n, err := LoadSnapshot(ctx, restored, path)
+	if err != nil || n != 2 {
+		t.Fatalf("復元した件数 = %d, エラー = %v, 期待値 = 2", n, err)
+	}
+	want, _ := source.GetAll(ctx)
+	got, _ := restored.GetAll(ctx)
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Title != want[i].Title || !got[i].CreatedAt.Equal(want[i].CreatedAt) || len(got[i].Tags) != len(want[i].Tags) {
+			t.Errorf("復元結果 = %+v, 期待値 = %+v", got[i], want[i])
+		}
+	}
+	if got[0].DueDate == nil || !got[0].DueDate.Equal(dueDate) {
+		t.Errorf("期限 = %v, 期待値 = %v", got[0].DueDate, dueDate)
+	}
+
+	// 復元後に作成したTodoは、復元したTodoの最大のIDの次から採番される
+	if created, _ := restored.Create(ctx, &entity.Todo{Title: "新しいTodo"}); created.ID != 3 {
+		t.Errorf("復元後に作成したTodoのID = %d, 期待値 = 3", created.ID)
+	}
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// DBMetrics はデータベースの接続プールとクエリの実行時間をPrometheusのメトリクスとして公開します
+//
+// 接続プールの統計（sql.DB.Stats()）はワーカーが一定間隔で ObservePool に渡し、
+// クエリの実行時間は database.WithQueryObserver で ObserveQuery を登録して記録します
+//
+// ダッシュボードでの集計例：
+//   - 使用中の接続の割合: todoapp_db_connections_in_use / todoapp_db_connections_max_open
+//   - 接続待ちの平均時間: rate(todoapp_db_connection_wait_seconds_sum[5m]) / rate(todoapp_db_connection_wait_seconds_count[5m])
+//   - クエリの平均実行時間: rate(todoapp_db_query_duration_seconds_sum[5m]) / rate(todoapp_db_query_duration_seconds_count[5m])
+type DBMetrics struct {
+	maxOpen *Gauge
+	open    *Gauge
+	inUse   *Gauge
+	idle    *Gauge
+
+	wait              *Summary
+	maxIdleClosed     *Counter
+	maxIdleTimeClosed *Counter
+	maxLifetimeClosed *Counter
+
+	queryDuration *Summary
+	queryErrors   *Counter
+
+	// last は前回 ObservePool に渡された統計です（累計値の差分を求めるために保持します）
+	mu   sync.Mutex
+	last sql.DBStats
+}
+
+// NewDBMetrics はデータベースのメトリクスをレジストリに登録します
+func NewDBMetrics(reg *Registry) *DBMetrics {
+	return &DBMetrics{
+		maxOpen: reg.NewGauge("todoapp_db_connections_max_open", "Maximum number of open connections to the database."),
+		open:    reg.NewGauge("todoapp_db_connections_open", "Number of established connections, both in use and idle."),
+		inUse:   reg.NewGauge("todoapp_db_connections_in_use", "Number of connections currently in use."),
+		idle:    reg.NewGauge("todoapp_db_connections_idle", "Number of idle connections."),
+
+		wait:              reg.NewSummary("todoapp_db_connection_wait_seconds", "Time blocked waiting for a new connection."),
+		maxIdleClosed:     reg.NewCounter("todoapp_db_connections_max_idle_closed_total", "Total number of connections closed due to SetMaxIdleConns."),
+		maxIdleTimeClosed: reg.NewCounter("todoapp_db_connections_max_idle_time_closed_total", "Total number of connections closed due to SetConnMaxIdleTime."),
+		maxLifetimeClosed: reg.NewCounter("todoapp_db_connections_max_lifetime_closed_total", "Total number of connections closed due to SetConnMaxLifetime."),
+
+		queryDuration: reg.NewSummary("todoapp_db_query_duration_seconds", "Time spent executing database queries."),
+		queryErrors:   reg.NewCounter("todoapp_db_query_errors_total", "Total number of database queries that returned an error."),
+	}
+}
+
+// ObservePool は接続プールの統計を記録します（常に同じ *sql.DB の統計を渡してください）
+// 接続数はそのままゲージに設定し、累計値（待ち回数・閉じた接続数）は前回からの増加分を加えます
+func (m *DBMetrics) ObservePool(stats sql.DBStats) {
+	m.mu.Lock()
+	last := m.last
+	m.last = stats
+	m.mu.Unlock()
+
+	m.maxOpen.Set(float64(stats.MaxOpenConnections))
+	m.open.Set(float64(stats.OpenConnections))
+	m.inUse.Set(float64(stats.InUse))
+	m.idle.Set(float64(stats.Idle))
+
+	m.wait.Add((stats.WaitDuration - last.WaitDuration).Seconds(), uint64(stats.WaitCount-last.WaitCount))
+	m.maxIdleClosed.Add(uint64(stats.MaxIdleClosed - last.MaxIdleClosed))
+	m.maxIdleTimeClosed.Add(uint64(stats.MaxIdleTimeClosed - last.MaxIdleTimeClosed))
+	m.maxLifetimeClosed.Add(uint64(stats.MaxLifetimeClosed - last.MaxLifetimeClosed))
+}
+
+// ObserveQuery は1回のクエリの実行時間と結果を記録します（database.QueryObserver として登録します）
+func (m *DBMetrics) ObserveQuery(d time.Duration, err error) {
+	m.queryDuration.Observe(d.Seconds())
+	if err != nil {
+		m.queryErrors.Inc()
+	}
+}
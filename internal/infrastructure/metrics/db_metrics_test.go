@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDBMetrics は接続プールの統計とクエリの実行時間の記録をテストします
+func TestDBMetrics(t *testing.T) {
+	reg := NewRegistry()
+	m := NewDBMetrics(reg)
+
+	m.ObservePool(sql.DBStats{MaxOpenConnections: 10, OpenConnections: 3, InUse: 2, Idle: 1, WaitCount: 2, WaitDuration: time.Second, MaxIdleClosed: 1})
+	// 累計値は前回からの増加分だけを加える（二重に数えない）
+	m.ObservePool(sql.DBStats{MaxOpenConnections: 10, OpenConnections: 4, InUse: 4, Idle: 0, WaitCount: 5, WaitDuration: 3 * time.Second, MaxIdleClosed: 1})
+
+	m.ObserveQuery(500*time.Millisecond, nil)
+	m.ObserveQuery(250*time.Millisecond, errors.New("deadlock"))
+
+	var buf bytes.Buffer
+	if err := reg.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		"todoapp_db_connections_max_open 10\n",
+		"todoapp_db_connections_open 4\n",
+		"todoapp_db_connections_in_use 4\n",
+		"todoapp_db_connections_idle 0\n",
+		"todoapp_db_connection_wait_seconds_sum 3\n",
+		"todoapp_db_connection_wait_seconds_count 5\n",
+		"todoapp_db_connections_max_idle_closed_total 1\n",
+		"todoapp_db_connections_max_lifetime_closed_total 0\n",
+		"todoapp_db_query_duration_seconds_sum 0.75\n",
+		"todoapp_db_query_duration_seconds_count 2\n",
+		"todoapp_db_query_errors_total 1\n",
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("出力に %q が含まれていません\n%s", strings.TrimSpace(line), out)
+		}
+	}
+}
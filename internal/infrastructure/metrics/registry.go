@@ -0,0 +1,224 @@
+// Package metrics はPrometheusのテキスト形式でメトリクスを公開する最小限の実装です
+//
+// 学習ポイント：
+//  1. Prometheus のテキスト形式（# HELP / # TYPE と「名前 値」の行）は標準パッケージだけで出力できる
+//  2. カウンターは増えるだけの値で、「1時間あたりの件数」はダッシュボード側で increase() / rate() を使って求める
+//  3. 平均値は「合計（_sum）」と「回数（_count）」を公開し、ダッシュボード側で割り算する（サマリー型）
+//
+// 外部ライブラリに依存しないよう、このアプリケーションで必要な型（カウンター・ゲージ・サマリー）のみを実装しています
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// metricNamePattern はPrometheusのメトリクス名として有効な形式です
+var metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// collector はレジストリに登録されるメトリクスです
+type collector interface {
+	// write はメトリクスをテキスト形式で書き込みます
+	write(w io.Writer) error
+}
+
+// Registry はメトリクスの登録先で、/metrics エンドポイントの http.Handler でもあります
+type Registry struct {
+	mu         sync.Mutex
+	collectors map[string]collector
+}
+
+// NewRegistry はRegistryのコンストラクタです
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: make(map[string]collector),
+	}
+}
+
+// NewCounter はカウンター（増加のみする値）を作成して登録します
+func (r *Registry) NewCounter(name, help string) *Counter {
+	c := &Counter{name: name, help: help}
+	r.register(name, c)
+	return c
+}
+
+// NewGauge はゲージ（増減する値）を作成して登録します
+func (r *Registry) NewGauge(name, help string) *Gauge {
+	g := &Gauge{name: name, help: help}
+	r.register(name, g)
+	return g
+}
+
+// NewSummary はサマリー（観測値の合計と回数）を作成して登録します
+func (r *Registry) NewSummary(name, help string) *Summary {
+	s := &Summary{name: name, help: help}
+	r.register(name, s)
+	return s
+}
+
+// register はメトリクスを登録します
+// 名前の誤りや重複は起動時のプログラミングミスのため panic します
+func (r *Registry) register(name string, c collector) {
+	if !metricNamePattern.MatchString(name) {
+		panic(fmt.Sprintf("metrics: invalid metric name %q", name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.collectors[name]; exists {
+		panic(fmt.Sprintf("metrics: duplicate metric name %q", name))
+	}
+	r.collectors[name] = c
+}
+
+// WriteText はすべてのメトリクスを名前順にPrometheusのテキスト形式で書き込みます
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	collectors := make([]collector, len(names))
+	sort.Strings(names)
+	for i, name := range names {
+		collectors[i] = r.collectors[name]
+	}
+	r.mu.Unlock()
+
+	buf := bufio.NewWriter(w)
+	for _, c := range collectors {
+		if err := c.write(buf); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// ServeHTTP は GET /metrics への応答としてメトリクスを返します
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if req.Method == http.MethodHead {
+		return
+	}
+	if err := r.WriteText(w); err != nil {
+		// ヘッダー送信後のため、ステータスコードは変更できない
+		return
+	}
+}
+
+// Counter は増加のみする値です（例: 作成されたTodoの累計数）
+type Counter struct {
+	name  string
+	help  string
+	value atomic.Uint64
+}
+
+// Inc はカウンターを1増やします
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Add はカウンターを n 増やします（累計値の差分をまとめて加える場合に使用します）
+func (c *Counter) Add(n uint64) {
+	c.value.Add(n)
+}
+
+// Value は現在の値を返します
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+func (c *Counter) write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", c.name, c.help, c.name, c.name, c.Value())
+	return err
+}
+
+// Gauge は増減する値です（例: 未完了のTodoの数）
+type Gauge struct {
+	name string
+	help string
+	bits atomic.Uint64 // float64 のビット表現
+}
+
+// Set はゲージに値を設定します
+func (g *Gauge) Set(value float64) {
+	g.bits.Store(math.Float64bits(value))
+}
+
+// Value は現在の値を返します
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(g.bits.Load())
+}
+
+func (g *Gauge) write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", g.name, g.help, g.name, g.name, formatFloat(g.Value()))
+	return err
+}
+
+// Summary は観測値の合計と回数です（例: 一覧の件数の平均 = _sum / _count）
+// 分位数（quantile）は計算しません
+type Summary struct {
+	name  string
+	help  string
+	mu    sync.Mutex
+	sum   float64
+	count uint64
+}
+
+// Observe は値を1回分記録します
+func (s *Summary) Observe(value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sum += value
+	s.count++
+}
+
+// Add は複数回分の観測値の合計と回数をまとめて記録します
+// 外部で集計された累計値（sql.DBStats の待ち時間と回数など）の差分を取り込む場合に使用します
+func (s *Summary) Add(sum float64, count uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sum += sum
+	s.count += count
+}
+
+// Snapshot は現在の合計と回数を返します
+func (s *Summary) Snapshot() (sum float64, count uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sum, s.count
+}
+
+func (s *Summary) write(w io.Writer) error {
+	sum, count := s.Snapshot()
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s summary\n%s_sum %s\n%s_count %d\n",
+		s.name, s.help, s.name, s.name, formatFloat(sum), s.name, count)
+	return err
+}
+
+// formatFloat はPrometheusのテキスト形式で浮動小数点数を表します
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRegistry_WriteText はPrometheusのテキスト形式の出力をテストします
+func TestRegistry_WriteText(t *testing.T) {
+	reg := NewRegistry()
+	kpis := NewTodoKPIs(reg)
+
+	kpis.TodoCreated()
+	kpis.TodoCreated()
+	kpis.TodoCompleted()
+	kpis.TodoListObserved(3)
+	kpis.TodoListObserved(4)
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	expected := `# HELP todoapp_todo_list_size Number of todos returned by list requests.
+# TYPE todoapp_todo_list_size summary
+todoapp_todo_list_size_sum 7
+todoapp_todo_list_size_count 2
+# HELP todoapp_todo_list_size_last Number of todos returned by the most recent list request.
+# TYPE todoapp_todo_list_size_last gauge
+todoapp_todo_list_size_last 4
+# HELP todoapp_todos_completed_total Total number of todos moved from incomplete to completed.
+# TYPE todoapp_todos_completed_total counter
+todoapp_todos_completed_total 1
+# HELP todoapp_todos_created_total Total number of todos created.
+# TYPE todoapp_todos_created_total counter
+todoapp_todos_created_total 2
+`
+	if got := rec.Body.String(); got != expected {
+		t.Errorf("出力 =\n%s\n期待値 =\n%s", got, expected)
+	}
+}
+
+// TestRegistry_Register は不正・重複したメトリクス名の登録で panic することをテストします
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(reg *Registry)
+	}{
+		{name: "不正な名前", register: func(reg *Registry) { reg.NewCounter("todos-created", "") }},
+		{name: "重複した名前", register: func(reg *Registry) {
+			reg.NewCounter("todos_created_total", "")
+			reg.NewGauge("todos_created_total", "")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("panic が期待されましたが、発生しませんでした")
+				}
+			}()
+			tt.register(NewRegistry())
+		})
+	}
+}
+
+// TestRegistry_MethodNotAllowed はGET以外のメソッドを拒否することをテストします
+func TestRegistry_MethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewRegistry().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
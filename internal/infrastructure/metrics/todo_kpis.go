@@ -0,0 +1,45 @@
+package metrics
+
+import "todoapp-api-golang/internal/domain/service"
+
+// TodoKPIs はTodoのビジネス指標をPrometheusのメトリクスとして公開する service.TodoMetrics の実装です
+//
+// ダッシュボードでの集計例：
+//   - 1時間あたりの作成数: increase(todoapp_todos_created_total[1h])
+//   - 1時間あたりの完了数: increase(todoapp_todos_completed_total[1h])
+//   - 一覧の平均件数: rate(todoapp_todo_list_size_sum[1h]) / rate(todoapp_todo_list_size_count[1h])
+type TodoKPIs struct {
+	created   *Counter
+	completed *Counter
+	listSize  *Summary
+	lastList  *Gauge
+}
+
+// インターフェースの実装を保証するためのコンパイル時チェック
+var _ service.TodoMetrics = (*TodoKPIs)(nil)
+
+// NewTodoKPIs はTodoのビジネス指標をレジストリに登録します
+func NewTodoKPIs(reg *Registry) *TodoKPIs {
+	return &TodoKPIs{
+		created:   reg.NewCounter("todoapp_todos_created_total", "Total number of todos created."),
+		completed: reg.NewCounter("todoapp_todos_completed_total", "Total number of todos moved from incomplete to completed."),
+		listSize:  reg.NewSummary("todoapp_todo_list_size", "Number of todos returned by list requests."),
+		lastList:  reg.NewGauge("todoapp_todo_list_size_last", "Number of todos returned by the most recent list request."),
+	}
+}
+
+// TodoCreated はTodoの作成数を1増やします
+func (k *TodoKPIs) TodoCreated() {
+	k.created.Inc()
+}
+
+// TodoCompleted はTodoの完了数を1増やします
+func (k *TodoKPIs) TodoCompleted() {
+	k.completed.Inc()
+}
+
+// TodoListObserved は一覧の件数を記録します
+func (k *TodoKPIs) TodoListObserved(size int) {
+	k.listSize.Observe(float64(size))
+	k.lastList.Set(float64(size))
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// LogNotifier はリマインダーを標準ログに出力する ReminderNotifier の実装です
+// 外部の通知サービスを用意しなくても動作確認ができるデフォルトの通知先です
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier はLogNotifierのコンストラクタです
+// logger に nil を渡した場合は標準ロガーを使用します
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogNotifier{
+		logger: logger,
+	}
+}
+
+// Notify はリマインダーの内容をログに出力します
+func (n *LogNotifier) Notify(ctx context.Context, todo *entity.Todo) error {
+	remindAt := ""
+	if todo.RemindAt != nil {
+		remindAt = todo.RemindAt.Format(time.RFC3339)
+	}
+	n.logger.Printf("Reminder: todo %d %q (remind_at=%s)", todo.ID, todo.Title, remindAt)
+	return nil
+}
+
+// コンパイル時インターフェース実装確認
+var _ service.ReminderNotifier = (*LogNotifier)(nil)
@@ -0,0 +1,80 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// WebhookNotifier はリマインダーを外部のURLへJSONでPOSTする ReminderNotifier の実装です
+// Slack の Incoming Webhook など、任意のWebhook受信側への通知に使用します
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+// webhookPayload はWebhookで送信するリマインダーの内容です
+type webhookPayload struct {
+	Event    string `json:"event"`
+	TodoID   int    `json:"todo_id"`
+	Title    string `json:"title"`
+	RemindAt string `json:"remind_at,omitempty"`
+	// Text はSlackのIncoming Webhookでそのまま表示される本文です
+	Text string `json:"text"`
+}
+
+// NewWebhookNotifier はWebhookNotifierのコンストラクタです
+// client には httpclient.Factory で作成した、タイムアウトと再試行が設定されたクライアントを渡します
+func NewWebhookNotifier(client *http.Client, url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: client,
+		url:    url,
+	}
+}
+
+// Notify はリマインダーの内容をWebhookのURLへ送信します
+// 2xx 以外のレスポンスはエラーとして返し、リマインダーは次回のスキャンで再通知されます
+func (n *WebhookNotifier) Notify(ctx context.Context, todo *entity.Todo) error {
+	payload := webhookPayload{
+		Event:  "todo.reminder",
+		TodoID: todo.ID,
+		Title:  todo.Title,
+		Text:   fmt.Sprintf("Reminder: %s", todo.Title),
+	}
+	if todo.RemindAt != nil {
+		payload.RemindAt = todo.RemindAt.Format(time.RFC3339)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// 同じリマインダーの通知であることを受信側が判別できるようにし、再試行を可能にする
+	req.Header.Set("Idempotency-Key", fmt.Sprintf("reminder-%d-%s", todo.ID, payload.RemindAt))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// コンパイル時インターフェース実装確認
+var _ service.ReminderNotifier = (*WebhookNotifier)(nil)
@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// TestWebhookNotifier_Notify はWebhookへの送信内容とレスポンスの扱いをテストします
+func TestWebhookNotifier_Notify(t *testing.T) {
+	remindAt := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	todo := &entity.Todo{ID: 7, Title: "請求書を送る", RemindAt: &remindAt}
+
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{name: "2xxは成功", status: http.StatusNoContent},
+		{name: "4xxはエラー", status: http.StatusBadRequest, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload webhookPayload
+			var idempotencyKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				idempotencyKey = r.Header.Get("Idempotency-Key")
+				json.NewDecoder(r.Body).Decode(&payload)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewWebhookNotifier(server.Client(), server.URL).Notify(context.Background(), todo)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("エラー = %v, エラーを期待 = %v", err, tt.expectError)
+			}
+
+			if payload.Event != "todo.reminder" || payload.TodoID != 7 || payload.RemindAt != "2024-01-02T09:00:00Z" {
+				t.Errorf("送信内容 = %+v", payload)
+			}
+			if idempotencyKey != "reminder-7-2024-01-02T09:00:00Z" {
+				t.Errorf("Idempotency-Key = %q", idempotencyKey)
+			}
+		})
+	}
+}
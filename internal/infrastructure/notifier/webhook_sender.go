@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// HTTPWebhookSender はWebhookの通知を登録されたURLへJSONでPOSTする WebhookSender の実装です
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+// NewHTTPWebhookSender はHTTPWebhookSenderのコンストラクタです
+// client には httpclient.Factory で作成した、タイムアウトと再試行が設定されたクライアントを渡します
+func NewHTTPWebhookSender(client *http.Client) *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		client: client,
+	}
+}
+
+// Send は通知の本文を url へPOSTします
+// 受信側がイベントの種類を本文を読まずに判別できるよう、X-Webhook-Event ヘッダーにも設定します
+func (s *HTTPWebhookSender) Send(ctx context.Context, url string, message service.WebhookMessage) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(message.Body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(message.Event))
+	// 再送でも同じ値を送り、受信側が同じ通知を二重に処理しないようにする
+	req.Header.Set("Idempotency-Key", message.ID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// コンパイル時インターフェース実装確認
+var _ service.WebhookSender = (*HTTPWebhookSender)(nil)
@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// TestHTTPWebhookSender_Send は通知のPOST内容とレスポンスの扱いをテストします
+func TestHTTPWebhookSender_Send(t *testing.T) {
+	message := service.WebhookMessage{
+		ID:    "todo.completed-7-1",
+		Event: entity.WebhookEventTodoCompleted,
+		Body:  []byte(`{"event":"todo.completed"}`),
+	}
+
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{name: "2xxは成功", status: http.StatusAccepted},
+		{name: "5xxはエラー", status: http.StatusServiceUnavailable, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewHTTPWebhookSender(server.Client()).Send(context.Background(), server.URL+"/hooks", message)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("エラー = %v, エラーを期待 = %v", err, tt.expectError)
+			}
+
+			if received.Method != http.MethodPost || received.URL.Path != "/hooks" || string(body) != string(message.Body) {
+				t.Errorf("送信内容 = %s %s %s", received.Method, received.URL.Path, body)
+			}
+			if received.Header.Get("X-Webhook-Event") != "todo.completed" || received.Header.Get("Idempotency-Key") != message.ID {
+				t.Errorf("ヘッダー = %v", received.Header)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/infrastructure/websocket"
+)
+
+// client は1つの WebSocket の接続です
+type client struct {
+	hub  *Hub
+	conn *websocket.Conn
+
+	// visibility は配信してよいTodoの範囲です（接続時に決まり、変わらない）
+	visibility visibility
+
+	// send は送信待ちのメッセージです（writePump だけが取り出す）
+	send chan []byte
+
+	// done は接続をハブから外したときに閉じられ、writePump に切断の通知を依頼します
+	done chan struct{}
+
+	// closeCode と closeReason は切断の通知の内容です（done を閉じる前に hub.mu の中で設定する）
+	closeCode   int
+	closeReason string
+
+	// subscriptionMu は購読の状態を保護します（readPump が更新し、ハブが参照する）
+	subscriptionMu sync.Mutex
+	all            bool
+	todoIDs        map[int]bool
+}
+
+// newClient は接続を client にします（購読は subscribe を受信するまで空です）
+func newClient(hub *Hub, conn *websocket.Conn, visibility visibility) *client {
+	return &client{
+		hub:        hub,
+		conn:       conn,
+		visibility: visibility,
+		send:       make(chan []byte, sendBufferSize),
+		done:       make(chan struct{}),
+		todoIDs:    make(map[int]bool),
+	}
+}
+
+// enqueue はメッセージを送信待ちにします。送信待ちが上限に達している場合は false を返します
+// 呼び出し側は hub.mu を取得しているため、done が閉じられた後に呼び出されることはありません
+func (c *client) enqueue(data []byte) bool {
+	select {
+	case c.send <- data:
+		return true
+	default:
+		return false
+	}
+}
+
+// subscribes は todoID の変更を購読しているかどうかを判定します
+func (c *client) subscribes(todoID int) bool {
+	c.subscriptionMu.Lock()
+	defer c.subscriptionMu.Unlock()
+	return c.all || c.todoIDs[todoID]
+}
+
+// readPump はクライアントからのメッセージを処理します
+// 読み込みに失敗した（切断された・期限内に Pong が届かない）場合は接続をハブから外します
+func (c *client) readPump() {
+	defer c.hub.pumps.Done()
+
+	pongWait := 2 * c.hub.pingInterval
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func() { c.conn.SetReadDeadline(time.Now().Add(pongWait)) })
+
+	for {
+		messageType, data, err := c.conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				log.Printf("WebSocket read from %s failed: %v", c.conn.RemoteAddr(), err)
+			}
+			c.hub.disconnect(c, websocket.CloseNormalClosure, "")
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		if messageType != websocket.TextMessage {
+			c.reply(typedMessage{Type: messageError, Error: "messages must be JSON text"})
+			continue
+		}
+		c.handle(data)
+	}
+}
+
+// handle はクライアントからのメッセージを1件処理します
+func (c *client) handle(data []byte) {
+	var message clientMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		c.reply(typedMessage{Type: messageError, Error: "invalid JSON message"})
+		return
+	}
+
+	switch message.Type {
+	case messageSubscribe:
+		c.subscriptionMu.Lock()
+		if len(message.TodoIDs) == 0 {
+			c.all = true
+		}
+		for _, id := range message.TodoIDs {
+			c.todoIDs[int(id)] = true
+		}
+		c.subscriptionMu.Unlock()
+		c.reply(c.subscriptionState())
+	case messageUnsubscribe:
+		c.subscriptionMu.Lock()
+		if len(message.TodoIDs) == 0 {
+			c.all = false
+			clear(c.todoIDs)
+		}
+		for _, id := range message.TodoIDs {
+			delete(c.todoIDs, int(id))
+		}
+		c.subscriptionMu.Unlock()
+		c.reply(c.subscriptionState())
+	case messagePing:
+		c.reply(typedMessage{Type: messagePong})
+	default:
+		c.reply(typedMessage{Type: messageError, Error: "unknown message type: " + message.Type})
+	}
+}
+
+// subscriptionState は現在の購読の状態のメッセージを返します
+func (c *client) subscriptionState() subscribedMessage {
+	c.subscriptionMu.Lock()
+	defer c.subscriptionMu.Unlock()
+	ids := make([]dto.ID, 0, len(c.todoIDs))
+	for id := range c.todoIDs {
+		ids = append(ids, dto.ID(id))
+	}
+	slices.Sort(ids)
+	return subscribedMessage{Type: messageSubscribed, All: c.all, TodoIDs: ids}
+}
+
+// reply はこのクライアントだけにメッセージを送ります
+func (c *client) reply(message any) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	c.hub.mu.Lock()
+	_, connected := c.hub.clients[c]
+	ok := !connected || c.enqueue(data)
+	c.hub.mu.Unlock()
+	if !ok {
+		c.hub.disconnect(c, websocket.ClosePolicyViolation, "client is too slow")
+	}
+}
+
+// writePump は送信待ちのメッセージを順に送信し、定期的に Ping を送ります
+// ハブから外されたら、送信待ちのメッセージを送り切ってから切断を通知して接続を閉じます
+func (c *client) writePump() {
+	defer c.hub.pumps.Done()
+	defer c.conn.Close()
+
+	ticker := time.NewTicker(c.hub.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case data := <-c.send:
+			if !c.write(data) {
+				c.hub.disconnect(c, websocket.CloseNormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WritePing(nil); err != nil {
+				c.hub.disconnect(c, websocket.CloseNormalClosure, "")
+				return
+			}
+		case <-c.done:
+			c.drain()
+			c.conn.WriteClose(c.closeCode, c.closeReason)
+			return
+		}
+	}
+}
+
+// drain は切断の前に、送信待ちのメッセージを送り切ります（シャットダウン時の配信漏れを防ぐ）
+// 受信が追いつかないために切断する場合は、送り切れないため破棄します
+func (c *client) drain() {
+	if c.closeCode == websocket.ClosePolicyViolation {
+		return
+	}
+	for {
+		select {
+		case data := <-c.send:
+			if !c.write(data) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// write は1件のメッセージを期限付きで送信します
+func (c *client) write(data []byte) bool {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.conn.WriteMessage(websocket.TextMessage, data) == nil
+}
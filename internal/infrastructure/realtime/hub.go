@@ -0,0 +1,252 @@
+// Package realtime はTodoの変更を WebSocket（/ws）でブラウザへリアルタイムに届けるハブです
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/websocket"
+)
+
+const (
+	// sendBufferSize は接続ごとの送信待ちメッセージの上限です
+	// 上限に達した（受信が追いつかない）接続は、他の接続を遅らせないよう切断します
+	sendBufferSize = 64
+
+	// writeWait は1件のメッセージの送信にかけられる時間です
+	writeWait = 10 * time.Second
+
+	// defaultPingInterval はサーバーから Ping を送る間隔です
+	defaultPingInterval = 30 * time.Second
+
+	// maxMessageSize はクライアントから受信するメッセージの上限です
+	maxMessageSize = 4 << 10
+)
+
+// Hub は WebSocket の接続を管理し、Todoの変更を購読している接続へ配信します
+//
+// 学習ポイント（接続ごとの goroutine の分担）：
+//  1. 読み込み（readPump）: クライアントからの購読の指定を処理し、切断を検知する
+//  2. 送信（writePump）: 送信待ちのチャネルから順に送信し、定期的に Ping を送る
+//     1つの接続への送信をこの goroutine だけが行うため、配信する側は送信の完了を待たない
+//  3. ハブ: 接続の一覧と購読の状態を管理し、イベントを該当する接続の送信待ちのチャネルへ入れる
+//
+// シャットダウン（Shutdown）では新しい接続を断り、送信待ちのメッセージを送り切ってから
+// 1001 Going Away で切断を通知します
+type Hub struct {
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	closing bool
+
+	// pumps は全ての接続の readPump / writePump の完了を待つためのものです
+	pumps sync.WaitGroup
+
+	upgradeOptions websocket.UpgradeOptions
+	pingInterval   time.Duration
+}
+
+// HubOption はHubに任意の設定を行う関数型オプションです
+type HubOption func(*Hub)
+
+// WithAllowedOrigins は同じオリジンに加えて、接続を許可するオリジン（例: https://app.example.com）を指定します
+// "*" を指定すると全てのオリジンを許可します（開発用）
+func WithAllowedOrigins(origins []string) HubOption {
+	return func(h *Hub) {
+		if len(origins) == 0 {
+			return
+		}
+		h.upgradeOptions.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" || slices.Contains(origins, "*") || slices.Contains(origins, origin) {
+				return true
+			}
+			u, err := url.Parse(origin)
+			return err == nil && strings.EqualFold(u.Host, r.Host)
+		}
+	}
+}
+
+// WithPingInterval はサーバーから Ping を送る間隔を指定します
+// 間隔の2倍の時間にわたって Pong もメッセージも届かない接続は切断します
+func WithPingInterval(interval time.Duration) HubOption {
+	return func(h *Hub) {
+		h.pingInterval = interval
+	}
+}
+
+// NewHub はHubのコンストラクタです
+func NewHub(opts ...HubOption) *Hub {
+	h := &Hub{
+		clients:      make(map[*client]struct{}),
+		pingInterval: defaultPingInterval,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Subscribe はTodoのイベントを購読し、変更を JSON Patch として接続へ配信します
+// 配信は送信待ちのチャネルに入れるだけのため、イベントを発行したリクエストを待たせません
+func (h *Hub) Subscribe(bus *event.Bus) {
+	event.SubscribeTodoEvents(bus, func(ctx context.Context, e event.TodoEvent) {
+		message, err := newPatchMessage(e)
+		if err != nil {
+			log.Printf("Failed to encode realtime patch for %s: %v", e.EventName(), err)
+			return
+		}
+		h.broadcast(e.Change().Todo(), message)
+	})
+}
+
+// ServeHTTP は GET /ws のハンドシェイクを行い、接続をハブに登録します
+// 接続の処理は別の goroutine で続けるため、ハンドラーはすぐに戻ります
+//
+// 認証が有効な場合、ルーターはアクセストークン（ブラウザはセッションのCookie）を検証してから呼び出します
+// 接続にはハンドシェイクのリクエストの所有者・ワークスペースを記録し、その範囲のTodoの変更だけを配信します
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	closing := h.closing
+	h.mu.Unlock()
+	if closing {
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := websocket.Upgrade(w, r, h.upgradeOptions)
+	if err != nil {
+		log.Printf("WebSocket upgrade rejected from %s: %v", r.RemoteAddr, err)
+		return
+	}
+
+	c := newClient(h, conn, visibilityFromContext(r.Context()))
+	h.mu.Lock()
+	if h.closing {
+		h.mu.Unlock()
+		conn.WriteClose(websocket.CloseGoingAway, "server is shutting down")
+		conn.Close()
+		return
+	}
+	h.clients[c] = struct{}{}
+	h.pumps.Add(2)
+	h.mu.Unlock()
+
+	go c.writePump()
+	go c.readPump()
+}
+
+// Len は接続中のクライアントの数を返します
+func (h *Hub) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// Shutdown は新しい接続を断り、全ての接続へ送信待ちのメッセージを送り切ってから切断を通知します
+// ctx が終了するまでに終わらない接続は、通知を待たずに閉じて ctx のエラーを返します
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	h.closing = true
+	clients := make([]*client, 0, len(h.clients))
+	for c := range h.clients {
+		clients = append(clients, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range clients {
+		h.disconnect(c, websocket.CloseGoingAway, "server is shutting down")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.pumps.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, c := range clients {
+			c.conn.Close()
+		}
+		return ctx.Err()
+	}
+}
+
+// broadcast は todo を参照でき、購読している接続へメッセージを送ります
+// 送信待ちが上限に達している接続は、受信が追いつかないものとして切断します
+func (h *Hub) broadcast(todo *entity.Todo, message any) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to encode realtime message: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	var slow []*client
+	for c := range h.clients {
+		if !c.visibility.allows(todo) || !c.subscribes(todo.ID) {
+			continue
+		}
+		if !c.enqueue(data) {
+			slow = append(slow, c)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, c := range slow {
+		log.Printf("Disconnecting slow WebSocket client %s", c.conn.RemoteAddr())
+		h.disconnect(c, websocket.ClosePolicyViolation, "client is too slow")
+	}
+}
+
+// disconnect は接続をハブから外し、writePump に切断の通知を依頼します（2回目以降は何もしない）
+func (h *Hub) disconnect(c *client, code int, reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+	delete(h.clients, c)
+	c.closeCode, c.closeReason = code, reason
+	close(c.done)
+}
+
+// visibility は接続に配信してよいTodoの範囲です（REST のTodoの一覧と同じ範囲）
+//   - ワークスペースを指定した場合: そのワークスペースのTodo
+//   - 認証されたユーザーの場合: 本人の個人のTodo
+//   - 認証が無効な場合: 全てのTodo
+type visibility struct {
+	userID, workspaceID   int
+	hasUser, hasWorkspace bool
+}
+
+// visibilityFromContext はハンドシェイクのリクエストのコンテキストから、配信してよいTodoの範囲を決めます
+func visibilityFromContext(ctx context.Context) visibility {
+	var v visibility
+	v.workspaceID, v.hasWorkspace = repository.WorkspaceFromContext(ctx)
+	v.userID, v.hasUser = repository.OwnerFromContext(ctx)
+	return v
+}
+
+// allows は todo を配信してよいかどうかを判定します
+func (v visibility) allows(todo *entity.Todo) bool {
+	switch {
+	case v.hasWorkspace:
+		return todo.WorkspaceID != nil && *todo.WorkspaceID == v.workspaceID
+	case v.hasUser:
+		return todo.UserID != nil && *todo.UserID == v.userID && todo.WorkspaceID == nil
+	default:
+		return true
+	}
+}
@@ -0,0 +1,289 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+	"todoapp-api-golang/internal/domain/repository"
+	"todoapp-api-golang/internal/infrastructure/websocket"
+)
+
+// newTestHub はHubとイベントバスを httptest のサーバーで起動します
+func newTestHub(t *testing.T, opts ...HubOption) (*Hub, *event.Bus, string) {
+	t.Helper()
+	bus := event.NewBus()
+	hub := NewHub(opts...)
+	hub.Subscribe(bus)
+	server := httptest.NewServer(hub)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		hub.Shutdown(ctx)
+		server.Close()
+	})
+	return hub, bus, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// connect はハブに接続し、subscribe を送って購読の状態の応答を待ちます
+func connect(t *testing.T, url string, todoIDs ...dto.ID) *websocket.Conn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	send(t, conn, clientMessage{Type: messageSubscribe, TodoIDs: todoIDs})
+	var subscribed subscribedMessage
+	receive(t, conn, &subscribed)
+	if subscribed.Type != messageSubscribed {
+		t.Fatalf("subscribe の応答が %q になっています", subscribed.Type)
+	}
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, message any) {
+	t.Helper()
+	data, _ := json.Marshal(message)
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+}
+
+func receive(t *testing.T, conn *websocket.Conn, v any) {
+	t.Helper()
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("メッセージを解析できません: %v (%s)", err, data)
+	}
+}
+
+// waitForClients はハブに登録された接続の数が n になるまで待ちます
+func waitForClients(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for hub.Len() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("接続の数が %d になりません（%d）", n, hub.Len())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestHub_DeliversPatchesToSubscribers は購読しているTodoの変更だけが届くことをテストします
+func TestHub_DeliversPatchesToSubscribers(t *testing.T) {
+	_, bus, url := newTestHub(t)
+	all := connect(t, url)
+	onlyTwo := connect(t, url, 2)
+
+	before := &entity.Todo{ID: 1, Title: "買い物"}
+	after := *before
+	after.Title = "牛乳を買う"
+	bus.Publish(context.Background(), event.NewTodoEvent(entity.TodoHistoryUpdated, before, &after))
+	bus.Publish(context.Background(), event.NewTodoEvent(entity.TodoHistoryDeleted, &entity.Todo{ID: 2}, nil))
+
+	var patch patchMessage
+	receive(t, all, &patch)
+	if patch.Event != "todo.updated" || patch.TodoID != 1 {
+		t.Fatalf("最初のメッセージが %s (todo_id=%d) になっています", patch.Event, patch.TodoID)
+	}
+	if len(patch.Patch) != 1 || patch.Patch[0].Op != "replace" || patch.Patch[0].Path != "/title" ||
+		string(patch.Patch[0].Value) != `"牛乳を買う"` {
+		t.Errorf("更新の patch = %+v", patch.Patch)
+	}
+	receive(t, all, &patch)
+	if patch.Event != "todo.deleted" || patch.TodoID != 2 {
+		t.Errorf("2つ目のメッセージが %s (todo_id=%d) になっています", patch.Event, patch.TodoID)
+	}
+
+	// todo_ids を指定した接続には、ID=2 の変更だけが届く
+	receive(t, onlyTwo, &patch)
+	if patch.Event != "todo.deleted" || patch.TodoID != 2 {
+		t.Errorf("ID=2 だけを購読した接続に %s (todo_id=%d) が届きました", patch.Event, patch.TodoID)
+	}
+}
+
+// TestHub_ClientMessages はクライアントからのメッセージへの応答をテストします
+func TestHub_ClientMessages(t *testing.T) {
+	_, _, url := newTestHub(t)
+	conn := connect(t, url, 3, 1)
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{name: "ping", message: `{"type":"ping"}`, want: `{"type":"pong"}`},
+		{name: "購読の追加", message: `{"type":"subscribe","todo_ids":[2]}`, want: `{"type":"subscribed","all":false,"todo_ids":[1,2,3]}`},
+		{name: "購読の解除", message: `{"type":"unsubscribe","todo_ids":[1,3]}`, want: `{"type":"subscribed","all":false,"todo_ids":[2]}`},
+		{name: "全ての購読", message: `{"type":"subscribe"}`, want: `{"type":"subscribed","all":true,"todo_ids":[2]}`},
+		{name: "全ての解除", message: `{"type":"unsubscribe"}`, want: `{"type":"subscribed","all":false,"todo_ids":[]}`},
+		{name: "不明な種類", message: `{"type":"dance"}`, want: `{"type":"error","error":"unknown message type: dance"}`},
+		{name: "不正なJSON", message: `{`, want: `{"type":"error","error":"invalid JSON message"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(tt.message)); err != nil {
+				t.Fatalf("WriteMessage() error = %v", err)
+			}
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("ReadMessage() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("応答 = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+// TestHub_Shutdown は送信待ちのメッセージを送り切ってから 1001 で切断し、新しい接続を断ることをテストします
+func TestHub_Shutdown(t *testing.T) {
+	hub, bus, url := newTestHub(t)
+	conn := connect(t, url)
+	waitForClients(t, hub, 1)
+
+	bus.Publish(context.Background(), event.NewTodoEvent(entity.TodoHistoryCreated, nil, &entity.Todo{ID: 5, Title: "最後の変更"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- hub.Shutdown(ctx) }()
+
+	var patch patchMessage
+	receive(t, conn, &patch)
+	if patch.TodoID != 5 {
+		t.Errorf("シャットダウン前の変更が届いていません: %+v", patch)
+	}
+
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseGoingAway {
+		t.Errorf("ReadMessage() error = %v, want 1001 の CloseError", err)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+	if hub.Len() != 0 {
+		t.Errorf("シャットダウン後に %d 件の接続が残っています", hub.Len())
+	}
+
+	// シャットダウン後の接続は 503 で断る
+	resp, err := http.Get("http" + strings.TrimPrefix(url, "ws"))
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("シャットダウン後のステータス = %d, want 503", resp.StatusCode)
+	}
+}
+
+// TestWithAllowedOrigins は許可するオリジンの判定をテストします
+func TestWithAllowedOrigins(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		want    bool
+	}{
+		{name: "同じオリジン", allowed: []string{"https://app.example.com"}, origin: "http://api.example.com", want: true},
+		{name: "許可したオリジン", allowed: []string{"https://app.example.com"}, origin: "https://app.example.com", want: true},
+		{name: "許可していないオリジン", allowed: []string{"https://app.example.com"}, origin: "https://evil.example.com", want: false},
+		{name: "全て許可", allowed: []string{"*"}, origin: "https://evil.example.com", want: true},
+		{name: "Originなし", allowed: []string{"https://app.example.com"}, origin: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(WithAllowedOrigins(tt.allowed))
+			r := httptest.NewRequest(http.MethodGet, "http://api.example.com/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := hub.upgradeOptions.CheckOrigin(r); got != tt.want {
+				t.Errorf("CheckOrigin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHub_DeliversOnlyVisibleTodos は接続時の所有者・ワークスペースのTodoの変更だけが届くことをテストします
+func TestHub_DeliversOnlyVisibleTodos(t *testing.T) {
+	bus := event.NewBus()
+	hub := NewHub()
+	hub.Subscribe(bus)
+	// 認証の代わりに、テスト用のヘッダーで所有者・ワークスペースを設定する
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if r.Header.Get("X-Test-User") != "" {
+			ctx = repository.WithOwner(ctx, 1)
+		}
+		if r.Header.Get("X-Test-Workspace") != "" {
+			ctx = repository.WithWorkspace(ctx, 10)
+		}
+		hub.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		hub.Shutdown(ctx)
+		server.Close()
+	})
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	dial := func(header http.Header) *websocket.Conn {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := websocket.Dial(ctx, url, header)
+		if err != nil {
+			t.Fatalf("Dial() error = %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		send(t, conn, clientMessage{Type: messageSubscribe})
+		var subscribed subscribedMessage
+		receive(t, conn, &subscribed)
+		return conn
+	}
+	user := dial(http.Header{"X-Test-User": {"1"}})
+	workspace := dial(http.Header{"X-Test-User": {"1"}, "X-Test-Workspace": {"10"}})
+	waitForClients(t, hub, 2)
+
+	owner, other, workspaceID := 1, 2, 10
+	for _, todo := range []*entity.Todo{
+		{ID: 1, Title: "他のユーザー", UserID: &other},
+		{ID: 2, Title: "他のワークスペース", UserID: &owner, WorkspaceID: &other},
+		{ID: 3, Title: "ワークスペース", UserID: &other, WorkspaceID: &workspaceID},
+		{ID: 4, Title: "本人", UserID: &owner},
+	} {
+		bus.Publish(context.Background(), event.NewTodoEvent(entity.TodoHistoryCreated, nil, todo))
+	}
+
+	var patch patchMessage
+	receive(t, user, &patch)
+	if patch.TodoID != 4 {
+		t.Errorf("ユーザーの接続に todo_id=%d が届きました（期待値 4）", patch.TodoID)
+	}
+	receive(t, workspace, &patch)
+	if patch.TodoID != 3 {
+		t.Errorf("ワークスペースの接続に todo_id=%d が届きました（期待値 3）", patch.TodoID)
+	}
+}
@@ -0,0 +1,159 @@
+package realtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/event"
+)
+
+// クライアントとサーバーがやり取りするメッセージ（JSONのテキストメッセージ）の種類です
+//
+// クライアント -> サーバー：
+//
+//	{"type": "subscribe", "todo_ids": [1, 2]}    指定したTodoの変更を購読（todo_ids を省略すると全てのTodo）
+//	{"type": "unsubscribe", "todo_ids": [1]}     購読の解除（todo_ids を省略すると全て解除）
+//	{"type": "ping"}                             アプリケーションレベルの生存確認（pong を返す）
+//
+// サーバー -> クライアント：
+//
+//	{"type": "subscribed", "all": false, "todo_ids": [1, 2]}                   購読の状態
+//	{"type": "patch", "event": "todo.updated", "todo_id": 1, "patch": [...]}   Todoの変更（JSON Patch）
+//	{"type": "pong"}
+//	{"type": "error", "error": "..."}
+const (
+	messageSubscribe   = "subscribe"
+	messageUnsubscribe = "unsubscribe"
+	messagePing        = "ping"
+	messageSubscribed  = "subscribed"
+	messagePatch       = "patch"
+	messagePong        = "pong"
+	messageError       = "error"
+)
+
+// clientMessage はクライアントから受信するメッセージです
+type clientMessage struct {
+	Type    string   `json:"type"`
+	TodoIDs []dto.ID `json:"todo_ids"`
+}
+
+// subscribedMessage は購読の状態を知らせるメッセージです
+type subscribedMessage struct {
+	Type    string   `json:"type"`
+	All     bool     `json:"all"`
+	TodoIDs []dto.ID `json:"todo_ids"`
+}
+
+// patchMessage はTodoの変更を知らせるメッセージです
+type patchMessage struct {
+	Type   string           `json:"type"`
+	Event  string           `json:"event"`
+	TodoID dto.ID           `json:"todo_id"`
+	Patch  []patchOperation `json:"patch"`
+}
+
+// patchOperation は JSON Patch（RFC 6902）の1つの操作です
+// パスはTodoのJSON表現（GET /api/v1/todos/{id} と同じ形式）を基準にします
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// typedMessage は種類だけのメッセージです
+type typedMessage struct {
+	Type  string `json:"type"`
+	Error string `json:"error,omitempty"`
+}
+
+// pointerEscaper は JSON Pointer（RFC 6901）のパスの要素をエスケープします
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// newPatchMessage はTodoのイベントを、変更前から変更後への JSON Patch のメッセージにします
+//   - 作成・復元: Todo全体の add
+//   - 削除: Todo全体の remove
+//   - それ以外: 変わった項目ごとの replace / add / remove
+func newPatchMessage(e event.TodoEvent) (patchMessage, error) {
+	change := e.Change()
+	message := patchMessage{Type: messagePatch, Event: e.EventName(), TodoID: dto.ID(change.Todo().ID)}
+
+	switch {
+	case change.Before == nil:
+		value, err := todoJSON(change.After)
+		if err != nil {
+			return message, err
+		}
+		message.Patch = []patchOperation{{Op: "add", Path: "", Value: value}}
+	case change.After == nil:
+		message.Patch = []patchOperation{{Op: "remove", Path: ""}}
+	default:
+		patch, err := diffTodos(change.Before, change.After)
+		if err != nil {
+			return message, err
+		}
+		message.Patch = patch
+	}
+	return message, nil
+}
+
+// diffTodos は2つのTodoのJSON表現の最上位の項目を比較し、JSON Patch を返します
+func diffTodos(before, after *entity.Todo) ([]patchOperation, error) {
+	beforeFields, err := todoFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := todoFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	// 操作の順序を一定にするため、項目名の順に比較する
+	names := make([]string, 0, len(beforeFields)+len(afterFields))
+	for name := range beforeFields {
+		names = append(names, name)
+	}
+	for name := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	patch := []patchOperation{}
+	for _, name := range names {
+		path := "/" + pointerEscaper.Replace(name)
+		oldValue, hadOld := beforeFields[name]
+		newValue, hasNew := afterFields[name]
+		switch {
+		case !hasNew:
+			patch = append(patch, patchOperation{Op: "remove", Path: path})
+		case !hadOld:
+			patch = append(patch, patchOperation{Op: "add", Path: path, Value: newValue})
+		case !bytes.Equal(oldValue, newValue):
+			patch = append(patch, patchOperation{Op: "replace", Path: path, Value: newValue})
+		}
+	}
+	return patch, nil
+}
+
+// todoJSON はTodoをAPIのレスポンスと同じJSON表現にします
+func todoJSON(todo *entity.Todo) (json.RawMessage, error) {
+	return json.Marshal(dto.ToTodoResponse(todo))
+}
+
+// todoFields はTodoのJSON表現を最上位の項目ごとに分けます
+func todoFields(todo *entity.Todo) (map[string]json.RawMessage, error) {
+	data, err := todoJSON(todo)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,233 @@
+// Package replay は記録したHTTP通信（middleware.TrafficRecorderMiddleware）を再送信し、
+// 記録時と同じレスポンスが返るかを比較します
+//
+// 利用者から報告された不具合を、修正したビルドに同じリクエストを送って再現・確認するための開発用ツールです
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"todoapp-api-golang/internal/application/middleware"
+)
+
+// DefaultIgnoreFields は比較時に無視する、実行のたびに変わるJSONのキーです
+var DefaultIgnoreFields = []string{"server_time", "created_at", "updated_at", "changed_at"}
+
+// skippedHeaders は再送信時に引き継がないヘッダーです（接続ごとに決まるもの）
+var skippedHeaders = []string{"Host", "Content-Length", "Connection", "Accept-Encoding"}
+
+// Recording はファイルから読み込んだ記録です
+type Recording struct {
+	// Name はファイル名です
+	Name string
+
+	Exchange middleware.RecordedExchange
+}
+
+// Load はディレクトリ内の記録（*.json）を名前順（＝受信順）に読み込みます
+func Load(dir string) ([]Recording, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	recordings := make([]Recording, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var exchange middleware.RecordedExchange
+		if err := json.Unmarshal(data, &exchange); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		recordings = append(recordings, Recording{Name: filepath.Base(path), Exchange: exchange})
+	}
+	return recordings, nil
+}
+
+// Result は1件の再送信の結果です
+type Result struct {
+	Recording Recording
+
+	// Status は再送信で返ったステータスコードです（送信できなかった場合は 0）
+	Status int
+
+	// Skipped が空でない場合は再送信しなかった理由です
+	Skipped string
+
+	// Diff が空でない場合は記録時のレスポンスとの差分の説明です
+	Diff string
+
+	// Err は送信に失敗した場合のエラーです
+	Err error
+}
+
+// OK は記録時と同じレスポンスが返ったかどうかを返します（スキップした場合も true）
+func (r Result) OK() bool {
+	return r.Err == nil && r.Diff == ""
+}
+
+// Replayer は記録を対象のサーバーに再送信します
+type Replayer struct {
+	// Client は再送信に使用するHTTPクライアントです
+	Client *http.Client
+
+	// Target は再送信先のURL（例: http://localhost:8080）です
+	Target string
+
+	// Header は全てのリクエストに追加するヘッダーです
+	// 記録時に伏せた認証情報を補うために使用します
+	Header http.Header
+
+	// IgnoreFields は比較時に無視するJSONのキーです（階層を問わず一致したキーを除外）
+	IgnoreFields []string
+}
+
+// Replay は記録を1件再送信し、記録時のレスポンスと比較します
+func (rp *Replayer) Replay(ctx context.Context, recording Recording) Result {
+	result := Result{Recording: recording}
+	recorded := recording.Exchange
+
+	if recorded.Request.BodyTruncated {
+		result.Skipped = "request body was truncated when recorded"
+		return result
+	}
+	body, err := recorded.Request.Bytes()
+	if err != nil {
+		result.Err = fmt.Errorf("failed to decode request body: %w", err)
+		return result
+	}
+
+	req, err := http.NewRequestWithContext(ctx, recorded.Request.Method, strings.TrimSuffix(rp.Target, "/")+recorded.Request.URI, bytes.NewReader(body))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	for name, values := range recorded.Request.Header {
+		if len(values) == 1 && values[0] == middleware.RedactedValue {
+			continue
+		}
+		req.Header[name] = append([]string(nil), values...)
+	}
+	for _, name := range skippedHeaders {
+		req.Header.Del(name)
+	}
+	for name, values := range rp.Header {
+		req.Header[name] = values
+	}
+
+	resp, err := rp.Client.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	var got bytes.Buffer
+	if _, err := got.ReadFrom(resp.Body); err != nil {
+		result.Err = fmt.Errorf("failed to read response body: %w", err)
+		return result
+	}
+	result.Status = resp.StatusCode
+
+	if resp.StatusCode != recorded.Response.Status {
+		result.Diff = fmt.Sprintf("status %d, recorded %d", resp.StatusCode, recorded.Response.Status)
+		return result
+	}
+	if recorded.Response.BodyTruncated {
+		return result
+	}
+	want, err := recorded.Response.Bytes()
+	if err != nil {
+		result.Err = fmt.Errorf("failed to decode recorded response body: %w", err)
+		return result
+	}
+	result.Diff = rp.compareBodies(want, got.Bytes())
+	return result
+}
+
+// compareBodies はレスポンスボディを比較し、差分の説明を返します（一致した場合は空文字）
+// 両方がJSONの場合は無視するキーを除いて値で比較し、最初に異なる箇所のパスを返します
+func (rp *Replayer) compareBodies(want, got []byte) string {
+	var wantJSON, gotJSON any
+	if json.Unmarshal(want, &wantJSON) != nil || json.Unmarshal(got, &gotJSON) != nil {
+		if !bytes.Equal(want, got) {
+			return "body differs"
+		}
+		return ""
+	}
+
+	ignore := make(map[string]bool, len(rp.IgnoreFields))
+	for _, field := range rp.IgnoreFields {
+		ignore[field] = true
+	}
+	return diffJSON("$", wantJSON, gotJSON, ignore)
+}
+
+// diffJSON は2つのJSONの値を再帰的に比較し、最初に異なる箇所を説明します
+func diffJSON(path string, want, got any, ignore map[string]bool) string {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return fmt.Sprintf("%s: type differs", path)
+		}
+		keys := make([]string, 0, len(w)+len(g))
+		for key := range w {
+			keys = append(keys, key)
+		}
+		for key := range g {
+			if _, ok := w[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if ignore[key] {
+				continue
+			}
+			wv, wok := w[key]
+			gv, gok := g[key]
+			if !gok {
+				return fmt.Sprintf("%s.%s: missing in replayed response", path, key)
+			}
+			if !wok {
+				return fmt.Sprintf("%s.%s: not in recorded response", path, key)
+			}
+			if diff := diffJSON(path+"."+key, wv, gv, ignore); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			return fmt.Sprintf("%s: type differs", path)
+		}
+		if len(w) != len(g) {
+			return fmt.Sprintf("%s: length %d, recorded %d", path, len(g), len(w))
+		}
+		for i := range w {
+			if diff := diffJSON(fmt.Sprintf("%s[%d]", path, i), w[i], g[i], ignore); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	default:
+		if !reflect.DeepEqual(want, got) {
+			return fmt.Sprintf("%s: %v, recorded %v", path, got, want)
+		}
+		return ""
+	}
+}
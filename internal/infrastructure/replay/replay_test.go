@@ -0,0 +1,99 @@
+package replay
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/internal/application/middleware"
+)
+
+// TestReplayer_Replay は再送信と記録時のレスポンスとの比較をテストします
+func TestReplayer_Replay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 伏せた認証ヘッダーは送らず、-header で指定した値を送る
+		if r.Header.Get("Authorization") != "Bearer replay" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"title":` + string(body) + `,"updated_at":"2025-06-01T00:00:00Z","tags":["a"]}`))
+	}))
+	defer server.Close()
+
+	replayer := &Replayer{
+		Client:       server.Client(),
+		Target:       server.URL,
+		Header:       http.Header{"Authorization": {"Bearer replay"}},
+		IgnoreFields: DefaultIgnoreFields,
+	}
+
+	exchange := func(reqBody, respBody string, status int) middleware.RecordedExchange {
+		return middleware.RecordedExchange{
+			Request: middleware.RecordedRequest{
+				Method:       http.MethodPost,
+				URI:          "/api/v1/todos",
+				Header:       http.Header{"Authorization": {middleware.RedactedValue}},
+				RecordedBody: middleware.RecordedBody{Body: reqBody},
+			},
+			Response: middleware.RecordedResponse{Status: status, RecordedBody: middleware.RecordedBody{Body: respBody}},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		exchange     middleware.RecordedExchange
+		expectedOK   bool
+		expectedDiff string
+	}{
+		{
+			name:       "一致（更新日時は無視）",
+			exchange:   exchange(`"a"`, `{"title":"a","updated_at":"2024-01-01T00:00:00Z","tags":["a"]}`, http.StatusOK),
+			expectedOK: true,
+		},
+		{
+			name:         "値が異なる",
+			exchange:     exchange(`"b"`, `{"title":"a","tags":["a"]}`, http.StatusOK),
+			expectedDiff: "$.title: b, recorded a",
+		},
+		{
+			name:         "配列の長さが異なる",
+			exchange:     exchange(`"a"`, `{"title":"a","tags":["a","b"]}`, http.StatusOK),
+			expectedDiff: "$.tags: length 1, recorded 2",
+		},
+		{
+			name:         "ステータスコードが異なる",
+			exchange:     exchange(`"a"`, `{}`, http.StatusCreated),
+			expectedDiff: "status 200, recorded 201",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := replayer.Replay(context.Background(), Recording{Name: tt.name, Exchange: tt.exchange})
+
+			if result.Err != nil {
+				t.Fatalf("予期しないエラーが発生しました: %v", result.Err)
+			}
+			if result.OK() != tt.expectedOK || result.Diff != tt.expectedDiff {
+				t.Errorf("OK() = %v, Diff = %q, 期待値 = %v, %q", result.OK(), result.Diff, tt.expectedOK, tt.expectedDiff)
+			}
+		})
+	}
+}
+
+// TestReplayer_Replay_TruncatedBody はボディが切り詰められた記録をスキップすることをテストします
+func TestReplayer_Replay_TruncatedBody(t *testing.T) {
+	replayer := &Replayer{Client: http.DefaultClient, Target: "http://127.0.0.1:0"}
+	recording := Recording{Exchange: middleware.RecordedExchange{
+		Request: middleware.RecordedRequest{Method: http.MethodPost, URI: "/api/v1/todos", RecordedBody: middleware.RecordedBody{BodyTruncated: true}},
+	}}
+
+	result := replayer.Replay(context.Background(), recording)
+	if result.Skipped == "" || !result.OK() {
+		t.Errorf("スキップを期待しました: %+v", result)
+	}
+}
@@ -0,0 +1,263 @@
+// Package smoketest はデプロイ後の動作確認（スモークテスト）を行います
+//
+// 対象のサーバーに Todo の作成 → 取得 → 一覧 → 完了 → 削除 を順に実行し、
+// ステータスコードとレスポンスの内容を検証します。CI/CD のパイプラインでデプロイの直後に実行し、
+// 失敗した場合にロールバックするといった使い方を想定しています
+//
+// 本番環境に対して実行するため、作成したTodoは途中で失敗した場合も最後に削除を試みます
+package smoketest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Step は1つの手順の結果です
+type Step struct {
+	// Name は手順の名前です（例: "create"）
+	Name string
+
+	// Method と Path は送信したリクエストです
+	Method string
+	Path   string
+
+	// Status は返ったステータスコードです（送信できなかった場合は 0）
+	Status int
+
+	// Duration はレスポンスを受け取るまでの時間です
+	Duration time.Duration
+
+	// Err は検証に失敗した場合のエラーです
+	Err error
+}
+
+// OK は手順が成功したかどうかを返します
+func (s Step) OK() bool {
+	return s.Err == nil
+}
+
+// Runner はスモークテストの手順を対象のサーバーに実行します
+type Runner struct {
+	// Client はリクエストに使用するHTTPクライアントです
+	Client *http.Client
+
+	// BaseURL は対象のサーバーのURLです（ベースパスで公開している場合はそれも含める。例: https://example.com/todo）
+	BaseURL string
+
+	// Header は全てのリクエストに追加するヘッダーです（認証が必要な環境向け）
+	Header http.Header
+
+	// Title は作成するTodoのタイトルです（空の場合は "smoketest " と現在時刻）
+	Title string
+
+	// ReadyTimeout は /health が成功するまで再試行する時間です（デプロイ直後の起動待ち。0 の場合は再試行しない）
+	ReadyTimeout time.Duration
+
+	// RetryInterval は /health を再試行する間隔です（デフォルト: 1秒）
+	RetryInterval time.Duration
+}
+
+// todo はレスポンスのうち、検証に使うフィールドです
+type todo struct {
+	ID          json.RawMessage `json:"id"`
+	Title       string          `json:"title"`
+	IsCompleted bool            `json:"is_completed"`
+}
+
+// Run は手順を順に実行し、各手順の結果を返します
+// 失敗した手順より後は実行しません（前の手順で作成したTodoを使うため）
+// ただし、作成したTodoを削除する前に失敗した場合は、最後に "cleanup" として削除を試みます
+func (r *Runner) Run(ctx context.Context) (steps []Step) {
+	title := r.Title
+	if title == "" {
+		title = "smoketest " + time.Now().UTC().Format(time.RFC3339)
+	}
+
+	run := func(name, method, path string, body any, expectedStatus int, check func([]byte) error) bool {
+		step := r.do(ctx, name, method, path, body, expectedStatus, check)
+		steps = append(steps, step)
+		return step.OK()
+	}
+
+	health := r.waitReady(ctx)
+	steps = append(steps, health)
+	if !health.OK() {
+		return steps
+	}
+
+	var created todo
+	ok := run("create", http.MethodPost, "/api/v1/todos", map[string]string{"title": title}, http.StatusCreated, func(body []byte) error {
+		if err := json.Unmarshal(body, &created); err != nil {
+			return fmt.Errorf("invalid todo: %w", err)
+		}
+		if len(created.ID) == 0 {
+			return fmt.Errorf("id is missing")
+		}
+		return expectTodo(created, title, false)
+	})
+	if !ok {
+		return steps
+	}
+	id, err := pathID(created.ID)
+	if err != nil {
+		steps[len(steps)-1].Err = err
+		return steps
+	}
+	todoPath := "/api/v1/todos/" + url.PathEscape(id)
+
+	deleted := false
+	defer func() {
+		if !deleted {
+			run("cleanup", http.MethodDelete, todoPath, nil, http.StatusNoContent, nil)
+		}
+	}()
+
+	ok = run("get", http.MethodGet, todoPath, nil, http.StatusOK, func(body []byte) error {
+		var got todo
+		if err := json.Unmarshal(body, &got); err != nil {
+			return fmt.Errorf("invalid todo: %w", err)
+		}
+		return expectTodo(got, title, false)
+	}) && run("list", http.MethodGet, "/api/v1/todos?limit=100", nil, http.StatusOK, func(body []byte) error {
+		var list struct {
+			Todos []todo `json:"todos"`
+		}
+		if err := json.Unmarshal(body, &list); err != nil {
+			return fmt.Errorf("invalid todo list: %w", err)
+		}
+		for _, t := range list.Todos {
+			if bytes.Equal(t.ID, created.ID) {
+				return nil
+			}
+		}
+		return fmt.Errorf("created todo %s is not in the list", created.ID)
+	}) && run("complete", http.MethodPatch, todoPath+"/complete", nil, http.StatusOK, func(body []byte) error {
+		var got todo
+		if err := json.Unmarshal(body, &got); err != nil {
+			return fmt.Errorf("invalid todo: %w", err)
+		}
+		return expectTodo(got, title, true)
+	})
+	if !ok {
+		return steps
+	}
+
+	if !run("delete", http.MethodDelete, todoPath, nil, http.StatusNoContent, nil) {
+		return steps
+	}
+	deleted = true
+	run("verify-deleted", http.MethodGet, todoPath, nil, http.StatusNotFound, nil)
+	return steps
+}
+
+// waitReady は ReadyTimeout の間、/health が成功するまで再試行します（最後の結果を返します）
+func (r *Runner) waitReady(ctx context.Context) Step {
+	interval := r.RetryInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	deadline := time.Now().Add(r.ReadyTimeout)
+	for {
+		step := r.do(ctx, "health", http.MethodGet, "/health", nil, http.StatusOK, nil)
+		if step.OK() || time.Now().Add(interval).After(deadline) {
+			return step
+		}
+		select {
+		case <-ctx.Done():
+			return step
+		case <-time.After(interval):
+		}
+	}
+}
+
+// do はリクエストを1つ送信し、ステータスコードとレスポンスを検証します
+func (r *Runner) do(ctx context.Context, name, method, path string, body any, expectedStatus int, check func([]byte) error) Step {
+	step := Step{Name: name, Method: method, Path: path}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			step.Err = err
+			return step
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(r.BaseURL, "/")+path, reader)
+	if err != nil {
+		step.Err = err
+		return step
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, values := range r.Header {
+		req.Header[name] = values
+	}
+
+	start := time.Now()
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		step.Err = err
+		return step
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	step.Duration = time.Since(start)
+	step.Status = resp.StatusCode
+	if err != nil {
+		step.Err = fmt.Errorf("failed to read response body: %w", err)
+		return step
+	}
+
+	if resp.StatusCode != expectedStatus {
+		step.Err = fmt.Errorf("status %d, expected %d: %s", resp.StatusCode, expectedStatus, truncate(data, 200))
+		return step
+	}
+	if check != nil {
+		step.Err = check(data)
+	}
+	return step
+}
+
+// expectTodo はTodoのタイトルと完了状態を検証します
+func expectTodo(t todo, title string, completed bool) error {
+	if t.Title != title {
+		return fmt.Errorf("title %q, expected %q", t.Title, title)
+	}
+	if t.IsCompleted != completed {
+		return fmt.Errorf("is_completed %v, expected %v", t.IsCompleted, completed)
+	}
+	return nil
+}
+
+// pathID はレスポンスの id をURLのパスに使う文字列にします
+// IDは整数のほか、文字列（RESPONSE_STRING_IDS・ID_OBFUSCATION_SALT を設定したサーバー）の場合があります
+func pathID(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", fmt.Errorf("invalid id %s", raw)
+	}
+	return n.String(), nil
+}
+
+// truncate はエラーメッセージに含めるレスポンスボディを n バイトまでに切り詰めます
+func truncate(data []byte, n int) string {
+	if len(data) <= n {
+		return string(data)
+	}
+	return string(data[:n]) + "..."
+}
@@ -0,0 +1,96 @@
+package smoketest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/handler"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/internal/infrastructure/memory"
+	"todoapp-api-golang/internal/infrastructure/web"
+)
+
+// TestRunner_Run は実際のAPI（メモリ上のリポジトリ）に対して全ての手順が成功することと、
+// 途中で失敗した場合に作成したTodoを削除することをテストします
+func TestRunner_Run(t *testing.T) {
+	tests := []struct {
+		name          string
+		failPath      string // このパスへのリクエストを 500 にする
+		expectedSteps []string
+		expectedFail  string
+	}{
+		{
+			name:          "全て成功",
+			expectedSteps: []string{"health", "create", "get", "list", "complete", "delete", "verify-deleted"},
+		},
+		{
+			name:          "完了に失敗した場合は削除する",
+			failPath:      "/complete",
+			expectedSteps: []string{"health", "create", "get", "list", "complete", "cleanup"},
+			expectedFail:  "complete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewTodoRepository()
+			api := web.NewRouter(handler.NewTodoHandler(service.NewTodoService(repo))).SetupRoutes()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.failPath != "" && strings.HasSuffix(r.URL.Path, tt.failPath) {
+					http.Error(w, "injected failure", http.StatusInternalServerError)
+					return
+				}
+				api.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			runner := &Runner{Client: server.Client(), BaseURL: server.URL + "/", Title: "スモークテスト"}
+			steps := runner.Run(context.Background())
+
+			var names []string
+			for _, step := range steps {
+				names = append(names, step.Name)
+				if step.OK() == (step.Name == tt.expectedFail) {
+					t.Errorf("%s: 結果 = %v（%v）", step.Name, step.OK(), step.Err)
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectedSteps, ",") {
+				t.Errorf("実行した手順 = %v, 期待値 = %v", names, tt.expectedSteps)
+			}
+
+			// 作成したTodoは残らない
+			if todos, _ := repo.GetAll(context.Background()); len(todos) != 0 {
+				t.Errorf("残ったTodo = %d 件, 期待値 = 0", len(todos))
+			}
+		})
+	}
+}
+
+// TestRunner_WaitReady は /health が成功するまで再試行することをテストします
+func TestRunner_WaitReady(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	runner := &Runner{Client: server.Client(), BaseURL: server.URL, ReadyTimeout: time.Second, RetryInterval: 10 * time.Millisecond}
+	if step := runner.waitReady(context.Background()); !step.OK() || attempts != 3 {
+		t.Errorf("結果 = %v（%v）, 試行回数 = %d, 期待値 = 3", step.OK(), step.Err, attempts)
+	}
+
+	attempts = -100
+	runner.ReadyTimeout = 0
+	if step := runner.waitReady(context.Background()); step.OK() || attempts != -99 {
+		t.Errorf("ReadyTimeout = 0 の場合は再試行しない: 結果 = %v, 試行回数 = %d", step.OK(), attempts+100)
+	}
+}
@@ -0,0 +1,148 @@
+// Package telemetry はオプトインの匿名利用状況レポートを送信します
+//
+// 学習ポイント：
+//  1. 利用者の同意なしに外部へ情報を送らない（TELEMETRY_ENABLED=true の場合のみ起動する）
+//  2. 個人やインストールを特定できる情報（ホスト名・IPアドレス・IDやタイトル）は含めない
+//  3. 件数は正確な値ではなく範囲（バケット）に丸め、規模の目安だけを伝える
+//
+// メンテナーはこの集計をもとに、よく使われている構成（DBドライバー等）や規模を把握し、
+// 機能の優先順位を決めます
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// reportSchemaVersion はレポートの形式のバージョンです（項目を変更した場合に上げる）
+const reportSchemaVersion = 1
+
+// Environment は起動時に決まるアプリケーションの構成です
+type Environment struct {
+	// AppVersion はアプリケーションのバージョン（APP_VERSION）
+	AppVersion string
+
+	// DBDriver はデータベースドライバー名（DB_DRIVER）
+	DBDriver string
+
+	// Sharded はシャーディングを使用しているかどうか
+	Sharded bool
+}
+
+// Report は送信する利用状況レポートの内容です
+// 送信する項目はすべてこの構造体に列挙し、README にも同じ内容を記載します
+type Report struct {
+	SchemaVersion int    `json:"schema_version"`
+	AppVersion    string `json:"app_version"`
+	GoVersion     string `json:"go_version"`
+	OS            string `json:"os"`
+	Arch          string `json:"arch"`
+	DBDriver      string `json:"db_driver"`
+	Sharded       bool   `json:"sharded"`
+
+	// Todos と CompletedTodos は件数のバケット（例: "10-99"）です
+	Todos          string `json:"todos"`
+	CompletedTodos string `json:"completed_todos"`
+}
+
+// Reporter は利用状況レポートを集計して送信します
+type Reporter struct {
+	client   *http.Client
+	endpoint string
+	env      Environment
+	todoRepo repository.TodoRepository
+}
+
+// NewReporter はReporterのコンストラクタです
+// client には httpclient.Factory で作成した、タイムアウトと再試行が設定されたクライアントを渡します
+func NewReporter(client *http.Client, endpoint string, env Environment, todoRepo repository.TodoRepository) *Reporter {
+	return &Reporter{
+		client:   client,
+		endpoint: endpoint,
+		env:      env,
+		todoRepo: todoRepo,
+	}
+}
+
+// Build は現在の利用状況からレポートを作成します
+// 件数はリポジトリで数えるため、Todoの内容は読み込みません
+func (r *Reporter) Build(ctx context.Context) (*Report, error) {
+	todos, err := r.todoRepo.Count(ctx, repository.TodoFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count todos: %w", err)
+	}
+	isCompleted := true
+	completed, err := r.todoRepo.Count(ctx, repository.TodoFilter{Completed: &isCompleted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count completed todos: %w", err)
+	}
+
+	return &Report{
+		SchemaVersion:  reportSchemaVersion,
+		AppVersion:     r.env.AppVersion,
+		GoVersion:      runtime.Version(),
+		OS:             runtime.GOOS,
+		Arch:           runtime.GOARCH,
+		DBDriver:       r.env.DBDriver,
+		Sharded:        r.env.Sharded,
+		Todos:          Bucket(todos),
+		CompletedTodos: Bucket(completed),
+	}, nil
+}
+
+// Send はレポートを作成して送信先へJSONでPOSTします
+// worker.Task と同じシグネチャで、定期実行ワーカーからそのまま呼び出せます
+// 送信は処理件数として数えず、失敗はエラーとして返します（次回の送信で再試行）
+func (r *Reporter) Send(ctx context.Context) (int, error) {
+	report, err := r.Build(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode telemetry report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send telemetry report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("telemetry endpoint responded with status %d", resp.StatusCode)
+	}
+	return 0, nil
+}
+
+// Bucket は件数を桁数ごとの範囲に丸めます
+// 例: 0 → "0"、5 → "1-9"、42 → "10-99"、12345 → "10000+"
+func Bucket(n int) string {
+	switch {
+	case n <= 0:
+		return "0"
+	case n < 10:
+		return "1-9"
+	case n < 100:
+		return "10-99"
+	case n < 1000:
+		return "100-999"
+	case n < 10000:
+		return "1000-9999"
+	default:
+		return "10000+"
+	}
+}
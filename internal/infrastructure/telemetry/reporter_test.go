@@ -0,0 +1,164 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/repository"
+)
+
+// stubTodoRepository は一覧の取得のみを行うテスト用のTodoRepositoryです
+type stubTodoRepository struct {
+	todos []*entity.Todo
+	err   error
+}
+
+func (s *stubTodoRepository) Create(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) CreateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) GetByID(ctx context.Context, id int) (*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) Exists(ctx context.Context, id int) (bool, error) {
+	return false, errors.New("not supported")
+}
+func (s *stubTodoRepository) GetAll(ctx context.Context) ([]*entity.Todo, error) {
+	return s.todos, s.err
+}
+func (s *stubTodoRepository) GetByColor(ctx context.Context, color entity.Color) ([]*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) Find(ctx context.Context, filter repository.TodoFilter, order entity.TodoSortOrder) ([]*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) Count(ctx context.Context, filter repository.TodoFilter) (int, error) {
+	count := 0
+	for _, todo := range s.todos {
+		if filter.Matches(todo) {
+			count++
+		}
+	}
+	return count, s.err
+}
+func (s *stubTodoRepository) CountGrouped(ctx context.Context, group repository.TodoGroup, filter repository.TodoFilter) ([]repository.TodoGroupCount, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) GetStats(ctx context.Context) (entity.TodoStats, error) {
+	return entity.TodoStats{}, errors.New("not supported")
+}
+func (s *stubTodoRepository) ExistsByTitle(ctx context.Context, title string, excludeID int) (bool, error) {
+	return false, errors.New("not supported")
+}
+func (s *stubTodoRepository) Update(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) UpdateFields(ctx context.Context, todo *entity.Todo, fields []entity.TodoField) (*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) UpdateMany(ctx context.Context, todos []*entity.Todo) ([]*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) UpdateWithLock(ctx context.Context, id int, mutate func(todo *entity.Todo) error) (*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) Upsert(ctx context.Context, todo *entity.Todo) (*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) Delete(ctx context.Context, id int) error {
+	return errors.New("not supported")
+}
+func (s *stubTodoRepository) Restore(ctx context.Context, todo *entity.Todo) error {
+	return errors.New("not supported")
+}
+func (s *stubTodoRepository) GetDeleted(ctx context.Context) ([]*entity.Todo, error) {
+	return nil, errors.New("not supported")
+}
+func (s *stubTodoRepository) HardDelete(ctx context.Context, id int) error {
+	return errors.New("not supported")
+}
+
+// TestReporter_Send はレポートの送信内容に件数のバケットのみが含まれることをテストします
+func TestReporter_Send(t *testing.T) {
+	todos := make([]*entity.Todo, 0, 42)
+	for i := 0; i < 42; i++ {
+		todos = append(todos, &entity.Todo{ID: i + 1, Title: "社外秘のタスク", IsCompleted: i < 5})
+	}
+
+	tests := []struct {
+		name        string
+		repo        *stubTodoRepository
+		status      int
+		expectError bool
+	}{
+		{name: "送信成功", repo: &stubTodoRepository{todos: todos}, status: http.StatusAccepted},
+		{name: "送信先のエラー", repo: &stubTodoRepository{todos: todos}, status: http.StatusInternalServerError, expectError: true},
+		{name: "集計の失敗", repo: &stubTodoRepository{err: errors.New("database unavailable")}, status: http.StatusAccepted, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			env := Environment{AppVersion: "1.2.0", DBDriver: "mysql"}
+			_, err := NewReporter(server.Client(), server.URL, env, tt.repo).Send(context.Background())
+			if (err != nil) != tt.expectError {
+				t.Fatalf("エラー = %v, エラーを期待 = %v", err, tt.expectError)
+			}
+			if tt.repo.err != nil {
+				if body != "" {
+					t.Errorf("集計に失敗した場合は送信しないべきです: %s", body)
+				}
+				return
+			}
+
+			var report Report
+			if err := json.Unmarshal([]byte(body), &report); err != nil {
+				t.Fatalf("送信内容のJSONパースに失敗: %v", err)
+			}
+			if report.Todos != "10-99" || report.CompletedTodos != "1-9" || report.AppVersion != "1.2.0" || report.DBDriver != "mysql" {
+				t.Errorf("送信内容 = %+v", report)
+			}
+			if strings.Contains(body, "社外秘") {
+				t.Errorf("送信内容にTodoの内容が含まれています: %s", body)
+			}
+		})
+	}
+}
+
+// TestBucket は件数のバケットへの丸めをテストします
+func TestBucket(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{n: 0, expected: "0"},
+		{n: 1, expected: "1-9"},
+		{n: 9, expected: "1-9"},
+		{n: 10, expected: "10-99"},
+		{n: 999, expected: "100-999"},
+		{n: 1000, expected: "1000-9999"},
+		{n: 123456, expected: "10000+"},
+	}
+
+	for _, tt := range tests {
+		if got := Bucket(tt.n); got != tt.expected {
+			t.Errorf("Bucket(%d) = %q, 期待値 = %q", tt.n, got, tt.expected)
+		}
+	}
+}
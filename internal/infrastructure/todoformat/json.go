@@ -0,0 +1,66 @@
+// Package todoformat はTodoのインポート・エクスポートの形式（transfer.Exporter / transfer.Importer）の実装です
+// 形式ごとに1つのファイルで完結し、起動時に transfer.Registry へ登録して使います
+package todoformat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// JSON はTodoの配列をそのままJSONで読み書きする形式です（?format=json）
+// エクスポートしたファイルをそのままインポートでき、バックアップや環境間の移行に使えます
+type JSON struct{}
+
+// コンパイル時インターフェース実装確認
+var _ transfer.ImporterExporter = JSON{}
+
+// Name は形式の名前を返します
+func (JSON) Name() string { return "json" }
+
+// ContentType はエクスポートしたデータのメディアタイプを返します
+func (JSON) ContentType() string { return "application/json" }
+
+// FileExtension はダウンロードするファイルの拡張子を返します
+func (JSON) FileExtension() string { return "json" }
+
+// Export はTodoの配列を、人が読みやすいようにインデントしたJSONで書き出します
+func (JSON) Export(w io.Writer, todos []*entity.Todo) error {
+	if todos == nil {
+		todos = []*entity.Todo{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(todos)
+}
+
+// Import はTodoの配列のJSONを読み込みます
+// 保存時に決まる項目（ID・作成日時・更新日時・チェックリストの進捗・繰り返しの元）は読み捨てます
+func (JSON) Import(r io.Reader) ([]*entity.Todo, error) {
+	var todos []*entity.Todo
+	if err := json.NewDecoder(r).Decode(&todos); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	imported := make([]*entity.Todo, 0, len(todos))
+	for i, todo := range todos {
+		if todo == nil {
+			return nil, fmt.Errorf("todo %d: must be an object", i+1)
+		}
+		imported = append(imported, &entity.Todo{
+			Title:           todo.Title,
+			Description:     todo.Description,
+			IsCompleted:     todo.IsCompleted,
+			RemindAt:        todo.RemindAt,
+			DueDate:         todo.DueDate,
+			Recurrence:      todo.Recurrence,
+			Color:           todo.Color,
+			EstimateMinutes: todo.EstimateMinutes,
+			ActualMinutes:   todo.ActualMinutes,
+		})
+	}
+	return imported, nil
+}
@@ -0,0 +1,97 @@
+package todoformat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// OPML はアウトライナー向けのOPML 2.0で読み書きする形式です（?format=opml）
+//
+// Todoを1つの outline 要素として書き出します：
+//   - text     -> タイトル
+//   - _note    -> 説明
+//   - _status  -> 完了している場合は "checked"（OmniOutliner などのアウトライナーの慣習）
+//
+// インポートでは入れ子の outline も1件のTodoとして読み込みます（階層は保持しません）
+type OPML struct{}
+
+// コンパイル時インターフェース実装確認
+var _ transfer.ImporterExporter = OPML{}
+
+// opmlStatusChecked は完了を表す _status 属性の値です
+const opmlStatusChecked = "checked"
+
+// opmlDocument はOPMLの文書です
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Title   string        `xml:"head>title"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+// opmlOutline はOPMLの outline 要素です
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Note     string        `xml:"_note,attr,omitempty"`
+	Status   string        `xml:"_status,attr,omitempty"`
+	Children []opmlOutline `xml:"outline"`
+}
+
+// Name は形式の名前を返します
+func (OPML) Name() string { return "opml" }
+
+// ContentType はエクスポートしたデータのメディアタイプを返します
+func (OPML) ContentType() string { return "text/x-opml; charset=utf-8" }
+
+// FileExtension はダウンロードするファイルの拡張子を返します
+func (OPML) FileExtension() string { return "opml" }
+
+// Export はTodoの一覧をOPMLの文書として書き出します
+func (OPML) Export(w io.Writer, todos []*entity.Todo) error {
+	document := opmlDocument{Version: "2.0", Title: "Todos", Body: make([]opmlOutline, len(todos))}
+	for i, todo := range todos {
+		outline := opmlOutline{Text: todo.Title, Note: todo.Description}
+		if todo.IsCompleted {
+			outline.Status = opmlStatusChecked
+		}
+		document.Body[i] = outline
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Import はOPMLの文書の outline 要素を、文書内の順にTodoとして読み込みます
+func (OPML) Import(r io.Reader) ([]*entity.Todo, error) {
+	var document opmlDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid OPML: %w", err)
+	}
+
+	var todos []*entity.Todo
+	var walk func(outlines []opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, outline := range outlines {
+			todos = append(todos, &entity.Todo{
+				Title:       outline.Text,
+				Description: outline.Note,
+				IsCompleted: outline.Status == opmlStatusChecked,
+			})
+			walk(outline.Children)
+		}
+	}
+	walk(document.Body)
+	return todos, nil
+}
@@ -0,0 +1,105 @@
+package todoformat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// Org はEmacsのOrg-modeのTODO見出しで書き出す形式です（?format=org、エクスポートのみ）
+//
+// Todoを1つの見出しとして書き出します：
+//
+//   - TODO 牛乳を買う
+//     DEADLINE: <2024-05-01 Wed 09:00 +1w>
+//     :PROPERTIES:
+//     :ID:       42
+//     :CREATED:  [2024-04-28 Sun 10:15]
+//     :END:
+//     説明の本文
+//
+// Org-modeのタイムスタンプはタイムゾーンを持たないため、日時はUTCで書き出します
+type Org struct{}
+
+// コンパイル時インターフェース実装確認
+var _ transfer.Exporter = Org{}
+
+// orgRepeaters は繰り返し規則に対応するOrg-modeのリピーターです
+var orgRepeaters = map[entity.Recurrence]string{
+	entity.RecurrenceDaily:   "+1d",
+	entity.RecurrenceWeekly:  "+1w",
+	entity.RecurrenceMonthly: "+1m",
+}
+
+// Name は形式の名前を返します
+func (Org) Name() string { return "org" }
+
+// ContentType はエクスポートしたデータのメディアタイプを返します
+func (Org) ContentType() string { return "text/org; charset=utf-8" }
+
+// FileExtension はダウンロードするファイルの拡張子を返します
+func (Org) FileExtension() string { return "org" }
+
+// Export はTodoの一覧をOrg-modeの文書として書き出します
+func (Org) Export(w io.Writer, todos []*entity.Todo) error {
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "#+TITLE: Todos")
+	for _, todo := range todos {
+		keyword := "TODO"
+		if todo.IsCompleted {
+			keyword = "DONE"
+		}
+		fmt.Fprintf(out, "\n* %s %s\n", keyword, orgHeadingText(todo.Title))
+
+		// 計画の行（DEADLINE）は見出しの直後に置く必要がある
+		if todo.DueDate != nil {
+			fmt.Fprintf(out, "  DEADLINE: %s\n", orgTimestamp(*todo.DueDate, '<', orgRepeaters[todo.Recurrence]))
+		}
+
+		fmt.Fprintln(out, "  :PROPERTIES:")
+		fmt.Fprintf(out, "  :ID:       %d\n", todo.ID)
+		if !todo.CreatedAt.IsZero() {
+			fmt.Fprintf(out, "  :CREATED:  %s\n", orgTimestamp(todo.CreatedAt, '[', ""))
+		}
+		if todo.Color != entity.ColorNone {
+			fmt.Fprintf(out, "  :COLOR:    %s\n", todo.Color)
+		}
+		fmt.Fprintln(out, "  :END:")
+
+		// 本文は字下げして、行頭の * が見出しとして解釈されないようにする
+		if description := strings.TrimSpace(strings.ReplaceAll(todo.Description, "\r\n", "\n")); description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				if line == "" {
+					fmt.Fprintln(out)
+					continue
+				}
+				fmt.Fprintf(out, "  %s\n", line)
+			}
+		}
+	}
+	return out.Flush()
+}
+
+// orgHeadingText はタイトルを見出しの1行に収まるようにします
+func orgHeadingText(title string) string {
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// orgTimestamp はOrg-modeのタイムスタンプを返します
+// open が '<' の場合はアクティブ（アジェンダに表示される）、'[' の場合は非アクティブです
+func orgTimestamp(t time.Time, open byte, repeater string) string {
+	closing := byte('>')
+	if open == '[' {
+		closing = ']'
+	}
+	stamp := t.UTC().Format("2006-01-02 Mon 15:04")
+	if repeater != "" {
+		stamp += " " + repeater
+	}
+	return string(open) + stamp + string(closing)
+}
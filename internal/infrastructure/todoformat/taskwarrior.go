@@ -0,0 +1,115 @@
+package todoformat
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// Taskwarrior は `task import` で読み込めるTaskwarriorのJSONで書き出す形式です（?format=taskwarrior、エクスポートのみ）
+//
+// 対応する項目：
+//   - description -> タイトル
+//   - annotations -> 説明（1件の注釈）
+//   - status      -> pending / completed
+//   - entry, modified, due, end -> 作成日時・更新日時・期限・完了日時（完了済みの場合の更新日時）
+//   - uuid        -> TodoのIDから作る固定のUUID
+//
+// uuid はTodoごとに毎回同じ値になるため、エクスポートし直したファイルを再び `task import` すると、
+// Taskwarrior側では新しいタスクが増えるのではなく、既存のタスクが更新されます
+type Taskwarrior struct{}
+
+// コンパイル時インターフェース実装確認
+var _ transfer.Exporter = Taskwarrior{}
+
+// taskwarriorTimeFormat はTaskwarriorの日時の形式です（UTC）
+const taskwarriorTimeFormat = "20060102T150405Z"
+
+// taskwarriorNamespace はTodoのIDからUUIDを作るときの名前空間です（RFC 4122 の名前ベースUUID）
+var taskwarriorNamespace = [16]byte{0x6f, 0x1c, 0x2b, 0x4e, 0x93, 0x0a, 0x4d, 0x58, 0xa2, 0x17, 0x5e, 0x3b, 0x8c, 0x41, 0xd0, 0x96}
+
+// taskwarriorTask はTaskwarriorのタスクのJSONです
+type taskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Entry       string                  `json:"entry"`
+	Modified    string                  `json:"modified,omitempty"`
+	Due         string                  `json:"due,omitempty"`
+	End         string                  `json:"end,omitempty"`
+	Annotations []taskwarriorAnnotation `json:"annotations,omitempty"`
+}
+
+// taskwarriorAnnotation はTaskwarriorのタスクの注釈です
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// Name は形式の名前を返します
+func (Taskwarrior) Name() string { return "taskwarrior" }
+
+// ContentType はエクスポートしたデータのメディアタイプを返します
+func (Taskwarrior) ContentType() string { return "application/json" }
+
+// FileExtension はダウンロードするファイルの拡張子を返します
+func (Taskwarrior) FileExtension() string { return "json" }
+
+// Export はTodoの一覧をTaskwarriorのタスクの配列として書き出します
+func (Taskwarrior) Export(w io.Writer, todos []*entity.Todo) error {
+	tasks := make([]taskwarriorTask, len(todos))
+	for i, todo := range todos {
+		task := taskwarriorTask{
+			UUID:        taskwarriorUUID(todo.ID),
+			Description: todo.Title,
+			Status:      "pending",
+			Entry:       taskwarriorTime(todo.CreatedAt),
+			Modified:    taskwarriorTime(todo.UpdatedAt),
+		}
+		if todo.DueDate != nil {
+			task.Due = taskwarriorTime(*todo.DueDate)
+		}
+		if todo.IsCompleted {
+			task.Status = "completed"
+			task.End = task.Modified
+		}
+		if todo.Description != "" {
+			task.Annotations = []taskwarriorAnnotation{{Entry: task.Entry, Description: todo.Description}}
+		}
+		tasks[i] = task
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tasks)
+}
+
+// taskwarriorTime は日時をTaskwarriorの形式（UTC）にします（ゼロ値の場合は空文字）
+func taskwarriorTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(taskwarriorTimeFormat)
+}
+
+// taskwarriorUUID はTodoのIDから、名前ベース（SHA-1、バージョン5）のUUIDを作ります
+func taskwarriorUUID(id int) string {
+	hash := sha1.New()
+	hash.Write(taskwarriorNamespace[:])
+	hash.Write([]byte(strconv.Itoa(id)))
+	sum := hash.Sum(nil)
+
+	var uuid [16]byte
+	copy(uuid[:], sum)
+	uuid[6] = (uuid[6] & 0x0f) | 0x50 // バージョン5
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 のバリアント
+
+	encoded := hex.EncodeToString(uuid[:])
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:32]
+}
@@ -0,0 +1,198 @@
+package todoformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/transfer"
+	"todoapp-api-golang/internal/domain/entity"
+)
+
+// sampleTodos はテスト用のTodoです
+func sampleTodos() []*entity.Todo {
+	due := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	return []*entity.Todo{
+		{ID: 1, Title: "牛乳を買う", Description: "低脂肪 & 1L", Color: entity.Color("#3366ff"), DueDate: &due, CreatedAt: due},
+		{ID: 2, Title: "請求書を送る", IsCompleted: true},
+	}
+}
+
+// TestRoundTrip はエクスポートしたデータをインポートすると同じ内容のTodoになることをテストします
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		format transfer.ImporterExporter
+		// fullFidelity が true の形式は、タイトル・説明・完了状態以外の項目も保持する
+		fullFidelity bool
+	}{
+		{format: JSON{}, fullFidelity: true},
+		{format: OPML{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.Name(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.format.Export(&buf, sampleTodos()); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			imported, err := tt.format.Import(&buf)
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if len(imported) != 2 {
+				t.Fatalf("インポートした件数 = %d, 期待値 = 2", len(imported))
+			}
+
+			for i, want := range sampleTodos() {
+				got := imported[i]
+				if got.ID != 0 || !got.CreatedAt.IsZero() {
+					t.Errorf("%d件目: 保存時に決まる項目は読み捨てるべきです: %+v", i+1, got)
+				}
+				if got.Title != want.Title || got.Description != want.Description || got.IsCompleted != want.IsCompleted {
+					t.Errorf("%d件目 = %+v, 期待値 = %+v", i+1, got, want)
+				}
+				if tt.fullFidelity && (got.Color != want.Color || (want.DueDate != nil && !got.DueDate.Equal(*want.DueDate))) {
+					t.Errorf("%d件目の色・期限 = %s, %v", i+1, got.Color, got.DueDate)
+				}
+			}
+		})
+	}
+}
+
+// TestOPML_Import はアウトライナーで作成したOPMLの入れ子の outline を読み込めることをテストします
+func TestOPML_Import(t *testing.T) {
+	source := `<?xml version="1.0"?>
+<opml version="2.0">
+  <head><title>週末</title></head>
+  <body>
+    <outline text="買い物">
+      <outline text="牛乳" _status="checked"/>
+    </outline>
+    <outline text="掃除" _note="風呂も"/>
+  </body>
+</opml>`
+
+	todos, err := OPML{}.Import(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	titles := make([]string, len(todos))
+	for i, todo := range todos {
+		titles[i] = todo.Title
+	}
+	if strings.Join(titles, ",") != "買い物,牛乳,掃除" {
+		t.Errorf("タイトル = %v, 期待値 = [買い物 牛乳 掃除]", titles)
+	}
+	if !todos[1].IsCompleted || todos[2].Description != "風呂も" {
+		t.Errorf("完了状態・説明 = %+v, %+v", todos[1], todos[2])
+	}
+
+	for _, invalid := range []string{`<rss></rss>`, `<opml><body>`} {
+		if _, err := (OPML{}).Import(strings.NewReader(invalid)); err == nil {
+			t.Errorf("不正なOPML %q はエラーになるべきです", invalid)
+		}
+	}
+}
+
+// TestJSON_Import は不正なJSONのエラーをテストします
+func TestJSON_Import(t *testing.T) {
+	for _, invalid := range []string{`{"title": "配列ではない"}`, `[null]`, `[`} {
+		if _, err := (JSON{}).Import(strings.NewReader(invalid)); err == nil {
+			t.Errorf("不正なJSON %q はエラーになるべきです", invalid)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := (JSON{}).Export(&buf, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("空の一覧のエクスポート = %q, %v, 期待値 = []", buf.String(), err)
+	}
+}
+
+// TestOrg_Export はOrg-modeの見出し・計画の行・プロパティ・本文の書き出しをテストします
+func TestOrg_Export(t *testing.T) {
+	due := time.Date(2024, 5, 1, 18, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	created := time.Date(2024, 4, 28, 10, 15, 0, 0, time.UTC)
+	todos := []*entity.Todo{
+		{ID: 42, Title: "牛乳を\n買う", Description: "* 低脂肪\n\n1L", DueDate: &due, Recurrence: entity.RecurrenceWeekly, CreatedAt: created},
+		{ID: 43, Title: "請求書を送る", IsCompleted: true},
+	}
+
+	var buf bytes.Buffer
+	if err := (Org{}).Export(&buf, todos); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	expected := `#+TITLE: Todos
+
+* TODO 牛乳を 買う
+  DEADLINE: <2024-05-01 Wed 09:00 +1w>
+  :PROPERTIES:
+  :ID:       42
+  :CREATED:  [2024-04-28 Sun 10:15]
+  :END:
+  * 低脂肪
+
+  1L
+
+* DONE 請求書を送る
+  :PROPERTIES:
+  :ID:       43
+  :END:
+`
+	if buf.String() != expected {
+		t.Errorf("Export() =\n%s\n期待値 =\n%s", buf.String(), expected)
+	}
+}
+
+// TestTaskwarrior_Export はTaskwarriorのタスクの項目と、固定のUUIDをテストします
+func TestTaskwarrior_Export(t *testing.T) {
+	created := time.Date(2024, 4, 28, 10, 15, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	due := time.Date(2024, 5, 1, 18, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	todos := []*entity.Todo{
+		{ID: 1, Title: "牛乳を買う", Description: "低脂肪", DueDate: &due, CreatedAt: created, UpdatedAt: updated},
+		{ID: 2, Title: "請求書を送る", IsCompleted: true, CreatedAt: created, UpdatedAt: updated},
+	}
+
+	export := func() []map[string]any {
+		var buf bytes.Buffer
+		if err := (Taskwarrior{}).Export(&buf, todos); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		var tasks []map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &tasks); err != nil {
+			t.Fatalf("JSONのパースに失敗: %v", err)
+		}
+		return tasks
+	}
+	tasks := export()
+
+	pending, completed := tasks[0], tasks[1]
+	if pending["description"] != "牛乳を買う" || pending["status"] != "pending" || pending["entry"] != "20240428T101500Z" || pending["due"] != "20240501T090000Z" {
+		t.Errorf("未完了のタスク = %v", pending)
+	}
+	if _, ok := pending["end"]; ok {
+		t.Errorf("未完了のタスクに end があります: %v", pending)
+	}
+	annotations, _ := pending["annotations"].([]any)
+	if len(annotations) != 1 || annotations[0].(map[string]any)["description"] != "低脂肪" {
+		t.Errorf("注釈 = %v", pending["annotations"])
+	}
+	if completed["status"] != "completed" || completed["end"] != "20240428T111500Z" {
+		t.Errorf("完了済みのタスク = %v", completed)
+	}
+
+	uuid, _ := pending["uuid"].(string)
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(uuid) {
+		t.Errorf("uuid = %q, バージョン5のUUIDであるべきです", uuid)
+	}
+	if uuid == completed["uuid"] {
+		t.Error("別のTodoには別のUUIDを割り当てるべきです")
+	}
+	if again := export(); again[0]["uuid"] != uuid {
+		t.Errorf("同じTodoのUUIDが変わりました: %v, %v", uuid, again[0]["uuid"])
+	}
+}
@@ -0,0 +1,537 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"todoapp-api-golang/api"
+	"todoapp-api-golang/internal/application/contract"
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/application/handler"
+	"todoapp-api-golang/internal/application/middleware"
+	"todoapp-api-golang/internal/domain/entity"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/internal/infrastructure/database"
+	"todoapp-api-golang/internal/infrastructure/database/sqlrepo"
+	"todoapp-api-golang/internal/infrastructure/markdown"
+	"todoapp-api-golang/internal/infrastructure/notifier"
+	"todoapp-api-golang/pkg/msgpack"
+)
+
+// contractAdminToken は契約テストで管理者向けのエンドポイントに送る管理用トークンです
+const contractAdminToken = "contract-admin-token"
+
+// TestAPIContract は全てのエンドポイントのレスポンスが仕様書（api/openapi.json）どおりかをテストします
+//
+// main.go と同じ構成（実際のリポジトリ・サービス・ハンドラー）をSQLiteで組み立て、
+// ContractValidationMiddleware で全てのレスポンスを検証します
+// レスポンスのフィールドを追加・変更した場合は、仕様書も合わせて更新しないとこのテストが失敗します
+func TestAPIContract(t *testing.T) {
+	// 複数の接続で同じデータを共有するため、":memory:" ではなく一時ファイルを使用する
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "contract.db"))
+	if err != nil {
+		t.Fatalf("テストデータベースの作成に失敗: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.CreateSQLiteTables(db); err != nil {
+		t.Fatalf("テストテーブルの作成に失敗: %v", err)
+	}
+
+	validator, err := contract.NewValidator(api.OpenAPISpec)
+	if err != nil {
+		t.Fatalf("仕様書の読み込みに失敗: %v", err)
+	}
+
+	router := newContractTestRouter(t, db, middleware.ContractValidationMiddleware(validator, func(r *http.Request, err error) {
+		t.Errorf("仕様書との不一致: %s %s: %v", r.Method, r.URL.Path, err)
+	}))
+
+	// デッドレターの一覧・再投入・削除を確認するためのデータ
+	deliveryRepo := database.NewFailedDeliveryRepository(db)
+	for range 2 {
+		if _, err := deliveryRepo.Create(context.Background(), &entity.FailedDelivery{
+			Kind: entity.DeliveryKindReminder, TodoID: 1, Payload: []byte(`{"id":1}`),
+			Attempts: 5, LastError: "timeout", Status: entity.DeliveryStatusDead, NextAttemptAt: time.Now(),
+		}); err != nil {
+			t.Fatalf("デッドレターの作成に失敗: %v", err)
+		}
+	}
+
+	remindAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	dueDate := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	// 前のステップで作成したデータ（ID = 1, 2, ...）を後のステップで使用する
+	steps := []struct {
+		method         string
+		path           string
+		body           string
+		contentType    string // 省略時は application/json
+		accept         string
+		ifMatch        string
+		idempotencyKey string
+		authorization  string
+		expectedStatus int
+	}{
+		// Todo
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":"契約テスト","description":"**重要** [仕様](https://example.com)","remind_at":"` + remindAt + `","due_date":"` + dueDate + `","color":"#3b82f6","estimate_minutes":30}`, expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":"繰り返し","recurrence":"daily","due_date":"` + dueDate + `"}`, expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":""}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":"繰り返し"}`, expectedStatus: http.StatusConflict},
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/todos", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos?completed=false&color=%233b82f6&limit=1", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos?page=0&limit=500", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos?fields=id,title,is_completed", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos?fields=secret", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/todos?color=not-a-color", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/todos", accept: "text/html", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/overdue", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/today", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/upcoming", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/calendar.ics?component=todo&include_completed=true", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/calendar.ics?component=journal", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/todos/1", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/1", accept: "text/html", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/1", accept: "application/vnd.api+json", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos?limit=1", accept: "application/vnd.api+json", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/1?render=html", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/1?fields=id,title", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos?render=html", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/1?render=pdf", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/todos/abc", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/todos/999", expectedStatus: http.StatusNotFound},
+		{method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新後","actual_minutes":45}`, expectedStatus: http.StatusOK},
+		{method: http.MethodPut, path: "/api/v1/todos/999", body: `{"title":"x"}`, expectedStatus: http.StatusNotFound},
+		{method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新後"}`, expectedStatus: http.StatusOK},
+		{method: http.MethodPut, path: "/api/v1/todos/1", body: "\x81\xa5title\xa9更新後", contentType: msgpack.MediaType, accept: msgpack.MediaType, expectedStatus: http.StatusOK},
+		{method: http.MethodPut, path: "/api/v1/todos/1", body: "\x81\xa5title", contentType: msgpack.MediaType, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"競合"}`, ifMatch: `"stale"`, expectedStatus: http.StatusPreconditionFailed},
+		{method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"更新後"}`, ifMatch: "*", expectedStatus: http.StatusOK},
+		{method: http.MethodPatch, path: "/api/v1/todos", body: `[{"id":1,"estimate_minutes":20},{"id":"2","color":"#22c55e"}]`, expectedStatus: http.StatusOK},
+		{method: http.MethodPatch, path: "/api/v1/todos", body: `[{"id":1,"title":"x"},{"id":999}]`, expectedStatus: http.StatusUnprocessableEntity},
+		{method: http.MethodPatch, path: "/api/v1/todos/1/complete", expectedStatus: http.StatusOK},
+		{method: http.MethodPatch, path: "/api/v1/todos/1/incomplete", expectedStatus: http.StatusOK},
+		{method: http.MethodPatch, path: "/api/v1/todos/1/incomplete", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/stats", expectedStatus: http.StatusOK},
+
+		// チェックリスト
+		{method: http.MethodPost, path: "/api/v1/todos/1/checklist", body: `{"text":"手順1"}`, expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/todos/1/checklist", body: `{"text":""}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/todos/1/checklist", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/1/checklist/1", expectedStatus: http.StatusOK},
+		{method: http.MethodPut, path: "/api/v1/todos/1/checklist/1", body: `{"is_done":true}`, expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/999/checklist", expectedStatus: http.StatusNotFound},
+
+		// 複製（チェックリストを含む）
+		{method: http.MethodPost, path: "/api/v1/todos/1/duplicate", body: `{"title":"複製","include_checklist":true}`, expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/todos/999/duplicate", body: `{}`, expectedStatus: http.StatusNotFound},
+		{method: http.MethodPost, path: "/api/v1/todos/1/duplicate", body: `{}`, expectedStatus: http.StatusConflict},
+		{method: http.MethodPut, path: "/api/v1/todos/1", body: `{"title":"複製"}`, expectedStatus: http.StatusConflict},
+		{method: http.MethodDelete, path: "/api/v1/todos/1/checklist/1", expectedStatus: http.StatusNoContent},
+
+		// リマインダー
+		{method: http.MethodPost, path: "/api/v1/todos/1/reminder/snooze", body: `{"minutes":10}`, expectedStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/todos/1/reminder/snooze", body: `{"minutes":-1}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodDelete, path: "/api/v1/todos/1/reminder", expectedStatus: http.StatusNoContent},
+
+		// 変更履歴
+		{method: http.MethodGet, path: "/api/v1/todos/1/history", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/todos/999/history", expectedStatus: http.StatusNotFound},
+
+		// プロジェクト
+		{method: http.MethodPost, path: "/api/v1/projects", body: `{"name":"契約テスト","description":"説明"}`, expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/projects", body: `{"name":""}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/projects", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/projects", accept: "application/vnd.api+json", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/projects/1", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/projects/not-found", expectedStatus: http.StatusNotFound},
+		{method: http.MethodPost, path: "/api/v1/projects/1/archive", expectedStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":"アーカイブ済み","project_id":1}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodPost, path: "/api/v1/projects/1/restore", expectedStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/projects/not-found/archive", expectedStatus: http.StatusNotFound},
+
+		// スキーマ
+		{method: http.MethodGet, path: "/api/v1/schema/todo", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/schema/checklist_item", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/schema/unknown", expectedStatus: http.StatusNotFound},
+
+		// デッドレター
+		{method: http.MethodGet, path: "/api/v1/admin/dead-letters", expectedStatus: http.StatusUnauthorized},
+		{method: http.MethodGet, path: "/api/v1/admin/dead-letters", authorization: "Bearer " + contractAdminToken, expectedStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/admin/dead-letters/1/requeue", authorization: "Bearer " + contractAdminToken, expectedStatus: http.StatusOK},
+		{method: http.MethodDelete, path: "/api/v1/admin/dead-letters/2", authorization: "Bearer " + contractAdminToken, expectedStatus: http.StatusNoContent},
+		{method: http.MethodDelete, path: "/api/v1/admin/dead-letters/999", authorization: "Bearer " + contractAdminToken, expectedStatus: http.StatusNotFound},
+
+		// Webhook（以降の削除・取り消しが通知される）
+		{method: http.MethodPost, path: "/api/v1/webhooks", body: `{"url":"http://127.0.0.1:1/hooks","events":["todo.created","todo.deleted"]}`, expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/webhooks", body: `{"url":"/hooks","events":["todo.created"]}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodGet, path: "/api/v1/webhooks", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/webhooks/1", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/webhooks/999", expectedStatus: http.StatusNotFound},
+
+		// 削除・ルーターのエラー
+		{method: http.MethodDelete, path: "/api/v1/todos/2", ifMatch: `"stale"`, expectedStatus: http.StatusPreconditionFailed},
+		{method: http.MethodDelete, path: "/api/v1/todos/2", expectedStatus: http.StatusNoContent},
+		{method: http.MethodDelete, path: "/api/v1/todos/2", expectedStatus: http.StatusNotFound},
+		{method: http.MethodPost, path: "/api/v1/undo", expectedStatus: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/undo", expectedStatus: http.StatusNotFound},
+		{method: http.MethodGet, path: "/api/v1/todos/2", expectedStatus: http.StatusOK},
+		{method: http.MethodDelete, path: "/api/v1/todos/3", accept: "text/html", expectedStatus: http.StatusOK},
+		{method: http.MethodDelete, path: "/api/v1/todos", expectedStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodOptions, path: "/api/v1/todos", expectedStatus: http.StatusNoContent},
+		{method: http.MethodOptions, path: "/api/v1/todos/1/unknown", expectedStatus: http.StatusNotFound},
+		{method: http.MethodDelete, path: "/api/v1/webhooks/1", expectedStatus: http.StatusNoContent},
+		{method: http.MethodDelete, path: "/api/v1/webhooks/1", expectedStatus: http.StatusNotFound},
+		{method: http.MethodGet, path: "/api/v1/unknown", expectedStatus: http.StatusNotFound},
+
+		// Idempotency-Key（再送では同じレスポンスを返し、Todoを二重に作成しない）
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":"再送"}`, idempotencyKey: "contract-1", expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":"再送"}`, idempotencyKey: "contract-1", expectedStatus: http.StatusCreated},
+		{method: http.MethodPost, path: "/api/v1/todos", body: `{"title":"別の内容"}`, idempotencyKey: "contract-1", expectedStatus: http.StatusUnprocessableEntity},
+
+		// API仕様書
+		{method: http.MethodGet, path: "/api/v1/openapi.json", expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/api/v1/docs", expectedStatus: http.StatusOK},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(step.method, step.path, strings.NewReader(step.body))
+		if step.contentType != "" {
+			req.Header.Set("Content-Type", step.contentType)
+		} else if step.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if step.accept != "" {
+			req.Header.Set("Accept", step.accept)
+		}
+		if step.ifMatch != "" {
+			req.Header.Set("If-Match", step.ifMatch)
+		}
+		if step.idempotencyKey != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, step.idempotencyKey)
+		}
+		if step.authorization != "" {
+			req.Header.Set("Authorization", step.authorization)
+		}
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != step.expectedStatus {
+			t.Errorf("%s %s: ステータスコード = %d, 期待値 = %d（%s）", step.method, step.path, rec.Code, step.expectedStatus, rec.Body.String())
+		}
+	}
+}
+
+// TestAPIContract_Auth はユーザー登録・ログイン・トークンのローテーション・ログアウトのレスポンスが仕様書と一致することをテストします
+// リフレッシュトークンは前のステップのレスポンスから取り出して使うため、TestAPIContract とは別に順に実行します
+func TestAPIContract_Auth(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "auth.db"))
+	if err != nil {
+		t.Fatalf("テストデータベースの作成に失敗: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.CreateSQLiteTables(db); err != nil {
+		t.Fatalf("テストテーブルの作成に失敗: %v", err)
+	}
+
+	validator, err := contract.NewValidator(api.OpenAPISpec)
+	if err != nil {
+		t.Fatalf("仕様書の読み込みに失敗: %v", err)
+	}
+	authService := service.NewAuthService(database.NewUserRepository(db), database.NewRefreshTokenRepository(db),
+		[]byte("0123456789abcdef0123456789abcdef"), 15*time.Minute, 24*time.Hour)
+	workspaceService := service.NewWorkspaceService(database.NewWorkspaceRepository(db), database.NewUserRepository(db), time.Hour)
+	sessionService := service.NewSessionService(database.NewSessionRepository(db), database.NewUserRepository(db), time.Hour)
+	preferencesService := service.NewPreferencesService(database.NewPreferencesRepository(db, sqlrepo.SQLite))
+	router := newContractTestRouter(t, db, middleware.ContractValidationMiddleware(validator, func(r *http.Request, err error) {
+		t.Errorf("仕様書との不一致: %s %s: %v", r.Method, r.URL.Path, err)
+	}), WithAuth(handler.NewAuthHandler(authService), middleware.SessionAuthMiddleware(authService, sessionService)),
+		WithSessions(handler.NewSessionHandler(sessionService, "/", true)),
+		WithMiddleware(middleware.CSRFMiddleware("/", true)),
+		WithPreferencesHandler(handler.NewPreferencesHandler(preferencesService)),
+		WithDueDateHandler(handler.NewDueDateHandler(service.NewDueDateService(database.NewDueDateRepository(db)), handler.WithDueDatePreferences(preferencesService))),
+		WithWorkspaces(handler.NewWorkspaceHandler(workspaceService), middleware.WorkspaceMiddleware(workspaceService)))
+
+	// do はリクエストを送信し、ステータスコードを確認してレスポンスを返します
+	// workspace を設定すると X-Workspace-ID ヘッダーを、session を設定するとセッションのCookieを付けて送信します
+	// csrf を設定すると CSRF トークンをCookieと X-CSRF-Token ヘッダーの両方に付けます
+	var workspace, session, csrf string
+	do := func(method, path, body, accessToken string, expectedStatus int) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		if workspace != "" {
+			req.Header.Set(middleware.WorkspaceHeader, workspace)
+		}
+		if session != "" {
+			req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: session})
+		}
+		if csrf != "" {
+			req.AddCookie(&http.Cookie{Name: middleware.CSRFCookieName, Value: csrf})
+			req.Header.Set(middleware.CSRFHeaderName, csrf)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != expectedStatus {
+			t.Fatalf("%s %s: ステータスコード = %d, 期待値 = %d（%s）", method, path, rec.Code, expectedStatus, rec.Body.String())
+		}
+		return rec
+	}
+	tokens := func(rec *httptest.ResponseRecorder) dto.TokenResponse {
+		t.Helper()
+		var response dto.TokenResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("トークンのレスポンスのJSONパースに失敗: %v", err)
+		}
+		return response
+	}
+
+	credentials := `{"username":"alice","password":"correct horse"}`
+	do(http.MethodPost, "/api/v1/auth/register", credentials, "", http.StatusCreated)
+	do(http.MethodPost, "/api/v1/auth/register", credentials, "", http.StatusConflict)
+	do(http.MethodPost, "/api/v1/auth/register", `{"username":"a","password":"short"}`, "", http.StatusBadRequest)
+	do(http.MethodPost, "/api/v1/auth/login", `{"username":"alice","password":"wrong password"}`, "", http.StatusUnauthorized)
+	first := tokens(do(http.MethodPost, "/api/v1/auth/login", credentials, "", http.StatusOK))
+
+	// アクセストークンがない・不正なリクエストは 401、API仕様書はトークンなしで取得できる
+	do(http.MethodGet, "/api/v1/todos", "", "", http.StatusUnauthorized)
+	do(http.MethodGet, "/api/v1/todos", "", "not-a-token", http.StatusUnauthorized)
+	do(http.MethodGet, "/api/v1/todos", "", first.AccessToken, http.StatusOK)
+	do(http.MethodGet, "/api/v1/openapi.json", "", "", http.StatusOK)
+
+	// Cookieのセッション：ログインで設定したCookieだけで認証でき、ログアウトするとすぐに使えなくなる
+	// 状態を変更するリクエストには、ログインのレスポンスで受け取った CSRF トークンが必要
+	do(http.MethodPost, "/api/v1/auth/session", `{"username":"alice","password":"wrong password"}`, "", http.StatusUnauthorized)
+	var csrfToken string
+	for _, c := range do(http.MethodPost, "/api/v1/auth/session", credentials, "", http.StatusOK).Result().Cookies() {
+		switch c.Name {
+		case middleware.SessionCookieName:
+			session = c.Value
+		case middleware.CSRFCookieName:
+			csrfToken = c.Value
+		}
+	}
+	if session == "" || csrfToken == "" {
+		t.Fatal("ログインのレスポンスにセッションと CSRF トークンのCookieがありません")
+	}
+	do(http.MethodGet, "/api/v1/todos", "", "", http.StatusOK)
+	do(http.MethodPost, "/api/v1/todos", `{"title":"セッションで作成"}`, "", http.StatusForbidden)
+	do(http.MethodDelete, "/api/v1/auth/session", "", "", http.StatusForbidden)
+	csrf = csrfToken
+	do(http.MethodPost, "/api/v1/todos", `{"title":"セッションで作成"}`, "", http.StatusCreated)
+	do(http.MethodDelete, "/api/v1/auth/session", "", "", http.StatusNoContent)
+	do(http.MethodGet, "/api/v1/todos", "", "", http.StatusUnauthorized)
+	session, csrf = "", ""
+
+	// ローテーション：使ったリフレッシュトークンの再利用で、同じログインのトークンが全て失効する
+	second := tokens(do(http.MethodPost, "/api/v1/auth/refresh", `{"refresh_token":"`+first.RefreshToken+`"}`, "", http.StatusOK))
+	do(http.MethodPost, "/api/v1/auth/refresh", `{"refresh_token":"`+first.RefreshToken+`"}`, "", http.StatusUnauthorized)
+	do(http.MethodPost, "/api/v1/auth/refresh", `{"refresh_token":"`+second.RefreshToken+`"}`, "", http.StatusUnauthorized)
+
+	third := tokens(do(http.MethodPost, "/api/v1/auth/login", credentials, "", http.StatusOK))
+	do(http.MethodPost, "/api/v1/auth/logout", `{"refresh_token":"`+third.RefreshToken+`"}`, "", http.StatusNoContent)
+	do(http.MethodPost, "/api/v1/auth/refresh", `{"refresh_token":"`+third.RefreshToken+`"}`, "", http.StatusUnauthorized)
+	do(http.MethodPost, "/api/v1/auth/logout", `{}`, "", http.StatusBadRequest)
+
+	// Todoは作成したユーザーの所有になり、他のユーザーからは存在しないものとして扱われる
+	do(http.MethodPost, "/api/v1/auth/register", `{"username":"bob","password":"battery staple"}`, "", http.StatusCreated)
+	bob := tokens(do(http.MethodPost, "/api/v1/auth/login", `{"username":"bob","password":"battery staple"}`, "", http.StatusOK))
+	var created dto.TodoResponse
+	if err := json.Unmarshal(do(http.MethodPost, "/api/v1/todos", `{"title":"アリスのTodo"}`, third.AccessToken, http.StatusCreated).Body.Bytes(), &created); err != nil {
+		t.Fatalf("作成したTodoのJSONパースに失敗: %v", err)
+	}
+	todoPath := "/api/v1/todos/" + strconv.Itoa(int(created.ID))
+	do(http.MethodGet, todoPath, "", third.AccessToken, http.StatusOK)
+	do(http.MethodGet, todoPath, "", bob.AccessToken, http.StatusNotFound)
+	do(http.MethodDelete, todoPath, "", bob.AccessToken, http.StatusNotFound)
+	if body := do(http.MethodGet, "/api/v1/todos", "", bob.AccessToken, http.StatusOK).Body.String(); strings.Contains(body, "アリスのTodo") {
+		t.Errorf("他のユーザーの一覧にTodoが含まれています: %s", body)
+	}
+	// タイトルの重複はユーザーごとに確認するため、同じタイトルでも作成できる
+	do(http.MethodPost, "/api/v1/todos", `{"title":"アリスのTodo"}`, bob.AccessToken, http.StatusCreated)
+
+	// スコープを限定したトークン：読み取りのみのトークンではTodoを取得できるが、変更とスコープのないリソースは 403
+	var readOnly dto.ScopedTokenResponse
+	if err := json.Unmarshal(do(http.MethodPost, "/api/v1/tokens", `{"scopes":["todos:read"],"expires_in":3600}`, third.AccessToken, http.StatusCreated).Body.Bytes(), &readOnly); err != nil {
+		t.Fatalf("スコープを限定したトークンのJSONパースに失敗: %v", err)
+	}
+	do(http.MethodGet, todoPath, "", readOnly.AccessToken, http.StatusOK)
+	do(http.MethodPost, "/api/v1/todos", `{"title":"読み取りのみ"}`, readOnly.AccessToken, http.StatusForbidden)
+	do(http.MethodPost, "/api/v1/tokens", `{"scopes":["todos:read"]}`, readOnly.AccessToken, http.StatusForbidden)
+	do(http.MethodPost, "/api/v1/tokens", `{"scopes":["admin"]}`, third.AccessToken, http.StatusBadRequest)
+
+	// 設定：保存していない場合は既定の設定を返し、保存したタイムゾーンは期限の一覧の tz の既定値になる
+	if body := do(http.MethodGet, "/api/v1/me/preferences", "", third.AccessToken, http.StatusOK).Body.String(); !strings.Contains(body, `"timezone":"UTC"`) {
+		t.Errorf("既定の設定のタイムゾーンがUTCではありません: %s", body)
+	}
+	do(http.MethodPut, "/api/v1/me/preferences", `{"timezone":"Asia/Tokyo","sort_order":"title"}`, third.AccessToken, http.StatusOK)
+	do(http.MethodPut, "/api/v1/me/preferences", `{"timezone":"Mars/Olympus"}`, third.AccessToken, http.StatusBadRequest)
+	do(http.MethodPut, "/api/v1/me/preferences", `{"sort_order":"priority"}`, third.AccessToken, http.StatusBadRequest)
+	do(http.MethodGet, "/api/v1/me/preferences", "", readOnly.AccessToken, http.StatusForbidden)
+	if body := do(http.MethodGet, "/api/v1/todos/today", "", third.AccessToken, http.StatusOK).Body.String(); !strings.Contains(body, `"tz":"Asia/Tokyo"`) {
+		t.Errorf("保存したタイムゾーンが使われていません: %s", body)
+	}
+	if body := do(http.MethodGet, "/api/v1/todos/today", "", bob.AccessToken, http.StatusOK).Body.String(); !strings.Contains(body, `"tz":"UTC"`) {
+		t.Errorf("他のユーザーの設定が使われています: %s", body)
+	}
+
+	// 共有されたユーザーは、共有の権限の範囲でTodoを操作できる（削除と共有の管理は所有者だけ）
+	do(http.MethodPut, todoPath+"/shares/bob", `{"permission":"read"}`, third.AccessToken, http.StatusOK)
+	do(http.MethodGet, todoPath, "", bob.AccessToken, http.StatusOK)
+	do(http.MethodPatch, todoPath+"/complete", "", bob.AccessToken, http.StatusForbidden)
+	do(http.MethodPut, todoPath+"/shares/bob", `{"permission":"write"}`, third.AccessToken, http.StatusOK)
+	do(http.MethodPatch, todoPath+"/complete", "", bob.AccessToken, http.StatusOK)
+	do(http.MethodDelete, todoPath, "", bob.AccessToken, http.StatusForbidden)
+	do(http.MethodGet, todoPath+"/shares", "", bob.AccessToken, http.StatusForbidden)
+	if body := do(http.MethodGet, todoPath+"/shares", "", third.AccessToken, http.StatusOK).Body.String(); !strings.Contains(body, `"username":"bob"`) {
+		t.Errorf("共有の一覧に共有先が含まれていません: %s", body)
+	}
+	do(http.MethodPut, todoPath+"/shares/nobody", `{"permission":"read"}`, third.AccessToken, http.StatusNotFound)
+	do(http.MethodDelete, todoPath+"/shares/bob", "", third.AccessToken, http.StatusNoContent)
+	do(http.MethodGet, todoPath, "", bob.AccessToken, http.StatusNotFound)
+
+	// ワークスペースのTodoは、招待で参加したメンバー全員が X-Workspace-ID ヘッダーを付けて操作できる
+	var team dto.WorkspaceResponse
+	if err := json.Unmarshal(do(http.MethodPost, "/api/v1/workspaces", `{"name":"開発チーム"}`, third.AccessToken, http.StatusCreated).Body.Bytes(), &team); err != nil {
+		t.Fatalf("作成したワークスペースのJSONパースに失敗: %v", err)
+	}
+	teamPath := "/api/v1/workspaces/" + strconv.Itoa(int(team.ID))
+	var invitation dto.WorkspaceInvitationResponse
+	if err := json.Unmarshal(do(http.MethodPost, teamPath+"/invitations", "", third.AccessToken, http.StatusCreated).Body.Bytes(), &invitation); err != nil {
+		t.Fatalf("招待のJSONパースに失敗: %v", err)
+	}
+	do(http.MethodPost, teamPath+"/invitations", "", bob.AccessToken, http.StatusNotFound)
+	do(http.MethodPost, "/api/v1/workspaces/join", `{"token":"`+invitation.Token+`"}`, bob.AccessToken, http.StatusOK)
+	do(http.MethodPost, "/api/v1/workspaces/join", `{"token":"`+invitation.Token+`"}`, bob.AccessToken, http.StatusNotFound)
+	if body := do(http.MethodGet, teamPath+"/members", "", bob.AccessToken, http.StatusOK).Body.String(); !strings.Contains(body, `"username":"bob","role":"member"`) {
+		t.Errorf("メンバーの一覧に参加したユーザーが含まれていません: %s", body)
+	}
+
+	workspace = strconv.Itoa(int(team.ID))
+	var teamTodo dto.TodoResponse
+	if err := json.Unmarshal(do(http.MethodPost, "/api/v1/todos", `{"title":"チームのTodo"}`, third.AccessToken, http.StatusCreated).Body.Bytes(), &teamTodo); err != nil {
+		t.Fatalf("作成したTodoのJSONパースに失敗: %v", err)
+	}
+	teamTodoPath := "/api/v1/todos/" + strconv.Itoa(int(teamTodo.ID))
+	do(http.MethodPatch, teamTodoPath+"/complete", "", bob.AccessToken, http.StatusOK)
+	if body := do(http.MethodGet, "/api/v1/todos", "", bob.AccessToken, http.StatusOK).Body.String(); !strings.Contains(body, "チームのTodo") || strings.Contains(body, "アリスのTodo") {
+		t.Errorf("ワークスペースの一覧 = %s, 期待値 = ワークスペースのTodoのみ", body)
+	}
+	workspace = ""
+	do(http.MethodGet, teamTodoPath, "", bob.AccessToken, http.StatusNotFound)
+
+	// メンバーが抜けると、ワークスペースを指定できなくなる
+	do(http.MethodDelete, teamPath+"/members/bob", "", bob.AccessToken, http.StatusNoContent)
+	workspace = strconv.Itoa(int(team.ID))
+	do(http.MethodGet, "/api/v1/todos", "", bob.AccessToken, http.StatusNotFound)
+	workspace = ""
+}
+
+// TestRouter_Head は HEAD リクエストが GET と同じヘッダー（Content-Length, ETag）をボディなしで返すことをテストします
+func TestRouter_Head(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "head.db"))
+	if err != nil {
+		t.Fatalf("テストデータベースの作成に失敗: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.CreateSQLiteTables(db); err != nil {
+		t.Fatalf("テストテーブルの作成に失敗: %v", err)
+	}
+
+	// 一覧の meta.server_time でボディの長さが変わらないよう、サーバー時刻を固定する
+	originalNow := dto.Now
+	dto.Now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	t.Cleanup(func() { dto.Now = originalNow })
+
+	router := newContractTestRouter(t, db, func(next http.Handler) http.Handler { return next })
+	create := httptest.NewRequest(http.MethodPost, "/api/v1/todos", strings.NewReader(`{"title":"牛乳を買う"}`))
+	create.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), create)
+
+	for _, path := range []string{"/api/v1/todos", "/api/v1/todos/1", "/api/v1/todos/999"} {
+		t.Run(path, func(t *testing.T) {
+			get := httptest.NewRecorder()
+			router.ServeHTTP(get, httptest.NewRequest(http.MethodGet, path, nil))
+			head := httptest.NewRecorder()
+			router.ServeHTTP(head, httptest.NewRequest(http.MethodHead, path, nil))
+
+			if head.Code != get.Code {
+				t.Errorf("ステータスコード = %d, GET = %d", head.Code, get.Code)
+			}
+			if head.Body.Len() != 0 {
+				t.Errorf("HEAD のレスポンスにボディがあります: %s", head.Body.String())
+			}
+			if got, want := head.Header().Get("Content-Length"), strconv.Itoa(get.Body.Len()); got != want {
+				t.Errorf("Content-Length = %q, 期待値 = %q", got, want)
+			}
+			for _, key := range []string{"Content-Type", "ETag"} {
+				if head.Header().Get(key) != get.Header().Get(key) {
+					t.Errorf("%s = %q, GET = %q", key, head.Header().Get(key), get.Header().Get(key))
+				}
+			}
+		})
+	}
+}
+
+// newContractTestRouter は main.go と同じ構成のルーターを作成します
+// 任意の機能（タイトルの重複禁止・Markdownの変換）は全て有効にし、外部への通知はログ出力に置き換えます
+// opts は main.go で設定により有効にする機能（認証等）を追加する場合に指定します
+func newContractTestRouter(t *testing.T, db *sql.DB, validation func(http.Handler) http.Handler, opts ...RouterOption) http.Handler {
+	t.Helper()
+
+	todoRepo := database.NewTodoRepository(db, database.WithSQLiteLocking())
+	checklistRepo := database.NewChecklistRepository(db)
+	historyRepo := database.NewTodoHistoryRepository(db)
+	deliveryService := service.NewDeliveryService(database.NewFailedDeliveryRepository(db), service.DefaultRetryPolicy())
+	reminderService := service.NewReminderService(database.NewReminderRepository(db), todoRepo, notifier.NewLogNotifier(nil), service.WithDeliveryQueue(deliveryService))
+	deliveryService.RegisterHandler(entity.DeliveryKindReminder, reminderService.Redeliver)
+
+	// 登録するWebhookの通知先には接続できないため、通知は再送キューに登録される（終了前に送信中の通知を待つ）
+	webhookService := service.NewWebhookService(database.NewWebhookRepository(db), notifier.NewHTTPWebhookSender(&http.Client{Timeout: time.Second}), service.WithWebhookDeliveryQueue(deliveryService))
+	deliveryService.RegisterHandler(entity.DeliveryKindWebhook, webhookService.Redeliver)
+	t.Cleanup(func() { webhookService.Wait(context.Background()) })
+
+	openAPIHandler, err := handler.NewOpenAPIHandler(api.OpenAPISpec)
+	if err != nil {
+		t.Fatalf("仕様書の読み込みに失敗: %v", err)
+	}
+
+	projectRepo := database.NewProjectRepository(db)
+	shareRepo := database.NewTodoShareRepository(db, sqlrepo.SQLite)
+	undoService := service.NewUndoService(time.Minute)
+	todoService := service.NewTodoService(todoRepo, service.WithTodoHistory(historyRepo), service.WithTodoChecklist(checklistRepo), service.WithTodoProjects(projectRepo), service.WithUniqueTitles(), service.WithTodoUndo(undoService), service.WithTodoWebhooks(webhookService), service.WithTodoShares(shareRepo))
+
+	router := NewRouter(handler.NewTodoHandler(todoService, handler.WithMarkdownRenderer(markdown.NewRenderer())), append([]RouterOption{
+		WithChecklistHandler(handler.NewChecklistHandler(service.NewChecklistService(checklistRepo, todoRepo))),
+		WithSchemaHandler(handler.NewSchemaHandler()),
+		WithProjectHandler(handler.NewProjectHandler(service.NewProjectService(projectRepo))),
+		WithReminderHandler(handler.NewReminderHandler(reminderService)),
+		WithHistoryHandler(handler.NewTodoHistoryHandler(service.NewTodoHistoryService(historyRepo, todoRepo))),
+		WithDueDateHandler(handler.NewDueDateHandler(service.NewDueDateService(database.NewDueDateRepository(db)))),
+		WithDeadLetterHandler(handler.NewDeadLetterHandler(deliveryService), contractAdminToken),
+		WithUndoHandler(handler.NewUndoHandler(undoService)),
+		WithWebhookHandler(handler.NewWebhookHandler(webhookService)),
+		WithTodoShareHandler(handler.NewTodoShareHandler(service.NewTodoShareService(todoRepo, shareRepo, database.NewUserRepository(db)))),
+		WithOpenAPIHandler(openAPIHandler),
+		WithMiddleware(middleware.CodecMiddleware(msgpack.Codec{})),
+		WithMiddleware(middleware.IdempotencyMiddleware(service.NewIdempotencyService(database.NewIdempotencyRepository(db), time.Hour))),
+		WithMiddleware(validation),
+	}, opts...)...)
+	return router.SetupRoutes()
+}
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// HealthCacheConfig はヘルスチェック結果のキャッシュ設定です
+type HealthCacheConfig struct {
+	// TTL はチェック結果を再利用する期間です
+	TTL time.Duration
+
+	// Jitter は有効期限に加えるランダムな揺らぎの最大値です（0〜Jitter の範囲で加算）
+	// レプリカごとにキャッシュの切れるタイミングをずらし、DBへのチェックが同時に集中しないようにします
+	Jitter time.Duration
+
+	// Now は現在時刻を返す関数です（nil の場合は time.Now、テストで固定するためのフィールド）
+	Now func() time.Time
+
+	// Rand は [0.0, 1.0) の乱数を返す関数です（nil の場合は math/rand を使用、テストで固定するためのフィールド）
+	Rand func() float64
+}
+
+// CachedHealthCheck はチェック結果を一定期間キャッシュするヘルスチェックを返します
+//
+// 学習ポイント：
+//  1. オーケストレーター（Kubernetes等）は短い間隔で /health を呼ぶため、毎回DBに問い合わせると
+//     「プローブの回数 × レプリカ数」のクエリがDBに届く
+//  2. 失敗した結果もキャッシュし、DBの障害中にプローブがDBへの負荷を増やさないようにする
+//  3. 同時に届いたプローブは1回のチェックを共有する（チェック中はロックを保持する）
+func CachedHealthCheck(check HealthCheck, cfg HealthCacheConfig) HealthCheck {
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+	random := cfg.Rand
+	if random == nil {
+		random = rand.Float64
+	}
+
+	var (
+		mu        sync.Mutex
+		expiresAt time.Time
+		details   any
+		checkErr  error
+	)
+	return func(ctx context.Context) (any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if now().Before(expiresAt) {
+			return details, checkErr
+		}
+
+		details, checkErr = check(ctx)
+		if ctx.Err() != nil {
+			// 呼び出し元の切断やタイムアウトによる失敗は、次のプローブに引き継がない
+			expiresAt = time.Time{}
+			return details, checkErr
+		}
+		ttl := cfg.TTL
+		if cfg.Jitter > 0 {
+			ttl += time.Duration(random() * float64(cfg.Jitter))
+		}
+		expiresAt = now().Add(ttl)
+		return details, checkErr
+	}
+}
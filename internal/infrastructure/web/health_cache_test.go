@@ -0,0 +1,75 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCachedHealthCheck は有効期限（TTL + ジッター）の間、チェック結果を再利用することをテストします
+func TestCachedHealthCheck(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	var failWith error
+	check := CachedHealthCheck(func(ctx context.Context) (any, error) {
+		calls++
+		return calls, failWith
+	}, HealthCacheConfig{
+		TTL:    2 * time.Second,
+		Jitter: time.Second,
+		Now:    func() time.Time { return now },
+		Rand:   func() float64 { return 0.5 },
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		advance     time.Duration
+		failWith    error
+		wantDetails int
+		wantErr     bool
+	}{
+		{name: "初回はチェックを実行", advance: 0, wantDetails: 1},
+		{name: "TTL内はキャッシュを返す", advance: 2 * time.Second, wantDetails: 1},
+		{name: "ジッター分を過ぎると再チェック", advance: 500 * time.Millisecond, wantDetails: 2},
+		{name: "失敗した結果もキャッシュする", advance: 3 * time.Second, failWith: errors.New("database ping failed"), wantDetails: 3, wantErr: true},
+		{name: "失敗のキャッシュ中", advance: time.Second, wantDetails: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			failWith = tt.failWith
+
+			details, err := check(ctx)
+			if details != tt.wantDetails {
+				t.Errorf("details = %v, 期待値 = %d", details, tt.wantDetails)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestCachedHealthCheck_CanceledContext は呼び出し元のキャンセルによる失敗をキャッシュしないことをテストします
+func TestCachedHealthCheck_CanceledContext(t *testing.T) {
+	calls := 0
+	check := CachedHealthCheck(func(ctx context.Context) (any, error) {
+		calls++
+		return nil, ctx.Err()
+	}, HealthCacheConfig{TTL: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := check(ctx); err == nil {
+		t.Fatal("キャンセルされたコンテキストでエラーが返されませんでした")
+	}
+	if _, err := check(context.Background()); err != nil {
+		t.Errorf("キャンセルによる失敗がキャッシュされています: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("チェックの実行回数 = %d, 期待値 = 2", calls)
+	}
+}
@@ -0,0 +1,55 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/dto"
+)
+
+// ルーターが返すエラー（未定義のパス・未対応のメソッド）も、ハンドラーと同じ ErrorResponse 形式のJSONで返します
+// http.NotFound / http.Error はテキストを返すため、クライアントがエラーの形式を判別する必要がなくなります
+
+// writeRouteError は ErrorResponse 形式のJSONでエラーを返します
+func writeRouteError(w http.ResponseWriter, statusCode int, message, details string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(dto.ErrorResponse{Error: message, Details: details})
+}
+
+// notFound は未定義のパスへのリクエストに 404 を返します
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeRouteError(w, http.StatusNotFound, "Not found", fmt.Sprintf("no route for %s", r.URL.Path))
+}
+
+// methodNotAllowed はパスが対応するメソッド以外のリクエストに、Allow ヘッダー付きの 405 を返します
+// OPTIONS の場合はエラーにせず、対応するメソッドを Allow ヘッダーで知らせる 204 を返します
+//
+// 各ルートの switch の default（または単一メソッドの判定）から呼び出すため、
+// Allow ヘッダーの内容はルーティングの定義と常に一致します
+func methodNotAllowed(w http.ResponseWriter, r *http.Request, allowed ...string) {
+	allow := allowHeader(allowed)
+	w.Header().Set("Allow", allow)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeRouteError(w, http.StatusMethodNotAllowed, "Method not allowed", fmt.Sprintf("%s is not supported (allowed: %s)", r.Method, allow))
+}
+
+// allowHeader は Allow ヘッダーの値を作成します
+// GET に対応するパスは HEAD にも対応し（HeadMiddleware）、全てのパスが OPTIONS に対応します
+func allowHeader(allowed []string) string {
+	methods := make([]string, 0, len(allowed)+2)
+	for _, method := range allowed {
+		methods = append(methods, method)
+		if method == http.MethodGet {
+			methods = append(methods, http.MethodHead)
+		}
+	}
+	methods = append(methods, http.MethodOptions)
+	return strings.Join(methods, ", ")
+}
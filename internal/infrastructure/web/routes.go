@@ -1,11 +1,16 @@
 package web
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/pprof"
 	"strings"
+	"time"
 
 	"todoapp-api-golang/internal/application/handler"
 	"todoapp-api-golang/internal/application/middleware"
+	"todoapp-api-golang/internal/domain/entity"
 )
 
 // Router は標準パッケージを使用したHTTPルーティングを管理する構造体です
@@ -17,16 +22,309 @@ import (
 // 4. ミドルウェアチェーンの構築
 // 5. RESTful URLパターンの実装
 type Router struct {
-	mux         *http.ServeMux
-	todoHandler *handler.TodoHandler
+	mux               *http.ServeMux
+	todoHandler       *handler.TodoHandler
+	checklistHandler  *handler.ChecklistHandler
+	schemaHandler     *handler.SchemaHandler
+	projectHandler    *handler.ProjectHandler
+	reminderHandler   *handler.ReminderHandler
+	historyHandler    *handler.TodoHistoryHandler
+	shareHandler      *handler.TodoShareHandler
+	workspaceHandler  *handler.WorkspaceHandler
+	deadLetterHandler *handler.DeadLetterHandler
+	dueDateHandler    *handler.DueDateHandler
+	undoHandler       *handler.UndoHandler
+	webhookHandler    *handler.WebhookHandler
+	tagHandler        *handler.TagHandler
+	openAPIHandler    *handler.OpenAPIHandler
+	transferHandler   *handler.TodoTransferHandler
+	graphQLHandler    *handler.GraphQLHandler
+	logLevelHandler   *handler.LogLevelHandler
+	dbPoolHandler     *handler.DBPoolHandler
+	staticHandler     *StaticHandler
+
+	// webSocketHandler は /ws でTodoの変更をリアルタイムに配信するハンドラーです（nil の場合は無効）
+	webSocketHandler http.Handler
+
+	// adminToken は /admin/ 配下の管理用エンドポイントの Bearer トークンです
+	adminToken string
+
+	// authHandler はユーザー登録とトークンの発行（/api/v1/auth/）のハンドラーです（nil の場合は認証を行わない）
+	authHandler *handler.AuthHandler
+
+	// sessionHandler はCookieのセッションによるログイン・ログアウト（/api/v1/auth/session）のハンドラーです（nil の場合は無効）
+	sessionHandler *handler.SessionHandler
+
+	// preferencesHandler はユーザー自身の設定（/api/v1/me/preferences）のハンドラーです（nil の場合は無効）
+	preferencesHandler *handler.PreferencesHandler
+
+	// authMiddleware はアクセストークンを検証するミドルウェアです（authHandler と同時に設定する）
+	authMiddleware func(http.Handler) http.Handler
+
+	// workspaceMiddleware は X-Workspace-ID ヘッダーのワークスペースを設定するミドルウェアです（workspaceHandler と同時に設定する）
+	workspaceMiddleware func(http.Handler) http.Handler
+
+	// healthChecks は /health で実行する依存先（データベース等）のチェックです
+	healthChecks []namedHealthCheck
+
+	// metricsHandler は /metrics でPrometheus形式のメトリクスを返すハンドラーです（nil の場合は無効）
+	metricsHandler http.Handler
+
+	// opsAuth は /metrics や /debug/pprof/ などの運用向けエンドポイントを保護するミドルウェアです（nil の場合は保護しない）
+	opsAuth func(http.Handler) http.Handler
+
+	// basePath はリバースプロキシ配下で公開する場合のURLのプレフィックス（例: /todoapp）
+	basePath string
+
+	// middlewares は標準のミドルウェアチェーンの内側に追加するミドルウェアです
+	middlewares []func(http.Handler) http.Handler
+}
+
+// HealthCheck は依存先の状態を確認する関数です
+// details はレスポンスにそのまま含める詳細情報、err は異常がある場合のエラーです
+type HealthCheck func(ctx context.Context) (details any, err error)
+
+// namedHealthCheck は名前付きのヘルスチェックです（登録順にレスポンスへ含めます）
+type namedHealthCheck struct {
+	name  string
+	check HealthCheck
+}
+
+// healthCheckTimeout は1回のヘルスチェック全体のタイムアウトです
+const healthCheckTimeout = 3 * time.Second
+
+// RouterOption はRouterに任意の機能（追加のハンドラー等）を設定する関数型オプションです
+// 必須の依存関係はコンストラクタ引数、任意の依存関係はオプションで受け取ります
+type RouterOption func(*Router)
+
+// WithChecklistHandler はチェックリストのサブリソースを有効にします
+// 設定しない場合、/api/v1/todos/{id}/checklist は 404 になります
+func WithChecklistHandler(h *handler.ChecklistHandler) RouterOption {
+	return func(router *Router) {
+		router.checklistHandler = h
+	}
+}
+
+// WithSchemaHandler はフィールド制約の公開エンドポイント（/api/v1/schema/{resource}）を有効にします
+func WithSchemaHandler(h *handler.SchemaHandler) RouterOption {
+	return func(router *Router) {
+		router.schemaHandler = h
+	}
+}
+
+// WithProjectHandler はプロジェクトのエンドポイント（/api/v1/projects）を有効にします
+func WithProjectHandler(h *handler.ProjectHandler) RouterOption {
+	return func(router *Router) {
+		router.projectHandler = h
+	}
+}
+
+// WithReminderHandler はリマインダーの操作（/api/v1/todos/{id}/reminder）を有効にします
+func WithReminderHandler(h *handler.ReminderHandler) RouterOption {
+	return func(router *Router) {
+		router.reminderHandler = h
+	}
+}
+
+// WithHistoryHandler は変更履歴の参照（/api/v1/todos/{id}/history）を有効にします
+func WithHistoryHandler(h *handler.TodoHistoryHandler) RouterOption {
+	return func(router *Router) {
+		router.historyHandler = h
+	}
+}
+
+// WithTodoShareHandler はTodoの共有の管理（/api/v1/todos/{id}/shares）を有効にします
+func WithTodoShareHandler(h *handler.TodoShareHandler) RouterOption {
+	return func(router *Router) {
+		router.shareHandler = h
+	}
+}
+
+// WithOpenAPIHandler はAPI仕様書（/api/v1/openapi.json）と Swagger UI（/api/v1/docs）を有効にします
+func WithOpenAPIHandler(h *handler.OpenAPIHandler) RouterOption {
+	return func(router *Router) {
+		router.openAPIHandler = h
+	}
+}
+
+// WithDueDateHandler は期限に基づくビュー（/api/v1/todos/overdue, today, upcoming）を有効にします
+func WithDueDateHandler(h *handler.DueDateHandler) RouterOption {
+	return func(router *Router) {
+		router.dueDateHandler = h
+	}
+}
+
+// WithDeadLetterHandler は管理者向けのデッドレター管理（/api/v1/admin/dead-letters）を有効にします
+// エンドポイントは adminToken を Bearer トークンとして送ったリクエストのみ受け付けます（ユーザーのアクセストークンでは呼び出せません）
+func WithDeadLetterHandler(h *handler.DeadLetterHandler, adminToken string) RouterOption {
+	return func(router *Router) {
+		router.deadLetterHandler = h
+		router.adminToken = adminToken
+	}
+}
+
+// WithUndoHandler は直前の操作の取り消し（/api/v1/undo）を有効にします
+func WithUndoHandler(h *handler.UndoHandler) RouterOption {
+	return func(router *Router) {
+		router.undoHandler = h
+	}
+}
+
+// WithTodoTransferHandler はTodoのインポート・エクスポート（/api/v1/todos/import, /api/v1/todos/export）を有効にします
+func WithTodoTransferHandler(h *handler.TodoTransferHandler) RouterOption {
+	return func(router *Router) {
+		router.transferHandler = h
+	}
+}
+
+// WithGraphQLHandler はGraphQL API（/graphql）を有効にします
+func WithGraphQLHandler(h *handler.GraphQLHandler) RouterOption {
+	return func(router *Router) {
+		router.graphQLHandler = h
+	}
+}
+
+// WithTagHandler はタグの一括操作（/api/v1/tags）を有効にします
+func WithTagHandler(h *handler.TagHandler) RouterOption {
+	return func(router *Router) {
+		router.tagHandler = h
+	}
+}
+
+// WithWebhookHandler はWebhookの登録の管理（/api/v1/webhooks）を有効にします
+func WithWebhookHandler(h *handler.WebhookHandler) RouterOption {
+	return func(router *Router) {
+		router.webhookHandler = h
+	}
+}
+
+// WithLogLevelHandler は実行中のログレベルの変更（/admin/loglevel）を有効にします
+// エンドポイントは adminToken を Bearer トークンとして送ったリクエストのみ受け付けます
+func WithLogLevelHandler(h *handler.LogLevelHandler, adminToken string) RouterOption {
+	return func(router *Router) {
+		router.logLevelHandler = h
+		router.adminToken = adminToken
+	}
+}
+
+// WithDBPoolHandler は実行中のデータベースの接続プールの設定の変更（/admin/dbpool）を有効にします
+// エンドポイントは adminToken を Bearer トークンとして送ったリクエストのみ受け付けます
+func WithDBPoolHandler(h *handler.DBPoolHandler, adminToken string) RouterOption {
+	return func(router *Router) {
+		router.dbPoolHandler = h
+		router.adminToken = adminToken
+	}
+}
+
+// WithAuth はユーザー認証（/api/v1/auth/ とアクセストークンの検証）を有効にします
+// /api/v1/ 配下（トークンを発行する /api/v1/auth/ と API仕様書を除く）と /graphql は、
+// authMiddleware が検証したアクセストークンを持つリクエストのみ受け付けます
+func WithAuth(h *handler.AuthHandler, authMiddleware func(http.Handler) http.Handler) RouterOption {
+	return func(router *Router) {
+		router.authHandler = h
+		router.authMiddleware = authMiddleware
+	}
+}
+
+// WithSessions はCookieのセッションによるログイン・ログアウト（/api/v1/auth/session）を有効にします
+// セッションのCookieを検証するため、WithAuth には middleware.SessionAuthMiddleware を渡してください
+func WithSessions(h *handler.SessionHandler) RouterOption {
+	return func(router *Router) {
+		router.sessionHandler = h
+	}
+}
+
+// WithPreferencesHandler はユーザー自身の設定（GET・PUT /api/v1/me/preferences）を有効にします
+// 設定はユーザーごとに保存するため、WithAuth と合わせて設定してください
+func WithPreferencesHandler(h *handler.PreferencesHandler) RouterOption {
+	return func(router *Router) {
+		router.preferencesHandler = h
+	}
+}
+
+// WithWorkspaces はワークスペース（/api/v1/workspaces と X-Workspace-ID ヘッダー）を有効にします
+// workspaceMiddleware はアクセストークンの検証の直後に実行するため、WithAuth と合わせて設定してください
+func WithWorkspaces(h *handler.WorkspaceHandler, workspaceMiddleware func(http.Handler) http.Handler) RouterOption {
+	return func(router *Router) {
+		router.workspaceHandler = h
+		router.workspaceMiddleware = workspaceMiddleware
+	}
+}
+
+// WithHealthCheck は /health で依存先のチェックを実行するようにします
+// いずれかのチェックが失敗した場合、/health は 503 Service Unavailable を返します
+func WithHealthCheck(name string, check HealthCheck) RouterOption {
+	return func(router *Router) {
+		router.healthChecks = append(router.healthChecks, namedHealthCheck{name: name, check: check})
+	}
+}
+
+// WithMetricsHandler はPrometheus形式のメトリクス（/metrics）の公開を有効にします
+// WithOpsAuth を設定しない場合は認証を行わないため、公開範囲はリバースプロキシ等で制限してください
+func WithMetricsHandler(h http.Handler) RouterOption {
+	return func(router *Router) {
+		router.metricsHandler = h
+	}
+}
+
+// WithOpsAuth は運用向けエンドポイントを opsAuth（Basic 認証など）で保護します
+// 設定すると /metrics を保護し、あわせて /debug/pprof/ のプロファイリングを公開します
+// （pprof はメモリやゴルーチンの内部状態を含むため、保護なしでは公開しません）
+func WithOpsAuth(opsAuth func(http.Handler) http.Handler) RouterOption {
+	return func(router *Router) {
+		router.opsAuth = opsAuth
+	}
+}
+
+// WithWebSocketHandler はTodoの変更のリアルタイム配信（/ws）を有効にします
+func WithWebSocketHandler(h http.Handler) RouterOption {
+	return func(router *Router) {
+		router.webSocketHandler = h
+	}
+}
+
+// WithStaticHandler は組み込みUI（/ と /static/*）の配信を有効にします
+func WithStaticHandler(h *StaticHandler) RouterOption {
+	return func(router *Router) {
+		router.staticHandler = h
+	}
+}
+
+// WithBasePath はアプリケーション全体をURLのプレフィックス（例: /todoapp）配下で公開します
+// ルートの登録やハンドラーのパス解析はプレフィックスなしのまま行い、
+// 受信時にプレフィックスを取り除く・リンクとリダイレクトに付与する処理はミドルウェアが担当します
+func WithBasePath(basePath string) RouterOption {
+	return func(router *Router) {
+		router.basePath = basePath
+	}
+}
+
+// WithMiddleware は標準のミドルウェアチェーンの最も内側（ベースパスの除去の後）にミドルウェアを追加します
+// 追加したミドルウェアは、ベースパスを取り除いたパス（/api/v1/...）でリクエストを受け取ります
+func WithMiddleware(middlewares ...func(http.Handler) http.Handler) RouterOption {
+	return func(router *Router) {
+		router.middlewares = append(router.middlewares, middlewares...)
+	}
+}
+
+// protectOps は運用向けの保護が設定されていれば h をそのミドルウェアで包みます
+func (router *Router) protectOps(h http.Handler) http.Handler {
+	if router.opsAuth == nil {
+		return h
+	}
+	return router.opsAuth(h)
 }
 
 // NewRouter はRouterのコンストラクタです
-func NewRouter(todoHandler *handler.TodoHandler) *Router {
-	return &Router{
+func NewRouter(todoHandler *handler.TodoHandler, opts ...RouterOption) *Router {
+	router := &Router{
 		mux:         http.NewServeMux(),
 		todoHandler: todoHandler,
 	}
+	for _, opt := range opts {
+		opt(router)
+	}
+	return router
 }
 
 // SetupRoutes はHTTPルーティングを設定します
@@ -36,29 +334,153 @@ func (router *Router) SetupRoutes() http.Handler {
 	// システムの稼働状態を確認するためのシンプルなエンドポイント
 	router.mux.HandleFunc("/health", router.healthCheckHandler)
 
+	// 1-1. メトリクスエンドポイント（Prometheus がスクレイプする）
+	if router.metricsHandler != nil {
+		router.mux.Handle("/metrics", router.protectOps(router.metricsHandler))
+	}
+
+	// 1-2. プロファイリングエンドポイント（運用向けの保護を設定した場合のみ公開する）
+	if router.opsAuth != nil {
+		router.mux.Handle("/debug/pprof/", router.opsAuth(http.HandlerFunc(pprof.Index)))
+		router.mux.Handle("/debug/pprof/cmdline", router.opsAuth(http.HandlerFunc(pprof.Cmdline)))
+		router.mux.Handle("/debug/pprof/profile", router.opsAuth(http.HandlerFunc(pprof.Profile)))
+		router.mux.Handle("/debug/pprof/symbol", router.opsAuth(http.HandlerFunc(pprof.Symbol)))
+		router.mux.Handle("/debug/pprof/trace", router.opsAuth(http.HandlerFunc(pprof.Trace)))
+	}
+
 	// 2. API v1のルートハンドラー
 	// /api/v1/* へのすべてのリクエストを単一のハンドラーで処理
 	// 標準パッケージでは詳細なパスマッチングを手動で実装
 	router.mux.HandleFunc("/api/v1/", router.apiV1Handler)
 
+	// 2-1. 最近の活動のAtomフィード（フィードリーダー向けのため /api/v1 の外に置く）
+	// 認証が有効な場合は /api/v1 と同じくアクセストークンが必要で、本人（またはワークスペース）のTodoの活動だけを返す
+	if router.historyHandler != nil {
+		router.mux.HandleFunc(activityFeedPath, func(w http.ResponseWriter, r *http.Request) {
+			if !handler.RequireScope(w, r, entity.ScopeTodosRead) {
+				return
+			}
+			router.historyHandler.ActivityFeed(w, r)
+		})
+	}
+
+	// 2-2. 管理用エンドポイント（運用者向けのため /api/v1 の外に置き、トークンで保護する）
+	adminAuth := middleware.AdminTokenMiddleware(router.adminToken)
+	if router.logLevelHandler != nil {
+		router.mux.Handle("/admin/loglevel", adminAuth(http.HandlerFunc(router.logLevelHandler.LogLevel)))
+	}
+	if router.dbPoolHandler != nil {
+		router.mux.Handle("/admin/dbpool", adminAuth(http.HandlerFunc(router.dbPoolHandler.DBPool)))
+	}
+
+	// 2-3. GraphQL API（RESTとは別のエンドポイントとして /api/v1 の外に置く）
+	if router.graphQLHandler != nil {
+		router.mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+			if !handler.RequireScope(w, r, requiredScope("todos", r.Method)) {
+				return
+			}
+			router.graphQLHandler.GraphQL(w, r)
+		})
+	}
+
+	// 2-4. リアルタイム配信（ブラウザの WebSocket が接続するため /api/v1 の外に置く）
+	// 認証が有効な場合はハンドシェイクでアクセストークン（ブラウザはセッションのCookie）を検証し、参照できるTodoの変更だけを配信する
+	if router.webSocketHandler != nil {
+		router.mux.Handle(webSocketPath, router.webSocketHandler)
+	}
+
+	// 2-5. 組み込みUIの静的ファイル
+	// "/{$}" はルートパスのみに一致するパターン（他の未定義パスは404のまま）
+	if router.staticHandler != nil {
+		router.mux.Handle("/{$}", router.staticHandler)
+		router.mux.Handle(staticPathPrefix, router.staticHandler)
+	}
+
+	// 2-6. どのパターンにも一致しないパス（ServeMux の既定のテキストではなく、JSONの 404 を返す）
+	router.mux.HandleFunc("/", notFound)
+
 	// 3. ミドルウェアチェーンの構築
 	// 複数のミドルウェアを組み合わせてリクエスト処理を強化
-	finalHandler := middleware.ChainMiddleware(
+	// ベースパスの除去は最も内側で行い、アクセスログには元のパスが記録されるようにする
+	// ベースパス外へのリクエストも、未定義のパスと同じ ErrorResponse 形式の 404 を返す
+	stripBasePath := middleware.BasePathMiddleware(router.basePath, http.HandlerFunc(notFound))
+	middlewares := []func(http.Handler) http.Handler{
 		middleware.RecoveryMiddleware,   // パニック回復
 		middleware.LoggingMiddleware,    // アクセスログ
 		middleware.SimpleCORSMiddleware, // CORS対応
 		middleware.RequestIDMiddleware,  // リクエストID付与
-	)(router.mux)
+		middleware.HeadMiddleware,       // HEAD を GET として処理し、ボディを除いて返す
+		middleware.DeadlineMiddleware,   // クライアントが指定した期限の設定
+		middleware.ActorMiddleware,      // 操作者の設定（変更履歴用）
+		stripBasePath,                   // ベースパスの除去（未設定なら何もしない）
+		router.requireAuth,              // アクセストークンの検証（認証が有効な場合のみ）
+	}
+	finalHandler := middleware.ChainMiddleware(append(middlewares, router.middlewares...)...)(router.mux)
 
 	return finalHandler
 }
 
+// webSocketPath はリアルタイム配信の WebSocket のパスです
+const webSocketPath = "/ws"
+
+// activityFeedPath は最近の活動のAtomフィードのパスです
+const activityFeedPath = "/feeds/todos.atom"
+
+// publicAPIResources は認証が有効な場合も、アクセストークンなしで呼び出せる /api/v1/ 配下のリソースです
+// トークンを発行するエンドポイントと、API仕様書（クライアントが認証方法を知るためのもの）が該当します
+// 管理者向けのエンドポイントはユーザーのアクセストークンではなく、管理用トークン（ADMIN_TOKEN）で保護します
+var publicAPIResources = map[string]bool{
+	"auth":         true,
+	"openapi.json": true,
+	"docs":         true,
+	"admin":        true,
+}
+
+// requireAuth は認証が有効な場合に、/api/v1/ 配下と /graphql へのリクエストのアクセストークンを検証するミドルウェアです
+// WithMiddleware で追加したミドルウェア（Idempotency-Key 等）より外側に置き、認証されたユーザーを操作者として使わせます
+// ワークスペースが有効な場合は、検証の直後に X-Workspace-ID ヘッダーのワークスペースを設定します
+func (router *Router) requireAuth(next http.Handler) http.Handler {
+	if router.authMiddleware == nil {
+		return next
+	}
+	inner := next
+	if router.workspaceMiddleware != nil {
+		inner = router.workspaceMiddleware(next)
+	}
+	authenticated := router.authMiddleware(inner)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requiresAuth(r.URL.Path) {
+			authenticated.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requiresAuth はパスがアクセストークンを必要とするかを判定します（publicAPIResources を除く /api/v1/ 配下、/graphql、Atomフィードと /ws）
+func requiresAuth(path string) bool {
+	if path == "/graphql" || path == activityFeedPath || path == webSocketPath {
+		return true
+	}
+	rest, ok := strings.CutPrefix(path, "/api/v1/")
+	if !ok {
+		return false
+	}
+	resource, _, _ := strings.Cut(rest, "/")
+	return !publicAPIResources[resource]
+}
+
 // healthCheckHandler はヘルスチェックエンドポイントのハンドラーです
 // GET /health への対応
 func (router *Router) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	// HTTPメソッドの確認
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		methodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+
+	if len(router.healthChecks) > 0 {
+		router.writeHealthChecks(w, r)
 		return
 	}
 
@@ -75,6 +497,44 @@ func (router *Router) healthCheckHandler(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte(response))
 }
 
+// healthCheckResult は1つのヘルスチェックの結果です
+type healthCheckResult struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// writeHealthChecks は登録されたチェックを実行し、結果をまとめて返します
+// ロードバランサーが異常なインスタンスを切り離せるよう、失敗がある場合は 503 を返します
+func (router *Router) writeHealthChecks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	status := "ok"
+	statusCode := http.StatusOK
+	checks := make(map[string]healthCheckResult, len(router.healthChecks))
+	for _, hc := range router.healthChecks {
+		details, err := hc.check(ctx)
+		result := healthCheckResult{Status: "ok", Details: details}
+		if err != nil {
+			result.Status = "error"
+			result.Error = err.Error()
+			status = "error"
+			statusCode = http.StatusServiceUnavailable
+		}
+		checks[hc.name] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]any{
+		"status":  status,
+		"message": "Todo API is running",
+		"version": "1.0.0",
+		"checks":  checks,
+	})
+}
+
 // apiV1Handler は /api/v1/* へのすべてのリクエストを処理するメインハンドラーです
 // 標準パッケージでの手動ルーティングの実装例
 func (router *Router) apiV1Handler(w http.ResponseWriter, r *http.Request) {
@@ -87,7 +547,12 @@ func (router *Router) apiV1Handler(w http.ResponseWriter, r *http.Request) {
 
 	// 空のパスや無効なパスの処理
 	if len(segments) == 0 || segments[0] == "" {
-		http.NotFound(w, r)
+		notFound(w, r)
+		return
+	}
+
+	// スコープを限定したアクセストークンは、リソースとメソッドに対応するスコープがある場合だけ通す
+	if !handler.RequireScope(w, r, requiredScope(segments[0], r.Method)) {
 		return
 	}
 
@@ -95,8 +560,338 @@ func (router *Router) apiV1Handler(w http.ResponseWriter, r *http.Request) {
 	switch segments[0] {
 	case "todos":
 		router.handleTodosRoutes(w, r, segments[1:])
+	case "projects":
+		router.handleProjectsRoutes(w, r, segments[1:])
+	case "schema":
+		// GET /api/v1/schema/{resource} -> フィールド制約の取得
+		if router.schemaHandler == nil || len(segments) != 2 {
+			notFound(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.schemaHandler.GetSchema(w, r)
+	case "admin":
+		middleware.AdminTokenMiddleware(router.adminToken)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			router.handleAdminRoutes(w, r, segments[1:])
+		})).ServeHTTP(w, r)
+	case "auth":
+		router.handleAuthRoutes(w, r, segments[1:])
+	case "webhooks":
+		router.handleWebhooksRoutes(w, r, segments[1:])
+	case "tags":
+		router.handleTagsRoutes(w, r, segments[1:])
+	case "workspaces":
+		router.handleWorkspacesRoutes(w, r, segments[1:])
+	case "openapi.json":
+		// GET /api/v1/openapi.json -> API仕様書
+		if router.openAPIHandler == nil || len(segments) != 1 {
+			notFound(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.openAPIHandler.Spec(w, r)
+	case "docs":
+		// GET /api/v1/docs -> API仕様書の Swagger UI
+		if router.openAPIHandler == nil || len(segments) != 1 {
+			notFound(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.openAPIHandler.SwaggerUI(w, r)
+	case "undo":
+		// POST /api/v1/undo -> 直前の操作の取り消し
+		if router.undoHandler == nil || len(segments) != 1 {
+			notFound(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.undoHandler.Undo(w, r)
+	case "tokens":
+		// POST /api/v1/tokens -> スコープを限定したアクセストークンの発行
+		if router.authHandler == nil || len(segments) != 1 {
+			notFound(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.authHandler.CreateToken(w, r)
+	case "me":
+		// GET /api/v1/me/preferences -> 設定の取得
+		// PUT /api/v1/me/preferences -> 設定の置き換え
+		if router.preferencesHandler == nil || len(segments) != 2 || segments[1] != "preferences" {
+			notFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			router.preferencesHandler.GetPreferences(w, r)
+		case http.MethodPut:
+			router.preferencesHandler.UpdatePreferences(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodGet, http.MethodPut)
+		}
+	default:
+		notFound(w, r)
+	}
+}
+
+// requiredScope はリソースへのリクエストに必要なアクセストークンのスコープを返します
+// Todo（/api/v1/todos と /graphql）は取得に todos:read、変更に todos:write が必要です
+// スコープを定義していないリソースは空文字列を返し、スコープを限定していないトークンだけが操作できます
+func requiredScope(resource, method string) string {
+	if resource != "todos" {
+		return ""
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return entity.ScopeTodosRead
+	default:
+		return entity.ScopeTodosWrite
+	}
+}
+
+// handleAdminRoutes は管理者向けエンドポイントへのルーティングを処理します
+// GET    /api/v1/admin/dead-letters
+// POST   /api/v1/admin/dead-letters/{id}/requeue
+// DELETE /api/v1/admin/dead-letters/{id}
+func (router *Router) handleAdminRoutes(w http.ResponseWriter, r *http.Request, segments []string) {
+	if router.deadLetterHandler == nil || len(segments) == 0 || segments[0] != "dead-letters" {
+		notFound(w, r)
+		return
+	}
+
+	switch {
+	case len(segments) == 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.deadLetterHandler.ListDeadLetters(w, r)
+	case len(segments) == 2:
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w, r, http.MethodDelete)
+			return
+		}
+		router.deadLetterHandler.DiscardDeadLetter(w, r)
+	case len(segments) == 3 && segments[2] == "requeue":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.deadLetterHandler.RequeueDeadLetter(w, r)
+	default:
+		notFound(w, r)
+	}
+}
+
+// handleWorkspacesRoutes はワークスペースへのルーティングを処理します
+// GET・POST /api/v1/workspaces, POST /api/v1/workspaces/join,
+// GET /api/v1/workspaces/{id}/members, DELETE /api/v1/workspaces/{id}/members/{username},
+// POST /api/v1/workspaces/{id}/invitations
+func (router *Router) handleWorkspacesRoutes(w http.ResponseWriter, r *http.Request, segments []string) {
+	if router.workspaceHandler == nil {
+		notFound(w, r)
+		return
+	}
+
+	switch {
+	case len(segments) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			router.workspaceHandler.ListWorkspaces(w, r)
+		case http.MethodPost:
+			router.workspaceHandler.CreateWorkspace(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodGet, http.MethodPost)
+		}
+	case len(segments) == 1 && segments[0] == "join":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.workspaceHandler.AcceptInvitation(w, r)
+	case len(segments) == 2 && segments[1] == "members":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.workspaceHandler.ListMembers(w, r)
+	case len(segments) == 3 && segments[1] == "members":
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w, r, http.MethodDelete)
+			return
+		}
+		router.workspaceHandler.RemoveMember(w, r)
+	case len(segments) == 2 && segments[1] == "invitations":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.workspaceHandler.CreateInvitation(w, r)
 	default:
-		http.NotFound(w, r)
+		notFound(w, r)
+	}
+}
+
+// handleAuthRoutes はユーザー登録とトークンの発行へのルーティングを処理します
+// POST /api/v1/auth/register, login, refresh, logout
+// POST/DELETE /api/v1/auth/session（Cookieのセッション）
+func (router *Router) handleAuthRoutes(w http.ResponseWriter, r *http.Request, segments []string) {
+	if router.authHandler == nil || len(segments) != 1 {
+		notFound(w, r)
+		return
+	}
+
+	if segments[0] == "session" && router.sessionHandler != nil {
+		switch r.Method {
+		case http.MethodPost:
+			router.sessionHandler.Login(w, r)
+		case http.MethodDelete:
+			router.sessionHandler.Logout(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodPost, http.MethodDelete)
+		}
+		return
+	}
+
+	var serve http.HandlerFunc
+	switch segments[0] {
+	case "register":
+		serve = router.authHandler.Register
+	case "login":
+		serve = router.authHandler.Login
+	case "refresh":
+		serve = router.authHandler.Refresh
+	case "logout":
+		serve = router.authHandler.Logout
+	default:
+		notFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, r, http.MethodPost)
+		return
+	}
+	serve(w, r)
+}
+
+// handleWebhooksRoutes はWebhookの登録へのルーティングを処理します
+// GET/POST /api/v1/webhooks, GET/DELETE /api/v1/webhooks/{id}
+func (router *Router) handleWebhooksRoutes(w http.ResponseWriter, r *http.Request, segments []string) {
+	if router.webhookHandler == nil {
+		notFound(w, r)
+		return
+	}
+
+	switch len(segments) {
+	case 0:
+		switch r.Method {
+		case http.MethodGet:
+			router.webhookHandler.ListWebhooks(w, r)
+		case http.MethodPost:
+			router.webhookHandler.RegisterWebhook(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodGet, http.MethodPost)
+		}
+	case 1:
+		switch r.Method {
+		case http.MethodGet:
+			router.webhookHandler.GetWebhook(w, r)
+		case http.MethodDelete:
+			router.webhookHandler.DeleteWebhook(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodGet, http.MethodDelete)
+		}
+	default:
+		notFound(w, r)
+	}
+}
+
+// handleTagsRoutes はタグの一括操作へのルーティングを処理します
+// POST /api/v1/tags/merge, POST/DELETE /api/v1/tags/{tag}/todos
+func (router *Router) handleTagsRoutes(w http.ResponseWriter, r *http.Request, segments []string) {
+	if router.tagHandler == nil {
+		notFound(w, r)
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && segments[0] == "merge":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.tagHandler.MergeTags(w, r)
+	case len(segments) == 2 && segments[1] == "todos":
+		switch r.Method {
+		case http.MethodPost:
+			router.tagHandler.AddTag(w, r)
+		case http.MethodDelete:
+			router.tagHandler.RemoveTag(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodPost, http.MethodDelete)
+		}
+	default:
+		notFound(w, r)
+	}
+}
+
+// handleProjectsRoutes はプロジェクトリソースへのルーティングを処理します
+// GET/POST /api/v1/projects, GET /api/v1/projects/{id|slug}, POST /api/v1/projects/{id|slug}/{archive|restore}
+func (router *Router) handleProjectsRoutes(w http.ResponseWriter, r *http.Request, segments []string) {
+	if router.projectHandler == nil {
+		notFound(w, r)
+		return
+	}
+
+	switch len(segments) {
+	case 0:
+		switch r.Method {
+		case http.MethodGet:
+			router.projectHandler.GetAllProjects(w, r)
+		case http.MethodPost:
+			router.projectHandler.CreateProject(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodGet, http.MethodPost)
+		}
+	case 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.projectHandler.GetProject(w, r)
+	case 2:
+		var action http.HandlerFunc
+		switch segments[1] {
+		case "archive":
+			action = router.projectHandler.ArchiveProject
+		case "restore":
+			action = router.projectHandler.RestoreProject
+		default:
+			notFound(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		action(w, r)
+	default:
+		notFound(w, r)
 	}
 }
 
@@ -106,12 +901,92 @@ func (router *Router) apiV1Handler(w http.ResponseWriter, r *http.Request) {
 // 対応するエンドポイント：
 // GET    /api/v1/todos           -> 一覧取得
 // POST   /api/v1/todos           -> 新規作成
+// GET    /api/v1/todos/overdue   -> 期限切れの一覧
+// GET    /api/v1/todos/today     -> 今日が期限の一覧
+// GET    /api/v1/todos/upcoming  -> 今後の予定の一覧
+// GET    /api/v1/todos/stats     -> 件数と見積もり・実績の集計
 // GET    /api/v1/todos/{id}      -> 詳細取得
 // PUT    /api/v1/todos/{id}      -> 更新
 // DELETE /api/v1/todos/{id}      -> 削除
 // PATCH  /api/v1/todos/{id}/complete   -> 完了
 // PATCH  /api/v1/todos/{id}/incomplete -> 未完了
+// POST   /api/v1/todos/{id}/duplicate  -> 複製
+// *      /api/v1/todos/{id}/checklist[/{itemId}] -> チェックリスト
+// *      /api/v1/todos/{id}/reminder[/snooze]   -> リマインダー
+// GET    /api/v1/todos/{id}/history     -> 変更履歴
+// *      /api/v1/todos/{id}/shares[/{username}] -> 共有
 func (router *Router) handleTodosRoutes(w http.ResponseWriter, r *http.Request, segments []string) {
+	// 期限に基づくビューはIDと同じ位置のため、IDより先に判定
+	if len(segments) == 1 && router.dueDateHandler != nil {
+		var view http.HandlerFunc
+		switch segments[0] {
+		case "overdue":
+			view = router.dueDateHandler.ListOverdue
+		case "today":
+			view = router.dueDateHandler.ListDueToday
+		case "upcoming":
+			view = router.dueDateHandler.ListUpcoming
+		case "calendar.ics":
+			view = router.dueDateHandler.Calendar
+		}
+		if view != nil {
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, r, http.MethodGet)
+				return
+			}
+			view(w, r)
+			return
+		}
+	}
+
+	// 集計もIDと同じ位置のため、IDより先に判定
+	if len(segments) == 1 && segments[0] == "stats" {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.todoHandler.GetTodoStats(w, r)
+		return
+	}
+
+	// インポート・エクスポートもIDと同じ位置のため、IDより先に判定
+	if len(segments) == 1 && router.transferHandler != nil {
+		switch segments[0] {
+		case "export":
+			if r.Method != http.MethodGet {
+				methodNotAllowed(w, r, http.MethodGet)
+				return
+			}
+			router.transferHandler.Export(w, r)
+			return
+		case "import":
+			if r.Method != http.MethodPost {
+				methodNotAllowed(w, r, http.MethodPost)
+				return
+			}
+			router.transferHandler.Import(w, r)
+			return
+		}
+	}
+
+	// サブリソース（/api/v1/todos/{id}/checklist...）はアクションより先に判定
+	if len(segments) >= 2 && segments[1] == "checklist" {
+		router.handleChecklistRoutes(w, r, segments[0], segments[2:])
+		return
+	}
+	if len(segments) >= 2 && segments[1] == "reminder" {
+		router.handleReminderRoutes(w, r, segments[0], segments[2:])
+		return
+	}
+	if len(segments) == 2 && segments[1] == "history" {
+		router.handleHistoryRoutes(w, r, segments[0])
+		return
+	}
+	if len(segments) >= 2 && segments[1] == "shares" {
+		router.handleShareRoutes(w, r, segments[0], segments[2:])
+		return
+	}
+
 	switch len(segments) {
 	case 0:
 		// /api/v1/todos
@@ -123,7 +998,111 @@ func (router *Router) handleTodosRoutes(w http.ResponseWriter, r *http.Request,
 		// /api/v1/todos/{id}/{action}
 		router.handleTodoAction(w, r, segments[0], segments[1])
 	default:
-		http.NotFound(w, r)
+		notFound(w, r)
+	}
+}
+
+// handleChecklistRoutes はTodoのチェックリスト（サブリソース）へのルーティングを処理します
+// /api/v1/todos/{id}/checklist および /api/v1/todos/{id}/checklist/{itemId} へのリクエスト
+func (router *Router) handleChecklistRoutes(w http.ResponseWriter, r *http.Request, id string, rest []string) {
+	if router.checklistHandler == nil || id == "" {
+		notFound(w, r)
+		return
+	}
+
+	switch len(rest) {
+	case 0:
+		switch r.Method {
+		case http.MethodGet:
+			router.checklistHandler.ListItems(w, r)
+		case http.MethodPost:
+			router.checklistHandler.AddItem(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodGet, http.MethodPost)
+		}
+	case 1:
+		switch r.Method {
+		case http.MethodGet:
+			router.checklistHandler.GetItem(w, r)
+		case http.MethodPut:
+			router.checklistHandler.UpdateItem(w, r)
+		case http.MethodDelete:
+			router.checklistHandler.DeleteItem(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodGet, http.MethodPut, http.MethodDelete)
+		}
+	default:
+		notFound(w, r)
+	}
+}
+
+// handleReminderRoutes はTodoのリマインダーへのルーティングを処理します
+// DELETE /api/v1/todos/{id}/reminder, POST /api/v1/todos/{id}/reminder/snooze
+func (router *Router) handleReminderRoutes(w http.ResponseWriter, r *http.Request, id string, rest []string) {
+	if router.reminderHandler == nil || id == "" {
+		notFound(w, r)
+		return
+	}
+
+	switch {
+	case len(rest) == 0:
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w, r, http.MethodDelete)
+			return
+		}
+		router.reminderHandler.CancelReminder(w, r)
+	case len(rest) == 1 && rest[0] == "snooze":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.reminderHandler.SnoozeReminder(w, r)
+	default:
+		notFound(w, r)
+	}
+}
+
+// handleHistoryRoutes はTodoの変更履歴へのルーティングを処理します
+// GET /api/v1/todos/{id}/history
+func (router *Router) handleHistoryRoutes(w http.ResponseWriter, r *http.Request, id string) {
+	if router.historyHandler == nil || id == "" {
+		notFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+	router.historyHandler.GetHistory(w, r)
+}
+
+// handleShareRoutes はTodoの共有へのルーティングを処理します
+// GET /api/v1/todos/{id}/shares, PUT・DELETE /api/v1/todos/{id}/shares/{username}
+func (router *Router) handleShareRoutes(w http.ResponseWriter, r *http.Request, id string, rest []string) {
+	if router.shareHandler == nil || id == "" {
+		notFound(w, r)
+		return
+	}
+
+	switch len(rest) {
+	case 0:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, r, http.MethodGet)
+			return
+		}
+		router.shareHandler.ListShares(w, r)
+	case 1:
+		switch r.Method {
+		case http.MethodPut:
+			router.shareHandler.ShareTodo(w, r)
+		case http.MethodDelete:
+			router.shareHandler.UnshareTodo(w, r)
+		default:
+			methodNotAllowed(w, r, http.MethodPut, http.MethodDelete)
+		}
+	default:
+		notFound(w, r)
 	}
 }
 
@@ -137,10 +1116,11 @@ func (router *Router) handleTodoCollection(w http.ResponseWriter, r *http.Reques
 	case http.MethodPost:
 		// POST /api/v1/todos -> 新Todo作成
 		router.todoHandler.CreateTodo(w, r)
+	case http.MethodPatch:
+		// PATCH /api/v1/todos -> 複数Todoの一括部分更新
+		router.todoHandler.BatchUpdateTodos(w, r)
 	default:
-		// サポートされていないHTTPメソッド
-		w.Header().Set("Allow", "GET, POST")
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		methodNotAllowed(w, r, http.MethodGet, http.MethodPost, http.MethodPatch)
 	}
 }
 
@@ -149,7 +1129,7 @@ func (router *Router) handleTodoCollection(w http.ResponseWriter, r *http.Reques
 func (router *Router) handleTodoItem(w http.ResponseWriter, r *http.Request, id string) {
 	// IDの基本的な検証（空文字チェック）
 	if id == "" {
-		http.Error(w, "Todo ID is required", http.StatusBadRequest)
+		writeRouteError(w, http.StatusBadRequest, "Invalid URL", "todo ID is required")
 		return
 	}
 
@@ -164,9 +1144,7 @@ func (router *Router) handleTodoItem(w http.ResponseWriter, r *http.Request, id
 		// DELETE /api/v1/todos/{id} -> Todo削除
 		router.todoHandler.DeleteTodo(w, r)
 	default:
-		// サポートされていないHTTPメソッド
-		w.Header().Set("Allow", "GET, PUT, DELETE")
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		methodNotAllowed(w, r, http.MethodGet, http.MethodPut, http.MethodDelete)
 	}
 }
 
@@ -175,28 +1153,40 @@ func (router *Router) handleTodoItem(w http.ResponseWriter, r *http.Request, id
 func (router *Router) handleTodoAction(w http.ResponseWriter, r *http.Request, id, action string) {
 	// IDの基本的な検証
 	if id == "" {
-		http.Error(w, "Todo ID is required", http.StatusBadRequest)
+		writeRouteError(w, http.StatusBadRequest, "Invalid URL", "todo ID is required")
 		return
 	}
 
-	// PATCHメソッドのみサポート
-	if r.Method != http.MethodPatch {
-		w.Header().Set("Allow", "PATCH")
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	// POST /api/v1/todos/{id}/duplicate -> Todoの複製（新しいリソースを作成するためPOST）
+	if action == "duplicate" {
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, r, http.MethodPost)
+			return
+		}
+		router.todoHandler.DuplicateTodo(w, r)
 		return
 	}
 
 	// アクションタイプによる分岐
+	var handle http.HandlerFunc
 	switch action {
 	case "complete":
 		// PATCH /api/v1/todos/{id}/complete -> Todo完了
-		router.todoHandler.CompleteTodo(w, r)
+		handle = router.todoHandler.CompleteTodo
 	case "incomplete":
 		// PATCH /api/v1/todos/{id}/incomplete -> Todo未完了
-		router.todoHandler.IncompleteTodo(w, r)
+		handle = router.todoHandler.IncompleteTodo
 	default:
-		http.NotFound(w, r)
+		notFound(w, r)
+		return
+	}
+
+	// 完了・未完了の切り替えはPATCHメソッドのみサポート
+	if r.Method != http.MethodPatch {
+		methodNotAllowed(w, r, http.MethodPatch)
+		return
 	}
+	handle(w, r)
 }
 
 // GetMux はhttp.ServeMuxを返します（テスト等で使用）
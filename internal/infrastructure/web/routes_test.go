@@ -0,0 +1,353 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"todoapp-api-golang/internal/application/dto"
+	"todoapp-api-golang/internal/application/handler"
+	"todoapp-api-golang/internal/application/middleware"
+	"todoapp-api-golang/internal/domain/service"
+	"todoapp-api-golang/internal/infrastructure/websocket"
+)
+
+// TestRouter_HealthChecks は /health が登録されたチェックの結果を返すことをテストします
+func TestRouter_HealthChecks(t *testing.T) {
+	healthy := func(ctx context.Context) (any, error) {
+		return map[string]string{"state": "connected"}, nil
+	}
+	unhealthy := func(ctx context.Context) (any, error) {
+		return map[string]string{"state": "unavailable"}, errors.New("database ping failed")
+	}
+
+	tests := []struct {
+		name           string
+		opts           []RouterOption
+		expectedStatus int
+		expectedChecks int
+	}{
+		{name: "チェックなし", opts: nil, expectedStatus: http.StatusOK, expectedChecks: 0},
+		{name: "正常", opts: []RouterOption{WithHealthCheck("database", healthy)}, expectedStatus: http.StatusOK, expectedChecks: 1},
+		{name: "いずれかが異常", opts: []RouterOption{WithHealthCheck("cache", healthy), WithHealthCheck("database", unhealthy)}, expectedStatus: http.StatusServiceUnavailable, expectedChecks: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(nil, tt.opts...)
+			rec := httptest.NewRecorder()
+			router.SetupRoutes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+
+			var body struct {
+				Status string                       `json:"status"`
+				Checks map[string]healthCheckResult `json:"checks"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("レスポンスのJSONパースに失敗: %v", err)
+			}
+			if len(body.Checks) != tt.expectedChecks {
+				t.Errorf("チェック数 = %d, 期待値 = %d", len(body.Checks), tt.expectedChecks)
+			}
+			if tt.expectedStatus == http.StatusServiceUnavailable && body.Checks["database"].Error == "" {
+				t.Errorf("異常なチェックのエラーが含まれていません: %+v", body.Checks)
+			}
+		})
+	}
+}
+
+// TestRouter_AdminLogLevel は /admin/loglevel が管理用トークンで保護されることをテストします
+func TestRouter_AdminLogLevel(t *testing.T) {
+	const token = "0123456789abcdef"
+	level := new(slog.LevelVar)
+	router := NewRouter(nil, WithLogLevelHandler(handler.NewLogLevelHandler(level), token))
+	routes := router.SetupRoutes()
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+		expectedLevel  slog.Level
+	}{
+		{name: "トークンなし", expectedStatus: http.StatusUnauthorized, expectedLevel: slog.LevelInfo},
+		{name: "正しいトークン", authorization: "Bearer " + token, expectedStatus: http.StatusOK, expectedLevel: slog.LevelDebug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/loglevel", strings.NewReader(`{"level":"debug"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if got := level.Level(); got != tt.expectedLevel {
+				t.Errorf("ログレベル = %v, 期待値 = %v", got, tt.expectedLevel)
+			}
+		})
+	}
+
+	// 有効にしていないルーターでは公開しない
+	rec := httptest.NewRecorder()
+	NewRouter(nil).SetupRoutes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/loglevel", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("無効時のステータスコード = %v, 期待値 = %v", rec.Code, http.StatusNotFound)
+	}
+}
+
+// stubConnPool は設定を受け取るだけの handler.ConnPool です
+type stubConnPool struct{ maxOpen int }
+
+func (p *stubConnPool) SetMaxOpenConns(n int)            { p.maxOpen = n }
+func (p *stubConnPool) SetMaxIdleConns(int)              {}
+func (p *stubConnPool) SetConnMaxLifetime(time.Duration) {}
+func (p *stubConnPool) Stats() sql.DBStats               { return sql.DBStats{MaxOpenConnections: p.maxOpen} }
+
+// TestRouter_AdminDBPool は /admin/dbpool が管理用トークンで保護されることをテストします
+func TestRouter_AdminDBPool(t *testing.T) {
+	const token = "0123456789abcdef"
+	pool := &stubConnPool{maxOpen: 10}
+	router := NewRouter(nil, WithDBPoolHandler(handler.NewDBPoolHandler(pool, handler.DBPoolSettings{MaxOpenConns: 10}), token))
+	routes := router.SetupRoutes()
+
+	tests := []struct {
+		name            string
+		authorization   string
+		expectedStatus  int
+		expectedMaxOpen int
+	}{
+		{name: "トークンなし", expectedStatus: http.StatusUnauthorized, expectedMaxOpen: 10},
+		{name: "正しいトークン", authorization: "Bearer " + token, expectedStatus: http.StatusOK, expectedMaxOpen: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/admin/dbpool", strings.NewReader(`{"max_open_conns":20}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if pool.maxOpen != tt.expectedMaxOpen {
+				t.Errorf("最大オープン接続数 = %v, 期待値 = %v", pool.maxOpen, tt.expectedMaxOpen)
+			}
+		})
+	}
+}
+
+// TestRouter_OpsBasicAuth は運用向けエンドポイント（/metrics と /debug/pprof/）の Basic 認証をテストします
+func TestRouter_OpsBasicAuth(t *testing.T) {
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router := NewRouter(nil,
+		WithMetricsHandler(metrics),
+		WithOpsAuth(middleware.BasicAuthMiddleware("ops", "s3cret-passw0rd", "ops")),
+	)
+	routes := router.SetupRoutes()
+
+	tests := []struct {
+		name           string
+		path           string
+		withAuth       bool
+		expectedStatus int
+	}{
+		{name: "メトリクス（資格情報なし）", path: "/metrics", expectedStatus: http.StatusUnauthorized},
+		{name: "メトリクス（資格情報あり）", path: "/metrics", withAuth: true, expectedStatus: http.StatusOK},
+		{name: "pprof（資格情報なし）", path: "/debug/pprof/", expectedStatus: http.StatusUnauthorized},
+		{name: "pprof（資格情報あり）", path: "/debug/pprof/", withAuth: true, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.withAuth {
+				req.SetBasicAuth("ops", "s3cret-passw0rd")
+			}
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+
+	// 保護を設定していないルーターでは pprof を公開せず、メトリクスは認証なしで返す
+	plain := NewRouter(nil, WithMetricsHandler(metrics)).SetupRoutes()
+	rec := httptest.NewRecorder()
+	plain.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("無効時の pprof のステータスコード = %v, 期待値 = %v", rec.Code, http.StatusNotFound)
+	}
+	rec = httptest.NewRecorder()
+	plain.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("無効時のメトリクスのステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+}
+
+// TestRouter_WebSocket は /ws の接続がミドルウェアチェーン（ベースパスを含む）を通って切り替わることをテストします
+func TestRouter_WebSocket(t *testing.T) {
+	upgraded := make(chan struct{}, 1)
+	ws := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, websocket.UpgradeOptions{})
+		if err != nil {
+			return
+		}
+		upgraded <- struct{}{}
+		conn.WriteClose(websocket.CloseNormalClosure, "")
+		conn.Close()
+	})
+	router := NewRouter(nil, WithWebSocketHandler(ws), WithBasePath("/todoapp"))
+	server := httptest.NewServer(router.SetupRoutes())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http")+"/todoapp/ws", nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	select {
+	case <-upgraded:
+	case <-ctx.Done():
+		t.Fatal("WebSocket への切り替えが行われませんでした")
+	}
+}
+
+// TestRouter_RouteErrors はルーターの 404・405 がJSONで返り、OPTIONS に Allow ヘッダーで応答することをテストします
+func TestRouter_RouteErrors(t *testing.T) {
+	routes := NewRouter(nil).SetupRoutes()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		header         map[string]string
+		expectedStatus int
+		expectedAllow  string
+		expectedError  string // 空の場合はボディなしを期待
+	}{
+		{name: "未定義のAPIのパス", method: http.MethodGet, path: "/api/v1/unknown", expectedStatus: http.StatusNotFound, expectedError: "Not found"},
+		{name: "APIの外の未定義のパス", method: http.MethodGet, path: "/unknown", expectedStatus: http.StatusNotFound, expectedError: "Not found"},
+		{
+			name: "未対応のメソッド", method: http.MethodDelete, path: "/api/v1/todos",
+			expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "GET, HEAD, POST, PATCH, OPTIONS", expectedError: "Method not allowed",
+		},
+		{name: "コレクションの OPTIONS", method: http.MethodOptions, path: "/api/v1/todos", expectedStatus: http.StatusNoContent, expectedAllow: "GET, HEAD, POST, PATCH, OPTIONS"},
+		{name: "個別のTodoの OPTIONS", method: http.MethodOptions, path: "/api/v1/todos/1", expectedStatus: http.StatusNoContent, expectedAllow: "GET, HEAD, PUT, DELETE, OPTIONS"},
+		{name: "アクションの OPTIONS", method: http.MethodOptions, path: "/api/v1/todos/1/complete", expectedStatus: http.StatusNoContent, expectedAllow: "PATCH, OPTIONS"},
+		{name: "未定義のアクションの OPTIONS", method: http.MethodOptions, path: "/api/v1/todos/1/unknown", expectedStatus: http.StatusNotFound, expectedError: "Not found"},
+		{name: "ヘルスチェックの OPTIONS", method: http.MethodOptions, path: "/health", expectedStatus: http.StatusNoContent, expectedAllow: "GET, HEAD, OPTIONS"},
+		{
+			name: "CORSのプリフライトはCORSミドルウェアが応答する", method: http.MethodOptions, path: "/api/v1/todos",
+			header: map[string]string{"Origin": "http://example.com", "Access-Control-Request-Method": "POST"}, expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if got := rec.Header().Get("Allow"); got != tt.expectedAllow {
+				t.Errorf("Allow = %q, 期待値 = %q", got, tt.expectedAllow)
+			}
+			if tt.expectedError == "" {
+				if rec.Body.Len() != 0 {
+					t.Errorf("ボディ = %q, 期待値 = 空", rec.Body.String())
+				}
+				return
+			}
+
+			var body dto.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("エラーレスポンスがJSONではありません: %v (%s)", err, rec.Body.String())
+			}
+			if body.Error != tt.expectedError {
+				t.Errorf("error = %q, 期待値 = %q", body.Error, tt.expectedError)
+			}
+		})
+	}
+}
+
+// stubAccessTokenVerifier は "valid" だけをアクセストークンとして受け入れるテスト用の実装です
+type stubAccessTokenVerifier struct{}
+
+func (stubAccessTokenVerifier) VerifyAccessToken(token string) (service.Principal, error) {
+	if token != "valid" {
+		return service.Principal{}, service.ErrInvalidAccessToken
+	}
+	return service.Principal{UserID: 1, Username: "alice"}, nil
+}
+
+// TestRouter_Auth は認証が有効な場合に、トークンの発行と API仕様書以外へのアクセスにトークンが必要になることをテストします
+func TestRouter_Auth(t *testing.T) {
+	routes := NewRouter(nil,
+		WithAuth(handler.NewAuthHandler(nil), middleware.AuthMiddleware(stubAccessTokenVerifier{})),
+	).SetupRoutes()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "トークンなしのAPI", method: http.MethodGet, path: "/api/v1/webhooks", expectedStatus: http.StatusUnauthorized},
+		{name: "不正なトークン", method: http.MethodGet, path: "/api/v1/webhooks", authorization: "Bearer invalid", expectedStatus: http.StatusUnauthorized},
+		{name: "有効なトークン", method: http.MethodGet, path: "/api/v1/webhooks", authorization: "Bearer valid", expectedStatus: http.StatusNotFound},
+		{name: "トークンの発行はトークンなしで呼び出せる", method: http.MethodGet, path: "/api/v1/auth/login", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "未定義の認証のパス", method: http.MethodPost, path: "/api/v1/auth/unknown", expectedStatus: http.StatusNotFound},
+		{name: "API仕様書はトークンなしで呼び出せる", method: http.MethodGet, path: "/api/v1/openapi.json", expectedStatus: http.StatusNotFound},
+		{name: "名前が前方一致するだけのリソース", method: http.MethodGet, path: "/api/v1/authx", expectedStatus: http.StatusUnauthorized},
+		{name: "GraphQL もトークンが必要", method: http.MethodPost, path: "/graphql", expectedStatus: http.StatusUnauthorized},
+		{name: "Atomフィードもトークンが必要", method: http.MethodGet, path: "/feeds/todos.atom", expectedStatus: http.StatusUnauthorized},
+		{name: "WebSocket の接続もトークンが必要", method: http.MethodGet, path: "/ws", expectedStatus: http.StatusUnauthorized},
+		{name: "ヘルスチェックは対象外", method: http.MethodGet, path: "/health", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+}
@@ -25,14 +25,70 @@ type Server struct {
 	httpServer *http.Server
 	config     *config.Config
 	router     *Router
+
+	// shutdownHooks はHTTPサーバー停止後に実行する処理です（バックグラウンドワーカーの停止など）
+	shutdownHooks []func(ctx context.Context)
+
+	// virtualHosts はホスト名ごとに登録されたハンドラーです（Host で登録）
+	virtualHosts []virtualHost
+
+	// signaled はシグナルを受信してグレースフルシャットダウンを開始すると閉じられます
+	signaled chan struct{}
+}
+
+// virtualHost はServer.Host で登録されたホスト名ごとのハンドラーです
+type virtualHost struct {
+	pattern     string
+	handler     http.Handler
+	middlewares []func(http.Handler) http.Handler
 }
 
 // NewServer はServerのコンストラクタです
 func NewServer(cfg *config.Config, router *Router) *Server {
 	return &Server{
-		config: cfg,
-		router: router,
+		config:   cfg,
+		router:   router,
+		signaled: make(chan struct{}),
+	}
+}
+
+// OnShutdown はグレースフルシャットダウン時に実行する処理を登録します
+// 登録した順に、HTTPサーバーの停止後・プロセス終了前に同じタイムアウト付き context で呼び出されます
+// シャットダウンは os.Exit で終了するため、main の defer の代わりにこちらで後処理を行います
+func (s *Server) OnShutdown(hook func(ctx context.Context)) {
+	s.shutdownHooks = append(s.shutdownHooks, hook)
+}
+
+// Host はホスト名（"admin.example.com" や "*.tenant.example.com"）に専用のハンドラーを登録します
+// middlewares はこのホストにだけ適用されるミドルウェアチェーンです
+// 登録したハンドラーには Router のミドルウェア（アクセスログ等）は適用されないため、必要に応じて middlewares に含めます
+// どのホストにも一致しないリクエストは Router が処理します（SERVER_HOSTS 設定時は 421 を返します）
+// Start の前に呼び出してください
+func (s *Server) Host(pattern string, h http.Handler, middlewares ...func(http.Handler) http.Handler) {
+	s.virtualHosts = append(s.virtualHosts, virtualHost{pattern: pattern, handler: h, middlewares: middlewares})
+}
+
+// handler はサーバー全体のハンドラーを組み立てます
+// ホスト名の登録も SERVER_HOSTS の設定もない場合は、Router のハンドラーをそのまま使用します
+func (s *Server) handler() http.Handler {
+	routes := s.router.SetupRoutes()
+	if len(s.virtualHosts) == 0 && len(s.config.Server.Hosts) == 0 {
+		return routes
+	}
+
+	// SERVER_HOSTS が設定されている場合は、列挙したホスト名以外へのリクエストを拒否する
+	var fallback http.Handler
+	if len(s.config.Server.Hosts) == 0 {
+		fallback = routes
+	}
+	vhosts := NewVirtualHosts(fallback)
+	for _, host := range s.config.Server.Hosts {
+		vhosts.Handle(host, routes)
 	}
+	for _, vhost := range s.virtualHosts {
+		vhosts.Handle(vhost.pattern, vhost.handler, vhost.middlewares...)
+	}
+	return vhosts
 }
 
 // Start はHTTPサーバーを起動します
@@ -41,7 +97,7 @@ func (s *Server) Start() error {
 	// 1. HTTP サーバーの詳細設定
 	s.httpServer = &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.Port),
-		Handler: s.router.SetupRoutes(), // ルーティング設定を取得
+		Handler: s.handler(), // ルーティング設定（ホスト名ごとの振り分けを含む）を取得
 
 		// タイムアウト設定（セキュリティとパフォーマンス対策）
 		ReadTimeout:  time.Duration(s.config.Server.ReadTimeout) * time.Second,
@@ -85,6 +141,14 @@ func (s *Server) Start() error {
 	}
 
 	log.Println("Server stopped")
+
+	// シグナルによる停止の場合、ListenAndServe は実行中のリクエストや後処理（OnShutdown）を待たずに戻る
+	// 呼び出し元（main）が先に終了して後処理が途中で打ち切られないよう、gracefulShutdown がプロセスを終了するまで待つ
+	select {
+	case <-s.signaled:
+		select {}
+	default:
+	}
 	return nil
 }
 
@@ -119,6 +183,7 @@ func (s *Server) gracefulShutdown() {
 	// 3. シグナル受信を待機（ブロッキング）
 	sig := <-sigChan
 	log.Printf("Received signal: %v", sig)
+	close(s.signaled)
 
 	// 4. シャットダウンのタイムアウト設定
 	// 30秒以内に既存のリクエスト処理を完了させる
@@ -131,6 +196,11 @@ func (s *Server) gracefulShutdown() {
 		os.Exit(1)
 	}
 
+	// 6. 登録された後処理の実行（実行中のリクエストが終わった後に行う）
+	for _, hook := range s.shutdownHooks {
+		hook(shutdownCtx)
+	}
+
 	log.Println("Server shutdown completed")
 	os.Exit(0)
 }
@@ -188,7 +258,7 @@ func (s *Server) GetHandler() http.Handler {
 	if s.httpServer != nil {
 		return s.httpServer.Handler
 	}
-	return s.router.SetupRoutes()
+	return s.handler()
 }
 
 // IsRunning はサーバーが動作中かどうかを返します
@@ -0,0 +1,385 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// staticFiles は組み込みUIの静的ファイルです
+// go:embed によりバイナリに同梱されるため、実行時にファイルを配置する必要はありません
+//
+//go:embed static
+var staticFiles embed.FS
+
+// staticPathPrefix は静的ファイルを配信するURLのプレフィックスです
+const staticPathPrefix = "/static/"
+
+// staticHashLength はキャッシュバスター用ハッシュの桁数です
+const staticHashLength = 12
+
+// コンテンツエンコーディング名（Accept-Encoding / Content-Encoding の値）です
+// 優先順位の高い順に並べています
+const (
+	encodingBrotli   = "br"
+	encodingGzip     = "gzip"
+	encodingIdentity = "identity"
+)
+
+// precompressedExtensions はエンコーディングごとの事前圧縮ファイルの拡張子です
+var precompressedExtensions = map[string]string{
+	encodingBrotli: ".br",
+	encodingGzip:   ".gz",
+}
+
+// staticAsset は配信する1ファイル分の情報です
+// 全てのエンコーディングの内容を起動時にメモリへ読み込んでおき、リクエスト時は選択して書き込むだけにします
+type staticAsset struct {
+	// name は論理名（static ディレクトリからの相対パス、例: app.css）
+	name string
+
+	// hashedName はキャッシュバスター付きの名前（例: app.3f2a1b9c0d4e.css）
+	hashedName string
+
+	// hash は元の内容のSHA-256ハッシュ（先頭 staticHashLength 桁）
+	hash string
+
+	// contentType は拡張子から決定したContent-Type
+	contentType string
+
+	// variants はエンコーディングごとの内容（identity は必ず存在）
+	variants map[string][]byte
+}
+
+// StaticHandler は組み込みUIの静的ファイルを配信するハンドラーです
+//
+// 学習ポイント：
+// 1. Accept-Encoding によるコンテンツネゴシエーション（br > gzip > 無圧縮）
+// 2. 事前圧縮ファイル（.br/.gz）の利用で、リクエストごとの圧縮コストをなくす
+// 3. 内容のハッシュをファイル名に含めるキャッシュバスター（長期キャッシュと即時反映の両立）
+// 4. ETag / If-None-Match による条件付きリクエスト（304 Not Modified）
+type StaticHandler struct {
+	assets map[string]*staticAsset // 論理名 -> アセット
+	hashed map[string]*staticAsset // キャッシュバスター付きの名前 -> アセット
+	index  *staticAsset
+
+	// basePath はHTML内のリンクに付与するベースパス（例: /todoapp、未設定なら空文字）
+	basePath string
+}
+
+// NewStaticHandler は組み込みの静的ファイルからStaticHandlerを作成します
+// basePath はリバースプロキシ配下で公開する場合のURLのプレフィックスです（ルート直下なら空文字）
+func NewStaticHandler(basePath string) (*StaticHandler, error) {
+	fsys, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded static files: %w", err)
+	}
+	return newStaticHandler(fsys, basePath)
+}
+
+// newStaticHandler は任意のファイルシステムからStaticHandlerを作成します（テストで差し替え可能）
+//
+// 処理の流れ：
+// 1. .html 以外のファイルを読み込み、ハッシュと事前圧縮版を準備
+// 2. .html ファイルをテンプレートとして描画
+//   - {{asset "app.css"}} をキャッシュバスター付きのパスに置換
+//   - {{path "/api/v1/todos"}} をベースパス付きのパスに置換
+func newStaticHandler(fsys fs.FS, basePath string) (*StaticHandler, error) {
+	h := &StaticHandler{
+		assets:   make(map[string]*staticAsset),
+		hashed:   make(map[string]*staticAsset),
+		basePath: basePath,
+	}
+
+	var templates []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		switch path.Ext(name) {
+		case ".br", ".gz":
+			// 事前圧縮ファイルは元ファイルのバリアントとして読み込む
+			return nil
+		case ".html":
+			// HTMLは他のアセットのハッシュが確定してから描画する
+			templates = append(templates, name)
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("failed to read static file %s: %w", name, err)
+		}
+		asset := newStaticAsset(name, content)
+		asset.loadPrecompressed(fsys)
+		return h.add(asset)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range templates {
+		content, err := h.renderTemplate(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		// 描画後の内容は事前圧縮ファイルと一致しないため、起動時に圧縮する
+		if err := h.add(newStaticAsset(name, content)); err != nil {
+			return nil, err
+		}
+	}
+
+	h.index = h.assets["index.html"]
+	return h, nil
+}
+
+// newStaticAsset は内容からハッシュとContent-Typeを決定してアセットを作成します
+func newStaticAsset(name string, content []byte) *staticAsset {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])[:staticHashLength]
+
+	ext := path.Ext(name)
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+
+	return &staticAsset{
+		name:        name,
+		hashedName:  strings.TrimSuffix(name, ext) + "." + hash + ext,
+		hash:        hash,
+		contentType: contentType,
+		variants:    map[string][]byte{encodingIdentity: content},
+	}
+}
+
+// loadPrecompressed は name.br / name.gz が存在すればバリアントとして読み込みます
+// .gz は展開して元の内容と一致するか検証し、古い圧縮ファイルを配信しないようにします
+// （.br は標準パッケージに展開機能がないため、ビルド時に元ファイルと同時に生成する前提です）
+func (a *staticAsset) loadPrecompressed(fsys fs.FS) {
+	for encoding, ext := range precompressedExtensions {
+		content, err := fs.ReadFile(fsys, a.name+ext)
+		if err != nil {
+			continue
+		}
+		if encoding == encodingGzip && !gzipMatches(content, a.variants[encodingIdentity]) {
+			log.Printf("Static asset %s%s is stale; compressing at startup instead", a.name, ext)
+			continue
+		}
+		a.variants[encoding] = content
+	}
+}
+
+// add はアセットを論理名とキャッシュバスター付きの名前の両方で登録します
+// gzip 版がないテキスト系のアセットは、ここで一度だけ圧縮しておきます
+func (h *StaticHandler) add(asset *staticAsset) error {
+	if _, ok := asset.variants[encodingGzip]; !ok && isCompressible(asset.contentType) {
+		compressed, err := gzipBytes(asset.variants[encodingIdentity])
+		if err != nil {
+			return fmt.Errorf("failed to compress static file %s: %w", asset.name, err)
+		}
+		asset.variants[encodingGzip] = compressed
+	}
+	h.assets[asset.name] = asset
+	h.hashed[asset.hashedName] = asset
+	return nil
+}
+
+// AssetPath は論理名に対応するキャッシュバスター付きのURLパスを返します（ベースパスを含む）
+func (h *StaticHandler) AssetPath(name string) (string, error) {
+	asset, ok := h.assets[name]
+	if !ok {
+		return "", fmt.Errorf("unknown static asset: %s", name)
+	}
+	return h.basePath + staticPathPrefix + asset.hashedName, nil
+}
+
+// Path はアプリ内の絶対パスにベースパスを付与します（テンプレートの path 関数）
+func (h *StaticHandler) Path(p string) string {
+	return h.basePath + p
+}
+
+// renderTemplate はHTMLファイルをテンプレートとして描画します
+func (h *StaticHandler) renderTemplate(fsys fs.FS, name string) ([]byte, error) {
+	source, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read static file %s: %w", name, err)
+	}
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{"asset": h.AssetPath, "path": h.Path}).Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ServeHTTP は / （UIのトップページ）と /static/* を配信します
+func (h *StaticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		methodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+
+	var asset *staticAsset
+	immutable := false
+
+	switch {
+	case r.URL.Path == "/":
+		asset = h.index
+	case strings.HasPrefix(r.URL.Path, staticPathPrefix):
+		name := strings.TrimPrefix(r.URL.Path, staticPathPrefix)
+		if a, ok := h.hashed[name]; ok {
+			// ハッシュ付きのURLは内容が変わればURLも変わるため、長期間キャッシュさせる
+			asset, immutable = a, true
+		} else {
+			asset = h.assets[name]
+		}
+	}
+	if asset == nil {
+		notFound(w, r)
+		return
+	}
+
+	encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), asset.variants)
+	body := asset.variants[encoding]
+
+	header := w.Header()
+	header.Set("Content-Type", asset.contentType)
+	header.Add("Vary", "Accept-Encoding")
+	// エンコーディングごとに内容が異なるため、ETagもエンコーディングごとに分ける
+	etag := `"` + asset.hash + `"`
+	if encoding != encodingIdentity {
+		etag = `"` + asset.hash + "-" + encoding + `"`
+		header.Set("Content-Encoding", encoding)
+	}
+	header.Set("ETag", etag)
+	if immutable {
+		header.Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		// ハッシュなしのURLは毎回ETagで再検証させる
+		header.Set("Cache-Control", "no-cache")
+	}
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(body)
+}
+
+// negotiateEncoding は Accept-Encoding と利用可能なバリアントから配信するエンコーディングを決定します
+// 品質値（q）が最も高いものを選び、同じ場合は br > gzip > identity の順に優先します
+func negotiateEncoding(acceptEncoding string, variants map[string][]byte) string {
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		if name == "*" {
+			wildcard = q
+		} else {
+			qualities[name] = q
+		}
+	}
+
+	best, bestQ := encodingIdentity, 0.0
+	for _, encoding := range []string{encodingBrotli, encodingGzip} {
+		if _, ok := variants[encoding]; !ok {
+			continue
+		}
+		q, ok := qualities[encoding]
+		if !ok && wildcard >= 0 {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// etagMatches は If-None-Match の値に etag が含まれるかを判定します
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompressible は圧縮の効果があるContent-Typeかどうかを判定します
+// 画像やフォント等の既に圧縮済みの形式は対象外です
+func isCompressible(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "javascript") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "svg")
+}
+
+// gzipBytes は内容をgzipで圧縮します
+func gzipBytes(content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(content); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gzipMatches はgzip圧縮された内容を展開し、元の内容と一致するかを判定します
+func gzipMatches(compressed, original []byte) bool {
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return false
+	}
+	defer zr.Close()
+
+	content, err := io.ReadAll(zr)
+	return err == nil && bytes.Equal(content, original)
+}
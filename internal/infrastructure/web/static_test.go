@@ -0,0 +1,228 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// newTestStaticHandler は事前圧縮ファイルを含むテスト用のStaticHandlerを作成します
+func newTestStaticHandler(t *testing.T) *StaticHandler {
+	t.Helper()
+
+	css := []byte("body { color: #222; }")
+	gz, err := gzipBytes(css)
+	if err != nil {
+		t.Fatalf("テストデータの圧縮に失敗: %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		"index.html":  {Data: []byte(`<link rel="stylesheet" href="{{asset "app.css"}}">`)},
+		"app.css":     {Data: css},
+		"app.css.gz":  {Data: gz},
+		"app.css.br":  {Data: []byte("brotli-bytes")},
+		"logo.png":    {Data: []byte("\x89PNG\r\n\x1a\n")},
+		"stale.js":    {Data: []byte("console.log('new')")},
+		"stale.js.gz": {Data: []byte("not gzip")},
+	}
+
+	h, err := newStaticHandler(fsys, "")
+	if err != nil {
+		t.Fatalf("StaticHandlerの作成に失敗: %v", err)
+	}
+	return h
+}
+
+// TestStaticHandler_Encoding は Accept-Encoding に応じたバリアントの選択をテストします
+func TestStaticHandler_Encoding(t *testing.T) {
+	h := newTestStaticHandler(t)
+	hashedCSS, err := h.AssetPath("app.css")
+	if err != nil {
+		t.Fatalf("AssetPathの取得に失敗: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		path             string
+		acceptEncoding   string
+		expectedEncoding string
+	}{
+		{name: "brotliを優先", path: hashedCSS, acceptEncoding: "gzip, deflate, br", expectedEncoding: "br"},
+		{name: "gzipのみ対応", path: hashedCSS, acceptEncoding: "gzip", expectedEncoding: "gzip"},
+		{name: "品質値でgzipを優先", path: hashedCSS, acceptEncoding: "br;q=0.5, gzip", expectedEncoding: "gzip"},
+		{name: "brを拒否", path: hashedCSS, acceptEncoding: "br;q=0, *", expectedEncoding: "gzip"},
+		{name: "Accept-Encodingなし", path: hashedCSS, acceptEncoding: "", expectedEncoding: ""},
+		{name: "圧縮対象外の形式", path: "/static/logo.png", acceptEncoding: "gzip, br", expectedEncoding: ""},
+		{name: "古い.gzは使わず起動時に圧縮", path: "/static/stale.js", acceptEncoding: "gzip", expectedEncoding: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.expectedEncoding {
+				t.Errorf("Content-Encoding = %q, 期待値 = %q", got, tt.expectedEncoding)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, 期待値 = %q", got, "Accept-Encoding")
+			}
+		})
+	}
+
+	// 古い事前圧縮ファイルの内容は配信されないこと
+	if string(h.assets["stale.js"].variants[encodingGzip]) == "not gzip" {
+		t.Error("内容が一致しない .gz ファイルが採用されています")
+	}
+}
+
+// TestStaticHandler_CacheBusting はキャッシュバスターとキャッシュ制御ヘッダーをテストします
+func TestStaticHandler_CacheBusting(t *testing.T) {
+	h := newTestStaticHandler(t)
+	hashedCSS, _ := h.AssetPath("app.css")
+
+	if !strings.HasPrefix(hashedCSS, "/static/app.") || !strings.HasSuffix(hashedCSS, ".css") || hashedCSS == "/static/app.css" {
+		t.Fatalf("キャッシュバスター付きのパスが正しくありません: %s", hashedCSS)
+	}
+
+	// トップページのHTMLにはハッシュ付きのパスが埋め込まれる
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !strings.Contains(rec.Body.String(), hashedCSS) {
+		t.Errorf("トップページにハッシュ付きのパスが含まれていません: %s", rec.Body.String())
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("トップページのCache-Control = %q, 期待値 = no-cache", got)
+	}
+
+	// ハッシュ付きのパスは長期キャッシュ、ハッシュなしは再検証
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, hashedCSS, nil))
+	if got := rec.Header().Get("Cache-Control"); !strings.Contains(got, "immutable") {
+		t.Errorf("ハッシュ付きパスのCache-Control = %q, immutable を期待", got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/static/app.css", nil))
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("ハッシュなしパスのCache-Control = %q, 期待値 = no-cache", got)
+	}
+	etag := rec.Header().Get("ETag")
+
+	// ETagが一致すれば304
+	req := httptest.NewRequest(http.MethodGet, "/static/app.css", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusNotModified)
+	}
+
+	// 存在しないファイルは404
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/static/missing.css", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusNotFound)
+	}
+}
+
+// TestNewStaticHandler_Embedded は組み込みの静的ファイルが読み込めることをテストします
+func TestNewStaticHandler_Embedded(t *testing.T) {
+	h, err := NewStaticHandler("")
+	if err != nil {
+		t.Fatalf("組み込みの静的ファイルの読み込みに失敗: %v", err)
+	}
+	if h.index == nil {
+		t.Fatal("index.html が読み込まれていません")
+	}
+	if _, ok := h.assets["app.css"].variants[encodingGzip]; !ok {
+		t.Error("app.css の gzip 版がありません")
+	}
+}
+
+// TestRouter_StaticRoutes はルーターに静的ファイルのルートが登録されることをテストします
+func TestRouter_StaticRoutes(t *testing.T) {
+	router := NewRouter(nil, WithStaticHandler(newTestStaticHandler(t)))
+	handler := router.SetupRoutes()
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{path: "/", expectedStatus: http.StatusOK},
+		{path: "/static/app.css", expectedStatus: http.StatusOK},
+		{path: "/unknown", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+// TestRouter_BasePath はベースパス配下でUIと静的ファイルが配信され、リンクにベースパスが付与されることをテストします
+func TestRouter_BasePath(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html": {Data: []byte(`<link href="{{asset "app.css"}}"><form hx-post="{{path "/api/v1/todos"}}"></form>`)},
+		"app.css":    {Data: []byte("body { color: #222; }")},
+	}
+	static, err := newStaticHandler(fsys, "/todoapp")
+	if err != nil {
+		t.Fatalf("StaticHandlerの作成に失敗: %v", err)
+	}
+	handler := NewRouter(nil, WithStaticHandler(static), WithBasePath("/todoapp")).SetupRoutes()
+
+	hashedCSS, _ := static.AssetPath("app.css")
+	if !strings.HasPrefix(hashedCSS, "/todoapp/static/app.") {
+		t.Fatalf("アセットのパスにベースパスが含まれていません: %s", hashedCSS)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/todoapp/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("トップページ: ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `href="`+hashedCSS+`"`) || !strings.Contains(body, `hx-post="/todoapp/api/v1/todos"`) {
+		t.Errorf("リンクにベースパスが付与されていません: %s", body)
+	}
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{path: hashedCSS, expectedStatus: http.StatusOK},
+		{path: "/todoapp/health", expectedStatus: http.StatusOK},
+		{path: "/todoapp", expectedStatus: http.StatusMovedPermanently},
+		{path: "/", expectedStatus: http.StatusNotFound},
+		{path: "/health", expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			// ベースパス外のリクエストも、未定義のパスと同じJSONのエラーを返す
+			if rec.Code == http.StatusNotFound && rec.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Content-Type = %q, 期待値 = application/json", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
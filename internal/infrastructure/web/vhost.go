@@ -0,0 +1,104 @@
+package web
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"todoapp-api-golang/internal/application/middleware"
+)
+
+// VirtualHosts はリクエストの Host ヘッダーに応じてハンドラーを振り分ける http.Handler です
+// 1つのServerで、テナントごとのホスト名や管理画面用のホスト名を別々のハンドラー
+// （ホストごとのミドルウェアチェーン付き）で処理するために使用します
+//
+// ホスト名のパターンは次の2種類です（大文字小文字とポート番号は区別しません）：
+//   - "todo.example.com"   完全一致
+//   - "*.example.com"      サブドメインのワイルドカード（example.com 自体には一致しない）
+//
+// 完全一致が優先され、ワイルドカード同士ではより長い（具体的な）パターンが優先されます
+type VirtualHosts struct {
+	exact     map[string]http.Handler
+	wildcards []wildcardHost
+
+	// fallback はどのパターンにも一致しない場合のハンドラーです（nil の場合は 421 を返します）
+	fallback http.Handler
+}
+
+// wildcardHost は "*.example.com" 形式のパターンです（suffix は ".example.com"）
+type wildcardHost struct {
+	suffix  string
+	handler http.Handler
+}
+
+// NewVirtualHosts はVirtualHostsのコンストラクタです
+// fallback に nil を指定すると、登録されていないホスト名へのリクエストを拒否します
+func NewVirtualHosts(fallback http.Handler) *VirtualHosts {
+	return &VirtualHosts{
+		exact:    make(map[string]http.Handler),
+		fallback: fallback,
+	}
+}
+
+// Handle はホスト名のパターンにハンドラーを登録します
+// middlewares はこのホストへのリクエストにだけ適用され、先頭が最も外側になります（ChainMiddleware と同じ順序）
+// 起動時の設定を想定しているため、同じパターンを重複して登録した場合は後の登録で上書きします
+func (v *VirtualHosts) Handle(pattern string, h http.Handler, middlewares ...func(http.Handler) http.Handler) {
+	if len(middlewares) > 0 {
+		h = middleware.ChainMiddleware(middlewares...)(h)
+	}
+
+	pattern = normalizeHost(pattern)
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok && strings.HasPrefix(suffix, ".") {
+		for i, wildcard := range v.wildcards {
+			if wildcard.suffix == suffix {
+				v.wildcards[i].handler = h
+				return
+			}
+		}
+		v.wildcards = append(v.wildcards, wildcardHost{suffix: suffix, handler: h})
+		return
+	}
+	v.exact[pattern] = h
+}
+
+// ServeHTTP はHost ヘッダーに一致するハンドラーにリクエストを渡します
+func (v *VirtualHosts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h := v.match(normalizeHost(r.Host)); h != nil {
+		h.ServeHTTP(w, r)
+		return
+	}
+
+	// このサーバーでは扱わないホスト名（421 Misdirected Request）
+	http.Error(w, "Misdirected request", http.StatusMisdirectedRequest)
+}
+
+// match はホスト名に一致するハンドラーを返します（一致しない場合は fallback）
+func (v *VirtualHosts) match(host string) http.Handler {
+	if h, ok := v.exact[host]; ok {
+		return h
+	}
+
+	var matched *wildcardHost
+	for i, wildcard := range v.wildcards {
+		if strings.HasSuffix(host, wildcard.suffix) && len(host) > len(wildcard.suffix) {
+			if matched == nil || len(wildcard.suffix) > len(matched.suffix) {
+				matched = &v.wildcards[i]
+			}
+		}
+	}
+	if matched != nil {
+		return matched.handler
+	}
+	return v.fallback
+}
+
+// normalizeHost はホスト名を比較用に正規化します
+// ポート番号と末尾のドットを取り除き、小文字に揃えます（"Todo.Example.com.:8080" -> "todo.example.com"）
+func normalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"todoapp-api-golang/pkg/config"
+)
+
+// namedHandler はレスポンスボディに名前を書き込むテスト用のハンドラーです
+func namedHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(name))
+	})
+}
+
+// TestVirtualHosts はHost ヘッダーに応じたハンドラーの振り分けをテストします
+func TestVirtualHosts(t *testing.T) {
+	vhosts := NewVirtualHosts(namedHandler("default"))
+	vhosts.Handle("admin.example.com", namedHandler("admin"))
+	vhosts.Handle("*.example.com", namedHandler("tenant"))
+	vhosts.Handle("*.eu.example.com", namedHandler("eu-tenant"))
+
+	tests := []struct {
+		name     string
+		host     string
+		expected string
+	}{
+		{name: "完全一致", host: "admin.example.com", expected: "admin"},
+		{name: "ポート番号と大文字を無視", host: "Admin.Example.com:8080", expected: "admin"},
+		{name: "末尾のドットを無視", host: "admin.example.com.", expected: "admin"},
+		{name: "ワイルドカード", host: "acme.example.com", expected: "tenant"},
+		{name: "より具体的なワイルドカードを優先", host: "acme.eu.example.com", expected: "eu-tenant"},
+		{name: "ワイルドカードは親ドメインに一致しない", host: "example.com", expected: "default"},
+		{name: "未登録のホスト", host: "localhost:8080", expected: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+			vhosts.ServeHTTP(rec, req)
+
+			if rec.Body.String() != tt.expected {
+				t.Errorf("振り分け先 = %q, 期待値 = %q", rec.Body.String(), tt.expected)
+			}
+		})
+	}
+}
+
+// TestVirtualHosts_Middlewares はホストごとのミドルウェアチェーンが他のホストに影響しないことをテストします
+func TestVirtualHosts_Middlewares(t *testing.T) {
+	var order []string
+	mark := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	vhosts := NewVirtualHosts(nil)
+	vhosts.Handle("admin.example.com", namedHandler("admin"), mark("first"), mark("second"))
+	vhosts.Handle("todo.example.com", namedHandler("todo"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "admin.example.com"
+	vhosts.ServeHTTP(httptest.NewRecorder(), req)
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("ミドルウェアの実行順序 = %v, 期待値 = [first second]", order)
+	}
+
+	order = nil
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "todo.example.com"
+	vhosts.ServeHTTP(httptest.NewRecorder(), req)
+	if len(order) != 0 {
+		t.Errorf("他のホストのミドルウェアが実行されました: %v", order)
+	}
+
+	// fallback がない場合、未登録のホストは 421
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "unknown.example.org"
+	rec := httptest.NewRecorder()
+	vhosts.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMisdirectedRequest {
+		t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, http.StatusMisdirectedRequest)
+	}
+}
+
+// TestServer_Hosts はSERVER_HOSTS の設定とServer.Host での登録がハンドラーに反映されることをテストします
+func TestServer_Hosts(t *testing.T) {
+	tests := []struct {
+		name           string
+		hosts          []string
+		host           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "管理用ホスト", host: "admin.example.com", expectedStatus: http.StatusOK, expectedBody: "admin"},
+		{name: "ホスト名の制限なし", host: "anything.example.org", expectedStatus: http.StatusOK},
+		{name: "許可されたホスト", hosts: []string{"todo.example.com"}, host: "todo.example.com", expectedStatus: http.StatusOK},
+		{name: "許可されていないホスト", hosts: []string{"todo.example.com"}, host: "other.example.org", expectedStatus: http.StatusMisdirectedRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Server: config.ServerConfig{Hosts: tt.hosts}}
+			server := NewServer(cfg, NewRouter(nil))
+			server.Host("admin.example.com", namedHandler("admin"))
+
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+			server.GetHandler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedBody != "" && rec.Body.String() != tt.expectedBody {
+				t.Errorf("レスポンス = %q, 期待値 = %q", rec.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+// Package websocket は標準パッケージだけで実装した WebSocket（RFC 6455）の接続です
+//
+// 学習ポイント：
+//  1. WebSocket は HTTP の Upgrade で始まり、101 Switching Protocols の後は同じTCP接続でフレームをやり取りする
+//  2. net/http の Hijack で接続を取り出すと、以降の読み書き・期限・切断はアプリケーションの責任になる
+//  3. クライアントから送るフレームは必ずマスクし、サーバーから送るフレームはマスクしない
+//  4. Ping / Pong / Close は制御フレームで、メッセージの途中（分割されたフレームの間）にも届く
+//
+// 対応していないもの：拡張（permessage-deflate 等）とサブプロトコル
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// メッセージ（フレーム）の種類です（RFC 6455 のオペコード）
+const (
+	continuationFrame = 0
+	TextMessage       = 1
+	BinaryMessage     = 2
+	CloseMessage      = 8
+	PingMessage       = 9
+	PongMessage       = 10
+)
+
+// 切断の理由を表すステータスコードです（RFC 6455 7.4.1）
+const (
+	CloseNormalClosure    = 1000
+	CloseGoingAway        = 1001
+	CloseProtocolError    = 1002
+	CloseUnsupportedData  = 1003
+	CloseNoStatusReceived = 1005
+	CloseInvalidPayload   = 1007
+	ClosePolicyViolation  = 1008
+	CloseMessageTooBig    = 1009
+	CloseInternalError    = 1011
+)
+
+// maxControlPayload は制御フレームのペイロードの上限です
+const maxControlPayload = 125
+
+// defaultReadLimit はメッセージの大きさの既定の上限です（SetReadLimit で変更できます）
+const defaultReadLimit = 64 << 10
+
+// closeWriteWait は切断の通知（Closeフレーム）の送信を待つ時間です
+const closeWriteWait = time.Second
+
+// ErrCloseSent は切断を通知した後にメッセージを送信しようとした場合のエラーです
+var ErrCloseSent = errors.New("websocket: close sent")
+
+// CloseError は相手から切断が通知された、またはプロトコル違反で切断した場合のエラーです
+type CloseError struct {
+	Code int
+	Text string
+}
+
+// Error はステータスコードと理由を含むメッセージを返します
+func (e *CloseError) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("websocket: close %d", e.Code)
+	}
+	return fmt.Sprintf("websocket: close %d: %s", e.Code, e.Text)
+}
+
+// Conn は WebSocket の接続です
+//
+// 読み込み（ReadMessage）は1つの goroutine から、送信（WriteMessage 等）は複数の goroutine から呼び出せます
+// Ping には ReadMessage の中で自動的に Pong を返します
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	// isServer はサーバー側の接続かどうかです（受信するフレームのマスクの要否が逆になる）
+	isServer bool
+
+	// readLimit はメッセージ1件の最大バイト数です
+	readLimit int64
+
+	// pongHandler は Pong を受信した場合に呼び出されます（生存確認の期限の延長に使う）
+	pongHandler func()
+
+	writeMu   sync.Mutex
+	closeSent bool
+}
+
+// newConn は確立した接続から Conn を作成します
+func newConn(conn net.Conn, reader *bufio.Reader, isServer bool) *Conn {
+	return &Conn{
+		conn:        conn,
+		reader:      reader,
+		isServer:    isServer,
+		readLimit:   defaultReadLimit,
+		pongHandler: func() {},
+	}
+}
+
+// SetReadLimit はメッセージ1件の最大バイト数を設定します
+// 超えるメッセージを受信した場合は 1009 で切断し、ReadMessage はエラーを返します
+func (c *Conn) SetReadLimit(limit int64) {
+	c.readLimit = limit
+}
+
+// SetPongHandler は Pong を受信した場合の処理を設定します
+func (c *Conn) SetPongHandler(handler func()) {
+	c.pongHandler = handler
+}
+
+// SetReadDeadline は読み込みの期限を設定します（ゼロ値で解除）
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline は送信の期限を設定します（ゼロ値で解除）
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// RemoteAddr は相手のアドレスを返します
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close は通知せずに接続を閉じます（通知する場合は先に WriteClose を呼び出します）
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// ReadMessage は次のデータメッセージ（TextMessage または BinaryMessage）を読み込みます
+//
+// 分割されたフレームは1つのメッセージに結合し、途中に届いた制御フレームは次のように処理します：
+//   - Ping  -> 同じペイロードで Pong を返す
+//   - Pong  -> SetPongHandler の処理を呼び出す
+//   - Close -> 同じステータスコードで Close を返し、*CloseError を返す
+//
+// プロトコル違反の場合は対応するステータスコードで切断を通知し、*CloseError を返します
+func (c *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	messageType = -1
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return -1, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := c.writeControl(PongMessage, payload); err != nil && !errors.Is(err, ErrCloseSent) {
+				return -1, nil, err
+			}
+			continue
+		case PongMessage:
+			c.pongHandler()
+			continue
+		case CloseMessage:
+			return -1, nil, c.handleClose(payload)
+		case TextMessage, BinaryMessage:
+			if messageType != -1 {
+				return -1, nil, c.fail(CloseProtocolError, "new message before the previous one finished")
+			}
+			messageType = opcode
+		case continuationFrame:
+			if messageType == -1 {
+				return -1, nil, c.fail(CloseProtocolError, "continuation frame without a message")
+			}
+		default:
+			return -1, nil, c.fail(CloseProtocolError, fmt.Sprintf("unknown opcode %d", opcode))
+		}
+
+		if int64(len(data)+len(payload)) > c.readLimit {
+			return -1, nil, c.fail(CloseMessageTooBig, fmt.Sprintf("message exceeds %d bytes", c.readLimit))
+		}
+		data = append(data, payload...)
+
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(data) {
+				return -1, nil, c.fail(CloseInvalidPayload, "text message is not valid UTF-8")
+			}
+			return messageType, data, nil
+		}
+	}
+}
+
+// readFrame は1つのフレームを読み込み、マスクを解除したペイロードを返します
+func (c *Conn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin = header[0]&0x80 != 0
+	opcode = int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := int64(header[1] & 0x7f)
+
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "reserved bits must be zero without extensions")
+	}
+	// クライアントからのフレームはマスク必須、サーバーからのフレームはマスク禁止
+	if masked != c.isServer {
+		return false, 0, nil, c.fail(CloseProtocolError, "invalid frame masking")
+	}
+
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(extended[:]))
+		if length < 0 {
+			return false, 0, nil, c.fail(CloseProtocolError, "invalid payload length")
+		}
+	}
+
+	if opcode >= CloseMessage {
+		if !fin || length > maxControlPayload {
+			return false, 0, nil, c.fail(CloseProtocolError, "control frames must not be fragmented or exceed 125 bytes")
+		}
+	}
+	// 上限を超えるペイロードは読み込む前に打ち切る（巨大な長さでメモリを確保させない）
+	if length > c.readLimit {
+		return false, 0, nil, c.fail(CloseMessageTooBig, fmt.Sprintf("message exceeds %d bytes", c.readLimit))
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		maskBytes(mask, payload)
+	}
+	return fin, opcode, payload, nil
+}
+
+// handleClose は相手からの切断の通知に同じステータスコードで応答し、*CloseError を返します
+func (c *Conn) handleClose(payload []byte) error {
+	closeErr := &CloseError{Code: CloseNoStatusReceived}
+	switch {
+	case len(payload) == 1:
+		return c.fail(CloseProtocolError, "invalid close payload")
+	case len(payload) >= 2:
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Text = string(payload[2:])
+		if !utf8.ValidString(closeErr.Text) {
+			return c.fail(CloseInvalidPayload, "close reason is not valid UTF-8")
+		}
+	}
+
+	code := closeErr.Code
+	if code == CloseNoStatusReceived {
+		code = CloseNormalClosure
+	}
+	c.WriteClose(code, "")
+	return closeErr
+}
+
+// fail はプロトコル違反を相手に通知し、同じ内容の *CloseError を返します
+func (c *Conn) fail(code int, text string) error {
+	c.WriteClose(code, text)
+	return &CloseError{Code: code, Text: text}
+}
+
+// WriteMessage はデータメッセージを1つのフレームで送信します
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("websocket: invalid message type %d", messageType)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// WritePing は生存確認の Ping を送信します
+func (c *Conn) WritePing(data []byte) error {
+	return c.writeControl(PingMessage, data)
+}
+
+// WriteClose は切断を通知します（以降のメッセージは送信できません）
+// 応答の Close を受信するまで待たないため、通知の後は Close で接続を閉じてください
+func (c *Conn) WriteClose(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	if len(payload) > maxControlPayload {
+		payload = payload[:maxControlPayload]
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	c.closeSent = true
+
+	// 相手が受信していない場合でも切断を長く待たないよう、期限を設けて送信する
+	c.conn.SetWriteDeadline(time.Now().Add(closeWriteWait))
+	return c.writeFrame(CloseMessage, payload)
+}
+
+// writeControl は制御フレームを送信します
+func (c *Conn) writeControl(opcode int, payload []byte) error {
+	if len(payload) > maxControlPayload {
+		return fmt.Errorf("websocket: control frame payload exceeds %d bytes", maxControlPayload)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	return c.writeFrame(opcode, payload)
+}
+
+// writeFrame は1つのフレーム（FIN付き）を送信します。writeMu を取得してから呼び出します
+func (c *Conn) writeFrame(opcode int, payload []byte) error {
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|byte(opcode))
+
+	maskBit := byte(0)
+	if !c.isServer {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length <= 125:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	if c.isServer {
+		frame = append(frame, payload...)
+	} else {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		maskBytes(mask, frame[start:])
+	}
+
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// maskBytes はペイロードにマスクをかけます（同じ処理でマスクの解除にもなる）
+func maskBytes(mask [4]byte, data []byte) {
+	for i := range data {
+		data[i] ^= mask[i%4]
+	}
+}
@@ -0,0 +1,203 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newEchoServer は受信したメッセージをそのまま返すテスト用のサーバーを起動します
+// serverErr には ReadMessage が最後に返したエラーが送られます
+func newEchoServer(t *testing.T) (url string, serverErr <-chan error) {
+	t.Helper()
+	errs := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, UpgradeOptions{})
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetReadLimit(1024)
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), errs
+}
+
+// dial はテスト用のクライアントで接続します
+func dial(t *testing.T, url string) *Conn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+// TestConn_Echo はメッセージの送受信（長さの表現が異なる大きさ）と Ping への応答をテストします
+func TestConn_Echo(t *testing.T) {
+	url, _ := newEchoServer(t)
+	conn := dial(t, url)
+
+	pongs := 0
+	conn.SetPongHandler(func() { pongs++ })
+	if err := conn.WritePing([]byte("hi")); err != nil {
+		t.Fatalf("WritePing() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		messageType int
+		data        []byte
+	}{
+		{name: "短いテキスト", messageType: TextMessage, data: []byte("こんにちは")},
+		{name: "16ビットの長さ", messageType: BinaryMessage, data: bytes.Repeat([]byte{0xff}, 300)},
+		{name: "空のメッセージ", messageType: TextMessage, data: []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := conn.WriteMessage(tt.messageType, tt.data); err != nil {
+				t.Fatalf("WriteMessage() error = %v", err)
+			}
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("ReadMessage() error = %v", err)
+			}
+			if messageType != tt.messageType || !bytes.Equal(data, tt.data) {
+				t.Errorf("受信 = %d %q, 期待値 = %d %q", messageType, data, tt.messageType, tt.data)
+			}
+		})
+	}
+	if pongs != 1 {
+		t.Errorf("Pong の受信回数 = %d, 期待値 = 1", pongs)
+	}
+}
+
+// TestConn_Close は切断の通知に同じステータスコードで応答することをテストします
+func TestConn_Close(t *testing.T) {
+	url, serverErr := newEchoServer(t)
+	conn := dial(t, url)
+
+	if err := conn.WriteClose(CloseGoingAway, "bye"); err != nil {
+		t.Fatalf("WriteClose() error = %v", err)
+	}
+	if err := conn.WriteMessage(TextMessage, []byte("after close")); !errors.Is(err, ErrCloseSent) {
+		t.Errorf("切断の通知後の送信 = %v, 期待値 = ErrCloseSent", err)
+	}
+
+	_, _, err := conn.ReadMessage()
+	var closeErr *CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != CloseGoingAway {
+		t.Errorf("クライアントの ReadMessage() error = %v, 期待値 = close 1001", err)
+	}
+	if err := <-serverErr; !errors.As(err, &closeErr) || closeErr.Code != CloseGoingAway || closeErr.Text != "bye" {
+		t.Errorf("サーバーの ReadMessage() error = %v, 期待値 = close 1001: bye", err)
+	}
+}
+
+// TestConn_ProtocolErrors はプロトコル違反のステータスコードをテストします
+func TestConn_ProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		send         func(c *Conn) error
+		expectedCode int
+	}{
+		{
+			name:         "マスクされていないフレーム",
+			send:         func(c *Conn) error { c.isServer = true; return c.WriteMessage(TextMessage, []byte("x")) },
+			expectedCode: CloseProtocolError,
+		},
+		{
+			name:         "UTF-8ではないテキスト",
+			send:         func(c *Conn) error { return c.WriteMessage(TextMessage, []byte{0xff, 0xfe}) },
+			expectedCode: CloseInvalidPayload,
+		},
+		{
+			name:         "上限を超えるメッセージ",
+			send:         func(c *Conn) error { return c.WriteMessage(BinaryMessage, make([]byte, 2048)) },
+			expectedCode: CloseMessageTooBig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, serverErr := newEchoServer(t)
+			conn := dial(t, url)
+			if err := tt.send(conn); err != nil {
+				t.Fatalf("送信に失敗: %v", err)
+			}
+
+			var closeErr *CloseError
+			if err := <-serverErr; !errors.As(err, &closeErr) || closeErr.Code != tt.expectedCode {
+				t.Errorf("サーバーの ReadMessage() error = %v, 期待値 = close %d", err, tt.expectedCode)
+			}
+		})
+	}
+}
+
+// TestUpgrade_Rejects は WebSocket のハンドシェイクではないリクエストを拒否することをテストします
+func TestUpgrade_Rejects(t *testing.T) {
+	validHeaders := func() http.Header {
+		return http.Header{
+			"Connection":            {"keep-alive, Upgrade"},
+			"Upgrade":               {"websocket"},
+			"Sec-Websocket-Version": {"13"},
+			"Sec-Websocket-Key":     {"dGhlIHNhbXBsZSBub25jZQ=="},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		modify         func(h http.Header)
+		expectedStatus int
+	}{
+		{name: "通常のGET", method: http.MethodGet, modify: func(h http.Header) { h.Del("Upgrade") }, expectedStatus: http.StatusUpgradeRequired},
+		{name: "未対応のバージョン", method: http.MethodGet, modify: func(h http.Header) { h.Set("Sec-Websocket-Version", "8") }, expectedStatus: http.StatusUpgradeRequired},
+		{name: "不正なキー", method: http.MethodGet, modify: func(h http.Header) { h.Set("Sec-Websocket-Key", "short") }, expectedStatus: http.StatusBadRequest},
+		{name: "別のオリジン", method: http.MethodGet, modify: func(h http.Header) { h.Set("Origin", "https://evil.example") }, expectedStatus: http.StatusForbidden},
+		{name: "GET以外のメソッド", method: http.MethodPost, modify: func(h http.Header) {}, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://todo.example.com/ws", nil)
+			req.Header = validHeaders()
+			tt.modify(req.Header)
+			rec := httptest.NewRecorder()
+
+			if _, err := Upgrade(rec, req, UpgradeOptions{}); !errors.Is(err, ErrBadHandshake) {
+				t.Errorf("Upgrade() error = %v, 期待値 = ErrBadHandshake", err)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("ステータスコード = %v, 期待値 = %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+// TestAcceptKey は RFC 6455 の例の Sec-WebSocket-Accept を計算できることをテストします
+func TestAcceptKey(t *testing.T) {
+	if got := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("acceptKey() = %q, 期待値 = s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", got)
+	}
+}
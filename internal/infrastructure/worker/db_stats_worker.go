@@ -0,0 +1,18 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"todoapp-api-golang/internal/infrastructure/metrics"
+)
+
+// NewDBStatsWorker は一定間隔で接続プールの統計をメトリクスに記録するワーカーを作成します
+// 起動時に一度だけログに出すのではなく継続して記録することで、負荷に応じたプール設定の調整に使えます
+func NewDBStatsWorker(db *sql.DB, dbMetrics *metrics.DBMetrics, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("DBStats", interval, func(context.Context) (int, error) {
+		dbMetrics.ObservePool(db.Stats())
+		return 0, nil
+	})
+}
@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// NewDeliveryWorker は一定間隔で送信に失敗した通知などを再送するワーカーを作成します
+// 再送の間隔（バックオフ）は各送信の再送予定時刻で決まり、ワーカーの間隔はその確認頻度です
+func NewDeliveryWorker(deliveryService service.DeliveryServiceInterface, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("Delivery", interval, deliveryService.RetryDue)
+}
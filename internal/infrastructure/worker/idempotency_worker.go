@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// NewIdempotencyWorker は一定間隔で有効期限を過ぎた Idempotency-Key の記録を削除するワーカーを作成します
+// 期限切れの記録は同じキーのリクエストでも削除されるため、間隔はテーブルの大きさだけに影響します
+func NewIdempotencyWorker(idempotencyService service.IdempotencyServiceInterface, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("Idempotency", interval, idempotencyService.PurgeExpired)
+}
@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// NewOutboxWorker は一定間隔でアウトボックスのイベントを発行するワーカーを作成します
+// 間隔は変更が保存されてから購読者（Webhookなど）に届くまでの最大の遅れになります
+func NewOutboxWorker(relay *service.OutboxRelay, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("Outbox", interval, relay.RelayPending)
+}
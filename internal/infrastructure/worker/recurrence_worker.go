@@ -0,0 +1,12 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// NewRecurrenceWorker は一定間隔で繰り返しTodoの今後のオカレンスを先行作成するワーカーを作成します
+func NewRecurrenceWorker(recurrenceService service.RecurrenceServiceInterface, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("Recurrence", interval, recurrenceService.MaterializeUpcoming)
+}
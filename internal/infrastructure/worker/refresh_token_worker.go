@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// NewRefreshTokenWorker は一定間隔で有効期限を過ぎたリフレッシュトークンの記録を削除するワーカーを作成します
+// 期限切れのトークンは削除しなくても使えないため、間隔はテーブルの大きさだけに影響します
+func NewRefreshTokenWorker(authService service.AuthServiceInterface, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("RefreshToken", interval, authService.PurgeExpired)
+}
@@ -0,0 +1,12 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// NewReminderWorker は一定間隔で期限を過ぎたリマインダーをスキャンし、通知を実行するワーカーを作成します
+func NewReminderWorker(reminderService service.ReminderServiceInterface, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("Reminder", interval, reminderService.DispatchDue)
+}
@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/domain/service"
+)
+
+// NewSessionWorker は一定間隔で有効期限を過ぎたCookieのセッションを削除するワーカーを作成します
+// 期限切れのセッションは削除しなくても使えないため、間隔はストアの大きさだけに影響します
+func NewSessionWorker(sessionService service.SessionServiceInterface, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("Session", interval, sessionService.PurgeExpired)
+}
@@ -0,0 +1,13 @@
+package worker
+
+import (
+	"time"
+
+	"todoapp-api-golang/internal/infrastructure/telemetry"
+)
+
+// NewTelemetryWorker は一定間隔で匿名の利用状況レポートを送信するワーカーを作成します
+// TELEMETRY_ENABLED=true の場合のみ起動してください
+func NewTelemetryWorker(reporter *telemetry.Reporter, interval time.Duration) *PeriodicWorker {
+	return NewPeriodicWorker("Telemetry", interval, reporter.Send)
+}
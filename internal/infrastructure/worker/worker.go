@@ -0,0 +1,113 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Task はワーカーが定期的に実行する処理です
+// 戻り値は処理した件数で、0件より多い場合のみログに記録されます
+type Task func(ctx context.Context) (int, error)
+
+// PeriodicWorker は一定間隔で Task を実行するバックグラウンドワーカーです
+//
+// 学習ポイント：
+// 1. time.Ticker による定期実行
+// 2. context のキャンセルによる停止（サーバー終了と連動）
+// 3. 1回の失敗でワーカー全体を止めない（ログに記録して次回に再試行）
+type PeriodicWorker struct {
+	name     string
+	interval time.Duration
+	task     Task
+}
+
+// NewPeriodicWorker はPeriodicWorkerのコンストラクタです
+// name はログ出力に使うワーカー名です
+func NewPeriodicWorker(name string, interval time.Duration, task Task) *PeriodicWorker {
+	return &PeriodicWorker{
+		name:     name,
+		interval: interval,
+		task:     task,
+	}
+}
+
+// Run は ctx がキャンセルされるまで Task の実行を繰り返します
+// 起動直後に1回実行し、その後は interval ごとに実行します
+// 通常は goroutine として起動します（go worker.Run(ctx)、または Group.Start）
+func (w *PeriodicWorker) Run(ctx context.Context) {
+	log.Printf("%s worker started (interval: %s)", w.name, w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Printf("%s worker stopped", w.name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce は Task を1回実行します
+func (w *PeriodicWorker) RunOnce(ctx context.Context) {
+	processed, err := w.task(ctx)
+	if err != nil {
+		log.Printf("%s worker error: %v", w.name, err)
+	}
+	if processed > 0 {
+		log.Printf("%s worker processed %d item(s)", w.name, processed)
+	}
+}
+
+// Group は複数のワーカーを起動し、まとめて停止するための構造体です
+// Stop はワーカーに停止を指示したうえで、実行中の Task が終わるまで待機します
+// これにより、シャットダウン時にDB接続を閉じる前にワーカーの処理を完了させられます
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGroup はGroupのコンストラクタです
+func NewGroup() *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start はワーカーを goroutine として起動します
+func (g *Group) Start(w *PeriodicWorker) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		w.Run(g.ctx)
+	}()
+}
+
+// Stop はすべてのワーカーを停止し、終了を待機します
+// ctx がタイムアウトした場合は待機を打ち切り、ctx のエラーを返します
+// 複数回呼び出しても安全です
+func (g *Group) Stop(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGroup_StartStop はワーカーが起動直後に実行され、Stop で終了まで待機されることをテストします
+func TestGroup_StartStop(t *testing.T) {
+	var runs atomic.Int32
+	started := make(chan struct{}, 1)
+	task := func(ctx context.Context) (int, error) {
+		if runs.Add(1) == 1 {
+			started <- struct{}{}
+		}
+		return 0, nil
+	}
+
+	group := NewGroup()
+	group.Start(NewPeriodicWorker("Test", time.Hour, task))
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("起動直後にタスクが実行されていません")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := group.Stop(ctx); err != nil {
+		t.Fatalf("停止に失敗: %v", err)
+	}
+	if runs.Load() != 1 {
+		t.Errorf("実行回数 = %d, 期待値 = 1", runs.Load())
+	}
+
+	// 2回目の Stop も安全に呼び出せる
+	if err := group.Stop(ctx); err != nil {
+		t.Errorf("2回目の停止に失敗: %v", err)
+	}
+}
+
+// TestGroup_StopTimeout は実行中のタスクが終わらない場合に待機を打ち切ることをテストします
+func TestGroup_StopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	task := func(ctx context.Context) (int, error) {
+		close(started)
+		<-release
+		return 0, nil
+	}
+
+	group := NewGroup()
+	group.Start(NewPeriodicWorker("Blocking", time.Hour, task))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := group.Stop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("エラー = %v, 期待値 = %v", err, context.DeadlineExceeded)
+	}
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config はアプリケーション全体の設定を管理する構造体です
@@ -19,8 +21,20 @@ type Config struct {
 	// Database はデータベース接続関連の設定
 	Database DatabaseConfig `json:"database"`
 
+	// DynamoDB は DB_DRIVER=dynamodb の場合の Amazon DynamoDB の設定
+	DynamoDB DynamoDBConfig `json:"dynamodb"`
+
 	// App はアプリケーション固有の設定
 	App AppConfig `json:"app"`
+
+	// HTTPClient は外部サービス（Webhook等）を呼び出すHTTPクライアントの設定
+	HTTPClient HTTPClientConfig `json:"http_client"`
+
+	// Telemetry は匿名の利用状況レポート（オプトイン）の設定
+	Telemetry TelemetryConfig `json:"telemetry"`
+
+	// Auth はユーザー認証（アクセストークンとリフレッシュトークン）の設定
+	Auth AuthConfig `json:"auth"`
 }
 
 // ServerConfig はHTTPサーバーの設定を管理します
@@ -36,11 +50,71 @@ type ServerConfig struct {
 
 	// WriteTimeout は書き込みタイムアウト（秒）
 	WriteTimeout int `json:"write_timeout"`
+
+	// MaxBodyBytes はリクエストボディの最大バイト数（超えた場合は 413 Request Entity Too Large）
+	MaxBodyBytes int `json:"max_body_bytes"`
+
+	// BodyReadTimeout はリクエストボディを読み終えるまでの上限（秒、超えた場合は 408 Request Timeout）
+	// ReadTimeout より短くして、少しずつボディを送り続ける遅いアップロードを早めに打ち切ります
+	BodyReadTimeout int `json:"body_read_timeout"`
+
+	// BasePath はアプリケーションを公開するURLのプレフィックス（例: /todoapp）
+	// パスでルーティングするリバースプロキシの配下で動かす場合に設定します
+	// 先頭の / あり・末尾の / なしに正規化され、未設定の場合は空文字（ルート直下）です
+	BasePath string `json:"base_path"`
+
+	// Hosts はアプリケーションを公開するホスト名の一覧です（例: todo.example.com, *.tenant.example.com）
+	// 設定した場合、一覧にないホスト名へのリクエストは 421 Misdirected Request で拒否します
+	// 未設定の場合はホスト名を問わず受け付けます
+	Hosts []string `json:"hosts"`
+
+	// WebSocketOrigins は /ws への接続を許可する、同じオリジン以外のオリジンの一覧です（例: https://app.example.com）
+	// ブラウザの WebSocket は CORS の対象外のため、他のサイトからの接続をオリジンで拒否します
+	// "*" を指定すると全てのオリジンを許可します（開発用）
+	WebSocketOrigins []string `json:"websocket_origins"`
+
+	// GRPCPort はgRPCサーバー（api/proto/todo/v1/todo.proto）が使用するポート番号です
+	// HTTPサーバーとは別のポートで待ち受け、0 の場合はgRPCサーバーを起動しません
+	GRPCPort int `json:"grpc_port"`
+
+	// RateLimitMode はクライアント（IPアドレス）ごとのレート制限のモードです（off, warn, enforce）
+	// warn では上限を超えたリクエストも処理し、X-RateLimit-Warning ヘッダーとログで知らせるだけです
+	RateLimitMode string `json:"rate_limit_mode"`
+
+	// RateLimitRequestsPerMinute はクライアントごとの1分あたりのリクエスト数の上限
+	RateLimitRequestsPerMinute int `json:"rate_limit_requests_per_minute"`
+
+	// RateLimitBurst は続けて送信できるリクエスト数の上限
+	RateLimitBurst int `json:"rate_limit_burst"`
+
+	// RateLimitWarnPeriod は warn モードを続ける慣らし期間（秒）
+	// 起動からこの期間が過ぎると 429 で拒否するようになり、0 の場合は warn のままです
+	RateLimitWarnPeriod int `json:"rate_limit_warn_period"`
+
+	// RateLimitPlans は認証されたユーザーに適用するプランごとの上限です（キーはプラン名）
+	// 認証されたリクエストはIPアドレスではなくユーザーごとに数えます
+	RateLimitPlans map[string]RateLimitPlan `json:"rate_limit_plans"`
+
+	// RateLimitUserPlans はユーザー名ごとのプラン名です
+	RateLimitUserPlans map[string]string `json:"rate_limit_user_plans"`
+
+	// RateLimitDefaultPlan は RateLimitUserPlans にないユーザーのプラン名です
+	// 空の場合は、認証されたユーザーにも RateLimitRequestsPerMinute / RateLimitBurst を適用します
+	RateLimitDefaultPlan string `json:"rate_limit_default_plan"`
+}
+
+// RateLimitPlan はプランごとのレート制限の上限です
+type RateLimitPlan struct {
+	// RequestsPerMinute は1分あたりのリクエスト数の上限です（0 の場合は制限しない）
+	RequestsPerMinute int `json:"requests_per_minute"`
+
+	// Burst は続けて送信できるリクエスト数の上限です（0 の場合は RequestsPerMinute と同じ）
+	Burst int `json:"burst"`
 }
 
 // DatabaseConfig はデータベース接続の設定を管理します
 type DatabaseConfig struct {
-	// Driver はデータベースドライバー名（mysql, postgres等）
+	// Driver はデータベースドライバー名（mysql・sqlite、またはデータベースを使わない memory・embedded・dynamodb）
 	Driver string `json:"driver"`
 
 	// Host はデータベースサーバーのホスト名
@@ -69,6 +143,120 @@ type DatabaseConfig struct {
 
 	// ConnMaxLifetime は接続の最大生存時間（分）
 	ConnMaxLifetime int `json:"conn_max_lifetime"`
+
+	// ReconnectMaxAttempts はフェイルオーバーなどで接続できないときに再接続を試みる最大回数
+	ReconnectMaxAttempts int `json:"reconnect_max_attempts"`
+
+	// ConnectRetryWindow は起動時にデータベースへ接続できない場合に、再試行を続ける期間（秒）です
+	// データベースのコンテナがAPIより遅れて起動する場合に備えます（0 の場合は再試行しない）
+	ConnectRetryWindow int `json:"connect_retry_window"`
+
+	// StatsInterval は接続プールの統計（sql.DB.Stats()）を /metrics のメトリクスに記録する間隔（秒）です
+	// METRICS_ENABLED=true の場合のみ記録します（0 の場合は記録しない）
+	StatsInterval int `json:"stats_interval"`
+
+	// QueryTimeout は1回のクエリの期限（秒）です。期限を過ぎたクエリは打ち切り、APIは 504 Gateway Timeout を返します
+	// 遅いデータベースがサーバーの WriteTimeout までリクエストを止めないようにするためのものです（0 の場合は期限を設けない）
+	QueryTimeout int `json:"query_timeout"`
+
+	// Shards はシャーディング時の各シャードの接続先（host:port/name 形式）
+	// 空の場合はシャーディングを行わず、単一データベースで動作します
+	Shards []ShardConfig `json:"shards,omitempty"`
+}
+
+// ShardConfig は1つのシャード（データベース）の接続先を表します
+// ユーザー名やパスワード、プール設定は DatabaseConfig の値を共有します
+type ShardConfig struct {
+	// Name はシャード識別子（コンシステントハッシュのノード名として使用）
+	Name string `json:"name"`
+
+	// Host はシャードのデータベースホスト名
+	Host string `json:"host"`
+
+	// Port はシャードのデータベースポート番号
+	Port int `json:"port"`
+
+	// Database はシャードのデータベース名
+	Database string `json:"database"`
+}
+
+// HTTPClientConfig は外部サービスを呼び出すHTTPクライアントの設定を管理します
+type HTTPClientConfig struct {
+	// Timeout は1回の呼び出し（再試行を含む）のタイムアウト（秒）
+	Timeout int `json:"timeout"`
+
+	// MaxRetries は一時的な失敗時に再試行する最大回数
+	MaxRetries int `json:"max_retries"`
+
+	// MaxIdleConnsPerHost は接続先ホストごとに保持するアイドル接続の上限
+	MaxIdleConnsPerHost int `json:"max_idle_conns_per_host"`
+
+	// ProxyURL は経由するプロキシのURL（空の場合は HTTP_PROXY / HTTPS_PROXY に従う）
+	ProxyURL string `json:"proxy_url"`
+
+	// CAFile は追加で信頼するCA証明書（PEM）のパス
+	CAFile string `json:"ca_file"`
+}
+
+// DynamoDBConfig は Amazon DynamoDB にTodoを保存する場合（DB_DRIVER=dynamodb）の設定を管理します
+// 認証情報を設定しない場合は、Lambda 等の実行環境が設定する AWS_ACCESS_KEY_ID・AWS_SECRET_ACCESS_KEY・AWS_SESSION_TOKEN を使用します
+type DynamoDBConfig struct {
+	// Table はTodoを保存するテーブル名です
+	Table string `json:"table"`
+
+	// Region はテーブルのあるリージョンです
+	Region string `json:"region"`
+
+	// Endpoint は接続先のURLです（空の場合はリージョンのエンドポイント。DynamoDB Local に接続する場合に指定します）
+	Endpoint string `json:"endpoint"`
+
+	// AccessKeyID・SecretAccessKey・SessionToken はリクエストに署名する認証情報です
+	AccessKeyID     string `json:"-"`
+	SecretAccessKey string `json:"-"`
+	SessionToken    string `json:"-"`
+}
+
+// TelemetryConfig は匿名の利用状況レポートの設定を管理します
+// 明示的に有効にした場合のみ送信します（デフォルトは無効）
+type TelemetryConfig struct {
+	// Enabled が true の場合のみレポートを送信します
+	Enabled bool `json:"enabled"`
+
+	// Endpoint はレポートの送信先URL（有効にする場合は必須）
+	Endpoint string `json:"endpoint"`
+
+	// Interval はレポートを送信する間隔（秒）
+	Interval int `json:"interval"`
+}
+
+// AuthConfig はユーザー認証の設定を管理します
+// TokenSecret を設定した場合のみ、/api/v1/ 配下（/api/v1/auth/ を除く）にアクセストークンを必須にします
+type AuthConfig struct {
+	// TokenSecret はアクセストークン（JWT）の署名鍵です（32バイト以上）
+	// 空の場合はユーザー認証を無効にします（従来どおり誰でもAPIを呼び出せる）
+	TokenSecret string `json:"-"`
+
+	// AccessTokenTTL はアクセストークンの有効期間（秒）
+	// アクセストークンは失効させられないため、短くします
+	AccessTokenTTL int `json:"access_token_ttl"`
+
+	// RefreshTokenTTL はリフレッシュトークンの有効期間（秒）
+	// リフレッシュのたびに新しいトークンを発行するため、利用を続ける限りログインし直す必要はありません
+	RefreshTokenTTL int `json:"refresh_token_ttl"`
+
+	// InvitationTTL はワークスペースへの招待の有効期間（秒）
+	InvitationTTL int `json:"invitation_ttl"`
+
+	// SessionStore はCookieのセッション（POST /api/v1/auth/session）の保存先です（off, memory, database）
+	// memory はプロセスの再起動で全てのセッションが失われ、複数台のサーバーの間でも共有されません
+	SessionStore string `json:"session_store"`
+
+	// SessionTTL はセッションの有効期間（秒）
+	SessionTTL int `json:"session_ttl"`
+
+	// SessionCookieSecure はセッションのCookieに Secure 属性を付けるか（HTTPS でだけ送る）
+	// HTTP で動かす開発環境でのみ false にします
+	SessionCookieSecure bool `json:"session_cookie_secure"`
 }
 
 // AppConfig はアプリケーション固有の設定を管理します
@@ -77,10 +265,96 @@ type AppConfig struct {
 	Environment string `json:"environment"`
 
 	// LogLevel はログレベル（debug, info, warn, error）
+	// 起動時の初期値で、実行中は PUT /admin/loglevel で変更できます
 	LogLevel string `json:"log_level"`
 
+	// AdminToken は /admin/ 配下の管理用エンドポイントの Bearer トークン
+	// 空の場合は管理用エンドポイントを公開しません
+	AdminToken string `json:"-"`
+
+	// OpsUsername / OpsPassword は運用向けエンドポイント（/metrics と /debug/pprof/）の Basic 認証の資格情報
+	// 両方とも空の場合は保護せず、pprof も公開しません
+	OpsUsername string `json:"-"`
+	OpsPassword string `json:"-"`
+
 	// Version はアプリケーションバージョン
 	Version string `json:"version"`
+
+	// UniqueTodoTitles が true の場合、同じタイトル（大文字・小文字を区別しない）のTodoの作成・更新を 409 で拒否します
+	UniqueTodoTitles bool `json:"unique_todo_titles"`
+
+	// RequireIfMatch が true の場合、Todoの更新・削除に If-Match ヘッダー（取得時の ETag）を必須にします
+	// false の場合も、If-Match を付けたリクエストは検証します（一致しなければ 412）
+	RequireIfMatch bool `json:"require_if_match"`
+
+	// IdempotencyKeyTTL は Idempotency-Key を付けたリクエストのレスポンスを保持し、同じキーの再送に返す期間（秒）
+	// 0 の場合は Idempotency-Key を無視します（再送すると処理を繰り返します）
+	IdempotencyKeyTTL int `json:"idempotency_key_ttl"`
+
+	// UndoWindow は削除の後、POST /api/v1/undo で取り消せる期間（秒）
+	// 0 の場合は取り消しを無効にします
+	UndoWindow int `json:"undo_window"`
+
+	// ReminderScanInterval はリマインダーをスキャンする間隔（秒）
+	// 0 以下の場合はリマインダーワーカーを起動しません
+	ReminderScanInterval int `json:"reminder_scan_interval"`
+
+	// RecurrenceScanInterval は繰り返しTodoのオカレンスを先行作成する間隔（秒）
+	// 0 以下の場合は繰り返しワーカーを起動しません
+	RecurrenceScanInterval int `json:"recurrence_scan_interval"`
+
+	// RecurrenceHorizonDays は何日先の期限までオカレンスを先行作成するか
+	RecurrenceHorizonDays int `json:"recurrence_horizon_days"`
+
+	// DeliveryRetryInterval は送信に失敗した通知などを再送するスキャンの間隔（秒）
+	// 0 以下の場合は再送ワーカーを起動しません
+	DeliveryRetryInterval int `json:"delivery_retry_interval"`
+
+	// DeliveryMaxAttempts は最初の送信を含めた送信回数の上限（到達するとデッドレターになる）
+	DeliveryMaxAttempts int `json:"delivery_max_attempts"`
+
+	// OutboxRelayInterval はアウトボックスに保存したTodoのイベントをWebhookへ発行する間隔（秒）
+	// 0 以下の場合はアウトボックスを使わず、変更の保存後にすぐ通知します（プロセスが停止すると通知が失われ得る）
+	OutboxRelayInterval int `json:"outbox_relay_interval"`
+
+	// WebhookDebounceWindow は同じTodoに続いたイベントを1つのWebhookの通知にまとめる期間（秒）
+	// 0 の場合はまとめません。アウトボックスを使う場合（OutboxRelayInterval > 0）は保存順に全て通知します
+	WebhookDebounceWindow int `json:"webhook_debounce_window"`
+
+	// ReminderWebhookURL はリマインダーの通知先のWebhook URL（空の場合はログに出力）
+	ReminderWebhookURL string `json:"reminder_webhook_url"`
+
+	// ResponseTimeFormat はレスポンスJSONの日時の形式（rfc3339, epoch_seconds, epoch_millis）
+	ResponseTimeFormat string `json:"response_time_format"`
+
+	// ResponseStringIDs が true の場合、レスポンスJSONのIDを文字列で返します
+	// JavaScript の Number で大きなIDの精度が失われるクライアント向けの設定です
+	ResponseStringIDs bool `json:"response_string_ids"`
+
+	// IDObfuscationSalt を設定すると、APIで公開するIDを連番の整数ではなく Hashids の文字列にします（例: "gB0NV05e"）
+	// ソルトを変えると公開済みのID（URL）が全て変わるため、運用開始後は変更しないでください
+	IDObfuscationSalt string `json:"-"`
+
+	// IDObfuscationMinLength は Hashids の文字列の最小の長さ
+	IDObfuscationMinLength int `json:"id_obfuscation_min_length"`
+
+	// MetricsEnabled が true の場合、/metrics でビジネス指標をPrometheus形式で公開します
+	MetricsEnabled bool `json:"metrics_enabled"`
+
+	// HealthCheckCacheTTL は /health のDBチェックの結果を再利用する期間（秒）
+	// 0 の場合はキャッシュせず、プローブのたびにDBへ問い合わせます
+	HealthCheckCacheTTL int `json:"health_check_cache_ttl"`
+
+	// HealthCheckCacheJitter はキャッシュの有効期限に加えるランダムな揺らぎの最大値（秒）
+	HealthCheckCacheJitter int `json:"health_check_cache_jitter"`
+
+	// RecordTrafficDir を指定すると、APIのリクエストとレスポンスをこのディレクトリに記録します
+	// 不具合の再現用（cmd/replay で再送信できます）で、本番環境では指定できません
+	RecordTrafficDir string `json:"record_traffic_dir"`
+
+	// ContractValidation が true の場合、APIのレスポンスを仕様書（api/openapi.json）と照合し、違反をログに出力します
+	// 全てのレスポンスを検証するため開発用で、本番環境では有効にできません
+	ContractValidation bool `json:"contract_validation"`
 }
 
 // Load は環境変数から設定を読み込んでConfig構造体を作成します
@@ -89,10 +363,23 @@ func Load() (*Config, error) {
 	config := &Config{
 		// サーバー設定の読み込み
 		Server: ServerConfig{
-			Port:         getEnvAsInt("SERVER_PORT", 8080),        // デフォルト: 8080
-			Host:         getEnv("SERVER_HOST", "0.0.0.0"),        // デフォルト: 全IPでバインド
-			ReadTimeout:  getEnvAsInt("SERVER_READ_TIMEOUT", 30),  // デフォルト: 30秒
-			WriteTimeout: getEnvAsInt("SERVER_WRITE_TIMEOUT", 30), // デフォルト: 30秒
+			Port:            getEnvAsInt("SERVER_PORT", 8080),            // デフォルト: 8080
+			Host:            getEnv("SERVER_HOST", "0.0.0.0"),            // デフォルト: 全IPでバインド
+			ReadTimeout:     getEnvAsInt("SERVER_READ_TIMEOUT", 30),      // デフォルト: 30秒
+			WriteTimeout:    getEnvAsInt("SERVER_WRITE_TIMEOUT", 30),     // デフォルト: 30秒
+			MaxBodyBytes:    getEnvAsInt("SERVER_MAX_BODY_BYTES", 1<<20), // デフォルト: 1MB
+			BodyReadTimeout: getEnvAsInt("SERVER_BODY_READ_TIMEOUT", 10), // デフォルト: 10秒
+			BasePath:        normalizeBasePath(getEnv("BASE_PATH", "")),  // デフォルト: ルート直下
+			Hosts:           getEnvAsSlice("SERVER_HOSTS", nil),          // デフォルト: ホスト名を問わない
+
+			WebSocketOrigins: getEnvAsSlice("SERVER_WEBSOCKET_ORIGINS", nil), // デフォルト: 同じオリジンのみ
+			GRPCPort:         getEnvAsInt("SERVER_GRPC_PORT", 0),             // デフォルト: 無効
+
+			RateLimitMode:              getEnv("RATE_LIMIT_MODE", "off"),                   // デフォルト: 制限しない
+			RateLimitRequestsPerMinute: getEnvAsInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 120), // デフォルト: 1分あたり120回
+			RateLimitBurst:             getEnvAsInt("RATE_LIMIT_BURST", 30),                // デフォルト: 30回
+			RateLimitWarnPeriod:        getEnvAsInt("RATE_LIMIT_WARN_PERIOD", 0),           // デフォルト: warn のまま
+			RateLimitDefaultPlan:       getEnv("RATE_LIMIT_DEFAULT_PLAN", ""),              // デフォルト: クライアントごとの上限と同じ
 		},
 
 		// データベース設定の読み込み
@@ -107,6 +394,22 @@ func Load() (*Config, error) {
 			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 10),    // デフォルト: 10接続
 			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 5),     // デフォルト: 5接続
 			ConnMaxLifetime: getEnvAsInt("DB_CONN_MAX_LIFETIME", 60), // デフォルト: 60分
+
+			ReconnectMaxAttempts: getEnvAsInt("DB_RECONNECT_MAX_ATTEMPTS", 5), // デフォルト: 5回
+			ConnectRetryWindow:   getEnvAsInt("DB_CONNECT_RETRY_WINDOW", 60),  // デフォルト: 60秒
+			StatsInterval:        getEnvAsInt("DB_STATS_INTERVAL", 15),        // デフォルト: 15秒
+			QueryTimeout:         getEnvAsInt("DB_QUERY_TIMEOUT", 3),          // デフォルト: 3秒
+
+		},
+
+		// DynamoDBの設定の読み込み（AWS_ で始まる変数は AWS のツールと共通の名前）
+		DynamoDB: DynamoDBConfig{
+			Table:           getEnv("DYNAMODB_TABLE", "todos"),      // デフォルト: todos
+			Region:          getEnv("AWS_REGION", "ap-northeast-1"), // デフォルト: 東京リージョン
+			Endpoint:        getEnv("DYNAMODB_ENDPOINT", ""),        // デフォルト: リージョンのエンドポイント
+			AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),        // デフォルト: なし
+			SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),    // デフォルト: なし
+			SessionToken:    getEnv("AWS_SESSION_TOKEN", ""),        // デフォルト: なし
 		},
 
 		// アプリケーション設定の読み込み
@@ -114,9 +417,90 @@ func Load() (*Config, error) {
 			Environment: getEnv("APP_ENV", "development"), // デフォルト: 開発環境
 			LogLevel:    getEnv("LOG_LEVEL", "info"),      // デフォルト: infoレベル
 			Version:     getEnv("APP_VERSION", "1.0.0"),   // デフォルト: 1.0.0
+			AdminToken:  getEnv("ADMIN_TOKEN", ""),        // デフォルト: 管理用エンドポイントを公開しない
+
+			OpsUsername: getEnv("OPS_BASIC_AUTH_USERNAME", ""), // デフォルト: 運用向けエンドポイントを保護しない
+			OpsPassword: getEnv("OPS_BASIC_AUTH_PASSWORD", ""), // デフォルト: 運用向けエンドポイントを保護しない
+
+			UniqueTodoTitles: getEnvAsBool("UNIQUE_TODO_TITLES", false), // デフォルト: 重複を許可
+			RequireIfMatch:   getEnvAsBool("REQUIRE_IF_MATCH", false),   // デフォルト: 付けた場合のみ検証
+			UndoWindow:       getEnvAsInt("UNDO_WINDOW", 30),            // デフォルト: 30秒
+
+			IdempotencyKeyTTL: getEnvAsInt("IDEMPOTENCY_KEY_TTL", 86400), // デフォルト: 24時間
+
+			ReminderScanInterval:   getEnvAsInt("REMINDER_SCAN_INTERVAL", 60),    // デフォルト: 60秒
+			RecurrenceScanInterval: getEnvAsInt("RECURRENCE_SCAN_INTERVAL", 300), // デフォルト: 5分
+			RecurrenceHorizonDays:  getEnvAsInt("RECURRENCE_HORIZON_DAYS", 7),    // デフォルト: 7日先まで
+			ReminderWebhookURL:     getEnv("REMINDER_WEBHOOK_URL", ""),           // デフォルト: ログに出力
+			DeliveryRetryInterval:  getEnvAsInt("DELIVERY_RETRY_INTERVAL", 30),   // デフォルト: 30秒
+			DeliveryMaxAttempts:    getEnvAsInt("DELIVERY_MAX_ATTEMPTS", 8),      // デフォルト: 8回
+			OutboxRelayInterval:    getEnvAsInt("OUTBOX_RELAY_INTERVAL", 0),      // デフォルト: アウトボックスを使わない
+			WebhookDebounceWindow:  getEnvAsInt("WEBHOOK_DEBOUNCE_WINDOW", 0),    // デフォルト: まとめない
+
+			ResponseTimeFormat: getEnv("RESPONSE_TIME_FORMAT", "rfc3339"),  // デフォルト: RFC3339形式の文字列
+			ResponseStringIDs:  getEnvAsBool("RESPONSE_STRING_IDS", false), // デフォルト: 数値
+
+			IDObfuscationSalt:      getEnv("ID_OBFUSCATION_SALT", ""),           // デフォルト: 整数のIDをそのまま公開
+			IDObfuscationMinLength: getEnvAsInt("ID_OBFUSCATION_MIN_LENGTH", 8), // デフォルト: 8文字
+
+			MetricsEnabled: getEnvAsBool("METRICS_ENABLED", true), // デフォルト: 公開する
+
+			HealthCheckCacheTTL:    getEnvAsInt("HEALTH_CHECK_CACHE_TTL", 2),    // デフォルト: 2秒
+			HealthCheckCacheJitter: getEnvAsInt("HEALTH_CHECK_CACHE_JITTER", 1), // デフォルト: 最大1秒
+
+			RecordTrafficDir: getEnv("RECORD_TRAFFIC_DIR", ""), // デフォルト: 記録しない
+
+			ContractValidation: getEnvAsBool("CONTRACT_VALIDATION", false), // デフォルト: 検証しない
+		},
+
+		// 外部呼び出し用HTTPクライアントの設定の読み込み
+		HTTPClient: HTTPClientConfig{
+			Timeout:             getEnvAsInt("HTTP_CLIENT_TIMEOUT", 10),                 // デフォルト: 10秒
+			MaxRetries:          getEnvAsInt("HTTP_CLIENT_MAX_RETRIES", 2),              // デフォルト: 2回
+			MaxIdleConnsPerHost: getEnvAsInt("HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST", 10), // デフォルト: 10接続
+			ProxyURL:            getEnv("HTTP_CLIENT_PROXY_URL", ""),                    // デフォルト: 環境変数に従う
+			CAFile:              getEnv("HTTP_CLIENT_CA_FILE", ""),                      // デフォルト: システムの証明書のみ
+		},
+
+		// 利用状況レポートの設定の読み込み
+		Telemetry: TelemetryConfig{
+			Enabled:  getEnvAsBool("TELEMETRY_ENABLED", false), // デフォルト: 送信しない
+			Endpoint: getEnv("TELEMETRY_ENDPOINT", ""),         // デフォルト: なし
+			Interval: getEnvAsInt("TELEMETRY_INTERVAL", 86400), // デフォルト: 1日
+		},
+
+		// ユーザー認証の設定の読み込み
+		Auth: AuthConfig{
+			TokenSecret:     getEnv("AUTH_TOKEN_SECRET", ""),                // デフォルト: 認証しない
+			AccessTokenTTL:  getEnvAsInt("AUTH_ACCESS_TOKEN_TTL", 900),      // デフォルト: 15分
+			RefreshTokenTTL: getEnvAsInt("AUTH_REFRESH_TOKEN_TTL", 2592000), // デフォルト: 30日
+			InvitationTTL:   getEnvAsInt("AUTH_INVITATION_TTL", 604800),     // デフォルト: 7日
+
+			SessionStore:        getEnv("AUTH_SESSION_STORE", "database"),         // デフォルト: データベースに保存
+			SessionTTL:          getEnvAsInt("AUTH_SESSION_TTL", 86400),           // デフォルト: 1日
+			SessionCookieSecure: getEnvAsBool("AUTH_SESSION_COOKIE_SECURE", true), // デフォルト: HTTPS のみ
 		},
 	}
 
+	// シャード設定の読み込み（例: DB_SHARDS=db1:3306/todoapp_0,db2:3306/todoapp_1）
+	shards, err := parseShards(getEnvAsSlice("DB_SHARDS", nil), config.Database.Port)
+	if err != nil {
+		return nil, fmt.Errorf("config validation error: %w", err)
+	}
+	config.Database.Shards = shards
+
+	// レート制限のプランの読み込み（例: RATE_LIMIT_PLANS=free:60:10,pro:600:100, RATE_LIMIT_USER_PLANS=alice:pro）
+	plans, err := parseRateLimitPlans(getEnvAsSlice("RATE_LIMIT_PLANS", nil))
+	if err != nil {
+		return nil, fmt.Errorf("config validation error: %w", err)
+	}
+	config.Server.RateLimitPlans = plans
+	userPlans, err := parseRateLimitUserPlans(getEnvAsSlice("RATE_LIMIT_USER_PLANS", nil))
+	if err != nil {
+		return nil, fmt.Errorf("config validation error: %w", err)
+	}
+	config.Server.RateLimitUserPlans = userPlans
+
 	// 設定値のバリデーション
 	if err := config.validate(); err != nil {
 		return nil, fmt.Errorf("config validation error: %w", err)
@@ -131,12 +515,91 @@ func (c *Config) validate() error {
 	if c.Server.Port < 1 || c.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d (must be 1-65535)", c.Server.Port)
 	}
+	if c.Server.GRPCPort < 0 || c.Server.GRPCPort > 65535 {
+		return fmt.Errorf("invalid gRPC port: %d (must be 0-65535)", c.Server.GRPCPort)
+	}
+	if c.Server.GRPCPort == c.Server.Port {
+		return fmt.Errorf("invalid gRPC port: %d (must differ from the HTTP server port)", c.Server.GRPCPort)
+	}
+
+	// レート制限のモードと上限のチェック
+	if c.Server.RateLimitMode != "off" &&
+		c.Server.RateLimitMode != "warn" &&
+		c.Server.RateLimitMode != "enforce" {
+		return fmt.Errorf("invalid rate limit mode: %s (must be off, warn, or enforce)", c.Server.RateLimitMode)
+	}
+	if c.Server.RateLimitMode != "off" && (c.Server.RateLimitRequestsPerMinute < 1 || c.Server.RateLimitBurst < 1) {
+		return fmt.Errorf("invalid rate limit: requests per minute %d, burst %d (must be at least 1)", c.Server.RateLimitRequestsPerMinute, c.Server.RateLimitBurst)
+	}
+	if c.Server.RateLimitWarnPeriod < 0 {
+		return fmt.Errorf("invalid rate limit warn period: %d (must not be negative)", c.Server.RateLimitWarnPeriod)
+	}
+	if plan := c.Server.RateLimitDefaultPlan; plan != "" {
+		if _, ok := c.Server.RateLimitPlans[plan]; !ok {
+			return fmt.Errorf("invalid rate limit default plan: %s (must be defined in RATE_LIMIT_PLANS)", plan)
+		}
+	}
+	for username, plan := range c.Server.RateLimitUserPlans {
+		if _, ok := c.Server.RateLimitPlans[plan]; !ok {
+			return fmt.Errorf("invalid rate limit plan for user %s: %s (must be defined in RATE_LIMIT_PLANS)", username, plan)
+		}
+	}
 
 	// データベース名の必須チェック
 	if c.Database.Name == "" {
 		return fmt.Errorf("database name is required")
 	}
 
+	// ドライバーの必須チェック（使用できるドライバーとその対応する構成は、接続時に登録済みのドライバーで確認する）
+	// メモリと組み込みのファイルは1プロセス、DynamoDBは1つのテーブルのため、シャーディングには使えない
+	if c.Database.Driver == "" {
+		return fmt.Errorf("database driver is required")
+	}
+	if !c.IsSQLDatabase() && c.IsSharded() {
+		return fmt.Errorf("invalid database driver: %s does not support DB_SHARDS", c.Database.Driver)
+	}
+	// シャーディングはTodoをユーザーごとのシャードに振り分けるため、ユーザーを区別できない場合は全てのTodoが1つのシャードに集まる
+	// アウトボックスはイベントを DB_HOST のデータベースに保存し、シャードのTodoと同じトランザクションで書き込めないため併用できない
+	if c.IsSharded() && !c.IsAuthEnabled() {
+		return fmt.Errorf("invalid DB_SHARDS: sharding routes todos by user and requires user authentication (AUTH_TOKEN_SECRET)")
+	}
+	if c.IsSharded() && c.App.OutboxRelayInterval > 0 {
+		return fmt.Errorf("invalid DB_SHARDS: sharding does not support the outbox (OUTBOX_RELAY_INTERVAL)")
+	}
+	// SQLデータベース以外の保存先はTodo本体だけを保存するため、SQLデータベースが必要な機能を有効にした場合は起動させない
+	if !c.IsSQLDatabase() && c.IsAuthEnabled() {
+		return fmt.Errorf("invalid database driver: %s does not support user authentication (AUTH_TOKEN_SECRET requires a SQL database)", c.Database.Driver)
+	}
+	if !c.IsSQLDatabase() && c.App.OutboxRelayInterval > 0 {
+		return fmt.Errorf("invalid database driver: %s does not support the outbox (OUTBOX_RELAY_INTERVAL requires a SQL database)", c.Database.Driver)
+	}
+	if c.IsDynamoDB() {
+		if c.DynamoDB.Table == "" || c.DynamoDB.Region == "" {
+			return fmt.Errorf("DYNAMODB_TABLE and AWS_REGION are required when DB_DRIVER is dynamodb")
+		}
+		if (c.DynamoDB.AccessKeyID == "") != (c.DynamoDB.SecretAccessKey == "") {
+			return fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set together")
+		}
+		if c.DynamoDB.Endpoint != "" {
+			if u, err := url.Parse(c.DynamoDB.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid DynamoDB endpoint: %s (must be an http or https URL)", c.DynamoDB.Endpoint)
+			}
+		}
+	}
+
+	if c.Database.ReconnectMaxAttempts < 1 {
+		return fmt.Errorf("invalid database reconnect max attempts: %d (must be at least 1)", c.Database.ReconnectMaxAttempts)
+	}
+	if c.Database.ConnectRetryWindow < 0 {
+		return fmt.Errorf("invalid database connect retry window: %d (must not be negative)", c.Database.ConnectRetryWindow)
+	}
+	if c.Database.StatsInterval < 0 {
+		return fmt.Errorf("invalid database stats interval: %d (must not be negative)", c.Database.StatsInterval)
+	}
+	if c.Database.QueryTimeout < 0 {
+		return fmt.Errorf("invalid database query timeout: %d (must not be negative)", c.Database.QueryTimeout)
+	}
+
 	// 環境の値チェック
 	if c.App.Environment != "development" &&
 		c.App.Environment != "production" &&
@@ -144,6 +607,16 @@ func (c *Config) validate() error {
 		return fmt.Errorf("invalid environment: %s (must be development, production, or test)", c.App.Environment)
 	}
 
+	// 通信の記録は認証情報以外の個人データもディスクに残すため、本番環境では使用させない
+	if c.App.RecordTrafficDir != "" && c.App.Environment == "production" {
+		return fmt.Errorf("invalid record traffic dir: traffic recording is not allowed in production")
+	}
+
+	// 契約の検証は全てのレスポンスをバッファリングするため、本番環境では使用させない
+	if c.App.ContractValidation && c.App.Environment == "production" {
+		return fmt.Errorf("invalid contract validation: contract validation is not allowed in production")
+	}
+
 	// ログレベルの値チェック
 	if c.App.LogLevel != "debug" &&
 		c.App.LogLevel != "info" &&
@@ -152,45 +625,195 @@ func (c *Config) validate() error {
 		return fmt.Errorf("invalid log level: %s (must be debug, info, warn, or error)", c.App.LogLevel)
 	}
 
+	// 管理用トークンは推測されにくい長さを必須にする
+	if c.App.AdminToken != "" && len(c.App.AdminToken) < 16 {
+		return fmt.Errorf("invalid admin token: must be at least 16 characters")
+	}
+
+	// 運用向けの Basic 認証はユーザー名とパスワードを揃えて設定し、パスワードは推測されにくい長さを必須にする
+	if (c.App.OpsUsername == "") != (c.App.OpsPassword == "") {
+		return fmt.Errorf("invalid ops basic auth: username and password must be set together")
+	}
+	if c.App.OpsPassword != "" && len(c.App.OpsPassword) < 12 {
+		return fmt.Errorf("invalid ops basic auth password: must be at least 12 characters")
+	}
+
+	// リクエストボディは必ず上限付きで受け付ける
+	if c.Server.MaxBodyBytes < 1 || c.Server.BodyReadTimeout < 1 {
+		return fmt.Errorf("invalid request body limits: max bytes %d, read timeout %d (must be at least 1)", c.Server.MaxBodyBytes, c.Server.BodyReadTimeout)
+	}
+
+	// ベースパスはパス部分のみ（クエリ・フラグメント・空白は不可）
+	if strings.ContainsAny(c.Server.BasePath, "?# ") {
+		return fmt.Errorf("invalid base path: %q (must be a URL path such as /todoapp)", c.Server.BasePath)
+	}
+
+	// ホスト名はパスやスキームを含まず、ワイルドカードは先頭の "*." のみ
+	for _, host := range c.Server.Hosts {
+		name := strings.TrimPrefix(host, "*.")
+		if name == "" || strings.ContainsAny(name, "/*?# ") {
+			return fmt.Errorf("invalid server host: %q (must be a host name such as todo.example.com or *.example.com)", host)
+		}
+	}
+
+	// WebSocket のオリジンはスキーム付き（パスなし）か "*"
+	for _, origin := range c.Server.WebSocketOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+			return fmt.Errorf("invalid websocket origin: %q (must be an origin such as https://app.example.com)", origin)
+		}
+	}
+
+	// 外部呼び出しは必ずタイムアウト付きで行う
+	if c.HTTPClient.Timeout < 1 {
+		return fmt.Errorf("invalid http client timeout: %d (must be at least 1 second)", c.HTTPClient.Timeout)
+	}
+	if c.HTTPClient.MaxRetries < 0 {
+		return fmt.Errorf("invalid http client max retries: %d (must not be negative)", c.HTTPClient.MaxRetries)
+	}
+
+	// レスポンスの日時の形式チェック
+	if c.App.ResponseTimeFormat != "rfc3339" &&
+		c.App.ResponseTimeFormat != "epoch_seconds" &&
+		c.App.ResponseTimeFormat != "epoch_millis" {
+		return fmt.Errorf("invalid response time format: %s (must be rfc3339, epoch_seconds, or epoch_millis)", c.App.ResponseTimeFormat)
+	}
+
+	if c.App.IDObfuscationSalt != "" && (c.App.IDObfuscationMinLength < 0 || c.App.IDObfuscationMinLength > 32) {
+		return fmt.Errorf("invalid id obfuscation min length: %d (must be 0-32)", c.App.IDObfuscationMinLength)
+	}
+
+	if c.App.IdempotencyKeyTTL < 0 {
+		return fmt.Errorf("invalid idempotency key TTL: %d (must not be negative)", c.App.IdempotencyKeyTTL)
+	}
+	if c.App.UndoWindow < 0 {
+		return fmt.Errorf("invalid undo window: %d (must not be negative)", c.App.UndoWindow)
+	}
+
+	if c.App.HealthCheckCacheTTL < 0 || c.App.HealthCheckCacheJitter < 0 {
+		return fmt.Errorf("invalid health check cache: ttl %d, jitter %d (must not be negative)", c.App.HealthCheckCacheTTL, c.App.HealthCheckCacheJitter)
+	}
+
+	if c.App.DeliveryMaxAttempts < 1 {
+		return fmt.Errorf("invalid delivery max attempts: %d (must be at least 1)", c.App.DeliveryMaxAttempts)
+	}
+	if c.App.WebhookDebounceWindow < 0 {
+		return fmt.Errorf("invalid webhook debounce window: %d (must not be negative)", c.App.WebhookDebounceWindow)
+	}
+
+	// 繰り返しワーカーを起動する場合、先行作成期間は1日以上必要
+	if c.App.RecurrenceScanInterval > 0 && c.App.RecurrenceHorizonDays < 1 {
+		return fmt.Errorf("invalid recurrence horizon: %d days (must be at least 1)", c.App.RecurrenceHorizonDays)
+	}
+
+	// 利用状況レポートは送信先が http(s) のURLである場合のみ有効にできる
+	if c.Telemetry.Enabled {
+		endpoint, err := url.Parse(c.Telemetry.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("invalid telemetry endpoint: %q (must be an http or https URL when TELEMETRY_ENABLED is true)", c.Telemetry.Endpoint)
+		}
+		if c.Telemetry.Interval < 60 {
+			return fmt.Errorf("invalid telemetry interval: %d (must be at least 60 seconds)", c.Telemetry.Interval)
+		}
+	}
+
+	// アクセストークンの署名鍵は HS256 のハッシュと同じ長さ以上を必須にする
+	if c.IsAuthEnabled() {
+		if len(c.Auth.TokenSecret) < 32 {
+			return fmt.Errorf("invalid auth token secret: must be at least 32 bytes")
+		}
+		if c.Auth.AccessTokenTTL < 1 || c.Auth.RefreshTokenTTL <= c.Auth.AccessTokenTTL {
+			return fmt.Errorf("invalid auth token TTL: access %d, refresh %d (access must be at least 1 and refresh must be longer than access)", c.Auth.AccessTokenTTL, c.Auth.RefreshTokenTTL)
+		}
+		if c.Auth.InvitationTTL < 1 {
+			return fmt.Errorf("invalid invitation TTL: %d (must be at least 1)", c.Auth.InvitationTTL)
+		}
+		if c.Auth.SessionStore != "off" &&
+			c.Auth.SessionStore != "memory" &&
+			c.Auth.SessionStore != "database" {
+			return fmt.Errorf("invalid session store: %s (must be off, memory, or database)", c.Auth.SessionStore)
+		}
+		if c.Auth.SessionStore != "off" && c.Auth.SessionTTL < 1 {
+			return fmt.Errorf("invalid session TTL: %d (must be at least 1)", c.Auth.SessionTTL)
+		}
+	}
+
 	return nil
 }
 
-// GetDSN はデータベース接続文字列（DSN: Data Source Name）を生成します
-// データベースドライバーに応じて適切な接続文字列を返します
-func (c *Config) GetDSN() string {
-	switch c.Database.Driver {
-	case "mysql":
-		// MySQL用DSN形式: user:password@tcp(host:port)/dbname?parseTime=true
-		return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4",
-			c.Database.User,
-			c.Database.Password,
-			c.Database.Host,
-			c.Database.Port,
-			c.Database.Name,
-		)
-	case "postgres":
-		// PostgreSQL用DSN形式: host=localhost port=5432 user=todouser dbname=todoapp password=todopass sslmode=disable
-		return fmt.Sprintf("host=%s port=%d user=%s dbname=%s password=%s sslmode=%s",
-			c.Database.Host,
-			c.Database.Port,
-			c.Database.User,
-			c.Database.Name,
-			c.Database.Password,
-			c.Database.SSLMode,
-		)
-	case "sqlite":
-		// SQLite用DSN（開発・テスト環境用）
-		return c.Database.Name + ".db"
-	default:
-		// デフォルトはMySQL形式
-		return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4",
-			c.Database.User,
-			c.Database.Password,
-			c.Database.Host,
-			c.Database.Port,
-			c.Database.Name,
-		)
+// normalizeBasePath はベースパスを「先頭の / あり・末尾の / なし」に揃えます
+// "todoapp/"、"/todoapp/" はどちらも "/todoapp" に、"/" や空文字は空文字になります
+func normalizeBasePath(basePath string) string {
+	basePath = strings.Trim(strings.TrimSpace(basePath), "/")
+	if basePath == "" {
+		return ""
 	}
+	return "/" + basePath
+}
+
+// ShardConfigFor は指定シャードに接続するための Config のコピーを返します
+// 接続先（ホスト・ポート・DB名）のみ差し替え、その他の設定は共有します
+func (c *Config) ShardConfigFor(shard ShardConfig) *Config {
+	shardCfg := *c
+	shardCfg.Database.Host = shard.Host
+	shardCfg.Database.Port = shard.Port
+	shardCfg.Database.Name = shard.Database
+	shardCfg.Database.Shards = nil
+	return &shardCfg
+}
+
+// IsSQLite はSQLite（DB_DRIVER=sqlite）を使用するかどうかを判定します
+func (c *Config) IsSQLite() bool {
+	return c.Database.Driver == "sqlite"
+}
+
+// IsMemory はデータベースを使わず、Todoをメモリ上に保存する（DB_DRIVER=memory）かどうかを判定します
+func (c *Config) IsMemory() bool {
+	return c.Database.Driver == "memory"
+}
+
+// IsEmbedded はデータベースの代わりに、Todoをメモリ上に保持して変更をファイルに追記する（DB_DRIVER=embedded）かどうかを判定します
+func (c *Config) IsEmbedded() bool {
+	return c.Database.Driver == "embedded"
+}
+
+// EmbeddedPath は DB_DRIVER=embedded の場合にTodoを保存するファイルのパスです（DB_NAME に .journal を付けたもの）
+func (c *Config) EmbeddedPath() string {
+	return c.Database.Name + ".journal"
+}
+
+// IsDynamoDB はデータベースの代わりに Amazon DynamoDB にTodoを保存する（DB_DRIVER=dynamodb）かどうかを判定します
+func (c *Config) IsDynamoDB() bool {
+	return c.Database.Driver == "dynamodb"
+}
+
+// IsSQLDatabase はSQLデータベース（登録済みのドライバー）に保存するかどうかを判定します
+// false の場合（memory・embedded・dynamodb）はTodo本体だけを保存し、SQLデータベースが必要な機能は使用できません
+func (c *Config) IsSQLDatabase() bool {
+	return !c.IsMemory() && !c.IsEmbedded() && !c.IsDynamoDB()
+}
+
+// IsAuthEnabled はユーザー認証が有効かどうかを判定します
+func (c *Config) IsAuthEnabled() bool {
+	return c.Auth.TokenSecret != ""
+}
+
+// IsOpsAuthEnabled は運用向けエンドポイントの Basic 認証が設定されているかを判定します
+func (c *Config) IsOpsAuthEnabled() bool {
+	return c.App.OpsUsername != ""
+}
+
+// IsSessionEnabled はCookieのセッションによるログインが有効かどうかを判定します（ユーザー認証が有効な場合のみ）
+func (c *Config) IsSessionEnabled() bool {
+	return c.IsAuthEnabled() && c.Auth.SessionStore != "off"
+}
+
+// IsSharded はシャーディングが有効かどうかを判定します
+func (c *Config) IsSharded() bool {
+	return len(c.Database.Shards) > 0
 }
 
 // IsProduction は本番環境かどうかを判定します
@@ -228,6 +851,94 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsSlice は環境変数をカンマ区切りの文字列スライスとして取得します
+// 各要素の前後の空白は除去され、空要素は無視されます
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// parseShards は "host:port/name" 形式の文字列をShardConfigに変換します
+// ポートが省略された場合は defaultPort を使用します
+func parseShards(specs []string, defaultPort int) ([]ShardConfig, error) {
+	shards := make([]ShardConfig, 0, len(specs))
+	for _, spec := range specs {
+		hostPort, dbName, found := strings.Cut(spec, "/")
+		if !found || hostPort == "" || dbName == "" {
+			return nil, fmt.Errorf("invalid shard spec: %s (must be host:port/name)", spec)
+		}
+
+		host, portStr, hasPort := strings.Cut(hostPort, ":")
+		port := defaultPort
+		if hasPort {
+			p, err := strconv.Atoi(portStr)
+			if err != nil || p < 1 || p > 65535 {
+				return nil, fmt.Errorf("invalid shard port: %s", spec)
+			}
+			port = p
+		}
+
+		shards = append(shards, ShardConfig{
+			Name:     spec,
+			Host:     host,
+			Port:     port,
+			Database: dbName,
+		})
+	}
+	return shards, nil
+}
+
+// parseRateLimitPlans は "name:requests_per_minute[:burst]" 形式の文字列をプランごとの上限に変換します
+// requests_per_minute が 0 のプランは制限しません
+func parseRateLimitPlans(specs []string) (map[string]RateLimitPlan, error) {
+	plans := make(map[string]RateLimitPlan, len(specs))
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid rate limit plan: %s (must be name:requests_per_minute[:burst])", spec)
+		}
+
+		var plan RateLimitPlan
+		rpm, err := strconv.Atoi(parts[1])
+		if err != nil || rpm < 0 {
+			return nil, fmt.Errorf("invalid rate limit plan requests per minute: %s", spec)
+		}
+		plan.RequestsPerMinute = rpm
+		if len(parts) == 3 {
+			burst, err := strconv.Atoi(parts[2])
+			if err != nil || burst < 1 {
+				return nil, fmt.Errorf("invalid rate limit plan burst: %s", spec)
+			}
+			plan.Burst = burst
+		}
+		plans[parts[0]] = plan
+	}
+	return plans, nil
+}
+
+// parseRateLimitUserPlans は "username:plan" 形式の文字列をユーザー名ごとのプラン名に変換します
+func parseRateLimitUserPlans(specs []string) (map[string]string, error) {
+	userPlans := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		username, plan, found := strings.Cut(spec, ":")
+		if !found || username == "" || plan == "" {
+			return nil, fmt.Errorf("invalid rate limit user plan: %s (must be username:plan)", spec)
+		}
+		userPlans[username] = plan
+	}
+	return userPlans, nil
+}
+
 // getEnvAsBool は環境変数をbool値として取得します（将来の拡張用）
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,97 @@
+// Package graphql はGraphQLのクエリ言語の解析と実行を、標準パッケージだけで実装した最小限のエンジンです
+//
+// 対応している機能：
+//   - クエリ（query）とミューテーション（mutation）、操作名と変数（$id: ID!）
+//   - フィールドの引数・エイリアス・入れ子の選択、名前付きフラグメントとインラインフラグメント
+//   - @include(if:) / @skip(if:) ディレクティブ、__typename
+//
+// サブスクリプションとイントロスペクション（__schema）には対応していません
+package graphql
+
+// Document は解析したGraphQLの文書です
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation はクエリまたはミューテーションの1つの操作です
+type Operation struct {
+	// Kind は操作の種類です（"query"、"mutation"、"subscription"）
+	Kind         string
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+	Location     Location
+}
+
+// VariableDefinition は操作の変数の宣言です（$id: ID! = 1）
+type VariableDefinition struct {
+	Name     string
+	Type     string
+	Default  any
+	Location Location
+}
+
+// Selection は選択セットの要素（Field・FragmentSpread・InlineFragment）です
+type Selection interface {
+	selection()
+}
+
+// Field は選択したフィールドです
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    map[string]any
+	Directives   []*Directive
+	SelectionSet []Selection
+	Location     Location
+}
+
+// FragmentSpread は名前付きフラグメントの展開（...TodoFields）です
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Location   Location
+}
+
+// InlineFragment はインラインフラグメント（... on Todo { ... }）です
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Location      Location
+}
+
+// Fragment は名前付きフラグメントの定義です
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+	Location      Location
+}
+
+// Directive はフィールドやフラグメントに付けるディレクティブ（@include(if: $x)）です
+type Directive struct {
+	Name      string
+	Arguments map[string]any
+	Location  Location
+}
+
+// Variable は値の中で参照された変数（$id）です
+// 引数の値は int・float64・string・bool・nil・EnumValue・[]any・map[string]any・Variable のいずれかです
+type Variable string
+
+// EnumValue は列挙型の値（引用符のない名前）です
+type EnumValue string
+
+// ResponseKey は結果のJSONのキー（エイリアスがあればエイリアス）を返します
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+func (*Field) selection()          {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
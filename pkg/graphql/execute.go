@@ -0,0 +1,500 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Request はGraphQLのリクエスト（POST の本文、GET のクエリパラメータ）です
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response はGraphQLのレスポンスです
+// 解析・検証・変数のエラー（リクエストのエラー）の場合は Data が nil になり、実行されません
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error はGraphQLのエラーです（位置と、実行時のエラーの場合は結果の中のパスを含む）
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ExecuteOption は Execute に任意の制限を設定する関数型オプションです
+type ExecuteOption func(*executeOptions)
+
+type executeOptions struct {
+	queryOnly bool
+}
+
+// QueryOnly はミューテーションをリクエストのエラーにします
+// 副作用が許されない GET リクエストの場合に使います
+func QueryOnly() ExecuteOption {
+	return func(o *executeOptions) {
+		o.queryOnly = true
+	}
+}
+
+// Execute はリクエストを解析・検証し、スキーマのリゾルバーで実行します
+//
+// 学習ポイント（GraphQLの実行の流れ）：
+//  1. 解析: クエリの文字列を構文木（Document）にする
+//  2. 検証: 選択したフィールドと引数がスキーマに存在するかを、実行の前にまとめて確認する
+//  3. 実行: ルートの型から選択セットをたどり、フィールドごとにリゾルバーを呼び出す
+//     クライアントが選択したフィールドだけがレスポンスに含まれる
+//
+// リゾルバーのエラーはそのフィールドを null にしてエラーに記録し、他のフィールドの実行は続けます
+// ミューテーションのルートのフィールドは、書かれた順に1つずつ実行します
+func (s *Schema) Execute(ctx context.Context, req Request, opts ...ExecuteOption) *Response {
+	var options executeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	var root *Object
+	switch op.Kind {
+	case "query":
+		root = s.Query
+	case "mutation":
+		if options.queryOnly {
+			return requestError(op.Location, "Mutations are not allowed for this request; use POST")
+		}
+		if s.Mutation == nil {
+			return requestError(op.Location, "Schema is not configured for mutations")
+		}
+		root = s.Mutation
+	default:
+		return requestError(op.Location, "%s operations are not supported", op.Kind)
+	}
+
+	e := &execution{doc: doc}
+	e.validateVariables(op)
+	e.validateSelectionSet(root, op.SelectionSet, make(map[string]bool))
+	if len(e.errors) > 0 {
+		return &Response{Errors: e.errors}
+	}
+	variables, errs := coerceVariables(op, req.Variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+	e.variables = variables
+
+	data := e.executeSelectionSet(ctx, root, nil, op.SelectionSet, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+func requestError(loc Location, format string, args ...any) *Response {
+	return &Response{Errors: []*Error{{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}}}}
+}
+
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
+
+// selectOperation は実行する操作を選びます（複数ある場合は operationName が必要）
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations"}
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q", name)}
+}
+
+// coerceVariables は変数の値を宣言された型に変換し、省略された変数には既定値を設定します
+func coerceVariables(op *Operation, values map[string]any) (map[string]any, []*Error) {
+	coerced := make(map[string]any, len(op.Variables))
+	var errs []*Error
+	for _, def := range op.Variables {
+		value, provided := values[def.Name]
+		if !provided {
+			if def.Default == nil {
+				if strings.HasSuffix(def.Type, "!") {
+					errs = append(errs, &Error{Message: fmt.Sprintf("Variable \"$%s\" of required type %q was not provided", def.Name, def.Type), Locations: []Location{def.Location}})
+				}
+				continue
+			}
+			value = def.Default
+		}
+		c, err := coerceValue(def.Type, value)
+		if err != nil {
+			errs = append(errs, &Error{Message: fmt.Sprintf("Variable \"$%s\" got invalid value: %v", def.Name, err), Locations: []Location{def.Location}})
+			continue
+		}
+		coerced[def.Name] = c
+	}
+	return coerced, errs
+}
+
+// execution は1回の実行の状態です
+type execution struct {
+	doc       *Document
+	variables map[string]any
+	errors    []*Error
+
+	// defined は操作で宣言された変数です（検証で使う）
+	defined map[string]bool
+}
+
+func (e *execution) addError(message string, loc Location, path []any) {
+	e.errors = append(e.errors, &Error{Message: message, Locations: []Location{loc}, Path: path})
+}
+
+func (e *execution) validateVariables(op *Operation) {
+	e.defined = make(map[string]bool, len(op.Variables))
+	for _, def := range op.Variables {
+		if e.defined[def.Name] {
+			e.addError(fmt.Sprintf("There can be only one variable named \"$%s\"", def.Name), def.Location, nil)
+		}
+		e.defined[def.Name] = true
+	}
+}
+
+// validateSelectionSet は選択セットのフィールド・引数・フラグメントがスキーマに合っているかを検証します
+// spreading は展開中のフラグメントです（フラグメントの循環を検出する）
+func (e *execution) validateSelectionSet(obj *Object, selections []Selection, spreading map[string]bool) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *Field:
+			e.validateField(obj, sel, spreading)
+		case *FragmentSpread:
+			e.validateDirectives(sel.Directives)
+			fragment, ok := e.doc.Fragments[sel.Name]
+			if !ok {
+				e.addError(fmt.Sprintf("Unknown fragment %q", sel.Name), sel.Location, nil)
+				continue
+			}
+			if spreading[sel.Name] {
+				e.addError(fmt.Sprintf("Cannot spread fragment %q within itself", sel.Name), sel.Location, nil)
+				continue
+			}
+			if fragment.TypeCondition == obj.Name {
+				spreading[sel.Name] = true
+				e.validateSelectionSet(obj, fragment.SelectionSet, spreading)
+				delete(spreading, sel.Name)
+			}
+		case *InlineFragment:
+			e.validateDirectives(sel.Directives)
+			if sel.TypeCondition == "" || sel.TypeCondition == obj.Name {
+				e.validateSelectionSet(obj, sel.SelectionSet, spreading)
+			}
+		}
+	}
+}
+
+func (e *execution) validateField(obj *Object, field *Field, spreading map[string]bool) {
+	e.validateDirectives(field.Directives)
+	if field.Name == "__typename" {
+		if field.SelectionSet != nil {
+			e.addError("Field \"__typename\" must not have a selection since it is a scalar", field.Location, nil)
+		}
+		return
+	}
+
+	def, ok := obj.Fields[field.Name]
+	if !ok {
+		e.addError(fmt.Sprintf("Cannot query field %q on type %q", field.Name, obj.Name), field.Location, nil)
+		return
+	}
+
+	for name, value := range field.Arguments {
+		if !slices.ContainsFunc(def.Args, func(arg Arg) bool { return arg.Name == name }) {
+			e.addError(fmt.Sprintf("Unknown argument %q on field \"%s.%s\"", name, obj.Name, field.Name), field.Location, nil)
+		}
+		e.validateValue(value, field.Location)
+	}
+	for _, arg := range def.Args {
+		if _, provided := field.Arguments[arg.Name]; !provided && arg.Default == nil && strings.HasSuffix(arg.Type, "!") {
+			e.addError(fmt.Sprintf("Field %q argument %q of type %q is required, but it was not provided", field.Name, arg.Name, arg.Type), field.Location, nil)
+		}
+	}
+
+	switch {
+	case def.Type != nil && field.SelectionSet == nil:
+		e.addError(fmt.Sprintf("Field %q of type %q must have a selection of subfields", field.Name, def.Type.Name), field.Location, nil)
+	case def.Type == nil && field.SelectionSet != nil:
+		e.addError(fmt.Sprintf("Field %q must not have a selection since it is a scalar", field.Name), field.Location, nil)
+	case def.Type != nil:
+		e.validateSelectionSet(def.Type, field.SelectionSet, spreading)
+	}
+}
+
+func (e *execution) validateDirectives(directives []*Directive) {
+	for _, d := range directives {
+		if d.Name != "include" && d.Name != "skip" {
+			e.addError(fmt.Sprintf("Unknown directive \"@%s\"", d.Name), d.Location, nil)
+			continue
+		}
+		if _, ok := d.Arguments["if"]; !ok {
+			e.addError(fmt.Sprintf("Directive \"@%s\" argument \"if\" of type \"Boolean!\" is required", d.Name), d.Location, nil)
+		}
+		for _, value := range d.Arguments {
+			e.validateValue(value, d.Location)
+		}
+	}
+}
+
+// validateValue は値の中で参照された変数が宣言されているかを検証します
+func (e *execution) validateValue(value any, loc Location) {
+	switch v := value.(type) {
+	case Variable:
+		if !e.defined[string(v)] {
+			e.addError(fmt.Sprintf("Variable \"$%s\" is not defined", v), loc, nil)
+		}
+	case []any:
+		for _, item := range v {
+			e.validateValue(item, loc)
+		}
+	case map[string]any:
+		for _, item := range v {
+			e.validateValue(item, loc)
+		}
+	}
+}
+
+// fieldGroup は同じ結果のキーに選択されたフィールドの集まりです（フラグメントで重複して選択された場合など）
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+// collectFields はディレクティブとフラグメントを展開し、選択されたフィールドを結果のキーごとにまとめます
+func (e *execution) collectFields(obj *Object, selections []Selection, groups []*fieldGroup, visited map[string]bool) []*fieldGroup {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *Field:
+			if !e.included(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			found := false
+			for _, g := range groups {
+				if g.key == key {
+					g.fields = append(g.fields, sel)
+					found = true
+					break
+				}
+			}
+			if !found {
+				groups = append(groups, &fieldGroup{key: key, fields: []*Field{sel}})
+			}
+		case *FragmentSpread:
+			fragment := e.doc.Fragments[sel.Name]
+			if visited[sel.Name] || !e.included(sel.Directives) || fragment.TypeCondition != obj.Name {
+				continue
+			}
+			visited[sel.Name] = true
+			groups = e.collectFields(obj, fragment.SelectionSet, groups, visited)
+		case *InlineFragment:
+			if !e.included(sel.Directives) || (sel.TypeCondition != "" && sel.TypeCondition != obj.Name) {
+				continue
+			}
+			groups = e.collectFields(obj, sel.SelectionSet, groups, visited)
+		}
+	}
+	return groups
+}
+
+// included は @skip / @include ディレクティブを評価します
+func (e *execution) included(directives []*Directive) bool {
+	for _, d := range directives {
+		value, _ := e.resolveValue(d.Arguments["if"]).(bool)
+		if (d.Name == "skip" && value) || (d.Name == "include" && !value) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveValue は値の中の変数を、変数の値に置き換えます
+func (e *execution) resolveValue(value any) any {
+	switch v := value.(type) {
+	case Variable:
+		return e.variables[string(v)]
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			resolved[i] = e.resolveValue(item)
+		}
+		return resolved
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved[key] = e.resolveValue(item)
+		}
+		return resolved
+	}
+	return value
+}
+
+// executeSelectionSet は source に対して選択セットを実行し、選択した順のオブジェクトを返します
+func (e *execution) executeSelectionSet(ctx context.Context, obj *Object, source any, selections []Selection, path []any) *orderedMap {
+	groups := e.collectFields(obj, selections, nil, make(map[string]bool))
+	result := &orderedMap{values: make(map[string]any, len(groups))}
+	for _, g := range groups {
+		result.keys = append(result.keys, g.key)
+		result.values[g.key] = e.executeField(ctx, obj, source, g, appendPath(path, g.key))
+	}
+	return result
+}
+
+// executeField は1つのフィールドのリゾルバーを呼び出し、結果を選択セットに従って完成させます
+func (e *execution) executeField(ctx context.Context, obj *Object, source any, g *fieldGroup, path []any) any {
+	field := g.fields[0]
+	if field.Name == "__typename" {
+		return obj.Name
+	}
+	def := obj.Fields[field.Name]
+
+	args, err := e.coerceArguments(def, field)
+	if err != nil {
+		e.addError(err.Error(), field.Location, path)
+		return nil
+	}
+
+	value, err := resolve(ctx, def.Resolve, source, args)
+	if err != nil {
+		e.addError(err.Error(), field.Location, path)
+		return nil
+	}
+	if def.Type == nil || isNil(value) {
+		if isNil(value) {
+			return nil
+		}
+		return value
+	}
+
+	var subSelections []Selection
+	for _, f := range g.fields {
+		subSelections = append(subSelections, f.SelectionSet...)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		items := make([]any, rv.Len())
+		for i := range items {
+			item := rv.Index(i).Interface()
+			if isNil(item) {
+				continue
+			}
+			items[i] = e.executeSelectionSet(ctx, def.Type, item, subSelections, appendPath(path, i))
+		}
+		return items
+	}
+	return e.executeSelectionSet(ctx, def.Type, value, subSelections, path)
+}
+
+// coerceArguments は引数の変数を解決し、定義された型に変換します
+func (e *execution) coerceArguments(def *FieldDef, field *Field) (Args, error) {
+	args := make(Args, len(def.Args))
+	for _, arg := range def.Args {
+		raw, provided := field.Arguments[arg.Name]
+		if v, ok := raw.(Variable); ok {
+			_, provided = e.variables[string(v)]
+		}
+		value := e.resolveValue(raw)
+		if !provided {
+			if arg.Default == nil {
+				if strings.HasSuffix(arg.Type, "!") {
+					return nil, fmt.Errorf("Argument %q of required type %q was not provided", arg.Name, arg.Type)
+				}
+				continue
+			}
+			value = arg.Default
+		}
+		c, err := coerceValue(arg.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("Argument %q has invalid value: %v", arg.Name, err)
+		}
+		args[arg.Name] = c
+	}
+	return args, nil
+}
+
+// resolve はリゾルバーを呼び出します（パニックはそのフィールドのエラーとして扱う）
+func resolve(ctx context.Context, fn ResolveFunc, source any, args Args) (value any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("GraphQL resolver panicked: %v", r)
+			value, err = nil, fmt.Errorf("internal error")
+		}
+	}()
+	return fn(ctx, source, args)
+}
+
+// isNil は nil、または nil のポインタ・スライス・マップかどうかを判定します
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func appendPath(path []any, element any) []any {
+	return append(path[:len(path):len(path)], element)
+}
+
+// orderedMap は選択した順にキーを出力するJSONのオブジェクトです
+// （GraphQLのレスポンスはクエリに書かれた順にフィールドを返す）
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// MarshalJSON は選択した順にキーを出力します
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,249 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testBook はテスト用のスキーマで返す値です
+type testBook struct {
+	ID    int
+	Title string
+	Tags  []string
+}
+
+// newTestSchema は本の一覧と追加を持つテスト用のスキーマを作成します
+func newTestSchema() *Schema {
+	books := []*testBook{
+		{ID: 1, Title: "Go言語入門", Tags: []string{"go"}},
+		{ID: 2, Title: "GraphQL実践", Tags: []string{"graphql", "api"}},
+	}
+
+	book := &Object{Name: "Book", Fields: map[string]*FieldDef{
+		"id":    {Resolve: func(ctx context.Context, source any, args Args) (any, error) { return source.(*testBook).ID, nil }},
+		"title": {Resolve: func(ctx context.Context, source any, args Args) (any, error) { return source.(*testBook).Title, nil }},
+		"tags":  {Resolve: func(ctx context.Context, source any, args Args) (any, error) { return source.(*testBook).Tags, nil }},
+		"broken": {Resolve: func(ctx context.Context, source any, args Args) (any, error) {
+			return nil, errors.New("broken field")
+		}},
+	}}
+	book.Fields["related"] = &FieldDef{Type: book, Resolve: func(ctx context.Context, source any, args Args) (any, error) {
+		if source.(*testBook).ID == 1 {
+			return books[1], nil
+		}
+		return (*testBook)(nil), nil
+	}}
+
+	return &Schema{
+		Query: &Object{Name: "Query", Fields: map[string]*FieldDef{
+			"books": {
+				Type: book,
+				Args: []Arg{{Name: "first", Type: "Int", Default: 10}, {Name: "tags", Type: "[String!]"}},
+				Resolve: func(ctx context.Context, source any, args Args) (any, error) {
+					first, _ := args.Int("first")
+					var result []*testBook
+					for _, b := range books {
+						if tags, ok := args["tags"].([]any); ok && !hasAnyTag(b, tags) {
+							continue
+						}
+						result = append(result, b)
+					}
+					return result[:min(first, len(result))], nil
+				},
+			},
+			"book": {
+				Type: book,
+				Args: []Arg{{Name: "id", Type: "ID!"}},
+				Resolve: func(ctx context.Context, source any, args Args) (any, error) {
+					id, _ := args.String("id")
+					for _, b := range books {
+						if id == "1" && b.ID == 1 || id == "2" && b.ID == 2 {
+							return b, nil
+						}
+					}
+					return nil, nil
+				},
+			},
+		}},
+		Mutation: &Object{Name: "Mutation", Fields: map[string]*FieldDef{
+			"addBook": {
+				Type: book,
+				Args: []Arg{{Name: "input", Type: "BookInput!"}},
+				Resolve: func(ctx context.Context, source any, args Args) (any, error) {
+					input, _ := args.Object("input")
+					title, _ := input["title"].(string)
+					b := &testBook{ID: len(books) + 1, Title: title}
+					books = append(books, b)
+					return b, nil
+				},
+			},
+		}},
+	}
+}
+
+func hasAnyTag(b *testBook, tags []any) bool {
+	for _, tag := range tags {
+		for _, t := range b.Tags {
+			if t == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// responseJSON はレスポンスをJSONの文字列にします
+func responseJSON(t *testing.T, resp *Response) string {
+	t.Helper()
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("レスポンスのJSONへの変換に失敗: %v", err)
+	}
+	return string(data)
+}
+
+// TestSchema_Execute はクエリとミューテーションの実行結果をテストします
+func TestSchema_Execute(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   Request
+		opts      []ExecuteOption
+		expected  string
+		requestOK bool
+	}{
+		{
+			name:      "選択したフィールドだけを選択した順に返す",
+			request:   Request{Query: `{ books { title id } }`},
+			expected:  `{"data":{"books":[{"title":"Go言語入門","id":1},{"title":"GraphQL実践","id":2}]}}`,
+			requestOK: true,
+		},
+		{
+			name:      "エイリアスと引数",
+			request:   Request{Query: `query { first: books(first: 1) { title } api: books(tags: "api") { id } }`},
+			expected:  `{"data":{"first":[{"title":"Go言語入門"}],"api":[{"id":2}]}}`,
+			requestOK: true,
+		},
+		{
+			name:      "変数と既定値",
+			request:   Request{Query: `query Get($id: ID!, $n: Int = 1) { book(id: $id) { title } books(first: $n) { id } }`, Variables: map[string]any{"id": json.Number("2")}},
+			expected:  `{"data":{"book":{"title":"GraphQL実践"},"books":[{"id":1}]}}`,
+			requestOK: true,
+		},
+		{
+			name: "フラグメントとディレクティブと __typename",
+			request: Request{Query: `
+				query ($withTags: Boolean!) {
+					book(id: "1") { ...BookFields ... on Book { tags @include(if: $withTags) } related { __typename title } }
+				}
+				fragment BookFields on Book { id title @skip(if: true) }`, Variables: map[string]any{"withTags": true}},
+			expected:  `{"data":{"book":{"id":1,"tags":["go"],"related":{"__typename":"Book","title":"GraphQL実践"}}}}`,
+			requestOK: true,
+		},
+		{
+			name:      "リゾルバーのエラーはそのフィールドだけ null にする",
+			request:   Request{Query: `{ book(id: "2") { title broken related { id } } }`},
+			expected:  `{"data":{"book":{"title":"GraphQL実践","broken":null,"related":null}},"errors":[{"message":"broken field","locations":[{"line":1,"column":25}],"path":["book","broken"]}]}`,
+			requestOK: true,
+		},
+		{
+			name:      "ミューテーション",
+			request:   Request{Query: `mutation { addBook(input: {title: "Go実践"}) { id title } }`},
+			expected:  `{"data":{"addBook":{"id":3,"title":"Go実践"}}}`,
+			requestOK: true,
+		},
+		{
+			name:     "GETではミューテーションを実行しない",
+			request:  Request{Query: `mutation { addBook(input: {title: "x"}) { id } }`},
+			opts:     []ExecuteOption{QueryOnly()},
+			expected: `{"errors":[{"message":"Mutations are not allowed for this request; use POST","locations":[{"line":1,"column":1}]}]}`,
+		},
+		{
+			name:     "構文エラー",
+			request:  Request{Query: "{\n  books { id "},
+			expected: `{"errors":[{"message":"Syntax Error: Expected Name, found end of input","locations":[{"line":2,"column":14}]}]}`,
+		},
+		{
+			name:     "存在しないフィールド",
+			request:  Request{Query: `{ books { isbn } }`},
+			expected: `{"errors":[{"message":"Cannot query field \"isbn\" on type \"Book\"","locations":[{"line":1,"column":11}]}]}`,
+		},
+		{
+			name:     "必須の引数の省略",
+			request:  Request{Query: `{ book { id } }`},
+			expected: `{"errors":[{"message":"Field \"book\" argument \"id\" of type \"ID!\" is required, but it was not provided","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:     "オブジェクトの選択セットの省略",
+			request:  Request{Query: `{ books }`},
+			expected: `{"errors":[{"message":"Field \"books\" of type \"Book\" must have a selection of subfields","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:     "必須の変数の省略",
+			request:  Request{Query: `query ($id: ID!) { book(id: $id) { id } }`},
+			expected: `{"errors":[{"message":"Variable \"$id\" of required type \"ID!\" was not provided","locations":[{"line":1,"column":8}]}]}`,
+		},
+		{
+			name:     "複数の操作で操作名の省略",
+			request:  Request{Query: `query A { books { id } } query B { books { title } }`},
+			expected: `{"errors":[{"message":"Must provide operation name if query contains multiple operations"}]}`,
+		},
+		{
+			name:      "型の合わない引数",
+			request:   Request{Query: `{ books(first: "two") { id } }`},
+			expected:  `{"data":{"books":null},"errors":[{"message":"Argument \"first\" has invalid value: Int cannot represent value \"two\"","locations":[{"line":1,"column":3}],"path":["books"]}]}`,
+			requestOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := newTestSchema().Execute(context.Background(), tt.request, tt.opts...)
+			if got := responseJSON(t, resp); got != tt.expected {
+				t.Errorf("レスポンス =\n%s\n期待値 =\n%s", got, tt.expected)
+			}
+			if (resp.Data != nil) != tt.requestOK {
+				t.Errorf("実行されたかどうか = %v, 期待値 = %v", resp.Data != nil, tt.requestOK)
+			}
+		})
+	}
+}
+
+// TestParse は文字列のエスケープ・ブロック文字列・コメントの解析と、構文エラーの位置をテストします
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# コメント
+		query Q {
+			a: f(s: "改行\n\"引用\"A", b: """
+				1行目
+				  2行目
+			""", list: [1, -2.5e1, RED, null, {k: $v}])
+		}`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	field := doc.Operations[0].SelectionSet[0].(*Field)
+	if field.Alias != "a" || field.Name != "f" {
+		t.Errorf("エイリアス・名前 = %q, %q", field.Alias, field.Name)
+	}
+	if s := field.Arguments["s"]; s != "改行\n\"引用\"A" {
+		t.Errorf("文字列 = %q", s)
+	}
+	if b := field.Arguments["b"]; b != "1行目\n  2行目" {
+		t.Errorf("ブロック文字列 = %q", b)
+	}
+	list := field.Arguments["list"].([]any)
+	if list[0] != 1 || list[1] != -25.0 || list[2] != EnumValue("RED") || list[3] != nil || list[4].(map[string]any)["k"] != Variable("v") {
+		t.Errorf("リスト = %#v", list)
+	}
+
+	for _, src := range []string{`{ a(x: "unterminated) }`, `{ a } }`, `query { }`, `{ a(x: 01x) }`, `fragment on on T { a } { a }`} {
+		_, err := Parse(src)
+		var gqlErr *Error
+		if !errors.As(err, &gqlErr) || !strings.HasPrefix(gqlErr.Message, "Syntax Error") || len(gqlErr.Locations) != 1 {
+			t.Errorf("Parse(%q) error = %v, 位置付きの構文エラーを期待", src, err)
+		}
+	}
+}
@@ -0,0 +1,519 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Location はエラーの位置（1から始まる行と列）です
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// tokenKind は字句の種類です
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token は1つの字句です（tokenString の value はエスケープを解除した文字列）
+type token struct {
+	kind     tokenKind
+	value    string
+	location Location
+}
+
+// lexer はGraphQLの文書を字句に分割します
+type lexer struct {
+	src       string
+	pos       int
+	line      int
+	lineStart int
+}
+
+// Parse はGraphQLの文書を解析します
+// 構文エラーは *Error（位置付き）として返します
+func Parse(src string) (doc *Document, err error) {
+	p := &parser{lexer: &lexer{src: src, line: 1}}
+	defer func() {
+		if r := recover(); r != nil {
+			syntaxErr, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, syntaxErr
+		}
+	}()
+	p.advance()
+	return p.parseDocument(), nil
+}
+
+// parser は再帰下降でGraphQLの文書を解析します
+// 構文エラーは panic(*Error) で呼び出し元の Parse まで戻します（深い再帰でもエラー処理を簡潔にするため）
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+func (p *parser) fail(loc Location, format string, args ...any) {
+	panic(&Error{Message: "Syntax Error: " + fmt.Sprintf(format, args...), Locations: []Location{loc}})
+}
+
+func (p *parser) advance() {
+	tok, err := p.lexer.next()
+	if err != nil {
+		panic(err)
+	}
+	p.tok = tok
+}
+
+// peek は現在の字句が指定した記号または名前かどうかを返します
+func (p *parser) peek(value string) bool {
+	return (p.tok.kind == tokenPunct || p.tok.kind == tokenName) && p.tok.value == value
+}
+
+// skip は現在の字句が value なら読み進めて true を返します
+func (p *parser) skip(value string) bool {
+	if p.peek(value) {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(value string) Location {
+	loc := p.tok.location
+	if !p.skip(value) {
+		p.fail(loc, "Expected %q, found %s", value, p.describe())
+	}
+	return loc
+}
+
+func (p *parser) expectName() (string, Location) {
+	tok := p.tok
+	if tok.kind != tokenName {
+		p.fail(tok.location, "Expected Name, found %s", p.describe())
+	}
+	p.advance()
+	return tok.value, tok.location
+}
+
+// describe はエラーメッセージ用に現在の字句を表します
+func (p *parser) describe() string {
+	switch p.tok.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return strconv.Quote(p.tok.value)
+	default:
+		return fmt.Sprintf("%q", p.tok.value)
+	}
+}
+
+func (p *parser) parseDocument() *Document {
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			doc.Operations = append(doc.Operations, &Operation{Kind: "query", Location: p.tok.location, SelectionSet: p.parseSelectionSet()})
+		case p.peek("query"), p.peek("mutation"), p.peek("subscription"):
+			doc.Operations = append(doc.Operations, p.parseOperation())
+		case p.peek("fragment"):
+			fragment := p.parseFragment()
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				p.fail(fragment.Location, "There can be only one fragment named %q", fragment.Name)
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			p.fail(p.tok.location, "Unexpected %s", p.describe())
+		}
+	}
+	if len(doc.Operations) == 0 {
+		p.fail(p.tok.location, "Document must contain an operation")
+	}
+	return doc
+}
+
+func (p *parser) parseOperation() *Operation {
+	op := &Operation{Kind: p.tok.value, Location: p.tok.location}
+	p.advance()
+	if p.tok.kind == tokenName {
+		op.Name, _ = p.expectName()
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			v := &VariableDefinition{Location: p.expect("$")}
+			v.Name, _ = p.expectName()
+			p.expect(":")
+			v.Type = p.parseType()
+			if p.skip("=") {
+				v.Default = p.parseValue(true)
+			}
+			op.Variables = append(op.Variables, v)
+		}
+	}
+	p.parseDirectives()
+	op.SelectionSet = p.parseSelectionSet()
+	return op
+}
+
+// parseType は変数の型（ID!、[String!]）を文字列として読み取ります
+func (p *parser) parseType() string {
+	var typ string
+	if p.skip("[") {
+		typ = "[" + p.parseType() + "]"
+		p.expect("]")
+	} else {
+		typ, _ = p.expectName()
+	}
+	if p.skip("!") {
+		typ += "!"
+	}
+	return typ
+}
+
+func (p *parser) parseFragment() *Fragment {
+	loc := p.expect("fragment")
+	name, _ := p.expectName()
+	if name == "on" {
+		p.fail(loc, "Fragment cannot be named \"on\"")
+	}
+	p.expect("on")
+	typeCondition, _ := p.expectName()
+	p.parseDirectives()
+	return &Fragment{Name: name, TypeCondition: typeCondition, SelectionSet: p.parseSelectionSet(), Location: loc}
+}
+
+func (p *parser) parseSelectionSet() []Selection {
+	p.expect("{")
+	var selections []Selection
+	for !p.skip("}") {
+		selections = append(selections, p.parseSelection())
+	}
+	if len(selections) == 0 {
+		p.fail(p.tok.location, "Selection set must not be empty")
+	}
+	return selections
+}
+
+func (p *parser) parseSelection() Selection {
+	if p.peek("...") {
+		loc := p.tok.location
+		p.advance()
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			name, _ := p.expectName()
+			return &FragmentSpread{Name: name, Directives: p.parseDirectives(), Location: loc}
+		}
+		fragment := &InlineFragment{Location: loc}
+		if p.skip("on") {
+			fragment.TypeCondition, _ = p.expectName()
+		}
+		fragment.Directives = p.parseDirectives()
+		fragment.SelectionSet = p.parseSelectionSet()
+		return fragment
+	}
+
+	field := &Field{}
+	field.Name, field.Location = p.expectName()
+	if p.skip(":") {
+		field.Alias = field.Name
+		field.Name, _ = p.expectName()
+	}
+	field.Arguments = p.parseArguments(false)
+	field.Directives = p.parseDirectives()
+	if p.peek("{") {
+		field.SelectionSet = p.parseSelectionSet()
+	}
+	return field
+}
+
+func (p *parser) parseArguments(constant bool) map[string]any {
+	if !p.skip("(") {
+		return nil
+	}
+	args := make(map[string]any)
+	for !p.skip(")") {
+		name, loc := p.expectName()
+		if _, ok := args[name]; ok {
+			p.fail(loc, "There can be only one argument named %q", name)
+		}
+		p.expect(":")
+		args[name] = p.parseValue(constant)
+	}
+	return args
+}
+
+func (p *parser) parseDirectives() []*Directive {
+	var directives []*Directive
+	for p.peek("@") {
+		loc := p.tok.location
+		p.advance()
+		name, _ := p.expectName()
+		directives = append(directives, &Directive{Name: name, Arguments: p.parseArguments(false), Location: loc})
+	}
+	return directives
+}
+
+// parseValue は引数の値を読み取ります（constant の場合は変数を使えない）
+func (p *parser) parseValue(constant bool) any {
+	tok := p.tok
+	switch tok.kind {
+	case tokenInt:
+		p.advance()
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			p.fail(tok.location, "Int cannot represent value %s", tok.value)
+		}
+		return n
+	case tokenFloat:
+		p.advance()
+		f, _ := strconv.ParseFloat(tok.value, 64)
+		return f
+	case tokenString:
+		p.advance()
+		return tok.value
+	case tokenName:
+		p.advance()
+		switch tok.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		default:
+			return EnumValue(tok.value)
+		}
+	}
+
+	switch {
+	case p.peek("$"):
+		if constant {
+			p.fail(tok.location, "Unexpected variable in constant value")
+		}
+		p.advance()
+		name, _ := p.expectName()
+		return Variable(name)
+	case p.skip("["):
+		list := []any{}
+		for !p.skip("]") {
+			list = append(list, p.parseValue(constant))
+		}
+		return list
+	case p.skip("{"):
+		object := make(map[string]any)
+		for !p.skip("}") {
+			name, _ := p.expectName()
+			p.expect(":")
+			object[name] = p.parseValue(constant)
+		}
+		return object
+	}
+	p.fail(tok.location, "Unexpected %s", p.describe())
+	return nil
+}
+
+// next は次の字句を返します（空白・カンマ・コメントは読み飛ばす）
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.pos++
+			l.line++
+			l.lineStart = l.pos
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return l.scan()
+		}
+	}
+	return token{kind: tokenEOF, location: l.location()}, nil
+}
+
+func (l *lexer) location() Location {
+	return Location{Line: l.line, Column: utf8.RuneCountInString(l.src[l.lineStart:l.pos]) + 1}
+}
+
+func (l *lexer) errorf(loc Location, format string, args ...any) error {
+	return &Error{Message: "Syntax Error: " + fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
+
+func (l *lexer) scan() (token, error) {
+	loc := l.location()
+	start := l.pos
+	c := l.src[l.pos]
+
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokenPunct, value: "...", location: loc}, nil
+	case strings.IndexByte("!$()&:=@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunct, value: string(c), location: loc}, nil
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], location: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.scanNumber(loc)
+	case c == '"':
+		return l.scanString(loc)
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, l.errorf(loc, "Unexpected character %q", r)
+}
+
+func (l *lexer) scanNumber(loc Location) (token, error) {
+	start := l.pos
+	kind := tokenInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, l.errorf(loc, "Invalid number, expected digit")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if digits() == 0 {
+			return token{}, l.errorf(loc, "Invalid number, expected digit after \".\"")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if digits() == 0 {
+			return token{}, l.errorf(loc, "Invalid number, expected digit in exponent")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos])) {
+		return token{}, l.errorf(loc, "Invalid number, unexpected %q", l.src[l.pos])
+	}
+	return token{kind: kind, value: l.src[start:l.pos], location: loc}, nil
+}
+
+// scanString は文字列（"..."）とブロック文字列（"""..."""）を読み取ります
+func (l *lexer) scanString(loc Location) (token, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		end := strings.Index(l.src[l.pos+3:], `"""`)
+		if end < 0 {
+			return token{}, l.errorf(loc, "Unterminated string")
+		}
+		raw := l.src[l.pos+3 : l.pos+3+end]
+		for _, c := range raw {
+			if c == '\n' {
+				l.line++
+			}
+		}
+		l.pos += 3 + end + 3
+		if i := strings.LastIndexByte(l.src[:l.pos], '\n'); i >= 0 {
+			l.lineStart = i + 1
+		}
+		return token{kind: tokenString, value: blockStringValue(raw), location: loc}, nil
+	}
+
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return token{kind: tokenString, value: b.String(), location: loc}, nil
+		case c == '\n' || c == '\r':
+			return token{}, l.errorf(loc, "Unterminated string")
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, l.errorf(loc, "Unterminated string")
+			}
+			escape := l.src[l.pos+1]
+			l.pos += 2
+			switch escape {
+			case '"', '\\', '/':
+				b.WriteByte(escape)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, l.errorf(loc, "Invalid Unicode escape sequence")
+				}
+				code, err := strconv.ParseUint(l.src[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(loc, "Invalid Unicode escape sequence")
+				}
+				b.WriteRune(rune(code))
+				l.pos += 4
+			default:
+				return token{}, l.errorf(loc, "Invalid character escape sequence \\%c", escape)
+			}
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{}, l.errorf(loc, "Unterminated string")
+}
+
+// blockStringValue はブロック文字列の共通のインデントと前後の空行を取り除きます
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i := 1; i < len(lines) && indent > 0; i++ {
+		if len(lines[i]) >= indent {
+			lines[i] = lines[i][indent:]
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.ReplaceAll(strings.Join(lines, "\n"), `\"""`, `"""`)
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
@@ -0,0 +1,178 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Schema はGraphQLのスキーマ（クエリとミューテーションのルートの型）です
+type Schema struct {
+	// Query はクエリのルートの型です（必須）
+	Query *Object
+
+	// Mutation はミューテーションのルートの型です（nil の場合はミューテーションを受け付けない）
+	Mutation *Object
+}
+
+// Object はフィールドを持つオブジェクト型です
+type Object struct {
+	Name   string
+	Fields map[string]*FieldDef
+}
+
+// FieldDef はオブジェクト型の1つのフィールドの定義です
+type FieldDef struct {
+	// Type は結果のオブジェクト型です（スカラーの場合は nil）
+	// Resolve がスライスを返した場合は、各要素をこの型として解決します
+	Type *Object
+
+	// Args は受け付ける引数です
+	Args []Arg
+
+	// Resolve はフィールドの値を求める関数です
+	// source は親のオブジェクトの値（ルートの型では nil）、args は型変換済みの引数です
+	Resolve ResolveFunc
+}
+
+// ResolveFunc はフィールドの値を求める関数です
+// エラーを返すとフィールドは null になり、エラーがレスポンスの errors に含まれます
+type ResolveFunc func(ctx context.Context, source any, args Args) (any, error)
+
+// Arg はフィールドの引数の定義です
+type Arg struct {
+	Name string
+
+	// Type は引数の型です（Int・Float・String・ID・Boolean、[T]、末尾の ! で必須）
+	// それ以外の名前（入力オブジェクト型・列挙型）は検証せず、map[string]any・string のまま渡します
+	Type string
+
+	// Default は引数が省略された場合の値です（nil の場合は省略されたまま）
+	Default any
+}
+
+// Args は型変換済みの引数です
+// 値は Int が int、Float が float64、String・ID・列挙型が string、Boolean が bool、
+// リストが []any、入力オブジェクトが map[string]any になります
+type Args map[string]any
+
+// Int は整数の引数を返します（省略された場合は ok が false）
+func (a Args) Int(name string) (int, bool) {
+	v, ok := a[name].(int)
+	return v, ok
+}
+
+// String は文字列（ID・列挙型を含む）の引数を返します（省略された場合は ok が false）
+func (a Args) String(name string) (string, bool) {
+	v, ok := a[name].(string)
+	return v, ok
+}
+
+// Bool は真偽値の引数を返します（省略された場合は ok が false）
+func (a Args) Bool(name string) (bool, bool) {
+	v, ok := a[name].(bool)
+	return v, ok
+}
+
+// Object は入力オブジェクトの引数を返します（省略された場合は ok が false）
+func (a Args) Object(name string) (map[string]any, bool) {
+	v, ok := a[name].(map[string]any)
+	return v, ok
+}
+
+// coerceValue は入力値（リテラル・変数の値）を型 typ の値に変換します
+func coerceValue(typ string, v any) (any, error) {
+	base, nonNull := strings.CutSuffix(typ, "!")
+	if v == nil {
+		if nonNull {
+			return nil, fmt.Errorf("expected non-null value of type %q", typ)
+		}
+		return nil, nil
+	}
+
+	if strings.HasPrefix(base, "[") && strings.HasSuffix(base, "]") {
+		elem := base[1 : len(base)-1]
+		list, ok := v.([]any)
+		if !ok {
+			// リストの型に単独の値を渡した場合は、要素が1つのリストとして扱う
+			list = []any{v}
+		}
+		coerced := make([]any, len(list))
+		for i, item := range list {
+			c, err := coerceValue(elem, item)
+			if err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+			coerced[i] = c
+		}
+		return coerced, nil
+	}
+
+	switch base {
+	case "Int":
+		if n, ok := toNumber(v); ok && n == math.Trunc(n) && n >= math.MinInt32 && n <= math.MaxInt32 {
+			return int(n), nil
+		}
+	case "Float":
+		if n, ok := toNumber(v); ok {
+			return n, nil
+		}
+	case "String":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "ID":
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case int:
+			return strconv.Itoa(v), nil
+		case json.Number:
+			if _, err := v.Int64(); err == nil {
+				return v.String(), nil
+			}
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	default:
+		if e, ok := v.(EnumValue); ok {
+			return string(e), nil
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("%s cannot represent value %s", base, describeValue(v))
+}
+
+// toNumber はリテラル・JSONの数値を float64 にします
+func toNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// describeValue はエラーメッセージ用に値を表します
+func describeValue(v any) string {
+	switch v := v.(type) {
+	case EnumValue:
+		return string(v)
+	case map[string]any:
+		return "an object"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
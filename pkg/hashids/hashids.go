@@ -0,0 +1,257 @@
+// Package hashids は整数を短い不透明な文字列に変換する Hashids（https://hashids.org/）を、標準パッケージだけで実装したものです
+//
+// 連番のIDをそのまま公開すると、リソースの件数や作成の順序が推測でき、IDを1つずつ変えた総当たりもしやすくなります
+// Hashids はソルトで並べ替えたアルファベットを使って整数を文字列に変換するため、ソルトを知らなければ元の整数を推測しにくくなります
+// （暗号化ではないため、秘密の情報を隠す目的には使用しないでください）
+//
+// 他の言語の Hashids の実装と同じ文字列になるよう、デフォルトのアルファベットと区切り文字のアルゴリズムに従っています
+// ただし、ここでは1つの整数の変換のみに対応しています
+package hashids
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// DefaultAlphabet はデフォルトのアルファベットです
+	DefaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
+
+	// defaultSeparators は区切り文字の候補です（不適切な単語ができにくい文字が選ばれています）
+	defaultSeparators = "cfhistuCFHISTU"
+
+	minAlphabetLength = 16
+	separatorDiv      = 3.5
+	guardDiv          = 12.0
+)
+
+// ErrInvalidHash は文字列がこのソルト・アルファベットで作成したものではないことを表すエラーです
+var ErrInvalidHash = errors.New("invalid hash")
+
+// HashID は設定済みの変換器です（並行して使用できます）
+type HashID struct {
+	salt      []rune
+	minLength int
+	alphabet  []rune
+	guards    []rune
+}
+
+// New はソルトと最小の長さから HashID を作成します
+// minLength に満たない文字列は、ガード文字とアルファベットで埋めて長さを揃えます
+func New(salt string, minLength int) (*HashID, error) {
+	return NewWithAlphabet(salt, minLength, DefaultAlphabet)
+}
+
+// NewWithAlphabet は使用する文字を指定して HashID を作成します
+// アルファベットには重複しない16文字以上が必要で、空白は使用できません
+func NewWithAlphabet(salt string, minLength int, alphabet string) (*HashID, error) {
+	if minLength < 0 {
+		return nil, fmt.Errorf("hashids: min length must not be negative: %d", minLength)
+	}
+
+	var unique []rune
+	for _, r := range alphabet {
+		if r == ' ' {
+			return nil, errors.New("hashids: alphabet must not contain spaces")
+		}
+		if !containsRune(unique, r) {
+			unique = append(unique, r)
+		}
+	}
+	if len(unique) < minAlphabetLength {
+		return nil, fmt.Errorf("hashids: alphabet must contain at least %d unique characters", minAlphabetLength)
+	}
+
+	// 区切り文字はアルファベットに含まれるものだけを使い、アルファベットからは取り除く
+	var separators, letters []rune
+	for _, r := range defaultSeparators {
+		if containsRune(unique, r) {
+			separators = append(separators, r)
+		}
+	}
+	for _, r := range unique {
+		if !containsRune(separators, r) {
+			letters = append(letters, r)
+		}
+	}
+
+	saltRunes := []rune(salt)
+	consistentShuffle(separators, saltRunes)
+
+	// 区切り文字とアルファベットの比率を separatorDiv に揃える
+	if len(separators) == 0 || float64(len(letters))/float64(len(separators)) > separatorDiv {
+		separatorsLength := int(math.Ceil(float64(len(letters)) / separatorDiv))
+		if separatorsLength == 1 {
+			separatorsLength = 2
+		}
+		if separatorsLength > len(separators) {
+			diff := separatorsLength - len(separators)
+			separators = append(separators, letters[:diff]...)
+			letters = letters[diff:]
+		} else {
+			separators = separators[:separatorsLength]
+		}
+	}
+
+	consistentShuffle(letters, saltRunes)
+
+	// ガード文字（最小の長さに満たない場合の埋め草）を取り分ける
+	guardCount := int(math.Ceil(float64(len(letters)) / guardDiv))
+	// 区切り文字は複数の整数をつなぐ場合にのみ使うため、ここではアルファベットの計算にだけ使用する
+	var guards []rune
+	if len(letters) < 3 {
+		guards = separators[:guardCount]
+	} else {
+		guards = letters[:guardCount]
+		letters = letters[guardCount:]
+	}
+
+	return &HashID{
+		salt:      saltRunes,
+		minLength: minLength,
+		alphabet:  letters,
+		guards:    guards,
+	}, nil
+}
+
+// Encode は0以上の整数を文字列に変換します
+func (h *HashID) Encode(n int) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("hashids: number must not be negative: %d", n)
+	}
+
+	alphabet := append([]rune(nil), h.alphabet...)
+	numbersHash := n % 100
+	lottery := alphabet[numbersHash%len(alphabet)]
+
+	result := []rune{lottery}
+	buffer := make([]rune, 0, 1+len(h.salt)+len(alphabet))
+	buffer = append(buffer, lottery)
+	buffer = append(buffer, h.salt...)
+	buffer = append(buffer, alphabet...)
+	consistentShuffle(alphabet, buffer[:len(alphabet)])
+	result = append(result, hash(n, alphabet)...)
+
+	if len(result) < h.minLength {
+		guardIndex := (numbersHash + int(result[0])) % len(h.guards)
+		result = append([]rune{h.guards[guardIndex]}, result...)
+		if len(result) < h.minLength {
+			guardIndex = (numbersHash + int(result[2])) % len(h.guards)
+			result = append(result, h.guards[guardIndex])
+		}
+	}
+
+	halfLength := len(alphabet) / 2
+	for len(result) < h.minLength {
+		consistentShuffle(alphabet, append([]rune(nil), alphabet...))
+		padded := make([]rune, 0, len(alphabet)+len(result))
+		padded = append(padded, alphabet[halfLength:]...)
+		padded = append(padded, result...)
+		padded = append(padded, alphabet[:halfLength]...)
+		result = padded
+		if excess := len(result) - h.minLength; excess > 0 {
+			result = result[excess/2 : excess/2+h.minLength]
+		}
+	}
+
+	return string(result), nil
+}
+
+// Decode は Encode で作成した文字列を元の整数に戻します
+// 他のソルトで作成した文字列や、書き換えられた文字列の場合は ErrInvalidHash を返します
+func (h *HashID) Decode(s string) (int, error) {
+	if s == "" {
+		return 0, ErrInvalidHash
+	}
+
+	// ガード文字で区切った中央の部分が本体（ガード文字が1つまたは2つの場合）
+	parts := strings.Split(strings.Map(func(r rune) rune {
+		if containsRune(h.guards, r) {
+			return ' '
+		}
+		return r
+	}, s), " ")
+	body := []rune(parts[0])
+	if len(parts) == 2 || len(parts) == 3 {
+		body = []rune(parts[1])
+	}
+	if len(body) < 2 {
+		return 0, ErrInvalidHash
+	}
+
+	lottery := body[0]
+	alphabet := append([]rune(nil), h.alphabet...)
+	buffer := make([]rune, 0, 1+len(h.salt)+len(alphabet))
+	buffer = append(buffer, lottery)
+	buffer = append(buffer, h.salt...)
+	buffer = append(buffer, alphabet...)
+	consistentShuffle(alphabet, buffer[:len(alphabet)])
+
+	n, err := unhash(body[1:], alphabet)
+	if err != nil {
+		return 0, err
+	}
+
+	// 同じ整数から同じ文字列が作られることを確認し、書き換えられた文字列を拒否する
+	if encoded, err := h.Encode(n); err != nil || encoded != s {
+		return 0, ErrInvalidHash
+	}
+	return n, nil
+}
+
+// consistentShuffle はソルトに応じてアルファベットを決まった順序に並べ替えます（同じソルトなら常に同じ結果）
+func consistentShuffle(alphabet, salt []rune) {
+	if len(salt) == 0 {
+		return
+	}
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		integer := int(salt[v])
+		p += integer
+		j := (integer + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
+
+// hash は整数をアルファベットを桁とする記数法で表します
+func hash(n int, alphabet []rune) []rune {
+	var result []rune
+	for {
+		result = append([]rune{alphabet[n%len(alphabet)]}, result...)
+		n /= len(alphabet)
+		if n == 0 {
+			return result
+		}
+	}
+}
+
+// unhash は hash の逆変換です
+func unhash(input, alphabet []rune) (int, error) {
+	n := 0
+	for _, r := range input {
+		position := indexRune(alphabet, r)
+		if position < 0 {
+			return 0, ErrInvalidHash
+		}
+		if n > (math.MaxInt-position)/len(alphabet) {
+			return 0, ErrInvalidHash
+		}
+		n = n*len(alphabet) + position
+	}
+	return n, nil
+}
+
+func containsRune(runes []rune, r rune) bool {
+	return indexRune(runes, r) >= 0
+}
+
+func indexRune(runes []rune, r rune) int {
+	for i, c := range runes {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,92 @@
+package hashids
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestHashID_Encode は他の言語の実装（hashids.js 等）と同じ文字列になることをテストします
+func TestHashID_Encode(t *testing.T) {
+	tests := []struct {
+		name      string
+		salt      string
+		minLength int
+		number    int
+		expected  string
+	}{
+		{name: "ソルトあり", salt: "this is my salt", number: 12345, expected: "NkK9"},
+		{name: "最小の長さ", salt: "this is my salt", minLength: 8, number: 1, expected: "gB0NV05e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := New(tt.salt, tt.minLength)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			encoded, err := h.Encode(tt.number)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if encoded != tt.expected {
+				t.Errorf("Encode(%d) = %q, 期待値 = %q", tt.number, encoded, tt.expected)
+			}
+			decoded, err := h.Decode(encoded)
+			if err != nil || decoded != tt.number {
+				t.Errorf("Decode(%q) = %d, %v, 期待値 = %d", encoded, decoded, err, tt.number)
+			}
+		})
+	}
+}
+
+// TestHashID_RoundTrip は様々な整数・長さで元の整数に戻せることをテストします
+func TestHashID_RoundTrip(t *testing.T) {
+	for _, minLength := range []int{0, 4, 8, 20} {
+		h, err := New("todoapp", minLength)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		seen := make(map[string]int)
+		for _, n := range []int{0, 1, 2, 9, 10, 99, 100, 101, 12345, 1 << 31, 1<<62 + 7} {
+			encoded, err := h.Encode(n)
+			if err != nil {
+				t.Fatalf("Encode(%d) error = %v", n, err)
+			}
+			if len(encoded) < minLength {
+				t.Errorf("Encode(%d) = %q, %d文字以上である必要があります", n, encoded, minLength)
+			}
+			if other, ok := seen[encoded]; ok {
+				t.Errorf("%d と %d が同じ文字列 %q になりました", n, other, encoded)
+			}
+			seen[encoded] = n
+			if decoded, err := h.Decode(encoded); err != nil || decoded != n {
+				t.Errorf("minLength=%d: Decode(%q) = %d, %v, 期待値 = %d", minLength, encoded, decoded, err, n)
+			}
+		}
+	}
+}
+
+// TestHashID_DecodeInvalid は他のソルトで作成した・書き換えられた文字列を拒否することをテストします
+func TestHashID_DecodeInvalid(t *testing.T) {
+	h, _ := New("todoapp", 8)
+	other, _ := New("other salt", 8)
+	encoded, _ := other.Encode(42)
+
+	for _, input := range []string{"", "12", "!!!!!!!!", encoded, "a b"} {
+		if n, err := h.Decode(input); !errors.Is(err, ErrInvalidHash) {
+			t.Errorf("Decode(%q) = %d, %v, 期待値 = ErrInvalidHash", input, n, err)
+		}
+	}
+}
+
+// TestNew_InvalidAlphabet は使用できないアルファベットを拒否することをテストします
+func TestNew_InvalidAlphabet(t *testing.T) {
+	for _, alphabet := range []string{"abc", "abcdefghijklmno ", "aaaaaaaaaaaaaaaaaaaa"} {
+		if _, err := NewWithAlphabet("salt", 0, alphabet); err == nil {
+			t.Errorf("NewWithAlphabet(%q) でエラーになりませんでした", alphabet)
+		}
+	}
+	if _, err := New("salt", -1); err == nil {
+		t.Error("負の最小の長さでエラーになりませんでした")
+	}
+}
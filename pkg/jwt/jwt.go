@@ -0,0 +1,123 @@
+// Package jwt は HS256（HMAC-SHA256）で署名した JSON Web Token（RFC 7519）の作成と検証を、標準パッケージだけで実装したものです
+//
+// トークンは「ヘッダー.クレーム.署名」をそれぞれ base64url（パディングなし）で表した文字列です
+// 署名は秘密鍵を知っているサーバーだけが作成・検証できるため、サーバーはトークンを保存せずに発行者と有効期限を確認できます
+// （クレームは暗号化されず誰でも読めるため、秘密の情報は含めないでください）
+//
+// ここでは HS256 のみに対応し、ヘッダーの alg が HS256 以外のトークン（"none" 等）は全て拒否します
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinSecretLength は署名の秘密鍵の最小の長さ（バイト）です（HS256 ではハッシュと同じ 32 バイト以上を推奨）
+const MinSecretLength = 32
+
+var (
+	// ErrInvalidToken はトークンの形式・署名が不正であることを表すエラーです
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrExpired はトークンの有効期限（exp）を過ぎていることを表すエラーです
+	ErrExpired = errors.New("token has expired")
+)
+
+// Claims はトークンに含めるクレームです
+type Claims struct {
+	// Subject はトークンの主体（ユーザーID）です
+	Subject string `json:"sub"`
+
+	// Name はユーザー名です（表示・ログ用）
+	Name string `json:"name,omitempty"`
+
+	// IssuedAt は発行時刻（Unix時間の秒）です
+	IssuedAt int64 `json:"iat"`
+
+	// ExpiresAt は有効期限（Unix時間の秒）です
+	ExpiresAt int64 `json:"exp"`
+
+	// Scope は許可する操作の範囲をスペース区切りで並べたものです（RFC 8693 4.2節、空の場合は限定しない）
+	Scope string `json:"scope,omitempty"`
+}
+
+// header は HS256 のトークンのヘッダーです
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+// encodedHeader は全てのトークンで共通のヘッダー（{"alg":"HS256","typ":"JWT"}）を base64url にしたものです
+var encodedHeader = encodeSegment([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Sign はクレームに署名したトークンを作成します
+func Sign(claims Claims, secret []byte) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	signingInput := encodedHeader + "." + encodeSegment(payload)
+	return signingInput + "." + encodeSegment(sign(signingInput, secret)), nil
+}
+
+// Parse はトークンの署名と有効期限を検証し、クレームを返します
+// 署名が不正な場合は ErrInvalidToken、有効期限を過ぎている場合は ErrExpired を返します
+func Parse(token string, secret []byte, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrInvalidToken
+	}
+
+	// 署名を先に検証し、改ざんされたヘッダー・クレームは解析しない
+	signature, err := decodeSegment(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(parts[0]+"."+parts[1], secret)) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var h header
+	if err := decodeJSONSegment(parts[0], &h); err != nil || h.Algorithm != "HS256" {
+		return Claims{}, ErrInvalidToken
+	}
+	var claims Claims
+	if err := decodeJSONSegment(parts[1], &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if claims.ExpiresAt == 0 || !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return Claims{}, ErrExpired
+	}
+	return claims, nil
+}
+
+// sign は HMAC-SHA256 の署名を計算します
+func sign(signingInput string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+// encodeSegment はパディングなしの base64url に変換します
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSegment はパディングなしの base64url を復元します
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(segment)
+}
+
+// decodeJSONSegment は base64url のJSONを v に復元します
+func decodeJSONSegment(segment string, v any) error {
+	data, err := decodeSegment(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
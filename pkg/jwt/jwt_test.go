@@ -0,0 +1,70 @@
+package jwt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSign は他の実装（jwt.io 等）と同じ形式のトークンになることをテストします
+func TestSign(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	token, err := Sign(Claims{Subject: "1", Name: "alice", IssuedAt: 1700000000, ExpiresAt: 1700000900}, secret)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	// ヘッダーとクレームは base64url（パディングなし）のJSON
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9" {
+		t.Fatalf("トークンの形式が不正です: %s", token)
+	}
+	if strings.ContainsAny(token, "=+/") {
+		t.Errorf("base64url（パディングなし）ではない文字が含まれています: %s", token)
+	}
+}
+
+// TestParse はトークンの署名と有効期限の検証をテストします
+func TestParse(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	now := time.Unix(1700000000, 0)
+	claims := Claims{Subject: "1", Name: "alice", IssuedAt: now.Unix(), ExpiresAt: now.Add(15 * time.Minute).Unix()}
+	token, err := Sign(claims, secret)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	parts := strings.Split(token, ".")
+
+	// alg: none のトークン（署名なしで受け入れさせる攻撃）
+	noneToken := encodeSegment([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+
+	tests := []struct {
+		name        string
+		token       string
+		secret      []byte
+		now         time.Time
+		expectedErr error
+	}{
+		{name: "有効なトークン", token: token, secret: secret, now: now},
+		{name: "有効期限の直前", token: token, secret: secret, now: now.Add(15*time.Minute - time.Second)},
+		{name: "有効期限切れ", token: token, secret: secret, now: now.Add(15 * time.Minute), expectedErr: ErrExpired},
+		{name: "別の秘密鍵", token: token, secret: []byte("fedcba9876543210fedcba9876543210"), now: now, expectedErr: ErrInvalidToken},
+		{name: "クレームの改ざん", token: parts[0] + "." + encodeSegment([]byte(`{"sub":"2","iat":1700000000,"exp":1700000900}`)) + "." + parts[2], secret: secret, now: now, expectedErr: ErrInvalidToken},
+		{name: "alg: none", token: noneToken, secret: secret, now: now, expectedErr: ErrInvalidToken},
+		{name: "区切りが足りない", token: parts[0] + "." + parts[1], secret: secret, now: now, expectedErr: ErrInvalidToken},
+		{name: "空文字列", token: "", secret: secret, now: now, expectedErr: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.token, tt.secret, tt.now)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Parse() error = %v, 期待値 = %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr == nil && got != claims {
+				t.Errorf("Parse() = %+v, 期待値 = %+v", got, claims)
+			}
+		})
+	}
+}
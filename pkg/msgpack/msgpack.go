@@ -0,0 +1,519 @@
+// Package msgpack は MessagePack（https://msgpack.org/）とJSONの相互変換を、標準パッケージだけで実装したものです
+//
+// APIのDTOはJSONのタグでフィールド名・省略・日時の形式を定義しているため、
+// Goの値を直接 MessagePack にするのではなく、JSONと MessagePack の間で変換します
+// これにより、JSONのレスポンスと MessagePack のレスポンスの内容が必ず一致します
+//
+// 変換の対応：
+//   - JSONの整数 <-> MessagePack の int / uint、小数 <-> float64
+//   - JSONのオブジェクトのキーの順序は保持します
+//   - MessagePack の bin は Base64 の文字列、タイムスタンプ拡張型（-1）は RFC3339 の文字列になります
+//   - それ以外の拡張型と、文字列以外のマップのキー（整数を除く）は変換できません
+package msgpack
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// MediaType は MessagePack のメディアタイプです
+const MediaType = "application/msgpack"
+
+// maxDepth は入れ子の深さの上限です（深すぎる入れ子でスタックを使い果たさないようにするため）
+const maxDepth = 1000
+
+// ErrTooDeep は入れ子が深すぎる場合のエラーです
+var ErrTooDeep = errors.New("msgpack: nesting too deep")
+
+// Codec は MessagePack とJSONを相互に変換します
+// middleware.CodecMiddleware に登録して、Content-Type / Accept で MessagePack を選べるようにします
+type Codec struct{}
+
+// MediaType は MessagePack のメディアタイプを返します
+func (Codec) MediaType() string { return MediaType }
+
+// FromJSON はJSONを MessagePack に変換します
+func (Codec) FromJSON(data []byte) ([]byte, error) { return FromJSON(data) }
+
+// ToJSON は MessagePack をJSONに変換します
+func (Codec) ToJSON(data []byte) ([]byte, error) { return ToJSON(data) }
+
+// FromJSON はJSONの値を1つ読み込み、MessagePack に変換します
+func FromJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	node, err := readJSONNode(decoder, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("msgpack: unexpected data after top-level JSON value")
+	}
+
+	var buf bytes.Buffer
+	if err := writeNode(&buf, node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonNode はオブジェクト・配列の要素数を数えるために一度組み立てる、JSONの値の木です
+type jsonNode struct {
+	token json.Token // スカラーの場合の値
+	keys  []string   // オブジェクトのキー（順序を保持）
+	items []*jsonNode
+	kind  byte // 's'（スカラー）、'o'（オブジェクト）、'a'（配列）
+}
+
+// readJSONNode はJSONの値を1つ読み込みます
+func readJSONNode(decoder *json.Decoder, depth int) (*jsonNode, error) {
+	if depth > maxDepth {
+		return nil, ErrTooDeep
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("msgpack: invalid JSON: %w", err)
+	}
+
+	switch token {
+	case json.Delim('{'):
+		node := &jsonNode{kind: 'o'}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("msgpack: invalid JSON: %w", err)
+			}
+			value, err := readJSONNode(decoder, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			node.keys = append(node.keys, keyToken.(string))
+			node.items = append(node.items, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("msgpack: invalid JSON: %w", err)
+		}
+		return node, nil
+	case json.Delim('['):
+		node := &jsonNode{kind: 'a'}
+		for decoder.More() {
+			value, err := readJSONNode(decoder, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			node.items = append(node.items, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("msgpack: invalid JSON: %w", err)
+		}
+		return node, nil
+	}
+	return &jsonNode{kind: 's', token: token}, nil
+}
+
+// writeNode はJSONの値の木を MessagePack として書き込みます
+func writeNode(buf *bytes.Buffer, node *jsonNode) error {
+	switch node.kind {
+	case 'o':
+		writeMapHeader(buf, len(node.items))
+		for i, key := range node.keys {
+			writeString(buf, key)
+			if err := writeNode(buf, node.items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 'a':
+		writeArrayHeader(buf, len(node.items))
+		for _, item := range node.items {
+			if err := writeNode(buf, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch v := node.token.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if v {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case string:
+		writeString(buf, v)
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			writeInt(buf, i)
+		} else if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			writeUint(buf, u)
+		} else if f, err := v.Float64(); err == nil {
+			buf.WriteByte(0xcb)
+			binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+		} else {
+			return fmt.Errorf("msgpack: invalid JSON number %s", v)
+		}
+	default:
+		return fmt.Errorf("msgpack: unexpected JSON token %v", v)
+	}
+	return nil
+}
+
+// writeInt は整数を最も短い形式で書き込みます
+func writeInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0:
+		writeUint(buf, uint64(i))
+	case i >= -32:
+		buf.WriteByte(byte(int8(i)))
+	case i >= math.MinInt8:
+		buf.Write([]byte{0xd0, byte(int8(i))})
+	case i >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		binary.Write(buf, binary.BigEndian, int16(i))
+	case i >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		binary.Write(buf, binary.BigEndian, int32(i))
+	default:
+		buf.WriteByte(0xd3)
+		binary.Write(buf, binary.BigEndian, i)
+	}
+}
+
+// writeUint は0以上の整数を最も短い形式で書き込みます
+func writeUint(buf *bytes.Buffer, u uint64) {
+	switch {
+	case u <= 0x7f:
+		buf.WriteByte(byte(u))
+	case u <= math.MaxUint8:
+		buf.Write([]byte{0xcc, byte(u)})
+	case u <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		binary.Write(buf, binary.BigEndian, uint16(u))
+	case u <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		binary.Write(buf, binary.BigEndian, uint32(u))
+	default:
+		buf.WriteByte(0xcf)
+		binary.Write(buf, binary.BigEndian, u)
+	}
+}
+
+// writeString は文字列を書き込みます
+func writeString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n <= 31:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.Write([]byte{0xd9, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+// writeArrayHeader は配列の要素数を書き込みます
+func writeArrayHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n <= 15:
+		buf.WriteByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xdc)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdd)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+// writeMapHeader はマップの要素数を書き込みます
+func writeMapHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n <= 15:
+		buf.WriteByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xde)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdf)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+// ToJSON は MessagePack の値を1つ読み込み、JSONに変換します
+func ToJSON(data []byte) ([]byte, error) {
+	d := &decoder{data: data}
+	var buf bytes.Buffer
+	if err := d.writeJSON(&buf, 0); err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, errors.New("msgpack: unexpected data after top-level value")
+	}
+	return buf.Bytes(), nil
+}
+
+// decoder は MessagePack のバイト列を先頭から読み込みます
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+// errTruncated はデータが途中で終わっている場合のエラーです
+var errTruncated = errors.New("msgpack: unexpected end of data")
+
+// read は n バイトを読み込みます
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errTruncated
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// readUint は n バイト（1, 2, 4, 8）のビッグエンディアンの符号なし整数を読み込みます
+func (d *decoder) readUint(n int) (uint64, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return 0, err
+	}
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+// readLength は n バイトの長さを読み込みます（残りのデータより長い場合はエラー）
+func (d *decoder) readLength(n int) (int, error) {
+	u, err := d.readUint(n)
+	if err != nil {
+		return 0, err
+	}
+	if u > uint64(len(d.data)-d.pos) {
+		return 0, errTruncated
+	}
+	return int(u), nil
+}
+
+// writeJSON は MessagePack の値を1つ読み込み、JSONとして書き込みます
+func (d *decoder) writeJSON(buf *bytes.Buffer, depth int) error {
+	if depth > maxDepth {
+		return ErrTooDeep
+	}
+	head, err := d.read(1)
+	if err != nil {
+		return err
+	}
+	b := head[0]
+
+	switch {
+	case b <= 0x7f:
+		buf.WriteString(strconv.Itoa(int(b)))
+		return nil
+	case b >= 0xe0:
+		buf.WriteString(strconv.Itoa(int(int8(b))))
+		return nil
+	case b&0xf0 == 0x80:
+		return d.writeMap(buf, int(b&0x0f), depth)
+	case b&0xf0 == 0x90:
+		return d.writeArray(buf, int(b&0x0f), depth)
+	case b&0xe0 == 0xa0:
+		return d.writeString(buf, int(b&0x1f))
+	}
+
+	switch b {
+	case 0xc0:
+		buf.WriteString("null")
+	case 0xc2:
+		buf.WriteString("false")
+	case 0xc3:
+		buf.WriteString("true")
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.readLength(1 << (b - 0xc4))
+		if err != nil {
+			return err
+		}
+		data, err := d.read(n)
+		if err != nil {
+			return err
+		}
+		return writeJSONString(buf, base64.StdEncoding.EncodeToString(data))
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.readLength(1 << (b - 0xc7))
+		if err != nil {
+			return err
+		}
+		return d.writeExt(buf, n)
+	case 0xca:
+		u, err := d.readUint(4)
+		if err != nil {
+			return err
+		}
+		return writeJSONFloat(buf, float64(math.Float32frombits(uint32(u))), 32)
+	case 0xcb:
+		u, err := d.readUint(8)
+		if err != nil {
+			return err
+		}
+		return writeJSONFloat(buf, math.Float64frombits(u), 64)
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := d.readUint(1 << (b - 0xcc))
+		if err != nil {
+			return err
+		}
+		buf.WriteString(strconv.FormatUint(u, 10))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		u, err := d.readUint(size)
+		if err != nil {
+			return err
+		}
+		// 符号拡張（上位ビットを詰めてから算術シフトで戻す）
+		shift := 64 - 8*size
+		buf.WriteString(strconv.FormatInt(int64(u<<shift)>>shift, 10))
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.writeExt(buf, 1<<(b-0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.readLength(1 << (b - 0xd9))
+		if err != nil {
+			return err
+		}
+		return d.writeString(buf, n)
+	case 0xdc, 0xdd:
+		n, err := d.readLength(2 << (b - 0xdc))
+		if err != nil {
+			return err
+		}
+		return d.writeArray(buf, n, depth)
+	case 0xde, 0xdf:
+		n, err := d.readLength(2 << (b - 0xde))
+		if err != nil {
+			return err
+		}
+		return d.writeMap(buf, n, depth)
+	default:
+		return fmt.Errorf("msgpack: unknown format 0x%02x", b)
+	}
+	return nil
+}
+
+// writeString は n バイトの文字列を読み込んで、JSONの文字列として書き込みます
+func (d *decoder) writeString(buf *bytes.Buffer, n int) error {
+	data, err := d.read(n)
+	if err != nil {
+		return err
+	}
+	return writeJSONString(buf, string(data))
+}
+
+// writeArray は n 要素の配列をJSONの配列として書き込みます
+func (d *decoder) writeArray(buf *bytes.Buffer, n, depth int) error {
+	buf.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := d.writeJSON(buf, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// writeMap は n 組のマップをJSONのオブジェクトとして書き込みます
+// キーは文字列または整数（JSONでは文字列になります）のみ受け付けます
+func (d *decoder) writeMap(buf *bytes.Buffer, n, depth int) error {
+	buf.WriteByte('{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		var key bytes.Buffer
+		if err := d.writeJSON(&key, depth+1); err != nil {
+			return err
+		}
+		switch k := key.Bytes(); {
+		case len(k) > 0 && k[0] == '"':
+			buf.Write(k)
+		case len(k) > 0 && (k[0] == '-' || (k[0] >= '0' && k[0] <= '9')) && !bytes.ContainsAny(k, ".eE"):
+			writeJSONString(buf, string(k))
+		default:
+			return fmt.Errorf("msgpack: map key must be a string or an integer: %s", k)
+		}
+
+		buf.WriteByte(':')
+		if err := d.writeJSON(buf, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// writeExt は拡張型を読み込みます（タイムスタンプ拡張型のみ RFC3339 の文字列に変換します）
+func (d *decoder) writeExt(buf *bytes.Buffer, n int) error {
+	typeByte, err := d.read(1)
+	if err != nil {
+		return err
+	}
+	data, err := d.read(n)
+	if err != nil {
+		return err
+	}
+	if int8(typeByte[0]) != -1 {
+		return fmt.Errorf("msgpack: unsupported extension type %d", int8(typeByte[0]))
+	}
+
+	var t time.Time
+	switch n {
+	case 4:
+		t = time.Unix(int64(binary.BigEndian.Uint32(data)), 0)
+	case 8:
+		u := binary.BigEndian.Uint64(data)
+		t = time.Unix(int64(u&(1<<34-1)), int64(u>>34))
+	case 12:
+		t = time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data[:4])))
+	default:
+		return fmt.Errorf("msgpack: invalid timestamp length %d", n)
+	}
+	return writeJSONString(buf, t.UTC().Format(time.RFC3339Nano))
+}
+
+// writeJSONString は文字列をJSONの文字列として書き込みます
+func writeJSONString(buf *bytes.Buffer, s string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// writeJSONFloat は小数をJSONの数値として書き込みます（NaN・無限大はJSONで表せないためエラー）
+func writeJSONFloat(buf *bytes.Buffer, f float64, bitSize int) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("msgpack: %v cannot be represented in JSON", f)
+	}
+	buf.WriteString(strconv.FormatFloat(f, 'g', -1, bitSize))
+	return nil
+}
@@ -0,0 +1,96 @@
+package msgpack
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestFromJSON はJSONの値が MessagePack の最小の形式に変換されることをテストします
+func TestFromJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected []byte
+	}{
+		{name: "null", json: `null`, expected: []byte{0xc0}},
+		{name: "真偽値", json: `[true,false]`, expected: []byte{0x92, 0xc3, 0xc2}},
+		{name: "正の fixint", json: `127`, expected: []byte{0x7f}},
+		{name: "負の fixint", json: `-32`, expected: []byte{0xe0}},
+		{name: "uint 16", json: `65535`, expected: []byte{0xcd, 0xff, 0xff}},
+		{name: "int 32", json: `-100000`, expected: []byte{0xd2, 0xff, 0xfe, 0x79, 0x60}},
+		{name: "小数", json: `1.5`, expected: []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{name: "fixstr", json: `"abc"`, expected: []byte{0xa3, 'a', 'b', 'c'}},
+		{name: "str 8", json: `"` + strings.Repeat("a", 32) + `"`, expected: append([]byte{0xd9, 32}, bytes.Repeat([]byte{'a'}, 32)...)},
+		{name: "キーの順序を保持", json: `{"b":1,"a":[]}`, expected: []byte{0x82, 0xa1, 'b', 0x01, 0xa1, 'a', 0x90}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromJSON([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("FromJSON() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.expected) {
+				t.Errorf("FromJSON(%s) = % x, 期待値 = % x", tt.json, got, tt.expected)
+			}
+
+			// JSONに戻すと元の値になる
+			back, err := ToJSON(got)
+			if err != nil {
+				t.Fatalf("ToJSON() error = %v", err)
+			}
+			if string(back) != tt.json {
+				t.Errorf("ToJSON() = %s, 期待値 = %s", back, tt.json)
+			}
+		})
+	}
+}
+
+// TestToJSON はJSONにない型の変換と、不正なデータの拒否をテストします
+func TestToJSON(t *testing.T) {
+	nested := append(bytes.Repeat([]byte{0x91}, maxDepth+1), 0xc0)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		expected    string
+		expectedErr error
+		expectError bool
+	}{
+		{name: "bin は Base64", data: []byte{0xc4, 0x03, 'a', 'b', 'c'}, expected: `"YWJj"`},
+		{name: "タイムスタンプ（32ビット）", data: []byte{0xd6, 0xff, 0x66, 0x31, 0x86, 0x00}, expected: `"2024-05-01T00:00:00Z"`},
+		{name: "整数のキー", data: []byte{0x81, 0x01, 0xa1, 'x'}, expected: `{"1":"x"}`},
+		{name: "float 32", data: []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}, expected: `1.5`},
+		{name: "途中で終わっている", data: []byte{0xa3, 'a'}, expectError: true},
+		{name: "後ろに余分なデータ", data: []byte{0xc0, 0xc0}, expectError: true},
+		{name: "未対応の拡張型", data: []byte{0xd4, 0x01, 0x00}, expectError: true},
+		{name: "配列のキー", data: []byte{0x81, 0x90, 0xc0}, expectError: true},
+		{name: "深すぎる入れ子", data: nested, expectedErr: ErrTooDeep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToJSON(tt.data)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ToJSON() error = %v, 期待値 = %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ToJSON() = %s, エラーが期待されました", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToJSON() error = %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("ToJSON() = %s, 期待値 = %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package password はパスワードのハッシュ化と照合を、標準パッケージだけで実装したものです
+//
+// PBKDF2（RFC 8018）でHMAC-SHA256を繰り返し適用し、総当たりに時間がかかるハッシュを作成します
+// ソルトはパスワードごとにランダムに生成するため、同じパスワードでもハッシュは毎回異なります
+//
+// ハッシュは "pbkdf2-sha256$<繰り返し回数>$<ソルト>$<ハッシュ>"（ソルトとハッシュは base64）の形式の文字列で、
+// 繰り返し回数を含めて保存するため、後から回数を増やしても既存のハッシュを照合できます
+package password
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultIterations は Hash が使用する繰り返し回数です（OWASP の PBKDF2-HMAC-SHA256 の推奨値）
+	DefaultIterations = 600000
+
+	// scheme はハッシュの文字列の先頭に付ける方式名です
+	scheme = "pbkdf2-sha256"
+
+	saltLength = 16
+	keyLength  = sha256.Size
+)
+
+// Hash はパスワードのハッシュを DefaultIterations 回の繰り返しで作成します
+func Hash(password string) (string, error) {
+	return HashWithIterations(password, DefaultIterations)
+}
+
+// HashWithIterations は繰り返し回数を指定してパスワードのハッシュを作成します
+// 回数を減らすとハッシュ化が速くなる代わりに総当たりにも弱くなるため、テスト以外では Hash を使用してください
+func HashWithIterations(password string, iterations int) (string, error) {
+	if iterations < 1 {
+		return "", fmt.Errorf("iterations must be positive: %d", iterations)
+	}
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := pbkdf2([]byte(password), salt, iterations, keyLength)
+	return fmt.Sprintf("%s$%d$%s$%s", scheme, iterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify はパスワードがハッシュと一致するかを照合します（ハッシュの形式が不正な場合も false を返します）
+// 比較は subtle.ConstantTimeCompare で行い、一致したバイト数が応答時間から推測されないようにします
+func Verify(password, encoded string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 || parts[0] != scheme {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations < 1 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	key := pbkdf2([]byte(password), salt, iterations, len(expected))
+	return subtle.ConstantTimeCompare(key, expected) == 1
+}
+
+// pbkdf2 は PBKDF2-HMAC-SHA256 で keyLength バイトの鍵を導出します（RFC 8018 5.2節）
+func pbkdf2(password, salt []byte, iterations, keyLength int) []byte {
+	prf := hmac.New(sha256.New, password)
+	blocks := (keyLength + sha256.Size - 1) / sha256.Size
+
+	key := make([]byte, 0, blocks*sha256.Size)
+	var counter [4]byte
+	for block := 1; block <= blocks; block++ {
+		// U1 = PRF(password, salt || INT(block))
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+		u := prf.Sum(nil)
+
+		// T = U1 xor U2 xor ... xor Uc（Un = PRF(password, Un-1)）
+		t := append([]byte(nil), u...)
+		for n := 1; n < iterations; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range t {
+				t[i] ^= u[i]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLength]
+}
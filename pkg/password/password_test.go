@@ -0,0 +1,72 @@
+package password
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// TestPBKDF2 は RFC 7914 のテストベクター（PBKDF2-HMAC-SHA256）と同じ鍵になることをテストします
+func TestPBKDF2(t *testing.T) {
+	tests := []struct {
+		password   string
+		salt       string
+		iterations int
+		keyLength  int
+		expected   string
+	}{
+		{
+			password: "passwd", salt: "salt", iterations: 1, keyLength: 64,
+			expected: "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783",
+		},
+		{
+			password: "Password", salt: "NaCl", iterations: 80000, keyLength: 64,
+			expected: "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d",
+		},
+	}
+
+	for _, tt := range tests {
+		got := hex.EncodeToString(pbkdf2([]byte(tt.password), []byte(tt.salt), tt.iterations, tt.keyLength))
+		if got != tt.expected {
+			t.Errorf("pbkdf2(%q, %q, %d) = %s, 期待値 = %s", tt.password, tt.salt, tt.iterations, got, tt.expected)
+		}
+	}
+}
+
+// TestHashAndVerify はハッシュの作成と照合をテストします
+func TestHashAndVerify(t *testing.T) {
+	encoded, err := HashWithIterations("correct horse battery staple", 1000)
+	if err != nil {
+		t.Fatalf("HashWithIterations() error = %v", err)
+	}
+	if !strings.HasPrefix(encoded, "pbkdf2-sha256$1000$") {
+		t.Errorf("ハッシュの形式 = %s", encoded)
+	}
+
+	// 同じパスワードでもソルトが異なるため、ハッシュは毎回異なる
+	again, _ := HashWithIterations("correct horse battery staple", 1000)
+	if again == encoded {
+		t.Error("同じパスワードのハッシュが一致しました（ソルトが使われていません）")
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		encoded  string
+		expected bool
+	}{
+		{name: "一致", password: "correct horse battery staple", encoded: encoded, expected: true},
+		{name: "不一致", password: "Tr0ub4dor&3", encoded: encoded, expected: false},
+		{name: "方式が違う", password: "correct horse battery staple", encoded: strings.Replace(encoded, "pbkdf2-sha256", "md5", 1), expected: false},
+		{name: "形式が不正", password: "correct horse battery staple", encoded: "pbkdf2-sha256$abc", expected: false},
+		{name: "空", password: "", encoded: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.password, tt.encoded); got != tt.expected {
+				t.Errorf("Verify() = %v, 期待値 = %v", got, tt.expected)
+			}
+		})
+	}
+}